@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"time"
 
 	"github.com/alecthomas/jsonschema"
 	ma "github.com/multiformats/go-multiaddr"
@@ -553,10 +554,22 @@ func (c *Client) Find(ctx context.Context, dbID thread.ID, collectionName string
 		DbID:           dbID.Bytes(),
 		CollectionName: collectionName,
 		QueryJSON:      queryBytes,
+		Explain:        args.Explain != nil,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if args.Explain != nil && resp.Explain != nil {
+		*args.Explain = db.Explain{
+			Index:          resp.Explain.Index,
+			Scanned:        int(resp.Explain.Scanned),
+			Fetched:        int(resp.Explain.Fetched),
+			Matched:        int(resp.Explain.Matched),
+			Returned:       int(resp.Explain.Returned),
+			SortedInMemory: resp.Explain.SortedInMemory,
+			Duration:       time.Duration(resp.Explain.Duration),
+		}
+	}
 	return processFindReply(resp, dummy)
 }
 
@@ -0,0 +1,561 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+)
+
+// errNull signals a null value for a non-null field, which nulls the parent field.
+var errNull = errors.New("null value for non-null field")
+
+// request is a GraphQL request, see https://graphql.org/learn/serving-over-http.
+type request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+type response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []gqlError  `json:"errors,omitempty"`
+}
+
+type gqlError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func errorResponse(err error) response {
+	return response{Errors: []gqlError{{Message: err.Error()}}}
+}
+
+// orderedMap is a JSON object keeping the order of selections.
+type orderedMap []orderedField
+
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range m {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// executor executes an operation of a request against a db.
+type executor struct {
+	ctx    context.Context
+	db     *db.DB
+	token  thread.Token
+	schema *schema
+	doc    *document
+	op     *operation
+	vars   map[string]interface{}
+	errors []gqlError
+}
+
+func newExecutor(
+	ctx context.Context,
+	d *db.DB,
+	token thread.Token,
+	s *schema,
+	req request,
+) (*executor, error) {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	e := &executor{ctx: ctx, db: d, token: token, schema: s, doc: doc}
+	for _, op := range doc.operations {
+		if req.OperationName == "" || op.name == req.OperationName {
+			if e.op != nil {
+				return nil, fmt.Errorf("operationName is required for documents with multiple operations")
+			}
+			e.op = op
+		}
+	}
+	if e.op == nil {
+		return nil, fmt.Errorf("unknown operation %q", req.OperationName)
+	}
+	if e.vars, err = e.coerceVariables(req.Variables); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *executor) rootType() *objectType {
+	switch e.op.kind {
+	case "mutation":
+		return e.schema.mutation
+	case "subscription":
+		return e.schema.subscription
+	default:
+		return e.schema.query
+	}
+}
+
+// execute executes a query or a mutation. Mutation fields are executed serially,
+// each one in its own write transaction.
+func (e *executor) execute() response {
+	data, err := e.executeSelectionSet(e.rootType(), nil, e.op.selections, nil)
+	res := response{Errors: e.errors}
+	if err == nil {
+		res.Data = data
+	}
+	return res
+}
+
+func (e *executor) coerceVariables(input map[string]interface{}) (map[string]interface{}, error) {
+	vars := make(map[string]interface{})
+	for _, def := range e.op.vars {
+		v, ok := input[def.name]
+		if !ok && def.def != nil {
+			var err error
+			if v, err = e.literal(def.def); err != nil {
+				return nil, err
+			}
+		}
+		cv, err := e.coerce(v, def.typ)
+		if err != nil {
+			return nil, fmt.Errorf("variable $%s: %v", def.name, err)
+		}
+		vars[def.name] = cv
+	}
+	return vars, nil
+}
+
+// literal converts a value literal to a Go value, resolving variables.
+func (e *executor) literal(v *value) (interface{}, error) {
+	switch v.kind {
+	case valueVariable:
+		return e.vars[v.raw], nil
+	case valueInt:
+		if i, err := strconv.ParseInt(v.raw, 10, 64); err == nil {
+			return i, nil
+		}
+		return strconv.ParseFloat(v.raw, 64)
+	case valueFloat:
+		return strconv.ParseFloat(v.raw, 64)
+	case valueString, valueEnum:
+		return v.raw, nil
+	case valueBoolean:
+		return v.raw == "true", nil
+	case valueList:
+		list := make([]interface{}, len(v.list))
+		for i, item := range v.list {
+			var err error
+			if list[i], err = e.literal(item); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case valueObject:
+		obj := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			var err error
+			if obj[f.name], err = e.literal(f.value); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	default:
+		return nil, nil
+	}
+}
+
+// coerce coerces an input value to a type, see
+// https://spec.graphql.org/June2018/#sec-Input-Values.
+func (e *executor) coerce(v interface{}, typ *typeRef) (interface{}, error) {
+	if v == nil {
+		if typ.nonNull {
+			return nil, fmt.Errorf("expected non-null value of type %s", typ)
+		}
+		return nil, nil
+	}
+	if typ.elem != nil {
+		list, ok := v.([]interface{})
+		if !ok {
+			// a single value is coerced to a list of one item
+			list = []interface{}{v}
+		}
+		res := make([]interface{}, len(list))
+		for i, item := range list {
+			var err error
+			if res[i], err = e.coerce(item, typ.elem); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	if input, ok := e.schema.inputs[typ.name]; ok {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected object of type %s", typ.name)
+		}
+		for name := range obj {
+			if input.field(name) == nil {
+				return nil, fmt.Errorf("unknown field %s of type %s", name, typ.name)
+			}
+		}
+		res := make(map[string]interface{}, len(obj))
+		for _, f := range input.fields {
+			fv, err := e.coerce(obj[f.name], f.typ)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", f.name, err)
+			}
+			// explicit nulls are left out, optional properties can't be null
+			if fv != nil {
+				res[f.property] = fv
+			}
+		}
+		return res, nil
+	}
+	if values, ok := e.schema.enums[typ.name]; ok {
+		if s, ok := v.(string); ok {
+			for _, ev := range values {
+				if s == ev {
+					return s, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("expected value of enum %s", typ.name)
+	}
+	var ok bool
+	switch typ.name {
+	case scalarInt:
+		var i int64
+		if i, ok = toInt(v); ok {
+			return i, nil
+		}
+	case scalarFloat:
+		switch n := v.(type) {
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case scalarString:
+		_, ok = v.(string)
+	case scalarBoolean:
+		_, ok = v.(bool)
+	case scalarID:
+		if i, isInt := toInt(v); isInt {
+			return strconv.FormatInt(i, 10), nil
+		}
+		_, ok = v.(string)
+	case scalarJSON:
+		ok = true
+	default:
+		return nil, fmt.Errorf("unknown type %s", typ.name)
+	}
+	if !ok {
+		return nil, fmt.Errorf("expected value of type %s", typ.name)
+	}
+	return v, nil
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case float64:
+		if n == math.Trunc(n) && math.Abs(n) < 1<<53 {
+			return int64(n), true
+		}
+	}
+	return 0, false
+}
+
+// fieldGroup is a response key with the selections merged into it.
+type fieldGroup struct {
+	key        string
+	selections []*selection
+}
+
+// collectFields groups the fields selected on an object type by response key,
+// see https://spec.graphql.org/June2018/#CollectFields().
+func (e *executor) collectFields(
+	t *objectType,
+	sels []*selection,
+	groups []*fieldGroup,
+	visited map[string]bool,
+) ([]*fieldGroup, error) {
+	for _, sel := range sels {
+		include, err := e.included(sel.directives)
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			continue
+		}
+		switch {
+		case sel.spread != "":
+			if visited[sel.spread] {
+				continue
+			}
+			visited[sel.spread] = true
+			frag, ok := e.doc.fragments[sel.spread]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %s", sel.spread)
+			}
+			if frag.on != t.name {
+				continue
+			}
+			if groups, err = e.collectFields(t, frag.selections, groups, visited); err != nil {
+				return nil, err
+			}
+		case sel.inline:
+			if sel.on != "" && sel.on != t.name {
+				continue
+			}
+			if groups, err = e.collectFields(t, sel.selections, groups, visited); err != nil {
+				return nil, err
+			}
+		default:
+			key := sel.responseKey()
+			var group *fieldGroup
+			for _, g := range groups {
+				if g.key == key {
+					group = g
+					break
+				}
+			}
+			if group == nil {
+				group = &fieldGroup{key: key}
+				groups = append(groups, group)
+			}
+			group.selections = append(group.selections, sel)
+		}
+	}
+	return groups, nil
+}
+
+// included evaluates @skip and @include directives.
+func (e *executor) included(directives []*directive) (bool, error) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			continue
+		}
+		var cond interface{}
+		for _, a := range d.args {
+			if a.name == "if" {
+				var err error
+				if cond, err = e.literal(a.value); err != nil {
+					return false, err
+				}
+			}
+		}
+		b, ok := cond.(bool)
+		if !ok {
+			return false, fmt.Errorf("directive @%s requires a Boolean if argument", d.name)
+		}
+		if b == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (e *executor) executeSelectionSet(
+	t *objectType,
+	obj map[string]interface{},
+	sels []*selection,
+	path []interface{},
+) (orderedMap, error) {
+	groups, err := e.collectFields(t, sels, nil, make(map[string]bool))
+	if err != nil {
+		e.addError(err, path)
+		return nil, errNull
+	}
+	res := make(orderedMap, 0, len(groups))
+	for _, g := range groups {
+		fpath := appendPath(path, g.key)
+		name := g.selections[0].name
+		if name == "__typename" {
+			res = append(res, orderedField{key: g.key, value: t.name})
+			continue
+		}
+		f := t.field(name)
+		if f == nil {
+			e.addError(fmt.Errorf("cannot query field %s on type %s", name, t.name), fpath)
+			return nil, errNull
+		}
+		v, err := e.executeField(f, obj, g.selections, fpath)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, orderedField{key: g.key, value: v})
+	}
+	return res, nil
+}
+
+func (e *executor) executeField(
+	f *fieldDef,
+	obj map[string]interface{},
+	sels []*selection,
+	path []interface{},
+) (interface{}, error) {
+	var v interface{}
+	if f.resolve != nil {
+		args, err := e.coerceArgs(f, sels[0])
+		if err == nil {
+			v, err = f.resolve(e, args)
+		}
+		if err != nil {
+			e.addError(err, path)
+			return nullFor(f.typ)
+		}
+	} else {
+		v = obj[f.property]
+	}
+	var sub []*selection
+	for _, sel := range sels {
+		sub = append(sub, sel.selections...)
+	}
+	return e.complete(f.typ, v, sub, path)
+}
+
+func (e *executor) coerceArgs(f *fieldDef, sel *selection) (map[string]interface{}, error) {
+	for _, a := range sel.args {
+		found := false
+		for _, def := range f.args {
+			found = found || def.name == a.name
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown argument %s of field %s", a.name, f.name)
+		}
+	}
+	args := make(map[string]interface{})
+	for _, def := range f.args {
+		var v interface{}
+		for _, a := range sel.args {
+			if a.name == def.name {
+				var err error
+				if v, err = e.literal(a.value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		cv, err := e.coerce(v, def.typ)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %v", def.name, err)
+		}
+		if cv != nil {
+			args[def.name] = cv
+		}
+	}
+	return args, nil
+}
+
+// complete completes a resolved value according to its type, see
+// https://spec.graphql.org/June2018/#CompleteValue().
+func (e *executor) complete(typ *typeRef, v interface{}, sels []*selection, path []interface{}) (interface{}, error) {
+	if v == nil {
+		if typ.nonNull {
+			e.addError(fmt.Errorf("cannot return null for non-null field"), path)
+			return nil, errNull
+		}
+		return nil, nil
+	}
+	if typ.elem != nil {
+		list, ok := v.([]interface{})
+		if !ok {
+			e.addError(fmt.Errorf("expected a list value"), path)
+			return nullFor(typ)
+		}
+		res := make([]interface{}, len(list))
+		for i, item := range list {
+			var err error
+			if res[i], err = e.complete(typ.elem, item, sels, appendPath(path, i)); err != nil {
+				return nullFor(typ)
+			}
+		}
+		return res, nil
+	}
+	if t, ok := e.schema.types[typ.name]; ok {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			e.addError(fmt.Errorf("expected an object value of type %s", typ.name), path)
+			return nullFor(typ)
+		}
+		res, err := e.executeSelectionSet(t, obj, sels, path)
+		if err != nil {
+			return nullFor(typ)
+		}
+		return res, nil
+	}
+	res, err := e.serialize(typ.name, v)
+	if err != nil {
+		e.addError(err, path)
+		return nullFor(typ)
+	}
+	return res, nil
+}
+
+// serialize serializes a scalar or enum value read from an instance.
+func (e *executor) serialize(name string, v interface{}) (interface{}, error) {
+	var ok bool
+	switch name {
+	case scalarInt:
+		var i int64
+		if i, ok = toInt(v); ok {
+			return i, nil
+		}
+	case scalarFloat:
+		_, ok = v.(float64)
+	case scalarBoolean:
+		_, ok = v.(bool)
+	case scalarJSON:
+		ok = true
+	default:
+		// strings, IDs and enums
+		_, ok = v.(string)
+	}
+	if !ok {
+		return nil, fmt.Errorf("can't serialize %v as %s", v, name)
+	}
+	return v, nil
+}
+
+func nullFor(typ *typeRef) (interface{}, error) {
+	if typ.nonNull {
+		return nil, errNull
+	}
+	return nil, nil
+}
+
+func (e *executor) addError(err error, path []interface{}) {
+	e.errors = append(e.errors, gqlError{Message: err.Error(), Path: path})
+}
+
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	p := make([]interface{}, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, key)
+}
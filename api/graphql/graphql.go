@@ -0,0 +1,210 @@
+// Package graphql serves a GraphQL API over the collections of managed dbs.
+//
+// The schema of a db is generated from the JSON schemas of its collections, see the
+// GET /{threadID}/schema endpoint for the resulting SDL. Queries and mutations are
+// POSTed to /{threadID} as {"query", "variables", "operationName"}, queries can also
+// be sent with GET. Each mutation field runs in its own write transaction.
+//
+// Subscriptions are streamed as server-sent events, each event carrying the resume token
+// of its action as id, so reconnecting clients sending Last-Event-ID don't miss actions.
+//
+// Thread tokens are read from the Authorization: Bearer header, or from the token query
+// parameter for clients like EventSource which can't set headers.
+// Introspection isn't supported.
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+	"github.com/textileio/go-threads/logger"
+)
+
+var log = logger.Logger("graphql")
+
+// Handler is an http.Handler serving GraphQL requests against the dbs of a manager.
+type Handler struct {
+	manager *db.Manager
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler returns a handler for the dbs of manager.
+func NewHandler(manager *db.Manager) *Handler {
+	return &Handler{manager: manager}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Last-Event-ID")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) > 2 || (len(parts) == 2 && parts[1] != "schema") {
+		http.NotFound(w, r)
+		return
+	}
+	id, err := thread.Decode(parts[0])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid thread id: %v", err), http.StatusBadRequest)
+		return
+	}
+	token := requestToken(r)
+	d, err := h.manager.GetDB(r.Context(), id, db.WithManagedToken(token))
+	if err != nil {
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+	s, err := newSchema(d.ListCollections(db.WithToken(token)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(parts) == 2 {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = fmt.Fprintln(w, s.SDL())
+		return
+	}
+
+	req, err := readRequest(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	e, err := newExecutor(r.Context(), d, token, s, req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	switch e.op.kind {
+	case "subscription":
+		h.subscribe(w, r, e)
+	case "mutation":
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse(fmt.Errorf("mutations require POST")))
+			return
+		}
+		fallthrough
+	default:
+		writeJSON(w, http.StatusOK, e.execute())
+	}
+}
+
+// subscribe streams subscription events until the request is done.
+func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request, e *executor) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse(fmt.Errorf("streaming unsupported")))
+		return
+	}
+	sub, err := e.subscription()
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	l, err := e.db.ListenFrom(db.ResumeToken(r.Header.Get("Last-Event-ID")), sub.opt)
+	if errors.Is(err, db.ErrInvalidResumeToken) {
+		writeJSON(w, http.StatusBadRequest, errorResponse(err))
+		return
+	} else if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer l.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-l.Channel():
+			if !ok {
+				// the listener fell behind, the client resumes from the last event id
+				return
+			}
+			data, err := json.Marshal(e.event(sub, ev.Action))
+			if err != nil {
+				log.Errorf("encoding subscription event: %v", err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", ev.Token, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func readRequest(r *http.Request) (req request, err error) {
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				return req, fmt.Errorf("decoding variables: %v", err)
+			}
+		}
+	case http.MethodPost:
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/graphql") {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return req, err
+			}
+			req.Query = string(b)
+		} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, fmt.Errorf("decoding request: %v", err)
+		}
+	default:
+		return req, fmt.Errorf("unsupported method %s", r.Method)
+	}
+	if req.Query == "" {
+		return req, fmt.Errorf("missing query")
+	}
+	return req, nil
+}
+
+func requestToken(r *http.Request) thread.Token {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return thread.Token(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return thread.Token(r.URL.Query().Get("token"))
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, db.ErrDBNotFound), errors.Is(err, lstore.ErrThreadNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, thread.ErrInvalidToken):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("encoding response: %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package graphql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/textileio/go-threads/common"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+	"github.com/textileio/go-threads/util"
+)
+
+type person struct {
+	ID   string   `json:"_id"`
+	Name string   `json:"name"`
+	Age  int      `json:"age"`
+	Tags []string `json:"tags"`
+}
+
+func TestHandler(t *testing.T) {
+	m, clean := createTestManager(t)
+	defer clean()
+	id := thread.NewIDV1(thread.Raw, 32)
+	_, err := m.NewDB(context.Background(), id, db.WithNewManagedCollections(db.CollectionConfig{
+		Name:    "person",
+		Schema:  util.SchemaFromInstance(&person{}, false),
+		Indexes: []db.Index{{Path: "name"}},
+	}))
+	checkErr(t, err)
+	server := httptest.NewServer(NewHandler(m))
+	defer server.Close()
+	url := server.URL + "/" + id.String()
+
+	t.Run("Schema", func(t *testing.T) {
+		res, err := http.Get(url + "/schema")
+		checkErr(t, err)
+		defer res.Body.Close()
+		sdl, err := ioutil.ReadAll(res.Body)
+		checkErr(t, err)
+		for _, s := range []string{
+			"type Person {\n  _id: ID!\n  age: Int!\n  name: String!\n  tags: [String]!\n}",
+			"input PersonInput {\n  _id: ID\n",
+			"person(id: ID!): Person",
+			"personList(filter: JSON, orderBy: String, desc: Boolean, limit: Int, skip: Int): [Person!]!",
+			"createPerson(input: PersonInput!): Person!",
+			"deletePerson(id: ID!): ID!",
+			"personChanged(type: ActionType, id: ID): PersonEvent!",
+		} {
+			if !strings.Contains(string(sdl), s) {
+				t.Fatalf("expected schema to contain %q, got:\n%s", s, sdl)
+			}
+		}
+	})
+
+	var created string
+	t.Run("Mutations", func(t *testing.T) {
+		data := post(t, url, `
+			mutation Create($input: PersonInput!) {
+				a: createPerson(input: $input) { _id name }
+				b: createPerson(input: {name: "bob", age: 42, tags: "x"}) { _id tags }
+			}`, map[string]interface{}{
+			"input": map[string]interface{}{"name": "alice", "age": 30, "tags": []string{"x", "y"}},
+		})
+		a := data["a"].(map[string]interface{})
+		if a["name"] != "alice" || a["_id"] == "" {
+			t.Fatalf("unexpected created instance %v", a)
+		}
+		created = a["_id"].(string)
+		if tags := data["b"].(map[string]interface{})["tags"].([]interface{}); len(tags) != 1 {
+			t.Fatalf("expected a single tag to be coerced to a list, got %v", tags)
+		}
+
+		data = post(t, url, `
+			mutation ($id: ID!) {
+				savePerson(input: {_id: $id, name: "alice", age: 31, tags: []}) { age }
+			}`, map[string]interface{}{"id": created})
+		if age := data["savePerson"].(map[string]interface{})["age"]; age != float64(31) {
+			t.Fatalf("expected saved age 31, got %v", age)
+		}
+	})
+
+	t.Run("Queries", func(t *testing.T) {
+		data := post(t, url, `
+			query ($skip: Boolean = true) {
+				all: personList(orderBy: "age") { ...fields }
+				bobs: personList(filter: {name: "bob"}) { name __typename }
+				one: person(id: "`+created+`") { name tags @skip(if: $skip) }
+				none: person(id: "missing") { name }
+				_collections
+			}
+			fragment fields on Person { name age }`, nil)
+		all := data["all"].([]interface{})
+		if len(all) != 2 || all[0].(map[string]interface{})["name"] != "alice" {
+			t.Fatalf("unexpected list %v", all)
+		}
+		bobs := data["bobs"].([]interface{})
+		if len(bobs) != 1 || bobs[0].(map[string]interface{})["__typename"] != "Person" {
+			t.Fatalf("unexpected filtered list %v", bobs)
+		}
+		one := data["one"].(map[string]interface{})
+		if _, ok := one["tags"]; ok || one["name"] != "alice" {
+			t.Fatalf("unexpected instance %v", one)
+		}
+		if data["none"] != nil {
+			t.Fatalf("expected missing instance to be null")
+		}
+		if c := data["_collections"].([]interface{}); len(c) != 1 || c[0] != "person" {
+			t.Fatalf("unexpected collections %v", c)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		res := do(t, url, `{ personList { nope } }`, nil)
+		if len(res.Errors) != 1 || res.Data != nil {
+			t.Fatalf("expected an error for unknown field, got %+v", res)
+		}
+		res = do(t, url, `mutation { createPerson(input: {name: "x"}) { _id } }`, nil)
+		if len(res.Errors) != 1 {
+			t.Fatalf("expected an error for missing required field, got %+v", res)
+		}
+		res = do(t, url, `{ personList(`, nil)
+		if len(res.Errors) != 1 {
+			t.Fatalf("expected a syntax error, got %+v", res)
+		}
+	})
+
+	t.Run("Subscription", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		body, err := json.Marshal(request{
+			Query: `subscription { personChanged(type: DELETE) { type id instance { name } } }`,
+		})
+		checkErr(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		checkErr(t, err)
+		res, err := http.DefaultClient.Do(req)
+		checkErr(t, err)
+		defer res.Body.Close()
+		if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("expected event stream, got %s", ct)
+		}
+
+		data := post(t, url, `mutation { deletePerson(id: "`+created+`") }`, nil)
+		if data["deletePerson"] != created {
+			t.Fatalf("unexpected deleted id %v", data["deletePerson"])
+		}
+		scanner := bufio.NewScanner(res.Body)
+		var id, event string
+		for scanner.Scan() && event == "" {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "id: ") {
+				id = strings.TrimPrefix(line, "id: ")
+			} else if strings.HasPrefix(line, "data: ") {
+				event = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		if id == "" {
+			t.Fatal("expected event to have a resume token id")
+		}
+		expected := `{"data":{"personChanged":{"type":"DELETE","id":"` + created + `","instance":null}}}`
+		if event != expected {
+			t.Fatalf("expected event %s, got %s", expected, event)
+		}
+	})
+}
+
+func do(t *testing.T, url, query string, vars map[string]interface{}) response {
+	body, err := json.Marshal(request{Query: query, Variables: vars})
+	checkErr(t, err)
+	res, err := http.Post(url, "application/json", bytes.NewReader(body))
+	checkErr(t, err)
+	defer res.Body.Close()
+	var r response
+	checkErr(t, json.NewDecoder(res.Body).Decode(&r))
+	return r
+}
+
+func post(t *testing.T, url, query string, vars map[string]interface{}) map[string]interface{} {
+	res := do(t, url, query, vars)
+	if len(res.Errors) != 0 {
+		t.Fatalf("unexpected errors: %+v", res.Errors)
+	}
+	return res.Data.(map[string]interface{})
+}
+
+func createTestManager(t *testing.T) (*db.Manager, func()) {
+	dir, err := ioutil.TempDir("", "")
+	checkErr(t, err)
+	n, err := common.DefaultNetwork(
+		common.WithNetBadgerPersistence(dir),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+	)
+	checkErr(t, err)
+	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+	checkErr(t, err)
+	m, err := db.NewManager(store, n)
+	checkErr(t, err)
+	return m, func() {
+		if err := n.Close(); err != nil {
+			panic(err)
+		}
+		if err := m.Close(); err != nil {
+			panic(err)
+		}
+		if err := store.Close(); err != nil {
+			panic(err)
+		}
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func checkErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,577 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The parser covers executable GraphQL documents: operations, variables, fields with
+// aliases and arguments, fragments, inline fragments and directives.
+// See https://spec.graphql.org/June2018/#sec-Language.
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query, mutation or subscription
+	name       string
+	vars       []*varDef
+	selections []*selection
+}
+
+type varDef struct {
+	name string
+	typ  *typeRef
+	def  *value
+}
+
+type fragment struct {
+	name       string
+	on         string
+	selections []*selection
+}
+
+// selection is a field, a fragment spread or an inline fragment.
+type selection struct {
+	alias      string
+	name       string
+	args       []*argument
+	directives []*directive
+	selections []*selection
+
+	// spread is the name of a spread fragment
+	spread string
+	// inline is set for inline fragments, with on as the optional type condition
+	inline bool
+	on     string
+}
+
+func (s *selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type argument struct {
+	name  string
+	value *value
+}
+
+type directive struct {
+	name string
+	args []*argument
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []*value
+	fields []*argument
+}
+
+// typeRef is a named, list or non-null type.
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	var s string
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	} else {
+		s = t.name
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// namedType returns the name of the type, or of its items for lists.
+func (t *typeRef) namedType() string {
+	if t.elem != nil {
+		return t.elem.namedType()
+	}
+	return t.name
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+// parse parses an executable document.
+func parse(src string) (doc *document, err error) {
+	p := &parser{src: src}
+	defer func() {
+		if r := recover(); r != nil {
+			perr, ok := r.(parseError)
+			if !ok {
+				panic(r)
+			}
+			err = perr
+		}
+	}()
+	p.next()
+	doc = &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunct, "{"):
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: p.parseSelectionSet()})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			doc.operations = append(doc.operations, p.parseOperation())
+		case p.peek(tokenName, "fragment"):
+			f := p.parseFragment()
+			if _, ok := doc.fragments[f.name]; ok {
+				p.fail("fragment %s is defined more than once", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("document doesn't define an operation")
+	}
+	return doc, nil
+}
+
+type parseError struct {
+	msg string
+}
+
+func (e parseError) Error() string {
+	return e.msg
+}
+
+func (p *parser) fail(format string, args ...interface{}) {
+	line, col := 1, 1
+	for _, r := range p.src[:p.tok.pos] {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	panic(parseError{msg: fmt.Sprintf("syntax error at %d:%d: %s", line, col, fmt.Sprintf(format, args...))})
+}
+
+func (p *parser) unexpected() {
+	if p.tok.kind == tokenEOF {
+		p.fail("unexpected end of document")
+	}
+	p.fail("unexpected %q", p.tok.value)
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+// skip consumes the token if it matches.
+func (p *parser) skip(kind tokenKind, value string) bool {
+	if p.peek(kind, value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, value string) {
+	if !p.skip(kind, value) {
+		p.unexpected()
+	}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokenName {
+		p.unexpected()
+	}
+	n := p.tok.value
+	p.next()
+	return n
+}
+
+func (p *parser) parseOperation() *operation {
+	op := &operation{kind: p.name()}
+	if p.tok.kind == tokenName {
+		op.name = p.name()
+	}
+	if p.skip(tokenPunct, "(") {
+		for !p.skip(tokenPunct, ")") {
+			p.expect(tokenPunct, "$")
+			v := &varDef{name: p.name()}
+			p.expect(tokenPunct, ":")
+			v.typ = p.parseType()
+			if p.skip(tokenPunct, "=") {
+				v.def = p.parseValue(true)
+			}
+			op.vars = append(op.vars, v)
+		}
+	}
+	p.parseDirectives()
+	op.selections = p.parseSelectionSet()
+	return op
+}
+
+func (p *parser) parseFragment() *fragment {
+	p.expect(tokenName, "fragment")
+	f := &fragment{name: p.name()}
+	if f.name == "on" {
+		p.unexpected()
+	}
+	p.expect(tokenName, "on")
+	f.on = p.name()
+	p.parseDirectives()
+	f.selections = p.parseSelectionSet()
+	return f
+}
+
+func (p *parser) parseSelectionSet() []*selection {
+	p.expect(tokenPunct, "{")
+	var sels []*selection
+	for !p.skip(tokenPunct, "}") {
+		sels = append(sels, p.parseSelection())
+	}
+	if len(sels) == 0 {
+		p.fail("selection set is empty")
+	}
+	return sels
+}
+
+func (p *parser) parseSelection() *selection {
+	if p.skip(tokenPunct, "...") {
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			return &selection{spread: p.name(), directives: p.parseDirectives()}
+		}
+		s := &selection{inline: true}
+		if p.skip(tokenName, "on") {
+			s.on = p.name()
+		}
+		s.directives = p.parseDirectives()
+		s.selections = p.parseSelectionSet()
+		return s
+	}
+	s := &selection{name: p.name()}
+	if p.skip(tokenPunct, ":") {
+		s.alias, s.name = s.name, p.name()
+	}
+	s.args = p.parseArguments(false)
+	s.directives = p.parseDirectives()
+	if p.peek(tokenPunct, "{") {
+		s.selections = p.parseSelectionSet()
+	}
+	return s
+}
+
+func (p *parser) parseArguments(constant bool) []*argument {
+	var args []*argument
+	if p.skip(tokenPunct, "(") {
+		for !p.skip(tokenPunct, ")") {
+			a := &argument{name: p.name()}
+			p.expect(tokenPunct, ":")
+			a.value = p.parseValue(constant)
+			args = append(args, a)
+		}
+	}
+	return args
+}
+
+func (p *parser) parseDirectives() []*directive {
+	var dirs []*directive
+	for p.skip(tokenPunct, "@") {
+		dirs = append(dirs, &directive{name: p.name(), args: p.parseArguments(false)})
+	}
+	return dirs
+}
+
+func (p *parser) parseType() *typeRef {
+	var t *typeRef
+	if p.skip(tokenPunct, "[") {
+		t = &typeRef{elem: p.parseType()}
+		p.expect(tokenPunct, "]")
+	} else {
+		t = &typeRef{name: p.name()}
+	}
+	if p.skip(tokenPunct, "!") {
+		t.nonNull = true
+	}
+	return t
+}
+
+// parseValue parses a value, constant values can't contain variables.
+func (p *parser) parseValue(constant bool) *value {
+	tok := p.tok
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if constant {
+				p.fail("variables aren't allowed in constant values")
+			}
+			p.next()
+			return &value{kind: valueVariable, raw: p.name()}
+		case "[":
+			p.next()
+			v := &value{kind: valueList}
+			for !p.skip(tokenPunct, "]") {
+				v.list = append(v.list, p.parseValue(constant))
+			}
+			return v
+		case "{":
+			p.next()
+			v := &value{kind: valueObject}
+			for !p.skip(tokenPunct, "}") {
+				f := &argument{name: p.name()}
+				p.expect(tokenPunct, ":")
+				f.value = p.parseValue(constant)
+				v.fields = append(v.fields, f)
+			}
+			return v
+		}
+	case tokenInt:
+		p.next()
+		return &value{kind: valueInt, raw: tok.value}
+	case tokenFloat:
+		p.next()
+		return &value{kind: valueFloat, raw: tok.value}
+	case tokenString:
+		p.next()
+		return &value{kind: valueString, raw: tok.value}
+	case tokenName:
+		p.next()
+		switch tok.value {
+		case "true", "false":
+			return &value{kind: valueBoolean, raw: tok.value}
+		case "null":
+			return &value{kind: valueNull}
+		default:
+			return &value{kind: valueEnum, raw: tok.value}
+		}
+	}
+	p.unexpected()
+	return nil
+}
+
+// next reads the next token, skipping ignored tokens.
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+		} else if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+		} else if strings.HasPrefix(p.src[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+		} else {
+			break
+		}
+	}
+	start := p.pos
+	p.tok = token{pos: start}
+	if p.pos >= len(p.src) {
+		p.tok.kind = tokenEOF
+		return
+	}
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok.kind, p.tok.value = tokenPunct, "..."
+	case strings.IndexByte("!$():=@[]{|}", c) >= 0:
+		p.pos++
+		p.tok.kind, p.tok.value = tokenPunct, string(c)
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok.kind, p.tok.value = tokenName, p.src[start:p.pos]
+	case c == '-' || isDigit(c):
+		p.readNumber()
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		p.readBlockString()
+	case c == '"':
+		p.readString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.fail("unexpected character %q", r)
+	}
+}
+
+func (p *parser) readNumber() {
+	start := p.pos
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		if p.pos >= len(p.src) || !isDigit(p.src[p.pos]) {
+			p.fail("invalid number")
+		}
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+	digits()
+	kind := tokenInt
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.pos++
+		digits()
+		kind = tokenFloat
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+		kind = tokenFloat
+	}
+	p.tok.kind, p.tok.value = kind, p.src[start:p.pos]
+}
+
+func (p *parser) readString() {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' || p.src[p.pos] == '\r' {
+			p.fail("unterminated string")
+		}
+		c := p.src[p.pos]
+		if c == '"' {
+			p.pos++
+			break
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+		if p.pos+1 >= len(p.src) {
+			p.fail("unterminated string")
+		}
+		esc := p.src[p.pos+1]
+		p.pos += 2
+		switch esc {
+		case '"', '\\', '/':
+			b.WriteByte(esc)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if p.pos+4 > len(p.src) {
+				p.fail("invalid unicode escape")
+			}
+			r, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+			if err != nil {
+				p.fail("invalid unicode escape")
+			}
+			b.WriteRune(rune(r))
+			p.pos += 4
+		default:
+			p.fail("invalid escape \\%c", esc)
+		}
+	}
+	p.tok.kind, p.tok.value = tokenString, b.String()
+}
+
+// readBlockString reads a block string, common indentation and blank leading and
+// trailing lines are removed.
+func (p *parser) readBlockString() {
+	p.pos += 3
+	end := strings.Index(p.src[p.pos:], `"""`)
+	for end > 0 && p.src[p.pos+end-1] == '\\' {
+		next := strings.Index(p.src[p.pos+end+3:], `"""`)
+		if next < 0 {
+			end = -1
+			break
+		}
+		end += next + 3
+	}
+	if end < 0 {
+		p.fail("unterminated block string")
+	}
+	raw := strings.ReplaceAll(p.src[p.pos:p.pos+end], `\"""`, `"""`)
+	p.pos += end + 3
+
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, l := range lines[1:] {
+		trimmed := strings.TrimLeft(l, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(l) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		} else {
+			lines[i] = strings.TrimLeft(lines[i], " \t")
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	p.tok.kind, p.tok.value = tokenString, strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	t.Run("Document", func(t *testing.T) {
+		t.Parallel()
+		doc, err := parse(`
+			# comment
+			query Find($name: String! = "a\"b", $ids: [ID!]) {
+				p: personList(filter: {name: $name}, limit: 10) @include(if: true) {
+					...fields
+					... on Person { age }
+				}
+			}
+			fragment fields on Person { _id, name }
+			mutation { deletePerson(id: "x") }`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.operations) != 2 || len(doc.fragments) != 1 {
+			t.Fatalf("expected 2 operations and 1 fragment, got %d and %d", len(doc.operations), len(doc.fragments))
+		}
+		op := doc.operations[0]
+		if op.kind != "query" || op.name != "Find" {
+			t.Fatalf("unexpected operation %s %s", op.kind, op.name)
+		}
+		if len(op.vars) != 2 || op.vars[0].typ.String() != "String!" || op.vars[0].def.raw != `a"b` {
+			t.Fatalf("unexpected variables")
+		}
+		if op.vars[1].typ.String() != "[ID!]" {
+			t.Fatalf("expected [ID!], got %s", op.vars[1].typ)
+		}
+		sel := op.selections[0]
+		if sel.responseKey() != "p" || sel.name != "personList" || len(sel.args) != 2 {
+			t.Fatalf("unexpected selection %+v", sel)
+		}
+		if sel.args[0].value.kind != valueObject || sel.args[0].value.fields[0].value.kind != valueVariable {
+			t.Fatalf("unexpected filter argument")
+		}
+		if len(sel.directives) != 1 || sel.directives[0].name != "include" {
+			t.Fatalf("expected include directive")
+		}
+		if sel.selections[0].spread != "fields" || !sel.selections[1].inline || sel.selections[1].on != "Person" {
+			t.Fatalf("unexpected fragments")
+		}
+		if doc.operations[1].kind != "mutation" {
+			t.Fatalf("expected mutation, got %s", doc.operations[1].kind)
+		}
+	})
+	t.Run("Shorthand", func(t *testing.T) {
+		t.Parallel()
+		doc, err := parse(`{ a { b } c }`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if doc.operations[0].kind != "query" || len(doc.operations[0].selections) != 2 {
+			t.Fatalf("unexpected shorthand query")
+		}
+	})
+	t.Run("BlockString", func(t *testing.T) {
+		t.Parallel()
+		doc, err := parse("{ a(s: \"\"\"\n    one\n      two\n  \"\"\") }")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := doc.operations[0].selections[0].args[0].value.raw; v != "one\n  two" {
+			t.Fatalf("unexpected block string %q", v)
+		}
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		for _, src := range []string{
+			``,
+			`{`,
+			`{ a(b: ) }`,
+			`{ a(b: "c) }`,
+			`query ($a: String = $b) { a }`,
+			`fragment on on A { a }`,
+			`{ a } { b } }`,
+		} {
+			if _, err := parse(src); err == nil {
+				t.Fatalf("expected error parsing %q", src)
+			}
+		}
+	})
+}
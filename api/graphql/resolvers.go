@@ -0,0 +1,223 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/db"
+)
+
+type resolver func(e *executor, args map[string]interface{}) (interface{}, error)
+
+var actionTypes = map[db.ActionType]string{
+	db.ActionCreate: "CREATE",
+	db.ActionSave:   "SAVE",
+	db.ActionDelete: "DELETE",
+}
+
+var listenTypes = map[string]db.ListenActionType{
+	"CREATE": db.ListenCreate,
+	"SAVE":   db.ListenSave,
+	"DELETE": db.ListenDelete,
+}
+
+func resolveCollections(e *executor, _ map[string]interface{}) (interface{}, error) {
+	var names []interface{}
+	for _, c := range e.db.ListCollections(db.WithToken(e.token)) {
+		names = append(names, c.GetName())
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].(string) < names[j].(string)
+	})
+	return names, nil
+}
+
+func resolveFindByID(c *db.Collection) resolver {
+	return func(e *executor, args map[string]interface{}) (interface{}, error) {
+		return e.findByID(c, core.InstanceID(args["id"].(string)))
+	}
+}
+
+func resolveFind(c *db.Collection) resolver {
+	return func(e *executor, args map[string]interface{}) (interface{}, error) {
+		q := &db.Query{}
+		if filter, ok := args["filter"]; ok {
+			fields, ok := filter.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("filter must be an object of field paths and values")
+			}
+			paths := make([]string, 0, len(fields))
+			for p := range fields {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			for _, p := range paths {
+				v := fields[p]
+				switch n := v.(type) {
+				case int64:
+					v = float64(n)
+				case string, bool, float64:
+				default:
+					return nil, fmt.Errorf("filter value of %s must be a string, number or boolean", p)
+				}
+				q = q.And(p).Eq(v)
+			}
+		}
+		if orderBy, ok := args["orderBy"]; ok {
+			q.Sort.FieldPath = orderBy.(string)
+		}
+		if desc, ok := args["desc"]; ok {
+			q.Sort.Desc = desc.(bool)
+		}
+		if limit, ok := args["limit"]; ok {
+			q.Limit = int(limit.(int64))
+		}
+		if skip, ok := args["skip"]; ok {
+			q.Skip = int(skip.(int64))
+		}
+		instances, err := c.Find(q, db.WithTxnToken(e.token))
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, len(instances))
+		for i, instance := range instances {
+			if res[i], err = decodeInstance(instance); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+}
+
+func resolveCreate(c *db.Collection) resolver {
+	return func(e *executor, args map[string]interface{}) (interface{}, error) {
+		instance, err := json.Marshal(args["input"])
+		if err != nil {
+			return nil, err
+		}
+		var ids []core.InstanceID
+		if err := c.WriteTxn(func(txn *db.Txn) (err error) {
+			ids, err = txn.Create(instance)
+			return err
+		}, db.WithTxnToken(e.token)); err != nil {
+			return nil, err
+		}
+		return e.findByID(c, ids[0])
+	}
+}
+
+func resolveSave(c *db.Collection) resolver {
+	return func(e *executor, args map[string]interface{}) (interface{}, error) {
+		input := args["input"].(map[string]interface{})
+		id, ok := input[idFieldName].(string)
+		if !ok {
+			return nil, fmt.Errorf("input must have an %s", idFieldName)
+		}
+		instance, err := json.Marshal(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.WriteTxn(func(txn *db.Txn) error {
+			return txn.Save(instance)
+		}, db.WithTxnToken(e.token)); err != nil {
+			return nil, err
+		}
+		return e.findByID(c, core.InstanceID(id))
+	}
+}
+
+func resolveDelete(c *db.Collection) resolver {
+	return func(e *executor, args map[string]interface{}) (interface{}, error) {
+		id := core.InstanceID(args["id"].(string))
+		if err := c.WriteTxn(func(txn *db.Txn) error {
+			return txn.Delete(id)
+		}, db.WithTxnToken(e.token)); err != nil {
+			return nil, err
+		}
+		return id.String(), nil
+	}
+}
+
+// findByID returns a decoded instance, nil if it doesn't exist.
+func (e *executor) findByID(c *db.Collection, id core.InstanceID) (interface{}, error) {
+	instance, err := c.FindByID(id, db.WithTxnToken(e.token))
+	if errors.Is(err, db.ErrInstanceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeInstance(instance)
+}
+
+func decodeInstance(instance []byte) (interface{}, error) {
+	var v map[string]interface{}
+	if err := json.Unmarshal(instance, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// subscription is a subscription operation, its single root field maps to a db listener.
+type subscription struct {
+	field      *fieldDef
+	collection *db.Collection
+	opt        db.ListenOption
+}
+
+func (e *executor) subscription() (*subscription, error) {
+	groups, err := e.collectFields(e.schema.subscription, e.op.selections, nil, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) != 1 {
+		return nil, fmt.Errorf("subscriptions must select exactly one root field")
+	}
+	name := groups[0].selections[0].name
+	f := e.schema.subscription.field(name)
+	if f == nil {
+		return nil, fmt.Errorf("cannot query field %s on type %s", name, e.schema.subscription.name)
+	}
+	args, err := e.coerceArgs(f, groups[0].selections[0])
+	if err != nil {
+		return nil, err
+	}
+	sub := &subscription{
+		field:      f,
+		collection: f.collection,
+		opt:        db.ListenOption{Collection: f.collection.GetName()},
+	}
+	if t, ok := args["type"]; ok {
+		sub.opt.Type = listenTypes[t.(string)]
+	}
+	if id, ok := args["id"]; ok {
+		sub.opt.ID = core.InstanceID(id.(string))
+	}
+	return sub, nil
+}
+
+// event executes the subscription selection set for an action.
+func (e *executor) event(sub *subscription, a db.Action) response {
+	e.errors = nil
+	ev := map[string]interface{}{
+		"type": actionTypes[a.Type],
+		"id":   a.ID.String(),
+	}
+	if a.Type != db.ActionDelete {
+		instance, err := e.findByID(sub.collection, a.ID)
+		if err != nil {
+			e.addError(err, nil)
+		}
+		ev["instance"] = instance
+	}
+	root := map[string]interface{}{sub.field.property: ev}
+	data, err := e.executeSelectionSet(e.schema.subscription, root, e.op.selections, nil)
+	res := response{Errors: e.errors}
+	if err == nil {
+		res.Data = data
+	}
+	return res
+}
@@ -0,0 +1,355 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/textileio/go-threads/db"
+)
+
+const (
+	idFieldName = "_id"
+
+	scalarID      = "ID"
+	scalarString  = "String"
+	scalarInt     = "Int"
+	scalarFloat   = "Float"
+	scalarBoolean = "Boolean"
+	scalarJSON    = "JSON"
+
+	enumActionType = "ActionType"
+)
+
+var nameRx = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// schema is a GraphQL schema generated from the collections of a db.
+type schema struct {
+	query        *objectType
+	mutation     *objectType
+	subscription *objectType
+	types        map[string]*objectType
+	inputs       map[string]*objectType
+	enums        map[string][]string
+}
+
+type objectType struct {
+	name   string
+	fields []*fieldDef
+}
+
+func (t *objectType) field(name string) *fieldDef {
+	for _, f := range t.fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+type fieldDef struct {
+	name string
+	args []*fieldDef
+	typ  *typeRef
+	// property is the instance property of the field
+	property string
+
+	// resolve is set for query and mutation fields
+	resolve resolver
+	// collection is set for subscription fields
+	collection *db.Collection
+}
+
+func named(name string, nonNull bool) *typeRef {
+	return &typeRef{name: name, nonNull: nonNull}
+}
+
+func listOf(elem *typeRef, nonNull bool) *typeRef {
+	return &typeRef{elem: elem, nonNull: nonNull}
+}
+
+// newSchema generates a schema for collections. Each collection, e.g. Person, results in:
+//   - Person and PersonInput types, from the collection schema
+//   - person(id) and personList(filter, orderBy, desc, limit, skip) queries
+//   - createPerson(input), savePerson(input) and deletePerson(id) mutations
+//   - a personChanged(type, id) subscription
+//
+// Properties with names which aren't valid GraphQL names are left out.
+func newSchema(collections []*db.Collection) (*schema, error) {
+	s := &schema{
+		query:        &objectType{name: "Query"},
+		mutation:     &objectType{name: "Mutation"},
+		subscription: &objectType{name: "Subscription"},
+		types:        make(map[string]*objectType),
+		inputs:       make(map[string]*objectType),
+		enums:        map[string][]string{enumActionType: {"CREATE", "SAVE", "DELETE"}},
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].GetName() < collections[j].GetName()
+	})
+	s.query.fields = append(s.query.fields, &fieldDef{
+		name:    "_collections",
+		typ:     listOf(named(scalarString, true), true),
+		resolve: resolveCollections,
+	})
+	for _, c := range collections {
+		if err := s.addCollection(c); err != nil {
+			return nil, fmt.Errorf("collection %s: %w", c.GetName(), err)
+		}
+	}
+	return s, nil
+}
+
+func (s *schema) addCollection(c *db.Collection) error {
+	js := &jsonschema.Schema{}
+	if err := json.Unmarshal(c.GetSchema(), js); err != nil {
+		return err
+	}
+	name := typeName(c.GetName())
+	if _, ok := s.types[name]; ok {
+		return fmt.Errorf("type %s is generated by another collection", name)
+	}
+	b := &typeBuilder{s: s, defs: js.Definitions}
+	t := b.object(name, js.Type, false)
+	if t == nil {
+		return fmt.Errorf("schema doesn't describe an object")
+	}
+	input := b.object(name, js.Type, true)
+
+	event := &objectType{name: name + "Event", fields: []*fieldDef{
+		{name: "type", property: "type", typ: named(enumActionType, true)},
+		{name: "id", property: "id", typ: named(scalarID, true)},
+		{name: "instance", property: "instance", typ: named(name, false)},
+	}}
+	s.types[event.name] = event
+
+	field := lowerFirst(name)
+	id := &fieldDef{name: "id", typ: named(scalarID, true)}
+	s.query.fields = append(s.query.fields,
+		&fieldDef{
+			name:    field,
+			args:    []*fieldDef{id},
+			typ:     named(name, false),
+			resolve: resolveFindByID(c),
+		},
+		&fieldDef{
+			name: field + "List",
+			args: []*fieldDef{
+				{name: "filter", typ: named(scalarJSON, false)},
+				{name: "orderBy", typ: named(scalarString, false)},
+				{name: "desc", typ: named(scalarBoolean, false)},
+				{name: "limit", typ: named(scalarInt, false)},
+				{name: "skip", typ: named(scalarInt, false)},
+			},
+			typ:     listOf(named(name, true), true),
+			resolve: resolveFind(c),
+		})
+	s.mutation.fields = append(s.mutation.fields,
+		&fieldDef{
+			name:    "create" + name,
+			args:    []*fieldDef{{name: "input", typ: named(input.name, true)}},
+			typ:     named(name, true),
+			resolve: resolveCreate(c),
+		},
+		&fieldDef{
+			name:    "save" + name,
+			args:    []*fieldDef{{name: "input", typ: named(input.name, true)}},
+			typ:     named(name, true),
+			resolve: resolveSave(c),
+		},
+		&fieldDef{
+			name:    "delete" + name,
+			args:    []*fieldDef{id},
+			typ:     named(scalarID, true),
+			resolve: resolveDelete(c),
+		})
+	s.subscription.fields = append(s.subscription.fields, &fieldDef{
+		name:     field + "Changed",
+		property: field + "Changed",
+		args: []*fieldDef{
+			{name: "type", typ: named(enumActionType, false)},
+			{name: "id", typ: named(scalarID, false)},
+		},
+		typ:        named(event.name, true),
+		collection: c,
+	})
+	return nil
+}
+
+type typeBuilder struct {
+	s    *schema
+	defs jsonschema.Definitions
+}
+
+// object adds an object or input type for a JSON schema object, nil if jt isn't an
+// object with properties.
+func (b *typeBuilder) object(name string, jt *jsonschema.Type, input bool) *objectType {
+	jt = b.resolve(jt)
+	if jt == nil || len(jt.Properties) == 0 {
+		return nil
+	}
+	t := &objectType{name: name}
+	if input {
+		t.name += "Input"
+	}
+	required := make(map[string]bool)
+	for _, r := range jt.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(jt.Properties))
+	for p := range jt.Properties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+	for _, p := range props {
+		f := &fieldDef{name: p, property: p}
+		if p == idFieldName {
+			// IDs are set by the db on creation
+			f.typ = named(scalarID, !input)
+		} else if nameRx.MatchString(p) && !strings.HasPrefix(p, "__") {
+			f.typ = b.typeOf(name+upperFirst(p), jt.Properties[p], input)
+			f.typ.nonNull = required[p]
+		} else {
+			continue
+		}
+		t.fields = append(t.fields, f)
+	}
+	if input {
+		b.s.inputs[t.name] = t
+	} else {
+		b.s.types[t.name] = t
+	}
+	return t
+}
+
+func (b *typeBuilder) typeOf(name string, jt *jsonschema.Type, input bool) *typeRef {
+	jt = b.resolve(jt)
+	if jt == nil {
+		return named(scalarJSON, false)
+	}
+	switch jt.Type {
+	case "string":
+		return named(scalarString, false)
+	case "integer":
+		return named(scalarInt, false)
+	case "number":
+		return named(scalarFloat, false)
+	case "boolean":
+		return named(scalarBoolean, false)
+	case "array":
+		if jt.Items == nil {
+			return listOf(named(scalarJSON, false), false)
+		}
+		return listOf(b.typeOf(name, jt.Items, input), false)
+	case "object":
+		if t := b.object(name, jt, input); t != nil {
+			return named(t.name, false)
+		}
+	}
+	return named(scalarJSON, false)
+}
+
+func (b *typeBuilder) resolve(jt *jsonschema.Type) *jsonschema.Type {
+	if jt == nil || jt.Ref == "" {
+		return jt
+	}
+	parts := strings.Split(jt.Ref, "/")
+	return b.defs[parts[len(parts)-1]]
+}
+
+// typeName returns a GraphQL type name for a collection name, which may contain hyphens.
+func typeName(collection string) string {
+	parts := strings.Split(collection, "-")
+	for i := range parts {
+		parts[i] = upperFirst(parts[i])
+	}
+	name := strings.Join(parts, "")
+	if name == "" || isDigit(name[0]) {
+		name = "_" + name
+	}
+	return name
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// SDL returns the schema in the GraphQL schema definition language.
+func (s *schema) SDL() string {
+	var b strings.Builder
+	b.WriteString("scalar JSON\n\n")
+	names := make([]string, 0, len(s.enums))
+	for name := range s.enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "enum %s {\n", name)
+		for _, v := range s.enums[name] {
+			fmt.Fprintf(&b, "  %s\n", v)
+		}
+		b.WriteString("}\n\n")
+	}
+	for _, root := range s.roots() {
+		writeType(&b, "type", root)
+	}
+	for _, t := range sortedTypes(s.types) {
+		writeType(&b, "type", t)
+	}
+	for _, t := range sortedTypes(s.inputs) {
+		writeType(&b, "input", t)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// roots returns the root operation types, types without fields are left out.
+func (s *schema) roots() []*objectType {
+	roots := []*objectType{s.query}
+	if len(s.mutation.fields) > 0 {
+		roots = append(roots, s.mutation)
+	}
+	if len(s.subscription.fields) > 0 {
+		roots = append(roots, s.subscription)
+	}
+	return roots
+}
+
+func writeType(b *strings.Builder, kind string, t *objectType) {
+	fmt.Fprintf(b, "%s %s {\n", kind, t.name)
+	for _, f := range t.fields {
+		b.WriteString("  " + f.name)
+		if len(f.args) > 0 {
+			args := make([]string, len(f.args))
+			for i, a := range f.args {
+				args[i] = a.name + ": " + a.typ.String()
+			}
+			b.WriteString("(" + strings.Join(args, ", ") + ")")
+		}
+		b.WriteString(": " + f.typ.String() + "\n")
+	}
+	b.WriteString("}\n\n")
+}
+
+func sortedTypes(types map[string]*objectType) []*objectType {
+	list := make([]*objectType, 0, len(types))
+	for _, t := range types {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].name < list[j].name
+	})
+	return list
+}
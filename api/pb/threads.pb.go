@@ -1849,6 +1849,7 @@ type FindRequest struct {
 	DbID                 []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
 	CollectionName       string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
 	QueryJSON            []byte   `protobuf:"bytes,3,opt,name=queryJSON,proto3" json:"queryJSON,omitempty"`
+	Explain              bool     `protobuf:"varint,4,opt,name=explain,proto3" json:"explain,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1900,12 +1901,20 @@ func (m *FindRequest) GetQueryJSON() []byte {
 	return nil
 }
 
+func (m *FindRequest) GetExplain() bool {
+	if m != nil {
+		return m.Explain
+	}
+	return false
+}
+
 type FindReply struct {
-	Instances            [][]byte `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
-	TransactionError     string   `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Instances            [][]byte           `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	TransactionError     string             `protobuf:"bytes,2,opt,name=transactionError,proto3" json:"transactionError,omitempty"`
+	Explain              *FindReply_Explain `protobuf:"bytes,3,opt,name=explain,proto3" json:"explain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *FindReply) Reset()         { *m = FindReply{} }
@@ -1947,6 +1956,108 @@ func (m *FindReply) GetTransactionError() string {
 	return ""
 }
 
+func (m *FindReply) GetExplain() *FindReply_Explain {
+	if m != nil {
+		return m.Explain
+	}
+	return nil
+}
+
+type FindReply_Explain struct {
+	Index                string   `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Scanned              int64    `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Fetched              int64    `protobuf:"varint,3,opt,name=fetched,proto3" json:"fetched,omitempty"`
+	Matched              int64    `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`
+	Returned             int64    `protobuf:"varint,5,opt,name=returned,proto3" json:"returned,omitempty"`
+	Selectivity          float64  `protobuf:"fixed64,6,opt,name=selectivity,proto3" json:"selectivity,omitempty"`
+	SortedInMemory       bool     `protobuf:"varint,7,opt,name=sortedInMemory,proto3" json:"sortedInMemory,omitempty"`
+	Duration             int64    `protobuf:"varint,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindReply_Explain) Reset()         { *m = FindReply_Explain{} }
+func (m *FindReply_Explain) String() string { return proto.CompactTextString(m) }
+func (*FindReply_Explain) ProtoMessage()    {}
+func (*FindReply_Explain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f2ba358bb2150022, []int{36, 0}
+}
+
+func (m *FindReply_Explain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindReply_Explain.Unmarshal(m, b)
+}
+func (m *FindReply_Explain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindReply_Explain.Marshal(b, m, deterministic)
+}
+func (m *FindReply_Explain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindReply_Explain.Merge(m, src)
+}
+func (m *FindReply_Explain) XXX_Size() int {
+	return xxx_messageInfo_FindReply_Explain.Size(m)
+}
+func (m *FindReply_Explain) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindReply_Explain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindReply_Explain proto.InternalMessageInfo
+
+func (m *FindReply_Explain) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *FindReply_Explain) GetScanned() int64 {
+	if m != nil {
+		return m.Scanned
+	}
+	return 0
+}
+
+func (m *FindReply_Explain) GetFetched() int64 {
+	if m != nil {
+		return m.Fetched
+	}
+	return 0
+}
+
+func (m *FindReply_Explain) GetMatched() int64 {
+	if m != nil {
+		return m.Matched
+	}
+	return 0
+}
+
+func (m *FindReply_Explain) GetReturned() int64 {
+	if m != nil {
+		return m.Returned
+	}
+	return 0
+}
+
+func (m *FindReply_Explain) GetSelectivity() float64 {
+	if m != nil {
+		return m.Selectivity
+	}
+	return 0
+}
+
+func (m *FindReply_Explain) GetSortedInMemory() bool {
+	if m != nil {
+		return m.SortedInMemory
+	}
+	return false
+}
+
+func (m *FindReply_Explain) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
 type FindByIDRequest struct {
 	DbID                 []byte   `protobuf:"bytes,1,opt,name=dbID,proto3" json:"dbID,omitempty"`
 	CollectionName       string   `protobuf:"bytes,2,opt,name=collectionName,proto3" json:"collectionName,omitempty"`
@@ -2924,6 +3035,7 @@ func init() {
 	proto.RegisterType((*HasReply)(nil), "threads.pb.HasReply")
 	proto.RegisterType((*FindRequest)(nil), "threads.pb.FindRequest")
 	proto.RegisterType((*FindReply)(nil), "threads.pb.FindReply")
+	proto.RegisterType((*FindReply_Explain)(nil), "threads.pb.FindReply.Explain")
 	proto.RegisterType((*FindByIDRequest)(nil), "threads.pb.FindByIDRequest")
 	proto.RegisterType((*FindByIDReply)(nil), "threads.pb.FindByIDReply")
 	proto.RegisterType((*DiscardRequest)(nil), "threads.pb.DiscardRequest")
@@ -2941,124 +3053,133 @@ func init() {
 func init() { proto.RegisterFile("threads.proto", fileDescriptor_f2ba358bb2150022) }
 
 var fileDescriptor_f2ba358bb2150022 = []byte{
-	// 1867 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcd, 0x6f, 0x23, 0x49,
-	0x15, 0xef, 0xb6, 0xe3, 0xaf, 0xe7, 0x8f, 0x78, 0x4b, 0x19, 0xdb, 0xd3, 0x33, 0x8a, 0x4c, 0xc1,
-	0xce, 0x46, 0x0b, 0x58, 0xab, 0x0c, 0x0b, 0x0b, 0x91, 0x06, 0xec, 0xd8, 0x13, 0x87, 0x89, 0xc2,
-	0xa8, 0x93, 0x99, 0x15, 0x62, 0xd1, 0x6c, 0xc7, 0x5d, 0x4e, 0x9a, 0x38, 0x6e, 0x4f, 0x77, 0x67,
-	0x76, 0xfc, 0x17, 0xc0, 0x91, 0x2b, 0x57, 0x4e, 0xdc, 0x39, 0xc1, 0x15, 0x89, 0x23, 0x37, 0xee,
-	0xfc, 0x23, 0x48, 0xa8, 0xaa, 0xfa, 0xa3, 0xaa, 0x3f, 0x9c, 0x38, 0x64, 0x17, 0x6e, 0xee, 0xd7,
-	0xef, 0xbb, 0x7e, 0xaf, 0xde, 0xeb, 0x67, 0xa8, 0x7b, 0x17, 0x0e, 0x31, 0x4c, 0xb7, 0xb7, 0x70,
-	0x6c, 0xcf, 0x46, 0x10, 0x3e, 0x9e, 0xe1, 0x97, 0xb0, 0x79, 0x40, 0xbc, 0x53, 0xfb, 0x92, 0xcc,
-	0x75, 0xf2, 0xf6, 0x9a, 0xb8, 0x1e, 0x42, 0x90, 0xbf, 0x24, 0xcb, 0x8e, 0xda, 0x55, 0x77, 0x2a,
-	0x63, 0x45, 0xa7, 0x0f, 0x68, 0x1b, 0x2a, 0xae, 0x75, 0x3e, 0x37, 0xbc, 0x6b, 0x87, 0x74, 0x72,
-	0x5d, 0x75, 0xa7, 0x36, 0x56, 0xf4, 0x88, 0x34, 0xa8, 0x40, 0x69, 0x61, 0x2c, 0x67, 0xb6, 0x61,
-	0x62, 0x1d, 0xea, 0x91, 0xc6, 0xc5, 0x8c, 0xc9, 0x4e, 0x2e, 0x8c, 0xd9, 0x8c, 0xcc, 0xcf, 0x09,
-	0xd3, 0xca, 0x64, 0x43, 0x12, 0x6a, 0x41, 0xc1, 0xa3, 0xdc, 0x4c, 0x2f, 0xb5, 0xc8, 0x1f, 0x45,
-	0x9d, 0x7f, 0x55, 0xa1, 0x76, 0x4c, 0xbe, 0x1a, 0x0e, 0x22, 0x1f, 0x37, 0xcc, 0xb3, 0xc3, 0x21,
-	0x57, 0xa7, 0xb3, 0xdf, 0xa8, 0xc9, 0xfd, 0x2e, 0x32, 0x12, 0xf3, 0xba, 0x05, 0xc5, 0x99, 0x7d,
-	0xfe, 0x82, 0x2c, 0x3b, 0x25, 0x46, 0xf4, 0x9f, 0xa8, 0xf4, 0xdc, 0xb8, 0x22, 0x9d, 0x3c, 0x35,
-	0xa8, 0xb3, 0xdf, 0xe8, 0x19, 0x54, 0x27, 0xf6, 0x6c, 0x46, 0x26, 0x9e, 0x65, 0xcf, 0xdd, 0x4e,
-	0xae, 0x9b, 0xdf, 0xa9, 0xee, 0x3e, 0xee, 0x45, 0xa9, 0xea, 0xed, 0x87, 0xaf, 0xf7, 0xed, 0xf9,
-	0xd4, 0x3a, 0xd7, 0x45, 0x01, 0xd4, 0x81, 0xc2, 0xd9, 0xcc, 0x9e, 0x5c, 0x76, 0x0a, 0x5d, 0x75,
-	0xa7, 0x3c, 0xc8, 0x75, 0x54, 0x9d, 0x13, 0xf0, 0xbf, 0x54, 0xd8, 0x62, 0xce, 0x3f, 0x77, 0xec,
-	0xab, 0xbe, 0x69, 0x3a, 0x42, 0x10, 0x86, 0x69, 0x3a, 0x41, 0x10, 0xf4, 0x77, 0x10, 0x44, 0xee,
-	0xf6, 0x41, 0x6c, 0x64, 0x07, 0x91, 0x5f, 0x37, 0x88, 0x2d, 0x29, 0x08, 0x3f, 0x00, 0xd4, 0x85,
-	0x0a, 0xd7, 0xf0, 0x22, 0x48, 0x2f, 0x0b, 0x2f, 0x22, 0xe2, 0x3f, 0xab, 0xd0, 0x8c, 0x6b, 0x0e,
-	0x1d, 0x54, 0x05, 0x07, 0x5b, 0x50, 0x74, 0x27, 0x17, 0xe4, 0xca, 0xf0, 0x23, 0xf4, 0x9f, 0xd0,
-	0x77, 0xa1, 0x64, 0xcd, 0x4d, 0xf2, 0x9e, 0x04, 0x4e, 0x7f, 0x20, 0x3a, 0x7d, 0x48, 0x5f, 0xe9,
-	0x01, 0x07, 0x7a, 0x02, 0x8d, 0xaf, 0x1c, 0xcb, 0x23, 0xaf, 0x8d, 0x99, 0x65, 0x1a, 0x9e, 0xed,
-	0xf8, 0x39, 0x88, 0x51, 0xd1, 0x36, 0x00, 0x55, 0xf1, 0xdc, 0x9a, 0x79, 0xc4, 0x61, 0x21, 0x55,
-	0x74, 0x81, 0x82, 0x9f, 0x42, 0x81, 0x69, 0xa6, 0x9e, 0x2e, 0x0c, 0xef, 0x22, 0xf0, 0x94, 0xfe,
-	0xa6, 0x9e, 0x5e, 0xcf, 0xad, 0xb7, 0xd7, 0x1c, 0xee, 0x65, 0xdd, 0x7f, 0xc2, 0x35, 0x00, 0x1f,
-	0x89, 0x8b, 0xd9, 0x12, 0x37, 0xa1, 0x71, 0x64, 0xb9, 0xde, 0x70, 0xe0, 0xfa, 0x87, 0x8a, 0x7f,
-	0xab, 0x42, 0x2d, 0x24, 0x51, 0xf8, 0x7f, 0x1f, 0xf2, 0xe6, 0x99, 0xdb, 0x51, 0x59, 0x58, 0x8f,
-	0xc4, 0xb0, 0x44, 0xb6, 0xde, 0x70, 0xa0, 0x53, 0x3e, 0x6d, 0x0c, 0xb9, 0xe1, 0x20, 0x15, 0xdf,
-	0x3d, 0xd8, 0xb0, 0xe6, 0x53, 0x9b, 0xf9, 0x53, 0xdd, 0xd5, 0x44, 0x4d, 0x07, 0xc4, 0x1b, 0x0e,
-	0x0e, 0xe7, 0x53, 0x9b, 0xe9, 0xd2, 0x19, 0x1f, 0x7e, 0x02, 0x4d, 0x81, 0x9e, 0x59, 0x37, 0xf8,
-	0x08, 0x1a, 0xb2, 0x3c, 0x85, 0x01, 0x05, 0x23, 0x77, 0xba, 0xa6, 0xf3, 0x87, 0x14, 0x68, 0xa6,
-	0xd4, 0x11, 0xfe, 0x10, 0x36, 0x87, 0x64, 0x46, 0x3c, 0xb2, 0xb2, 0x58, 0xf1, 0x26, 0xd4, 0x23,
-	0x36, 0x9a, 0xc9, 0x2f, 0x59, 0x91, 0x44, 0x20, 0x5a, 0x55, 0xe9, 0x3f, 0x80, 0xe2, 0x84, 0x61,
-	0xcc, 0xcf, 0xc5, 0x6a, 0x84, 0xfb, 0xbc, 0x78, 0x0b, 0x50, 0xcc, 0x02, 0xb5, 0x3b, 0x81, 0xf6,
-	0xab, 0x85, 0x69, 0x78, 0xe4, 0xeb, 0x34, 0xdd, 0x86, 0x07, 0x49, 0x23, 0xd4, 0x7a, 0x1f, 0xda,
-	0x3c, 0x0d, 0xb7, 0xb3, 0x1e, 0x24, 0x3c, 0x27, 0x24, 0xbc, 0x0d, 0x0f, 0x92, 0x2a, 0xa8, 0xee,
-	0x01, 0x74, 0x0e, 0x88, 0x17, 0x51, 0x6f, 0xc0, 0x41, 0xaa, 0xf2, 0xbf, 0xa8, 0xd0, 0x4a, 0x51,
-	0x42, 0x41, 0xf2, 0x7f, 0x5f, 0xde, 0x23, 0x78, 0x14, 0x73, 0x9d, 0xe9, 0x5f, 0x37, 0x05, 0x63,
-	0x78, 0x98, 0xae, 0x86, 0x26, 0x41, 0x08, 0x4c, 0xbd, 0x29, 0x30, 0xfc, 0x3d, 0x68, 0xd1, 0x92,
-	0x8f, 0x54, 0xad, 0xf2, 0x05, 0x7f, 0x01, 0x5b, 0x09, 0x6e, 0x6a, 0x72, 0x28, 0xdf, 0xf1, 0xdc,
-	0x2c, 0x8e, 0xdd, 0x06, 0x29, 0x07, 0x26, 0xdd, 0xf4, 0xd8, 0x82, 0xfa, 0xbe, 0x43, 0x0c, 0x8f,
-	0xac, 0x4a, 0xc7, 0x13, 0x68, 0x44, 0x32, 0xc7, 0x51, 0x62, 0x62, 0x54, 0xf4, 0x18, 0x2a, 0xd6,
-	0xdc, 0xf5, 0x8c, 0xf9, 0xc4, 0x3f, 0xe0, 0x9a, 0x1e, 0x11, 0xf0, 0xaf, 0xa0, 0x1a, 0x98, 0xa2,
-	0xfe, 0x77, 0xa1, 0x1a, 0xbc, 0x3b, 0x1c, 0x72, 0xff, 0x2b, 0xba, 0x48, 0x42, 0x1f, 0x43, 0xd3,
-	0x73, 0x8c, 0xb9, 0x6b, 0x30, 0x0b, 0x23, 0xc7, 0xb1, 0x1d, 0xdf, 0x70, 0x82, 0x4e, 0xe3, 0x78,
-	0x4d, 0x1c, 0x6b, 0xba, 0xfc, 0xfa, 0xe3, 0xf8, 0x31, 0x54, 0x03, 0x53, 0x34, 0x8e, 0x34, 0x2f,
-	0xd5, 0x0c, 0x2f, 0xcf, 0xa1, 0x7a, 0x62, 0xbc, 0xfb, 0x06, 0x72, 0xfd, 0x23, 0xa8, 0x70, 0x43,
-	0xeb, 0x7a, 0x78, 0x15, 0xdc, 0xc7, 0xf7, 0xe1, 0x63, 0xec, 0x88, 0xf3, 0x89, 0x23, 0xa6, 0xb9,
-	0x0c, 0xcc, 0xad, 0xeb, 0xe9, 0x6f, 0x00, 0xc6, 0x86, 0xfb, 0xcd, 0xb8, 0x79, 0x0c, 0x65, 0x66,
-	0x8b, 0xfa, 0xd8, 0x82, 0x22, 0x79, 0x6f, 0xb9, 0x9e, 0xcb, 0x6c, 0x95, 0x75, 0xff, 0x69, 0x2d,
-	0xb4, 0x9e, 0x43, 0xf5, 0xb9, 0x35, 0x37, 0xef, 0x09, 0x07, 0x6f, 0xaf, 0x89, 0xb3, 0xfc, 0xf9,
-	0xc9, 0x2f, 0x8e, 0x59, 0x03, 0xae, 0xe9, 0x11, 0x01, 0xbf, 0x82, 0x0a, 0x37, 0x44, 0x3d, 0x97,
-	0x20, 0xa3, 0xc6, 0x20, 0xb3, 0x96, 0xff, 0x57, 0xb0, 0x49, 0xd5, 0x0e, 0x96, 0x87, 0xc3, 0xfb,
-	0x88, 0x61, 0x1b, 0x20, 0xca, 0xb6, 0x3f, 0x45, 0x08, 0x14, 0xfc, 0x39, 0xd4, 0x23, 0x73, 0x34,
-	0x12, 0x0d, 0xca, 0xc1, 0x6b, 0xdf, 0x60, 0xf8, 0xbc, 0x56, 0x1c, 0x4d, 0x68, 0x0c, 0x2d, 0x77,
-	0x62, 0x38, 0xc1, 0x51, 0xe0, 0x06, 0xd4, 0x42, 0x0a, 0x6d, 0x9e, 0xaf, 0xa0, 0x7d, 0xe2, 0x19,
-	0x8e, 0x77, 0x1a, 0x89, 0xde, 0x43, 0xc4, 0xf8, 0x6f, 0x39, 0x68, 0xe9, 0xc4, 0x30, 0x53, 0xd4,
-	0xbe, 0x81, 0xb6, 0x9b, 0x6e, 0x91, 0x59, 0xaa, 0xee, 0x7e, 0x5b, 0xbc, 0xe3, 0x33, 0x9c, 0x1b,
-	0x2b, 0x7a, 0x96, 0x16, 0xf4, 0x19, 0xc0, 0x45, 0x58, 0x38, 0xfe, 0xf8, 0xd2, 0x12, 0x75, 0x46,
-	0x65, 0x35, 0x56, 0x74, 0x81, 0x17, 0xed, 0x41, 0x75, 0x1a, 0xc1, 0x96, 0x1d, 0x54, 0x75, 0xb7,
-	0x2d, 0x8a, 0x0a, 0xa8, 0x1e, 0x2b, 0xba, 0xc8, 0x8d, 0x0e, 0x60, 0x73, 0x2a, 0x63, 0x86, 0xf5,
-	0xf3, 0xd8, 0x2c, 0x1c, 0x83, 0xd5, 0x58, 0xd1, 0xe3, 0x52, 0x83, 0x32, 0x14, 0xed, 0x05, 0x0d,
-	0x08, 0xff, 0x43, 0x85, 0xad, 0x44, 0x16, 0x29, 0x3e, 0x76, 0xa1, 0x7c, 0xe1, 0xd7, 0xab, 0x9f,
-	0xb4, 0xad, 0x44, 0x80, 0x8b, 0xd9, 0x72, 0xac, 0xe8, 0x21, 0x1f, 0xfa, 0x14, 0x2a, 0xd3, 0xa0,
-	0x54, 0xfc, 0xac, 0x3c, 0x48, 0x86, 0xc6, 0xa5, 0x22, 0x4e, 0xd4, 0x87, 0xfa, 0x54, 0xc4, 0xa6,
-	0x9f, 0x95, 0x87, 0xe9, 0x41, 0x71, 0x71, 0x59, 0x42, 0x08, 0xe8, 0xf7, 0x05, 0x68, 0x7f, 0x4e,
-	0xa7, 0x9b, 0xff, 0x05, 0x2e, 0xfa, 0x50, 0x9f, 0x88, 0xa3, 0x80, 0x9f, 0x04, 0x29, 0x12, 0x69,
-	0x56, 0xa0, 0x91, 0x48, 0x12, 0x54, 0xc5, 0x3b, 0xb1, 0x0b, 0x77, 0xca, 0x49, 0x15, 0x52, 0x9b,
-	0xa6, 0x2a, 0x24, 0x09, 0x8a, 0x31, 0x37, 0x6a, 0x91, 0x69, 0x18, 0x13, 0x3a, 0x28, 0xc5, 0x98,
-	0xc0, 0x4d, 0xed, 0x9b, 0x62, 0xf7, 0xf2, 0x11, 0x26, 0xd9, 0x97, 0xda, 0x1b, 0xb5, 0x2f, 0x49,
-	0xc4, 0xaa, 0xa3, 0x70, 0xf7, 0xea, 0x28, 0xfe, 0xb7, 0xd5, 0x51, 0xba, 0x4b, 0x75, 0xa0, 0x21,
-	0x34, 0x4c, 0xe9, 0x4a, 0xeb, 0x54, 0x92, 0xdf, 0x89, 0xf2, 0xa5, 0x37, 0x56, 0xf4, 0x98, 0x8c,
-	0x00, 0xc9, 0x7f, 0xe7, 0xe1, 0x41, 0x12, 0x92, 0x14, 0xf9, 0x7b, 0x50, 0x9d, 0x44, 0xf3, 0x9c,
-	0x0f, 0xc2, 0x76, 0x1a, 0x5a, 0x38, 0xea, 0x45, 0x6e, 0x2a, 0xfc, 0x2e, 0x1a, 0xa2, 0xfc, 0x58,
-	0xdb, 0x69, 0x38, 0xf1, 0x85, 0x05, 0x6e, 0x5a, 0xaa, 0x6e, 0x30, 0xdd, 0xa4, 0x95, 0x6a, 0x38,
-	0xfa, 0xb0, 0xe5, 0x54, 0x38, 0x07, 0xed, 0x41, 0xd5, 0x8c, 0x86, 0x8d, 0x34, 0x68, 0x09, 0xb3,
-	0x08, 0xb5, 0x29, 0x70, 0x4b, 0x57, 0xca, 0xc6, 0x5d, 0xae, 0x94, 0xc2, 0xdd, 0xaf, 0x94, 0xe2,
-	0xba, 0x57, 0x0a, 0x7a, 0x06, 0x35, 0x53, 0x68, 0x63, 0x7e, 0x1d, 0x76, 0x52, 0x31, 0xc0, 0x15,
-	0x48, 0xfc, 0xc2, 0xf9, 0xff, 0x29, 0x07, 0x75, 0xfa, 0xfd, 0x41, 0x56, 0xf6, 0xbd, 0x9f, 0x40,
-	0x69, 0xca, 0xbe, 0xb6, 0x82, 0x8d, 0x59, 0x37, 0xbe, 0xe0, 0x08, 0xe5, 0x7b, 0xfc, 0xb3, 0x4c,
-	0x0f, 0x04, 0xb4, 0xbf, 0xab, 0x50, 0xe4, 0xb4, 0x94, 0xf6, 0xa9, 0xde, 0x62, 0x60, 0xc8, 0xc5,
-	0x07, 0x06, 0xf4, 0x53, 0x28, 0x72, 0xa4, 0xb2, 0x43, 0x6e, 0xec, 0x7e, 0x74, 0x93, 0x37, 0xbd,
-	0x3e, 0x07, 0xb6, 0x2f, 0x86, 0x9f, 0x42, 0x91, 0x53, 0x50, 0x09, 0xf2, 0xfd, 0xa3, 0xa3, 0xa6,
-	0x82, 0x00, 0x8a, 0xfb, 0xfa, 0xa8, 0x7f, 0x3a, 0x6a, 0xaa, 0xa8, 0x0c, 0x1b, 0x27, 0xfd, 0xd7,
-	0xa3, 0x66, 0x8e, 0x52, 0x87, 0xa3, 0xa3, 0xd1, 0xe9, 0xa8, 0x99, 0xc7, 0xff, 0x54, 0xa1, 0x1a,
-	0x28, 0xa7, 0x87, 0x70, 0x5f, 0xd1, 0xfc, 0x30, 0x16, 0xcd, 0x76, 0x5a, 0x34, 0x8b, 0xd9, 0x32,
-	0x16, 0x84, 0x34, 0x25, 0x6d, 0xc8, 0x53, 0x12, 0xfe, 0x38, 0x0c, 0x30, 0x8a, 0x4b, 0x09, 0xe3,
-	0x52, 0x85, 0xb8, 0x72, 0xbb, 0x7f, 0xa8, 0x43, 0xbe, 0xff, 0xf2, 0x10, 0x8d, 0xa1, 0x1c, 0x6c,
-	0x74, 0xd1, 0xa3, 0xd8, 0x87, 0xa6, 0xb8, 0x39, 0xd6, 0x1e, 0xa6, 0xbf, 0xa4, 0xf3, 0x94, 0xb2,
-	0xa3, 0x7e, 0xa2, 0xa2, 0x3d, 0x28, 0xb0, 0xe5, 0x19, 0x92, 0x10, 0x29, 0x6e, 0x76, 0xb5, 0x56,
-	0xca, 0x1b, 0xa6, 0x00, 0xbd, 0x80, 0xba, 0xb4, 0x46, 0x45, 0xdd, 0x04, 0x6b, 0x6c, 0xc3, 0xba,
-	0x42, 0x59, 0x1f, 0x4a, 0xfe, 0xfa, 0x0d, 0x69, 0xa9, 0x3b, 0x39, 0xae, 0xa0, 0x93, 0xb5, 0xaf,
-	0xc3, 0x0a, 0x3a, 0x80, 0x4a, 0xb8, 0x37, 0x43, 0x8f, 0x33, 0xd6, 0x71, 0x5c, 0xcd, 0x8a, 0x65,
-	0x1d, 0x56, 0xd0, 0x10, 0xca, 0xc1, 0x2e, 0x4c, 0xce, 0x6f, 0x6c, 0x91, 0xa6, 0x3d, 0x4c, 0x7f,
-	0xc9, 0xb5, 0x9c, 0xb0, 0xf4, 0x44, 0x1f, 0xfe, 0x89, 0xf4, 0x24, 0x56, 0x4c, 0xda, 0xf6, 0x0a,
-	0x0e, 0xae, 0xf4, 0x0b, 0x68, 0xc6, 0x17, 0x57, 0x48, 0x9a, 0x37, 0x32, 0x76, 0x67, 0xda, 0xb7,
-	0x56, 0x33, 0x85, 0xda, 0xe3, 0xab, 0x2b, 0x59, 0x7b, 0xc6, 0x6e, 0x4c, 0xd6, 0x9e, 0xbe, 0xfd,
-	0x52, 0xd0, 0x1b, 0xf8, 0x20, 0xb1, 0x09, 0x41, 0xdf, 0xb9, 0x61, 0x51, 0xc2, 0xf5, 0xdf, 0x62,
-	0x9d, 0x82, 0x15, 0x74, 0x09, 0x5b, 0x69, 0x9b, 0x21, 0xf4, 0xd1, 0x0a, 0x69, 0x71, 0x05, 0xa5,
-	0x7d, 0x78, 0x33, 0x23, 0xb5, 0x94, 0xff, 0x5d, 0x4e, 0x45, 0xbf, 0x84, 0xcd, 0xd8, 0x3a, 0x08,
-	0xe1, 0x38, 0x38, 0x93, 0x9b, 0x25, 0xad, 0xbb, 0x92, 0x87, 0xc7, 0xf1, 0x0c, 0x8a, 0xbc, 0x63,
-	0xa3, 0xec, 0x99, 0x4f, 0xcb, 0x6a, 0xf0, 0x5c, 0x9e, 0x37, 0x6d, 0x94, 0x3d, 0xf0, 0x69, 0x59,
-	0x3d, 0x1e, 0x2b, 0xe8, 0x33, 0xd8, 0xa0, 0x9d, 0x1b, 0x65, 0x4d, 0x7b, 0x5a, 0x7a, 0x93, 0xe7,
-	0x96, 0xf9, 0xe9, 0xa3, 0xec, 0x51, 0x4f, 0xcb, 0xea, 0xf4, 0x58, 0x41, 0x9f, 0x42, 0x7e, 0x6c,
-	0xb8, 0x28, 0x63, 0xce, 0xd3, 0x52, 0x3b, 0x3d, 0x77, 0x98, 0xf6, 0x61, 0x94, 0x35, 0xe4, 0x69,
-	0xe9, 0xdd, 0x9e, 0x97, 0x7a, 0xd0, 0xc1, 0xd1, 0xaa, 0x09, 0x4f, 0xcb, 0x6e, 0xfa, 0x58, 0x41,
-	0xbf, 0x86, 0xcd, 0xd8, 0xe7, 0x8f, 0x8c, 0x85, 0xf4, 0x2f, 0x4c, 0x19, 0x0b, 0x69, 0xdf, 0x4f,
-	0xfe, 0x2d, 0xfd, 0x25, 0x34, 0xe3, 0x93, 0x9f, 0x5c, 0x96, 0x19, 0x9f, 0x2a, 0x72, 0x59, 0xa6,
-	0x0e, 0x8f, 0xbe, 0x85, 0x9f, 0x41, 0x91, 0xf7, 0x2f, 0xf9, 0xdc, 0xa4, 0x0e, 0x2d, 0x9f, 0x9b,
-	0xd0, 0xee, 0xb0, 0xf2, 0x89, 0x3a, 0xe8, 0x41, 0xdb, 0xb2, 0x7b, 0x1e, 0x79, 0xef, 0x59, 0x33,
-	0x12, 0x30, 0xbe, 0x39, 0x77, 0x16, 0x93, 0x41, 0xe9, 0x94, 0x3f, 0xbd, 0x54, 0xff, 0x98, 0x2b,
-	0x9d, 0x8e, 0xf5, 0x51, 0x7f, 0x78, 0x72, 0x56, 0x64, 0x7f, 0x7d, 0x3e, 0xfd, 0x4f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xc7, 0x43, 0x37, 0xb9, 0x0b, 0x1d, 0x00, 0x00,
+	// 2001 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcd, 0x73, 0x1b, 0x49,
+	0x15, 0xd7, 0xe8, 0x5b, 0x4f, 0x92, 0xad, 0xed, 0x72, 0xa4, 0xc9, 0x24, 0xb8, 0x94, 0x86, 0xcd,
+	0xba, 0x16, 0x50, 0x6d, 0x39, 0x2c, 0xbb, 0x90, 0xaa, 0x80, 0x14, 0x29, 0x96, 0x89, 0x09, 0xa9,
+	0xb6, 0x37, 0x5b, 0x14, 0x4b, 0x65, 0xc7, 0x9a, 0x96, 0x3d, 0x44, 0x9e, 0x51, 0x66, 0xc6, 0xde,
+	0xf8, 0x06, 0x27, 0x38, 0x72, 0xe5, 0xca, 0x89, 0x3b, 0x27, 0xb8, 0x52, 0xc5, 0x91, 0x1b, 0x77,
+	0xfe, 0x03, 0xfe, 0x02, 0xaa, 0xa8, 0xee, 0x9e, 0x8f, 0x9e, 0x2f, 0x39, 0x32, 0xde, 0x65, 0x6f,
+	0xea, 0xd7, 0xef, 0xbb, 0x7f, 0xaf, 0xfb, 0xcd, 0x13, 0xb4, 0xbd, 0x53, 0x87, 0xea, 0x86, 0x3b,
+	0x58, 0x3a, 0xb6, 0x67, 0x23, 0x08, 0x97, 0xc7, 0xf8, 0x39, 0x6c, 0xee, 0x51, 0xef, 0xc8, 0x7e,
+	0x45, 0x2d, 0x42, 0x5f, 0x9f, 0x53, 0xd7, 0x43, 0x08, 0x4a, 0xaf, 0xe8, 0xa5, 0xaa, 0xf4, 0x95,
+	0x9d, 0xc6, 0xb4, 0x40, 0xd8, 0x02, 0x6d, 0x43, 0xc3, 0x35, 0x4f, 0x2c, 0xdd, 0x3b, 0x77, 0xa8,
+	0x5a, 0xec, 0x2b, 0x3b, 0xad, 0x69, 0x81, 0x44, 0xa4, 0x51, 0x03, 0x6a, 0x4b, 0xfd, 0x72, 0x61,
+	0xeb, 0x06, 0x26, 0xd0, 0x8e, 0x34, 0x2e, 0x17, 0x5c, 0x76, 0x76, 0xaa, 0x2f, 0x16, 0xd4, 0x3a,
+	0xa1, 0xaa, 0x12, 0xc8, 0x86, 0x24, 0xd4, 0x85, 0x8a, 0xc7, 0xb8, 0xd5, 0xa2, 0x6f, 0x51, 0x2c,
+	0x65, 0x9d, 0x7f, 0x55, 0xa0, 0xf5, 0x8c, 0x7e, 0x31, 0x1e, 0x45, 0x3e, 0x96, 0x8d, 0xe3, 0xfd,
+	0xb1, 0x50, 0x47, 0xf8, 0x6f, 0xd4, 0x11, 0x7e, 0x57, 0x39, 0x89, 0x7b, 0xdd, 0x85, 0xea, 0xc2,
+	0x3e, 0x79, 0x4a, 0x2f, 0xd5, 0x1a, 0x27, 0xfa, 0x2b, 0x26, 0x6d, 0xe9, 0x67, 0x54, 0x2d, 0x31,
+	0x83, 0x84, 0xff, 0x46, 0x8f, 0xa0, 0x39, 0xb3, 0x17, 0x0b, 0x3a, 0xf3, 0x4c, 0xdb, 0x72, 0xd5,
+	0x62, 0xbf, 0xb4, 0xd3, 0xdc, 0xbd, 0x3b, 0x88, 0x52, 0x35, 0x78, 0x1c, 0x6e, 0x3f, 0xb6, 0xad,
+	0xb9, 0x79, 0x42, 0x64, 0x01, 0xa4, 0x42, 0xe5, 0x78, 0x61, 0xcf, 0x5e, 0xa9, 0x95, 0xbe, 0xb2,
+	0x53, 0x1f, 0x15, 0x55, 0x85, 0x08, 0x02, 0xfe, 0x97, 0x02, 0x5b, 0xdc, 0xf9, 0x27, 0x8e, 0x7d,
+	0x36, 0x34, 0x0c, 0x47, 0x0a, 0x42, 0x37, 0x0c, 0x27, 0x08, 0x82, 0xfd, 0x0e, 0x82, 0x28, 0xbe,
+	0x7d, 0x10, 0xe5, 0xfc, 0x20, 0x4a, 0xeb, 0x06, 0xb1, 0x15, 0x0b, 0xc2, 0x0f, 0x00, 0xf5, 0xa1,
+	0x21, 0x34, 0x3c, 0x0d, 0xd2, 0xcb, 0xc3, 0x8b, 0x88, 0xf8, 0xcf, 0x0a, 0x74, 0x92, 0x9a, 0x43,
+	0x07, 0x15, 0xc9, 0xc1, 0x2e, 0x54, 0xdd, 0xd9, 0x29, 0x3d, 0xd3, 0xfd, 0x08, 0xfd, 0x15, 0xfa,
+	0x36, 0xd4, 0x4c, 0xcb, 0xa0, 0x6f, 0x68, 0xe0, 0xf4, 0x3b, 0xb2, 0xd3, 0xfb, 0x6c, 0x8b, 0x04,
+	0x1c, 0xe8, 0x3e, 0x6c, 0x7c, 0xe1, 0x98, 0x1e, 0x7d, 0xa1, 0x2f, 0x4c, 0x43, 0xf7, 0x6c, 0xc7,
+	0xcf, 0x41, 0x82, 0x8a, 0xb6, 0x01, 0x98, 0x8a, 0x27, 0xe6, 0xc2, 0xa3, 0x0e, 0x0f, 0xa9, 0x41,
+	0x24, 0x0a, 0x7e, 0x00, 0x15, 0xae, 0x99, 0x79, 0xba, 0xd4, 0xbd, 0xd3, 0xc0, 0x53, 0xf6, 0x9b,
+	0x79, 0x7a, 0x6e, 0x99, 0xaf, 0xcf, 0x05, 0xdc, 0xeb, 0xc4, 0x5f, 0xe1, 0x16, 0x80, 0x8f, 0xc4,
+	0xe5, 0xe2, 0x12, 0x77, 0x60, 0xe3, 0xc0, 0x74, 0xbd, 0xf1, 0xc8, 0xf5, 0x0f, 0x15, 0xff, 0x56,
+	0x81, 0x56, 0x48, 0x62, 0xf0, 0xff, 0x2e, 0x94, 0x8c, 0x63, 0x57, 0x55, 0x78, 0x58, 0x77, 0xe4,
+	0xb0, 0x64, 0xb6, 0xc1, 0x78, 0x44, 0x18, 0x9f, 0x36, 0x85, 0xe2, 0x78, 0x94, 0x89, 0xef, 0x01,
+	0x94, 0x4d, 0x6b, 0x6e, 0x73, 0x7f, 0x9a, 0xbb, 0x9a, 0xac, 0x69, 0x8f, 0x7a, 0xe3, 0xd1, 0xbe,
+	0x35, 0xb7, 0xb9, 0x2e, 0xc2, 0xf9, 0xf0, 0x7d, 0xe8, 0x48, 0xf4, 0xdc, 0xba, 0xc1, 0x07, 0xb0,
+	0x11, 0x97, 0x67, 0x30, 0x60, 0x60, 0x14, 0x4e, 0xb7, 0x88, 0x58, 0x64, 0x40, 0x33, 0xa3, 0x8e,
+	0xf0, 0xbb, 0xb0, 0x39, 0xa6, 0x0b, 0xea, 0xd1, 0x95, 0xc5, 0x8a, 0x37, 0xa1, 0x1d, 0xb1, 0xb1,
+	0x4c, 0x7e, 0xce, 0x8b, 0x24, 0x02, 0xd1, 0xaa, 0x4a, 0xff, 0x1e, 0x54, 0x67, 0x1c, 0x63, 0x7e,
+	0x2e, 0x56, 0x23, 0xdc, 0xe7, 0xc5, 0x5b, 0x80, 0x12, 0x16, 0x98, 0xdd, 0x19, 0xf4, 0x3e, 0x59,
+	0x1a, 0xba, 0x47, 0xbf, 0x4c, 0xd3, 0x3d, 0xb8, 0x95, 0x36, 0xc2, 0xac, 0x0f, 0xa1, 0x27, 0xd2,
+	0xf0, 0x76, 0xd6, 0x83, 0x84, 0x17, 0xa5, 0x84, 0xf7, 0xe0, 0x56, 0x5a, 0x05, 0xd3, 0x3d, 0x02,
+	0x75, 0x8f, 0x7a, 0x11, 0xf5, 0x0a, 0x1c, 0x64, 0x2a, 0xff, 0x8b, 0x02, 0xdd, 0x0c, 0x25, 0x0c,
+	0x24, 0x5f, 0xfb, 0xf2, 0x9e, 0xc0, 0x9d, 0x84, 0xeb, 0x5c, 0xff, 0xba, 0x29, 0x98, 0xc2, 0xed,
+	0x6c, 0x35, 0x2c, 0x09, 0x52, 0x60, 0xca, 0x55, 0x81, 0xe1, 0xef, 0x40, 0x97, 0x95, 0x7c, 0xa4,
+	0x6a, 0x95, 0x2f, 0xf8, 0x33, 0xd8, 0x4a, 0x71, 0x33, 0x93, 0xe3, 0xf8, 0x1d, 0x2f, 0xcc, 0xe2,
+	0xc4, 0x6d, 0x90, 0x71, 0x60, 0xb1, 0x9b, 0x1e, 0x9b, 0xd0, 0x7e, 0xec, 0x50, 0xdd, 0xa3, 0xab,
+	0xd2, 0x71, 0x1f, 0x36, 0x22, 0x99, 0x67, 0x51, 0x62, 0x12, 0x54, 0x74, 0x17, 0x1a, 0xa6, 0xe5,
+	0x7a, 0xba, 0x35, 0xf3, 0x0f, 0xb8, 0x45, 0x22, 0x02, 0xfe, 0x05, 0x34, 0x03, 0x53, 0xcc, 0xff,
+	0x3e, 0x34, 0x83, 0xbd, 0xfd, 0xb1, 0xf0, 0xbf, 0x41, 0x64, 0x12, 0x7a, 0x1f, 0x3a, 0x9e, 0xa3,
+	0x5b, 0xae, 0xce, 0x2d, 0x4c, 0x1c, 0xc7, 0x76, 0x7c, 0xc3, 0x29, 0x3a, 0x8b, 0xe3, 0x05, 0x75,
+	0xcc, 0xf9, 0xe5, 0x97, 0x1f, 0xc7, 0x0f, 0xa0, 0x19, 0x98, 0x62, 0x71, 0x64, 0x79, 0xa9, 0xe4,
+	0x78, 0x79, 0x02, 0xcd, 0x43, 0xfd, 0xe2, 0x2b, 0xc8, 0xf5, 0x47, 0xd0, 0x10, 0x86, 0xd6, 0xf5,
+	0xf0, 0x2c, 0xb8, 0x8f, 0x6f, 0xc2, 0xc7, 0xc4, 0x11, 0x97, 0x52, 0x47, 0xcc, 0x72, 0x19, 0x98,
+	0x5b, 0xd7, 0xd3, 0x5f, 0x01, 0x4c, 0x75, 0xf7, 0xab, 0x71, 0xf3, 0x19, 0xd4, 0xb9, 0x2d, 0xe6,
+	0x63, 0x17, 0xaa, 0xf4, 0x8d, 0xe9, 0x7a, 0x2e, 0xb7, 0x55, 0x27, 0xfe, 0x6a, 0x2d, 0xb4, 0xfe,
+	0x46, 0x81, 0xe6, 0x13, 0xd3, 0x32, 0x6e, 0x08, 0x08, 0xaf, 0xcf, 0xa9, 0x73, 0xf9, 0x93, 0xc3,
+	0x9f, 0x3d, 0xe3, 0x2f, 0x70, 0x8b, 0x44, 0x04, 0xa4, 0x42, 0x8d, 0xbe, 0x59, 0x2e, 0x74, 0xd3,
+	0xe2, 0xb7, 0x67, 0x9d, 0x04, 0x4b, 0xfc, 0xeb, 0x12, 0x34, 0x84, 0x0f, 0x2c, 0xaa, 0x18, 0x9c,
+	0x94, 0x04, 0x9c, 0xd6, 0x89, 0x0d, 0x7d, 0x14, 0x59, 0x2c, 0xf1, 0xa7, 0xf1, 0x1b, 0xf2, 0x9d,
+	0x14, 0x5a, 0x1c, 0x4c, 0x04, 0x53, 0xe8, 0x90, 0xf6, 0x6f, 0x05, 0x6a, 0x3e, 0x91, 0x75, 0x1e,
+	0xfc, 0xb6, 0xf4, 0x4f, 0x5f, 0x2c, 0x58, 0x30, 0xee, 0x4c, 0xb7, 0x2c, 0x6a, 0x70, 0xeb, 0x25,
+	0x12, 0x2c, 0xd9, 0xce, 0x9c, 0x7a, 0xb3, 0x53, 0x6a, 0x70, 0xa3, 0x25, 0x12, 0x2c, 0xd9, 0xce,
+	0x99, 0x2e, 0x76, 0xca, 0x62, 0xc7, 0x5f, 0x22, 0x0d, 0xea, 0x0e, 0xf5, 0xce, 0x1d, 0xa6, 0xae,
+	0xc2, 0xb7, 0xc2, 0x35, 0x83, 0x84, 0x4b, 0x79, 0x96, 0x2f, 0x4c, 0x4f, 0x34, 0xbb, 0x0a, 0x91,
+	0x49, 0xec, 0x78, 0x5c, 0xdb, 0xf1, 0xa8, 0xb1, 0x6f, 0xfd, 0x94, 0x9e, 0xd9, 0x8e, 0x68, 0xcb,
+	0xeb, 0x24, 0x41, 0x65, 0x56, 0x8c, 0x73, 0x47, 0x67, 0xf9, 0x51, 0xeb, 0xc2, 0x4a, 0xb0, 0xc6,
+	0x67, 0xb0, 0xc9, 0xf2, 0x31, 0xba, 0xdc, 0x1f, 0xdf, 0x04, 0x12, 0xb6, 0x01, 0x22, 0xd0, 0xfa,
+	0xcd, 0x98, 0x44, 0xc1, 0x9f, 0x42, 0x3b, 0x32, 0xc7, 0x0e, 0x5d, 0x83, 0x7a, 0xb0, 0xed, 0x1b,
+	0x0c, 0xd7, 0x6b, 0xc1, 0xb9, 0x03, 0x1b, 0x63, 0xd3, 0x9d, 0xe9, 0x4e, 0x00, 0x68, 0xbc, 0x01,
+	0xad, 0x90, 0xc2, 0x7a, 0x90, 0x4f, 0xa0, 0x77, 0xe8, 0xe9, 0x8e, 0x77, 0x14, 0x89, 0xde, 0x40,
+	0xc4, 0xf8, 0x6f, 0x45, 0xe8, 0x12, 0xaa, 0x1b, 0x19, 0x6a, 0x5f, 0x42, 0xcf, 0xcd, 0xb6, 0xc8,
+	0x2d, 0x35, 0x77, 0xbf, 0x29, 0xc3, 0x32, 0xc7, 0xb9, 0x69, 0x81, 0xe4, 0x69, 0x41, 0x1f, 0x03,
+	0x9c, 0x86, 0xf7, 0x8f, 0xdf, 0x05, 0x76, 0x65, 0x9d, 0xd1, 0xed, 0x34, 0x2d, 0x10, 0x89, 0x17,
+	0x3d, 0x84, 0xe6, 0x3c, 0x2a, 0x7e, 0xbf, 0x4a, 0x7a, 0xe9, 0x2a, 0x09, 0x64, 0x65, 0x6e, 0xb4,
+	0x07, 0x9b, 0xf3, 0x38, 0x66, 0x38, 0xae, 0x13, 0x9f, 0x14, 0x09, 0x58, 0x4d, 0x0b, 0x24, 0x29,
+	0x35, 0xaa, 0x43, 0xd5, 0x5e, 0x72, 0x18, 0xfe, 0x43, 0x81, 0xad, 0x54, 0x16, 0x19, 0x3e, 0x76,
+	0xa1, 0x7e, 0xea, 0x5f, 0x7b, 0x7e, 0xd2, 0xb6, 0x52, 0x01, 0x2e, 0x17, 0x97, 0xd3, 0x02, 0x09,
+	0xf9, 0xd0, 0x87, 0xd0, 0x98, 0x07, 0x35, 0xee, 0x67, 0xe5, 0x56, 0xe6, 0x05, 0xc0, 0x3e, 0xfe,
+	0x43, 0x4e, 0x34, 0x84, 0xf6, 0x5c, 0xc6, 0xa6, 0x9f, 0x95, 0xdb, 0xd9, 0x41, 0x09, 0xf1, 0xb8,
+	0x84, 0x14, 0xd0, 0xef, 0x2b, 0xd0, 0xfb, 0xd4, 0x31, 0x3d, 0xfa, 0xff, 0xc0, 0xc5, 0x10, 0xda,
+	0x33, 0xb9, 0xa3, 0x52, 0x8b, 0xe9, 0x48, 0x62, 0x2d, 0x17, 0x8b, 0x24, 0x26, 0xc1, 0x54, 0x5c,
+	0xc8, 0xcd, 0x8c, 0x5a, 0x4f, 0xab, 0x88, 0x75, 0x3b, 0x4c, 0x45, 0x4c, 0x82, 0x61, 0xcc, 0x8d,
+	0x3a, 0x8d, 0x2c, 0x8c, 0x49, 0x8d, 0x08, 0xc3, 0x98, 0xc4, 0xcd, 0xec, 0x1b, 0x72, 0x13, 0xa0,
+	0x96, 0xd3, 0xf6, 0x63, 0x5d, 0x02, 0xb3, 0x1f, 0x93, 0x48, 0x54, 0x47, 0xe5, 0xfa, 0xd5, 0x51,
+	0xfd, 0x5f, 0xab, 0xa3, 0x76, 0x9d, 0xea, 0x40, 0x63, 0xd8, 0x30, 0x62, 0x57, 0x9a, 0xda, 0x48,
+	0x7f, 0x6e, 0xc7, 0x2f, 0xbd, 0x69, 0x81, 0x24, 0x64, 0x24, 0x48, 0xfe, 0xa7, 0x04, 0xb7, 0xd2,
+	0x90, 0x64, 0xc8, 0x7f, 0x08, 0xcd, 0x59, 0xd4, 0x16, 0xab, 0x4a, 0x3a, 0x5e, 0xa9, 0x6b, 0x66,
+	0xf1, 0x4a, 0xdc, 0x4c, 0xf8, 0x22, 0xea, 0x45, 0xd5, 0x5a, 0x5a, 0x58, 0x6a, 0x55, 0x99, 0xb0,
+	0xc4, 0xcd, 0x4a, 0xd5, 0x0d, 0x9a, 0xc4, 0xac, 0x52, 0x0d, 0x3b, 0x48, 0x3e, 0xe3, 0xd3, 0x2f,
+	0x22, 0x9b, 0x46, 0xd4, 0xb3, 0x65, 0x41, 0x4b, 0x6a, 0xe9, 0x98, 0x4d, 0x89, 0x3b, 0x76, 0xa5,
+	0x94, 0xaf, 0x73, 0xa5, 0x54, 0xae, 0x7f, 0xa5, 0x54, 0xd7, 0xbd, 0x52, 0xd0, 0x23, 0x68, 0x19,
+	0xd2, 0x33, 0xe6, 0xd7, 0xa1, 0x9a, 0x89, 0x01, 0xa1, 0x20, 0xc6, 0x2f, 0x9d, 0xff, 0x9f, 0x8a,
+	0xd0, 0x66, 0x9f, 0x71, 0x74, 0xe5, 0xbb, 0xf7, 0x43, 0xa8, 0xcd, 0xf9, 0x47, 0x6b, 0x30, 0x78,
+	0xec, 0x27, 0xe7, 0x44, 0xa1, 0xfc, 0x40, 0x7c, 0xdd, 0x92, 0x40, 0x40, 0xfb, 0xbb, 0x02, 0x55,
+	0x41, 0xcb, 0x78, 0x3e, 0x95, 0xb7, 0x68, 0x18, 0x8a, 0xc9, 0x86, 0x01, 0xfd, 0x08, 0xaa, 0x02,
+	0xa9, 0xfc, 0x90, 0x37, 0x76, 0xdf, 0xbb, 0xca, 0x9b, 0xc1, 0x50, 0x00, 0xdb, 0x17, 0xc3, 0x0f,
+	0xa0, 0x2a, 0x28, 0xa8, 0x06, 0xa5, 0xe1, 0xc1, 0x41, 0xa7, 0x80, 0x00, 0xaa, 0x8f, 0xc9, 0x64,
+	0x78, 0x34, 0xe9, 0x28, 0xa8, 0x0e, 0xe5, 0xc3, 0xe1, 0x8b, 0x49, 0xa7, 0xc8, 0xa8, 0xe3, 0xc9,
+	0xc1, 0xe4, 0x68, 0xd2, 0x29, 0xe1, 0x7f, 0x2a, 0xd0, 0x0c, 0x94, 0xb3, 0x43, 0xb8, 0xa9, 0x68,
+	0xbe, 0x9f, 0x88, 0x66, 0x3b, 0x2b, 0x1a, 0xd6, 0x99, 0xc6, 0x83, 0x88, 0x75, 0x49, 0xe5, 0x78,
+	0x97, 0x84, 0xdf, 0x0f, 0x03, 0x8c, 0xe2, 0x2a, 0x84, 0x71, 0x29, 0x52, 0x5c, 0xc5, 0xdd, 0x3f,
+	0xb4, 0xa1, 0x34, 0x7c, 0xbe, 0x8f, 0xa6, 0x50, 0x0f, 0x06, 0xe3, 0xe8, 0x4e, 0xe2, 0x7b, 0x5d,
+	0x1e, 0xc0, 0x6b, 0xb7, 0xb3, 0x37, 0x59, 0x3f, 0x55, 0xd8, 0x51, 0x3e, 0x50, 0xd0, 0x43, 0xa8,
+	0xf0, 0x19, 0x24, 0x8a, 0x21, 0x52, 0x1e, 0x90, 0x6b, 0xdd, 0x8c, 0x1d, 0xae, 0x00, 0x3d, 0x85,
+	0x76, 0x6c, 0x1a, 0x8d, 0xfa, 0x29, 0xd6, 0xc4, 0xa0, 0x7a, 0x85, 0xb2, 0x21, 0xd4, 0xfc, 0x29,
+	0x26, 0xd2, 0x32, 0x47, 0x9b, 0x42, 0x81, 0x9a, 0x37, 0xf6, 0xc4, 0x05, 0xb4, 0x07, 0x8d, 0x70,
+	0xfc, 0x88, 0xee, 0xe6, 0x4c, 0x35, 0x85, 0x9a, 0x15, 0x33, 0x4f, 0x5c, 0x40, 0x63, 0xa8, 0x07,
+	0x23, 0xc5, 0x78, 0x7e, 0x13, 0xf3, 0x48, 0xed, 0x76, 0xf6, 0xa6, 0xd0, 0x72, 0xc8, 0xd3, 0x13,
+	0xcd, 0x4f, 0x52, 0xe9, 0x49, 0x4d, 0xea, 0xb4, 0xed, 0x15, 0x1c, 0x42, 0xe9, 0x67, 0xd0, 0x49,
+	0xce, 0xff, 0x50, 0xac, 0xdf, 0xc8, 0x19, 0x41, 0x6a, 0xf7, 0x56, 0x33, 0x85, 0xda, 0x93, 0x13,
+	0xc0, 0xb8, 0xf6, 0x9c, 0x11, 0xa3, 0x76, 0x6f, 0x35, 0x93, 0xd0, 0xfe, 0x12, 0xde, 0x49, 0x0d,
+	0x94, 0xd0, 0xb7, 0xae, 0x98, 0x37, 0x09, 0xfd, 0x6f, 0x31, 0x95, 0xc2, 0x05, 0xf4, 0x0a, 0xb6,
+	0xb2, 0x06, 0x6c, 0xe8, 0xbd, 0x15, 0xd2, 0xf2, 0x24, 0x4f, 0x7b, 0xf7, 0x6a, 0x46, 0x66, 0xa9,
+	0xf4, 0xbb, 0xa2, 0x82, 0x7e, 0x0e, 0x9b, 0x89, 0xa9, 0x1a, 0xc2, 0x49, 0x70, 0xa6, 0x07, 0x74,
+	0x5a, 0x7f, 0x25, 0x8f, 0x88, 0xe3, 0x11, 0x54, 0xc5, 0x8b, 0x8d, 0xf2, 0x7b, 0x3e, 0x2d, 0xef,
+	0x81, 0x17, 0xf2, 0xe2, 0xd1, 0x46, 0xf9, 0x0d, 0x9f, 0x96, 0xf7, 0xc6, 0xe3, 0x02, 0xfa, 0x18,
+	0xca, 0xec, 0xe5, 0x46, 0x79, 0xdd, 0x9e, 0x96, 0xfd, 0xc8, 0x0b, 0xcb, 0xe2, 0xf4, 0x51, 0x7e,
+	0xab, 0xa7, 0xe5, 0xbd, 0xf4, 0xb8, 0x80, 0x3e, 0x84, 0xd2, 0x54, 0x77, 0x51, 0x4e, 0x9f, 0xa7,
+	0x65, 0xbe, 0xf4, 0xc2, 0x61, 0xf6, 0x0e, 0xa3, 0xbc, 0x26, 0x4f, 0xcb, 0x7e, 0xed, 0x45, 0xa9,
+	0x07, 0x2f, 0x38, 0x5a, 0xd5, 0xe1, 0x69, 0xf9, 0x8f, 0x3e, 0x2e, 0xa0, 0x5f, 0xc2, 0x66, 0xe2,
+	0xf3, 0x27, 0x8e, 0x85, 0xec, 0x2f, 0x4c, 0xad, 0xbf, 0x92, 0x27, 0xba, 0xa5, 0x3f, 0x87, 0x4e,
+	0xb2, 0xf3, 0x8b, 0x97, 0x65, 0xce, 0xa7, 0x8a, 0x76, 0x6f, 0x35, 0x53, 0x64, 0xe1, 0xc7, 0x50,
+	0x15, 0xef, 0x57, 0xfc, 0xdc, 0x62, 0x2f, 0xb4, 0xd6, 0xcb, 0xda, 0xe2, 0x3a, 0x3e, 0x50, 0x46,
+	0x03, 0xe8, 0x99, 0xf6, 0xc0, 0xa3, 0x6f, 0x3c, 0x73, 0x41, 0x03, 0xc6, 0x97, 0x27, 0xce, 0x72,
+	0x36, 0xaa, 0x1d, 0x89, 0xd5, 0x73, 0xe5, 0x8f, 0xc5, 0xda, 0xd1, 0x94, 0x4c, 0x86, 0xe3, 0xc3,
+	0xe3, 0x2a, 0xff, 0x07, 0xf9, 0xc1, 0x7f, 0x07, 0x00, 0x71, 0x94, 0xea, 0x43, 0x52, 0x1e, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
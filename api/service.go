@@ -7,9 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/alecthomas/jsonschema"
-	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/textileio/go-threads/api/pb"
@@ -19,13 +19,29 @@ import (
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/db"
 	kt "github.com/textileio/go-threads/db/keytransform"
-	"github.com/textileio/go-threads/util"
+	"github.com/textileio/go-threads/logger"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 var (
-	log = logging.Logger("threadsapi")
+	log = logger.Logger("threadsapi")
+
+	// readOnlyMethods are DB API methods which don't modify DBs.
+	readOnlyMethods = map[string]bool{
+		"GetToken":             true,
+		"ListDBs":              true,
+		"GetDBInfo":            true,
+		"GetCollectionInfo":    true,
+		"GetCollectionIndexes": true,
+		"ListCollections":      true,
+		"Verify":               true,
+		"Has":                  true,
+		"Find":                 true,
+		"FindByID":             true,
+		"ReadTransaction":      true,
+		"Listen":               true,
+	}
 )
 
 // Service is a gRPC DB API service backed by a DB manager.
@@ -36,6 +52,8 @@ type Service struct {
 // Config specifies service settings.
 type Config struct {
 	Debug bool
+	// EventStream receives change events of all dbs, if set. The stream is owned by the caller.
+	EventStream *db.EventStream
 }
 
 // NewService starts and returns a new service with the given network.
@@ -43,25 +61,37 @@ type Config struct {
 func NewService(store kt.TxnDatastoreExtended, network app.Net, conf Config) (*Service, error) {
 	var err error
 	if conf.Debug {
-		err = util.SetLogLevels(map[string]logging.LogLevel{
-			"threadsapi": logging.LevelDebug,
+		err = logger.SetLevels(map[string]logger.Level{
+			"threadsapi": logger.LevelDebug,
 		})
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	manager, err := db.NewManager(store, network, db.WithNewDebug(conf.Debug))
+	manager, err := db.NewManager(store, network, db.WithNewDebug(conf.Debug), db.WithNewEventStream(conf.EventStream))
 	if err != nil {
 		return nil, err
 	}
 	return &Service{manager: manager}, nil
 }
 
+// IsMutatingMethod returns whether or not a full gRPC method name
+// is a DB API method which modifies DBs.
+func IsMutatingMethod(fullMethod string) bool {
+	method := strings.TrimPrefix(fullMethod, "/threads.pb.API/")
+	return method != fullMethod && !readOnlyMethods[method]
+}
+
 func (s *Service) Close() error {
 	return s.manager.Close()
 }
 
+// Manager returns the DB manager backing the service.
+func (s *Service) Manager() *db.Manager {
+	return s.manager
+}
+
 // remoteIdentity implements core.thread.Identify.
 type remoteIdentity struct {
 	pk     thread.PubKey
@@ -979,8 +1009,25 @@ func (s *Service) processFindRequest(req *pb.FindRequest, token thread.Token, fi
 	if err := json.Unmarshal(req.QueryJSON, q); err != nil {
 		return &pb.FindReply{}, err
 	}
-	instances, err := findFunc(q, db.WithTxnToken(token))
-	return &pb.FindReply{Instances: instances}, err
+	if !req.Explain {
+		instances, err := findFunc(q, db.WithTxnToken(token))
+		return &pb.FindReply{Instances: instances}, err
+	}
+	var explain db.Explain
+	instances, err := findFunc(q, db.WithTxnToken(token), db.WithExplain(&explain))
+	return &pb.FindReply{
+		Instances: instances,
+		Explain: &pb.FindReply_Explain{
+			Index:          explain.Index,
+			Scanned:        int64(explain.Scanned),
+			Fetched:        int64(explain.Fetched),
+			Matched:        int64(explain.Matched),
+			Returned:       int64(explain.Returned),
+			Selectivity:    explain.Selectivity(),
+			SortedInMemory: explain.SortedInMemory,
+			Duration:       int64(explain.Duration),
+		},
+	}, err
 }
 
 func (s *Service) getDB(ctx context.Context, id thread.ID, token thread.Token) (*db.DB, error) {
@@ -0,0 +1,290 @@
+// Package audit provides an append-only local log of API calls and accepted remote pushes.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	currentFileName = "audit.log"
+	rotatedPrefix   = "audit-"
+	rotatedSuffix   = ".log"
+)
+
+var (
+	// DefaultMaxSize is the default size of the current audit file which triggers rotation.
+	DefaultMaxSize int64 = 1 << 26
+
+	// DefaultMaxFiles is the default number of rotated audit files to keep.
+	DefaultMaxFiles = 10
+)
+
+// Kind is the kind of an audited operation.
+type Kind string
+
+const (
+	// KindAPI is a mutating API call.
+	KindAPI Kind = "api"
+	// KindPush is a push received from a remote peer.
+	KindPush Kind = "push"
+)
+
+// Outcome is the result of an audited operation.
+type Outcome string
+
+const (
+	// OutcomeOK indicates the operation succeeded.
+	OutcomeOK Outcome = "ok"
+	// OutcomeError indicates the operation failed.
+	OutcomeError Outcome = "error"
+)
+
+// Entry is a single audit log entry.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Kind      Kind      `json:"kind"`
+	Operation string    `json:"operation"`
+	Peer      string    `json:"peer,omitempty"`
+	APIKey    string    `json:"apiKey,omitempty"`
+	ThreadID  string    `json:"threadID,omitempty"`
+	LogID     string    `json:"logID,omitempty"`
+	RecordID  string    `json:"recordID,omitempty"`
+	Outcome   Outcome   `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Config specifies audit log settings.
+type Config struct {
+	// Dir is the directory holding the audit files.
+	Dir string
+	// MaxSize is the size of the current audit file which triggers rotation.
+	MaxSize int64
+	// MaxFiles is the number of rotated audit files to keep, older files are removed.
+	MaxFiles int
+}
+
+// Log is an append-only audit log of JSON entries, one per line.
+// The current file is rotated once it reaches the configured size.
+type Log struct {
+	lock sync.Mutex
+	conf Config
+	f    *os.File
+	size int64
+}
+
+// New opens the audit log in conf.Dir, creating it if needed.
+func New(conf Config) (*Log, error) {
+	if conf.MaxSize <= 0 {
+		conf.MaxSize = DefaultMaxSize
+	}
+	if conf.MaxFiles <= 0 {
+		conf.MaxFiles = DefaultMaxFiles
+	}
+	if err := os.MkdirAll(conf.Dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	l := &Log{conf: conf}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record appends an entry to the log. The entry time is set if missing.
+// A nil log discards entries, so callers don't need to check if auditing is enabled.
+func (l *Log) Record(e Entry) error {
+	if l == nil {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.f == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+	if l.size > 0 && l.size+int64(len(b)) > l.conf.MaxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(b)
+	l.size += int64(n)
+	return err
+}
+
+// Export writes the entries recorded in [since, until) to w as JSON lines, oldest first.
+// Zero times leave the range open.
+func (l *Log) Export(w io.Writer, since, until time.Time) error {
+	return l.Walk(since, until, func(e Entry) error {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(b, '\n'))
+		return err
+	})
+}
+
+// Walk calls fn for each entry recorded in [since, until), oldest first.
+// Zero times leave the range open. Entries recorded while walking aren't visited.
+func (l *Log) Walk(since, until time.Time, fn func(Entry) error) error {
+	segments, size, err := l.segments()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range segments {
+			_ = f.Close()
+		}
+	}()
+	// fn may be slow, e.g. streaming to a client, so files are read without
+	// the lock: rotated files are immutable and the current one is read up to
+	// its size when walking started
+	for i, f := range segments {
+		var r io.Reader = f
+		if i == len(segments)-1 {
+			r = io.LimitReader(f, size)
+		}
+		if err := walkSegment(f.Name(), r, since, until, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the current audit file.
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+func (l *Log) open() error {
+	f, err := os.OpenFile(filepath.Join(l.conf.Dir, currentFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	l.f = f
+	l.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file aside, opens a new one, and removes
+// rotated files over the limit.
+func (l *Log) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	rotated := fmt.Sprintf("%s%020d%s", rotatedPrefix, time.Now().UnixNano(), rotatedSuffix)
+	if err := os.Rename(
+		filepath.Join(l.conf.Dir, currentFileName),
+		filepath.Join(l.conf.Dir, rotated),
+	); err != nil {
+		return err
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	files, err := l.rotatedFiles()
+	if err != nil {
+		return err
+	}
+	for len(files) > l.conf.MaxFiles {
+		if err := os.Remove(filepath.Join(l.conf.Dir, files[0])); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// rotatedFiles returns the names of rotated files, oldest first.
+func (l *Log) rotatedFiles() ([]string, error) {
+	infos, err := ioutil.ReadDir(l.conf.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, info := range infos {
+		name := info.Name()
+		if strings.HasPrefix(name, rotatedPrefix) && strings.HasSuffix(name, rotatedSuffix) {
+			files = append(files, name)
+		}
+	}
+	// names embed zero-padded rotation times
+	sort.Strings(files)
+	return files, nil
+}
+
+// segments opens the rotated files and the current one, oldest first, and returns the size
+// of the current file. Open files stay readable if they're rotated or removed meanwhile.
+func (l *Log) segments() ([]*os.File, int64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	files, err := l.rotatedFiles()
+	if err != nil {
+		return nil, 0, err
+	}
+	files = append(files, currentFileName)
+	segments := make([]*os.File, 0, len(files))
+	for _, name := range files {
+		f, err := os.Open(filepath.Join(l.conf.Dir, name))
+		if err != nil {
+			for _, f := range segments {
+				_ = f.Close()
+			}
+			return nil, 0, err
+		}
+		segments = append(segments, f)
+	}
+	return segments, l.size, nil
+}
+
+func walkSegment(path string, r io.Reader, since, until time.Time, fn func(Entry) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return fmt.Errorf("decoding audit entry in %s: %w", path, err)
+		}
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !e.Time.Before(until) {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
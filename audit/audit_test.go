@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLog_Export(t *testing.T) {
+	t.Parallel()
+	l, dir := setup(t, Config{})
+	defer os.RemoveAll(dir)
+	defer l.Close()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Record(Entry{
+			Time:      start.Add(time.Duration(i) * time.Second),
+			Kind:      KindPush,
+			Operation: "PushRecord",
+			RecordID:  fmt.Sprint(i),
+			Outcome:   OutcomeOK,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := l.Export(&buf, start.Add(time.Second), start.Add(2*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	var e Entry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("expected a single exported entry: %v", err)
+	}
+	if e.RecordID != "1" {
+		t.Fatalf("expected entry 1 to be exported, got %s", e.RecordID)
+	}
+}
+
+func TestLog_Rotate(t *testing.T) {
+	t.Parallel()
+	l, dir := setup(t, Config{MaxSize: 200, MaxFiles: 2})
+	defer os.RemoveAll(dir)
+	defer l.Close()
+
+	const total = 20
+	for i := 0; i < total; i++ {
+		if err := l.Record(Entry{
+			Kind:      KindAPI,
+			Operation: "/threads.net.pb.API/CreateThread",
+			ThreadID:  fmt.Sprint(i),
+			Outcome:   OutcomeOK,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := l.rotatedFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 rotated files, got %d", len(files))
+	}
+
+	var ids []string
+	if err := l.Walk(time.Time{}, time.Time{}, func(e Entry) error {
+		ids = append(ids, e.ThreadID)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) == 0 || len(ids) == total {
+		t.Fatalf("expected old entries to be pruned, got %d entries", len(ids))
+	}
+	if ids[len(ids)-1] != fmt.Sprint(total-1) {
+		t.Fatalf("expected last entry to be kept, got %s", ids[len(ids)-1])
+	}
+	for i := 1; i < len(ids); i++ {
+		prev, _ := strconv.Atoi(ids[i-1])
+		next, _ := strconv.Atoi(ids[i])
+		if next != prev+1 {
+			t.Fatalf("expected contiguous entries in order, got %v", ids)
+		}
+	}
+
+	// reopening appends to the current file
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	l, err = New(Config{Dir: dir, MaxSize: 200, MaxFiles: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Record(Entry{Kind: KindAPI, ThreadID: "last", Outcome: OutcomeOK}); err != nil {
+		t.Fatal(err)
+	}
+	var last string
+	if err := l.Walk(time.Time{}, time.Time{}, func(e Entry) error {
+		last = e.ThreadID
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if last != "last" {
+		t.Fatalf("expected reopened log to append, got last entry %s", last)
+	}
+}
+
+func TestLog_WalkUnlocked(t *testing.T) {
+	t.Parallel()
+	l, dir := setup(t, Config{MaxSize: 200, MaxFiles: 2})
+	defer os.RemoveAll(dir)
+	defer l.Close()
+
+	const total = 3
+	for i := 0; i < total; i++ {
+		if err := l.Record(Entry{Kind: KindAPI, ThreadID: fmt.Sprint(i), Outcome: OutcomeOK}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// entries recorded by the callback, rotating the current file, aren't visited
+	var ids []string
+	if err := l.Walk(time.Time{}, time.Time{}, func(e Entry) error {
+		ids = append(ids, e.ThreadID)
+		for i := 0; i < total; i++ {
+			if err := l.Record(Entry{Kind: KindAPI, ThreadID: "new", Outcome: OutcomeOK}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != total || ids[0] != "0" || ids[total-1] != fmt.Sprint(total-1) {
+		t.Fatalf("expected entries recorded before walking, got %v", ids)
+	}
+}
+
+func TestLog_Nil(t *testing.T) {
+	t.Parallel()
+	var l *Log
+	if err := l.Record(Entry{Kind: KindAPI}); err != nil {
+		t.Fatalf("expected nil log to discard entries, got %v", err)
+	}
+}
+
+func setup(t *testing.T, conf Config) (*Log, string) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.Dir = dir
+	l, err := New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, dir
+}
@@ -2,17 +2,24 @@ package cbor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/ipfs/go-ipld-format"
 	mh "github.com/multiformats/go-multihash"
 	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/crypto"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
 )
 
+// ErrBodyKeySealed indicates the event body key is sealed with a read key which isn't available,
+// e.g. the header was read with an index key.
+var ErrBodyKeySealed = errors.New("event body key is sealed with the read key")
+
 func init() {
 	cbornode.RegisterCborType(event{})
 	cbornode.RegisterCborType(eventHeader{})
@@ -25,12 +32,57 @@ type event struct {
 }
 
 // eventHeader defines the node structure of an event header.
+// Headers encrypted with an index key carry the body key sealed with the read key.
 type eventHeader struct {
-	Key []byte `refmt:",omitempty"`
+	Key       []byte `refmt:",omitempty"`
+	SealedKey []byte `refmt:",omitempty"`
+	Epoch     uint64 `refmt:",omitempty"`
+	Time      int64  `refmt:",omitempty"`
+	Type      string `refmt:",omitempty"`
+}
+
+// EventHeaderConfig holds the optional fields of a new event header.
+type EventHeaderConfig struct {
+	// Epoch is the write epoch of the log the event is added to.
+	Epoch uint64
+	// Time of the event creation, current time is used if it's zero.
+	Time time.Time
+	// Type is a hint of the event body type.
+	Type string
+	// IndexKey encrypts the header in place of the read key if set, and the body key is
+	// sealed with the read key, so index key holders can read the header but not the body.
+	IndexKey crypto.EncryptionKey
 }
 
 // CreateEvent create a new event by wrapping the body node.
 func CreateEvent(ctx context.Context, dag format.DAGService, body format.Node, rkey crypto.EncryptionKey) (net.Event, error) {
+	return CreateEventWithHeader(ctx, dag, body, rkey, EventHeaderConfig{})
+}
+
+// CreateEventWithEpoch creates a new event by wrapping the body node. The write epoch
+// of the log the event is added to is recorded in the event header.
+func CreateEventWithEpoch(
+	ctx context.Context,
+	dag format.DAGService,
+	body format.Node,
+	rkey crypto.EncryptionKey,
+	epoch uint64,
+) (net.Event, error) {
+	return CreateEventWithHeader(ctx, dag, body, rkey, EventHeaderConfig{Epoch: epoch})
+}
+
+// CreateEventWithHeader creates a new event by wrapping the body node, with the
+// optional header fields of config.
+func CreateEventWithHeader(
+	ctx context.Context,
+	dag format.DAGService,
+	body format.Node,
+	rkey crypto.EncryptionKey,
+	config EventHeaderConfig,
+) (net.Event, error) {
+	if config.Time.IsZero() {
+		config.Time = time.Now()
+	}
 	key, err := sym.NewRandom()
 	if err != nil {
 		return nil, err
@@ -44,13 +96,24 @@ func CreateEvent(ctx context.Context, dag format.DAGService, body format.Node, r
 		return nil, err
 	}
 	eventHeader := &eventHeader{
-		Key: keyb,
+		Epoch: config.Epoch,
+		Time:  config.Time.UnixNano(),
+		Type:  config.Type,
+	}
+	hkey := rkey
+	if config.IndexKey != nil {
+		if eventHeader.SealedKey, err = rkey.Encrypt(keyb); err != nil {
+			return nil, err
+		}
+		hkey = config.IndexKey
+	} else {
+		eventHeader.Key = keyb
 	}
 	header, err := cbornode.WrapObject(eventHeader, mh.SHA2_256, -1)
 	if err != nil {
 		return nil, err
 	}
-	codedHeader, err := EncodeBlock(header, rkey)
+	codedHeader, err := EncodeBlock(header, hkey)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +132,15 @@ func CreateEvent(ctx context.Context, dag format.DAGService, body format.Node, r
 		}
 	}
 
+	// the cached header is the one seen by read key holders
+	cached := *eventHeader
+	cached.Key = keyb
 	return &Event{
 		Node: node,
 		obj:  obj,
 		header: &EventHeader{
 			Node: codedHeader,
-			obj:  eventHeader,
+			obj:  &cached,
 		},
 		body: codedBody,
 	}, nil
@@ -152,11 +218,25 @@ func (e *Event) GetHeader(ctx context.Context, dag format.DAGService, key crypto
 	if key != nil {
 		node, err := DecodeBlock(e.header, key)
 		if err != nil {
-			return nil, err
+			// headers of indexable events are encrypted with the index key of the read key
+			rk, ok := key.(*sym.Key)
+			if !ok {
+				return nil, err
+			}
+			var ierr error
+			if node, ierr = DecodeBlock(e.header, thread.DeriveIndexKey(rk)); ierr != nil {
+				return nil, err
+			}
 		}
 		if err = cbornode.DecodeInto(node.RawData(), header); err != nil {
 			return nil, err
 		}
+		if len(header.Key) == 0 && len(header.SealedKey) != 0 {
+			// the body key is left sealed if the key is an index key
+			if keyb, err := key.Decrypt(header.SealedKey); err == nil {
+				header.Key = keyb
+			}
+		}
 		e.header.obj = header
 	}
 	return e.header, nil
@@ -205,5 +285,32 @@ func (h *EventHeader) Key() (crypto.DecryptionKey, error) {
 	if h.obj == nil {
 		return nil, fmt.Errorf("obj not loaded")
 	}
+	if len(h.obj.Key) == 0 && len(h.obj.SealedKey) != 0 {
+		return nil, ErrBodyKeySealed
+	}
 	return crypto.DecryptionKeyFromBytes(h.obj.Key)
 }
+
+func (h *EventHeader) Epoch() (uint64, error) {
+	if h.obj == nil {
+		return 0, fmt.Errorf("obj not loaded")
+	}
+	return h.obj.Epoch, nil
+}
+
+func (h *EventHeader) Time() (time.Time, error) {
+	if h.obj == nil {
+		return time.Time{}, fmt.Errorf("obj not loaded")
+	}
+	if h.obj.Time == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(0, h.obj.Time), nil
+}
+
+func (h *EventHeader) Type() (string, error) {
+	if h.obj == nil {
+		return "", fmt.Errorf("obj not loaded")
+	}
+	return h.obj.Type, nil
+}
@@ -0,0 +1,72 @@
+package cbor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+)
+
+// FallbackFetchTimeout is the max time duration to wait for a missing block from the fallback fetcher.
+var FallbackFetchTimeout = time.Second * 5
+
+// FallbackDAG is a DAG service resolving nodes missing from the local DAG using
+// a fallback node getter, e.g. a bitswap session reaching any connected peer.
+// Successfully fetched nodes are added to the local DAG, so the next access is local.
+type FallbackDAG struct {
+	format.DAGService
+	fallback format.NodeGetter
+}
+
+var _ format.DAGService = (*FallbackDAG)(nil)
+
+// NewFallbackDAG returns a DAG service which falls back to fetching missing nodes with fallback.
+func NewFallbackDAG(local format.DAGService, fallback format.NodeGetter) *FallbackDAG {
+	return &FallbackDAG{DAGService: local, fallback: fallback}
+}
+
+// Get returns a node from the local DAG or from the fallback getter if it's missing locally.
+func (d *FallbackDAG) Get(ctx context.Context, id cid.Cid) (format.Node, error) {
+	node, err := d.DAGService.Get(ctx, id)
+	if err == nil || !errors.Is(err, format.ErrNotFound) {
+		return node, err
+	}
+	fctx, cancel := context.WithTimeout(ctx, FallbackFetchTimeout)
+	defer cancel()
+	node, err = d.fallback.Get(fctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.DAGService.Add(ctx, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// GetMany returns a channel of nodes resolved with Get.
+func (d *FallbackDAG) GetMany(ctx context.Context, ids []cid.Cid) <-chan *format.NodeOption {
+	out := make(chan *format.NodeOption, len(ids))
+	go func() {
+		defer close(out)
+		for _, id := range ids {
+			node, err := d.Get(ctx, id)
+			select {
+			case out <- &format.NodeOption{Node: node, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Close closes the local DAG if it's closable.
+func (d *FallbackDAG) Close() error {
+	if c, ok := d.DAGService.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package cbor
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/textileio/go-threads/core/thread"
 
@@ -21,21 +23,27 @@ func init() {
 	cbornode.RegisterCborType(record{})
 }
 
-// record defines the node structure of a record.
+// record defines the node structure of a record. Skips were added to the format later,
+// records without skip links are encoded and signed as before, so all versions verify them.
+// Versions without skip links fail to verify records with skip links, see net.WithSkipLinks.
 type record struct {
 	Block  cid.Cid
 	Sig    []byte
 	PubKey []byte
-	Prev   cid.Cid `refmt:",omitempty"`
+	Prev   cid.Cid           `refmt:",omitempty"`
+	Skips  []cid.Cid         `refmt:",omitempty"`
+	Exts   map[string][]byte `refmt:",omitempty"`
 }
 
 // CreateRecordConfig wraps all the elements needed for creating a new record.
 type CreateRecordConfig struct {
 	Block      format.Node
 	Prev       cid.Cid
+	Skips      []cid.Cid
 	Key        ic.PrivKey
 	PubKey     thread.PubKey
 	ServiceKey crypto.EncryptionKey
+	Extensions net.RecordExtensions
 }
 
 // CreateRecord returns a new record from the given block and log private key.
@@ -44,13 +52,7 @@ func CreateRecord(ctx context.Context, dag format.DAGService, config CreateRecor
 	if err != nil {
 		return nil, err
 	}
-	var payload []byte
-	if config.Prev.Defined() {
-		payload = append(config.Block.Cid().Bytes(), config.Prev.Bytes()...)
-	} else {
-		payload = pkb
-	}
-	sig, err := config.Key.Sign(payload)
+	sig, err := config.Key.Sign(signedPayload(config.Block.Cid(), config.Prev, config.Skips, pkb, config.Extensions))
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +61,10 @@ func CreateRecord(ctx context.Context, dag format.DAGService, config CreateRecor
 		Sig:    sig,
 		PubKey: pkb,
 		Prev:   config.Prev,
+		Skips:  config.Skips,
+	}
+	if len(config.Extensions) > 0 {
+		obj.Exts = config.Extensions
 	}
 	node, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
 	if err != nil {
@@ -113,8 +119,54 @@ func RemoveRecord(ctx context.Context, dag format.DAGService, rec net.Record) er
 }
 
 // RecordToProto returns a proto version of a record for transport.
-// Nodes are sent encrypted.
+// Nodes are sent encrypted. Linked blocks are resolved with the given dag,
+// use a FallbackDAG to fetch the ones missing locally from remote peers.
+// Records are immutable, so the proto is built once per record and shared
+// by later calls; callers must not modify it. Records whose body was redacted
+// must be stripped with RedactRecord first.
 func RecordToProto(ctx context.Context, dag format.DAGService, rec net.Record) (*pb.Log_Record, error) {
+	r, cached := rec.(*Record)
+	if cached {
+		if prec, ok := r.proto.Load().(*pb.Log_Record); ok {
+			return prec, nil
+		}
+	}
+	withBody := !cached || atomic.LoadUint32(&r.redacted) == 0
+	prec, err := recordToProto(ctx, dag, rec, withBody)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		r.proto.Store(prec)
+	}
+	return prec, nil
+}
+
+// RedactRecord drops the event body of a record kept in memory, i.e. the body node of its
+// proto version and of its resolved event, so it's no longer sent once it's redacted.
+func RedactRecord(rec net.Record) {
+	r, ok := rec.(*Record)
+	if !ok {
+		return
+	}
+	atomic.StoreUint32(&r.redacted, 1)
+	if prec, ok := r.proto.Load().(*pb.Log_Record); ok && len(prec.BodyNode) != 0 {
+		redacted := *prec
+		redacted.BodyNode = nil
+		r.proto.Store(&redacted)
+	}
+	if event, ok := r.block.(*Event); ok && event.body != nil {
+		r.block = &Event{Node: event.Node, obj: event.obj, header: event.header}
+	}
+}
+
+// RedactedRecordToProto returns a proto version of a record whose event body was redacted,
+// i.e. without the body node. The record and event header are sent as with RecordToProto.
+func RedactedRecordToProto(ctx context.Context, dag format.DAGService, rec net.Record) (*pb.Log_Record, error) {
+	return recordToProto(ctx, dag, rec, false)
+}
+
+func recordToProto(ctx context.Context, dag format.DAGService, rec net.Record, withBody bool) (*pb.Log_Record, error) {
 	block, err := rec.GetBlock(ctx, dag)
 	if err != nil {
 		return nil, err
@@ -130,20 +182,26 @@ func RecordToProto(ctx context.Context, dag format.DAGService, rec net.Record) (
 	if err != nil {
 		return nil, err
 	}
-	body, err := event.GetBody(ctx, dag, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return &pb.Log_Record{
+	prec := &pb.Log_Record{
 		RecordNode: rec.RawData(),
 		EventNode:  block.RawData(),
 		HeaderNode: header.RawData(),
-		BodyNode:   body.RawData(),
-	}, nil
+	}
+	if withBody {
+		body, err := event.GetBody(ctx, dag, nil)
+		if err != nil {
+			return nil, err
+		}
+		prec.BodyNode = body.RawData()
+	}
+	return prec, nil
 }
 
 // RecordFromProto returns a node from a serialized version that contains link data.
+// The record keeps rec as its proto version, so relaying it doesn't re-serialize nodes.
+// A proto without event, header and body nodes results in a header record, see IsHeader.
+// A proto without the body node only, see RedactedRecordToProto, leaves the body to be
+// resolved with a dag.
 func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (net.Record, error) {
 	if key == nil {
 		return nil, fmt.Errorf("decryption key is required")
@@ -153,6 +211,9 @@ func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (net.Record,
 	if err != nil {
 		return nil, err
 	}
+	if len(rec.EventNode) == 0 && len(rec.HeaderNode) == 0 && len(rec.BodyNode) == 0 {
+		return RecordFromNode(rnode, key)
+	}
 	enode, err := cbornode.Decode(rec.EventNode, mh.SHA2_256, -1)
 	if err != nil {
 		return nil, err
@@ -161,9 +222,11 @@ func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (net.Record,
 	if err != nil {
 		return nil, err
 	}
-	body, err := cbornode.Decode(rec.BodyNode, mh.SHA2_256, -1)
-	if err != nil {
-		return nil, err
+	var body format.Node
+	if len(rec.BodyNode) != 0 {
+		if body, err = cbornode.Decode(rec.BodyNode, mh.SHA2_256, -1); err != nil {
+			return nil, err
+		}
 	}
 
 	decoded, err := DecodeBlock(rnode, key)
@@ -187,19 +250,23 @@ func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (net.Record,
 		},
 		body: body,
 	}
-	return &Record{
+	r := &Record{
 		Node:  rnode,
 		obj:   robj,
 		block: event,
-	}, nil
+	}
+	r.proto.Store(rec)
+	return r, nil
 }
 
 // Record is an IPLD node representing a record.
 type Record struct {
 	format.Node
 
-	obj   *record
-	block format.Node
+	obj      *record
+	block    format.Node
+	proto    atomic.Value // *pb.Log_Record
+	redacted uint32
 }
 
 func (r *Record) BlockID() cid.Cid {
@@ -223,6 +290,10 @@ func (r *Record) PrevID() cid.Cid {
 	return r.obj.Prev
 }
 
+func (r *Record) SkipIDs() []cid.Cid {
+	return r.obj.Skips
+}
+
 func (r *Record) Sig() []byte {
 	return r.obj.Sig
 }
@@ -231,19 +302,128 @@ func (r *Record) PubKey() []byte {
 	return r.obj.PubKey
 }
 
+func (r *Record) Extensions() net.RecordExtensions {
+	return r.obj.Exts
+}
+
 func (r *Record) Verify(key ic.PubKey) error {
 	if r.block == nil {
 		return fmt.Errorf("block not loaded")
 	}
-	var payload []byte
-	if r.PrevID().Defined() {
-		payload = append(r.block.Cid().Bytes(), r.PrevID().Bytes()...)
-	} else {
-		payload = r.PubKey()
-	}
+	return r.verifyLinks(key, r.block.Cid())
+}
+
+// verifyLinks checks the signature of the record links against the given block id.
+func (r *Record) verifyLinks(key ic.PubKey, block cid.Cid) error {
+	payload := signedPayload(block, r.PrevID(), r.SkipIDs(), r.PubKey(), r.obj.Exts)
 	ok, err := key.Verify(payload, r.Sig())
 	if !ok || err != nil {
 		return fmt.Errorf("bad signature")
 	}
 	return nil
 }
+
+// signedPayload returns the bytes signed by the log key. Skip links and extensions are only
+// appended if present, so records without them keep their original payload.
+func signedPayload(block, prev cid.Cid, skips []cid.Cid, pubKey []byte, exts net.RecordExtensions) []byte {
+	var payload []byte
+	if !prev.Defined() {
+		payload = append(payload, pubKey...)
+	} else {
+		payload = append(block.Bytes(), prev.Bytes()...)
+		for _, s := range skips {
+			payload = append(payload, s.Bytes()...)
+		}
+	}
+	if len(exts) == 0 {
+		return payload
+	}
+	// a zero byte can't start a cid, so extensions don't collide with links
+	payload = append(payload, 0)
+	for _, k := range exts.Keys() {
+		payload = appendLengthPrefixed(payload, []byte(k))
+		payload = appendLengthPrefixed(payload, exts[k])
+	}
+	return payload
+}
+
+func appendLengthPrefixed(dst, b []byte) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(b)))
+	return append(append(dst, buf[:n]...), b...)
+}
+
+// SkipLinkCount returns the number of skip links of a record at position pos,
+// counting from 1. Link i points to the record at pos-2^(i+1).
+func SkipLinkCount(pos int64) int {
+	var count int
+	for k := uint(1); pos%(1<<k) == 0 && pos-(1<<k) >= 1; k++ {
+		count++
+	}
+	return count
+}
+
+// NextAncestor returns the link of a record at position pos which leads farthest
+// towards the record at position target without passing it, and the position the
+// link points to. Skip links are used where present, falling back to prev.
+func NextAncestor(rec net.Record, pos, target int64) (cid.Cid, int64) {
+	skips := rec.SkipIDs()
+	for k := len(skips); k > 0; k-- {
+		if next := pos - (1 << uint(k)); next >= target {
+			return skips[k-1], next
+		}
+	}
+	return rec.PrevID(), pos - 1
+}
+
+// IsHeader returns true if the record was decoded without its event, e.g.,
+// from a headers-only reply. Its event is loaded from a DAG on demand.
+func IsHeader(rec net.Record) bool {
+	r, ok := rec.(*Record)
+	return ok && r.block == nil
+}
+
+// VerifyHeader checks the signature of the record links against the log key,
+// so it doesn't require the record event. The event is later checked by its cid.
+func VerifyHeader(rec net.Record, key ic.PubKey) error {
+	if r, ok := rec.(*Record); ok {
+		return r.verifyLinks(key, r.BlockID())
+	}
+	return rec.Verify(key)
+}
+
+// VerifyAncestryProof checks that a proof leads from its head to the record target,
+// and that the links of the proof records are signed with the log key.
+// The inner blocks of proof records are not required.
+func VerifyAncestryProof(proof net.AncestryProof, target cid.Cid, key ic.PubKey) error {
+	if len(proof.Records) == 0 {
+		return fmt.Errorf("empty proof")
+	}
+	if proof.Position < 1 || proof.Position > proof.Head.Counter {
+		return fmt.Errorf("position %d is out of log bounds", proof.Position)
+	}
+	if !proof.Records[0].Cid().Equals(proof.Head.ID) {
+		return fmt.Errorf("proof doesn't start at the log head")
+	}
+	pos := proof.Head.Counter
+	for i, rec := range proof.Records {
+		if err := VerifyHeader(rec, key); err != nil {
+			return fmt.Errorf("record %s: %w", rec.Cid(), err)
+		}
+		if pos == proof.Position {
+			if i != len(proof.Records)-1 || !rec.Cid().Equals(target) {
+				return fmt.Errorf("proof doesn't end at record %s", target)
+			}
+			return nil
+		}
+		if i == len(proof.Records)-1 {
+			break
+		}
+		var next cid.Cid
+		next, pos = NextAncestor(rec, pos, proof.Position)
+		if !next.Equals(proof.Records[i+1].Cid()) {
+			return fmt.Errorf("record %s isn't linked from record %s", proof.Records[i+1].Cid(), rec.Cid())
+		}
+	}
+	return fmt.Errorf("proof doesn't reach position %d", proof.Position)
+}
@@ -0,0 +1,87 @@
+package cbor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipfs/go-ipld-format"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/crypto"
+)
+
+// MatchSelector returns whether the record matches the selector. The target node of the
+// selector is decrypted with key, and the field at its path is compared with the selector
+// value by their DAG-CBOR encoding. Records without the field don't match.
+func MatchSelector(
+	ctx context.Context,
+	dag format.DAGService,
+	rec net.Record,
+	sel net.Selector,
+	key crypto.DecryptionKey,
+) (bool, error) {
+	want, err := cbornode.DumpObject(sel.Value)
+	if err != nil {
+		return false, fmt.Errorf("encoding selector value: %w", err)
+	}
+	node, err := selectorTarget(ctx, dag, rec, sel.Target, key)
+	if err != nil {
+		return false, err
+	}
+	var path []string
+	if p := strings.Trim(sel.Path, "/"); p != "" {
+		path = strings.Split(p, "/")
+	}
+	if len(path) == 0 {
+		return bytes.Equal(node.RawData(), want), nil
+	}
+	val, rest, err := node.Resolve(path)
+	if err != nil || len(rest) != 0 {
+		return false, nil
+	}
+	if link, ok := val.(*format.Link); ok {
+		val = link.Cid
+	}
+	got, err := cbornode.DumpObject(val)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(got, want), nil
+}
+
+// selectorTarget returns the decrypted event header or body node of a record.
+func selectorTarget(
+	ctx context.Context,
+	dag format.DAGService,
+	rec net.Record,
+	target net.SelectorTarget,
+	key crypto.DecryptionKey,
+) (format.Node, error) {
+	if key == nil {
+		return nil, errors.New("a key is required to evaluate selectors")
+	}
+	event, err := EventFromRecord(ctx, dag, rec)
+	if err != nil {
+		return nil, err
+	}
+	switch target {
+	case net.SelectHeader:
+		h, err := event.GetHeader(ctx, dag, key)
+		if err != nil {
+			return nil, err
+		}
+		header, ok := h.(*EventHeader)
+		if !ok || header.obj == nil {
+			return nil, errors.New("event header isn't decrypted")
+		}
+		return cbornode.WrapObject(header.obj, mh.SHA2_256, -1)
+	case net.SelectBody:
+		return event.GetBody(ctx, dag, key)
+	default:
+		return nil, fmt.Errorf("unknown selector target %d", target)
+	}
+}
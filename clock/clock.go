@@ -0,0 +1,73 @@
+// Package clock abstracts time for timers and caches, so that they could run in virtual time
+// in tests and simulations instead of waiting on the wall clock.
+package clock
+
+import (
+	"time"
+)
+
+// Clock tells the time and creates timers.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) *Timer
+	// NewTicker returns a ticker sending the time every period. It panics if d isn't positive.
+	NewTicker(d time.Duration) *Ticker
+}
+
+// Timer is a single event, like time.Timer.
+type Timer struct {
+	C     <-chan time.Time
+	stop  func() bool
+	reset func(time.Duration) bool
+}
+
+// Stop prevents the timer from firing, it returns false if the timer already fired or was stopped.
+func (t *Timer) Stop() bool {
+	return t.stop()
+}
+
+// Reset changes the timer to fire after the duration, it returns whether the timer was active.
+func (t *Timer) Reset(d time.Duration) bool {
+	return t.reset(d)
+}
+
+// Ticker delivers ticks at intervals, like time.Ticker.
+type Ticker struct {
+	C    <-chan time.Time
+	stop func()
+}
+
+// Stop turns off the ticker, no more ticks are sent.
+func (t *Ticker) Stop() {
+	t.stop()
+}
+
+// Real is the wall clock.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) *Timer {
+	t := time.NewTimer(d)
+	return &Timer{C: t.C, stop: t.Stop, reset: t.Reset}
+}
+
+func (realClock) NewTicker(d time.Duration) *Ticker {
+	t := time.NewTicker(d)
+	return &Ticker{C: t.C, stop: t.Stop}
+}
@@ -0,0 +1,153 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+var _ Clock = (*Mock)(nil)
+
+// Mock is a clock in virtual time, which only moves when it's advanced. Timers and tickers
+// fire in order of their deadlines while the clock is advanced past them. Like the ones of
+// the time package, they drop ticks which aren't received in time.
+type Mock struct {
+	now    time.Time
+	timers []*mockTimer
+	lock   sync.Mutex
+	// changed is signaled when timers are added
+	changed *sync.Cond
+}
+
+type mockTimer struct {
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+}
+
+// NewMock returns a virtual clock starting at the given time.
+func NewMock(start time.Time) *Mock {
+	m := &Mock{now: start}
+	m.changed = sync.NewCond(&m.lock)
+	return m
+}
+
+func (m *Mock) Now() time.Time {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.now
+}
+
+func (m *Mock) Since(t time.Time) time.Duration {
+	return m.Now().Sub(t)
+}
+
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	return m.NewTimer(d).C
+}
+
+func (m *Mock) NewTimer(d time.Duration) *Timer {
+	mt := &mockTimer{c: make(chan time.Time, 1)}
+	m.schedule(mt, d)
+	return &Timer{
+		C:    mt.c,
+		stop: func() bool { return m.remove(mt) },
+		reset: func(d time.Duration) bool {
+			active := m.remove(mt)
+			m.schedule(mt, d)
+			return active
+		},
+	}
+}
+
+func (m *Mock) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	mt := &mockTimer{c: make(chan time.Time, 1), period: d}
+	m.schedule(mt, d)
+	return &Ticker{C: mt.c, stop: func() { m.remove(mt) }}
+}
+
+// Add advances the clock by the duration, firing the timers due on the way.
+func (m *Mock) Add(d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	end := m.now.Add(d)
+	for len(m.timers) > 0 && !m.timers[0].at.After(end) {
+		mt := m.timers[0]
+		m.now = mt.at
+		m.timers = m.timers[1:]
+		m.fire(mt)
+		if mt.period > 0 {
+			mt.at = mt.at.Add(mt.period)
+			m.insert(mt)
+		}
+	}
+	m.now = end
+}
+
+// Set advances the clock to the given time, it doesn't move the clock backwards.
+func (m *Mock) Set(t time.Time) {
+	if d := t.Sub(m.Now()); d > 0 {
+		m.Add(d)
+	}
+}
+
+// Timers returns the number of active timers and tickers.
+func (m *Mock) Timers() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.timers)
+}
+
+// BlockUntil waits until at least n timers and tickers are active, e.g. to let loops under
+// test reach their next wait before the clock is advanced.
+func (m *Mock) BlockUntil(n int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for len(m.timers) < n {
+		m.changed.Wait()
+	}
+}
+
+func (m *Mock) schedule(mt *mockTimer, d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mt.at = m.now.Add(d)
+	if d <= 0 && mt.period == 0 {
+		m.fire(mt)
+		return
+	}
+	m.insert(mt)
+	m.changed.Broadcast()
+}
+
+// fire sends the current time unless the last tick wasn't received yet.
+func (m *Mock) fire(mt *mockTimer) {
+	select {
+	case mt.c <- m.now:
+	default:
+	}
+}
+
+func (m *Mock) insert(mt *mockTimer) {
+	i := sort.Search(len(m.timers), func(i int) bool {
+		return m.timers[i].at.After(mt.at)
+	})
+	m.timers = append(m.timers, nil)
+	copy(m.timers[i+1:], m.timers[i:])
+	m.timers[i] = mt
+}
+
+func (m *Mock) remove(mt *mockTimer) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i, t := range m.timers {
+		if t == mt {
+			m.timers = append(m.timers[:i], m.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMock_Timers(t *testing.T) {
+	start := time.Unix(0, 0)
+	m := NewMock(start)
+
+	early, late := m.NewTimer(time.Second), m.NewTimer(time.Minute)
+	stopped := m.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Fatal("expected active timer to be stopped")
+	}
+	m.Add(30 * time.Second)
+	if at := receive(t, early.C); !at.Equal(start.Add(time.Second)) {
+		t.Fatalf("expected timer to fire at its deadline, got %v", at)
+	}
+	expectNone(t, late.C)
+	expectNone(t, stopped.C)
+	if now := m.Now(); !now.Equal(start.Add(30 * time.Second)) {
+		t.Fatalf("expected clock to be advanced, got %v", now)
+	}
+
+	if !late.Reset(time.Second) {
+		t.Fatal("expected reset timer to be active")
+	}
+	m.Add(time.Second)
+	receive(t, late.C)
+	if m.Timers() != 0 {
+		t.Fatalf("expected fired timers to be dropped, got %d", m.Timers())
+	}
+
+	select {
+	case <-m.After(0):
+	default:
+		t.Fatal("expected zero duration to fire right away")
+	}
+}
+
+func TestMock_Ticker(t *testing.T) {
+	m := NewMock(time.Unix(0, 0))
+	tick := m.NewTicker(time.Second)
+
+	m.Add(time.Second)
+	receive(t, tick.C)
+	// ticks which aren't received are dropped
+	m.Add(3 * time.Second)
+	receive(t, tick.C)
+	expectNone(t, tick.C)
+
+	tick.Stop()
+	m.Add(time.Second)
+	expectNone(t, tick.C)
+}
+
+func TestMock_BlockUntil(t *testing.T) {
+	m := NewMock(time.Unix(0, 0))
+	fired := make(chan struct{})
+	go func() {
+		<-m.After(time.Hour)
+		close(fired)
+	}()
+	m.BlockUntil(1)
+	m.Add(time.Hour)
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("expected waiting goroutine to be woken up")
+	}
+}
+
+func receive(t *testing.T, c <-chan time.Time) time.Time {
+	t.Helper()
+	select {
+	case at := <-c:
+		return at
+	default:
+		t.Fatal("expected timer to fire")
+	}
+	return time.Time{}
+}
+
+func expectNone(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+	select {
+	case at := <-c:
+		t.Fatalf("unexpected tick at %v", at)
+	default:
+	}
+}
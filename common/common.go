@@ -5,27 +5,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"time"
 
 	ipfslite "github.com/hsanjuan/ipfs-lite"
+	"github.com/ipfs/go-blockservice"
 	ds "github.com/ipfs/go-datastore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	"github.com/ipfs/go-merkledag"
 	"github.com/libp2p/go-libp2p"
 	connmgr "github.com/libp2p/go-libp2p-connmgr"
 	cconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/pnet"
 	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
 	ma "github.com/multiformats/go-multiaddr"
 	mongods "github.com/textileio/go-ds-mongo"
+	"github.com/textileio/go-threads/audit"
+	"github.com/textileio/go-threads/clock"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/datastore"
+	"github.com/textileio/go-threads/logger"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	"github.com/textileio/go-threads/logstore/lstorehybrid"
 	"github.com/textileio/go-threads/logstore/lstoremem"
 	"github.com/textileio/go-threads/net"
+	"github.com/textileio/go-threads/synctrace"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
 )
@@ -52,6 +63,9 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 	if err := setDefaults(&config); err != nil {
 		return nil, err
 	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid network config: %w", err)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	fin.Add(util.NewContextCloser(cancel))
@@ -72,15 +86,34 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		return nil, fin.Cleanup(err)
 	}
 
+	tstore, err := buildLogstore(ctx, config, fin)
+	if err != nil {
+		return nil, fin.Cleanup(err)
+	}
+
+	hostOpts := []libp2p.Option{
+		libp2p.Peerstore(pstore),
+		libp2p.ConnectionManager(config.ConnManager),
+		libp2p.DisableRelay(),
+	}
+	// the gater admits peers of the logstore threads, so it's set up along with the host
+	var gater *net.MembershipGater
+	if config.MembershipGating {
+		allow := append([]peer.ID(nil), config.GatingAllowlist...)
+		for _, m := range config.Federation {
+			allow = append(allow, m.ID)
+		}
+		gater = net.NewMembershipGater(tstore, config.Clock, allow...)
+		hostOpts = append(hostOpts, libp2p.ConnectionGater(gater))
+	}
+
 	h, d, err := ipfslite.SetupLibp2p(
 		ctx,
 		hostKey,
-		nil,
+		config.PrivateNetworkKey,
 		[]ma.Multiaddr{config.HostAddr},
 		litestore,
-		libp2p.Peerstore(pstore),
-		libp2p.ConnectionManager(config.ConnManager),
-		libp2p.DisableRelay(),
+		hostOpts...,
 	)
 	if err != nil {
 		return nil, fin.Cleanup(err)
@@ -91,16 +124,28 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		return nil, fin.Cleanup(err)
 	}
 
-	tstore, err := buildLogstore(ctx, config, fin)
-	if err != nil {
-		return nil, fin.Cleanup(err)
+	// Annotations, bootstrap peers and the block index are local-only, they stay in memory along with an in-memory logstore
+	netConfig := config.netConfig()
+	if config.LSType != LogstoreInMemory {
+		if netConfig.AnnotationStore, err = persistentStore(ctx, config, "annotations", fin); err != nil {
+			return nil, fin.Cleanup(err)
+		}
+		if netConfig.BootstrapStore, err = persistentStore(ctx, config, "bootstrap", fin); err != nil {
+			return nil, fin.Cleanup(err)
+		}
+		if netConfig.BlockIndexStore, err = persistentStore(ctx, config, "blockindex", fin); err != nil {
+			return nil, fin.Cleanup(err)
+		}
 	}
 
+	// The network reads the local DAG, missing blocks are fetched over bitswap by the lite peer
+	bstore := lite.BlockStore()
+	local := merkledag.NewDAGService(blockservice.New(bstore, offline.Exchange(bstore)))
+	netConfig.BlockFetcher = lite
+
 	// Build a network
-	api, err := net.NewNetwork(ctx, h, lite.BlockStore(), lite, tstore, net.Config{
-		Debug:  config.Debug,
-		PubSub: config.PubSub,
-	}, config.GRPCServerOptions, config.GRPCDialOptions)
+	api, err := net.NewNetwork(ctx, h, bstore, local, tstore, netConfig,
+		config.GRPCServerOptions, config.GRPCDialOptions)
 	if err != nil {
 		return nil, fin.Cleanup(err)
 	}
@@ -109,6 +154,7 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 	return &netBoostrapper{
 		Net:       api,
 		litepeer:  lite,
+		gater:     gater,
 		finalizer: fin,
 	}, nil
 }
@@ -130,7 +176,7 @@ func buildLogstore(ctx context.Context, config NetConfig, fin *util.Finalizer) (
 		return persistentLogstore(ctx, config, fin)
 
 	default:
-		return nil, fmt.Errorf("unsupported logstore type: %s", config.LSType)
+		return nil, fmt.Errorf("%w: %s", errUnsupportedLogstore, config.LSType)
 	}
 }
 
@@ -139,14 +185,20 @@ func persistentLogstore(ctx context.Context, config NetConfig, fin *util.Finaliz
 	if err != nil {
 		return nil, err
 	}
-	return lstoreds.NewLogstore(ctx, pds, lstoreds.DefaultOpts())
+	opts := lstoreds.DefaultOpts()
+	if len(config.LSMigration) != 0 {
+		opts.Migration = config.LSMigration
+	}
+	return lstoreds.NewLogstore(ctx, pds, opts)
 }
 
 func persistentStore(ctx context.Context, config NetConfig, name string, fin *util.Finalizer) (ds.Batching, error) {
 	if len(config.MongoUri) != 0 {
 		return mongoStore(ctx, config.MongoUri, config.MongoDB, name, fin)
-	} else {
+	} else if config.Backend == datastore.BackendBadger {
 		return badgerStore(filepath.Join(config.BadgerRepoPath, name), fin)
+	} else {
+		return backendStore(config.Backend, datastore.Path(config.BadgerRepoPath, name, config.Backend), fin)
 	}
 }
 
@@ -164,6 +216,16 @@ func badgerStore(repoPath string, fin *util.Finalizer) (ds.Batching, error) {
 	return dstore, nil
 }
 
+func backendStore(backend datastore.Backend, repoPath string, fin *util.Finalizer) (ds.Batching, error) {
+	dstore, err := datastore.New(backend, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	fin.Add(dstore)
+
+	return dstore, nil
+}
+
 func mongoStore(ctx context.Context, uri, db, collection string, fin *util.Finalizer) (ds.Batching, error) {
 	dstore, err := mongods.New(ctx, uri, db, mongods.WithCollName(collection))
 	if err != nil {
@@ -253,9 +315,14 @@ func setDefaults(config *NetConfig) error {
 		config.MongoDB = "threadnet"
 	}
 
+	if len(config.Backend) == 0 {
+		config.Backend = datastore.BackendBadger
+	}
+
 	return nil
 }
 
+// LogstoreType is the kind of logstore backing the network.
 type LogstoreType string
 
 const (
@@ -264,21 +331,105 @@ const (
 	LogstoreHybrid     LogstoreType = "hybrid"
 )
 
+var errUnsupportedLogstore = errors.New("unsupported logstore type")
+
+// NetConfig is the config of a default network, it's built with NetOption.
 type NetConfig struct {
 	HostAddr          ma.Multiaddr
 	ConnManager       cconnmgr.ConnManager
 	GRPCServerOptions []grpc.ServerOption
 	GRPCDialOptions   []grpc.DialOption
 	LSType            LogstoreType
+	LSMigration       lstoreds.MigrationMode
 	BadgerRepoPath    string
+	Backend           datastore.Backend
 	MongoUri          string
 	MongoDB           string
+	PrivateNetworkKey pnet.PSK
 	PubSub            bool
+	PubSubCacheSize   int
+	MaxRecordSize     int
+	PullMemoryBudget  int64
+	ConnLimits        net.ConnLimits
+	AuditLog          *audit.Log
+	Transport         net.Transport
+	Federation        []peer.AddrInfo
+	LightClient       bool
+	RequireEdgeProofs bool
+	BurstSync         bool
+	Access            net.AccessList
+	KeepAlive         net.KeepAlive
+	SyncTrace         *synctrace.Recorder
+	SyncEvents        *logger.EventExporter
+	MembershipGating  bool
+	GatingAllowlist   []peer.ID
+	Clock             clock.Clock
 	Debug             bool
 }
 
+// Validate returns an error if the config is invalid or options conflict with each other.
+func (c NetConfig) Validate() error {
+	if c.HostAddr == nil {
+		return errors.New("host address is required")
+	}
+	if len(c.BadgerRepoPath) != 0 && len(c.MongoUri) != 0 {
+		return errors.New("badger and mongo persistence are mutually exclusive")
+	}
+	if _, err := datastore.ParseBackend(string(c.Backend)); err != nil {
+		return err
+	}
+	if len(c.MongoUri) != 0 && c.Backend != datastore.BackendBadger {
+		return errors.New("datastore backends don't apply to mongo persistence")
+	}
+	switch c.LSType {
+	case LogstoreInMemory, LogstorePersistent, LogstoreHybrid:
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedLogstore, c.LSType)
+	}
+	if len(c.LSMigration) != 0 {
+		if _, err := lstoreds.ParseMigrationMode(string(c.LSMigration)); err != nil {
+			return err
+		}
+	}
+	for _, p := range c.GatingAllowlist {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("invalid gating allowlist peer: %w", err)
+		}
+	}
+	if c.PrivateNetworkKey != nil && len(c.PrivateNetworkKey) != 32 {
+		return errors.New("private network key must be 32 bytes long")
+	}
+	return c.netConfig().Validate()
+}
+
+// netConfig returns the options passed to the network itself.
+func (c NetConfig) netConfig() net.Config {
+	return net.Config{
+		Debug:             c.Debug,
+		PubSub:            c.PubSub,
+		PubSubCacheSize:   c.PubSubCacheSize,
+		MaxRecordSize:     c.MaxRecordSize,
+		PullMemoryBudget:  c.PullMemoryBudget,
+		ConnLimits:        c.ConnLimits,
+		AuditLog:          c.AuditLog,
+		Transport:         c.Transport,
+		Federation:        c.Federation,
+		LightClient:       c.LightClient,
+		RequireEdgeProofs: c.RequireEdgeProofs,
+		BurstSync:         c.BurstSync,
+		Access:            c.Access,
+		KeepAlive:         c.KeepAlive,
+		SyncTrace:         c.SyncTrace,
+		SyncEvents:        c.SyncEvents,
+		Clock:             c.Clock,
+	}
+}
+
+// NetOption specifies a default network option.
+// Options may fail early, the resulting config is validated as a whole once all options are applied.
 type NetOption func(c *NetConfig) error
 
+// WithNetHostAddr sets the address the host listens on, defaults to /ip4/0.0.0.0/tcp/0.
 func WithNetHostAddr(addr ma.Multiaddr) NetOption {
 	return func(c *NetConfig) error {
 		c.HostAddr = addr
@@ -286,6 +437,7 @@ func WithNetHostAddr(addr ma.Multiaddr) NetOption {
 	}
 }
 
+// WithConnectionManager sets the host connection manager.
 func WithConnectionManager(cm cconnmgr.ConnManager) NetOption {
 	return func(c *NetConfig) error {
 		c.ConnManager = cm
@@ -293,6 +445,7 @@ func WithConnectionManager(cm cconnmgr.ConnManager) NetOption {
 	}
 }
 
+// WithNetDebug enables debug logging of the network.
 func WithNetDebug(enabled bool) NetOption {
 	return func(c *NetConfig) error {
 		c.Debug = enabled
@@ -300,6 +453,7 @@ func WithNetDebug(enabled bool) NetOption {
 	}
 }
 
+// WithNetGRPCServerOptions sets options of the gRPC server used by peers to reach the host.
 func WithNetGRPCServerOptions(opts ...grpc.ServerOption) NetOption {
 	return func(c *NetConfig) error {
 		c.GRPCServerOptions = opts
@@ -307,6 +461,7 @@ func WithNetGRPCServerOptions(opts ...grpc.ServerOption) NetOption {
 	}
 }
 
+// WithNetGRPCDialOptions sets options used to dial peers over gRPC.
 func WithNetGRPCDialOptions(opts ...grpc.DialOption) NetOption {
 	return func(c *NetConfig) error {
 		c.GRPCDialOptions = opts
@@ -314,6 +469,7 @@ func WithNetGRPCDialOptions(opts ...grpc.DialOption) NetOption {
 	}
 }
 
+// WithNetPubSub enables exchanging records and presence over pubsub.
 func WithNetPubSub(enabled bool) NetOption {
 	return func(c *NetConfig) error {
 		c.PubSub = enabled
@@ -321,6 +477,16 @@ func WithNetPubSub(enabled bool) NetOption {
 	}
 }
 
+// WithNetPubSubCacheSize sets the number of records recently multicast over each thread topic
+// kept for peers joining late. It requires WithNetPubSub, caching is disabled if zero.
+func WithNetPubSubCacheSize(size int) NetOption {
+	return func(c *NetConfig) error {
+		c.PubSubCacheSize = size
+		return nil
+	}
+}
+
+// WithNetLogstore sets the kind of logstore, defaults to LogstorePersistent.
 func WithNetLogstore(lt LogstoreType) NetOption {
 	return func(c *NetConfig) error {
 		c.LSType = lt
@@ -328,6 +494,17 @@ func WithNetLogstore(lt LogstoreType) NetOption {
 	}
 }
 
+// WithNetLogstoreMigration sets how pending schema migrations of a persistent logstore
+// are handled on start, defaults to lstoreds.MigrateApply.
+func WithNetLogstoreMigration(mode lstoreds.MigrationMode) NetOption {
+	return func(c *NetConfig) error {
+		c.LSMigration = mode
+		return nil
+	}
+}
+
+// WithNetBadgerPersistence persists the network in a badger datastore at repoPath.
+// It can't be combined with WithNetMongoPersistence.
 func WithNetBadgerPersistence(repoPath string) NetOption {
 	return func(c *NetConfig) error {
 		c.BadgerRepoPath = repoPath
@@ -335,6 +512,17 @@ func WithNetBadgerPersistence(repoPath string) NetOption {
 	}
 }
 
+// WithNetDatastoreBackend sets the backend of the datastores persisted with WithNetBadgerPersistence,
+// defaults to datastore.BackendBadger. Use datastore.MigrateRepo to move existing datastores to another backend.
+func WithNetDatastoreBackend(backend datastore.Backend) NetOption {
+	return func(c *NetConfig) error {
+		c.Backend = backend
+		return nil
+	}
+}
+
+// WithNetMongoPersistence persists the network in the mongo db at uri.
+// It can't be combined with WithNetBadgerPersistence.
 func WithNetMongoPersistence(uri, db string) NetOption {
 	return func(c *NetConfig) error {
 		c.MongoUri = uri
@@ -343,9 +531,170 @@ func WithNetMongoPersistence(uri, db string) NetOption {
 	}
 }
 
+// WithNetPrivateNetwork restricts the host to the private network protected by the given
+// pre-shared key. Only peers configured with the same key are able to connect to the host.
+func WithNetPrivateNetwork(psk pnet.PSK) NetOption {
+	return func(c *NetConfig) error {
+		if len(psk) != 32 {
+			return fmt.Errorf("private network key must be 32 bytes long")
+		}
+		c.PrivateNetworkKey = psk
+		return nil
+	}
+}
+
+// WithNetPrivateNetworkFile loads the pre-shared key of a private network from a swarm key file.
+func WithNetPrivateNetworkFile(path string) NetOption {
+	return func(c *NetConfig) error {
+		psk, err := util.LoadPSK(path)
+		if err != nil {
+			return err
+		}
+		c.PrivateNetworkKey = psk
+		return nil
+	}
+}
+
+// WithNetMaxRecordSize sets the maximum size of records created or accepted by the host.
+func WithNetMaxRecordSize(size int) NetOption {
+	return func(c *NetConfig) error {
+		c.MaxRecordSize = size
+		return nil
+	}
+}
+
+// WithNetPullMemoryBudget sets the maximum total size in bytes of records being pulled at once.
+// Pulls aren't limited if zero.
+func WithNetPullMemoryBudget(size int64) NetOption {
+	return func(c *NetConfig) error {
+		c.PullMemoryBudget = size
+		return nil
+	}
+}
+
+// WithNetConnLimits bounds the connections and concurrent calls to peers.
+// Calls wait for free streams when limits are hit, see net.ConnLimits.
+func WithNetConnLimits(limits net.ConnLimits) NetOption {
+	return func(c *NetConfig) error {
+		c.ConnLimits = limits
+		return nil
+	}
+}
+
+// WithNetAuditLog records pushes accepted from remote peers in the given audit log.
+// The log isn't closed along with the network.
+func WithNetAuditLog(l *audit.Log) NetOption {
+	return func(c *NetConfig) error {
+		c.AuditLog = l
+		return nil
+	}
+}
+
+// WithNetSyncTrace records sync protocol messages of threads in the given recorder,
+// so head divergence can be reproduced offline with a synctrace.Replayer.
+func WithNetSyncTrace(r *synctrace.Recorder) NetOption {
+	return func(c *NetConfig) error {
+		c.SyncTrace = r
+		return nil
+	}
+}
+
+// WithNetSyncEvents exports the exchanges with thread peers as JSON lines to the given exporter.
+func WithNetSyncEvents(e *logger.EventExporter) NetOption {
+	return func(c *NetConfig) error {
+		c.SyncEvents = e
+		return nil
+	}
+}
+
+// WithNetTransport carries the network service over the given transport instead of libp2p,
+// e.g., a transport created with net.NewTLSTransport. It can't be combined with WithNetPubSub.
+func WithNetTransport(t net.Transport) NetOption {
+	return func(c *NetConfig) error {
+		c.Transport = t
+		return nil
+	}
+}
+
+// WithNetMembershipGating only accepts inbound libp2p connections of peers sharing a thread
+// with the host, suitable for always-on nodes with public addresses. Allowed peers and
+// federation members are accepted regardless, see net.MembershipGater.
+func WithNetMembershipGating(allow ...peer.ID) NetOption {
+	return func(c *NetConfig) error {
+		c.MembershipGating = true
+		c.GatingAllowlist = allow
+		return nil
+	}
+}
+
+// WithNetFederation shares responsibility for threads with other always-on nodes.
+// Threads are assigned to live members, which pull them and receive pushes forwarded by the others.
+func WithNetFederation(members []peer.AddrInfo) NetOption {
+	return func(c *NetConfig) error {
+		c.Federation = members
+		return nil
+	}
+}
+
+// WithNetAccessList restricts the peers allowed to connect to the network service.
+// The list can be replaced at runtime through the admin API.
+func WithNetAccessList(list net.AccessList) NetOption {
+	return func(c *NetConfig) error {
+		c.Access = list
+		return nil
+	}
+}
+
+// WithNetKeepAlive pings the peers the host syncs threads with, so NAT mappings stay open
+// and dead connections are detected between exchanges, see net.KeepAlive.
+func WithNetKeepAlive(k net.KeepAlive) NetOption {
+	return func(c *NetConfig) error {
+		c.KeepAlive = k
+		return nil
+	}
+}
+
+// WithNetLightClient makes the host sync only record headers, which are verified without
+// events. Events and bodies are fetched from peers on demand.
+func WithNetLightClient(enabled bool) NetOption {
+	return func(c *NetConfig) error {
+		c.LightClient = enabled
+		return nil
+	}
+}
+
+// WithNetRequireEdgeProofs makes the host pull records after an edge exchange
+// only from peers proving their heads with signed head records.
+func WithNetRequireEdgeProofs(required bool) NetOption {
+	return func(c *NetConfig) error {
+		c.RequireEdgeProofs = required
+		return nil
+	}
+}
+
+// WithNetBurstSync coalesces periodic pulls into bursts, which suits hosts on battery.
+// Pulls are suspended altogether while the app reports it's backgrounded, see SetPowerState.
+func WithNetBurstSync(enabled bool) NetOption {
+	return func(c *NetConfig) error {
+		c.BurstSync = enabled
+		return nil
+	}
+}
+
+// WithNetClock runs the sync loops, queues and caches of the network on the given clock,
+// e.g. a clock.Mock advanced by tests and simulations in virtual time.
+func WithNetClock(clk clock.Clock) NetOption {
+	return func(c *NetConfig) error {
+		c.Clock = clk
+		return nil
+	}
+}
+
 type netBoostrapper struct {
 	app.Net
-	litepeer  *ipfslite.Peer
+	litepeer *ipfslite.Peer
+	// gater admits inbound connections of thread members, nil if membership gating is disabled
+	gater     *net.MembershipGater
 	finalizer *util.Finalizer
 }
 
@@ -359,6 +708,65 @@ func (tsb *netBoostrapper) GetIpfsLite() *ipfslite.Peer {
 	return tsb.litepeer
 }
 
+// MembershipGater returns the gater of inbound connections, or nil if membership gating
+// is disabled. Peers joining threads with invites can be allowed with it.
+func (tsb *netBoostrapper) MembershipGater() *net.MembershipGater {
+	return tsb.gater
+}
+
+// ThreadSnapshot dumps the sync state of a thread, see net.ThreadSnapshotter.
+func (tsb *netBoostrapper) ThreadSnapshot(ctx context.Context, id thread.ID) (net.ThreadSnapshot, error) {
+	snapshotter, ok := tsb.Net.(net.ThreadSnapshotter)
+	if !ok {
+		return net.ThreadSnapshot{}, errors.New("thread snapshots aren't supported by the network")
+	}
+	return snapshotter.ThreadSnapshot(ctx, id)
+}
+
+// ExportDAG writes the record DAG of a thread, see net.DAGExporter.
+func (tsb *netBoostrapper) ExportDAG(ctx context.Context, id thread.ID, w io.Writer, format net.DAGFormat, r net.DAGRange) error {
+	exporter, ok := tsb.Net.(net.DAGExporter)
+	if !ok {
+		return errors.New("record DAG exports aren't supported by the network")
+	}
+	return exporter.ExportDAG(ctx, id, w, format, r)
+}
+
+// AccessList returns the access list of the network service, see net.AccessController.
+func (tsb *netBoostrapper) AccessList() net.AccessList {
+	if controller, ok := tsb.Net.(net.AccessController); ok {
+		return controller.AccessList()
+	}
+	return net.AccessList{}
+}
+
+// SetAccessList replaces the access list of the network service, see net.AccessController.
+func (tsb *netBoostrapper) SetAccessList(list net.AccessList) error {
+	controller, ok := tsb.Net.(net.AccessController)
+	if !ok {
+		return errors.New("access lists aren't supported by the network")
+	}
+	return controller.SetAccessList(list)
+}
+
+// Backup writes a differential backup of the network, see net.Backupper.
+func (tsb *netBoostrapper) Backup(ctx context.Context, w io.Writer, since string) (string, error) {
+	backupper, ok := tsb.Net.(net.Backupper)
+	if !ok {
+		return "", errors.New("backups aren't supported by the network")
+	}
+	return backupper.Backup(ctx, w, since)
+}
+
+// Restore applies a backup stream, see net.Backupper.
+func (tsb *netBoostrapper) Restore(ctx context.Context, r io.Reader) (net.BackupStats, error) {
+	backupper, ok := tsb.Net.(net.Backupper)
+	if !ok {
+		return net.BackupStats{}, errors.New("backups aren't supported by the network")
+	}
+	return backupper.Restore(ctx, r)
+}
+
 func (tsb *netBoostrapper) Close() error {
 	return tsb.finalizer.Cleanup(nil)
 }
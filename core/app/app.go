@@ -95,6 +95,11 @@ func (l *LocalEventListener) Discard() {
 	l.listener.Discard()
 }
 
+// AppResolver connects an app to a thread labeled with tags, if it serves the thread.
+// It's called before records of threads without a connected app are handled, and
+// reports whether an app was connected.
+type AppResolver func(id thread.ID, tags []string) bool
+
 // Net adds the ability to connect an app to a thread.
 type Net interface {
 	net.Net
@@ -102,10 +107,18 @@ type Net interface {
 	// ConnectApp returns an app<->thread connector.
 	ConnectApp(App, thread.ID) (*Connector, error)
 
+	// SetAppResolver sets a resolver connecting apps to threads on demand,
+	// replacing the current one. A nil resolver disables it.
+	SetAppResolver(r AppResolver)
+
 	// Validate thread ID and token against the net host.
 	// If token is present and was issued the net host (is valid), the embedded public key is returned.
 	// If token is not present, both the returned public key and error will be nil.
 	Validate(id thread.ID, token thread.Token, readOnly bool) (thread.PubKey, error)
+
+	// MaxRecordSize returns the maximum size of records created or accepted by the net host.
+	// Record bodies must leave room for net.RecordOverhead.
+	MaxRecordSize() int
 }
 
 // Connector connects an app to a thread.
@@ -146,8 +159,14 @@ func (c *Connector) Token() net.Token {
 }
 
 // CreateNetRecord calls net.CreateRecord while supplying thread ID and API token.
-func (c *Connector) CreateNetRecord(ctx context.Context, body format.Node, token thread.Token) (net.ThreadRecord, error) {
-	return c.Net.CreateRecord(ctx, c.threadID, body, net.WithThreadToken(token), net.WithAPIToken(c.token))
+func (c *Connector) CreateNetRecord(
+	ctx context.Context,
+	body format.Node,
+	token thread.Token,
+	opts ...net.ThreadOption,
+) (net.ThreadRecord, error) {
+	opts = append(opts, net.WithThreadToken(token), net.WithAPIToken(c.token))
+	return c.Net.CreateRecord(ctx, c.threadID, body, opts...)
 }
 
 // Validate thread token against the net host.
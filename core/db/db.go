@@ -73,6 +73,9 @@ type ReduceAction struct {
 	Collection string
 	// InstanceID of the instance in reduced action.
 	InstanceID InstanceID
+	// Conflicts are paths of the instance changed concurrently by the action
+	// and other actions, e.g. JSON pointers. Codecs may not detect conflicts.
+	Conflicts []string
 }
 
 // IndexFunc handles index updates.
@@ -88,3 +91,11 @@ type EventCodec interface {
 	// EventsFromBytes deserializes a format.Node bytes payload into Events.
 	EventsFromBytes(data []byte) ([]Event, error)
 }
+
+// TxnEventCodec is an EventCodec which reduces events within a transaction of the caller,
+// so state derived from the reduced actions is committed along with them.
+type TxnEventCodec interface {
+	EventCodec
+	// ReduceTxn applies generated events into state within txn, which the caller commits.
+	ReduceTxn(events []Event, txn ds.Txn, baseKey ds.Key, indexFunc IndexFunc) ([]ReduceAction, error)
+}
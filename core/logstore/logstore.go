@@ -30,6 +30,9 @@ var ErrEmptyDump = errors.New("empty dump")
 // ErrEdgeUnavailable indicates failed concurrent edge computation.
 var ErrEdgeUnavailable = errors.New("edge unavailable")
 
+// ErrCorruptedEntry indicates a stored entry failed its checksum verification.
+var ErrCorruptedEntry = errors.New("corrupted logstore entry")
+
 // Logstore stores log keys, addresses, heads and thread meta data.
 type Logstore interface {
 	Close() error
@@ -78,6 +81,10 @@ type ThreadMetadata interface {
 	// PutString stores a string value under key.
 	PutString(t thread.ID, key string, val string) error
 
+	// CompareAndSwapString stores a string value under key if the current value is old,
+	// nil meaning no value. It returns whether the value was stored.
+	CompareAndSwapString(t thread.ID, key string, old *string, val string) (bool, error)
+
 	// GetBool retrieves a boolean value under key.
 	GetBool(t thread.ID, key string) (*bool, error)
 
@@ -126,6 +133,12 @@ type KeyBook interface {
 	// AddServiceKey adds a service key under a thread.
 	AddServiceKey(thread.ID, *sym.Key) error
 
+	// IndexKey retrieves the index key of a thread.
+	IndexKey(thread.ID) (*sym.Key, error)
+
+	// AddIndexKey adds an index key under a thread.
+	AddIndexKey(thread.ID, *sym.Key) error
+
 	// ClearKeys deletes all keys under a thread.
 	ClearKeys(thread.ID) error
 
@@ -237,6 +250,7 @@ type (
 			Private map[thread.ID]map[peer.ID]crypto.PrivKey
 			Read    map[thread.ID][]byte
 			Service map[thread.ID][]byte
+			Index   map[thread.ID][]byte
 		}
 	}
 
@@ -254,3 +268,41 @@ type (
 		}
 	}
 )
+
+// EntryKind is the kind of a logstore entry covered by integrity checks.
+type EntryKind string
+
+const (
+	EntryHeads      EntryKind = "heads"
+	EntryAddrs      EntryKind = "addrs"
+	EntryPubKey     EntryKind = "pubkey"
+	EntryPrivKey    EntryKind = "privkey"
+	EntryReadKey    EntryKind = "readkey"
+	EntryServiceKey EntryKind = "servicekey"
+	EntryIndexKey   EntryKind = "indexkey"
+)
+
+// IntegrityEvent reports a corrupted logstore entry.
+type IntegrityEvent struct {
+	// ThreadID is the thread of the entry.
+	ThreadID thread.ID
+	// LogID is the log of the entry, it's empty for thread keys.
+	LogID peer.ID
+	// Entry is the kind of the corrupted entry.
+	Entry EntryKind
+	// Repaired is set once the entry was restored from a replica or from peers.
+	Repaired bool
+}
+
+// IntegrityHandler receives integrity events.
+type IntegrityHandler func(IntegrityEvent)
+
+// IntegrityChecker is implemented by logstores which verify entry checksums on read.
+// Corrupted entries are reported to the handler and marked until they're rewritten.
+type IntegrityChecker interface {
+	// NotifyIntegrity sets the handler of integrity events, replacing the previous one.
+	NotifyIntegrity(IntegrityHandler)
+
+	// Corrupted returns the marked entries which are still corrupted.
+	Corrupted() ([]IntegrityEvent, error)
+}
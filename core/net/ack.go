@@ -0,0 +1,18 @@
+package net
+
+import (
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// DeliveryReport tells which peers acknowledged a record of a log authored by the host.
+// Peers with record acks enabled acknowledge the records they store and the ones marked
+// as seen, acks cover all records of a log up to a position.
+type DeliveryReport struct {
+	LogID    peer.ID
+	RecordID cid.Cid
+	// Delivered are the peers which stored the record.
+	Delivered []peer.ID
+	// Seen are the peers on which the record was marked as seen, they're also in Delivered.
+	Seen []peer.ID
+}
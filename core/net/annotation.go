@@ -0,0 +1,33 @@
+package net
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ipfs/go-cid"
+)
+
+// ErrRecordNotFound indicates a record isn't available locally.
+var ErrRecordNotFound = errors.New("record not found")
+
+// Annotation is local-only metadata attached to a record by the host's apps.
+// Annotations aren't replicated to thread peers, and are removed along with
+// the records, e.g. when records are pruned or the thread is deleted.
+type Annotation struct {
+	Read    bool
+	Starred bool
+	// Labels are app-defined local labels of the record.
+	Labels []string
+}
+
+// IsZero returns whether the annotation is empty.
+func (a Annotation) IsZero() bool {
+	return !a.Read && !a.Starred && len(a.Labels) == 0
+}
+
+// RecordAnnotation is the annotation of a record.
+type RecordAnnotation struct {
+	Annotation
+	RecordID  cid.Cid
+	UpdatedAt time.Time
+}
@@ -0,0 +1,45 @@
+package net
+
+import (
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// LogApprovalRecordType is the record type of log approval records, see Net.ApproveLog.
+	LogApprovalRecordType = "threads/log-approval"
+
+	// ExtensionApproveLog is the name of the threads record extension holding the ID of the log
+	// a log approval record approves. Unlike the body, it's readable by hosts without the read key.
+	ExtensionApproveLog = "approve-log"
+)
+
+var (
+	// ErrLogApprovalNotRequired indicates a log was approved in a thread which doesn't require approvals.
+	ErrLogApprovalNotRequired = errors.New("thread doesn't require log approval")
+
+	// ErrNotThreadOwner indicates a log was approved by an identity other than the thread owner.
+	ErrNotThreadOwner = errors.New("only the thread owner can approve logs")
+
+	// ErrLogNotPending indicates an approved log isn't pending, e.g. it was approved already.
+	ErrLogNotPending = errors.New("log isn't pending approval")
+)
+
+// PendingLog is a log pushed by a new writer of a thread requiring log approval. It isn't
+// shared with the thread peers and its records are rejected until the thread owner approves it.
+type PendingLog struct {
+	// ID is the log ID.
+	ID peer.ID
+	// PubKey is the public key of the log.
+	PubKey crypto.PubKey
+	// Addrs are the addresses the log is pulled from once it's approved.
+	Addrs []ma.Multiaddr
+	// From is the peer which pushed the log.
+	From peer.ID
+	// Since is when the log was first pushed.
+	Since time.Time
+}
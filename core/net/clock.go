@@ -0,0 +1,78 @@
+package net
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// VectorClock maps the logs of a thread to their heights, i.e. the counters of their heads.
+// Since logs are append-only, comparing clocks tells whether all records seen by one
+// party were seen by another, e.g. whether a host caught up with a client's writes.
+type VectorClock map[peer.ID]int64
+
+// ClockOrder is the causal order of two vector clocks.
+type ClockOrder int
+
+const (
+	// ClockEqual indicates clocks of the same records.
+	ClockEqual ClockOrder = iota
+	// ClockBefore indicates a clock covered by the other one, which has more records.
+	ClockBefore
+	// ClockAfter indicates a clock covering the other one and more records.
+	ClockAfter
+	// ClockConcurrent indicates clocks which both have records the other one doesn't.
+	ClockConcurrent
+)
+
+// VectorClockFromInfo returns the clock of the log heads of a thread.
+func VectorClockFromInfo(info thread.Info) VectorClock {
+	c := make(VectorClock, len(info.Logs))
+	for _, lg := range info.Logs {
+		if lg.Head.Counter > 0 {
+			c[lg.ID] = lg.Head.Counter
+		}
+	}
+	return c
+}
+
+// Observe advances the height of a log, e.g. as records of a subscription are received.
+func (c VectorClock) Observe(lid peer.ID, height int64) {
+	if height > c[lid] {
+		c[lid] = height
+	}
+}
+
+// Covers returns whether c is at least as high as other for every log, i.e. whether
+// every record up to other was seen.
+func (c VectorClock) Covers(other VectorClock) bool {
+	for lid, h := range other {
+		if c[lid] < h {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns the causal order of c relative to other.
+func (c VectorClock) Compare(other VectorClock) ClockOrder {
+	covers, covered := c.Covers(other), other.Covers(c)
+	switch {
+	case covers && covered:
+		return ClockEqual
+	case covered:
+		return ClockBefore
+	case covers:
+		return ClockAfter
+	default:
+		return ClockConcurrent
+	}
+}
+
+// Copy returns a copy of the clock.
+func (c VectorClock) Copy() VectorClock {
+	cp := make(VectorClock, len(c))
+	for lid, h := range c {
+		cp[lid] = h
+	}
+	return cp
+}
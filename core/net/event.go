@@ -2,12 +2,17 @@ package net
 
 import (
 	"context"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
 	"github.com/textileio/go-threads/crypto"
 )
 
+// ContentTypeDBEvent is the content type of records created by dbs, see
+// EventHeader.Type.
+const ContentTypeDBEvent = "application/vnd.threads.db-events+cbor"
+
 // Event is the Block format used by threads
 type Event interface {
 	format.Node
@@ -32,4 +37,17 @@ type EventHeader interface {
 
 	// Key returns a single-use decryption key for the event body.
 	Key() (crypto.DecryptionKey, error)
+
+	// Epoch returns the write epoch of the log the event was created in,
+	// or zero if the log writer wasn't fenced.
+	Epoch() (uint64, error)
+
+	// Time returns the time the event was created at,
+	// or zero time if it wasn't recorded by the creator.
+	Time() (time.Time, error)
+
+	// Type returns the content type of the event body set by the creator, if any.
+	// Content types are MIME types by convention, e.g. "application/vnd.example.note+cbor",
+	// so that consumers can route records to handlers, see net.Dispatcher.
+	Type() (string, error)
 }
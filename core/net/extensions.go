@@ -0,0 +1,112 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ExtensionNamespaceThreads is the record extension namespace reserved for threads itself.
+const ExtensionNamespaceThreads = "threads"
+
+// ExtensionIdentitySig is the name of the threads record extension holding a signature of the
+// record identity over the thread, the log and the event of the record along with its other
+// extensions. The identity of a record is otherwise only claimed by the log key, records
+// authorizing thread changes, e.g. log approvals, must be signed by their identity.
+const ExtensionIdentitySig = "identity-sig"
+
+var (
+	// MaxRecordExtensionsSize is the maximum total size of the keys and values of record extensions.
+	MaxRecordExtensionsSize = 1 << 10
+
+	// ErrExtensionNamespaceTaken indicates that a record extension namespace is already registered.
+	ErrExtensionNamespaceTaken = errors.New("record extension namespace is already registered")
+	// ErrExtensionNamespaceUnknown indicates that a record extension key isn't in a registered namespace.
+	ErrExtensionNamespaceUnknown = errors.New("record extension namespace isn't registered")
+	// ErrInvalidExtensionKey indicates that a record extension key isn't a namespaced key, see ExtensionKey.
+	ErrInvalidExtensionKey = errors.New("invalid record extension key")
+	// ErrRecordExtensionsTooLarge indicates that record extensions exceed MaxRecordExtensionsSize.
+	ErrRecordExtensionsTooLarge = errors.New("record extensions are too large")
+	// ErrSignerRequired indicates a record must be signed by an identity which isn't available
+	// to the host, see WithSigner.
+	ErrSignerRequired = errors.New("a signer of the identity is required")
+
+	namespaceRx = regexp.MustCompile(`^[a-z0-9]+(?:[.-][a-z0-9]+)*$`)
+
+	namespaces   = map[string]struct{}{ExtensionNamespaceThreads: {}}
+	namespacesMu sync.RWMutex
+)
+
+// RegisterExtensionNamespace reserves a namespace for the record extensions of an application,
+// e.g. "io.textile.routing". Namespaces consist of lowercase alphanumeric characters separated
+// by dots or hyphens. Only keys in registered namespaces can be attached to records.
+func RegisterExtensionNamespace(namespace string) error {
+	if !namespaceRx.MatchString(namespace) {
+		return fmt.Errorf("%w: namespace %q", ErrInvalidExtensionKey, namespace)
+	}
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+	if _, ok := namespaces[namespace]; ok {
+		return fmt.Errorf("%w: %s", ErrExtensionNamespaceTaken, namespace)
+	}
+	namespaces[namespace] = struct{}{}
+	return nil
+}
+
+// ExtensionKey returns the key of a record extension in a namespace.
+func ExtensionKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// RecordExtensions are small key/value pairs attached to a record header and signed along with it.
+// Unlike the record body, they're only encrypted with the thread service key, so they're
+// readable by hosts without the read key, e.g. for routing hints or content-type tags.
+type RecordExtensions map[string][]byte
+
+// Get returns the value of an extension in a namespace.
+func (e RecordExtensions) Get(namespace, name string) ([]byte, bool) {
+	v, ok := e[ExtensionKey(namespace, name)]
+	return v, ok
+}
+
+// Keys returns the extension keys in order.
+func (e RecordExtensions) Keys() []string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Size returns the total size of the extension keys and values.
+func (e RecordExtensions) Size() int {
+	var size int
+	for k, v := range e {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// Validate returns an error if a key isn't in a registered namespace, or the extensions are too large.
+func (e RecordExtensions) Validate() error {
+	for k := range e {
+		i := strings.IndexByte(k, '/')
+		if i <= 0 || i == len(k)-1 {
+			return fmt.Errorf("%w: %q", ErrInvalidExtensionKey, k)
+		}
+		namespacesMu.RLock()
+		_, ok := namespaces[k[:i]]
+		namespacesMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrExtensionNamespaceUnknown, k[:i])
+		}
+	}
+	if size := e.Size(); size > MaxRecordExtensionsSize {
+		return fmt.Errorf("%w: %d bytes exceed %d", ErrRecordExtensionsTooLarge, size, MaxRecordExtensionsSize)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package net
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// Follower is a peer following a thread. Follows are signed by the following peer and
+// replicated between thread peers, so any replica pushes new records to the followers,
+// including those it didn't learn the thread from.
+type Follower struct {
+	Peer peer.ID
+	// Addrs are the addresses the follower announced with its follow.
+	Addrs []ma.Multiaddr
+	// Since is the time the peer started following the thread.
+	Since time.Time
+}
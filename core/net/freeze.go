@@ -0,0 +1,35 @@
+package net
+
+import (
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	// ErrThreadFrozen indicates a thread is frozen, so no records are created or accepted.
+	ErrThreadFrozen = errors.New("thread is frozen")
+
+	// ErrNotFreezeAdmin indicates a frozen thread was changed by a peer other than the one
+	// which froze it.
+	ErrNotFreezeAdmin = errors.New("thread was frozen by another peer")
+
+	// ErrFreezeDenied indicates a thread was frozen or unfrozen by a peer which is neither
+	// the thread owner nor a writer of a thread log.
+	ErrFreezeDenied = errors.New("only the thread owner or log writers can freeze it")
+)
+
+// FreezeState is the archival state of a thread. Replicas of a frozen thread reject records
+// beyond the heads it was frozen at, while its history is still served.
+type FreezeState struct {
+	Frozen bool
+	// Admin is the peer which froze the thread and the only peer allowed to unfreeze it,
+	// i.e. the host of the thread owner or the log of the freezing identity. It's the peer
+	// which last unfroze the thread if it isn't frozen.
+	Admin peer.ID
+	// Heads is the clock of the log heads the thread was frozen at.
+	Heads VectorClock
+	// Time is the time the state changed at, zero if the thread was never frozen.
+	Time time.Time
+}
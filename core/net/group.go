@@ -0,0 +1,47 @@
+package net
+
+import (
+	"errors"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrGroupNotFound indicates no thread is a member of the requested group.
+var ErrGroupNotFound = errors.New("thread group not found")
+
+// ErrInvalidGroup indicates an empty group name.
+var ErrInvalidGroup = errors.New("invalid thread group name")
+
+// GroupSummary describes a group of threads which sync as a unit, e.g. a workspace
+// containing many threads.
+type GroupSummary struct {
+	Name string
+	// Threads are the group members, ordered by id.
+	Threads []thread.ID
+	// Health is the worst sync health of the members.
+	Health SyncHealth
+	// Paused is set if the sync of all members is paused.
+	Paused bool
+	// Usage is the size of member records stored locally, in bytes.
+	Usage int64
+	// Quota is the maximum stored size of the group, zero if it's unlimited.
+	Quota int64
+	// OverQuota is set once remote records pushed usage beyond the quota.
+	OverQuota bool
+}
+
+// GroupSyncProgress is the sync progress of a group, summed over its members.
+type GroupSyncProgress struct {
+	Name string
+	// Records is the number of member records applied locally.
+	Records int64
+	// Expected is the number of member records once the group is synced, at least Records.
+	Expected int64
+	// Threads is the progress of each member, ordered by thread id.
+	Threads []SyncProgress
+}
+
+// Done returns whether all expected records of the group are applied locally.
+func (p GroupSyncProgress) Done() bool {
+	return p.Records >= p.Expected
+}
@@ -0,0 +1,16 @@
+package net
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// HeadsUpdate notifies that the heads of a thread have advanced.
+// It carries no records, subscribers can pull them as needed.
+type HeadsUpdate struct {
+	ThreadID thread.ID
+	// Edge is a deterministic hash of all thread heads, it changes with every update.
+	Edge uint64
+	// Heads are the current heads of the thread logs.
+	Heads map[peer.ID]thread.Head
+}
@@ -0,0 +1,21 @@
+package net
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// HeadHints are the log heads of a thread known to the peer sharing the thread, e.g. along
+// with an invite. They tell a new replica how much history to expect before it syncs.
+type HeadHints map[peer.ID]thread.Head
+
+// HeadHintsFromInfo returns the hints of the log heads of a thread.
+func HeadHintsFromInfo(info thread.Info) HeadHints {
+	h := make(HeadHints, len(info.Logs))
+	for _, lg := range info.Logs {
+		if lg.Head.Counter > 0 {
+			h[lg.ID] = lg.Head
+		}
+	}
+	return h
+}
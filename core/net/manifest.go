@@ -0,0 +1,84 @@
+package net
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// manifestDomain separates manifest signatures from other signatures of the issuer key.
+const manifestDomain = "/threads/manifest/1.0.0"
+
+// ErrManifestMismatch indicates the records of a thread don't match a manifest.
+var ErrManifestMismatch = errors.New("thread doesn't match the manifest")
+
+// Manifest attests the records of a thread up to its log heads, e.g. for anchoring
+// into a timestamping service. Root is a Merkle root over the cids of the records,
+// so the signed manifest is small regardless of the thread size.
+type Manifest struct {
+	ThreadID thread.ID
+	// Heads are the log heads the manifest was issued at, ordered by log ID.
+	Heads []ManifestHead
+	// Records is the number of attested records.
+	Records int64
+	// Root is the Merkle root over the record cids, ordered by log ID and position.
+	Root []byte
+	// Issuer is the peer which signed the manifest.
+	Issuer peer.ID
+	// Time the manifest was issued at.
+	Time time.Time
+	// Sig is the signature of Payload by the issuer key.
+	Sig []byte
+}
+
+// ManifestHead is the head of a log attested by a manifest.
+type ManifestHead struct {
+	LogID peer.ID
+	Head  thread.Head
+}
+
+// Payload returns the deterministic encoding of the manifest without its signature,
+// which is signed by the issuer. Its hash is what gets anchored externally.
+func (m Manifest) Payload() []byte {
+	var buf bytes.Buffer
+	writeBytes := func(b []byte) {
+		var l [binary.MaxVarintLen64]byte
+		buf.Write(l[:binary.PutUvarint(l[:], uint64(len(b)))])
+		buf.Write(b)
+	}
+	writeInt := func(i int64) {
+		var l [binary.MaxVarintLen64]byte
+		buf.Write(l[:binary.PutVarint(l[:], i)])
+	}
+	writeBytes([]byte(manifestDomain))
+	writeBytes(m.ThreadID.Bytes())
+	writeInt(int64(len(m.Heads)))
+	for _, h := range m.Heads {
+		writeBytes([]byte(h.LogID))
+		writeBytes(h.Head.ID.Bytes())
+		writeInt(h.Head.Counter)
+	}
+	writeInt(m.Records)
+	writeBytes(m.Root)
+	writeBytes([]byte(m.Issuer))
+	writeInt(m.Time.UnixNano())
+	return buf.Bytes()
+}
+
+// VerifySignature checks that the manifest is signed by its issuer. It doesn't
+// require the thread, use Net.VerifyManifest to check the thread records.
+func (m Manifest) VerifySignature() error {
+	pk, err := m.Issuer.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("extracting issuer key: %w", err)
+	}
+	if ok, err := pk.Verify(m.Payload(), m.Sig); err != nil || !ok {
+		return errors.New("bad manifest signature")
+	}
+	return nil
+}
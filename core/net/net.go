@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
+	lstore "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
 )
 
@@ -22,6 +24,133 @@ type Net interface {
 
 	// Host provides a network identity.
 	Host() host.Host
+
+	// SubscribeIntegrity returns a read-only channel that receives corrupted logstore entries,
+	// and the same entries once they're repaired, if the logstore checks its entries.
+	// Cancelling the context effectively unsubscribes and releases the resources.
+	SubscribeIntegrity(ctx context.Context) (<-chan lstore.IntegrityEvent, error)
+
+	// GetAncestryProof returns a proof that record rid is part of a log,
+	// which is verified against the log head with cbor.VerifyAncestryProof.
+	GetAncestryProof(ctx context.Context, id thread.ID, lid peer.ID, rid cid.Cid) (AncestryProof, error)
+
+	// QueryRecords requests the thread records matching a selector from a peer, which evaluates it
+	// over its local logs. Records are returned with proofs linking them to their log heads. Proofs
+	// are verified, and so are the matches if the host can decrypt the selector target.
+	QueryRecords(ctx context.Context, id thread.ID, pid peer.ID, sel Selector, opts ...ThreadOption) ([]QueryResult, error)
+
+	// IssueManifest returns a manifest of the thread records up to the current log heads,
+	// signed with the host key. It's suitable for anchoring into external systems.
+	IssueManifest(ctx context.Context, id thread.ID, opts ...ThreadOption) (Manifest, error)
+
+	// VerifyManifest checks the manifest signature and that the thread logs contain
+	// the records it attests, up to its heads. Records added since are ignored.
+	// A thread which doesn't match returns ErrManifestMismatch.
+	VerifyManifest(ctx context.Context, id thread.ID, m Manifest, opts ...ThreadOption) error
+
+	// VerifyThread checks that the blocks of the thread records are stored locally and
+	// match their cids, walking back the logs from their heads. Damaged records are
+	// re-fetched from the peers holding their logs in the background.
+	VerifyThread(ctx context.Context, id thread.ID, opts ...ThreadOption) (ThreadVerification, error)
+
+	// GetSyncProgress returns the number of thread records applied locally out of the records expected
+	// from the head hints the thread was added with and the log heights learned from peers.
+	GetSyncProgress(ctx context.Context, id thread.ID, opts ...ThreadOption) (SyncProgress, error)
+
+	// MarkSeen marks a record of a log and the log records before it as seen by the host,
+	// which is acknowledged to the log author if record acks are enabled.
+	MarkSeen(ctx context.Context, id thread.ID, lid peer.ID, rid cid.Cid, opts ...ThreadOption) error
+
+	// GetDeliveryReport returns the peers which acknowledged a record of a log authored by the host.
+	GetDeliveryReport(ctx context.Context, id thread.ID, lid peer.ID, rid cid.Cid, opts ...ThreadOption) (DeliveryReport, error)
+
+	// Follow registers the host as a follower of a thread with the thread peers,
+	// which then push new records to the host.
+	Follow(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// Unfollow withdraws the host's follow of a thread.
+	Unfollow(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// Followers returns the known followers of a thread, oldest first.
+	Followers(ctx context.Context, id thread.ID, opts ...ThreadOption) ([]Follower, error)
+
+	// FreezeThread archives a thread at its current heads, the freeze is replicated to the
+	// thread peers. Records are rejected until the thread is unfrozen by the host. Only the thread
+	// owner and identities with a log of the thread freeze it.
+	FreezeThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// UnfreezeThread accepts records of a thread frozen by the host again.
+	UnfreezeThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// GetFreezeState returns the archival state of a thread.
+	GetFreezeState(ctx context.Context, id thread.ID, opts ...ThreadOption) (FreezeState, error)
+
+	// RedactRecord replaces the body of a record with a tombstone across the thread replicas, e.g.
+	// to remove sensitive content. A redaction record is added to the log of the identity, which
+	// has to be the record author and sign the redaction, see WithSigner. The record and its event
+	// header are kept, so logs stay linked.
+	RedactRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts ...ThreadOption) (ThreadRecord, error)
+
+	// ListRedactions returns the redactions of the thread records known to the host.
+	ListRedactions(ctx context.Context, id thread.ID, opts ...ThreadOption) ([]Redaction, error)
+
+	// ApproveLog activates a log pushed by a new writer of a thread requiring log approval. A log
+	// approval record is added to the log of the identity, which has to be the thread owner. Thread
+	// peers activate the log once they observe the record, see WithLogApproval.
+	ApproveLog(ctx context.Context, id thread.ID, lid peer.ID, opts ...ThreadOption) (ThreadRecord, error)
+
+	// PendingLogs returns the logs of a thread waiting for the approval of the thread owner, oldest first.
+	PendingLogs(ctx context.Context, id thread.ID, opts ...ThreadOption) ([]PendingLog, error)
+
+	// SetWriteLimits declares rate limits of the records written by each identity to a thread. A write
+	// limits record is added to the log of the identity, which has to be the thread owner. Thread peers
+	// quarantine the remote records exceeding the limits once they observe the record.
+	SetWriteLimits(ctx context.Context, id thread.ID, limits WriteLimits, opts ...ThreadOption) (ThreadRecord, error)
+
+	// GetWriteLimits returns the write limits of a thread, which are zero if they weren't declared.
+	GetWriteLimits(ctx context.Context, id thread.ID, opts ...ThreadOption) (WriteLimits, error)
+
+	// QuarantinedRecords returns the records of a thread held back by its write limits, by log.
+	QuarantinedRecords(ctx context.Context, id thread.ID, opts ...ThreadOption) ([]QuarantinedRecord, error)
+
+	// ReleaseQuarantined applies the quarantined records of a log regardless of the write limits.
+	ReleaseQuarantined(ctx context.Context, id thread.ID, lid peer.ID, opts ...ThreadOption) error
+
+	// DiscardQuarantined drops the quarantined records of a log. The log isn't accepted past the
+	// first discarded record anymore.
+	DiscardQuarantined(ctx context.Context, id thread.ID, lid peer.ID, opts ...ThreadOption) error
+
+	// PauseSync stops exchanging edges, pulling and pushing records of a thread, e.g. to save
+	// bandwidth on metered connections, while the thread stays readable and writable locally.
+	// It returns once the sync calls in flight are drained. The pause persists across restarts.
+	PauseSync(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// ResumeSync syncs a paused thread again. Records created meanwhile are pulled by the thread peers.
+	ResumeSync(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// AddToGroup makes a thread a member of a named group, leaving its previous group if any.
+	// Group members are pulled together in the same edge exchanges, share the group quota and
+	// are paused, resumed and reported on as a unit. Membership persists across restarts.
+	AddToGroup(ctx context.Context, group string, id thread.ID, opts ...ThreadOption) error
+
+	// RemoveFromGroup makes a thread leave its group. The sync state of the thread is kept.
+	RemoveFromGroup(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// GetGroup returns the summary of a group, or ErrGroupNotFound if it has no members.
+	GetGroup(ctx context.Context, group string, opts ...ThreadOption) (GroupSummary, error)
+
+	// PauseGroup pauses the sync of all group members, see PauseSync.
+	PauseGroup(ctx context.Context, group string, opts ...ThreadOption) error
+
+	// ResumeGroup resumes the sync of all group members, see ResumeSync.
+	ResumeGroup(ctx context.Context, group string, opts ...ThreadOption) error
+
+	// GetGroupProgress returns the sync progress of all group members, see GetSyncProgress.
+	GetGroupProgress(ctx context.Context, group string, opts ...ThreadOption) (GroupSyncProgress, error)
+
+	// SetPowerState tells the host about the platform state. Periodic pulls are suspended while the
+	// app is backgrounded or low on battery, and resume with a catch-up of the most active threads first.
+	SetPowerState(state PowerState)
 }
 
 // API is the network interface for thread orchestration.
@@ -44,11 +173,27 @@ type API interface {
 	// GetThread returns thread info by id.
 	GetThread(ctx context.Context, id thread.ID, opts ...ThreadOption) (thread.Info, error)
 
+	// ListThreads returns a page of known threads matching opts, ordered by thread id.
+	// Use the returned page token with WithPageToken to request the next page.
+	ListThreads(ctx context.Context, opts ...ListOption) (ThreadsPage, error)
+
+	// GetVectorClock returns the clock of the current log heads of a thread, to check
+	// whether the host has seen all records up to a clock received elsewhere.
+	GetVectorClock(ctx context.Context, id thread.ID, opts ...ThreadOption) (VectorClock, error)
+
+	// GetThreadLogs returns a page of thread logs by thread id, ordered by log id.
+	GetThreadLogs(ctx context.Context, id thread.ID, opts ...ListOption) (LogsPage, error)
+
 	// PullThread requests new records from each known thread host.
 	// This method is called internally on an interval as part of the orchestration protocol.
 	// Calling it manually can be useful when new records are known to be available.
 	PullThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
 
+	// PullThreadFrom syncs all logs and records of a thread from the peer at addr,
+	// which doesn't need to be a known thread host, e.g., a backup server.
+	// Records which are already known are skipped.
+	PullThreadFrom(ctx context.Context, id thread.ID, addr ma.Multiaddr, opts ...ThreadOption) error
+
 	// DeleteThread removes a thread by id and opts.
 	DeleteThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
 
@@ -56,6 +201,10 @@ type API interface {
 	// All logs and records are pushed to the new host.
 	AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...ThreadOption) (peer.ID, error)
 
+	// UpdateLogAddrs replaces the addresses of a log managed by the host, e.g. after the host
+	// moved networks. The addresses are signed by the log key and pushed to all thread peers.
+	UpdateLogAddrs(ctx context.Context, id thread.ID, lid peer.ID, addrs []ma.Multiaddr, opts ...ThreadOption) error
+
 	// CreateRecord creates and adds a new record with body to a thread by id.
 	CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...ThreadOption) (ThreadRecord, error)
 
@@ -65,9 +214,93 @@ type API interface {
 	// GetRecord returns a record by thread id and cid.
 	GetRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts ...ThreadOption) (Record, error)
 
+	// ActivityFeed returns summaries of the thread records created after since, newest first and
+	// across all logs, for rendering recent changes. At most limit summaries are returned if it's
+	// positive. The feed is bounded by the number of recent records the host indexes per thread.
+	ActivityFeed(ctx context.Context, id thread.ID, since time.Time, limit int, opts ...ThreadOption) ([]RecordSummary, error)
+
+	// AnnotateRecord replaces the local annotation of a thread record, an empty annotation removes it.
+	// The record must be available locally, otherwise ErrRecordNotFound is returned.
+	AnnotateRecord(ctx context.Context, id thread.ID, rid cid.Cid, a Annotation, opts ...ThreadOption) error
+
+	// GetAnnotation returns the local annotation of a thread record, which is empty if there's none.
+	GetAnnotation(ctx context.Context, id thread.ID, rid cid.Cid, opts ...ThreadOption) (RecordAnnotation, error)
+
+	// QueryAnnotations returns the local annotations of thread records matching opts,
+	// most recently updated first.
+	QueryAnnotations(ctx context.Context, id thread.ID, opts ...AnnotationOption) ([]RecordAnnotation, error)
+
 	// Subscribe returns a read-only channel that receives newly created / added thread records.
 	// Cancelling the context effectively unsubscribes and releases the resources.
 	Subscribe(ctx context.Context, opts ...SubOption) (<-chan ThreadRecord, error)
+
+	// SubscribeHeads returns a read-only channel that receives thread heads each time they advance.
+	// Unlike Subscribe, updates don't include records, so they're cheap to deliver and decode.
+	// Cancelling the context effectively unsubscribes and releases the resources.
+	SubscribeHeads(ctx context.Context, opts ...SubOption) (<-chan HeadsUpdate, error)
+
+	// SubscribeSyncProgress returns a read-only channel that receives the sync progress of a thread
+	// each time it changes, starting with the current one, e.g. to show a progress bar during first sync.
+	// Cancelling the context effectively unsubscribes and releases the resources.
+	SubscribeSyncProgress(ctx context.Context, id thread.ID, opts ...ThreadOption) (<-chan SyncProgress, error)
+
+	// PublishPresence broadcasts the presence of the token identity with an optional app payload
+	// to thread peers over pubsub. Presence expires unless it's periodically published again.
+	// The payload is encrypted with the thread service key. Presence of identities other than
+	// the host is signed with WithSigner, or carries a signature given with WithPresenceSig.
+	PublishPresence(ctx context.Context, id thread.ID, status PresenceStatus, payload []byte, opts ...ThreadOption) error
+
+	// SubscribePresence returns a read-only channel that receives the presence of thread identities,
+	// starting with the currently known ones. Expired presence is received with PresenceOffline status.
+	// Cancelling the context effectively unsubscribes and releases the resources.
+	SubscribePresence(ctx context.Context, id thread.ID, opts ...ThreadOption) (<-chan Presence, error)
+}
+
+// ThreadSummary describes a thread in a listing.
+type ThreadSummary struct {
+	ID           thread.ID
+	Tags         []string
+	LastActivity time.Time
+	LastSync     time.Time
+	Health       SyncHealth
+	// Usage is the size of records stored locally, in bytes.
+	Usage int64
+	// Quota is the maximum stored size of the thread, zero if it's unlimited.
+	Quota int64
+	// OverQuota is set once remote records pushed usage beyond the quota.
+	OverQuota bool
+	// StoredBlocks and StoredBytes are the number and size of the blocks stored locally
+	// for the thread. They're zero for threads added before blocks were tracked by thread.
+	StoredBlocks int64
+	StoredBytes  int64
+}
+
+// RecordSummary describes a record in a thread activity feed.
+type RecordSummary struct {
+	ID    cid.Cid
+	LogID peer.ID
+	// Author is the identity which created the record, if any.
+	Author thread.PubKey
+	// Time the record was created at, it's zero for records of older hosts.
+	Time time.Time
+	// Size of the record along with its event blocks, in bytes.
+	Size int
+	// Type is the hint of the record body type set by the creator, if any.
+	Type string
+}
+
+// ThreadsPage is a single page of a thread listing.
+type ThreadsPage struct {
+	Threads []ThreadSummary
+	// NextPageToken continues the listing, it's empty for the last page.
+	NextPageToken string
+}
+
+// LogsPage is a single page of a thread's logs.
+type LogsPage struct {
+	Logs []thread.LogInfo
+	// NextPageToken continues the listing, it's empty for the last page.
+	NextPageToken string
 }
 
 // Token is used to restrict network APIs to a single app.App.
@@ -1,15 +1,25 @@
 package net
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/textileio/go-threads/core/thread"
 )
 
 // NewThreadOptions defines options to be used when creating / adding a thread.
 type NewThreadOptions struct {
-	ThreadKey thread.Key
-	LogKey    crypto.Key
-	Token     thread.Token
+	ThreadKey   thread.Key
+	LogKey      crypto.Key
+	Token       thread.Token
+	Tags        []string
+	Quota       int64
+	HeadHints   HeadHints
+	SyncPolicy  SyncPolicy
+	Progressive bool
+	LogApproval bool
+	Owner       thread.PubKey
+	SkipLinks   bool
 }
 
 // NewThreadOption specifies new thread options.
@@ -39,10 +49,80 @@ func WithNewThreadToken(t thread.Token) NewThreadOption {
 	}
 }
 
+// WithNewThreadTags labels the thread with tags, which can be used to filter thread listings.
+func WithNewThreadTags(tags ...string) NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.Tags = append(args.Tags, tags...)
+	}
+}
+
+// WithNewThreadQuota limits the size of records stored locally for the thread, in bytes.
+// Once reached, creating records fails with ErrQuotaExceeded, while records of other
+// peers are still accepted. Zero keeps the current quota.
+func WithNewThreadQuota(bytes int64) NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.Quota = bytes
+	}
+}
+
+// WithHeadHints passes the log heads known to the peer sharing the thread when it's added.
+// Sync progress is reported against the hinted heads, and records are pulled from the
+// sharing peer first until they're reached.
+func WithHeadHints(hints HeadHints) NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.HeadHints = hints
+	}
+}
+
+// WithProgressiveBootstrap loads the history of an added thread newest-first. The newest
+// records of each log are pulled and applied first, older records are back-filled in the
+// background and aren't delivered to subscribers. It has no effect on threads connected to apps.
+func WithProgressiveBootstrap() NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.Progressive = true
+	}
+}
+
+// WithSkipLinks adds skip links to the records the host appends to the thread, which shorten
+// ancestry proofs, see Net.GetAncestryProof. Skip links are part of the signed record node, so
+// hosts running versions without skip links can't verify these records. Enable it only once all
+// the thread peers support them, records without skip links are verified by all versions.
+func WithSkipLinks() NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.SkipLinks = true
+	}
+}
+
+// WithLogApproval requires the owner identity to approve the logs of new writers of the thread.
+// Logs pushed by other peers are held as pending until a log approval record of the owner is
+// observed, see Net.ApproveLog. A nil owner is the identity creating the thread. Logs of the
+// peer a thread is added from are trusted when it's added.
+func WithLogApproval(owner thread.PubKey) NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.LogApproval = true
+		args.Owner = owner
+	}
+}
+
+// WithNewThreadSyncPolicy bounds the sync of the thread, so it doesn't hold up the sync
+// of other threads. The zero policy keeps the current one.
+func WithNewThreadSyncPolicy(p SyncPolicy) NewThreadOption {
+	return func(args *NewThreadOptions) {
+		args.SyncPolicy = p
+	}
+}
+
 // ThreadOptions defines options for interacting with a thread.
 type ThreadOptions struct {
-	Token    thread.Token
-	APIToken Token
+	Token          thread.Token
+	APIToken       Token
+	IdempotencyKey string
+	RecordType     string
+	Priority       RecordPriority
+	Extensions     RecordExtensions
+	Signer         thread.Identity
+	PresenceSig    []byte
+	PresenceTime   time.Time
 }
 
 // ThreadOption specifies thread options.
@@ -65,9 +145,76 @@ func WithAPIToken(t Token) ThreadOption {
 	}
 }
 
+// WithSigner signs records authorizing thread changes, e.g. log approvals, with the identity
+// of the token. Records of the host identity are signed with the host key. Other records are
+// signed if a signer is given, so they're metered by their identity under write limits.
+func WithSigner(signer thread.Identity) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.Signer = signer
+	}
+}
+
+// WithPresenceSig provides the identity signature of presence published at t, see
+// PresenceIdentityPayload. It's used by clients which can't pass a signer to the host.
+func WithPresenceSig(t time.Time, sig []byte) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.PresenceTime = t
+		args.PresenceSig = sig
+	}
+}
+
+// WithIdempotencyKey identifies a record creation, so retries of CreateRecord with the
+// same key return the record created first instead of appending a new one.
+// Keys are remembered by the host for a limited time only.
+func WithIdempotencyKey(key string) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.IdempotencyKey = key
+	}
+}
+
+// WithRecordType sets the content type of the record body, recorded in the event header
+// of a record created with CreateRecord, see EventHeader.Type. It's exposed by the thread
+// activity feed.
+func WithRecordType(typ string) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.RecordType = typ
+	}
+}
+
+// WithRecordExtensions attaches extensions to the header of a record created with CreateRecord.
+// Their keys must be in registered namespaces, see RegisterExtensionNamespace.
+func WithRecordExtensions(exts RecordExtensions) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.Extensions = exts
+	}
+}
+
+// RecordPriority is the propagation priority of a created record.
+type RecordPriority int32
+
+const (
+	// PriorityNormal records are pushed to log addresses and the thread topic right away.
+	PriorityNormal RecordPriority = iota
+	// PriorityHigh records are pushed right away without waiting for streams
+	// used by other calls, e.g. small metadata updates during large imports.
+	PriorityHigh
+	// PriorityLow records are pushed in batches, e.g. bulk imports.
+	PriorityLow
+)
+
+// WithRecordPriority sets the propagation priority of a record created with CreateRecord.
+// Records are pushed with normal priority by default.
+func WithRecordPriority(p RecordPriority) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.Priority = p
+	}
+}
+
 // SubOptions defines options for a thread subscription.
+// Subscriptions without thread or tag filters cover all threads, including the ones added later.
 type SubOptions struct {
 	ThreadIDs thread.IDSlice
+	Tags      []string
 	Token     thread.Token
 }
 
@@ -82,9 +229,165 @@ func WithSubFilter(id thread.ID) SubOption {
 	}
 }
 
+// WithSubTag restricts the subscription to threads labeled with a tag.
+// Threads are matched when their records arrive, so threads added with the tag
+// after subscribing are included. Use this option multiple times to subscribe to
+// threads having any of the tags, it combines with WithSubFilter in the same way.
+func WithSubTag(tag string) SubOption {
+	return func(args *SubOptions) {
+		args.Tags = append(args.Tags, tag)
+	}
+}
+
 // WithSubToken provides authorization for a subscription.
 func WithSubToken(t thread.Token) SubOption {
 	return func(args *SubOptions) {
 		args.Token = t
 	}
 }
+
+// SyncHealth describes how recently a thread was synced with remote peers.
+type SyncHealth int
+
+const (
+	// SyncHealthAny matches threads regardless of their sync health.
+	SyncHealthAny SyncHealth = iota
+	// SyncHealthSynced indicates the thread was recently synced with a remote peer.
+	SyncHealthSynced
+	// SyncHealthStale indicates the thread was synced before, but not recently.
+	SyncHealthStale
+	// SyncHealthUnsynced indicates the thread was never synced with a remote peer.
+	SyncHealthUnsynced
+)
+
+// String returns a human-readable sync health.
+func (h SyncHealth) String() string {
+	switch h {
+	case SyncHealthAny:
+		return "any"
+	case SyncHealthSynced:
+		return "synced"
+	case SyncHealthStale:
+		return "stale"
+	case SyncHealthUnsynced:
+		return "unsynced"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncPolicy bounds the resources spent on syncing a thread. Zero fields are unlimited.
+type SyncPolicy struct {
+	// MaxPullDuration is the maximum duration of a single pull of the thread from a peer.
+	// Records received before the deadline are kept, the rest is pulled later.
+	MaxPullDuration time.Duration
+	// RetryBudget is the number of failed pulls of the thread tolerated per exchange round,
+	// further pulls are skipped until the next round.
+	RetryBudget int
+	// StalenessTolerance is how long after the last sync the thread is left out of
+	// periodic exchanges. It also replaces the default duration after which the thread
+	// is reported as stale, if it's longer.
+	StalenessTolerance time.Duration
+}
+
+// IsZero returns whether the policy is unlimited.
+func (p SyncPolicy) IsZero() bool {
+	return p == SyncPolicy{}
+}
+
+// ListOptions defines options for paginated listings.
+type ListOptions struct {
+	Token        thread.Token
+	PageToken    string
+	Limit        int
+	Tag          string
+	ActiveSince  time.Time
+	ActiveBefore time.Time
+	Health       SyncHealth
+}
+
+// ListOption specifies listing options.
+type ListOption func(*ListOptions)
+
+// WithListToken provides authorization for a listing.
+func WithListToken(t thread.Token) ListOption {
+	return func(args *ListOptions) {
+		args.Token = t
+	}
+}
+
+// WithPageToken continues a listing from the page token returned with the previous page.
+func WithPageToken(pt string) ListOption {
+	return func(args *ListOptions) {
+		args.PageToken = pt
+	}
+}
+
+// WithPageLimit restricts the number of items returned in a single page.
+func WithPageLimit(limit int) ListOption {
+	return func(args *ListOptions) {
+		args.Limit = limit
+	}
+}
+
+// WithTagFilter restricts a thread listing to the threads labeled with tag.
+func WithTagFilter(tag string) ListOption {
+	return func(args *ListOptions) {
+		args.Tag = tag
+	}
+}
+
+// WithActivityWindow restricts a thread listing to the threads with the last activity
+// in [since, before). A zero time leaves the corresponding bound open.
+func WithActivityWindow(since, before time.Time) ListOption {
+	return func(args *ListOptions) {
+		args.ActiveSince = since
+		args.ActiveBefore = before
+	}
+}
+
+// WithSyncHealthFilter restricts a thread listing to the threads with the given sync health.
+func WithSyncHealthFilter(h SyncHealth) ListOption {
+	return func(args *ListOptions) {
+		args.Health = h
+	}
+}
+
+// AnnotationOptions defines options for querying record annotations.
+type AnnotationOptions struct {
+	Token   thread.Token
+	Unread  bool
+	Starred bool
+	Label   string
+}
+
+// AnnotationOption specifies annotation query options.
+type AnnotationOption func(*AnnotationOptions)
+
+// WithAnnotationToken provides authorization for an annotation query.
+func WithAnnotationToken(t thread.Token) AnnotationOption {
+	return func(args *AnnotationOptions) {
+		args.Token = t
+	}
+}
+
+// WithUnreadFilter restricts an annotation query to the records not marked as read.
+func WithUnreadFilter() AnnotationOption {
+	return func(args *AnnotationOptions) {
+		args.Unread = true
+	}
+}
+
+// WithStarredFilter restricts an annotation query to the starred records.
+func WithStarredFilter() AnnotationOption {
+	return func(args *AnnotationOptions) {
+		args.Starred = true
+	}
+}
+
+// WithLabelFilter restricts an annotation query to the records labeled with label.
+func WithLabelFilter(label string) AnnotationOption {
+	return func(args *AnnotationOptions) {
+		args.Label = label
+	}
+}
@@ -0,0 +1,6 @@
+package net
+
+import "errors"
+
+// ErrSyncPaused indicates the sync of a thread is paused, so it isn't pulled from peers.
+var ErrSyncPaused = errors.New("thread sync is paused")
@@ -0,0 +1,27 @@
+package net
+
+// PowerState is the platform state of the host reported by an embedding app, e.g., on mobile.
+type PowerState int
+
+const (
+	// PowerForeground indicates the app is in use, threads are pulled periodically.
+	PowerForeground PowerState = iota
+	// PowerBackground indicates the app is backgrounded, periodic pulls are suspended.
+	PowerBackground
+	// PowerLowBattery indicates the device is low on battery, periodic pulls are suspended.
+	PowerLowBattery
+)
+
+// String returns a human-readable power state.
+func (s PowerState) String() string {
+	switch s {
+	case PowerForeground:
+		return "foreground"
+	case PowerBackground:
+		return "background"
+	case PowerLowBattery:
+		return "low battery"
+	default:
+		return "unknown"
+	}
+}
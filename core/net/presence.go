@@ -0,0 +1,75 @@
+package net
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrPresenceUnavailable indicates presence is not available, since pubsub is disabled.
+var ErrPresenceUnavailable = errors.New("presence requires pubsub")
+
+// PresenceStatus is the presence status of an identity in a thread.
+type PresenceStatus int
+
+const (
+	// PresenceOffline indicates the identity left the thread or its presence expired.
+	PresenceOffline PresenceStatus = iota
+	// PresenceOnline indicates the identity is active in the thread.
+	PresenceOnline
+	// PresenceAway indicates the identity is connected but idle.
+	PresenceAway
+)
+
+func (s PresenceStatus) String() string {
+	switch s {
+	case PresenceOffline:
+		return "offline"
+	case PresenceOnline:
+		return "online"
+	case PresenceAway:
+		return "away"
+	default:
+		return "unknown"
+	}
+}
+
+// Presence is the latest known presence of an identity in a thread.
+// Presence is ephemeral, it's exchanged over pubsub and never written to a log.
+type Presence struct {
+	ThreadID thread.ID
+	// Peer is the host the presence was published from.
+	Peer peer.ID
+	// Identity is the present identity, it's nil if the presence was published without a token.
+	// Presence of an identity is signed by it, see PresenceIdentityPayload.
+	Identity thread.PubKey
+	Status   PresenceStatus
+	// Payload is an app-defined payload, e.g., a cursor position.
+	Payload []byte
+	// Time is the time the presence was published.
+	Time time.Time
+}
+
+// PresenceIdentityPayload returns the payload signed by the identity of a presence. It binds
+// the thread, the publishing peer, the status, the payload and the time of the presence,
+// so the signature can't be replayed by other peers or in later presence.
+func PresenceIdentityPayload(p Presence) []byte {
+	var buf bytes.Buffer
+	write := func(b []byte) {
+		var l [binary.MaxVarintLen64]byte
+		buf.Write(l[:binary.PutUvarint(l[:], uint64(len(b)))])
+		buf.Write(b)
+	}
+	var num [binary.MaxVarintLen64]byte
+	write([]byte("threads/presence"))
+	write(p.ThreadID.Bytes())
+	write([]byte(p.Peer))
+	write(num[:binary.PutUvarint(num[:], uint64(p.Status))])
+	write(p.Payload)
+	write(num[:binary.PutVarint(num[:], p.Time.UnixNano())])
+	return buf.Bytes()
+}
@@ -0,0 +1,42 @@
+package net
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// SyncProgress is the sync progress of a thread. The expected records are estimated from
+// the log heights learned from peers and the head hints the thread was added with.
+type SyncProgress struct {
+	ThreadID thread.ID
+	// Records is the number of records applied locally.
+	Records int64
+	// Expected is the number of records once the thread is synced, at least Records.
+	Expected int64
+	// Logs is the progress of each log, ordered by log id.
+	Logs []LogSyncProgress
+	// Bytes is the size of the thread records received from peers since the host started.
+	Bytes int64
+	// ETA is the estimated time until the expected records are applied, from the rate they're
+	// applied at since the progress was first requested. It's zero if unknown or done.
+	ETA time.Duration
+}
+
+// Done returns whether all expected records are applied locally.
+func (p SyncProgress) Done() bool {
+	return p.Records >= p.Expected
+}
+
+// LogSyncProgress is the sync progress of a thread log.
+type LogSyncProgress struct {
+	LogID peer.ID
+	// Applied is the height of the local log head.
+	Applied int64
+	// Height is the highest known height of the log, at least Applied.
+	Height int64
+	// Floor is the height of the oldest record stored while the log is back-filled
+	// after a progressive bootstrap, zero once the log is stored in full.
+	Floor int64
+}
@@ -0,0 +1,32 @@
+package net
+
+import "github.com/libp2p/go-libp2p-core/peer"
+
+// SelectorTarget is the record node a selector is evaluated over.
+type SelectorTarget int
+
+const (
+	// SelectHeader evaluates selectors over the event header, which requires the read or index key.
+	SelectHeader SelectorTarget = iota
+	// SelectBody evaluates selectors over the record body, which requires the read key.
+	SelectBody
+)
+
+// Selector matches records whose IPLD node field equals a value, e.g. headers of a type.
+type Selector struct {
+	// Target is the record node holding the field.
+	Target SelectorTarget
+	// Path is the slash-separated path of the field within the target node, e.g. "type" or
+	// "time" of event headers. Links aren't traversed, so the field has to be part of the node
+	// itself. An empty path selects the whole node.
+	Path string
+	// Value the field has to equal in the IPLD data model, e.g. a string, an integer or a cid.
+	Value interface{}
+}
+
+// QueryResult is a record matching a selector, along with the proof it's part of its log.
+type QueryResult struct {
+	LogID  peer.ID
+	Record Record
+	Proof  AncestryProof
+}
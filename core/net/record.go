@@ -2,6 +2,8 @@ package net
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
@@ -10,6 +12,51 @@ import (
 	"github.com/textileio/go-threads/core/thread"
 )
 
+// RecordOverhead is an upper bound of the bytes added to a record body by encryption and
+// linking. It counts towards the maximum record size when records are created.
+const RecordOverhead = 1 << 10
+
+// ErrRecordTooLarge indicates a record exceeds the maximum record size of a host.
+var ErrRecordTooLarge = errors.New("record too large")
+
+// ErrLogFenced indicates an own log is written by another process sharing the datastore,
+// which would fork the log. Records are refused until the other writer's lease expires.
+var ErrLogFenced = errors.New("log is written by another process")
+
+// RecordTooLargeError is returned for records exceeding the maximum record size of a host.
+// It matches ErrRecordTooLarge with errors.Is.
+type RecordTooLargeError struct {
+	Size    int
+	MaxSize int
+}
+
+func (e *RecordTooLargeError) Error() string {
+	return fmt.Sprintf("%s: %d bytes exceeds the maximum of %d bytes", ErrRecordTooLarge, e.Size, e.MaxSize)
+}
+
+func (e *RecordTooLargeError) Is(target error) bool {
+	return target == ErrRecordTooLarge
+}
+
+// ErrQuotaExceeded indicates a record would exceed the storage quota of a thread.
+var ErrQuotaExceeded = errors.New("thread storage quota exceeded")
+
+// QuotaExceededError is returned for local records exceeding the storage quota of a thread.
+// It matches ErrQuotaExceeded with errors.Is.
+type QuotaExceededError struct {
+	Usage int64
+	Size  int64
+	Quota int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %d bytes stored, adding %d bytes exceeds the quota of %d bytes", ErrQuotaExceeded, e.Usage, e.Size, e.Quota)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
 // Record is the most basic component of a log.
 type Record interface {
 	format.Node
@@ -23,16 +70,36 @@ type Record interface {
 	// PrevID returns the cid of the previous record.
 	PrevID() cid.Cid
 
+	// SkipIDs returns skip links of the record. Link i points to the
+	// record 2^(i+1) positions back in the log. Records have a link for each power
+	// of two above one that divides their position, so many have none.
+	SkipIDs() []cid.Cid
+
 	// Sig returns a signature from the log key.
 	Sig() []byte
 
 	// PubKey of the identity used to author this record.
 	PubKey() []byte
 
+	// Extensions returns the key/value pairs attached to the record header, if any.
+	Extensions() RecordExtensions
+
 	// Verify returns a nil error if the node signature is valid.
 	Verify(key crypto.PubKey) error
 }
 
+// AncestryProof proves a record is an ancestor of a log head. Records lead from
+// the head to the record, following skip links where possible, so a proof holds
+// O(log n) records for a log of n records.
+type AncestryProof struct {
+	// Head is the log head the proof starts from.
+	Head thread.Head
+	// Position of the proven record in the log, counting from 1.
+	Position int64
+	// Records from the head to the proven record, both included.
+	Records []Record
+}
+
 // ThreadRecord wraps Record within a thread and log context.
 type ThreadRecord interface {
 	// Value returns the underlying record.
@@ -43,4 +110,9 @@ type ThreadRecord interface {
 
 	// LogID returns the record's log ID.
 	LogID() peer.ID
+
+	// Clock returns the vector clock of the thread once the record was added, which has
+	// the height of the record for its log. It's nil for records which weren't delivered
+	// by Subscribe or returned by CreateRecord.
+	Clock() VectorClock
 }
@@ -0,0 +1,39 @@
+package net
+
+import (
+	"errors"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// RedactionRecordType is the record type of redaction records, see Net.RedactRecord.
+	RedactionRecordType = "threads/redaction"
+
+	// ExtensionRedact is the name of the threads record extension holding the ID of the record
+	// a redaction record redacts. Unlike the body, it's readable by hosts without the read key.
+	ExtensionRedact = "redact"
+)
+
+var (
+	// ErrRecordRedacted indicates the body of a record was redacted.
+	ErrRecordRedacted = errors.New("record body was redacted")
+
+	// ErrRedactionDenied indicates a record was redacted by an identity other than its author.
+	ErrRedactionDenied = errors.New("only the record author can redact it")
+)
+
+// Redaction is the removal of a record body across the thread replicas. The record itself is
+// kept along with its event header, so logs stay linked and record signatures can be checked.
+type Redaction struct {
+	// RecordID is the redacted record.
+	RecordID cid.Cid
+	// RedactionID is the redaction record.
+	RedactionID cid.Cid
+	// LogID is the log of the redaction record.
+	LogID peer.ID
+	// Applied is whether the body was removed locally. Redactions of records which weren't
+	// received yet are applied once the records are, if the redacting identity authored them.
+	Applied bool
+}
@@ -0,0 +1,22 @@
+package net
+
+import (
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// ThreadVerification is the outcome of checking the local blocks of the thread records.
+type ThreadVerification struct {
+	// Records is the number of checked records.
+	Records int64
+	// Damaged are the records with missing or corrupt blocks, which are re-fetched from peers.
+	Damaged []DamagedRecord
+}
+
+// DamagedRecord is a log record whose blocks are missing locally or don't match their cids.
+type DamagedRecord struct {
+	LogID    peer.ID
+	RecordID cid.Cid
+	// Blocks are the damaged blocks, i.e. the record envelope, its event, header or body.
+	Blocks []cid.Cid
+}
@@ -0,0 +1,73 @@
+package net
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+const (
+	// WriteLimitsRecordType is the record type of write limits records, see Net.SetWriteLimits.
+	WriteLimitsRecordType = "threads/write-limits"
+
+	// ExtensionWriteLimits is the name of the threads record extension holding the write limits
+	// declared by a write limits record, so they're enforced by hosts without the read key too.
+	ExtensionWriteLimits = "write-limits"
+)
+
+var (
+	// ErrNoThreadOwner indicates a thread has no owner, see WithLogApproval.
+	ErrNoThreadOwner = errors.New("thread has no owner")
+
+	// ErrLogNotQuarantined indicates a reviewed log has no quarantined records.
+	ErrLogNotQuarantined = errors.New("log has no quarantined records")
+)
+
+// WriteLimit bounds the rate of records written by an identity. Zero fields are unlimited.
+type WriteLimit struct {
+	RecordsPerMinute int
+	BytesPerHour     int64
+}
+
+// IsZero returns whether the limit is unlimited.
+func (l WriteLimit) IsZero() bool {
+	return l.RecordsPerMinute == 0 && l.BytesPerHour == 0
+}
+
+// WriteLimits are the write limits of a thread declared by its owner. Records are metered by
+// their identity only if they're signed by it, see WithSigner, other records are metered by log
+// under the default limit. Records signed by the owner aren't limited.
+type WriteLimits struct {
+	// Default applies to each identity without a limit of its own.
+	Default WriteLimit
+	// Identities are the limits of specific identities, by their string encoding.
+	Identities map[string]WriteLimit
+}
+
+// Limit returns the limit of an identity.
+func (l WriteLimits) Limit(identity thread.PubKey) WriteLimit {
+	if limit, ok := l.Identities[identity.String()]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// QuarantinedRecord is a remote record held back because its author exceeded the write limits
+// of the thread. Quarantined records are applied once they're within the limits, or released
+// or discarded by the host admin, see Net.ReleaseQuarantined.
+type QuarantinedRecord struct {
+	// RecordID is the quarantined record.
+	RecordID cid.Cid
+	// LogID is the log of the record. Later records of the log are quarantined along with it.
+	LogID peer.ID
+	// Author is the identity which exceeded its limit, it's nil if the records were metered
+	// by log, i.e. they aren't signed by their identity.
+	Author thread.PubKey
+	// Size is the size of the record, in bytes.
+	Size int64
+	// Since is when the record was first quarantined.
+	Since time.Time
+}
@@ -0,0 +1,178 @@
+package thread
+
+import (
+	"errors"
+	"fmt"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	ma "github.com/multiformats/go-multiaddr"
+	mbase "github.com/multiformats/go-multibase"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+var (
+	// ErrInvalidDescriptor indicates a malformed thread descriptor.
+	ErrInvalidDescriptor = errors.New("invalid thread descriptor")
+
+	// ErrDescriptorTooLarge indicates a thread descriptor exceeds MaxDescriptorBytes,
+	// fewer addresses have to be included.
+	ErrDescriptorTooLarge = errors.New("thread descriptor too large")
+)
+
+const (
+	// MaxDescriptorBytes caps binary descriptors so their string encoding stays within
+	// 1025 alphanumeric characters, which is scanned reliably from screens and paper.
+	MaxDescriptorBytes = 640
+
+	// descriptorV1 is the current descriptor format version.
+	descriptorV1 = 1
+
+	// descriptorServiceKey flags a descriptor embedding the service key.
+	descriptorServiceKey = 1 << 0
+	// descriptorReadKey flags a descriptor embedding the read key.
+	descriptorReadKey = 1 << 1
+	// descriptorIndexKey flags a descriptor embedding the index key.
+	descriptorIndexKey = 1 << 2
+)
+
+// descriptor is the CBOR form of a thread descriptor, with single letter keys to keep it compact.
+// Addresses are stored without the trailing thread component, which is restored from the ID.
+type descriptor struct {
+	Version uint8    `refmt:"v"`
+	Flags   uint8    `refmt:"f"`
+	ID      []byte   `refmt:"i"`
+	Keys    []byte   `refmt:"k,omitempty"`
+	Addrs   [][]byte `refmt:"a,omitempty"`
+}
+
+func init() {
+	cbornode.RegisterCborType(descriptor{})
+}
+
+// MarshalDescriptor returns the compact binary thread descriptor of the thread ID,
+// selected keys, and addresses of info, for binary QR codes and other byte-oriented channels.
+func MarshalDescriptor(info Info, keys ShareKeys) ([]byte, error) {
+	if err := info.ID.Validate(); err != nil {
+		return nil, err
+	}
+	d := descriptor{Version: descriptorV1, ID: info.ID.Bytes()}
+	switch keys {
+	case ShareFullKey:
+		if info.Key.Defined() {
+			d.Flags |= descriptorServiceKey
+		}
+		if info.Key.CanRead() {
+			d.Flags |= descriptorReadKey
+		} else if info.Key.CanIndex() {
+			d.Flags |= descriptorIndexKey
+		}
+		d.Keys = info.Key.Bytes()
+	case ShareServiceKey:
+		if info.Key.Defined() {
+			d.Flags |= descriptorServiceKey
+			d.Keys = info.Key.Service().Bytes()
+		}
+	case ShareIndexKey:
+		if !info.Key.CanIndex() {
+			return nil, fmt.Errorf("an index or read key is required to share the index key")
+		}
+		d.Flags |= descriptorServiceKey | descriptorIndexKey
+		d.Keys = NewIndexKey(info.Key.Service(), info.Key.Index()).Bytes()
+	case ShareNoKey:
+	default:
+		return nil, fmt.Errorf("unknown share keys selection %d", keys)
+	}
+
+	threadComp, err := ma.NewComponent(Name, info.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range info.Addrs {
+		if rest, last := ma.SplitLast(addr); last != nil && last.Equal(threadComp) {
+			addr = rest
+		}
+		d.Addrs = append(d.Addrs, addr.Bytes())
+	}
+
+	b, err := cbornode.DumpObject(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > MaxDescriptorBytes {
+		return nil, fmt.Errorf("%w: %d bytes > %d", ErrDescriptorTooLarge, len(b), MaxDescriptorBytes)
+	}
+	return b, nil
+}
+
+// UnmarshalDescriptor returns the thread info of a binary thread descriptor.
+// Only the thread ID, embedded keys, and addresses are set.
+func UnmarshalDescriptor(b []byte) (info Info, err error) {
+	if len(b) > MaxDescriptorBytes {
+		return info, ErrDescriptorTooLarge
+	}
+	var d descriptor
+	if err = cbornode.DecodeInto(b, &d); err != nil {
+		return info, fmt.Errorf("%w: %v", ErrInvalidDescriptor, err)
+	}
+	if d.Version != descriptorV1 {
+		return info, fmt.Errorf("%w: unsupported version %d", ErrInvalidDescriptor, d.Version)
+	}
+	if info.ID, err = Cast(d.ID); err != nil {
+		return info, fmt.Errorf("%w: %v", ErrInvalidDescriptor, err)
+	}
+
+	var keyBytes int
+	switch d.Flags {
+	case 0:
+	case descriptorServiceKey:
+		keyBytes = sym.KeyBytes
+	case descriptorServiceKey | descriptorReadKey:
+		keyBytes = sym.KeyBytes * 2
+	case descriptorServiceKey | descriptorIndexKey:
+		keyBytes = sym.KeyBytes*2 + 1
+	default:
+		return info, fmt.Errorf("%w: bad key flags %#x", ErrInvalidDescriptor, d.Flags)
+	}
+	if len(d.Keys) != keyBytes {
+		return info, fmt.Errorf("%w: keys don't match flags", ErrInvalidDescriptor)
+	}
+	if keyBytes > 0 {
+		if info.Key, err = KeyFromBytes(d.Keys); err != nil {
+			return info, fmt.Errorf("%w: %v", ErrInvalidDescriptor, err)
+		}
+	}
+
+	threadComp, err := ma.NewComponent(Name, info.ID.String())
+	if err != nil {
+		return info, err
+	}
+	for _, ab := range d.Addrs {
+		addr, err := ma.NewMultiaddrBytes(ab)
+		if err != nil {
+			return info, fmt.Errorf("%w: %v", ErrInvalidDescriptor, err)
+		}
+		info.Addrs = append(info.Addrs, addr.Encapsulate(threadComp))
+	}
+	return info, nil
+}
+
+// EncodeDescriptor returns the thread descriptor of info as a base32 multibase string.
+// Its upper case alphabet fits the alphanumeric mode of QR codes, which stores
+// more characters than the byte mode used by share links.
+func EncodeDescriptor(info Info, keys ShareKeys) (string, error) {
+	b, err := MarshalDescriptor(info, keys)
+	if err != nil {
+		return "", err
+	}
+	return mbase.Encode(mbase.Base32Upper, b)
+}
+
+// DecodeDescriptor returns the thread info of a thread descriptor string.
+// Only the thread ID, embedded keys, and addresses are set.
+func DecodeDescriptor(s string) (Info, error) {
+	_, b, err := mbase.Decode(s)
+	if err != nil {
+		return Info{}, fmt.Errorf("%w: %v", ErrInvalidDescriptor, err)
+	}
+	return UnmarshalDescriptor(b)
+}
@@ -0,0 +1,124 @@
+package thread
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestDescriptor(t *testing.T) {
+	id := NewIDV1(Raw, 32)
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4006/p2p/12D3KooWRt1Yh5ry3x4BDfsb1Xf1Pua3ZUr3rrAJX2YVYPjVFDNs/thread/" + id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := Info{
+		ID:    id,
+		Key:   NewRandomKey(),
+		Addrs: []ma.Multiaddr{addr},
+	}
+
+	t.Run("full", func(t *testing.T) {
+		s, err := EncodeDescriptor(info, ShareFullKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.ToUpper(s) != s {
+			t.Fatalf("expected an upper case descriptor, got %s", s)
+		}
+		link, err := NewShareLink(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, _ := MarshalDescriptor(info, ShareFullKey); len(b) >= len(link)*3/4 {
+			t.Fatalf("expected descriptor of %d bytes to be smaller than the share link", len(b))
+		}
+		got, err := DecodeDescriptor(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.ID.Equals(info.ID) {
+			t.Fatal("thread IDs are not equal")
+		}
+		if !bytes.Equal(got.Key.Bytes(), info.Key.Bytes()) {
+			t.Fatal("keys are not equal")
+		}
+		if len(got.Addrs) != 1 || !got.Addrs[0].Equal(addr) {
+			t.Fatalf("unexpected addresses %v", got.Addrs)
+		}
+	})
+
+	t.Run("service key", func(t *testing.T) {
+		b, err := MarshalDescriptor(Info{ID: id, Key: info.Key}, ShareServiceKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := UnmarshalDescriptor(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Key.CanRead() || !bytes.Equal(got.Key.Service().Bytes(), info.Key.Service().Bytes()) {
+			t.Fatal("expected only the service key")
+		}
+		if len(got.Addrs) != 0 {
+			t.Fatal("expected no addresses")
+		}
+	})
+
+	t.Run("index key", func(t *testing.T) {
+		b, err := MarshalDescriptor(Info{ID: id, Key: info.Key}, ShareIndexKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := UnmarshalDescriptor(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Key.CanRead() || !bytes.Equal(got.Key.Index().Bytes(), info.Key.Index().Bytes()) {
+			t.Fatal("expected the service and index keys")
+		}
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		s, err := EncodeDescriptor(info, ShareNoKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := DecodeDescriptor(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Key.Defined() {
+			t.Fatal("expected no keys")
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		large := info
+		large.Addrs = nil
+		for i := 0; i < 20; i++ {
+			large.Addrs = append(large.Addrs, addr)
+		}
+		if _, err := EncodeDescriptor(large, ShareFullKey); !errors.Is(err, ErrDescriptorTooLarge) {
+			t.Fatalf("expected descriptor to be too large, got %v", err)
+		}
+	})
+
+	t.Run("bad flags", func(t *testing.T) {
+		b, err := MarshalDescriptor(info, ShareFullKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := bytes.Index(b, []byte{0x61, 'f', descriptorServiceKey | descriptorReadKey})
+		if i < 0 {
+			t.Fatal("flags not found")
+		}
+		b[i+2] = descriptorServiceKey
+		if _, err = UnmarshalDescriptor(b); !errors.Is(err, ErrInvalidDescriptor) {
+			t.Fatalf("expected invalid descriptor, got %v", err)
+		}
+	})
+}
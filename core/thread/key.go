@@ -1,6 +1,8 @@
 package thread
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"fmt"
 
 	mbase "github.com/multiformats/go-multibase"
@@ -12,12 +14,24 @@ var (
 	ErrInvalidKey = fmt.Errorf("invalid key")
 )
 
+const (
+	// indexKeyTag terminates the bytes of an index-only key, which would otherwise
+	// have the same length as a full key.
+	indexKeyTag = 0x69
+
+	// indexKeyLabel separates index key derivation from other uses of the read key.
+	indexKeyLabel = "threads/index-key"
+)
+
 // Key is a thread encryption key with two components.
 // Service key is used to encrypt outer log record linkages.
 // Read key is used to encrypt inner record events.
+// An index key, derived from the read key, grants access to event headers
+// but not bodies, so the holder can traverse and index records without reading them.
 type Key struct {
 	sk *sym.Key
 	rk *sym.Key
+	ik *sym.Key
 }
 
 // NewKey wraps service and read keys.
@@ -33,6 +47,25 @@ func NewServiceKey(sk *sym.Key) Key {
 	return Key{sk: sk}
 }
 
+// NewIndexKey wraps service and index keys, without the read key.
+func NewIndexKey(sk, ik *sym.Key) Key {
+	if sk == nil {
+		panic("service-key must not be nil")
+	}
+	return Key{sk: sk, ik: ik}
+}
+
+// DeriveIndexKey returns the index key of a read key.
+func DeriveIndexKey(rk *sym.Key) *sym.Key {
+	mac := hmac.New(sha256.New, rk.Bytes())
+	mac.Write([]byte(indexKeyLabel))
+	ik, err := sym.FromBytes(mac.Sum(nil))
+	if err != nil {
+		panic("should not error with sha256 sized key: " + err.Error())
+	}
+	return ik
+}
+
 // NewRandomKey returns a random key, which includes a service and read key.
 func NewRandomKey() Key {
 	return Key{sk: sym.New(), rk: sym.New()}
@@ -45,6 +78,17 @@ func NewRandomServiceKey() Key {
 
 // KeyFromBytes returns a key by wrapping k.
 func KeyFromBytes(b []byte) (k Key, err error) {
+	if len(b) == sym.KeyBytes*2+1 && b[len(b)-1] == indexKeyTag {
+		sk, err := sym.FromBytes(b[:sym.KeyBytes])
+		if err != nil {
+			return k, err
+		}
+		ik, err := sym.FromBytes(b[sym.KeyBytes : sym.KeyBytes*2])
+		if err != nil {
+			return k, err
+		}
+		return Key{sk: sk, ik: ik}, nil
+	}
 	if len(b) != sym.KeyBytes && len(b) != sym.KeyBytes*2 {
 		return k, ErrInvalidKey
 	}
@@ -81,6 +125,14 @@ func (k Key) Read() *sym.Key {
 	return k.rk
 }
 
+// Index returns the index key, which is derived from the read key if it's available.
+func (k Key) Index() *sym.Key {
+	if k.rk != nil {
+		return DeriveIndexKey(k.rk)
+	}
+	return k.ik
+}
+
 // Defined returns whether or not key has any defined components.
 // Since it's not possible to have a read key w/o a service key,
 // we just need to check service key.
@@ -93,6 +145,11 @@ func (k Key) CanRead() bool {
 	return k.rk != nil
 }
 
+// CanIndex returns whether or not event headers can be read with the key.
+func (k Key) CanIndex() bool {
+	return k.rk != nil || k.ik != nil
+}
+
 // MarshalBinary implements BinaryMarshaler.
 func (k Key) MarshalBinary() ([]byte, error) {
 	return k.Bytes(), nil
@@ -102,6 +159,11 @@ func (k Key) MarshalBinary() ([]byte, error) {
 func (k Key) Bytes() []byte {
 	if k.rk != nil {
 		return append(k.sk.Bytes(), k.rk.Bytes()...)
+	} else if k.sk != nil && k.ik != nil {
+		b := make([]byte, 0, sym.KeyBytes*2+1)
+		b = append(b, k.sk.Bytes()...)
+		b = append(b, k.ik.Bytes()...)
+		return append(b, indexKeyTag)
 	} else if k.sk != nil {
 		return k.sk.Bytes()
 	} else {
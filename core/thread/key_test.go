@@ -86,3 +86,37 @@ func TestKey_FromString(t *testing.T) {
 		}
 	})
 }
+
+func TestKey_Index(t *testing.T) {
+	full := NewRandomKey()
+	if !full.CanIndex() {
+		t.Fatal("full key should be able to index")
+	}
+	index := NewIndexKey(full.Service(), full.Index())
+	if index.CanRead() || !index.CanIndex() {
+		t.Fatal("index key should be able to index but not read")
+	}
+	if !bytes.Equal(index.Index().Bytes(), DeriveIndexKey(full.Read()).Bytes()) {
+		t.Fatal("index key is not derived from the read key")
+	}
+	if bytes.Equal(index.Index().Bytes(), full.Read().Bytes()) {
+		t.Fatal("index key should differ from the read key")
+	}
+	if NewRandomServiceKey().CanIndex() {
+		t.Fatal("service key should not be able to index")
+	}
+
+	k, err := KeyFromString(index.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k.sk.Bytes(), index.sk.Bytes()) {
+		t.Fatal("service keys are not equal")
+	}
+	if !bytes.Equal(k.ik.Bytes(), index.ik.Bytes()) {
+		t.Fatal("index keys are not equal")
+	}
+	if k.rk != nil {
+		t.Fatal("read key should be nil")
+	}
+}
@@ -0,0 +1,271 @@
+package thread
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	ma "github.com/multiformats/go-multiaddr"
+	mbase "github.com/multiformats/go-multibase"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrInvalidShareLink indicates a malformed share link.
+	ErrInvalidShareLink = errors.New("invalid share link")
+
+	// ErrShareLinkChecksum indicates a share link was altered or truncated.
+	ErrShareLinkChecksum = errors.New("share link checksum mismatch")
+
+	// ErrPassphraseRequired indicates a share link is encrypted and no passphrase was given.
+	ErrPassphraseRequired = errors.New("share link requires a passphrase")
+
+	// ErrWrongPassphrase indicates a share link couldn't be decrypted with the given passphrase.
+	ErrWrongPassphrase = errors.New("wrong share link passphrase")
+)
+
+const (
+	// shareLinkV1 is the current share link format version.
+	shareLinkV1 = 0x01
+
+	// shareLinkEncrypted flags a share link with a passphrase-encrypted payload.
+	shareLinkEncrypted = 0x01
+
+	shareChecksumBytes = 4
+	shareSaltBytes     = 16
+
+	// scrypt parameters for passphrase-derived keys
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ShareKeys selects the thread keys embedded in a share link.
+type ShareKeys int
+
+const (
+	// ShareFullKey embeds the service and read keys, so the recipient can read the thread.
+	ShareFullKey ShareKeys = iota
+	// ShareServiceKey embeds only the service key, so the recipient can replicate the thread without reading it.
+	ShareServiceKey
+	// ShareNoKey embeds no keys, the recipient has to obtain them elsewhere.
+	ShareNoKey
+	// ShareIndexKey embeds the service and index keys, so the recipient can replicate and
+	// index the thread by its event headers without reading event bodies.
+	ShareIndexKey
+)
+
+// ShareLinkOptions defines options for creating and parsing share links.
+type ShareLinkOptions struct {
+	Keys       ShareKeys
+	Addrs      []ma.Multiaddr
+	Passphrase string
+}
+
+// ShareLinkOption specifies share link options.
+type ShareLinkOption func(*ShareLinkOptions)
+
+// WithShareKeys selects the embedded keys, defaults to ShareFullKey.
+func WithShareKeys(keys ShareKeys) ShareLinkOption {
+	return func(args *ShareLinkOptions) {
+		args.Keys = keys
+	}
+}
+
+// WithShareAddrs sets the bootstrap addresses, defaults to the thread info addresses.
+func WithShareAddrs(addrs ...ma.Multiaddr) ShareLinkOption {
+	return func(args *ShareLinkOptions) {
+		args.Addrs = addrs
+	}
+}
+
+// WithSharePassphrase encrypts the link payload with a key derived from passphrase.
+// The same passphrase is required to parse the link.
+func WithSharePassphrase(passphrase string) ShareLinkOption {
+	return func(args *ShareLinkOptions) {
+		args.Passphrase = passphrase
+	}
+}
+
+// NewShareLink returns a compact URL-safe string encoding the thread ID, selected keys,
+// and bootstrap addresses, so the thread can be joined with ParseShareLink.
+// The link carries a checksum which detects altered or truncated links.
+func NewShareLink(info Info, opts ...ShareLinkOption) (string, error) {
+	args := &ShareLinkOptions{Addrs: info.Addrs}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := info.ID.Validate(); err != nil {
+		return "", err
+	}
+
+	var key []byte
+	switch args.Keys {
+	case ShareFullKey:
+		key = info.Key.Bytes()
+	case ShareServiceKey:
+		if info.Key.Defined() {
+			key = info.Key.Service().Bytes()
+		}
+	case ShareIndexKey:
+		if !info.Key.CanIndex() {
+			return "", fmt.Errorf("an index or read key is required to share the index key")
+		}
+		key = NewIndexKey(info.Key.Service(), info.Key.Index()).Bytes()
+	case ShareNoKey:
+	default:
+		return "", fmt.Errorf("unknown share keys selection %d", args.Keys)
+	}
+
+	var payload []byte
+	payload = appendShareField(payload, info.ID.Bytes())
+	payload = appendShareField(payload, key)
+	payload = appendUvarint(payload, uint64(len(args.Addrs)))
+	for _, addr := range args.Addrs {
+		payload = appendShareField(payload, addr.Bytes())
+	}
+
+	link := []byte{shareLinkV1, 0}
+	if args.Passphrase != "" {
+		salt := make([]byte, shareSaltBytes)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		k, err := passphraseKey(args.Passphrase, salt)
+		if err != nil {
+			return "", err
+		}
+		if payload, err = k.Encrypt(payload); err != nil {
+			return "", err
+		}
+		link[1] |= shareLinkEncrypted
+		link = append(link, salt...)
+	}
+	link = append(link, payload...)
+	link = append(link, shareChecksum(link)...)
+	return mbase.Encode(mbase.Base64url, link)
+}
+
+// ParseShareLink returns the thread info encoded in a share link. Only the thread ID,
+// embedded keys, and bootstrap addresses are set. Encrypted links require WithSharePassphrase,
+// other options are ignored.
+func ParseShareLink(link string, opts ...ShareLinkOption) (Info, error) {
+	args := &ShareLinkOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	_, b, err := mbase.Decode(link)
+	if err != nil {
+		return Info{}, fmt.Errorf("%w: %v", ErrInvalidShareLink, err)
+	}
+	if len(b) < 2+shareChecksumBytes {
+		return Info{}, ErrInvalidShareLink
+	}
+	body, sum := b[:len(b)-shareChecksumBytes], b[len(b)-shareChecksumBytes:]
+	if !bytes.Equal(shareChecksum(body), sum) {
+		return Info{}, ErrShareLinkChecksum
+	}
+	if body[0] != shareLinkV1 {
+		return Info{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidShareLink, body[0])
+	}
+
+	payload := body[2:]
+	if body[1]&shareLinkEncrypted != 0 {
+		if args.Passphrase == "" {
+			return Info{}, ErrPassphraseRequired
+		}
+		if len(payload) < shareSaltBytes {
+			return Info{}, ErrInvalidShareLink
+		}
+		k, err := passphraseKey(args.Passphrase, payload[:shareSaltBytes])
+		if err != nil {
+			return Info{}, err
+		}
+		if payload, err = k.Decrypt(payload[shareSaltBytes:]); err != nil {
+			return Info{}, ErrWrongPassphrase
+		}
+	}
+	return decodeSharePayload(payload)
+}
+
+func decodeSharePayload(payload []byte) (info Info, err error) {
+	r := bytes.NewReader(payload)
+	idb, err := readShareField(r)
+	if err != nil {
+		return
+	}
+	if info.ID, err = Cast(idb); err != nil {
+		return info, fmt.Errorf("%w: %v", ErrInvalidShareLink, err)
+	}
+	keyb, err := readShareField(r)
+	if err != nil {
+		return
+	}
+	if len(keyb) > 0 {
+		if info.Key, err = KeyFromBytes(keyb); err != nil {
+			return info, fmt.Errorf("%w: %v", ErrInvalidShareLink, err)
+		}
+	}
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return info, ErrInvalidShareLink
+	}
+	for i := uint64(0); i < n; i++ {
+		ab, err := readShareField(r)
+		if err != nil {
+			return info, err
+		}
+		addr, err := ma.NewMultiaddrBytes(ab)
+		if err != nil {
+			return info, fmt.Errorf("%w: %v", ErrInvalidShareLink, err)
+		}
+		info.Addrs = append(info.Addrs, addr)
+	}
+	if r.Len() != 0 {
+		return info, ErrInvalidShareLink
+	}
+	return info, nil
+}
+
+func appendShareField(b, field []byte) []byte {
+	b = appendUvarint(b, uint64(len(field)))
+	return append(b, field...)
+}
+
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
+
+func readShareField(r *bytes.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return nil, ErrInvalidShareLink
+	}
+	field := make([]byte, n)
+	if _, err = io.ReadFull(r, field); err != nil {
+		return nil, ErrInvalidShareLink
+	}
+	return field, nil
+}
+
+func shareChecksum(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:shareChecksumBytes]
+}
+
+// passphraseKey derives a symmetric key from a passphrase.
+func passphraseKey(passphrase string, salt []byte) (*sym.Key, error) {
+	k, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, sym.KeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	return sym.FromBytes(k)
+}
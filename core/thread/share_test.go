@@ -0,0 +1,118 @@
+package thread
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	mbase "github.com/multiformats/go-multibase"
+)
+
+func TestShareLink(t *testing.T) {
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4006/p2p/12D3KooWRt1Yh5ry3x4BDfsb1Xf1Pua3ZUr3rrAJX2YVYPjVFDNs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := Info{
+		ID:    NewIDV1(Raw, 32),
+		Key:   NewRandomKey(),
+		Addrs: []ma.Multiaddr{addr},
+	}
+
+	t.Run("full", func(t *testing.T) {
+		link, err := NewShareLink(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseShareLink(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.ID.Equals(info.ID) {
+			t.Fatal("thread IDs are not equal")
+		}
+		if !bytes.Equal(got.Key.Bytes(), info.Key.Bytes()) {
+			t.Fatal("keys are not equal")
+		}
+		if len(got.Addrs) != 1 || !got.Addrs[0].Equal(addr) {
+			t.Fatalf("unexpected addresses %v", got.Addrs)
+		}
+	})
+
+	t.Run("service key", func(t *testing.T) {
+		link, err := NewShareLink(info, WithShareKeys(ShareServiceKey), WithShareAddrs())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseShareLink(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Key.CanRead() || !bytes.Equal(got.Key.Service().Bytes(), info.Key.Service().Bytes()) {
+			t.Fatal("expected only the service key")
+		}
+		if len(got.Addrs) != 0 {
+			t.Fatal("expected no addresses")
+		}
+	})
+
+	t.Run("index key", func(t *testing.T) {
+		link, err := NewShareLink(info, WithShareKeys(ShareIndexKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseShareLink(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Key.CanRead() || !got.Key.CanIndex() {
+			t.Fatal("expected the service and index keys")
+		}
+		if !bytes.Equal(got.Key.Index().Bytes(), info.Key.Index().Bytes()) {
+			t.Fatal("index keys are not equal")
+		}
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		link, err := NewShareLink(info, WithSharePassphrase("open sesame"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ParseShareLink(link); !errors.Is(err, ErrPassphraseRequired) {
+			t.Fatalf("expected passphrase to be required, got %v", err)
+		}
+		if _, err = ParseShareLink(link, WithSharePassphrase("wrong")); !errors.Is(err, ErrWrongPassphrase) {
+			t.Fatalf("expected wrong passphrase error, got %v", err)
+		}
+		got, err := ParseShareLink(link, WithSharePassphrase("open sesame"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Key.Bytes(), info.Key.Bytes()) {
+			t.Fatal("keys are not equal")
+		}
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		link, err := NewShareLink(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, b, err := mbase.Decode(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b[3] ^= 0xff
+		altered, err := mbase.Encode(mbase.Base64url, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ParseShareLink(altered); !errors.Is(err, ErrShareLinkChecksum) {
+			t.Fatalf("expected checksum mismatch, got %v", err)
+		}
+		if _, err = ParseShareLink(link[:len(link)-2]); err == nil {
+			t.Fatal("expected truncated link to be invalid")
+		}
+	})
+}
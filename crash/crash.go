@@ -0,0 +1,130 @@
+// Package crash contains panics of request handlers and workers, so that a single failing
+// call is reported and answered with an error instead of stopping the node.
+package crash
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/logger"
+)
+
+var log = logger.Logger("crash")
+
+// MaxReports is the number of recent reports kept in memory.
+var MaxReports = 100
+
+// Report describes a recovered panic.
+type Report struct {
+	Time time.Time `json:"time"`
+	// Component is the handler or worker which panicked, e.g. a full gRPC method name.
+	Component string `json:"component"`
+	Value     string `json:"value"`
+	Stack     string `json:"stack"`
+}
+
+// PanicError is the error a recovered panic is converted into.
+type PanicError struct {
+	Report Report
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked: %s", e.Report.Component, e.Report.Value)
+}
+
+var (
+	lock     sync.Mutex
+	reports  []Report
+	reporter func(Report)
+)
+
+// SetReporter sets a function called with each report, in addition to the report being
+// logged and kept in memory. Calls are made from the goroutine which panicked.
+func SetReporter(f func(Report)) {
+	lock.Lock()
+	defer lock.Unlock()
+	reporter = f
+}
+
+// Reports returns the recent reports, oldest first.
+func Reports() []Report {
+	lock.Lock()
+	defer lock.Unlock()
+	return append([]Report(nil), reports...)
+}
+
+// Recover recovers a panic of the calling goroutine, records a report of it and sets err
+// to a *PanicError if err isn't nil. It must be deferred directly, e.g.
+//
+//	defer crash.Recover("worker", &err)
+func Recover(component string, err *error) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	r := record(component, v, debug.Stack())
+	if err != nil {
+		*err = &PanicError{Report: r}
+	}
+}
+
+// Call calls f, converting a panic into an error.
+func Call(component string, f func() error) (err error) {
+	defer Recover(component, &err)
+	return f()
+}
+
+// Go runs f in a new goroutine which is kept from stopping the process if f panics.
+func Go(component string, f func()) {
+	go func() {
+		defer Recover(component, nil)
+		f()
+	}()
+}
+
+// DirReporter returns a reporter writing each report to a JSON file in dir.
+func DirReporter(dir string) (func(Report), error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return func(r Report) {
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			log.Errorf("encoding crash report: %v", err)
+			return
+		}
+		name := fmt.Sprintf("crash-%d.json", r.Time.UnixNano())
+		if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			log.Errorf("writing crash report: %v", err)
+		}
+	}, nil
+}
+
+func record(component string, v interface{}, stack []byte) Report {
+	r := Report{
+		Time:      time.Now(),
+		Component: component,
+		Value:     fmt.Sprint(v),
+		Stack:     string(stack),
+	}
+	log.Errorf("recovered panic in %s: %s\n%s", component, r.Value, r.Stack)
+
+	lock.Lock()
+	reports = append(reports, r)
+	if n := len(reports) - MaxReports; n > 0 {
+		reports = append(reports[:0], reports[n:]...)
+	}
+	f := reporter
+	lock.Unlock()
+
+	if f != nil {
+		f(r)
+	}
+	return r
+}
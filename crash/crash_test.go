@@ -0,0 +1,77 @@
+package crash
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCall(t *testing.T) {
+	var reported []Report
+	SetReporter(func(r Report) { reported = append(reported, r) })
+	defer SetReporter(nil)
+
+	failure := errors.New("failure")
+	if err := Call("worker", func() error { return failure }); err != failure {
+		t.Fatalf("expected error to be passed through, got %v", err)
+	}
+	err := Call("worker", func() error { panic("boom") })
+	var perr *PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected panic error, got %v", err)
+	}
+	if perr.Report.Component != "worker" || perr.Report.Value != "boom" || perr.Report.Stack == "" {
+		t.Fatalf("unexpected report %+v", perr.Report)
+	}
+	if len(reported) != 1 || reported[0].Value != "boom" {
+		t.Fatalf("expected report to be passed to the reporter, got %v", reported)
+	}
+}
+
+func TestReports(t *testing.T) {
+	defer func(max int) { MaxReports = max }(MaxReports)
+	MaxReports = 2
+
+	for _, v := range []string{"a", "b", "c"} {
+		_ = Call("worker", func() error { panic(v) })
+	}
+	reports := Reports()
+	if len(reports) != 2 || reports[0].Value != "b" || reports[1].Value != "c" {
+		t.Fatalf("expected the recent reports to be kept, got %v", reports)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.API/Method"}
+	_, err := UnaryServerInterceptor()(context.Background(), nil, info,
+		func(context.Context, interface{}) (interface{}, error) {
+			var m map[string]int
+			m["key"] = 1
+			return nil, nil
+		})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+
+	resp, err := UnaryServerInterceptor()(context.Background(), nil, info,
+		func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+	if err != nil || resp != "ok" {
+		t.Fatalf("expected response to be passed through, got %v, %v", resp, err)
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/test.API/Stream"}
+	err := StreamServerInterceptor()(nil, nil, info, func(interface{}, grpc.ServerStream) error {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+}
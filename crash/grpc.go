@@ -0,0 +1,42 @@
+package crash
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns an interceptor answering calls whose handler panics with
+// codes.Internal. It should come first in a chain to cover the other interceptors too.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				resp, err = nil, internal(info.FullMethod, v)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor ending streams whose handler panics with
+// codes.Internal. It should come first in a chain to cover the other interceptors too.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = internal(info.FullMethod, v)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func internal(method string, v interface{}) error {
+	// the panic value may hold internal details, so it's only kept in the report
+	record(method, v, debug.Stack())
+	return status.Error(codes.Internal, "internal error handling "+method)
+}
@@ -0,0 +1,141 @@
+// Package shamir implements Shamir's secret sharing over GF(2^8).
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ShareOverhead is the number of bytes a share adds to the secret length.
+// The last byte of a share is its x coordinate.
+const ShareOverhead = 1
+
+// Split splits secret into parts shares, any threshold of which reconstruct it.
+func Split(secret []byte, parts, threshold int) ([][]byte, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("cannot split an empty secret")
+	}
+	if threshold < 2 {
+		return nil, errors.New("threshold must be at least 2")
+	}
+	if parts < threshold {
+		return nil, errors.New("parts must not be less than threshold")
+	}
+	if parts > 255 {
+		return nil, errors.New("parts must not exceed 255")
+	}
+
+	shares := make([][]byte, parts)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+ShareOverhead)
+		shares[i][len(secret)] = byte(i + 1)
+	}
+	coeffs := make([]byte, threshold)
+	for j, b := range secret {
+		// random polynomial of degree threshold-1 with the secret byte as constant term
+		if _, err := rand.Read(coeffs[1:]); err != nil {
+			return nil, err
+		}
+		coeffs[0] = b
+		for i := range shares {
+			shares[i][j] = evaluate(coeffs, byte(i+1))
+		}
+	}
+	return shares, nil
+}
+
+// Combine reconstructs a secret from shares. Combining fewer shares than the
+// split threshold results in a wrong secret, which can't be detected here.
+func Combine(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, errors.New("at least 2 shares are required")
+	}
+	size := len(shares[0])
+	if size <= ShareOverhead {
+		return nil, errors.New("shares are too short")
+	}
+	xs := make([]byte, len(shares))
+	seen := make(map[byte]struct{}, len(shares))
+	for i, s := range shares {
+		if len(s) != size {
+			return nil, errors.New("shares must have the same length")
+		}
+		x := s[size-1]
+		if x == 0 {
+			return nil, fmt.Errorf("share %d has an invalid x coordinate", i)
+		}
+		if _, ok := seen[x]; ok {
+			return nil, fmt.Errorf("share %d is duplicated", i)
+		}
+		seen[x] = struct{}{}
+		xs[i] = x
+	}
+
+	secret := make([]byte, size-ShareOverhead)
+	ys := make([]byte, len(shares))
+	for j := range secret {
+		for i, s := range shares {
+			ys[i] = s[j]
+		}
+		secret[j] = interpolateAtZero(xs, ys)
+	}
+	return secret, nil
+}
+
+// evaluate returns the value of the polynomial with the given coefficients at x.
+func evaluate(coeffs []byte, x byte) byte {
+	var y byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = add(mul(y, x), coeffs[i])
+	}
+	return y
+}
+
+// interpolateAtZero returns the value at zero of the Lagrange polynomial through the points.
+func interpolateAtZero(xs, ys []byte) byte {
+	var y byte
+	for i := range xs {
+		basis := byte(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			// x_j / (x_j - x_i), subtraction is addition in GF(2^8)
+			basis = mul(basis, div(xs[j], add(xs[j], xs[i])))
+		}
+		y = add(y, mul(ys[i], basis))
+	}
+	return y
+}
+
+func add(a, b byte) byte {
+	return a ^ b
+}
+
+// mul multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x + 1, without data-dependent branches.
+func mul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		carry := -(a >> 7)
+		a = (a << 1) ^ (carry & 0x1b)
+		b >>= 1
+	}
+	return p
+}
+
+// inverse returns a^254, the multiplicative inverse of a non-zero a.
+func inverse(a byte) byte {
+	b := mul(a, a)
+	r := b
+	for i := 0; i < 6; i++ {
+		b = mul(b, b)
+		r = mul(r, b)
+	}
+	return r
+}
+
+func div(a, b byte) byte {
+	return mul(a, inverse(b))
+}
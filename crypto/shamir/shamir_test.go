@@ -0,0 +1,76 @@
+package shamir_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	. "github.com/textileio/go-threads/crypto/shamir"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := make([]byte, 64)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatal(err)
+	}
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("expected 5 shares, got %d", len(shares))
+	}
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		var parts [][]byte
+		for _, i := range subset {
+			parts = append(parts, shares[i])
+		}
+		combined, err := Combine(parts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(combined, secret) {
+			t.Fatalf("shares %v didn't reconstruct the secret", subset)
+		}
+	}
+
+	combined, err := Combine(shares[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(combined, secret) {
+		t.Fatal("expected shares below threshold not to reconstruct the secret")
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	secret := []byte("secret")
+	if _, err := Split(nil, 3, 2); err == nil {
+		t.Fatal("expected error for empty secret")
+	}
+	if _, err := Split(secret, 3, 1); err == nil {
+		t.Fatal("expected error for threshold below 2")
+	}
+	if _, err := Split(secret, 2, 3); err == nil {
+		t.Fatal("expected error for parts below threshold")
+	}
+	if _, err := Split(secret, 256, 3); err == nil {
+		t.Fatal("expected error for too many parts")
+	}
+}
+
+func TestCombineInvalid(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Combine(shares[:1]); err == nil {
+		t.Fatal("expected error for a single share")
+	}
+	if _, err = Combine([][]byte{shares[0], shares[0]}); err == nil {
+		t.Fatal("expected error for duplicated shares")
+	}
+	if _, err = Combine([][]byte{shares[0], shares[1][1:]}); err == nil {
+		t.Fatal("expected error for shares of different lengths")
+	}
+}
@@ -0,0 +1,495 @@
+package datastore
+
+import (
+	"errors"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v3"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	"github.com/textileio/go-threads/logger"
+)
+
+var (
+	_ Datastore  = (*badger3Datastore)(nil)
+	_ Metered    = (*badger3Datastore)(nil)
+	_ dse.TxnExt = (*badger3Txn)(nil)
+)
+
+// badgerLog adapts a subsystem logger to badger.Logger.
+type badgerLog struct {
+	logger.EventLogger
+}
+
+func (l badgerLog) Warningf(format string, args ...interface{}) {
+	l.Warnf(format, args...)
+}
+
+// badger3Options returns Badger v3 options tuned for thread entries, which are small and
+// mostly appended. Values are kept in the LSM tree up to a few KiB, so value log garbage
+// collection rarely has to rewrite files, and compactions aren't flushed on close.
+func badger3Options(path string, args *Options) badger.Options {
+	opts := badger.DefaultOptions(path).
+		WithLogger(badgerLog{log}).
+		WithSyncWrites(args.SyncWrites).
+		WithNumVersionsToKeep(1).
+		WithCompactL0OnClose(false).
+		WithValueThreshold(4 << 10).
+		WithValueLogFileSize(256 << 20).
+		WithNumCompactors(4).
+		WithMemTableSize(32 << 20).
+		WithBlockCacheSize(128 << 20).
+		WithIndexCacheSize(64 << 20)
+	if args.LowMem {
+		opts = opts.
+			WithNumMemtables(2).
+			WithNumLevelZeroTables(2).
+			WithNumLevelZeroTablesStall(8).
+			WithMemTableSize(8 << 20).
+			WithBlockCacheSize(16 << 20).
+			WithIndexCacheSize(8 << 20)
+	}
+	return opts
+}
+
+// badger3Datastore is a datastore backed by Badger v3.
+type badger3Datastore struct {
+	counters
+	life       lifecycle
+	db         *badger.DB
+	gcInterval time.Duration
+}
+
+func newBadger3(path string, args *Options) (*badger3Datastore, error) {
+	db, err := badger.Open(badger3Options(path, args))
+	if err != nil {
+		return nil, err
+	}
+	d := &badger3Datastore{
+		life:       newLifecycle(),
+		db:         db,
+		gcInterval: args.GCInterval,
+	}
+	if d.gcInterval > 0 {
+		go d.periodicGC()
+	}
+	return d, nil
+}
+
+// periodicGC runs a value log garbage collection cycle every gcInterval. Rounds of a cycle
+// are repeated until no more value log file is worth rewriting.
+func (d *badger3Datastore) periodicGC() {
+	timer := time.NewTimer(d.gcInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			start := time.Now()
+			switch err := d.gcOnce(); err {
+			case nil:
+				d.counters.gc(start)
+				timer.Reset(defaultGCSleep)
+			case badger.ErrNoRewrite, badger.ErrRejected:
+				timer.Reset(d.gcInterval)
+			case ErrClosed:
+				return
+			default:
+				log.Errorf("badger3 gc cycle failed: %v", err)
+				timer.Reset(d.gcInterval)
+			}
+		case <-d.life.closing:
+			return
+		}
+	}
+}
+
+func (d *badger3Datastore) gcOnce() error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	return d.db.RunValueLogGC(defaultGCDiscardRatio)
+}
+
+func (d *badger3Datastore) CollectGarbage() (err error) {
+	for err == nil {
+		start := time.Now()
+		if err = d.gcOnce(); err == nil {
+			d.counters.gc(start)
+		}
+	}
+	if err == badger.ErrNoRewrite {
+		err = nil
+	}
+	return err
+}
+
+func (d *badger3Datastore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return d.newTransaction(readOnly)
+}
+
+func (d *badger3Datastore) NewTransactionExtended(readOnly bool) (dse.TxnExt, error) {
+	return d.newTransaction(readOnly)
+}
+
+func (d *badger3Datastore) newTransaction(readOnly bool) (*badger3Txn, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	return &badger3Txn{ds: d, txn: d.db.NewTransaction(!readOnly)}, nil
+}
+
+// update runs f in an implicit write transaction.
+func (d *badger3Datastore) update(f func(t *badger3Txn) error) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	t := &badger3Txn{ds: d, txn: d.db.NewTransaction(true)}
+	defer t.txn.Discard()
+	if err := f(t); err != nil {
+		return err
+	}
+	return t.commit()
+}
+
+// view runs f in an implicit read-only transaction.
+func (d *badger3Datastore) view(f func(t *badger3Txn) error) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	t := &badger3Txn{ds: d, txn: d.db.NewTransaction(false)}
+	defer t.txn.Discard()
+	return f(t)
+}
+
+func (d *badger3Datastore) Put(key ds.Key, value []byte) error {
+	return d.update(func(t *badger3Txn) error {
+		return t.put(key, value)
+	})
+}
+
+func (d *badger3Datastore) Delete(key ds.Key) error {
+	return d.update(func(t *badger3Txn) error {
+		return t.delete(key)
+	})
+}
+
+func (d *badger3Datastore) Get(key ds.Key) (value []byte, err error) {
+	err = d.view(func(t *badger3Txn) error {
+		value, err = t.get(key)
+		return err
+	})
+	return value, err
+}
+
+func (d *badger3Datastore) Has(key ds.Key) (exists bool, err error) {
+	err = d.view(func(t *badger3Txn) error {
+		exists, err = t.has(key)
+		return err
+	})
+	return exists, err
+}
+
+func (d *badger3Datastore) GetSize(key ds.Key) (size int, err error) {
+	size = -1
+	err = d.view(func(t *badger3Txn) error {
+		size, err = t.getSize(key)
+		return err
+	})
+	return size, err
+}
+
+func (d *badger3Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	return d.QueryExtended(dse.QueryExt{Query: q})
+}
+
+func (d *badger3Datastore) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	// the implicit transaction is discarded once the query stops
+	t := &badger3Txn{ds: d, txn: d.db.NewTransaction(false)}
+	return t.query(q, t.txn.Discard)
+}
+
+func (d *badger3Datastore) Sync(ds.Key) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	return d.db.Sync()
+}
+
+func (d *badger3Datastore) Batch() (ds.Batch, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	return &badger3Batch{ds: d, wb: d.db.NewWriteBatch()}, nil
+}
+
+// DiskUsage returns the size of the LSM tree and value log files in bytes.
+func (d *badger3Datastore) DiskUsage() (uint64, error) {
+	if !d.life.acquire() {
+		return 0, ErrClosed
+	}
+	defer d.life.release()
+	lsm, vlog := d.db.Size()
+	return uint64(lsm + vlog), nil
+}
+
+// Stats returns the datastore metrics. Cache metrics are those of the block cache.
+func (d *badger3Datastore) Stats() Stats {
+	s := d.counters.snapshot(BackendBadger3)
+	if !d.life.acquire() {
+		return s
+	}
+	defer d.life.release()
+	if m := d.db.BlockCacheMetrics(); m != nil {
+		s.CacheHits = m.Hits()
+		s.CacheMisses = m.Misses()
+	}
+	lsm, vlog := d.db.Size()
+	s.DiskUsage = uint64(lsm + vlog)
+	return s
+}
+
+func (d *badger3Datastore) Close() error {
+	return d.life.close(d.db.Close)
+}
+
+// badger3Batch is a Badger v3 write batch, which is split into
+// transactions as they fill up.
+type badger3Batch struct {
+	ds *badger3Datastore
+	wb *badger.WriteBatch
+}
+
+func (b *badger3Batch) Put(key ds.Key, value []byte) error {
+	if !b.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer b.ds.life.release()
+	b.ds.counters.put(len(value))
+	return b.wb.Set(key.Bytes(), value)
+}
+
+func (b *badger3Batch) Delete(key ds.Key) error {
+	if !b.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer b.ds.life.release()
+	b.ds.counters.delete()
+	return b.wb.Delete(key.Bytes())
+}
+
+func (b *badger3Batch) Commit() error {
+	if !b.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer b.ds.life.release()
+	if err := b.wb.Flush(); err != nil {
+		b.wb.Cancel()
+		return err
+	}
+	b.ds.counters.commit(false)
+	return nil
+}
+
+// badger3Txn is a Badger v3 transaction.
+type badger3Txn struct {
+	ds  *badger3Datastore
+	txn *badger.Txn
+}
+
+func (t *badger3Txn) Put(key ds.Key, value []byte) error {
+	if !t.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.put(key, value)
+}
+
+func (t *badger3Txn) put(key ds.Key, value []byte) error {
+	t.ds.counters.put(len(value))
+	return t.txn.Set(key.Bytes(), value)
+}
+
+func (t *badger3Txn) Delete(key ds.Key) error {
+	if !t.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.delete(key)
+}
+
+func (t *badger3Txn) delete(key ds.Key) error {
+	t.ds.counters.delete()
+	return t.txn.Delete(key.Bytes())
+}
+
+func (t *badger3Txn) Get(key ds.Key) ([]byte, error) {
+	if !t.ds.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.get(key)
+}
+
+func (t *badger3Txn) get(key ds.Key) ([]byte, error) {
+	item, err := t.txn.Get(key.Bytes())
+	if err == badger.ErrKeyNotFound {
+		return nil, ds.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+	t.ds.counters.get(len(value))
+	return value, nil
+}
+
+func (t *badger3Txn) Has(key ds.Key) (bool, error) {
+	if !t.ds.life.acquire() {
+		return false, ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.has(key)
+}
+
+func (t *badger3Txn) has(key ds.Key) (bool, error) {
+	_, err := t.txn.Get(key.Bytes())
+	switch err {
+	case nil:
+		return true, nil
+	case badger.ErrKeyNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (t *badger3Txn) GetSize(key ds.Key) (int, error) {
+	if !t.ds.life.acquire() {
+		return -1, ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.getSize(key)
+}
+
+func (t *badger3Txn) getSize(key ds.Key) (int, error) {
+	item, err := t.txn.Get(key.Bytes())
+	switch err {
+	case nil:
+		return int(item.ValueSize()), nil
+	case badger.ErrKeyNotFound:
+		return -1, ds.ErrNotFound
+	default:
+		return -1, err
+	}
+}
+
+func (t *badger3Txn) Query(q dsq.Query) (dsq.Results, error) {
+	return t.QueryExtended(dse.QueryExt{Query: q})
+}
+
+func (t *badger3Txn) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	if !t.ds.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.query(q, nil)
+}
+
+func (t *badger3Txn) query(q dse.QueryExt, done func()) (dsq.Results, error) {
+	t.ds.counters.query()
+	return runQuery(&t.ds.life, q, func(prefix []byte, reverse, keysOnly bool) (iterator, error) {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = !keysOnly
+		opts.Prefix = prefix
+		opts.Reverse = reverse
+		return &badger3Iterator{ds: t.ds, it: t.txn.NewIterator(opts), prefix: prefix, reverse: reverse}, nil
+	}, done)
+}
+
+func (t *badger3Txn) Commit() error {
+	if !t.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer t.ds.life.release()
+	return t.commit()
+}
+
+func (t *badger3Txn) commit() error {
+	err := t.txn.Commit()
+	t.ds.counters.commit(errors.Is(err, badger.ErrConflict))
+	if errors.Is(err, badger.ErrConflict) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (t *badger3Txn) Discard() {
+	if !t.ds.life.acquire() {
+		return
+	}
+	defer t.ds.life.release()
+	t.txn.Discard()
+}
+
+// badger3Iterator adapts a Badger v3 iterator to queries.
+type badger3Iterator struct {
+	ds      *badger3Datastore
+	it      *badger.Iterator
+	prefix  []byte
+	reverse bool
+}
+
+func (i *badger3Iterator) rewind() {
+	if i.reverse && len(i.prefix) > 0 {
+		// reverse iterators start from the last key, which may lie past the prefix
+		i.it.Seek(append(append([]byte{}, i.prefix...), 0xff))
+		return
+	}
+	i.it.Rewind()
+}
+
+func (i *badger3Iterator) seek(key []byte) {
+	i.it.Seek(key)
+}
+
+func (i *badger3Iterator) valid() bool {
+	return i.it.ValidForPrefix(i.prefix)
+}
+
+func (i *badger3Iterator) next() {
+	i.it.Next()
+}
+
+func (i *badger3Iterator) key() []byte {
+	return i.it.Item().KeyCopy(nil)
+}
+
+func (i *badger3Iterator) value() ([]byte, error) {
+	v, err := i.it.Item().ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+	i.ds.counters.read(len(v))
+	return v, nil
+}
+
+func (i *badger3Iterator) size() int {
+	return int(i.it.Item().ValueSize())
+}
+
+func (i *badger3Iterator) close() {
+	i.it.Close()
+}
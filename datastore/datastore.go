@@ -0,0 +1,156 @@
+// Package datastore provides the persistent datastore backends threads can be stored in.
+package datastore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	badger1 "github.com/dgraph-io/badger"
+	"github.com/dgraph-io/badger/options"
+	ds "github.com/ipfs/go-datastore"
+	badger "github.com/textileio/go-ds-badger"
+	kt "github.com/textileio/go-threads/db/keytransform"
+	"github.com/textileio/go-threads/logger"
+)
+
+var log = logger.Logger("datastore")
+
+var (
+	// ErrUnsupportedBackend indicates an unknown datastore backend.
+	ErrUnsupportedBackend = errors.New("unsupported datastore backend")
+
+	// ErrClosed indicates the datastore was closed.
+	ErrClosed = errors.New("datastore closed")
+
+	// ErrConflict indicates a transaction conflicted with a concurrent one and can be retried.
+	// Backends return the Badger v1 error, so conflicts are handled the same with all of them.
+	ErrConflict = badger1.ErrConflict
+)
+
+// Backend is the kind of storage engine backing a datastore.
+type Backend string
+
+const (
+	// BackendBadger is the Badger v1 datastore, which threads were always stored in.
+	BackendBadger Backend = "badger"
+	// BackendBadger3 is the Badger v3 datastore, tuned for lower write amplification.
+	BackendBadger3 Backend = "badger3"
+	// BackendPebble is the Pebble datastore, tuned for steady compactions under heavy writes.
+	BackendPebble Backend = "pebble"
+)
+
+// Backends lists the supported datastore backends.
+var Backends = []Backend{BackendBadger, BackendBadger3, BackendPebble}
+
+// ParseBackend returns the backend named s.
+func ParseBackend(s string) (Backend, error) {
+	for _, b := range Backends {
+		if string(b) == s {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedBackend, s)
+}
+
+// Datastore is a persistent datastore supporting transactions, batches, and extended queries.
+type Datastore interface {
+	kt.TxnDatastoreExtended
+	ds.Batching
+	ds.PersistentDatastore
+	ds.GCDatastore
+}
+
+// Metered is implemented by datastores which collect Stats.
+type Metered interface {
+	Stats() Stats
+}
+
+const (
+	// DefaultGCInterval is the default interval between garbage collection cycles.
+	DefaultGCInterval = 15 * time.Minute
+
+	// defaultGCSleep is the pause between rounds of a garbage collection cycle.
+	defaultGCSleep = 10 * time.Second
+
+	// defaultGCDiscardRatio is the ratio of stale data which makes a Badger value log file rewritten.
+	defaultGCDiscardRatio = 0.2
+)
+
+// Options defines options for opening a datastore.
+type Options struct {
+	LowMem     bool
+	SyncWrites bool
+	GCInterval time.Duration
+}
+
+// Option specifies datastore options.
+type Option func(*Options)
+
+// WithLowMem trades read performance for lower memory usage.
+func WithLowMem(enabled bool) Option {
+	return func(args *Options) {
+		args.LowMem = enabled
+	}
+}
+
+// WithSyncWrites syncs every write to disk, instead of leaving it to the backend.
+func WithSyncWrites(enabled bool) Option {
+	return func(args *Options) {
+		args.SyncWrites = enabled
+	}
+}
+
+// WithGCInterval sets the interval between garbage collection cycles,
+// defaults to DefaultGCInterval. Automatic garbage collection is disabled if negative.
+func WithGCInterval(interval time.Duration) Option {
+	return func(args *Options) {
+		args.GCInterval = interval
+	}
+}
+
+// New opens the datastore at path with the given backend, creating it if needed.
+func New(backend Backend, path string, opts ...Option) (Datastore, error) {
+	args := &Options{GCInterval: DefaultGCInterval}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if args.GCInterval < 0 {
+		args.GCInterval = 0
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return nil, err
+	}
+	switch backend {
+	case BackendBadger:
+		return newBadger(path, args)
+	case BackendBadger3:
+		return newBadger3(path, args)
+	case BackendPebble:
+		return newPebble(path, args)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, backend)
+	}
+}
+
+// Path returns the location of the named datastore in a repo. Badger datastores keep
+// their original location, the other backends are stored under a directory of their own,
+// so repos can hold the same datastore in different backends while migrating.
+func Path(repoPath, name string, backend Backend) string {
+	if backend == BackendBadger {
+		return filepath.Join(repoPath, name)
+	}
+	return filepath.Join(repoPath, string(backend), name)
+}
+
+func newBadger(path string, args *Options) (Datastore, error) {
+	opts := badger.DefaultOptions
+	if args.LowMem {
+		opts.TableLoadingMode = options.FileIO
+	}
+	opts.SyncWrites = args.SyncWrites
+	opts.GcInterval = args.GCInterval
+	return badger.NewDatastore(path, &opts)
+}
@@ -0,0 +1,334 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dstest "github.com/ipfs/go-datastore/test"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+func TestBackends(t *testing.T) {
+	for _, b := range Backends {
+		b := b
+		t.Run(string(b), func(t *testing.T) {
+			t.Run("Suite", func(t *testing.T) {
+				d, clean := newTestDatastore(t, b)
+				defer clean()
+				dstest.SubtestAll(t, d)
+			})
+			t.Run("Txn", func(t *testing.T) {
+				d, clean := newTestDatastore(t, b)
+				defer clean()
+				testTxn(t, d)
+			})
+			t.Run("QueryExtended", func(t *testing.T) {
+				d, clean := newTestDatastore(t, b)
+				defer clean()
+				testQueryExtended(t, d, b)
+			})
+			t.Run("GC", func(t *testing.T) {
+				d, clean := newTestDatastore(t, b)
+				defer clean()
+				testGC(t, d)
+			})
+		})
+	}
+}
+
+func testTxn(t *testing.T, d Datastore) {
+	k1, k2 := ds.NewKey("/a/1"), ds.NewKey("/a/2")
+	if err := d.Put(k1, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+
+	txn, err := d.NewTransaction(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Put(k2, []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Delete(k1); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := txn.Get(k2); err != nil || string(v) != "v2" {
+		t.Fatalf("transaction should read its own writes, got %s (%v)", v, err)
+	}
+	if has, err := txn.Has(k1); err != nil || has {
+		t.Fatalf("transaction should read its own deletes (%v)", err)
+	}
+	if has, err := d.Has(k2); err != nil || has {
+		t.Fatalf("uncommitted writes should not be visible (%v)", err)
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	txn.Discard()
+	if v, err := d.Get(k2); err != nil || string(v) != "v2" {
+		t.Fatalf("committed write not found, got %s (%v)", v, err)
+	}
+	if _, err := d.Get(k1); err != ds.ErrNotFound {
+		t.Fatalf("committed delete not applied: %v", err)
+	}
+
+	// discarded transactions leave no trace
+	txn, err = d.NewTransaction(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Put(k1, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	txn.Discard()
+	if has, err := d.Has(k1); err != nil || has {
+		t.Fatalf("discarded write should not be applied (%v)", err)
+	}
+
+	// read-only transactions don't see later writes
+	rtxn, err := d.NewTransaction(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rtxn.Discard()
+	if err := d.Put(k1, []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := rtxn.Has(k1); err != nil || has {
+		t.Fatalf("read-only transaction should read from a snapshot (%v)", err)
+	}
+}
+
+func testQueryExtended(t *testing.T, d Datastore, b Backend) {
+	for i := 0; i < 10; i++ {
+		if err := d.Put(ds.NewKey(fmt.Sprintf("/q/%d", i)), []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a sibling key sharing the prefix string
+	if err := d.Put(ds.NewKey("/qq/0"), []byte{0}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		q       dse.QueryExt
+		txn     bool
+		skip    Backend
+		expKeys []string
+	}{
+		{
+			name:    "seek",
+			q:       dse.QueryExt{Query: dsq.Query{Prefix: "/q"}, SeekPrefix: "/q/7"},
+			expKeys: []string{"/q/7", "/q/8", "/q/9"},
+		},
+		{
+			name: "seek reverse",
+			q: dse.QueryExt{
+				Query:      dsq.Query{Prefix: "/q", Orders: []dsq.Order{dsq.OrderByKeyDescending{}}},
+				SeekPrefix: "/q/2",
+			},
+			expKeys: []string{"/q/2", "/q/1", "/q/0"},
+		},
+		{
+			name: "reverse limit",
+			q: dse.QueryExt{
+				Query: dsq.Query{Prefix: "/q", Orders: []dsq.Order{dsq.OrderByKeyDescending{}}, Limit: 2},
+			},
+			txn: true,
+			// Badger v1 iterators don't rewind to the end of a prefix in reverse
+			skip:    BackendBadger,
+			expKeys: []string{"/q/9", "/q/8"},
+		},
+		{
+			name:    "offset",
+			q:       dse.QueryExt{Query: dsq.Query{Prefix: "/q", Offset: 8, KeysOnly: true}},
+			txn:     true,
+			expKeys: []string{"/q/8", "/q/9"},
+		},
+	}
+	for _, c := range cases {
+		if c.skip == b {
+			continue
+		}
+		var res dsq.Results
+		var err error
+		if c.txn {
+			txn, terr := d.NewTransactionExtended(true)
+			if terr != nil {
+				t.Fatal(terr)
+			}
+			res, err = txn.QueryExtended(c.q)
+			defer txn.Discard()
+		} else {
+			res, err = d.QueryExtended(c.q)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries, err := res.Rest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != len(c.expKeys) {
+			t.Fatalf("%s: expected %d entries, got %d", c.name, len(c.expKeys), len(entries))
+		}
+		for i, e := range entries {
+			if e.Key != c.expKeys[i] {
+				t.Fatalf("%s: expected key %s at %d, got %s", c.name, c.expKeys[i], i, e.Key)
+			}
+		}
+	}
+}
+
+func testGC(t *testing.T, d Datastore) {
+	for i := 0; i < 100; i++ {
+		k := ds.NewKey(fmt.Sprintf("/gc/%d", i))
+		if err := d.Put(k, make([]byte, 1024)); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Delete(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.CollectGarbage(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.DiskUsage(); err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := d.(Metered); ok {
+		s := m.Stats()
+		if s.Puts != 100 || s.Deletes != 100 {
+			t.Fatalf("unexpected stats: %+v", s)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	for _, b := range []Backend{BackendBadger3, BackendPebble} {
+		b := b
+		t.Run(string(b), func(t *testing.T) {
+			from, clean := newTestDatastore(t, BackendBadger)
+			defer clean()
+			to, clean := newTestDatastore(t, b)
+			defer clean()
+
+			for i := 0; i < 25; i++ {
+				if err := from.Put(ds.NewKey(fmt.Sprintf("/m/%d", i)), []byte{byte(i)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var progress []int
+			n, err := Migrate(context.Background(), from, to,
+				WithMigrateBatchSize(10),
+				WithMigrateProgress(func(copied int) {
+					progress = append(progress, copied)
+				}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != 25 {
+				t.Fatalf("expected 25 migrated entries, got %d", n)
+			}
+			if len(progress) != 3 || progress[2] != 25 {
+				t.Fatalf("unexpected progress: %v", progress)
+			}
+			for i := 0; i < 25; i++ {
+				v, err := to.Get(ds.NewKey(fmt.Sprintf("/m/%d", i)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(v) != 1 || v[0] != byte(i) {
+					t.Fatalf("unexpected value of entry %d: %v", i, v)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateRepo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src, err := New(BackendBadger, Path(dir, "eventstore", BackendBadger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Put(ds.NewKey("/e/1"), []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// missing datastores are skipped
+	names := []string{"eventstore", "logstore"}
+	if err := MigrateRepo(context.Background(), dir, BackendBadger, BackendPebble, names); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, string(BackendPebble), "logstore")); !os.IsNotExist(err) {
+		t.Fatal("missing source datastore should not be migrated")
+	}
+
+	dst, err := New(BackendPebble, Path(dir, "eventstore", BackendPebble))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := dst.Get(ds.NewKey("/e/1")); err != nil || string(v) != "v1" {
+		t.Fatalf("migrated entry not found, got %s (%v)", v, err)
+	}
+	// entries written after a migration aren't overwritten by the next run
+	if err := dst.Put(ds.NewKey("/e/1"), []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := MigrateRepo(context.Background(), dir, BackendBadger, BackendPebble, names); err != nil {
+		t.Fatal(err)
+	}
+	dst, err = New(BackendPebble, Path(dir, "eventstore", BackendPebble))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	if v, err := dst.Get(ds.NewKey("/e/1")); err != nil || string(v) != "v2" {
+		t.Fatalf("migrated datastore should be skipped, got %s (%v)", v, err)
+	}
+}
+
+func TestParseBackend(t *testing.T) {
+	for _, b := range Backends {
+		if parsed, err := ParseBackend(string(b)); err != nil || parsed != b {
+			t.Fatalf("parsing %s failed: %v", b, err)
+		}
+	}
+	if _, err := ParseBackend("leveldb"); err == nil {
+		t.Fatal("unknown backend should not be parsed")
+	}
+}
+
+func newTestDatastore(t *testing.T, b Backend) (Datastore, func()) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := New(b, dir, WithGCInterval(-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, func() {
+		_ = d.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,170 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// DefaultMigrateBatchSize is the default number of entries written per batch while migrating.
+const DefaultMigrateBatchSize = 1000
+
+// migratedKey records the backend a datastore was migrated from, in db key:
+// /datastore/migrated:<backend>
+func migratedKey(from Backend) ds.Key {
+	return ds.NewKey("/datastore/migrated:" + string(from))
+}
+
+// MigrateOptions defines options for migrating datastores.
+type MigrateOptions struct {
+	BatchSize int
+	Progress  func(copied int)
+}
+
+// MigrateOption specifies migration options.
+type MigrateOption func(*MigrateOptions)
+
+// WithMigrateBatchSize sets the number of entries written per batch, defaults to DefaultMigrateBatchSize.
+func WithMigrateBatchSize(size int) MigrateOption {
+	return func(args *MigrateOptions) {
+		args.BatchSize = size
+	}
+}
+
+// WithMigrateProgress sets a function called with the number of entries copied so far,
+// after each batch is written.
+func WithMigrateProgress(f func(copied int)) MigrateOption {
+	return func(args *MigrateOptions) {
+		args.Progress = f
+	}
+}
+
+// Migrate copies all entries of from into to, and returns the number of copied entries.
+// Transactional sources are read from a snapshot, so they keep serving reads and writes
+// while the copy runs, but writes made after the copy started aren't migrated.
+// Entries of to which aren't in from are left untouched.
+func Migrate(ctx context.Context, from ds.Datastore, to ds.Batching, opts ...MigrateOption) (int, error) {
+	args := &MigrateOptions{BatchSize: DefaultMigrateBatchSize}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if args.BatchSize <= 0 {
+		return 0, fmt.Errorf("migrate batch size must be positive")
+	}
+
+	var reader ds.Read = from
+	if tds, ok := from.(ds.TxnDatastore); ok {
+		txn, err := tds.NewTransaction(true)
+		if err != nil {
+			return 0, err
+		}
+		defer txn.Discard()
+		reader = txn
+	}
+	results, err := reader.Query(dsq.Query{})
+	if err != nil {
+		return 0, fmt.Errorf("querying source: %w", err)
+	}
+	defer results.Close()
+
+	var copied, pending int
+	batch, err := to.Batch()
+	if err != nil {
+		return 0, err
+	}
+	flush := func() error {
+		if err := batch.Commit(); err != nil {
+			return fmt.Errorf("writing batch: %w", err)
+		}
+		copied += pending
+		pending = 0
+		if args.Progress != nil {
+			args.Progress(copied)
+		}
+		batch, err = to.Batch()
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return copied, ctx.Err()
+		case r, ok := <-results.Next():
+			if !ok {
+				if pending > 0 {
+					if err := flush(); err != nil {
+						return copied, err
+					}
+				}
+				return copied, nil
+			}
+			if r.Error != nil {
+				return copied, fmt.Errorf("reading source: %w", r.Error)
+			}
+			if err := batch.Put(ds.RawKey(r.Key), r.Value); err != nil {
+				return copied, err
+			}
+			pending++
+			if pending == args.BatchSize {
+				if err := flush(); err != nil {
+					return copied, err
+				}
+			}
+		}
+	}
+}
+
+// MigrateRepo migrates the named datastores of a repo from one backend to another.
+// Datastores which don't exist in the source backend are skipped, as are the ones migrated
+// before, so an interrupted migration is resumed by running it again. Source datastores
+// are left in place.
+func MigrateRepo(ctx context.Context, repoPath string, from, to Backend, names []string, opts ...Option) error {
+	if from == to {
+		return fmt.Errorf("source and destination backends are the same: %s", from)
+	}
+	for _, name := range names {
+		if err := migrateRepoStore(ctx, repoPath, name, from, to, opts...); err != nil {
+			return fmt.Errorf("migrating %s from %s to %s: %w", name, from, to, err)
+		}
+	}
+	return nil
+}
+
+func migrateRepoStore(ctx context.Context, repoPath, name string, from, to Backend, opts ...Option) error {
+	srcPath := Path(repoPath, name, from)
+	if _, err := os.Stat(srcPath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	dst, err := New(to, Path(repoPath, name, to), opts...)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	if done, err := dst.Has(migratedKey(from)); err != nil {
+		return err
+	} else if done {
+		return nil
+	}
+
+	src, err := New(from, srcPath, opts...)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	n, err := Migrate(ctx, src, dst, WithMigrateProgress(func(copied int) {
+		log.Debugf("migrated %d entries of %s", copied, name)
+	}))
+	if err != nil {
+		return err
+	}
+	if err := dst.Put(migratedKey(from), []byte{1}); err != nil {
+		return err
+	}
+	log.Infof("migrated %d entries of %s from %s to %s", n, name, from, to)
+	return dst.Sync(ds.NewKey("/"))
+}
@@ -0,0 +1,530 @@
+package datastore
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+var (
+	_ Datastore  = (*pebbleDatastore)(nil)
+	_ Metered    = (*pebbleDatastore)(nil)
+	_ dse.TxnExt = (*pebbleTxn)(nil)
+)
+
+// errReadOnlyTxn indicates a write to a read-only transaction.
+var errReadOnlyTxn = errors.New("read-only transaction")
+
+// pebbleGCMinDeletes is the number of deletes which makes a garbage collection
+// cycle compact the key space, dropping the deleted entries.
+const pebbleGCMinDeletes = 10000
+
+// pebbleOptions returns Pebble options tuned for thread entries. Level 0 is compacted early
+// and allowed to grow large before writes stall, so bursts of records don't block writers,
+// and compactions run concurrently to pay the debt back. Bloom filters speed up the lookups
+// of missing keys, e.g. when records are checked before being fetched.
+func pebbleOptions(args *Options) *pebble.Options {
+	opts := &pebble.Options{
+		L0CompactionThreshold:       2,
+		L0StopWritesThreshold:       1000,
+		LBaseMaxBytes:               64 << 20,
+		MaxConcurrentCompactions:    3,
+		MaxOpenFiles:                1000,
+		MemTableSize:                64 << 20,
+		MemTableStopWritesThreshold: 4,
+		Logger:                      log,
+	}
+	if args.LowMem {
+		opts.MaxConcurrentCompactions = 1
+		opts.MaxOpenFiles = 256
+		opts.MemTableSize = 8 << 20
+	}
+	opts.Levels = make([]pebble.LevelOptions, 7)
+	for i := range opts.Levels {
+		l := &opts.Levels[i]
+		l.BlockSize = 32 << 10
+		l.IndexBlockSize = 256 << 10
+		l.FilterPolicy = bloom.FilterPolicy(10)
+		l.FilterType = pebble.TableFilter
+		l.TargetFileSize = 2 << 20
+		if i > 0 {
+			l.TargetFileSize = opts.Levels[i-1].TargetFileSize * 2
+		}
+		l.EnsureDefaults()
+	}
+	return opts.EnsureDefaults()
+}
+
+// pebbleDatastore is a datastore backed by Pebble.
+type pebbleDatastore struct {
+	counters
+	life       lifecycle
+	db         *pebble.DB
+	writeOpts  *pebble.WriteOptions
+	gcInterval time.Duration
+
+	// gcDeletes is the delete count at the last garbage collection
+	gcDeletes uint64
+	gcLk      sync.Mutex
+}
+
+func newPebble(path string, args *Options) (*pebbleDatastore, error) {
+	cacheSize := int64(128 << 20)
+	if args.LowMem {
+		cacheSize = 16 << 20
+	}
+	cache := pebble.NewCache(cacheSize)
+	// the database holds its own reference
+	defer cache.Unref()
+
+	opts := pebbleOptions(args)
+	opts.Cache = cache
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	d := &pebbleDatastore{
+		life:       newLifecycle(),
+		db:         db,
+		writeOpts:  pebble.NoSync,
+		gcInterval: args.GCInterval,
+	}
+	if args.SyncWrites {
+		d.writeOpts = pebble.Sync
+	}
+	if d.gcInterval > 0 {
+		go d.periodicGC()
+	}
+	return d, nil
+}
+
+// periodicGC compacts the key space every gcInterval if enough entries were deleted since
+// the last cycle. Pebble compacts on its own, but tombstones of deleted entries linger
+// in the lower levels until compactions reach them.
+func (d *pebbleDatastore) periodicGC() {
+	ticker := time.NewTicker(d.gcInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.gcOnce(false); err != nil && err != ErrClosed {
+				log.Errorf("pebble gc cycle failed: %v", err)
+			}
+		case <-d.life.closing:
+			return
+		}
+	}
+}
+
+func (d *pebbleDatastore) gcOnce(force bool) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	d.gcLk.Lock()
+	defer d.gcLk.Unlock()
+
+	deletes := atomic.LoadUint64(&d.counters.deletes)
+	if !force && deletes-d.gcDeletes < pebbleGCMinDeletes {
+		return nil
+	}
+	start := time.Now()
+	it := d.db.NewIter(nil)
+	var first, last []byte
+	if it.First() {
+		first = append(first, it.Key()...)
+	}
+	if it.Last() {
+		last = append(last, it.Key()...)
+	}
+	if err := it.Close(); err != nil {
+		return err
+	}
+	if first != nil {
+		if err := d.db.Compact(first, append(last, 0)); err != nil {
+			return err
+		}
+	}
+	d.gcDeletes = deletes
+	d.counters.gc(start)
+	return nil
+}
+
+func (d *pebbleDatastore) CollectGarbage() error {
+	return d.gcOnce(true)
+}
+
+func (d *pebbleDatastore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return d.newTransaction(readOnly)
+}
+
+func (d *pebbleDatastore) NewTransactionExtended(readOnly bool) (dse.TxnExt, error) {
+	return d.newTransaction(readOnly)
+}
+
+// newTransaction returns a transaction reading from a snapshot if readOnly, or an indexed
+// batch otherwise. Write transactions see their own writes and are committed atomically,
+// but Pebble doesn't detect conflicts between them.
+func (d *pebbleDatastore) newTransaction(readOnly bool) (*pebbleTxn, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	t := &pebbleTxn{ds: d}
+	if readOnly {
+		t.snap = d.db.NewSnapshot()
+		t.reader = t.snap
+	} else {
+		t.batch = d.db.NewIndexedBatch()
+		t.reader = t.batch
+	}
+	return t, nil
+}
+
+func (d *pebbleDatastore) Put(key ds.Key, value []byte) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	d.counters.put(len(value))
+	return d.db.Set(key.Bytes(), value, d.writeOpts)
+}
+
+func (d *pebbleDatastore) Delete(key ds.Key) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	d.counters.delete()
+	return d.db.Delete(key.Bytes(), d.writeOpts)
+}
+
+func (d *pebbleDatastore) Get(key ds.Key) ([]byte, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	return pebbleGet(d, d.db, key)
+}
+
+func (d *pebbleDatastore) Has(key ds.Key) (bool, error) {
+	if !d.life.acquire() {
+		return false, ErrClosed
+	}
+	defer d.life.release()
+	return pebbleHas(d.db, key)
+}
+
+func (d *pebbleDatastore) GetSize(key ds.Key) (int, error) {
+	if !d.life.acquire() {
+		return -1, ErrClosed
+	}
+	defer d.life.release()
+	return pebbleGetSize(d.db, key)
+}
+
+func (d *pebbleDatastore) Query(q dsq.Query) (dsq.Results, error) {
+	return d.QueryExtended(dse.QueryExt{Query: q})
+}
+
+func (d *pebbleDatastore) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	// queries outside of transactions read from a snapshot, which is released once the query stops
+	snap := d.db.NewSnapshot()
+	return pebbleQuery(d, snap, q, func() { _ = snap.Close() })
+}
+
+func (d *pebbleDatastore) Sync(ds.Key) error {
+	if !d.life.acquire() {
+		return ErrClosed
+	}
+	defer d.life.release()
+	// a synced empty log entry syncs the write-ahead log
+	return d.db.LogData(nil, pebble.Sync)
+}
+
+func (d *pebbleDatastore) Batch() (ds.Batch, error) {
+	if !d.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer d.life.release()
+	return &pebbleBatch{ds: d, batch: d.db.NewBatch()}, nil
+}
+
+// DiskUsage returns the size of the sstables and write-ahead log in bytes.
+func (d *pebbleDatastore) DiskUsage() (uint64, error) {
+	if !d.life.acquire() {
+		return 0, ErrClosed
+	}
+	defer d.life.release()
+	return pebbleDiskUsage(d.db.Metrics()), nil
+}
+
+// Stats returns the datastore metrics. Cache metrics are those of the block cache.
+func (d *pebbleDatastore) Stats() Stats {
+	s := d.counters.snapshot(BackendPebble)
+	if !d.life.acquire() {
+		return s
+	}
+	defer d.life.release()
+	m := d.db.Metrics()
+	s.Compactions = m.Compact.Count
+	s.Backlog = m.Compact.EstimatedDebt
+	s.CacheHits = uint64(m.BlockCache.Hits)
+	s.CacheMisses = uint64(m.BlockCache.Misses)
+	s.DiskUsage = pebbleDiskUsage(m)
+	return s
+}
+
+func (d *pebbleDatastore) Close() error {
+	return d.life.close(d.db.Close)
+}
+
+func pebbleDiskUsage(m *pebble.Metrics) uint64 {
+	return uint64(m.Total().Size) + m.WAL.Size + m.Table.ZombieSize
+}
+
+// pebbleReader is implemented by the database, snapshots, and indexed batches.
+type pebbleReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) *pebble.Iterator
+}
+
+func pebbleGet(d *pebbleDatastore, r pebbleReader, key ds.Key) ([]byte, error) {
+	v, closer, err := r.Get(key.Bytes())
+	if err == pebble.ErrNotFound {
+		return nil, ds.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	d.counters.get(len(v))
+	return append([]byte{}, v...), nil
+}
+
+func pebbleHas(r pebbleReader, key ds.Key) (bool, error) {
+	_, closer, err := r.Get(key.Bytes())
+	if err == pebble.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, closer.Close()
+}
+
+func pebbleGetSize(r pebbleReader, key ds.Key) (int, error) {
+	v, closer, err := r.Get(key.Bytes())
+	if err == pebble.ErrNotFound {
+		return -1, ds.ErrNotFound
+	} else if err != nil {
+		return -1, err
+	}
+	defer closer.Close()
+	return len(v), nil
+}
+
+func pebbleQuery(d *pebbleDatastore, r pebbleReader, q dse.QueryExt, done func()) (dsq.Results, error) {
+	d.counters.query()
+	return runQuery(&d.life, q, func(prefix []byte, reverse, _ bool) (iterator, error) {
+		opts := &pebble.IterOptions{}
+		if len(prefix) > 0 {
+			opts.LowerBound = prefix
+			opts.UpperBound = prefixEnd(prefix)
+		}
+		return &pebbleIterator{ds: d, it: r.NewIter(opts), reverse: reverse}, nil
+	}, done)
+}
+
+// pebbleBatch is a Pebble write batch.
+type pebbleBatch struct {
+	ds    *pebbleDatastore
+	batch *pebble.Batch
+}
+
+func (b *pebbleBatch) Put(key ds.Key, value []byte) error {
+	b.ds.counters.put(len(value))
+	return b.batch.Set(key.Bytes(), value, nil)
+}
+
+func (b *pebbleBatch) Delete(key ds.Key) error {
+	b.ds.counters.delete()
+	return b.batch.Delete(key.Bytes(), nil)
+}
+
+func (b *pebbleBatch) Commit() error {
+	if !b.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer b.ds.life.release()
+	if err := b.batch.Commit(b.ds.writeOpts); err != nil {
+		return err
+	}
+	b.ds.counters.commit(false)
+	// batches are reusable after a commit
+	b.batch.Reset()
+	return nil
+}
+
+// pebbleTxn is a transaction reading from a snapshot, or writing to an indexed batch.
+type pebbleTxn struct {
+	ds     *pebbleDatastore
+	snap   *pebble.Snapshot
+	batch  *pebble.Batch
+	reader pebbleReader
+	done   bool
+}
+
+func (t *pebbleTxn) Put(key ds.Key, value []byte) error {
+	if t.batch == nil {
+		return errReadOnlyTxn
+	}
+	t.ds.counters.put(len(value))
+	return t.batch.Set(key.Bytes(), value, nil)
+}
+
+func (t *pebbleTxn) Delete(key ds.Key) error {
+	if t.batch == nil {
+		return errReadOnlyTxn
+	}
+	t.ds.counters.delete()
+	return t.batch.Delete(key.Bytes(), nil)
+}
+
+func (t *pebbleTxn) Get(key ds.Key) ([]byte, error) {
+	if !t.ds.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer t.ds.life.release()
+	return pebbleGet(t.ds, t.reader, key)
+}
+
+func (t *pebbleTxn) Has(key ds.Key) (bool, error) {
+	if !t.ds.life.acquire() {
+		return false, ErrClosed
+	}
+	defer t.ds.life.release()
+	return pebbleHas(t.reader, key)
+}
+
+func (t *pebbleTxn) GetSize(key ds.Key) (int, error) {
+	if !t.ds.life.acquire() {
+		return -1, ErrClosed
+	}
+	defer t.ds.life.release()
+	return pebbleGetSize(t.reader, key)
+}
+
+func (t *pebbleTxn) Query(q dsq.Query) (dsq.Results, error) {
+	return t.QueryExtended(dse.QueryExt{Query: q})
+}
+
+func (t *pebbleTxn) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	if !t.ds.life.acquire() {
+		return nil, ErrClosed
+	}
+	defer t.ds.life.release()
+	return pebbleQuery(t.ds, t.reader, q, nil)
+}
+
+func (t *pebbleTxn) Commit() error {
+	if !t.ds.life.acquire() {
+		return ErrClosed
+	}
+	defer t.ds.life.release()
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+	t.done = true
+	if t.snap != nil {
+		return t.snap.Close()
+	}
+	defer t.batch.Close()
+	if err := t.batch.Commit(t.ds.writeOpts); err != nil {
+		return err
+	}
+	t.ds.counters.commit(false)
+	return nil
+}
+
+func (t *pebbleTxn) Discard() {
+	if !t.ds.life.acquire() {
+		return
+	}
+	defer t.ds.life.release()
+	if t.done {
+		return
+	}
+	t.done = true
+	if t.snap != nil {
+		_ = t.snap.Close()
+	} else {
+		_ = t.batch.Close()
+	}
+}
+
+// pebbleIterator adapts a Pebble iterator to queries.
+type pebbleIterator struct {
+	ds      *pebbleDatastore
+	it      *pebble.Iterator
+	reverse bool
+}
+
+func (i *pebbleIterator) rewind() {
+	if i.reverse {
+		i.it.Last()
+	} else {
+		i.it.First()
+	}
+}
+
+func (i *pebbleIterator) seek(key []byte) {
+	if i.reverse {
+		// position at the last key not past key
+		i.it.SeekLT(append(append([]byte{}, key...), 0))
+	} else {
+		i.it.SeekGE(key)
+	}
+}
+
+func (i *pebbleIterator) valid() bool {
+	return i.it.Valid()
+}
+
+func (i *pebbleIterator) next() {
+	if i.reverse {
+		i.it.Prev()
+	} else {
+		i.it.Next()
+	}
+}
+
+func (i *pebbleIterator) key() []byte {
+	return append([]byte{}, i.it.Key()...)
+}
+
+func (i *pebbleIterator) value() ([]byte, error) {
+	v := append([]byte{}, i.it.Value()...)
+	i.ds.counters.read(len(v))
+	return v, nil
+}
+
+func (i *pebbleIterator) size() int {
+	return len(i.it.Value())
+}
+
+func (i *pebbleIterator) close() {
+	if err := i.it.Close(); err != nil {
+		log.Errorf("closing pebble iterator: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package datastore
+
+import (
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/jbenet/goprocess"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+// lifecycle guards datastore operations against a concurrent Close.
+type lifecycle struct {
+	lk        sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+	closing   chan struct{}
+}
+
+func newLifecycle() lifecycle {
+	return lifecycle{closing: make(chan struct{})}
+}
+
+// acquire returns false if the datastore was closed, release must be called otherwise.
+func (l *lifecycle) acquire() bool {
+	l.lk.RLock()
+	if l.closed {
+		l.lk.RUnlock()
+		return false
+	}
+	return true
+}
+
+func (l *lifecycle) release() {
+	l.lk.RUnlock()
+}
+
+// close stops background tasks and waits for running operations before calling closeFn.
+func (l *lifecycle) close(closeFn func() error) error {
+	l.closeOnce.Do(func() {
+		close(l.closing)
+	})
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	if l.closed {
+		return ErrClosed
+	}
+	l.closed = true
+	return closeFn()
+}
+
+// iterator is the ordered key iteration a backend provides to queries.
+type iterator interface {
+	// rewind positions the iterator at the first entry in iteration order.
+	rewind()
+	// seek positions the iterator at the first entry at or past key in iteration order.
+	seek(key []byte)
+	valid() bool
+	next()
+	key() []byte
+	// value returns a copy of the current value.
+	value() ([]byte, error)
+	size() int
+	close()
+}
+
+// openIterator opens an iterator over keys starting with prefix.
+type openIterator func(prefix []byte, reverse, keysOnly bool) (iterator, error)
+
+// runQuery executes q over the iterator returned by open. The iterator is opened and
+// closed while life is held, so is done called once the query stops.
+func runQuery(life *lifecycle, q dse.QueryExt, open openIterator, done func()) (dsq.Results, error) {
+	var reverse bool
+	if len(q.Orders) > 0 {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey:
+		case dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			reverse = true
+		default:
+			// run the base query and apply the orders, offset, and limit afterwards
+			base := q
+			base.Limit = 0
+			base.Offset = 0
+			base.Orders = nil
+			res, err := runQuery(life, base, open, done)
+			if err != nil {
+				return nil, err
+			}
+			res = dsq.ResultsReplaceQuery(res, q.Query)
+			naive := q.Query
+			naive.Prefix = ""
+			naive.Filters = nil
+			return dsq.NaiveQueryApply(naive, res), nil
+		}
+	}
+
+	var prefix []byte
+	if p := ds.NewKey(q.Prefix).String(); p != "/" {
+		prefix = []byte(p + "/")
+	}
+
+	qrb := dsq.NewResultBuilder(q.Query)
+	qrb.Process.Go(func(worker goprocess.Process) {
+		if !life.acquire() {
+			select {
+			case qrb.Output <- dsq.Result{Error: ErrClosed}:
+			case <-worker.Closing():
+			}
+			return
+		}
+		defer life.release()
+		if done != nil {
+			defer done()
+		}
+
+		send := func(r dsq.Result) bool {
+			select {
+			case qrb.Output <- r:
+				return true
+			case <-life.closing:
+				return false
+			case <-worker.Closing():
+				return false
+			}
+		}
+
+		it, err := open(prefix, reverse, q.KeysOnly)
+		if err != nil {
+			send(dsq.Result{Error: err})
+			return
+		}
+		defer it.close()
+
+		if q.SeekPrefix != "" {
+			it.seek([]byte(q.SeekPrefix))
+		} else {
+			it.rewind()
+		}
+
+		// skip to the offset, which applies after filters
+		for skipped := 0; skipped < q.Offset && it.valid(); it.next() {
+			if len(q.Filters) == 0 {
+				skipped++
+				continue
+			}
+			e := dsq.Entry{Key: string(it.key()), Size: it.size()}
+			if !q.KeysOnly {
+				v, err := it.value()
+				if err != nil {
+					if !send(dsq.Result{Error: err}) {
+						return
+					}
+					continue
+				}
+				e.Value = v
+			}
+			if !filtered(q.Filters, e) {
+				skipped++
+			}
+		}
+
+		for sent := 0; (q.Limit <= 0 || sent < q.Limit) && it.valid(); it.next() {
+			e := dsq.Entry{Key: string(it.key()), Size: it.size()}
+			if !q.KeysOnly {
+				v, err := it.value()
+				if err != nil {
+					if !send(dsq.Result{Error: err}) {
+						return
+					}
+					continue
+				}
+				e.Value = v
+			}
+			if filtered(q.Filters, e) {
+				continue
+			}
+			if !send(dsq.Result{Entry: e}) {
+				return
+			}
+			sent++
+		}
+	})
+	go qrb.Process.CloseAfterChildren() //nolint
+
+	return qrb.Results(), nil
+}
+
+// filtered returns true if the entry doesn't pass all filters.
+func filtered(filters []dsq.Filter, e dsq.Entry) bool {
+	for _, f := range filters {
+		if !f.Filter(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixEnd returns the smallest key greater than all keys starting with prefix,
+// or nil if there's none.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
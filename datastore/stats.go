@@ -0,0 +1,106 @@
+package datastore
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of datastore metrics. Counters are accumulated since the datastore was opened.
+type Stats struct {
+	Backend Backend
+
+	Gets         uint64
+	Puts         uint64
+	Deletes      uint64
+	Queries      uint64
+	BytesRead    uint64
+	BytesWritten uint64
+
+	Commits   uint64
+	Conflicts uint64
+
+	// GCCycles is the number of garbage collection cycles which reclaimed space.
+	GCCycles       uint64
+	LastGC         time.Time
+	LastGCDuration time.Duration
+
+	// Compactions is the number of compactions run by the backend.
+	Compactions int64
+	// Backlog is the number of bytes the backend estimates it has to compact.
+	Backlog uint64
+
+	CacheHits   uint64
+	CacheMisses uint64
+	DiskUsage   uint64
+}
+
+// counters collects the operation metrics of a datastore.
+type counters struct {
+	gets           uint64
+	puts           uint64
+	deletes        uint64
+	queries        uint64
+	bytesRead      uint64
+	bytesWritten   uint64
+	commits        uint64
+	conflicts      uint64
+	gcCycles       uint64
+	lastGC         int64
+	lastGCDuration int64
+}
+
+func (c *counters) get(size int) {
+	atomic.AddUint64(&c.gets, 1)
+	atomic.AddUint64(&c.bytesRead, uint64(size))
+}
+
+func (c *counters) put(size int) {
+	atomic.AddUint64(&c.puts, 1)
+	atomic.AddUint64(&c.bytesWritten, uint64(size))
+}
+
+func (c *counters) delete() {
+	atomic.AddUint64(&c.deletes, 1)
+}
+
+func (c *counters) query() {
+	atomic.AddUint64(&c.queries, 1)
+}
+
+func (c *counters) read(size int) {
+	atomic.AddUint64(&c.bytesRead, uint64(size))
+}
+
+func (c *counters) commit(conflict bool) {
+	if conflict {
+		atomic.AddUint64(&c.conflicts, 1)
+	} else {
+		atomic.AddUint64(&c.commits, 1)
+	}
+}
+
+func (c *counters) gc(start time.Time) {
+	atomic.AddUint64(&c.gcCycles, 1)
+	atomic.StoreInt64(&c.lastGC, start.UnixNano())
+	atomic.StoreInt64(&c.lastGCDuration, int64(time.Since(start)))
+}
+
+func (c *counters) snapshot(backend Backend) Stats {
+	s := Stats{
+		Backend:        backend,
+		Gets:           atomic.LoadUint64(&c.gets),
+		Puts:           atomic.LoadUint64(&c.puts),
+		Deletes:        atomic.LoadUint64(&c.deletes),
+		Queries:        atomic.LoadUint64(&c.queries),
+		BytesRead:      atomic.LoadUint64(&c.bytesRead),
+		BytesWritten:   atomic.LoadUint64(&c.bytesWritten),
+		Commits:        atomic.LoadUint64(&c.commits),
+		Conflicts:      atomic.LoadUint64(&c.conflicts),
+		GCCycles:       atomic.LoadUint64(&c.gcCycles),
+		LastGCDuration: time.Duration(atomic.LoadInt64(&c.lastGCDuration)),
+	}
+	if last := atomic.LoadInt64(&c.lastGC); last != 0 {
+		s.LastGC = time.Unix(0, last)
+	}
+	return s
+}
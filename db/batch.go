@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// commitBatch is a set of committed actions written to the thread in a single record.
+type commitBatch struct {
+	token   thread.Token
+	actions []core.Action
+	timer   *time.Timer
+	done    chan struct{}
+	err     error
+}
+
+// wait blocks until the batch is written and returns the write error.
+func (b *commitBatch) wait() error {
+	<-b.done
+	return b.err
+}
+
+// commitBatcher coalesces the actions of write transactions committed within a window
+// into a single net record. Batches are written in commit order, and a batch is flushed
+// early once it reaches the maximum number of actions or a commit with another token arrives.
+type commitBatcher struct {
+	sync.Mutex
+	db         *DB
+	window     time.Duration
+	maxActions int
+	pending    *commitBatch
+	flushes    chan *commitBatch
+	closed     chan struct{}
+}
+
+func newCommitBatcher(d *DB, window time.Duration, maxActions int) *commitBatcher {
+	b := &commitBatcher{
+		db:         d,
+		window:     window,
+		maxActions: maxActions,
+		flushes:    make(chan *commitBatch, 1),
+		closed:     make(chan struct{}),
+	}
+	go b.writeBatches()
+	return b
+}
+
+// add actions of a committed transaction to the pending batch, which is returned.
+func (b *commitBatcher) add(token thread.Token, actions []core.Action) *commitBatch {
+	b.Lock()
+	defer b.Unlock()
+	if b.pending != nil && b.pending.token != token {
+		b.flushLocked()
+	}
+	if b.pending == nil {
+		batch := &commitBatch{token: token, done: make(chan struct{})}
+		batch.timer = time.AfterFunc(b.window, func() {
+			b.flush(batch)
+		})
+		b.pending = batch
+	}
+	batch := b.pending
+	batch.actions = append(batch.actions, actions...)
+	if b.maxActions > 0 && len(batch.actions) >= b.maxActions {
+		b.flushLocked()
+	}
+	return batch
+}
+
+// flush the batch if it's still pending.
+func (b *commitBatcher) flush(batch *commitBatch) {
+	b.Lock()
+	defer b.Unlock()
+	if b.pending == batch {
+		b.flushLocked()
+	}
+}
+
+func (b *commitBatcher) flushLocked() {
+	b.pending.timer.Stop()
+	b.flushes <- b.pending
+	b.pending = nil
+}
+
+// close flushes the pending batch and waits for all batches to be written.
+func (b *commitBatcher) close() {
+	b.Lock()
+	if b.pending != nil {
+		b.flushLocked()
+	}
+	close(b.flushes)
+	b.Unlock()
+	<-b.closed
+}
+
+func (b *commitBatcher) writeBatches() {
+	defer close(b.closed)
+	for batch := range b.flushes {
+		ctx, cancel := context.WithTimeout(context.Background(), createNetRecordTimeout)
+		batch.err = b.db.writeActions(ctx, batch.actions, batch.token, b.db.dispatcher.Dispatch)
+		cancel()
+		if batch.err != nil {
+			log.Errorf("writing batch of %d actions failed: %v", len(batch.actions), batch.err)
+		}
+		close(batch.done)
+	}
+}
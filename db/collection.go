@@ -62,6 +62,9 @@ type Collection struct {
 	writeValidator    goja.Callable
 	rawReadFilter     []byte
 	readFilter        goja.Callable
+	rawMigration      []byte
+	migration         goja.Callable
+	ttl               time.Duration
 	sync.Mutex
 }
 
@@ -80,6 +83,9 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 	if idType.Type != "string" {
 		return nil, ErrInvalidCollectionSchema
 	}
+	if config.TTL < 0 {
+		return nil, ErrInvalidTTL
+	}
 	sb, err := json.Marshal(config.Schema)
 	if err != nil {
 		return nil, err
@@ -87,6 +93,7 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 	vm := goja.New()
 	wv := []byte(config.WriteValidator)
 	rf := []byte(config.ReadFilter)
+	mg := []byte(config.Migration)
 	c := &Collection{
 		name:              config.Name,
 		schemaLoader:      gojsonschema.NewBytesLoader(sb),
@@ -95,6 +102,8 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 		vm:                vm,
 		rawWriteValidator: wv,
 		rawReadFilter:     rf,
+		rawMigration:      mg,
+		ttl:               config.TTL,
 	}
 	wvObj, err := compileJSFunc(wv, writeValidatorFn, "writer", "event", "instance")
 	if err != nil {
@@ -116,6 +125,16 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 			return nil, err
 		}
 	}
+	mgObj, err := compileJSFunc(mg, migrationFn, "instance")
+	if err != nil {
+		return nil, err
+	}
+	if mgObj != nil {
+		c.migration, err = loadJSFunc(vm, migrationFn, mgObj)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return c, nil
 }
 
@@ -291,7 +310,11 @@ func (c *Collection) ModifiedSince(time int64, opts ...TxnOption) (ids []core.In
 
 // validInstance validates the json object against the collection schema.
 func (c *Collection) validInstance(v []byte) error {
-	r, err := gojsonschema.Validate(c.schemaLoader, gojsonschema.NewBytesLoader(v))
+	return validateInstance(c.schemaLoader, v)
+}
+
+func validateInstance(schemaLoader gojsonschema.JSONLoader, v []byte) error {
+	r, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewBytesLoader(v))
 	if err != nil {
 		return err
 	}
@@ -358,7 +381,12 @@ func (c *Collection) validWrite(identity thread.PubKey, e core.Event) error {
 }
 
 // filterRead filters an instance against the identity and user-defined read filter function.
+// Instances are upgraded with the collection migration first, if needed.
 func (c *Collection) filterRead(identity thread.PubKey, instance []byte) ([]byte, error) {
+	instance, err := c.upgrade(instance)
+	if err != nil {
+		return nil, err
+	}
 	c.Lock()
 	defer c.Unlock()
 	if c.readFilter == nil {
@@ -393,6 +421,7 @@ type Txn struct {
 	discarded  bool
 	committed  bool
 	readonly   bool
+	explain    *Explain
 
 	actions []core.Action
 }
@@ -417,6 +446,9 @@ func (t *Txn) Create(new ...[]byte) ([]core.InstanceID, error) {
 		if id == core.EmptyInstanceID {
 			id, updated = setNewInstanceID(updated)
 		}
+		if updated, err = t.beforeCreate(id, updated); err != nil {
+			return nil, err
+		}
 
 		if err := t.collection.validInstance(updated); err != nil {
 			return nil, err
@@ -497,6 +529,10 @@ func (t *Txn) createSaveActions(identity thread.PubKey, updated ...[]byte) ([]co
 
 		next := make([]byte, len(updated[i]))
 		copy(next, updated[i])
+		next, err := t.beforeSave(next)
+		if err != nil {
+			return nil, err
+		}
 
 		if err := t.collection.validInstance(next); err != nil {
 			return nil, err
@@ -631,24 +667,33 @@ func (t *Txn) FindByID(id core.InstanceID) ([]byte, error) {
 // Commit applies all changes done in the current transaction
 // to the collection. This is a syncrhonous call so changes can
 // be assumed to be applied on function return.
+// Changes which don't fit into a single net record are split across
+// multiple records, which are not applied atomically.
 func (t *Txn) Commit() error {
-	events, node, err := t.createEvents(t.actions)
-	if err != nil {
-		return err
-	}
-	if node == nil {
-		return nil
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), createNetRecordTimeout)
 	defer cancel()
-	if _, err = t.collection.db.connector.CreateNetRecord(ctx, node, t.token); err != nil {
-		return err
+	return t.commit(ctx, t.actions)
+}
+
+// commit creates a net record for actions and dispatches their events.
+func (t *Txn) commit(ctx context.Context, actions []core.Action) error {
+	if t.discarded || t.committed {
+		return errAlreadyDiscardedCommitedTxn
 	}
-	if err = t.collection.db.dispatcher.Dispatch(events); err != nil {
-		return err
+	return t.collection.db.writeActions(ctx, actions, t.token, t.collection.db.dispatcher.Dispatch)
+}
+
+// commitBatched adds the transaction actions to a batch written to the thread by the batcher.
+// Their events are applied once the batch is written, so failed writes don't change the state.
+func (t *Txn) commitBatched(b *commitBatcher) (*commitBatch, error) {
+	_, node, err := t.createEvents(t.actions)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, nil
 	}
-	return t.collection.db.notifyTxnEvents(node, t.token)
+	return b.add(t.token, t.actions), nil
 }
 
 // Discard discards all changes done in the current transaction.
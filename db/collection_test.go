@@ -2,15 +2,19 @@ package db
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	logging "github.com/ipfs/go-log"
+	"github.com/textileio/go-threads/common"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/util"
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -261,9 +265,23 @@ func TestUpdateCollection(t *testing.T) {
 		_, err = c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
 		checkErr(t, err)
 
+		_, err = db.UpdateCollection(CollectionConfig{
+			Name:   "Dog",
+			Schema: util.SchemaFromInstance(&Dog2{}, false),
+		})
+		if !errors.Is(err, ErrIncompatibleSchema) {
+			t.Fatalf("expected incompatible schema error, got: %v", err)
+		}
 		c, err = db.UpdateCollection(CollectionConfig{
 			Name:   "Dog",
 			Schema: util.SchemaFromInstance(&Dog2{}, false),
+			Migration: `
+				instance.FullName = instance.Name
+				delete instance.Name
+				instance.Breed = ""
+				instance.Toys = {Favorite: "", Names: []}
+				return instance
+			`,
 		})
 		checkErr(t, err)
 		_, err = c.Create([]byte(`{"Name": "Fido", "Comments": []}`))
@@ -284,6 +302,9 @@ func TestUpdateCollection(t *testing.T) {
 		dog2 := &Dog2{}
 		err = json.Unmarshal(dogs[1], dog2)
 		checkErr(t, err)
+		if dog1.FullName != "Fido" && dog2.FullName != "Fido" {
+			t.Fatal("expected existing instance to be upgraded")
+		}
 	})
 	t.Run("AddFieldsAndIndexes", func(t *testing.T) {
 		t.Parallel()
@@ -771,6 +792,48 @@ func TestVariadic(t *testing.T) {
 	}
 }
 
+func TestCommitSplitsLargeTxn(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDBWithNetOptions(t, []common.NetOption{common.WithNetMaxRecordSize(4 << 10)})
+	defer clean()
+	m, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	counter := func() int64 {
+		info, err := db.connector.Net.GetThread(context.Background(), db.connector.ThreadID())
+		checkErr(t, err)
+		var c int64
+		for _, lg := range info.Logs {
+			c += lg.Head.Counter
+		}
+		return c
+	}
+	before := counter()
+
+	name := strings.Repeat("a", 256)
+	persons := make([][]byte, 32)
+	for i := range persons {
+		persons[i] = util.JSONFromInstance(&Person{Name: name, Age: i})
+	}
+	res, err := m.CreateMany(persons)
+	checkErr(t, err)
+	for i, id := range res {
+		assertPersonInCollection(t, m, util.SetJSONID(id, persons[i]))
+	}
+	if created := counter() - before; created < 2 {
+		t.Fatalf("expected the transaction to be split across records, got %d record(s)", created)
+	}
+
+	_, err = m.Create(util.JSONFromInstance(&Person{Name: strings.Repeat("a", 8<<10)}))
+	if !errors.Is(err, net.ErrRecordTooLarge) {
+		t.Fatalf("expected record too large error, got %v", err)
+	}
+}
+
 func TestGetInstance(t *testing.T) {
 	t.Parallel()
 
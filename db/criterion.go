@@ -40,6 +40,8 @@ const (
 	ge           // >=
 	le           // <=
 	fn           // func
+	contains
+	containsAny
 )
 
 type errTypeMismatch struct {
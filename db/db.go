@@ -17,7 +17,6 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	format "github.com/ipfs/go-ipld-format"
-	logging "github.com/ipfs/go-log"
 	ma "github.com/multiformats/go-multiaddr"
 	threadcbor "github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/app"
@@ -26,7 +25,8 @@ import (
 	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	kt "github.com/textileio/go-threads/db/keytransform"
-	"github.com/textileio/go-threads/util"
+	"github.com/textileio/go-threads/lifecycle"
+	"github.com/textileio/go-threads/logger"
 )
 
 const (
@@ -39,7 +39,7 @@ const (
 )
 
 var (
-	log = logging.Logger("db")
+	log = logger.Logger("db")
 
 	// ErrThreadReadKeyRequired indicates the provided thread key does not contain a read key.
 	ErrThreadReadKeyRequired = errors.New("thread read key is required")
@@ -50,6 +50,8 @@ var (
 	ErrInvalidCollectionSchema = errors.New("the collection schema _id property must be a string")
 	// ErrCannotIndexIDField indicates a custom index was specified on the ID field.
 	ErrCannotIndexIDField = errors.New("cannot create custom index on " + idFieldName)
+	// ErrConflictingOptions indicates the provided options conflict with each other or with arguments.
+	ErrConflictingOptions = errors.New("conflicting options")
 
 	nameRx *regexp.Regexp
 
@@ -85,10 +87,23 @@ type DB struct {
 	lock        sync.RWMutex
 	txnlock     sync.RWMutex
 	collections map[string]*Collection
-	closed      bool
+	// closed is guarded by txnlock
+	closed bool
 
 	localEventsBus      *app.LocalEventsBus
 	stateChangedNotifee *stateChangedNotifee
+	leases              *leaseTracker
+	batcher             *commitBatcher
+	expiry              *expirySweeper
+	metrics             *metrics
+	actionLog           *actionLog
+	hooks               map[string]Hooks
+	// remote is set while events of remote records are dispatched
+	remote bool
+	// sortMemoryLimit bounds the size of query results sorted in memory
+	sortMemoryLimit int
+	// life tracks the background goroutines, which are stopped on Close
+	life *lifecycle.Manager
 }
 
 // NewDB creates a new DB, which will *own* ds and dispatcher for internal use.
@@ -104,17 +119,17 @@ func NewDB(
 	for _, opt := range opts {
 		opt(args)
 	}
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
 	if args.Debug {
-		if err := util.SetLogLevels(map[string]logging.LogLevel{
-			"db": logging.LevelDebug,
+		if err := logger.SetLevels(map[string]logger.Level{
+			"db": logger.LevelDebug,
 		}); err != nil {
 			return nil, err
 		}
 	}
 
-	if args.Key.Defined() && !args.Key.CanRead() {
-		return nil, ErrThreadReadKeyRequired
-	}
 	if _, err := network.CreateThread(
 		ctx,
 		id,
@@ -142,9 +157,15 @@ func NewDBFromAddr(
 	for _, opt := range opts {
 		opt(args)
 	}
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+	if args.Key.Defined() {
+		return nil, fmt.Errorf("%w: the thread key must be passed as an argument", ErrConflictingOptions)
+	}
 	if args.Debug {
-		if err := util.SetLogLevels(map[string]logging.LogLevel{
-			"db": logging.LevelDebug,
+		if err := logger.SetLevels(map[string]logger.Level{
+			"db": logger.LevelDebug,
 		}); err != nil {
 			return nil, err
 		}
@@ -159,6 +180,7 @@ func NewDBFromAddr(
 		net.WithThreadKey(key),
 		net.WithLogKey(args.LogKey),
 		net.WithNewThreadToken(args.Token),
+		net.WithHeadHints(args.HeadHints),
 	)
 	if err != nil {
 		return nil, err
@@ -173,13 +195,15 @@ func NewDBFromAddr(
 			return nil, err
 		}
 	} else {
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), pullThreadBackgroundTimeout)
+		if err = d.life.Go(lifecycle.StageSync, "pull thread", func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, pullThreadBackgroundTimeout)
 			defer cancel()
 			if err := network.PullThread(ctx, info.ID, net.WithThreadToken(args.Token)); err != nil {
 				log.Errorf("error pulling thread %s", info.ID)
 			}
-		}()
+		}); err != nil {
+			return nil, err
+		}
 	}
 	return d, nil
 }
@@ -197,7 +221,19 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 		collections:         make(map[string]*Collection),
 		localEventsBus:      app.NewLocalEventsBus(),
 		stateChangedNotifee: &stateChangedNotifee{},
+		hooks:               make(map[string]Hooks),
+		sortMemoryLimit:     opts.SortMemoryLimit,
+		metrics:             newMetrics(),
+		life:                lifecycle.New(context.Background()),
+	}
+	if err := clearSortSpills(s, dsSortPrefix); err != nil {
+		return nil, err
+	}
+	actionLog, err := newActionLog(s)
+	if err != nil {
+		return nil, err
 	}
+	d.actionLog = actionLog
 	if err := d.loadName(); err != nil {
 		return nil, err
 	}
@@ -213,7 +249,15 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 	if err := d.reCreateCollections(); err != nil {
 		return nil, err
 	}
+	leases, err := newLeaseTracker(d)
+	if err != nil {
+		return nil, err
+	}
+	d.leases = leases
 	d.dispatcher.Register(d)
+	if opts.BatchWindow > 0 {
+		d.batcher = newCommitBatcher(d, opts.BatchWindow, opts.BatchMaxActions)
+	}
 
 	connector, err := n.ConnectApp(d, id)
 	if err != nil {
@@ -226,6 +270,22 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 			return nil, err
 		}
 	}
+	if opts.EventStream != nil {
+		if err := opts.EventStream.attach(id, d); err != nil {
+			return nil, err
+		}
+	}
+	d.expiry = newExpirySweeper(d)
+	if err := d.life.Go(lifecycle.StageApp, "lease expiration", func(context.Context) {
+		d.leases.start(d.notifyStateChanged)
+	}); err != nil {
+		return nil, err
+	}
+	if err := d.life.Go(lifecycle.StageApp, "expiry sweeps", func(context.Context) {
+		d.expiry.start()
+	}); err != nil {
+		return nil, err
+	}
 	return d, nil
 }
 
@@ -278,11 +338,21 @@ func (d *DB) reCreateCollections() error {
 		if err != nil && !errors.Is(err, ds.ErrNotFound) {
 			return err
 		}
+		mg, err := d.datastore.Get(dsMigrations.ChildString(name))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		ttl, err := d.loadTTL(name)
+		if err != nil {
+			return err
+		}
 		c, err := newCollection(d, CollectionConfig{
 			Name:           name,
 			Schema:         schema,
 			WriteValidator: string(wv),
 			ReadFilter:     string(rf),
+			TTL:            ttl,
+			Migration:      string(mg),
 		})
 		if err != nil {
 			return err
@@ -309,9 +379,12 @@ type Info struct {
 	Name  string
 	Addrs []ma.Multiaddr
 	Key   thread.Key
+	// Heads are the current log heads of the DB thread, which tell joining peers
+	// how much history to expect, see WithNewHeadHints.
+	Heads net.HeadHints
 }
 
-// GetDBInfo returns the addresses and key that can be used to join the DB thread.
+// GetDBInfo returns the addresses, key and log heads that can be used to join the DB thread.
 func (d *DB) GetDBInfo(opts ...Option) (info Info, err error) {
 	options := &Options{}
 	for _, opt := range opts {
@@ -329,6 +402,7 @@ func (d *DB) GetDBInfo(opts ...Option) (info Info, err error) {
 		Name:  d.name,
 		Addrs: thrd.Addrs,
 		Key:   thrd.Key,
+		Heads: net.HeadHintsFromInfo(thrd),
 	}, nil
 }
 
@@ -357,6 +431,20 @@ type CollectionConfig struct {
 	// Most implementation will modify and return the current instance.
 	// Note: Only the function body should be defined here.
 	ReadFilter string
+	// TTL is how long instances live after they're last written, e.g. for sessions or drafts.
+	// Expired instances are deleted by a background sweep, which emits regular delete events,
+	// so deletes are replicated. Zero disables expiry.
+	TTL time.Duration
+	// An optional JavaScript (ECMAScript 5.1) function that is used to upgrade instances
+	// which are invalid under the schema, e.g. instances written before a breaking schema update.
+	// Instances are upgraded lazily when read, and stay upgraded once saved.
+	// The function receives one argument:
+	//   - instance: The current instance as a JavaScript object.
+	// The function must return the upgraded instance as a JavaScript object.
+	// UpdateCollection rejects schemas which existing instances violate, unless a migration
+	// is defined, see CheckSchemaCompatibility.
+	// Note: Only the function body should be defined here.
+	Migration string
 }
 
 // NewCollection creates a new db collection with config.
@@ -389,6 +477,7 @@ func (d *DB) NewCollection(config CollectionConfig, opts ...Option) (*Collection
 // UpdateCollection updates an existing db collection with a new config.
 // Indexes to new paths will be created.
 // Indexes to removed paths will be dropped.
+// Schemas breaking compatibility with existing instances are rejected, unless a migration is defined.
 func (d *DB) UpdateCollection(config CollectionConfig, opts ...Option) (*Collection, error) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
@@ -403,6 +492,13 @@ func (d *DB) UpdateCollection(config CollectionConfig, opts ...Option) (*Collect
 	if !ok {
 		return nil, ErrCollectionNotFound
 	}
+	if err := xc.checkSchemaUpdate(config.Schema, config.Migration); err != nil {
+		return nil, err
+	}
+	// Instances written before a previous breaking update may still need the previous migration
+	if config.Migration == "" {
+		config.Migration = string(xc.rawMigration)
+	}
 	c, err := newCollection(d, config)
 	if err != nil {
 		return nil, err
@@ -452,6 +548,14 @@ func (d *DB) saveCollection(c *Collection) error {
 			return err
 		}
 	}
+	if len(c.rawMigration) != 0 {
+		if err := d.datastore.Put(dsMigrations.ChildString(c.name), c.rawMigration); err != nil {
+			return err
+		}
+	}
+	if err := d.saveTTL(c); err != nil {
+		return err
+	}
 	d.collections[c.name] = c
 	return nil
 }
@@ -481,11 +585,12 @@ func (d *DB) ListCollections(opts ...Option) []*Collection {
 	if err := d.connector.Validate(args.Token, true); err != nil {
 		return nil
 	}
-	list := make([]*Collection, len(d.collections))
-	var i int
+	list := make([]*Collection, 0, len(d.collections))
 	for _, c := range d.collections {
-		list[i] = c
-		i++
+		if c.name == leaseCollectionName {
+			continue
+		}
+		list = append(list, c)
 	}
 	return list
 }
@@ -502,7 +607,7 @@ func (d *DB) DeleteCollection(name string, opts ...Option) error {
 		return err
 	}
 	c, ok := d.collections[name]
-	if !ok {
+	if !ok || name == leaseCollectionName {
 		return ErrCollectionNotFound
 	}
 	txn, err := d.datastore.NewTransaction(false)
@@ -521,37 +626,107 @@ func (d *DB) DeleteCollection(name string, opts ...Option) error {
 	if err := txn.Delete(dsFilters.ChildString(c.name)); err != nil {
 		return err
 	}
+	if err := txn.Delete(dsMigrations.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsTTLs.ChildString(c.name)); err != nil {
+		return err
+	}
 	if err := txn.Commit(); err != nil {
 		return err
 	}
 	delete(d.collections, c.name)
+	delete(d.hooks, c.name)
 	return nil
 }
 
 func (d *DB) Close() error {
+	// sweeps write to the db, so they're stopped before writes are blocked
+	d.expiry.close()
+	d.closeState()
+	// background goroutines may need the locks, so they're waited for once released
+	return d.life.Close()
+}
+
+func (d *DB) closeState() {
+	d.txnlock.Lock()
+	closed := d.closed
+	d.closed = true
+	d.txnlock.Unlock()
+	if closed {
+		return
+	}
+	// pending batches are applied once written, which takes the locks
+	if d.batcher != nil {
+		d.batcher.close()
+	}
+
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
-
-	if d.closed {
-		return nil
-	}
-	d.closed = true
+	d.leases.close()
 	d.localEventsBus.Discard()
 	d.stateChangedNotifee.close()
-	return nil
 }
 
 func (d *DB) Reduce(events []core.Event) error {
-	codecActions, err := d.eventcodec.Reduce(events, d.datastore, baseKey, defaultIndexFunc(d))
+	start := time.Now()
+	codec, ok := d.eventcodec.(core.TxnEventCodec)
+	if !ok {
+		codecActions, err := d.eventcodec.Reduce(events, d.datastore, baseKey, defaultIndexFunc(d))
+		if err != nil {
+			return err
+		}
+		d.metrics.observeApply(events, start)
+		actions := d.reducedActions(codecActions, d.datastore.Get)
+		d.afterApply(actions, d.remote)
+		d.notifyStateChanged(actions)
+		return nil
+	}
+
+	txn, err := d.datastore.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	codecActions, err := codec.ReduceTxn(events, txn, baseKey, defaultIndexFunc(d))
+	if err != nil {
+		return err
+	}
+	actions := d.reducedActions(codecActions, txn.Get)
+
+	// actions are logged along with the state, so resumable listeners don't miss
+	// actions applied right before a crash
+	d.actionLog.lock.Lock()
+	defer d.actionLog.lock.Unlock()
+	logged, err := d.actionLog.put(txn, actions)
 	if err != nil {
 		return err
 	}
-	actions := make([]Action, len(codecActions))
-	for i, ca := range codecActions {
+	if err = txn.Commit(); err != nil {
+		return err
+	}
+	d.actionLog.advance(logged)
+	d.metrics.observeApply(events, start)
+	d.afterApply(actions, d.remote)
+	d.stateChangedNotifee.notify(logged)
+	d.metrics.observeFanout(actions, start)
+	return nil
+}
+
+// reducedActions returns the actions of reduced codec actions, reading leases with get.
+func (d *DB) reducedActions(codecActions []core.ReduceAction, get func(ds.Key) ([]byte, error)) []Action {
+	actions := make([]Action, 0, len(codecActions))
+	for _, ca := range codecActions {
+		if ca.Collection == leaseCollectionName {
+			if a, ok := d.leases.reduced(get, ca); ok {
+				actions = append(actions, a)
+			}
+			continue
+		}
 		var actionType ActionType
-		switch codecActions[i].Type {
+		switch ca.Type {
 		case core.Create:
 			actionType = ActionCreate
 		case core.Save:
@@ -561,10 +736,17 @@ func (d *DB) Reduce(events []core.Event) error {
 		default:
 			panic("eventcodec action not recognized")
 		}
-		actions[i] = Action{Collection: ca.Collection, Type: actionType, ID: ca.InstanceID}
+		actions = append(actions, Action{Collection: ca.Collection, Type: actionType, ID: ca.InstanceID})
+		for _, path := range ca.Conflicts {
+			actions = append(actions, Action{
+				Collection: ca.Collection,
+				Type:       ActionConflict,
+				ID:         ca.InstanceID,
+				Path:       path,
+			})
+		}
 	}
-	d.notifyStateChanged(actions)
-	return nil
+	return actions
 }
 
 func defaultIndexFunc(d *DB) func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
@@ -652,6 +834,8 @@ func (d *DB) getBlockWithRetry(ctx context.Context, rec net.Record) (format.Node
 func (d *DB) dispatch(events []core.Event) error {
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
+	d.remote = true
+	defer func() { d.remote = false }()
 	return d.dispatcher.Dispatch(events)
 }
 
@@ -663,7 +847,7 @@ func (d *DB) readTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) e
 	for _, opt := range opts {
 		opt(args)
 	}
-	txn := &Txn{collection: c, token: args.Token, readonly: true}
+	txn := &Txn{collection: c, token: args.Token, readonly: true, explain: args.Explain}
 	defer txn.Discard()
 	if err := f(txn); err != nil {
 		return err
@@ -672,17 +856,90 @@ func (d *DB) readTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) e
 }
 
 func (d *DB) writeTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
-	d.txnlock.Lock()
-	defer d.txnlock.Unlock()
+	start := time.Now()
+	defer func() { d.metrics.writeTxns.Observe(time.Since(start)) }()
+	if d.batcher == nil {
+		d.txnlock.Lock()
+		defer d.txnlock.Unlock()
+		if d.closed {
+			return errors.New("db is closed")
+		}
+		return d.runWriteTxn(c, f, opts...)
+	}
 
+	// batched commits wait for the thread write without blocking other transactions
+	batch, err := func() (*commitBatch, error) {
+		d.txnlock.Lock()
+		defer d.txnlock.Unlock()
+		if d.closed {
+			return nil, errors.New("db is closed")
+		}
+		var batch *commitBatch
+		err := d.runWriteTxn(c, func(txn *Txn) (err error) {
+			if err = f(txn); err != nil {
+				return err
+			}
+			batch, err = txn.commitBatched(d.batcher)
+			txn.committed = true
+			return err
+		}, opts...)
+		return batch, err
+	}()
+	if err != nil || batch == nil {
+		return err
+	}
+	return batch.wait()
+}
+
+func (d *DB) runWriteTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
 	args := &TxnOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
-	txn := &Txn{collection: c, token: args.Token}
+	// check before the transaction, so frozen dbs don't apply local changes
+	if err := d.checkWritable(); err != nil {
+		return err
+	}
+	txn := &Txn{collection: c, token: args.Token, explain: args.Explain}
 	defer txn.Discard()
 	if err := f(txn); err != nil {
 		return err
 	}
+	if txn.committed {
+		return nil
+	}
 	return txn.Commit()
 }
+
+// writeActions creates a net record of actions and applies their events with apply, if set.
+// Actions are halved until their record body fits into the net host's maximum record size.
+func (d *DB) writeActions(ctx context.Context, actions []core.Action, token thread.Token, apply func([]core.Event) error) error {
+	events, node, err := d.eventcodec.Create(actions)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 && node == nil {
+		return nil
+	}
+	if len(events) == 0 || node == nil {
+		return fmt.Errorf("created events and node must both be nil or not-nil")
+	}
+
+	maxBodySize := d.connector.Net.MaxRecordSize() - net.RecordOverhead
+	if len(node.RawData()) > maxBodySize && len(actions) > 1 {
+		half := len(actions) / 2
+		if err = d.writeActions(ctx, actions[:half], token, apply); err != nil {
+			return err
+		}
+		return d.writeActions(ctx, actions[half:], token, apply)
+	}
+	if _, err = d.connector.CreateNetRecord(ctx, node, token, net.WithRecordType(net.ContentTypeDBEvent)); err != nil {
+		return err
+	}
+	if apply != nil {
+		if err = apply(events); err != nil {
+			return err
+		}
+	}
+	return d.notifyTxnEvents(node, token)
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"reflect"
@@ -17,6 +18,7 @@ import (
 	"github.com/textileio/go-threads/common"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/util"
 )
@@ -162,6 +164,33 @@ func TestMissingCollection(t *testing.T) {
 	}
 }
 
+func TestNewOptionsValidate(t *testing.T) {
+	t.Parallel()
+	cc := CollectionConfig{
+		Name:   "Dog",
+		Schema: util.SchemaFromInstance(&Dog{}, false),
+	}
+	tests := []struct {
+		name string
+		opts []NewOption
+		err  error
+	}{
+		{name: "valid", opts: []NewOption{WithNewName("my-db"), WithNewCollections(cc)}},
+		{name: "invalid name", opts: []NewOption{WithNewName("my db")}, err: ErrInvalidName},
+		{name: "missing read key", opts: []NewOption{WithNewKey(thread.NewRandomServiceKey())}, err: ErrThreadReadKeyRequired},
+		{name: "duplicate collections", opts: []NewOption{WithNewCollections(cc, cc)}, err: ErrConflictingOptions},
+	}
+	for _, tc := range tests {
+		args := &NewOptions{}
+		for _, opt := range tc.opts {
+			opt(args)
+		}
+		if err := args.Validate(); !errors.Is(err, tc.err) {
+			t.Fatalf("%s: expected error %v, got %v", tc.name, tc.err, err)
+		}
+	}
+}
+
 func TestWithNewName(t *testing.T) {
 	t.Parallel()
 	tmpDir, err := ioutil.TempDir("", "")
@@ -264,6 +293,81 @@ func TestWithNewEventCodec(t *testing.T) {
 	checkErr(t, d.Close())
 }
 
+func TestWithNewBatchWindow(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t, WithNewBatchWindow(time.Millisecond*200, 0))
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+
+	records := func() (n int64) {
+		info, err := d.connector.Net.GetThread(context.Background(), d.connector.ThreadID())
+		checkErr(t, err)
+		for _, l := range info.Logs {
+			n += l.Head.Counter
+		}
+		return n
+	}
+	before := records()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.Create(util.JSONFromInstance(dummy{Name: fmt.Sprintf("Textile%d", i)}))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		checkErr(t, err)
+	}
+
+	all, err := c.Find(&Query{})
+	checkErr(t, err)
+	if len(all) != 10 {
+		t.Fatalf("expected 10 instances, got %d", len(all))
+	}
+	if written := records() - before; written >= 10 {
+		t.Fatalf("expected batched records, got %d records for 10 transactions", written)
+	}
+	checkErr(t, d.Close())
+}
+
+func TestWithNewBatchWindow_FailedWrite(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t, WithNewBatchWindow(time.Second, 0))
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		errs <- err
+	}()
+	// the thread is frozen before the batch is written
+	time.Sleep(time.Millisecond * 200)
+	checkErr(t, d.connector.Net.FreezeThread(context.Background(), d.connector.ThreadID()))
+	if err := <-errs; !errors.Is(err, net.ErrThreadFrozen) {
+		t.Fatalf("expected the batch write to fail, got %v", err)
+	}
+	all, err := c.Find(&Query{})
+	checkErr(t, err)
+	if len(all) != 0 {
+		t.Fatalf("expected no instances of the failed batch, got %d", len(all))
+	}
+}
+
 func TestListeners(t *testing.T) {
 	t.Parallel()
 
@@ -509,3 +613,39 @@ func (dec *mockEventCodec) EventsFromBytes([]byte) ([]core.Event, error) {
 	dec.called = true
 	return nil, nil
 }
+
+func TestFrozenDB(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Dog",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(dummy{Name: "Fido"}))
+	checkErr(t, err)
+
+	ctx := context.Background()
+	checkErr(t, d.connector.Net.FreezeThread(ctx, d.connector.ThreadID()))
+	frozen, err := d.IsFrozen()
+	checkErr(t, err)
+	if !frozen {
+		t.Fatal("expected db to be frozen")
+	}
+	if _, err = c.Create(util.JSONFromInstance(dummy{Name: "Rex"})); !errors.Is(err, net.ErrThreadFrozen) {
+		t.Fatalf("expected write to be rejected, got: %v", err)
+	}
+	if err = c.Delete(id); !errors.Is(err, net.ErrThreadFrozen) {
+		t.Fatalf("expected delete to be rejected, got: %v", err)
+	}
+	// data is still readable
+	if _, err = c.FindByID(id); err != nil {
+		t.Fatalf("expected instance to be readable: %v", err)
+	}
+
+	checkErr(t, d.connector.Net.UnfreezeThread(ctx, d.connector.ThreadID()))
+	if _, err = c.Create(util.JSONFromInstance(dummy{Name: "Rex"})); err != nil {
+		t.Fatalf("expected write to be accepted once unfrozen: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	gonet "net"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/lifecycle"
+)
+
+// EventStreamBuffer is the number of events buffered for each stream consumer.
+// Consumers falling behind by more events are disconnected, so they don't block writes.
+var EventStreamBuffer = 1024
+
+// StreamEvent is a db change event sent to stream consumers.
+type StreamEvent struct {
+	Time       time.Time `json:"time"`
+	DB         string    `json:"db"`
+	Collection string    `json:"collection"`
+	Instance   string    `json:"instance"`
+	Action     string    `json:"action"`
+}
+
+var actionNames = map[ActionType]string{
+	ActionCreate: "create",
+	ActionSave:   "save",
+	ActionDelete: "delete",
+}
+
+// EventStream serves change events of dbs to processes outside of the API, e.g. search
+// indexers or exporters running as sidecars. Consumers connect to the stream listener,
+// usually a UNIX socket, and read events encoded as JSON, one event per line. Events are
+// sent from the time a consumer connects, earlier events aren't replayed.
+type EventStream struct {
+	lis       gonet.Listener
+	lock      sync.Mutex
+	consumers map[*streamConsumer]struct{}
+	closed    bool
+}
+
+type streamConsumer struct {
+	conn gonet.Conn
+	c    chan StreamEvent
+}
+
+// NewEventStream returns a stream of db events served with lis once Serve is called.
+func NewEventStream(lis gonet.Listener) *EventStream {
+	return &EventStream{
+		lis:       lis,
+		consumers: make(map[*streamConsumer]struct{}),
+	}
+}
+
+// Serve accepts stream consumers until the stream is closed.
+func (s *EventStream) Serve() error {
+	for {
+		conn, err := s.lis.Accept()
+		if err != nil {
+			s.lock.Lock()
+			closed := s.closed
+			s.lock.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		c := &streamConsumer{conn: conn, c: make(chan StreamEvent, EventStreamBuffer)}
+		s.lock.Lock()
+		if s.closed {
+			s.lock.Unlock()
+			_ = conn.Close()
+			return nil
+		}
+		s.consumers[c] = struct{}{}
+		s.lock.Unlock()
+		go s.serveConsumer(c)
+	}
+}
+
+// Close stops accepting consumers and disconnects the connected ones.
+func (s *EventStream) Close() error {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return nil
+	}
+	s.closed = true
+	for c := range s.consumers {
+		s.removeLocked(c)
+	}
+	s.lock.Unlock()
+	return s.lis.Close()
+}
+
+// attach forwards change events of the db to stream consumers until the db is closed.
+func (s *EventStream) attach(id thread.ID, d *DB) error {
+	// listen with a buffer as deep as consumer's, so bursts of actions aren't dropped
+	d.txnlock.Lock()
+	if d.closed {
+		d.txnlock.Unlock()
+		return fmt.Errorf("can't stream events of closed DB")
+	}
+	l := &listener{
+		scn: d.stateChangedNotifee,
+		c:   make(chan Action, EventStreamBuffer),
+	}
+	d.stateChangedNotifee.addListener(l)
+	d.txnlock.Unlock()
+
+	return d.life.Go(lifecycle.StageApp, "event stream", func(context.Context) {
+		for a := range l.c {
+			s.publish(StreamEvent{
+				Time:       time.Now(),
+				DB:         id.String(),
+				Collection: a.Collection,
+				Instance:   a.ID.String(),
+				Action:     actionNames[a.Type],
+			})
+		}
+	})
+}
+
+func (s *EventStream) publish(e StreamEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for c := range s.consumers {
+		select {
+		case c.c <- e:
+		default:
+			log.Warnf("disconnecting event stream consumer %s falling behind", c.conn.RemoteAddr())
+			s.removeLocked(c)
+		}
+	}
+}
+
+func (s *EventStream) serveConsumer(c *streamConsumer) {
+	// consumers don't send anything, so a read returns once they disconnect
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, c.conn)
+		s.lock.Lock()
+		s.removeLocked(c)
+		s.lock.Unlock()
+	}()
+
+	enc := json.NewEncoder(c.conn)
+	for e := range c.c {
+		if err := enc.Encode(e); err != nil {
+			log.Debugf("writing to event stream consumer failed: %v", err)
+			s.lock.Lock()
+			s.removeLocked(c)
+			s.lock.Unlock()
+			return
+		}
+	}
+}
+
+func (s *EventStream) removeLocked(c *streamConsumer) {
+	if _, ok := s.consumers[c]; !ok {
+		return
+	}
+	delete(s.consumers, c)
+	close(c.c)
+	_ = c.conn.Close()
+}
+
+// EventStreamReader reads events from a stream connection.
+type EventStreamReader struct {
+	s *bufio.Scanner
+}
+
+// NewEventStreamReader returns a reader of events sent over r, e.g. a connection
+// to a stream UNIX socket.
+func NewEventStreamReader(r io.Reader) *EventStreamReader {
+	return &EventStreamReader{s: bufio.NewScanner(r)}
+}
+
+// Next blocks until the next event is read. It returns io.EOF once the stream ends.
+func (r *EventStreamReader) Next() (StreamEvent, error) {
+	var e StreamEvent
+	if !r.s.Scan() {
+		if err := r.s.Err(); err != nil {
+			return e, err
+		}
+		return e, io.EOF
+	}
+	if err := json.Unmarshal(r.s.Bytes(), &e); err != nil {
+		return e, fmt.Errorf("malformed event: %w", err)
+	}
+	return e, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	gonet "net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/textileio/go-threads/util"
+)
+
+func TestEventStream(t *testing.T) {
+	t.Parallel()
+	socket := filepath.Join(t.TempDir(), "events.sock")
+	lis, err := gonet.Listen("unix", socket)
+	checkErr(t, err)
+	s := NewEventStream(lis)
+	defer s.Close()
+	go func() {
+		if err := s.Serve(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	d, clean := createTestDB(t, WithNewEventStream(s))
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Docs",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+
+	conn, err := gonet.Dial("unix", socket)
+	checkErr(t, err)
+	defer conn.Close()
+	waitConsumers(t, s, 1)
+
+	id, err := c.Create(util.JSONFromInstance(dummy{Name: "doc"}))
+	checkErr(t, err)
+	checkErr(t, c.Delete(id))
+
+	r := NewEventStreamReader(conn)
+	for _, action := range []string{"create", "delete"} {
+		e, err := r.Next()
+		checkErr(t, err)
+		if e.Collection != "Docs" || e.Instance != id.String() || e.Action != action {
+			t.Fatalf("expected %s event of %s, got %+v", action, id, e)
+		}
+	}
+
+	// consumers are disconnected once the stream is closed
+	checkErr(t, s.Close())
+	if _, err := r.Next(); err == nil {
+		t.Fatal("expected stream to end")
+	}
+}
+
+func waitConsumers(t *testing.T, s *EventStream, n int) {
+	for i := 0; i < 100; i++ {
+		s.lock.Lock()
+		connected := len(s.consumers)
+		s.lock.Unlock()
+		if connected == n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d stream consumers", n)
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+)
+
+var (
+	// ErrInvalidTTL indicates a collection TTL is negative.
+	ErrInvalidTTL = errors.New("collection ttl must not be negative")
+
+	// ExpiryCheckInterval is the interval between sweeps of expired instances.
+	ExpiryCheckInterval = time.Second * 10
+
+	dsTTLs = dsPrefix.ChildString("ttl")
+)
+
+// GetTTL returns how long instances of the collection live after they're last
+// written, zero if they don't expire.
+func (c *Collection) GetTTL() time.Duration {
+	return c.ttl
+}
+
+func (d *DB) saveTTL(c *Collection) error {
+	key := dsTTLs.ChildString(c.name)
+	if c.ttl == 0 {
+		return d.datastore.Delete(key)
+	}
+	return d.datastore.Put(key, []byte(strconv.FormatInt(int64(c.ttl), 10)))
+}
+
+func (d *DB) loadTTL(name string) (time.Duration, error) {
+	v, err := d.datastore.Get(dsTTLs.ChildString(name))
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	ttl, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ttl), nil
+}
+
+// expiredInstances returns IDs of instances written more than the collection TTL ago.
+// Expiry is based on the replicated modification time of instances, so all peers
+// agree on it. Instances without a modification time don't expire.
+func (c *Collection) expiredInstances(now time.Time) ([]core.InstanceID, error) {
+	results, err := c.db.datastore.Query(query.Query{
+		Prefix: c.baseKey().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	deadline := now.Add(-c.ttl).UnixNano()
+	var ids []core.InstanceID
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		var instance struct {
+			ID  core.InstanceID `json:"_id"`
+			Mod int64           `json:"_mod"`
+		}
+		if err := json.Unmarshal(res.Value, &instance); err != nil {
+			return nil, err
+		}
+		if instance.Mod != 0 && instance.Mod < deadline {
+			ids = append(ids, instance.ID)
+		}
+	}
+	return ids, nil
+}
+
+// expirySweeper deletes expired instances of collections with a TTL. Instances are
+// deleted with regular write transactions, so deletes are replicated to other peers.
+// Peers sweeping the same instance concurrently is harmless, deletes of missing
+// instances are ignored.
+type expirySweeper struct {
+	d *DB
+
+	once sync.Once
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newExpirySweeper(d *DB) *expirySweeper {
+	return &expirySweeper{
+		d:    d,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// start sweeps expired instances until the sweeper is closed.
+func (s *expirySweeper) start() {
+	defer close(s.done)
+	tick := time.NewTicker(ExpiryCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-tick.C:
+			s.sweep()
+		}
+	}
+}
+
+func (s *expirySweeper) sweep() {
+	s.d.lock.RLock()
+	var collections []*Collection
+	for _, c := range s.d.collections {
+		if c.ttl > 0 {
+			collections = append(collections, c)
+		}
+	}
+	s.d.lock.RUnlock()
+
+	now := time.Now()
+	for _, c := range collections {
+		ids, err := c.expiredInstances(now)
+		if err != nil {
+			log.Errorf("finding expired instances of %s: %v", c.name, err)
+			continue
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if err := c.WriteTxn(func(txn *Txn) error {
+			for _, id := range ids {
+				if err := txn.Delete(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			log.Errorf("deleting expired instances of %s: %v", c.name, err)
+			continue
+		}
+		log.Debugf("deleted %d expired instances of %s", len(ids), c.name)
+	}
+}
+
+// close stops the sweeper, waiting for a running sweep to finish.
+func (s *expirySweeper) close() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
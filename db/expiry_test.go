@@ -0,0 +1,59 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/textileio/go-threads/util"
+)
+
+func TestCollectionTTL(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+
+	if _, err := d.NewCollection(CollectionConfig{
+		Name:   "Invalid",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+		TTL:    -time.Second,
+	}); !errors.Is(err, ErrInvalidTTL) {
+		t.Fatalf("expected invalid ttl error, got: %v", err)
+	}
+
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Sessions",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+		TTL:    time.Second,
+	})
+	checkErr(t, err)
+	if c.GetTTL() != time.Second {
+		t.Fatalf("expected ttl of 1s, got %v", c.GetTTL())
+	}
+	expiring, err := c.Create(util.JSONFromInstance(dummy{Name: "expiring"}))
+	checkErr(t, err)
+
+	l, err := d.Listen(ListenOption{Type: ListenDelete, Collection: "Sessions"})
+	checkErr(t, err)
+	defer l.Close()
+
+	time.Sleep(time.Second)
+	live, err := c.Create(util.JSONFromInstance(dummy{Name: "live"}))
+	checkErr(t, err)
+	d.expiry.sweep()
+
+	select {
+	case a := <-l.Channel():
+		if a.Type != ActionDelete || a.ID != expiring {
+			t.Fatalf("unexpected action: %v", a)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("expired instance wasn't deleted")
+	}
+	if ok, err := c.Has(expiring); err != nil || ok {
+		t.Fatalf("expected expired instance to be deleted, got: %v, %v", ok, err)
+	}
+	if ok, err := c.Has(live); err != nil || !ok {
+		t.Fatalf("expected live instance to remain, got: %v, %v", ok, err)
+	}
+}
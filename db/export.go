@@ -0,0 +1,388 @@
+package db
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// Format is an encoding of collection instances used by Export and Import.
+type Format int
+
+const (
+	// JSONL encodes each instance as a JSON object on its own line (JSON Lines).
+	JSONL Format = iota
+	// CSV encodes each instance as a row of comma-separated values. The first row
+	// holds column names. Columns are the top-level properties of the collection
+	// schema, objects and arrays are encoded as JSON.
+	CSV
+)
+
+// ErrUnknownFormat indicates an unsupported import or export format.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// DefaultImportBatchSize is the default number of instances written with a single
+// transaction while importing.
+var DefaultImportBatchSize = 500
+
+// Export writes all instances of the collection to w, encoded with format.
+// Instances are read with the read filter of the collection, if any.
+func (c *Collection) Export(w io.Writer, format Format, opts ...TxnOption) error {
+	return c.ReadTxn(func(txn *Txn) error {
+		if err := c.db.connector.Validate(txn.token, true); err != nil {
+			return err
+		}
+		pk, err := txn.token.PubKey()
+		if err != nil {
+			return err
+		}
+		enc, err := c.newEncoder(w, format)
+		if err != nil {
+			return err
+		}
+		results, err := c.db.datastore.Query(query.Query{
+			Prefix: c.baseKey().String(),
+			Orders: []query.Order{query.OrderByKey{}},
+		})
+		if err != nil {
+			return err
+		}
+		defer results.Close()
+		for res := range results.Next() {
+			if res.Error != nil {
+				return res.Error
+			}
+			instance, err := c.filterRead(pk, res.Value)
+			if err != nil {
+				return err
+			}
+			if instance == nil {
+				continue
+			}
+			if err := enc.encode(instance); err != nil {
+				return err
+			}
+		}
+		return enc.flush()
+	}, opts...)
+}
+
+// Import reads instances encoded with format from r and writes them to the collection
+// in batches, each batch with a single transaction. Instances with IDs of existing
+// instances replace them, other instances are created. It returns the number of
+// instances written, which were committed even if an error is returned.
+func (c *Collection) Import(r io.Reader, format Format, opts ...ImportOption) (int, error) {
+	args := &ImportOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if args.BatchSize <= 0 {
+		args.BatchSize = DefaultImportBatchSize
+	}
+	dec, err := c.newDecoder(r, format, args.Fields)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	batch := make([][]byte, 0, args.BatchSize)
+	for {
+		instance, err := dec.decode()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return n, fmt.Errorf("decoding instance %d: %w", n+len(batch)+1, err)
+		}
+		if batch = append(batch, instance); len(batch) == args.BatchSize {
+			if err := c.importBatch(batch, args.Token); err != nil {
+				return n, err
+			}
+			n += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := c.importBatch(batch, args.Token); err != nil {
+			return n, err
+		}
+		n += len(batch)
+	}
+	return n, nil
+}
+
+func (c *Collection) importBatch(batch [][]byte, token thread.Token) error {
+	return c.WriteTxn(func(txn *Txn) error {
+		for _, instance := range batch {
+			id, err := getInstanceID(instance)
+			if err != nil && !errors.Is(err, errMissingInstanceID) {
+				return err
+			}
+			if id != core.EmptyInstanceID {
+				exists, err := c.db.datastore.Has(c.baseKey().ChildString(id.String()))
+				if err != nil {
+					return err
+				}
+				if exists {
+					if err := txn.Save(instance); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			if _, err := txn.Create(instance); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, WithTxnToken(token))
+}
+
+func (c *Collection) schema() (*jsonschema.Schema, error) {
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal(c.GetSchema(), schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+type encoder interface {
+	encode(instance []byte) error
+	flush() error
+}
+
+func (c *Collection) newEncoder(w io.Writer, format Format) (encoder, error) {
+	switch format {
+	case JSONL:
+		return &jsonlEncoder{w: w}, nil
+	case CSV:
+		schema, err := c.schema()
+		if err != nil {
+			return nil, err
+		}
+		return &csvEncoder{w: csv.NewWriter(w), columns: csvColumns(schema)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type jsonlEncoder struct {
+	w io.Writer
+}
+
+func (e *jsonlEncoder) encode(instance []byte) error {
+	var v map[string]interface{}
+	if err := json.Unmarshal(instance, &v); err != nil {
+		return err
+	}
+	// the modification time is set by the db on writes, so it's left out
+	delete(v, modFieldName)
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+func (e *jsonlEncoder) flush() error {
+	return nil
+}
+
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []string
+	header  bool
+}
+
+func (e *csvEncoder) encode(instance []byte) error {
+	if !e.header {
+		if err := e.w.Write(e.columns); err != nil {
+			return err
+		}
+		e.header = true
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(instance, &v); err != nil {
+		return err
+	}
+	row := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		cell, err := formatCell(v[col])
+		if err != nil {
+			return err
+		}
+		row[i] = cell
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) flush() error {
+	if !e.header {
+		if err := e.w.Write(e.columns); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvColumns returns the top-level schema properties, the ID first.
+func csvColumns(schema *jsonschema.Schema) []string {
+	props, _ := getSchemaTypeProperties(schema.Type, schema.Definitions)
+	columns := []string{idFieldName}
+	for name := range props {
+		if name != idFieldName {
+			columns = append(columns, name)
+		}
+	}
+	sort.Strings(columns[1:])
+	return columns
+}
+
+func formatCell(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
+
+type decoder interface {
+	// decode returns the next instance, or io.EOF once all instances are read.
+	decode() ([]byte, error)
+}
+
+func (c *Collection) newDecoder(r io.Reader, format Format, fields map[string]string) (decoder, error) {
+	switch format {
+	case JSONL:
+		return &jsonlDecoder{dec: json.NewDecoder(r), fields: fields}, nil
+	case CSV:
+		schema, err := c.schema()
+		if err != nil {
+			return nil, err
+		}
+		return &csvDecoder{r: csv.NewReader(r), schema: schema, fields: fields}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type jsonlDecoder struct {
+	dec    *json.Decoder
+	fields map[string]string
+}
+
+func (d *jsonlDecoder) decode() ([]byte, error) {
+	var v map[string]interface{}
+	if err := d.dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	delete(v, modFieldName)
+	if len(d.fields) == 0 {
+		return json.Marshal(v)
+	}
+	mapped := make(map[string]interface{}, len(v))
+	for name, value := range v {
+		setFieldPath(mapped, mapField(d.fields, name), value)
+	}
+	return json.Marshal(mapped)
+}
+
+type csvDecoder struct {
+	r      *csv.Reader
+	schema *jsonschema.Schema
+	fields map[string]string
+	header []string
+}
+
+func (d *csvDecoder) decode() ([]byte, error) {
+	if d.header == nil {
+		header, err := d.r.Read()
+		if err != nil {
+			return nil, err
+		}
+		d.header = make([]string, len(header))
+		for i, name := range header {
+			d.header[i] = mapField(d.fields, name)
+		}
+	}
+	row, err := d.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]interface{}, len(row))
+	for i, cell := range row {
+		if cell == "" || d.header[i] == modFieldName {
+			continue
+		}
+		value, err := parseCell(d.schema, d.header[i], cell)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", d.header[i], err)
+		}
+		setFieldPath(v, d.header[i], value)
+	}
+	return json.Marshal(v)
+}
+
+// parseCell converts a cell to the schema type of the field at path.
+// Cells of fields missing from the schema are kept as strings.
+func parseCell(schema *jsonschema.Schema, path, cell string) (interface{}, error) {
+	jt, err := getSchemaTypeAtPath(schema, path)
+	if err != nil {
+		return cell, nil
+	}
+	switch jt.Type {
+	case "integer", "number":
+		return strconv.ParseFloat(cell, 64)
+	case "boolean":
+		return strconv.ParseBool(cell)
+	case "string":
+		return cell, nil
+	default:
+		var v interface{}
+		if err := json.Unmarshal([]byte(cell), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// mapField returns the instance field path a source field is mapped to.
+func mapField(fields map[string]string, name string) string {
+	if path, ok := fields[name]; ok {
+		return path
+	}
+	return name
+}
+
+// setFieldPath sets the value of a field at a dot separated path, creating parent objects.
+func setFieldPath(v map[string]interface{}, path string, value interface{}) {
+	parts := strings.Split(path, ".")
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := v[p].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			v[p] = child
+		}
+		v = child
+	}
+	v[parts[len(parts)-1]] = value
+}
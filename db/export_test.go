@@ -0,0 +1,106 @@
+package db
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/textileio/go-threads/util"
+)
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	newCollection := func(name string) *Collection {
+		c, err := d.NewCollection(CollectionConfig{
+			Name:   name,
+			Schema: util.SchemaFromInstance(&Book{}, false),
+		})
+		checkErr(t, err)
+		return c
+	}
+	c := newCollection("Books")
+	for _, b := range data[:3] {
+		_, err := c.Create(util.JSONFromInstance(b))
+		checkErr(t, err)
+	}
+	expected, err := c.Find(nil)
+	checkErr(t, err)
+
+	t.Run("JSONL", func(t *testing.T) {
+		var buf bytes.Buffer
+		checkErr(t, c.Export(&buf, JSONL))
+		if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+			t.Fatalf("expected 3 lines, got %d", lines)
+		}
+		imported := newCollection("BooksJSONL")
+		n, err := imported.Import(&buf, JSONL, WithImportBatchSize(2))
+		checkErr(t, err)
+		if n != 3 {
+			t.Fatalf("expected 3 imported instances, got %d", n)
+		}
+		assertSameBooks(t, expected, imported)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		checkErr(t, c.Export(&buf, CSV))
+		header := strings.SplitN(buf.String(), "\n", 2)[0]
+		if header != "_id,Author,Banned,Meta,Title" {
+			t.Fatalf("unexpected header: %s", header)
+		}
+		imported := newCollection("BooksCSV")
+		n, err := imported.Import(&buf, CSV)
+		checkErr(t, err)
+		if n != 3 {
+			t.Fatalf("expected 3 imported instances, got %d", n)
+		}
+		assertSameBooks(t, expected, imported)
+
+		// re-importing replaces existing instances
+		checkErr(t, c.Export(&buf, CSV))
+		_, err = imported.Import(&buf, CSV)
+		checkErr(t, err)
+		assertSameBooks(t, expected, imported)
+	})
+
+	t.Run("FieldMapping", func(t *testing.T) {
+		imported := newCollection("BooksMapped")
+		in := "title,author,reads,rating,banned\nTitle9,Author9,42,4.5,true\n"
+		_, err := imported.Import(strings.NewReader(in), CSV, WithImportFields(map[string]string{
+			"title":  "Title",
+			"author": "Author",
+			"reads":  "Meta.TotalReads",
+			"rating": "Meta.Rating",
+			"banned": "Banned",
+		}))
+		checkErr(t, err)
+		res, err := imported.Find(nil)
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance, got %d", len(res))
+		}
+		var b Book
+		util.InstanceFromJSON(res[0], &b)
+		if b.Title != "Title9" || b.Author != "Author9" || b.Meta.TotalReads != 42 || b.Meta.Rating != 4.5 || !b.Banned {
+			t.Fatalf("unexpected imported instance: %+v", b)
+		}
+	})
+}
+
+func assertSameBooks(t *testing.T, expected [][]byte, c *Collection) {
+	res, err := c.Find(nil)
+	checkErr(t, err)
+	if len(res) != len(expected) {
+		t.Fatalf("expected %d instances, got %d", len(expected), len(res))
+	}
+	for i := range expected {
+		var e, b Book
+		util.InstanceFromJSON(expected[i], &e)
+		util.InstanceFromJSON(res[i], &b)
+		if e != b {
+			t.Fatalf("expected %+v, got %+v", e, b)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package db
+
+import (
+	"context"
+
+	"github.com/textileio/go-threads/core/net"
+)
+
+// IsFrozen returns whether the db thread is frozen. Collections of frozen dbs are read-only,
+// write transactions fail with net.ErrThreadFrozen until the thread is unfrozen.
+func (d *DB) IsFrozen() (bool, error) {
+	state, err := d.connector.Net.GetFreezeState(context.Background(), d.connector.ThreadID())
+	if err != nil {
+		return false, err
+	}
+	return state.Frozen, nil
+}
+
+// checkWritable returns net.ErrThreadFrozen if the db thread is frozen.
+func (d *DB) checkWritable() error {
+	if frozen, err := d.IsFrozen(); err != nil {
+		return err
+	} else if frozen {
+		return net.ErrThreadFrozen
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	core "github.com/textileio/go-threads/core/db"
+)
+
+// ErrHookChangedID indicates a write hook changed the ID of an instance.
+var ErrHookChangedID = errors.New("write hook changed instance ID")
+
+// Hooks are functions run on writes to the instances of a collection, so validation and
+// derived fields live next to the db instead of in every client. Hooks aren't persisted,
+// they have to be registered again once the db is started.
+type Hooks struct {
+	// BeforeCreate runs for instances created locally, before they are validated against the
+	// schema. It returns the instance to create, possibly modified, or an error rejecting it.
+	BeforeCreate func(txn *Txn, instance []byte) ([]byte, error)
+	// BeforeSave runs for instances saved locally, before they are validated against the
+	// schema. Previous is the stored instance, nil if there's none. It returns the instance
+	// to save, possibly modified, or an error rejecting it.
+	BeforeSave func(txn *Txn, previous, instance []byte) ([]byte, error)
+	// AfterApply runs once an action is applied to the collection, remote is set for actions
+	// received from other peers. The txn is read-only, and since the hook runs while writes
+	// are blocked, it must not write to the db itself.
+	AfterApply func(txn *Txn, action Action, remote bool)
+}
+
+// RegisterHooks sets the write hooks of a collection, replacing hooks registered before.
+func (d *DB) RegisterHooks(collection string, hooks Hooks, opts ...Option) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return err
+	}
+	if _, ok := d.collections[collection]; !ok {
+		return ErrCollectionNotFound
+	}
+	d.hooks[collection] = hooks
+	return nil
+}
+
+// getHooks returns the write hooks of a collection.
+func (d *DB) getHooks(collection string) Hooks {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.hooks[collection]
+}
+
+// beforeCreate runs the create hook of the txn collection, if any.
+func (t *Txn) beforeCreate(id core.InstanceID, instance []byte) ([]byte, error) {
+	h := t.collection.db.getHooks(t.collection.name)
+	if h.BeforeCreate == nil {
+		return instance, nil
+	}
+	updated, err := h.BeforeCreate(t, instance)
+	if err != nil {
+		return nil, err
+	}
+	return updated, checkHookID(id, updated)
+}
+
+// beforeSave runs the save hook of the txn collection, if any.
+func (t *Txn) beforeSave(instance []byte) ([]byte, error) {
+	h := t.collection.db.getHooks(t.collection.name)
+	if h.BeforeSave == nil {
+		return instance, nil
+	}
+	id, err := getInstanceID(instance)
+	if err != nil {
+		return nil, err
+	}
+	key := baseKey.ChildString(t.collection.name).ChildString(id.String())
+	previous, err := t.collection.db.datastore.Get(key)
+	if errors.Is(err, ds.ErrNotFound) {
+		previous = nil
+	} else if err != nil {
+		return nil, err
+	}
+	updated, err := h.BeforeSave(t, previous, instance)
+	if err != nil {
+		return nil, err
+	}
+	return updated, checkHookID(id, updated)
+}
+
+func checkHookID(id core.InstanceID, instance []byte) error {
+	updated, err := getInstanceID(instance)
+	if err != nil {
+		return err
+	}
+	if updated != id {
+		return fmt.Errorf("%w: %s to %s", ErrHookChangedID, id, updated)
+	}
+	return nil
+}
+
+// afterApply runs the apply hooks of the collections of applied actions.
+func (d *DB) afterApply(actions []Action, remote bool) {
+	for _, a := range actions {
+		h := d.getHooks(a.Collection)
+		if h.AfterApply == nil {
+			continue
+		}
+		d.lock.RLock()
+		c, ok := d.collections[a.Collection]
+		d.lock.RUnlock()
+		if !ok {
+			continue
+		}
+		h.AfterApply(&Txn{collection: c, readonly: true}, a, remote)
+	}
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Dummies",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+
+	errEmptyName := errors.New("empty name")
+	var applied []bool
+	checkErr(t, d.RegisterHooks("Dummies", Hooks{
+		BeforeCreate: func(_ *Txn, instance []byte) ([]byte, error) {
+			var v dummy
+			util.InstanceFromJSON(instance, &v)
+			v.Counter = len(v.Name)
+			return util.JSONFromInstance(v), nil
+		},
+		BeforeSave: func(txn *Txn, previous, instance []byte) ([]byte, error) {
+			var v dummy
+			util.InstanceFromJSON(instance, &v)
+			if v.Name == "" {
+				return nil, errEmptyName
+			}
+			if ok, err := txn.Has(v.ID); err != nil || !ok || previous == nil {
+				t.Fatal("expected saved instance to exist")
+			}
+			return instance, nil
+		},
+		AfterApply: func(_ *Txn, a Action, remote bool) {
+			applied = append(applied, remote)
+		},
+	}))
+	if err := d.RegisterHooks("Missing", Hooks{}); !errors.Is(err, ErrCollectionNotFound) {
+		t.Fatalf("expected collection not found, got %v", err)
+	}
+
+	id, err := c.Create(util.JSONFromInstance(dummy{Name: "dummy"}))
+	checkErr(t, err)
+	var v dummy
+	res, err := c.FindByID(id)
+	checkErr(t, err)
+	util.InstanceFromJSON(res, &v)
+	if v.Counter != 5 {
+		t.Fatalf("expected derived counter of 5, got %d", v.Counter)
+	}
+
+	v.Name = ""
+	if err := c.Save(util.JSONFromInstance(v)); !errors.Is(err, errEmptyName) {
+		t.Fatalf("expected save to be rejected, got %v", err)
+	}
+
+	// events of remote records are applied with the remote flag
+	events, _, err := d.eventcodec.Create([]core.Action{{
+		Type:           core.Delete,
+		InstanceID:     id,
+		CollectionName: "Dummies",
+	}})
+	checkErr(t, err)
+	checkErr(t, d.dispatch(events))
+	if len(applied) != 2 || applied[0] || !applied[1] {
+		t.Fatalf("expected a local and a remote action, got %v", applied)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 
 	"github.com/alecthomas/jsonschema"
 	ds "github.com/ipfs/go-datastore"
@@ -87,6 +88,13 @@ func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Op
 	if err != nil {
 		return err
 	}
+	// Arrays are indexed by their items, see Contains and ContainsAny
+	if jt.Type == "array" {
+		jt = resolveSchemaRef(jt.Items, schema.Definitions)
+		if jt == nil {
+			return ErrNotIndexable
+		}
+	}
 	var valid bool
 	for _, t := range indexTypes {
 		if jt.Type == t {
@@ -115,10 +123,16 @@ func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Op
 			if !res.Exists() {
 				continue
 			}
-			if _, ok := vals[res.Value()]; ok {
-				return ErrCantCreateUniqueIndex
-			} else {
-				vals[res.Value()] = struct{}{}
+			items := []gjson.Result{res}
+			if res.IsArray() {
+				items = uniqueItems(res)
+			}
+			for _, item := range items {
+				if _, ok := vals[item.Value()]; ok {
+					return ErrCantCreateUniqueIndex
+				} else {
+					vals[item.Value()] = struct{}{}
+				}
 			}
 		}
 	}
@@ -171,14 +185,23 @@ func (c *Collection) indexDelete(tx ds.Txn, key ds.Key, originalData []byte) err
 
 // indexUpdate adds or removes a specific index on an item.
 func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Key, input []byte, delete bool) error {
-	valueKey, err := getIndexValue(field, input)
+	valueKeys, err := getIndexValues(field, input)
 	if err != nil {
 		if errors.Is(err, ErrNotIndexable) {
 			return nil
 		}
 		return err
 	}
+	for _, valueKey := range valueKeys {
+		if err := c.indexUpdateValue(field, index, tx, key, valueKey, delete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// indexUpdateValue adds or removes an item from the index entry of a value.
+func (c *Collection) indexUpdateValue(field string, index Index, tx ds.Txn, key, valueKey ds.Key, delete bool) error {
 	indexKey := indexPrefix.Child(c.baseKey()).ChildString(field).ChildString(valueKey.String()[1:])
 	data, err := tx.Get(indexKey)
 	if err != nil && err != ds.ErrNotFound {
@@ -212,13 +235,36 @@ func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Ke
 	return tx.Put(indexKey, val)
 }
 
-// getIndexValue returns the result of a field search on input.
-func getIndexValue(field string, input []byte) (ds.Key, error) {
+// getIndexValues returns the results of a field search on input.
+// Arrays result in their unique items.
+func getIndexValues(field string, input []byte) ([]ds.Key, error) {
 	result := gjson.GetBytes(input, field)
 	if !result.Exists() {
-		return ds.Key{}, ErrNotIndexable
+		return nil, ErrNotIndexable
+	}
+	if !result.IsArray() {
+		return []ds.Key{ds.NewKey(result.String())}, nil
+	}
+	items := uniqueItems(result)
+	keys := make([]ds.Key, len(items))
+	for i, item := range items {
+		keys[i] = ds.NewKey(item.String())
 	}
-	return ds.NewKey(result.String()), nil
+	return keys, nil
+}
+
+// uniqueItems returns the items of an array result, without duplicates.
+func uniqueItems(result gjson.Result) []gjson.Result {
+	var items []gjson.Result
+	seen := make(map[string]struct{})
+	for _, item := range result.Array() {
+		if _, ok := seen[item.String()]; ok {
+			continue
+		}
+		seen[item.String()] = struct{}{}
+		items = append(items, item)
+	}
+	return items
 }
 
 // keyList is a slice of unique, sorted keys([]byte) such as what an index points to
@@ -273,6 +319,11 @@ type iterator struct {
 	err      error
 	keyCache []ds.Key
 	iter     query.Results
+
+	// execution statistics, see Explain
+	scanned int
+	fetched int
+	matched int
 }
 
 func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) *iterator {
@@ -286,6 +337,12 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) *iterator {
 	} else {
 		prefix = indexPrefix.Child(baseKey).ChildString(q.Index)
 	}
+	// Instances with several index entries, e.g. array items, are read once
+	seen := make(map[string]struct{})
+	if values, ok := q.indexLookups(); ok {
+		i.nextKeys = i.lookupKeys(prefix, values, seen)
+		return i
+	}
 
 	dsq := dse.QueryExt{
 		Query: query.Query{
@@ -303,8 +360,23 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) *iterator {
 			dsq.Orders = []query.Order{query.OrderByKey{}}
 		}
 	}
-	if q.Seek != "" {
-		dsq.SeekPrefix = prefix.Child(ds.NewKey(string(q.Seek))).String()
+	seek := string(q.Seek)
+	if q.Index == "" {
+		// Instance keys are ordered by ID, so ID bounds are seeked
+		lower, upper := q.idBounds()
+		if i.desc() {
+			if upper != "" && (seek == "" || upper < seek) {
+				seek = upper
+			}
+		} else if lower > seek {
+			seek = lower
+		}
+	}
+	if seek != "" {
+		dsq.SeekPrefix = prefix.Child(ds.NewKey(seek)).String()
+	} else if i.desc() {
+		// Reverse iterators start from the last key before the seek, which must be under prefix
+		dsq.SeekPrefix = prefixEnd(prefix.String() + "/")
 	}
 	i.iter, i.err = txn.QueryExtended(dsq)
 
@@ -329,6 +401,7 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) *iterator {
 				return nKeys, result.Error
 			}
 			first = false
+			i.scanned++
 			// result.Key contains the indexed value, extract here first
 			key := ds.RawKey(result.Key)
 			base := prefix.Name()
@@ -350,11 +423,16 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) *iterator {
 				return nil, fmt.Errorf("error when matching entry with query: %v", err)
 			}
 			if ok {
+				i.matched++
 				indexValue := make(keyList, 0)
 				if err := DefaultDecode(result.Value, &indexValue); err != nil {
 					return nil, err
 				}
 				for _, v := range indexValue {
+					if _, ok := seen[string(v)]; ok {
+						continue
+					}
+					seen[string(v)] = struct{}{}
 					nKeys = append(nKeys, ds.RawKey(string(v)))
 				}
 			}
@@ -364,13 +442,61 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) *iterator {
 	return i
 }
 
+// lookupKeys returns keys of instances in index entries of values.
+func (i *iterator) lookupKeys(prefix ds.Key, values []Value, seen map[string]struct{}) func() ([]ds.Key, error) {
+	return func() ([]ds.Key, error) {
+		var nKeys []ds.Key
+		for len(values) > 0 && len(nKeys) < iteratorKeyMinCacheSize {
+			v := values[0]
+			values = values[1:]
+			var name string
+			if v.String != nil {
+				name = *v.String
+			} else {
+				name = strconv.FormatBool(*v.Bool)
+			}
+			data, err := i.txn.Get(prefix.ChildString(ds.NewKey(name).String()[1:]))
+			if errors.Is(err, ds.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			i.scanned++
+			i.matched++
+			indexValue := make(keyList, 0)
+			if err := DefaultDecode(data, &indexValue); err != nil {
+				return nil, err
+			}
+			for _, k := range indexValue {
+				if _, ok := seen[string(k)]; ok {
+					continue
+				}
+				seen[string(k)] = struct{}{}
+				nKeys = append(nKeys, ds.RawKey(string(k)))
+			}
+		}
+		return nKeys, nil
+	}
+}
+
 // NextSync returns the next key value that matches the iterators criteria
 // If there is an error, ok is false and result.Error() will return the error
 func (i *iterator) NextSync() (MarshaledResult, bool) {
 	if i.query.Index == "" {
 		value := MarshaledResult{}
 		var ok bool
+		lower, upper := i.query.idBounds()
 		for res := range i.iter.Next() {
+			if res.Error == nil {
+				id := ds.RawKey(res.Key).Name()
+				if (i.desc() && lower != "" && id < lower) || (!i.desc() && upper != "" && id >= upper) {
+					break
+				}
+				if !i.query.matchID(id) {
+					continue
+				}
+			}
+			i.scanned++
 			val := make(map[string]interface{})
 			if value.Error = json.Unmarshal(res.Value, &val); value.Error != nil {
 				break
@@ -380,6 +506,7 @@ func (i *iterator) NextSync() (MarshaledResult, bool) {
 				break
 			}
 			if ok {
+				i.matched++
 				return MarshaledResult{
 					Result:         res,
 					MarshaledValue: val,
@@ -388,7 +515,7 @@ func (i *iterator) NextSync() (MarshaledResult, bool) {
 		}
 		return value, ok
 	}
-	if len(i.keyCache) == 0 {
+	for len(i.keyCache) == 0 {
 		newKeys, err := i.nextKeys()
 		if err != nil {
 			return MarshaledResult{
@@ -407,12 +534,17 @@ func (i *iterator) NextSync() (MarshaledResult, bool) {
 				},
 			}, false
 		}
-		i.keyCache = append(i.keyCache, newKeys...)
+		for _, k := range newKeys {
+			if i.query.matchID(k.Name()) {
+				i.keyCache = append(i.keyCache, k)
+			}
+		}
 	}
 
 	key := i.keyCache[0]
 	i.keyCache = i.keyCache[1:]
 
+	i.fetched++
 	value, err := i.txn.Get(key)
 	if err != nil {
 		return MarshaledResult{
@@ -431,8 +563,15 @@ func (i *iterator) NextSync() (MarshaledResult, bool) {
 		}}, true
 }
 
+// desc returns whether instances are read in descending ID order.
+func (i *iterator) desc() bool {
+	return i.query.Sort.FieldPath == idFieldName && i.query.Sort.Desc
+}
+
 func (i *iterator) Close() {
-	i.iter.Close()
+	if i.iter != nil {
+		i.iter.Close()
+	}
 }
 
 // Error returns the last error on the iterator
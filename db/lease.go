@@ -0,0 +1,323 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/util"
+)
+
+const leaseCollectionName = "_leases"
+
+var (
+	// ErrLeaseHeld indicates the instance is leased by another holder.
+	ErrLeaseHeld = errors.New("instance is leased by another holder")
+	// ErrLeaseNotFound indicates the instance has no active lease.
+	ErrLeaseNotFound = errors.New("lease not found")
+	// ErrInvalidLeaseTTL indicates the lease duration is not positive or exceeds MaxLeaseTTL.
+	ErrInvalidLeaseTTL = errors.New("invalid lease ttl")
+
+	// MaxLeaseTTL is the maximum duration of a single lease. Leases are meant to be
+	// short-lived and periodically renewed by the holder while it's active.
+	MaxLeaseTTL = time.Minute * 5
+
+	// LeaseCheckInterval is the interval between checks for expired leases.
+	LeaseCheckInterval = time.Second
+)
+
+// Lease is an advisory write lock on an instance held by an identity until it expires.
+// Leases are replicated alongside regular instances, so all db peers see the same holder.
+// The db does not enforce leases on writes, it's up to the app to respect them.
+type Lease struct {
+	// Collection is the name of the leased instance's collection.
+	Collection string
+	// InstanceID is the leased instance's ID.
+	InstanceID core.InstanceID
+	// Holder is the identity holding the lease.
+	Holder thread.PubKey
+	// Expires is the time the lease expires unless renewed.
+	Expires time.Time
+}
+
+// Active returns whether or not the lease is not expired.
+func (l Lease) Active() bool {
+	return time.Now().Before(l.Expires)
+}
+
+// leaseInstance is the replicated form of a lease. Expires is stored in
+// milliseconds, since json patches don't preserve int64 nanosecond precision.
+type leaseInstance struct {
+	ID         core.InstanceID `json:"_id"`
+	Mod        int64           `json:"_mod"`
+	Collection string          `json:"collection"`
+	InstanceID core.InstanceID `json:"instanceID"`
+	Holder     string          `json:"holder"`
+	Expires    int64           `json:"expires"`
+	Sig        []byte          `json:"sig"`
+}
+
+// AcquireLease acquires or renews a lease on the instance for ttl.
+// The lease is signed by identity, which becomes the lease holder.
+// ErrLeaseHeld is returned if the instance is leased by another holder.
+func (c *Collection) AcquireLease(
+	ctx context.Context,
+	id core.InstanceID,
+	identity thread.Identity,
+	ttl time.Duration,
+	opts ...TxnOption,
+) (lease Lease, err error) {
+	if ttl <= 0 || ttl > MaxLeaseTTL {
+		return lease, ErrInvalidLeaseTTL
+	}
+	lid := leaseID(c.name, id)
+	err = c.db.leases.collection.WriteTxn(func(txn *Txn) error {
+		current, exists, err := findLease(txn, lid)
+		if err != nil {
+			return err
+		}
+		if exists && current.Active() && !current.Holder.Equals(identity.GetPublic()) {
+			return ErrLeaseHeld
+		}
+		lease = Lease{
+			Collection: c.name,
+			InstanceID: id,
+			Holder:     identity.GetPublic(),
+			Expires:    time.Now().Add(ttl).Truncate(time.Millisecond),
+		}
+		li := leaseInstance{
+			ID:         lid,
+			Collection: lease.Collection,
+			InstanceID: lease.InstanceID,
+			Holder:     lease.Holder.String(),
+			Expires:    lease.Expires.UnixNano() / int64(time.Millisecond),
+		}
+		if li.Sig, err = identity.Sign(ctx, li.payload()); err != nil {
+			return err
+		}
+		v, err := json.Marshal(li)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return txn.Save(v)
+		}
+		_, err = txn.Create(v)
+		return err
+	}, opts...)
+	return lease, err
+}
+
+// ReleaseLease releases the lease on the instance held by holder.
+// Expired leases can be released by anyone.
+func (c *Collection) ReleaseLease(id core.InstanceID, holder thread.PubKey, opts ...TxnOption) error {
+	lid := leaseID(c.name, id)
+	return c.db.leases.collection.WriteTxn(func(txn *Txn) error {
+		current, exists, err := findLease(txn, lid)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrLeaseNotFound
+		}
+		if current.Active() && !current.Holder.Equals(holder) {
+			return ErrLeaseHeld
+		}
+		return txn.Delete(lid)
+	}, opts...)
+}
+
+// GetLease returns the active lease on the instance.
+// ErrLeaseNotFound is returned if the instance is not leased or the lease is expired.
+func (c *Collection) GetLease(id core.InstanceID, opts ...TxnOption) (lease Lease, err error) {
+	err = c.db.leases.collection.ReadTxn(func(txn *Txn) error {
+		var exists bool
+		lease, exists, err = findLease(txn, leaseID(c.name, id))
+		if err != nil {
+			return err
+		}
+		if !exists || !lease.Active() {
+			return ErrLeaseNotFound
+		}
+		return nil
+	}, opts...)
+	return lease, err
+}
+
+// findLease returns a lease by ID. Leases with invalid signatures are treated as missing.
+func findLease(txn *Txn, lid core.InstanceID) (Lease, bool, error) {
+	v, err := txn.FindByID(lid)
+	if errors.Is(err, ErrInstanceNotFound) {
+		return Lease{}, false, nil
+	} else if err != nil {
+		return Lease{}, false, err
+	}
+	lease, err := leaseFromInstance(v)
+	if err != nil {
+		log.Warnf("ignoring lease %s: %v", lid, err)
+		return Lease{}, false, nil
+	}
+	return lease, true, nil
+}
+
+// leaseID returns a deterministic lease instance ID for an instance.
+func leaseID(collection string, id core.InstanceID) core.InstanceID {
+	sum := sha256.Sum256([]byte(collection + "/" + id.String()))
+	return core.InstanceID(hex.EncodeToString(sum[:]))
+}
+
+func (li leaseInstance) payload() []byte {
+	return []byte(fmt.Sprintf("%s\n%s\n%s\n%d", li.Collection, li.InstanceID, li.Holder, li.Expires))
+}
+
+// leaseFromInstance decodes a lease and verifies its signature.
+func leaseFromInstance(v []byte) (lease Lease, err error) {
+	var li leaseInstance
+	if err = json.Unmarshal(v, &li); err != nil {
+		return
+	}
+	holder := &thread.Libp2pPubKey{}
+	if err = holder.UnmarshalString(li.Holder); err != nil {
+		return lease, fmt.Errorf("invalid holder: %w", err)
+	}
+	if ok, err := holder.Verify(li.payload(), li.Sig); !ok || err != nil {
+		return lease, errors.New("bad signature")
+	}
+	return Lease{
+		Collection: li.Collection,
+		InstanceID: li.InstanceID,
+		Holder:     holder,
+		Expires:    time.Unix(0, li.Expires*int64(time.Millisecond)),
+	}, nil
+}
+
+// leaseTracker follows active leases and notifies listeners about lease changes.
+type leaseTracker struct {
+	collection *Collection
+
+	lock   sync.Mutex
+	active map[core.InstanceID]Lease
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newLeaseTracker registers the internal lease collection and loads active leases.
+func newLeaseTracker(d *DB) (*leaseTracker, error) {
+	schema := util.SchemaFromInstance(&leaseInstance{}, false)
+	c, err := newCollection(d, CollectionConfig{Schema: schema})
+	if err != nil {
+		return nil, err
+	}
+	// the internal name is set directly, since it's not allowed for user collections
+	c.name = leaseCollectionName
+	if err := d.addIndexes(c, schema, nil); err != nil {
+		return nil, err
+	}
+	d.collections[c.name] = c
+
+	t := &leaseTracker{
+		collection: c,
+		active:     make(map[core.InstanceID]Lease),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	results, err := d.datastore.Query(query.Query{
+		Prefix: c.baseKey().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		lease, err := leaseFromInstance(res.Value)
+		if err != nil || !lease.Active() {
+			continue
+		}
+		t.active[leaseID(lease.Collection, lease.InstanceID)] = lease
+	}
+	return t, nil
+}
+
+// reduced turns an applied lease collection action into a lease action.
+func (t *leaseTracker) reduced(get func(ds.Key) ([]byte, error), ra core.ReduceAction) (Action, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	switch ra.Type {
+	case core.Create, core.Save:
+		v, err := get(t.collection.baseKey().ChildString(ra.InstanceID.String()))
+		if err != nil {
+			log.Errorf("getting lease %s: %v", ra.InstanceID, err)
+			return Action{}, false
+		}
+		lease, err := leaseFromInstance(v)
+		if err != nil {
+			log.Warnf("ignoring lease %s: %v", ra.InstanceID, err)
+			return Action{}, false
+		}
+		if !lease.Active() {
+			return Action{}, false
+		}
+		t.active[ra.InstanceID] = lease
+		return Action{Collection: lease.Collection, Type: ActionLeaseAcquire, ID: lease.InstanceID}, true
+	case core.Delete:
+		lease, ok := t.active[ra.InstanceID]
+		if !ok {
+			return Action{}, false
+		}
+		delete(t.active, ra.InstanceID)
+		return Action{Collection: lease.Collection, Type: ActionLeaseRelease, ID: lease.InstanceID}, true
+	default:
+		return Action{}, false
+	}
+}
+
+// expired drops and returns actions for the leases expired since the last check.
+func (t *leaseTracker) expired() []Action {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var actions []Action
+	for lid, lease := range t.active {
+		if !lease.Active() {
+			delete(t.active, lid)
+			actions = append(actions, Action{Collection: lease.Collection, Type: ActionLeaseExpire, ID: lease.InstanceID})
+		}
+	}
+	return actions
+}
+
+// start notifies about expired leases until the tracker is closed.
+func (t *leaseTracker) start(notify func([]Action)) {
+	defer close(t.done)
+	tick := time.NewTicker(LeaseCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-tick.C:
+			if actions := t.expired(); len(actions) > 0 {
+				notify(actions)
+			}
+		}
+	}
+}
+
+func (t *leaseTracker) close() {
+	close(t.stop)
+	<-t.done
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestLeases(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Docs",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(dummy{Name: "doc"}))
+	checkErr(t, err)
+
+	l, err := d.Listen(ListenOption{Type: ListenLease})
+	checkErr(t, err)
+	defer l.Close()
+
+	alice := createLeaseIdentity(t)
+	bob := createLeaseIdentity(t)
+	ctx := context.Background()
+
+	t.Run("Acquire", func(t *testing.T) {
+		lease, err := c.AcquireLease(ctx, id, alice, time.Second)
+		checkErr(t, err)
+		if !lease.Holder.Equals(alice.GetPublic()) || !lease.Active() {
+			t.Fatalf("unexpected lease: %v", lease)
+		}
+		assertLeaseAction(t, l, Action{Collection: "Docs", Type: ActionLeaseAcquire, ID: id})
+
+		lease, err = c.GetLease(id)
+		checkErr(t, err)
+		if !lease.Holder.Equals(alice.GetPublic()) {
+			t.Fatalf("unexpected lease holder: %s", lease.Holder)
+		}
+		if _, err = c.AcquireLease(ctx, id, bob, time.Second); !errors.Is(err, ErrLeaseHeld) {
+			t.Fatalf("expected lease to be held, got: %v", err)
+		}
+		if err = c.ReleaseLease(id, bob.GetPublic()); !errors.Is(err, ErrLeaseHeld) {
+			t.Fatalf("expected lease to be held, got: %v", err)
+		}
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		assertLeaseAction(t, l, Action{Collection: "Docs", Type: ActionLeaseExpire, ID: id})
+		if _, err := c.GetLease(id); !errors.Is(err, ErrLeaseNotFound) {
+			t.Fatalf("expected lease to be expired, got: %v", err)
+		}
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		_, err := c.AcquireLease(ctx, id, bob, time.Minute)
+		checkErr(t, err)
+		assertLeaseAction(t, l, Action{Collection: "Docs", Type: ActionLeaseAcquire, ID: id})
+		checkErr(t, c.ReleaseLease(id, bob.GetPublic()))
+		assertLeaseAction(t, l, Action{Collection: "Docs", Type: ActionLeaseRelease, ID: id})
+		if _, err := c.GetLease(id); !errors.Is(err, ErrLeaseNotFound) {
+			t.Fatalf("expected lease to be released, got: %v", err)
+		}
+	})
+
+	t.Run("Hidden", func(t *testing.T) {
+		for _, c := range d.ListCollections() {
+			if c.GetName() == leaseCollectionName {
+				t.Fatal("lease collection should not be listed")
+			}
+		}
+		if err := d.DeleteCollection(leaseCollectionName); !errors.Is(err, ErrCollectionNotFound) {
+			t.Fatalf("expected lease collection to be protected, got: %v", err)
+		}
+	})
+}
+
+func createLeaseIdentity(t *testing.T) thread.Identity {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	checkErr(t, err)
+	return thread.NewLibp2pIdentity(sk)
+}
+
+func assertLeaseAction(t *testing.T, l Listener, expected Action) {
+	t.Helper()
+	select {
+	case a := <-l.Channel():
+		if a != expected {
+			t.Fatalf("expected action %v, got %v", expected, a)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("action %v wasn't received", expected)
+	}
+}
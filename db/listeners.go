@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/textileio/go-threads/core/app"
@@ -13,6 +14,8 @@ import (
 // Listen returns a Listener which notifies about actions applying the
 // defined filters. The DB *won't* wait for slow receivers, so if the
 // channel is full, the action will be dropped.
+// Lease actions are only delivered to listeners with a ListenLease filter,
+// and conflict actions to listeners with a ListenConflict filter.
 func (d *DB) Listen(los ...ListenOption) (Listener, error) {
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
@@ -30,7 +33,23 @@ func (d *DB) Listen(los ...ListenOption) (Listener, error) {
 }
 
 func (d *DB) notifyStateChanged(actions []Action) {
-	d.stateChangedNotifee.notify(actions)
+	start := time.Now()
+	d.actionLog.lock.Lock()
+	defer d.actionLog.lock.Unlock()
+	events, err := d.actionLog.append(actions)
+	if err != nil {
+		// resumable listeners would miss the actions, they're closed and can't resume
+		// from the tokens they received
+		log.Errorf("logging actions failed: %v", err)
+		d.actionLog.skip()
+		d.stateChangedNotifee.closeResumables()
+		events = make([]ListenEvent, len(actions))
+		for i, a := range actions {
+			events[i] = ListenEvent{Action: a}
+		}
+	}
+	d.stateChangedNotifee.notify(events)
+	d.metrics.observeFanout(actions, start)
 }
 
 func (d *DB) notifyTxnEvents(node format.Node, token thread.Token) error {
@@ -47,6 +66,15 @@ const (
 	ActionCreate ActionType = iota + 1
 	ActionSave
 	ActionDelete
+	// ActionLeaseAcquire indicates an instance lease was acquired or renewed.
+	ActionLeaseAcquire
+	// ActionLeaseRelease indicates an instance lease was released by the holder.
+	ActionLeaseRelease
+	// ActionLeaseExpire indicates an instance lease expired.
+	ActionLeaseExpire
+	// ActionConflict indicates a path of an instance was changed concurrently, it follows
+	// the save action merging the changes. Each conflicting path has its own action.
+	ActionConflict
 )
 
 const (
@@ -54,12 +82,18 @@ const (
 	ListenCreate
 	ListenSave
 	ListenDelete
+	// ListenLease listens for lease actions, which are excluded from ListenAll.
+	ListenLease
+	// ListenConflict listens for conflict actions, which are excluded from ListenAll.
+	ListenConflict
 )
 
 type Action struct {
 	Collection string
 	Type       ActionType
 	ID         core.InstanceID
+	// Path is the conflicting path of ActionConflict actions.
+	Path string
 }
 
 type ListenOption struct {
@@ -74,8 +108,9 @@ type Listener interface {
 }
 
 type stateChangedNotifee struct {
-	lock      sync.RWMutex
-	listeners []*listener
+	lock       sync.RWMutex
+	listeners  []*listener
+	resumables map[*resumableListener]struct{}
 }
 
 type listener struct {
@@ -86,10 +121,11 @@ type listener struct {
 
 var _ Listener = (*listener)(nil)
 
-func (scn *stateChangedNotifee) notify(actions []Action) {
+func (scn *stateChangedNotifee) notify(events []ListenEvent) {
 	scn.lock.RLock()
-	defer scn.lock.RUnlock()
-	for _, a := range actions {
+	behind := make(map[*resumableListener]struct{})
+	for _, e := range events {
+		a := e.Action
 		for _, l := range scn.listeners {
 			if l.evaluate(a) {
 				select {
@@ -99,7 +135,48 @@ func (scn *stateChangedNotifee) notify(actions []Action) {
 				}
 			}
 		}
+		for l := range scn.resumables {
+			if _, ok := behind[l]; ok {
+				continue
+			}
+			// listeners falling behind are closed, so they don't miss actions
+			if !l.notify(e) {
+				behind[l] = struct{}{}
+			}
+		}
+	}
+	scn.lock.RUnlock()
+	for l := range behind {
+		scn.removeResumable(l)
+	}
+}
+
+func (scn *stateChangedNotifee) addResumable(l *resumableListener) {
+	scn.lock.Lock()
+	defer scn.lock.Unlock()
+	if scn.resumables == nil {
+		scn.resumables = make(map[*resumableListener]struct{})
+	}
+	scn.resumables[l] = struct{}{}
+}
+
+func (scn *stateChangedNotifee) removeResumable(l *resumableListener) {
+	scn.lock.Lock()
+	defer scn.lock.Unlock()
+	if _, ok := scn.resumables[l]; ok {
+		delete(scn.resumables, l)
+		close(l.live)
+	}
+}
+
+// closeResumables closes the resumable listeners.
+func (scn *stateChangedNotifee) closeResumables() {
+	scn.lock.Lock()
+	defer scn.lock.Unlock()
+	for l := range scn.resumables {
+		close(l.live)
 	}
+	scn.resumables = nil
 }
 
 func (scn *stateChangedNotifee) addListener(sl *listener) {
@@ -130,6 +207,10 @@ func (scn *stateChangedNotifee) close() {
 		scn.listeners[i] = nil
 	}
 	scn.listeners = nil
+	for l := range scn.resumables {
+		close(l.live)
+	}
+	scn.resumables = nil
 }
 
 // Channel returns an unbuffered channel to receive
@@ -148,11 +229,22 @@ func (sl *listener) Close() {
 
 func (sl *listener) evaluate(a Action) bool {
 	if len(sl.filters) == 0 {
-		return true
+		return !a.Type.isLease() && a.Type != ActionConflict
 	}
 	for _, f := range sl.filters {
 		switch f.Type {
+		case ListenLease:
+			if !a.Type.isLease() {
+				continue
+			}
+		case ListenConflict:
+			if a.Type != ActionConflict {
+				continue
+			}
 		case ListenAll:
+			if a.Type.isLease() || a.Type == ActionConflict {
+				continue
+			}
 		case ListenCreate:
 			if a.Type != ActionCreate {
 				continue
@@ -180,3 +272,7 @@ func (sl *listener) evaluate(a Action) bool {
 	}
 	return false
 }
+
+func (t ActionType) isLease() bool {
+	return t == ActionLeaseAcquire || t == ActionLeaseRelease || t == ActionLeaseExpire
+}
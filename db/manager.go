@@ -3,19 +3,21 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/keytransform"
 	"github.com/ipfs/go-datastore/query"
-	logging "github.com/ipfs/go-log"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/core/app"
 	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	kt "github.com/textileio/go-threads/db/keytransform"
-	"github.com/textileio/go-threads/util"
+	"github.com/textileio/go-threads/lifecycle"
+	"github.com/textileio/go-threads/logger"
 )
 
 var (
@@ -27,6 +29,9 @@ var (
 	dsManagerBaseKey = ds.NewKey("/manager")
 )
 
+// DBThreadTag labels threads of managed dbs, see WithNewAutoCreate.
+const DBThreadTag = "threaddb"
+
 type Manager struct {
 	io.Closer
 
@@ -34,6 +39,7 @@ type Manager struct {
 
 	store   kt.TxnDatastoreExtended
 	network app.Net
+	lock    sync.RWMutex
 	dbs     map[thread.ID]*DB
 }
 
@@ -45,8 +51,8 @@ func NewManager(store kt.TxnDatastoreExtended, network app.Net, opts ...NewOptio
 	}
 
 	if args.Debug {
-		if err := util.SetLogLevels(map[string]logging.LogLevel{
-			"db": logging.LevelDebug,
+		if err := logger.SetLevels(map[string]logger.Level{
+			"db": logger.LevelDebug,
 		}); err != nil {
 			return nil, err
 		}
@@ -105,6 +111,9 @@ func NewManager(store kt.TxnDatastoreExtended, network app.Net, opts ...NewOptio
 			return nil, err
 		}
 	}
+	if m.opts.AutoCreate {
+		m.network.SetAppResolver(m.resolveDB)
+	}
 	return m, nil
 }
 
@@ -115,19 +124,15 @@ func (m *Manager) GetToken(ctx context.Context, identity thread.Identity) (threa
 
 // NewDB creates a new db and prefixes its datastore with base key.
 func (m *Manager) NewDB(ctx context.Context, id thread.ID, opts ...NewManagedOption) (*DB, error) {
-	if _, ok := m.dbs[id]; ok {
+	if _, ok := m.getDB(id); ok {
 		return nil, ErrDBExists
 	}
 	args := &NewManagedOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
-	if args.Name != "" && !nameRx.MatchString(args.Name) { // Pre-check name
-		return nil, ErrInvalidName
-	}
-
-	if args.Key.Defined() && !args.Key.CanRead() {
-		return nil, ErrThreadReadKeyRequired
+	if err := args.Validate(); err != nil {
+		return nil, err
 	}
 	if _, err := m.network.CreateThread(
 		ctx,
@@ -135,20 +140,11 @@ func (m *Manager) NewDB(ctx context.Context, id thread.ID, opts ...NewManagedOpt
 		net.WithThreadKey(args.Key),
 		net.WithLogKey(args.LogKey),
 		net.WithNewThreadToken(args.Token),
+		net.WithNewThreadTags(DBThreadTag),
 	); err != nil {
 		return nil, err
 	}
-
-	store, dbOpts, err := wrapDB(m.store, id, m.opts, args.Name, args.Collections...)
-	if err != nil {
-		return nil, err
-	}
-	db, err := newDB(store, m.network, id, dbOpts)
-	if err != nil {
-		return nil, err
-	}
-	m.dbs[id] = db
-	return db, nil
+	return m.openDB(id, args.Name, args.Collections...)
 }
 
 // NewDBFromAddr creates a new db from address and prefixes its datastore with base key.
@@ -164,15 +160,18 @@ func (m *Manager) NewDBFromAddr(
 	if err != nil {
 		return nil, err
 	}
-	if _, ok := m.dbs[id]; ok {
+	if _, ok := m.getDB(id); ok {
 		return nil, ErrDBExists
 	}
 	args := &NewManagedOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
-	if args.Name != "" && !nameRx.MatchString(args.Name) { // Pre-check name
-		return nil, ErrInvalidName
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+	if args.Key.Defined() {
+		return nil, fmt.Errorf("%w: the thread key must be passed as an argument", ErrConflictingOptions)
 	}
 
 	if key.Defined() && !key.CanRead() {
@@ -184,32 +183,30 @@ func (m *Manager) NewDBFromAddr(
 		net.WithThreadKey(key),
 		net.WithLogKey(args.LogKey),
 		net.WithNewThreadToken(args.Token),
+		net.WithNewThreadTags(DBThreadTag),
+		net.WithHeadHints(args.HeadHints),
 	); err != nil {
 		return nil, err
 	}
-
-	store, dbOpts, err := wrapDB(m.store, id, m.opts, args.Name, args.Collections...)
-	if err != nil {
-		return nil, err
-	}
-	db, err := newDB(store, m.network, id, dbOpts)
+	db, err := m.openDB(id, args.Name, args.Collections...)
 	if err != nil {
 		return nil, err
 	}
-	m.dbs[id] = db
 
 	if args.Block {
 		if err = m.network.PullThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
 			return nil, err
 		}
 	} else {
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), pullThreadBackgroundTimeout)
+		if err = db.life.Go(lifecycle.StageSync, "pull thread", func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, pullThreadBackgroundTimeout)
 			defer cancel()
 			if err := m.network.PullThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
 				log.Errorf("error pulling thread %s", id)
 			}
-		}()
+		}); err != nil {
+			return nil, err
+		}
 	}
 	return db, nil
 }
@@ -221,8 +218,15 @@ func (m *Manager) ListDBs(ctx context.Context, opts ...ManagedOption) (map[threa
 		opt(args)
 	}
 
-	dbs := make(map[thread.ID]*DB)
+	m.lock.RLock()
+	all := make(map[thread.ID]*DB, len(m.dbs))
 	for id, db := range m.dbs {
+		all[id] = db
+	}
+	m.lock.RUnlock()
+
+	dbs := make(map[thread.ID]*DB)
+	for id, db := range all {
 		if _, err := m.network.GetThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
 			return nil, err
 		}
@@ -240,11 +244,25 @@ func (m *Manager) GetDB(ctx context.Context, id thread.ID, opts ...ManagedOption
 	if _, err := m.network.GetThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
 		return nil, err
 	}
-	db, ok := m.dbs[id]
-	if !ok {
-		return nil, ErrDBNotFound
+	if db, ok := m.getDB(id); ok {
+		return db, nil
 	}
-	return db, nil
+	if m.opts.AutoCreate {
+		tagged, err := m.isDBThread(ctx, id, args.Token)
+		if err != nil {
+			return nil, err
+		}
+		if tagged {
+			db, err := m.openDB(id, "")
+			if errors.Is(err, ErrDBExists) {
+				// created concurrently, e.g. once the thread received records
+				db, _ = m.getDB(id)
+				return db, nil
+			}
+			return db, err
+		}
+	}
+	return nil, ErrDBNotFound
 }
 
 // DeleteDB deletes a db by id.
@@ -256,7 +274,7 @@ func (m *Manager) DeleteDB(ctx context.Context, id thread.ID, opts ...ManagedOpt
 	if _, err := m.network.GetThread(ctx, id, net.WithThreadToken(args.Token)); err != nil {
 		return err
 	}
-	db, ok := m.dbs[id]
+	db, ok := m.getDB(id)
 	if !ok {
 		return ErrDBNotFound
 	}
@@ -281,10 +299,79 @@ func (m *Manager) DeleteDB(ctx context.Context, id thread.ID, opts ...ManagedOpt
 		return err
 	}
 
+	m.lock.Lock()
 	delete(m.dbs, id)
+	m.lock.Unlock()
 	return nil
 }
 
+func (m *Manager) getDB(id thread.ID) (*DB, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	db, ok := m.dbs[id]
+	return db, ok
+}
+
+// openDB starts the db of an existing thread, unless it's started already.
+func (m *Manager) openDB(id thread.ID, name string, collections ...CollectionConfig) (*DB, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.dbs[id]; ok {
+		return nil, ErrDBExists
+	}
+	store, opts, err := wrapDB(m.store, id, m.opts, name, collections...)
+	if err != nil {
+		return nil, err
+	}
+	db, err := newDB(store, m.network, id, opts)
+	if err != nil {
+		return nil, err
+	}
+	m.dbs[id] = db
+	return db, nil
+}
+
+// isDBThread returns whether the thread is tagged with DBThreadTag.
+func (m *Manager) isDBThread(ctx context.Context, id thread.ID, token thread.Token) (bool, error) {
+	var pageToken string
+	for {
+		page, err := m.network.ListThreads(
+			ctx,
+			net.WithListToken(token),
+			net.WithTagFilter(DBThreadTag),
+			net.WithPageToken(pageToken),
+		)
+		if err != nil {
+			return false, err
+		}
+		for _, t := range page.Threads {
+			if t.ID == id {
+				return true, nil
+			}
+		}
+		if page.NextPageToken == "" {
+			return false, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// resolveDB is the app resolver auto-creating dbs of tagged threads once they receive records.
+func (m *Manager) resolveDB(id thread.ID, tags []string) bool {
+	for _, tag := range tags {
+		if tag != DBThreadTag {
+			continue
+		}
+		if _, err := m.openDB(id, ""); err != nil && !errors.Is(err, ErrDBExists) {
+			log.Errorf("auto-creating db %s failed: %v", id, err)
+			return false
+		}
+		log.Debugf("auto-created db %s", id)
+		return true
+	}
+	return false
+}
+
 func (m *Manager) deleteThreadNamespace(id thread.ID) error {
 	pre := dsManagerBaseKey.ChildString(id.String())
 	q := query.Query{Prefix: pre.String(), KeysOnly: true}
@@ -308,6 +395,11 @@ func (m *Manager) Net() net.Net {
 
 // Close all dbs.
 func (m *Manager) Close() error {
+	if m.opts.AutoCreate {
+		m.network.SetAppResolver(nil)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	for _, s := range m.dbs {
 		if err := s.Close(); err != nil {
 			log.Error("error when closing manager datastore: %v", err)
@@ -333,10 +425,14 @@ func wrapDB(
 		Prefix: dsManagerBaseKey.ChildString(id.String()),
 	})
 	opts := &NewOptions{
-		Name:        name,
-		Collections: append(base.Collections, collections...),
-		EventCodec:  base.EventCodec,
-		Debug:       base.Debug,
+		Name:            name,
+		Collections:     append(base.Collections, collections...),
+		EventCodec:      base.EventCodec,
+		Debug:           base.Debug,
+		BatchWindow:     base.BatchWindow,
+		BatchMaxActions: base.BatchMaxActions,
+		EventStream:     base.EventStream,
+		SortMemoryLimit: base.SortMemoryLimit,
 	}
 	return store, opts, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/textileio/go-threads/common"
 	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/util"
 )
@@ -247,7 +248,62 @@ func TestManager_DeleteDB(t *testing.T) {
 	}
 }
 
-func createTestManager(t *testing.T) (*Manager, func()) {
+func TestManager_AutoCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	person := CollectionConfig{Name: "Person", Schema: util.SchemaFromSchemaString(jsonSchema)}
+
+	t.Run("OnAccess", func(t *testing.T) {
+		man, clean := createTestManager(t, WithNewAutoCreate(true))
+		defer clean()
+
+		plain := thread.NewIDV1(thread.Raw, 32)
+		_, err := man.Net().CreateThread(ctx, plain)
+		checkErr(t, err)
+		if _, err := man.GetDB(ctx, plain); !errors.Is(err, ErrDBNotFound) {
+			t.Fatalf("expected db of untagged thread to be not found, got: %v", err)
+		}
+
+		tagged := thread.NewIDV1(thread.Raw, 32)
+		_, err = man.Net().CreateThread(ctx, tagged, net.WithNewThreadTags(DBThreadTag))
+		checkErr(t, err)
+		db, err := man.GetDB(ctx, tagged)
+		checkErr(t, err)
+		again, err := man.GetDB(ctx, tagged)
+		checkErr(t, err)
+		if db != again {
+			t.Fatal("expected the auto-created db to be reused")
+		}
+	})
+
+	t.Run("OnRemoteRecords", func(t *testing.T) {
+		man1, clean1 := createTestManager(t)
+		defer clean1()
+		man2, clean2 := createTestManager(t, WithNewAutoCreate(true), WithNewCollections(person))
+		defer clean2()
+
+		id := thread.NewIDV1(thread.Raw, 32)
+		db1, err := man1.NewDB(ctx, id, WithNewManagedCollections(person))
+		checkErr(t, err)
+		iid, err := db1.GetCollection("Person").Create([]byte(`{"_id": "", "name": "foo", "age": 21}`))
+		checkErr(t, err)
+		info, err := db1.GetDBInfo()
+		checkErr(t, err)
+
+		_, err = man2.Net().AddThread(ctx, info.Addrs[0], net.WithThreadKey(info.Key), net.WithNewThreadTags(DBThreadTag))
+		checkErr(t, err)
+		checkErr(t, man2.Net().PullThread(ctx, id))
+		db2, ok := man2.getDB(id)
+		if !ok {
+			t.Fatal("expected db to be auto-created once records were received")
+		}
+		if _, err := db2.GetCollection("Person").FindByID(iid); err != nil {
+			t.Fatalf("expected remote instance to be applied: %v", err)
+		}
+	})
+}
+
+func createTestManager(t *testing.T, opts ...NewOption) (*Manager, func()) {
 	dir, err := ioutil.TempDir("", "")
 	checkErr(t, err)
 	n, err := common.DefaultNetwork(
@@ -258,7 +314,7 @@ func createTestManager(t *testing.T) (*Manager, func()) {
 	checkErr(t, err)
 	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
 	checkErr(t, err)
-	m, err := NewManager(store, n, WithNewDebug(true))
+	m, err := NewManager(store, n, append([]NewOption{WithNewDebug(true)}, opts...)...)
 	checkErr(t, err)
 	return m, func() {
 		if err := n.Close(); err != nil {
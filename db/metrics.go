@@ -0,0 +1,127 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/net/queue"
+)
+
+// Metrics is a snapshot of db metrics, accumulated since the db was started.
+// Histograms are the ones used for net sync metrics, see net.SyncStats.
+type Metrics struct {
+	// WriteTxns is the duration of write transactions, from start to commit,
+	// including time spent waiting for other transactions.
+	WriteTxns queue.HistogramSnapshot
+	// Apply is the time spent applying events to collections.
+	Apply queue.HistogramSnapshot
+	// ApplyLag is the time between creation of events and their application,
+	// local and remote.
+	ApplyLag queue.HistogramSnapshot
+	// Fanout is the time spent sending applied actions to listeners.
+	Fanout queue.HistogramSnapshot
+	// Events is the number of applied events.
+	Events uint64
+	// Actions is the number of actions sent to listeners.
+	Actions uint64
+	// Indexes holds entry counts of indexes, i.e. the number of indexed
+	// instances, by collection name and index path.
+	Indexes map[string]map[string]int
+}
+
+type metrics struct {
+	writeTxns *queue.Histogram
+	apply     *queue.Histogram
+	applyLag  *queue.Histogram
+	fanout    *queue.Histogram
+	events    uint64
+	actions   uint64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		writeTxns: queue.NewHistogram(),
+		apply:     queue.NewHistogram(),
+		applyLag:  queue.NewHistogram(),
+		fanout:    queue.NewHistogram(),
+	}
+}
+
+// observeApply accounts events applied since start.
+func (m *metrics) observeApply(events []core.Event, start time.Time) {
+	now := time.Now()
+	m.apply.Observe(now.Sub(start))
+	atomic.AddUint64(&m.events, uint64(len(events)))
+	for _, e := range events {
+		var created int64
+		if err := binary.Read(bytes.NewReader(e.Time()), binary.BigEndian, &created); err != nil || created == 0 {
+			continue
+		}
+		m.applyLag.Observe(now.Sub(time.Unix(0, created)))
+	}
+}
+
+// observeFanout accounts actions sent to listeners since start.
+func (m *metrics) observeFanout(actions []Action, start time.Time) {
+	m.fanout.Observe(time.Since(start))
+	atomic.AddUint64(&m.actions, uint64(len(actions)))
+}
+
+// Metrics returns the current db metrics. Index entries are counted
+// when called, which reads all indexes.
+func (d *DB) Metrics(opts ...Option) (Metrics, error) {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		return Metrics{}, err
+	}
+	m := Metrics{
+		WriteTxns: d.metrics.writeTxns.Snapshot(),
+		Apply:     d.metrics.apply.Snapshot(),
+		ApplyLag:  d.metrics.applyLag.Snapshot(),
+		Fanout:    d.metrics.fanout.Snapshot(),
+		Events:    atomic.LoadUint64(&d.metrics.events),
+		Actions:   atomic.LoadUint64(&d.metrics.actions),
+		Indexes:   make(map[string]map[string]int),
+	}
+	for _, c := range d.ListCollections(opts...) {
+		counts := make(map[string]int)
+		for _, index := range c.GetIndexes() {
+			n, err := c.countIndexEntries(index.Path)
+			if err != nil {
+				return Metrics{}, err
+			}
+			counts[index.Path] = n
+		}
+		m.Indexes[c.name] = counts
+	}
+	return m, nil
+}
+
+func (c *Collection) countIndexEntries(path string) (int, error) {
+	results, err := c.db.datastore.Query(query.Query{
+		Prefix: indexPrefix.Child(c.baseKey()).ChildString(path).String(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer results.Close()
+	var n int
+	for res := range results.Next() {
+		if res.Error != nil {
+			return 0, res.Error
+		}
+		var keys keyList
+		if err := DefaultDecode(res.Value, &keys); err != nil {
+			return 0, err
+		}
+		n += len(keys)
+	}
+	return n, nil
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/textileio/go-threads/util"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Dummies",
+		Schema:  util.SchemaFromInstance(&dummy{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: name}))
+		checkErr(t, err)
+	}
+
+	m, err := d.Metrics()
+	checkErr(t, err)
+	if m.WriteTxns.Count != 3 {
+		t.Fatalf("expected 3 write txns, got %d", m.WriteTxns.Count)
+	}
+	if m.Events != 3 || m.Apply.Count != 3 || m.ApplyLag.Count != 3 {
+		t.Fatalf("expected 3 applied events, got %d (apply %d, lag %d)", m.Events, m.Apply.Count, m.ApplyLag.Count)
+	}
+	if m.Actions != 3 || m.Fanout.Count != 3 {
+		t.Fatalf("expected 3 notified actions, got %d (fanout %d)", m.Actions, m.Fanout.Count)
+	}
+	if n := m.Indexes["Dummies"]["Name"]; n != 3 {
+		t.Fatalf("expected 3 entries of the Name index, got %d", n)
+	}
+}
@@ -0,0 +1,305 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	// ErrIncompatibleSchema indicates a collection update with a schema which existing
+	// instances violate, and no migration to upgrade them.
+	ErrIncompatibleSchema = errors.New("schema is incompatible with existing instances")
+
+	dsMigrations = dsPrefix.ChildString("migration")
+)
+
+const migrationFn = "_migrate"
+
+// SchemaChange is a change between two schemas breaking compatibility.
+type SchemaChange struct {
+	// Path is the dot separated path of the changed field, empty for the root object.
+	// Array items are denoted by "[]".
+	Path string
+	// Description describes the change.
+	Description string
+	// BreaksBackward indicates instances valid under the old schema may be invalid
+	// under the new schema.
+	BreaksBackward bool
+	// BreaksForward indicates instances valid under the new schema may be invalid
+	// under the old schema.
+	BreaksForward bool
+}
+
+func (c SchemaChange) String() string {
+	if c.Path == "" {
+		return c.Description
+	}
+	return c.Path + ": " + c.Description
+}
+
+// SchemaCompatibility is the result of a schema compatibility analysis.
+type SchemaCompatibility struct {
+	// Backward is true if instances valid under the old schema are valid under the new one,
+	// i.e. existing instances can be read with the new schema.
+	Backward bool
+	// Forward is true if instances valid under the new schema are valid under the old one,
+	// i.e. peers with the old schema can read new instances.
+	Forward bool
+	// Changes are the changes breaking compatibility.
+	Changes []SchemaChange
+}
+
+// CheckSchemaCompatibility compares the types, properties, required properties and enums
+// of two schemas. Other validation keywords aren't compared.
+func CheckSchemaCompatibility(old, new *jsonschema.Schema) SchemaCompatibility {
+	cc := &compatChecker{oldDefs: old.Definitions, newDefs: new.Definitions}
+	cc.compare("", old.Type, new.Type)
+	res := SchemaCompatibility{Backward: true, Forward: true, Changes: cc.changes}
+	for _, c := range cc.changes {
+		if c.BreaksBackward {
+			res.Backward = false
+		}
+		if c.BreaksForward {
+			res.Forward = false
+		}
+	}
+	return res
+}
+
+type compatChecker struct {
+	oldDefs jsonschema.Definitions
+	newDefs jsonschema.Definitions
+	changes []SchemaChange
+}
+
+func (cc *compatChecker) change(path string, backward, forward bool, format string, args ...interface{}) {
+	cc.changes = append(cc.changes, SchemaChange{
+		Path:           path,
+		Description:    fmt.Sprintf(format, args...),
+		BreaksBackward: backward,
+		BreaksForward:  forward,
+	})
+}
+
+func (cc *compatChecker) compare(path string, old, new *jsonschema.Type) {
+	old = resolveSchemaRef(old, cc.oldDefs)
+	new = resolveSchemaRef(new, cc.newDefs)
+	if old == nil || new == nil {
+		return
+	}
+	if old.Type != new.Type {
+		if old.Type == "integer" && new.Type == "number" {
+			cc.change(path, false, true, "type widened from integer to number")
+		} else {
+			cc.change(path, true, true, "type changed from %q to %q", old.Type, new.Type)
+		}
+		return
+	}
+	cc.compareEnums(path, old.Enum, new.Enum)
+	switch new.Type {
+	case "object":
+		cc.compareObjects(path, old, new)
+	case "array":
+		cc.compare(path+"[]", old.Items, new.Items)
+	}
+}
+
+func (cc *compatChecker) compareObjects(path string, old, new *jsonschema.Type) {
+	oldClosed, newClosed := isClosedObject(old), isClosedObject(new)
+	if !oldClosed && newClosed {
+		cc.change(path, true, false, "additional properties disallowed")
+	} else if oldClosed && !newClosed {
+		cc.change(path, false, true, "additional properties allowed")
+	}
+	oldRequired, newRequired := stringSet(old.Required), stringSet(new.Required)
+	for _, name := range sortedProperties(old.Properties) {
+		fpath := joinPath(path, name)
+		if _, ok := new.Properties[name]; !ok {
+			cc.change(fpath, newClosed, oldRequired[name], "property removed")
+			continue
+		}
+		cc.compare(fpath, old.Properties[name], new.Properties[name])
+		if !oldRequired[name] && newRequired[name] {
+			cc.change(fpath, true, false, "property became required")
+		} else if oldRequired[name] && !newRequired[name] {
+			cc.change(fpath, false, true, "property became optional")
+		}
+	}
+	for _, name := range sortedProperties(new.Properties) {
+		if _, ok := old.Properties[name]; !ok {
+			if oldClosed || newRequired[name] {
+				cc.change(joinPath(path, name), newRequired[name], oldClosed, "property added")
+			}
+		}
+	}
+}
+
+func (cc *compatChecker) compareEnums(path string, old, new []interface{}) {
+	if len(old) == 0 && len(new) == 0 {
+		return
+	}
+	oldValues, newValues := enumSet(old), enumSet(new)
+	// an empty enum allows any value
+	removed := len(new) > 0 && (len(old) == 0 || !isSubset(oldValues, newValues))
+	added := len(old) > 0 && (len(new) == 0 || !isSubset(newValues, oldValues))
+	if removed {
+		cc.change(path, true, false, "enum values removed")
+	}
+	if added {
+		cc.change(path, false, true, "enum values added")
+	}
+}
+
+func resolveSchemaRef(jt *jsonschema.Type, defs jsonschema.Definitions) *jsonschema.Type {
+	if jt == nil || jt.Ref == "" {
+		return jt
+	}
+	parts := strings.Split(jt.Ref, "/")
+	return defs[parts[len(parts)-1]]
+}
+
+func isClosedObject(jt *jsonschema.Type) bool {
+	return strings.TrimSpace(string(jt.AdditionalProperties)) == "false"
+}
+
+func sortedProperties(props map[string]*jsonschema.Type) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func enumSet(values []interface{}) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		b, _ := json.Marshal(v)
+		set[string(b)] = true
+	}
+	return set
+}
+
+func isSubset(a, b map[string]bool) bool {
+	for v := range a {
+		if !b[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// checkSchemaUpdate returns ErrIncompatibleSchema if existing instances violate an
+// updated schema breaking backward compatibility, and there's no migration to upgrade them.
+func (c *Collection) checkSchemaUpdate(schema *jsonschema.Schema, migration string) error {
+	if migration != "" {
+		return nil
+	}
+	old, err := c.schema()
+	if err != nil {
+		return err
+	}
+	compat := CheckSchemaCompatibility(old, schema)
+	if compat.Backward {
+		return nil
+	}
+	sb, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	loader := gojsonschema.NewBytesLoader(sb)
+	results, err := c.db.datastore.Query(query.Query{
+		Prefix: c.baseKey().String(),
+	})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+	for res := range results.Next() {
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := validateStoredInstance(loader, res.Value); err != nil {
+			var changes []string
+			for _, c := range compat.Changes {
+				if c.BreaksBackward {
+					changes = append(changes, c.String())
+				}
+			}
+			return fmt.Errorf("%w: %s", ErrIncompatibleSchema, strings.Join(changes, "; "))
+		}
+	}
+	return nil
+}
+
+// validateStoredInstance validates an instance ignoring its modification time, which is
+// set by the db after validation.
+func validateStoredInstance(schemaLoader gojsonschema.JSONLoader, instance []byte) error {
+	var v map[string]interface{}
+	if err := json.Unmarshal(instance, &v); err != nil {
+		return err
+	}
+	delete(v, modFieldName)
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return validateInstance(schemaLoader, b)
+}
+
+// GetMigration returns the current collection migration.
+func (c *Collection) GetMigration() []byte {
+	return c.rawMigration
+}
+
+// upgrade migrates an instance which is invalid under the collection schema, e.g. one
+// written before a breaking schema update. Instances stay upgraded once saved.
+func (c *Collection) upgrade(instance []byte) ([]byte, error) {
+	if c.migration == nil || validateStoredInstance(c.schemaLoader, instance) == nil {
+		return instance, nil
+	}
+	upgraded, err := c.migrate(instance)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateStoredInstance(c.schemaLoader, upgraded); err != nil {
+		return nil, fmt.Errorf("upgrading instance: %w", err)
+	}
+	return upgraded, nil
+}
+
+func (c *Collection) migrate(instance []byte) ([]byte, error) {
+	c.Lock()
+	defer c.Unlock()
+	inv, err := parseJSON(c.vm, instance)
+	if err != nil {
+		return nil, fmt.Errorf("parsing instance in migration: %v", err)
+	}
+	res, err := c.migration(nil, inv)
+	if err != nil {
+		return nil, fmt.Errorf("running migration func: %v", err)
+	}
+	return json.Marshal(res.Export())
+}
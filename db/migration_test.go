@@ -0,0 +1,180 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestCheckSchemaCompatibility(t *testing.T) {
+	t.Parallel()
+	type person struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	type personOptional struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+		Age  int    `json:"age,omitempty"`
+	}
+	type personNumber struct {
+		ID   string  `json:"_id"`
+		Name string  `json:"name"`
+		Age  float64 `json:"age"`
+	}
+	type personEmail struct {
+		ID    string `json:"_id"`
+		Name  string `json:"name"`
+		Age   int    `json:"age"`
+		Email string `json:"email"`
+	}
+	open := func(s *jsonschema.Schema) *jsonschema.Schema {
+		b, err := json.Marshal(s)
+		checkErr(t, err)
+		var o jsonschema.Schema
+		checkErr(t, json.Unmarshal(b, &o))
+		o.Definitions[typeName(s)].AdditionalProperties = nil
+		return &o
+	}
+
+	cases := []struct {
+		name               string
+		old, new           *jsonschema.Schema
+		backward, forward  bool
+		backwardBreakPaths []string
+	}{
+		{"Same", util.SchemaFromInstance(&person{}, false), util.SchemaFromInstance(&person{}, false), true, true, nil},
+		{"OptionalField", util.SchemaFromInstance(&person{}, false), util.SchemaFromInstance(&personOptional{}, false), true, false, nil},
+		{"RequiredField", util.SchemaFromInstance(&personOptional{}, false), util.SchemaFromInstance(&person{}, false), false, true, []string{"age"}},
+		{"WidenedType", util.SchemaFromInstance(&person{}, false), util.SchemaFromInstance(&personNumber{}, false), true, false, nil},
+		{"NarrowedType", util.SchemaFromInstance(&personNumber{}, false), util.SchemaFromInstance(&person{}, false), false, false, []string{"age"}},
+		{"AddedField", util.SchemaFromInstance(&person{}, false), util.SchemaFromInstance(&personEmail{}, false), false, false, []string{"email"}},
+		{"RemovedField", util.SchemaFromInstance(&personEmail{}, false), util.SchemaFromInstance(&person{}, false), false, false, []string{"email"}},
+		{"RemovedFieldOpen", util.SchemaFromInstance(&personEmail{}, false), open(util.SchemaFromInstance(&person{}, false)), true, false, nil},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			res := CheckSchemaCompatibility(c.old, c.new)
+			if res.Backward != c.backward || res.Forward != c.forward {
+				t.Fatalf("expected backward=%v forward=%v, got %+v", c.backward, c.forward, res)
+			}
+			var paths []string
+			for _, change := range res.Changes {
+				if change.BreaksBackward {
+					paths = append(paths, change.Path)
+				}
+			}
+			if len(paths) != len(c.backwardBreakPaths) {
+				t.Fatalf("expected backward breaking changes at %v, got %v", c.backwardBreakPaths, res.Changes)
+			}
+			for i := range paths {
+				if paths[i] != c.backwardBreakPaths[i] {
+					t.Fatalf("expected backward breaking changes at %v, got %v", c.backwardBreakPaths, res.Changes)
+				}
+			}
+		})
+	}
+}
+
+func typeName(s *jsonschema.Schema) string {
+	for name := range s.Definitions {
+		return name
+	}
+	return ""
+}
+
+func TestCollectionMigration(t *testing.T) {
+	t.Parallel()
+	type person struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+	}
+	type person2 struct {
+		ID        string `json:"_id"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+	}
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&person{}, false),
+	})
+	checkErr(t, err)
+
+	// breaking changes are allowed while no instance violates them
+	_, err = d.UpdateCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&person2{}, false),
+	})
+	checkErr(t, err)
+	_, err = d.UpdateCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&person{}, false),
+	})
+	checkErr(t, err)
+
+	ids, err := c.CreateMany([][]byte{
+		util.JSONFromInstance(person{Name: "Ada Lovelace"}),
+		util.JSONFromInstance(person{Name: "Alan Turing"}),
+	})
+	checkErr(t, err)
+	if _, err = d.UpdateCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&person2{}, false),
+	}); !errors.Is(err, ErrIncompatibleSchema) {
+		t.Fatalf("expected incompatible schema error, got: %v", err)
+	}
+	migration := `
+		var names = instance.name.split(" ")
+		instance.firstName = names[0]
+		instance.lastName = names[1]
+		delete instance.name
+		return instance
+	`
+	c, err = d.UpdateCollection(CollectionConfig{
+		Name:      "Person",
+		Schema:    util.SchemaFromInstance(&person2{}, false),
+		Migration: migration,
+	})
+	checkErr(t, err)
+	if string(c.GetMigration()) != migration {
+		t.Fatal("expected migration to be set")
+	}
+
+	instance, err := c.FindByID(ids[0])
+	checkErr(t, err)
+	p := &person2{}
+	checkErr(t, json.Unmarshal(instance, p))
+	if p.FirstName != "Ada" || p.LastName != "Lovelace" {
+		t.Fatalf("expected upgraded instance, got: %s", instance)
+	}
+	// upgraded instances can be saved
+	p.LastName = "King"
+	checkErr(t, c.Save(util.JSONFromInstance(p)))
+
+	// compatible updates keep the migration
+	c, err = d.UpdateCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&person2{}, false),
+	})
+	checkErr(t, err)
+	instances, err := c.Find(nil)
+	checkErr(t, err)
+	if len(instances) != 2 {
+		t.Fatalf("expected 2 instances, got %d", len(instances))
+	}
+	for _, instance := range instances {
+		p := &person2{}
+		checkErr(t, json.Unmarshal(instance, p))
+		if p.FirstName == "" || p.LastName == "" {
+			t.Fatalf("expected upgraded instance, got: %s", instance)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package db
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/crypto"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/jsonpatcher"
 )
@@ -21,6 +25,25 @@ type NewOptions struct {
 	EventCodec  core.EventCodec
 	Token       thread.Token
 	Debug       bool
+	// BatchWindow is how long committed write transactions are coalesced
+	// into a single net record. Zero disables batching.
+	BatchWindow time.Duration
+	// BatchMaxActions flushes a batch early once it holds this many actions.
+	BatchMaxActions int
+	// EventStream receives change events of the db, if set.
+	EventStream *EventStream
+	// SortMemoryLimit is the size of query results, in bytes, sorted in memory
+	// before they are spilled to the datastore. Zero uses DefaultSortMemoryLimit.
+	SortMemoryLimit int
+	// AutoCreate makes a manager create dbs of threads tagged with DBThreadTag on demand.
+	AutoCreate bool
+	// HeadHints are the log heads of the thread of a db created from an address.
+	HeadHints net.HeadHints
+}
+
+// Validate returns an error if the options are invalid or conflict with each other.
+func (o *NewOptions) Validate() error {
+	return validateNewOptions(o.Name, o.Key, o.Collections)
 }
 
 // NewOption specifies a new db option.
@@ -90,6 +113,54 @@ func WithNewDebug(enable bool) NewOption {
 	}
 }
 
+// WithNewEventStream sends change events of the db to an event stream served to
+// other local processes. Dbs of a manager created with the option all share the stream.
+func WithNewEventStream(s *EventStream) NewOption {
+	return func(o *NewOptions) {
+		o.EventStream = s
+	}
+}
+
+// WithNewBatchWindow enables coalescing of write transactions committed within
+// window into a single net record. A batch is written early once it holds
+// maxActions actions, if maxActions is positive. Events of a batched transaction
+// are applied locally once the batch record is written, and the commit returns
+// after they are. Transactions of a failed batch don't change the db.
+func WithNewBatchWindow(window time.Duration, maxActions int) NewOption {
+	return func(o *NewOptions) {
+		o.BatchWindow = window
+		o.BatchMaxActions = maxActions
+	}
+}
+
+// WithNewSortMemoryLimit bounds the size of query results, in bytes, sorted in memory
+// when they're ordered by a field other than the ID. Larger results are sorted in
+// runs spilled to the datastore, which are merged once all results are read.
+func WithNewSortMemoryLimit(limit int) NewOption {
+	return func(o *NewOptions) {
+		o.SortMemoryLimit = limit
+	}
+}
+
+// WithNewAutoCreate makes a manager create dbs of threads tagged with DBThreadTag,
+// e.g. threads added by other processes or shared with the host, once they're first
+// accessed with GetDB or receive their first remote records, instead of requiring
+// NewDBFromAddr. Auto-created dbs use the manager's base collections. Records received
+// before auto-creation is enabled aren't applied to auto-created dbs.
+func WithNewAutoCreate(enable bool) NewOption {
+	return func(o *NewOptions) {
+		o.AutoCreate = enable
+	}
+}
+
+// WithNewHeadHints passes the log heads of Info.Heads to a db created from an address,
+// so its sync progress is known before the history is pulled. See net.WithHeadHints.
+func WithNewHeadHints(hints net.HeadHints) NewOption {
+	return func(o *NewOptions) {
+		o.HeadHints = hints
+	}
+}
+
 // Options defines options for interacting with a db.
 type Options struct {
 	Token thread.Token
@@ -107,7 +178,8 @@ func WithToken(t thread.Token) Option {
 
 // TxnOptions defines options for a transaction.
 type TxnOptions struct {
-	Token thread.Token
+	Token   thread.Token
+	Explain *Explain
 }
 
 // TxnOption specifies a transaction option.
@@ -120,6 +192,49 @@ func WithTxnToken(t thread.Token) TxnOption {
 	}
 }
 
+// WithExplain fills e with the execution details of queries run by the transaction,
+// e.g. to find out why a query is slow. Details of the last query are kept.
+func WithExplain(e *Explain) TxnOption {
+	return func(o *TxnOptions) {
+		o.Explain = e
+	}
+}
+
+// ImportOptions defines options for importing instances.
+type ImportOptions struct {
+	Token thread.Token
+	// BatchSize is the number of instances written with a single transaction.
+	BatchSize int
+	// Fields maps source fields, i.e. JSON keys or CSV columns, to instance field paths.
+	Fields map[string]string
+}
+
+// ImportOption specifies an import option.
+type ImportOption func(*ImportOptions)
+
+// WithImportToken provides authorization for writing imported instances.
+func WithImportToken(t thread.Token) ImportOption {
+	return func(o *ImportOptions) {
+		o.Token = t
+	}
+}
+
+// WithImportBatchSize sets the number of instances written with a single transaction.
+func WithImportBatchSize(size int) ImportOption {
+	return func(o *ImportOptions) {
+		o.BatchSize = size
+	}
+}
+
+// WithImportFields maps source fields, i.e. JSON keys or CSV columns, to instance
+// field paths, which may be dot separated paths of nested fields. Unmapped source
+// fields are imported as they are named.
+func WithImportFields(fields map[string]string) ImportOption {
+	return func(o *ImportOptions) {
+		o.Fields = fields
+	}
+}
+
 // NewManagedOptions defines options for creating a new managed db.
 type NewManagedOptions struct {
 	Name        string
@@ -128,6 +243,12 @@ type NewManagedOptions struct {
 	Token       thread.Token
 	Collections []CollectionConfig
 	Block       bool
+	HeadHints   net.HeadHints
+}
+
+// Validate returns an error if the options are invalid or conflict with each other.
+func (o *NewManagedOptions) Validate() error {
+	return validateNewOptions(o.Name, o.Key, o.Collections)
 }
 
 // NewManagedOption specifies a new managed db option.
@@ -182,6 +303,13 @@ func WithNewManagedBackfillBlock(block bool) NewManagedOption {
 	}
 }
 
+// WithNewManagedHeadHints passes the log heads of Info.Heads to a managed db created from an address.
+func WithNewManagedHeadHints(hints net.HeadHints) NewManagedOption {
+	return func(o *NewManagedOptions) {
+		o.HeadHints = hints
+	}
+}
+
 // ManagedOptions defines options for interacting with a managed db.
 type ManagedOptions struct {
 	Token thread.Token
@@ -196,3 +324,20 @@ func WithManagedToken(t thread.Token) ManagedOption {
 		o.Token = t
 	}
 }
+
+func validateNewOptions(name string, key thread.Key, cs []CollectionConfig) error {
+	if name != "" && !nameRx.MatchString(name) {
+		return ErrInvalidName
+	}
+	if key.Defined() && !key.CanRead() {
+		return ErrThreadReadKeyRequired
+	}
+	names := make(map[string]struct{}, len(cs))
+	for _, c := range cs {
+		if _, ok := names[c.Name]; ok {
+			return fmt.Errorf("%w: collection %s is specified more than once", ErrConflictingOptions, c.Name)
+		}
+		names[c.Name] = struct{}{}
+	}
+	return nil
+}
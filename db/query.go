@@ -4,9 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
@@ -23,6 +23,12 @@ type Query struct {
 	Limit int
 	Skip  int
 	Index string
+	// IDPrefix restricts results to instances with IDs starting with the prefix.
+	IDPrefix string
+	// IDStart and IDEnd restrict results to instances with IDs in [IDStart, IDEnd).
+	// An empty bound is open.
+	IDStart core.InstanceID
+	IDEnd   core.InstanceID
 }
 
 // Criterion represents a restriction on a field.
@@ -30,7 +36,9 @@ type Criterion struct {
 	FieldPath string
 	Operation Operation
 	Value     Value
-	query     *Query
+	// Values are the values of ContainsAny.
+	Values []Value
+	query  *Query
 }
 
 // Value models a single value in JSON.
@@ -55,6 +63,9 @@ func (q *Query) Validate() error {
 			return err
 		}
 	}
+	if q.IDStart != "" && q.IDEnd != "" && q.IDStart >= q.IDEnd {
+		return fmt.Errorf("id range start should be less than its end")
+	}
 	return nil
 }
 
@@ -63,14 +74,29 @@ func (c *Criterion) Validate() error {
 	if c == nil {
 		return nil
 	}
+	if c.Operation == ContainsAny {
+		if len(c.Values) == 0 {
+			return fmt.Errorf("values should describe at least one value")
+		}
+		for _, v := range c.Values {
+			if err := v.validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return c.Value.validate()
+}
+
+func (v Value) validate() error {
 	noNil := 0
-	if c.Value.Bool != nil {
+	if v.Bool != nil {
 		noNil++
 	}
-	if c.Value.String != nil {
+	if v.String != nil {
 		noNil++
 	}
-	if c.Value.Float != nil {
+	if v.Float != nil {
 		noNil++
 	}
 	if noNil != 1 {
@@ -101,6 +127,10 @@ const (
 	Ge = Operation(ge)
 	// Le is "less than or equal to"
 	Le = Operation(le)
+	// Contains is "array contains", values other than arrays are compared for equality
+	Contains = Operation(contains)
+	// ContainsAny is "array contains any of"
+	ContainsAny = Operation(containsAny)
 )
 
 var (
@@ -148,6 +178,16 @@ func OrderByIDDesc() *Query {
 	return q
 }
 
+// PrefixID restricts the query results to IDs starting with prefix.
+func PrefixID(prefix string) *Query {
+	return &Query{IDPrefix: prefix}
+}
+
+// RangeID restricts the query results to IDs in [start, end).
+func RangeID(start, end core.InstanceID) *Query {
+	return &Query{IDStart: start, IDEnd: end}
+}
+
 // And concatenates a new condition in an existing field.
 func (q *Query) And(field string) *Criterion {
 	return &Criterion{
@@ -208,6 +248,22 @@ func (q *Query) SeekID(id core.InstanceID) *Query {
 	return q
 }
 
+// PrefixID restricts the query results to IDs starting with prefix.
+// Instances are read from the range of keys with the prefix, so IDs with meaningful
+// prefixes, e.g. dates, partition instances without an index.
+func (q *Query) PrefixID(prefix string) *Query {
+	q.IDPrefix = prefix
+	return q
+}
+
+// RangeID restricts the query results to IDs in [start, end), either bound is open if empty.
+// Instances are read from the range of keys of the IDs.
+func (q *Query) RangeID(start, end core.InstanceID) *Query {
+	q.IDStart = start
+	q.IDEnd = end
+	return q
+}
+
 // LimitTo sets the maximum number of results.
 func (q *Query) LimitTo(limit int) *Query {
 	q.Limit = limit
@@ -252,6 +308,27 @@ func (c *Criterion) Le(value interface{}) *Query {
 	return c.createcriterion(Le, value)
 }
 
+// Contains is an array membership operator against a field.
+// Instances are read through an index of the field, if the query uses it.
+func (c *Criterion) Contains(value interface{}) *Query {
+	return c.createcriterion(Contains, value)
+}
+
+// ContainsAny is an array membership operator against a field, matching arrays
+// containing any of values.
+// Instances are read through an index of the field, if the query uses it.
+func (c *Criterion) ContainsAny(values ...interface{}) *Query {
+	c.Operation = ContainsAny
+	for _, v := range values {
+		c.Values = append(c.Values, createValue(v))
+	}
+	if c.query == nil {
+		c.query = &Query{}
+	}
+	c.query.Ands = append(c.query.Ands, c)
+	return c.query
+}
+
 func createValue(value interface{}) Value {
 	s, ok := value.(string)
 	if ok {
@@ -290,6 +367,34 @@ func (c *Criterion) createcriterion(op Operation, value interface{}) *Query {
 	return c.query
 }
 
+// Explain describes the execution of a query, see WithExplain.
+type Explain struct {
+	// Index is the path of the index used, empty if instances were scanned.
+	Index string
+	// Scanned is the number of entries read, index entries or instances if no index is used.
+	Scanned int
+	// Fetched is the number of instances read through the index.
+	Fetched int
+	// Matched is the number of scanned entries matching the query criteria.
+	Matched int
+	// Returned is the number of instances returned, after read filters, skip and limit.
+	Returned int
+	// SortedInMemory is set if results were sorted once read, which happens unless
+	// they are ordered by ID.
+	SortedInMemory bool
+	// Duration of the query.
+	Duration time.Duration
+}
+
+// Selectivity returns the share of scanned entries matching the query criteria.
+// Low selectivity of a scan suggests adding an index.
+func (e Explain) Selectivity() float64 {
+	if e.Scanned == 0 {
+		return 0
+	}
+	return float64(e.Matched) / float64(e.Scanned)
+}
+
 // Find queries for instances by Query.
 func (t *Txn) Find(q *Query) ([][]byte, error) {
 	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
@@ -306,6 +411,7 @@ func (t *Txn) Find(q *Query) ([][]byte, error) {
 		return nil, fmt.Errorf("error building internal query: %v", err)
 	}
 	defer txn.Discard()
+	start := time.Now()
 	iter := newIterator(txn, t.collection.baseKey(), q)
 	defer iter.Close()
 
@@ -313,65 +419,58 @@ func (t *Txn) Find(q *Query) ([][]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	var values []MarshaledResult
+	// Results sorted by a field other than the ID are skipped and limited once sorted
+	var sorter *sorter
+	if q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName {
+		sorter = newSorter(t.collection.db.datastore, q.Sort, t.collection.db.sortMemoryLimit)
+		defer sorter.close()
+	}
+	var res [][]byte
 	// Use count to track real count of returned values taking into account
 	// read filter and any indexes etc in the query
 	var count = 0
 	for {
-		res, ok := iter.NextSync()
+		r, ok := iter.NextSync()
 		if !ok {
 			break
 		}
-		res.Value, err = t.collection.filterRead(pk, res.Value)
+		r.Value, err = t.collection.filterRead(pk, r.Value)
 		if err != nil {
 			return nil, err
 		}
-		if res.Value != nil {
+		if r.Value != nil {
+			if sorter != nil {
+				if err := sorter.add(r); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			// Only count valid values that aren't filtered by the read filter
 			count++
 			if count > q.Skip {
-				values = append(values, res)
+				res = append(res, r.Value)
 			}
 		}
-		if len(values) == q.Limit {
+		if len(res) == q.Limit {
 			break
 		}
 	}
-
-	if q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName {
-		var wrongField, cantCompare bool
-		sort.Slice(values, func(i, j int) bool {
-			fieldI, err := traverseFieldPathMap(values[i].MarshaledValue, q.Sort.FieldPath)
-			if err != nil {
-				wrongField = true
-				return false
-			}
-			fieldJ, err := traverseFieldPathMap(values[j].MarshaledValue, q.Sort.FieldPath)
-			if err != nil {
-				wrongField = true
-				return false
-			}
-			res, err := compare(fieldI.Interface(), fieldJ.Interface())
-			if err != nil {
-				cantCompare = true
-				return false
-			}
-			if q.Sort.Desc {
-				res *= -1
-			}
-			return res < 0
-		})
-		if wrongField {
-			return nil, ErrInvalidSortingField
-		}
-		if cantCompare {
-			panic("can't compare while sorting")
+	if sorter != nil {
+		if res, err = sorter.results(q.Skip, q.Limit); err != nil {
+			return nil, err
 		}
 	}
 
-	res := make([][]byte, len(values))
-	for i := range values {
-		res[i] = values[i].Value
+	if t.explain != nil {
+		*t.explain = Explain{
+			Index:          q.Index,
+			Scanned:        iter.scanned,
+			Fetched:        iter.fetched,
+			Matched:        iter.matched,
+			Returned:       len(res),
+			SortedInMemory: q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName,
+			Duration:       time.Since(start),
+		}
 	}
 
 	return res, nil
@@ -451,6 +550,17 @@ func compareValue(value interface{}, critVal Value) (int, error) {
 
 func (c *Criterion) match(value reflect.Value) (bool, error) {
 	valueInterface := value.Interface()
+	switch c.Operation {
+	case Contains:
+		return containsValue(valueInterface, c.Value), nil
+	case ContainsAny:
+		for _, v := range c.Values {
+			if containsValue(valueInterface, v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
 	result, err := compareValue(valueInterface, c.Value)
 	if err != nil {
 		return false, err
@@ -474,6 +584,83 @@ func (c *Criterion) match(value reflect.Value) (bool, error) {
 
 }
 
+// containsValue returns whether an array contains a value. Other values are compared
+// for equality, which matches array items while scanning an index of the array.
+func containsValue(value interface{}, critVal Value) bool {
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	for _, item := range items {
+		if res, err := compareValue(item, critVal); err == nil && res == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// idBounds returns the IDs matching the ID prefix and range of the query, as a lower
+// bound and an exclusive upper bound. Empty bounds are open.
+func (q *Query) idBounds() (lower, upper string) {
+	lower, upper = string(q.IDStart), string(q.IDEnd)
+	if q.IDPrefix != "" {
+		if q.IDPrefix > lower {
+			lower = q.IDPrefix
+		}
+		if end := prefixEnd(q.IDPrefix); end != "" && (upper == "" || end < upper) {
+			upper = end
+		}
+	}
+	return lower, upper
+}
+
+// matchID returns whether id matches the ID prefix and range of the query.
+func (q *Query) matchID(id string) bool {
+	lower, upper := q.idBounds()
+	return (lower == "" || id >= lower) && (upper == "" || id < upper)
+}
+
+// prefixEnd returns the smallest string greater than all strings starting with prefix,
+// empty if there's none.
+func prefixEnd(prefix string) string {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1])
+		}
+	}
+	return ""
+}
+
+// indexLookups returns the values of index entries matching a query which only has a
+// criterion on the index field, so entries can be read without scanning the index.
+// Floats are formatted differently by instances, so they're scanned.
+func (q *Query) indexLookups() ([]Value, bool) {
+	if q.Index == "" || q.Seek != "" || len(q.Ors) != 0 || len(q.Ands) != 1 {
+		return nil, false
+	}
+	c := q.Ands[0]
+	if c.FieldPath != q.Index {
+		return nil, false
+	}
+	var values []Value
+	switch c.Operation {
+	case Eq, Contains:
+		values = []Value{c.Value}
+	case ContainsAny:
+		values = c.Values
+	default:
+		return nil, false
+	}
+	for _, v := range values {
+		if v.Float != nil {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
 func traverseFieldPathMap(value map[string]interface{}, fieldPath string) (reflect.Value, error) {
 	fields := strings.Split(fieldPath, ".")
 
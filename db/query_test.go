@@ -3,9 +3,11 @@ package db
 import (
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/ipfs/go-datastore/query"
 	logging "github.com/ipfs/go-log"
 	"github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/util"
@@ -330,3 +332,187 @@ func createCollectionWithJSONData(t *testing.T) (*Collection, []Book, func()) {
 	}
 	return c, dataCopy, clean
 }
+
+func TestQueryExplain(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Dummies",
+		Schema:  util.SchemaFromInstance(&dummy{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	for i := 0; i < 10; i++ {
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: "name" + strconv.Itoa(i%5), Counter: i}))
+		checkErr(t, err)
+	}
+
+	var e Explain
+	_, err = c.Find(Where("Name").Eq("name1").OrderBy("Counter"), WithExplain(&e))
+	checkErr(t, err)
+	if e.Index != "" || e.Scanned != 10 || e.Matched != 2 || e.Returned != 2 || !e.SortedInMemory {
+		t.Fatalf("unexpected explain of a scan: %+v", e)
+	}
+	if s := e.Selectivity(); s != 0.2 {
+		t.Fatalf("expected selectivity of 0.2, got %v", s)
+	}
+
+	_, err = c.Find(Where("Name").Eq("name1").UseIndex("Name"), WithExplain(&e))
+	checkErr(t, err)
+	if e.Index != "Name" || e.Scanned != 1 || e.Matched != 1 || e.Fetched != 2 || e.Returned != 2 || e.SortedInMemory {
+		t.Fatalf("unexpected explain of an index lookup: %+v", e)
+	}
+
+	_, err = c.Find(Where("Name").Ge("name3").UseIndex("Name"), WithExplain(&e))
+	checkErr(t, err)
+	if e.Index != "Name" || e.Scanned != 5 || e.Matched != 2 || e.Fetched != 4 || e.Returned != 4 || e.SortedInMemory {
+		t.Fatalf("unexpected explain of an index scan: %+v", e)
+	}
+}
+
+func TestQueryContains(t *testing.T) {
+	t.Parallel()
+	type post struct {
+		ID   string   `json:"_id"`
+		Tags []string `json:"tags"`
+	}
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Posts",
+		Schema:  util.SchemaFromInstance(&post{}, false),
+		Indexes: []Index{{Path: "tags"}},
+	})
+	checkErr(t, err)
+	ids, err := c.CreateMany([][]byte{
+		util.JSONFromInstance(post{Tags: []string{"go", "db"}}),
+		util.JSONFromInstance(post{Tags: []string{"go", "go", "p2p"}}),
+		util.JSONFromInstance(post{Tags: []string{"js"}}),
+		util.JSONFromInstance(post{Tags: []string{}}),
+	})
+	checkErr(t, err)
+
+	assertPosts := func(q *Query, expected ...int) {
+		var e Explain
+		res, err := c.Find(q, WithExplain(&e))
+		checkErr(t, err)
+		var got []string
+		for _, r := range res {
+			var p post
+			util.InstanceFromJSON(r, &p)
+			got = append(got, p.ID)
+		}
+		var want []string
+		for _, i := range expected {
+			want = append(want, ids[i].String())
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected posts %v, got %v (explain %+v)", want, got, e)
+		}
+	}
+	assertPosts(Where("tags").Contains("go"), 0, 1)
+	assertPosts(Where("tags").Contains("go").UseIndex("tags"), 0, 1)
+	assertPosts(Where("tags").ContainsAny("db", "p2p", "rust"), 0, 1)
+	assertPosts(Where("tags").ContainsAny("go", "js").UseIndex("tags"), 0, 1, 2)
+	assertPosts(Where("tags").Ne("go").UseIndex("tags"), 0, 1, 2)
+
+	// index entries are updated with items
+	var p post
+	instance, err := c.FindByID(ids[2])
+	checkErr(t, err)
+	util.InstanceFromJSON(instance, &p)
+	p.Tags = []string{"go"}
+	checkErr(t, c.Save(util.JSONFromInstance(p)))
+	assertPosts(Where("tags").Contains("go").UseIndex("tags"), 0, 1, 2)
+	assertPosts(Where("tags").Contains("js").UseIndex("tags"))
+	checkErr(t, c.Delete(ids[0]))
+	assertPosts(Where("tags").ContainsAny("go", "db").UseIndex("tags"), 1, 2)
+}
+
+func TestQuerySortSpill(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t, WithNewSortMemoryLimit(100))
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Dummies",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+	for i := 0; i < 30; i++ {
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: "name", Counter: i * 7 % 30}))
+		checkErr(t, err)
+	}
+
+	res, err := c.Find(OrderByDesc("Counter").SkipNum(3).LimitTo(10))
+	checkErr(t, err)
+	if len(res) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(res))
+	}
+	for i, r := range res {
+		var v dummy
+		util.InstanceFromJSON(r, &v)
+		if v.Counter != 26-i {
+			t.Fatalf("expected counter %d at %d, got %d", 26-i, i, v.Counter)
+		}
+	}
+
+	spilled, err := d.datastore.Query(query.Query{Prefix: dsSortPrefix.String(), KeysOnly: true})
+	checkErr(t, err)
+	entries, err := spilled.Rest()
+	checkErr(t, err)
+	if len(entries) != 0 {
+		t.Fatalf("expected spilled results to be deleted, got %d", len(entries))
+	}
+}
+
+func TestQueryIDRange(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Dummies",
+		Schema:  util.SchemaFromInstance(&dummy{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	ids := []string{
+		"2024-08-30-a", "2024-08-31-a", "2024-09-01-a", "2024-09-01-b",
+		"2024-09-15-a", "2024-09-30-a", "2024-10-01-a",
+	}
+	for _, id := range ids {
+		_, err := c.Create(util.JSONFromInstance(dummy{ID: db.InstanceID(id), Name: "name"}))
+		checkErr(t, err)
+	}
+
+	assertIDs := func(q *Query, scanned int, expected ...string) {
+		var e Explain
+		res, err := c.Find(q, WithExplain(&e))
+		checkErr(t, err)
+		var got []string
+		for _, r := range res {
+			var v dummy
+			util.InstanceFromJSON(r, &v)
+			got = append(got, v.ID.String())
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected ids %v, got %v", expected, got)
+		}
+		if scanned >= 0 && e.Scanned != scanned {
+			t.Fatalf("expected %d scanned instances, got %d", scanned, e.Scanned)
+		}
+	}
+	assertIDs(PrefixID("2024-09-"), 4, "2024-09-01-a", "2024-09-01-b", "2024-09-15-a", "2024-09-30-a")
+	assertIDs(PrefixID("2024-09-").OrderByIDDesc(), 4, "2024-09-30-a", "2024-09-15-a", "2024-09-01-b", "2024-09-01-a")
+	assertIDs(RangeID("2024-08-31", "2024-09-15"), 3, "2024-08-31-a", "2024-09-01-a", "2024-09-01-b")
+	assertIDs(RangeID("2024-09-15", "").OrderByIDDesc(), 3, "2024-10-01-a", "2024-09-30-a", "2024-09-15-a")
+	assertIDs(PrefixID("2024-09-").RangeID("", "2024-09-02").And("Name").Eq("name"), 2, "2024-09-01-a", "2024-09-01-b")
+	assertIDs(PrefixID("2024-09-30").UseIndex("Name").And("Name").Eq("name"), -1, "2024-09-30-a")
+	assertIDs(PrefixID("2025-"), 0)
+
+	if _, err := c.Find(RangeID("b", "a")); err == nil {
+		t.Fatal("expected invalid range error")
+	}
+}
@@ -0,0 +1,327 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+var (
+	// ErrInvalidResumeToken indicates a resume token wasn't issued by the db.
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+
+	// ResumableListenerBuffer is the number of actions buffered for a resumable listener.
+	// Listeners falling behind by more actions are closed, they can resume from the
+	// token of the last action received.
+	ResumableListenerBuffer = 256
+
+	// ActionLogRetention is the number of latest actions kept to resume listeners from.
+	// Tokens of older actions are invalid.
+	ActionLogRetention uint64 = 100000
+
+	dsActionLog = dsPrefix.ChildString("actionlog")
+)
+
+// ResumeToken is an opaque position in the stream of db actions.
+type ResumeToken string
+
+// ListenEvent is an action received by a resumable listener, along with the
+// token resuming the stream after it.
+type ListenEvent struct {
+	Action
+	Token ResumeToken
+}
+
+// ResumableListener notifies about actions from a position in the stream of db actions.
+type ResumableListener interface {
+	// Channel returns a channel receiving actions. It's closed once the listener
+	// is closed or falls behind, see ResumableListenerBuffer.
+	Channel() <-chan ListenEvent
+	Close()
+}
+
+// ListenFrom returns a listener which notifies about actions applying the defined
+// filters, starting after the action of token. Unlike Listen, each action comes
+// with a resume token, so a listener reconnecting with the token of the last action
+// it received doesn't miss any action. An empty token starts from now, tokens of
+// actions beyond ActionLogRetention are invalid.
+func (d *DB) ListenFrom(token ResumeToken, los ...ListenOption) (ResumableListener, error) {
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	if d.closed {
+		return nil, fmt.Errorf("can't listen on closed DB")
+	}
+
+	d.actionLog.lock.Lock()
+	defer d.actionLog.lock.Unlock()
+	head := d.actionLog.seq
+	from := head
+	if token != "" {
+		var err error
+		if from, err = parseResumeToken(token); err != nil || from > head || from+1 < d.actionLog.first {
+			return nil, ErrInvalidResumeToken
+		}
+	}
+	l := &resumableListener{
+		scn:     d.stateChangedNotifee,
+		filter:  &listener{filters: los},
+		live:    make(chan ListenEvent, ResumableListenerBuffer),
+		c:       make(chan ListenEvent),
+		closing: make(chan struct{}),
+	}
+	d.stateChangedNotifee.addResumable(l)
+	go l.run(d.actionLog, from, head)
+	return l, nil
+}
+
+// actionLog persists applied actions, so listeners can resume from a position.
+type actionLog struct {
+	store kt.TxnDatastoreExtended
+	// lock serializes appending and notifying of actions
+	lock sync.Mutex
+	seq  uint64
+	// first is the oldest action listeners can resume from
+	first uint64
+}
+
+func newActionLog(store kt.TxnDatastoreExtended) (*actionLog, error) {
+	results, err := store.Query(query.Query{
+		Prefix:   dsActionLog.String(),
+		Orders:   []query.Order{query.OrderByKeyDescending{}},
+		Limit:    1,
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	l := &actionLog{store: store}
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if l.seq, err = strconv.ParseUint(ds.RawKey(res.Key).Name(), 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	l.first = retainedFrom(l.seq)
+	if err := l.prune(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// retainedFrom returns the oldest action retained along with the action of seq.
+func retainedFrom(seq uint64) uint64 {
+	if seq < ActionLogRetention {
+		return 1
+	}
+	return seq - ActionLogRetention + 1
+}
+
+// prune removes the actions older than the retained ones, e.g. after the retention was lowered.
+func (l *actionLog) prune() error {
+	results, err := l.store.Query(query.Query{
+		Prefix:   dsActionLog.String(),
+		Orders:   []query.Order{query.OrderByKey{}},
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+	first := actionKey(l.first).String()
+	for res := range results.Next() {
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.Key >= first {
+			break
+		}
+		if err := l.store.Delete(ds.RawKey(res.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func actionKey(seq uint64) ds.Key {
+	return dsActionLog.ChildString(fmt.Sprintf("%020d", seq))
+}
+
+// append persists actions and returns them with their resume tokens.
+// The caller must hold the log lock.
+func (l *actionLog) append(actions []Action) ([]ListenEvent, error) {
+	txn, err := l.store.NewTransaction(false)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard()
+	events, err := l.put(txn, actions)
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.Commit(); err != nil {
+		return nil, err
+	}
+	l.advance(events)
+	return events, nil
+}
+
+// put writes actions within txn, along with the removal of the actions falling out of
+// the retention, and returns them with their resume tokens. The log is advanced once
+// txn is committed, see advance. The caller must hold the log lock.
+func (l *actionLog) put(txn ds.Txn, actions []Action) ([]ListenEvent, error) {
+	events := make([]ListenEvent, len(actions))
+	seq := l.seq
+	for i, a := range actions {
+		seq++
+		v, err := json.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+		if err := txn.Put(actionKey(seq), v); err != nil {
+			return nil, err
+		}
+		if seq > ActionLogRetention {
+			if err := txn.Delete(actionKey(seq - ActionLogRetention)); err != nil {
+				return nil, err
+			}
+		}
+		events[i] = ListenEvent{Action: a, Token: formatResumeToken(seq)}
+	}
+	return events, nil
+}
+
+// advance moves the log past committed actions.
+func (l *actionLog) advance(events []ListenEvent) {
+	l.seq += uint64(len(events))
+	if first := retainedFrom(l.seq); first > l.first {
+		l.first = first
+	}
+}
+
+// skip invalidates the tokens issued so far, since actions which couldn't be logged
+// follow them. The caller must hold the log lock.
+func (l *actionLog) skip() {
+	l.first = l.seq + 2
+}
+
+func formatResumeToken(seq uint64) ResumeToken {
+	return ResumeToken(strconv.FormatUint(seq, 36))
+}
+
+func parseResumeToken(t ResumeToken) (uint64, error) {
+	return strconv.ParseUint(string(t), 36, 64)
+}
+
+type resumableListener struct {
+	scn    *stateChangedNotifee
+	filter *listener
+	// live receives actions notified once the listener is registered
+	live chan ListenEvent
+	c    chan ListenEvent
+
+	once    sync.Once
+	closing chan struct{}
+}
+
+var _ ResumableListener = (*resumableListener)(nil)
+
+// run replays logged actions in (from, head], then forwards live actions.
+func (l *resumableListener) run(al *actionLog, from, head uint64) {
+	defer close(l.c)
+	if from < head {
+		txn, err := al.store.NewTransactionExtended(true)
+		if err != nil {
+			l.fail(err)
+			return
+		}
+		defer txn.Discard()
+		results, err := txn.QueryExtended(dse.QueryExt{
+			Query: query.Query{
+				Prefix: dsActionLog.String(),
+				Orders: []query.Order{query.OrderByKey{}},
+			},
+			SeekPrefix: actionKey(from + 1).String(),
+		})
+		if err != nil {
+			l.fail(err)
+			return
+		}
+		defer results.Close()
+		end := actionKey(head).String()
+		for res := range results.Next() {
+			if res.Error != nil {
+				l.fail(res.Error)
+				return
+			}
+			if res.Key > end {
+				break
+			}
+			var e ListenEvent
+			if err := json.Unmarshal(res.Value, &e.Action); err != nil {
+				l.fail(err)
+				return
+			}
+			seq, _ := strconv.ParseUint(ds.RawKey(res.Key).Name(), 10, 64)
+			e.Token = formatResumeToken(seq)
+			if !l.send(e) {
+				return
+			}
+		}
+	}
+	for e := range l.live {
+		if !l.send(e) {
+			return
+		}
+	}
+}
+
+func (l *resumableListener) send(e ListenEvent) bool {
+	if !l.filter.evaluate(e.Action) {
+		return true
+	}
+	select {
+	case l.c <- e:
+		return true
+	case <-l.closing:
+		return false
+	}
+}
+
+func (l *resumableListener) fail(err error) {
+	log.Errorf("replaying actions failed: %v", err)
+	l.scn.removeResumable(l)
+}
+
+// notify queues a live action, it returns false if the listener is full.
+func (l *resumableListener) notify(e ListenEvent) bool {
+	select {
+	case l.live <- e:
+		return true
+	default:
+		log.Warnf("closing resumable listener with filters %v falling behind", l.filter.filters)
+		return false
+	}
+}
+
+// Channel returns a channel to receive db change notifications.
+func (l *resumableListener) Channel() <-chan ListenEvent {
+	return l.c
+}
+
+// Close stops notifications, the channel is closed once pending ones are discarded.
+func (l *resumableListener) Close() {
+	l.once.Do(func() {
+		close(l.closing)
+	})
+	l.scn.removeResumable(l)
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestListenFrom(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Dog",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+
+	receive := func(l ResumableListener, n int) []ListenEvent {
+		var events []ListenEvent
+		for len(events) < n {
+			select {
+			case e, ok := <-l.Channel():
+				if !ok {
+					t.Fatal("listener was closed")
+				}
+				events = append(events, e)
+			case <-time.After(time.Second * 5):
+				t.Fatalf("expected %d events, got %d", n, len(events))
+			}
+		}
+		return events
+	}
+
+	l, err := d.ListenFrom("")
+	checkErr(t, err)
+	var ids []core.InstanceID
+	for i := 0; i < 3; i++ {
+		id, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		ids = append(ids, id)
+	}
+	events := receive(l, 3)
+	for i, e := range events {
+		if e.ID != ids[i] || e.Type != ActionCreate || e.Token == "" {
+			t.Fatalf("unexpected event: %v", e)
+		}
+	}
+	l.Close()
+
+	// resume after the second action, missing the third and later ones
+	for i := 0; i < 2; i++ {
+		id, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		ids = append(ids, id)
+	}
+	l, err = d.ListenFrom(events[1].Token, ListenOption{Collection: "Dog"})
+	checkErr(t, err)
+	defer l.Close()
+	id, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+	checkErr(t, err)
+	ids = append(ids, id)
+	resumed := receive(l, 4)
+	for i, e := range resumed {
+		if e.ID != ids[i+2] {
+			t.Fatalf("expected action on %s, got %v", ids[i+2], e)
+		}
+	}
+	if resumed[0].Token != events[2].Token {
+		t.Fatalf("expected token %s, got %s", events[2].Token, resumed[0].Token)
+	}
+
+	if _, err := d.ListenFrom("invalid-token"); !errors.Is(err, ErrInvalidResumeToken) {
+		t.Fatalf("expected invalid resume token error, got: %v", err)
+	}
+}
+
+func TestListenFrom_Retention(t *testing.T) {
+	defer func(retention uint64) { ActionLogRetention = retention }(ActionLogRetention)
+	ActionLogRetention = 3
+
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Dog",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	})
+	checkErr(t, err)
+	l, err := d.ListenFrom("")
+	checkErr(t, err)
+	var events []ListenEvent
+	for i := 0; i < 5; i++ {
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		select {
+		case e := <-l.Channel():
+			events = append(events, e)
+		case <-time.After(time.Second * 5):
+			t.Fatal("expected an action")
+		}
+	}
+	l.Close()
+
+	// only the latest actions are kept
+	if _, err := d.ListenFrom(events[0].Token); !errors.Is(err, ErrInvalidResumeToken) {
+		t.Fatalf("expected token of a dropped action to be invalid, got: %v", err)
+	}
+	l, err = d.ListenFrom(events[1].Token)
+	checkErr(t, err)
+	defer l.Close()
+	for _, expected := range events[2:] {
+		select {
+		case e := <-l.Channel():
+			if e.Token != expected.Token {
+				t.Fatalf("expected token %s, got %s", expected.Token, e.Token)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("expected a replayed action")
+		}
+	}
+}
+
+func TestActionLog_Prune(t *testing.T) {
+	defer func(retention uint64) { ActionLogRetention = retention }(ActionLogRetention)
+	ActionLogRetention = 10
+
+	store := NewTxMapDatastore()
+	l, err := newActionLog(store)
+	checkErr(t, err)
+	actions := make([]Action, 6)
+	for i := range actions {
+		actions[i] = Action{Collection: "Dog", Type: ActionCreate, ID: core.NewInstanceID()}
+	}
+	_, err = l.append(actions)
+	checkErr(t, err)
+
+	// lowered retentions apply once the log is loaded again
+	ActionLogRetention = 2
+	l, err = newActionLog(store)
+	checkErr(t, err)
+	if l.seq != 6 || l.first != 5 {
+		t.Fatalf("expected actions from 5 to 6, got %d to %d", l.first, l.seq)
+	}
+	for seq := uint64(1); seq <= l.seq; seq++ {
+		if has, err := store.Has(actionKey(seq)); err != nil {
+			t.Fatal(err)
+		} else if has != (seq >= l.first) {
+			t.Fatalf("expected action %d to be kept: %v", seq, seq >= l.first)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package db
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+// DefaultSortMemoryLimit is the default size of query results, in bytes, sorted in
+// memory before they are spilled to the datastore, see WithNewSortMemoryLimit.
+var DefaultSortMemoryLimit = 32 << 20
+
+// spillTxnSize is the size of spilled results written with a single transaction.
+const spillTxnSize = 1 << 20
+
+// dsSortPrefix holds results of running sorts spilled to the datastore.
+var dsSortPrefix = dsPrefix.ChildString("sort")
+
+type sortEntry struct {
+	Field interface{} `json:"f"`
+	Value []byte      `json:"v"`
+}
+
+// sorter orders query results by a field with an external merge sort. Results are
+// buffered until they exceed the memory limit, then the buffer is sorted and written
+// to the datastore as a run. Once all results are added, runs are merged.
+type sorter struct {
+	store    kt.TxnDatastoreExtended
+	prefix   ds.Key
+	sort     Sort
+	memLimit int
+
+	buf  []sortEntry
+	size int
+	runs int
+}
+
+func newSorter(store kt.TxnDatastoreExtended, s Sort, memLimit int) *sorter {
+	if memLimit <= 0 {
+		memLimit = DefaultSortMemoryLimit
+	}
+	return &sorter{
+		store:    store,
+		prefix:   dsSortPrefix.Child(ds.RandomKey()),
+		sort:     s,
+		memLimit: memLimit,
+	}
+}
+
+// add buffers a result, spilling the buffer if it's over the memory limit.
+func (s *sorter) add(r MarshaledResult) error {
+	field, err := traverseFieldPathMap(r.MarshaledValue, s.sort.FieldPath)
+	if err != nil {
+		return ErrInvalidSortingField
+	}
+	s.buf = append(s.buf, sortEntry{Field: field.Interface(), Value: r.Value})
+	s.size += len(r.Value)
+	if s.size > s.memLimit {
+		return s.spill()
+	}
+	return nil
+}
+
+func (s *sorter) less(a, b sortEntry) (bool, error) {
+	res, err := compare(a.Field, b.Field)
+	if err != nil {
+		return false, fmt.Errorf("can't compare sorting field %s: %v", s.sort.FieldPath, err)
+	}
+	if s.sort.Desc {
+		res *= -1
+	}
+	return res < 0, nil
+}
+
+func (s *sorter) sortBuffer() (err error) {
+	sort.SliceStable(s.buf, func(i, j int) bool {
+		less, lerr := s.less(s.buf[i], s.buf[j])
+		if lerr != nil && err == nil {
+			err = lerr
+		}
+		return less
+	})
+	return err
+}
+
+func (s *sorter) runKey(run int) ds.Key {
+	return s.prefix.ChildString(fmt.Sprintf("%d", run))
+}
+
+// spill writes the sorted buffer to the datastore as a new run.
+func (s *sorter) spill() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	if err := s.sortBuffer(); err != nil {
+		return err
+	}
+	key := s.runKey(s.runs)
+	var (
+		txn  ds.Txn
+		size int
+		err  error
+	)
+	for i, e := range s.buf {
+		if txn == nil {
+			if txn, err = s.store.NewTransaction(false); err != nil {
+				return err
+			}
+		}
+		v, err := json.Marshal(e)
+		if err != nil {
+			txn.Discard()
+			return err
+		}
+		if err := txn.Put(key.ChildString(fmt.Sprintf("%012d", i)), v); err != nil {
+			txn.Discard()
+			return err
+		}
+		if size += len(v); size > spillTxnSize {
+			if err := txn.Commit(); err != nil {
+				return err
+			}
+			txn, size = nil, 0
+		}
+	}
+	if txn != nil {
+		if err := txn.Commit(); err != nil {
+			return err
+		}
+	}
+	s.runs++
+	s.buf, s.size = nil, 0
+	return nil
+}
+
+// results returns sorted results, after skipping skip results and up to limit results.
+// Limit is ignored if it's not positive.
+func (s *sorter) results(skip, limit int) ([][]byte, error) {
+	var res [][]byte
+	collect := func(e sortEntry) bool {
+		if skip > 0 {
+			skip--
+			return true
+		}
+		res = append(res, e.Value)
+		return limit <= 0 || len(res) < limit
+	}
+
+	if s.runs == 0 {
+		if err := s.sortBuffer(); err != nil {
+			return nil, err
+		}
+		for _, e := range s.buf {
+			if !collect(e) {
+				break
+			}
+		}
+		return res, nil
+	}
+
+	if err := s.spill(); err != nil {
+		return nil, err
+	}
+	h := &runHeap{s: s}
+	defer h.close()
+	for i := 0; i < s.runs; i++ {
+		r, err := s.store.Query(query.Query{
+			Prefix: s.runKey(i).String(),
+			Orders: []query.Order{query.OrderByKey{}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		h.runs = append(h.runs, &run{index: i, results: r})
+		if err := h.push(h.runs[i]); err != nil {
+			return nil, err
+		}
+	}
+	for h.Len() > 0 {
+		r := h.heads[0]
+		if !collect(r.head) {
+			break
+		}
+		heap.Pop(h)
+		if err := h.push(r); err != nil {
+			return nil, err
+		}
+	}
+	if h.err != nil {
+		return nil, h.err
+	}
+	return res, nil
+}
+
+// close deletes spilled runs.
+func (s *sorter) close() {
+	if s.runs == 0 {
+		return
+	}
+	if err := clearSortSpills(s.store, s.prefix); err != nil {
+		log.Errorf("error deleting spilled sort results: %v", err)
+	}
+}
+
+// clearSortSpills deletes results spilled under prefix, including those of
+// sorts interrupted by a crash.
+func clearSortSpills(store ds.Datastore, prefix ds.Key) error {
+	res, err := store.Query(query.Query{Prefix: prefix.String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if err := store.Delete(ds.NewKey(r.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type run struct {
+	index   int
+	results query.Results
+	head    sortEntry
+}
+
+// runHeap merges spilled runs by their smallest results, ties are ordered by run.
+type runHeap struct {
+	s     *sorter
+	runs  []*run
+	heads []*run
+	err   error
+}
+
+// push reads the next result of r and adds r to the heap, unless it's exhausted.
+func (h *runHeap) push(r *run) error {
+	res, ok := r.results.NextSync()
+	if !ok {
+		return nil
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	r.head = sortEntry{}
+	if err := json.Unmarshal(res.Value, &r.head); err != nil {
+		return err
+	}
+	heap.Push(h, r)
+	return h.err
+}
+
+func (h *runHeap) close() {
+	for _, r := range h.runs {
+		_ = r.results.Close()
+	}
+}
+
+func (h *runHeap) Len() int { return len(h.heads) }
+
+func (h *runHeap) Less(i, j int) bool {
+	a, b := h.heads[i], h.heads[j]
+	less, err := h.s.less(a.head, b.head)
+	if err != nil {
+		h.err = err
+		return false
+	}
+	if less {
+		return true
+	}
+	if more, _ := h.s.less(b.head, a.head); more {
+		return false
+	}
+	return a.index < b.index
+}
+
+func (h *runHeap) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *runHeap) Push(x interface{}) { h.heads = append(h.heads, x.(*run)) }
+
+func (h *runHeap) Pop() interface{} {
+	old := h.heads
+	r := old[len(old)-1]
+	h.heads = old[:len(old)-1]
+	return r
+}
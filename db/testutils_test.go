@@ -20,13 +20,17 @@ func checkErr(t *testing.T, err error) {
 }
 
 func createTestDB(t *testing.T, opts ...NewOption) (*DB, func()) {
+	return createTestDBWithNetOptions(t, nil, opts...)
+}
+
+func createTestDBWithNetOptions(t *testing.T, netOpts []common.NetOption, opts ...NewOption) (*DB, func()) {
 	dir, err := ioutil.TempDir("", "")
 	checkErr(t, err)
-	n, err := common.DefaultNetwork(
+	n, err := common.DefaultNetwork(append([]common.NetOption{
 		common.WithNetBadgerPersistence(dir),
 		common.WithNetHostAddr(util.FreeLocalAddr()),
 		common.WithNetDebug(true),
-	)
+	}, netOpts...)...)
 	checkErr(t, err)
 	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
 	checkErr(t, err)
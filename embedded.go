@@ -0,0 +1,162 @@
+// Package threads wires up a complete thread node, for apps embedding threads instead of talking to threadsd.
+package threads
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/common"
+	"github.com/textileio/go-threads/core/app"
+	"github.com/textileio/go-threads/datastore"
+	"github.com/textileio/go-threads/db"
+	kt "github.com/textileio/go-threads/db/keytransform"
+	"github.com/textileio/go-threads/util"
+)
+
+// DefaultRepoPath is the repo of embedded nodes which don't set one.
+const DefaultRepoPath = ".threads"
+
+// ErrClosed indicates that the embedded node is closed.
+var ErrClosed = errors.New("embedded node is closed")
+
+// EmbeddedConfig is the config of an embedded node. The zero value is a node persisted
+// under DefaultRepoPath, listening on a random port and bootstrapping from util.DefaultBoostrapPeers.
+type EmbeddedConfig struct {
+	// RepoPath is the directory of the host key and the datastores.
+	RepoPath string
+	// Backend is the datastore backend, defaults to datastore.BackendBadger.
+	Backend datastore.Backend
+	// LowMem uses the low memory settings of the datastore backend.
+	LowMem bool
+	// HostAddr is the address the libp2p host listens on, defaults to /ip4/0.0.0.0/tcp/0.
+	HostAddr ma.Multiaddr
+	// Bootstrap are the peers connected to on Start, defaults to util.DefaultBoostrapPeers.
+	// Set an empty, non-nil slice to skip bootstrapping.
+	Bootstrap []peer.AddrInfo
+	// NetOptions are applied after the options derived from the fields above.
+	NetOptions []common.NetOption
+	// DBOptions are the base options of the dbs of the manager.
+	DBOptions []db.NewOption
+	Debug     bool
+}
+
+// Embedded is a thread node made of a libp2p host, the network and a db manager.
+// Its components are created by NewEmbedded, and connected to peers by Start.
+type Embedded struct {
+	net     common.NetBoostrapper
+	store   kt.TxnDatastoreExtended
+	manager *db.Manager
+	boot    []peer.AddrInfo
+
+	lock    sync.Mutex
+	started bool
+	closed  bool
+	fin     *util.Finalizer
+}
+
+// NewEmbedded creates the host, datastores, logstore, network and db manager of a node.
+// The node must be closed once no longer used, which closes all of them.
+func NewEmbedded(cfg EmbeddedConfig) (*Embedded, error) {
+	if len(cfg.RepoPath) == 0 {
+		cfg.RepoPath = DefaultRepoPath
+	}
+	if len(cfg.Backend) == 0 {
+		cfg.Backend = datastore.BackendBadger
+	}
+	if cfg.Bootstrap == nil {
+		cfg.Bootstrap = util.DefaultBoostrapPeers()
+	}
+
+	fin := util.NewFinalizer()
+	opts := []common.NetOption{
+		common.WithNetBadgerPersistence(cfg.RepoPath),
+		common.WithNetDatastoreBackend(cfg.Backend),
+		common.WithNetDebug(cfg.Debug),
+	}
+	if cfg.HostAddr != nil {
+		opts = append(opts, common.WithNetHostAddr(cfg.HostAddr))
+	}
+	n, err := common.DefaultNetwork(append(opts, cfg.NetOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("creating network: %w", err)
+	}
+	fin.Add(n)
+
+	store, err := datastore.New(
+		cfg.Backend,
+		datastore.Path(cfg.RepoPath, "eventstore", cfg.Backend),
+		datastore.WithLowMem(cfg.LowMem),
+	)
+	if err != nil {
+		return nil, fin.Cleanup(fmt.Errorf("creating eventstore: %w", err))
+	}
+	fin.Add(store)
+
+	dbOpts := append([]db.NewOption{db.WithNewDebug(cfg.Debug)}, cfg.DBOptions...)
+	manager, err := db.NewManager(store, n, dbOpts...)
+	if err != nil {
+		return nil, fin.Cleanup(fmt.Errorf("creating db manager: %w", err))
+	}
+	fin.Add(manager)
+
+	return &Embedded{
+		net:     n,
+		store:   store,
+		manager: manager,
+		boot:    cfg.Bootstrap,
+		fin:     fin,
+	}, nil
+}
+
+// Start connects the node to its bootstrap peers. Starting a started node does nothing.
+func (e *Embedded) Start() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.closed {
+		return ErrClosed
+	}
+	if e.started {
+		return nil
+	}
+	if len(e.boot) > 0 {
+		e.net.Bootstrap(e.boot)
+	}
+	e.started = true
+	return nil
+}
+
+// Close closes the db manager, the datastores and the network, in that order.
+// Closing a closed node does nothing.
+func (e *Embedded) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.fin.Cleanup(nil)
+}
+
+// Net returns the thread network of the node.
+func (e *Embedded) Net() app.Net {
+	return e.net
+}
+
+// Manager returns the db manager of the node.
+func (e *Embedded) Manager() *db.Manager {
+	return e.manager
+}
+
+// Host returns the libp2p host of the node.
+func (e *Embedded) Host() host.Host {
+	return e.net.Host()
+}
+
+// Datastore returns the datastore of the node's dbs.
+func (e *Embedded) Datastore() kt.TxnDatastoreExtended {
+	return e.store
+}
@@ -0,0 +1,66 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestEmbedded(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := EmbeddedConfig{
+		RepoPath:  dir,
+		HostAddr:  ma.StringCast("/ip4/127.0.0.1/tcp/0"),
+		Bootstrap: []peer.AddrInfo{},
+	}
+
+	e, err := NewEmbedded(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	id := thread.NewIDV1(thread.Raw, 32)
+	if _, err = e.Manager().NewDB(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	hostID := e.Host().ID()
+	if err = e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = e.Close(); err != nil {
+		t.Fatalf("expected closing twice to do nothing, got %v", err)
+	}
+	if err = e.Start(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected closed node not to start, got %v", err)
+	}
+
+	// the host key, threads and dbs are kept in the repo
+	e, err = NewEmbedded(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	if e.Host().ID() != hostID {
+		t.Fatal("expected the host key to be reused")
+	}
+	if _, err = e.Manager().GetDB(ctx, id); err != nil {
+		t.Fatalf("expected db to be reloaded, got %v", err)
+	}
+	if _, err = e.Net().GetThread(ctx, id); err != nil {
+		t.Fatalf("expected thread to be reloaded, got %v", err)
+	}
+}
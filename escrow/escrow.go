@@ -0,0 +1,281 @@
+// Package escrow provides social recovery of thread keys. Keys are split into Shamir
+// shares, each encrypted to a recovery contact and stored as a record of a recovery
+// thread shared with the contacts. A user who lost their device asks for recovery
+// from a new identity, contacts release their shares to it, and the key is rebuilt
+// once enough shares are released.
+package escrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crypto/shamir"
+)
+
+const (
+	// TypeShare is the record type of a share deposited for a contact.
+	TypeShare = "escrow/share"
+	// TypeRequest is the record type of a recovery request.
+	TypeRequest = "escrow/request"
+	// TypeRelease is the record type of a share released to a recovery requester.
+	TypeRelease = "escrow/release"
+)
+
+var (
+	// ErrNotEnoughShares indicates fewer shares than the threshold were released so far.
+	ErrNotEnoughShares = errors.New("not enough shares released")
+	// ErrNoShare indicates the contact holds no share of the thread key.
+	ErrNoShare = errors.New("no share deposited for contact")
+)
+
+func init() {
+	cbornode.RegisterCborType(entry{})
+}
+
+// entry is the body of escrow records.
+type entry struct {
+	Type      string
+	Thread    []byte
+	Contact   []byte `refmt:",omitempty"`
+	Requester []byte `refmt:",omitempty"`
+	Threshold int    `refmt:",omitempty"`
+	Share     []byte `refmt:",omitempty"`
+}
+
+// Request is a pending recovery request of a thread key.
+type Request struct {
+	// ID of the request record.
+	ID cid.Cid
+	// Thread whose key is recovered.
+	Thread thread.ID
+	// Requester is the identity the shares are released to.
+	Requester thread.PubKey
+	// Time the request was made.
+	Time time.Time
+}
+
+// Escrow deposits and recovers thread keys using a recovery thread.
+// The recovery thread must be readable by the user and all contacts.
+type Escrow struct {
+	net      core.Net
+	recovery thread.ID
+	opts     []core.ThreadOption
+}
+
+// New returns an escrow storing its records in the recovery thread.
+// Options, e.g. a thread token, apply to the recovery thread.
+func New(n core.Net, recovery thread.ID, opts ...core.ThreadOption) *Escrow {
+	return &Escrow{net: n, recovery: recovery, opts: opts}
+}
+
+// Deposit splits the key of thread id into a share for each contact, threshold of
+// which are needed to recover the key. Shares are encrypted to the contacts.
+func (e *Escrow) Deposit(ctx context.Context, id thread.ID, contacts []thread.PubKey, threshold int) error {
+	info, err := e.net.GetThread(ctx, id, e.opts...)
+	if err != nil {
+		return err
+	}
+	if !info.Key.Defined() {
+		return fmt.Errorf("thread %s has no key", id)
+	}
+	shares, err := shamir.Split(info.Key.Bytes(), len(contacts), threshold)
+	if err != nil {
+		return err
+	}
+	for i, c := range contacts {
+		contact, err := c.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		share, err := c.Encrypt(shares[i])
+		if err != nil {
+			return err
+		}
+		if err = e.write(ctx, entry{
+			Type:      TypeShare,
+			Thread:    id.Bytes(),
+			Contact:   contact,
+			Threshold: threshold,
+			Share:     share,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InitiateRecovery asks contacts to release their shares of the key of thread id to requester.
+func (e *Escrow) InitiateRecovery(ctx context.Context, id thread.ID, requester thread.PubKey) error {
+	req, err := requester.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return e.write(ctx, entry{
+		Type:      TypeRequest,
+		Thread:    id.Bytes(),
+		Requester: req,
+	})
+}
+
+// Requests returns the recovery requests, most recent first.
+// Contacts should confirm requests out of band before releasing their shares.
+func (e *Escrow) Requests(ctx context.Context) ([]Request, error) {
+	var reqs []Request
+	err := e.walk(ctx, TypeRequest, func(s core.RecordSummary, en entry) error {
+		id, err := thread.Cast(en.Thread)
+		if err != nil {
+			return err
+		}
+		requester := &thread.Libp2pPubKey{}
+		if err = requester.UnmarshalBinary(en.Requester); err != nil {
+			return err
+		}
+		reqs = append(reqs, Request{ID: s.ID, Thread: id, Requester: requester, Time: s.Time})
+		return nil
+	})
+	return reqs, err
+}
+
+// Release decrypts the share deposited for contact and releases it to the requester of req.
+func (e *Escrow) Release(ctx context.Context, req Request, contact thread.Identity) error {
+	var share *entry
+	if err := e.walk(ctx, TypeShare, func(_ core.RecordSummary, en entry) error {
+		if share == nil && e.matches(en, req.Thread, en.Contact, contact.GetPublic()) {
+			share = &en
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if share == nil {
+		return ErrNoShare
+	}
+	plain, err := contact.Decrypt(ctx, share.Share)
+	if err != nil {
+		return fmt.Errorf("decrypting share: %w", err)
+	}
+	released, err := req.Requester.Encrypt(plain)
+	if err != nil {
+		return err
+	}
+	requester, err := req.Requester.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return e.write(ctx, entry{
+		Type:      TypeRelease,
+		Thread:    req.Thread.Bytes(),
+		Requester: requester,
+		Threshold: share.Threshold,
+		Share:     released,
+	})
+}
+
+// CompleteRecovery combines the shares released to requester into the key of thread id.
+// ErrNotEnoughShares is returned until threshold shares are released.
+func (e *Escrow) CompleteRecovery(ctx context.Context, id thread.ID, requester thread.Identity) (thread.Key, error) {
+	var (
+		shares    [][]byte
+		threshold int
+	)
+	if err := e.walk(ctx, TypeRelease, func(_ core.RecordSummary, en entry) error {
+		if !e.matches(en, id, en.Requester, requester.GetPublic()) {
+			return nil
+		}
+		share, err := requester.Decrypt(ctx, en.Share)
+		if err != nil {
+			return fmt.Errorf("decrypting share: %w", err)
+		}
+		for _, s := range shares {
+			if s[len(s)-1] == share[len(share)-1] {
+				// released more than once
+				return nil
+			}
+		}
+		shares = append(shares, share)
+		threshold = en.Threshold
+		return nil
+	}); err != nil {
+		return thread.Key{}, err
+	}
+	if len(shares) == 0 || len(shares) < threshold {
+		return thread.Key{}, fmt.Errorf("%w: %d of %d", ErrNotEnoughShares, len(shares), threshold)
+	}
+	secret, err := shamir.Combine(shares)
+	if err != nil {
+		return thread.Key{}, err
+	}
+	return thread.KeyFromBytes(secret)
+}
+
+// matches returns true if the entry belongs to thread id and key equals the marshaled key.
+func (e *Escrow) matches(en entry, id thread.ID, key []byte, expected thread.PubKey) bool {
+	if tid, err := thread.Cast(en.Thread); err != nil || !tid.Equals(id) {
+		return false
+	}
+	pk := &thread.Libp2pPubKey{}
+	if err := pk.UnmarshalBinary(key); err != nil {
+		return false
+	}
+	return pk.Equals(expected)
+}
+
+func (e *Escrow) write(ctx context.Context, en entry) error {
+	body, err := cbornode.WrapObject(en, mh.SHA2_256, -1)
+	if err != nil {
+		return err
+	}
+	_, err = e.net.CreateRecord(ctx, e.recovery, body, append(e.opts, core.WithRecordType(en.Type))...)
+	return err
+}
+
+// walk calls f with escrow entries of the given type in the recovery thread, most recent first.
+func (e *Escrow) walk(ctx context.Context, typ string, f func(core.RecordSummary, entry) error) error {
+	info, err := e.net.GetThread(ctx, e.recovery, e.opts...)
+	if err != nil {
+		return err
+	}
+	if !info.Key.CanRead() {
+		return fmt.Errorf("recovery thread %s isn't readable", e.recovery)
+	}
+	feed, err := e.net.ActivityFeed(ctx, e.recovery, time.Time{}, 0, e.opts...)
+	if err != nil {
+		return err
+	}
+	for _, s := range feed {
+		if s.Type != typ {
+			continue
+		}
+		rec, err := e.net.GetRecord(ctx, e.recovery, s.ID, e.opts...)
+		if err != nil {
+			return err
+		}
+		event, err := cbor.EventFromRecord(ctx, e.net, rec)
+		if err != nil {
+			return err
+		}
+		node, err := event.GetBody(ctx, e.net, info.Key.Read())
+		if err != nil {
+			return err
+		}
+		var en entry
+		if err = cbornode.DecodeInto(node.RawData(), &en); err != nil {
+			return err
+		}
+		if en.Type != typ {
+			continue
+		}
+		if err = f(s, en); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package escrow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	bserv "github.com/ipfs/go-blockservice"
+	ds "github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	dag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	tstore "github.com/textileio/go-threads/logstore/lstoremem"
+	"github.com/textileio/go-threads/net"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestEscrow_Recover(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	target, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovery, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := New(n, recovery.ID)
+
+	contacts := make([]thread.Identity, 3)
+	pubs := make([]thread.PubKey, len(contacts))
+	for i := range contacts {
+		contacts[i] = makeIdentity(t)
+		pubs[i] = contacts[i].GetPublic()
+	}
+	if err := e.Deposit(ctx, target.ID, pubs, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	device := makeIdentity(t)
+	if err := e.InitiateRecovery(ctx, target.ID, device.GetPublic()); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := e.Requests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 1 || !reqs[0].Thread.Equals(target.ID) || !reqs[0].Requester.Equals(device.GetPublic()) {
+		t.Fatalf("unexpected recovery requests: %v", reqs)
+	}
+
+	if err := e.Release(ctx, reqs[0], contacts[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.CompleteRecovery(ctx, target.ID, device); !errors.Is(err, ErrNotEnoughShares) {
+		t.Fatalf("expected not enough shares, got %v", err)
+	}
+	if err := e.Release(ctx, reqs[0], contacts[2]); err != nil {
+		t.Fatal(err)
+	}
+	key, err := e.CompleteRecovery(ctx, target.ID, device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.String() != target.Key.String() {
+		t.Fatal("recovered key doesn't match the thread key")
+	}
+
+	if err := e.Release(ctx, reqs[0], makeIdentity(t)); !errors.Is(err, ErrNoShare) {
+		t.Fatalf("expected no share for unknown contact, got %v", err)
+	}
+}
+
+func makeIdentity(t *testing.T) thread.Identity {
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return thread.NewLibp2pIdentity(sk)
+}
+
+func makeNetwork(t *testing.T) core.Net {
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, err := libp2p.New(
+		context.Background(),
+		libp2p.ListenAddrs(util.MustParseAddr("/ip4/127.0.0.1/tcp/0")),
+		libp2p.Identity(sk),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
+	bsrv := bserv.New(bs, offline.Exchange(bs))
+	n, err := net.NewNetwork(
+		context.Background(),
+		host,
+		bsrv.Blockstore(),
+		dag.NewDAGService(bsrv),
+		tstore.NewLogstore(),
+		net.Config{},
+		nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
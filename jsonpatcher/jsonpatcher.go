@@ -13,9 +13,9 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	format "github.com/ipfs/go-ipld-format"
-	logging "github.com/ipfs/go-log"
 	"github.com/multiformats/go-multihash"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/logger"
 )
 
 type operationType int
@@ -39,7 +39,7 @@ func (ot operationType) String() (s string) {
 }
 
 var (
-	log                           = logging.Logger("jsonpatcher")
+	log                           = logger.Logger("jsonpatcher")
 	errCantCreateExistingInstance = errors.New("cant't create already existent instance")
 	errUnknownOperation           = errors.New("unknown operation type")
 )
@@ -48,11 +48,17 @@ type operation struct {
 	Type       operationType
 	InstanceID core.InstanceID
 	JSONPatch  []byte
+	// Base reverts the patch, i.e. holds the previous values of patched paths.
+	// It's used to detect concurrent patches, and is empty for other operations.
+	Base []byte
 }
 
-type jsonPatcher struct{}
+type jsonPatcher struct {
+	strategy   ConflictStrategy
+	strategies map[string]ConflictStrategy
+}
 
-var _ core.EventCodec = (*jsonPatcher)(nil)
+var _ core.TxnEventCodec = (*jsonPatcher)(nil)
 
 func init() {
 	cbornode.RegisterCborType(patchEvent{})
@@ -60,9 +66,39 @@ func init() {
 	cbornode.RegisterCborType(operation{})
 }
 
-// New returns a JSON-Patcher EventCodec
-func New() core.EventCodec {
-	return &jsonPatcher{}
+// Option configures a JSON-Patcher EventCodec.
+type Option func(*jsonPatcher)
+
+// WithConflictStrategy sets the strategy resolving concurrent patches of all collections
+// without their own strategy. LastWriterWins is used by default.
+func WithConflictStrategy(strategy ConflictStrategy) Option {
+	return func(jp *jsonPatcher) {
+		jp.strategy = strategy
+	}
+}
+
+// WithCollectionConflictStrategy sets the strategy resolving concurrent patches of a collection.
+func WithCollectionConflictStrategy(collection string, strategy ConflictStrategy) Option {
+	return func(jp *jsonPatcher) {
+		jp.strategies[collection] = strategy
+	}
+}
+
+// New returns a JSON-Patcher EventCodec. Concurrent saves of an instance are merged,
+// and reduced actions report the paths changed concurrently as conflicts.
+func New(opts ...Option) core.EventCodec {
+	jp := &jsonPatcher{strategies: make(map[string]ConflictStrategy)}
+	for _, opt := range opts {
+		opt(jp)
+	}
+	return jp
+}
+
+func (jp *jsonPatcher) conflictStrategy(collection string) ConflictStrategy {
+	if s, ok := jp.strategies[collection]; ok {
+		return s
+	}
+	return jp.strategy
 }
 
 func (jp *jsonPatcher) Create(actions []core.Action) ([]core.Event, format.Node, error) {
@@ -114,7 +150,22 @@ func (jp *jsonPatcher) Reduce(
 		return nil, err
 	}
 	defer txn.Discard()
+	actions, err := jp.ReduceTxn(events, txn, baseKey, indexFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.Commit(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
 
+func (jp *jsonPatcher) ReduceTxn(
+	events []core.Event,
+	txn ds.Txn,
+	baseKey ds.Key,
+	indexFunc core.IndexFunc,
+) ([]core.ReduceAction, error) {
 	sort.Slice(events, func(i, j int) bool {
 		ei, oki := events[i].(patchEvent)
 		ej, okj := events[j].(patchEvent)
@@ -126,8 +177,8 @@ func (jp *jsonPatcher) Reduce(
 		return ei.time().Before(ej.time())
 	})
 
-	actions := make([]core.ReduceAction, len(events))
-	for i, e := range events {
+	actions := make([]core.ReduceAction, 0, len(events))
+	for _, e := range events {
 		je, ok := e.(patchEvent)
 		if !ok {
 			return nil, fmt.Errorf("event unrecognized for jsonpatcher eventcodec")
@@ -148,7 +199,10 @@ func (jp *jsonPatcher) Reduce(
 			if err := indexFunc(e.Collection(), key, nil, je.Patch.JSONPatch, txn); err != nil {
 				return nil, fmt.Errorf("error when indexing created data: %w", err)
 			}
-			actions[i] = core.ReduceAction{Type: core.Create, Collection: e.Collection(), InstanceID: e.InstanceID()}
+			if err := putClock(txn, key, clock{"": je.timestamp()}); err != nil {
+				return nil, err
+			}
+			actions = append(actions, core.ReduceAction{Type: core.Create, Collection: e.Collection(), InstanceID: e.InstanceID()})
 			log.Debug("\tcreate operation applied")
 		case save:
 			value, err := txn.Get(key)
@@ -157,39 +211,65 @@ func (jp *jsonPatcher) Reduce(
 			} else if err != nil {
 				return nil, err
 			}
-			patchedValue, err := jsonpatch.MergePatch(value, je.Patch.JSONPatch)
+			c, err := getClock(txn, key)
+			if err != nil {
+				return nil, err
+			}
+			patchedValue, conflicts, err := merge(
+				value,
+				je.Patch.JSONPatch,
+				je.Patch.Base,
+				je.timestamp(),
+				c,
+				jp.conflictStrategy(e.Collection()),
+			)
 			if err != nil {
 				return nil, fmt.Errorf("error when reducing save event: %w", err)
 			}
 			if err = txn.Put(key, patchedValue); err != nil {
 				return nil, err
 			}
+			if err = putClock(txn, key, c); err != nil {
+				return nil, err
+			}
 			if err := indexFunc(e.Collection(), key, value, patchedValue, txn); err != nil {
 				return nil, fmt.Errorf("error when indexing created data: %w", err)
 			}
-			actions[i] = core.ReduceAction{Type: core.Save, Collection: e.Collection(), InstanceID: e.InstanceID()}
-			log.Debug("\tsave operation applied")
+			actions = append(actions, core.ReduceAction{
+				Type:       core.Save,
+				Collection: e.Collection(),
+				InstanceID: e.InstanceID(),
+				Conflicts:  conflicts,
+			})
+			if len(conflicts) != 0 {
+				log.Debugf("\tsave operation applied with conflicts at %v", conflicts)
+			} else {
+				log.Debug("\tsave operation applied")
+			}
 		case del:
 			value, err := txn.Get(key)
-			if err != nil {
+			if errors.Is(err, ds.ErrNotFound) {
+				// peers may delete the same instance concurrently, e.g. once it expires
+				log.Debug("\tdelete operation of missing instance skipped")
+				continue
+			} else if err != nil {
 				return nil, err
 			}
 			if err := txn.Delete(key); err != nil {
 				return nil, err
 			}
+			if err := txn.Delete(clockKey(key)); err != nil {
+				return nil, err
+			}
 			if err := indexFunc(e.Collection(), key, value, nil, txn); err != nil {
 				return nil, fmt.Errorf("error when removing index: %w", err)
 			}
-			actions[i] = core.ReduceAction{Type: core.Delete, Collection: e.Collection(), InstanceID: e.InstanceID()}
+			actions = append(actions, core.ReduceAction{Type: core.Delete, Collection: e.Collection(), InstanceID: e.InstanceID()})
 			log.Debug("\tdelete operation applied")
 		default:
 			return nil, errUnknownOperation
 		}
 	}
-	if err := txn.Commit(); err != nil {
-		return nil, err
-	}
-
 	return actions, nil
 }
 
@@ -225,10 +305,15 @@ func saveEvent(id core.InstanceID, prev []byte, curr []byte) (*operation, error)
 	if err != nil {
 		return nil, err
 	}
+	base, err := jsonpatch.CreateMergePatch(curr, prev)
+	if err != nil {
+		return nil, err
+	}
 	return &operation{
 		Type:       save,
 		InstanceID: id,
 		JSONPatch:  jsonPatch,
+		Base:       base,
 	}, nil
 }
 
@@ -271,6 +356,19 @@ func (je patchEvent) time() (t time.Time) {
 	return t
 }
 
+// timestamp returns the creation time of the event in nanoseconds.
+func (je patchEvent) timestamp() int64 {
+	switch ts := je.Timestamp.(type) {
+	case time.Time:
+		return ts.UnixNano()
+	case int64:
+		return ts
+	case int:
+		return int64(ts)
+	}
+	return 0
+}
+
 func (je patchEvent) InstanceID() core.InstanceID {
 	return je.ID
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	mh "github.com/multiformats/go-multihash"
 	core "github.com/textileio/go-threads/core/db"
@@ -79,3 +80,102 @@ func TestJsonPatcher_Migration(t *testing.T) {
 		t.Error("encodable time should be equal to input")
 	}
 }
+
+func TestJsonPatcher_ConcurrentSaves(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{name: "LastWriterWins", expected: `{"age":2,"name":"bob"}`},
+		{name: "FirstWriterWins", opts: []Option{WithCollectionConflictStrategy("people", FirstWriterWins)}, expected: `{"age":2,"name":"alice"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			jp := New(tc.opts...)
+			create := makeEvent(t, jp, 1, core.Action{Type: core.Create, Current: []byte(`{"name":"eve","age":1}`)})
+			// both peers rename concurrently, while only the second one changes the age
+			first := makeEvent(t, jp, 2, core.Action{Type: core.Save,
+				Previous: []byte(`{"name":"eve","age":1}`), Current: []byte(`{"name":"alice","age":1}`)})
+			second := makeEvent(t, jp, 3, core.Action{Type: core.Save,
+				Previous: []byte(`{"name":"eve","age":1}`), Current: []byte(`{"name":"bob","age":2}`)})
+
+			for _, order := range [][]core.Event{{create, first, second}, {create, second, first}} {
+				store := txnStore{ds.NewMapDatastore()}
+				var conflicts []string
+				for _, e := range order {
+					actions, err := jp.Reduce([]core.Event{e}, store, ds.NewKey("db"), noIndex)
+					if err != nil {
+						t.Fatal(err)
+					}
+					conflicts = append(conflicts, actions[0].Conflicts...)
+				}
+				value, err := store.Get(ds.NewKey("db/people/1"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(value) != tc.expected {
+					t.Fatalf("expected %s, got %s", tc.expected, value)
+				}
+				if len(conflicts) != 1 || conflicts[0] != "/name" {
+					t.Fatalf("expected a conflict at /name, got %v", conflicts)
+				}
+			}
+		})
+	}
+}
+
+func TestJsonPatcher_ConcurrentDeletes(t *testing.T) {
+	jp := New()
+	store := txnStore{ds.NewMapDatastore()}
+	create := makeEvent(t, jp, 1, core.Action{Type: core.Create, Current: []byte(`{"name":"eve"}`)})
+	// both peers delete the instance, e.g. once it expires
+	first := makeEvent(t, jp, 2, core.Action{Type: core.Delete})
+	second := makeEvent(t, jp, 3, core.Action{Type: core.Delete})
+
+	actions, err := jp.Reduce([]core.Event{create, first}, store, ds.NewKey("db"), noIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 || actions[1].Type != core.Delete {
+		t.Fatalf("expected create and delete actions, got %v", actions)
+	}
+	actions, err = jp.Reduce([]core.Event{second}, store, ds.NewKey("db"), noIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 0 {
+		t.Fatalf("expected delete of missing instance to be skipped, got %v", actions)
+	}
+}
+
+func makeEvent(t *testing.T, jp core.EventCodec, ts int64, a core.Action) core.Event {
+	a.InstanceID = "1"
+	a.CollectionName = "people"
+	events, _, err := jp.Create([]core.Action{a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := events[0].(patchEvent)
+	e.Timestamp = ts
+	return e
+}
+
+func noIndex(string, ds.Key, []byte, []byte, ds.Txn) error {
+	return nil
+}
+
+type txnStore struct {
+	*ds.MapDatastore
+}
+
+func (s txnStore) NewTransaction(bool) (ds.Txn, error) {
+	return txn{s.MapDatastore}, nil
+}
+
+type txn struct {
+	*ds.MapDatastore
+}
+
+func (txn) Commit() error { return nil }
+
+func (txn) Discard() {}
@@ -0,0 +1,221 @@
+package jsonpatcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	ds "github.com/ipfs/go-datastore"
+)
+
+// ConflictStrategy resolves concurrent patches to the same path of an instance.
+// Strategies are deterministic, so peers applying patches in different order converge.
+type ConflictStrategy int
+
+const (
+	// LastWriterWins keeps the value of the patch created last.
+	LastWriterWins ConflictStrategy = iota
+	// FirstWriterWins keeps the value of the patch created first.
+	FirstWriterWins
+)
+
+// clockPrefix is the key prefix of instance write clocks, which hold creation
+// times of the patches that wrote the current values, for each patched path.
+var clockPrefix = ds.NewKey("_clock")
+
+// clock maps JSON pointers of instance paths to write times, the root path is empty.
+type clock map[string]int64
+
+// at returns the time of the last write affecting the value at path,
+// i.e. a write to the path itself, its ancestors or descendants.
+func (c clock) at(path string) (ts int64) {
+	for p, t := range c {
+		if t > ts && (p == path || isAncestor(p, path) || isAncestor(path, p)) {
+			ts = t
+		}
+	}
+	return ts
+}
+
+// set records a write to path, which replaces writes to its descendants.
+func (c clock) set(path string, ts int64) {
+	for p := range c {
+		if isAncestor(path, p) {
+			delete(c, p)
+		}
+	}
+	c[path] = ts
+}
+
+func isAncestor(ancestor, path string) bool {
+	return strings.HasPrefix(path, ancestor+"/")
+}
+
+func clockKey(key ds.Key) ds.Key {
+	return clockPrefix.Child(key)
+}
+
+func getClock(txn ds.Txn, key ds.Key) (clock, error) {
+	c := make(clock)
+	v, err := txn.Get(clockKey(key))
+	if errors.Is(err, ds.ErrNotFound) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(v, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func putClock(txn ds.Txn, key ds.Key, c clock) error {
+	v, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return txn.Put(clockKey(key), v)
+}
+
+// merge applies a save patch to the current value with a three-way merge. A path of
+// the patch conflicts if its current value differs from both the value the patch was
+// created from (base) and the patched value, i.e. the path was changed concurrently.
+// Conflicts are resolved with the strategy, and paths the patch lost are left as is.
+// Patches without a base, created by older hosts, are applied without merging.
+func merge(value, patch, base []byte, ts int64, c clock, strategy ConflictStrategy) (merged []byte, conflicts []string, err error) {
+	var doc, p, b interface{}
+	if err = json.Unmarshal(value, &doc); err != nil {
+		return
+	}
+	if err = json.Unmarshal(patch, &p); err != nil {
+		return
+	}
+	if len(base) != 0 {
+		if err = json.Unmarshal(base, &b); err != nil {
+			return
+		}
+	}
+
+	leaves := make(map[string]interface{})
+	flatten("", p, leaves)
+	paths := make([]string, 0, len(leaves))
+	for path := range leaves {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if len(base) != 0 {
+			prev, _ := lookup(b, path)
+			curr, _ := lookup(doc, path)
+			next := leaves[path]
+			if !equal(prev, curr) && !equal(next, curr) {
+				conflicts = append(conflicts, path)
+				if !wins(ts, c.at(path), next, curr, strategy) {
+					p = remove(p, path)
+					continue
+				}
+			}
+		}
+		c.set(path, ts)
+	}
+
+	if patch, err = json.Marshal(p); err != nil {
+		return
+	}
+	merged, err = jsonpatch.MergePatch(value, patch)
+	return merged, conflicts, err
+}
+
+// wins returns whether a patched value written at ts replaces the current value written at curTs.
+func wins(ts, curTs int64, next, curr interface{}, strategy ConflictStrategy) bool {
+	if ts == curTs {
+		// order equal times by values, so all peers pick the same one
+		nb, _ := json.Marshal(next)
+		cb, _ := json.Marshal(curr)
+		return bytes.Compare(nb, cb) > 0
+	}
+	if strategy == FirstWriterWins {
+		return ts < curTs
+	}
+	return ts > curTs
+}
+
+// flatten collects the leaves of a merge patch by path. Objects are merged
+// recursively, so any other value, including null and arrays, is a leaf.
+func flatten(path string, v interface{}, leaves map[string]interface{}) {
+	obj, ok := v.(map[string]interface{})
+	if !ok || (len(obj) == 0 && path != "") {
+		leaves[path] = v
+		return
+	}
+	for k, child := range obj {
+		flatten(path+"/"+escape(k), child, leaves)
+	}
+}
+
+// lookup returns the value at path, missing values are null.
+func lookup(v interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, k := range strings.Split(path[1:], "/") {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[unescape(k)]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// remove drops the leaf at path from a merge patch, along with objects left empty.
+func remove(v interface{}, path string) interface{} {
+	if path == "" {
+		return map[string]interface{}{}
+	}
+	keys := strings.Split(path[1:], "/")
+	var drop func(obj map[string]interface{}, keys []string)
+	drop = func(obj map[string]interface{}, keys []string) {
+		k := unescape(keys[0])
+		if len(keys) > 1 {
+			if child, ok := obj[k].(map[string]interface{}); ok {
+				drop(child, keys[1:])
+				if len(child) != 0 {
+					return
+				}
+			}
+		}
+		delete(obj, k)
+	}
+	if obj, ok := v.(map[string]interface{}); ok {
+		drop(obj, keys)
+	}
+	return v
+}
+
+func equal(a, b interface{}) bool {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
+
+// escape and unescape keys in JSON pointers, see RFC 6901.
+func escape(k string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+}
+
+func unescape(k string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(k)
+}
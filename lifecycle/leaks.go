@@ -0,0 +1,84 @@
+package lifecycle
+
+import (
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Snapshot is the set of goroutines running at some point, by goroutine ID.
+type Snapshot map[string]struct{}
+
+// TB is the part of testing.TB used to report leaks.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Goroutines takes a snapshot of the running goroutines.
+func Goroutines() Snapshot {
+	s := make(Snapshot)
+	for _, g := range stacks() {
+		s[goroutineID(g)] = struct{}{}
+	}
+	return s
+}
+
+// Leaked returns the stacks of the goroutines started since a snapshot which are still running
+// once timeout elapsed, except the ones with a stack containing any of ignore.
+func Leaked(since Snapshot, timeout time.Duration, ignore ...string) []string {
+	deadline := time.Now().Add(timeout)
+	for {
+		var leaked []string
+	Stacks:
+		for _, g := range stacks() {
+			if _, ok := since[goroutineID(g)]; ok {
+				continue
+			}
+			for _, s := range ignore {
+				if strings.Contains(g, s) {
+					continue Stacks
+				}
+			}
+			leaked = append(leaked, g)
+		}
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			return leaked
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+}
+
+// CheckLeaks fails a test if goroutines started since a snapshot are still running after
+// a second, e.g. once the components under test are closed. See Leaked.
+func CheckLeaks(t TB, since Snapshot, ignore ...string) {
+	t.Helper()
+	if leaked := Leaked(since, time.Second, ignore...); len(leaked) != 0 {
+		t.Errorf("%d goroutines leaked:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+	}
+}
+
+// stacks returns the stacks of all goroutines except the calling one.
+func stacks() []string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	gs := strings.Split(strings.TrimSpace(string(buf)), "\n\n")
+	// the calling goroutine is the first one
+	return gs[1:]
+}
+
+// goroutineID returns the ID of a goroutine from its stack, which starts with "goroutine <id> [<state>]:".
+func goroutineID(stack string) string {
+	fields := strings.SplitN(stack, " ", 3)
+	if len(fields) < 2 {
+		return stack
+	}
+	return fields[1]
+}
@@ -0,0 +1,232 @@
+// Package lifecycle tracks the goroutines and resources started by components, so that they
+// are shut down in a documented order and the goroutines which don't stop are reported.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/textileio/go-threads/logger"
+)
+
+var log = logger.Logger("lifecycle")
+
+// ErrClosed indicates a goroutine was started once the manager was closed.
+var ErrClosed = errors.New("lifecycle manager is closed")
+
+// StopTimeout is the duration to wait for the goroutines of a stage to stop once it's shut down.
+// Goroutines still running afterwards are reported as leaks, see Manager.Leaks.
+var StopTimeout = time.Second * 10
+
+// Stage is a step of the shutdown order. Stages are shut down one after another in increasing
+// order. Shutting down a stage closes its resources in reverse order of registration, then
+// cancels its context and waits for its goroutines.
+type Stage int
+
+const (
+	// StageAPI are the servers and listeners of the APIs, which stop taking calls first.
+	StageAPI Stage = iota
+	// StageApp are the apps on top of the network, e.g. dbs, and the subscriptions of their clients.
+	StageApp
+	// StageSync are the background loops syncing threads, e.g. pulls, exchanges and pubsub.
+	StageSync
+	// StageTransport are the network service, the peer connections and the host.
+	StageTransport
+	// StageStorage are the datastores, the logstore and the blockstore, which are closed last.
+	StageStorage
+
+	numStages
+)
+
+func (s Stage) String() string {
+	switch s {
+	case StageAPI:
+		return "api"
+	case StageApp:
+		return "app"
+	case StageSync:
+		return "sync"
+	case StageTransport:
+		return "transport"
+	case StageStorage:
+		return "storage"
+	default:
+		return fmt.Sprintf("stage(%d)", int(s))
+	}
+}
+
+// Manager tracks the goroutines and resources of a component by stage.
+type Manager struct {
+	stages [numStages]*stage
+	lock   sync.Mutex
+	closed bool
+	leaks  []string
+	next   uint64
+}
+
+type stage struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running map[uint64]string
+	closers []namedCloser
+}
+
+type namedCloser struct {
+	name string
+	c    io.Closer
+}
+
+// New returns a manager, the contexts of its stages are derived from ctx.
+func New(ctx context.Context) *Manager {
+	m := &Manager{}
+	for i := range m.stages {
+		sctx, cancel := context.WithCancel(ctx)
+		m.stages[i] = &stage{ctx: sctx, cancel: cancel, running: make(map[uint64]string)}
+	}
+	return m
+}
+
+// Context returns the context of a stage, which is cancelled once the stage is shut down.
+func (m *Manager) Context(s Stage) context.Context {
+	return m.stages[s].ctx
+}
+
+// Go runs f in a tracked goroutine of a stage, with the stage context. It returns ErrClosed
+// without running f if the manager is closed.
+func (m *Manager) Go(s Stage, name string, f func(ctx context.Context)) error {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return fmt.Errorf("starting %s/%s: %w", s, name, ErrClosed)
+	}
+	st := m.stages[s]
+	id := m.next
+	m.next++
+	st.running[id] = name
+	st.wg.Add(1)
+	m.lock.Unlock()
+
+	go func() {
+		defer func() {
+			m.lock.Lock()
+			delete(st.running, id)
+			m.lock.Unlock()
+			st.wg.Done()
+		}()
+		f(st.ctx)
+	}()
+	return nil
+}
+
+// Track registers a resource closed when its stage is shut down. Resources registered
+// once the manager is closed are closed right away.
+func (m *Manager) Track(s Stage, name string, c io.Closer) {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		if err := c.Close(); err != nil {
+			log.Errorf("closing %s failed: %v", name, err)
+		}
+		return
+	}
+	defer m.lock.Unlock()
+	m.stages[s].closers = append(m.stages[s].closers, namedCloser{name: name, c: c})
+}
+
+// TrackFunc registers a function called when its stage is shut down, see Track.
+func (m *Manager) TrackFunc(s Stage, name string, f func() error) {
+	m.Track(s, name, closerFunc(f))
+}
+
+// Running returns the tracked goroutines which are running, as "stage/name".
+func (m *Manager) Running() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var names []string
+	for i, st := range m.stages {
+		for _, name := range st.running {
+			names = append(names, Stage(i).String()+"/"+name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Leaks returns the goroutines which didn't stop within StopTimeout when their stage was shut down.
+func (m *Manager) Leaks() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]string(nil), m.leaks...)
+}
+
+// Close shuts down the stages in order. Errors of the closed resources are returned together,
+// closing the manager again is a no-op.
+func (m *Manager) Close() error {
+	return m.Cleanup(nil)
+}
+
+// Cleanup closes the manager and returns err along with the close errors, e.g. to release
+// the resources of a component which failed to start.
+func (m *Manager) Cleanup(err error) error {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return err
+	}
+	m.closed = true
+	m.lock.Unlock()
+
+	var errs []error
+	for i, st := range m.stages {
+		s := Stage(i)
+		for j := len(st.closers) - 1; j >= 0; j-- {
+			if e := st.closers[j].c.Close(); e != nil {
+				errs = append(errs, fmt.Errorf("closing %s/%s: %w", s, st.closers[j].name, e))
+			}
+		}
+		st.cancel()
+		if leaks := m.wait(s, st); len(leaks) != 0 {
+			log.Errorf("goroutines of stage %s didn't stop: %v", s, leaks)
+		}
+	}
+	return multierror.Append(err, errs...).ErrorOrNil()
+}
+
+// wait waits for the goroutines of a stage, and returns the ones which didn't stop in time.
+func (m *Manager) wait(s Stage, st *stage) []string {
+	done := make(chan struct{})
+	go func() {
+		st.wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(StopTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var leaks []string
+	for _, name := range st.running {
+		leaks = append(leaks, s.String()+"/"+name)
+	}
+	sort.Strings(leaks)
+	m.leaks = append(m.leaks, leaks...)
+	return leaks
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
@@ -0,0 +1,110 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManager_Order(t *testing.T) {
+	m := New(context.Background())
+	var order []string
+	track := func(s Stage, name string) {
+		m.TrackFunc(s, name, func() error {
+			order = append(order, name)
+			return nil
+		})
+	}
+	track(StageStorage, "store")
+	track(StageApp, "db")
+	track(StageTransport, "host")
+	track(StageAPI, "server")
+	track(StageTransport, "pool")
+
+	stopped := make(chan string, 1)
+	if err := m.Go(StageSync, "loop", func(ctx context.Context) {
+		<-ctx.Done()
+		stopped <- fmt.Sprint(order)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if running := m.Running(); !reflect.DeepEqual(running, []string{"sync/loop"}) {
+		t.Fatalf("expected sync/loop to be running, got %v", running)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"server", "db", "pool", "host", "store"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected closing order %v, got %v", expected, order)
+	}
+	// the sync stage is shut down after the app stage only
+	if s := <-stopped; s != "[server db]" {
+		t.Fatalf("expected sync goroutines to stop after apps, got %s", s)
+	}
+	if running := m.Running(); len(running) != 0 {
+		t.Fatalf("expected no running goroutines, got %v", running)
+	}
+	if err := m.Go(StageSync, "late", func(context.Context) {}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestManager_Leaks(t *testing.T) {
+	defer func(timeout time.Duration) { StopTimeout = timeout }(StopTimeout)
+	StopTimeout = time.Millisecond * 100
+
+	m := New(context.Background())
+	stop := make(chan struct{})
+	defer close(stop)
+	if err := m.Go(StageApp, "stuck", func(context.Context) { <-stop }); err != nil {
+		t.Fatal(err)
+	}
+	m.TrackFunc(StageStorage, "store", func() error { return errors.New("boom") })
+	err := m.Close()
+	if err == nil || !strings.Contains(err.Error(), "storage/store: boom") {
+		t.Fatalf("expected close error of the store, got %v", err)
+	}
+	if leaks := m.Leaks(); !reflect.DeepEqual(leaks, []string{"app/stuck"}) {
+		t.Fatalf("expected app/stuck to leak, got %v", leaks)
+	}
+}
+
+type fakeTB struct {
+	errors []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheckLeaks(t *testing.T) {
+	since := Goroutines()
+	stop := make(chan struct{})
+	go leakingGoroutine(stop)
+
+	tb := &fakeTB{}
+	CheckLeaks(tb, since)
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "leakingGoroutine") {
+		t.Fatalf("expected the goroutine to be reported, got %v", tb.errors)
+	}
+	tb = &fakeTB{}
+	CheckLeaks(tb, since, "leakingGoroutine")
+	if len(tb.errors) != 0 {
+		t.Fatalf("expected ignored goroutine not to be reported, got %v", tb.errors)
+	}
+
+	close(stop)
+	CheckLeaks(t, since)
+}
+
+func leakingGoroutine(stop chan struct{}) {
+	<-stop
+}
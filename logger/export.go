@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventExporter writes events as JSON lines, e.g. for ingestion into ELK. Each line holds the
+// event time in "@timestamp", the "subsystem" and "event" names and the event fields.
+// It's safe for concurrent use.
+type EventExporter struct {
+	lk  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewEventExporter returns an exporter writing to w.
+func NewEventExporter(w io.Writer) *EventExporter {
+	return &EventExporter{enc: json.NewEncoder(w)}
+}
+
+// Export writes an event with the given fields. Fields named like the reserved keys are dropped.
+func (e *EventExporter) Export(subsystem, event string, fields map[string]interface{}) error {
+	line := make(map[string]interface{}, len(fields)+3)
+	for k, v := range fields {
+		line[k] = v
+	}
+	line["@timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	line["subsystem"] = subsystem
+	line["event"] = event
+
+	e.lk.Lock()
+	defer e.lk.Unlock()
+	return e.enc.Encode(line)
+}
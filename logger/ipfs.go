@@ -0,0 +1,25 @@
+package logger
+
+import (
+	logging "github.com/ipfs/go-log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type ipfsBackend struct{}
+
+// IPFSBackend returns the backend writing to the global ipfs log, which is the default.
+// Subsystem levels are set on the ipfs log as well, so they can also be changed with
+// its environment variables and functions.
+func IPFSBackend() Backend {
+	return ipfsBackend{}
+}
+
+func (ipfsBackend) Logger(subsystem string) EventLogger {
+	// the caller of the subsystem logger is reported, not the subsystem logger
+	return logging.Logger(subsystem).Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+}
+
+func (ipfsBackend) SetLevel(subsystem string, level Level) error {
+	return logging.SetLogLevel(subsystem, zapcore.Level(level).CapitalString())
+}
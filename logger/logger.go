@@ -0,0 +1,299 @@
+// Package logger provides the loggers of the thread subsystems. Loggers write to a pluggable
+// backend, the ipfs log by default, and their levels can be changed per subsystem at runtime.
+package logger
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level is the minimum severity of the entries a subsystem logs.
+type Level int8
+
+const (
+	// LevelDebug logs all entries.
+	LevelDebug Level = iota - 1
+	// LevelInfo logs informational entries, warnings and errors.
+	LevelInfo
+	// LevelWarn logs warnings and errors.
+	LevelWarn
+	// LevelError logs errors only.
+	LevelError
+)
+
+// String returns a human-readable level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", l)
+	}
+}
+
+// ParseLevel returns the level of a name, e.g. "debug".
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// EventLogger is the structured logger of a subsystem. The w-suffixed methods add loosely typed
+// key-value pairs to the message, e.g. log.Infow("pulled thread", "thread", id, "records", n).
+type EventLogger interface {
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warn(args ...interface{})
+	Warnf(format string, args ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	// Fatal and Fatalf log regardless of the level and exit the process.
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// Backend creates the loggers which subsystem entries are written to.
+type Backend interface {
+	Logger(subsystem string) EventLogger
+}
+
+// LevelSetter is implemented by backends filtering entries by level on their own,
+// which are passed the levels set on subsystems.
+type LevelSetter interface {
+	SetLevel(subsystem string, level Level) error
+}
+
+var (
+	lk         sync.Mutex
+	backend    = IPFSBackend()
+	subsystems = make(map[string]*subsystemLogger)
+)
+
+// Logger returns the logger of a subsystem, which writes to the current backend.
+func Logger(subsystem string) EventLogger {
+	lk.Lock()
+	defer lk.Unlock()
+	s, ok := subsystems[subsystem]
+	if !ok {
+		s = &subsystemLogger{name: subsystem, level: levelUnset}
+		subsystems[subsystem] = s
+	}
+	if s.l.Load() == nil {
+		s.l.Store(loggerBox{backend.Logger(subsystem)})
+		if level := atomic.LoadInt32(&s.level); level != levelUnset {
+			if err := setBackendLevel(subsystem, Level(level)); err != nil {
+				backend.Logger(subsystem).Errorf("setting log level failed: %v", err)
+			}
+		}
+	}
+	return s
+}
+
+// SetBackend replaces the backend of all subsystem loggers, e.g. with an application logger.
+// Levels set on subsystems are kept.
+func SetBackend(b Backend) error {
+	lk.Lock()
+	defer lk.Unlock()
+	backend = b
+	for name, s := range subsystems {
+		if s.l.Load() == nil {
+			continue
+		}
+		s.l.Store(loggerBox{b.Logger(name)})
+		if level := atomic.LoadInt32(&s.level); level != levelUnset {
+			if err := setBackendLevel(name, Level(level)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetLevel sets the level of a subsystem at runtime, "*" sets the level of all subsystems.
+func SetLevel(subsystem string, level Level) error {
+	lk.Lock()
+	defer lk.Unlock()
+	if subsystem == "*" {
+		for name, s := range subsystems {
+			atomic.StoreInt32(&s.level, int32(level))
+			if s.l.Load() == nil {
+				continue
+			}
+			if err := setBackendLevel(name, level); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	s, ok := subsystems[subsystem]
+	if !ok {
+		// the level applies once the subsystem logger is created
+		subsystems[subsystem] = &subsystemLogger{name: subsystem, level: int32(level)}
+		return nil
+	}
+	atomic.StoreInt32(&s.level, int32(level))
+	if s.l.Load() == nil {
+		return nil
+	}
+	return setBackendLevel(subsystem, level)
+}
+
+// SetLevels sets the levels of the given subsystems.
+func SetLevels(levels map[string]Level) error {
+	for subsystem, level := range levels {
+		if err := SetLevel(subsystem, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subsystems returns the names of the subsystems which have a logger.
+func Subsystems() []string {
+	lk.Lock()
+	defer lk.Unlock()
+	names := make([]string, 0, len(subsystems))
+	for name, s := range subsystems {
+		if s.l.Load() != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func setBackendLevel(subsystem string, level Level) error {
+	if ls, ok := backend.(LevelSetter); ok {
+		return ls.SetLevel(subsystem, level)
+	}
+	return nil
+}
+
+// loggerBox keeps the loggers of different backends in the same atomic value.
+type loggerBox struct {
+	EventLogger
+}
+
+// levelUnset is the level of subsystems which weren't set one, their entries are filtered
+// by the backend only.
+const levelUnset = math.MinInt8
+
+// subsystemLogger filters the entries of a subsystem by level and writes them to the
+// logger of the current backend.
+type subsystemLogger struct {
+	name  string
+	level int32
+	l     atomic.Value
+}
+
+func (s *subsystemLogger) enabled(level Level) (EventLogger, bool) {
+	if min := atomic.LoadInt32(&s.level); min != levelUnset && level < Level(min) {
+		return nil, false
+	}
+	return s.l.Load().(loggerBox).EventLogger, true
+}
+
+func (s *subsystemLogger) Debug(args ...interface{}) {
+	if l, ok := s.enabled(LevelDebug); ok {
+		l.Debug(args...)
+	}
+}
+
+func (s *subsystemLogger) Debugf(format string, args ...interface{}) {
+	if l, ok := s.enabled(LevelDebug); ok {
+		l.Debugf(format, args...)
+	}
+}
+
+func (s *subsystemLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	if l, ok := s.enabled(LevelDebug); ok {
+		l.Debugw(msg, keysAndValues...)
+	}
+}
+
+func (s *subsystemLogger) Info(args ...interface{}) {
+	if l, ok := s.enabled(LevelInfo); ok {
+		l.Info(args...)
+	}
+}
+
+func (s *subsystemLogger) Infof(format string, args ...interface{}) {
+	if l, ok := s.enabled(LevelInfo); ok {
+		l.Infof(format, args...)
+	}
+}
+
+func (s *subsystemLogger) Infow(msg string, keysAndValues ...interface{}) {
+	if l, ok := s.enabled(LevelInfo); ok {
+		l.Infow(msg, keysAndValues...)
+	}
+}
+
+func (s *subsystemLogger) Warn(args ...interface{}) {
+	if l, ok := s.enabled(LevelWarn); ok {
+		l.Warn(args...)
+	}
+}
+
+func (s *subsystemLogger) Warnf(format string, args ...interface{}) {
+	if l, ok := s.enabled(LevelWarn); ok {
+		l.Warnf(format, args...)
+	}
+}
+
+func (s *subsystemLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	if l, ok := s.enabled(LevelWarn); ok {
+		l.Warnw(msg, keysAndValues...)
+	}
+}
+
+func (s *subsystemLogger) Error(args ...interface{}) {
+	if l, ok := s.enabled(LevelError); ok {
+		l.Error(args...)
+	}
+}
+
+func (s *subsystemLogger) Errorf(format string, args ...interface{}) {
+	if l, ok := s.enabled(LevelError); ok {
+		l.Errorf(format, args...)
+	}
+}
+
+func (s *subsystemLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	if l, ok := s.enabled(LevelError); ok {
+		l.Errorw(msg, keysAndValues...)
+	}
+}
+
+func (s *subsystemLogger) Fatal(args ...interface{}) {
+	s.l.Load().(loggerBox).Fatal(args...)
+}
+
+func (s *subsystemLogger) Fatalf(format string, args ...interface{}) {
+	s.l.Load().(loggerBox).Fatalf(format, args...)
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger_Levels(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	if err := SetBackend(NewZapBackend(zap.New(core))); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = SetBackend(IPFSBackend()) }()
+
+	// levels set before the logger is created apply once it is
+	if err := SetLevel("test-levels", LevelWarn); err != nil {
+		t.Fatal(err)
+	}
+	log := Logger("test-levels")
+	log.Infof("dropped %d", 1)
+	log.Warnw("kept", "thread", "t1")
+	if logs.Len() != 1 {
+		t.Fatalf("expected 1 entry, got %d", logs.Len())
+	}
+	e := logs.TakeAll()[0]
+	if e.LoggerName != "test-levels" || e.Message != "kept" || e.ContextMap()["thread"] != "t1" {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+
+	if err := SetLevel("test-levels", LevelDebug); err != nil {
+		t.Fatal(err)
+	}
+	log.Debug("kept")
+	if logs.Len() != 1 {
+		t.Fatal("expected debug entry once the level is lowered at runtime")
+	}
+
+	found := false
+	for _, s := range Subsystems() {
+		found = found || s == "test-levels"
+	}
+	if !found {
+		t.Fatal("expected subsystem to be listed")
+	}
+	if _, err := ParseLevel("bogus"); err == nil {
+		t.Fatal("expected unknown level to be rejected")
+	}
+}
+
+func TestEventExporter(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEventExporter(&buf)
+	if err := e.Export("net", "exchange", map[string]interface{}{"op": "pushRecord", "event": "ignored"}); err != nil {
+		t.Fatal(err)
+	}
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line["subsystem"] != "net" || line["event"] != "exchange" || line["op"] != "pushRecord" || line["@timestamp"] == nil {
+		t.Fatalf("unexpected event %v", line)
+	}
+}
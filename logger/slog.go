@@ -0,0 +1,83 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+type slogBackend struct {
+	l *slog.Logger
+}
+
+// NewSlogBackend returns a backend writing to a slog logger, with the subsystem of each entry
+// in its "subsystem" attribute. Loosely typed key-value pairs are passed as attributes.
+func NewSlogBackend(l *slog.Logger) Backend {
+	return slogBackend{l: l}
+}
+
+func (b slogBackend) Logger(subsystem string) EventLogger {
+	return slogLogger{l: b.l.With("subsystem", subsystem)}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) log(level slog.Level, msg string, keysAndValues ...interface{}) {
+	s.l.Log(context.Background(), level, msg, keysAndValues...)
+}
+
+func (s slogLogger) Debug(args ...interface{}) { s.log(slog.LevelDebug, fmt.Sprint(args...)) }
+
+func (s slogLogger) Debugf(format string, args ...interface{}) {
+	s.log(slog.LevelDebug, fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Debugw(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelDebug, msg, keysAndValues...)
+}
+
+func (s slogLogger) Info(args ...interface{}) { s.log(slog.LevelInfo, fmt.Sprint(args...)) }
+
+func (s slogLogger) Infof(format string, args ...interface{}) {
+	s.log(slog.LevelInfo, fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Infow(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelInfo, msg, keysAndValues...)
+}
+
+func (s slogLogger) Warn(args ...interface{}) { s.log(slog.LevelWarn, fmt.Sprint(args...)) }
+
+func (s slogLogger) Warnf(format string, args ...interface{}) {
+	s.log(slog.LevelWarn, fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelWarn, msg, keysAndValues...)
+}
+
+func (s slogLogger) Error(args ...interface{}) { s.log(slog.LevelError, fmt.Sprint(args...)) }
+
+func (s slogLogger) Errorf(format string, args ...interface{}) {
+	s.log(slog.LevelError, fmt.Sprintf(format, args...))
+}
+
+func (s slogLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	s.log(slog.LevelError, msg, keysAndValues...)
+}
+
+func (s slogLogger) Fatal(args ...interface{}) {
+	s.log(slog.LevelError, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+func (s slogLogger) Fatalf(format string, args ...interface{}) {
+	s.log(slog.LevelError, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
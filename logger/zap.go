@@ -0,0 +1,18 @@
+package logger
+
+import "go.uber.org/zap"
+
+type zapBackend struct {
+	l *zap.Logger
+}
+
+// NewZapBackend returns a backend writing to a zap logger, named after each subsystem.
+// The logger level applies on top of the subsystem levels, so it has to be low enough for
+// the entries of subsystems set to lower levels at runtime.
+func NewZapBackend(l *zap.Logger) Backend {
+	return zapBackend{l: l.WithOptions(zap.AddCallerSkip(1))}
+}
+
+func (b zapBackend) Logger(subsystem string) EventLogger {
+	return b.l.Named(subsystem).Sugar()
+}
@@ -124,6 +124,21 @@ func (ls *logstore) AddThread(info thread.Info) error {
 				return fmt.Errorf("read-key mismatch")
 			}
 		}
+	} else if info.Key.CanIndex() {
+		ik, err := ls.IndexKey(info.ID)
+		if err != nil {
+			return err
+		}
+		if ik == nil {
+			if err := ls.AddIndexKey(info.ID, info.Key.Index()); err != nil {
+				return err
+			}
+		} else {
+			// Ensure keys are the same
+			if !bytes.Equal(info.Key.Index().Bytes(), ik.Bytes()) {
+				return fmt.Errorf("index-key mismatch")
+			}
+		}
 	}
 	return nil
 }
@@ -144,6 +159,16 @@ func (ls *logstore) GetThread(id thread.ID) (info thread.Info, err error) {
 	if err != nil {
 		return
 	}
+	key := thread.NewKey(sk, rk)
+	if rk == nil {
+		ik, err := ls.IndexKey(id)
+		if err != nil {
+			return info, err
+		}
+		if ik != nil {
+			key = thread.NewIndexKey(sk, ik)
+		}
+	}
 
 	set, err := ls.getLogIDs(id)
 	if err != nil {
@@ -162,7 +187,7 @@ func (ls *logstore) GetThread(id thread.ID) (info thread.Info, err error) {
 	return thread.Info{
 		ID:   id,
 		Logs: logs,
-		Key:  thread.NewKey(sk, rk),
+		Key:  key,
 	}, nil
 }
 
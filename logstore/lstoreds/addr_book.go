@@ -3,6 +3,7 @@ package lstoreds
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
@@ -11,13 +12,13 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
-	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-peerstore/pstoremem"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/logger"
 	pb "github.com/textileio/go-threads/net/pb"
 	"github.com/textileio/go-threads/util"
 	"github.com/whyrusleeping/base32"
@@ -31,7 +32,7 @@ const (
 )
 
 var (
-	log = logging.Logger("logstore")
+	log = logger.Logger("logstore")
 
 	// Thread addresses are stored db key pattern:
 	// /thread/addrs/<b32 thread id no padding>/<b32 log id no padding>
@@ -49,6 +50,7 @@ type DsAddrBook struct {
 	cache       cache
 	gc          *dsAddrBookGc
 	subsManager *pstoremem.AddrSubManager
+	integrity   *integrity
 
 	// controls children goroutine lifetime.
 	childrenDone sync.WaitGroup
@@ -78,17 +80,22 @@ type cacheKey struct {
 // ARC cache, the size of which is adjustable via Options.CacheSize.
 //
 // The user has a choice of two GC algorithms:
-//  - full-purge GC (default): performs a full visit of the store with periodicity Options.GCPurgeInterval. Useful when
-//    the range of possible TTL values is small and the values themselves are also extreme, e.g. 10 minutes or
-//    permanent, popular values used in other libp2p modules. In this cited case, optimizing with lookahead windows
-//    makes little sense.
+//   - full-purge GC (default): performs a full visit of the store with periodicity Options.GCPurgeInterval. Useful when
+//     the range of possible TTL values is small and the values themselves are also extreme, e.g. 10 minutes or
+//     permanent, popular values used in other libp2p modules. In this cited case, optimizing with lookahead windows
+//     makes little sense.
 func NewAddrBook(ctx context.Context, ds ds.Batching, opts Options) (*DsAddrBook, error) {
+	return newAddrBook(ctx, ds, opts, newIntegrity(ds))
+}
+
+func newAddrBook(ctx context.Context, ds ds.Batching, opts Options, integrity *integrity) (*DsAddrBook, error) {
 	ctx, cancelFn := context.WithCancel(ctx)
 	ab := &DsAddrBook{
 		ctx:         ctx,
 		ds:          ds,
 		opts:        opts,
 		subsManager: pstoremem.NewAddrSubManager(),
+		integrity:   integrity,
 		cancelFn:    cancelFn,
 	}
 
@@ -244,7 +251,10 @@ func (ab *DsAddrBook) AddrsEdge(t thread.ID) (uint64, error) {
 	)
 	for entry := range result.Next() {
 		_, pid, addrRec, err := ab.decodeAddrEntry(entry, true)
-		if err != nil {
+		if errors.Is(err, core.ErrCorruptedEntry) {
+			// corrupted addresses are reported, the edge is computed without them
+			continue
+		} else if err != nil {
 			return 0, err
 		}
 		for i := 0; i < len(addrRec.Addrs); i++ {
@@ -302,6 +312,9 @@ func (ab *DsAddrBook) loadRecord(t thread.ID, p peer.ID, cache bool, update bool
 		pr.ThreadID = &pb.ProtoThreadID{ID: t}
 		pr.PeerID = &pb.ProtoPeerID{ID: p}
 	case nil:
+		if data, err = ab.integrity.verify(key, data, logEntry(t, p, core.EntryAddrs)); err != nil {
+			return nil, err
+		}
 		if err = pr.Unmarshal(data); err != nil {
 			return nil, err
 		}
@@ -385,7 +398,7 @@ func (r *addrsRecord) flush(write ds.Write) (err error) {
 	if err != nil {
 		return err
 	}
-	if err = write.Put(key, data); err != nil {
+	if err = write.Put(key, seal(key, data)); err != nil {
 		return err
 	}
 	// write succeeded; record is no longer dirty.
@@ -605,7 +618,9 @@ func (ab *DsAddrBook) traverse(withAddrs bool) (map[thread.ID]map[peer.ID]*pb.Ad
 
 	for entry := range result.Next() {
 		tid, pid, record, err := ab.decodeAddrEntry(entry, withAddrs)
-		if err != nil {
+		if errors.Is(err, core.ErrCorruptedEntry) {
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
@@ -642,8 +657,14 @@ func (ab *DsAddrBook) decodeAddrEntry(
 		return
 	}
 	if withAddrs {
-		var pr = &addrsRecord{AddrBookRecord: &pb.AddrBookRecord{}}
-		if err = pr.Unmarshal(entry.Value); err != nil {
+		var (
+			pr = &addrsRecord{AddrBookRecord: &pb.AddrBookRecord{}}
+			v  []byte
+		)
+		if v, err = ab.integrity.verify(ds.RawKey(entry.Key), entry.Value, logEntry(tid, pid, core.EntryAddrs)); err != nil {
+			return
+		}
+		if err = pr.Unmarshal(v); err != nil {
 			err = fmt.Errorf("cannot decode addressbook record: %w", err)
 			return
 		}
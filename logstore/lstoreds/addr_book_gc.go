@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	query "github.com/ipfs/go-datastore/query"
 	pb "github.com/textileio/go-threads/net/pb"
 )
@@ -96,7 +97,12 @@ func (gc *dsAddrBookGc) purgeStore() {
 	// keys: 	/thread/addrs/<thread ID b32>
 	for result := range results.Next() {
 		record.Reset()
-		if err = record.Unmarshal(result.Value); err != nil {
+		value, err := unseal(ds.RawKey(result.Key), result.Value)
+		if err != nil {
+			// corrupted entries are reported once read by the book
+			continue
+		}
+		if err = record.Unmarshal(value); err != nil {
 			log.Warnf("key %v has an unmarshable record", result.Key)
 			continue
 		}
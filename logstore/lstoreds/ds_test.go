@@ -1,15 +1,22 @@
 package lstoreds
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
 	badger "github.com/textileio/go-ds-badger"
 	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
 	pt "github.com/textileio/go-threads/test"
 )
 
@@ -78,6 +85,226 @@ func TestDatastoreMetadataBook(t *testing.T) {
 	}
 }
 
+func TestIntegrity(t *testing.T) {
+	store, closeStore := badgerStore(t)
+	defer closeStore()
+	ls, err := NewLogstore(context.Background(), store, DefaultOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ls.Close()
+
+	events := make(chan core.IntegrityEvent, 10)
+	ic := ls.(core.IntegrityChecker)
+	ic.NotifyIntegrity(func(ev core.IntegrityEvent) { events <- ev })
+
+	tid := thread.NewIDV1(thread.Raw, 32)
+	_, pk, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := thread.Head{ID: testCid(t, "head"), Counter: 1}
+	if err := ls.AddPubKey(tid, lid, pk); err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.AddHead(tid, lid, head); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("DetectCorrupted", func(t *testing.T) {
+		key := dsLogKey(tid, lid, hbBase)
+		v, err := store.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v[len(v)-1] ^= 0xff
+		if err := store.Put(key, v); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ls.Heads(tid, lid); !errors.Is(err, core.ErrCorruptedEntry) {
+			t.Fatalf("expected corrupted entry error, got: %v", err)
+		}
+		select {
+		case ev := <-events:
+			if ev.ThreadID != tid || ev.LogID != lid || ev.Entry != core.EntryHeads || ev.Repaired {
+				t.Fatalf("unexpected integrity event: %+v", ev)
+			}
+		default:
+			t.Fatal("integrity event not received")
+		}
+		if _, err := ls.PubKey(tid, lid); err != nil {
+			t.Fatalf("intact entry reported: %v", err)
+		}
+
+		corrupted, err := ic.Corrupted()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(corrupted) != 1 || corrupted[0].Entry != core.EntryHeads || corrupted[0].LogID != lid {
+			t.Fatalf("unexpected corrupted entries: %+v", corrupted)
+		}
+	})
+
+	t.Run("Rewritten", func(t *testing.T) {
+		if err := ls.SetHead(tid, lid, head); err != nil {
+			t.Fatal(err)
+		}
+		heads, err := ls.Heads(tid, lid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(heads) != 1 || !heads[0].ID.Equals(head.ID) {
+			t.Fatalf("unexpected heads: %v", heads)
+		}
+		corrupted, err := ic.Corrupted()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(corrupted) != 0 {
+			t.Fatalf("expected no corrupted entries, got: %+v", corrupted)
+		}
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		key := dsLogKey(tid, lid, kbBase).Child(pubSuffix)
+		pkb, err := pk.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Put(key, pkb); err != nil {
+			t.Fatal(err)
+		}
+		if stored, err := ls.PubKey(tid, lid); err != nil || !stored.Equals(pk) {
+			t.Fatalf("legacy entry not read: %v", err)
+		}
+
+		// legacy entries are sealed by the first schema migration
+		if err := store.Delete(schemaVersionKey); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Migrate(store, MigrateApply); err != nil {
+			t.Fatal(err)
+		}
+		v, err := store.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(v, checksumMagic) {
+			t.Fatal("legacy entry not sealed")
+		}
+		if stored, err := ls.PubKey(tid, lid); err != nil || !stored.Equals(pk) {
+			t.Fatalf("sealed entry not read: %v", err)
+		}
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	store, closeStore := badgerStore(t)
+	defer closeStore()
+
+	// a legacy datastore, with an unsealed entry and no schema version
+	tid := thread.NewIDV1(thread.Raw, 32)
+	_, pk, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := dsLogKey(tid, lid, kbBase).Child(pubSuffix)
+	pkb, err := pk.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(key, pkb); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(sealedKey, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		opts := DefaultOpts()
+		opts.Migration = MigrateDryRun
+		if _, err := NewLogstore(context.Background(), store, opts); !errors.Is(err, ErrMigrationsPending) {
+			t.Fatalf("expected pending migrations, got: %v", err)
+		}
+		steps, err := Migrate(store, MigrateDryRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps) != 1 || steps[0].Version != 1 || steps[0].Puts != 1 || steps[0].Deletes != 1 {
+			t.Fatalf("unexpected pending migrations: %+v", steps)
+		}
+		if v, err := storedSchemaVersion(store); err != nil || v != 0 {
+			t.Fatalf("dry run changed schema version to %d: %v", v, err)
+		}
+		if v, err := store.Get(key); err != nil || !bytes.Equal(v, pkb) {
+			t.Fatalf("dry run changed entry: %v", err)
+		}
+	})
+
+	t.Run("Backup", func(t *testing.T) {
+		opts := DefaultOpts()
+		opts.Migration = MigrateBackup
+		ls, err := NewLogstore(context.Background(), store, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ls.Close()
+		if stored, err := ls.PubKey(tid, lid); err != nil || !stored.Equals(pk) {
+			t.Fatalf("migrated entry not read: %v", err)
+		}
+		if v, err := storedSchemaVersion(store); err != nil || v != SchemaVersion() {
+			t.Fatalf("unexpected schema version %d: %v", v, err)
+		}
+		if has, err := store.Has(sealedKey); err != nil || has {
+			t.Fatalf("legacy marker not deleted: %v", err)
+		}
+		if steps, err := Migrate(store, MigrateApply); err != nil || len(steps) != 0 {
+			t.Fatalf("expected migrated datastore to be up to date, got %+v: %v", steps, err)
+		}
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		if err := RestoreBackup(store, 5); !errors.Is(err, ErrBackupNotFound) {
+			t.Fatalf("expected missing backup, got: %v", err)
+		}
+		if err := RestoreBackup(store, 0); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := store.Get(key); err != nil || !bytes.Equal(v, pkb) {
+			t.Fatalf("entry not restored: %v", err)
+		}
+		if v, err := storedSchemaVersion(store); err != nil || v != 0 {
+			t.Fatalf("schema version not restored, got %d: %v", v, err)
+		}
+	})
+
+	t.Run("TooNew", func(t *testing.T) {
+		if err := store.Put(schemaVersionKey, encodeSchemaVersion(SchemaVersion()+1)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewLogstore(context.Background(), store, DefaultOpts()); !errors.Is(err, ErrSchemaTooNew) {
+			t.Fatalf("expected schema to be too new, got: %v", err)
+		}
+	})
+}
+
+func testCid(t *testing.T, data string) cid.Cid {
+	h, err := mh.Sum([]byte(data), mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cid.NewCidV1(cid.Raw, h)
+}
+
 func logstoreFactory(tb testing.TB, storeFactory datastoreFactory, opts Options) pt.LogstoreFactory {
 	return func() (core.Logstore, func()) {
 		store, closeFunc := storeFactory(tb)
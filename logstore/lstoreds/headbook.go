@@ -20,7 +20,8 @@ import (
 )
 
 type dsHeadBook struct {
-	ds ds.TxnDatastore
+	ds        ds.TxnDatastore
+	integrity *integrity
 }
 
 var (
@@ -37,8 +38,13 @@ var (
 
 // NewHeadBook returns a new HeadBook backed by a datastore.
 func NewHeadBook(ds ds.TxnDatastore) core.HeadBook {
+	return newHeadBook(ds, newIntegrity(ds))
+}
+
+func newHeadBook(ds ds.TxnDatastore, integrity *integrity) *dsHeadBook {
 	return &dsHeadBook{
-		ds: ds,
+		ds:        ds,
+		integrity: integrity,
 	}
 }
 
@@ -58,6 +64,9 @@ func (hb *dsHeadBook) AddHeads(t thread.ID, p peer.ID, heads []thread.Head) erro
 	hr := pb.HeadBookRecord{}
 	v, err := txn.Get(key)
 	if err == nil {
+		if v, err = hb.integrity.verify(key, v, logEntry(t, p, core.EntryHeads)); err != nil {
+			return err
+		}
 		if err := proto.Unmarshal(v, &hr); err != nil {
 			return fmt.Errorf("error unmarshaling headbookrecord proto: %w", err)
 		}
@@ -82,7 +91,7 @@ func (hb *dsHeadBook) AddHeads(t thread.ID, p peer.ID, heads []thread.Head) erro
 	}
 	if data, err := proto.Marshal(&hr); err != nil {
 		return fmt.Errorf("error when marshaling headbookrecord proto for %v: %w", key, err)
-	} else if err = txn.Put(key, data); err != nil {
+	} else if err = txn.Put(key, seal(key, data)); err != nil {
 		return fmt.Errorf("error when saving new head record in datastore for %v: %v", key, err)
 	} else if err := hb.invalidateEdge(txn, t); err != nil {
 		return fmt.Errorf("edge invalidation failed for thread %v: %w", t, err)
@@ -120,7 +129,7 @@ func (hb *dsHeadBook) SetHeads(t thread.ID, p peer.ID, heads []thread.Head) erro
 
 	if data, err := proto.Marshal(&hr); err != nil {
 		return fmt.Errorf("error when marshaling headbookrecord proto for %v: %w", key, err)
-	} else if err = txn.Put(key, data); err != nil {
+	} else if err = txn.Put(key, seal(key, data)); err != nil {
 		return fmt.Errorf("error when saving new head record in datastore for %v: %w", key, err)
 	} else if err := hb.invalidateEdge(txn, t); err != nil {
 		return fmt.Errorf("edge invalidation failed for thread %v: %w", t, err)
@@ -137,6 +146,9 @@ func (hb *dsHeadBook) Heads(t thread.ID, p peer.ID) ([]thread.Head, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when getting current heads from log %s: %w", key, err)
 	}
+	if v, err = hb.integrity.verify(key, v, logEntry(t, p, core.EntryHeads)); err != nil {
+		return nil, err
+	}
 	hr := pb.HeadBookRecord{}
 	if err := proto.Unmarshal(v, &hr); err != nil {
 		return nil, fmt.Errorf("error unmarshaling headbookrecord proto: %v", err)
@@ -205,7 +217,10 @@ func (hb *dsHeadBook) getEdge(tid thread.ID, key ds.Key) (uint64, error) {
 	var hs []util.LogHead
 	for entry := range result.Next() {
 		_, lid, heads, err := hb.decodeHeadEntry(entry, true)
-		if err != nil {
+		if errors.Is(err, core.ErrCorruptedEntry) {
+			// corrupted heads are reported, the edge is computed without them
+			continue
+		} else if err != nil {
 			return 0, err
 		}
 		for i := 0; i < len(heads); i++ {
@@ -283,7 +298,9 @@ func (hb *dsHeadBook) traverse(withHeads bool) (map[thread.ID]map[peer.ID][]thre
 
 	for entry := range result.Next() {
 		tid, lid, heads, err := hb.decodeHeadEntry(entry, withHeads)
-		if err != nil {
+		if errors.Is(err, core.ErrCorruptedEntry) {
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
@@ -319,8 +336,14 @@ func (hb *dsHeadBook) decodeHeadEntry(
 		return
 	}
 	if withHeads {
-		var hr pb.HeadBookRecord
-		if err = proto.Unmarshal(entry.Value, &hr); err != nil {
+		var (
+			hr pb.HeadBookRecord
+			v  []byte
+		)
+		if v, err = hb.integrity.verify(ds.RawKey(entry.Key), entry.Value, logEntry(tid, lid, core.EntryHeads)); err != nil {
+			return
+		}
+		if err = proto.Unmarshal(v, &hr); err != nil {
 			err = fmt.Errorf("cannot decode headbook record: %w", err)
 			return
 		}
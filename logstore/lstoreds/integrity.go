@@ -0,0 +1,197 @@
+package lstoreds
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sync/atomic"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/whyrusleeping/base32"
+)
+
+var (
+	// Corrupted entries are marked in db key pattern:
+	// /thread/corrupted/<entry kind>/<b32 thread id no padding>[/<b32 log id no padding>]
+	corruptedBase = ds.NewKey("/thread/corrupted")
+
+	// Legacy entries without checksums were sealed once, which was recorded in db key:
+	// /thread/integrity:sealed
+	sealedKey = ds.NewKey("/thread/integrity:sealed")
+
+	// checksumMagic prefixes entries sealed with a checksum. Its leading zero byte never
+	// starts the protobuf-encoded entries which were written before checksums were added.
+	checksumMagic = []byte{0x00, 0x74, 0x63, 0x01}
+
+	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+	_ core.IntegrityChecker = (*integrity)(nil)
+)
+
+// seal prepends a checksum of the entry key and value, so entries which were
+// damaged or moved to another key are detected on read.
+func seal(key ds.Key, value []byte) []byte {
+	sealed := make([]byte, len(checksumMagic)+crc32.Size, len(checksumMagic)+crc32.Size+len(value))
+	copy(sealed, checksumMagic)
+	binary.BigEndian.PutUint32(sealed[len(checksumMagic):], checksum(key, value))
+	return append(sealed, value...)
+}
+
+// unseal verifies and strips the checksum of an entry.
+// Entries written before checksums were added are returned as is.
+func unseal(key ds.Key, stored []byte) ([]byte, error) {
+	if !bytes.HasPrefix(stored, checksumMagic) {
+		return stored, nil
+	}
+	var header = len(checksumMagic) + crc32.Size
+	if len(stored) < header {
+		return nil, core.ErrCorruptedEntry
+	}
+	value := stored[header:]
+	if binary.BigEndian.Uint32(stored[len(checksumMagic):header]) != checksum(key, value) {
+		return nil, core.ErrCorruptedEntry
+	}
+	return value, nil
+}
+
+func checksum(key ds.Key, value []byte) uint32 {
+	h := crc32.New(castagnoli)
+	_, _ = h.Write(key.Bytes())
+	_, _ = h.Write(value)
+	return h.Sum32()
+}
+
+// integrity reports corrupted entries of the books sharing a datastore,
+// and keeps them marked until they're rewritten.
+type integrity struct {
+	ds      ds.Datastore
+	handler atomic.Value
+}
+
+func newIntegrity(store ds.Datastore) *integrity {
+	return &integrity{ds: store}
+}
+
+// NotifyIntegrity sets the handler of integrity events. It's called on the
+// reading goroutine, so it must not block.
+func (i *integrity) NotifyIntegrity(h core.IntegrityHandler) {
+	i.handler.Store(h)
+}
+
+// Corrupted returns the marked entries which are still corrupted.
+// Marks of entries which were rewritten or deleted are dropped.
+func (i *integrity) Corrupted() ([]core.IntegrityEvent, error) {
+	results, err := i.ds.Query(query.Query{Prefix: corruptedBase.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var events []core.IntegrityEvent
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		mark := ds.RawKey(result.Key)
+		ev, err := parseCorruptedMark(mark)
+		if err != nil {
+			log.Warnf("dropping bad corruption mark %s: %v", mark, err)
+			_ = i.ds.Delete(mark)
+			continue
+		}
+		key := ds.RawKey(string(result.Value))
+		v, err := i.ds.Get(key)
+		if err == nil {
+			if _, err = unseal(key, v); err != nil {
+				events = append(events, ev)
+				continue
+			}
+		} else if err != ds.ErrNotFound {
+			return nil, err
+		}
+		if err := i.ds.Delete(mark); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// verify unseals an entry which was read from the datastore. Corrupted entries
+// are marked and reported, the returned error wraps core.ErrCorruptedEntry.
+func (i *integrity) verify(key ds.Key, stored []byte, ev core.IntegrityEvent) ([]byte, error) {
+	value, err := unseal(key, stored)
+	if err == nil {
+		return value, nil
+	}
+	log.Errorf("corrupted %s entry detected: %s", ev.Entry, key)
+	if err := i.ds.Put(corruptedMark(ev), key.Bytes()); err != nil {
+		log.Errorf("marking corrupted entry %s failed: %v", key, err)
+	}
+	if h, ok := i.handler.Load().(core.IntegrityHandler); ok && h != nil {
+		h(ev)
+	}
+	return nil, fmt.Errorf("%w: %s", core.ErrCorruptedEntry, key)
+}
+
+func corruptedMark(ev core.IntegrityEvent) ds.Key {
+	key := corruptedBase.ChildString(string(ev.Entry)).
+		ChildString(base32.RawStdEncoding.EncodeToString(ev.ThreadID.Bytes()))
+	if ev.LogID != "" {
+		key = key.ChildString(base32.RawStdEncoding.EncodeToString([]byte(ev.LogID)))
+	}
+	return key
+}
+
+func parseCorruptedMark(mark ds.Key) (ev core.IntegrityEvent, err error) {
+	kns := mark.Namespaces()
+	if len(kns) != 4 && len(kns) != 5 {
+		return ev, fmt.Errorf("unexpected key length")
+	}
+	ev.Entry = core.EntryKind(kns[2])
+	if ev.ThreadID, err = parseThreadID(kns[3]); err != nil {
+		return ev, err
+	}
+	if len(kns) == 5 {
+		if ev.LogID, err = parseLogID(kns[4]); err != nil {
+			return ev, err
+		}
+	}
+	return ev, nil
+}
+
+// sealLegacyEntries adds checksums to the key, address, and head entries which
+// were written before checksums were added. It's the first schema migration, which
+// replaced the marker of datastores sealed before migrations were added.
+func sealLegacyEntries(r ds.Read, w ds.Write) error {
+	for _, prefix := range []ds.Key{kbBase, hbBase, logBookBase} {
+		if err := forEachEntry(r, prefix, func(key ds.Key, value []byte) error {
+			if bytes.HasPrefix(value, checksumMagic) {
+				return nil
+			}
+			return w.Put(key, seal(key, value))
+		}); err != nil {
+			return err
+		}
+	}
+	if done, err := r.Has(sealedKey); err != nil {
+		return err
+	} else if done {
+		return w.Delete(sealedKey)
+	}
+	return nil
+}
+
+// threadEntry returns an integrity event template for a thread entry.
+func threadEntry(t thread.ID, kind core.EntryKind) core.IntegrityEvent {
+	return core.IntegrityEvent{ThreadID: t, Entry: kind}
+}
+
+// logEntry returns an integrity event template for a log entry.
+func logEntry(t thread.ID, p peer.ID, kind core.EntryKind) core.IntegrityEvent {
+	return core.IntegrityEvent{ThreadID: t, LogID: p, Entry: kind}
+}
@@ -14,19 +14,21 @@ import (
 )
 
 type dsKeyBook struct {
-	ds ds.Datastore
+	ds        ds.Datastore
+	integrity *integrity
 }
 
 // Public and private keys are stored under the following db key pattern:
 // /threads/keys/<b32 thread id no padding>/<b32 log id no padding>/(pub|priv)
-// Follow and read keys are stored under the following db key pattern:
-// /threads/keys/<b32 thread id no padding>/(service|read)
+// Follow, read and index keys are stored under the following db key pattern:
+// /threads/keys/<b32 thread id no padding>/(service|read|index)
 var (
 	kbBase        = ds.NewKey("/thread/keys")
 	pubSuffix     = ds.NewKey("/pub")
 	privSuffix    = ds.NewKey("/priv")
 	readSuffix    = ds.NewKey("/read")
 	serviceSuffix = ds.NewKey("/service")
+	indexSuffix   = ds.NewKey("/index")
 )
 
 var _ core.KeyBook = (*dsKeyBook)(nil)
@@ -34,7 +36,11 @@ var _ core.KeyBook = (*dsKeyBook)(nil)
 // NewKeyBook returns a new key book for storing public and private keys
 // of (thread.ID, peer.ID) pairs with durable guarantees by store.
 func NewKeyBook(store ds.Datastore) (core.KeyBook, error) {
-	return &dsKeyBook{ds: store}, nil
+	return newKeyBook(store, newIntegrity(store)), nil
+}
+
+func newKeyBook(store ds.Datastore, integrity *integrity) *dsKeyBook {
+	return &dsKeyBook{ds: store, integrity: integrity}
 }
 
 // PubKey returns the public key of (thread.ID, peer.ID). The implementation
@@ -50,6 +56,9 @@ func (kb *dsKeyBook) PubKey(t thread.ID, p peer.ID) (crypto.PubKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when getting key %s from store: %v", key, err)
 	}
+	if v, err = kb.integrity.verify(key, v, logEntry(t, p, core.EntryPubKey)); err != nil {
+		return nil, err
+	}
 	pk, err := crypto.UnmarshalPublicKey(v)
 	if err != nil {
 		return nil, fmt.Errorf("store backed public key %s can't be unmarshaled: %w", key, err)
@@ -72,7 +81,7 @@ func (kb *dsKeyBook) AddPubKey(t thread.ID, p peer.ID, pk crypto.PubKey) error {
 		return fmt.Errorf("error when getting bytes from public key: %w", err)
 	}
 	key := dsLogKey(t, p, kbBase).Child(pubSuffix)
-	if kb.ds.Put(key, val) != nil {
+	if kb.ds.Put(key, seal(key, val)) != nil {
 		return fmt.Errorf("error when putting public key in store: %w", err)
 	}
 	return nil
@@ -89,6 +98,9 @@ func (kb *dsKeyBook) PrivKey(t thread.ID, p peer.ID) (crypto.PrivKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when getting private key for %s", key)
 	}
+	if v, err = kb.integrity.verify(key, v, logEntry(t, p, core.EntryPrivKey)); err != nil {
+		return nil, err
+	}
 	sk, err := crypto.UnmarshalPrivateKey(v)
 	if err != nil {
 		return nil, fmt.Errorf("error when unmarshaling private key of %v", key)
@@ -109,7 +121,7 @@ func (kb *dsKeyBook) AddPrivKey(t thread.ID, p peer.ID, sk crypto.PrivKey) error
 		return fmt.Errorf("error when getting private key bytes: %w", err)
 	}
 	key := dsLogKey(t, p, kbBase).Child(privSuffix)
-	if err = kb.ds.Put(key, skb); err != nil {
+	if err = kb.ds.Put(key, seal(key, skb)); err != nil {
 		return fmt.Errorf("error when putting key %v in datastore: %w", key, err)
 	}
 	return nil
@@ -126,6 +138,9 @@ func (kb *dsKeyBook) ReadKey(t thread.ID) (*sym.Key, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when getting read-key from datastore: %v", err)
 	}
+	if v, err = kb.integrity.verify(key, v, threadEntry(t, core.EntryReadKey)); err != nil {
+		return nil, err
+	}
 	return sym.FromBytes(v)
 }
 
@@ -135,7 +150,7 @@ func (kb *dsKeyBook) AddReadKey(t thread.ID, rk *sym.Key) error {
 		return fmt.Errorf("read-key is nil")
 	}
 	key := dsThreadKey(t, kbBase).Child(readSuffix)
-	if err := kb.ds.Put(key, rk.Bytes()); err != nil {
+	if err := kb.ds.Put(key, seal(key, rk.Bytes())); err != nil {
 		return fmt.Errorf("error when adding read-key to datastore: %w", err)
 	}
 	return nil
@@ -153,6 +168,9 @@ func (kb *dsKeyBook) ServiceKey(t thread.ID) (*sym.Key, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when getting service-key from datastore: %v", err)
 	}
+	if v, err = kb.integrity.verify(key, v, threadEntry(t, core.EntryServiceKey)); err != nil {
+		return nil, err
+	}
 	return sym.FromBytes(v)
 }
 
@@ -162,12 +180,41 @@ func (kb *dsKeyBook) AddServiceKey(t thread.ID, fk *sym.Key) error {
 		return fmt.Errorf("service-key is nil")
 	}
 	key := dsThreadKey(t, kbBase).Child(serviceSuffix)
-	if err := kb.ds.Put(key, fk.Bytes()); err != nil {
+	if err := kb.ds.Put(key, seal(key, fk.Bytes())); err != nil {
 		return fmt.Errorf("error when adding service-key to datastore: %w", err)
 	}
 	return nil
 }
 
+// IndexKey returns the index-key associated with thread.ID.
+// In case it doesn't exist, it will return nil.
+func (kb *dsKeyBook) IndexKey(t thread.ID) (*sym.Key, error) {
+	key := dsThreadKey(t, kbBase).Child(indexSuffix)
+	v, err := kb.ds.Get(key)
+	if err == ds.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error when getting index-key from datastore: %v", err)
+	}
+	if v, err = kb.integrity.verify(key, v, threadEntry(t, core.EntryIndexKey)); err != nil {
+		return nil, err
+	}
+	return sym.FromBytes(v)
+}
+
+// AddIndexKey adds an index-key for a thread.ID.
+func (kb *dsKeyBook) AddIndexKey(t thread.ID, ik *sym.Key) error {
+	if ik == nil {
+		return fmt.Errorf("index-key is nil")
+	}
+	key := dsThreadKey(t, kbBase).Child(indexSuffix)
+	if err := kb.ds.Put(key, seal(key, ik.Bytes())); err != nil {
+		return fmt.Errorf("error when adding index-key to datastore: %w", err)
+	}
+	return nil
+}
+
 // ClearKeys deletes all keys under a thread.
 func (kb *dsKeyBook) ClearKeys(t thread.ID) error {
 	return kb.clearKeys(dsThreadKey(t, kbBase))
@@ -230,6 +277,7 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 		priv = make(map[thread.ID]map[peer.ID]crypto.PrivKey)
 		rks  = make(map[thread.ID][]byte)
 		sks  = make(map[thread.ID][]byte)
+		iks  = make(map[thread.ID][]byte)
 	)
 
 	result, err := kb.ds.Query(query.Query{Prefix: kbBase.String(), KeysOnly: false})
@@ -238,6 +286,7 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 	}
 	defer result.Close()
 
+	// corrupted keys are reported and left out of the dump
 	for entry := range result.Next() {
 		kns := ds.RawKey(entry.Key).Namespaces()
 		if len(kns) < 4 {
@@ -256,7 +305,11 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 			if err != nil {
 				return dump, fmt.Errorf("cannot parse log ID %s: %w", ls, err)
 			}
-			pk, err := crypto.UnmarshalPublicKey(entry.Value)
+			v, err := kb.integrity.verify(ds.RawKey(entry.Key), entry.Value, logEntry(tid, lid, core.EntryPubKey))
+			if err != nil {
+				continue
+			}
+			pk, err := crypto.UnmarshalPublicKey(v)
 			if err != nil {
 				return dump, fmt.Errorf("cannot unmarshal public key: %w", err)
 			}
@@ -277,7 +330,11 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 			if err != nil {
 				return dump, fmt.Errorf("cannot parse log ID %s: %w", ls, err)
 			}
-			pk, err := crypto.UnmarshalPrivateKey(entry.Value)
+			v, err := kb.integrity.verify(ds.RawKey(entry.Key), entry.Value, logEntry(tid, lid, core.EntryPrivKey))
+			if err != nil {
+				continue
+			}
+			pk, err := crypto.UnmarshalPrivateKey(v)
 			if err != nil {
 				return dump, fmt.Errorf("cannot unmarshal private key: %w", err)
 			}
@@ -294,7 +351,11 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 			if err != nil {
 				return dump, fmt.Errorf("cannot restore thread ID %s: %w", ts, err)
 			}
-			rks[tid] = entry.Value
+			v, err := kb.integrity.verify(ds.RawKey(entry.Key), entry.Value, threadEntry(tid, core.EntryReadKey))
+			if err != nil {
+				continue
+			}
+			rks[tid] = v
 
 		case serviceSuffix.String():
 			ts := kns[2]
@@ -302,7 +363,23 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 			if err != nil {
 				return dump, fmt.Errorf("cannot restore thread ID %s: %w", ts, err)
 			}
-			sks[tid] = entry.Value
+			v, err := kb.integrity.verify(ds.RawKey(entry.Key), entry.Value, threadEntry(tid, core.EntryServiceKey))
+			if err != nil {
+				continue
+			}
+			sks[tid] = v
+
+		case indexSuffix.String():
+			ts := kns[2]
+			tid, err := parseThreadID(ts)
+			if err != nil {
+				return dump, fmt.Errorf("cannot restore thread ID %s: %w", ts, err)
+			}
+			v, err := kb.integrity.verify(ds.RawKey(entry.Key), entry.Value, threadEntry(tid, core.EntryIndexKey))
+			if err != nil {
+				continue
+			}
+			iks[tid] = v
 
 		default:
 			return dump, fmt.Errorf("bad suffix %s in a key: %s", suffix, entry.Key)
@@ -313,6 +390,7 @@ func (kb *dsKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 	dump.Data.Private = priv
 	dump.Data.Read = rks
 	dump.Data.Service = sks
+	dump.Data.Index = iks
 
 	return dump, nil
 }
@@ -322,7 +400,8 @@ func (kb *dsKeyBook) RestoreKeys(dump core.DumpKeyBook) error {
 		len(dump.Data.Public) == 0 &&
 		len(dump.Data.Private) == 0 &&
 		len(dump.Data.Read) == 0 &&
-		len(dump.Data.Service) == 0 {
+		len(dump.Data.Service) == 0 &&
+		len(dump.Data.Index) == 0 {
 		return core.ErrEmptyDump
 	}
 
@@ -367,5 +446,15 @@ func (kb *dsKeyBook) RestoreKeys(dump core.DumpKeyBook) error {
 		}
 	}
 
+	for tid, ik := range dump.Data.Index {
+		key, err := sym.FromBytes(ik)
+		if err != nil {
+			return fmt.Errorf("decoding index key for thread %s: %w", tid, err)
+		}
+		if err := kb.AddIndexKey(tid, key); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package lstoreds
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	ds "github.com/ipfs/go-datastore"
@@ -30,6 +31,9 @@ type Options struct {
 	// Initial delay before GC processes start. Intended to give the system breathing room to fully boot
 	// before starting GC.
 	GCInitialDelay time.Duration
+
+	// How pending schema migrations are handled when the logstore is opened.
+	Migration MigrationMode
 }
 
 // DefaultOpts returns the default options for a persistent peerstore, with the full-purge GC algorithm:
@@ -37,32 +41,59 @@ type Options struct {
 // * Cache size: 1024.
 // * GC purge interval: 2 hours.
 // * GC initial delay: 60 seconds.
+// * Migration: apply.
 func DefaultOpts() Options {
 	return Options{
 		CacheSize:       1024,
 		GCPurgeInterval: 2 * time.Hour,
 		GCInitialDelay:  60 * time.Second,
+		Migration:       MigrateApply,
 	}
 }
 
+// dsLogstore is a logstore which verifies the checksums of stored entries.
+type dsLogstore struct {
+	core.Logstore
+	*integrity
+}
+
+var _ core.IntegrityChecker = (*dsLogstore)(nil)
+
 // NewLogstore creates a logstore backed by the provided persistent datastore.
+// Key, address, and head entries are stored with checksums, corrupted entries
+// are reported with the handler set by NotifyIntegrity.
+// The key layout is migrated to SchemaVersion according to opts.Migration.
 func NewLogstore(ctx context.Context, store ds.Batching, opts Options) (core.Logstore, error) {
-	addrBook, err := NewAddrBook(ctx, store, opts)
+	if len(opts.Migration) == 0 {
+		opts.Migration = MigrateApply
+	}
+	steps, err := Migrate(store, opts.Migration)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("migrating logstore: %w", err)
+	}
+	if opts.Migration == MigrateDryRun && len(steps) > 0 {
+		for _, s := range steps {
+			log.Infof("pending logstore migration to schema version %d: %s (%d puts, %d deletes)",
+				s.Version, s.Description, s.Puts, s.Deletes)
+		}
+		return nil, fmt.Errorf("%w: schema version %d to %d",
+			ErrMigrationsPending, steps[0].Version-1, SchemaVersion())
 	}
 
-	keyBook, err := NewKeyBook(store)
+	integrity := newIntegrity(store)
+	addrBook, err := newAddrBook(ctx, store, opts, integrity)
 	if err != nil {
 		return nil, err
 	}
 
+	keyBook := newKeyBook(store, integrity)
+
 	threadMetadata := NewThreadMetadata(store)
 
-	headBook := NewHeadBook(store.(ds.TxnDatastore))
+	headBook := newHeadBook(store.(ds.TxnDatastore), integrity)
 
 	ps := lstore.NewLogstore(keyBook, addrBook, headBook, threadMetadata)
-	return ps, nil
+	return &dsLogstore{Logstore: ps, integrity: integrity}, nil
 }
 
 // uniqueThreadIds extracts and returns unique thread IDs from database keys.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/gob"
 	"fmt"
+	"sync"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
@@ -21,6 +22,8 @@ var (
 
 type dsThreadMetadata struct {
 	ds ds.Datastore
+	// swapLock serializes swaps of datastores without transactions
+	swapLock sync.Mutex
 }
 
 func NewThreadMetadata(ds ds.Datastore) core.ThreadMetadata {
@@ -61,6 +64,54 @@ func (m *dsThreadMetadata) PutString(t thread.ID, key string, val string) error
 	return m.setValue(t, key, val)
 }
 
+func (m *dsThreadMetadata) CompareAndSwapString(t thread.ID, key string, old *string, val string) (bool, error) {
+	m.swapLock.Lock()
+	defer m.swapLock.Unlock()
+	var (
+		rw     ds.Write = m.ds
+		get             = m.ds.Get
+		commit          = func() error { return nil }
+	)
+	if tds, ok := m.ds.(ds.TxnDatastore); ok {
+		txn, err := tds.NewTransaction(false)
+		if err != nil {
+			return false, fmt.Errorf("error when starting metadata transaction: %w", err)
+		}
+		defer txn.Discard()
+		rw, get, commit = txn, txn.Get, txn.Commit
+	}
+
+	k := keyMeta(t, key)
+	v, err := get(k)
+	if err == ds.ErrNotFound {
+		if old != nil {
+			return false, nil
+		}
+	} else if err != nil {
+		return false, fmt.Errorf("error when getting key from meta datastore: %w", err)
+	} else {
+		var cur string
+		if err := gob.NewDecoder(bytes.NewReader(v)).Decode(&cur); err != nil {
+			return false, fmt.Errorf("error when deserializing value in datastore for %s: %v", key, err)
+		}
+		if old == nil || cur != *old {
+			return false, nil
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(val); err != nil {
+		return false, fmt.Errorf("error when marshaling value: %w", err)
+	}
+	if err := rw.Put(k, buf.Bytes()); err != nil {
+		return false, fmt.Errorf("error when saving marshaled value in datastore: %w", err)
+	}
+	if err := commit(); err != nil {
+		return false, fmt.Errorf("error when committing metadata transaction: %w", err)
+	}
+	return true, nil
+}
+
 func (m *dsThreadMetadata) GetBool(t thread.ID, key string) (*bool, error) {
 	var val bool
 	err := m.getValue(t, key, &val)
@@ -0,0 +1,288 @@
+package lstoreds
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+var (
+	// ErrUnsupportedMigrationMode indicates an unknown migration mode.
+	ErrUnsupportedMigrationMode = errors.New("unsupported migration mode")
+
+	// ErrMigrationsPending indicates a dry run found migrations which must be applied
+	// before the datastore can be opened.
+	ErrMigrationsPending = errors.New("logstore migrations pending")
+
+	// ErrSchemaTooNew indicates the datastore was migrated by a newer version.
+	ErrSchemaTooNew = errors.New("logstore schema is newer than supported")
+
+	// ErrBackupNotFound indicates there's no backup of the requested schema version.
+	ErrBackupNotFound = errors.New("logstore backup not found")
+
+	// The schema version of the key layout is stored in db key:
+	// /thread/schema:version
+	schemaVersionKey = ds.NewKey("/thread/schema:version")
+
+	// Entries are backed up before migrating in db key pattern:
+	// /backup/logstore/v<schema version>/<original key>
+	backupBase = ds.NewKey("/backup/logstore")
+
+	// threadBase prefixes all the entries of the logstore.
+	threadBase = ds.NewKey("/thread")
+
+	errStopIteration = errors.New("stop iteration")
+)
+
+// MigrationMode is how pending migrations are handled when a logstore is opened.
+type MigrationMode string
+
+const (
+	// MigrateApply applies pending migrations.
+	MigrateApply MigrationMode = "apply"
+	// MigrateBackup copies the logstore entries before applying pending migrations,
+	// so they can be restored with RestoreBackup.
+	MigrateBackup MigrationMode = "backup"
+	// MigrateDryRun only reports pending migrations, opening a logstore with pending
+	// migrations fails with ErrMigrationsPending.
+	MigrateDryRun MigrationMode = "dry-run"
+)
+
+// MigrationModes lists the supported migration modes.
+var MigrationModes = []MigrationMode{MigrateApply, MigrateBackup, MigrateDryRun}
+
+// ParseMigrationMode returns the migration mode named s.
+func ParseMigrationMode(s string) (MigrationMode, error) {
+	for _, m := range MigrationModes {
+		if string(m) == s {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedMigrationMode, s)
+}
+
+// migration changes the key layout from the previous schema version to version.
+// Reads are done on the datastore and writes on the batch, which is committed along
+// with the new schema version, so a migration is either fully applied or not at all.
+type migration struct {
+	version     uint64
+	description string
+	migrate     func(r ds.Read, w ds.Write) error
+}
+
+// migrations are the ordered migrations of the key layout.
+// New migrations are appended with the next version, released ones must not change.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "seal legacy key, address, and head entries with checksums",
+		migrate:     sealLegacyEntries,
+	},
+}
+
+// SchemaVersion is the schema version of the key layout written by this package.
+func SchemaVersion() uint64 {
+	return migrations[len(migrations)-1].version
+}
+
+// MigrationStep reports a migration which was applied or found pending.
+type MigrationStep struct {
+	Version     uint64
+	Description string
+	// Puts and Deletes count the entries written and deleted by the migration.
+	// With MigrateDryRun they're counted against the unmigrated layout, so later
+	// steps don't account for the changes of earlier ones.
+	Puts    int
+	Deletes int
+}
+
+// Migrate brings the key layout of a datastore to SchemaVersion, returning the migrations
+// which were applied, or which are pending with MigrateDryRun.
+func Migrate(store ds.Batching, mode MigrationMode) ([]MigrationStep, error) {
+	if _, err := ParseMigrationMode(string(mode)); err != nil {
+		return nil, err
+	}
+	current, err := storedSchemaVersion(store)
+	if err != nil {
+		return nil, err
+	}
+	if current > SchemaVersion() {
+		return nil, fmt.Errorf("%w: %d > %d", ErrSchemaTooNew, current, SchemaVersion())
+	}
+	if current == SchemaVersion() {
+		return nil, nil
+	}
+
+	if mode == MigrateBackup {
+		if err := backup(store, current); err != nil {
+			return nil, fmt.Errorf("backing up schema version %d: %w", current, err)
+		}
+	}
+	var steps []MigrationStep
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		step, err := runMigration(store, m, mode == MigrateDryRun)
+		if err != nil {
+			return steps, fmt.Errorf("migrating to schema version %d: %w", m.version, err)
+		}
+		if mode != MigrateDryRun {
+			log.Infof("migrated logstore to schema version %d: %s (%d puts, %d deletes)",
+				step.Version, step.Description, step.Puts, step.Deletes)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func runMigration(store ds.Batching, m migration, dryRun bool) (MigrationStep, error) {
+	step := MigrationStep{Version: m.version, Description: m.description}
+	var w ds.Write = &discardWrite{}
+	var batch ds.Batch
+	if !dryRun {
+		var err error
+		if batch, err = store.Batch(); err != nil {
+			return step, err
+		}
+		w = batch
+	}
+	cw := &countingWrite{Write: w}
+	if err := m.migrate(store, cw); err != nil {
+		return step, err
+	}
+	step.Puts, step.Deletes = cw.puts, cw.deletes
+	if dryRun {
+		return step, nil
+	}
+	if err := batch.Put(schemaVersionKey, encodeSchemaVersion(m.version)); err != nil {
+		return step, err
+	}
+	return step, batch.Commit()
+}
+
+// RestoreBackup replaces the logstore entries with the backup taken before migrating
+// from the given schema version. The backup is kept.
+func RestoreBackup(store ds.Batching, version uint64) error {
+	prefix := backupKey(version)
+	if found, err := hasPrefix(store, prefix); err != nil {
+		return err
+	} else if !found {
+		return fmt.Errorf("%w: schema version %d", ErrBackupNotFound, version)
+	}
+
+	batch, err := store.Batch()
+	if err != nil {
+		return err
+	}
+	if err := forEachEntry(store, threadBase, func(key ds.Key, _ []byte) error {
+		return batch.Delete(key)
+	}); err != nil {
+		return err
+	}
+	if err := forEachEntry(store, prefix, func(key ds.Key, value []byte) error {
+		return batch.Put(ds.NewKey(key.String()[len(prefix.String()):]), value)
+	}); err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+// backup copies the logstore entries under the backup key of the schema version.
+func backup(store ds.Batching, version uint64) error {
+	batch, err := store.Batch()
+	if err != nil {
+		return err
+	}
+	prefix := backupKey(version)
+	if err := forEachEntry(store, threadBase, func(key ds.Key, value []byte) error {
+		return batch.Put(prefix.Child(key), value)
+	}); err != nil {
+		return err
+	}
+	return batch.Commit()
+}
+
+func backupKey(version uint64) ds.Key {
+	return backupBase.ChildString("v" + strconv.FormatUint(version, 10))
+}
+
+// storedSchemaVersion returns the schema version of a datastore, which is zero for
+// datastores written before schema versions were added.
+func storedSchemaVersion(store ds.Read) (uint64, error) {
+	v, err := store.Get(schemaVersionKey)
+	if err == ds.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if len(v) != 8 {
+		return 0, fmt.Errorf("bad schema version entry of %d bytes", len(v))
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func encodeSchemaVersion(version uint64) []byte {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, version)
+	return v
+}
+
+func forEachEntry(store ds.Read, prefix ds.Key, fn func(key ds.Key, value []byte) error) error {
+	results, err := store.Query(query.Query{Prefix: prefix.String()})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		key := ds.RawKey(result.Key)
+		if !prefix.IsAncestorOf(key) {
+			continue
+		}
+		if err := fn(key, result.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasPrefix(store ds.Read, prefix ds.Key) (found bool, err error) {
+	err = forEachEntry(store, prefix, func(ds.Key, []byte) error {
+		found = true
+		return errStopIteration
+	})
+	if err == errStopIteration {
+		err = nil
+	}
+	return found, err
+}
+
+// countingWrite counts the writes of a migration.
+type countingWrite struct {
+	ds.Write
+	puts, deletes int
+}
+
+func (w *countingWrite) Put(key ds.Key, value []byte) error {
+	w.puts++
+	return w.Write.Put(key, value)
+}
+
+func (w *countingWrite) Delete(key ds.Key) error {
+	w.deletes++
+	return w.Write.Delete(key)
+}
+
+// discardWrite drops the writes of dry runs.
+type discardWrite struct{}
+
+func (discardWrite) Put(ds.Key, []byte) error { return nil }
+
+func (discardWrite) Delete(ds.Key) error { return nil }
@@ -6,8 +6,14 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	lpt "github.com/libp2p/go-libp2p-core/test"
+	mh "github.com/multiformats/go-multihash"
 	badger "github.com/textileio/go-ds-badger"
 	core "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	m "github.com/textileio/go-threads/logstore/lstoremem"
 	pt "github.com/textileio/go-threads/test"
@@ -80,6 +86,111 @@ func TestHybridMetadataBook(t *testing.T) {
 	}
 }
 
+func TestHybridIntegrityRepair(t *testing.T) {
+	dataPath, err := ioutil.TempDir(os.TempDir(), "badger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataPath)
+	backend, err := badger.NewDatastore(dataPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	ps, err := lstoreds.NewLogstore(context.Background(), backend, lstoreds.DefaultOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls, err := NewLogstore(ps, m.NewLogstore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ls.Close()
+
+	events := make(chan core.IntegrityEvent, 10)
+	ls.NotifyIntegrity(func(ev core.IntegrityEvent) { events <- ev })
+
+	tid := thread.NewIDV1(thread.Raw, 32)
+	lid, err := lpt.RandPeerID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := thread.Head{ID: testCid(t, "first"), Counter: 1}
+	if err := ls.AddHead(tid, lid, first); err != nil {
+		t.Fatal(err)
+	}
+
+	// damage the persisted heads entry
+	results, err := backend.Query(query.Query{Prefix: "/thread/heads"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected a single heads entry, got %d", len(entries))
+	}
+	v := entries[0].Value
+	v[len(v)-1] ^= 0xff
+	if err := backend.Put(ds.RawKey(entries[0].Key), v); err != nil {
+		t.Fatal(err)
+	}
+
+	second := thread.Head{ID: testCid(t, "second"), Counter: 2}
+	if err := ls.AddHead(tid, lid, second); err != nil {
+		t.Fatalf("adding head over a corrupted entry failed: %v", err)
+	}
+	select {
+	case ev := <-events:
+		if ev.Entry != core.EntryHeads || ev.LogID != lid || !ev.Repaired {
+			t.Fatalf("unexpected integrity event: %+v", ev)
+		}
+	default:
+		t.Fatal("integrity event not received")
+	}
+
+	heads, err := ps.Heads(tid, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameHeads(heads, first, second) {
+		t.Fatalf("persisted heads not repaired: %v", heads)
+	}
+	corrupted, err := ls.Corrupted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrupted) != 0 {
+		t.Fatalf("expected no corrupted entries, got: %+v", corrupted)
+	}
+}
+
+func sameHeads(heads []thread.Head, expected ...thread.Head) bool {
+	if len(heads) != len(expected) {
+		return false
+	}
+	ids := make(map[cid.Cid]struct{}, len(heads))
+	for _, h := range heads {
+		ids[h.ID] = struct{}{}
+	}
+	for _, h := range expected {
+		if _, ok := ids[h.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func testCid(t *testing.T, data string) cid.Cid {
+	h, err := mh.Sum([]byte(data), mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cid.NewCidV1(cid.Raw, h)
+}
+
 /* store factories */
 
 func logstoreFactory(tb testing.TB, persistF, memF storeFactory) pt.LogstoreFactory {
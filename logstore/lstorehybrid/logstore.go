@@ -2,21 +2,31 @@ package lstorehybrid
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
 	ma "github.com/multiformats/go-multiaddr"
 	core "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/logger"
 )
 
-var _ core.Logstore = (*lstore)(nil)
+var log = logger.Logger("logstore")
+
+var (
+	_ core.Logstore         = (*lstore)(nil)
+	_ core.IntegrityChecker = (*lstore)(nil)
+)
 
 type lstore struct {
 	inMem, persist core.Logstore
+	handler        atomic.Value
 }
 
 func NewLogstore(persist, inMem core.Logstore) (*lstore, error) {
@@ -56,6 +66,61 @@ func NewLogstore(persist, inMem core.Logstore) (*lstore, error) {
 	return &lstore{inMem: inMem, persist: persist}, nil
 }
 
+// NotifyIntegrity sets the handler of integrity events, if the persistent storage
+// checks its entries. Corrupted heads and addresses found while writing are
+// repaired from the in-memory storage.
+func (l *lstore) NotifyIntegrity(h core.IntegrityHandler) {
+	l.handler.Store(h)
+	if ic, ok := l.persist.(core.IntegrityChecker); ok {
+		ic.NotifyIntegrity(l.repair)
+	}
+}
+
+func (l *lstore) Corrupted() ([]core.IntegrityEvent, error) {
+	if ic, ok := l.persist.(core.IntegrityChecker); ok {
+		return ic.Corrupted()
+	}
+	return nil, nil
+}
+
+// repair rewrites a corrupted persistent entry with the in-memory one, before
+// passing the event on. Entries missing in memory are left to the handler.
+func (l *lstore) repair(ev core.IntegrityEvent) {
+	var err error
+	switch ev.Entry {
+	case core.EntryHeads:
+		var heads []thread.Head
+		if heads, err = l.inMem.Heads(ev.ThreadID, ev.LogID); err == nil && len(heads) > 0 {
+			err = l.persist.SetHeads(ev.ThreadID, ev.LogID, heads)
+			ev.Repaired = err == nil
+		}
+	case core.EntryAddrs:
+		var addrs []ma.Multiaddr
+		if addrs, err = l.inMem.Addrs(ev.ThreadID, ev.LogID); err == nil && len(addrs) > 0 {
+			// clearing doesn't read the corrupted entry
+			if err = l.persist.ClearAddrs(ev.ThreadID, ev.LogID); err == nil {
+				err = l.persist.AddAddrs(ev.ThreadID, ev.LogID, addrs, pstore.PermanentAddrTTL)
+			}
+			ev.Repaired = err == nil
+		}
+	}
+	if err != nil {
+		log.Errorf("repairing %s of log %s/%s failed: %v", ev.Entry, ev.ThreadID, ev.LogID, err)
+	}
+	if h, ok := l.handler.Load().(core.IntegrityHandler); ok && h != nil {
+		h(ev)
+	}
+}
+
+// persistRepaired applies a write to the persistent storage, which is retried
+// once if it ran into a corrupted entry.
+func (l *lstore) persistRepaired(write func() error) error {
+	if err := write(); !errors.Is(err, core.ErrCorruptedEntry) {
+		return err
+	}
+	return write()
+}
+
 func (l *lstore) Close() error {
 	if err := l.persist.Close(); err != nil {
 		return err
@@ -85,6 +150,13 @@ func (l *lstore) PutString(tid thread.ID, key string, val string) error {
 	return l.inMem.PutString(tid, key, val)
 }
 
+func (l *lstore) CompareAndSwapString(tid thread.ID, key string, old *string, val string) (bool, error) {
+	if swapped, err := l.persist.CompareAndSwapString(tid, key, old, val); err != nil || !swapped {
+		return false, err
+	}
+	return true, l.inMem.PutString(tid, key, val)
+}
+
 func (l *lstore) GetBool(tid thread.ID, key string) (*bool, error) {
 	return l.inMem.GetBool(tid, key)
 }
@@ -158,6 +230,17 @@ func (l *lstore) AddServiceKey(tid thread.ID, key *sym.Key) error {
 	return l.inMem.AddServiceKey(tid, key)
 }
 
+func (l *lstore) IndexKey(tid thread.ID) (*sym.Key, error) {
+	return l.inMem.IndexKey(tid)
+}
+
+func (l *lstore) AddIndexKey(tid thread.ID, key *sym.Key) error {
+	if err := l.persist.AddIndexKey(tid, key); err != nil {
+		return err
+	}
+	return l.inMem.AddIndexKey(tid, key)
+}
+
 func (l *lstore) ClearKeys(tid thread.ID) error {
 	if err := l.persist.ClearKeys(tid); err != nil {
 		return err
@@ -181,35 +264,35 @@ func (l *lstore) ThreadsFromKeys() (thread.IDSlice, error) {
 }
 
 func (l *lstore) AddAddr(tid thread.ID, lid peer.ID, addr ma.Multiaddr, dur time.Duration) error {
-	if err := l.persist.AddAddr(tid, lid, addr, dur); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.AddAddr(tid, lid, addr, dur) }); err != nil {
 		return err
 	}
 	return l.inMem.AddAddr(tid, lid, addr, dur)
 }
 
 func (l *lstore) AddAddrs(tid thread.ID, lid peer.ID, addrs []ma.Multiaddr, dur time.Duration) error {
-	if err := l.persist.AddAddrs(tid, lid, addrs, dur); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.AddAddrs(tid, lid, addrs, dur) }); err != nil {
 		return err
 	}
 	return l.inMem.AddAddrs(tid, lid, addrs, dur)
 }
 
 func (l *lstore) SetAddr(tid thread.ID, lid peer.ID, addr ma.Multiaddr, dur time.Duration) error {
-	if err := l.persist.SetAddr(tid, lid, addr, dur); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.SetAddr(tid, lid, addr, dur) }); err != nil {
 		return err
 	}
 	return l.inMem.SetAddr(tid, lid, addr, dur)
 }
 
 func (l *lstore) SetAddrs(tid thread.ID, lid peer.ID, addrs []ma.Multiaddr, dur time.Duration) error {
-	if err := l.persist.SetAddrs(tid, lid, addrs, dur); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.SetAddrs(tid, lid, addrs, dur) }); err != nil {
 		return err
 	}
 	return l.inMem.SetAddrs(tid, lid, addrs, dur)
 }
 
 func (l *lstore) UpdateAddrs(tid thread.ID, lid peer.ID, oldTTL time.Duration, newTTL time.Duration) error {
-	if err := l.persist.UpdateAddrs(tid, lid, oldTTL, newTTL); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.UpdateAddrs(tid, lid, oldTTL, newTTL) }); err != nil {
 		return err
 	}
 	return l.inMem.UpdateAddrs(tid, lid, oldTTL, newTTL)
@@ -243,14 +326,14 @@ func (l *lstore) AddrsEdge(t thread.ID) (uint64, error) {
 }
 
 func (l *lstore) AddHead(tid thread.ID, lid peer.ID, head thread.Head) error {
-	if err := l.persist.AddHead(tid, lid, head); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.AddHead(tid, lid, head) }); err != nil {
 		return err
 	}
 	return l.inMem.AddHead(tid, lid, head)
 }
 
 func (l *lstore) AddHeads(tid thread.ID, lid peer.ID, heads []thread.Head) error {
-	if err := l.persist.AddHeads(tid, lid, heads); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.AddHeads(tid, lid, heads) }); err != nil {
 		return err
 	}
 	return l.inMem.AddHeads(tid, lid, heads)
@@ -308,7 +391,7 @@ func (l *lstore) DeleteThread(tid thread.ID) error {
 }
 
 func (l *lstore) AddLog(tid thread.ID, info thread.LogInfo) error {
-	if err := l.persist.AddLog(tid, info); err != nil {
+	if err := l.persistRepaired(func() error { return l.persist.AddLog(tid, info) }); err != nil {
 		return err
 	}
 	return l.inMem.AddLog(tid, info)
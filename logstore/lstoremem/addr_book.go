@@ -8,16 +8,16 @@ import (
 	"time"
 
 	"github.com/dgtony/collections/bitset"
-	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-peerstore/addr"
 	ma "github.com/multiformats/go-multiaddr"
 	core "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/logger"
 	"github.com/textileio/go-threads/util"
 )
 
-var log = logging.Logger("logstore")
+var log = logger.Logger("logstore")
 
 const numSegments = 256
 
@@ -18,6 +18,7 @@ type memoryKeyBook struct {
 	sks map[thread.ID]map[peer.ID]crypto.PrivKey
 	rks map[thread.ID][]byte
 	fks map[thread.ID][]byte
+	iks map[thread.ID][]byte
 }
 
 func (mkb *memoryKeyBook) getPubKey(t thread.ID, p peer.ID) (crypto.PubKey, bool) {
@@ -46,6 +47,7 @@ func NewKeyBook() core.KeyBook {
 		sks: map[thread.ID]map[peer.ID]crypto.PrivKey{},
 		rks: map[thread.ID][]byte{},
 		fks: map[thread.ID][]byte{},
+		iks: map[thread.ID][]byte{},
 	}
 }
 
@@ -139,12 +141,34 @@ func (mkb *memoryKeyBook) AddServiceKey(t thread.ID, key *sym.Key) error {
 	return nil
 }
 
+func (mkb *memoryKeyBook) IndexKey(t thread.ID) (key *sym.Key, err error) {
+	mkb.RLock()
+	b := mkb.iks[t]
+	if b != nil {
+		key, err = sym.FromBytes(b)
+	}
+	mkb.RUnlock()
+	return
+}
+
+func (mkb *memoryKeyBook) AddIndexKey(t thread.ID, key *sym.Key) error {
+	if key == nil {
+		return errors.New("key is nil (IndexKey)")
+	}
+
+	mkb.Lock()
+	mkb.iks[t] = key.Bytes()
+	mkb.Unlock()
+	return nil
+}
+
 func (mkb *memoryKeyBook) ClearKeys(t thread.ID) error {
 	mkb.Lock()
 	delete(mkb.pks, t)
 	delete(mkb.sks, t)
 	delete(mkb.rks, t)
 	delete(mkb.fks, t)
+	delete(mkb.iks, t)
 	mkb.Unlock()
 	return nil
 }
@@ -205,6 +229,7 @@ func (mkb *memoryKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 		private = make(map[thread.ID]map[peer.ID]crypto.PrivKey, len(mkb.sks))
 		read    = make(map[thread.ID][]byte, len(mkb.rks))
 		service = make(map[thread.ID][]byte, len(mkb.fks))
+		index   = make(map[thread.ID][]byte, len(mkb.iks))
 	)
 
 	for tid, logs := range mkb.pks {
@@ -231,10 +256,15 @@ func (mkb *memoryKeyBook) DumpKeys() (core.DumpKeyBook, error) {
 		service[tid] = key
 	}
 
+	for tid, key := range mkb.iks {
+		index[tid] = key
+	}
+
 	dump.Data.Public = public
 	dump.Data.Private = private
 	dump.Data.Read = read
 	dump.Data.Service = service
+	dump.Data.Index = index
 
 	return dump, nil
 }
@@ -244,7 +274,8 @@ func (mkb *memoryKeyBook) RestoreKeys(dump core.DumpKeyBook) error {
 		len(dump.Data.Public) == 0 &&
 		len(dump.Data.Private) == 0 &&
 		len(dump.Data.Read) == 0 &&
-		len(dump.Data.Service) == 0 {
+		len(dump.Data.Service) == 0 &&
+		len(dump.Data.Index) == 0 {
 		return core.ErrEmptyDump
 	}
 
@@ -255,5 +286,9 @@ func (mkb *memoryKeyBook) RestoreKeys(dump core.DumpKeyBook) error {
 	mkb.sks = dump.Data.Private
 	mkb.rks = dump.Data.Read
 	mkb.fks = dump.Data.Service
+	mkb.iks = dump.Data.Index
+	if mkb.iks == nil {
+		mkb.iks = map[thread.ID][]byte{}
+	}
 	return nil
 }
@@ -47,6 +47,18 @@ func (m *memoryThreadMetadata) GetString(t thread.ID, key string) (*string, erro
 	return &val, nil
 }
 
+func (m *memoryThreadMetadata) CompareAndSwapString(t thread.ID, key string, old *string, val string) (bool, error) {
+	m.dslock.Lock()
+	defer m.dslock.Unlock()
+	k := core.MetadataKey{T: t, K: key}
+	cur, ok := m.ds[k].(string)
+	if ok != (old != nil) || (ok && cur != *old) {
+		return false, nil
+	}
+	m.ds[k] = val
+	return true, nil
+}
+
 func (m *memoryThreadMetadata) PutBool(t thread.ID, key string, val bool) error {
 	m.putValue(t, key, val)
 	return nil
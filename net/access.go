@@ -0,0 +1,217 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	gonet "net"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// AccessList restricts the peers allowed to connect to the network service.
+// Denied peers are rejected before the gRPC handshake, so no handler runs for them.
+// A peer matches a network if any of the addresses it's observed at is in the network.
+type AccessList struct {
+	// AllowPeers and AllowNets restrict connections to matching peers. All peers
+	// which aren't denied are allowed if both are empty.
+	AllowPeers []peer.ID
+	AllowNets  []*gonet.IPNet
+	// DenyPeers and DenyNets reject matching peers, even if they're allowed.
+	DenyPeers []peer.ID
+	DenyNets  []*gonet.IPNet
+}
+
+// Validate returns an error if the access list is invalid.
+func (a AccessList) Validate() error {
+	for _, n := range append(a.AllowNets[:len(a.AllowNets):len(a.AllowNets)], a.DenyNets...) {
+		if n == nil {
+			return errors.New("access list networks must not be nil")
+		}
+	}
+	for _, p := range append(a.AllowPeers[:len(a.AllowPeers):len(a.AllowPeers)], a.DenyPeers...) {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("invalid access list peer: %w", err)
+		}
+	}
+	return nil
+}
+
+// Empty returns whether the access list allows all peers.
+func (a AccessList) Empty() bool {
+	return len(a.AllowPeers) == 0 && len(a.AllowNets) == 0 && len(a.DenyPeers) == 0 && len(a.DenyNets) == 0
+}
+
+// Allows returns whether a peer observed at ips is allowed to connect.
+func (a AccessList) Allows(pid peer.ID, ips []gonet.IP) bool {
+	if containsPeer(a.DenyPeers, pid) || containsIP(a.DenyNets, ips) {
+		return false
+	}
+	if len(a.AllowPeers) == 0 && len(a.AllowNets) == 0 {
+		return true
+	}
+	return containsPeer(a.AllowPeers, pid) || containsIP(a.AllowNets, ips)
+}
+
+func containsPeer(ids []peer.ID, pid peer.ID) bool {
+	for _, id := range ids {
+		if id == pid {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIP(nets []*gonet.IPNet, ips []gonet.IP) bool {
+	for _, n := range nets {
+		for _, ip := range ips {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AccessController manages the access list of the network service at runtime,
+// it's implemented by the threads network.
+type AccessController interface {
+	// AccessList returns the current access list.
+	AccessList() AccessList
+	// SetAccessList replaces the access list. Open connections of peers which
+	// aren't allowed anymore are closed.
+	SetAccessList(a AccessList) error
+}
+
+var _ AccessController = (*net)(nil)
+
+func (n *net) AccessList() AccessList {
+	return n.access.get()
+}
+
+func (n *net) SetAccessList(a AccessList) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	n.access.set(a)
+	return nil
+}
+
+// observedIPs returns the IPs a connected peer is observed at.
+func (n *net) observedIPs(conn gonet.Conn, pid peer.ID) []gonet.IP {
+	var ips []gonet.IP
+	if oc, ok := conn.(interface{ ObservedAddr() gonet.Addr }); ok {
+		if ta, ok := oc.ObservedAddr().(*gonet.TCPAddr); ok {
+			ips = append(ips, ta.IP)
+		}
+	}
+	for _, c := range n.host.Network().ConnsToPeer(pid) {
+		if ip, err := manet.ToIP(c.RemoteMultiaddr()); err == nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// accessControl enforces an access list on the connections accepted by the network service.
+type accessControl struct {
+	observe func(conn gonet.Conn, pid peer.ID) []gonet.IP
+
+	lock  sync.Mutex
+	list  AccessList
+	conns map[*accessConn]struct{}
+}
+
+func newAccessControl(list AccessList, observe func(gonet.Conn, peer.ID) []gonet.IP) *accessControl {
+	return &accessControl{
+		observe: observe,
+		list:    list,
+		conns:   make(map[*accessConn]struct{}),
+	}
+}
+
+func (c *accessControl) get() AccessList {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.list
+}
+
+// set replaces the access list, and closes the open connections it denies.
+func (c *accessControl) set(list AccessList) {
+	c.lock.Lock()
+	c.list = list
+	var denied []*accessConn
+	for conn := range c.conns {
+		if !list.Allows(conn.pid, c.observe(conn.Conn, conn.pid)) {
+			denied = append(denied, conn)
+		}
+	}
+	c.lock.Unlock()
+
+	for _, conn := range denied {
+		log.Infof("closing connection of peer %s denied by access list", conn.pid)
+		_ = conn.Close()
+	}
+}
+
+// admit tracks an accepted connection, or returns false if the access list denies it.
+func (c *accessControl) admit(conn gonet.Conn) (*accessConn, bool) {
+	pid, err := peer.Decode(conn.RemoteAddr().String())
+	if err != nil {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.list.Allows(pid, c.observe(conn, pid)) {
+		return nil, false
+	}
+	ac := &accessConn{Conn: conn, pid: pid, c: c}
+	c.conns[ac] = struct{}{}
+	return ac, true
+}
+
+func (c *accessControl) forget(conn *accessConn) {
+	c.lock.Lock()
+	delete(c.conns, conn)
+	c.lock.Unlock()
+}
+
+// listen wraps a listener of the network service so it only returns allowed connections.
+func (c *accessControl) listen(l gonet.Listener) gonet.Listener {
+	return &accessListener{Listener: l, c: c}
+}
+
+type accessListener struct {
+	gonet.Listener
+	c *accessControl
+}
+
+func (l *accessListener) Accept() (gonet.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		ac, ok := l.c.admit(conn)
+		if !ok {
+			log.Debugf("rejecting connection of peer %s denied by access list", conn.RemoteAddr())
+			_ = conn.Close()
+			continue
+		}
+		return ac, nil
+	}
+}
+
+// accessConn is an admitted connection, which is closed if the access list changes to deny it.
+type accessConn struct {
+	gonet.Conn
+	pid  peer.ID
+	c    *accessControl
+	once sync.Once
+}
+
+func (c *accessConn) Close() error {
+	c.once.Do(func() { c.c.forget(c) })
+	return c.Conn.Close()
+}
@@ -0,0 +1,101 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	gonet "net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestAccessList_Allows(t *testing.T) {
+	t.Parallel()
+	p1, p2 := peer.ID("p1"), peer.ID("p2")
+	_, local, _ := gonet.ParseCIDR("127.0.0.0/8")
+	_, private, _ := gonet.ParseCIDR("10.0.0.0/8")
+	ip := gonet.ParseIP("127.0.0.1")
+
+	cases := []struct {
+		name  string
+		list  AccessList
+		allow bool
+	}{
+		{"empty", AccessList{}, true},
+		{"allowed peer", AccessList{AllowPeers: []peer.ID{p1}}, true},
+		{"other peer allowed", AccessList{AllowPeers: []peer.ID{p2}}, false},
+		{"allowed net", AccessList{AllowPeers: []peer.ID{p2}, AllowNets: []*gonet.IPNet{local}}, true},
+		{"other net allowed", AccessList{AllowNets: []*gonet.IPNet{private}}, false},
+		{"denied peer", AccessList{AllowNets: []*gonet.IPNet{local}, DenyPeers: []peer.ID{p1}}, false},
+		{"denied net", AccessList{AllowPeers: []peer.ID{p1}, DenyNets: []*gonet.IPNet{local}}, false},
+		{"other peer denied", AccessList{DenyPeers: []peer.ID{p2}}, true},
+	}
+	for _, c := range cases {
+		if allowed := c.list.Allows(p1, []gonet.IP{ip}); allowed != c.allow {
+			t.Errorf("%s: expected allowed to be %v", c.name, c.allow)
+		}
+	}
+}
+
+func TestNet_AccessList(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	_, local, _ := gonet.ParseCIDR("127.0.0.0/8")
+	_, private, _ := gonet.ParseCIDR("10.0.0.0/8")
+
+	if err := n1.SetAccessList(AccessList{DenyPeers: []peer.ID{n2.Host().ID()}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err == nil {
+		t.Fatal("expected denied peer to be rejected")
+	}
+	if err := n2.DeleteThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := n1.SetAccessList(AccessList{AllowNets: []*gonet.IPNet{local}}); err != nil {
+		t.Fatal(err)
+	}
+	// the rejected connection is redialed after a backoff
+	waitFor(t, func() bool {
+		if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+			_ = n2.DeleteThread(ctx, info.ID)
+			return false
+		}
+		return true
+	})
+	n1.access.lock.Lock()
+	admitted := len(n1.access.conns)
+	n1.access.lock.Unlock()
+	if admitted == 0 {
+		t.Fatal("expected admitted connection to be tracked")
+	}
+
+	// open connections are closed once they're denied
+	if err := n1.SetAccessList(AccessList{AllowNets: []*gonet.IPNet{private}}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		n1.access.lock.Lock()
+		defer n1.access.lock.Unlock()
+		return len(n1.access.conns) == 0
+	})
+	if got := n1.AccessList(); len(got.AllowNets) != 1 || got.AllowNets[0].String() != "10.0.0.0/8" {
+		t.Fatalf("unexpected access list %+v", got)
+	}
+	if err := n1.SetAccessList(AccessList{AllowNets: []*gonet.IPNet{nil}}); err == nil {
+		t.Fatal("expected invalid access list to be rejected")
+	}
+}
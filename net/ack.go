@@ -0,0 +1,395 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+var (
+	// AckInterval is the interval at which acks of received and seen records are sent to log authors.
+	AckInterval = time.Second
+
+	// MaxAcks is the max number of peer acks kept per log authored by the host.
+	// Acks of peers unknown to the thread are dropped first, then the oldest ones.
+	MaxAcks = 256
+
+	// MaxAckClockSkew is how far ahead of the local clock the timestamp of a received ack
+	// can be. Acks further ahead are rejected, so they can't evict the acks of other peers.
+	MaxAckClockSkew = time.Minute
+)
+
+const (
+	// metaAcks prefixes the metadata keys of the marshaled peer acks of a log authored by the host.
+	metaAcks = "acks/"
+
+	// metaSeen prefixes the metadata keys of the marshaled range of log records seen on the host.
+	metaSeen = "seen/"
+)
+
+func (n *net) MarkSeen(ctx context.Context, id thread.ID, lid peer.ID, rid cid.Cid, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	var pos int64
+	if err := n.withLogIndex(ctx, id, lid, func(idx *logIndex) error {
+		pos = idx.position(rid)
+		return nil
+	}); err != nil {
+		return err
+	} else if pos == 0 {
+		return core.ErrRecordNotFound
+	}
+
+	n.ackLock.Lock()
+	defer n.ackLock.Unlock()
+	seen, err := n.seenRange(id, lid)
+	if err != nil {
+		return err
+	}
+	if seen != nil && seen.Counter >= pos {
+		return nil
+	}
+	seen = &pb.RecordAck_Range{Head: &pb.ProtoCid{Cid: rid}, Counter: pos}
+	val, err := seen.Marshal()
+	if err != nil {
+		return err
+	}
+	if err = n.store.PutBytes(id, metaSeen+lid.String(), val); err != nil {
+		return err
+	}
+	n.scheduleAck(id, lid)
+	return nil
+}
+
+func (n *net) GetDeliveryReport(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	rid cid.Cid,
+	opts ...core.ThreadOption,
+) (core.DeliveryReport, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.DeliveryReport{}, err
+	}
+	acks, err := n.logAcks(id, lid)
+	if err != nil {
+		return core.DeliveryReport{}, err
+	}
+	report := core.DeliveryReport{LogID: lid, RecordID: rid}
+	err = n.withLogIndex(ctx, id, lid, func(idx *logIndex) error {
+		pos := idx.position(rid)
+		if pos == 0 {
+			return core.ErrRecordNotFound
+		}
+		// ranges only count if they end at a record of the local log
+		covers := func(r *pb.RecordAck_Range) bool {
+			return r != nil && r.Head != nil && r.Counter >= pos && r.Counter <= int64(len(idx.ids)) &&
+				idx.ids[r.Counter-1].Equals(r.Head.Cid)
+		}
+		for _, a := range acks {
+			if covers(a.Body.Delivered) {
+				report.Delivered = append(report.Delivered, a.Body.PeerID.ID)
+				if covers(a.Body.Seen) {
+					report.Seen = append(report.Seen, a.Body.PeerID.ID)
+				}
+			}
+		}
+		return nil
+	})
+	return report, err
+}
+
+// scheduleAck queues an ack of a log to be sent to the log author with the next batch,
+// if record acks are enabled.
+func (n *net) scheduleAck(id thread.ID, lid peer.ID) {
+	if n.acks != nil {
+		n.acks.add(id, lid)
+	}
+}
+
+// startAcking sends the scheduled acks to log authors on an interval.
+func (n *net) startAcking() {
+	tick := n.clock.NewTicker(AckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-tick.C:
+			n.sendAcks(n.ctx)
+		}
+	}
+}
+
+// sendAcks signs acks of the scheduled logs and pushes them to the log authors,
+// batched per thread and peer. Failed pushes aren't retried, the next ack of a log
+// covers the records acknowledged before.
+func (n *net) sendAcks(ctx context.Context) {
+	var wg sync.WaitGroup
+	for id, lids := range n.acks.take() {
+		batches := make(map[peer.ID][]*pb.RecordAck)
+		for _, lid := range lids {
+			lg, err := n.store.GetLog(id, lid)
+			if err != nil {
+				log.Debugf("getting log %s of thread %s to ack failed: %v", lid, id, err)
+				continue
+			}
+			if lg.PrivKey != nil || lg.Head.Counter == thread.CounterUndef {
+				continue
+			}
+			a, err := n.ackToProto(id, lg)
+			if err != nil {
+				log.Errorf("signing ack of log %s failed: %v", lid, err)
+				continue
+			}
+			authors, err := n.uniquePeers(lg.Addrs)
+			if err != nil {
+				log.Debugf("getting authors of log %s failed: %v", lid, err)
+				continue
+			}
+			for _, pid := range authors {
+				batches[pid] = append(batches[pid], a)
+			}
+		}
+		for pid, acks := range batches {
+			wg.Add(1)
+			go func(id thread.ID, pid peer.ID, acks []*pb.RecordAck) {
+				defer wg.Done()
+				if err := n.server.pushAcks(ctx, pid, id, acks); err != nil {
+					log.Debugf("pushing acks of thread %s to %s failed: %v", id, pid, err)
+				}
+			}(id, pid, acks)
+		}
+	}
+	wg.Wait()
+}
+
+// ackToProto returns an ack of the log records stored and seen on the host, signed with the host key.
+func (n *net) ackToProto(id thread.ID, lg thread.LogInfo) (*pb.RecordAck, error) {
+	seen, err := n.seenRange(id, lg.ID)
+	if err != nil {
+		return nil, err
+	}
+	body := &pb.RecordAck_Body{
+		ThreadID:  &pb.ProtoThreadID{ID: id},
+		LogID:     &pb.ProtoPeerID{ID: lg.ID},
+		PeerID:    &pb.ProtoPeerID{ID: n.host.ID()},
+		Delivered: &pb.RecordAck_Range{Head: &pb.ProtoCid{Cid: lg.Head.ID}, Counter: lg.Head.Counter},
+		Seen:      seen,
+		Timestamp: n.clock.Now().UnixNano(),
+	}
+	msg, release, err := pb.MarshalPooled(body)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	sig, err := n.getPrivKey().Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RecordAck{Body: body, Sig: sig}, nil
+}
+
+// verifyAck checks that the ack of a thread log is signed by the acknowledging peer.
+func verifyAck(id thread.ID, a *pb.RecordAck) error {
+	if a == nil || a.Body == nil || a.Body.ThreadID == nil || a.Body.LogID == nil || a.Body.PeerID == nil {
+		return errors.New("incomplete ack message")
+	}
+	if !a.Body.ThreadID.ID.Equals(id) {
+		return fmt.Errorf("ack of another thread %s", a.Body.ThreadID.ID)
+	}
+	pk, err := a.Body.PeerID.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+	msg, release, err := pb.MarshalPooled(a.Body)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if ok, err := pk.Verify(msg, a.Sig); !ok || err != nil {
+		return errors.New("bad ack signature")
+	}
+	return nil
+}
+
+// seenRange returns the range of log records seen on the host, if any.
+func (n *net) seenRange(id thread.ID, lid peer.ID) (*pb.RecordAck_Range, error) {
+	val, err := n.store.GetBytes(id, metaSeen+lid.String())
+	if err != nil || val == nil {
+		return nil, err
+	}
+	r := &pb.RecordAck_Range{}
+	if err = r.Unmarshal(*val); err != nil {
+		return nil, fmt.Errorf("decoding seen range: %w", err)
+	}
+	return r, nil
+}
+
+// logAcks returns the stored peer acks of a log.
+func (n *net) logAcks(id thread.ID, lid peer.ID) ([]*pb.RecordAck, error) {
+	val, err := n.store.GetBytes(id, metaAcks+lid.String())
+	if err != nil || val == nil {
+		return nil, err
+	}
+	var raw [][]byte
+	if err = json.Unmarshal(*val, &raw); err != nil {
+		return nil, fmt.Errorf("decoding acks: %w", err)
+	}
+	acks := make([]*pb.RecordAck, 0, len(raw))
+	for _, r := range raw {
+		a := &pb.RecordAck{}
+		if err = a.Unmarshal(r); err != nil {
+			return nil, fmt.Errorf("decoding ack: %w", err)
+		}
+		acks = append(acks, a)
+	}
+	return acks, nil
+}
+
+// putAcks merges verified acks pushed by a peer of logs authored by the host into the stored
+// ones, the latest ack of each peer wins. Invalid acks, acks of other logs or peers than the
+// pushing one and acks timestamped beyond MaxAckClockSkew ahead of the local clock are skipped.
+func (n *net) putAcks(id thread.ID, pid peer.ID, acks []*pb.RecordAck) error {
+	byLog := make(map[peer.ID][]*pb.RecordAck)
+	limit := n.clock.Now().Add(MaxAckClockSkew).UnixNano()
+	for _, a := range acks {
+		if err := verifyAck(id, a); err != nil {
+			log.Debugf("skipping ack of thread %s: %v", id, err)
+			continue
+		}
+		if a.Body.PeerID.ID != pid {
+			log.Debugf("skipping ack of %s pushed by %s (thread %s)", a.Body.PeerID.ID, pid, id)
+			continue
+		}
+		if a.Body.Timestamp > limit {
+			log.Debugf("skipping ack of %s ahead of the local clock (thread %s)", pid, id)
+			continue
+		}
+		byLog[a.Body.LogID.ID] = append(byLog[a.Body.LogID.ID], a)
+	}
+
+	n.ackLock.Lock()
+	defer n.ackLock.Unlock()
+	for lid, acks := range byLog {
+		lg, err := n.store.GetLog(id, lid)
+		if err != nil {
+			log.Debugf("skipping acks of log %s: %v", lid, err)
+			continue
+		} else if lg.PrivKey == nil {
+			continue
+		}
+		if err = n.mergeAcks(id, lid, acks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *net) mergeAcks(id thread.ID, lid peer.ID, acks []*pb.RecordAck) error {
+	current, err := n.logAcks(id, lid)
+	if err != nil {
+		return err
+	}
+	byPeer := make(map[peer.ID]*pb.RecordAck, len(current))
+	for _, a := range current {
+		byPeer[a.Body.PeerID.ID] = a
+	}
+	var changed bool
+	for _, a := range acks {
+		pid := a.Body.PeerID.ID
+		if known, ok := byPeer[pid]; ok && known.Body.Timestamp >= a.Body.Timestamp {
+			continue
+		}
+		byPeer[pid] = a
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	merged := make([]*pb.RecordAck, 0, len(byPeer))
+	for _, a := range byPeer {
+		merged = append(merged, a)
+	}
+	_, peers, err := n.threadOffsets(id)
+	if err != nil {
+		return err
+	}
+	// acks of the thread peers outrank the ones of peers unknown to it
+	sort.Slice(merged, func(i, j int) bool {
+		ki, kj := containsPeer(peers, merged[i].Body.PeerID.ID), containsPeer(peers, merged[j].Body.PeerID.ID)
+		if ki != kj {
+			return kj
+		}
+		return merged[i].Body.Timestamp < merged[j].Body.Timestamp
+	})
+	if len(merged) > MaxAcks {
+		merged = merged[len(merged)-MaxAcks:]
+	}
+	raw := make([][]byte, len(merged))
+	for i, a := range merged {
+		if raw[i], err = a.Marshal(); err != nil {
+			return err
+		}
+	}
+	val, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(id, metaAcks+lid.String(), val)
+}
+
+// pendingAcks collects the logs to acknowledge with the next batch.
+type pendingAcks struct {
+	sync.Mutex
+	logs map[thread.ID]map[peer.ID]struct{}
+}
+
+func newPendingAcks() *pendingAcks {
+	return &pendingAcks{logs: make(map[thread.ID]map[peer.ID]struct{})}
+}
+
+func (p *pendingAcks) add(id thread.ID, lid peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+	lids, ok := p.logs[id]
+	if !ok {
+		lids = make(map[peer.ID]struct{})
+		p.logs[id] = lids
+	}
+	lids[lid] = struct{}{}
+}
+
+// take returns the pending logs grouped by thread and resets the batch.
+func (p *pendingAcks) take() map[thread.ID][]peer.ID {
+	p.Lock()
+	defer p.Unlock()
+	batch := make(map[thread.ID][]peer.ID, len(p.logs))
+	for id, lids := range p.logs {
+		for lid := range lids {
+			batch[id] = append(batch[id], lid)
+		}
+	}
+	p.logs = make(map[thread.ID]map[peer.ID]struct{})
+	return batch
+}
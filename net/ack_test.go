@@ -0,0 +1,206 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+func TestNet_RecordAcks(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{RecordAcks: true}).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{RecordAcks: true}).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	lid := recs[0].LogID()
+
+	report := func(i int) core.DeliveryReport {
+		r, err := n1.GetDeliveryReport(ctx, info.ID, lid, recs[i].Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	only := func(peers []peer.ID, pid peer.ID) bool {
+		return len(peers) == 1 && peers[0] == pid
+	}
+	if r := report(2); len(r.Delivered) != 0 || len(r.Seen) != 0 {
+		t.Fatalf("expected no acks before the records are pulled, got %v", r)
+	}
+
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		return only(report(2).Delivered, n2.Host().ID())
+	})
+	if r := report(0); !only(r.Delivered, n2.Host().ID()) || len(r.Seen) != 0 {
+		t.Fatalf("expected the first record to be delivered and not seen, got %v", r)
+	}
+
+	// seeing a record acknowledges the records before it too
+	if err := n2.MarkSeen(ctx, info.ID, lid, recs[1].Value().Cid()); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		return only(report(1).Seen, n2.Host().ID())
+	})
+	if r := report(0); !only(r.Seen, n2.Host().ID()) {
+		t.Fatalf("expected the first record to be seen, got %v", r)
+	}
+	if r := report(2); !only(r.Delivered, n2.Host().ID()) || len(r.Seen) != 0 {
+		t.Fatalf("expected the last record not to be seen, got %v", r)
+	}
+
+	// seen ranges only move forward
+	if err := n2.MarkSeen(ctx, info.ID, lid, recs[0].Value().Cid()); err != nil {
+		t.Fatal(err)
+	}
+	if seen, err := n2.seenRange(info.ID, lid); err != nil || seen.Counter != 2 {
+		t.Fatalf("expected the seen range to be kept, got %v, %v", seen, err)
+	}
+	unknown := recs[0].Value().BlockID()
+	if err := n2.MarkSeen(ctx, info.ID, lid, unknown); !errors.Is(err, core.ErrRecordNotFound) {
+		t.Fatalf("expected unknown record to be rejected, got %v", err)
+	}
+	if _, err := n1.GetDeliveryReport(ctx, info.ID, lid, unknown); !errors.Is(err, core.ErrRecordNotFound) {
+		t.Fatalf("expected report of unknown record to fail, got %v", err)
+	}
+}
+
+func TestNet_RecordAcksBounds(t *testing.T) {
+	t.Parallel()
+	n := makeNetworkWithConfig(t, Config{RecordAcks: true}).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mint := func(ts time.Time) (*pb.RecordAck, peer.ID) {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pid, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &pb.RecordAck_Body{
+			ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+			LogID:     &pb.ProtoPeerID{ID: r.LogID()},
+			PeerID:    &pb.ProtoPeerID{ID: pid},
+			Delivered: &pb.RecordAck_Range{Head: &pb.ProtoCid{Cid: r.Value().Cid()}, Counter: 1},
+			Timestamp: ts.UnixNano(),
+		}
+		msg, err := b.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := sk.Sign(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pb.RecordAck{Body: b, Sig: sig}, pid
+	}
+	stored := func() []*pb.RecordAck {
+		acks, err := n.logAcks(info.ID, r.LogID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return acks
+	}
+
+	now := n.clock.Now()
+	future, pid := mint(now.Add(2 * MaxAckClockSkew))
+	if err := n.putAcks(info.ID, pid, []*pb.RecordAck{future}); err != nil {
+		t.Fatal(err)
+	}
+	if acks := stored(); len(acks) != 0 {
+		t.Fatalf("expected an ack ahead of the local clock to be rejected, got %d acks", len(acks))
+	}
+	a, known := mint(now)
+	if err := n.putAcks(info.ID, pid, []*pb.RecordAck{a}); err != nil {
+		t.Fatal(err)
+	}
+	if acks := stored(); len(acks) != 0 {
+		t.Fatalf("expected an ack pushed by another peer to be rejected, got %d acks", len(acks))
+	}
+	if err := n.putAcks(info.ID, known, []*pb.RecordAck{a}); err != nil {
+		t.Fatal(err)
+	}
+	if acks := stored(); len(acks) != 1 {
+		t.Fatalf("expected the ack to be stored, got %d acks", len(acks))
+	}
+
+	// acks of unknown peers can't evict the ones of thread peers
+	sk, pk, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ma.StringCast("/ip4/127.0.0.1/tcp/4006/p2p/" + known.String())
+	if err := n.store.AddLog(info.ID, thread.LogInfo{ID: lid, PubKey: pk, PrivKey: sk, Addrs: []ma.Multiaddr{addr}}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < MaxAcks; i++ {
+		a, pid := mint(now.Add(time.Duration(i+1) * time.Millisecond))
+		if err := n.putAcks(info.ID, pid, []*pb.RecordAck{a}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	acks := stored()
+	if len(acks) != MaxAcks {
+		t.Fatalf("expected %d acks, got %d", MaxAcks, len(acks))
+	}
+	var kept bool
+	for _, a := range acks {
+		kept = kept || a.Body.PeerID.ID == known
+	}
+	if !kept {
+		t.Fatal("expected the ack of a thread peer to be kept")
+	}
+}
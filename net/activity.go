@@ -0,0 +1,261 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// ActivityIndexSize is the maximum number of the most recent records kept in the activity
+// index of a thread, which bounds the thread activity feed.
+var ActivityIndexSize = 1000
+
+// threadActivity is the activity index of a thread.
+type threadActivity struct {
+	// ready is closed once the index is built from the thread logs
+	ready chan struct{}
+	err   error
+	// summaries of the most recent records, oldest first
+	entries []core.RecordSummary
+	known   map[cid.Cid]struct{}
+}
+
+// activityIndex keeps summaries of the most recent thread records ordered by time.
+// Thread indexes are built from the logs on the first activity feed request, and
+// are maintained incrementally with the records added afterwards.
+type activityIndex struct {
+	sync.Mutex
+	threads map[thread.ID]*threadActivity
+}
+
+func newActivityIndex() *activityIndex {
+	return &activityIndex{threads: make(map[thread.ID]*threadActivity)}
+}
+
+// indexed returns whether the activity of a thread is indexed or being indexed.
+func (a *activityIndex) indexed(tid thread.ID) bool {
+	a.Lock()
+	defer a.Unlock()
+	_, ok := a.threads[tid]
+	return ok
+}
+
+// add a record summary to the thread index, if the thread is indexed.
+// Index is trimmed to ActivityIndexSize most recent records.
+func (a *activityIndex) add(tid thread.ID, s core.RecordSummary) {
+	a.Lock()
+	defer a.Unlock()
+	ta, ok := a.threads[tid]
+	if !ok {
+		return
+	}
+	if _, ok := ta.known[s.ID]; ok {
+		return
+	}
+	i := sort.Search(len(ta.entries), func(i int) bool {
+		return ta.entries[i].Time.After(s.Time)
+	})
+	if i == 0 && len(ta.entries) >= ActivityIndexSize {
+		// older than everything kept
+		return
+	}
+	ta.entries = append(ta.entries, core.RecordSummary{})
+	copy(ta.entries[i+1:], ta.entries[i:])
+	ta.entries[i] = s
+	ta.known[s.ID] = struct{}{}
+	for len(ta.entries) > ActivityIndexSize {
+		delete(ta.known, ta.entries[0].ID)
+		ta.entries = ta.entries[1:]
+	}
+}
+
+// feed returns summaries of records created after since, newest first.
+func (a *activityIndex) feed(tid thread.ID, since time.Time, limit int) []core.RecordSummary {
+	a.Lock()
+	defer a.Unlock()
+	ta, ok := a.threads[tid]
+	if !ok {
+		return nil
+	}
+	var feed []core.RecordSummary
+	for i := len(ta.entries) - 1; i >= 0; i-- {
+		if limit > 0 && len(feed) == limit {
+			break
+		}
+		e := ta.entries[i]
+		if !since.IsZero() && !e.Time.After(since) {
+			break
+		}
+		feed = append(feed, e)
+	}
+	return feed
+}
+
+// forget drops the index of a thread.
+func (a *activityIndex) forget(tid thread.ID) {
+	a.Lock()
+	defer a.Unlock()
+	delete(a.threads, tid)
+}
+
+func (n *net) ActivityFeed(
+	ctx context.Context,
+	id thread.ID,
+	since time.Time,
+	limit int,
+	opts ...core.ThreadOption,
+) ([]core.RecordSummary, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	if err := n.ensureActivityIndex(ctx, id); err != nil {
+		return nil, err
+	}
+	return n.activity.feed(id, since, limit), nil
+}
+
+// ensureActivityIndex builds the activity index of a thread unless it's built already.
+// Concurrent callers wait for the index built by the first one.
+func (n *net) ensureActivityIndex(ctx context.Context, tid thread.ID) error {
+	n.activity.Lock()
+	ta, ok := n.activity.threads[tid]
+	if ok {
+		n.activity.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ta.ready:
+			return ta.err
+		}
+	}
+	ta = &threadActivity{
+		ready: make(chan struct{}),
+		known: make(map[cid.Cid]struct{}),
+	}
+	// records added while building are indexed right away
+	n.activity.threads[tid] = ta
+	n.activity.Unlock()
+
+	err := n.buildActivityIndex(ctx, tid)
+	if err != nil {
+		n.activity.forget(tid)
+		ta.err = fmt.Errorf("indexing activity of thread %s: %w", tid, err)
+	}
+	close(ta.ready)
+	return ta.err
+}
+
+// buildActivityIndex walks back the logs of a thread from their heads, adding up to
+// ActivityIndexSize records of each log to the index.
+func (n *net) buildActivityIndex(ctx context.Context, tid thread.ID) error {
+	key, err := n.headerKey(tid)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return fmt.Errorf("a read or index key is required to index activity")
+	}
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return err
+	}
+	for _, lg := range info.Logs {
+		cursor := lg.Head.ID
+		for i := 0; i < ActivityIndexSize && cursor.Defined(); i++ {
+			// stop at the records pruned from the log
+			if known, err := n.isKnown(cursor); err != nil {
+				return err
+			} else if !known {
+				break
+			}
+			rec, err := n.getRecord(ctx, tid, cursor)
+			if err != nil {
+				return err
+			}
+			s, err := n.recordSummary(ctx, lg.ID, rec, key)
+			if err != nil {
+				return err
+			}
+			n.activity.add(tid, s)
+			cursor = rec.PrevID()
+		}
+	}
+	return nil
+}
+
+// indexActivity adds a new record of a thread to its activity index, if the thread is indexed.
+func (n *net) indexActivity(ctx context.Context, tid thread.ID, lid peer.ID, rec core.Record) {
+	if !n.activity.indexed(tid) {
+		return
+	}
+	key, err := n.headerKey(tid)
+	if err != nil || key == nil {
+		log.Warnf("getting header key of thread %s failed: %v", tid, err)
+		return
+	}
+	s, err := n.recordSummary(ctx, lid, rec, key)
+	if err != nil {
+		log.Warnf("indexing activity of record %s (thread %s) failed: %v", rec.Cid(), tid, err)
+		return
+	}
+	n.activity.add(tid, s)
+}
+
+// recordSummary reads the summary of a local record with a header key, its body isn't decrypted.
+// The size of redacted records doesn't include their bodies, which aren't stored.
+func (n *net) recordSummary(ctx context.Context, lid peer.ID, rec core.Record, key *sym.Key) (core.RecordSummary, error) {
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		return core.RecordSummary{}, err
+	}
+	header, err := event.GetHeader(ctx, n, key)
+	if err != nil {
+		return core.RecordSummary{}, err
+	}
+	size := len(rec.RawData()) + len(event.RawData()) + len(header.RawData())
+	if stored, err := n.isKnown(event.BodyID()); err != nil {
+		return core.RecordSummary{}, err
+	} else if stored {
+		body, err := event.GetBody(ctx, n, nil)
+		if err != nil {
+			return core.RecordSummary{}, err
+		}
+		size += len(body.RawData())
+	}
+	created, err := header.Time()
+	if err != nil {
+		return core.RecordSummary{}, err
+	}
+	typ, err := header.Type()
+	if err != nil {
+		return core.RecordSummary{}, err
+	}
+	s := core.RecordSummary{
+		ID:    rec.Cid(),
+		LogID: lid,
+		Time:  created,
+		Size:  size,
+		Type:  typ,
+	}
+	if len(rec.PubKey()) != 0 {
+		author := &thread.Libp2pPubKey{}
+		if err := author.UnmarshalBinary(rec.PubKey()); err != nil {
+			return core.RecordSummary{}, err
+		}
+		s.Author = author
+	}
+	return s, nil
+}
@@ -0,0 +1,217 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// dsAnnotations is the annotation store namespace of record annotations:
+// /net/annotations/<thread id>/<record id>
+var dsAnnotations = ds.NewKey("/net/annotations")
+
+type annotationRecord struct {
+	Read      bool     `json:"read,omitempty"`
+	Starred   bool     `json:"starred,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	UpdatedAt int64    `json:"updatedAt"`
+}
+
+func annotationKey(tid thread.ID, rid cid.Cid) ds.Key {
+	return dsAnnotations.ChildString(tid.String()).ChildString(rid.String())
+}
+
+func (n *net) AnnotateRecord(
+	ctx context.Context,
+	id thread.ID,
+	rid cid.Cid,
+	a core.Annotation,
+	opts ...core.ThreadOption,
+) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	for _, l := range a.Labels {
+		if len(l) == 0 {
+			return fmt.Errorf("annotation labels must not be empty")
+		}
+	}
+	if a.IsZero() {
+		return n.annotations.Delete(annotationKey(id, rid))
+	}
+	if err := n.checkLocalRecord(ctx, id, rid); err != nil {
+		return err
+	}
+	val, err := json.Marshal(annotationRecord{
+		Read:      a.Read,
+		Starred:   a.Starred,
+		Labels:    a.Labels,
+		UpdatedAt: n.clock.Now().UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+	return n.annotations.Put(annotationKey(id, rid), val)
+}
+
+func (n *net) GetAnnotation(
+	ctx context.Context,
+	id thread.ID,
+	rid cid.Cid,
+	opts ...core.ThreadOption,
+) (core.RecordAnnotation, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.RecordAnnotation{}, err
+	}
+	val, err := n.annotations.Get(annotationKey(id, rid))
+	if errors.Is(err, ds.ErrNotFound) {
+		return core.RecordAnnotation{RecordID: rid}, nil
+	} else if err != nil {
+		return core.RecordAnnotation{}, err
+	}
+	if pruned, err := n.pruneAnnotation(id, rid); err != nil {
+		return core.RecordAnnotation{}, err
+	} else if pruned {
+		return core.RecordAnnotation{RecordID: rid}, nil
+	}
+	return annotationFromBytes(rid, val)
+}
+
+func (n *net) QueryAnnotations(
+	_ context.Context,
+	id thread.ID,
+	opts ...core.AnnotationOption,
+) ([]core.RecordAnnotation, error) {
+	args := &core.AnnotationOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	results, err := n.annotations.Query(query.Query{
+		Prefix: dsAnnotations.ChildString(id.String()).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// pruned annotations are removed along the way, so entries are read upfront
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []core.RecordAnnotation
+	for _, e := range entries {
+		rid, err := cid.Decode(ds.RawKey(e.Key).Name())
+		if err != nil {
+			return nil, fmt.Errorf("decoding annotated record id: %w", err)
+		}
+		if pruned, err := n.pruneAnnotation(id, rid); err != nil {
+			return nil, err
+		} else if pruned {
+			continue
+		}
+		a, err := annotationFromBytes(rid, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		if matchAnnotation(a, args) {
+			annotations = append(annotations, a)
+		}
+	}
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].UpdatedAt.After(annotations[j].UpdatedAt)
+	})
+	return annotations, nil
+}
+
+// checkLocalRecord ensures a record of the thread is available locally.
+func (n *net) checkLocalRecord(ctx context.Context, tid thread.ID, rid cid.Cid) error {
+	if known, err := n.isKnown(rid); err != nil {
+		return err
+	} else if !known {
+		return fmt.Errorf("%w: %s", core.ErrRecordNotFound, rid)
+	}
+	// records are decoded with the thread service key
+	if _, err := n.getRecord(ctx, tid, rid); err != nil {
+		return fmt.Errorf("%w: %s isn't a record of thread %s", core.ErrRecordNotFound, rid, tid)
+	}
+	return nil
+}
+
+// pruneAnnotation removes the annotation of a record which was pruned from the blockstore.
+func (n *net) pruneAnnotation(tid thread.ID, rid cid.Cid) (bool, error) {
+	if known, err := n.isKnown(rid); err != nil || known {
+		return false, err
+	}
+	log.Debugf("removing annotation of pruned record %s (thread %s)", rid, tid)
+	return true, n.annotations.Delete(annotationKey(tid, rid))
+}
+
+// deleteAnnotations removes the annotations of all thread records.
+func (n *net) deleteAnnotations(tid thread.ID) error {
+	results, err := n.annotations.Query(query.Query{
+		Prefix:   dsAnnotations.ChildString(tid.String()).String(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := n.annotations.Delete(ds.RawKey(e.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func annotationFromBytes(rid cid.Cid, val []byte) (core.RecordAnnotation, error) {
+	var rec annotationRecord
+	if err := json.Unmarshal(val, &rec); err != nil {
+		return core.RecordAnnotation{}, fmt.Errorf("decoding annotation of record %s: %w", rid, err)
+	}
+	return core.RecordAnnotation{
+		Annotation: core.Annotation{
+			Read:    rec.Read,
+			Starred: rec.Starred,
+			Labels:  rec.Labels,
+		},
+		RecordID:  rid,
+		UpdatedAt: time.Unix(0, rec.UpdatedAt),
+	}, nil
+}
+
+func matchAnnotation(a core.RecordAnnotation, args *core.AnnotationOptions) bool {
+	if args.Unread && a.Read {
+		return false
+	}
+	if args.Starred && !a.Starred {
+		return false
+	}
+	if len(args.Label) != 0 && !containsTag(a.Labels, args.Label) {
+		return false
+	}
+	return true
+}
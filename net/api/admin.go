@@ -0,0 +1,315 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	gonet "net"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/audit"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	tnet "github.com/textileio/go-threads/net"
+	pb "github.com/textileio/go-threads/net/api/pb"
+	"github.com/textileio/go-threads/net/faults"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminService is a gRPC service for managing net API keys and peer access lists at runtime,
+// exporting the audit log, configuring injected failures and dumping thread sync state and
+// record DAGs. It should only be exposed along with the key store interceptors, which restrict
+// it to admin API keys.
+type AdminService struct {
+	keys  *KeyStore
+	audit *audit.Log
+	net   net.Net
+}
+
+// NewAdminService returns a new admin service backed by a key store and a network.
+// The audit log is optional, exports fail if it's nil. Thread snapshots, record DAG exports and
+// access lists fail unless the network implements tnet.ThreadSnapshotter, tnet.DAGExporter
+// and tnet.AccessController.
+func NewAdminService(keys *KeyStore, auditLog *audit.Log, network net.Net) *AdminService {
+	return &AdminService{keys: keys, audit: auditLog, net: network}
+}
+
+func (s *AdminService) CreateAPIKey(_ context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyReply, error) {
+	log.Debugf("received create api key request")
+
+	scope := Scope{
+		Admin:    req.Admin,
+		ReadOnly: req.ReadOnly,
+		Threads:  make([]thread.ID, len(req.ThreadIDs)),
+	}
+	for i, b := range req.ThreadIDs {
+		id, err := thread.Cast(b)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		scope.Threads[i] = id
+	}
+	key, secret, err := s.keys.Create(scope)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateAPIKeyReply{
+		Key:    apiKeyToProto(key),
+		Secret: secret,
+	}, nil
+}
+
+func (s *AdminService) ListAPIKeys(_ context.Context, _ *pb.ListAPIKeysRequest) (*pb.ListAPIKeysReply, error) {
+	log.Debugf("received list api keys request")
+
+	keys, err := s.keys.List()
+	if err != nil {
+		return nil, err
+	}
+	pkeys := make([]*pb.APIKey, len(keys))
+	for i, k := range keys {
+		pkeys[i] = apiKeyToProto(k)
+	}
+	return &pb.ListAPIKeysReply{Keys: pkeys}, nil
+}
+
+func (s *AdminService) RevokeAPIKey(_ context.Context, req *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyReply, error) {
+	log.Debugf("received revoke api key request")
+
+	if err := s.keys.Revoke(req.Key); errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return &pb.RevokeAPIKeyReply{}, nil
+}
+
+func (s *AdminService) ExportAuditLog(req *pb.ExportAuditLogRequest, server pb.Admin_ExportAuditLogServer) error {
+	log.Debugf("received export audit log request")
+
+	if s.audit == nil {
+		return status.Error(codes.FailedPrecondition, "audit log is disabled")
+	}
+	var since, until time.Time
+	if req.Since > 0 {
+		since = time.Unix(0, req.Since)
+	}
+	if req.Until > 0 {
+		until = time.Unix(0, req.Until)
+	}
+	return s.audit.Walk(since, until, func(e audit.Entry) error {
+		return server.Send(&pb.AuditEntry{
+			Time:      e.Time.UnixNano(),
+			Kind:      string(e.Kind),
+			Operation: e.Operation,
+			Peer:      e.Peer,
+			ApiKey:    e.APIKey,
+			ThreadID:  e.ThreadID,
+			LogID:     e.LogID,
+			RecordID:  e.RecordID,
+			Outcome:   string(e.Outcome),
+			Error:     e.Error,
+		})
+	})
+}
+
+// SetFaults replaces the injected failures of the host.
+// It fails with codes.Unimplemented unless failure injection is built in.
+func (s *AdminService) SetFaults(_ context.Context, req *pb.SetFaultsRequest) (*pb.SetFaultsReply, error) {
+	log.Debugf("received set faults request")
+
+	var c faults.Config
+	if req.Faults != nil {
+		c = faults.Config{
+			DropPushRecord:     req.Faults.DropPushRecord,
+			DelayExchangeEdges: time.Duration(req.Faults.DelayExchangeEdges),
+			CorruptRecordBody:  req.Faults.CorruptRecordBody,
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := faults.Set(c); errors.Is(err, faults.ErrDisabled) {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return &pb.SetFaultsReply{}, nil
+}
+
+// GetFaults returns the injected failures of the host and whether failure injection is built in.
+func (s *AdminService) GetFaults(_ context.Context, _ *pb.GetFaultsRequest) (*pb.GetFaultsReply, error) {
+	log.Debugf("received get faults request")
+
+	c := faults.Get()
+	return &pb.GetFaultsReply{
+		Enabled: faults.Enabled,
+		Faults: &pb.Faults{
+			DropPushRecord:     c.DropPushRecord,
+			DelayExchangeEdges: int64(c.DelayExchangeEdges),
+			CorruptRecordBody:  c.CorruptRecordBody,
+		},
+	}, nil
+}
+
+func (s *AdminService) GetThreadSnapshot(ctx context.Context, req *pb.GetThreadSnapshotRequest) (*pb.GetThreadSnapshotReply, error) {
+	log.Debugf("received get thread snapshot request")
+
+	snapshotter, ok := s.net.(tnet.ThreadSnapshotter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "thread snapshots aren't supported by the network")
+	}
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	snapshot, err := snapshotter.ThreadSnapshot(ctx, id)
+	if errors.Is(err, lstore.ErrThreadNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetThreadSnapshotReply{Snapshot: data}, nil
+}
+
+func (s *AdminService) SetAccessList(_ context.Context, req *pb.SetAccessListRequest) (*pb.SetAccessListReply, error) {
+	log.Debugf("received set access list request")
+
+	controller, ok := s.net.(tnet.AccessController)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "access lists aren't supported by the network")
+	}
+	list, err := AccessListFromProto(req.AccessList)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = controller.SetAccessList(list); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pb.SetAccessListReply{}, nil
+}
+
+func (s *AdminService) GetAccessList(_ context.Context, _ *pb.GetAccessListRequest) (*pb.GetAccessListReply, error) {
+	log.Debugf("received get access list request")
+
+	controller, ok := s.net.(tnet.AccessController)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "access lists aren't supported by the network")
+	}
+	return &pb.GetAccessListReply{AccessList: AccessListToProto(controller.AccessList())}, nil
+}
+
+func (s *AdminService) ExportThreadDAG(ctx context.Context, req *pb.ExportThreadDAGRequest) (*pb.ExportThreadDAGReply, error) {
+	log.Debugf("received export thread dag request")
+
+	exporter, ok := s.net.(tnet.DAGExporter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "record DAG exports aren't supported by the network")
+	}
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r := tnet.DAGRange{MinHeight: req.MinHeight, MaxHeight: req.MaxHeight}
+	for _, b := range req.LogIDs {
+		lid, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		r.Logs = append(r.Logs, lid)
+	}
+	var buf bytes.Buffer
+	err = exporter.ExportDAG(ctx, id, &buf, tnet.DAGFormat(req.Format), r)
+	if errors.Is(err, lstore.ErrThreadNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if errors.Is(err, tnet.ErrUnknownDAGFormat) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return &pb.ExportThreadDAGReply{Graph: buf.Bytes()}, nil
+}
+
+// AccessListToProto returns the proto form of an access list.
+func AccessListToProto(a tnet.AccessList) *pb.AccessList {
+	pa := &pb.AccessList{}
+	for _, pid := range a.AllowPeers {
+		pa.AllowPeers = append(pa.AllowPeers, []byte(pid))
+	}
+	for _, n := range a.AllowNets {
+		pa.AllowNets = append(pa.AllowNets, n.String())
+	}
+	for _, pid := range a.DenyPeers {
+		pa.DenyPeers = append(pa.DenyPeers, []byte(pid))
+	}
+	for _, n := range a.DenyNets {
+		pa.DenyNets = append(pa.DenyNets, n.String())
+	}
+	return pa
+}
+
+// AccessListFromProto returns the access list of its proto form, nil is an empty list.
+func AccessListFromProto(pa *pb.AccessList) (a tnet.AccessList, err error) {
+	if pa == nil {
+		return a, nil
+	}
+	if a.AllowPeers, err = peersFromProto(pa.AllowPeers); err != nil {
+		return a, err
+	}
+	if a.AllowNets, err = netsFromProto(pa.AllowNets); err != nil {
+		return a, err
+	}
+	if a.DenyPeers, err = peersFromProto(pa.DenyPeers); err != nil {
+		return a, err
+	}
+	if a.DenyNets, err = netsFromProto(pa.DenyNets); err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+func peersFromProto(ids [][]byte) ([]peer.ID, error) {
+	var peers []peer.ID
+	for _, b := range ids {
+		pid, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, pid)
+	}
+	return peers, nil
+}
+
+func netsFromProto(cidrs []string) ([]*gonet.IPNet, error) {
+	var nets []*gonet.IPNet
+	for _, c := range cidrs {
+		_, n, err := gonet.ParseCIDR(c)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func apiKeyToProto(k APIKey) *pb.APIKey {
+	ids := make([][]byte, len(k.Scope.Threads))
+	for i, id := range k.Scope.Threads {
+		ids[i] = id.Bytes()
+	}
+	return &pb.APIKey{
+		Key:       k.Key,
+		Admin:     k.Scope.Admin,
+		ReadOnly:  k.Scope.ReadOnly,
+		ThreadIDs: ids,
+		CreatedAt: k.CreatedAt.UnixNano(),
+	}
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/util"
+)
+
+const (
+	// APIKeyMDKey is the request metadata key holding an API key.
+	APIKeyMDKey = "x-api-key"
+	// APISecretMDKey is the request metadata key holding an API key secret.
+	APISecretMDKey = "x-api-secret"
+
+	apiKeyBytes    = 16
+	apiSecretBytes = 32
+)
+
+var (
+	// ErrAPIKeyNotFound indicates the API key doesn't exist or was revoked.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrInvalidAPISecret indicates the secret doesn't match the API key.
+	ErrInvalidAPISecret = errors.New("invalid api secret")
+
+	dsAPIKeys = ds.NewKey("/netapi/apikeys")
+
+	keyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// Scope restricts what an API key is allowed to do.
+type Scope struct {
+	// Admin keys have full access, including API key management.
+	Admin bool
+	// ReadOnly keys can't call methods which modify threads.
+	ReadOnly bool
+	// Threads restricts access to the listed threads. All threads are accessible if empty.
+	Threads []thread.ID
+}
+
+// AllowsThread returns whether or not the scope grants access to a thread.
+func (s Scope) AllowsThread(id thread.ID) bool {
+	if s.Admin || len(s.Threads) == 0 {
+		return true
+	}
+	for _, t := range s.Threads {
+		if t.Equals(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey is an API key authorized for a scope.
+// The key secret is only available at creation time, only its hash is stored.
+type APIKey struct {
+	Key       string
+	Scope     Scope
+	CreatedAt time.Time
+}
+
+type apiKeyRecord struct {
+	Key        string   `json:"key"`
+	SecretHash []byte   `json:"secretHash"`
+	Admin      bool     `json:"admin"`
+	ReadOnly   bool     `json:"readOnly"`
+	Threads    []string `json:"threads"`
+	CreatedAt  int64    `json:"createdAt"`
+}
+
+// KeyStore persists API keys and authenticates requests against them.
+type KeyStore struct {
+	lock sync.RWMutex
+	ds   ds.Datastore
+}
+
+// NewKeyStore returns a key store persisting API keys in store.
+func NewKeyStore(store ds.Datastore) *KeyStore {
+	return &KeyStore{ds: store}
+}
+
+// Create creates a new API key for scope and returns it with its secret.
+func (ks *KeyStore) Create(scope Scope) (key APIKey, secret string, err error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	key = APIKey{
+		Key:       keyEncoding.EncodeToString(util.GenerateRandomBytes(apiKeyBytes)),
+		Scope:     scope,
+		CreatedAt: time.Now(),
+	}
+	secret = keyEncoding.EncodeToString(util.GenerateRandomBytes(apiSecretBytes))
+	hash := sha256.Sum256([]byte(secret))
+	rec := apiKeyRecord{
+		Key:        key.Key,
+		SecretHash: hash[:],
+		Admin:      scope.Admin,
+		ReadOnly:   scope.ReadOnly,
+		Threads:    make([]string, len(scope.Threads)),
+		CreatedAt:  key.CreatedAt.UnixNano(),
+	}
+	for i, id := range scope.Threads {
+		rec.Threads[i] = id.String()
+	}
+	val, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	if err = ks.ds.Put(dsAPIKeys.ChildString(key.Key), val); err != nil {
+		return
+	}
+	return key, secret, nil
+}
+
+// Authenticate returns the API key if secret matches it.
+func (ks *KeyStore) Authenticate(key, secret string) (APIKey, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
+	rec, err := ks.get(key)
+	if err != nil {
+		return APIKey{}, err
+	}
+	hash := sha256.Sum256([]byte(secret))
+	if subtle.ConstantTimeCompare(hash[:], rec.SecretHash) != 1 {
+		return APIKey{}, ErrInvalidAPISecret
+	}
+	return rec.apiKey()
+}
+
+// List returns all API keys ordered by creation time.
+func (ks *KeyStore) List() ([]APIKey, error) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+
+	results, err := ks.ds.Query(query.Query{Prefix: dsAPIKeys.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	var keys []APIKey
+	for res := range results.Next() {
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		var rec apiKeyRecord
+		if err := json.Unmarshal(res.Value, &rec); err != nil {
+			return nil, err
+		}
+		key, err := rec.apiKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// Revoke deletes an API key.
+func (ks *KeyStore) Revoke(key string) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if _, err := ks.get(key); err != nil {
+		return err
+	}
+	return ks.ds.Delete(dsAPIKeys.ChildString(key))
+}
+
+// HasAdmin returns whether or not an admin API key exists.
+func (ks *KeyStore) HasAdmin() (bool, error) {
+	keys, err := ks.List()
+	if err != nil {
+		return false, err
+	}
+	for _, k := range keys {
+		if k.Scope.Admin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (ks *KeyStore) get(key string) (rec apiKeyRecord, err error) {
+	// keys are base32 encoded, anything else would be an invalid datastore key
+	if key == "" || strings.ContainsAny(key, "/") {
+		return rec, ErrAPIKeyNotFound
+	}
+	val, err := ks.ds.Get(dsAPIKeys.ChildString(key))
+	if errors.Is(err, ds.ErrNotFound) {
+		return rec, ErrAPIKeyNotFound
+	} else if err != nil {
+		return rec, err
+	}
+	err = json.Unmarshal(val, &rec)
+	return rec, err
+}
+
+func (r apiKeyRecord) apiKey() (APIKey, error) {
+	key := APIKey{
+		Key: r.Key,
+		Scope: Scope{
+			Admin:    r.Admin,
+			ReadOnly: r.ReadOnly,
+			Threads:  make([]thread.ID, len(r.Threads)),
+		},
+		CreatedAt: time.Unix(0, r.CreatedAt),
+	}
+	for i, s := range r.Threads {
+		id, err := thread.Decode(s)
+		if err != nil {
+			return APIKey{}, err
+		}
+		key.Scope.Threads[i] = id
+	}
+	return key, nil
+}
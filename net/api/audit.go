@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/textileio/go-threads/audit"
+	"google.golang.org/grpc"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+// adminReadOnlyMethods are admin methods which don't modify API keys, access lists or injected failures.
+var adminReadOnlyMethods = map[string]bool{
+	"ListAPIKeys":       true,
+	"ExportAuditLog":    true,
+	"GetFaults":         true,
+	"GetThreadSnapshot": true,
+	"GetAccessList":     true,
+}
+
+// IsMutatingMethod returns whether or not a full gRPC method name is a
+// net API or admin method which modifies threads, API keys, access lists or injected failures.
+func IsMutatingMethod(fullMethod string) bool {
+	switch service, method := splitMethodName(fullMethod); service {
+	case apiServiceName:
+		return !readOnlyMethods[method]
+	case adminServiceName:
+		return !adminReadOnlyMethods[method]
+	default:
+		return false
+	}
+}
+
+// AuditUnaryServerInterceptor returns a gRPC interceptor which records calls
+// to methods matched by audited in l, along with their outcome.
+func AuditUnaryServerInterceptor(l *audit.Log, audited func(fullMethod string) bool) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !audited(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		res, err := handler(ctx, req)
+		recordCall(l, ctx, info.FullMethod, req, err)
+		return res, err
+	}
+}
+
+// AuditStreamServerInterceptor returns a gRPC interceptor which records streams
+// of methods matched by audited in l, along with their outcome.
+// Threads are taken from the first message received over the stream.
+func AuditStreamServerInterceptor(l *audit.Log, audited func(fullMethod string) bool) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !audited(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		as := &auditedStream{ServerStream: ss}
+		err := handler(srv, as)
+		recordCall(l, ss.Context(), info.FullMethod, as.req, err)
+		return err
+	}
+}
+
+// recordCall records an API call in the audit log.
+// Failing to record an entry doesn't fail the call.
+func recordCall(l *audit.Log, ctx context.Context, fullMethod string, req interface{}, err error) {
+	e := audit.Entry{
+		Kind:      audit.KindAPI,
+		Operation: fullMethod,
+		APIKey:    metautils.ExtractIncoming(ctx).Get(APIKeyMDKey),
+		Outcome:   audit.OutcomeOK,
+	}
+	if p, ok := grpcpeer.FromContext(ctx); ok && p.Addr != nil {
+		e.Peer = p.Addr.String()
+	}
+	if ids, _ := requestThreads(req); len(ids) == 1 {
+		e.ThreadID = ids[0].String()
+	}
+	if err != nil {
+		e.Outcome = audit.OutcomeError
+		e.Error = err.Error()
+	}
+	if err := l.Record(e); err != nil {
+		log.Errorf("error recording api audit entry: %v", err)
+	}
+}
+
+// auditedStream keeps the first request received over a stream.
+type auditedStream struct {
+	grpc.ServerStream
+	req interface{}
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.req == nil {
+		s.req = m
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/core/thread"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	apiServiceName   = "threads.net.pb.API"
+	adminServiceName = "threads.net.pb.Admin"
+)
+
+var (
+	// readOnlyMethods are net API methods which don't modify threads.
+	readOnlyMethods = map[string]bool{
+		"GetHostID":             true,
+		"GetToken":              true,
+		"GetThread":             true,
+		"ListThreads":           true,
+		"GetThreadLogs":         true,
+		"ActivityFeed":          true,
+		"PullThread":            true,
+		"GetRecord":             true,
+		"GetAnnotation":         true,
+		"QueryAnnotations":      true,
+		"Subscribe":             true,
+		"SubscribeHeads":        true,
+		"SubscribePresence":     true,
+		"SubscribeSyncProgress": true,
+	}
+
+	// threadlessMethods are net API methods which don't expose any thread.
+	threadlessMethods = map[string]bool{
+		"GetHostID": true,
+		"GetToken":  true,
+	}
+
+	errNoThreadScope = errors.New("api key is not scoped to the requested threads")
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor which authorizes net API
+// and admin requests with API keys. Requests to other services are passed through.
+func (ks *KeyStore) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		service, method := splitMethodName(info.FullMethod)
+		if service != apiServiceName && service != adminServiceName {
+			return handler(ctx, req)
+		}
+		key, err := ks.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = authorize(key, service, method, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor which authorizes net API
+// and admin streams with API keys. Streams to other services are passed through.
+func (ks *KeyStore) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		service, method := splitMethodName(info.FullMethod)
+		if service != apiServiceName && service != adminServiceName {
+			return handler(srv, ss)
+		}
+		key, err := ks.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		if info.IsClientStream {
+			// client streams don't target threads
+			if err = authorize(key, service, method, nil); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}
+		// the single request of a server stream is authorized once received
+		return handler(srv, &authorizedStream{
+			ServerStream: ss,
+			authorize: func(req interface{}) error {
+				return authorize(key, service, method, req)
+			},
+		})
+	}
+}
+
+// authenticate returns the API key from the incoming request metadata.
+func (ks *KeyStore) authenticate(ctx context.Context) (APIKey, error) {
+	md := metautils.ExtractIncoming(ctx)
+	key, secret := md.Get(APIKeyMDKey), md.Get(APISecretMDKey)
+	if key == "" {
+		return APIKey{}, status.Error(codes.Unauthenticated, "api key required")
+	}
+	k, err := ks.Authenticate(key, secret)
+	if errors.Is(err, ErrAPIKeyNotFound) || errors.Is(err, ErrInvalidAPISecret) {
+		return APIKey{}, status.Error(codes.Unauthenticated, err.Error())
+	} else if err != nil {
+		return APIKey{}, status.Error(codes.Internal, err.Error())
+	}
+	return k, nil
+}
+
+// authorize checks that the key's scope allows calling method with req.
+func authorize(key APIKey, service, method string, req interface{}) error {
+	if key.Scope.Admin {
+		return nil
+	}
+	if service == adminServiceName {
+		return status.Error(codes.PermissionDenied, "admin api key required")
+	}
+	if key.Scope.ReadOnly && !readOnlyMethods[method] {
+		return status.Error(codes.PermissionDenied, "api key is read-only")
+	}
+	if len(key.Scope.Threads) == 0 || threadlessMethods[method] {
+		return nil
+	}
+	ids, err := requestThreads(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(ids) == 0 {
+		// the request targets all threads
+		return status.Error(codes.PermissionDenied, errNoThreadScope.Error())
+	}
+	for _, id := range ids {
+		if !key.Scope.AllowsThread(id) {
+			return status.Error(codes.PermissionDenied, errNoThreadScope.Error())
+		}
+	}
+	return nil
+}
+
+// requestThreads returns the IDs of the threads targeted by a net or db API request.
+func requestThreads(req interface{}) ([]thread.ID, error) {
+	switch r := req.(type) {
+	case interface{ GetThreadID() []byte }:
+		id, err := thread.Cast(r.GetThreadID())
+		if err != nil {
+			return nil, err
+		}
+		return []thread.ID{id}, nil
+	case interface{ GetThreadIDs() [][]byte }:
+		ids := make([]thread.ID, len(r.GetThreadIDs()))
+		for i, b := range r.GetThreadIDs() {
+			id, err := thread.Cast(b)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+		return ids, nil
+	case interface{ GetDbID() []byte }:
+		// db IDs are thread IDs
+		id, err := thread.Cast(r.GetDbID())
+		if err != nil {
+			return nil, err
+		}
+		return []thread.ID{id}, nil
+	case interface{ GetAddr() []byte }:
+		addr, err := ma.NewMultiaddrBytes(r.GetAddr())
+		if err != nil {
+			return nil, err
+		}
+		str, err := addr.ValueForProtocol(thread.Code)
+		if err != nil {
+			return nil, err
+		}
+		id, err := thread.Decode(str)
+		if err != nil {
+			return nil, err
+		}
+		return []thread.ID{id}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// splitMethodName splits a full gRPC method name into service and method names.
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
+
+// authorizedStream authorizes the request received over a server stream.
+type authorizedStream struct {
+	grpc.ServerStream
+	authorize func(interface{}) error
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.authorize(m)
+}
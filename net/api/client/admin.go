@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/textileio/go-threads/audit"
+	"github.com/textileio/go-threads/core/thread"
+	tnet "github.com/textileio/go-threads/net"
+	"github.com/textileio/go-threads/net/api"
+	pb "github.com/textileio/go-threads/net/api/pb"
+	"github.com/textileio/go-threads/net/faults"
+	"google.golang.org/grpc"
+)
+
+// APIKeyCredentials implements PerRPCCredentials, including an API key
+// and its secret in request metadata.
+type APIKeyCredentials struct {
+	Key    string
+	Secret string
+	Secure bool
+}
+
+func (c APIKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{
+		api.APIKeyMDKey:    c.Key,
+		api.APISecretMDKey: c.Secret,
+	}, nil
+}
+
+func (c APIKeyCredentials) RequireTransportSecurity() bool {
+	return c.Secure
+}
+
+// AdminClient provides the admin api for managing API keys and access lists, exporting
+// the audit log, configuring injected failures and dumping thread sync state.
+type AdminClient struct {
+	c    pb.AdminClient
+	conn *grpc.ClientConn
+}
+
+// NewAdminClient starts the admin client.
+// Use APIKeyCredentials with an admin API key to authorize requests.
+func NewAdminClient(target string, opts ...grpc.DialOption) (*AdminClient, error) {
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminClient{
+		c:    pb.NewAdminClient(conn),
+		conn: conn,
+	}, nil
+}
+
+// Close closes the client's grpc connection and cancels any active requests.
+func (c *AdminClient) Close() error {
+	return c.conn.Close()
+}
+
+// CreateAPIKey creates a new API key for scope and returns it with its secret.
+func (c *AdminClient) CreateAPIKey(ctx context.Context, scope api.Scope) (api.APIKey, string, error) {
+	ids := make([][]byte, len(scope.Threads))
+	for i, id := range scope.Threads {
+		ids[i] = id.Bytes()
+	}
+	resp, err := c.c.CreateAPIKey(ctx, &pb.CreateAPIKeyRequest{
+		Admin:     scope.Admin,
+		ReadOnly:  scope.ReadOnly,
+		ThreadIDs: ids,
+	})
+	if err != nil {
+		return api.APIKey{}, "", err
+	}
+	key, err := apiKeyFromProto(resp.Key)
+	if err != nil {
+		return api.APIKey{}, "", err
+	}
+	return key, resp.Secret, nil
+}
+
+// ListAPIKeys returns all API keys.
+func (c *AdminClient) ListAPIKeys(ctx context.Context) ([]api.APIKey, error) {
+	resp, err := c.c.ListAPIKeys(ctx, &pb.ListAPIKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]api.APIKey, len(resp.Keys))
+	for i, k := range resp.Keys {
+		if keys[i], err = apiKeyFromProto(k); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key.
+func (c *AdminClient) RevokeAPIKey(ctx context.Context, key string) error {
+	_, err := c.c.RevokeAPIKey(ctx, &pb.RevokeAPIKeyRequest{
+		Key: key,
+	})
+	return err
+}
+
+// ExportAuditLog returns the audit log entries recorded in [since, until), oldest first.
+// Zero times leave the range open.
+func (c *AdminClient) ExportAuditLog(ctx context.Context, since, until time.Time) ([]audit.Entry, error) {
+	req := &pb.ExportAuditLogRequest{}
+	if !since.IsZero() {
+		req.Since = since.UnixNano()
+	}
+	if !until.IsZero() {
+		req.Until = until.UnixNano()
+	}
+	stream, err := c.c.ExportAuditLog(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var entries []audit.Entry
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		entries = append(entries, audit.Entry{
+			Time:      time.Unix(0, e.Time),
+			Kind:      audit.Kind(e.Kind),
+			Operation: e.Operation,
+			Peer:      e.Peer,
+			APIKey:    e.ApiKey,
+			ThreadID:  e.ThreadID,
+			LogID:     e.LogID,
+			RecordID:  e.RecordID,
+			Outcome:   audit.Outcome(e.Outcome),
+			Error:     e.Error,
+		})
+	}
+	return entries, nil
+}
+
+// SetFaults replaces failures injected by the host, zero config stops injection.
+// It fails with codes.Unimplemented if the host is built without the chaos tag.
+func (c *AdminClient) SetFaults(ctx context.Context, config faults.Config) error {
+	_, err := c.c.SetFaults(ctx, &pb.SetFaultsRequest{
+		Faults: &pb.Faults{
+			DropPushRecord:     config.DropPushRecord,
+			DelayExchangeEdges: int64(config.DelayExchangeEdges),
+			CorruptRecordBody:  config.CorruptRecordBody,
+		},
+	})
+	return err
+}
+
+// GetFaults returns failures injected by the host, and whether injection is built in.
+func (c *AdminClient) GetFaults(ctx context.Context) (faults.Config, bool, error) {
+	resp, err := c.c.GetFaults(ctx, &pb.GetFaultsRequest{})
+	if err != nil {
+		return faults.Config{}, false, err
+	}
+	var config faults.Config
+	if resp.Faults != nil {
+		config = faults.Config{
+			DropPushRecord:     resp.Faults.DropPushRecord,
+			DelayExchangeEdges: time.Duration(resp.Faults.DelayExchangeEdges),
+			CorruptRecordBody:  resp.Faults.CorruptRecordBody,
+		}
+	}
+	return config, resp.Enabled, nil
+}
+
+// GetThreadSnapshot returns the sync state of a thread as JSON, e.g. to attach it to bug reports.
+// It includes the logstore entries, queued calls, the latest exchanges with each peer and recent errors.
+func (c *AdminClient) GetThreadSnapshot(ctx context.Context, id thread.ID) ([]byte, error) {
+	resp, err := c.c.GetThreadSnapshot(ctx, &pb.GetThreadSnapshotRequest{ThreadID: id.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshot, nil
+}
+
+// ExportThreadDAG returns the record DAG of a thread in a format, tnet.DAGFormatDOT or
+// tnet.DAGFormatGraphML, e.g. to visualize the logs when debugging record ordering.
+// Records are labeled with their log, height, author and size.
+func (c *AdminClient) ExportThreadDAG(ctx context.Context, id thread.ID, format tnet.DAGFormat, r tnet.DAGRange) ([]byte, error) {
+	req := &pb.ExportThreadDAGRequest{
+		ThreadID:  id.Bytes(),
+		Format:    string(format),
+		MinHeight: r.MinHeight,
+		MaxHeight: r.MaxHeight,
+	}
+	for _, lid := range r.Logs {
+		req.LogIDs = append(req.LogIDs, []byte(lid))
+	}
+	resp, err := c.c.ExportThreadDAG(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Graph, nil
+}
+
+// SetAccessList replaces the access list of the host's network service.
+// Open connections of peers which aren't allowed anymore are closed.
+func (c *AdminClient) SetAccessList(ctx context.Context, list tnet.AccessList) error {
+	_, err := c.c.SetAccessList(ctx, &pb.SetAccessListRequest{AccessList: api.AccessListToProto(list)})
+	return err
+}
+
+// GetAccessList returns the access list of the host's network service.
+func (c *AdminClient) GetAccessList(ctx context.Context) (tnet.AccessList, error) {
+	resp, err := c.c.GetAccessList(ctx, &pb.GetAccessListRequest{})
+	if err != nil {
+		return tnet.AccessList{}, err
+	}
+	return api.AccessListFromProto(resp.AccessList)
+}
+
+func apiKeyFromProto(k *pb.APIKey) (key api.APIKey, err error) {
+	threads := make([]thread.ID, len(k.ThreadIDs))
+	for i, b := range k.ThreadIDs {
+		if threads[i], err = thread.Cast(b); err != nil {
+			return
+		}
+	}
+	return api.APIKey{
+		Key: k.Key,
+		Scope: api.Scope{
+			Admin:    k.Admin,
+			ReadOnly: k.ReadOnly,
+			Threads:  threads,
+		},
+		CreatedAt: time.Unix(0, k.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/ipfs/go-cid"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+// RecordCacheConfig bounds the client-side cache of decoded records.
+type RecordCacheConfig struct {
+	// Size is the maximum number of cached records, least recently used ones are evicted first.
+	Size int
+	// TTL is how long records stay cached after they're added, they don't expire if zero.
+	TTL time.Duration
+}
+
+// Validate returns an error if the config is invalid.
+func (c RecordCacheConfig) Validate() error {
+	if c.Size <= 0 {
+		return errors.New("record cache size must be positive")
+	}
+	if c.TTL < 0 {
+		return errors.New("record cache TTL must not be negative")
+	}
+	return nil
+}
+
+type cachedRecord struct {
+	rec     core.Record
+	expires time.Time
+}
+
+// recordCache keeps decoded records keyed by CID, so records seen once through
+// the client aren't fetched and decoded again. A nil cache caches nothing.
+type recordCache struct {
+	lru *lru.Cache
+	ttl time.Duration
+}
+
+func newRecordCache(conf RecordCacheConfig) (*recordCache, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	c, err := lru.New(conf.Size)
+	if err != nil {
+		return nil, err
+	}
+	return &recordCache{lru: c, ttl: conf.TTL}, nil
+}
+
+func (rc *recordCache) get(rid cid.Cid) (core.Record, bool) {
+	if rc == nil {
+		return nil, false
+	}
+	v, ok := rc.lru.Get(rid)
+	if !ok {
+		return nil, false
+	}
+	cr := v.(cachedRecord)
+	if !cr.expires.IsZero() && time.Now().After(cr.expires) {
+		rc.lru.Remove(rid)
+		return nil, false
+	}
+	return cr.rec, true
+}
+
+func (rc *recordCache) add(rec core.Record) {
+	if rc == nil {
+		return
+	}
+	cr := cachedRecord{rec: rec}
+	if rc.ttl > 0 {
+		cr.expires = time.Now().Add(rc.ttl)
+	}
+	rc.lru.Add(rec.Cid(), cr)
+}
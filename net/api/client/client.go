@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
@@ -25,10 +26,21 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	resubscribeMinDelay = time.Millisecond * 500
+	resubscribeMaxDelay = time.Second * 30
+	minConnectTimeout   = time.Second * 20
+)
+
 // Client provides the client api.
 type Client struct {
-	c    pb.APIClient
-	conn *grpc.ClientConn
+	c     pb.APIClient
+	conn  *grpc.ClientConn
+	cache *recordCache
+	// reconnect is the managed reconnection config, nil if disabled
+	reconnect *ReconnectConfig
+	// cancel stops watching the connection state, if watched
+	cancel context.CancelFunc
 }
 
 var _ core.API = (*Client)(nil)
@@ -45,8 +57,27 @@ func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
 	}, nil
 }
 
+// NewClientWithRecordCache starts the client with a cache of records it has seen.
+// Cached records are returned by GetRecord without a request, which skips access checks
+// of later requests, and subscriptions are resumed when the connection drops.
+func NewClientWithRecordCache(target string, conf RecordCacheConfig, opts ...grpc.DialOption) (*Client, error) {
+	cache, err := newRecordCache(conf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record cache config: %w", err)
+	}
+	c, err := NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache = cache
+	return c, nil
+}
+
 // Close closes the client's grpc connection and cancels any active requests.
 func (c *Client) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
 	return c.conn.Close()
 }
 
@@ -132,6 +163,7 @@ func (c *Client) CreateThread(ctx context.Context, id thread.ID, opts ...core.Ne
 	resp, err := c.c.CreateThread(ctx, &pb.CreateThreadRequest{
 		ThreadID: id.Bytes(),
 		Keys:     keys,
+		Tags:     args.Tags,
 	})
 	if err != nil {
 		return
@@ -152,6 +184,7 @@ func (c *Client) AddThread(ctx context.Context, addr ma.Multiaddr, opts ...core.
 	resp, err := c.c.AddThread(ctx, &pb.AddThreadRequest{
 		Addr: addr.Bytes(),
 		Keys: keys,
+		Tags: args.Tags,
 	})
 	if err != nil {
 		return
@@ -174,6 +207,117 @@ func (c *Client) GetThread(ctx context.Context, id thread.ID, opts ...core.Threa
 	return threadInfoFromProto(resp)
 }
 
+func (c *Client) ListThreads(ctx context.Context, opts ...core.ListOption) (page core.ThreadsPage, err error) {
+	args := &core.ListOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	req := &pb.ListThreadsRequest{
+		PageToken: args.PageToken,
+		Limit:     int32(args.Limit),
+		Tag:       args.Tag,
+		Health:    pb.SyncHealth(args.Health),
+	}
+	if !args.ActiveSince.IsZero() {
+		req.ActiveSince = args.ActiveSince.UnixNano()
+	}
+	if !args.ActiveBefore.IsZero() {
+		req.ActiveBefore = args.ActiveBefore.UnixNano()
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.ListThreads(ctx, req)
+	if err != nil {
+		return
+	}
+	page.Threads = make([]core.ThreadSummary, len(resp.Threads))
+	for i, t := range resp.Threads {
+		id, err := thread.Cast(t.ThreadID)
+		if err != nil {
+			return page, err
+		}
+		page.Threads[i] = core.ThreadSummary{
+			ID:        id,
+			Tags:      t.Tags,
+			Health:    core.SyncHealth(t.Health),
+			Usage:     t.Usage,
+			Quota:     t.Quota,
+			OverQuota: t.OverQuota,
+		}
+		if t.LastActivity != 0 {
+			page.Threads[i].LastActivity = time.Unix(0, t.LastActivity)
+		}
+		if t.LastSync != 0 {
+			page.Threads[i].LastSync = time.Unix(0, t.LastSync)
+		}
+	}
+	page.NextPageToken = resp.NextPageToken
+	return page, nil
+}
+
+func (c *Client) GetVectorClock(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.VectorClock, error) {
+	info, err := c.GetThread(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return core.VectorClockFromInfo(info), nil
+}
+
+func (c *Client) GetThreadLogs(ctx context.Context, id thread.ID, opts ...core.ListOption) (page core.LogsPage, err error) {
+	args := &core.ListOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetThreadLogs(ctx, &pb.GetThreadLogsRequest{
+		ThreadID:  id.Bytes(),
+		PageToken: args.PageToken,
+		Limit:     int32(args.Limit),
+	})
+	if err != nil {
+		return
+	}
+	page.Logs = make([]thread.LogInfo, len(resp.Logs))
+	for i, lg := range resp.Logs {
+		if page.Logs[i], err = logInfoFromProto(lg); err != nil {
+			return
+		}
+	}
+	page.NextPageToken = resp.NextPageToken
+	return page, nil
+}
+
+func (c *Client) ActivityFeed(
+	ctx context.Context,
+	id thread.ID,
+	since time.Time,
+	limit int,
+	opts ...core.ThreadOption,
+) ([]core.RecordSummary, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	req := &pb.ActivityFeedRequest{
+		ThreadID: id.Bytes(),
+		Limit:    int32(limit),
+	}
+	if !since.IsZero() {
+		req.Since = since.UnixNano()
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.ActivityFeed(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	feed := make([]core.RecordSummary, len(resp.Records))
+	for i, r := range resp.Records {
+		if feed[i], err = recordSummaryFromProto(r); err != nil {
+			return nil, err
+		}
+	}
+	return feed, nil
+}
+
 func (c *Client) PullThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -186,6 +330,19 @@ func (c *Client) PullThread(ctx context.Context, id thread.ID, opts ...core.Thre
 	return err
 }
 
+func (c *Client) PullThreadFrom(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.PullThreadFrom(ctx, &pb.PullThreadFromRequest{
+		ThreadID: id.Bytes(),
+		Addr:     paddr.Bytes(),
+	})
+	return err
+}
+
 func (c *Client) DeleteThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -214,24 +371,58 @@ func (c *Client) AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multi
 	return peer.IDFromBytes(resp.PeerID)
 }
 
+func (c *Client) UpdateLogAddrs(ctx context.Context, id thread.ID, lid peer.ID, addrs []ma.Multiaddr, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	lidb, _ := lid.Marshal()
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	req := &pb.UpdateLogAddrsRequest{
+		ThreadID: id.Bytes(),
+		LogID:    lidb,
+		Addrs:    make([][]byte, len(addrs)),
+	}
+	for i, a := range addrs {
+		req.Addrs[i] = a.Bytes()
+	}
+	_, err := c.c.UpdateLogAddrs(ctx, req)
+	return err
+}
+
 func (c *Client) CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...core.ThreadOption) (core.ThreadRecord, error) {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
+	if err := args.Extensions.Validate(); err != nil {
+		return nil, err
+	}
 	info, err := c.GetThread(ctx, id, opts...)
 	if err != nil {
 		return nil, err
 	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
-	resp, err := c.c.CreateRecord(ctx, &pb.CreateRecordRequest{
-		ThreadID: id.Bytes(),
-		Body:     body.RawData(),
-	})
+	req := &pb.CreateRecordRequest{
+		ThreadID:       id.Bytes(),
+		Body:           body.RawData(),
+		IdempotencyKey: args.IdempotencyKey,
+		RecordType:     args.RecordType,
+		Priority:       int32(args.Priority),
+	}
+	for _, k := range args.Extensions.Keys() {
+		req.Extensions = append(req.Extensions, &pb.RecordExtension{Key: k, Value: args.Extensions[k]})
+	}
+	resp, err := c.c.CreateRecord(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := threadRecordFromProto(resp, info.Key.Service())
 	if err != nil {
 		return nil, err
 	}
-	return threadRecordFromProto(resp, info.Key.Service())
+	c.cache.add(rec.Value())
+	return rec, nil
 }
 
 func (c *Client) AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core.Record, opts ...core.ThreadOption) error {
@@ -250,10 +441,17 @@ func (c *Client) AddRecord(ctx context.Context, id thread.ID, lid peer.ID, rec c
 		LogID:    lidb,
 		Record:   util.RecFromServiceRec(prec),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	c.cache.add(rec)
+	return nil
 }
 
 func (c *Client) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts ...core.ThreadOption) (core.Record, error) {
+	if rec, ok := c.cache.get(rid); ok {
+		return rec, nil
+	}
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
 		opt(args)
@@ -270,7 +468,85 @@ func (c *Client) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts
 	if err != nil {
 		return nil, err
 	}
-	return cbor.RecordFromProto(util.RecToServiceRec(resp.Record), info.Key.Service())
+	rec, err := cbor.RecordFromProto(util.RecToServiceRec(resp.Record), info.Key.Service())
+	if err != nil {
+		return nil, err
+	}
+	c.cache.add(rec)
+	return rec, nil
+}
+
+func (c *Client) AnnotateRecord(
+	ctx context.Context,
+	id thread.ID,
+	rid cid.Cid,
+	a core.Annotation,
+	opts ...core.ThreadOption,
+) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.AnnotateRecord(ctx, &pb.AnnotateRecordRequest{
+		ThreadID: id.Bytes(),
+		RecordID: rid.Bytes(),
+		Annotation: &pb.Annotation{
+			Read:    a.Read,
+			Starred: a.Starred,
+			Labels:  a.Labels,
+		},
+	})
+	return err
+}
+
+func (c *Client) GetAnnotation(
+	ctx context.Context,
+	id thread.ID,
+	rid cid.Cid,
+	opts ...core.ThreadOption,
+) (core.RecordAnnotation, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetAnnotation(ctx, &pb.GetAnnotationRequest{
+		ThreadID: id.Bytes(),
+		RecordID: rid.Bytes(),
+	})
+	if err != nil {
+		return core.RecordAnnotation{}, err
+	}
+	return recordAnnotationFromProto(resp.Annotation)
+}
+
+func (c *Client) QueryAnnotations(
+	ctx context.Context,
+	id thread.ID,
+	opts ...core.AnnotationOption,
+) ([]core.RecordAnnotation, error) {
+	args := &core.AnnotationOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.QueryAnnotations(ctx, &pb.QueryAnnotationsRequest{
+		ThreadID: id.Bytes(),
+		Unread:   args.Unread,
+		Starred:  args.Starred,
+		Label:    args.Label,
+	})
+	if err != nil {
+		return nil, err
+	}
+	annotations := make([]core.RecordAnnotation, len(resp.Annotations))
+	for i, a := range resp.Annotations {
+		if annotations[i], err = recordAnnotationFromProto(a); err != nil {
+			return nil, err
+		}
+	}
+	return annotations, nil
 }
 
 func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan core.ThreadRecord, error) {
@@ -279,17 +555,28 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 		opt(args)
 	}
 	ids := make([][]byte, len(args.ThreadIDs))
-	var err error
 	for i, id := range args.ThreadIDs {
 		ids[i] = id.Bytes()
 	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
-	stream, err := c.c.Subscribe(ctx, &pb.SubscribeRequest{
+	req := &pb.SubscribeRequest{
 		ThreadIDs: ids,
-	})
+		Tags:      args.Tags,
+	}
+	stream, err := c.c.Subscribe(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	// delivered are the log heights of the records delivered so far, which resubscriptions
+	// resume after. Filtered threads start at their current heights.
+	delivered := make(map[thread.ID]core.VectorClock)
+	if c.resubscribes() {
+		for _, id := range args.ThreadIDs {
+			if delivered[id], err = c.GetVectorClock(ctx, id, core.WithThreadToken(args.Token)); err != nil {
+				return nil, err
+			}
+		}
+	}
 	threads := make(map[thread.ID]*symmetric.Key) // Service-key cache
 	channel := make(chan core.ThreadRecord)
 	go func() {
@@ -301,6 +588,16 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 			}
 			if err != nil {
 				stat := status.Convert(err)
+				if stat.Code() == codes.Unavailable && c.resubscribes() {
+					req.Resume = resumePoints(delivered)
+					if c.retry(ctx, func() (err error) {
+						stream, err = c.c.Subscribe(ctx, req)
+						return err
+					}) {
+						continue
+					}
+					return
+				}
 				if stat.Code() != codes.Canceled {
 					log.Fatalf("error in subscription stream: %v", err)
 				}
@@ -327,12 +624,221 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 			if err != nil {
 				log.Fatalf("error unpacking record: %v", err)
 			}
+			if c.resubscribes() {
+				clock, ok := delivered[threadID]
+				if !ok {
+					clock = make(core.VectorClock)
+					delivered[threadID] = clock
+				}
+				clock.Observe(rec.LogID(), rec.Clock()[rec.LogID()])
+			}
+			c.cache.add(rec.Value())
 			channel <- rec
 		}
 	}()
 	return channel, nil
 }
 
+// resumePoints returns the log heights of delivered records, which a subscription resumes after.
+func resumePoints(delivered map[thread.ID]core.VectorClock) []*pb.ResumePoint {
+	points := make([]*pb.ResumePoint, 0, len(delivered))
+	for id, clock := range delivered {
+		rp := &pb.ResumePoint{ThreadID: id.Bytes()}
+		for lid, h := range clock {
+			lidb, _ := lid.Marshal()
+			rp.Heads = append(rp.Heads, &pb.LogHead{LogID: lidb, Counter: h})
+		}
+		points = append(points, rp)
+	}
+	return points
+}
+
+func (c *Client) SubscribeHeads(ctx context.Context, opts ...core.SubOption) (<-chan core.HeadsUpdate, error) {
+	args := &core.SubOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ids := make([][]byte, len(args.ThreadIDs))
+	for i, id := range args.ThreadIDs {
+		ids[i] = id.Bytes()
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	req := &pb.SubscribeHeadsRequest{
+		ThreadIDs: ids,
+		Tags:      args.Tags,
+	}
+	stream, err := c.c.SubscribeHeads(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	channel := make(chan core.HeadsUpdate)
+	go func() {
+		defer close(channel)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				stat := status.Convert(err)
+				if stat.Code() == codes.Unavailable && c.resubscribes() {
+					if c.retry(ctx, func() (err error) {
+						stream, err = c.c.SubscribeHeads(ctx, req)
+						return err
+					}) {
+						continue
+					}
+					return
+				}
+				if stat.Code() != codes.Canceled {
+					log.Fatalf("error in heads stream: %v", err)
+				}
+				return
+			}
+			u, err := headsUpdateFromProto(resp)
+			if err != nil {
+				log.Fatalf("error unpacking heads: %v", err)
+			}
+			channel <- u
+		}
+	}()
+	return channel, nil
+}
+
+func (c *Client) PublishPresence(
+	ctx context.Context,
+	id thread.ID,
+	status core.PresenceStatus,
+	payload []byte,
+	opts ...core.ThreadOption,
+) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	req := &pb.PublishPresenceRequest{
+		ThreadID: id.Bytes(),
+		Status:   pb.PresenceStatus(status),
+		Payload:  payload,
+	}
+	if args.Signer != nil {
+		// the host publishes presence signed by the identity
+		host, err := c.GetHostID(ctx)
+		if err != nil {
+			return err
+		}
+		p := core.Presence{
+			ThreadID: id,
+			Peer:     host,
+			Identity: args.Signer.GetPublic(),
+			Status:   status,
+			Payload:  payload,
+			Time:     time.Now(),
+		}
+		if req.IdentitySig, err = args.Signer.Sign(ctx, core.PresenceIdentityPayload(p)); err != nil {
+			return err
+		}
+		req.Timestamp = p.Time.UnixNano()
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.PublishPresence(ctx, req)
+	return err
+}
+
+func (c *Client) SubscribePresence(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (<-chan core.Presence, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	req := &pb.SubscribePresenceRequest{
+		ThreadID: id.Bytes(),
+	}
+	stream, err := c.c.SubscribePresence(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	channel := make(chan core.Presence)
+	go func() {
+		defer close(channel)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				stat := status.Convert(err)
+				if stat.Code() == codes.Unavailable && c.resubscribes() {
+					if c.retry(ctx, func() (err error) {
+						stream, err = c.c.SubscribePresence(ctx, req)
+						return err
+					}) {
+						continue
+					}
+					return
+				}
+				if stat.Code() != codes.Canceled {
+					log.Fatalf("error in presence stream: %v", err)
+				}
+				return
+			}
+			p, err := presenceFromProto(resp)
+			if err != nil {
+				log.Fatalf("error unpacking presence: %v", err)
+			}
+			channel <- p
+		}
+	}()
+	return channel, nil
+}
+
+func (c *Client) SubscribeSyncProgress(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (<-chan core.SyncProgress, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	req := &pb.SubscribeSyncProgressRequest{
+		ThreadID: id.Bytes(),
+	}
+	stream, err := c.c.SubscribeSyncProgress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	channel := make(chan core.SyncProgress)
+	go func() {
+		defer close(channel)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				stat := status.Convert(err)
+				if stat.Code() == codes.Unavailable && c.resubscribes() {
+					if c.retry(ctx, func() (err error) {
+						stream, err = c.c.SubscribeSyncProgress(ctx, req)
+						return err
+					}) {
+						continue
+					}
+					return
+				}
+				if stat.Code() != codes.Canceled {
+					log.Fatalf("error in sync progress stream: %v", err)
+				}
+				return
+			}
+			p, err := syncProgressFromProto(resp)
+			if err != nil {
+				log.Fatalf("error unpacking sync progress: %v", err)
+			}
+			channel <- p
+		}
+	}()
+	return channel, nil
+}
+
 func getThreadKeys(args *core.NewThreadOptions) (*pb.Keys, error) {
 	keys := &pb.Keys{
 		ThreadKey: args.ThreadKey.Bytes(),
@@ -358,52 +864,8 @@ func threadInfoFromProto(reply *pb.ThreadInfoReply) (info thread.Info, err error
 	}
 	logs := make([]thread.LogInfo, len(reply.Logs))
 	for i, lg := range reply.Logs {
-		id, err := peer.IDFromBytes(lg.ID)
-		if err != nil {
-			return info, err
-		}
-		pk, err := ic.UnmarshalPublicKey(lg.PubKey)
-		if err != nil {
-			return info, err
-		}
-		var sk ic.PrivKey
-		if lg.PrivKey != nil {
-			sk, err = ic.UnmarshalPrivateKey(lg.PrivKey)
-			if err != nil {
-				return info, err
-			}
-		}
-		addrs := make([]ma.Multiaddr, len(lg.Addrs))
-		for j, addr := range lg.Addrs {
-			addrs[j], err = ma.NewMultiaddrBytes(addr)
-			if err != nil {
-				return info, err
-			}
-		}
-		var head cid.Cid
-		if lg.Head != nil {
-			head, err = cid.Cast(lg.Head)
-			if err != nil {
-				return info, err
-			}
-		} else {
-			head = cid.Undef
-		}
-		var counter int64
-		if lg.Counter != nil {
-			counter, _ = binary.Varint(lg.Counter)
-		} else {
-			counter = thread.CounterUndef
-		}
-		logs[i] = thread.LogInfo{
-			ID:      id,
-			PubKey:  pk,
-			PrivKey: sk,
-			Addrs:   addrs,
-			Head:    thread.Head{
-				ID:      head,
-				Counter: counter,
-			},
+		if logs[i], err = logInfoFromProto(lg); err != nil {
+			return
 		}
 	}
 	addrs := make([]ma.Multiaddr, len(reply.Addrs))
@@ -421,6 +883,93 @@ func threadInfoFromProto(reply *pb.ThreadInfoReply) (info thread.Info, err error
 	}, nil
 }
 
+func recordSummaryFromProto(r *pb.RecordSummary) (s core.RecordSummary, err error) {
+	if s.ID, err = cid.Cast(r.RecordID); err != nil {
+		return
+	}
+	if s.LogID, err = peer.IDFromBytes(r.LogID); err != nil {
+		return
+	}
+	if len(r.Author) != 0 {
+		author := &thread.Libp2pPubKey{}
+		if err = author.UnmarshalBinary(r.Author); err != nil {
+			return
+		}
+		s.Author = author
+	}
+	if r.Time != 0 {
+		s.Time = time.Unix(0, r.Time)
+	}
+	s.Size = int(r.Size_)
+	s.Type = r.Type
+	return s, nil
+}
+
+func recordAnnotationFromProto(a *pb.RecordAnnotation) (ra core.RecordAnnotation, err error) {
+	if ra.RecordID, err = cid.Cast(a.RecordID); err != nil {
+		return
+	}
+	if a.Annotation != nil {
+		ra.Read = a.Annotation.Read
+		ra.Starred = a.Annotation.Starred
+		ra.Labels = a.Annotation.Labels
+	}
+	if a.UpdatedAt != 0 {
+		ra.UpdatedAt = time.Unix(0, a.UpdatedAt)
+	}
+	return ra, nil
+}
+
+func logInfoFromProto(lg *pb.LogInfo) (info thread.LogInfo, err error) {
+	id, err := peer.IDFromBytes(lg.ID)
+	if err != nil {
+		return info, err
+	}
+	pk, err := ic.UnmarshalPublicKey(lg.PubKey)
+	if err != nil {
+		return info, err
+	}
+	var sk ic.PrivKey
+	if lg.PrivKey != nil {
+		sk, err = ic.UnmarshalPrivateKey(lg.PrivKey)
+		if err != nil {
+			return info, err
+		}
+	}
+	addrs := make([]ma.Multiaddr, len(lg.Addrs))
+	for j, addr := range lg.Addrs {
+		addrs[j], err = ma.NewMultiaddrBytes(addr)
+		if err != nil {
+			return info, err
+		}
+	}
+	var head cid.Cid
+	if lg.Head != nil {
+		head, err = cid.Cast(lg.Head)
+		if err != nil {
+			return info, err
+		}
+	} else {
+		head = cid.Undef
+	}
+	var counter int64
+	if lg.Counter != nil {
+		counter, _ = binary.Varint(lg.Counter)
+	} else {
+		counter = thread.CounterUndef
+	}
+	return thread.LogInfo{
+		ID:      id,
+		PubKey:  pk,
+		PrivKey: sk,
+		Addrs:   addrs,
+		Head: thread.Head{
+			ID:      head,
+			Counter: counter,
+		},
+	}, nil
+}
+
 func threadRecordFromProto(reply *pb.NewRecordReply, key crypto.DecryptionKey) (core.ThreadRecord, error) {
 	threadID, err := thread.Cast(reply.ThreadID)
 	if err != nil {
@@ -437,5 +986,95 @@ func threadRecordFromProto(reply *pb.NewRecordReply, key crypto.DecryptionKey) (
 	if err = threadID.Validate(); err != nil {
 		return nil, err
 	}
-	return net.NewRecord(rec, threadID, logID), nil
+	var clock core.VectorClock
+	if len(reply.Clock) > 0 {
+		clock = make(core.VectorClock, len(reply.Clock))
+		for _, h := range reply.Clock {
+			lid, err := peer.IDFromBytes(h.LogID)
+			if err != nil {
+				return nil, err
+			}
+			clock[lid] = h.Counter
+		}
+	}
+	return net.NewRecordWithClock(rec, threadID, logID, clock), nil
+}
+
+func headsUpdateFromProto(reply *pb.HeadsReply) (u core.HeadsUpdate, err error) {
+	threadID, err := thread.Cast(reply.ThreadID)
+	if err != nil {
+		return
+	}
+	heads := make(map[peer.ID]thread.Head, len(reply.Heads))
+	for _, h := range reply.Heads {
+		lid, err := peer.IDFromBytes(h.LogID)
+		if err != nil {
+			return u, err
+		}
+		head := cid.Undef
+		if len(h.Head) > 0 {
+			if head, err = cid.Cast(h.Head); err != nil {
+				return u, err
+			}
+		}
+		heads[lid] = thread.Head{ID: head, Counter: h.Counter}
+	}
+	return core.HeadsUpdate{
+		ThreadID: threadID,
+		Edge:     reply.Edge,
+		Heads:    heads,
+	}, nil
+}
+
+func presenceFromProto(reply *pb.PresenceReply) (p core.Presence, err error) {
+	threadID, err := thread.Cast(reply.ThreadID)
+	if err != nil {
+		return
+	}
+	pid, err := peer.IDFromBytes(reply.PeerID)
+	if err != nil {
+		return
+	}
+	p = core.Presence{
+		ThreadID: threadID,
+		Peer:     pid,
+		Status:   core.PresenceStatus(reply.Status),
+		Payload:  reply.Payload,
+		Time:     time.Unix(0, reply.Time),
+	}
+	if len(reply.Identity) > 0 {
+		identity := &thread.Libp2pPubKey{}
+		if err = identity.UnmarshalBinary(reply.Identity); err != nil {
+			return
+		}
+		p.Identity = identity
+	}
+	return p, nil
+}
+
+func syncProgressFromProto(reply *pb.SyncProgressReply) (p core.SyncProgress, err error) {
+	threadID, err := thread.Cast(reply.ThreadID)
+	if err != nil {
+		return
+	}
+	p = core.SyncProgress{
+		ThreadID: threadID,
+		Records:  reply.Records,
+		Expected: reply.Expected,
+		Logs:     make([]core.LogSyncProgress, len(reply.Logs)),
+		Bytes:    reply.Bytes,
+		ETA:      time.Duration(reply.Eta),
+	}
+	for i, l := range reply.Logs {
+		lid, err := peer.IDFromBytes(l.LogID)
+		if err != nil {
+			return p, err
+		}
+		p.Logs[i] = core.LogSyncProgress{
+			LogID:   lid,
+			Applied: l.Applied,
+			Height:  l.Height,
+		}
+	}
+	return p, nil
 }
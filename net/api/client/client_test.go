@@ -3,7 +3,12 @@ package client_test
 import (
 	"context"
 	crand "crypto/rand"
+	"encoding/json"
+	"io"
 	"log"
+	gonet "net"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -14,14 +19,19 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/audit"
 	"github.com/textileio/go-threads/cbor"
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
+	tnet "github.com/textileio/go-threads/net"
 	"github.com/textileio/go-threads/net/api"
 	. "github.com/textileio/go-threads/net/api/client"
+	"github.com/textileio/go-threads/net/faults"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestClient_GetHostID(t *testing.T) {
@@ -120,6 +130,47 @@ func TestClient_GetThread(t *testing.T) {
 	})
 }
 
+func TestClient_ListThreads(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info, err := client.CreateThread(context.Background(), thread.NewIDV1(thread.Raw, 32), core.WithNewThreadTags("listed"))
+	if err != nil {
+		t.Fatalf("failed to create thread: %v", err)
+	}
+	createThread(t, client)
+
+	t.Run("test list threads", func(t *testing.T) {
+		page, err := client.ListThreads(context.Background(), core.WithTagFilter("listed"))
+		if err != nil {
+			t.Fatalf("failed to list threads: %v", err)
+		}
+		if len(page.Threads) != 1 || !page.Threads[0].ID.Equals(info.ID) {
+			t.Fatal("got bad threads from list threads")
+		}
+		if len(page.Threads[0].Tags) != 1 || page.Threads[0].Tags[0] != "listed" {
+			t.Fatal("got bad tags from list threads")
+		}
+		if page.Threads[0].LastActivity.IsZero() {
+			t.Fatal("got no last activity from list threads")
+		}
+	})
+
+	t.Run("test get thread logs", func(t *testing.T) {
+		page, err := client.GetThreadLogs(context.Background(), info.ID, core.WithPageLimit(1))
+		if err != nil {
+			t.Fatalf("failed to get thread logs: %v", err)
+		}
+		if len(page.Logs) != 1 || !page.Logs[0].ID.MatchesPublicKey(info.Logs[0].PubKey) {
+			t.Fatal("got bad logs from get thread logs")
+		}
+		if len(page.NextPageToken) != 0 {
+			t.Fatal("got unexpected next page")
+		}
+	})
+}
+
 func TestClient_PullThread(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -134,6 +185,37 @@ func TestClient_PullThread(t *testing.T) {
 	})
 }
 
+func TestClient_PullThreadFrom(t *testing.T) {
+	t.Parallel()
+	hostAddr1, client1, done1 := setup(t)
+	defer done1()
+	_, client2, done2 := setup(t)
+	defer done2()
+
+	info := createThread(t, client1)
+	hostID1, err := client1.GetHostID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client2.CreateThread(context.Background(), info.ID, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test pull thread from", func(t *testing.T) {
+		addr := peerAddr(t, hostAddr1, hostID1)
+		if err := client2.PullThreadFrom(context.Background(), info.ID, addr); err != nil {
+			t.Fatalf("failed to pull thread from peer: %v", err)
+		}
+		info2, err := client2.GetThread(context.Background(), info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(info2.Logs) != 2 {
+			t.Fatalf("expected 2 logs got %d", len(info2.Logs))
+		}
+	})
+}
+
 func TestClient_DeleteThread(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -176,6 +258,32 @@ func TestClient_AddReplicator(t *testing.T) {
 	})
 }
 
+func TestClient_UpdateLogAddrs(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	hostID, err := client.GetHostID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test update log addrs", func(t *testing.T) {
+		addrs := []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/4006/p2p/" + hostID.String())}
+		if err := client.UpdateLogAddrs(context.Background(), info.ID, info.Logs[0].ID, addrs); err != nil {
+			t.Fatalf("failed to update log addrs: %v", err)
+		}
+		updated, err := client.GetThread(context.Background(), info.ID)
+		if err != nil {
+			t.Fatalf("failed to get thread: %v", err)
+		}
+		if len(updated.Logs[0].Addrs) != 1 || !updated.Logs[0].Addrs[0].Equal(addrs[0]) {
+			t.Fatalf("expected log addrs to be replaced, got %v", updated.Logs[0].Addrs)
+		}
+	})
+}
+
 func TestClient_CreateRecord(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -305,6 +413,312 @@ func TestClient_GetRecord(t *testing.T) {
 	})
 }
 
+func TestClient_ActivityFeed(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := client.CreateRecord(context.Background(), info.ID, body, core.WithRecordType("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test activity feed", func(t *testing.T) {
+		feed, err := client.ActivityFeed(context.Background(), info.ID, time.Time{}, 10)
+		if err != nil {
+			t.Fatalf("failed to get activity feed: %v", err)
+		}
+		if len(feed) != 1 {
+			t.Fatalf("expected 1 record in feed, got %d", len(feed))
+		}
+		s := feed[0]
+		if !s.ID.Equals(rec.Value().Cid()) || s.LogID != rec.LogID() || s.Type != "foo" {
+			t.Fatalf("got bad record summary: %+v", s)
+		}
+		if s.Author == nil || s.Time.IsZero() || s.Size == 0 {
+			t.Fatalf("expected record author, time and size, got %+v", s)
+		}
+	})
+}
+
+func TestClient_Annotations(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := client.CreateRecord(context.Background(), info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test annotate record", func(t *testing.T) {
+		a := core.Annotation{Starred: true, Labels: []string{"foo"}}
+		if err := client.AnnotateRecord(context.Background(), info.ID, rec.Value().Cid(), a); err != nil {
+			t.Fatalf("failed to annotate record: %v", err)
+		}
+		got, err := client.GetAnnotation(context.Background(), info.ID, rec.Value().Cid())
+		if err != nil {
+			t.Fatalf("failed to get annotation: %v", err)
+		}
+		if !got.RecordID.Equals(rec.Value().Cid()) || got.Read || !got.Starred ||
+			len(got.Labels) != 1 || got.Labels[0] != "foo" || got.UpdatedAt.IsZero() {
+			t.Fatalf("got bad annotation: %+v", got)
+		}
+	})
+
+	t.Run("test query annotations", func(t *testing.T) {
+		list, err := client.QueryAnnotations(context.Background(), info.ID, core.WithUnreadFilter(), core.WithLabelFilter("foo"))
+		if err != nil {
+			t.Fatalf("failed to query annotations: %v", err)
+		}
+		if len(list) != 1 || !list[0].RecordID.Equals(rec.Value().Cid()) {
+			t.Fatalf("expected annotation of record %s, got %+v", rec.Value().Cid(), list)
+		}
+		if list, err = client.QueryAnnotations(context.Background(), info.ID, core.WithLabelFilter("bar")); err != nil {
+			t.Fatalf("failed to query annotations: %v", err)
+		}
+		if len(list) != 0 {
+			t.Fatalf("expected no annotations, got %d", len(list))
+		}
+	})
+
+	t.Run("test annotate unknown record", func(t *testing.T) {
+		other, err := cbornode.WrapObject(map[string]interface{}{
+			"bar": "baz",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = client.AnnotateRecord(context.Background(), info.ID, other.Cid(), core.Annotation{Read: true})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestClient_RecordCache(t *testing.T) {
+	t.Parallel()
+	_, addr, shutdown, err := api.CreateTestService("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := util.TCPAddrFromMultiAddr(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClientWithRecordCache(target, RecordCacheConfig{}, grpc.WithInsecure()); err == nil {
+		t.Fatal("expected invalid record cache config error")
+	}
+	ttl := time.Second
+	client, err := NewClientWithRecordCache(target, RecordCacheConfig{Size: 10, TTL: ttl},
+		grpc.WithInsecure(), grpc.WithPerRPCCredentials(thread.Credentials{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	info := createThread(t, client)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := client.CreateRecord(context.Background(), info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rid := rec.Value().Cid()
+	shutdown()
+
+	t.Run("test get cached record", func(t *testing.T) {
+		cached, err := client.GetRecord(context.Background(), info.ID, rid)
+		if err != nil {
+			t.Fatalf("failed to get cached record: %v", err)
+		}
+		if !cached.Cid().Equals(rid) {
+			t.Fatal("got bad cached record")
+		}
+	})
+
+	t.Run("test cached record expires", func(t *testing.T) {
+		time.Sleep(ttl)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := client.GetRecord(ctx, info.ID, rid); err == nil {
+			t.Fatal("expected expired record to be requested from the stopped service")
+		}
+	})
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	t.Parallel()
+	_, addr, shutdown, err := api.CreateTestService("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown()
+	target, err := util.TCPAddrFromMultiAddr(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client1, err := NewClient(target, grpc.WithInsecure(), grpc.WithPerRPCCredentials(thread.Credentials{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client1.Close()
+	proxy := newTCPProxy(t, target)
+	defer proxy.cut()
+
+	if _, err := NewClientWithReconnect(proxy.addr, ReconnectConfig{MinDelay: time.Second, MaxDelay: time.Millisecond}); err == nil {
+		t.Fatal("expected invalid reconnect config error")
+	}
+	states := make(chan ConnState, 100)
+	conf := ReconnectConfig{
+		MinDelay:      time.Millisecond * 50,
+		MaxDelay:      time.Millisecond * 200,
+		OnStateChange: func(s ConnState) { states <- s },
+	}
+	client2, err := NewClientWithReconnect(proxy.addr, conf, grpc.WithInsecure(), grpc.WithPerRPCCredentials(thread.Credentials{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client2.Close()
+	waitState := func(expected ConnState) {
+		t.Helper()
+		timeout := time.After(time.Second * 10)
+		for {
+			select {
+			case s := <-states:
+				if s == expected {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("expected connection to be %s", expected)
+			}
+		}
+	}
+
+	info := createThread(t, client1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := client2.Subscribe(ctx, core.WithSubFilter(info.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitState(ConnOnline)
+	addRecord := func(i int) cid.Cid {
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := client1.CreateRecord(context.Background(), info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rec.Value().Cid()
+	}
+	expectRecord := func(rid cid.Cid) {
+		t.Helper()
+		select {
+		case rec := <-sub:
+			if !rec.Value().Cid().Equals(rid) {
+				t.Fatalf("expected record %s, got %s", rid, rec.Value().Cid())
+			}
+		case <-time.After(time.Second * 10):
+			t.Fatalf("expected record %s to be delivered", rid)
+		}
+	}
+
+	expectRecord(addRecord(1))
+	proxy.cut()
+	waitState(ConnOffline)
+	// records created while disconnected are delivered once the subscription resumes
+	missed := addRecord(2)
+	proxy.restore()
+	waitState(ConnOnline)
+	expectRecord(missed)
+	expectRecord(addRecord(3))
+}
+
+// tcpProxy forwards connections to a target, which can be cut to simulate connection loss.
+type tcpProxy struct {
+	t        *testing.T
+	addr     string
+	target   string
+	lock     sync.Mutex
+	listener gonet.Listener
+	conns    []gonet.Conn
+}
+
+func newTCPProxy(t *testing.T, target string) *tcpProxy {
+	l, err := gonet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &tcpProxy{t: t, addr: l.Addr().String(), target: target}
+	p.serve(l)
+	return p
+}
+
+func (p *tcpProxy) serve(l gonet.Listener) {
+	p.listener = l
+	go func() {
+		for {
+			in, err := l.Accept()
+			if err != nil {
+				return
+			}
+			out, err := gonet.Dial("tcp", p.target)
+			if err != nil {
+				_ = in.Close()
+				continue
+			}
+			p.lock.Lock()
+			p.conns = append(p.conns, in, out)
+			p.lock.Unlock()
+			go func() { _, _ = io.Copy(out, in); _ = out.Close() }()
+			go func() { _, _ = io.Copy(in, out); _ = in.Close() }()
+		}
+	}()
+}
+
+// cut closes the proxied connections and stops accepting new ones.
+func (p *tcpProxy) cut() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_ = p.listener.Close()
+	for _, c := range p.conns {
+		_ = c.Close()
+	}
+	p.conns = nil
+}
+
+// restore accepts connections again on the same address.
+func (p *tcpProxy) restore() {
+	l, err := gonet.Listen("tcp", p.addr)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.serve(l)
+}
+
 func TestClient_Subscribe(t *testing.T) {
 	t.Parallel()
 	_, client1, done1 := setup(t)
@@ -363,6 +777,331 @@ func TestClient_Subscribe(t *testing.T) {
 		}
 		lock.Unlock()
 	})
+
+	t.Run("test subscribe heads", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sub, err := client2.SubscribeHeads(ctx, core.WithSubFilter(info.ID))
+		if err != nil {
+			t.Fatalf("failed to subscribe to heads: %v", err)
+		}
+
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar3"}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := client1.CreateRecord(context.Background(), info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case u := <-sub:
+			if !u.ThreadID.Equals(info.ID) || u.Edge == 0 {
+				t.Fatalf("unexpected heads update: %v", u)
+			}
+			h, ok := u.Heads[rec.LogID()]
+			if !ok || !h.ID.Equals(rec.Value().Cid()) {
+				t.Fatal("expected head to be the new record")
+			}
+		case <-time.After(time.Second * 10):
+			t.Fatal("heads update wasn't received")
+		}
+	})
+}
+
+func TestClient_Presence(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	identity := createIdentity(t)
+	tok, err := client.GetToken(context.Background(), identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test presence", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sub, err := client.SubscribePresence(ctx, info.ID)
+		if err != nil {
+			t.Fatalf("failed to subscribe to presence: %v", err)
+		}
+		if err := client.PublishPresence(
+			ctx,
+			info.ID,
+			core.PresenceAway,
+			[]byte("cursor"),
+			core.WithThreadToken(tok),
+			core.WithSigner(identity),
+		); err != nil {
+			t.Fatalf("failed to publish presence: %v", err)
+		}
+		select {
+		case p := <-sub:
+			if p.Status != core.PresenceAway || string(p.Payload) != "cursor" {
+				t.Fatalf("unexpected presence: %v", p)
+			}
+			if !p.Identity.Equals(identity.GetPublic()) {
+				t.Fatal("unexpected presence identity")
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("presence wasn't received")
+		}
+	})
+}
+
+func TestClient_SubscribeSyncProgress(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := client.SubscribeSyncProgress(ctx, info.ID)
+	if err != nil {
+		t.Fatalf("failed to subscribe to sync progress: %v", err)
+	}
+	select {
+	case p := <-sub:
+		if !p.ThreadID.Equals(info.ID) || !p.Done() || len(p.Logs) != 1 || p.Logs[0].LogID != info.Logs[0].ID {
+			t.Fatalf("unexpected sync progress: %v", p)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("sync progress wasn't received")
+	}
+}
+
+func TestClient_APIKeys(t *testing.T) {
+	t.Parallel()
+	addr, keys, shutdown, err := api.CreateTestServiceWithAPIKeys(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown()
+	target, err := util.TCPAddrFromMultiAddr(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminKey, adminSecret, err := keys.Create(api.Scope{Admin: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := NewAdminClient(target, grpc.WithInsecure(), grpc.WithPerRPCCredentials(APIKeyCredentials{
+		Key:    adminKey.Key,
+		Secret: adminSecret,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer admin.Close()
+	newClient := func(key, secret string) *Client {
+		c, err := NewClient(target, grpc.WithInsecure(), grpc.WithPerRPCCredentials(APIKeyCredentials{
+			Key:    key,
+			Secret: secret,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	ctx := context.Background()
+
+	t.Run("test unauthenticated", func(t *testing.T) {
+		c := newClient(adminKey.Key, "bad secret")
+		defer c.Close()
+		if _, err := c.GetHostID(ctx); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("expected unauthenticated error, got %v", err)
+		}
+	})
+
+	t.Run("test scopes", func(t *testing.T) {
+		full := newClient(adminKey.Key, adminSecret)
+		defer full.Close()
+		info := createThread(t, full)
+		other := createThread(t, full)
+
+		key, secret, err := admin.CreateAPIKey(ctx, api.Scope{ReadOnly: true, Threads: []thread.ID{info.ID}})
+		if err != nil {
+			t.Fatalf("failed to create api key: %v", err)
+		}
+		c := newClient(key.Key, secret)
+		defer c.Close()
+		if _, err := c.GetThread(ctx, info.ID); err != nil {
+			t.Fatalf("failed to get scoped thread: %v", err)
+		}
+		if _, err := c.GetThread(ctx, other.ID); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied for other thread, got %v", err)
+		}
+		if err := c.DeleteThread(ctx, info.ID); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied for read-only key, got %v", err)
+		}
+		if _, err := c.ListThreads(ctx); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied for listing, got %v", err)
+		}
+
+		nonAdmin, err := NewAdminClient(target, grpc.WithInsecure(), grpc.WithPerRPCCredentials(APIKeyCredentials{
+			Key:    key.Key,
+			Secret: secret,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer nonAdmin.Close()
+		if _, err := nonAdmin.ListAPIKeys(ctx); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied for admin api, got %v", err)
+		}
+	})
+
+	t.Run("test revoke", func(t *testing.T) {
+		key, secret, err := admin.CreateAPIKey(ctx, api.Scope{})
+		if err != nil {
+			t.Fatalf("failed to create api key: %v", err)
+		}
+		list, err := admin.ListAPIKeys(ctx)
+		if err != nil {
+			t.Fatalf("failed to list api keys: %v", err)
+		}
+		if list[len(list)-1].Key != key.Key {
+			t.Fatal("created api key wasn't listed")
+		}
+		if err := admin.RevokeAPIKey(ctx, key.Key); err != nil {
+			t.Fatalf("failed to revoke api key: %v", err)
+		}
+		c := newClient(key.Key, secret)
+		defer c.Close()
+		if _, err := c.GetHostID(ctx); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("expected unauthenticated error, got %v", err)
+		}
+	})
+
+	t.Run("test thread snapshot", func(t *testing.T) {
+		c := newClient(adminKey.Key, adminSecret)
+		defer c.Close()
+		info := createThread(t, c)
+		data, err := admin.GetThreadSnapshot(ctx, info.ID)
+		if err != nil {
+			t.Fatalf("failed to get thread snapshot: %v", err)
+		}
+		var snapshot tnet.ThreadSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			t.Fatalf("failed to decode thread snapshot: %v", err)
+		}
+		if snapshot.Thread != info.ID.String() || len(snapshot.Logs) != 1 {
+			t.Fatalf("unexpected thread snapshot %s", data)
+		}
+		if _, err := admin.GetThreadSnapshot(ctx, thread.NewIDV1(thread.Raw, 32)); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected not found for unknown thread, got %v", err)
+		}
+	})
+
+	t.Run("test export thread dag", func(t *testing.T) {
+		c := newClient(adminKey.Key, adminSecret)
+		defer c.Close()
+		info := createThread(t, c)
+		data, err := admin.ExportThreadDAG(ctx, info.ID, tnet.DAGFormatDOT, tnet.DAGRange{})
+		if err != nil {
+			t.Fatalf("failed to export thread dag: %v", err)
+		}
+		if !strings.HasPrefix(string(data), "digraph") {
+			t.Fatalf("unexpected thread dag %s", data)
+		}
+		if _, err := admin.ExportThreadDAG(ctx, info.ID, "svg", tnet.DAGRange{}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected invalid argument for unknown format, got %v", err)
+		}
+	})
+
+	t.Run("test access list", func(t *testing.T) {
+		_, local, _ := gonet.ParseCIDR("127.0.0.0/8")
+		list := tnet.AccessList{AllowNets: []*gonet.IPNet{local}}
+		if err := admin.SetAccessList(ctx, list); err != nil {
+			t.Fatalf("failed to set access list: %v", err)
+		}
+		defer func() {
+			if err := admin.SetAccessList(ctx, tnet.AccessList{}); err != nil {
+				t.Fatalf("failed to reset access list: %v", err)
+			}
+		}()
+		got, err := admin.GetAccessList(ctx)
+		if err != nil {
+			t.Fatalf("failed to get access list: %v", err)
+		}
+		if len(got.AllowNets) != 1 || got.AllowNets[0].String() != local.String() || len(got.AllowPeers) != 0 {
+			t.Fatalf("unexpected access list %+v", got)
+		}
+	})
+
+	t.Run("test faults", func(t *testing.T) {
+		if err := admin.SetFaults(ctx, faults.Config{DropPushRecord: 101}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected invalid argument for bad config, got %v", err)
+		}
+		config := faults.Config{DropPushRecord: 10, DelayExchangeEdges: time.Second}
+		err := admin.SetFaults(ctx, config)
+		if !faults.Enabled {
+			if status.Code(err) != codes.Unimplemented {
+				t.Fatalf("expected unimplemented without failure injection, got %v", err)
+			}
+			return
+		} else if err != nil {
+			t.Fatalf("failed to set faults: %v", err)
+		}
+		defer func() {
+			if err := admin.SetFaults(ctx, faults.Config{}); err != nil {
+				t.Fatalf("failed to reset faults: %v", err)
+			}
+		}()
+		got, enabled, err := admin.GetFaults(ctx)
+		if err != nil {
+			t.Fatalf("failed to get faults: %v", err)
+		}
+		if !enabled || got != config {
+			t.Fatalf("expected faults %+v to be enabled, got %+v (enabled: %v)", config, got, enabled)
+		}
+	})
+
+	t.Run("test audit log", func(t *testing.T) {
+		start := time.Now()
+		full := newClient(adminKey.Key, adminSecret)
+		defer full.Close()
+		info := createThread(t, full)
+		key, secret, err := admin.CreateAPIKey(ctx, api.Scope{ReadOnly: true})
+		if err != nil {
+			t.Fatalf("failed to create api key: %v", err)
+		}
+		c := newClient(key.Key, secret)
+		defer c.Close()
+		if err := c.DeleteThread(ctx, info.ID); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied for read-only key, got %v", err)
+		}
+		if _, err := c.GetThread(ctx, info.ID); err != nil {
+			t.Fatalf("failed to get thread: %v", err)
+		}
+
+		entries, err := admin.ExportAuditLog(ctx, start, time.Time{})
+		if err != nil {
+			t.Fatalf("failed to export audit log: %v", err)
+		}
+		ops := make([]string, len(entries))
+		for i, e := range entries {
+			ops[i] = e.Operation
+		}
+		expected := []string{
+			"/threads.net.pb.API/CreateThread",
+			"/threads.net.pb.Admin/CreateAPIKey",
+			"/threads.net.pb.API/DeleteThread",
+		}
+		if !reflect.DeepEqual(ops, expected) {
+			t.Fatalf("expected audited operations %v, got %v", expected, ops)
+		}
+		if entries[0].ThreadID != info.ID.String() || entries[0].APIKey != adminKey.Key || entries[0].Outcome != audit.OutcomeOK {
+			t.Fatalf("unexpected create thread entry: %+v", entries[0])
+		}
+		if entries[2].APIKey != key.Key || entries[2].Outcome != audit.OutcomeError {
+			t.Fatalf("unexpected delete thread entry: %+v", entries[2])
+		}
+	})
 }
 
 func TestClient_Close(t *testing.T) {
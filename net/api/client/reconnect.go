@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnState is the state of the client connection, suitable for showing online status.
+type ConnState int
+
+const (
+	// ConnOffline indicates the client isn't connected, requests fail until it reconnects.
+	ConnOffline ConnState = iota
+	// ConnConnecting indicates the client is connecting.
+	ConnConnecting
+	// ConnOnline indicates the client is connected.
+	ConnOnline
+)
+
+// String returns a human-readable connection state.
+func (s ConnState) String() string {
+	switch s {
+	case ConnOffline:
+		return "offline"
+	case ConnConnecting:
+		return "connecting"
+	case ConnOnline:
+		return "online"
+	default:
+		return "unknown"
+	}
+}
+
+// ReconnectConfig configures the managed reconnection of a client.
+type ReconnectConfig struct {
+	// MinDelay is the delay before the first reconnection attempt, which doubles with each
+	// failed attempt. A default of half a second is used if zero.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between reconnection attempts. A default of 30 seconds is used if zero.
+	MaxDelay time.Duration
+	// OnStateChange is called with the connection state each time it changes, starting with
+	// the initial state. Calls are made from a single goroutine, so they must not block.
+	OnStateChange func(ConnState)
+}
+
+// Validate returns an error if the config is invalid.
+func (c ReconnectConfig) Validate() error {
+	if c.MinDelay < 0 || c.MaxDelay < 0 {
+		return errors.New("reconnect delays must not be negative")
+	}
+	if c.MaxDelay != 0 && c.MaxDelay < c.MinDelay {
+		return errors.New("max reconnect delay must not be shorter than the min delay")
+	}
+	return nil
+}
+
+func (c ReconnectConfig) delays() (min, max time.Duration) {
+	min, max = c.MinDelay, c.MaxDelay
+	if min == 0 {
+		min = resubscribeMinDelay
+	}
+	if max == 0 {
+		max = resubscribeMaxDelay
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+// NewClientWithReconnect starts the client with managed reconnection. The connection is
+// re-established with exponential backoff when it drops, and subscriptions are reopened.
+// Record subscriptions resume after the last delivered records, so records created while
+// disconnected are delivered once the client is back online.
+func NewClientWithReconnect(target string, conf ReconnectConfig, opts ...grpc.DialOption) (*Client, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid reconnect config: %w", err)
+	}
+	min, max := conf.delays()
+	bc := backoff.DefaultConfig
+	bc.BaseDelay, bc.Multiplier, bc.MaxDelay = min, 2, max
+	opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{Backoff: bc, MinConnectTimeout: minConnectTimeout}))
+	c, err := NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.reconnect = &conf
+	if conf.OnStateChange != nil {
+		var ctx context.Context
+		ctx, c.cancel = context.WithCancel(context.Background())
+		go c.watchState(ctx, conf.OnStateChange)
+	}
+	return c, nil
+}
+
+// watchState reports changes of the connection state until the context is done.
+func (c *Client) watchState(ctx context.Context, onChange func(ConnState)) {
+	state := c.conn.GetState()
+	last := connStateFromGRPC(state)
+	onChange(last)
+	for c.conn.WaitForStateChange(ctx, state) {
+		if state = c.conn.GetState(); state == connectivity.Shutdown {
+			return
+		}
+		if cs := connStateFromGRPC(state); cs != last {
+			last = cs
+			onChange(cs)
+		}
+	}
+}
+
+func connStateFromGRPC(state connectivity.State) ConnState {
+	switch state {
+	case connectivity.Ready:
+		return ConnOnline
+	case connectivity.Connecting:
+		return ConnConnecting
+	default:
+		return ConnOffline
+	}
+}
+
+// resubscribes returns whether subscriptions dropped along with the connection are reopened.
+func (c *Client) resubscribes() bool {
+	return c.reconnect != nil || c.cache != nil
+}
+
+// retry calls open with a backoff until it succeeds, returning false once the context is done.
+func (c *Client) retry(ctx context.Context, open func() error) bool {
+	var conf ReconnectConfig
+	if c.reconnect != nil {
+		conf = *c.reconnect
+	}
+	delay, max := conf.delays()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		err := open()
+		if err == nil {
+			return true
+		}
+		log.Printf("resuming subscription failed: %v", err)
+		if delay *= 2; delay > max {
+			delay = max
+		}
+	}
+}
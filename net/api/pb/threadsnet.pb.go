@@ -26,6 +26,65 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type SyncHealth int32
+
+const (
+	SyncHealth_ANY      SyncHealth = 0
+	SyncHealth_SYNCED   SyncHealth = 1
+	SyncHealth_STALE    SyncHealth = 2
+	SyncHealth_UNSYNCED SyncHealth = 3
+)
+
+var SyncHealth_name = map[int32]string{
+	0: "ANY",
+	1: "SYNCED",
+	2: "STALE",
+	3: "UNSYNCED",
+}
+
+var SyncHealth_value = map[string]int32{
+	"ANY":      0,
+	"SYNCED":   1,
+	"STALE":    2,
+	"UNSYNCED": 3,
+}
+
+func (x SyncHealth) String() string {
+	return proto.EnumName(SyncHealth_name, int32(x))
+}
+
+func (SyncHealth) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{0}
+}
+
+type PresenceStatus int32
+
+const (
+	PresenceStatus_OFFLINE PresenceStatus = 0
+	PresenceStatus_ONLINE  PresenceStatus = 1
+	PresenceStatus_AWAY    PresenceStatus = 2
+)
+
+var PresenceStatus_name = map[int32]string{
+	0: "OFFLINE",
+	1: "ONLINE",
+	2: "AWAY",
+}
+
+var PresenceStatus_value = map[string]int32{
+	"OFFLINE": 0,
+	"ONLINE":  1,
+	"AWAY":    2,
+}
+
+func (x PresenceStatus) String() string {
+	return proto.EnumName(PresenceStatus_name, int32(x))
+}
+
+func (PresenceStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{1}
+}
+
 type GetHostIDRequest struct {
 }
 
@@ -277,8 +336,9 @@ func (*GetTokenReply) XXX_OneofWrappers() []interface{} {
 }
 
 type CreateThreadRequest struct {
-	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Keys     *Keys  `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	ThreadID []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Keys     *Keys    `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Tags     []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (m *CreateThreadRequest) Reset()         { *m = CreateThreadRequest{} }
@@ -328,6 +388,13 @@ func (m *CreateThreadRequest) GetKeys() *Keys {
 	return nil
 }
 
+func (m *CreateThreadRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type Keys struct {
 	ThreadKey []byte `protobuf:"bytes,1,opt,name=threadKey,proto3" json:"threadKey,omitempty"`
 	LogKey    []byte `protobuf:"bytes,2,opt,name=logKey,proto3" json:"logKey,omitempty"`
@@ -533,8 +600,9 @@ func (m *LogInfo) GetCounter() []byte {
 }
 
 type AddThreadRequest struct {
-	Addr []byte `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
-	Keys *Keys  `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Addr []byte   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Keys *Keys    `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (m *AddThreadRequest) Reset()         { *m = AddThreadRequest{} }
@@ -584,6 +652,13 @@ func (m *AddThreadRequest) GetKeys() *Keys {
 	return nil
 }
 
+func (m *AddThreadRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type GetThreadRequest struct {
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 }
@@ -628,22 +703,27 @@ func (m *GetThreadRequest) GetThreadID() []byte {
 	return nil
 }
 
-type PullThreadRequest struct {
-	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+type ListThreadsRequest struct {
+	PageToken    string     `protobuf:"bytes,1,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	Limit        int32      `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Tag          string     `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	ActiveSince  int64      `protobuf:"varint,4,opt,name=activeSince,proto3" json:"activeSince,omitempty"`
+	ActiveBefore int64      `protobuf:"varint,5,opt,name=activeBefore,proto3" json:"activeBefore,omitempty"`
+	Health       SyncHealth `protobuf:"varint,6,opt,name=health,proto3,enum=threads.net.pb.SyncHealth" json:"health,omitempty"`
 }
 
-func (m *PullThreadRequest) Reset()         { *m = PullThreadRequest{} }
-func (m *PullThreadRequest) String() string { return proto.CompactTextString(m) }
-func (*PullThreadRequest) ProtoMessage()    {}
-func (*PullThreadRequest) Descriptor() ([]byte, []int) {
+func (m *ListThreadsRequest) Reset()         { *m = ListThreadsRequest{} }
+func (m *ListThreadsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListThreadsRequest) ProtoMessage()    {}
+func (*ListThreadsRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{10}
 }
-func (m *PullThreadRequest) XXX_Unmarshal(b []byte) error {
+func (m *ListThreadsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PullThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListThreadsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PullThreadRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListThreadsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -653,40 +733,83 @@ func (m *PullThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *PullThreadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PullThreadRequest.Merge(m, src)
+func (m *ListThreadsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListThreadsRequest.Merge(m, src)
 }
-func (m *PullThreadRequest) XXX_Size() int {
+func (m *ListThreadsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PullThreadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PullThreadRequest.DiscardUnknown(m)
+func (m *ListThreadsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListThreadsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PullThreadRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListThreadsRequest proto.InternalMessageInfo
 
-func (m *PullThreadRequest) GetThreadID() []byte {
+func (m *ListThreadsRequest) GetPageToken() string {
 	if m != nil {
-		return m.ThreadID
+		return m.PageToken
 	}
-	return nil
+	return ""
 }
 
-type PullThreadReply struct {
+func (m *ListThreadsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
 }
 
-func (m *PullThreadReply) Reset()         { *m = PullThreadReply{} }
-func (m *PullThreadReply) String() string { return proto.CompactTextString(m) }
-func (*PullThreadReply) ProtoMessage()    {}
-func (*PullThreadReply) Descriptor() ([]byte, []int) {
+func (m *ListThreadsRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *ListThreadsRequest) GetActiveSince() int64 {
+	if m != nil {
+		return m.ActiveSince
+	}
+	return 0
+}
+
+func (m *ListThreadsRequest) GetActiveBefore() int64 {
+	if m != nil {
+		return m.ActiveBefore
+	}
+	return 0
+}
+
+func (m *ListThreadsRequest) GetHealth() SyncHealth {
+	if m != nil {
+		return m.Health
+	}
+	return SyncHealth_ANY
+}
+
+type ThreadSummary struct {
+	ThreadID     []byte     `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Tags         []string   `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	LastActivity int64      `protobuf:"varint,3,opt,name=lastActivity,proto3" json:"lastActivity,omitempty"`
+	LastSync     int64      `protobuf:"varint,4,opt,name=lastSync,proto3" json:"lastSync,omitempty"`
+	Health       SyncHealth `protobuf:"varint,5,opt,name=health,proto3,enum=threads.net.pb.SyncHealth" json:"health,omitempty"`
+	Usage        int64      `protobuf:"varint,6,opt,name=usage,proto3" json:"usage,omitempty"`
+	Quota        int64      `protobuf:"varint,7,opt,name=quota,proto3" json:"quota,omitempty"`
+	OverQuota    bool       `protobuf:"varint,8,opt,name=overQuota,proto3" json:"overQuota,omitempty"`
+}
+
+func (m *ThreadSummary) Reset()         { *m = ThreadSummary{} }
+func (m *ThreadSummary) String() string { return proto.CompactTextString(m) }
+func (*ThreadSummary) ProtoMessage()    {}
+func (*ThreadSummary) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{11}
 }
-func (m *PullThreadReply) XXX_Unmarshal(b []byte) error {
+func (m *ThreadSummary) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PullThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ThreadSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PullThreadReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ThreadSummary.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -696,34 +819,91 @@ func (m *PullThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *PullThreadReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PullThreadReply.Merge(m, src)
+func (m *ThreadSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThreadSummary.Merge(m, src)
 }
-func (m *PullThreadReply) XXX_Size() int {
+func (m *ThreadSummary) XXX_Size() int {
 	return m.Size()
 }
-func (m *PullThreadReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_PullThreadReply.DiscardUnknown(m)
+func (m *ThreadSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThreadSummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PullThreadReply proto.InternalMessageInfo
+var xxx_messageInfo_ThreadSummary proto.InternalMessageInfo
 
-type DeleteThreadRequest struct {
-	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+func (m *ThreadSummary) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
 }
 
-func (m *DeleteThreadRequest) Reset()         { *m = DeleteThreadRequest{} }
-func (m *DeleteThreadRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteThreadRequest) ProtoMessage()    {}
-func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
+func (m *ThreadSummary) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ThreadSummary) GetLastActivity() int64 {
+	if m != nil {
+		return m.LastActivity
+	}
+	return 0
+}
+
+func (m *ThreadSummary) GetLastSync() int64 {
+	if m != nil {
+		return m.LastSync
+	}
+	return 0
+}
+
+func (m *ThreadSummary) GetHealth() SyncHealth {
+	if m != nil {
+		return m.Health
+	}
+	return SyncHealth_ANY
+}
+
+func (m *ThreadSummary) GetUsage() int64 {
+	if m != nil {
+		return m.Usage
+	}
+	return 0
+}
+
+func (m *ThreadSummary) GetQuota() int64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *ThreadSummary) GetOverQuota() bool {
+	if m != nil {
+		return m.OverQuota
+	}
+	return false
+}
+
+type ListThreadsReply struct {
+	Threads       []*ThreadSummary `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+	NextPageToken string           `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (m *ListThreadsReply) Reset()         { *m = ListThreadsReply{} }
+func (m *ListThreadsReply) String() string { return proto.CompactTextString(m) }
+func (*ListThreadsReply) ProtoMessage()    {}
+func (*ListThreadsReply) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{12}
 }
-func (m *DeleteThreadRequest) XXX_Unmarshal(b []byte) error {
+func (m *ListThreadsReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListThreadsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteThreadRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListThreadsReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -733,40 +913,50 @@ func (m *DeleteThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *DeleteThreadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteThreadRequest.Merge(m, src)
+func (m *ListThreadsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListThreadsReply.Merge(m, src)
 }
-func (m *DeleteThreadRequest) XXX_Size() int {
+func (m *ListThreadsReply) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteThreadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteThreadRequest.DiscardUnknown(m)
+func (m *ListThreadsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListThreadsReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteThreadRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListThreadsReply proto.InternalMessageInfo
 
-func (m *DeleteThreadRequest) GetThreadID() []byte {
+func (m *ListThreadsReply) GetThreads() []*ThreadSummary {
 	if m != nil {
-		return m.ThreadID
+		return m.Threads
 	}
 	return nil
 }
 
-type DeleteThreadReply struct {
+func (m *ListThreadsReply) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
 }
 
-func (m *DeleteThreadReply) Reset()         { *m = DeleteThreadReply{} }
-func (m *DeleteThreadReply) String() string { return proto.CompactTextString(m) }
-func (*DeleteThreadReply) ProtoMessage()    {}
-func (*DeleteThreadReply) Descriptor() ([]byte, []int) {
+type GetThreadLogsRequest struct {
+	ThreadID  []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	Limit     int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *GetThreadLogsRequest) Reset()         { *m = GetThreadLogsRequest{} }
+func (m *GetThreadLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetThreadLogsRequest) ProtoMessage()    {}
+func (*GetThreadLogsRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{13}
 }
-func (m *DeleteThreadReply) XXX_Unmarshal(b []byte) error {
+func (m *GetThreadLogsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetThreadLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteThreadReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetThreadLogsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -776,35 +966,56 @@ func (m *DeleteThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *DeleteThreadReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteThreadReply.Merge(m, src)
+func (m *GetThreadLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThreadLogsRequest.Merge(m, src)
 }
-func (m *DeleteThreadReply) XXX_Size() int {
+func (m *GetThreadLogsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteThreadReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteThreadReply.DiscardUnknown(m)
+func (m *GetThreadLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThreadLogsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteThreadReply proto.InternalMessageInfo
+var xxx_messageInfo_GetThreadLogsRequest proto.InternalMessageInfo
 
-type AddReplicatorRequest struct {
-	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Addr     []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+func (m *GetThreadLogsRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
 }
 
-func (m *AddReplicatorRequest) Reset()         { *m = AddReplicatorRequest{} }
-func (m *AddReplicatorRequest) String() string { return proto.CompactTextString(m) }
-func (*AddReplicatorRequest) ProtoMessage()    {}
-func (*AddReplicatorRequest) Descriptor() ([]byte, []int) {
+func (m *GetThreadLogsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *GetThreadLogsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetThreadLogsReply struct {
+	Logs          []*LogInfo `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	NextPageToken string     `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (m *GetThreadLogsReply) Reset()         { *m = GetThreadLogsReply{} }
+func (m *GetThreadLogsReply) String() string { return proto.CompactTextString(m) }
+func (*GetThreadLogsReply) ProtoMessage()    {}
+func (*GetThreadLogsReply) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{14}
 }
-func (m *AddReplicatorRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetThreadLogsReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AddReplicatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetThreadLogsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AddReplicatorRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetThreadLogsReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -814,48 +1025,50 @@ func (m *AddReplicatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *AddReplicatorRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddReplicatorRequest.Merge(m, src)
+func (m *GetThreadLogsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThreadLogsReply.Merge(m, src)
 }
-func (m *AddReplicatorRequest) XXX_Size() int {
+func (m *GetThreadLogsReply) XXX_Size() int {
 	return m.Size()
 }
-func (m *AddReplicatorRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddReplicatorRequest.DiscardUnknown(m)
+func (m *GetThreadLogsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThreadLogsReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddReplicatorRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetThreadLogsReply proto.InternalMessageInfo
 
-func (m *AddReplicatorRequest) GetThreadID() []byte {
+func (m *GetThreadLogsReply) GetLogs() []*LogInfo {
 	if m != nil {
-		return m.ThreadID
+		return m.Logs
 	}
 	return nil
 }
 
-func (m *AddReplicatorRequest) GetAddr() []byte {
+func (m *GetThreadLogsReply) GetNextPageToken() string {
 	if m != nil {
-		return m.Addr
+		return m.NextPageToken
 	}
-	return nil
+	return ""
 }
 
-type AddReplicatorReply struct {
-	PeerID []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+type ActivityFeedRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Since    int64  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Limit    int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (m *AddReplicatorReply) Reset()         { *m = AddReplicatorReply{} }
-func (m *AddReplicatorReply) String() string { return proto.CompactTextString(m) }
-func (*AddReplicatorReply) ProtoMessage()    {}
-func (*AddReplicatorReply) Descriptor() ([]byte, []int) {
+func (m *ActivityFeedRequest) Reset()         { *m = ActivityFeedRequest{} }
+func (m *ActivityFeedRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivityFeedRequest) ProtoMessage()    {}
+func (*ActivityFeedRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{15}
 }
-func (m *AddReplicatorReply) XXX_Unmarshal(b []byte) error {
+func (m *ActivityFeedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AddReplicatorReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ActivityFeedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AddReplicatorReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ActivityFeedRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -865,42 +1078,60 @@ func (m *AddReplicatorReply) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *AddReplicatorReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddReplicatorReply.Merge(m, src)
+func (m *ActivityFeedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivityFeedRequest.Merge(m, src)
 }
-func (m *AddReplicatorReply) XXX_Size() int {
+func (m *ActivityFeedRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *AddReplicatorReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddReplicatorReply.DiscardUnknown(m)
+func (m *ActivityFeedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivityFeedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddReplicatorReply proto.InternalMessageInfo
+var xxx_messageInfo_ActivityFeedRequest proto.InternalMessageInfo
 
-func (m *AddReplicatorReply) GetPeerID() []byte {
+func (m *ActivityFeedRequest) GetThreadID() []byte {
 	if m != nil {
-		return m.PeerID
+		return m.ThreadID
 	}
 	return nil
 }
 
-type CreateRecordRequest struct {
-	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Body     []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+func (m *ActivityFeedRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
 }
 
-func (m *CreateRecordRequest) Reset()         { *m = CreateRecordRequest{} }
-func (m *CreateRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRecordRequest) ProtoMessage()    {}
-func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+func (m *ActivityFeedRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type RecordSummary struct {
+	RecordID []byte `protobuf:"bytes,1,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	LogID    []byte `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Author   []byte `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Time     int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Size_    int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Type     string `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (m *RecordSummary) Reset()         { *m = RecordSummary{} }
+func (m *RecordSummary) String() string { return proto.CompactTextString(m) }
+func (*RecordSummary) ProtoMessage()    {}
+func (*RecordSummary) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{16}
 }
-func (m *CreateRecordRequest) XXX_Unmarshal(b []byte) error {
+func (m *RecordSummary) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RecordSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateRecordRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RecordSummary.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -910,50 +1141,76 @@ func (m *CreateRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *CreateRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRecordRequest.Merge(m, src)
+func (m *RecordSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordSummary.Merge(m, src)
 }
-func (m *CreateRecordRequest) XXX_Size() int {
+func (m *RecordSummary) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRecordRequest.DiscardUnknown(m)
+func (m *RecordSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordSummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_RecordSummary proto.InternalMessageInfo
 
-func (m *CreateRecordRequest) GetThreadID() []byte {
+func (m *RecordSummary) GetRecordID() []byte {
 	if m != nil {
-		return m.ThreadID
+		return m.RecordID
 	}
 	return nil
 }
 
-func (m *CreateRecordRequest) GetBody() []byte {
+func (m *RecordSummary) GetLogID() []byte {
 	if m != nil {
-		return m.Body
+		return m.LogID
 	}
 	return nil
 }
 
-type NewRecordReply struct {
-	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+func (m *RecordSummary) GetAuthor() []byte {
+	if m != nil {
+		return m.Author
+	}
+	return nil
 }
 
-func (m *NewRecordReply) Reset()         { *m = NewRecordReply{} }
-func (m *NewRecordReply) String() string { return proto.CompactTextString(m) }
-func (*NewRecordReply) ProtoMessage()    {}
-func (*NewRecordReply) Descriptor() ([]byte, []int) {
+func (m *RecordSummary) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *RecordSummary) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *RecordSummary) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+type ActivityFeedReply struct {
+	Records []*RecordSummary `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (m *ActivityFeedReply) Reset()         { *m = ActivityFeedReply{} }
+func (m *ActivityFeedReply) String() string { return proto.CompactTextString(m) }
+func (*ActivityFeedReply) ProtoMessage()    {}
+func (*ActivityFeedReply) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0a395cd12426f651, []int{17}
 }
-func (m *NewRecordReply) XXX_Unmarshal(b []byte) error {
+func (m *ActivityFeedReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NewRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ActivityFeedReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NewRecordReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ActivityFeedReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -963,57 +1220,103 @@ func (m *NewRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *NewRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewRecordReply.Merge(m, src)
+func (m *ActivityFeedReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivityFeedReply.Merge(m, src)
 }
-func (m *NewRecordReply) XXX_Size() int {
+func (m *ActivityFeedReply) XXX_Size() int {
 	return m.Size()
 }
-func (m *NewRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewRecordReply.DiscardUnknown(m)
+func (m *ActivityFeedReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivityFeedReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_ActivityFeedReply proto.InternalMessageInfo
 
-func (m *NewRecordReply) GetThreadID() []byte {
+func (m *ActivityFeedReply) GetRecords() []*RecordSummary {
 	if m != nil {
-		return m.ThreadID
+		return m.Records
 	}
 	return nil
 }
 
-func (m *NewRecordReply) GetLogID() []byte {
+type Annotation struct {
+	Read    bool     `protobuf:"varint,1,opt,name=read,proto3" json:"read,omitempty"`
+	Starred bool     `protobuf:"varint,2,opt,name=starred,proto3" json:"starred,omitempty"`
+	Labels  []string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+}
+
+func (m *Annotation) Reset()         { *m = Annotation{} }
+func (m *Annotation) String() string { return proto.CompactTextString(m) }
+func (*Annotation) ProtoMessage()    {}
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{18}
+}
+func (m *Annotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Annotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Annotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Annotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Annotation.Merge(m, src)
+}
+func (m *Annotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Annotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Annotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Annotation proto.InternalMessageInfo
+
+func (m *Annotation) GetRead() bool {
 	if m != nil {
-		return m.LogID
+		return m.Read
 	}
-	return nil
+	return false
 }
 
-func (m *NewRecordReply) GetRecord() *Record {
+func (m *Annotation) GetStarred() bool {
 	if m != nil {
-		return m.Record
+		return m.Starred
+	}
+	return false
+}
+
+func (m *Annotation) GetLabels() []string {
+	if m != nil {
+		return m.Labels
 	}
 	return nil
 }
 
-type AddRecordRequest struct {
-	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+type RecordAnnotation struct {
+	RecordID   []byte      `protobuf:"bytes,1,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	Annotation *Annotation `protobuf:"bytes,2,opt,name=annotation,proto3" json:"annotation,omitempty"`
+	UpdatedAt  int64       `protobuf:"varint,3,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
 }
 
-func (m *AddRecordRequest) Reset()         { *m = AddRecordRequest{} }
-func (m *AddRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*AddRecordRequest) ProtoMessage()    {}
-func (*AddRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a395cd12426f651, []int{18}
+func (m *RecordAnnotation) Reset()         { *m = RecordAnnotation{} }
+func (m *RecordAnnotation) String() string { return proto.CompactTextString(m) }
+func (*RecordAnnotation) ProtoMessage()    {}
+func (*RecordAnnotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{19}
 }
-func (m *AddRecordRequest) XXX_Unmarshal(b []byte) error {
+func (m *RecordAnnotation) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AddRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RecordAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AddRecordRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RecordAnnotation.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1023,58 +1326,57 @@ func (m *AddRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *AddRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddRecordRequest.Merge(m, src)
+func (m *RecordAnnotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordAnnotation.Merge(m, src)
 }
-func (m *AddRecordRequest) XXX_Size() int {
+func (m *RecordAnnotation) XXX_Size() int {
 	return m.Size()
 }
-func (m *AddRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddRecordRequest.DiscardUnknown(m)
+func (m *RecordAnnotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordAnnotation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_RecordAnnotation proto.InternalMessageInfo
 
-func (m *AddRecordRequest) GetThreadID() []byte {
+func (m *RecordAnnotation) GetRecordID() []byte {
 	if m != nil {
-		return m.ThreadID
+		return m.RecordID
 	}
 	return nil
 }
 
-func (m *AddRecordRequest) GetLogID() []byte {
+func (m *RecordAnnotation) GetAnnotation() *Annotation {
 	if m != nil {
-		return m.LogID
+		return m.Annotation
 	}
 	return nil
 }
 
-func (m *AddRecordRequest) GetRecord() *Record {
+func (m *RecordAnnotation) GetUpdatedAt() int64 {
 	if m != nil {
-		return m.Record
+		return m.UpdatedAt
 	}
-	return nil
+	return 0
 }
 
-type Record struct {
-	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
-	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
-	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
-	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+type AnnotateRecordRequest struct {
+	ThreadID   []byte      `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	RecordID   []byte      `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	Annotation *Annotation `protobuf:"bytes,3,opt,name=annotation,proto3" json:"annotation,omitempty"`
 }
 
-func (m *Record) Reset()         { *m = Record{} }
-func (m *Record) String() string { return proto.CompactTextString(m) }
-func (*Record) ProtoMessage()    {}
-func (*Record) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a395cd12426f651, []int{19}
+func (m *AnnotateRecordRequest) Reset()         { *m = AnnotateRecordRequest{} }
+func (m *AnnotateRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*AnnotateRecordRequest) ProtoMessage()    {}
+func (*AnnotateRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{20}
 }
-func (m *Record) XXX_Unmarshal(b []byte) error {
+func (m *AnnotateRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AnnotateRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AnnotateRecordRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1084,61 +1386,54 @@ func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Record) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Record.Merge(m, src)
+func (m *AnnotateRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnotateRecordRequest.Merge(m, src)
 }
-func (m *Record) XXX_Size() int {
+func (m *AnnotateRecordRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Record) XXX_DiscardUnknown() {
-	xxx_messageInfo_Record.DiscardUnknown(m)
+func (m *AnnotateRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnotateRecordRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Record proto.InternalMessageInfo
-
-func (m *Record) GetRecordNode() []byte {
-	if m != nil {
-		return m.RecordNode
-	}
-	return nil
-}
+var xxx_messageInfo_AnnotateRecordRequest proto.InternalMessageInfo
 
-func (m *Record) GetEventNode() []byte {
+func (m *AnnotateRecordRequest) GetThreadID() []byte {
 	if m != nil {
-		return m.EventNode
+		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *Record) GetHeaderNode() []byte {
+func (m *AnnotateRecordRequest) GetRecordID() []byte {
 	if m != nil {
-		return m.HeaderNode
+		return m.RecordID
 	}
 	return nil
 }
 
-func (m *Record) GetBodyNode() []byte {
+func (m *AnnotateRecordRequest) GetAnnotation() *Annotation {
 	if m != nil {
-		return m.BodyNode
+		return m.Annotation
 	}
 	return nil
 }
 
-type AddRecordReply struct {
+type AnnotateRecordReply struct {
 }
 
-func (m *AddRecordReply) Reset()         { *m = AddRecordReply{} }
-func (m *AddRecordReply) String() string { return proto.CompactTextString(m) }
-func (*AddRecordReply) ProtoMessage()    {}
-func (*AddRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a395cd12426f651, []int{20}
+func (m *AnnotateRecordReply) Reset()         { *m = AnnotateRecordReply{} }
+func (m *AnnotateRecordReply) String() string { return proto.CompactTextString(m) }
+func (*AnnotateRecordReply) ProtoMessage()    {}
+func (*AnnotateRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{21}
 }
-func (m *AddRecordReply) XXX_Unmarshal(b []byte) error {
+func (m *AnnotateRecordReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AddRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AnnotateRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AddRecordReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AnnotateRecordReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1148,35 +1443,35 @@ func (m *AddRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *AddRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddRecordReply.Merge(m, src)
+func (m *AnnotateRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnotateRecordReply.Merge(m, src)
 }
-func (m *AddRecordReply) XXX_Size() int {
+func (m *AnnotateRecordReply) XXX_Size() int {
 	return m.Size()
 }
-func (m *AddRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddRecordReply.DiscardUnknown(m)
+func (m *AnnotateRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnotateRecordReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_AnnotateRecordReply proto.InternalMessageInfo
 
-type GetRecordRequest struct {
+type GetAnnotationRequest struct {
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 	RecordID []byte `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
 }
 
-func (m *GetRecordRequest) Reset()         { *m = GetRecordRequest{} }
-func (m *GetRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRecordRequest) ProtoMessage()    {}
-func (*GetRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a395cd12426f651, []int{21}
+func (m *GetAnnotationRequest) Reset()         { *m = GetAnnotationRequest{} }
+func (m *GetAnnotationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAnnotationRequest) ProtoMessage()    {}
+func (*GetAnnotationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{22}
 }
-func (m *GetRecordRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetAnnotationRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetAnnotationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetRecordRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetAnnotationRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1186,48 +1481,48 @@ func (m *GetRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *GetRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRecordRequest.Merge(m, src)
+func (m *GetAnnotationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAnnotationRequest.Merge(m, src)
 }
-func (m *GetRecordRequest) XXX_Size() int {
+func (m *GetAnnotationRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRecordRequest.DiscardUnknown(m)
+func (m *GetAnnotationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAnnotationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetAnnotationRequest proto.InternalMessageInfo
 
-func (m *GetRecordRequest) GetThreadID() []byte {
+func (m *GetAnnotationRequest) GetThreadID() []byte {
 	if m != nil {
 		return m.ThreadID
 	}
 	return nil
 }
 
-func (m *GetRecordRequest) GetRecordID() []byte {
+func (m *GetAnnotationRequest) GetRecordID() []byte {
 	if m != nil {
 		return m.RecordID
 	}
 	return nil
 }
 
-type GetRecordReply struct {
-	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+type GetAnnotationReply struct {
+	Annotation *RecordAnnotation `protobuf:"bytes,1,opt,name=annotation,proto3" json:"annotation,omitempty"`
 }
 
-func (m *GetRecordReply) Reset()         { *m = GetRecordReply{} }
-func (m *GetRecordReply) String() string { return proto.CompactTextString(m) }
-func (*GetRecordReply) ProtoMessage()    {}
-func (*GetRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a395cd12426f651, []int{22}
+func (m *GetAnnotationReply) Reset()         { *m = GetAnnotationReply{} }
+func (m *GetAnnotationReply) String() string { return proto.CompactTextString(m) }
+func (*GetAnnotationReply) ProtoMessage()    {}
+func (*GetAnnotationReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{23}
 }
-func (m *GetRecordReply) XXX_Unmarshal(b []byte) error {
+func (m *GetAnnotationReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetAnnotationReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetRecordReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetAnnotationReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1237,41 +1532,44 @@ func (m *GetRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *GetRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRecordReply.Merge(m, src)
+func (m *GetAnnotationReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAnnotationReply.Merge(m, src)
 }
-func (m *GetRecordReply) XXX_Size() int {
+func (m *GetAnnotationReply) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRecordReply.DiscardUnknown(m)
+func (m *GetAnnotationReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAnnotationReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_GetAnnotationReply proto.InternalMessageInfo
 
-func (m *GetRecordReply) GetRecord() *Record {
+func (m *GetAnnotationReply) GetAnnotation() *RecordAnnotation {
 	if m != nil {
-		return m.Record
+		return m.Annotation
 	}
 	return nil
 }
 
-type SubscribeRequest struct {
-	ThreadIDs [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+type QueryAnnotationsRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Unread   bool   `protobuf:"varint,2,opt,name=unread,proto3" json:"unread,omitempty"`
+	Starred  bool   `protobuf:"varint,3,opt,name=starred,proto3" json:"starred,omitempty"`
+	Label    string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
 }
 
-func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
-func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
-func (*SubscribeRequest) ProtoMessage()    {}
-func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0a395cd12426f651, []int{23}
+func (m *QueryAnnotationsRequest) Reset()         { *m = QueryAnnotationsRequest{} }
+func (m *QueryAnnotationsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryAnnotationsRequest) ProtoMessage()    {}
+func (*QueryAnnotationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{24}
 }
-func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+func (m *QueryAnnotationsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryAnnotationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryAnnotationsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1281,2069 +1579,15759 @@ func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+func (m *QueryAnnotationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryAnnotationsRequest.Merge(m, src)
 }
-func (m *SubscribeRequest) XXX_Size() int {
+func (m *QueryAnnotationsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SubscribeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+func (m *QueryAnnotationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryAnnotationsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryAnnotationsRequest proto.InternalMessageInfo
 
-func (m *SubscribeRequest) GetThreadIDs() [][]byte {
+func (m *QueryAnnotationsRequest) GetThreadID() []byte {
 	if m != nil {
-		return m.ThreadIDs
+		return m.ThreadID
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*GetHostIDRequest)(nil), "threads.net.pb.GetHostIDRequest")
-	proto.RegisterType((*GetHostIDReply)(nil), "threads.net.pb.GetHostIDReply")
-	proto.RegisterType((*GetTokenRequest)(nil), "threads.net.pb.GetTokenRequest")
-	proto.RegisterType((*GetTokenReply)(nil), "threads.net.pb.GetTokenReply")
-	proto.RegisterType((*CreateThreadRequest)(nil), "threads.net.pb.CreateThreadRequest")
-	proto.RegisterType((*Keys)(nil), "threads.net.pb.Keys")
-	proto.RegisterType((*ThreadInfoReply)(nil), "threads.net.pb.ThreadInfoReply")
-	proto.RegisterType((*LogInfo)(nil), "threads.net.pb.LogInfo")
-	proto.RegisterType((*AddThreadRequest)(nil), "threads.net.pb.AddThreadRequest")
-	proto.RegisterType((*GetThreadRequest)(nil), "threads.net.pb.GetThreadRequest")
-	proto.RegisterType((*PullThreadRequest)(nil), "threads.net.pb.PullThreadRequest")
-	proto.RegisterType((*PullThreadReply)(nil), "threads.net.pb.PullThreadReply")
-	proto.RegisterType((*DeleteThreadRequest)(nil), "threads.net.pb.DeleteThreadRequest")
-	proto.RegisterType((*DeleteThreadReply)(nil), "threads.net.pb.DeleteThreadReply")
-	proto.RegisterType((*AddReplicatorRequest)(nil), "threads.net.pb.AddReplicatorRequest")
-	proto.RegisterType((*AddReplicatorReply)(nil), "threads.net.pb.AddReplicatorReply")
-	proto.RegisterType((*CreateRecordRequest)(nil), "threads.net.pb.CreateRecordRequest")
-	proto.RegisterType((*NewRecordReply)(nil), "threads.net.pb.NewRecordReply")
-	proto.RegisterType((*AddRecordRequest)(nil), "threads.net.pb.AddRecordRequest")
-	proto.RegisterType((*Record)(nil), "threads.net.pb.Record")
-	proto.RegisterType((*AddRecordReply)(nil), "threads.net.pb.AddRecordReply")
-	proto.RegisterType((*GetRecordRequest)(nil), "threads.net.pb.GetRecordRequest")
-	proto.RegisterType((*GetRecordReply)(nil), "threads.net.pb.GetRecordReply")
-	proto.RegisterType((*SubscribeRequest)(nil), "threads.net.pb.SubscribeRequest")
+func (m *QueryAnnotationsRequest) GetUnread() bool {
+	if m != nil {
+		return m.Unread
+	}
+	return false
 }
 
-func init() { proto.RegisterFile("threadsnet.proto", fileDescriptor_0a395cd12426f651) }
-
-var fileDescriptor_0a395cd12426f651 = []byte{
-	// 916 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0xb7, 0x93, 0x34, 0xa9, 0x5f, 0xb3, 0x69, 0x3a, 0x8d, 0x8a, 0x15, 0xc0, 0x64, 0x07, 0x0e,
-	0x91, 0x40, 0xa1, 0x84, 0x2b, 0x07, 0x52, 0x52, 0x36, 0x61, 0x51, 0x08, 0xde, 0x80, 0x90, 0xf6,
-	0xb0, 0x72, 0xe2, 0x21, 0x8d, 0x6a, 0x79, 0x82, 0x3d, 0x59, 0xc8, 0x95, 0x0f, 0x00, 0x7c, 0x06,
-	0x3e, 0x0d, 0xc7, 0x3d, 0x72, 0x44, 0xed, 0xc7, 0xe0, 0x82, 0x66, 0xc6, 0x76, 0xfc, 0xaf, 0x89,
-	0x2b, 0x71, 0xf3, 0x7b, 0xf3, 0xe6, 0xf7, 0xfe, 0xcc, 0x7b, 0xbf, 0x67, 0x68, 0xb2, 0x1b, 0x8f,
-	0x58, 0xb6, 0xef, 0x12, 0xd6, 0x5b, 0x7b, 0x94, 0x51, 0xd4, 0x08, 0x34, 0x3d, 0xa1, 0x9a, 0x63,
-	0x04, 0xcd, 0x67, 0x84, 0x8d, 0xa8, 0xcf, 0xc6, 0x43, 0x93, 0xfc, 0xb4, 0x21, 0x3e, 0xc3, 0x5d,
-	0x68, 0xc4, 0x74, 0x6b, 0x67, 0x8b, 0x2e, 0xa0, 0xba, 0x26, 0xc4, 0x1b, 0x0f, 0x75, 0xb5, 0xa3,
-	0x76, 0xeb, 0x66, 0x20, 0xe1, 0x29, 0x9c, 0x3e, 0x23, 0x6c, 0x46, 0x6f, 0x89, 0x1b, 0x5c, 0x46,
-	0x08, 0xca, 0xb7, 0x64, 0x2b, 0xec, 0xb4, 0x91, 0x62, 0x72, 0x01, 0x19, 0xa0, 0xf9, 0xab, 0xa5,
-	0x6b, 0xb1, 0x8d, 0x47, 0xf4, 0x12, 0x47, 0x18, 0x29, 0xe6, 0x4e, 0x75, 0xa5, 0x41, 0x6d, 0x6d,
-	0x6d, 0x1d, 0x6a, 0xd9, 0xd8, 0x84, 0x27, 0x3b, 0x44, 0xee, 0xda, 0x00, 0x6d, 0x71, 0x63, 0x39,
-	0x0e, 0x71, 0x97, 0x44, 0x7a, 0xe7, 0x77, 0x23, 0x15, 0xba, 0x80, 0x23, 0xc6, 0xad, 0x05, 0x2e,
-	0xf7, 0x28, 0xc5, 0x38, 0xe6, 0x4b, 0x38, 0xff, 0xc2, 0x23, 0x16, 0x23, 0x33, 0x91, 0x7b, 0x18,
-	0x69, 0x1b, 0x8e, 0x65, 0x31, 0xa2, 0xb4, 0x22, 0x19, 0x75, 0xa1, 0x72, 0x4b, 0xb6, 0xbe, 0x00,
-	0x3d, 0xe9, 0xb7, 0x7a, 0xc9, 0xaa, 0xf5, 0x9e, 0x93, 0xad, 0x6f, 0x0a, 0x0b, 0xfc, 0x19, 0x54,
-	0xb8, 0x84, 0xde, 0x01, 0x4d, 0x1a, 0x3d, 0x0f, 0xb2, 0xaf, 0x9b, 0x3b, 0x05, 0x2f, 0xa0, 0x43,
-	0x97, 0xfc, 0xa8, 0x24, 0x0b, 0x28, 0x25, 0xfc, 0x9b, 0x0a, 0xa7, 0x32, 0xaa, 0xb1, 0xfb, 0x23,
-	0x95, 0x19, 0xef, 0x8b, 0x2b, 0xe1, 0xa5, 0x94, 0xf6, 0xf2, 0x21, 0x54, 0x1c, 0xba, 0xf4, 0xf5,
-	0x72, 0xa7, 0xdc, 0x3d, 0xe9, 0xbf, 0x95, 0x8e, 0xfa, 0x6b, 0xba, 0x14, 0x5e, 0x84, 0x11, 0x6a,
-	0xc1, 0x91, 0x65, 0xdb, 0x9e, 0xaf, 0x57, 0x3a, 0xe5, 0x6e, 0xdd, 0x94, 0x02, 0xfe, 0x5d, 0x85,
-	0x5a, 0x60, 0x87, 0x1a, 0x50, 0x8a, 0x42, 0x28, 0x8d, 0x87, 0xa2, 0x0b, 0x36, 0xf3, 0x58, 0x12,
-	0x52, 0x42, 0x3a, 0xd4, 0xd6, 0xde, 0xea, 0x35, 0x3f, 0x28, 0x8b, 0x83, 0x50, 0xcc, 0xf7, 0x81,
-	0x10, 0x54, 0x6e, 0x88, 0x65, 0xeb, 0x47, 0xc2, 0x58, 0x7c, 0x73, 0x8c, 0x05, 0xdd, 0xb8, 0x8c,
-	0x78, 0x7a, 0x55, 0x62, 0x04, 0x22, 0x9e, 0x42, 0x73, 0x60, 0xdb, 0xc9, 0xa7, 0x43, 0x50, 0xe1,
-	0x50, 0x41, 0x6c, 0xe2, 0xfb, 0x11, 0x4f, 0xd6, 0x13, 0x3d, 0x5f, 0xb8, 0x19, 0xf0, 0xc7, 0x70,
-	0x36, 0xdd, 0x38, 0x4e, 0xf1, 0x0b, 0x67, 0x70, 0x1a, 0xbf, 0xb0, 0x76, 0xb6, 0xf8, 0x13, 0x38,
-	0x1f, 0x12, 0x87, 0x3c, 0xa2, 0x07, 0xf1, 0x39, 0x9c, 0x25, 0xaf, 0x70, 0x9c, 0x2f, 0xa1, 0x35,
-	0xb0, 0xc5, 0xf7, 0x6a, 0x61, 0x31, 0xea, 0x15, 0x69, 0xe6, 0xb0, 0x5a, 0xa5, 0x5d, 0xb5, 0xf0,
-	0x47, 0x80, 0x52, 0x38, 0xfb, 0xe6, 0xfc, 0x3a, 0x9c, 0x20, 0x93, 0x2c, 0xa8, 0x67, 0x17, 0x74,
-	0x3a, 0xa7, 0x76, 0xd8, 0x2a, 0xe2, 0x1b, 0x7b, 0xd0, 0x98, 0x90, 0x9f, 0x43, 0x8c, 0x43, 0xbd,
-	0xde, 0x82, 0x23, 0x87, 0x2e, 0xc7, 0xc3, 0x00, 0x42, 0x0a, 0xa8, 0x07, 0x55, 0x4f, 0x00, 0x88,
-	0x5e, 0x3b, 0xe9, 0x5f, 0xa4, 0x1f, 0x3a, 0x80, 0x0f, 0xac, 0x30, 0x13, 0xed, 0x53, 0x3c, 0xee,
-	0xff, 0xc7, 0xeb, 0xaf, 0x2a, 0x54, 0xa5, 0x0a, 0x19, 0x00, 0x52, 0x39, 0xa1, 0x76, 0xc0, 0x60,
-	0x66, 0x4c, 0xc3, 0x47, 0x9a, 0xbc, 0x26, 0x2e, 0x13, 0xc7, 0xc1, 0x48, 0x47, 0x0a, 0x7e, 0x9b,
-	0xcf, 0x07, 0xf1, 0xc4, 0xb1, 0x1c, 0xaf, 0x98, 0x86, 0xa7, 0xc2, 0x4b, 0x2b, 0x4e, 0x2b, 0x32,
-	0x95, 0x50, 0xc6, 0x4d, 0x68, 0xc4, 0x52, 0xe7, 0xdd, 0xf3, 0x95, 0xe8, 0xfc, 0xe2, 0xc5, 0x68,
-	0xc3, 0xb1, 0x8c, 0x34, 0xaa, 0x47, 0x24, 0xe3, 0xcf, 0xc5, 0x96, 0x88, 0x3f, 0xe6, 0xae, 0x48,
-	0x6a, 0xa1, 0x22, 0x5d, 0x42, 0xf3, 0xc5, 0x66, 0xee, 0x2f, 0xbc, 0xd5, 0x9c, 0x84, 0xd1, 0x44,
-	0x04, 0x37, 0x1e, 0xfa, 0xba, 0x2a, 0x58, 0x63, 0xa7, 0xe8, 0xff, 0x5b, 0x83, 0xf2, 0x60, 0x3a,
-	0x46, 0xdf, 0x80, 0x16, 0x6d, 0x28, 0xd4, 0x49, 0xbb, 0x49, 0x2f, 0xb4, 0xb6, 0xb1, 0xc7, 0x82,
-	0x97, 0x45, 0x41, 0x53, 0x38, 0x0e, 0xd7, 0x0e, 0x7a, 0x2f, 0xc7, 0x3a, 0xbe, 0xe2, 0xda, 0xef,
-	0x3e, 0x6c, 0x20, 0xd0, 0xba, 0xea, 0xa5, 0x8a, 0xbe, 0x87, 0x7a, 0x7c, 0xe9, 0xa0, 0xf7, 0xd3,
-	0x97, 0x72, 0x56, 0x52, 0x3b, 0xe3, 0x3a, 0xb5, 0x1b, 0x44, 0xa4, 0x5a, 0x44, 0x87, 0xd9, 0xd4,
-	0xd3, 0x4c, 0x59, 0x10, 0x31, 0xa2, 0xc3, 0xdc, 0x62, 0x3e, 0x1a, 0xd1, 0x04, 0xd8, 0xf1, 0x1f,
-	0x7a, 0x9a, 0xbe, 0x90, 0x21, 0xd3, 0x2c, 0x66, 0x9a, 0x3e, 0x15, 0xf4, 0x03, 0xd4, 0xe3, 0x6c,
-	0x98, 0xad, 0x67, 0x0e, 0xbd, 0xb6, 0x9f, 0xee, 0x37, 0x92, 0xc8, 0x2f, 0xe1, 0x49, 0x82, 0x0a,
-	0xd1, 0x07, 0x39, 0x55, 0xcd, 0x30, 0x6e, 0x1b, 0x1f, 0xb0, 0x92, 0xe0, 0xdf, 0x85, 0x6d, 0x10,
-	0xb0, 0xc1, 0x03, 0x6d, 0x90, 0x18, 0xc9, 0x6c, 0xbf, 0x26, 0x59, 0x13, 0x2b, 0x7c, 0x00, 0xa2,
-	0xd1, 0xce, 0xed, 0x82, 0x03, 0x80, 0x29, 0x5e, 0x50, 0x82, 0x89, 0x7a, 0x08, 0x30, 0x4d, 0x1a,
-	0xb9, 0x13, 0x95, 0x04, 0xfc, 0x16, 0xb4, 0x68, 0xb8, 0xb3, 0x80, 0xe9, 0xb9, 0x3f, 0x9c, 0xf2,
-	0xa5, 0x7a, 0x35, 0xfd, 0xeb, 0xce, 0x50, 0xdf, 0xdc, 0x19, 0xea, 0x3f, 0x77, 0x86, 0xfa, 0xc7,
-	0xbd, 0xa1, 0xbc, 0xb9, 0x37, 0x94, 0xbf, 0xef, 0x0d, 0x05, 0xde, 0x5e, 0xd1, 0x1e, 0x23, 0xbf,
-	0xb0, 0x95, 0x43, 0x42, 0x9c, 0x57, 0x2e, 0x61, 0xaf, 0x96, 0xde, 0x7a, 0x71, 0x05, 0xf2, 0xb9,
-	0xfd, 0x09, 0x61, 0x53, 0xf5, 0xcf, 0x12, 0xcc, 0x46, 0xe6, 0xf5, 0x60, 0xf8, 0x62, 0x72, 0x3d,
-	0x9b, 0x57, 0xc5, 0x4f, 0xf1, 0xa7, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x46, 0xb9, 0xe9, 0x06,
-	0x28, 0x0b, 0x00, 0x00,
+func (m *QueryAnnotationsRequest) GetStarred() bool {
+	if m != nil {
+		return m.Starred
+	}
+	return false
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// APIClient is the client API for API service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type APIClient interface {
-	GetHostID(ctx context.Context, in *GetHostIDRequest, opts ...grpc.CallOption) (*GetHostIDReply, error)
-	GetToken(ctx context.Context, opts ...grpc.CallOption) (API_GetTokenClient, error)
-	CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
-	AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
-	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
-	PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error)
-	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error)
-	AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error)
-	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error)
-	AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error)
-	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error)
-	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error)
+func (m *QueryAnnotationsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
 }
 
-type aPIClient struct {
-	cc *grpc.ClientConn
+type QueryAnnotationsReply struct {
+	Annotations []*RecordAnnotation `protobuf:"bytes,1,rep,name=annotations,proto3" json:"annotations,omitempty"`
 }
 
-func NewAPIClient(cc *grpc.ClientConn) APIClient {
-	return &aPIClient{cc}
+func (m *QueryAnnotationsReply) Reset()         { *m = QueryAnnotationsReply{} }
+func (m *QueryAnnotationsReply) String() string { return proto.CompactTextString(m) }
+func (*QueryAnnotationsReply) ProtoMessage()    {}
+func (*QueryAnnotationsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{25}
 }
-
-func (c *aPIClient) GetHostID(ctx context.Context, in *GetHostIDRequest, opts ...grpc.CallOption) (*GetHostIDReply, error) {
-	out := new(GetHostIDReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetHostID", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *QueryAnnotationsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *aPIClient) GetToken(ctx context.Context, opts ...grpc.CallOption) (API_GetTokenClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[0], "/threads.net.pb.API/GetToken", opts...)
-	if err != nil {
-		return nil, err
+func (m *QueryAnnotationsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryAnnotationsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	x := &aPIGetTokenClient{stream}
-	return x, nil
 }
-
-type API_GetTokenClient interface {
-	Send(*GetTokenRequest) error
-	Recv() (*GetTokenReply, error)
-	grpc.ClientStream
+func (m *QueryAnnotationsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryAnnotationsReply.Merge(m, src)
 }
-
-type aPIGetTokenClient struct {
-	grpc.ClientStream
+func (m *QueryAnnotationsReply) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *aPIGetTokenClient) Send(m *GetTokenRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *QueryAnnotationsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryAnnotationsReply.DiscardUnknown(m)
 }
 
-func (x *aPIGetTokenClient) Recv() (*GetTokenReply, error) {
-	m := new(GetTokenReply)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
+var xxx_messageInfo_QueryAnnotationsReply proto.InternalMessageInfo
 
-func (c *aPIClient) CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
-	out := new(ThreadInfoReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/CreateThread", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *QueryAnnotationsReply) GetAnnotations() []*RecordAnnotation {
+	if m != nil {
+		return m.Annotations
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
-	out := new(ThreadInfoReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AddThread", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PullThreadRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 }
 
-func (c *aPIClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
-	out := new(ThreadInfoReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetThread", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PullThreadRequest) Reset()         { *m = PullThreadRequest{} }
+func (m *PullThreadRequest) String() string { return proto.CompactTextString(m) }
+func (*PullThreadRequest) ProtoMessage()    {}
+func (*PullThreadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{26}
 }
-
-func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error) {
-	out := new(PullThreadReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/PullThread", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PullThreadRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *aPIClient) DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error) {
-	out := new(DeleteThreadReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/DeleteThread", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PullThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PullThreadRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *PullThreadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullThreadRequest.Merge(m, src)
+}
+func (m *PullThreadRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PullThreadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullThreadRequest.DiscardUnknown(m)
 }
 
-func (c *aPIClient) AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error) {
-	out := new(AddReplicatorReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AddReplicator", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PullThreadRequest proto.InternalMessageInfo
+
+func (m *PullThreadRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error) {
-	out := new(NewRecordReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/CreateRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PullThreadReply struct {
 }
 
-func (c *aPIClient) AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error) {
-	out := new(AddRecordReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AddRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PullThreadReply) Reset()         { *m = PullThreadReply{} }
+func (m *PullThreadReply) String() string { return proto.CompactTextString(m) }
+func (*PullThreadReply) ProtoMessage()    {}
+func (*PullThreadReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{27}
+}
+func (m *PullThreadReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PullThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PullThreadReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *PullThreadReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullThreadReply.Merge(m, src)
+}
+func (m *PullThreadReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *PullThreadReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullThreadReply.DiscardUnknown(m)
 }
 
-func (c *aPIClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error) {
-	out := new(GetRecordReply)
-	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+var xxx_messageInfo_PullThreadReply proto.InternalMessageInfo
+
+type PullThreadFromRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Addr     []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
 }
 
-func (c *aPIClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[1], "/threads.net.pb.API/Subscribe", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &aPISubscribeClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *PullThreadFromRequest) Reset()         { *m = PullThreadFromRequest{} }
+func (m *PullThreadFromRequest) String() string { return proto.CompactTextString(m) }
+func (*PullThreadFromRequest) ProtoMessage()    {}
+func (*PullThreadFromRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{28}
+}
+func (m *PullThreadFromRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PullThreadFromRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PullThreadFromRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
 }
-
-type API_SubscribeClient interface {
-	Recv() (*NewRecordReply, error)
-	grpc.ClientStream
+func (m *PullThreadFromRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullThreadFromRequest.Merge(m, src)
 }
-
-type aPISubscribeClient struct {
-	grpc.ClientStream
+func (m *PullThreadFromRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PullThreadFromRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullThreadFromRequest.DiscardUnknown(m)
 }
 
-func (x *aPISubscribeClient) Recv() (*NewRecordReply, error) {
-	m := new(NewRecordReply)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_PullThreadFromRequest proto.InternalMessageInfo
+
+func (m *PullThreadFromRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return m, nil
+	return nil
 }
 
-// APIServer is the server API for API service.
-type APIServer interface {
-	GetHostID(context.Context, *GetHostIDRequest) (*GetHostIDReply, error)
-	GetToken(API_GetTokenServer) error
-	CreateThread(context.Context, *CreateThreadRequest) (*ThreadInfoReply, error)
-	AddThread(context.Context, *AddThreadRequest) (*ThreadInfoReply, error)
-	GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error)
-	PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error)
-	DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error)
-	AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error)
-	CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error)
-	AddRecord(context.Context, *AddRecordRequest) (*AddRecordReply, error)
-	GetRecord(context.Context, *GetRecordRequest) (*GetRecordReply, error)
-	Subscribe(*SubscribeRequest, API_SubscribeServer) error
+func (m *PullThreadFromRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
 }
 
-// UnimplementedAPIServer can be embedded to have forward compatible implementations.
-type UnimplementedAPIServer struct {
+type PullThreadFromReply struct {
 }
 
-func (*UnimplementedAPIServer) GetHostID(ctx context.Context, req *GetHostIDRequest) (*GetHostIDReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetHostID not implemented")
+func (m *PullThreadFromReply) Reset()         { *m = PullThreadFromReply{} }
+func (m *PullThreadFromReply) String() string { return proto.CompactTextString(m) }
+func (*PullThreadFromReply) ProtoMessage()    {}
+func (*PullThreadFromReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{29}
 }
-func (*UnimplementedAPIServer) GetToken(srv API_GetTokenServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetToken not implemented")
+func (m *PullThreadFromReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedAPIServer) CreateThread(ctx context.Context, req *CreateThreadRequest) (*ThreadInfoReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+func (m *PullThreadFromReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PullThreadFromReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedAPIServer) AddThread(ctx context.Context, req *AddThreadRequest) (*ThreadInfoReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddThread not implemented")
+func (m *PullThreadFromReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullThreadFromReply.Merge(m, src)
 }
-func (*UnimplementedAPIServer) GetThread(ctx context.Context, req *GetThreadRequest) (*ThreadInfoReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+func (m *PullThreadFromReply) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedAPIServer) PullThread(ctx context.Context, req *PullThreadRequest) (*PullThreadReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PullThread not implemented")
+func (m *PullThreadFromReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullThreadFromReply.DiscardUnknown(m)
 }
-func (*UnimplementedAPIServer) DeleteThread(ctx context.Context, req *DeleteThreadRequest) (*DeleteThreadReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
+
+var xxx_messageInfo_PullThreadFromReply proto.InternalMessageInfo
+
+type DeleteThreadRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 }
-func (*UnimplementedAPIServer) AddReplicator(ctx context.Context, req *AddReplicatorRequest) (*AddReplicatorReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddReplicator not implemented")
+
+func (m *DeleteThreadRequest) Reset()         { *m = DeleteThreadRequest{} }
+func (m *DeleteThreadRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteThreadRequest) ProtoMessage()    {}
+func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{30}
 }
-func (*UnimplementedAPIServer) CreateRecord(ctx context.Context, req *CreateRecordRequest) (*NewRecordReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
+func (m *DeleteThreadRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedAPIServer) AddRecord(ctx context.Context, req *AddRecordRequest) (*AddRecordReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddRecord not implemented")
+func (m *DeleteThreadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteThreadRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedAPIServer) GetRecord(ctx context.Context, req *GetRecordRequest) (*GetRecordReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
+func (m *DeleteThreadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteThreadRequest.Merge(m, src)
 }
-func (*UnimplementedAPIServer) Subscribe(req *SubscribeRequest, srv API_SubscribeServer) error {
-	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+func (m *DeleteThreadRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func RegisterAPIServer(s *grpc.Server, srv APIServer) {
-	s.RegisterService(&_API_serviceDesc, srv)
+func (m *DeleteThreadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteThreadRequest.DiscardUnknown(m)
 }
 
-func _API_GetHostID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetHostIDRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).GetHostID(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/GetHostID",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetHostID(ctx, req.(*GetHostIDRequest))
+var xxx_messageInfo_DeleteThreadRequest proto.InternalMessageInfo
+
+func (m *DeleteThreadRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_GetToken_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(APIServer).GetToken(&aPIGetTokenServer{stream})
+type DeleteThreadReply struct {
 }
 
-type API_GetTokenServer interface {
-	Send(*GetTokenReply) error
-	Recv() (*GetTokenRequest, error)
-	grpc.ServerStream
+func (m *DeleteThreadReply) Reset()         { *m = DeleteThreadReply{} }
+func (m *DeleteThreadReply) String() string { return proto.CompactTextString(m) }
+func (*DeleteThreadReply) ProtoMessage()    {}
+func (*DeleteThreadReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{31}
 }
-
-type aPIGetTokenServer struct {
-	grpc.ServerStream
+func (m *DeleteThreadReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (x *aPIGetTokenServer) Send(m *GetTokenReply) error {
-	return x.ServerStream.SendMsg(m)
+func (m *DeleteThreadReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteThreadReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteThreadReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteThreadReply.Merge(m, src)
+}
+func (m *DeleteThreadReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteThreadReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteThreadReply.DiscardUnknown(m)
 }
 
-func (x *aPIGetTokenServer) Recv() (*GetTokenRequest, error) {
-	m := new(GetTokenRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+var xxx_messageInfo_DeleteThreadReply proto.InternalMessageInfo
+
+type AddReplicatorRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Addr     []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
 }
 
-func _API_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateThreadRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).CreateThread(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/CreateThread",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).CreateThread(ctx, req.(*CreateThreadRequest))
+func (m *AddReplicatorRequest) Reset()         { *m = AddReplicatorRequest{} }
+func (m *AddReplicatorRequest) String() string { return proto.CompactTextString(m) }
+func (*AddReplicatorRequest) ProtoMessage()    {}
+func (*AddReplicatorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{32}
+}
+func (m *AddReplicatorRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddReplicatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddReplicatorRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *AddReplicatorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddReplicatorRequest.Merge(m, src)
+}
+func (m *AddReplicatorRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddReplicatorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddReplicatorRequest.DiscardUnknown(m)
 }
 
-func _API_AddThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddThreadRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).AddThread(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/AddThread",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).AddThread(ctx, req.(*AddThreadRequest))
+var xxx_messageInfo_AddReplicatorRequest proto.InternalMessageInfo
+
+func (m *AddReplicatorRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetThreadRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).GetThread(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/GetThread",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetThread(ctx, req.(*GetThreadRequest))
+func (m *AddReplicatorRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_PullThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PullThreadRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).PullThread(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/PullThread",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).PullThread(ctx, req.(*PullThreadRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type AddReplicatorReply struct {
+	PeerID []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
 }
 
-func _API_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteThreadRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).DeleteThread(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/DeleteThread",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteThread(ctx, req.(*DeleteThreadRequest))
+func (m *AddReplicatorReply) Reset()         { *m = AddReplicatorReply{} }
+func (m *AddReplicatorReply) String() string { return proto.CompactTextString(m) }
+func (*AddReplicatorReply) ProtoMessage()    {}
+func (*AddReplicatorReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{33}
+}
+func (m *AddReplicatorReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddReplicatorReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddReplicatorReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *AddReplicatorReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddReplicatorReply.Merge(m, src)
+}
+func (m *AddReplicatorReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddReplicatorReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddReplicatorReply.DiscardUnknown(m)
 }
 
-func _API_AddReplicator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddReplicatorRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).AddReplicator(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/AddReplicator",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).AddReplicator(ctx, req.(*AddReplicatorRequest))
+var xxx_messageInfo_AddReplicatorReply proto.InternalMessageInfo
+
+func (m *AddReplicatorReply) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRecordRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).CreateRecord(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/CreateRecord",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).CreateRecord(ctx, req.(*CreateRecordRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type CreateRecordRequest struct {
+	ThreadID       []byte             `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Body           []byte             `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	IdempotencyKey string             `protobuf:"bytes,3,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	RecordType     string             `protobuf:"bytes,4,opt,name=recordType,proto3" json:"recordType,omitempty"`
+	Priority       int32              `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Extensions     []*RecordExtension `protobuf:"bytes,6,rep,name=extensions,proto3" json:"extensions,omitempty"`
 }
 
-func _API_AddRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddRecordRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).AddRecord(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/AddRecord",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).AddRecord(ctx, req.(*AddRecordRequest))
+func (m *CreateRecordRequest) Reset()         { *m = CreateRecordRequest{} }
+func (m *CreateRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRecordRequest) ProtoMessage()    {}
+func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{34}
+}
+func (m *CreateRecordRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateRecordRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *CreateRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRecordRequest.Merge(m, src)
+}
+func (m *CreateRecordRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRecordRequest.DiscardUnknown(m)
 }
 
-func _API_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRecordRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(APIServer).GetRecord(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/threads.net.pb.API/GetRecord",
+var xxx_messageInfo_CreateRecordRequest proto.InternalMessageInfo
+
+func (m *CreateRecordRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).GetRecord(ctx, req.(*GetRecordRequest))
+	return nil
+}
+
+func (m *CreateRecordRequest) GetBody() []byte {
+	if m != nil {
+		return m.Body
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _API_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribeRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *CreateRecordRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
 	}
-	return srv.(APIServer).Subscribe(m, &aPISubscribeServer{stream})
+	return ""
 }
 
-type API_SubscribeServer interface {
-	Send(*NewRecordReply) error
-	grpc.ServerStream
+func (m *CreateRecordRequest) GetRecordType() string {
+	if m != nil {
+		return m.RecordType
+	}
+	return ""
 }
 
-type aPISubscribeServer struct {
-	grpc.ServerStream
+func (m *CreateRecordRequest) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
 }
 
-func (x *aPISubscribeServer) Send(m *NewRecordReply) error {
-	return x.ServerStream.SendMsg(m)
+func (m *CreateRecordRequest) GetExtensions() []*RecordExtension {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
 }
 
-var _API_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "threads.net.pb.API",
-	HandlerType: (*APIServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetHostID",
-			Handler:    _API_GetHostID_Handler,
-		},
-		{
-			MethodName: "CreateThread",
-			Handler:    _API_CreateThread_Handler,
-		},
-		{
-			MethodName: "AddThread",
-			Handler:    _API_AddThread_Handler,
-		},
-		{
-			MethodName: "GetThread",
-			Handler:    _API_GetThread_Handler,
-		},
-		{
-			MethodName: "PullThread",
-			Handler:    _API_PullThread_Handler,
-		},
-		{
-			MethodName: "DeleteThread",
-			Handler:    _API_DeleteThread_Handler,
-		},
-		{
-			MethodName: "AddReplicator",
-			Handler:    _API_AddReplicator_Handler,
-		},
-		{
-			MethodName: "CreateRecord",
-			Handler:    _API_CreateRecord_Handler,
-		},
-		{
-			MethodName: "AddRecord",
-			Handler:    _API_AddRecord_Handler,
-		},
-		{
-			MethodName: "GetRecord",
-			Handler:    _API_GetRecord_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "GetToken",
-			Handler:       _API_GetToken_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "Subscribe",
-			Handler:       _API_Subscribe_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "threadsnet.proto",
+type NewRecordReply struct {
+	ThreadID []byte     `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte     `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record   *Record    `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	Clock    []*LogHead `protobuf:"bytes,4,rep,name=clock,proto3" json:"clock,omitempty"`
 }
 
-func (m *GetHostIDRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *NewRecordReply) Reset()         { *m = NewRecordReply{} }
+func (m *NewRecordReply) String() string { return proto.CompactTextString(m) }
+func (*NewRecordReply) ProtoMessage()    {}
+func (*NewRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{35}
+}
+func (m *NewRecordReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NewRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NewRecordReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *GetHostIDRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *NewRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewRecordReply.Merge(m, src)
+}
+func (m *NewRecordReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *NewRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewRecordReply.DiscardUnknown(m)
 }
 
-func (m *GetHostIDRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+var xxx_messageInfo_NewRecordReply proto.InternalMessageInfo
+
+func (m *NewRecordReply) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
 }
 
-func (m *GetHostIDReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *NewRecordReply) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetHostIDReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *NewRecordReply) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
 }
 
-func (m *GetHostIDReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.PeerID) > 0 {
-		i -= len(m.PeerID)
-		copy(dAtA[i:], m.PeerID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PeerID)))
-		i--
-		dAtA[i] = 0xa
+func (m *NewRecordReply) GetClock() []*LogHead {
+	if m != nil {
+		return m.Clock
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetTokenRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type AddRecordRequest struct {
+	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
 }
 
-func (m *GetTokenRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AddRecordRequest) Reset()         { *m = AddRecordRequest{} }
+func (m *AddRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*AddRecordRequest) ProtoMessage()    {}
+func (*AddRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{36}
 }
-
-func (m *GetTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Payload != nil {
-		{
-			size := m.Payload.Size()
-			i -= size
-			if _, err := m.Payload.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *AddRecordRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddRecordRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *GetTokenRequest_Key) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AddRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRecordRequest.Merge(m, src)
 }
-
-func (m *GetTokenRequest_Key) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	i -= len(m.Key)
-	copy(dAtA[i:], m.Key)
-	i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Key)))
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+func (m *AddRecordRequest) XXX_Size() int {
+	return m.Size()
 }
-func (m *GetTokenRequest_Signature) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AddRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRecordRequest.DiscardUnknown(m)
 }
 
-func (m *GetTokenRequest_Signature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Signature != nil {
-		i -= len(m.Signature)
-		copy(dAtA[i:], m.Signature)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Signature)))
-		i--
-		dAtA[i] = 0x12
-	}
-	return len(dAtA) - i, nil
-}
-func (m *GetTokenReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AddRecordRequest proto.InternalMessageInfo
+
+func (m *AddRecordRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetTokenReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AddRecordRequest) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
 }
 
-func (m *GetTokenReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Payload != nil {
-		{
-			size := m.Payload.Size()
-			i -= size
-			if _, err := m.Payload.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+func (m *AddRecordRequest) GetRecord() *Record {
+	if m != nil {
+		return m.Record
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetTokenReply_Challenge) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Record struct {
+	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
+	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
+	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
+	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
 }
 
-func (m *GetTokenReply_Challenge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Challenge != nil {
-		i -= len(m.Challenge)
-		copy(dAtA[i:], m.Challenge)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Challenge)))
-		i--
-		dAtA[i] = 0xa
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+func (*Record) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{37}
+}
+func (m *Record) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *GetTokenReply_Token) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Record) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record.Merge(m, src)
 }
-
-func (m *GetTokenReply_Token) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	i -= len(m.Token)
-	copy(dAtA[i:], m.Token)
-	i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Token)))
-	i--
-	dAtA[i] = 0x12
-	return len(dAtA) - i, nil
+func (m *Record) XXX_Size() int {
+	return m.Size()
 }
-func (m *CreateThreadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *Record) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record.DiscardUnknown(m)
 }
 
-func (m *CreateThreadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_Record proto.InternalMessageInfo
+
+func (m *Record) GetRecordNode() []byte {
+	if m != nil {
+		return m.RecordNode
+	}
+	return nil
 }
 
-func (m *CreateThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Keys != nil {
-		{
-			size, err := m.Keys.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (m *Record) GetEventNode() []byte {
+	if m != nil {
+		return m.EventNode
 	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *Record) GetHeaderNode() []byte {
+	if m != nil {
+		return m.HeaderNode
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *Keys) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Record) GetBodyNode() []byte {
+	if m != nil {
+		return m.BodyNode
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Keys) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type AddRecordReply struct {
 }
 
-func (m *Keys) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LogKey) > 0 {
-		i -= len(m.LogKey)
-		copy(dAtA[i:], m.LogKey)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogKey)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ThreadKey) > 0 {
-		i -= len(m.ThreadKey)
-		copy(dAtA[i:], m.ThreadKey)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadKey)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *AddRecordReply) Reset()         { *m = AddRecordReply{} }
+func (m *AddRecordReply) String() string { return proto.CompactTextString(m) }
+func (*AddRecordReply) ProtoMessage()    {}
+func (*AddRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{38}
 }
-
-func (m *ThreadInfoReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AddRecordReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddRecordReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *AddRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRecordReply.Merge(m, src)
+}
+func (m *AddRecordReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRecordReply.DiscardUnknown(m)
 }
 
-func (m *ThreadInfoReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_AddRecordReply proto.InternalMessageInfo
+
+type GetRecordRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	RecordID []byte `protobuf:"bytes,2,opt,name=recordID,proto3" json:"recordID,omitempty"`
 }
 
-func (m *ThreadInfoReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.Logs) > 0 {
-		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
+func (m *GetRecordRequest) Reset()         { *m = GetRecordRequest{} }
+func (m *GetRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRecordRequest) ProtoMessage()    {}
+func (*GetRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{39}
+}
+func (m *GetRecordRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRecordRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.ThreadKey) > 0 {
-		i -= len(m.ThreadKey)
-		copy(dAtA[i:], m.ThreadKey)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadKey)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *GetRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordRequest.Merge(m, src)
+}
+func (m *GetRecordRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRecordRequest proto.InternalMessageInfo
+
+func (m *GetRecordRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *LogInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GetRecordRequest) GetRecordID() []byte {
+	if m != nil {
+		return m.RecordID
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *LogInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GetRecordReply struct {
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
 }
 
-func (m *LogInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Counter) > 0 {
-		i -= len(m.Counter)
-		copy(dAtA[i:], m.Counter)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Counter)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.Head) > 0 {
-		i -= len(m.Head)
-		copy(dAtA[i:], m.Head)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Head)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x22
+func (m *GetRecordReply) Reset()         { *m = GetRecordReply{} }
+func (m *GetRecordReply) String() string { return proto.CompactTextString(m) }
+func (*GetRecordReply) ProtoMessage()    {}
+func (*GetRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{40}
+}
+func (m *GetRecordReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRecordReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.PrivKey) > 0 {
-		i -= len(m.PrivKey)
-		copy(dAtA[i:], m.PrivKey)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PrivKey)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.PubKey) > 0 {
-		i -= len(m.PubKey)
-		copy(dAtA[i:], m.PubKey)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PubKey)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+}
+func (m *GetRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordReply.Merge(m, src)
+}
+func (m *GetRecordReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordReply.DiscardUnknown(m)
 }
 
-func (m *AddThreadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetRecordReply proto.InternalMessageInfo
+
+func (m *GetRecordReply) GetRecord() *Record {
+	if m != nil {
+		return m.Record
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AddThreadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SubscribeRequest struct {
+	ThreadIDs [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	Tags      []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// resume replays the records added to threads after the given log heights, which were
+	// missed by a subscription dropped along with the connection
+	Resume []*ResumePoint `protobuf:"bytes,3,rep,name=resume,proto3" json:"resume,omitempty"`
 }
 
-func (m *AddThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Keys != nil {
-		{
-			size, err := m.Keys.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Addr) > 0 {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{41}
 }
-
-func (m *GetThreadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
 }
 
-func (m *GetThreadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetThreadIDs() [][]byte {
+	if m != nil {
+		return m.ThreadIDs
+	}
+	return nil
 }
 
-func (m *GetThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+func (m *SubscribeRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *PullThreadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SubscribeRequest) GetResume() []*ResumePoint {
+	if m != nil {
+		return m.Resume
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PullThreadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SubscribeHeadsRequest struct {
+	ThreadIDs [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	Tags      []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
-func (m *PullThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+func (m *SubscribeHeadsRequest) Reset()         { *m = SubscribeHeadsRequest{} }
+func (m *SubscribeHeadsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeHeadsRequest) ProtoMessage()    {}
+func (*SubscribeHeadsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{42}
+}
+func (m *SubscribeHeadsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeHeadsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeHeadsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SubscribeHeadsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeHeadsRequest.Merge(m, src)
+}
+func (m *SubscribeHeadsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeHeadsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeHeadsRequest.DiscardUnknown(m)
 }
 
-func (m *PullThreadReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SubscribeHeadsRequest proto.InternalMessageInfo
+
+func (m *SubscribeHeadsRequest) GetThreadIDs() [][]byte {
+	if m != nil {
+		return m.ThreadIDs
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PullThreadReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SubscribeHeadsRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
 
-func (m *PullThreadReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+type LogHead struct {
+	LogID   []byte `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	Head    []byte `protobuf:"bytes,2,opt,name=head,proto3" json:"head,omitempty"`
+	Counter int64  `protobuf:"varint,3,opt,name=counter,proto3" json:"counter,omitempty"`
 }
 
-func (m *DeleteThreadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LogHead) Reset()         { *m = LogHead{} }
+func (m *LogHead) String() string { return proto.CompactTextString(m) }
+func (*LogHead) ProtoMessage()    {}
+func (*LogHead) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{43}
+}
+func (m *LogHead) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogHead) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogHead.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *DeleteThreadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogHead) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogHead.Merge(m, src)
+}
+func (m *LogHead) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogHead) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogHead.DiscardUnknown(m)
 }
 
-func (m *DeleteThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_LogHead proto.InternalMessageInfo
+
+func (m *LogHead) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *DeleteThreadReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LogHead) GetHead() []byte {
+	if m != nil {
+		return m.Head
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DeleteThreadReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogHead) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
 }
 
-func (m *DeleteThreadReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+type HeadsReply struct {
+	ThreadID []byte     `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Edge     uint64     `protobuf:"varint,2,opt,name=edge,proto3" json:"edge,omitempty"`
+	Heads    []*LogHead `protobuf:"bytes,3,rep,name=heads,proto3" json:"heads,omitempty"`
 }
 
-func (m *AddReplicatorRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *HeadsReply) Reset()         { *m = HeadsReply{} }
+func (m *HeadsReply) String() string { return proto.CompactTextString(m) }
+func (*HeadsReply) ProtoMessage()    {}
+func (*HeadsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{44}
+}
+func (m *HeadsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeadsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeadsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *AddReplicatorRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *HeadsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeadsReply.Merge(m, src)
+}
+func (m *HeadsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeadsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeadsReply.DiscardUnknown(m)
 }
 
-func (m *AddReplicatorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Addr) > 0 {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0x12
+var xxx_messageInfo_HeadsReply proto.InternalMessageInfo
+
+func (m *HeadsReply) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *HeadsReply) GetEdge() uint64 {
+	if m != nil {
+		return m.Edge
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *AddReplicatorReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *HeadsReply) GetHeads() []*LogHead {
+	if m != nil {
+		return m.Heads
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AddReplicatorReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PublishPresenceRequest struct {
+	ThreadID    []byte         `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Status      PresenceStatus `protobuf:"varint,2,opt,name=status,proto3,enum=threads.net.pb.PresenceStatus" json:"status,omitempty"`
+	Payload     []byte         `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Timestamp   int64          `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IdentitySig []byte         `protobuf:"bytes,5,opt,name=identitySig,proto3" json:"identitySig,omitempty"`
 }
 
-func (m *AddReplicatorReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.PeerID) > 0 {
-		i -= len(m.PeerID)
-		copy(dAtA[i:], m.PeerID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PeerID)))
-		i--
-		dAtA[i] = 0xa
+func (m *PublishPresenceRequest) Reset()         { *m = PublishPresenceRequest{} }
+func (m *PublishPresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishPresenceRequest) ProtoMessage()    {}
+func (*PublishPresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{45}
+}
+func (m *PublishPresenceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublishPresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublishPresenceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PublishPresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishPresenceRequest.Merge(m, src)
+}
+func (m *PublishPresenceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublishPresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishPresenceRequest.DiscardUnknown(m)
 }
 
-func (m *CreateRecordRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PublishPresenceRequest proto.InternalMessageInfo
+
+func (m *PublishPresenceRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CreateRecordRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PublishPresenceRequest) GetStatus() PresenceStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PresenceStatus_OFFLINE
 }
 
-func (m *CreateRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Body) > 0 {
-		i -= len(m.Body)
-		copy(dAtA[i:], m.Body)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Body)))
-		i--
-		dAtA[i] = 0x12
+func (m *PublishPresenceRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *PublishPresenceRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *NewRecordReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PublishPresenceRequest) GetIdentitySig() []byte {
+	if m != nil {
+		return m.IdentitySig
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *NewRecordReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PublishPresenceReply struct {
 }
 
-func (m *NewRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Record != nil {
-		{
-			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+func (m *PublishPresenceReply) Reset()         { *m = PublishPresenceReply{} }
+func (m *PublishPresenceReply) String() string { return proto.CompactTextString(m) }
+func (*PublishPresenceReply) ProtoMessage()    {}
+func (*PublishPresenceReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{46}
+}
+func (m *PublishPresenceReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PublishPresenceReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PublishPresenceReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.LogID) > 0 {
-		i -= len(m.LogID)
-		copy(dAtA[i:], m.LogID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *AddRecordRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *PublishPresenceReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishPresenceReply.Merge(m, src)
+}
+func (m *PublishPresenceReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *PublishPresenceReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishPresenceReply.DiscardUnknown(m)
 }
 
-func (m *AddRecordRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_PublishPresenceReply proto.InternalMessageInfo
+
+type SubscribePresenceRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
 }
 
-func (m *AddRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Record != nil {
-		{
-			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+func (m *SubscribePresenceRequest) Reset()         { *m = SubscribePresenceRequest{} }
+func (m *SubscribePresenceRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribePresenceRequest) ProtoMessage()    {}
+func (*SubscribePresenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{47}
+}
+func (m *SubscribePresenceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribePresenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribePresenceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.LogID) > 0 {
-		i -= len(m.LogID)
-		copy(dAtA[i:], m.LogID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SubscribePresenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribePresenceRequest.Merge(m, src)
+}
+func (m *SubscribePresenceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribePresenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribePresenceRequest.DiscardUnknown(m)
 }
 
-func (m *Record) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SubscribePresenceRequest proto.InternalMessageInfo
+
+func (m *SubscribePresenceRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Record) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PresenceReply struct {
+	ThreadID []byte         `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	PeerID   []byte         `protobuf:"bytes,2,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Identity []byte         `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	Status   PresenceStatus `protobuf:"varint,4,opt,name=status,proto3,enum=threads.net.pb.PresenceStatus" json:"status,omitempty"`
+	Payload  []byte         `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Time     int64          `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
 }
 
-func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.BodyNode) > 0 {
-		i -= len(m.BodyNode)
-		copy(dAtA[i:], m.BodyNode)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.BodyNode)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.HeaderNode) > 0 {
-		i -= len(m.HeaderNode)
-		copy(dAtA[i:], m.HeaderNode)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.HeaderNode)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.EventNode) > 0 {
-		i -= len(m.EventNode)
-		copy(dAtA[i:], m.EventNode)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.EventNode)))
-		i--
-		dAtA[i] = 0x12
+func (m *PresenceReply) Reset()         { *m = PresenceReply{} }
+func (m *PresenceReply) String() string { return proto.CompactTextString(m) }
+func (*PresenceReply) ProtoMessage()    {}
+func (*PresenceReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{48}
+}
+func (m *PresenceReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PresenceReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PresenceReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.RecordNode) > 0 {
-		i -= len(m.RecordNode)
-		copy(dAtA[i:], m.RecordNode)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordNode)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *PresenceReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PresenceReply.Merge(m, src)
+}
+func (m *PresenceReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *PresenceReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PresenceReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PresenceReply proto.InternalMessageInfo
+
+func (m *PresenceReply) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AddRecordReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PresenceReply) GetPeerID() []byte {
+	if m != nil {
+		return m.PeerID
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AddRecordReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PresenceReply) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
 }
 
-func (m *AddRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *PresenceReply) GetStatus() PresenceStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PresenceStatus_OFFLINE
 }
 
-func (m *GetRecordRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PresenceReply) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetRecordRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PresenceReply) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
 }
 
-func (m *GetRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.RecordID) > 0 {
-		i -= len(m.RecordID)
-		copy(dAtA[i:], m.RecordID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
-		i--
-		dAtA[i] = 0x12
+type APIKey struct {
+	Key       string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Admin     bool     `protobuf:"varint,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	ReadOnly  bool     `protobuf:"varint,3,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	ThreadIDs [][]byte `protobuf:"bytes,4,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	CreatedAt int64    `protobuf:"varint,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (m *APIKey) Reset()         { *m = APIKey{} }
+func (m *APIKey) String() string { return proto.CompactTextString(m) }
+func (*APIKey) ProtoMessage()    {}
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{49}
+}
+func (m *APIKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *APIKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_APIKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.ThreadID) > 0 {
-		i -= len(m.ThreadID)
-		copy(dAtA[i:], m.ThreadID)
-		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *APIKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIKey.Merge(m, src)
+}
+func (m *APIKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *APIKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIKey proto.InternalMessageInfo
+
+func (m *APIKey) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *GetRecordReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *APIKey) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *GetRecordReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *APIKey) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
 }
 
-func (m *GetRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Record != nil {
-		{
-			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *APIKey) GetThreadIDs() [][]byte {
+	if m != nil {
+		return m.ThreadIDs
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *APIKey) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type CreateAPIKeyRequest struct {
+	Admin     bool     `protobuf:"varint,1,opt,name=admin,proto3" json:"admin,omitempty"`
+	ReadOnly  bool     `protobuf:"varint,2,opt,name=readOnly,proto3" json:"readOnly,omitempty"`
+	ThreadIDs [][]byte `protobuf:"bytes,3,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
 }
 
-func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ThreadIDs) > 0 {
-		for iNdEx := len(m.ThreadIDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.ThreadIDs[iNdEx])
-			copy(dAtA[i:], m.ThreadIDs[iNdEx])
-			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadIDs[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (m *CreateAPIKeyRequest) Reset()         { *m = CreateAPIKeyRequest{} }
+func (m *CreateAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAPIKeyRequest) ProtoMessage()    {}
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{50}
+}
+func (m *CreateAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateAPIKeyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *CreateAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAPIKeyRequest.Merge(m, src)
+}
+func (m *CreateAPIKeyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAPIKeyRequest.DiscardUnknown(m)
 }
 
-func encodeVarintThreadsnet(dAtA []byte, offset int, v uint64) int {
-	offset -= sovThreadsnet(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_CreateAPIKeyRequest proto.InternalMessageInfo
+
+func (m *CreateAPIKeyRequest) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return false
 }
-func (m *GetHostIDRequest) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *CreateAPIKeyRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
 	}
-	var l int
-	_ = l
-	return n
+	return false
 }
 
-func (m *GetHostIDReply) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PeerID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *CreateAPIKeyRequest) GetThreadIDs() [][]byte {
+	if m != nil {
+		return m.ThreadIDs
 	}
-	return n
+	return nil
 }
 
-func (m *GetTokenRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Payload != nil {
-		n += m.Payload.Size()
-	}
-	return n
+type CreateAPIKeyReply struct {
+	Key    *APIKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Secret string  `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
 }
 
-func (m *GetTokenRequest_Key) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	n += 1 + l + sovThreadsnet(uint64(l))
-	return n
+func (m *CreateAPIKeyReply) Reset()         { *m = CreateAPIKeyReply{} }
+func (m *CreateAPIKeyReply) String() string { return proto.CompactTextString(m) }
+func (*CreateAPIKeyReply) ProtoMessage()    {}
+func (*CreateAPIKeyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{51}
 }
-func (m *GetTokenRequest_Signature) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Signature != nil {
-		l = len(m.Signature)
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	return n
+func (m *CreateAPIKeyReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (m *GetTokenReply) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Payload != nil {
-		n += m.Payload.Size()
+func (m *CreateAPIKeyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateAPIKeyReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *CreateAPIKeyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAPIKeyReply.Merge(m, src)
+}
+func (m *CreateAPIKeyReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateAPIKeyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAPIKeyReply.DiscardUnknown(m)
 }
 
-func (m *GetTokenReply_Challenge) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Challenge != nil {
-		l = len(m.Challenge)
-		n += 1 + l + sovThreadsnet(uint64(l))
+var xxx_messageInfo_CreateAPIKeyReply proto.InternalMessageInfo
+
+func (m *CreateAPIKeyReply) GetKey() *APIKey {
+	if m != nil {
+		return m.Key
 	}
-	return n
+	return nil
 }
-func (m *GetTokenReply_Token) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *CreateAPIKeyReply) GetSecret() string {
+	if m != nil {
+		return m.Secret
 	}
-	var l int
-	_ = l
-	l = len(m.Token)
-	n += 1 + l + sovThreadsnet(uint64(l))
-	return n
+	return ""
 }
-func (m *CreateThreadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	if m.Keys != nil {
-		l = m.Keys.Size()
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	return n
+
+type ListAPIKeysRequest struct {
 }
 
-func (m *Keys) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadKey)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *ListAPIKeysRequest) Reset()         { *m = ListAPIKeysRequest{} }
+func (m *ListAPIKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAPIKeysRequest) ProtoMessage()    {}
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{52}
+}
+func (m *ListAPIKeysRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAPIKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAPIKeysRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.LogKey)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+}
+func (m *ListAPIKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAPIKeysRequest.Merge(m, src)
+}
+func (m *ListAPIKeysRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAPIKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAPIKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAPIKeysRequest proto.InternalMessageInfo
+
+type ListAPIKeysReply struct {
+	Keys []*APIKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (m *ListAPIKeysReply) Reset()         { *m = ListAPIKeysReply{} }
+func (m *ListAPIKeysReply) String() string { return proto.CompactTextString(m) }
+func (*ListAPIKeysReply) ProtoMessage()    {}
+func (*ListAPIKeysReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{53}
+}
+func (m *ListAPIKeysReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListAPIKeysReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListAPIKeysReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ListAPIKeysReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAPIKeysReply.Merge(m, src)
+}
+func (m *ListAPIKeysReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListAPIKeysReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAPIKeysReply.DiscardUnknown(m)
 }
 
-func (m *ThreadInfoReply) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ListAPIKeysReply proto.InternalMessageInfo
+
+func (m *ListAPIKeysReply) GetKeys() []*APIKey {
+	if m != nil {
+		return m.Keys
 	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *RevokeAPIKeyRequest) Reset()         { *m = RevokeAPIKeyRequest{} }
+func (m *RevokeAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeAPIKeyRequest) ProtoMessage()    {}
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{54}
+}
+func (m *RevokeAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeAPIKeyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.ThreadKey)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+}
+func (m *RevokeAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeAPIKeyRequest.Merge(m, src)
+}
+func (m *RevokeAPIKeyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeAPIKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeAPIKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeAPIKeyRequest) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	if len(m.Logs) > 0 {
-		for _, e := range m.Logs {
-			l = e.Size()
-			n += 1 + l + sovThreadsnet(uint64(l))
+	return ""
+}
+
+type RevokeAPIKeyReply struct {
+}
+
+func (m *RevokeAPIKeyReply) Reset()         { *m = RevokeAPIKeyReply{} }
+func (m *RevokeAPIKeyReply) String() string { return proto.CompactTextString(m) }
+func (*RevokeAPIKeyReply) ProtoMessage()    {}
+func (*RevokeAPIKeyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{55}
+}
+func (m *RevokeAPIKeyReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeAPIKeyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeAPIKeyReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovThreadsnet(uint64(l))
+}
+func (m *RevokeAPIKeyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeAPIKeyReply.Merge(m, src)
+}
+func (m *RevokeAPIKeyReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeAPIKeyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeAPIKeyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeAPIKeyReply proto.InternalMessageInfo
+
+type ExportAuditLogRequest struct {
+	Since int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until int64 `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+}
+
+func (m *ExportAuditLogRequest) Reset()         { *m = ExportAuditLogRequest{} }
+func (m *ExportAuditLogRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportAuditLogRequest) ProtoMessage()    {}
+func (*ExportAuditLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{56}
+}
+func (m *ExportAuditLogRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportAuditLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportAuditLogRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ExportAuditLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportAuditLogRequest.Merge(m, src)
+}
+func (m *ExportAuditLogRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportAuditLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportAuditLogRequest.DiscardUnknown(m)
 }
 
-func (m *LogInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.PubKey)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+var xxx_messageInfo_ExportAuditLogRequest proto.InternalMessageInfo
+
+func (m *ExportAuditLogRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
 	}
-	l = len(m.PrivKey)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+	return 0
+}
+
+func (m *ExportAuditLogRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovThreadsnet(uint64(l))
+	return 0
+}
+
+type AuditEntry struct {
+	Time      int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Operation string `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Peer      string `protobuf:"bytes,4,opt,name=peer,proto3" json:"peer,omitempty"`
+	ApiKey    string `protobuf:"bytes,5,opt,name=apiKey,proto3" json:"apiKey,omitempty"`
+	ThreadID  string `protobuf:"bytes,6,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID     string `protobuf:"bytes,7,opt,name=logID,proto3" json:"logID,omitempty"`
+	RecordID  string `protobuf:"bytes,8,opt,name=recordID,proto3" json:"recordID,omitempty"`
+	Outcome   string `protobuf:"bytes,9,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Error     string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{57}
+}
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	l = len(m.Head)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.Counter)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	return n
+}
+func (m *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(m, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
 }
 
-func (m *AddThreadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Addr)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	if m.Keys != nil {
-		l = m.Keys.Size()
-		n += 1 + l + sovThreadsnet(uint64(l))
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetTime() int64 {
+	if m != nil {
+		return m.Time
 	}
-	return n
+	return 0
 }
 
-func (m *GetThreadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetKind() string {
+	if m != nil {
+		return m.Kind
 	}
-	return n
+	return ""
 }
 
-func (m *PullThreadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetOperation() string {
+	if m != nil {
+		return m.Operation
 	}
-	return n
+	return ""
 }
 
-func (m *PullThreadReply) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AuditEntry) GetPeer() string {
+	if m != nil {
+		return m.Peer
 	}
-	var l int
-	_ = l
-	return n
+	return ""
 }
 
-func (m *DeleteThreadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetApiKey() string {
+	if m != nil {
+		return m.ApiKey
 	}
-	return n
+	return ""
 }
 
-func (m *DeleteThreadReply) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AuditEntry) GetThreadID() string {
+	if m != nil {
+		return m.ThreadID
 	}
-	var l int
-	_ = l
-	return n
+	return ""
 }
 
-func (m *AddReplicatorRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.Addr)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetLogID() string {
+	if m != nil {
+		return m.LogID
 	}
-	return n
+	return ""
 }
 
-func (m *AddReplicatorReply) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PeerID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetRecordID() string {
+	if m != nil {
+		return m.RecordID
 	}
-	return n
+	return ""
 }
 
-func (m *CreateRecordRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.Body)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetOutcome() string {
+	if m != nil {
+		return m.Outcome
 	}
-	return n
+	return ""
 }
 
-func (m *NewRecordReply) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.LogID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	if m.Record != nil {
-		l = m.Record.Size()
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *AuditEntry) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	return n
+	return ""
 }
 
-func (m *AddRecordRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.LogID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	if m.Record != nil {
-		l = m.Record.Size()
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	return n
+type Faults struct {
+	DropPushRecord     uint32 `protobuf:"varint,1,opt,name=dropPushRecord,proto3" json:"dropPushRecord,omitempty"`
+	DelayExchangeEdges int64  `protobuf:"varint,2,opt,name=delayExchangeEdges,proto3" json:"delayExchangeEdges,omitempty"`
+	CorruptRecordBody  uint32 `protobuf:"varint,3,opt,name=corruptRecordBody,proto3" json:"corruptRecordBody,omitempty"`
 }
 
-func (m *Record) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.RecordNode)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.EventNode)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.HeaderNode)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
-	}
-	l = len(m.BodyNode)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *Faults) Reset()         { *m = Faults{} }
+func (m *Faults) String() string { return proto.CompactTextString(m) }
+func (*Faults) ProtoMessage()    {}
+func (*Faults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{58}
+}
+func (m *Faults) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Faults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Faults.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Faults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Faults.Merge(m, src)
+}
+func (m *Faults) XXX_Size() int {
+	return m.Size()
+}
+func (m *Faults) XXX_DiscardUnknown() {
+	xxx_messageInfo_Faults.DiscardUnknown(m)
 }
 
-func (m *AddRecordReply) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_Faults proto.InternalMessageInfo
+
+func (m *Faults) GetDropPushRecord() uint32 {
+	if m != nil {
+		return m.DropPushRecord
 	}
-	var l int
-	_ = l
-	return n
+	return 0
 }
 
-func (m *GetRecordRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ThreadID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+func (m *Faults) GetDelayExchangeEdges() int64 {
+	if m != nil {
+		return m.DelayExchangeEdges
 	}
-	l = len(m.RecordID)
-	if l > 0 {
-		n += 1 + l + sovThreadsnet(uint64(l))
+	return 0
+}
+
+func (m *Faults) GetCorruptRecordBody() uint32 {
+	if m != nil {
+		return m.CorruptRecordBody
 	}
-	return n
+	return 0
 }
 
-func (m *GetRecordReply) Size() (n int) {
-	if m == nil {
-		return 0
+type SetFaultsRequest struct {
+	Faults *Faults `protobuf:"bytes,1,opt,name=faults,proto3" json:"faults,omitempty"`
+}
+
+func (m *SetFaultsRequest) Reset()         { *m = SetFaultsRequest{} }
+func (m *SetFaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetFaultsRequest) ProtoMessage()    {}
+func (*SetFaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{59}
+}
+func (m *SetFaultsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetFaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetFaultsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.Record != nil {
-		l = m.Record.Size()
-		n += 1 + l + sovThreadsnet(uint64(l))
+}
+func (m *SetFaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFaultsRequest.Merge(m, src)
+}
+func (m *SetFaultsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetFaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFaultsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFaultsRequest proto.InternalMessageInfo
+
+func (m *SetFaultsRequest) GetFaults() *Faults {
+	if m != nil {
+		return m.Faults
 	}
-	return n
+	return nil
 }
 
-func (m *SubscribeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type SetFaultsReply struct {
+}
+
+func (m *SetFaultsReply) Reset()         { *m = SetFaultsReply{} }
+func (m *SetFaultsReply) String() string { return proto.CompactTextString(m) }
+func (*SetFaultsReply) ProtoMessage()    {}
+func (*SetFaultsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{60}
+}
+func (m *SetFaultsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetFaultsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetFaultsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if len(m.ThreadIDs) > 0 {
-		for _, b := range m.ThreadIDs {
-			l = len(b)
-			n += 1 + l + sovThreadsnet(uint64(l))
+}
+func (m *SetFaultsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFaultsReply.Merge(m, src)
+}
+func (m *SetFaultsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetFaultsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFaultsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFaultsReply proto.InternalMessageInfo
+
+type GetFaultsRequest struct {
+}
+
+func (m *GetFaultsRequest) Reset()         { *m = GetFaultsRequest{} }
+func (m *GetFaultsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFaultsRequest) ProtoMessage()    {}
+func (*GetFaultsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{61}
+}
+func (m *GetFaultsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetFaultsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetFaultsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *GetFaultsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFaultsRequest.Merge(m, src)
+}
+func (m *GetFaultsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetFaultsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFaultsRequest.DiscardUnknown(m)
 }
 
-func sovThreadsnet(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+var xxx_messageInfo_GetFaultsRequest proto.InternalMessageInfo
+
+type GetFaultsReply struct {
+	Enabled bool    `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Faults  *Faults `protobuf:"bytes,2,opt,name=faults,proto3" json:"faults,omitempty"`
 }
-func sozThreadsnet(x uint64) (n int) {
-	return sovThreadsnet(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *GetFaultsReply) Reset()         { *m = GetFaultsReply{} }
+func (m *GetFaultsReply) String() string { return proto.CompactTextString(m) }
+func (*GetFaultsReply) ProtoMessage()    {}
+func (*GetFaultsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{62}
 }
-func (m *GetHostIDRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowThreadsnet
+func (m *GetFaultsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetFaultsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetFaultsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetFaultsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFaultsReply.Merge(m, src)
+}
+func (m *GetFaultsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetFaultsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFaultsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFaultsReply proto.InternalMessageInfo
+
+func (m *GetFaultsReply) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *GetFaultsReply) GetFaults() *Faults {
+	if m != nil {
+		return m.Faults
+	}
+	return nil
+}
+
+type GetThreadSnapshotRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+}
+
+func (m *GetThreadSnapshotRequest) Reset()         { *m = GetThreadSnapshotRequest{} }
+func (m *GetThreadSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*GetThreadSnapshotRequest) ProtoMessage()    {}
+func (*GetThreadSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{63}
+}
+func (m *GetThreadSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetThreadSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetThreadSnapshotRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetThreadSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThreadSnapshotRequest.Merge(m, src)
+}
+func (m *GetThreadSnapshotRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetThreadSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThreadSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetThreadSnapshotRequest proto.InternalMessageInfo
+
+func (m *GetThreadSnapshotRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+type GetThreadSnapshotReply struct {
+	// snapshot is the JSON encoded sync state of the thread.
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (m *GetThreadSnapshotReply) Reset()         { *m = GetThreadSnapshotReply{} }
+func (m *GetThreadSnapshotReply) String() string { return proto.CompactTextString(m) }
+func (*GetThreadSnapshotReply) ProtoMessage()    {}
+func (*GetThreadSnapshotReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{64}
+}
+func (m *GetThreadSnapshotReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetThreadSnapshotReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetThreadSnapshotReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetThreadSnapshotReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetThreadSnapshotReply.Merge(m, src)
+}
+func (m *GetThreadSnapshotReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetThreadSnapshotReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetThreadSnapshotReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetThreadSnapshotReply proto.InternalMessageInfo
+
+func (m *GetThreadSnapshotReply) GetSnapshot() []byte {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+type UpdateLogAddrsRequest struct {
+	ThreadID []byte   `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte   `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Addrs    [][]byte `protobuf:"bytes,3,rep,name=addrs,proto3" json:"addrs,omitempty"`
+}
+
+func (m *UpdateLogAddrsRequest) Reset()         { *m = UpdateLogAddrsRequest{} }
+func (m *UpdateLogAddrsRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateLogAddrsRequest) ProtoMessage()    {}
+func (*UpdateLogAddrsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{65}
+}
+func (m *UpdateLogAddrsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateLogAddrsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateLogAddrsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateLogAddrsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateLogAddrsRequest.Merge(m, src)
+}
+func (m *UpdateLogAddrsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateLogAddrsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateLogAddrsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateLogAddrsRequest proto.InternalMessageInfo
+
+func (m *UpdateLogAddrsRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *UpdateLogAddrsRequest) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *UpdateLogAddrsRequest) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+type UpdateLogAddrsReply struct {
+}
+
+func (m *UpdateLogAddrsReply) Reset()         { *m = UpdateLogAddrsReply{} }
+func (m *UpdateLogAddrsReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateLogAddrsReply) ProtoMessage()    {}
+func (*UpdateLogAddrsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{66}
+}
+func (m *UpdateLogAddrsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateLogAddrsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateLogAddrsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateLogAddrsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateLogAddrsReply.Merge(m, src)
+}
+func (m *UpdateLogAddrsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateLogAddrsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateLogAddrsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateLogAddrsReply proto.InternalMessageInfo
+
+type SubscribeSyncProgressRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+}
+
+func (m *SubscribeSyncProgressRequest) Reset()         { *m = SubscribeSyncProgressRequest{} }
+func (m *SubscribeSyncProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeSyncProgressRequest) ProtoMessage()    {}
+func (*SubscribeSyncProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{67}
+}
+func (m *SubscribeSyncProgressRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeSyncProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeSyncProgressRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeSyncProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeSyncProgressRequest.Merge(m, src)
+}
+func (m *SubscribeSyncProgressRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeSyncProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeSyncProgressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeSyncProgressRequest proto.InternalMessageInfo
+
+func (m *SubscribeSyncProgressRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+type SyncProgressReply struct {
+	ThreadID []byte                               `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Records  int64                                `protobuf:"varint,2,opt,name=records,proto3" json:"records,omitempty"`
+	Expected int64                                `protobuf:"varint,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Logs     []*SyncProgressReply_LogSyncProgress `protobuf:"bytes,4,rep,name=logs,proto3" json:"logs,omitempty"`
+	Bytes    int64                                `protobuf:"varint,5,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Eta      int64                                `protobuf:"varint,6,opt,name=eta,proto3" json:"eta,omitempty"`
+}
+
+func (m *SyncProgressReply) Reset()         { *m = SyncProgressReply{} }
+func (m *SyncProgressReply) String() string { return proto.CompactTextString(m) }
+func (*SyncProgressReply) ProtoMessage()    {}
+func (*SyncProgressReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{68}
+}
+func (m *SyncProgressReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SyncProgressReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SyncProgressReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SyncProgressReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncProgressReply.Merge(m, src)
+}
+func (m *SyncProgressReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *SyncProgressReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncProgressReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncProgressReply proto.InternalMessageInfo
+
+func (m *SyncProgressReply) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *SyncProgressReply) GetRecords() int64 {
+	if m != nil {
+		return m.Records
+	}
+	return 0
+}
+
+func (m *SyncProgressReply) GetExpected() int64 {
+	if m != nil {
+		return m.Expected
+	}
+	return 0
+}
+
+func (m *SyncProgressReply) GetLogs() []*SyncProgressReply_LogSyncProgress {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *SyncProgressReply) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *SyncProgressReply) GetEta() int64 {
+	if m != nil {
+		return m.Eta
+	}
+	return 0
+}
+
+type SyncProgressReply_LogSyncProgress struct {
+	LogID   []byte `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	Applied int64  `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	Height  int64  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *SyncProgressReply_LogSyncProgress) Reset()         { *m = SyncProgressReply_LogSyncProgress{} }
+func (m *SyncProgressReply_LogSyncProgress) String() string { return proto.CompactTextString(m) }
+func (*SyncProgressReply_LogSyncProgress) ProtoMessage()    {}
+func (*SyncProgressReply_LogSyncProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{68, 0}
+}
+func (m *SyncProgressReply_LogSyncProgress) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SyncProgressReply_LogSyncProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SyncProgressReply_LogSyncProgress.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SyncProgressReply_LogSyncProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncProgressReply_LogSyncProgress.Merge(m, src)
+}
+func (m *SyncProgressReply_LogSyncProgress) XXX_Size() int {
+	return m.Size()
+}
+func (m *SyncProgressReply_LogSyncProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncProgressReply_LogSyncProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncProgressReply_LogSyncProgress proto.InternalMessageInfo
+
+func (m *SyncProgressReply_LogSyncProgress) GetLogID() []byte {
+	if m != nil {
+		return m.LogID
+	}
+	return nil
+}
+
+func (m *SyncProgressReply_LogSyncProgress) GetApplied() int64 {
+	if m != nil {
+		return m.Applied
+	}
+	return 0
+}
+
+func (m *SyncProgressReply_LogSyncProgress) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type RecordExtension struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *RecordExtension) Reset()         { *m = RecordExtension{} }
+func (m *RecordExtension) String() string { return proto.CompactTextString(m) }
+func (*RecordExtension) ProtoMessage()    {}
+func (*RecordExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{69}
+}
+func (m *RecordExtension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordExtension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecordExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordExtension.Merge(m, src)
+}
+func (m *RecordExtension) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordExtension proto.InternalMessageInfo
+
+func (m *RecordExtension) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RecordExtension) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type AccessList struct {
+	AllowPeers [][]byte `protobuf:"bytes,1,rep,name=allowPeers,proto3" json:"allowPeers,omitempty"`
+	AllowNets  []string `protobuf:"bytes,2,rep,name=allowNets,proto3" json:"allowNets,omitempty"`
+	DenyPeers  [][]byte `protobuf:"bytes,3,rep,name=denyPeers,proto3" json:"denyPeers,omitempty"`
+	DenyNets   []string `protobuf:"bytes,4,rep,name=denyNets,proto3" json:"denyNets,omitempty"`
+}
+
+func (m *AccessList) Reset()         { *m = AccessList{} }
+func (m *AccessList) String() string { return proto.CompactTextString(m) }
+func (*AccessList) ProtoMessage()    {}
+func (*AccessList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{70}
+}
+func (m *AccessList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccessList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccessList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AccessList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessList.Merge(m, src)
+}
+func (m *AccessList) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccessList) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessList proto.InternalMessageInfo
+
+func (m *AccessList) GetAllowPeers() [][]byte {
+	if m != nil {
+		return m.AllowPeers
+	}
+	return nil
+}
+
+func (m *AccessList) GetAllowNets() []string {
+	if m != nil {
+		return m.AllowNets
+	}
+	return nil
+}
+
+func (m *AccessList) GetDenyPeers() [][]byte {
+	if m != nil {
+		return m.DenyPeers
+	}
+	return nil
+}
+
+func (m *AccessList) GetDenyNets() []string {
+	if m != nil {
+		return m.DenyNets
+	}
+	return nil
+}
+
+type SetAccessListRequest struct {
+	AccessList *AccessList `protobuf:"bytes,1,opt,name=accessList,proto3" json:"accessList,omitempty"`
+}
+
+func (m *SetAccessListRequest) Reset()         { *m = SetAccessListRequest{} }
+func (m *SetAccessListRequest) String() string { return proto.CompactTextString(m) }
+func (*SetAccessListRequest) ProtoMessage()    {}
+func (*SetAccessListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{71}
+}
+func (m *SetAccessListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetAccessListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetAccessListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetAccessListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAccessListRequest.Merge(m, src)
+}
+func (m *SetAccessListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetAccessListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAccessListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAccessListRequest proto.InternalMessageInfo
+
+func (m *SetAccessListRequest) GetAccessList() *AccessList {
+	if m != nil {
+		return m.AccessList
+	}
+	return nil
+}
+
+type SetAccessListReply struct {
+}
+
+func (m *SetAccessListReply) Reset()         { *m = SetAccessListReply{} }
+func (m *SetAccessListReply) String() string { return proto.CompactTextString(m) }
+func (*SetAccessListReply) ProtoMessage()    {}
+func (*SetAccessListReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{72}
+}
+func (m *SetAccessListReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetAccessListReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetAccessListReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetAccessListReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAccessListReply.Merge(m, src)
+}
+func (m *SetAccessListReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetAccessListReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAccessListReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAccessListReply proto.InternalMessageInfo
+
+type GetAccessListRequest struct {
+}
+
+func (m *GetAccessListRequest) Reset()         { *m = GetAccessListRequest{} }
+func (m *GetAccessListRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccessListRequest) ProtoMessage()    {}
+func (*GetAccessListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{73}
+}
+func (m *GetAccessListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAccessListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAccessListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetAccessListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccessListRequest.Merge(m, src)
+}
+func (m *GetAccessListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAccessListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccessListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccessListRequest proto.InternalMessageInfo
+
+type GetAccessListReply struct {
+	AccessList *AccessList `protobuf:"bytes,1,opt,name=accessList,proto3" json:"accessList,omitempty"`
+}
+
+func (m *GetAccessListReply) Reset()         { *m = GetAccessListReply{} }
+func (m *GetAccessListReply) String() string { return proto.CompactTextString(m) }
+func (*GetAccessListReply) ProtoMessage()    {}
+func (*GetAccessListReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{74}
+}
+func (m *GetAccessListReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAccessListReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAccessListReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetAccessListReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccessListReply.Merge(m, src)
+}
+func (m *GetAccessListReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAccessListReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccessListReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccessListReply proto.InternalMessageInfo
+
+func (m *GetAccessListReply) GetAccessList() *AccessList {
+	if m != nil {
+		return m.AccessList
+	}
+	return nil
+}
+
+type ResumePoint struct {
+	ThreadID []byte     `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Heads    []*LogHead `protobuf:"bytes,2,rep,name=heads,proto3" json:"heads,omitempty"`
+}
+
+func (m *ResumePoint) Reset()         { *m = ResumePoint{} }
+func (m *ResumePoint) String() string { return proto.CompactTextString(m) }
+func (*ResumePoint) ProtoMessage()    {}
+func (*ResumePoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{75}
+}
+func (m *ResumePoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResumePoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResumePoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResumePoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumePoint.Merge(m, src)
+}
+func (m *ResumePoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResumePoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumePoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumePoint proto.InternalMessageInfo
+
+func (m *ResumePoint) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *ResumePoint) GetHeads() []*LogHead {
+	if m != nil {
+		return m.Heads
+	}
+	return nil
+}
+
+type ExportThreadDAGRequest struct {
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	// format is either "dot" or "graphml".
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// logIDs restrict the export to some logs, all logs are exported if empty.
+	LogIDs [][]byte `protobuf:"bytes,3,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
+	// minHeight and maxHeight bound the heights of exported records, they're unbounded if zero.
+	MinHeight int64 `protobuf:"varint,4,opt,name=minHeight,proto3" json:"minHeight,omitempty"`
+	MaxHeight int64 `protobuf:"varint,5,opt,name=maxHeight,proto3" json:"maxHeight,omitempty"`
+}
+
+func (m *ExportThreadDAGRequest) Reset()         { *m = ExportThreadDAGRequest{} }
+func (m *ExportThreadDAGRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportThreadDAGRequest) ProtoMessage()    {}
+func (*ExportThreadDAGRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{76}
+}
+func (m *ExportThreadDAGRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportThreadDAGRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportThreadDAGRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportThreadDAGRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportThreadDAGRequest.Merge(m, src)
+}
+func (m *ExportThreadDAGRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportThreadDAGRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportThreadDAGRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportThreadDAGRequest proto.InternalMessageInfo
+
+func (m *ExportThreadDAGRequest) GetThreadID() []byte {
+	if m != nil {
+		return m.ThreadID
+	}
+	return nil
+}
+
+func (m *ExportThreadDAGRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *ExportThreadDAGRequest) GetLogIDs() [][]byte {
+	if m != nil {
+		return m.LogIDs
+	}
+	return nil
+}
+
+func (m *ExportThreadDAGRequest) GetMinHeight() int64 {
+	if m != nil {
+		return m.MinHeight
+	}
+	return 0
+}
+
+func (m *ExportThreadDAGRequest) GetMaxHeight() int64 {
+	if m != nil {
+		return m.MaxHeight
+	}
+	return 0
+}
+
+type ExportThreadDAGReply struct {
+	Graph []byte `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+}
+
+func (m *ExportThreadDAGReply) Reset()         { *m = ExportThreadDAGReply{} }
+func (m *ExportThreadDAGReply) String() string { return proto.CompactTextString(m) }
+func (*ExportThreadDAGReply) ProtoMessage()    {}
+func (*ExportThreadDAGReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a395cd12426f651, []int{77}
+}
+func (m *ExportThreadDAGReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportThreadDAGReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportThreadDAGReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportThreadDAGReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportThreadDAGReply.Merge(m, src)
+}
+func (m *ExportThreadDAGReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportThreadDAGReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportThreadDAGReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportThreadDAGReply proto.InternalMessageInfo
+
+func (m *ExportThreadDAGReply) GetGraph() []byte {
+	if m != nil {
+		return m.Graph
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("threads.net.pb.SyncHealth", SyncHealth_name, SyncHealth_value)
+	proto.RegisterEnum("threads.net.pb.PresenceStatus", PresenceStatus_name, PresenceStatus_value)
+	proto.RegisterType((*GetHostIDRequest)(nil), "threads.net.pb.GetHostIDRequest")
+	proto.RegisterType((*GetHostIDReply)(nil), "threads.net.pb.GetHostIDReply")
+	proto.RegisterType((*GetTokenRequest)(nil), "threads.net.pb.GetTokenRequest")
+	proto.RegisterType((*GetTokenReply)(nil), "threads.net.pb.GetTokenReply")
+	proto.RegisterType((*CreateThreadRequest)(nil), "threads.net.pb.CreateThreadRequest")
+	proto.RegisterType((*Keys)(nil), "threads.net.pb.Keys")
+	proto.RegisterType((*ThreadInfoReply)(nil), "threads.net.pb.ThreadInfoReply")
+	proto.RegisterType((*LogInfo)(nil), "threads.net.pb.LogInfo")
+	proto.RegisterType((*AddThreadRequest)(nil), "threads.net.pb.AddThreadRequest")
+	proto.RegisterType((*GetThreadRequest)(nil), "threads.net.pb.GetThreadRequest")
+	proto.RegisterType((*ListThreadsRequest)(nil), "threads.net.pb.ListThreadsRequest")
+	proto.RegisterType((*ThreadSummary)(nil), "threads.net.pb.ThreadSummary")
+	proto.RegisterType((*ListThreadsReply)(nil), "threads.net.pb.ListThreadsReply")
+	proto.RegisterType((*GetThreadLogsRequest)(nil), "threads.net.pb.GetThreadLogsRequest")
+	proto.RegisterType((*GetThreadLogsReply)(nil), "threads.net.pb.GetThreadLogsReply")
+	proto.RegisterType((*ActivityFeedRequest)(nil), "threads.net.pb.ActivityFeedRequest")
+	proto.RegisterType((*RecordSummary)(nil), "threads.net.pb.RecordSummary")
+	proto.RegisterType((*ActivityFeedReply)(nil), "threads.net.pb.ActivityFeedReply")
+	proto.RegisterType((*Annotation)(nil), "threads.net.pb.Annotation")
+	proto.RegisterType((*RecordAnnotation)(nil), "threads.net.pb.RecordAnnotation")
+	proto.RegisterType((*AnnotateRecordRequest)(nil), "threads.net.pb.AnnotateRecordRequest")
+	proto.RegisterType((*AnnotateRecordReply)(nil), "threads.net.pb.AnnotateRecordReply")
+	proto.RegisterType((*GetAnnotationRequest)(nil), "threads.net.pb.GetAnnotationRequest")
+	proto.RegisterType((*GetAnnotationReply)(nil), "threads.net.pb.GetAnnotationReply")
+	proto.RegisterType((*QueryAnnotationsRequest)(nil), "threads.net.pb.QueryAnnotationsRequest")
+	proto.RegisterType((*QueryAnnotationsReply)(nil), "threads.net.pb.QueryAnnotationsReply")
+	proto.RegisterType((*PullThreadRequest)(nil), "threads.net.pb.PullThreadRequest")
+	proto.RegisterType((*PullThreadReply)(nil), "threads.net.pb.PullThreadReply")
+	proto.RegisterType((*PullThreadFromRequest)(nil), "threads.net.pb.PullThreadFromRequest")
+	proto.RegisterType((*PullThreadFromReply)(nil), "threads.net.pb.PullThreadFromReply")
+	proto.RegisterType((*DeleteThreadRequest)(nil), "threads.net.pb.DeleteThreadRequest")
+	proto.RegisterType((*DeleteThreadReply)(nil), "threads.net.pb.DeleteThreadReply")
+	proto.RegisterType((*AddReplicatorRequest)(nil), "threads.net.pb.AddReplicatorRequest")
+	proto.RegisterType((*AddReplicatorReply)(nil), "threads.net.pb.AddReplicatorReply")
+	proto.RegisterType((*CreateRecordRequest)(nil), "threads.net.pb.CreateRecordRequest")
+	proto.RegisterType((*NewRecordReply)(nil), "threads.net.pb.NewRecordReply")
+	proto.RegisterType((*AddRecordRequest)(nil), "threads.net.pb.AddRecordRequest")
+	proto.RegisterType((*Record)(nil), "threads.net.pb.Record")
+	proto.RegisterType((*AddRecordReply)(nil), "threads.net.pb.AddRecordReply")
+	proto.RegisterType((*GetRecordRequest)(nil), "threads.net.pb.GetRecordRequest")
+	proto.RegisterType((*GetRecordReply)(nil), "threads.net.pb.GetRecordReply")
+	proto.RegisterType((*SubscribeRequest)(nil), "threads.net.pb.SubscribeRequest")
+	proto.RegisterType((*SubscribeHeadsRequest)(nil), "threads.net.pb.SubscribeHeadsRequest")
+	proto.RegisterType((*LogHead)(nil), "threads.net.pb.LogHead")
+	proto.RegisterType((*HeadsReply)(nil), "threads.net.pb.HeadsReply")
+	proto.RegisterType((*PublishPresenceRequest)(nil), "threads.net.pb.PublishPresenceRequest")
+	proto.RegisterType((*PublishPresenceReply)(nil), "threads.net.pb.PublishPresenceReply")
+	proto.RegisterType((*SubscribePresenceRequest)(nil), "threads.net.pb.SubscribePresenceRequest")
+	proto.RegisterType((*PresenceReply)(nil), "threads.net.pb.PresenceReply")
+	proto.RegisterType((*APIKey)(nil), "threads.net.pb.APIKey")
+	proto.RegisterType((*CreateAPIKeyRequest)(nil), "threads.net.pb.CreateAPIKeyRequest")
+	proto.RegisterType((*CreateAPIKeyReply)(nil), "threads.net.pb.CreateAPIKeyReply")
+	proto.RegisterType((*ListAPIKeysRequest)(nil), "threads.net.pb.ListAPIKeysRequest")
+	proto.RegisterType((*ListAPIKeysReply)(nil), "threads.net.pb.ListAPIKeysReply")
+	proto.RegisterType((*RevokeAPIKeyRequest)(nil), "threads.net.pb.RevokeAPIKeyRequest")
+	proto.RegisterType((*RevokeAPIKeyReply)(nil), "threads.net.pb.RevokeAPIKeyReply")
+	proto.RegisterType((*ExportAuditLogRequest)(nil), "threads.net.pb.ExportAuditLogRequest")
+	proto.RegisterType((*AuditEntry)(nil), "threads.net.pb.AuditEntry")
+	proto.RegisterType((*Faults)(nil), "threads.net.pb.Faults")
+	proto.RegisterType((*SetFaultsRequest)(nil), "threads.net.pb.SetFaultsRequest")
+	proto.RegisterType((*SetFaultsReply)(nil), "threads.net.pb.SetFaultsReply")
+	proto.RegisterType((*GetFaultsRequest)(nil), "threads.net.pb.GetFaultsRequest")
+	proto.RegisterType((*GetFaultsReply)(nil), "threads.net.pb.GetFaultsReply")
+	proto.RegisterType((*GetThreadSnapshotRequest)(nil), "threads.net.pb.GetThreadSnapshotRequest")
+	proto.RegisterType((*GetThreadSnapshotReply)(nil), "threads.net.pb.GetThreadSnapshotReply")
+	proto.RegisterType((*UpdateLogAddrsRequest)(nil), "threads.net.pb.UpdateLogAddrsRequest")
+	proto.RegisterType((*UpdateLogAddrsReply)(nil), "threads.net.pb.UpdateLogAddrsReply")
+	proto.RegisterType((*SubscribeSyncProgressRequest)(nil), "threads.net.pb.SubscribeSyncProgressRequest")
+	proto.RegisterType((*SyncProgressReply)(nil), "threads.net.pb.SyncProgressReply")
+	proto.RegisterType((*SyncProgressReply_LogSyncProgress)(nil), "threads.net.pb.SyncProgressReply.LogSyncProgress")
+	proto.RegisterType((*RecordExtension)(nil), "threads.net.pb.RecordExtension")
+	proto.RegisterType((*AccessList)(nil), "threads.net.pb.AccessList")
+	proto.RegisterType((*SetAccessListRequest)(nil), "threads.net.pb.SetAccessListRequest")
+	proto.RegisterType((*SetAccessListReply)(nil), "threads.net.pb.SetAccessListReply")
+	proto.RegisterType((*GetAccessListRequest)(nil), "threads.net.pb.GetAccessListRequest")
+	proto.RegisterType((*GetAccessListReply)(nil), "threads.net.pb.GetAccessListReply")
+	proto.RegisterType((*ResumePoint)(nil), "threads.net.pb.ResumePoint")
+	proto.RegisterType((*ExportThreadDAGRequest)(nil), "threads.net.pb.ExportThreadDAGRequest")
+	proto.RegisterType((*ExportThreadDAGReply)(nil), "threads.net.pb.ExportThreadDAGReply")
+}
+
+func init() { proto.RegisterFile("threadsnet.proto", fileDescriptor_0a395cd12426f651) }
+
+var fileDescriptor_0a395cd12426f651 = []byte{
+	// 2974 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x1a, 0x5d, 0x6f, 0x24, 0x47,
+	0xd1, 0xb3, 0x5f, 0xf6, 0x96, 0x7d, 0xeb, 0x75, 0xfb, 0x23, 0xab, 0xc9, 0x65, 0xcf, 0xd7, 0x77,
+	0x49, 0xac, 0x70, 0x98, 0xc4, 0x41, 0x41, 0x44, 0x08, 0xb2, 0x8e, 0x7d, 0xb6, 0x89, 0xf1, 0x6d,
+	0x66, 0xef, 0x92, 0x1c, 0x11, 0x09, 0xe3, 0x9d, 0xbe, 0xdd, 0x91, 0x67, 0x67, 0x26, 0x33, 0xbd,
+	0x17, 0x2f, 0x12, 0x2f, 0x88, 0x07, 0x24, 0x24, 0x40, 0x42, 0xbc, 0x03, 0x7f, 0x80, 0x5f, 0x01,
+	0xe2, 0x31, 0x0f, 0x3c, 0xf0, 0x88, 0x92, 0x37, 0x7e, 0x01, 0x0f, 0x20, 0xa1, 0xfe, 0x98, 0x99,
+	0x9e, 0x8f, 0xfd, 0xb8, 0x24, 0x6f, 0x53, 0xd5, 0xd5, 0xd5, 0xd5, 0xd5, 0x55, 0xd5, 0x55, 0xd5,
+	0x03, 0x4d, 0x3a, 0x0c, 0x88, 0x69, 0x85, 0x2e, 0xa1, 0xfb, 0x7e, 0xe0, 0x51, 0x0f, 0x35, 0x24,
+	0x66, 0x9f, 0xa3, 0x2e, 0x31, 0x82, 0xe6, 0x09, 0xa1, 0xa7, 0x5e, 0x48, 0xcf, 0x8e, 0x0c, 0xf2,
+	0xc9, 0x98, 0x84, 0x14, 0xef, 0x41, 0x43, 0xc1, 0xf9, 0xce, 0x04, 0xed, 0x40, 0xcd, 0x27, 0x24,
+	0x38, 0x3b, 0x6a, 0x69, 0xbb, 0xda, 0xde, 0x9a, 0x21, 0x21, 0xdc, 0x85, 0xf5, 0x13, 0x42, 0x1f,
+	0x7a, 0x57, 0xc4, 0x95, 0x93, 0x11, 0x82, 0xf2, 0x15, 0x99, 0x70, 0xba, 0xfa, 0xe9, 0x92, 0xc1,
+	0x00, 0xd4, 0x86, 0x7a, 0x68, 0x0f, 0x5c, 0x93, 0x8e, 0x03, 0xd2, 0x2a, 0x31, 0x0e, 0xa7, 0x4b,
+	0x46, 0x82, 0x3a, 0xac, 0xc3, 0xb2, 0x6f, 0x4e, 0x1c, 0xcf, 0xb4, 0xb0, 0x01, 0x37, 0x12, 0x8e,
+	0x6c, 0xe9, 0x36, 0xd4, 0xfb, 0x43, 0xd3, 0x71, 0x88, 0x3b, 0x20, 0x2d, 0x2d, 0x9a, 0x1b, 0xa3,
+	0xd0, 0x0e, 0x54, 0x29, 0xa3, 0x6e, 0x95, 0xe4, 0x8a, 0x02, 0x54, 0x79, 0x7a, 0xb0, 0xf9, 0x76,
+	0x40, 0x4c, 0x4a, 0x1e, 0xf2, 0xbd, 0x47, 0x92, 0xea, 0xb0, 0x22, 0x94, 0x11, 0x6f, 0x2b, 0x86,
+	0xd1, 0x1e, 0x54, 0xae, 0xc8, 0x24, 0xe4, 0x4c, 0x57, 0x0f, 0xb6, 0xf6, 0xd3, 0x5a, 0xdb, 0x7f,
+	0x87, 0x4c, 0x42, 0x83, 0x53, 0x20, 0x04, 0x15, 0x6a, 0x0e, 0xc2, 0x56, 0x79, 0xb7, 0xbc, 0x57,
+	0x37, 0xf8, 0x37, 0xfe, 0x1e, 0x54, 0x18, 0x05, 0xba, 0x09, 0x75, 0x31, 0xf1, 0x1d, 0xa9, 0x91,
+	0x35, 0x23, 0x41, 0x30, 0xa5, 0x3a, 0xde, 0x80, 0x0d, 0x95, 0x84, 0x52, 0x05, 0x84, 0x7f, 0xa3,
+	0xc1, 0xba, 0x90, 0xf4, 0xcc, 0x7d, 0xe2, 0x09, 0x2d, 0xcc, 0x92, 0x35, 0xb5, 0x4a, 0x29, 0xbb,
+	0xca, 0x37, 0xa0, 0xe2, 0x78, 0x52, 0xbe, 0xd5, 0x83, 0xe7, 0xb2, 0x3b, 0x39, 0xf7, 0x06, 0x7c,
+	0x15, 0x4e, 0x84, 0xb6, 0xa0, 0x6a, 0x5a, 0x56, 0x10, 0xb6, 0x2a, 0xbb, 0xe5, 0xbd, 0x35, 0x43,
+	0x00, 0xf8, 0xb7, 0x1a, 0x2c, 0x4b, 0x3a, 0xd4, 0x80, 0x52, 0x2c, 0x42, 0xe9, 0xec, 0x88, 0x5b,
+	0xc6, 0xf8, 0x52, 0xd9, 0x84, 0x80, 0x50, 0x0b, 0x96, 0xfd, 0xc0, 0x7e, 0xca, 0x06, 0xca, 0x7c,
+	0x20, 0x02, 0x8b, 0xd7, 0x60, 0x6a, 0x1c, 0x12, 0xd3, 0x6a, 0x55, 0x39, 0x31, 0xff, 0x66, 0x3c,
+	0xfa, 0xde, 0xd8, 0xa5, 0x24, 0x68, 0xd5, 0x04, 0x0f, 0x09, 0x62, 0x0b, 0x9a, 0x1d, 0xcb, 0x4a,
+	0x1f, 0x27, 0x82, 0x0a, 0x63, 0x25, 0x65, 0xe3, 0xdf, 0x5f, 0xf1, 0x18, 0xf7, 0xb9, 0x6f, 0x2c,
+	0x6c, 0x34, 0xf8, 0x1f, 0x1a, 0xa0, 0x73, 0x3b, 0x94, 0x33, 0xc2, 0x68, 0xca, 0x4d, 0xa8, 0xfb,
+	0xe6, 0x80, 0x70, 0x9b, 0x16, 0x7e, 0x61, 0x24, 0x08, 0xa6, 0x0e, 0xc7, 0x1e, 0xd9, 0x94, 0xcb,
+	0x58, 0x35, 0x04, 0x80, 0x9a, 0x50, 0xa6, 0xe6, 0x80, 0xab, 0xae, 0x6e, 0xb0, 0x4f, 0xb4, 0x0b,
+	0xab, 0x66, 0x9f, 0xda, 0x4f, 0x49, 0xcf, 0x76, 0xfb, 0xa4, 0x55, 0xd9, 0xd5, 0xf6, 0xca, 0x86,
+	0x8a, 0x42, 0x18, 0xd6, 0x04, 0x78, 0x48, 0x9e, 0x78, 0x01, 0xe1, 0xaa, 0x2c, 0x1b, 0x29, 0x1c,
+	0x3a, 0x80, 0xda, 0x90, 0x98, 0x0e, 0x1d, 0x72, 0x8d, 0x36, 0x0e, 0xf4, 0xac, 0x4a, 0x7a, 0x13,
+	0xb7, 0x7f, 0xca, 0x29, 0x0c, 0x49, 0x89, 0xff, 0xa7, 0xc1, 0x0d, 0xb1, 0xa5, 0xde, 0x78, 0x34,
+	0x32, 0x83, 0xd9, 0xd6, 0x18, 0x29, 0xb2, 0x94, 0x28, 0x92, 0x49, 0xe6, 0x98, 0x21, 0xed, 0x30,
+	0x49, 0x6c, 0x2a, 0x2c, 0xa2, 0x6c, 0xa4, 0x70, 0x8c, 0x27, 0x83, 0xd9, 0xfa, 0x72, 0x73, 0x31,
+	0xac, 0x48, 0x5d, 0x5d, 0x54, 0x6a, 0xa6, 0xd7, 0x71, 0x68, 0x0e, 0x08, 0xdf, 0x68, 0xd9, 0x10,
+	0x00, 0xc3, 0x7e, 0x32, 0xf6, 0xa8, 0xd9, 0x5a, 0x16, 0x58, 0x0e, 0xb0, 0x13, 0xf2, 0x9e, 0x92,
+	0xe0, 0x5d, 0x3e, 0xb2, 0xb2, 0xab, 0xed, 0xad, 0x18, 0x09, 0x02, 0x7f, 0x02, 0xcd, 0xd4, 0xa9,
+	0x32, 0x7f, 0xfc, 0x0e, 0x2c, 0x4b, 0x11, 0x5a, 0x1a, 0x77, 0xac, 0x17, 0xb2, 0x22, 0xa5, 0x34,
+	0x66, 0x44, 0xd4, 0xe8, 0x2e, 0xdc, 0x70, 0xc9, 0x35, 0xed, 0xc6, 0x06, 0xc1, 0xc3, 0x96, 0x91,
+	0x46, 0xe2, 0x27, 0xb0, 0x15, 0x5b, 0xde, 0xb9, 0x37, 0x08, 0x17, 0x09, 0x59, 0x29, 0x33, 0x2b,
+	0x4d, 0x35, 0xb3, 0xb2, 0x62, 0x66, 0x78, 0x00, 0x28, 0xb3, 0x8e, 0xef, 0x24, 0x21, 0x43, 0x5b,
+	0x24, 0x64, 0x2c, 0xb6, 0xa1, 0x9f, 0xc0, 0x66, 0x74, 0xd2, 0xf7, 0x09, 0x59, 0x28, 0x04, 0x6f,
+	0x41, 0x35, 0xe4, 0xa6, 0x5e, 0x12, 0x47, 0xc5, 0x81, 0x29, 0xfb, 0xf8, 0x83, 0x06, 0x37, 0x0c,
+	0xd2, 0xf7, 0x02, 0xd5, 0x44, 0x03, 0x8e, 0x48, 0x38, 0x47, 0x30, 0xe7, 0xe1, 0x0d, 0xce, 0x8e,
+	0x64, 0xc8, 0x12, 0x00, 0x8b, 0x64, 0xe6, 0x98, 0x0e, 0xbd, 0x40, 0x06, 0x2c, 0x09, 0x71, 0x83,
+	0xb6, 0x47, 0x91, 0xc7, 0xf1, 0x6f, 0x86, 0x0b, 0xed, 0x9f, 0x45, 0x2e, 0xc6, 0xbf, 0x39, 0xdd,
+	0xc4, 0x17, 0xf6, 0xc6, 0x0c, 0x7f, 0xe2, 0x13, 0x7c, 0x0e, 0x1b, 0xe9, 0x6d, 0x4b, 0xdb, 0x11,
+	0xa2, 0x4c, 0xb5, 0x9d, 0xd4, 0x56, 0x8c, 0x88, 0x1a, 0x1b, 0x00, 0x1d, 0xd7, 0xf5, 0xa8, 0x49,
+	0x6d, 0xcf, 0x65, 0xeb, 0xb1, 0x49, 0x7c, 0x77, 0x2b, 0x46, 0x25, 0x90, 0x11, 0x33, 0xa4, 0x66,
+	0x10, 0x10, 0x8b, 0xef, 0x6d, 0xc5, 0x88, 0x40, 0x7e, 0xd9, 0x98, 0x97, 0xc4, 0x89, 0x22, 0x9c,
+	0x84, 0xf0, 0xaf, 0x34, 0x68, 0x8a, 0xe5, 0x14, 0xd6, 0xb3, 0x94, 0xf7, 0x26, 0x80, 0x19, 0x53,
+	0xca, 0xc0, 0x9a, 0xf3, 0xc7, 0x84, 0x97, 0xa1, 0x50, 0x33, 0x13, 0x1d, 0xfb, 0x96, 0x49, 0x89,
+	0xd5, 0xa1, 0x32, 0x08, 0x24, 0x08, 0xfc, 0x6b, 0x0d, 0xb6, 0xe5, 0x44, 0x22, 0x44, 0x5a, 0xc4,
+	0x4c, 0x54, 0x59, 0x4b, 0x33, 0x65, 0x2d, 0x3f, 0x8b, 0xac, 0x78, 0x1b, 0x36, 0xb3, 0xc2, 0xf8,
+	0xce, 0x04, 0x5f, 0x70, 0xcf, 0x54, 0xe6, 0x7c, 0x35, 0x11, 0xf1, 0x7b, 0x80, 0x32, 0xfc, 0x98,
+	0x89, 0xbc, 0x95, 0x12, 0x5c, 0xe3, 0x82, 0xef, 0x16, 0x5b, 0xc9, 0x14, 0xf1, 0x7f, 0x0e, 0xcf,
+	0xbd, 0x3b, 0x26, 0xc1, 0x24, 0x19, 0x5e, 0x28, 0x88, 0xec, 0x40, 0x6d, 0xec, 0xb2, 0x6f, 0x69,
+	0x3f, 0x12, 0x52, 0x0d, 0xab, 0x9c, 0x36, 0x2c, 0xe6, 0x4c, 0xcc, 0x94, 0xb8, 0x7f, 0xd4, 0x0d,
+	0x01, 0xe0, 0x0f, 0x61, 0x3b, 0xbf, 0x3c, 0xdb, 0xd9, 0x21, 0xac, 0x26, 0x52, 0x46, 0x0e, 0x30,
+	0x7f, 0x6b, 0xea, 0x24, 0xfc, 0x2d, 0xd8, 0xe8, 0x8e, 0x1d, 0x67, 0xf1, 0x8b, 0x79, 0x03, 0xd6,
+	0xd5, 0x09, 0xec, 0x1c, 0x4f, 0x60, 0x3b, 0x41, 0xdd, 0x0f, 0xbc, 0xd1, 0x22, 0xda, 0x89, 0x52,
+	0x8c, 0x52, 0x92, 0x62, 0x30, 0x3b, 0xc9, 0x32, 0x62, 0xfc, 0x5f, 0x83, 0xcd, 0x23, 0xe2, 0x90,
+	0x67, 0xc8, 0x39, 0xf1, 0x26, 0x6c, 0xa4, 0xa7, 0x30, 0x3e, 0xf7, 0x61, 0xab, 0x63, 0xf1, 0x6f,
+	0xbb, 0x6f, 0x52, 0x2f, 0xf8, 0xb2, 0x62, 0xde, 0x03, 0x94, 0xe1, 0x33, 0x2b, 0xaf, 0xff, 0xb7,
+	0x16, 0xa5, 0xcc, 0x8b, 0x3b, 0x22, 0x82, 0xca, 0xa5, 0x67, 0x45, 0x79, 0x20, 0xff, 0x46, 0x2f,
+	0x41, 0xc3, 0xb6, 0xc8, 0xc8, 0xf7, 0x28, 0x71, 0xfb, 0x93, 0x28, 0x19, 0xac, 0x1b, 0x19, 0x2c,
+	0x6a, 0x03, 0x08, 0x8f, 0x78, 0xc8, 0x22, 0xa8, 0xb0, 0x24, 0x05, 0xc3, 0xd6, 0xf5, 0x03, 0xdb,
+	0x0b, 0x58, 0xf2, 0x50, 0xe5, 0x81, 0x3f, 0x86, 0xd1, 0x0f, 0x00, 0xc8, 0x35, 0x25, 0x6e, 0xc8,
+	0x0d, 0xaa, 0xc6, 0x0d, 0xea, 0x56, 0xb1, 0x41, 0x1d, 0x47, 0x74, 0x86, 0x32, 0x05, 0xff, 0x49,
+	0x83, 0xc6, 0x05, 0xf9, 0x54, 0xf1, 0xf2, 0x79, 0xf7, 0x52, 0xc1, 0xed, 0xb1, 0x0f, 0x35, 0x21,
+	0xaf, 0x0c, 0x33, 0x3b, 0xc5, 0x12, 0x18, 0x92, 0x0a, 0x7d, 0x13, 0xaa, 0x7d, 0xc7, 0xeb, 0x5f,
+	0xb5, 0x2a, 0x53, 0x2f, 0xd9, 0x53, 0x66, 0x04, 0x82, 0x0a, 0x53, 0x9e, 0xf0, 0x2e, 0x7e, 0x18,
+	0x5f, 0x8b, 0x90, 0xf8, 0x17, 0x1a, 0xd4, 0x04, 0x2a, 0x39, 0xa1, 0x0b, 0xcf, 0x92, 0x75, 0x98,
+	0xa1, 0x60, 0x58, 0x68, 0x27, 0x4f, 0x89, 0x4b, 0xf9, 0xb0, 0x2c, 0x42, 0x62, 0x04, 0x9b, 0xcd,
+	0x32, 0x7a, 0x12, 0xf0, 0x61, 0x71, 0xbf, 0x2a, 0x18, 0xb6, 0x15, 0x66, 0x2f, 0x7c, 0xb4, 0x22,
+	0xb6, 0x12, 0xc1, 0xb8, 0x09, 0x0d, 0x65, 0xeb, 0xcc, 0x27, 0x7e, 0xc8, 0xf3, 0xf2, 0xaf, 0xe5,
+	0x8a, 0xc0, 0x6f, 0x41, 0x43, 0xe1, 0xc5, 0xce, 0x3e, 0x51, 0x92, 0xb6, 0x90, 0x92, 0x26, 0xd0,
+	0xec, 0x8d, 0x2f, 0xc3, 0x7e, 0x60, 0x5f, 0x12, 0x25, 0xe5, 0x8f, 0x56, 0x17, 0x31, 0x2e, 0x2e,
+	0xc9, 0xce, 0x8e, 0xc2, 0xc2, 0x14, 0xf9, 0x75, 0xb6, 0x6a, 0x38, 0x1e, 0x11, 0x59, 0xa8, 0x3d,
+	0x9f, 0x5f, 0x95, 0x8d, 0x76, 0x3d, 0xdb, 0xa5, 0x86, 0x24, 0xc5, 0x67, 0xb0, 0x1d, 0x2f, 0x7d,
+	0x9a, 0x29, 0x39, 0x9e, 0x6d, 0x7d, 0xfc, 0x23, 0x5e, 0xe2, 0x31, 0x26, 0x89, 0xed, 0x68, 0xaa,
+	0xed, 0x44, 0x05, 0x5a, 0xa9, 0xb8, 0x40, 0x13, 0xb7, 0x79, 0x04, 0xe2, 0x2b, 0x80, 0xd3, 0x24,
+	0x5b, 0x9e, 0x13, 0x36, 0x88, 0x35, 0x10, 0x36, 0x53, 0x31, 0xf8, 0x37, 0x73, 0x0e, 0xc6, 0x7f,
+	0x56, 0xd1, 0x2a, 0x9c, 0x83, 0x53, 0xe1, 0xbf, 0x69, 0xb0, 0xd3, 0x1d, 0x5f, 0x3a, 0x76, 0x38,
+	0xec, 0x06, 0x24, 0x24, 0x6e, 0x9f, 0x2c, 0x62, 0x16, 0x6f, 0x40, 0x2d, 0xa4, 0x26, 0x1d, 0x8b,
+	0xf2, 0xb0, 0x71, 0xd0, 0xce, 0x2e, 0x13, 0x31, 0xeb, 0x71, 0x2a, 0x43, 0x52, 0xa3, 0x56, 0xdc,
+	0x59, 0x88, 0x4b, 0x5b, 0x01, 0x72, 0xb5, 0xdb, 0x23, 0x12, 0x52, 0x73, 0xe4, 0xcb, 0x7c, 0x31,
+	0x41, 0xb0, 0x0a, 0xce, 0xb6, 0x88, 0x4b, 0x6d, 0x3a, 0xe9, 0xd9, 0x03, 0x59, 0xe9, 0xaa, 0x28,
+	0xbc, 0x03, 0x5b, 0xb9, 0x7d, 0x30, 0x83, 0x7f, 0x03, 0x5a, 0xf1, 0x39, 0x3f, 0xc3, 0x0e, 0xf1,
+	0x5f, 0x35, 0xb8, 0x91, 0xe2, 0x34, 0xef, 0xee, 0x97, 0x97, 0x41, 0x49, 0xbd, 0x0c, 0xd8, 0x9c,
+	0x48, 0x48, 0xb9, 0xe1, 0x18, 0x56, 0x74, 0x58, 0xf9, 0xb2, 0x3a, 0xac, 0xa6, 0x75, 0x18, 0xa5,
+	0xdb, 0xb5, 0x24, 0xdd, 0x66, 0x49, 0x6a, 0xad, 0xd3, 0x3d, 0x63, 0x37, 0x45, 0x53, 0x69, 0x2f,
+	0x89, 0xe6, 0x12, 0xef, 0x27, 0x8c, 0x6c, 0x57, 0x66, 0x2c, 0x02, 0x10, 0x3e, 0x6f, 0x5a, 0x0f,
+	0x5c, 0x67, 0x22, 0x33, 0x96, 0x18, 0x4e, 0x7b, 0x47, 0x25, 0xeb, 0x1d, 0x37, 0xa1, 0xde, 0x0f,
+	0x88, 0x4c, 0x52, 0x45, 0x82, 0x9f, 0x20, 0x30, 0x89, 0x2e, 0x46, 0x21, 0x4f, 0x74, 0x0a, 0xb1,
+	0x10, 0xda, 0x34, 0x21, 0x4a, 0xb3, 0x84, 0x28, 0x67, 0x84, 0xc0, 0x8f, 0x60, 0x23, 0xbd, 0x0c,
+	0x3b, 0xbc, 0xbd, 0x64, 0xef, 0x05, 0x61, 0x49, 0x52, 0x72, 0x9d, 0xec, 0x40, 0x2d, 0x24, 0xfd,
+	0x80, 0x50, 0x59, 0x8d, 0x49, 0x08, 0x6f, 0x89, 0x06, 0x85, 0x20, 0x8d, 0xa2, 0x05, 0xfe, 0x3e,
+	0x34, 0x53, 0x58, 0xb6, 0xd6, 0x2b, 0xb2, 0x73, 0x22, 0x12, 0xb4, 0x69, 0x8b, 0x71, 0x1a, 0xfc,
+	0x32, 0x6c, 0x1a, 0xe4, 0xa9, 0x77, 0x95, 0xd1, 0x49, 0xee, 0xa8, 0x58, 0x86, 0x93, 0x26, 0x64,
+	0xc6, 0xfd, 0x36, 0x6c, 0x1f, 0x5f, 0xfb, 0x5e, 0x40, 0x3b, 0x63, 0xcb, 0xa6, 0xe7, 0xde, 0x40,
+	0xd1, 0xa9, 0x28, 0x00, 0xb5, 0x4c, 0x01, 0x38, 0x76, 0xa9, 0xed, 0x44, 0x65, 0x21, 0x07, 0xf0,
+	0x7f, 0x35, 0x00, 0x3e, 0xff, 0xd8, 0xa5, 0xc1, 0x24, 0x36, 0x22, 0x2d, 0x5d, 0xb3, 0x5d, 0xd9,
+	0xae, 0x25, 0x35, 0xc2, 0xbf, 0x79, 0xe1, 0xef, 0x93, 0x20, 0xa9, 0x0f, 0xea, 0x46, 0x82, 0x60,
+	0x33, 0x7c, 0x42, 0x02, 0x99, 0x8f, 0xf0, 0x6f, 0x5e, 0x25, 0xfa, 0x36, 0xcb, 0x64, 0xaa, 0x42,
+	0xb3, 0x02, 0x4a, 0x39, 0x96, 0xa8, 0x00, 0x0b, 0x2e, 0xe3, 0x65, 0x99, 0x22, 0x33, 0x20, 0x75,
+	0x2b, 0xad, 0x88, 0x19, 0x11, 0xcc, 0xdc, 0xc3, 0x1b, 0xd3, 0xbe, 0x37, 0x22, 0xad, 0x3a, 0x1f,
+	0x8a, 0x40, 0xc6, 0x8b, 0x04, 0x81, 0x17, 0xb4, 0x40, 0xf0, 0xe2, 0x00, 0x6b, 0x19, 0xd6, 0xee,
+	0x9b, 0x63, 0x87, 0x86, 0x2c, 0xe5, 0xb2, 0x02, 0xcf, 0xef, 0x8e, 0xc3, 0xa1, 0x91, 0x5c, 0x63,
+	0x37, 0x8c, 0x0c, 0x16, 0xed, 0x03, 0xb2, 0x88, 0x63, 0x4e, 0x8e, 0xaf, 0xfb, 0x43, 0xd3, 0x1d,
+	0x90, 0x63, 0x6b, 0x40, 0x42, 0xa9, 0xd4, 0x82, 0x11, 0x74, 0x0f, 0x36, 0xfa, 0x5e, 0x10, 0x8c,
+	0x7d, 0x79, 0x59, 0x1e, 0xb2, 0x5c, 0xaf, 0xcc, 0x59, 0xe7, 0x07, 0xf0, 0x21, 0x34, 0x7b, 0x84,
+	0x0a, 0x91, 0xa2, 0xf3, 0xdc, 0x87, 0xda, 0x13, 0x8e, 0x98, 0x66, 0xc1, 0x92, 0x5c, 0x52, 0xb1,
+	0x8b, 0x5f, 0xe1, 0xc1, 0x4c, 0x45, 0x34, 0xab, 0x53, 0x5c, 0xf1, 0x8f, 0xa1, 0xa1, 0xe0, 0x98,
+	0xe9, 0xb6, 0x60, 0x99, 0xb8, 0xe6, 0xa5, 0x43, 0xa2, 0xda, 0x38, 0x02, 0x15, 0x09, 0x4a, 0x0b,
+	0x49, 0xf0, 0x06, 0xb4, 0xe2, 0xf6, 0x48, 0xcf, 0x35, 0xfd, 0x70, 0xe8, 0xd1, 0x45, 0xe2, 0xee,
+	0xb7, 0x61, 0xa7, 0x60, 0x9e, 0x8c, 0xbf, 0xa1, 0x44, 0x44, 0xb3, 0x22, 0x18, 0x7f, 0x0c, 0xdb,
+	0x8f, 0x78, 0x31, 0x7c, 0xee, 0x0d, 0x3a, 0xac, 0x29, 0xfa, 0xe5, 0x13, 0xbd, 0xb8, 0xc7, 0x5a,
+	0x56, 0xfb, 0xb8, 0xdb, 0xb0, 0x99, 0x5d, 0x80, 0x69, 0xf5, 0x4d, 0xb8, 0x19, 0xdf, 0x2e, 0xac,
+	0x91, 0xd6, 0x0d, 0xbc, 0x41, 0x40, 0xc2, 0x45, 0x96, 0xc7, 0x7f, 0x29, 0xc1, 0x46, 0x7a, 0xce,
+	0xbc, 0x5b, 0xa6, 0x95, 0x74, 0x3f, 0x84, 0xb1, 0x45, 0x20, 0x9b, 0x45, 0xae, 0x7d, 0xd2, 0xa7,
+	0xb2, 0xc8, 0x2c, 0x1b, 0x31, 0x8c, 0x8e, 0x65, 0x4b, 0x4a, 0x64, 0xcb, 0xaf, 0x15, 0xf5, 0xff,
+	0x52, 0x22, 0xb0, 0x14, 0x21, 0x85, 0x8c, 0xfb, 0xdb, 0x97, 0x13, 0x4a, 0x42, 0x19, 0xd7, 0x05,
+	0xc0, 0x02, 0x15, 0xa1, 0xa6, 0xbc, 0x71, 0xd8, 0xa7, 0xfe, 0x18, 0xd6, 0x33, 0x0c, 0xa6, 0x64,
+	0x45, 0x2d, 0x58, 0x36, 0x7d, 0xdf, 0xb1, 0x65, 0xc3, 0xa5, 0x6c, 0x44, 0x20, 0x0b, 0x14, 0x43,
+	0x62, 0x0f, 0x86, 0x51, 0xa3, 0x43, 0x42, 0xf8, 0xbb, 0xb0, 0x9e, 0x29, 0x46, 0x8a, 0xef, 0xb4,
+	0xa7, 0xa6, 0x33, 0x8e, 0x32, 0x69, 0x01, 0xe0, 0x5f, 0xb2, 0x20, 0xd7, 0xef, 0x93, 0x30, 0x64,
+	0xe1, 0x9a, 0x25, 0xd5, 0xa6, 0xe3, 0x78, 0x9f, 0x76, 0x09, 0x09, 0xa2, 0x2c, 0x4f, 0xc1, 0xb0,
+	0xe0, 0xc6, 0xa1, 0x0b, 0x42, 0xa3, 0x5c, 0x2f, 0x41, 0xb0, 0x51, 0x8b, 0xb8, 0x13, 0x31, 0x59,
+	0xde, 0x3f, 0x31, 0x82, 0x9d, 0x05, 0x03, 0xf8, 0xd4, 0x0a, 0x9f, 0x1a, 0xc3, 0xd8, 0x80, 0xad,
+	0x1e, 0xa1, 0x89, 0x20, 0x91, 0x9d, 0xb0, 0x6e, 0x4b, 0x8c, 0x6c, 0x69, 0x53, 0xba, 0x2d, 0xc9,
+	0x34, 0x85, 0x9a, 0x5d, 0x4c, 0x19, 0x9e, 0xcc, 0x32, 0x77, 0x44, 0xb3, 0x25, 0xbb, 0x12, 0xee,
+	0x02, 0xca, 0xe0, 0x99, 0xd5, 0x7d, 0x95, 0xf5, 0x3f, 0x80, 0x55, 0x25, 0xc1, 0x9e, 0x69, 0xc0,
+	0x71, 0x72, 0x5a, 0x5a, 0x28, 0x39, 0xfd, 0xa3, 0x06, 0x3b, 0xe2, 0x7e, 0x13, 0xf1, 0xe0, 0xa8,
+	0x73, 0xb2, 0x60, 0x23, 0xe6, 0x89, 0x17, 0x8c, 0xcc, 0xf8, 0x06, 0x17, 0x90, 0x7c, 0x34, 0x4a,
+	0x72, 0x06, 0x09, 0xb1, 0xe3, 0x1c, 0xd9, 0xee, 0xa9, 0xb0, 0x38, 0x99, 0x7a, 0xc6, 0x08, 0x3e,
+	0x6a, 0x5e, 0xcb, 0x51, 0x99, 0xd3, 0xc4, 0x08, 0x7c, 0x0f, 0xb6, 0x72, 0x12, 0x32, 0x85, 0x6e,
+	0x41, 0x75, 0x10, 0x98, 0xfe, 0x30, 0x32, 0x79, 0x0e, 0xbc, 0xf2, 0x26, 0x40, 0xd2, 0x6e, 0x47,
+	0xcb, 0x50, 0xee, 0x5c, 0x3c, 0x6e, 0x2e, 0x21, 0x80, 0x5a, 0xef, 0xf1, 0xc5, 0xdb, 0xc7, 0x47,
+	0x4d, 0x0d, 0xd5, 0xa1, 0xda, 0x7b, 0xd8, 0x39, 0x3f, 0x6e, 0x96, 0xd0, 0x1a, 0xac, 0x3c, 0xba,
+	0x90, 0x03, 0xe5, 0x57, 0x5e, 0x87, 0x46, 0x3a, 0x21, 0x44, 0xab, 0xb0, 0xfc, 0xe0, 0xfe, 0xfd,
+	0xf3, 0xb3, 0x8b, 0x63, 0xc1, 0xe3, 0xc1, 0x05, 0xff, 0xd6, 0xd0, 0x0a, 0x54, 0x3a, 0xef, 0x77,
+	0x1e, 0x37, 0x4b, 0x07, 0xff, 0x69, 0x42, 0xb9, 0xd3, 0x3d, 0x43, 0x0f, 0xa0, 0x1e, 0x3f, 0x4b,
+	0xa2, 0x5c, 0xcb, 0x28, 0xfb, 0x8a, 0xa9, 0xb7, 0x67, 0x50, 0x30, 0xe3, 0x5a, 0x42, 0x5d, 0x58,
+	0x89, 0xde, 0x1a, 0xd1, 0xad, 0x02, 0x6a, 0xf5, 0x5d, 0x53, 0x7f, 0x61, 0x3a, 0x01, 0xe7, 0xb6,
+	0xa7, 0xbd, 0xaa, 0xa1, 0xf7, 0x60, 0x4d, 0x7d, 0x69, 0x44, 0x77, 0xb2, 0x93, 0x0a, 0xde, 0x21,
+	0xf5, 0x5b, 0xc5, 0x4f, 0x07, 0xf1, 0xe3, 0x1f, 0x97, 0xb4, 0x1e, 0xbf, 0x77, 0xe5, 0xb7, 0x9e,
+	0x7d, 0x0a, 0x5b, 0x90, 0x63, 0x7c, 0x45, 0x15, 0x2a, 0xf3, 0x99, 0x39, 0x3e, 0x82, 0x55, 0xe5,
+	0x99, 0x04, 0xe1, 0x9c, 0x5f, 0xe4, 0x5e, 0xc6, 0xf4, 0xdd, 0x99, 0x34, 0x82, 0xed, 0x87, 0xe2,
+	0x41, 0x38, 0x7e, 0xa2, 0x40, 0x77, 0xa7, 0x0a, 0xab, 0xbc, 0x94, 0xe8, 0x78, 0x0e, 0x95, 0x60,
+	0xfe, 0x01, 0xac, 0xa9, 0xfd, 0xf9, 0xfc, 0x79, 0x15, 0x3c, 0x5a, 0xe8, 0xb7, 0x67, 0x13, 0x09,
+	0xce, 0x06, 0x40, 0xd2, 0x16, 0x44, 0xb9, 0x29, 0xb9, 0xfe, 0xa5, 0x7e, 0x6b, 0x16, 0x89, 0xe0,
+	0xf9, 0x11, 0x34, 0xd2, 0xad, 0x46, 0xf4, 0xe2, 0xf4, 0x49, 0x4a, 0x4f, 0x53, 0xbf, 0x33, 0x8f,
+	0x2c, 0xd6, 0x86, 0xda, 0x80, 0xcc, 0x6b, 0xa3, 0xa0, 0xa3, 0xa9, 0xdf, 0x9e, 0x4d, 0x14, 0x1f,
+	0x62, 0xaa, 0xfb, 0x98, 0x3f, 0xc4, 0xa2, 0x26, 0xa7, 0x8e, 0xe7, 0x50, 0x45, 0x86, 0xb7, 0xa6,
+	0xf6, 0x2a, 0xa7, 0x39, 0x5d, 0xaa, 0x5f, 0x94, 0x8f, 0x0e, 0xe9, 0x0e, 0x20, 0x5e, 0x62, 0xe1,
+	0x26, 0xee, 0x3b, 0x15, 0xfa, 0xdc, 0x1c, 0x86, 0x99, 0xa6, 0xd5, 0x92, 0x8c, 0x5f, 0xd3, 0x18,
+	0x66, 0x3b, 0x5a, 0x7a, 0x7b, 0x06, 0x45, 0x6c, 0x0f, 0xe9, 0x27, 0x8a, 0xbc, 0x3d, 0x14, 0xbe,
+	0xa7, 0xe8, 0x77, 0xe6, 0x91, 0xa9, 0xae, 0xa7, 0xbc, 0x0b, 0x15, 0xb9, 0x5e, 0xee, 0x29, 0x44,
+	0xc7, 0x73, 0xa8, 0x04, 0x73, 0x0b, 0x9a, 0xd9, 0x17, 0x02, 0xf4, 0x72, 0x76, 0xe6, 0x94, 0x27,
+	0x0c, 0xfd, 0xc5, 0xf9, 0x84, 0x62, 0x95, 0x77, 0xa1, 0x1e, 0xe7, 0xb6, 0x79, 0x9d, 0x67, 0xfb,
+	0x76, 0xf3, 0xad, 0xe2, 0x55, 0x0d, 0xbd, 0x0f, 0x8d, 0x74, 0xd3, 0x2d, 0xaf, 0xf5, 0xc2, 0xa6,
+	0x9c, 0x9e, 0xcb, 0x45, 0x4e, 0x95, 0x38, 0xf7, 0xaa, 0x86, 0x4c, 0x58, 0xcf, 0x74, 0x7f, 0xd0,
+	0x4b, 0x79, 0xc7, 0x2d, 0x6a, 0x73, 0xe9, 0x77, 0xe7, 0xd2, 0x09, 0x75, 0xfc, 0x14, 0x36, 0x72,
+	0x8d, 0x24, 0xb4, 0x37, 0x55, 0xfc, 0xec, 0x32, 0x2f, 0x4c, 0xeb, 0xee, 0x24, 0x9b, 0xf8, 0x08,
+	0x1a, 0xe9, 0x1a, 0x23, 0xaf, 0x9d, 0xc2, 0x22, 0x47, 0xbf, 0x33, 0x8f, 0x4c, 0xec, 0xc0, 0x51,
+	0x5a, 0x9e, 0xa9, 0xfc, 0xfc, 0xde, 0xd4, 0x5d, 0x14, 0xd4, 0x34, 0xf9, 0xa8, 0x95, 0xab, 0x20,
+	0xd8, 0x6e, 0x0e, 0x7e, 0xbf, 0x0c, 0xd5, 0x0e, 0x6f, 0xe5, 0x7c, 0x10, 0x05, 0x19, 0xd9, 0x87,
+	0x9a, 0x12, 0x64, 0x52, 0x1d, 0x10, 0xfd, 0xf6, 0x6c, 0xa2, 0xd4, 0xbd, 0x29, 0x90, 0x53, 0xee,
+	0xcd, 0x74, 0xc3, 0x46, 0xdf, 0x9d, 0x49, 0x13, 0x07, 0x73, 0xb5, 0xd7, 0x92, 0x17, 0xb8, 0xa0,
+	0x65, 0xa3, 0xdf, 0x9e, 0x4d, 0x24, 0x38, 0xbf, 0x0f, 0x8d, 0x74, 0xc3, 0x26, 0x7f, 0xc4, 0x85,
+	0x0d, 0x9d, 0xbc, 0x03, 0x24, 0x1d, 0x1b, 0x6e, 0x3b, 0x0f, 0xa0, 0x1e, 0x17, 0xfc, 0x05, 0xce,
+	0x9a, 0xa9, 0xfc, 0xf5, 0xf6, 0x0c, 0x0a, 0x35, 0xe2, 0x4e, 0x63, 0x78, 0x32, 0x97, 0xe1, 0x49,
+	0x96, 0xe1, 0x00, 0x36, 0x72, 0x85, 0x7d, 0xde, 0x7f, 0xa6, 0xf5, 0x0c, 0xf4, 0x97, 0x16, 0xa0,
+	0x8c, 0x43, 0x6f, 0xaa, 0x1e, 0xca, 0x87, 0xde, 0xa2, 0x12, 0x4c, 0xc7, 0x73, 0xa8, 0x52, 0x71,
+	0x7d, 0x06, 0xf3, 0x93, 0x85, 0x98, 0x9f, 0x14, 0x31, 0x37, 0x61, 0x3d, 0x53, 0x4c, 0xe4, 0xa3,
+	0x58, 0x71, 0x3d, 0xa4, 0xdf, 0x9d, 0x4b, 0xc7, 0x97, 0x38, 0xec, 0xfe, 0xfd, 0xf3, 0xb6, 0xf6,
+	0xd9, 0xe7, 0x6d, 0xed, 0x5f, 0x9f, 0xb7, 0xb5, 0xdf, 0x7d, 0xd1, 0x5e, 0xfa, 0xec, 0x8b, 0xf6,
+	0xd2, 0x3f, 0xbf, 0x68, 0x2f, 0xc1, 0xf3, 0xb6, 0xb7, 0x4f, 0xc9, 0x35, 0xb5, 0x1d, 0x12, 0xf1,
+	0xfa, 0xd8, 0x25, 0xf4, 0xe3, 0x41, 0xe0, 0xf7, 0x0f, 0x41, 0x30, 0x0a, 0x2f, 0x08, 0xed, 0x6a,
+	0x7f, 0x2e, 0xc1, 0xc3, 0x53, 0xe3, 0xb8, 0x73, 0xd4, 0xbb, 0x38, 0x7e, 0x78, 0x59, 0xe3, 0x3f,
+	0x47, 0xbe, 0xfe, 0xff, 0x01, 0x00, 0x49, 0xae, 0x37, 0x3e, 0x30, 0x29, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type APIClient interface {
+	GetHostID(ctx context.Context, in *GetHostIDRequest, opts ...grpc.CallOption) (*GetHostIDReply, error)
+	GetToken(ctx context.Context, opts ...grpc.CallOption) (API_GetTokenClient, error)
+	CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
+	AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
+	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
+	ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsReply, error)
+	GetThreadLogs(ctx context.Context, in *GetThreadLogsRequest, opts ...grpc.CallOption) (*GetThreadLogsReply, error)
+	ActivityFeed(ctx context.Context, in *ActivityFeedRequest, opts ...grpc.CallOption) (*ActivityFeedReply, error)
+	PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error)
+	PullThreadFrom(ctx context.Context, in *PullThreadFromRequest, opts ...grpc.CallOption) (*PullThreadFromReply, error)
+	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error)
+	AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error)
+	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error)
+	AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error)
+	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error)
+	AnnotateRecord(ctx context.Context, in *AnnotateRecordRequest, opts ...grpc.CallOption) (*AnnotateRecordReply, error)
+	GetAnnotation(ctx context.Context, in *GetAnnotationRequest, opts ...grpc.CallOption) (*GetAnnotationReply, error)
+	QueryAnnotations(ctx context.Context, in *QueryAnnotationsRequest, opts ...grpc.CallOption) (*QueryAnnotationsReply, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error)
+	SubscribeHeads(ctx context.Context, in *SubscribeHeadsRequest, opts ...grpc.CallOption) (API_SubscribeHeadsClient, error)
+	PublishPresence(ctx context.Context, in *PublishPresenceRequest, opts ...grpc.CallOption) (*PublishPresenceReply, error)
+	SubscribePresence(ctx context.Context, in *SubscribePresenceRequest, opts ...grpc.CallOption) (API_SubscribePresenceClient, error)
+	UpdateLogAddrs(ctx context.Context, in *UpdateLogAddrsRequest, opts ...grpc.CallOption) (*UpdateLogAddrsReply, error)
+	SubscribeSyncProgress(ctx context.Context, in *SubscribeSyncProgressRequest, opts ...grpc.CallOption) (API_SubscribeSyncProgressClient, error)
+}
+
+type aPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIClient(cc *grpc.ClientConn) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) GetHostID(ctx context.Context, in *GetHostIDRequest, opts ...grpc.CallOption) (*GetHostIDReply, error) {
+	out := new(GetHostIDReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetHostID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetToken(ctx context.Context, opts ...grpc.CallOption) (API_GetTokenClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[0], "/threads.net.pb.API/GetToken", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIGetTokenClient{stream}
+	return x, nil
+}
+
+type API_GetTokenClient interface {
+	Send(*GetTokenRequest) error
+	Recv() (*GetTokenReply, error)
+	grpc.ClientStream
+}
+
+type aPIGetTokenClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIGetTokenClient) Send(m *GetTokenRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIGetTokenClient) Recv() (*GetTokenReply, error) {
+	m := new(GetTokenReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/CreateThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AddThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error) {
+	out := new(ThreadInfoReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsReply, error) {
+	out := new(ListThreadsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/ListThreads", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetThreadLogs(ctx context.Context, in *GetThreadLogsRequest, opts ...grpc.CallOption) (*GetThreadLogsReply, error) {
+	out := new(GetThreadLogsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetThreadLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ActivityFeed(ctx context.Context, in *ActivityFeedRequest, opts ...grpc.CallOption) (*ActivityFeedReply, error) {
+	out := new(ActivityFeedReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/ActivityFeed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error) {
+	out := new(PullThreadReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/PullThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) PullThreadFrom(ctx context.Context, in *PullThreadFromRequest, opts ...grpc.CallOption) (*PullThreadFromReply, error) {
+	out := new(PullThreadFromReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/PullThreadFrom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error) {
+	out := new(DeleteThreadReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/DeleteThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error) {
+	out := new(AddReplicatorReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AddReplicator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error) {
+	out := new(NewRecordReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/CreateRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error) {
+	out := new(AddRecordReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AddRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error) {
+	out := new(GetRecordReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AnnotateRecord(ctx context.Context, in *AnnotateRecordRequest, opts ...grpc.CallOption) (*AnnotateRecordReply, error) {
+	out := new(AnnotateRecordReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/AnnotateRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetAnnotation(ctx context.Context, in *GetAnnotationRequest, opts ...grpc.CallOption) (*GetAnnotationReply, error) {
+	out := new(GetAnnotationReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetAnnotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) QueryAnnotations(ctx context.Context, in *QueryAnnotationsRequest, opts ...grpc.CallOption) (*QueryAnnotationsReply, error) {
+	out := new(QueryAnnotationsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/QueryAnnotations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[1], "/threads.net.pb.API/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeClient interface {
+	Recv() (*NewRecordReply, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeClient) Recv() (*NewRecordReply, error) {
+	m := new(NewRecordReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) SubscribeHeads(ctx context.Context, in *SubscribeHeadsRequest, opts ...grpc.CallOption) (API_SubscribeHeadsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[2], "/threads.net.pb.API/SubscribeHeads", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeHeadsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeHeadsClient interface {
+	Recv() (*HeadsReply, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeHeadsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeHeadsClient) Recv() (*HeadsReply, error) {
+	m := new(HeadsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) PublishPresence(ctx context.Context, in *PublishPresenceRequest, opts ...grpc.CallOption) (*PublishPresenceReply, error) {
+	out := new(PublishPresenceReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/PublishPresence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubscribePresence(ctx context.Context, in *SubscribePresenceRequest, opts ...grpc.CallOption) (API_SubscribePresenceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[3], "/threads.net.pb.API/SubscribePresence", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribePresenceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribePresenceClient interface {
+	Recv() (*PresenceReply, error)
+	grpc.ClientStream
+}
+
+type aPISubscribePresenceClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribePresenceClient) Recv() (*PresenceReply, error) {
+	m := new(PresenceReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) UpdateLogAddrs(ctx context.Context, in *UpdateLogAddrsRequest, opts ...grpc.CallOption) (*UpdateLogAddrsReply, error) {
+	out := new(UpdateLogAddrsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/UpdateLogAddrs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubscribeSyncProgress(ctx context.Context, in *SubscribeSyncProgressRequest, opts ...grpc.CallOption) (API_SubscribeSyncProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_API_serviceDesc.Streams[4], "/threads.net.pb.API/SubscribeSyncProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPISubscribeSyncProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_SubscribeSyncProgressClient interface {
+	Recv() (*SyncProgressReply, error)
+	grpc.ClientStream
+}
+
+type aPISubscribeSyncProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPISubscribeSyncProgressClient) Recv() (*SyncProgressReply, error) {
+	m := new(SyncProgressReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServer is the server API for API service.
+type APIServer interface {
+	GetHostID(context.Context, *GetHostIDRequest) (*GetHostIDReply, error)
+	GetToken(API_GetTokenServer) error
+	CreateThread(context.Context, *CreateThreadRequest) (*ThreadInfoReply, error)
+	AddThread(context.Context, *AddThreadRequest) (*ThreadInfoReply, error)
+	GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error)
+	ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsReply, error)
+	GetThreadLogs(context.Context, *GetThreadLogsRequest) (*GetThreadLogsReply, error)
+	ActivityFeed(context.Context, *ActivityFeedRequest) (*ActivityFeedReply, error)
+	PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error)
+	PullThreadFrom(context.Context, *PullThreadFromRequest) (*PullThreadFromReply, error)
+	DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error)
+	AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error)
+	CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error)
+	AddRecord(context.Context, *AddRecordRequest) (*AddRecordReply, error)
+	GetRecord(context.Context, *GetRecordRequest) (*GetRecordReply, error)
+	AnnotateRecord(context.Context, *AnnotateRecordRequest) (*AnnotateRecordReply, error)
+	GetAnnotation(context.Context, *GetAnnotationRequest) (*GetAnnotationReply, error)
+	QueryAnnotations(context.Context, *QueryAnnotationsRequest) (*QueryAnnotationsReply, error)
+	Subscribe(*SubscribeRequest, API_SubscribeServer) error
+	SubscribeHeads(*SubscribeHeadsRequest, API_SubscribeHeadsServer) error
+	PublishPresence(context.Context, *PublishPresenceRequest) (*PublishPresenceReply, error)
+	SubscribePresence(*SubscribePresenceRequest, API_SubscribePresenceServer) error
+	UpdateLogAddrs(context.Context, *UpdateLogAddrsRequest) (*UpdateLogAddrsReply, error)
+	SubscribeSyncProgress(*SubscribeSyncProgressRequest, API_SubscribeSyncProgressServer) error
+}
+
+// UnimplementedAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (*UnimplementedAPIServer) GetHostID(ctx context.Context, req *GetHostIDRequest) (*GetHostIDReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostID not implemented")
+}
+func (*UnimplementedAPIServer) GetToken(srv API_GetTokenServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetToken not implemented")
+}
+func (*UnimplementedAPIServer) CreateThread(ctx context.Context, req *CreateThreadRequest) (*ThreadInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+}
+func (*UnimplementedAPIServer) AddThread(ctx context.Context, req *AddThreadRequest) (*ThreadInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddThread not implemented")
+}
+func (*UnimplementedAPIServer) GetThread(ctx context.Context, req *GetThreadRequest) (*ThreadInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+}
+func (*UnimplementedAPIServer) ListThreads(ctx context.Context, req *ListThreadsRequest) (*ListThreadsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreads not implemented")
+}
+func (*UnimplementedAPIServer) GetThreadLogs(ctx context.Context, req *GetThreadLogsRequest) (*GetThreadLogsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreadLogs not implemented")
+}
+func (*UnimplementedAPIServer) ActivityFeed(ctx context.Context, req *ActivityFeedRequest) (*ActivityFeedReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivityFeed not implemented")
+}
+func (*UnimplementedAPIServer) PullThread(ctx context.Context, req *PullThreadRequest) (*PullThreadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullThread not implemented")
+}
+func (*UnimplementedAPIServer) PullThreadFrom(ctx context.Context, req *PullThreadFromRequest) (*PullThreadFromReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullThreadFrom not implemented")
+}
+func (*UnimplementedAPIServer) DeleteThread(ctx context.Context, req *DeleteThreadRequest) (*DeleteThreadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
+}
+func (*UnimplementedAPIServer) AddReplicator(ctx context.Context, req *AddReplicatorRequest) (*AddReplicatorReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReplicator not implemented")
+}
+func (*UnimplementedAPIServer) CreateRecord(ctx context.Context, req *CreateRecordRequest) (*NewRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
+}
+func (*UnimplementedAPIServer) AddRecord(ctx context.Context, req *AddRecordRequest) (*AddRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRecord not implemented")
+}
+func (*UnimplementedAPIServer) GetRecord(ctx context.Context, req *GetRecordRequest) (*GetRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
+}
+func (*UnimplementedAPIServer) AnnotateRecord(ctx context.Context, req *AnnotateRecordRequest) (*AnnotateRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnotateRecord not implemented")
+}
+func (*UnimplementedAPIServer) GetAnnotation(ctx context.Context, req *GetAnnotationRequest) (*GetAnnotationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnnotation not implemented")
+}
+func (*UnimplementedAPIServer) QueryAnnotations(ctx context.Context, req *QueryAnnotationsRequest) (*QueryAnnotationsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAnnotations not implemented")
+}
+func (*UnimplementedAPIServer) Subscribe(req *SubscribeRequest, srv API_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedAPIServer) SubscribeHeads(req *SubscribeHeadsRequest, srv API_SubscribeHeadsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeHeads not implemented")
+}
+func (*UnimplementedAPIServer) PublishPresence(ctx context.Context, req *PublishPresenceRequest) (*PublishPresenceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishPresence not implemented")
+}
+func (*UnimplementedAPIServer) SubscribePresence(req *SubscribePresenceRequest, srv API_SubscribePresenceServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribePresence not implemented")
+}
+func (*UnimplementedAPIServer) UpdateLogAddrs(ctx context.Context, req *UpdateLogAddrsRequest) (*UpdateLogAddrsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLogAddrs not implemented")
+}
+func (*UnimplementedAPIServer) SubscribeSyncProgress(req *SubscribeSyncProgressRequest, srv API_SubscribeSyncProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeSyncProgress not implemented")
+}
+
+func RegisterAPIServer(s *grpc.Server, srv APIServer) {
+	s.RegisterService(&_API_serviceDesc, srv)
+}
+
+func _API_GetHostID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetHostID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetHostID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetHostID(ctx, req.(*GetHostIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetToken_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).GetToken(&aPIGetTokenServer{stream})
+}
+
+type API_GetTokenServer interface {
+	Send(*GetTokenReply) error
+	Recv() (*GetTokenRequest, error)
+	grpc.ServerStream
+}
+
+type aPIGetTokenServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIGetTokenServer) Send(m *GetTokenReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPIGetTokenServer) Recv() (*GetTokenRequest, error) {
+	m := new(GetTokenRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/CreateThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateThread(ctx, req.(*CreateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AddThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/AddThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddThread(ctx, req.(*AddThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/ListThreads",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListThreads(ctx, req.(*ListThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetThreadLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetThreadLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetThreadLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetThreadLogs(ctx, req.(*GetThreadLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ActivityFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ActivityFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/ActivityFeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ActivityFeed(ctx, req.(*ActivityFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_PullThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).PullThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/PullThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).PullThread(ctx, req.(*PullThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_PullThreadFrom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullThreadFromRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).PullThreadFrom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/PullThreadFrom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).PullThreadFrom(ctx, req.(*PullThreadFromRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/DeleteThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteThread(ctx, req.(*DeleteThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AddReplicator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReplicatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddReplicator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/AddReplicator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddReplicator(ctx, req.(*AddReplicatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/CreateRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateRecord(ctx, req.(*CreateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AddRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/AddRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddRecord(ctx, req.(*AddRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetRecord(ctx, req.(*GetRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AnnotateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AnnotateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/AnnotateRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AnnotateRecord(ctx, req.(*AnnotateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetAnnotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnnotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetAnnotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetAnnotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetAnnotation(ctx, req.(*GetAnnotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_QueryAnnotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAnnotationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).QueryAnnotations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/QueryAnnotations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).QueryAnnotations(ctx, req.(*QueryAnnotationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).Subscribe(m, &aPISubscribeServer{stream})
+}
+
+type API_SubscribeServer interface {
+	Send(*NewRecordReply) error
+	grpc.ServerStream
+}
+
+type aPISubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeServer) Send(m *NewRecordReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_SubscribeHeads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHeadsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeHeads(m, &aPISubscribeHeadsServer{stream})
+}
+
+type API_SubscribeHeadsServer interface {
+	Send(*HeadsReply) error
+	grpc.ServerStream
+}
+
+type aPISubscribeHeadsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeHeadsServer) Send(m *HeadsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_PublishPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishPresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).PublishPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/PublishPresence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).PublishPresence(ctx, req.(*PublishPresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubscribePresence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribePresenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribePresence(m, &aPISubscribePresenceServer{stream})
+}
+
+type API_SubscribePresenceServer interface {
+	Send(*PresenceReply) error
+	grpc.ServerStream
+}
+
+type aPISubscribePresenceServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribePresenceServer) Send(m *PresenceReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_UpdateLogAddrs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLogAddrsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateLogAddrs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/UpdateLogAddrs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateLogAddrs(ctx, req.(*UpdateLogAddrsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubscribeSyncProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeSyncProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).SubscribeSyncProgress(m, &aPISubscribeSyncProgressServer{stream})
+}
+
+type API_SubscribeSyncProgressServer interface {
+	Send(*SyncProgressReply) error
+	grpc.ServerStream
+}
+
+type aPISubscribeSyncProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPISubscribeSyncProgressServer) Send(m *SyncProgressReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _API_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "threads.net.pb.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHostID",
+			Handler:    _API_GetHostID_Handler,
+		},
+		{
+			MethodName: "CreateThread",
+			Handler:    _API_CreateThread_Handler,
+		},
+		{
+			MethodName: "AddThread",
+			Handler:    _API_AddThread_Handler,
+		},
+		{
+			MethodName: "GetThread",
+			Handler:    _API_GetThread_Handler,
+		},
+		{
+			MethodName: "ListThreads",
+			Handler:    _API_ListThreads_Handler,
+		},
+		{
+			MethodName: "GetThreadLogs",
+			Handler:    _API_GetThreadLogs_Handler,
+		},
+		{
+			MethodName: "ActivityFeed",
+			Handler:    _API_ActivityFeed_Handler,
+		},
+		{
+			MethodName: "PullThread",
+			Handler:    _API_PullThread_Handler,
+		},
+		{
+			MethodName: "PullThreadFrom",
+			Handler:    _API_PullThreadFrom_Handler,
+		},
+		{
+			MethodName: "DeleteThread",
+			Handler:    _API_DeleteThread_Handler,
+		},
+		{
+			MethodName: "AddReplicator",
+			Handler:    _API_AddReplicator_Handler,
+		},
+		{
+			MethodName: "CreateRecord",
+			Handler:    _API_CreateRecord_Handler,
+		},
+		{
+			MethodName: "AddRecord",
+			Handler:    _API_AddRecord_Handler,
+		},
+		{
+			MethodName: "GetRecord",
+			Handler:    _API_GetRecord_Handler,
+		},
+		{
+			MethodName: "AnnotateRecord",
+			Handler:    _API_AnnotateRecord_Handler,
+		},
+		{
+			MethodName: "GetAnnotation",
+			Handler:    _API_GetAnnotation_Handler,
+		},
+		{
+			MethodName: "QueryAnnotations",
+			Handler:    _API_QueryAnnotations_Handler,
+		},
+		{
+			MethodName: "PublishPresence",
+			Handler:    _API_PublishPresence_Handler,
+		},
+		{
+			MethodName: "UpdateLogAddrs",
+			Handler:    _API_UpdateLogAddrs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetToken",
+			Handler:       _API_GetToken_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _API_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeHeads",
+			Handler:       _API_SubscribeHeads_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribePresence",
+			Handler:       _API_SubscribePresence_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeSyncProgress",
+			Handler:       _API_SubscribeSyncProgress_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "threadsnet.proto",
+}
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyReply, error)
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysReply, error)
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyReply, error)
+	ExportAuditLog(ctx context.Context, in *ExportAuditLogRequest, opts ...grpc.CallOption) (Admin_ExportAuditLogClient, error)
+	SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*SetFaultsReply, error)
+	GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*GetFaultsReply, error)
+	GetThreadSnapshot(ctx context.Context, in *GetThreadSnapshotRequest, opts ...grpc.CallOption) (*GetThreadSnapshotReply, error)
+	SetAccessList(ctx context.Context, in *SetAccessListRequest, opts ...grpc.CallOption) (*SetAccessListReply, error)
+	GetAccessList(ctx context.Context, in *GetAccessListRequest, opts ...grpc.CallOption) (*GetAccessListReply, error)
+	ExportThreadDAG(ctx context.Context, in *ExportThreadDAGRequest, opts ...grpc.CallOption) (*ExportThreadDAGReply, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyReply, error) {
+	out := new(CreateAPIKeyReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/CreateAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysReply, error) {
+	out := new(ListAPIKeysReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/ListAPIKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*RevokeAPIKeyReply, error) {
+	out := new(RevokeAPIKeyReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/RevokeAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportAuditLog(ctx context.Context, in *ExportAuditLogRequest, opts ...grpc.CallOption) (Admin_ExportAuditLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Admin_serviceDesc.Streams[0], "/threads.net.pb.Admin/ExportAuditLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminExportAuditLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_ExportAuditLogClient interface {
+	Recv() (*AuditEntry, error)
+	grpc.ClientStream
+}
+
+type adminExportAuditLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminExportAuditLogClient) Recv() (*AuditEntry, error) {
+	m := new(AuditEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*SetFaultsReply, error) {
+	out := new(SetFaultsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/SetFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetFaults(ctx context.Context, in *GetFaultsRequest, opts ...grpc.CallOption) (*GetFaultsReply, error) {
+	out := new(GetFaultsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/GetFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetThreadSnapshot(ctx context.Context, in *GetThreadSnapshotRequest, opts ...grpc.CallOption) (*GetThreadSnapshotReply, error) {
+	out := new(GetThreadSnapshotReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/GetThreadSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetAccessList(ctx context.Context, in *SetAccessListRequest, opts ...grpc.CallOption) (*SetAccessListReply, error) {
+	out := new(SetAccessListReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/SetAccessList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetAccessList(ctx context.Context, in *GetAccessListRequest, opts ...grpc.CallOption) (*GetAccessListReply, error) {
+	out := new(GetAccessListReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/GetAccessList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportThreadDAG(ctx context.Context, in *ExportThreadDAGRequest, opts ...grpc.CallOption) (*ExportThreadDAGReply, error) {
+	out := new(ExportThreadDAGReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.Admin/ExportThreadDAG", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyReply, error)
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysReply, error)
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*RevokeAPIKeyReply, error)
+	ExportAuditLog(*ExportAuditLogRequest, Admin_ExportAuditLogServer) error
+	SetFaults(context.Context, *SetFaultsRequest) (*SetFaultsReply, error)
+	GetFaults(context.Context, *GetFaultsRequest) (*GetFaultsReply, error)
+	GetThreadSnapshot(context.Context, *GetThreadSnapshotRequest) (*GetThreadSnapshotReply, error)
+	SetAccessList(context.Context, *SetAccessListRequest) (*SetAccessListReply, error)
+	GetAccessList(context.Context, *GetAccessListRequest) (*GetAccessListReply, error)
+	ExportThreadDAG(context.Context, *ExportThreadDAGRequest) (*ExportThreadDAGReply, error)
+}
+
+// UnimplementedAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (*UnimplementedAdminServer) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*CreateAPIKeyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (*UnimplementedAdminServer) ListAPIKeys(ctx context.Context, req *ListAPIKeysRequest) (*ListAPIKeysReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (*UnimplementedAdminServer) RevokeAPIKey(ctx context.Context, req *RevokeAPIKeyRequest) (*RevokeAPIKeyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (*UnimplementedAdminServer) ExportAuditLog(req *ExportAuditLogRequest, srv Admin_ExportAuditLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLog not implemented")
+}
+func (*UnimplementedAdminServer) SetFaults(ctx context.Context, req *SetFaultsRequest) (*SetFaultsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFaults not implemented")
+}
+func (*UnimplementedAdminServer) GetFaults(ctx context.Context, req *GetFaultsRequest) (*GetFaultsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFaults not implemented")
+}
+func (*UnimplementedAdminServer) GetThreadSnapshot(ctx context.Context, req *GetThreadSnapshotRequest) (*GetThreadSnapshotReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreadSnapshot not implemented")
+}
+func (*UnimplementedAdminServer) SetAccessList(ctx context.Context, req *SetAccessListRequest) (*SetAccessListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccessList not implemented")
+}
+func (*UnimplementedAdminServer) GetAccessList(ctx context.Context, req *GetAccessListRequest) (*GetAccessListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessList not implemented")
+}
+func (*UnimplementedAdminServer) ExportThreadDAG(ctx context.Context, req *ExportThreadDAGRequest) (*ExportThreadDAGReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportThreadDAG not implemented")
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/CreateAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/ListAPIKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/RevokeAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportAuditLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAuditLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ExportAuditLog(m, &adminExportAuditLogServer{stream})
+}
+
+type Admin_ExportAuditLogServer interface {
+	Send(*AuditEntry) error
+	grpc.ServerStream
+}
+
+type adminExportAuditLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminExportAuditLogServer) Send(m *AuditEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_SetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/SetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetFaults(ctx, req.(*SetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/GetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetFaults(ctx, req.(*GetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetThreadSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetThreadSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/GetThreadSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetThreadSnapshot(ctx, req.(*GetThreadSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetAccessList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccessListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetAccessList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/SetAccessList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetAccessList(ctx, req.(*SetAccessListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetAccessList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetAccessList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/GetAccessList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetAccessList(ctx, req.(*GetAccessListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportThreadDAG_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportThreadDAGRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ExportThreadDAG(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.Admin/ExportThreadDAG",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ExportThreadDAG(ctx, req.(*ExportThreadDAGRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "threads.net.pb.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _Admin_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _Admin_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _Admin_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "SetFaults",
+			Handler:    _Admin_SetFaults_Handler,
+		},
+		{
+			MethodName: "GetFaults",
+			Handler:    _Admin_GetFaults_Handler,
+		},
+		{
+			MethodName: "GetThreadSnapshot",
+			Handler:    _Admin_GetThreadSnapshot_Handler,
+		},
+		{
+			MethodName: "SetAccessList",
+			Handler:    _Admin_SetAccessList_Handler,
+		},
+		{
+			MethodName: "GetAccessList",
+			Handler:    _Admin_GetAccessList_Handler,
+		},
+		{
+			MethodName: "ExportThreadDAG",
+			Handler:    _Admin_ExportThreadDAG_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAuditLog",
+			Handler:       _Admin_ExportAuditLog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "threadsnet.proto",
+}
+
+func (m *GetHostIDRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetHostIDRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetHostIDRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetHostIDReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetHostIDReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetHostIDReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PeerID) > 0 {
+		i -= len(m.PeerID)
+		copy(dAtA[i:], m.PeerID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PeerID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Payload != nil {
+		{
+			size := m.Payload.Size()
+			i -= size
+			if _, err := m.Payload.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetTokenRequest_Key) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokenRequest_Key) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.Key)
+	copy(dAtA[i:], m.Key)
+	i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Key)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+func (m *GetTokenRequest_Signature) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokenRequest_Signature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Signature != nil {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *GetTokenReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetTokenReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokenReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Payload != nil {
+		{
+			size := m.Payload.Size()
+			i -= size
+			if _, err := m.Payload.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetTokenReply_Challenge) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokenReply_Challenge) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Challenge != nil {
+		i -= len(m.Challenge)
+		copy(dAtA[i:], m.Challenge)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Challenge)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *GetTokenReply_Token) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokenReply_Token) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i -= len(m.Token)
+	copy(dAtA[i:], m.Token)
+	i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Token)))
+	i--
+	dAtA[i] = 0x12
+	return len(dAtA) - i, nil
+}
+func (m *CreateThreadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateThreadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Keys != nil {
+		{
+			size, err := m.Keys.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Keys) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Keys) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Keys) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LogKey) > 0 {
+		i -= len(m.LogKey)
+		copy(dAtA[i:], m.LogKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadKey) > 0 {
+		i -= len(m.ThreadKey)
+		copy(dAtA[i:], m.ThreadKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ThreadInfoReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThreadInfoReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ThreadInfoReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ThreadKey) > 0 {
+		i -= len(m.ThreadKey)
+		copy(dAtA[i:], m.ThreadKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Counter) > 0 {
+		i -= len(m.Counter)
+		copy(dAtA[i:], m.Counter)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Counter)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Head) > 0 {
+		i -= len(m.Head)
+		copy(dAtA[i:], m.Head)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Head)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.PrivKey) > 0 {
+		i -= len(m.PrivKey)
+		copy(dAtA[i:], m.PrivKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PrivKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PubKey) > 0 {
+		i -= len(m.PubKey)
+		copy(dAtA[i:], m.PubKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PubKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddThreadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddThreadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Keys != nil {
+		{
+			size, err := m.Keys.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetThreadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThreadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListThreadsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListThreadsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListThreadsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Health != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Health))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ActiveBefore != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.ActiveBefore))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ActiveSince != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.ActiveSince))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Tag) > 0 {
+		i -= len(m.Tag)
+		copy(dAtA[i:], m.Tag)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Tag)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Limit != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.PageToken) > 0 {
+		i -= len(m.PageToken)
+		copy(dAtA[i:], m.PageToken)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PageToken)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ThreadSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThreadSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ThreadSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.OverQuota {
+		i--
+		if m.OverQuota {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Quota != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Quota))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Usage != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Usage))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Health != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Health))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LastSync != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.LastSync))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LastActivity != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.LastActivity))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListThreadsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListThreadsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListThreadsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextPageToken) > 0 {
+		i -= len(m.NextPageToken)
+		copy(dAtA[i:], m.NextPageToken)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.NextPageToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Threads) > 0 {
+		for iNdEx := len(m.Threads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Threads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetThreadLogsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThreadLogsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetThreadLogsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.PageToken) > 0 {
+		i -= len(m.PageToken)
+		copy(dAtA[i:], m.PageToken)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PageToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetThreadLogsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThreadLogsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetThreadLogsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextPageToken) > 0 {
+		i -= len(m.NextPageToken)
+		copy(dAtA[i:], m.NextPageToken)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.NextPageToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActivityFeedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivityFeedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActivityFeedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Since != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Since))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Size_ != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Time != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Author) > 0 {
+		i -= len(m.Author)
+		copy(dAtA[i:], m.Author)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Author)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActivityFeedReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivityFeedReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActivityFeedReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Annotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Annotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Annotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Labels[iNdEx])
+			copy(dAtA[i:], m.Labels[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Labels[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Starred {
+		i--
+		if m.Starred {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Read {
+		i--
+		if m.Read {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordAnnotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordAnnotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordAnnotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.UpdatedAt != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.UpdatedAt))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Annotation != nil {
+		{
+			size, err := m.Annotation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AnnotateRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AnnotateRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AnnotateRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Annotation != nil {
+		{
+			size, err := m.Annotation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AnnotateRecordReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AnnotateRecordReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AnnotateRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAnnotationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAnnotationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAnnotationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAnnotationReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAnnotationReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAnnotationReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Annotation != nil {
+		{
+			size, err := m.Annotation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryAnnotationsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryAnnotationsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryAnnotationsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Label) > 0 {
+		i -= len(m.Label)
+		copy(dAtA[i:], m.Label)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Label)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Starred {
+		i--
+		if m.Starred {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Unread {
+		i--
+		if m.Unread {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryAnnotationsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryAnnotationsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryAnnotationsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Annotations) > 0 {
+		for iNdEx := len(m.Annotations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Annotations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PullThreadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PullThreadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PullThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PullThreadReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PullThreadReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PullThreadReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *PullThreadFromRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PullThreadFromRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PullThreadFromRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PullThreadFromReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PullThreadFromReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PullThreadFromReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteThreadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteThreadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteThreadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteThreadReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteThreadReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteThreadReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AddReplicatorRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddReplicatorRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddReplicatorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddReplicatorReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddReplicatorReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddReplicatorReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PeerID) > 0 {
+		i -= len(m.PeerID)
+		copy(dAtA[i:], m.PeerID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PeerID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Extensions) > 0 {
+		for iNdEx := len(m.Extensions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Extensions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Priority != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.RecordType) > 0 {
+		i -= len(m.RecordType)
+		copy(dAtA[i:], m.RecordType)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordType)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.IdempotencyKey) > 0 {
+		i -= len(m.IdempotencyKey)
+		copy(dAtA[i:], m.IdempotencyKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.IdempotencyKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Body) > 0 {
+		i -= len(m.Body)
+		copy(dAtA[i:], m.Body)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Body)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NewRecordReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewRecordReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NewRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Clock) > 0 {
+		for iNdEx := len(m.Clock) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Clock[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Record) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Record) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BodyNode) > 0 {
+		i -= len(m.BodyNode)
+		copy(dAtA[i:], m.BodyNode)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.BodyNode)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.HeaderNode) > 0 {
+		i -= len(m.HeaderNode)
+		copy(dAtA[i:], m.HeaderNode)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.HeaderNode)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.EventNode) > 0 {
+		i -= len(m.EventNode)
+		copy(dAtA[i:], m.EventNode)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.EventNode)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordNode) > 0 {
+		i -= len(m.RecordNode)
+		copy(dAtA[i:], m.RecordNode)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordNode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddRecordReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddRecordReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Resume) > 0 {
+		for iNdEx := len(m.Resume) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Resume[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ThreadIDs) > 0 {
+		for iNdEx := len(m.ThreadIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ThreadIDs[iNdEx])
+			copy(dAtA[i:], m.ThreadIDs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadIDs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeHeadsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeHeadsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeHeadsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ThreadIDs) > 0 {
+		for iNdEx := len(m.ThreadIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ThreadIDs[iNdEx])
+			copy(dAtA[i:], m.ThreadIDs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadIDs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogHead) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogHead) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogHead) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Counter != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Head) > 0 {
+		i -= len(m.Head)
+		copy(dAtA[i:], m.Head)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Head)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HeadsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeadsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeadsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Heads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Edge != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Edge))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PublishPresenceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishPresenceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PublishPresenceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IdentitySig) > 0 {
+		i -= len(m.IdentitySig)
+		copy(dAtA[i:], m.IdentitySig)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.IdentitySig)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Status != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PublishPresenceReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishPresenceReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PublishPresenceReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribePresenceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribePresenceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribePresenceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PresenceReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PresenceReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PresenceReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Status != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PeerID) > 0 {
+		i -= len(m.PeerID)
+		copy(dAtA[i:], m.PeerID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.PeerID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *APIKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *APIKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *APIKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CreatedAt != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.CreatedAt))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ThreadIDs) > 0 {
+		for iNdEx := len(m.ThreadIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ThreadIDs[iNdEx])
+			copy(dAtA[i:], m.ThreadIDs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Admin {
+		i--
+		if m.Admin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateAPIKeyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateAPIKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateAPIKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadIDs) > 0 {
+		for iNdEx := len(m.ThreadIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ThreadIDs[iNdEx])
+			copy(dAtA[i:], m.ThreadIDs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Admin {
+		i--
+		if m.Admin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateAPIKeyReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateAPIKeyReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateAPIKeyReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Secret) > 0 {
+		i -= len(m.Secret)
+		copy(dAtA[i:], m.Secret)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Secret)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Key != nil {
+		{
+			size, err := m.Key.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAPIKeysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAPIKeysRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAPIKeysRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ListAPIKeysReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListAPIKeysReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListAPIKeysReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Keys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeAPIKeyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeAPIKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeAPIKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeAPIKeyReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeAPIKeyReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeAPIKeyReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportAuditLogRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportAuditLogRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportAuditLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Until != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Until))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Since != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Since))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuditEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuditEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuditEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Outcome) > 0 {
+		i -= len(m.Outcome)
+		copy(dAtA[i:], m.Outcome)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Outcome)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.ApiKey) > 0 {
+		i -= len(m.ApiKey)
+		copy(dAtA[i:], m.ApiKey)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ApiKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Peer) > 0 {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Operation) > 0 {
+		i -= len(m.Operation)
+		copy(dAtA[i:], m.Operation)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Operation)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Kind) > 0 {
+		i -= len(m.Kind)
+		copy(dAtA[i:], m.Kind)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Kind)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Time != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Faults) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Faults) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Faults) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CorruptRecordBody != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.CorruptRecordBody))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.DelayExchangeEdges != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.DelayExchangeEdges))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.DropPushRecord != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.DropPushRecord))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetFaultsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetFaultsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetFaultsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Faults != nil {
+		{
+			size, err := m.Faults.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetFaultsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetFaultsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetFaultsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetFaultsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFaultsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFaultsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetFaultsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetFaultsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetFaultsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Faults != nil {
+		{
+			size, err := m.Faults.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Enabled {
+		i--
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetThreadSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThreadSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetThreadSnapshotRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetThreadSnapshotReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetThreadSnapshotReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetThreadSnapshotReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Snapshot) > 0 {
+		i -= len(m.Snapshot)
+		copy(dAtA[i:], m.Snapshot)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Snapshot)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateLogAddrsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateLogAddrsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateLogAddrsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateLogAddrsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateLogAddrsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateLogAddrsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeSyncProgressRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeSyncProgressRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeSyncProgressRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SyncProgressReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncProgressReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SyncProgressReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Eta != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Eta))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Bytes != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Expected != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Expected))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Records != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Records))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SyncProgressReply_LogSyncProgress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncProgressReply_LogSyncProgress) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SyncProgressReply_LogSyncProgress) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Applied != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.Applied))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LogID) > 0 {
+		i -= len(m.LogID)
+		copy(dAtA[i:], m.LogID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordExtension) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordExtension) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccessList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DenyNets) > 0 {
+		for iNdEx := len(m.DenyNets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DenyNets[iNdEx])
+			copy(dAtA[i:], m.DenyNets[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.DenyNets[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.DenyPeers) > 0 {
+		for iNdEx := len(m.DenyPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DenyPeers[iNdEx])
+			copy(dAtA[i:], m.DenyPeers[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.DenyPeers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.AllowNets) > 0 {
+		for iNdEx := len(m.AllowNets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowNets[iNdEx])
+			copy(dAtA[i:], m.AllowNets[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.AllowNets[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.AllowPeers) > 0 {
+		for iNdEx := len(m.AllowPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowPeers[iNdEx])
+			copy(dAtA[i:], m.AllowPeers[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.AllowPeers[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetAccessListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetAccessListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetAccessListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AccessList != nil {
+		{
+			size, err := m.AccessList.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetAccessListReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetAccessListReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetAccessListReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAccessListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAccessListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAccessListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAccessListReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAccessListReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAccessListReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AccessList != nil {
+		{
+			size, err := m.AccessList.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResumePoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResumePoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResumePoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Heads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintThreadsnet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportThreadDAGRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportThreadDAGRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportThreadDAGRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxHeight != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.MaxHeight))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MinHeight != 0 {
+		i = encodeVarintThreadsnet(dAtA, i, uint64(m.MinHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.LogIDs) > 0 {
+		for iNdEx := len(m.LogIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LogIDs[iNdEx])
+			copy(dAtA[i:], m.LogIDs[iNdEx])
+			i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.LogIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Format) > 0 {
+		i -= len(m.Format)
+		copy(dAtA[i:], m.Format)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Format)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ThreadID) > 0 {
+		i -= len(m.ThreadID)
+		copy(dAtA[i:], m.ThreadID)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.ThreadID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportThreadDAGReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportThreadDAGReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportThreadDAGReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Graph) > 0 {
+		i -= len(m.Graph)
+		copy(dAtA[i:], m.Graph)
+		i = encodeVarintThreadsnet(dAtA, i, uint64(len(m.Graph)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintThreadsnet(dAtA []byte, offset int, v uint64) int {
+	offset -= sovThreadsnet(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetHostIDRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetHostIDReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PeerID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetTokenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Payload != nil {
+		n += m.Payload.Size()
+	}
+	return n
+}
+
+func (m *GetTokenRequest_Key) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	n += 1 + l + sovThreadsnet(uint64(l))
+	return n
+}
+func (m *GetTokenRequest_Signature) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Signature != nil {
+		l = len(m.Signature)
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+func (m *GetTokenReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Payload != nil {
+		n += m.Payload.Size()
+	}
+	return n
+}
+
+func (m *GetTokenReply_Challenge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Challenge != nil {
+		l = len(m.Challenge)
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+func (m *GetTokenReply_Token) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	n += 1 + l + sovThreadsnet(uint64(l))
+	return n
+}
+func (m *CreateThreadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Keys != nil {
+		l = m.Keys.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Keys) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.LogKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *ThreadInfoReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.ThreadKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LogInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.PubKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.PrivKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	l = len(m.Head)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Counter)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *AddThreadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Keys != nil {
+		l = m.Keys.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetThreadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *ListThreadsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PageToken)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Limit))
+	}
+	l = len(m.Tag)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.ActiveSince != 0 {
+		n += 1 + sovThreadsnet(uint64(m.ActiveSince))
+	}
+	if m.ActiveBefore != 0 {
+		n += 1 + sovThreadsnet(uint64(m.ActiveBefore))
+	}
+	if m.Health != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Health))
+	}
+	return n
+}
+
+func (m *ThreadSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if m.LastActivity != 0 {
+		n += 1 + sovThreadsnet(uint64(m.LastActivity))
+	}
+	if m.LastSync != 0 {
+		n += 1 + sovThreadsnet(uint64(m.LastSync))
+	}
+	if m.Health != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Health))
+	}
+	if m.Usage != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Usage))
+	}
+	if m.Quota != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Quota))
+	}
+	if m.OverQuota {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListThreadsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Threads) > 0 {
+		for _, e := range m.Threads {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	l = len(m.NextPageToken)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetThreadLogsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.PageToken)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *GetThreadLogsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	l = len(m.NextPageToken)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivityFeedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Since != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Since))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *RecordSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Author)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Time))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Size_))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivityFeedReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Annotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Read {
+		n += 2
+	}
+	if m.Starred {
+		n += 2
+	}
+	if len(m.Labels) > 0 {
+		for _, s := range m.Labels {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RecordAnnotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Annotation != nil {
+		l = m.Annotation.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.UpdatedAt != 0 {
+		n += 1 + sovThreadsnet(uint64(m.UpdatedAt))
+	}
+	return n
+}
+
+func (m *AnnotateRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Annotation != nil {
+		l = m.Annotation.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *AnnotateRecordReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetAnnotationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetAnnotationReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Annotation != nil {
+		l = m.Annotation.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryAnnotationsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Unread {
+		n += 2
+	}
+	if m.Starred {
+		n += 2
+	}
+	l = len(m.Label)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryAnnotationsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Annotations) > 0 {
+		for _, e := range m.Annotations {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PullThreadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *PullThreadReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PullThreadFromRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *PullThreadFromReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *DeleteThreadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteThreadReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AddReplicatorRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *AddReplicatorReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PeerID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.IdempotencyKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.RecordType)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Priority))
+	}
+	if len(m.Extensions) > 0 {
+		for _, e := range m.Extensions {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NewRecordReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Clock) > 0 {
+		for _, e := range m.Clock {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AddRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *Record) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordNode)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.EventNode)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.HeaderNode)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.BodyNode)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *AddRecordReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ThreadIDs) > 0 {
+		for _, b := range m.ThreadIDs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.Resume) > 0 {
+		for _, e := range m.Resume {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubscribeHeadsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ThreadIDs) > 0 {
+		for _, b := range m.ThreadIDs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LogHead) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Head)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Counter))
+	}
+	return n
+}
+
+func (m *HeadsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Edge != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Edge))
+	}
+	if len(m.Heads) > 0 {
+		for _, e := range m.Heads {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PublishPresenceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Status))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Timestamp))
+	}
+	l = len(m.IdentitySig)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *PublishPresenceReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SubscribePresenceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *PresenceReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.PeerID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Status))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Time))
+	}
+	return n
+}
+
+func (m *APIKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Admin {
+		n += 2
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	if len(m.ThreadIDs) > 0 {
+		for _, b := range m.ThreadIDs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovThreadsnet(uint64(m.CreatedAt))
+	}
+	return n
+}
+
+func (m *CreateAPIKeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Admin {
+		n += 2
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	if len(m.ThreadIDs) > 0 {
+		for _, b := range m.ThreadIDs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CreateAPIKeyReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = m.Key.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *ListAPIKeysRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListAPIKeysReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, e := range m.Keys {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RevokeAPIKeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *RevokeAPIKeyReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ExportAuditLogRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Since != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Since))
+	}
+	if m.Until != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Until))
+	}
+	return n
+}
+
+func (m *AuditEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Time))
+	}
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Operation)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Peer)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.ApiKey)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Outcome)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *Faults) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DropPushRecord != 0 {
+		n += 1 + sovThreadsnet(uint64(m.DropPushRecord))
+	}
+	if m.DelayExchangeEdges != 0 {
+		n += 1 + sovThreadsnet(uint64(m.DelayExchangeEdges))
+	}
+	if m.CorruptRecordBody != 0 {
+		n += 1 + sovThreadsnet(uint64(m.CorruptRecordBody))
+	}
+	return n
+}
+
+func (m *SetFaultsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Faults != nil {
+		l = m.Faults.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *SetFaultsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetFaultsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetFaultsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if m.Faults != nil {
+		l = m.Faults.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetThreadSnapshotRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetThreadSnapshotReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Snapshot)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateLogAddrsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *UpdateLogAddrsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SubscribeSyncProgressRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *SyncProgressReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Records != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Records))
+	}
+	if m.Expected != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Expected))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Bytes))
+	}
+	if m.Eta != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Eta))
+	}
+	return n
+}
+
+func (m *SyncProgressReply_LogSyncProgress) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LogID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if m.Applied != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Applied))
+	}
+	if m.Height != 0 {
+		n += 1 + sovThreadsnet(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *RecordExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *AccessList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AllowPeers) > 0 {
+		for _, b := range m.AllowPeers {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.AllowNets) > 0 {
+		for _, s := range m.AllowNets {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.DenyPeers) > 0 {
+		for _, b := range m.DenyPeers {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if len(m.DenyNets) > 0 {
+		for _, s := range m.DenyNets {
+			l = len(s)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetAccessListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccessList != nil {
+		l = m.AccessList.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *SetAccessListReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetAccessListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetAccessListReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccessList != nil {
+		l = m.AccessList.Size()
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func (m *ResumePoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.Heads) > 0 {
+		for _, e := range m.Heads {
+			l = e.Size()
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExportThreadDAGRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ThreadID)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	l = len(m.Format)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	if len(m.LogIDs) > 0 {
+		for _, b := range m.LogIDs {
+			l = len(b)
+			n += 1 + l + sovThreadsnet(uint64(l))
+		}
+	}
+	if m.MinHeight != 0 {
+		n += 1 + sovThreadsnet(uint64(m.MinHeight))
+	}
+	if m.MaxHeight != 0 {
+		n += 1 + sovThreadsnet(uint64(m.MaxHeight))
+	}
+	return n
+}
+
+func (m *ExportThreadDAGReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Graph)
+	if l > 0 {
+		n += 1 + l + sovThreadsnet(uint64(l))
+	}
+	return n
+}
+
+func sovThreadsnet(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozThreadsnet(x uint64) (n int) {
+	return sovThreadsnet(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetHostIDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetHostIDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetHostIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetHostIDReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetHostIDReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetHostIDReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerID = append(m.PeerID[:0], dAtA[iNdEx:postIndex]...)
+			if m.PeerID == nil {
+				m.PeerID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetTokenRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetTokenRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = &GetTokenRequest_Key{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Payload = &GetTokenRequest_Signature{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetTokenReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetTokenReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetTokenReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Challenge", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Payload = &GetTokenReply_Challenge{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = &GetTokenReply_Token{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateThreadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateThreadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Keys == nil {
+				m.Keys = &Keys{}
+			}
+			if err := m.Keys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Keys) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Keys: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Keys: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadKey = append(m.ThreadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadKey == nil {
+				m.ThreadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogKey = append(m.LogKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogKey == nil {
+				m.LogKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThreadInfoReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThreadInfoReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadKey = append(m.ThreadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadKey == nil {
+				m.ThreadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &LogInfo{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PubKey = append(m.PubKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PubKey == nil {
+				m.PubKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrivKey = append(m.PrivKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrivKey == nil {
+				m.PrivKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Head = append(m.Head[:0], dAtA[iNdEx:postIndex]...)
+			if m.Head == nil {
+				m.Head = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Counter = append(m.Counter[:0], dAtA[iNdEx:postIndex]...)
+			if m.Counter == nil {
+				m.Counter = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddThreadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddThreadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Keys == nil {
+				m.Keys = &Keys{}
+			}
+			if err := m.Keys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetThreadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetThreadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListThreadsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListThreadsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListThreadsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveSince", wireType)
+			}
+			m.ActiveSince = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActiveSince |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveBefore", wireType)
+			}
+			m.ActiveBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActiveBefore |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+			}
+			m.Health = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Health |= SyncHealth(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThreadSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThreadSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThreadSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastActivity", wireType)
+			}
+			m.LastActivity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastActivity |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSync", wireType)
+			}
+			m.LastSync = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSync |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Health", wireType)
+			}
+			m.Health = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Health |= SyncHealth(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			m.Usage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Usage |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quota", wireType)
+			}
+			m.Quota = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Quota |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OverQuota", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OverQuota = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListThreadsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListThreadsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListThreadsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Threads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Threads = append(m.Threads, &ThreadSummary{})
+			if err := m.Threads[len(m.Threads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextPageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetThreadLogsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetThreadLogsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetThreadLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetThreadLogsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetThreadLogsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetThreadLogsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &LogInfo{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextPageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivityFeedRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivityFeedRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivityFeedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Since |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecordSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecordSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecordSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordID = append(m.RecordID[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordID == nil {
+				m.RecordID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogID == nil {
+				m.LogID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Author = append(m.Author[:0], dAtA[iNdEx:postIndex]...)
+			if m.Author == nil {
+				m.Author = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivityFeedReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivityFeedReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivityFeedReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, &RecordSummary{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Annotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Annotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Annotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Read", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Read = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Starred", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Starred = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecordAnnotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecordAnnotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecordAnnotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordID = append(m.RecordID[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordID == nil {
+				m.RecordID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Annotation == nil {
+				m.Annotation = &Annotation{}
+			}
+			if err := m.Annotation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			m.UpdatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AnnotateRecordRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AnnotateRecordRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AnnotateRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordID = append(m.RecordID[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordID == nil {
+				m.RecordID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Annotation == nil {
+				m.Annotation = &Annotation{}
+			}
+			if err := m.Annotation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AnnotateRecordReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AnnotateRecordReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AnnotateRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAnnotationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAnnotationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAnnotationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordID = append(m.RecordID[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordID == nil {
+				m.RecordID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAnnotationReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAnnotationReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAnnotationReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Annotation == nil {
+				m.Annotation = &RecordAnnotation{}
+			}
+			if err := m.Annotation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryAnnotationsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryAnnotationsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryAnnotationsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unread", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Unread = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Starred", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Starred = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryAnnotationsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryAnnotationsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryAnnotationsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Annotations = append(m.Annotations, &RecordAnnotation{})
+			if err := m.Annotations[len(m.Annotations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PullThreadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PullThreadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PullThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PullThreadReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PullThreadReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PullThreadReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PullThreadFromRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PullThreadFromRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PullThreadFromRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PullThreadFromReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PullThreadFromReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PullThreadFromReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteThreadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteThreadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteThreadReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteThreadReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteThreadReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddReplicatorRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddReplicatorRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddReplicatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddReplicatorReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddReplicatorReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddReplicatorReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerID = append(m.PeerID[:0], dAtA[iNdEx:postIndex]...)
+			if m.PeerID == nil {
+				m.PeerID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateRecordRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateRecordRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
+			if m.Body == nil {
+				m.Body = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdempotencyKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdempotencyKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extensions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extensions = append(m.Extensions, &RecordExtension{})
+			if err := m.Extensions[len(m.Extensions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewRecordReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewRecordReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogID == nil {
+				m.LogID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Clock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Clock = append(m.Clock, &LogHead{})
+			if err := m.Clock[len(m.Clock)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddRecordRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogID == nil {
+				m.LogID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Record) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordNode = append(m.RecordNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordNode == nil {
+				m.RecordNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventNode = append(m.EventNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.EventNode == nil {
+				m.EventNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderNode = append(m.HeaderNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.HeaderNode == nil {
+				m.HeaderNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BodyNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BodyNode = append(m.BodyNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.BodyNode == nil {
+				m.BodyNode = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddRecordReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddRecordReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordID = append(m.RecordID[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordID == nil {
+				m.RecordID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadIDs = append(m.ThreadIDs, make([]byte, postIndex-iNdEx))
+			copy(m.ThreadIDs[len(m.ThreadIDs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resume", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Resume = append(m.Resume, &ResumePoint{})
+			if err := m.Resume[len(m.Resume)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeHeadsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeHeadsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeHeadsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadIDs = append(m.ThreadIDs, make([]byte, postIndex-iNdEx))
+			copy(m.ThreadIDs[len(m.ThreadIDs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogHead) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogHead: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogHead: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogID == nil {
+				m.LogID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Head = append(m.Head[:0], dAtA[iNdEx:postIndex]...)
+			if m.Head == nil {
+				m.Head = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeadsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeadsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeadsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Edge", wireType)
+			}
+			m.Edge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Edge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Heads = append(m.Heads, &LogHead{})
+			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PublishPresenceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishPresenceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishPresenceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= PresenceStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdentitySig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdentitySig = append(m.IdentitySig[:0], dAtA[iNdEx:postIndex]...)
+			if m.IdentitySig == nil {
+				m.IdentitySig = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PublishPresenceReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishPresenceReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishPresenceReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribePresenceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribePresenceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribePresenceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PresenceReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PresenceReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PresenceReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerID = append(m.PeerID[:0], dAtA[iNdEx:postIndex]...)
+			if m.PeerID == nil {
+				m.PeerID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= PresenceStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *APIKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: APIKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: APIKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Admin = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ThreadIDs = append(m.ThreadIDs, make([]byte, postIndex-iNdEx))
+			copy(m.ThreadIDs[len(m.ThreadIDs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetHostIDRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetHostIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -3365,7 +17353,7 @@ func (m *GetHostIDRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetHostIDReply) Unmarshal(dAtA []byte) error {
+func (m *CreateAPIKeyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3388,15 +17376,55 @@ func (m *GetHostIDReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetHostIDReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateAPIKeyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetHostIDReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateAPIKeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Admin = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadIDs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3423,10 +17451,8 @@ func (m *GetHostIDReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerID = append(m.PeerID[:0], dAtA[iNdEx:postIndex]...)
-			if m.PeerID == nil {
-				m.PeerID = []byte{}
-			}
+			m.ThreadIDs = append(m.ThreadIDs, make([]byte, postIndex-iNdEx))
+			copy(m.ThreadIDs[len(m.ThreadIDs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3449,7 +17475,7 @@ func (m *GetHostIDReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetTokenRequest) Unmarshal(dAtA []byte) error {
+func (m *CreateAPIKeyReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3472,17 +17498,17 @@ func (m *GetTokenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetTokenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateAPIKeyReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateAPIKeyReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3492,29 +17518,33 @@ func (m *GetTokenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = &GetTokenRequest_Key{string(dAtA[iNdEx:postIndex])}
+			if m.Key == nil {
+				m.Key = &APIKey{}
+			}
+			if err := m.Key.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3524,24 +17554,23 @@ func (m *GetTokenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := make([]byte, postIndex-iNdEx)
-			copy(v, dAtA[iNdEx:postIndex])
-			m.Payload = &GetTokenRequest_Signature{v}
+			m.Secret = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3564,7 +17593,7 @@ func (m *GetTokenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetTokenReply) Unmarshal(dAtA []byte) error {
+func (m *ListAPIKeysRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3587,50 +17616,67 @@ func (m *GetTokenReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetTokenReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListAPIKeysRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetTokenReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListAPIKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Challenge", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListAPIKeysReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := make([]byte, postIndex-iNdEx)
-			copy(v, dAtA[iNdEx:postIndex])
-			m.Payload = &GetTokenReply_Challenge{v}
-			iNdEx = postIndex
-		case 2:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListAPIKeysReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListAPIKeysReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3640,23 +17686,25 @@ func (m *GetTokenReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = &GetTokenReply_Token{string(dAtA[iNdEx:postIndex])}
+			m.Keys = append(m.Keys, &APIKey{})
+			if err := m.Keys[len(m.Keys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3679,7 +17727,7 @@ func (m *GetTokenReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateThreadRequest) Unmarshal(dAtA []byte) error {
+func (m *RevokeAPIKeyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3702,51 +17750,17 @@ func (m *CreateThreadRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateThreadRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeAPIKeyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeAPIKeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadID == nil {
-				m.ThreadID = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3756,28 +17770,74 @@ func (m *CreateThreadRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Keys == nil {
-				m.Keys = &Keys{}
-			}
-			if err := m.Keys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevokeAPIKeyReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevokeAPIKeyReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevokeAPIKeyReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -3799,7 +17859,7 @@ func (m *CreateThreadRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Keys) Unmarshal(dAtA []byte) error {
+func (m *ExportAuditLogRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3822,17 +17882,17 @@ func (m *Keys) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Keys: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportAuditLogRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Keys: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportAuditLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
 			}
-			var byteLen int
+			m.Since = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3842,31 +17902,16 @@ func (m *Keys) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Since |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ThreadKey = append(m.ThreadKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadKey == nil {
-				m.ThreadKey = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Until", wireType)
 			}
-			var byteLen int
+			m.Until = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3876,26 +17921,11 @@ func (m *Keys) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Until |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LogKey = append(m.LogKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.LogKey == nil {
-				m.LogKey = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -3917,7 +17947,7 @@ func (m *Keys) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
+func (m *AuditEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3940,17 +17970,17 @@ func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ThreadInfoReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ThreadInfoReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var byteLen int
+			m.Time = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3960,31 +17990,16 @@ func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Time |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadID == nil {
-				m.ThreadID = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -3994,31 +18009,29 @@ func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThreadKey = append(m.ThreadKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadKey == nil {
-				m.ThreadKey = []byte{}
-			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4028,31 +18041,29 @@ func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &LogInfo{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Operation = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4062,79 +18073,29 @@ func (m *ThreadInfoReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			m.Peer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipThreadsnet(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LogInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowThreadsnet
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LogInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ApiKey", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4144,31 +18105,29 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
+			m.ApiKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4178,31 +18137,29 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PubKey = append(m.PubKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.PubKey == nil {
-				m.PubKey = []byte{}
-			}
+			m.ThreadID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrivKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4212,31 +18169,29 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PrivKey = append(m.PrivKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.PrivKey == nil {
-				m.PrivKey = []byte{}
-			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4246,29 +18201,29 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			m.RecordID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Outcome", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4278,31 +18233,29 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Head = append(m.Head[:0], dAtA[iNdEx:postIndex]...)
-			if m.Head == nil {
-				m.Head = []byte{}
-			}
+			m.Outcome = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4312,25 +18265,23 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Counter = append(m.Counter[:0], dAtA[iNdEx:postIndex]...)
-			if m.Counter == nil {
-				m.Counter = []byte{}
-			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4353,7 +18304,7 @@ func (m *LogInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddThreadRequest) Unmarshal(dAtA []byte) error {
+func (m *Faults) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4376,17 +18327,17 @@ func (m *AddThreadRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddThreadRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Faults: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Faults: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DropPushRecord", wireType)
 			}
-			var byteLen int
+			m.DropPushRecord = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4396,29 +18347,102 @@ func (m *AddThreadRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.DropPushRecord |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DelayExchangeEdges", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			m.DelayExchangeEdges = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DelayExchangeEdges |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CorruptRecordBody", wireType)
+			}
+			m.CorruptRecordBody = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CorruptRecordBody |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetFaultsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
 			}
-			iNdEx = postIndex
-		case 2:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetFaultsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetFaultsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Faults", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4445,10 +18469,10 @@ func (m *AddThreadRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Keys == nil {
-				m.Keys = &Keys{}
+			if m.Faults == nil {
+				m.Faults = &Faults{}
 			}
-			if err := m.Keys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Faults.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4473,7 +18497,7 @@ func (m *AddThreadRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetThreadRequest) Unmarshal(dAtA []byte) error {
+func (m *SetFaultsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4496,17 +18520,137 @@ func (m *GetThreadRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetThreadRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetFaultsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetFaultsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetFaultsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetFaultsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetFaultsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetFaultsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetFaultsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetFaultsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Faults", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -4516,24 +18660,26 @@ func (m *GetThreadRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadID == nil {
-				m.ThreadID = []byte{}
+			if m.Faults == nil {
+				m.Faults = &Faults{}
+			}
+			if err := m.Faults.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -4557,7 +18703,7 @@ func (m *GetThreadRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PullThreadRequest) Unmarshal(dAtA []byte) error {
+func (m *GetThreadSnapshotRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4580,10 +18726,10 @@ func (m *PullThreadRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PullThreadRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetThreadSnapshotRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PullThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetThreadSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4641,7 +18787,7 @@ func (m *PullThreadRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PullThreadReply) Unmarshal(dAtA []byte) error {
+func (m *GetThreadSnapshotReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4664,12 +18810,46 @@ func (m *PullThreadReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PullThreadReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetThreadSnapshotReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PullThreadReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetThreadSnapshotReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Snapshot = append(m.Snapshot[:0], dAtA[iNdEx:postIndex]...)
+			if m.Snapshot == nil {
+				m.Snapshot = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -4691,7 +18871,7 @@ func (m *PullThreadReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteThreadRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateLogAddrsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4714,10 +18894,10 @@ func (m *DeleteThreadRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteThreadRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateLogAddrsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteThreadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateLogAddrsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4754,6 +18934,72 @@ func (m *DeleteThreadRequest) Unmarshal(dAtA []byte) error {
 				m.ThreadID = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogID == nil {
+				m.LogID = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -4775,7 +19021,7 @@ func (m *DeleteThreadRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteThreadReply) Unmarshal(dAtA []byte) error {
+func (m *UpdateLogAddrsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4798,10 +19044,10 @@ func (m *DeleteThreadReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteThreadReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateLogAddrsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteThreadReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateLogAddrsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -4825,7 +19071,7 @@ func (m *DeleteThreadReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddReplicatorRequest) Unmarshal(dAtA []byte) error {
+func (m *SubscribeSyncProgressRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4848,10 +19094,10 @@ func (m *AddReplicatorRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddReplicatorRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubscribeSyncProgressRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddReplicatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubscribeSyncProgressRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4888,40 +19134,6 @@ func (m *AddReplicatorRequest) Unmarshal(dAtA []byte) error {
 				m.ThreadID = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -4943,7 +19155,7 @@ func (m *AddReplicatorRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddReplicatorReply) Unmarshal(dAtA []byte) error {
+func (m *SyncProgressReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4966,15 +19178,15 @@ func (m *AddReplicatorReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddReplicatorReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: SyncProgressReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddReplicatorReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SyncProgressReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5001,11 +19213,121 @@ func (m *AddReplicatorReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerID = append(m.PeerID[:0], dAtA[iNdEx:postIndex]...)
-			if m.PeerID == nil {
-				m.PeerID = []byte{}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			m.Records = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Records |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			m.Expected = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Expected |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &SyncProgressReply_LogSyncProgress{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Eta", wireType)
+			}
+			m.Eta = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Eta |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -5027,7 +19349,7 @@ func (m *AddReplicatorReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateRecordRequest) Unmarshal(dAtA []byte) error {
+func (m *SyncProgressReply_LogSyncProgress) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5050,15 +19372,15 @@ func (m *CreateRecordRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateRecordRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogSyncProgress: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogSyncProgress: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5085,16 +19407,16 @@ func (m *CreateRecordRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadID == nil {
-				m.ThreadID = []byte{}
+			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
+			if m.LogID == nil {
+				m.LogID = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Applied", wireType)
 			}
-			var byteLen int
+			m.Applied = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -5104,26 +19426,30 @@ func (m *CreateRecordRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Applied |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.Body = append(m.Body[:0], dAtA[iNdEx:postIndex]...)
-			if m.Body == nil {
-				m.Body = []byte{}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -5145,7 +19471,7 @@ func (m *CreateRecordRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NewRecordReply) Unmarshal(dAtA []byte) error {
+func (m *RecordExtension) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5168,17 +19494,17 @@ func (m *NewRecordReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NewRecordReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: RecordExtension: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NewRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RecordExtension: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -5188,29 +19514,27 @@ func (m *NewRecordReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadID == nil {
-				m.ThreadID = []byte{}
-			}
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5237,45 +19561,9 @@ func (m *NewRecordReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
-			if m.LogID == nil {
-				m.LogID = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Record == nil {
-				m.Record = &Record{}
-			}
-			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5299,7 +19587,7 @@ func (m *NewRecordReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
+func (m *AccessList) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5322,15 +19610,15 @@ func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddRecordRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AccessList: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AccessList: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowPeers", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5357,14 +19645,44 @@ func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ThreadID == nil {
-				m.ThreadID = []byte{}
-			}
+			m.AllowPeers = append(m.AllowPeers, make([]byte, postIndex-iNdEx))
+			copy(m.AllowPeers[len(m.AllowPeers)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowNets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowNets = append(m.AllowNets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DenyPeers", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5391,16 +19709,14 @@ func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogID = append(m.LogID[:0], dAtA[iNdEx:postIndex]...)
-			if m.LogID == nil {
-				m.LogID = []byte{}
-			}
+			m.DenyPeers = append(m.DenyPeers, make([]byte, postIndex-iNdEx))
+			copy(m.DenyPeers[len(m.DenyPeers)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DenyNets", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -5410,27 +19726,23 @@ func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Record == nil {
-				m.Record = &Record{}
-			}
-			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DenyNets = append(m.DenyNets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5453,7 +19765,7 @@ func (m *AddRecordRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Record) Unmarshal(dAtA []byte) error {
+func (m *SetAccessListRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5476,17 +19788,17 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetAccessListRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetAccessListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RecordNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessList", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -5496,128 +19808,128 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RecordNode = append(m.RecordNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.RecordNode == nil {
-				m.RecordNode = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventNode", wireType)
+			if m.AccessList == nil {
+				m.AccessList = &AccessList{}
 			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.AccessList.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EventNode = append(m.EventNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.EventNode == nil {
-				m.EventNode = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeaderNode", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthThreadsnet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetAccessListReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.HeaderNode = append(m.HeaderNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.HeaderNode == nil {
-				m.HeaderNode = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BodyNode", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowThreadsnet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetAccessListReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetAccessListReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipThreadsnet(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthThreadsnet
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAccessListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowThreadsnet
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BodyNode = append(m.BodyNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.BodyNode == nil {
-				m.BodyNode = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAccessListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAccessListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -5639,7 +19951,7 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddRecordReply) Unmarshal(dAtA []byte) error {
+func (m *GetAccessListReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5662,12 +19974,48 @@ func (m *AddRecordReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddRecordReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAccessListReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAccessListReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessList", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AccessList == nil {
+				m.AccessList = &AccessList{}
+			}
+			if err := m.AccessList.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -5689,7 +20037,7 @@ func (m *AddRecordReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordRequest) Unmarshal(dAtA []byte) error {
+func (m *ResumePoint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5712,10 +20060,10 @@ func (m *GetRecordRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResumePoint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResumePoint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5754,9 +20102,9 @@ func (m *GetRecordRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -5766,24 +20114,24 @@ func (m *GetRecordRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RecordID = append(m.RecordID[:0], dAtA[iNdEx:postIndex]...)
-			if m.RecordID == nil {
-				m.RecordID = []byte{}
+			m.Heads = append(m.Heads, &LogHead{})
+			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -5807,7 +20155,7 @@ func (m *GetRecordRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordReply) Unmarshal(dAtA []byte) error {
+func (m *ExportThreadDAGRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5830,17 +20178,17 @@ func (m *GetRecordReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportThreadDAGRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportThreadDAGRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowThreadsnet
@@ -5850,28 +20198,128 @@ func (m *GetRecordReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthThreadsnet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Record == nil {
-				m.Record = &Record{}
+			m.ThreadID = append(m.ThreadID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ThreadID == nil {
+				m.ThreadID = []byte{}
 			}
-			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Format = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthThreadsnet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthThreadsnet
 			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogIDs = append(m.LogIDs, make([]byte, postIndex-iNdEx))
+			copy(m.LogIDs[len(m.LogIDs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinHeight", wireType)
+			}
+			m.MinHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxHeight", wireType)
+			}
+			m.MaxHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowThreadsnet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipThreadsnet(dAtA[iNdEx:])
@@ -5893,7 +20341,7 @@ func (m *GetRecordReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+func (m *ExportThreadDAGReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5916,15 +20364,15 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportThreadDAGReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportThreadDAGReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Graph", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5951,8 +20399,10 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThreadIDs = append(m.ThreadIDs, make([]byte, postIndex-iNdEx))
-			copy(m.ThreadIDs[len(m.ThreadIDs)-1], dAtA[iNdEx:postIndex])
+			m.Graph = append(m.Graph[:0], dAtA[iNdEx:postIndex]...)
+			if m.Graph == nil {
+				m.Graph = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
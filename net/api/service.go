@@ -4,11 +4,12 @@ package api
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
-	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
@@ -16,15 +17,15 @@ import (
 	"github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/logger"
 	pb "github.com/textileio/go-threads/net/api/pb"
 	"github.com/textileio/go-threads/net/util"
-	tutil "github.com/textileio/go-threads/util"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 var (
-	log = logging.Logger("netapi")
+	log = logger.Logger("netapi")
 )
 
 // Service is a gRPC service for a thread network.
@@ -41,8 +42,8 @@ type Config struct {
 func NewService(network net.Net, conf Config) (*Service, error) {
 	var err error
 	if conf.Debug {
-		err = tutil.SetLogLevels(map[string]logging.LogLevel{
-			"netapi": logging.LevelDebug,
+		err = logger.SetLevels(map[string]logger.Level{
+			"netapi": logger.LevelDebug,
 		})
 		if err != nil {
 			return nil, err
@@ -171,7 +172,7 @@ func (s *Service) CreateThread(ctx context.Context, req *pb.CreateThreadRequest)
 	if err != nil {
 		return nil, err
 	}
-	opts = append(opts, net.WithNewThreadToken(token))
+	opts = append(opts, net.WithNewThreadToken(token), net.WithNewThreadTags(req.Tags...))
 	info, err := s.net.CreateThread(ctx, id, opts...)
 	if err != nil {
 		return nil, err
@@ -194,7 +195,7 @@ func (s *Service) AddThread(ctx context.Context, req *pb.AddThreadRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
-	opts = append(opts, net.WithNewThreadToken(token))
+	opts = append(opts, net.WithNewThreadToken(token), net.WithNewThreadTags(req.Tags...))
 	info, err := s.net.AddThread(ctx, addr, opts...)
 	if err != nil {
 		return nil, err
@@ -225,6 +226,116 @@ func (s *Service) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.
 	return threadInfoToProto(info)
 }
 
+func (s *Service) ListThreads(ctx context.Context, req *pb.ListThreadsRequest) (*pb.ListThreadsReply, error) {
+	log.Debugf("received list threads request")
+
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []net.ListOption{
+		net.WithListToken(token),
+		net.WithPageToken(req.PageToken),
+		net.WithPageLimit(int(req.Limit)),
+		net.WithTagFilter(req.Tag),
+		net.WithSyncHealthFilter(net.SyncHealth(req.Health)),
+	}
+	var since, before time.Time
+	if req.ActiveSince != 0 {
+		since = time.Unix(0, req.ActiveSince)
+	}
+	if req.ActiveBefore != 0 {
+		before = time.Unix(0, req.ActiveBefore)
+	}
+	opts = append(opts, net.WithActivityWindow(since, before))
+	page, err := s.net.ListThreads(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	threads := make([]*pb.ThreadSummary, len(page.Threads))
+	for i, t := range page.Threads {
+		threads[i] = &pb.ThreadSummary{
+			ThreadID:  t.ID.Bytes(),
+			Tags:      t.Tags,
+			Health:    pb.SyncHealth(t.Health),
+			Usage:     t.Usage,
+			Quota:     t.Quota,
+			OverQuota: t.OverQuota,
+		}
+		if !t.LastActivity.IsZero() {
+			threads[i].LastActivity = t.LastActivity.UnixNano()
+		}
+		if !t.LastSync.IsZero() {
+			threads[i].LastSync = t.LastSync.UnixNano()
+		}
+	}
+	return &pb.ListThreadsReply{
+		Threads:       threads,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (s *Service) GetThreadLogs(ctx context.Context, req *pb.GetThreadLogsRequest) (*pb.GetThreadLogsReply, error) {
+	log.Debugf("received get thread logs request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.net.GetThreadLogs(
+		ctx,
+		id,
+		net.WithListToken(token),
+		net.WithPageToken(req.PageToken),
+		net.WithPageLimit(int(req.Limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]*pb.LogInfo, len(page.Logs))
+	for i, lg := range page.Logs {
+		if logs[i], err = logInfoToProto(lg); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.GetThreadLogsReply{
+		Logs:          logs,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (s *Service) ActivityFeed(ctx context.Context, req *pb.ActivityFeedRequest) (*pb.ActivityFeedReply, error) {
+	log.Debugf("received activity feed request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(0, req.Since)
+	}
+	feed, err := s.net.ActivityFeed(ctx, id, since, int(req.Limit), net.WithThreadToken(token))
+	if err != nil {
+		return nil, err
+	}
+	recs := make([]*pb.RecordSummary, len(feed))
+	for i, r := range feed {
+		if recs[i], err = recordSummaryToProto(r); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.ActivityFeedReply{Records: recs}, nil
+}
+
 func (s *Service) PullThread(ctx context.Context, req *pb.PullThreadRequest) (*pb.PullThreadReply, error) {
 	log.Debugf("received pull thread request")
 
@@ -242,6 +353,27 @@ func (s *Service) PullThread(ctx context.Context, req *pb.PullThreadRequest) (*p
 	return &pb.PullThreadReply{}, nil
 }
 
+func (s *Service) PullThreadFrom(ctx context.Context, req *pb.PullThreadFromRequest) (*pb.PullThreadFromReply, error) {
+	log.Debugf("received pull thread from request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	addr, err := ma.NewMultiaddrBytes(req.Addr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.net.PullThreadFrom(ctx, id, addr, net.WithThreadToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.PullThreadFromReply{}, nil
+}
+
 func (s *Service) DeleteThread(ctx context.Context, req *pb.DeleteThreadRequest) (*pb.DeleteThreadReply, error) {
 	log.Debugf("received delete thread request")
 
@@ -283,6 +415,33 @@ func (s *Service) AddReplicator(ctx context.Context, req *pb.AddReplicatorReques
 	}, nil
 }
 
+func (s *Service) UpdateLogAddrs(ctx context.Context, req *pb.UpdateLogAddrsRequest) (*pb.UpdateLogAddrsReply, error) {
+	log.Debugf("received update log addrs request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	lid, err := peer.IDFromBytes(req.LogID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	addrs := make([]ma.Multiaddr, len(req.Addrs))
+	for i, a := range req.Addrs {
+		if addrs[i], err = ma.NewMultiaddrBytes(a); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.net.UpdateLogAddrs(ctx, id, lid, addrs, net.WithThreadToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateLogAddrsReply{}, nil
+}
+
 func (s *Service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest) (*pb.NewRecordReply, error) {
 	log.Debugf("received create record request")
 
@@ -298,8 +457,32 @@ func (s *Service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 	if err != nil {
 		return nil, err
 	}
-	rec, err := s.net.CreateRecord(ctx, id, body, net.WithThreadToken(token))
-	if err != nil {
+	var exts net.RecordExtensions
+	if len(req.Extensions) > 0 {
+		exts = make(net.RecordExtensions, len(req.Extensions))
+		for _, e := range req.Extensions {
+			exts[e.Key] = e.Value
+		}
+	}
+	rec, err := s.net.CreateRecord(
+		ctx,
+		id,
+		body,
+		net.WithThreadToken(token),
+		net.WithIdempotencyKey(req.IdempotencyKey),
+		net.WithRecordType(req.RecordType),
+		net.WithRecordPriority(net.RecordPriority(req.Priority)),
+		net.WithRecordExtensions(exts),
+	)
+	if errors.Is(err, net.ErrInvalidExtensionKey) ||
+		errors.Is(err, net.ErrExtensionNamespaceUnknown) ||
+		errors.Is(err, net.ErrRecordExtensionsTooLarge) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if errors.Is(err, net.ErrRecordTooLarge) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	} else if errors.Is(err, net.ErrLogFenced) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
 		return nil, err
 	}
 	prec, err := cbor.RecordToProto(ctx, s.net, rec.Value())
@@ -310,6 +493,7 @@ func (s *Service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest)
 		ThreadID: rec.ThreadID().Bytes(),
 		LogID:    marshalPeerID(rec.LogID()),
 		Record:   util.RecFromServiceRec(prec),
+		Clock:    clockToProto(rec.Clock()),
 	}, nil
 }
 
@@ -336,7 +520,9 @@ func (s *Service) AddRecord(ctx context.Context, req *pb.AddRecordRequest) (*pb.
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if err = s.net.AddRecord(ctx, id, logID, rec, net.WithThreadToken(token)); err != nil {
+	if err = s.net.AddRecord(ctx, id, logID, rec, net.WithThreadToken(token)); errors.Is(err, net.ErrRecordTooLarge) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	} else if err != nil {
 		return nil, err
 	}
 	return &pb.AddRecordReply{}, nil
@@ -370,6 +556,90 @@ func (s *Service) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.
 	}, nil
 }
 
+func (s *Service) AnnotateRecord(ctx context.Context, req *pb.AnnotateRecordRequest) (*pb.AnnotateRecordReply, error) {
+	log.Debugf("received annotate record request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rid, err := cid.Cast(req.RecordID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var a net.Annotation
+	if req.Annotation != nil {
+		a = annotationFromProto(req.Annotation)
+	}
+	err = s.net.AnnotateRecord(ctx, id, rid, a, net.WithThreadToken(token))
+	if errors.Is(err, net.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return &pb.AnnotateRecordReply{}, nil
+}
+
+func (s *Service) GetAnnotation(ctx context.Context, req *pb.GetAnnotationRequest) (*pb.GetAnnotationReply, error) {
+	log.Debugf("received get annotation request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rid, err := cid.Cast(req.RecordID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.net.GetAnnotation(ctx, id, rid, net.WithThreadToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAnnotationReply{
+		Annotation: recordAnnotationToProto(a),
+	}, nil
+}
+
+func (s *Service) QueryAnnotations(ctx context.Context, req *pb.QueryAnnotationsRequest) (*pb.QueryAnnotationsReply, error) {
+	log.Debugf("received query annotations request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []net.AnnotationOption{net.WithAnnotationToken(token)}
+	if req.Unread {
+		opts = append(opts, net.WithUnreadFilter())
+	}
+	if req.Starred {
+		opts = append(opts, net.WithStarredFilter())
+	}
+	if len(req.Label) != 0 {
+		opts = append(opts, net.WithLabelFilter(req.Label))
+	}
+	list, err := s.net.QueryAnnotations(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	annotations := make([]*pb.RecordAnnotation, len(list))
+	for i, a := range list {
+		annotations[i] = recordAnnotationToProto(a)
+	}
+	return &pb.QueryAnnotationsReply{Annotations: annotations}, nil
+}
+
 func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeServer) error {
 	log.Debugf("received subscribe request")
 
@@ -381,6 +651,9 @@ func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 		}
 		opts[i] = net.WithSubFilter(id)
 	}
+	for _, tag := range req.Tags {
+		opts = append(opts, net.WithSubTag(tag))
+	}
 
 	token, err := thread.NewTokenFromMD(server.Context())
 	if err != nil {
@@ -388,19 +661,148 @@ func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 	}
 	opts = append(opts, net.WithSubToken(token))
 
+	// subscribe before replaying missed records, so records added meanwhile aren't lost
 	sub, err := s.net.Subscribe(server.Context(), opts...)
 	if err != nil {
 		return err
 	}
-	for rec := range sub {
-		prec, err := cbor.RecordToProto(server.Context(), s.net, rec.Value())
+	replayed := make(map[thread.ID]net.VectorClock, len(req.Resume))
+	for _, rp := range req.Resume {
+		id, heights, err := resumePointFromProto(rp)
 		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if replayed[id], err = s.replayRecords(server, id, heights, token); err != nil {
 			return err
 		}
-		if err := server.Send(&pb.NewRecordReply{
-			ThreadID: rec.ThreadID().Bytes(),
-			LogID:    marshalPeerID(rec.LogID()),
-			Record:   util.RecFromServiceRec(prec),
+	}
+	for rec := range sub {
+		if clock, ok := replayed[rec.ThreadID()]; ok {
+			if height, ok := rec.Clock()[rec.LogID()]; ok && height <= clock[rec.LogID()] {
+				continue
+			}
+		}
+		if err := s.sendRecord(server, rec.ThreadID(), rec.LogID(), rec.Value(), rec.Clock()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayRecords sends the records of a thread added after the given log heights, oldest
+// first in each log. It returns the log heights the records were replayed up to.
+func (s *Service) replayRecords(
+	server pb.API_SubscribeServer,
+	id thread.ID,
+	heights net.VectorClock,
+	token thread.Token,
+) (net.VectorClock, error) {
+	ctx := server.Context()
+	info, err := s.net.GetThread(ctx, id, net.WithThreadToken(token))
+	if err != nil {
+		return nil, err
+	}
+	clock := net.VectorClockFromInfo(info)
+	for _, lg := range info.Logs {
+		var (
+			missed  []net.Record
+			rid     = lg.Head.ID
+			counter = lg.Head.Counter
+		)
+		for ; counter > heights[lg.ID] && rid.Defined(); counter-- {
+			rec, err := s.net.GetRecord(ctx, id, rid, net.WithThreadToken(token))
+			if err != nil {
+				// records before a pruned base can't be replayed
+				log.Warnf("replaying records of log %s (thread %s) stopped at %s: %v", lg.ID, id, rid, err)
+				break
+			}
+			missed = append(missed, rec)
+			rid = rec.PrevID()
+		}
+		for i := len(missed) - 1; i >= 0; i-- {
+			counter++
+			// replayed records carry the height of their own log only
+			if err := s.sendRecord(server, id, lg.ID, missed[i], net.VectorClock{lg.ID: counter}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return clock, nil
+}
+
+func (s *Service) sendRecord(
+	server pb.API_SubscribeServer,
+	id thread.ID,
+	lid peer.ID,
+	rec net.Record,
+	clock net.VectorClock,
+) error {
+	prec, err := cbor.RecordToProto(server.Context(), s.net, rec)
+	if err != nil {
+		return err
+	}
+	return server.Send(&pb.NewRecordReply{
+		ThreadID: id.Bytes(),
+		LogID:    marshalPeerID(lid),
+		Record:   util.RecFromServiceRec(prec),
+		Clock:    clockToProto(clock),
+	})
+}
+
+func resumePointFromProto(rp *pb.ResumePoint) (thread.ID, net.VectorClock, error) {
+	id, err := thread.Cast(rp.ThreadID)
+	if err != nil {
+		return id, nil, err
+	}
+	heights := make(net.VectorClock, len(rp.Heads))
+	for _, h := range rp.Heads {
+		lid, err := peer.IDFromBytes(h.LogID)
+		if err != nil {
+			return id, nil, err
+		}
+		heights[lid] = h.Counter
+	}
+	return id, heights, nil
+}
+
+func (s *Service) SubscribeHeads(req *pb.SubscribeHeadsRequest, server pb.API_SubscribeHeadsServer) error {
+	log.Debugf("received subscribe heads request")
+
+	opts := make([]net.SubOption, len(req.ThreadIDs))
+	for i, id := range req.ThreadIDs {
+		id, err := thread.Cast(id)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts[i] = net.WithSubFilter(id)
+	}
+	for _, tag := range req.Tags {
+		opts = append(opts, net.WithSubTag(tag))
+	}
+
+	token, err := thread.NewTokenFromMD(server.Context())
+	if err != nil {
+		return err
+	}
+	opts = append(opts, net.WithSubToken(token))
+
+	sub, err := s.net.SubscribeHeads(server.Context(), opts...)
+	if err != nil {
+		return err
+	}
+	for u := range sub {
+		heads := make([]*pb.LogHead, 0, len(u.Heads))
+		for lid, h := range u.Heads {
+			heads = append(heads, &pb.LogHead{
+				LogID:   marshalPeerID(lid),
+				Head:    h.ID.Bytes(),
+				Counter: h.Counter,
+			})
+		}
+		if err := server.Send(&pb.HeadsReply{
+			ThreadID: u.ThreadID.Bytes(),
+			Edge:     u.Edge,
+			Heads:    heads,
 		}); err != nil {
 			return err
 		}
@@ -408,11 +810,116 @@ func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 	return nil
 }
 
+func (s *Service) PublishPresence(ctx context.Context, req *pb.PublishPresenceRequest) (*pb.PublishPresenceReply, error) {
+	log.Debugf("received publish presence request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []net.ThreadOption{net.WithThreadToken(token)}
+	if len(req.IdentitySig) > 0 {
+		opts = append(opts, net.WithPresenceSig(time.Unix(0, req.Timestamp), req.IdentitySig))
+	}
+	if err = s.net.PublishPresence(ctx, id, net.PresenceStatus(req.Status), req.Payload, opts...); err != nil {
+		return nil, err
+	}
+	return &pb.PublishPresenceReply{}, nil
+}
+
+func (s *Service) SubscribePresence(req *pb.SubscribePresenceRequest, server pb.API_SubscribePresenceServer) error {
+	log.Debugf("received subscribe presence request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(server.Context())
+	if err != nil {
+		return err
+	}
+	sub, err := s.net.SubscribePresence(server.Context(), id, net.WithThreadToken(token))
+	if err != nil {
+		return err
+	}
+	for p := range sub {
+		reply := &pb.PresenceReply{
+			ThreadID: p.ThreadID.Bytes(),
+			PeerID:   marshalPeerID(p.Peer),
+			Status:   pb.PresenceStatus(p.Status),
+			Payload:  p.Payload,
+			Time:     p.Time.UnixNano(),
+		}
+		if p.Identity != nil {
+			if reply.Identity, err = p.Identity.MarshalBinary(); err != nil {
+				return err
+			}
+		}
+		if err := server.Send(reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) SubscribeSyncProgress(req *pb.SubscribeSyncProgressRequest, server pb.API_SubscribeSyncProgressServer) error {
+	log.Debugf("received subscribe sync progress request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(server.Context())
+	if err != nil {
+		return err
+	}
+	sub, err := s.net.SubscribeSyncProgress(server.Context(), id, net.WithThreadToken(token))
+	if err != nil {
+		return err
+	}
+	for p := range sub {
+		reply := &pb.SyncProgressReply{
+			ThreadID: p.ThreadID.Bytes(),
+			Records:  p.Records,
+			Expected: p.Expected,
+			Logs:     make([]*pb.SyncProgressReply_LogSyncProgress, len(p.Logs)),
+			Bytes:    p.Bytes,
+			Eta:      int64(p.ETA),
+		}
+		for i, l := range p.Logs {
+			reply.Logs[i] = &pb.SyncProgressReply_LogSyncProgress{
+				LogID:   marshalPeerID(l.LogID),
+				Applied: l.Applied,
+				Height:  l.Height,
+			}
+		}
+		if err := server.Send(reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func marshalPeerID(id peer.ID) []byte {
 	b, _ := id.Marshal() // This will never return an error
 	return b
 }
 
+func clockToProto(c net.VectorClock) []*pb.LogHead {
+	heads := make([]*pb.LogHead, 0, len(c))
+	for lid, h := range c {
+		heads = append(heads, &pb.LogHead{
+			LogID:   marshalPeerID(lid),
+			Counter: h,
+		})
+	}
+	return heads
+}
+
 func getKeyOptions(keys *pb.Keys) (opts []net.NewThreadOption, err error) {
 	if keys == nil {
 		return
@@ -441,31 +948,10 @@ func getKeyOptions(keys *pb.Keys) (opts []net.NewThreadOption, err error) {
 func threadInfoToProto(info thread.Info) (*pb.ThreadInfoReply, error) {
 	logs := make([]*pb.LogInfo, len(info.Logs))
 	for i, lg := range info.Logs {
-		pk, err := crypto.MarshalPublicKey(lg.PubKey)
-		if err != nil {
+		var err error
+		if logs[i], err = logInfoToProto(lg); err != nil {
 			return nil, err
 		}
-		var sk []byte
-		if lg.PrivKey != nil {
-			sk, err = crypto.MarshalPrivateKey(lg.PrivKey)
-			if err != nil {
-				return nil, err
-			}
-		}
-		addrs := make([][]byte, len(lg.Addrs))
-		for j, addr := range lg.Addrs {
-			addrs[j] = addr.Bytes()
-		}
-		counter := make([]byte, 8)
-		binary.PutVarint(counter, lg.Head.Counter)
-		logs[i] = &pb.LogInfo{
-			ID:      marshalPeerID(lg.ID),
-			PubKey:  pk,
-			PrivKey: sk,
-			Addrs:   addrs,
-			Head:    lg.Head.ID.Bytes(),
-			Counter: counter,
-		}
 	}
 	addrs := make([][]byte, len(info.Addrs))
 	for i, addr := range info.Addrs {
@@ -478,3 +964,77 @@ func threadInfoToProto(info thread.Info) (*pb.ThreadInfoReply, error) {
 		Addrs:     addrs,
 	}, nil
 }
+
+func recordSummaryToProto(r net.RecordSummary) (*pb.RecordSummary, error) {
+	var author []byte
+	if r.Author != nil {
+		var err error
+		if author, err = r.Author.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	var created int64
+	if !r.Time.IsZero() {
+		created = r.Time.UnixNano()
+	}
+	return &pb.RecordSummary{
+		RecordID: r.ID.Bytes(),
+		LogID:    marshalPeerID(r.LogID),
+		Author:   author,
+		Time:     created,
+		Size_:    int64(r.Size),
+		Type:     r.Type,
+	}, nil
+}
+
+func annotationFromProto(a *pb.Annotation) net.Annotation {
+	return net.Annotation{
+		Read:    a.Read,
+		Starred: a.Starred,
+		Labels:  a.Labels,
+	}
+}
+
+func recordAnnotationToProto(a net.RecordAnnotation) *pb.RecordAnnotation {
+	var updated int64
+	if !a.UpdatedAt.IsZero() {
+		updated = a.UpdatedAt.UnixNano()
+	}
+	return &pb.RecordAnnotation{
+		RecordID: a.RecordID.Bytes(),
+		Annotation: &pb.Annotation{
+			Read:    a.Read,
+			Starred: a.Starred,
+			Labels:  a.Labels,
+		},
+		UpdatedAt: updated,
+	}
+}
+
+func logInfoToProto(lg thread.LogInfo) (*pb.LogInfo, error) {
+	pk, err := crypto.MarshalPublicKey(lg.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	var sk []byte
+	if lg.PrivKey != nil {
+		sk, err = crypto.MarshalPrivateKey(lg.PrivKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	addrs := make([][]byte, len(lg.Addrs))
+	for j, addr := range lg.Addrs {
+		addrs[j] = addr.Bytes()
+	}
+	counter := make([]byte, 8)
+	binary.PutVarint(counter, lg.Head.Counter)
+	return &pb.LogInfo{
+		ID:      marshalPeerID(lg.ID),
+		PubKey:  pk,
+		PrivKey: sk,
+		Addrs:   addrs,
+		Head:    lg.Head.ID.Bytes(),
+		Counter: counter,
+	}, nil
+}
@@ -6,10 +6,16 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/audit"
 	"github.com/textileio/go-threads/common"
+	"github.com/textileio/go-threads/crash"
 	pb "github.com/textileio/go-threads/net/api/pb"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
@@ -18,6 +24,24 @@ import (
 // CreateTestService creates a test network API gRPC service for test purpose.
 // It uses either the addr passed in as host addr, or pick an available local addr if it is empty
 func CreateTestService(addr string, debug bool) (hostAddr ma.Multiaddr, gRPCAddr ma.Multiaddr, stop func(), err error) {
+	return createTestService(addr, debug, nil)
+}
+
+// CreateTestServiceWithAPIKeys creates a test network API gRPC service which requires API keys,
+// along with the admin service. Keys can be created with the returned key store.
+// Mutating calls and accepted pushes are recorded in an audit log, which can be exported
+// with the admin service.
+func CreateTestServiceWithAPIKeys(debug bool) (gRPCAddr ma.Multiaddr, keys *KeyStore, stop func(), err error) {
+	keys = NewKeyStore(dssync.MutexWrap(ds.NewMapDatastore()))
+	_, gRPCAddr, stop, err = createTestService("", debug, keys)
+	return gRPCAddr, keys, stop, err
+}
+
+func createTestService(
+	addr string,
+	debug bool,
+	keys *KeyStore,
+) (hostAddr ma.Multiaddr, gRPCAddr ma.Multiaddr, stop func(), err error) {
 	time.Sleep(time.Second * time.Duration(rand.Intn(5)))
 	dir, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -28,12 +52,20 @@ func CreateTestService(addr string, debug bool) (hostAddr ma.Multiaddr, gRPCAddr
 	} else {
 		hostAddr, _ = ma.NewMultiaddr(addr)
 	}
-	n, err := common.DefaultNetwork(
+	opts := []common.NetOption{
 		common.WithNetBadgerPersistence(dir),
 		common.WithNetHostAddr(hostAddr),
 		common.WithNetPubSub(true),
 		common.WithNetDebug(debug),
-	)
+	}
+	var auditLog *audit.Log
+	if keys != nil {
+		if auditLog, err = audit.New(audit.Config{Dir: filepath.Join(dir, "audit")}); err != nil {
+			return
+		}
+		opts = append(opts, common.WithNetAuditLog(auditLog))
+	}
+	n, err := common.DefaultNetwork(opts...)
 	if err != nil {
 		return
 	}
@@ -48,13 +80,29 @@ func CreateTestService(addr string, debug bool) (hostAddr ma.Multiaddr, gRPCAddr
 	if err != nil {
 		return
 	}
-	server := grpc.NewServer()
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(crash.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(crash.StreamServerInterceptor()),
+	}
+	if keys != nil {
+		serverOpts = append(serverOpts,
+			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+				AuditUnaryServerInterceptor(auditLog, IsMutatingMethod),
+				keys.UnaryServerInterceptor())),
+			grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+				AuditStreamServerInterceptor(auditLog, IsMutatingMethod),
+				keys.StreamServerInterceptor())))
+	}
+	server := grpc.NewServer(serverOpts...)
 	listener, err := net.Listen("tcp", target)
 	if err != nil {
 		return
 	}
 	go func() {
 		pb.RegisterAPIServer(server, service)
+		if keys != nil {
+			pb.RegisterAdminServer(server, NewAdminService(keys, auditLog, n))
+		}
 		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			log.Fatalf("serve error: %v", err)
 		}
@@ -65,6 +113,9 @@ func CreateTestService(addr string, debug bool) (hostAddr ma.Multiaddr, gRPCAddr
 		if err := n.Close(); err != nil {
 			return
 		}
+		if auditLog != nil {
+			_ = auditLog.Close()
+		}
 		_ = os.RemoveAll(dir)
 	}, nil
 }
@@ -0,0 +1,352 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// MaxPendingLogs is the max number of logs held per thread for the approval of the thread owner.
+// Logs pushed once the limit is reached are dropped, they're pushed again by their writers.
+var MaxPendingLogs = 64
+
+const (
+	// metaApprovalOwner is the metadata key of the marshaled owner identity of a thread
+	// requiring log approval, see core.WithLogApproval.
+	metaApprovalOwner = "approval:owner"
+
+	// metaPendingLogs is the metadata key of the marshaled logs waiting for approval, by log ID.
+	metaPendingLogs = "approval:pending"
+
+	// metaApprovedLogs is the metadata key of the marshaled log approvals, by log ID. Approvals
+	// observed before the log are kept, so the log is added once it's pushed.
+	metaApprovedLogs = "approval:approved"
+)
+
+// pendingLogEntry is a log held until it's approved.
+type pendingLogEntry struct {
+	Log   []byte  `json:"log"`
+	From  peer.ID `json:"from"`
+	Since int64   `json:"since"`
+}
+
+// approvalEntry is an approval of a log by the thread owner.
+type approvalEntry struct {
+	By cid.Cid `json:"by"`
+}
+
+func (n *net) ApproveLog(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	opts ...core.ThreadOption,
+) (core.ThreadRecord, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	identity, err := n.Validate(id, args.Token, false)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	}
+	if _, frozen, err := n.frozenHeight(id, ""); err != nil {
+		return nil, err
+	} else if frozen {
+		return nil, core.ErrThreadFrozen
+	}
+	owner, err := n.logApprovalOwner(id)
+	if err != nil {
+		return nil, err
+	} else if owner == nil {
+		return nil, core.ErrLogApprovalNotRequired
+	}
+	author, err := identity.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(owner, author) {
+		return nil, core.ErrNotThreadOwner
+	}
+	signer, err := n.identitySigner(identity, args.Signer)
+	if err != nil {
+		return nil, err
+	}
+	// logs pushed to other peers only can be approved too
+	if _, err = n.store.GetLog(id, lid); err == nil {
+		return nil, fmt.Errorf("%w: %s", core.ErrLogNotPending, lid)
+	} else if !errors.Is(err, lstore.ErrLogNotFound) {
+		return nil, err
+	}
+	if approvals, err := n.approvedLogs(id); err != nil {
+		return nil, err
+	} else if _, ok := approvals[lid.String()]; ok {
+		return nil, fmt.Errorf("%w: %s", core.ErrLogNotPending, lid)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"log": lid.String(),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		return nil, err
+	}
+	exts := core.RecordExtensions{
+		core.ExtensionKey(core.ExtensionNamespaceThreads, core.ExtensionApproveLog): []byte(lid),
+	}
+	tr, head, _, err := n.appendRecord(ctx, id, body, identity, "", core.LogApprovalRecordType, exts, signer)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok, err := n.observeLogApproval(id, tr.LogID(), tr.Value()); err != nil {
+		return nil, err
+	} else if ok {
+		if err = n.activateLog(id, entry); err != nil {
+			return nil, err
+		}
+	}
+	log.Debugf("approved log %s with %s (thread=%s)", lid, tr.Value().Cid(), id)
+	if err = n.publishRecord(ctx, id, tr, head, args.Priority); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+func (n *net) PendingLogs(ctx context.Context, id thread.ID, opts ...core.ThreadOption) ([]core.PendingLog, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return nil, err
+	}
+	n.approvalLock.Lock()
+	entries, err := n.pendingLogs(id)
+	n.approvalLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]core.PendingLog, 0, len(entries))
+	for _, entry := range entries {
+		lg, err := entry.logInfo()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, core.PendingLog{
+			ID:     lg.ID,
+			PubKey: lg.PubKey,
+			Addrs:  lg.Addrs,
+			From:   entry.From,
+			Since:  time.Unix(0, entry.Since),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].Since.Equal(list[j].Since) {
+			return list[i].Since.Before(list[j].Since)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+// requireLogApproval makes new writers of a thread wait for the approval of the owner identity.
+func (n *net) requireLogApproval(id thread.ID, owner thread.PubKey) error {
+	val, err := owner.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(id, metaApprovalOwner, val)
+}
+
+// logApprovalOwner returns the marshaled owner identity of a thread, which is nil
+// if the thread doesn't require log approval.
+func (n *net) logApprovalOwner(id thread.ID) ([]byte, error) {
+	val, err := n.store.GetBytes(id, metaApprovalOwner)
+	if err != nil || val == nil || len(*val) == 0 {
+		return nil, err
+	}
+	return *val, nil
+}
+
+// approvedLog returns the log approved by a log approval record.
+func approvedLog(rec core.Record) (peer.ID, bool) {
+	v, ok := rec.Extensions().Get(core.ExtensionNamespaceThreads, core.ExtensionApproveLog)
+	if !ok {
+		return "", false
+	}
+	lid, err := peer.IDFromBytes(v)
+	if err != nil {
+		return "", false
+	}
+	return lid, true
+}
+
+// holdLog holds a log of a thread requiring log approval until it's approved. It returns false
+// if the log can be added, i.e. the thread doesn't require approval or the log is known or approved.
+func (n *net) holdLog(tid thread.ID, from peer.ID, lg thread.LogInfo) (bool, error) {
+	if owner, err := n.logApprovalOwner(tid); err != nil || owner == nil {
+		return false, err
+	}
+	if _, err := n.store.GetLog(tid, lg.ID); err == nil {
+		return false, nil
+	} else if !errors.Is(err, lstore.ErrLogNotFound) {
+		return false, err
+	}
+
+	n.approvalLock.Lock()
+	defer n.approvalLock.Unlock()
+	if approvals, err := n.approvedLogs(tid); err != nil {
+		return false, err
+	} else if _, ok := approvals[lg.ID.String()]; ok {
+		return false, nil
+	}
+	entries, err := n.pendingLogs(tid)
+	if err != nil {
+		return false, err
+	}
+	val, err := logToProto(lg).Marshal()
+	if err != nil {
+		return false, err
+	}
+	entry, ok := entries[lg.ID.String()]
+	if !ok {
+		if len(entries) >= MaxPendingLogs {
+			log.Warnf("too many logs pending approval, dropping log %s (thread %s)", lg.ID, tid)
+			return true, nil
+		}
+		entry = pendingLogEntry{From: from, Since: n.clock.Now().UnixNano()}
+		log.Debugf("holding log %s from %s until it's approved (thread %s)", lg.ID, from, tid)
+	}
+	// the latest addresses are kept
+	entry.Log = val
+	entries[lg.ID.String()] = entry
+	return true, n.putPendingLogs(tid, entries)
+}
+
+// observeLogApproval records a log approval record of a thread log stored locally, and returns
+// the pending log it approves if it was pushed already. Approvals which aren't signed by the
+// thread owner are ignored, see core.ExtensionIdentitySig.
+func (n *net) observeLogApproval(tid thread.ID, rlid peer.ID, rec core.Record) (entry pendingLogEntry, ok bool, err error) {
+	lid, ok := approvedLog(rec)
+	if !ok {
+		return entry, false, nil
+	}
+	owner, err := n.logApprovalOwner(tid)
+	if err != nil || owner == nil {
+		return entry, false, err
+	}
+	if identity, verified := verifiedIdentity(tid, rlid, rec); !verified {
+		log.Warnf("ignoring approval %s of log %s without a valid owner signature (thread %s)", rec.Cid(), lid, tid)
+		return entry, false, nil
+	} else if signer, err := identity.MarshalBinary(); err != nil || !bytes.Equal(owner, signer) {
+		log.Warnf("ignoring approval %s of log %s by another identity than the owner (thread %s)", rec.Cid(), lid, tid)
+		return entry, false, err
+	}
+
+	n.approvalLock.Lock()
+	defer n.approvalLock.Unlock()
+	approvals, err := n.approvedLogs(tid)
+	if err != nil {
+		return entry, false, err
+	}
+	if _, known := approvals[lid.String()]; !known {
+		approvals[lid.String()] = approvalEntry{By: rec.Cid()}
+		if err = n.putApprovedLogs(tid, approvals); err != nil {
+			return entry, false, err
+		}
+	}
+	entries, err := n.pendingLogs(tid)
+	if err != nil {
+		return entry, false, err
+	}
+	if entry, ok = entries[lid.String()]; !ok {
+		return entry, false, nil
+	}
+	delete(entries, lid.String())
+	return entry, true, n.putPendingLogs(tid, entries)
+}
+
+// activateLog adds an approved log and pulls its records from the peer which pushed it.
+func (n *net) activateLog(tid thread.ID, entry pendingLogEntry) error {
+	lg, err := entry.logInfo()
+	if err != nil {
+		return err
+	}
+	if err = n.createExternalLogsIfNotExist(tid, []thread.LogInfo{lg}); err != nil {
+		return err
+	}
+	log.Debugf("activated approved log %s (thread %s)", lg.ID, tid)
+	if n.isResponsible(tid) && n.queueGetRecords.Schedule(entry.From, tid, callPriorityLow, n.updateRecordsFromPeer) {
+		log.Debugf("record update for thread %s from %s scheduled", tid, entry.From)
+	}
+	return nil
+}
+
+func (e pendingLogEntry) logInfo() (thread.LogInfo, error) {
+	var pl pb.Log
+	if err := pl.Unmarshal(e.Log); err != nil {
+		return thread.LogInfo{}, fmt.Errorf("decoding pending log: %w", err)
+	}
+	return logFromProto(&pl), nil
+}
+
+func (n *net) pendingLogs(tid thread.ID) (map[string]pendingLogEntry, error) {
+	entries := make(map[string]pendingLogEntry)
+	val, err := n.store.GetBytes(tid, metaPendingLogs)
+	if err != nil {
+		return nil, err
+	} else if val == nil || len(*val) == 0 {
+		return entries, nil
+	}
+	if err = json.Unmarshal(*val, &entries); err != nil {
+		return nil, fmt.Errorf("decoding pending logs of thread %s: %w", tid, err)
+	}
+	return entries, nil
+}
+
+func (n *net) putPendingLogs(tid thread.ID, entries map[string]pendingLogEntry) error {
+	val, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(tid, metaPendingLogs, val)
+}
+
+func (n *net) approvedLogs(tid thread.ID) (map[string]approvalEntry, error) {
+	entries := make(map[string]approvalEntry)
+	val, err := n.store.GetBytes(tid, metaApprovedLogs)
+	if err != nil {
+		return nil, err
+	} else if val == nil || len(*val) == 0 {
+		return entries, nil
+	}
+	if err = json.Unmarshal(*val, &entries); err != nil {
+		return nil, fmt.Errorf("decoding log approvals of thread %s: %w", tid, err)
+	}
+	return entries, nil
+}
+
+func (n *net) putApprovedLogs(tid thread.ID, entries map[string]approvalEntry) error {
+	val, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(tid, metaApprovedLogs, val)
+}
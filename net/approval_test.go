@@ -0,0 +1,164 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_LogApproval(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithLogApproval(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := thread.NewLibp2pPubKey(n1.getPrivKey().GetPublic())
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key), core.WithLogApproval(owner)); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n2.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the pushed log is held until it's approved
+	var pending []core.PendingLog
+	for i := 0; i < 50 && len(pending) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		if pending, err = n1.PendingLogs(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(pending) != 1 || pending[0].ID != r.LogID() || pending[0].From != n2.Host().ID() {
+		t.Fatalf("expected log %s to be pending, got %v", r.LogID(), pending)
+	}
+	if _, err := n1.store.GetLog(info.ID, r.LogID()); err == nil {
+		t.Fatal("expected pending log not to be added")
+	}
+	if _, err := n2.ApproveLog(ctx, info.ID, r.LogID()); !errors.Is(err, core.ErrNotThreadOwner) {
+		t.Fatalf("expected approval of another identity to be denied, got %v", err)
+	}
+
+	if _, err := n1.ApproveLog(ctx, info.ID, r.LogID()); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err = n1.PendingLogs(ctx, info.ID); err != nil || len(pending) != 0 {
+		t.Fatalf("expected approved log not to be pending, got %v (%v)", pending, err)
+	}
+	var head thread.Head
+	for i := 0; i < 50 && head.ID != r.Value().Cid(); i++ {
+		time.Sleep(100 * time.Millisecond)
+		if lg, err := n1.store.GetLog(info.ID, r.LogID()); err == nil {
+			head = lg.Head
+		}
+	}
+	if head.ID != r.Value().Cid() {
+		t.Fatalf("expected records of the approved log to be pulled, got head %s", head.ID)
+	}
+	if _, err := n1.ApproveLog(ctx, info.ID, r.LogID()); !errors.Is(err, core.ErrLogNotPending) {
+		t.Fatalf("expected active log not to be approved again, got %v", err)
+	}
+
+	// approved writers can't approve logs by claiming the owner identity
+	_, pk, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if held, err := n1.holdLog(info.ID, n2.Host().ID(), thread.LogInfo{ID: lid, PubKey: pk}); err != nil || !held {
+		t.Fatalf("expected log to be held, got %v (%v)", held, err)
+	}
+	lg, err := n2.store.GetLog(info.ID, r.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exts := core.RecordExtensions{
+		core.ExtensionKey(core.ExtensionNamespaceThreads, core.ExtensionApproveLog): []byte(lid),
+	}
+	header := cbor.EventHeaderConfig{Type: core.LogApprovalRecordType}
+	for _, signer := range []thread.Identity{nil, thread.NewLibp2pIdentity(n2.getPrivKey())} {
+		forged, err := n2.newRecord(ctx, info.ID, lg, body, owner, header, exts, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok, err := n1.observeLogApproval(info.ID, lg.ID, forged); err != nil || ok {
+			t.Fatalf("expected forged approval to be rejected, got %v (%v)", ok, err)
+		}
+	}
+	if pending, err = n1.PendingLogs(ctx, info.ID); err != nil || len(pending) != 1 || pending[0].ID != lid {
+		t.Fatalf("expected log %s to stay pending, got %v (%v)", lid, pending, err)
+	}
+}
+
+func TestNet_LogApprovalInlinedLogs(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithLogApproval(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n2.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// logs inlined into the exchange edges reply are held like pushed ones
+	data, err := n2.server.inlineLogs(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.server.applyInlinedLogs(info.ID, n2.Host().ID(), data); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := n1.PendingLogs(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ID != r.LogID() || pending[0].From != n2.Host().ID() {
+		t.Fatalf("expected log %s to be pending, got %v", r.LogID(), pending)
+	}
+	if _, err := n1.store.GetLog(info.ID, r.LogID()); err == nil {
+		t.Fatal("expected pending log not to be added")
+	}
+}
@@ -0,0 +1,34 @@
+package net
+
+import (
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/audit"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// auditPush records a push received from a remote peer, if an audit log is configured.
+// Failing to record an entry doesn't fail the push.
+func (n *net) auditPush(op string, pid peer.ID, tid thread.ID, lid peer.ID, rid cid.Cid, err error) {
+	if n.audit == nil {
+		return
+	}
+	e := audit.Entry{
+		Kind:      audit.KindPush,
+		Operation: op,
+		Peer:      pid.String(),
+		ThreadID:  tid.String(),
+		LogID:     lid.String(),
+		Outcome:   audit.OutcomeOK,
+	}
+	if rid.Defined() {
+		e.RecordID = rid.String()
+	}
+	if err != nil {
+		e.Outcome = audit.OutcomeError
+		e.Error = err.Error()
+	}
+	if err := n.audit.Record(e); err != nil {
+		log.Errorf("error recording push audit entry: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+const (
+	// metadata key of threads bootstrapped progressively, i.e. from the newest records of their logs
+	metaProgressive = "bootstrap:progressive"
+
+	// metaLogFloor prefixes the metadata keys of the floors of logs being back-filled. A floor is
+	// the oldest record stored above the gap left by a progressive bootstrap, the log is stored
+	// in full once the floor reaches its first record.
+	metaLogFloor = "bootstrap:floor/"
+)
+
+var (
+	// BootstrapPageSize is the number of the newest records of each log pulled first when a thread
+	// is bootstrapped progressively. Older records are back-filled in the background.
+	BootstrapPageSize = 100
+
+	// BackfillPageSize is the number of records of a log requested at once while back-filling.
+	BackfillPageSize = 1000
+)
+
+// backfillSet tracks the threads being back-filled, so there's a single back-fill per thread.
+type backfillSet struct {
+	sync.Mutex
+	running map[thread.ID]struct{}
+}
+
+func newBackfillSet() *backfillSet {
+	return &backfillSet{running: make(map[thread.ID]struct{})}
+}
+
+// start returns false if the thread is back-filled already.
+func (b *backfillSet) start(tid thread.ID) bool {
+	b.Lock()
+	defer b.Unlock()
+	if _, ok := b.running[tid]; ok {
+		return false
+	}
+	b.running[tid] = struct{}{}
+	return true
+}
+
+func (b *backfillSet) done(tid thread.ID) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.running, tid)
+}
+
+// deferLinkage returns whether the logs of a thread may start from their newest records,
+// leaving a gap which is back-filled later. Apps handle records in order, so the records
+// of threads connected to apps are always linked to the log heads before they're applied.
+func (n *net) deferLinkage(tid thread.ID) bool {
+	if _, connected := n.getConnector(tid); connected {
+		return false
+	}
+	v, err := n.store.GetBool(tid, metaProgressive)
+	return err == nil && v != nil && *v
+}
+
+// logFloor returns the floor of a log, which is undefined unless the log is being back-filled.
+func (n *net) logFloor(tid thread.ID, lid peer.ID) (thread.Head, error) {
+	v, err := n.store.GetBytes(tid, metaLogFloor+lid.String())
+	if err != nil || v == nil || len(*v) == 0 {
+		return thread.HeadUndef, err
+	}
+	var floor thread.Head
+	if err := json.Unmarshal(*v, &floor); err != nil {
+		return thread.HeadUndef, fmt.Errorf("decoding floor of log %s: %w", lid, err)
+	}
+	return floor, nil
+}
+
+// setLogFloor moves the floor of a log, an undefined floor marks the log as complete.
+func (n *net) setLogFloor(tid thread.ID, lid peer.ID, floor thread.Head) error {
+	var val []byte
+	if floor.ID.Defined() {
+		var err error
+		if val, err = json.Marshal(floor); err != nil {
+			return err
+		}
+	}
+	return n.store.PutBytes(tid, metaLogFloor+lid.String(), val)
+}
+
+// logFloors returns the floors of the thread logs being back-filled.
+func (n *net) logFloors(tid thread.ID) (map[peer.ID]thread.Head, error) {
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	floors := make(map[peer.ID]thread.Head)
+	for _, lg := range info.Logs {
+		floor, err := n.logFloor(tid, lg.ID)
+		if err != nil {
+			return nil, err
+		} else if floor.ID.Defined() {
+			floors[lg.ID] = floor
+		}
+	}
+	return floors, nil
+}
+
+// scheduleBackfill starts back-filling the logs of a thread in the background,
+// unless they're back-filled already.
+func (n *net) scheduleBackfill(tid thread.ID) {
+	if !n.backfills.start(tid) {
+		return
+	}
+	go func() {
+		defer n.backfills.done(tid)
+		if err := n.backfill(n.ctx, tid); errors.Is(err, core.ErrSyncPaused) {
+			log.Debugf("back-filling thread %s is paused", tid)
+		} else if err != nil {
+			log.Warnf("back-filling thread %s failed: %v", tid, err)
+		}
+	}()
+}
+
+// backfill pages back the logs of a thread from their floors. Logs whose peers don't serve
+// the records preceding the floor are left for the next pull of the thread.
+func (n *net) backfill(ctx context.Context, tid thread.ID) error {
+	floors, err := n.logFloors(tid)
+	if err != nil {
+		return err
+	}
+	for lid, floor := range floors {
+		for floor.ID.Defined() {
+			if !n.syncs.enter(tid) {
+				return core.ErrSyncPaused
+			}
+			next, err := n.backfillPage(ctx, tid, lid, floor)
+			n.syncs.leave(tid)
+			if err != nil {
+				return fmt.Errorf("back-filling log %s: %w", lid, err)
+			} else if next.ID.Equals(floor.ID) {
+				break
+			}
+			floor = next
+		}
+	}
+	return nil
+}
+
+// backfillPage stores the records preceding the floor of a log from the first thread peer
+// serving them, and returns the moved floor.
+func (n *net) backfillPage(ctx context.Context, tid thread.ID, lid peer.ID, floor thread.Head) (thread.Head, error) {
+	rec, err := n.getRecord(ctx, tid, floor.ID)
+	if err != nil {
+		return floor, err
+	}
+	want := rec.PrevID()
+	if !want.Defined() {
+		return thread.HeadUndef, n.setLogFloor(tid, lid, thread.HeadUndef)
+	}
+
+	offsets, peers, err := n.threadOffsets(tid)
+	if err != nil {
+		return floor, err
+	}
+	req, sk, err := n.server.buildGetRecordsRequest(tid, offsets, BackfillPageSize)
+	if err != nil {
+		return floor, err
+	}
+	for _, l := range req.Body.Logs {
+		if l.LogID.ID == lid {
+			l.Direction = pb.GetRecordsRequest_BACKWARD
+			l.Anchor = &pb.ProtoCid{Cid: floor.ID}
+			l.Limit = int32(BackfillPageSize)
+		}
+	}
+
+	for _, pid := range peers {
+		if err := n.server.requireCapability(ctx, pid, CapBackwardRecords); err != nil {
+			continue
+		}
+		var recs map[peer.ID]peerRecords
+		if err := n.queueGetRecords.Call(pid, tid, func(ctx context.Context, pid peer.ID, tid thread.ID) (err error) {
+			recs, err = n.server.getRecordsFromPeer(ctx, tid, pid, req, sk)
+			return err
+		}); err != nil {
+			log.Debugf("back-filling log %s (thread %s) from %s failed: %v", lid, tid, pid, err)
+			continue
+		}
+		// peers ignoring the anchor reply with the newest records instead
+		page := recs[lid].records
+		if len(page) == 0 || !page[len(page)-1].Cid().Equals(want) {
+			continue
+		}
+		if err := n.storeBackfill(ctx, tid, lid, page); err != nil {
+			return floor, err
+		}
+		next := thread.Head{ID: page[0].Cid(), Counter: floor.Counter - int64(len(page))}
+		if !page[0].PrevID().Defined() {
+			next = thread.HeadUndef
+		}
+		if err := n.setLogFloor(tid, lid, next); err != nil {
+			return floor, err
+		}
+		log.Debugf("back-filled %d records of log %s (thread %s)", len(page), lid, tid)
+		return next, nil
+	}
+	return floor, nil
+}
+
+// storeBackfill stores the back-filled records of a log, oldest first. They're stored and indexed
+// like applied records, but they aren't delivered to subscribers, which received newer records
+// of the log already.
+func (n *net) storeBackfill(ctx context.Context, tid thread.ID, lid peer.ID, recs []core.Record) error {
+	redactions, err := n.redactions(tid)
+	if err != nil {
+		return err
+	}
+	for _, r := range recs {
+		// light clients fetch events on demand
+		if !n.lightClient || !cbor.IsHeader(r) {
+			event, err := cbor.EventFromRecord(ctx, n, r)
+			if err != nil {
+				return err
+			}
+			header, err := event.GetHeader(ctx, n, nil)
+			if err != nil {
+				return err
+			}
+			blocks := []format.Node{event, header}
+			if !bodyRedacted(tid, lid, r, redactions, recs) {
+				body, err := event.GetBody(ctx, n, nil)
+				if err != nil {
+					return err
+				}
+				blocks = append(blocks, body)
+			} else {
+				cbor.RedactRecord(r)
+			}
+			if err = n.AddMany(ctx, blocks); err != nil {
+				return err
+			}
+		}
+		if err := n.Add(ctx, r); err != nil {
+			return err
+		}
+		if _, redaction := redactionTarget(r); redaction {
+			if err := n.applyRedactions(ctx, tid, lid, r); err != nil {
+				return err
+			}
+		} else if _, pending := redactions[r.Cid().String()]; pending {
+			if err := n.applyRedactions(ctx, tid, lid, r); err != nil {
+				return err
+			}
+		}
+		n.indexActivity(ctx, tid, lid, r)
+		n.addUsage(ctx, tid, r)
+	}
+	return nil
+}
+
+// getLocalRecordsBefore returns up to limit local records preceding a record, oldest first.
+// Records end at the first record of the log, or where the log is pruned or has a gap.
+func (n *net) getLocalRecordsBefore(ctx context.Context, id thread.ID, anchor cid.Cid, limit int) ([]core.Record, error) {
+	if known, err := n.isKnown(anchor); err != nil || !known {
+		return nil, err
+	}
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if sk == nil {
+		return nil, fmt.Errorf("a service-key is required to get records")
+	}
+	r, err := cbor.GetRecord(ctx, n, anchor, sk)
+	if err != nil {
+		return nil, err
+	}
+	var recs []core.Record
+	for cursor := r.PrevID(); cursor.Defined() && len(recs) < limit; {
+		if known, err := n.isKnown(cursor); err != nil {
+			return nil, err
+		} else if !known {
+			break
+		}
+		if r, err = cbor.GetRecord(ctx, n, cursor, sk); err != nil {
+			return nil, err
+		}
+		recs = append([]core.Record{r}, recs...)
+		cursor = r.PrevID()
+	}
+	return recs, nil
+}
+
+// requestsBackward returns whether records preceding an anchor are requested for some log.
+func requestsBackward(req *pb.GetRecordsRequest) bool {
+	for _, l := range req.Body.Logs {
+		if l.Direction == pb.GetRecordsRequest_BACKWARD {
+			return true
+		}
+	}
+	return false
+}
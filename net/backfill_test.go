@@ -0,0 +1,106 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_ProgressiveBootstrap(t *testing.T) {
+	defer func(bootstrap, backfill int) {
+		BootstrapPageSize, BackfillPageSize = bootstrap, backfill
+	}(BootstrapPageSize, BackfillPageSize)
+	BootstrapPageSize, BackfillPageSize = 2, 2
+
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i := 0; i < 7; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+
+	t.Run("records before anchor", func(t *testing.T) {
+		before, err := n1.getLocalRecordsBefore(ctx, info.ID, recs[4].Value().Cid(), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(before) != 2 || !before[0].Cid().Equals(recs[2].Value().Cid()) || !before[1].Cid().Equals(recs[3].Value().Cid()) {
+			t.Fatalf("expected the two records preceding the anchor, oldest first, got %v", before)
+		}
+	})
+
+	sub, err := n2.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key), core.WithProgressiveBootstrap()); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	lid := recs[0].LogID()
+	head, err := n2.currentHead(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !head.ID.Equals(recs[6].Value().Cid()) || head.Counter != 7 {
+		t.Fatalf("expected the newest record to be applied first, got head %v", head)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		floor, err := n2.logFloor(info.ID, lid)
+		if err != nil {
+			t.Fatal(err)
+		} else if !floor.ID.Defined() {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("expected the log to be back-filled, floor is at %d", floor.Counter)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	for i, r := range recs {
+		if known, err := n2.isKnown(r.Value().Cid()); err != nil {
+			t.Fatal(err)
+		} else if !known {
+			t.Fatalf("expected record %d to be back-filled", i)
+		}
+	}
+
+	var delivered int
+	for done := false; !done; {
+		select {
+		case <-sub:
+			delivered++
+		case <-time.After(500 * time.Millisecond):
+			done = true
+		}
+	}
+	if delivered != BootstrapPageSize {
+		t.Fatalf("expected only the newest records to be delivered, got %d", delivered)
+	}
+}
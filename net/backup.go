@@ -0,0 +1,447 @@
+package net
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	cid "github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// backupVersion is the format version of backup streams and tokens.
+const backupVersion = 1
+
+var (
+	// ErrInvalidBackup indicates a malformed or truncated backup stream.
+	ErrInvalidBackup = errors.New("invalid backup")
+
+	// ErrInvalidBackupToken indicates a malformed backup token.
+	ErrInvalidBackupToken = errors.New("invalid backup token")
+)
+
+// Backupper writes and restores differential backups of the logstore and the records
+// of all threads, it's implemented by the threads network.
+type Backupper interface {
+	// Backup writes the threads, logs, and records which changed since the backup
+	// the token was returned for, or all of them if the token is empty. The returned
+	// token is passed to the next backup.
+	Backup(ctx context.Context, w io.Writer, since string) (token string, err error)
+	// Restore applies a backup stream. Backups are restored in the order they were
+	// taken, starting with a full one. Records are restored below the app level,
+	// so dbs are restored separately, and threads deleted since the previous
+	// backup are kept.
+	Restore(ctx context.Context, r io.Reader) (BackupStats, error)
+}
+
+var _ Backupper = (*net)(nil)
+
+// BackupStats counts the entries of a backup stream.
+type BackupStats struct {
+	Threads int
+	Logs    int
+	Records int
+}
+
+// Kinds of backup stream entries.
+const (
+	backupHeader = "header"
+	backupThread = "thread"
+	backupLog    = "log"
+	backupRecord = "record"
+	backupEnd    = "end"
+)
+
+// backupEntry is a line of a backup stream. Records of a log precede the log entry
+// moving its head to them, and the stream ends with the token of the backup, so
+// truncated streams are detected.
+type backupEntry struct {
+	Kind    string   `json:"kind"`
+	Version int      `json:"version,omitempty"`
+	Thread  string   `json:"thread,omitempty"`
+	Key     string   `json:"key,omitempty"`
+	Log     string   `json:"log,omitempty"`
+	PubKey  []byte   `json:"pubKey,omitempty"`
+	PrivKey []byte   `json:"privKey,omitempty"`
+	Addrs   []string `json:"addrs,omitempty"`
+	Head    string   `json:"head,omitempty"`
+	Counter int64    `json:"counter,omitempty"`
+	Record  []byte   `json:"record,omitempty"`
+	Token   string   `json:"token,omitempty"`
+}
+
+// backupToken is the state of the logstore at a backup, which the next backup is diffed against.
+type backupToken struct {
+	Version int                     `json:"v"`
+	Threads map[string]backupMarker `json:"t"`
+}
+
+type backupMarker struct {
+	// Keys is the digest of the thread keys.
+	Keys string               `json:"k"`
+	Logs map[string]logMarker `json:"l"`
+}
+
+type logMarker struct {
+	Counter int64 `json:"c"`
+	// Digest is the digest of the log keys, addresses, and head.
+	Digest string `json:"d"`
+}
+
+func encodeBackupToken(t backupToken) (string, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeBackupToken(s string) (t backupToken, err error) {
+	t.Threads = make(map[string]backupMarker)
+	if len(s) == 0 {
+		return t, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, fmt.Errorf("%w: %v", ErrInvalidBackupToken, err)
+	}
+	if err = json.Unmarshal(b, &t); err != nil {
+		return t, fmt.Errorf("%w: %v", ErrInvalidBackupToken, err)
+	}
+	if t.Version != backupVersion {
+		return t, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackupToken, t.Version)
+	}
+	return t, nil
+}
+
+func (n *net) Backup(ctx context.Context, w io.Writer, since string) (string, error) {
+	prev, err := decodeBackupToken(since)
+	if err != nil {
+		return "", err
+	}
+	next := backupToken{Version: backupVersion, Threads: make(map[string]backupMarker)}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err = enc.Encode(backupEntry{Kind: backupHeader, Version: backupVersion}); err != nil {
+		return "", err
+	}
+
+	tids, err := n.store.Threads()
+	if err != nil {
+		return "", err
+	}
+	for _, tid := range tids {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		marker, err := n.backupThread(ctx, enc, tid, prev.Threads[tid.String()])
+		if err != nil {
+			return "", fmt.Errorf("backing up thread %s: %w", tid, err)
+		}
+		next.Threads[tid.String()] = marker
+	}
+
+	token, err := encodeBackupToken(next)
+	if err != nil {
+		return "", err
+	}
+	if err = enc.Encode(backupEntry{Kind: backupEnd, Token: token}); err != nil {
+		return "", err
+	}
+	if err = bw.Flush(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// backupThread writes the entries of a thread which changed since the previous marker.
+func (n *net) backupThread(ctx context.Context, enc *json.Encoder, tid thread.ID, prev backupMarker) (backupMarker, error) {
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return backupMarker{}, err
+	}
+	marker := backupMarker{Keys: digest(info.Key.Bytes()), Logs: make(map[string]logMarker)}
+	if marker.Keys != prev.Keys {
+		if err = enc.Encode(backupEntry{Kind: backupThread, Thread: tid.String(), Key: info.Key.String()}); err != nil {
+			return marker, err
+		}
+	}
+
+	sort.Slice(info.Logs, func(i, j int) bool { return info.Logs[i].ID < info.Logs[j].ID })
+	for _, lg := range info.Logs {
+		entry, err := logBackupEntry(tid, lg)
+		if err != nil {
+			return marker, err
+		}
+		lm := logMarker{Counter: lg.Head.Counter, Digest: entryDigest(entry)}
+		marker.Logs[lg.ID.String()] = lm
+		last, ok := prev.Logs[lg.ID.String()]
+		if ok && last.Digest == lm.Digest {
+			continue
+		}
+		if err = n.backupRecords(ctx, enc, tid, lg.ID, lg.Head, last.Counter); err != nil {
+			return marker, fmt.Errorf("log %s: %w", lg.ID, err)
+		}
+		if err = enc.Encode(entry); err != nil {
+			return marker, err
+		}
+	}
+	return marker, nil
+}
+
+// backupRecords writes the records of a log after the counter, oldest first. Records
+// preceding a checkpoint the log continues from aren't stored, so they're skipped.
+func (n *net) backupRecords(ctx context.Context, enc *json.Encoder, tid thread.ID, lid peer.ID, head thread.Head, after int64) error {
+	var (
+		rids    []cid.Cid
+		cursor  = head.ID
+		counter = head.Counter
+	)
+	for cursor.Defined() && (counter == thread.CounterUndef || counter > after) {
+		if known, err := n.isKnown(cursor); err != nil {
+			return err
+		} else if !known {
+			break
+		}
+		rec, err := n.getRecord(ctx, tid, cursor)
+		if err != nil {
+			return err
+		}
+		rids = append(rids, cursor)
+		cursor = rec.PrevID()
+		if counter != thread.CounterUndef {
+			counter--
+		}
+	}
+	for i := len(rids) - 1; i >= 0; i-- {
+		rec, err := n.getRecord(ctx, tid, rids[i])
+		if err != nil {
+			return err
+		}
+		prec, err := n.recordToProto(ctx, tid, rec)
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(prec)
+		if err != nil {
+			return err
+		}
+		if err = enc.Encode(backupEntry{Kind: backupRecord, Thread: tid.String(), Log: lid.String(), Record: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func logBackupEntry(tid thread.ID, lg thread.LogInfo) (backupEntry, error) {
+	entry := backupEntry{
+		Kind:    backupLog,
+		Thread:  tid.String(),
+		Log:     lg.ID.String(),
+		Counter: lg.Head.Counter,
+	}
+	var err error
+	if entry.PubKey, err = crypto.MarshalPublicKey(lg.PubKey); err != nil {
+		return entry, err
+	}
+	if lg.PrivKey != nil {
+		if entry.PrivKey, err = crypto.MarshalPrivateKey(lg.PrivKey); err != nil {
+			return entry, err
+		}
+	}
+	for _, addr := range lg.Addrs {
+		entry.Addrs = append(entry.Addrs, addr.String())
+	}
+	sort.Strings(entry.Addrs)
+	if lg.Head.ID.Defined() {
+		entry.Head = lg.Head.ID.String()
+	}
+	return entry, nil
+}
+
+func entryDigest(e backupEntry) string {
+	h := sha256.New()
+	for _, b := range [][]byte{e.PubKey, e.PrivKey, []byte(e.Head)} {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(b)))
+		_, _ = h.Write(b)
+	}
+	_ = binary.Write(h, binary.BigEndian, e.Counter)
+	for _, a := range e.Addrs {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(a)))
+		_, _ = h.Write([]byte(a))
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:16])
+}
+
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func (n *net) Restore(ctx context.Context, r io.Reader) (BackupStats, error) {
+	var (
+		stats   BackupStats
+		dec     = json.NewDecoder(bufio.NewReader(r))
+		entry   backupEntry
+		started bool
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		entry = backupEntry{}
+		if err := dec.Decode(&entry); err == io.EOF {
+			return stats, fmt.Errorf("%w: stream ended before its end entry", ErrInvalidBackup)
+		} else if err != nil {
+			return stats, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+		if !started {
+			if entry.Kind != backupHeader || entry.Version != backupVersion {
+				return stats, fmt.Errorf("%w: unsupported stream", ErrInvalidBackup)
+			}
+			started = true
+			continue
+		}
+
+		var err error
+		switch entry.Kind {
+		case backupThread:
+			err = n.restoreThread(entry)
+			stats.Threads++
+		case backupLog:
+			err = n.restoreLog(entry)
+			stats.Logs++
+		case backupRecord:
+			err = n.restoreRecord(ctx, entry)
+			stats.Records++
+		case backupEnd:
+			return stats, nil
+		default:
+			err = fmt.Errorf("%w: unknown entry %q", ErrInvalidBackup, entry.Kind)
+		}
+		if err != nil {
+			return stats, err
+		}
+	}
+}
+
+func (n *net) restoreThread(e backupEntry) error {
+	tid, err := thread.Decode(e.Thread)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	key, err := thread.KeyFromString(e.Key)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	return n.store.AddThread(thread.Info{ID: tid, Key: key})
+}
+
+func (n *net) restoreLog(e backupEntry) error {
+	tid, err := thread.Decode(e.Thread)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	lg := thread.LogInfo{Head: thread.Head{Counter: e.Counter}}
+	if lg.ID, err = peer.Decode(e.Log); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	if lg.PubKey, err = crypto.UnmarshalPublicKey(e.PubKey); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	if len(e.PrivKey) != 0 {
+		if lg.PrivKey, err = crypto.UnmarshalPrivateKey(e.PrivKey); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+	}
+	for _, a := range e.Addrs {
+		addr, err := ma.NewMultiaddr(a)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+		lg.Addrs = append(lg.Addrs, addr)
+	}
+	if len(e.Head) != 0 {
+		if lg.Head.ID, err = cid.Decode(e.Head); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+	}
+
+	existing, err := n.store.GetLog(tid, lg.ID)
+	if errors.Is(err, lstore.ErrLogNotFound) {
+		return n.store.AddLog(tid, lg)
+	} else if err != nil {
+		return err
+	}
+	if lg.PrivKey != nil && existing.PrivKey == nil {
+		if err = n.store.AddPrivKey(tid, lg.ID, lg.PrivKey); err != nil {
+			return err
+		}
+	}
+	if err = n.store.SetAddrs(tid, lg.ID, lg.Addrs, pstore.PermanentAddrTTL); err != nil {
+		return err
+	}
+	if !lg.Head.ID.Defined() {
+		return nil
+	}
+	return n.store.SetHead(tid, lg.ID, lg.Head)
+}
+
+// restoreRecord stores the blocks of a record, and recovers the redactions it's part of.
+func (n *net) restoreRecord(ctx context.Context, e backupEntry) error {
+	prec := &pb.Log_Record{}
+	if err := proto.Unmarshal(e.Record, prec); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	nodes := make([]format.Node, 0, 4)
+	for _, data := range [][]byte{prec.RecordNode, prec.EventNode, prec.HeaderNode, prec.BodyNode} {
+		if len(data) == 0 {
+			continue
+		}
+		nd, err := cbornode.Decode(data, mh.SHA2_256, -1)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+		nodes = append(nodes, nd)
+	}
+	if err := n.AddMany(ctx, nodes); err != nil {
+		return err
+	}
+
+	tid, err := thread.Decode(e.Thread)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	sk, err := n.store.ServiceKey(tid)
+	if err != nil {
+		return err
+	} else if sk == nil {
+		return fmt.Errorf("%w: record of unknown thread %s", ErrInvalidBackup, tid)
+	}
+	rec, err := cbor.RecordFromNode(nodes[0], sk)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	n.indexRecordBlocks(ctx, tid, rec)
+	// the log of records is missing in older backups
+	lid, _ := peer.Decode(e.Log)
+	return n.applyRedactions(ctx, tid, lid, rec)
+}
@@ -0,0 +1,102 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_Backup(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	addRecord := func(i int) {
+		body, err := cbornode.WrapObject(map[string]interface{}{"msg": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, tr)
+	}
+	addRecord(0)
+	addRecord(1)
+
+	var full bytes.Buffer
+	token, err := n1.Backup(ctx, &full, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) == 0 {
+		t.Fatal("expected backup token")
+	}
+
+	// unchanged threads are left out of differential backups
+	var empty bytes.Buffer
+	if token, err = n1.Backup(ctx, &empty, token); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(empty.String(), `"kind":"record"`) || strings.Contains(empty.String(), `"kind":"log"`) {
+		t.Fatalf("expected empty differential backup, got %s", empty.String())
+	}
+
+	addRecord(2)
+	var diff bytes.Buffer
+	if _, err = n1.Backup(ctx, &diff, token); err != nil {
+		t.Fatal(err)
+	}
+	if c := strings.Count(diff.String(), `"kind":"record"`); c != 1 {
+		t.Fatalf("expected 1 record in differential backup, got %d", c)
+	}
+
+	// a truncated stream is rejected
+	truncated := diff.Bytes()[:bytes.LastIndex(bytes.TrimRight(diff.Bytes(), "\n"), []byte("\n"))+1]
+	if _, err := n2.Restore(ctx, bytes.NewReader(truncated)); !errors.Is(err, ErrInvalidBackup) {
+		t.Fatalf("expected truncated backup to be rejected, got %v", err)
+	}
+
+	stats, err := n2.Restore(ctx, &full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Threads != 1 || stats.Logs != 1 || stats.Records != 2 {
+		t.Fatalf("unexpected full backup stats %+v", stats)
+	}
+	if stats, err = n2.Restore(ctx, &diff); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Threads != 0 || stats.Logs != 1 || stats.Records != 1 {
+		t.Fatalf("unexpected differential backup stats %+v", stats)
+	}
+
+	restored, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Key.String() != info.Key.String() || len(restored.Logs) != 1 || restored.Logs[0].Head.Counter != 3 {
+		t.Fatalf("unexpected restored thread %+v", restored)
+	}
+	for _, tr := range recs {
+		if _, err := n2.GetRecord(ctx, info.ID, tr.Value().Cid()); err != nil {
+			t.Fatalf("expected record %s to be restored: %v", tr.Value().Cid(), err)
+		}
+	}
+
+	if _, err := n1.Backup(ctx, &bytes.Buffer{}, "bogus"); !errors.Is(err, ErrInvalidBackupToken) {
+		t.Fatalf("expected invalid token to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	bs "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var (
+	// dsThreadBlocks is the block index namespace of the blocks stored for each thread,
+	// valued by their size: /net/blocks/<thread id>/<block id>
+	dsThreadBlocks = ds.NewKey("/net/blocks")
+
+	// dsBlockUsage is the block index namespace of the totals of thread namespaces,
+	// which are present for threads indexed since they were added: /net/blockusage/<thread id>
+	dsBlockUsage = ds.NewKey("/net/blockusage")
+)
+
+// blockUsage is the total of the blocks stored for a thread.
+type blockUsage struct {
+	Blocks int64 `json:"blocks"`
+	Bytes  int64 `json:"bytes"`
+}
+
+// blockIndex namespaces the shared blockstore by thread. Blocks stay addressed by their
+// CIDs in the blockstore, while the index files each of them under the thread it was
+// stored for, so thread usage is a single read and deleting a thread is a range delete.
+// Record and event blocks are encrypted with thread keys, so they aren't shared by threads.
+type blockIndex struct {
+	store ds.Datastore
+	lock  sync.Mutex
+}
+
+func newBlockIndex(store ds.Datastore) *blockIndex {
+	return &blockIndex{store: store}
+}
+
+func threadBlockKey(tid thread.ID, c cid.Cid) ds.Key {
+	return dsThreadBlocks.ChildString(tid.String()).ChildString(c.String())
+}
+
+// init starts an empty namespace for a new thread.
+func (b *blockIndex) init(tid thread.ID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, indexed, err := b.getUsage(tid); err != nil || indexed {
+		return err
+	}
+	return b.putUsage(tid, blockUsage{})
+}
+
+// usage returns the total of the blocks stored for a thread, indexed is false for
+// threads added before the index, whose usage isn't known.
+func (b *blockIndex) usage(tid thread.ID) (u blockUsage, indexed bool, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.getUsage(tid)
+}
+
+// add files blocks under a thread, blocks filed already are skipped.
+func (b *blockIndex) add(tid thread.ID, blocks map[cid.Cid]int) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	u, indexed, err := b.getUsage(tid)
+	if err != nil || !indexed {
+		return err
+	}
+	var added bool
+	for c, size := range blocks {
+		k := threadBlockKey(tid, c)
+		if has, err := b.store.Has(k); err != nil {
+			return err
+		} else if has {
+			continue
+		}
+		if err := b.store.Put(k, []byte(strconv.Itoa(size))); err != nil {
+			return err
+		}
+		u.Blocks++
+		u.Bytes += int64(size)
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	return b.putUsage(tid, u)
+}
+
+// remove drops a block deleted from the blockstore from the namespace of a thread.
+func (b *blockIndex) remove(tid thread.ID, c cid.Cid) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	u, indexed, err := b.getUsage(tid)
+	if err != nil || !indexed {
+		return err
+	}
+	k := threadBlockKey(tid, c)
+	val, err := b.store.Get(k)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	size, err := strconv.Atoi(string(val))
+	if err != nil {
+		return fmt.Errorf("decoding size of block %s: %w", c, err)
+	}
+	if err := b.store.Delete(k); err != nil {
+		return err
+	}
+	u.Blocks--
+	u.Bytes -= int64(size)
+	return b.putUsage(tid, u)
+}
+
+// drop deletes the namespace of a thread, returning the blocks filed under it.
+func (b *blockIndex) drop(tid thread.ID) ([]cid.Cid, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	results, err := b.store.Query(query.Query{
+		Prefix:   dsThreadBlocks.ChildString(tid.String()).String(),
+		KeysOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]cid.Cid, 0, len(entries))
+	for _, e := range entries {
+		k := ds.RawKey(e.Key)
+		c, err := cid.Decode(k.BaseNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("decoding indexed block %s: %w", k, err)
+		}
+		if err := b.store.Delete(k); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, c)
+	}
+	if err := b.store.Delete(dsBlockUsage.ChildString(tid.String())); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+func (b *blockIndex) getUsage(tid thread.ID) (blockUsage, bool, error) {
+	var u blockUsage
+	val, err := b.store.Get(dsBlockUsage.ChildString(tid.String()))
+	if errors.Is(err, ds.ErrNotFound) {
+		return u, false, nil
+	} else if err != nil {
+		return u, false, err
+	}
+	if err := json.Unmarshal(val, &u); err != nil {
+		return u, false, fmt.Errorf("decoding block usage of thread %s: %w", tid, err)
+	}
+	return u, true, nil
+}
+
+func (b *blockIndex) putUsage(tid thread.ID, u blockUsage) error {
+	val, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return b.store.Put(dsBlockUsage.ChildString(tid.String()), val)
+}
+
+// initBlocks adds a thread with add, starting its block namespace if the thread is new.
+// Threads known before keep their namespace, or stay unindexed.
+func (n *net) initBlocks(id thread.ID, add func() error) error {
+	_, err := n.store.GetThread(id)
+	known := err == nil
+	if err != nil && !errors.Is(err, lstore.ErrThreadNotFound) {
+		return err
+	}
+	if err := add(); err != nil {
+		return err
+	}
+	if known {
+		return nil
+	}
+	return n.blocks.init(id)
+}
+
+// indexRecordBlocks files the locally stored blocks of a record under its thread: the
+// record itself, and its event, header and body if they're stored. Indexing failures
+// are logged only, the thread is then deleted with its unindexed blocks left behind.
+func (n *net) indexRecordBlocks(ctx context.Context, tid thread.ID, rec core.Record) {
+	ids := []cid.Cid{rec.Cid(), rec.BlockID()}
+	if known, err := n.isKnown(rec.BlockID()); err == nil && known {
+		if event, err := cbor.EventFromRecord(ctx, n, rec); err == nil {
+			ids = append(ids, event.HeaderID(), event.BodyID())
+		}
+	}
+	blocks := make(map[cid.Cid]int, len(ids))
+	for _, c := range ids {
+		size, err := n.bstore.GetSize(c)
+		if errors.Is(err, bs.ErrNotFound) {
+			continue
+		} else if err != nil {
+			log.Errorf("getting size of block %s failed: %v", c, err)
+			return
+		}
+		blocks[c] = size
+	}
+	if err := n.blocks.add(tid, blocks); err != nil {
+		log.Errorf("indexing blocks of record %s (thread %s) failed: %v", rec.Cid(), tid, err)
+	}
+}
+
+// deleteThreadBlocks deletes the blocks of a thread. Blocks of threads indexed since
+// they were added are deleted by their namespace, others by walking back their logs.
+func (n *net) deleteThreadBlocks(ctx context.Context, info thread.Info) error {
+	if _, indexed, err := n.blocks.usage(info.ID); err != nil {
+		return err
+	} else if !indexed {
+		for _, lg := range info.Logs {
+			head := lg.Head.ID
+			for head.Defined() {
+				if head, err = n.deleteRecord(ctx, head, info.Key.Service()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	blocks, err := n.blocks.drop(info.ID)
+	if err != nil {
+		return err
+	}
+	for _, c := range blocks {
+		if err := n.bstore.DeleteBlock(c); err != nil && !errors.Is(err, bs.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package net
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+)
+
+func TestNet_ThreadBlockNamespace(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	other := createThread(t, ctx, n)
+	var blocks []cid.Cid
+	for i := 0; i < 2; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.EventFromRecord(ctx, n, r.Value())
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, r.Value().Cid(), event.Cid(), event.HeaderID(), event.BodyID())
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "other"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, err := n.CreateRecord(ctx, other.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := n.threadSummary(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var size int64
+	for _, c := range blocks {
+		s, err := n.bstore.GetSize(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size += int64(s)
+	}
+	if summary.StoredBlocks != int64(len(blocks)) || summary.StoredBytes != size {
+		t.Fatalf("expected %d blocks of %d bytes, got %d blocks of %d bytes",
+			len(blocks), size, summary.StoredBlocks, summary.StoredBytes)
+	}
+
+	if err := n.DeleteThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range blocks {
+		if known, err := n.isKnown(c); err != nil {
+			t.Fatal(err)
+		} else if known {
+			t.Fatalf("expected block %d of the deleted thread to be deleted", i)
+		}
+	}
+	if _, indexed, err := n.blocks.usage(info.ID); err != nil || indexed {
+		t.Fatalf("expected namespace of the deleted thread to be dropped, got %v", err)
+	}
+	if known, err := n.isKnown(kept.Value().Cid()); err != nil || !known {
+		t.Fatalf("expected blocks of other threads to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,336 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/clock"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var (
+	// BootstrapRefreshInterval is the interval between persisting bootstrap peers and reconnecting to them.
+	BootstrapRefreshInterval = time.Minute * 5
+
+	// BootstrapConnectCount is the number of healthiest bootstrap peers kept connected.
+	BootstrapConnectCount = 8
+
+	// BootstrapMaxPeers is the maximum number of peers kept in the global list and in each thread list.
+	BootstrapMaxPeers = 64
+
+	// BootstrapPeerTTL is the duration after the last successful contact a bootstrap peer is forgotten.
+	BootstrapPeerTTL = time.Hour * 24 * 30
+
+	// bootstrapScoreWeight is the weight of the latest outcome in a peer's health score.
+	bootstrapScoreWeight = 0.2
+)
+
+// dsBootstrap is the bootstrap store namespace of known-good peers:
+// /net/bootstrap/peers/<peer id> for all threads and
+// /net/bootstrap/threads/<thread id>/<peer id> for a single thread.
+var dsBootstrap = ds.NewKey("/net/bootstrap")
+
+// bootstrapPeer is a peer which was reachable in the past, along with its health.
+type bootstrapPeer struct {
+	ID    peer.ID
+	Addrs []ma.Multiaddr
+	// Score is a moving average of call outcomes between zero (failing) and one (healthy).
+	Score float64
+	// LastSeen is the time of the last successful call.
+	LastSeen time.Time
+}
+
+type bootstrapRecord struct {
+	Addrs    []string `json:"addrs"`
+	Score    float64  `json:"score"`
+	LastSeen int64    `json:"lastSeen"`
+}
+
+// bootstrapBook keeps the known-good peers of the host and of each thread in memory,
+// dirty entries are written to the store on flush.
+type bootstrapBook struct {
+	store ds.Datastore
+	clock clock.Clock
+
+	lock  sync.Mutex
+	peers map[ds.Key]*bootstrapRecord
+	dirty map[ds.Key]struct{}
+}
+
+func bootstrapKey(tid thread.ID, pid peer.ID) ds.Key {
+	if tid == thread.Undef {
+		return dsBootstrap.ChildString("peers").ChildString(pid.String())
+	}
+	return dsBootstrap.ChildString("threads").ChildString(tid.String()).ChildString(pid.String())
+}
+
+// newBootstrapBook loads the persisted peers of a store.
+func newBootstrapBook(store ds.Datastore, clk clock.Clock) (*bootstrapBook, error) {
+	b := &bootstrapBook{
+		store: store,
+		clock: clk,
+		peers: make(map[ds.Key]*bootstrapRecord),
+		dirty: make(map[ds.Key]struct{}),
+	}
+	res, err := store.Query(query.Query{Prefix: dsBootstrap.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	for e := range res.Next() {
+		if e.Error != nil {
+			return nil, e.Error
+		}
+		var rec bootstrapRecord
+		if err := json.Unmarshal(e.Value, &rec); err != nil {
+			log.Errorf("decoding bootstrap peer %s failed: %v", e.Key, err)
+			continue
+		}
+		b.peers[ds.NewKey(e.Key)] = &rec
+	}
+	return b, nil
+}
+
+// observe updates the health of a peer with the outcome of a call. Successful peers are
+// added to the global list and to the thread list, failures only lower known scores.
+func (b *bootstrapBook) observe(tid thread.ID, pid peer.ID, addrs []ma.Multiaddr, ok bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	keys := []ds.Key{bootstrapKey(thread.Undef, pid)}
+	if tid != thread.Undef {
+		keys = append(keys, bootstrapKey(tid, pid))
+	}
+	outcome := 0.0
+	if ok {
+		outcome = 1
+	}
+	now := b.clock.Now()
+	for _, k := range keys {
+		rec, found := b.peers[k]
+		if !found {
+			if !ok {
+				continue
+			}
+			rec = &bootstrapRecord{Score: outcome}
+			b.peers[k] = rec
+		} else {
+			rec.Score += bootstrapScoreWeight * (outcome - rec.Score)
+		}
+		if ok {
+			rec.LastSeen = now.UnixNano()
+			if len(addrs) > 0 {
+				rec.Addrs = rec.Addrs[:0]
+				for _, a := range addrs {
+					rec.Addrs = append(rec.Addrs, a.String())
+				}
+			}
+		}
+		b.dirty[k] = struct{}{}
+	}
+}
+
+// list returns the peers of a thread, or the global peers if tid is undefined,
+// healthiest first.
+func (b *bootstrapBook) list(tid thread.ID) []bootstrapPeer {
+	prefix := dsBootstrap.ChildString("peers")
+	if tid != thread.Undef {
+		prefix = dsBootstrap.ChildString("threads").ChildString(tid.String())
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var peers []bootstrapPeer
+	for k, rec := range b.peers {
+		if !k.Parent().Equal(prefix) {
+			continue
+		}
+		pid, err := peer.Decode(k.BaseNamespace())
+		if err != nil {
+			continue
+		}
+		p := bootstrapPeer{
+			ID:       pid,
+			Score:    rec.Score,
+			LastSeen: time.Unix(0, rec.LastSeen),
+		}
+		for _, a := range rec.Addrs {
+			if addr, err := ma.NewMultiaddr(a); err == nil {
+				p.Addrs = append(p.Addrs, addr)
+			}
+		}
+		peers = append(peers, p)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].Score != peers[j].Score {
+			return peers[i].Score > peers[j].Score
+		}
+		return peers[i].LastSeen.After(peers[j].LastSeen)
+	})
+	return peers
+}
+
+// forgetThread removes the peer list of a thread.
+func (b *bootstrapBook) forgetThread(tid thread.ID) {
+	prefix := dsBootstrap.ChildString("threads").ChildString(tid.String())
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for k := range b.peers {
+		if k.Parent().Equal(prefix) {
+			delete(b.peers, k)
+			b.dirty[k] = struct{}{}
+		}
+	}
+}
+
+// prune forgets peers not seen within BootstrapPeerTTL and the least healthy
+// peers of lists exceeding BootstrapMaxPeers. The TTL counts from the last
+// contact with any peer, so peers aren't forgotten while the host is offline.
+func (b *bootstrapBook) prune() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var latest int64
+	for _, rec := range b.peers {
+		if rec.LastSeen > latest {
+			latest = rec.LastSeen
+		}
+	}
+	expired := latest - int64(BootstrapPeerTTL)
+	lists := make(map[string][]ds.Key)
+	for k, rec := range b.peers {
+		if rec.LastSeen < expired {
+			delete(b.peers, k)
+			b.dirty[k] = struct{}{}
+			continue
+		}
+		parent := k.Parent().String()
+		lists[parent] = append(lists[parent], k)
+	}
+	for _, keys := range lists {
+		if len(keys) <= BootstrapMaxPeers {
+			continue
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return b.peers[keys[i]].Score > b.peers[keys[j]].Score
+		})
+		for _, k := range keys[BootstrapMaxPeers:] {
+			delete(b.peers, k)
+			b.dirty[k] = struct{}{}
+		}
+	}
+}
+
+// flush writes the entries changed since the last flush to the store.
+func (b *bootstrapBook) flush() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for k := range b.dirty {
+		if rec, ok := b.peers[k]; ok {
+			val, err := json.Marshal(rec)
+			if err != nil {
+				return err
+			}
+			if err := b.store.Put(k, val); err != nil {
+				return err
+			}
+		} else if err := b.store.Delete(k); err != nil {
+			return err
+		}
+		delete(b.dirty, k)
+	}
+	return nil
+}
+
+// observePeer updates the bootstrap health of a peer with the outcome of a sync call.
+func (n *net) observePeer(tid thread.ID, pid peer.ID, ok bool) {
+	var addrs []ma.Multiaddr
+	if ok {
+		addrs = n.host.Peerstore().Addrs(pid)
+	}
+	n.bootstrap.observe(tid, pid, addrs, ok)
+}
+
+// startBootstrap reconnects to bootstrap peers on start and every BootstrapRefreshInterval.
+// Persisted addresses are re-added to the peerstore, so that peers remain reachable
+// after long offline periods, when address book entries went stale.
+func (n *net) startBootstrap() {
+	n.refreshBootstrap()
+	tick := n.clock.NewTicker(BootstrapRefreshInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			n.refreshBootstrap()
+		case <-n.ctx.Done():
+			return
+		}
+	}
+}
+
+func (n *net) refreshBootstrap() {
+	n.bootstrap.prune()
+	if err := n.bootstrap.flush(); err != nil {
+		log.Errorf("persisting bootstrap peers failed: %v", err)
+	}
+	// other transports dial known peers only
+	if _, ok := n.transport.(*libp2pTransport); !ok {
+		return
+	}
+
+	targets := make(map[peer.ID]bootstrapPeer)
+	for _, p := range n.bootstrap.list(thread.Undef) {
+		if len(targets) >= BootstrapConnectCount {
+			break
+		}
+		targets[p.ID] = p
+	}
+	ts, err := n.store.Threads()
+	if err != nil {
+		log.Errorf("error listing threads: %v", err)
+	}
+	for _, tid := range ts {
+		// the healthiest disconnected peer of each thread without connected peers
+		var candidate *bootstrapPeer
+		peers := n.bootstrap.list(tid)
+		for i, p := range peers {
+			if n.host.Network().Connectedness(p.ID) == network.Connected {
+				candidate = nil
+				break
+			}
+			if candidate == nil {
+				candidate = &peers[i]
+			}
+		}
+		if candidate != nil {
+			targets[candidate.ID] = *candidate
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range targets {
+		if p.ID == n.host.ID() || n.host.Network().Connectedness(p.ID) == network.Connected {
+			continue
+		}
+		wg.Add(1)
+		go func(p bootstrapPeer) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(n.ctx, DialTimeout)
+			defer cancel()
+			n.host.Peerstore().AddAddrs(p.ID, p.Addrs, pstore.AddressTTL)
+			if err := n.host.Connect(ctx, peer.AddrInfo{ID: p.ID, Addrs: p.Addrs}); err != nil {
+				log.Debugf("connecting to bootstrap peer %s failed: %v", p.ID, err)
+				n.bootstrap.observe(thread.Undef, p.ID, nil, false)
+				return
+			}
+			n.bootstrap.observe(thread.Undef, p.ID, p.Addrs, true)
+		}(p)
+	}
+	wg.Wait()
+}
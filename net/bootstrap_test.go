@@ -0,0 +1,117 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/clock"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestBootstrapBook(t *testing.T) {
+	t.Parallel()
+	store := syncds.MutexWrap(ds.NewMapDatastore())
+	b, err := newBootstrapBook(store, clock.Real)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tid := thread.NewIDV1(thread.Raw, 32)
+	p1, p2, p3 := makePeerID(t), makePeerID(t), makePeerID(t)
+	addr := ma.StringCast("/ip4/127.0.0.1/tcp/4006")
+
+	b.observe(tid, p1, []ma.Multiaddr{addr}, true)
+	b.observe(thread.Undef, p2, nil, true)
+	b.observe(thread.Undef, p2, nil, false)
+	// failures of unknown peers aren't recorded
+	b.observe(tid, p3, nil, false)
+
+	global := b.list(thread.Undef)
+	if len(global) != 2 || global[0].ID != p1 || global[1].ID != p2 {
+		t.Fatalf("expected healthiest peer first, got %v", global)
+	}
+	if global[1].Score >= 1 {
+		t.Fatalf("expected failure to lower the score, got %f", global[1].Score)
+	}
+	peers := b.list(tid)
+	if len(peers) != 1 || peers[0].ID != p1 || len(peers[0].Addrs) != 1 || !peers[0].Addrs[0].Equal(addr) {
+		t.Fatalf("expected thread peer with address, got %v", peers)
+	}
+
+	// peers are persisted on flush
+	if err := b.flush(); err != nil {
+		t.Fatal(err)
+	}
+	b, err = newBootstrapBook(store, clock.Real)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.list(thread.Undef)) != 2 || len(b.list(tid)) != 1 {
+		t.Fatal("expected peers to be loaded from the store")
+	}
+
+	// expiry counts from the last contact with any peer
+	b.peers[bootstrapKey(thread.Undef, p2)].LastSeen -= int64(BootstrapPeerTTL * 2)
+	b.prune()
+	if global = b.list(thread.Undef); len(global) != 1 || global[0].ID != p1 {
+		t.Fatalf("expected stale peer to be forgotten, got %v", global)
+	}
+	for k := range b.peers {
+		b.peers[k].LastSeen -= int64(BootstrapPeerTTL * 2)
+	}
+	b.prune()
+	if len(b.list(thread.Undef)) != 1 {
+		t.Fatal("expected peers to be kept while the host was offline")
+	}
+
+	b.forgetThread(tid)
+	if len(b.list(tid)) != 0 {
+		t.Fatal("expected thread peers to be forgotten")
+	}
+	if err := b.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := store.Has(bootstrapKey(tid, p1)); has {
+		t.Fatal("expected forgotten peer to be deleted from the store")
+	}
+}
+
+func TestNet_Bootstrap(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.TempAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr := ma.StringCast("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	peers := n2.bootstrap.list(info.ID)
+	if len(peers) != 1 || peers[0].ID != n1.Host().ID() || len(peers[0].Addrs) == 0 {
+		t.Fatalf("expected synced peer to be a thread bootstrap peer, got %v", peers)
+	}
+
+	// stale address book entries don't prevent reconnecting
+	if err := n2.Host().Network().ClosePeer(n1.Host().ID()); err != nil {
+		t.Fatal(err)
+	}
+	n2.Host().Peerstore().ClearAddrs(n1.Host().ID())
+	time.Sleep(time.Millisecond * 100)
+	n2.refreshBootstrap()
+	if n2.Host().Network().Connectedness(n1.Host().ID()) != network.Connected {
+		t.Fatal("expected bootstrap peer to be reconnected")
+	}
+}
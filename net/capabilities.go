@@ -0,0 +1,203 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Capability is an optional protocol feature advertised in handshakes.
+type Capability string
+
+const (
+	// CapEdgeExchange is the exchange of log address and head edges.
+	CapEdgeExchange Capability = "edge-exchange"
+	// CapLogDigests is the comparison of logs by digests of record ranges.
+	CapLogDigests Capability = "log-digests"
+	// CapLazyBodies is the fetching of single records by CID, used to load
+	// the bodies of records which were skipped by light pulls.
+	CapLazyBodies Capability = "lazy-bodies"
+	// CapCheckpoints is serving pruned logs from checkpoint records.
+	CapCheckpoints Capability = "checkpoints"
+	// CapRecordAcks is accepting acks of records of logs authored by the peer.
+	CapRecordAcks Capability = "record-acks"
+	// CapRecentRecords is serving the records recently multicast over pubsub.
+	CapRecentRecords Capability = "recent-records"
+	// CapRecordQueries is serving the records matching selectors.
+	CapRecordQueries Capability = "record-queries"
+	// CapBackwardRecords is serving the records preceding an anchor, used to back-fill
+	// progressively bootstrapped logs.
+	CapBackwardRecords Capability = "backward-records"
+)
+
+var (
+	// CapabilitiesTTL is how long the capabilities of a peer are cached before
+	// they're requested again.
+	CapabilitiesTTL = time.Hour
+
+	// ErrCapabilityUnsupported indicates a peer doesn't support an optional protocol feature.
+	ErrCapabilityUnsupported = errors.New("capability not supported by peer")
+)
+
+// peerCapabilitiesKey is the peerstore metadata key of a peer's handshake.
+const peerCapabilitiesKey = "threads/capabilities"
+
+// PeerCapabilities are the protocol version and capabilities advertised by a peer.
+type PeerCapabilities struct {
+	// Version is the protocol version of the peer.
+	Version string
+	// Capabilities are the optional protocol features supported by the peer.
+	Capabilities []Capability
+	// Known is false for peers which predate handshakes, or couldn't be reached.
+	Known bool
+}
+
+// Supports returns whether the peer supports a capability. Peers with unknown
+// capabilities are assumed to support all of them, so calls to them behave as
+// before handshakes were added.
+func (c PeerCapabilities) Supports(cap Capability) bool {
+	if !c.Known {
+		return true
+	}
+	for _, pc := range c.Capabilities {
+		if pc == cap {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedCapabilities are the peerstore entry of a peer's handshake.
+type cachedCapabilities struct {
+	caps    PeerCapabilities
+	fetched time.Time
+}
+
+// PeerCapabilities returns the protocol version and capabilities of a peer,
+// handshaking with it if they aren't cached.
+func (n *net) PeerCapabilities(ctx context.Context, pid peer.ID) (PeerCapabilities, error) {
+	if err := pid.Validate(); err != nil {
+		return PeerCapabilities{}, err
+	}
+	return n.server.peerCapabilities(ctx, pid)
+}
+
+// capabilities returns the capabilities supported by the host.
+func (n *net) capabilities() []Capability {
+	caps := []Capability{CapEdgeExchange, CapLogDigests, CapLazyBodies, CapCheckpoints, CapRecordQueries, CapBackwardRecords}
+	if n.acks != nil {
+		caps = append(caps, CapRecordAcks)
+	}
+	if n.server.ps != nil {
+		caps = append(caps, CapRecentRecords)
+	}
+	return caps
+}
+
+func (n *net) setPeerCapabilities(pid peer.ID, caps PeerCapabilities) {
+	entry := cachedCapabilities{caps: caps, fetched: n.clock.Now()}
+	if err := n.host.Peerstore().Put(pid, peerCapabilitiesKey, entry); err != nil {
+		log.Errorf("storing capabilities of %s failed: %v", pid, err)
+	}
+}
+
+func (n *net) cachedPeerCapabilities(pid peer.ID) (PeerCapabilities, bool) {
+	v, err := n.host.Peerstore().Get(pid, peerCapabilitiesKey)
+	if err != nil {
+		return PeerCapabilities{}, false
+	}
+	entry, ok := v.(cachedCapabilities)
+	if !ok || n.clock.Since(entry.fetched) > CapabilitiesTTL {
+		return PeerCapabilities{}, false
+	}
+	return entry.caps, true
+}
+
+// Handshake receives a handshake request, remembering the capabilities of the caller.
+func (s *server) Handshake(ctx context.Context, req *pb.HandshakeRequest) (*pb.HandshakeReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received handshake request from %s", pid)
+
+	if req.Body != nil {
+		s.net.setPeerCapabilities(pid, capabilitiesFromProto(req.Body.Version, req.Body.Capabilities))
+	}
+	return &pb.HandshakeReply{
+		Version:      thread.Version,
+		Capabilities: capabilitiesToProto(s.net.capabilities()),
+	}, nil
+}
+
+// peerCapabilities returns the cached capabilities of a peer, or handshakes with it.
+// Peers which don't implement handshakes are cached as unknown, while peers which
+// couldn't be reached are reported as unknown without caching them.
+func (s *server) peerCapabilities(ctx context.Context, pid peer.ID) (PeerCapabilities, error) {
+	if caps, ok := s.net.cachedPeerCapabilities(pid); ok {
+		return caps, nil
+	}
+	client, err := s.dial(pid)
+	if err != nil {
+		return PeerCapabilities{}, fmt.Errorf("dial %s failed: %w", pid, err)
+	}
+	cctx, cancel := context.WithTimeout(ctx, DialTimeout)
+	defer cancel()
+	reply, err := client.Handshake(cctx, &pb.HandshakeRequest{
+		Body: &pb.HandshakeRequest_Body{
+			Version:      thread.Version,
+			Capabilities: capabilitiesToProto(s.net.capabilities()),
+		},
+	})
+	if status.Code(err) == codes.Unimplemented {
+		log.Debugf("%s doesn't support handshakes, assuming all capabilities", pid)
+		s.net.setPeerCapabilities(pid, PeerCapabilities{})
+		return PeerCapabilities{}, nil
+	} else if err != nil {
+		return PeerCapabilities{}, err
+	}
+	caps := capabilitiesFromProto(reply.Version, reply.Capabilities)
+	s.net.setPeerCapabilities(pid, caps)
+	return caps, nil
+}
+
+// requireCapability returns ErrCapabilityUnsupported if a peer advertised it doesn't
+// support a capability. Handshake failures are ignored, the call itself reports them.
+func (s *server) requireCapability(ctx context.Context, pid peer.ID, c Capability) error {
+	caps, err := s.peerCapabilities(ctx, pid)
+	if err != nil {
+		log.Debugf("handshake with %s failed: %v", pid, err)
+		return nil
+	}
+	if !caps.Supports(c) {
+		return fmt.Errorf("%w: %s doesn't support %s", ErrCapabilityUnsupported, pid, c)
+	}
+	return nil
+}
+
+func capabilitiesToProto(caps []Capability) []string {
+	pcaps := make([]string, len(caps))
+	for i, c := range caps {
+		pcaps[i] = string(c)
+	}
+	return pcaps
+}
+
+func capabilitiesFromProto(version string, pcaps []string) PeerCapabilities {
+	caps := PeerCapabilities{Version: version, Known: true}
+	for _, c := range pcaps {
+		caps.Capabilities = append(caps.Capabilities, Capability(c))
+	}
+	sort.Slice(caps.Capabilities, func(i, j int) bool {
+		return caps.Capabilities[i] < caps.Capabilities[j]
+	})
+	return caps
+}
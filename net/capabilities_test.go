@@ -0,0 +1,82 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/textileio/go-threads/clock"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"github.com/textileio/go-threads/test"
+)
+
+func TestNet_PeerCapabilities(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{RecordAcks: true}).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	caps, err := n2.PeerCapabilities(ctx, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.Known || caps.Version != thread.Version {
+		t.Fatalf("expected known capabilities of version %s, got %+v", thread.Version, caps)
+	}
+	if !caps.Supports(CapRecordAcks) || !caps.Supports(CapEdgeExchange) {
+		t.Fatalf("expected record acks and edge exchange to be supported, got %v", caps.Capabilities)
+	}
+	if caps.Supports(CapRecentRecords) {
+		t.Fatal("expected recent records to be unsupported without pubsub")
+	}
+
+	// the callee remembers the capabilities of the caller
+	caps, ok := n1.cachedPeerCapabilities(n2.Host().ID())
+	if !ok || !caps.Known || caps.Supports(CapRecordAcks) {
+		t.Fatalf("expected handshake of caller to be cached without record acks, got %+v", caps)
+	}
+
+	// optional calls fail clearly instead of with unimplemented errors
+	info := createThread(t, ctx, n1)
+	err = n1.server.pushAcks(ctx, n2.Host().ID(), info.ID, []*pb.RecordAck{})
+	if !errors.Is(err, ErrCapabilityUnsupported) {
+		t.Fatalf("expected unsupported capability error, got %v", err)
+	}
+}
+
+func TestPeerCapabilities_Unknown(t *testing.T) {
+	t.Parallel()
+	var caps PeerCapabilities
+	if !caps.Supports(CapLogDigests) {
+		t.Fatal("expected peers without handshakes to be assumed to support all capabilities")
+	}
+	caps = capabilitiesFromProto("0.0.1", []string{string(CapLogDigests)})
+	if !caps.Supports(CapLogDigests) || caps.Supports(CapLazyBodies) {
+		t.Fatalf("unexpected capabilities %v", caps.Capabilities)
+	}
+}
+
+func TestNet_PeerCapabilitiesExpiry(t *testing.T) {
+	t.Parallel()
+	clk := clock.NewMock(time.Now())
+	n := makeNetworkWithConfig(t, Config{Clock: clk}).(*net)
+	defer n.Close()
+
+	pid := test.GeneratePeerIDs(1)[0]
+	n.setPeerCapabilities(pid, PeerCapabilities{Version: thread.Version, Known: true})
+	clk.Add(CapabilitiesTTL)
+	if _, ok := n.cachedPeerCapabilities(pid); !ok {
+		t.Fatal("expected capabilities to be cached until they expire")
+	}
+	clk.Add(time.Second)
+	if _, ok := n.cachedPeerCapabilities(pid); ok {
+		t.Fatal("expected capabilities to expire in virtual time")
+	}
+}
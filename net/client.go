@@ -9,11 +9,12 @@ import (
 	"time"
 
 	"github.com/gogo/status"
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pstore "github.com/libp2p/go-libp2p-core/peerstore"
-	gostream "github.com/libp2p/go-libp2p-gostream"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
@@ -21,7 +22,6 @@ import (
 	pb "github.com/textileio/go-threads/net/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/connectivity"
 )
 
 var (
@@ -32,7 +32,10 @@ var (
 )
 
 // getLogs in a thread.
-func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thread.LogInfo, error) {
+func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) (_ []thread.LogInfo, err error) {
+	start := time.Now()
+	defer func() { s.net.observeExchange(id, pid, opGetLogs, start, err) }()
+
 	sk, err := s.net.store.ServiceKey(id)
 	if err != nil {
 		return nil, err
@@ -45,6 +48,24 @@ func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thre
 		ThreadID:   &pb.ProtoThreadID{ID: id},
 		ServiceKey: &pb.ProtoKey{Key: sk},
 	}
+	// send local address edges, so only new or changed logs are returned
+	info, err := s.net.store.GetThread(id)
+	if err != nil && !errors.Is(err, lstore.ErrThreadNotFound) {
+		return nil, err
+	}
+	body.Logs = make([]*pb.GetLogsRequest_Body_LogEntry, len(info.Logs))
+	for i, l := range info.Logs {
+		body.Logs[i] = &pb.GetLogsRequest_Body_LogEntry{
+			LogID:       &pb.ProtoPeerID{ID: l.ID},
+			AddressEdge: logAddrsEdge(l),
+		}
+	}
+	if body.Follows, err = s.net.threadFollows(id); err != nil {
+		return nil, err
+	}
+	if body.Freeze, err = s.net.threadFreeze(id); err != nil {
+		return nil, err
+	}
 	req := &pb.GetLogsRequest{
 		Body: body,
 	}
@@ -64,6 +85,14 @@ func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thre
 	}
 
 	log.Debugf("received %d logs from %s", len(reply.Logs), pid)
+	if _, err = s.net.putFollows(id, reply.Follows); err != nil {
+		log.Errorf("putting follows of thread %s from %s failed: %v", id, pid, err)
+	}
+	if reply.Freeze != nil {
+		if _, err = s.net.putFreeze(id, reply.Freeze); err != nil {
+			log.Debugf("skipping freeze of thread %s from %s: %v", id, pid, err)
+		}
+	}
 
 	lgs := make([]thread.LogInfo, len(reply.Logs))
 	for i, l := range reply.Logs {
@@ -74,7 +103,10 @@ func (s *server) getLogs(ctx context.Context, id thread.ID, pid peer.ID) ([]thre
 }
 
 // pushLog to a peer.
-func (s *server) pushLog(ctx context.Context, id thread.ID, lg thread.LogInfo, pid peer.ID, sk *sym.Key, rk *sym.Key) error {
+func (s *server) pushLog(ctx context.Context, id thread.ID, lg thread.LogInfo, pid peer.ID, sk *sym.Key, rk *sym.Key) (err error) {
+	start := time.Now()
+	defer func() { s.net.observeExchange(id, pid, opPushLog, start, err) }()
+
 	body := &pb.PushLogRequest_Body{
 		ThreadID: &pb.ProtoThreadID{ID: id},
 		Log:      logToProto(lg),
@@ -85,6 +117,12 @@ func (s *server) pushLog(ctx context.Context, id thread.ID, lg thread.LogInfo, p
 	if rk != nil {
 		body.ReadKey = &pb.ProtoKey{Key: rk}
 	}
+	// peers knowing the log replace its addresses only if they're signed
+	if lg.PrivKey != nil {
+		if body.Addrs, err = logAddrsToProto(id, lg); err != nil {
+			return err
+		}
+	}
 	lreq := &pb.PushLogRequest{
 		Body: body,
 	}
@@ -135,6 +173,7 @@ func (s *server) getRecords(
 				}
 				for lid, rs := range recs {
 					rc.UpdateHeadCounter(lid, rs.counter)
+					rc.UpdateBase(lid, rs.base)
 					for _, rec := range rs.records {
 						rc.Store(lid, rec)
 					}
@@ -162,20 +201,29 @@ func (s *server) buildGetRecordsRequest(
 		return
 	}
 
-	var pblgs = make([]*pb.GetRecordsRequest_Body_LogEntry, 0, len(offsets))
+	var (
+		pblgs       = make([]*pb.GetRecordsRequest_Body_LogEntry, 0, len(offsets))
+		progressive = s.net.deferLinkage(tid)
+	)
 	for lid, offset := range offsets {
+		// logs bootstrapped progressively start from a page of the newest records
+		var l = limit
+		if progressive && !offset.ID.Defined() && BootstrapPageSize < l {
+			l = BootstrapPageSize
+		}
 		pblgs = append(pblgs, &pb.GetRecordsRequest_Body_LogEntry{
 			LogID:   &pb.ProtoPeerID{ID: lid},
 			Offset:  &pb.ProtoCid{Cid: offset.ID},
-			Limit:   int32(limit),
+			Limit:   int32(l),
 			Counter: offset.Counter,
 		})
 	}
 
 	body := &pb.GetRecordsRequest_Body{
-		ThreadID:   &pb.ProtoThreadID{ID: tid},
-		ServiceKey: &pb.ProtoKey{Key: serviceKey},
-		Logs:       pblgs,
+		ThreadID:    &pb.ProtoThreadID{ID: tid},
+		ServiceKey:  &pb.ProtoKey{Key: serviceKey},
+		Logs:        pblgs,
+		HeadersOnly: s.net.lightClient,
 	}
 
 	req = &pb.GetRecordsRequest{
@@ -187,6 +235,8 @@ func (s *server) buildGetRecordsRequest(
 type peerRecords struct {
 	records []core.Record
 	counter int64
+	// base is the pruned predecessor of the first record, if the log was pruned by the peer
+	base cid.Cid
 }
 
 // Send GetRecords request to a certain peer.
@@ -196,10 +246,23 @@ func (s *server) getRecordsFromPeer(
 	pid peer.ID,
 	req *pb.GetRecordsRequest,
 	serviceKey *sym.Key,
-) (map[peer.ID]peerRecords, error) {
+) (_ map[peer.ID]peerRecords, err error) {
+	var (
+		start = time.Now()
+		// failed requests aren't returned to the caller, but still end up in the journal
+		reqErr error
+	)
+	defer func() {
+		if reqErr == nil {
+			reqErr = err
+		}
+		s.net.observeExchange(tid, pid, opGetRecords, start, reqErr)
+	}()
+
 	log.Debugf("getting records from %s...", pid)
 	client, err := s.dial(pid)
 	if err != nil {
+		s.net.observePeer(tid, pid, false)
 		return nil, fmt.Errorf("dial %s failed: %w", pid, err)
 	}
 
@@ -209,17 +272,30 @@ func (s *server) getRecordsFromPeer(
 	reply, err := client.GetRecords(cctx, req)
 	if err != nil {
 		log.Warnf("get records from %s failed: %s", pid, err)
+		s.net.observePeer(tid, pid, false)
+		reqErr = err
 		return recs, nil
 	}
+	s.net.observePeer(tid, pid, true)
+
+	var (
+		received     int
+		receivedSize int64
+	)
+	for _, l := range reply.Logs {
+		for _, r := range l.Records {
+			receivedSize += int64(recordSize(r))
+		}
+		received += len(l.Records)
+	}
+	s.net.pullBudget.Observe(received, receivedSize)
+	s.net.progress.observeBytes(tid, receivedSize)
 
 	for _, l := range reply.Logs {
 		var logID = l.LogID.ID
 		log.Debugf("received %d records in log %s from %s", len(l.Records), logID, pid)
-
-		if l.Log != nil && len(l.Log.Addrs) > 0 {
-			if err = s.net.store.AddAddrs(tid, logID, addrsFromProto(l.Log.Addrs), pstore.PermanentAddrTTL); err != nil {
-				return nil, err
-			}
+		if l.Log != nil {
+			s.net.observeHeight(tid, logID, l.Log.Counter)
 		}
 
 		pk, err := s.net.store.PubKey(tid, logID)
@@ -232,65 +308,306 @@ func (s *server) getRecordsFromPeer(
 				// cannot verify received records
 				continue
 			}
+			// logs of new writers may have to be approved by the thread owner first
+			if pending, err := s.net.holdLog(tid, pid, logFromProto(l.Log)); err != nil {
+				return nil, err
+			} else if pending {
+				continue
+			}
 			if err := s.net.store.AddPubKey(tid, logID, l.Log.PubKey); err != nil {
 				return nil, err
 			}
 			pk = l.Log.PubKey
 		}
-		var records []core.Record
+
+		if l.Log != nil && len(l.Log.Addrs) > 0 {
+			if err = s.net.store.AddAddrs(tid, logID, addrsFromProto(l.Log.Addrs), pstore.PermanentAddrTTL); err != nil {
+				return nil, err
+			}
+		}
+		records := make([]core.Record, 0, len(l.Records))
 		for _, r := range l.Records {
+			if size := recordSize(r); size > s.net.maxRecordSize {
+				// records beyond it don't follow the kept ones, so the rest of the log is dropped
+				log.Warnf("record of log %s from %s exceeds the maximum record size (%d > %d bytes)",
+					logID, pid, size, s.net.maxRecordSize)
+				break
+			}
 			rec, err := cbor.RecordFromProto(r, serviceKey)
 			if err != nil {
 				return nil, err
 			}
-			if err = rec.Verify(pk); err != nil {
-				return nil, err
+			if n := len(records); n > 0 && !rec.PrevID().Equals(records[n-1].Cid()) {
+				return nil, fmt.Errorf("record %s of log %s doesn't follow record %s", rec.Cid(), logID, records[n-1].Cid())
 			}
 			records = append(records, rec)
 		}
+		if err = s.net.verifier.verify(ctx, len(records), func(i int) error {
+			if s.net.lightClient && cbor.IsHeader(records[i]) {
+				// events are checked by their cid once fetched
+				return cbor.VerifyHeader(records[i], pk)
+			}
+			return records[i].Verify(pk)
+		}); err != nil {
+			return nil, err
+		}
+		var base cid.Cid
+		if l.Base != nil && len(records) > 0 {
+			if base = l.Base.Cid; !records[0].PrevID().Equals(base) {
+				return nil, fmt.Errorf("log %s base %s doesn't precede the checkpoint record", logID, base)
+			}
+		}
 		recs[logID] = peerRecords{
 			records: records,
 			counter: l.Log.Counter,
+			base:    base,
 		}
 	}
 
 	return recs, nil
 }
 
-// pushRecord to log addresses and thread topic.
-func (s *server) pushRecord(ctx context.Context, tid thread.ID, lid peer.ID, rec core.Record, counter int64) error {
-	// Collect known writers
-	addrs := make([]ma.Multiaddr, 0)
-	info, err := s.net.store.GetThread(tid)
+// getRecordsByCID requests specific records of a log from a peer. Records unknown to the peer
+// or exceeding the maximum record size are omitted, returned records are checked to be the
+// requested ones, signed by the log key and linked to the log head of the peer.
+func (s *server) getRecordsByCID(
+	ctx context.Context,
+	tid thread.ID,
+	pid peer.ID,
+	lid peer.ID,
+	rids []cid.Cid,
+) ([]core.Record, error) {
+	sk, err := s.net.store.ServiceKey(tid)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("obtaining service key: %w", err)
+	} else if sk == nil {
+		return nil, errors.New("a service-key is required to request records")
 	}
-	for _, l := range info.Logs {
-		addrs = append(addrs, l.Addrs...)
+	pk, err := s.net.store.PubKey(tid, lid)
+	if err != nil {
+		return nil, err
+	} else if pk == nil {
+		return nil, fmt.Errorf("cannot verify records of unknown log %s", lid)
+	}
+
+	requested := make(map[cid.Cid]struct{}, len(rids))
+	pbrids := make([]pb.ProtoCid, len(rids))
+	for i, rid := range rids {
+		requested[rid] = struct{}{}
+		pbrids[i] = pb.ProtoCid{Cid: rid}
+	}
+	if err := s.requireCapability(ctx, pid, CapLazyBodies); err != nil {
+		return nil, err
 	}
-	peers, err := s.net.uniquePeers(addrs)
+	client, err := s.dial(pid)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("dial %s failed: %w", pid, err)
+	}
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+	reply, err := client.GetRecordsByCID(cctx, &pb.GetRecordsByCIDRequest{
+		Body: &pb.GetRecordsByCIDRequest_Body{
+			ThreadID:   &pb.ProtoThreadID{ID: tid},
+			ServiceKey: &pb.ProtoKey{Key: sk},
+			Logs: []*pb.GetRecordsByCIDRequest_Body_LogEntry{{
+				LogID:     &pb.ProtoPeerID{ID: lid},
+				RecordIDs: pbrids,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	pbrec, err := cbor.RecordToProto(ctx, s.net, rec)
+	var (
+		recs   []core.Record
+		proofs []core.AncestryProof
+	)
+	for _, l := range reply.Logs {
+		if l.LogID == nil || l.LogID.ID != lid {
+			continue
+		}
+		if len(l.Proofs) != len(l.Records) {
+			return nil, fmt.Errorf("records of log %s from %s aren't proven", lid, pid)
+		}
+		for i, r := range l.Records {
+			if size := recordSize(r); size > s.net.maxRecordSize {
+				log.Warnf("record of log %s from %s exceeds the maximum record size (%d > %d bytes)",
+					lid, pid, size, s.net.maxRecordSize)
+				continue
+			}
+			rec, err := cbor.RecordFromProto(r, sk)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := requested[rec.Cid()]; !ok {
+				return nil, fmt.Errorf("received unexpected record %s from %s", rec.Cid(), pid)
+			}
+			proof, err := proofFromProto(l.Proofs[i], sk)
+			if err != nil {
+				return nil, fmt.Errorf("decoding proof of record %s: %w", rec.Cid(), err)
+			}
+			recs = append(recs, rec)
+			proofs = append(proofs, proof)
+		}
+	}
+	// records are linked to the log head of the peer, which is signed by the log key
+	if err = s.net.verifier.verify(ctx, len(recs), func(i int) error {
+		if err := recs[i].Verify(pk); err != nil {
+			return err
+		}
+		return cbor.VerifyAncestryProof(proofs[i], recs[i].Cid(), pk)
+	}); err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// getLogDigests requests the digests of tree nodes over the first length records of a log
+// from a peer. The peer's record counter is returned along with the digests, which are
+// omitted if the peer has fewer records.
+func (s *server) getLogDigests(
+	ctx context.Context,
+	pid peer.ID,
+	tid thread.ID,
+	lid peer.ID,
+	length int64,
+	nodes []digestNode,
+) (int64, [][]byte, error) {
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return 0, nil, fmt.Errorf("obtaining service key: %w", err)
+	} else if sk == nil {
+		return 0, nil, errors.New("a service-key is required to request digests")
+	}
+	ranges := make([]*pb.GetLogDigestsRequest_Range, len(nodes))
+	for i, nd := range nodes {
+		ranges[i] = &pb.GetLogDigestsRequest_Range{Level: int32(nd.level), Index: nd.index}
+	}
+	if err := s.requireCapability(ctx, pid, CapLogDigests); err != nil {
+		return 0, nil, err
+	}
+	client, err := s.dial(pid)
+	if err != nil {
+		return 0, nil, fmt.Errorf("dial %s failed: %w", pid, err)
+	}
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+	reply, err := client.GetLogDigests(cctx, &pb.GetLogDigestsRequest{
+		Body: &pb.GetLogDigestsRequest_Body{
+			ThreadID:   &pb.ProtoThreadID{ID: tid},
+			ServiceKey: &pb.ProtoKey{Key: sk},
+			LogID:      &pb.ProtoPeerID{ID: lid},
+			Length:     length,
+			Ranges:     ranges,
+		},
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return reply.Counter, reply.Digests, nil
+}
+
+// pushAcks sends acks of thread logs to their author.
+func (s *server) pushAcks(ctx context.Context, pid peer.ID, tid thread.ID, acks []*pb.RecordAck) error {
+	sk, err := s.net.store.ServiceKey(tid)
 	if err != nil {
+		return fmt.Errorf("obtaining service key: %w", err)
+	} else if sk == nil {
+		return errors.New("a service-key is required to push acks")
+	}
+	if err := s.requireCapability(ctx, pid, CapRecordAcks); err != nil {
 		return err
 	}
-	body := &pb.PushRecordRequest_Body{
-		ThreadID: &pb.ProtoThreadID{ID: tid},
-		LogID:    &pb.ProtoPeerID{ID: lid},
-		Record:   pbrec,
+	client, err := s.dial(pid)
+	if err != nil {
+		return fmt.Errorf("dial %s failed: %w", pid, err)
 	}
-	req := &pb.PushRecordRequest{
-		Body:    body,
-		Counter: counter,
+	cctx, cancel := context.WithTimeout(ctx, PushTimeout)
+	defer cancel()
+	_, err = client.PushAcks(cctx, &pb.PushAcksRequest{
+		Body: &pb.PushAcksRequest_Body{
+			ThreadID:   &pb.ProtoThreadID{ID: tid},
+			ServiceKey: &pb.ProtoKey{Key: sk},
+			Acks:       acks,
+		},
+	})
+	return err
+}
+
+// getRecentRecords requests the records recently multicast over a thread's topic from a peer.
+func (s *server) getRecentRecords(ctx context.Context, pid peer.ID, tid thread.ID) ([]*pb.PushRecordRequest, error) {
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining service key: %w", err)
+	} else if sk == nil {
+		return nil, errors.New("a service-key is required to request records")
+	}
+	if err := s.requireCapability(ctx, pid, CapRecentRecords); err != nil {
+		return nil, err
 	}
+	client, err := s.dial(pid)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s failed: %w", pid, err)
+	}
+	reply, err := client.GetRecentRecords(ctx, &pb.GetRecentRecordsRequest{
+		Body: &pb.GetRecentRecordsRequest_Body{
+			ThreadID:      &pb.ProtoThreadID{ID: tid},
+			ServiceKey:    &pb.ProtoKey{Key: sk},
+			MaxRecordSize: int64(s.net.maxRecordSize),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Records, nil
+}
 
-	// Push to each address
+// pushRecord to log addresses and thread topic. Low priority records are queued
+// and pushed in batches.
+func (s *server) pushRecord(
+	ctx context.Context,
+	tid thread.ID,
+	lid peer.ID,
+	rec core.Record,
+	counter int64,
+	priority core.RecordPriority,
+) error {
+	if priority == core.PriorityLow {
+		s.batcher.add(tid, lid, rec, counter)
+		return nil
+	}
+	// records of threads with paused sync are pulled by peers once it's resumed
+	if !s.net.syncs.enter(tid) {
+		return nil
+	}
+	var wg sync.WaitGroup
+	defer func() {
+		go func() {
+			wg.Wait()
+			s.net.syncs.leave(tid)
+		}()
+	}()
+	peers, err := s.pushPeers(tid)
+	if err != nil {
+		return err
+	}
+	req, err := s.pushRecordRequest(ctx, tid, lid, rec, counter)
+	if err != nil {
+		return err
+	}
+
+	// Push to each address, skipping peers which wouldn't accept the record
+	size := recordSize(req.Body.Record)
 	for _, p := range peers {
+		if !s.net.peerAcceptsRecord(p, size) {
+			log.Warnf("record exceeds the max record size of %s, skip pushing (thread: %s, log: %s)", p, tid, lid)
+			continue
+		}
+		wg.Add(1)
 		go func(pid peer.ID) {
-			if err := s.pushRecordToPeer(req, pid, tid, lid); err != nil {
+			defer wg.Done()
+			if err := s.pushRecordToPeer(req, pid, tid, lid, priority); err != nil {
 				log.Errorf("pushing record to %s (thread: %s, log: %s) failed: %v", pid, tid, lid, err)
 			}
 		}(p)
@@ -306,18 +623,71 @@ func (s *server) pushRecord(ctx context.Context, tid thread.ID, lid peer.ID, rec
 	return nil
 }
 
+// pushPeers returns the unique peers of the log addresses of a thread, i.e. its known writers.
+func (s *server) pushPeers(tid thread.ID) ([]peer.ID, error) {
+	addrs := make([]ma.Multiaddr, 0)
+	info, err := s.net.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range info.Logs {
+		addrs = append(addrs, l.Addrs...)
+	}
+	followers, err := s.net.followerPeers(tid)
+	if err != nil {
+		return nil, err
+	}
+	for _, pid := range followers {
+		addr, err := ma.NewMultiaddr("/" + ma.ProtocolWithCode(ma.P_P2P).Name + "/" + pid.String())
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return s.net.uniquePeers(addrs)
+}
+
+func (s *server) pushRecordRequest(
+	ctx context.Context,
+	tid thread.ID,
+	lid peer.ID,
+	rec core.Record,
+	counter int64,
+) (*pb.PushRecordRequest, error) {
+	pbrec, err := s.net.recordToProto(ctx, tid, rec)
+	if err != nil {
+		return nil, err
+	}
+	body := &pb.PushRecordRequest_Body{
+		ThreadID: &pb.ProtoThreadID{ID: tid},
+		LogID:    &pb.ProtoPeerID{ID: lid},
+		Record:   pbrec,
+	}
+	return &pb.PushRecordRequest{
+		Body:    body,
+		Counter: counter,
+	}, nil
+}
+
 func (s *server) pushRecordToPeer(
 	req *pb.PushRecordRequest,
 	pid peer.ID,
 	tid thread.ID,
 	lid peer.ID,
-) error {
+	priority core.RecordPriority,
+) (err error) {
+	start := time.Now()
+	defer func() { s.net.observeExchange(tid, pid, opPushRecord, start, err) }()
+
 	client, err := s.dial(pid)
 	if err != nil {
 		return fmt.Errorf("dial failed: %w", err)
 	}
 	rctx, cancel := context.WithTimeout(context.Background(), PushTimeout)
 	defer cancel()
+	if priority == core.PriorityHigh {
+		rctx = withStreamPriority(rctx)
+	}
 	_, err = client.PushRecord(rctx, req)
 	if err == nil {
 		return nil
@@ -353,10 +723,43 @@ func (s *server) pushRecordToPeer(
 	}
 }
 
+// scheduleRecordPulls of threads from a peer which doesn't support edge exchange.
+func (s *server) scheduleRecordPulls(pid peer.ID, tids []thread.ID) {
+	for _, tid := range tids {
+		if s.net.queueGetRecords.Schedule(pid, tid, callPriorityLow, s.net.updateRecordsFromPeer) {
+			log.Debugf("record update for thread %s from %s scheduled", tid, pid)
+		}
+	}
+}
+
 // exchangeEdges of specified threads with a peer.
-func (s *server) exchangeEdges(ctx context.Context, pid peer.ID, tids []thread.ID) error {
+func (s *server) exchangeEdges(ctx context.Context, pid peer.ID, tids []thread.ID) (err error) {
+	start := time.Now()
+	defer func() {
+		for _, tid := range tids {
+			s.net.observeExchange(tid, pid, opExchangeEdges, start, err)
+		}
+	}()
+
+	// threads with paused sync are left out
+	active := tids[:0:0]
+	for _, tid := range tids {
+		if s.net.syncs.enter(tid) {
+			active = append(active, tid)
+		}
+	}
+	defer func() {
+		for _, tid := range active {
+			s.net.syncs.leave(tid)
+		}
+	}()
+	tids = active
+
 	log.Debugf("exchanging edges of %d threads with %s...", len(tids), pid)
-	var body = &pb.ExchangeEdgesRequest_Body{}
+	var body = &pb.ExchangeEdgesRequest_Body{
+		MaxRecordSize: int64(s.net.maxRecordSize),
+		GossipPeers:   int32(GossipPeers),
+	}
 
 	// fill local edges
 	for _, tid := range tids {
@@ -379,25 +782,30 @@ func (s *server) exchangeEdges(ctx context.Context, pid peer.ID, tids []thread.I
 	req := &pb.ExchangeEdgesRequest{
 		Body: body,
 	}
+	if err := s.requireCapability(ctx, pid, CapEdgeExchange); err != nil {
+		log.Debugf("%s doesn't support edge exchange, falling back to direct record pulling", pid)
+		s.scheduleRecordPulls(pid, tids)
+		return nil
+	}
 
 	// send request
 	client, err := s.dial(pid)
 	if err != nil {
+		s.net.observePeer(thread.Undef, pid, false)
 		return fmt.Errorf("dial %s failed: %w", pid, err)
 	}
 	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
 	defer cancel()
 	reply, err := client.ExchangeEdges(cctx, req)
 	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() != codes.Unimplemented {
+			s.net.observePeer(thread.Undef, pid, false)
+		}
 		if st, ok := status.FromError(err); ok {
 			switch st.Code() {
 			case codes.Unimplemented:
 				log.Debugf("%s doesn't support edge exchange, falling back to direct record pulling", pid)
-				for _, tid := range tids {
-					if s.net.queueGetRecords.Schedule(pid, tid, callPriorityLow, s.net.updateRecordsFromPeer) {
-						log.Debugf("record update for thread %s from %s scheduled", tid, pid)
-					}
-				}
+				s.scheduleRecordPulls(pid, tids)
 				return nil
 			case codes.Unavailable:
 				log.Debugf("%s unavailable, skip edge exchange", pid)
@@ -406,6 +814,10 @@ func (s *server) exchangeEdges(ctx context.Context, pid peer.ID, tids []thread.I
 		}
 		return err
 	}
+	s.net.setPeerMaxRecordSize(pid, reply.MaxRecordSize)
+	for _, tid := range tids {
+		s.net.observePeer(tid, pid, true)
+	}
 
 	for _, e := range reply.GetEdges() {
 		tid := e.ThreadID.ID
@@ -422,62 +834,145 @@ func (s *server) exchangeEdges(ctx context.Context, pid peer.ID, tids []thread.I
 		// We only update the logs if we got non empty values and different hashes for addresses
 		// Note that previous versions also sent 0 (aka EmptyEdgeValue) values when the addresses
 		// were non-existent, so it shouldn't break backwards compatibility
+		if responseEdge != lstoreds.EmptyEdgeValue && responseEdge != addrsEdgeLocal && len(e.GetLogs()) > 0 {
+			// try to catch up using the logs inlined into reply. The returned edge may be stale,
+			// as stores recomputing it asynchronously (e.g. lstoremem) lag behind, in which case
+			// the logs are requested below anyway
+			if addrsEdgeLocal, err = s.applyInlinedLogs(tid, pid, e.GetLogs()); err != nil {
+				log.Debugf("applying inlined logs for %s from %s failed: %v", tid, pid, err)
+			}
+		}
 		if responseEdge != lstoreds.EmptyEdgeValue && responseEdge != addrsEdgeLocal {
 			if s.net.queueGetLogs.Schedule(pid, tid, callPriorityLow, s.net.updateLogsFromPeer) {
 				log.Debugf("log information update for thread %s from %s scheduled", tid, pid)
 			}
 		}
 
+		if len(e.GetPeers()) > 0 {
+			if err := s.applyGossipPeers(tid, pid, e.GetPeers()); err != nil {
+				log.Debugf("applying gossiped peers for %s from %s failed: %v", tid, pid, err)
+			}
+		}
+
 		responseEdge = e.GetHeadsEdge()
-		// We only update the records if we got non empty values and different hashes for heads
+		// We only update the records if we got non empty values and different hashes for heads,
+		// which are proven with head records signed by the log keys
 		if responseEdge != lstoreds.EmptyEdgeValue && responseEdge != headsEdgeLocal {
-			if s.net.queueGetRecords.Schedule(pid, tid, callPriorityLow, s.net.updateRecordsFromPeer) {
+			if pull, err := s.verifyHeadProofs(tid, responseEdge, e.GetHeads()); err != nil {
+				log.Warnf("heads edge of thread %s from %s rejected: %v", tid, pid, err)
+			} else if !pull {
+				log.Debugf("proven heads of thread %s from %s are known, skip record update", tid, pid)
+			} else if s.net.queueGetRecords.Schedule(pid, tid, callPriorityLow, s.net.updateRecordsFromPeer) {
 				log.Debugf("record update for thread %s from %s scheduled", tid, pid)
 			}
+		} else if responseEdge == headsEdgeLocal && headsEdgeLocal != lstoreds.EmptyEdgeValue {
+			// heads are the same on both sides, the thread is in sync with the peer
+			s.net.markSynced(tid)
 		}
 	}
 
 	return nil
 }
 
-// dial attempts to open a gRPC connection over libp2p to a peer.
-func (s *server) dial(peerID peer.ID) (pb.ServiceClient, error) {
-	s.Lock()
-	defer s.Unlock()
-	conn, ok := s.conns[peerID]
-	if ok {
-		if conn.GetState() == connectivity.Shutdown {
-			if err := conn.Close(); err != nil {
-				log.Errorf("error closing connection: %v", err)
-			}
-		} else {
-			return pb.NewServiceClient(conn), nil
+// applyInlinedLogs decrypts logs received with the exchange edges reply, stores them
+// and returns an updated local address edge. Logs which weren't approved yet are held
+// if the thread requires log approval.
+func (s *server) applyInlinedLogs(tid thread.ID, pid peer.ID, data []byte) (uint64, error) {
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return lstoreds.EmptyEdgeValue, err
+	} else if sk == nil {
+		return lstoreds.EmptyEdgeValue, errors.New("a service-key is required to decrypt logs")
+	}
+	plaintext, err := sk.Decrypt(data)
+	if err != nil {
+		return lstoreds.EmptyEdgeValue, fmt.Errorf("decrypting logs: %w", err)
+	}
+	var reply pb.GetLogsReply
+	if err := reply.Unmarshal(plaintext); err != nil {
+		return lstoreds.EmptyEdgeValue, fmt.Errorf("unmarshaling logs: %w", err)
+	}
+	lgs := make([]thread.LogInfo, 0, len(reply.Logs))
+	for _, l := range reply.Logs {
+		lg := logFromProto(l)
+		if pending, err := s.net.holdLog(tid, pid, lg); err != nil {
+			return lstoreds.EmptyEdgeValue, err
+		} else if pending {
+			continue
 		}
+		lgs = append(lgs, lg)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
-	defer cancel()
-	conn, err := grpc.DialContext(ctx, peerID.Pretty(), s.opts...)
+	if err := s.net.createExternalLogsIfNotExist(tid, lgs); err != nil {
+		return lstoreds.EmptyEdgeValue, err
+	}
+	return s.net.store.AddrsEdge(tid)
+}
+
+// applyGossipPeers decrypts peers received with the exchange edges reply and adds their
+// addresses to the peerstore. Records are pulled from peers unknown to replicate the thread,
+// which become its bootstrap peers once reached.
+func (s *server) applyGossipPeers(tid thread.ID, pid peer.ID, data []byte) error {
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return err
+	} else if sk == nil {
+		return errors.New("a service-key is required to decrypt peers")
+	}
+	plaintext, err := sk.Decrypt(data)
+	if err != nil {
+		return fmt.Errorf("decrypting peers: %w", err)
+	}
+	var gossip pb.GossipPeers
+	if err := gossip.Unmarshal(plaintext); err != nil {
+		return fmt.Errorf("unmarshaling peers: %w", err)
+	}
+	_, known, err := s.net.threadOffsets(tid)
+	if err != nil {
+		return err
+	}
+	for _, p := range s.net.bootstrap.list(tid) {
+		known = append(known, p.ID)
+	}
+	for i, gp := range gossip.Peers {
+		if i >= GossipPeers {
+			break
+		}
+		if gp.PeerID == nil || gp.PeerID.ID == s.net.host.ID() || gp.PeerID.ID == pid || len(gp.Addrs) == 0 {
+			continue
+		}
+		addrs := make([]ma.Multiaddr, len(gp.Addrs))
+		for j, a := range gp.Addrs {
+			addrs[j] = a.Multiaddr
+		}
+		s.net.host.Peerstore().AddAddrs(gp.PeerID.ID, addrs, pstore.AddressTTL)
+		if containsPeer(known, gp.PeerID.ID) {
+			continue
+		}
+		if s.net.queueGetRecords.Schedule(gp.PeerID.ID, tid, callPriorityLow, s.net.updateRecordsFromPeer) {
+			log.Debugf("record update for thread %s from gossiped peer %s scheduled", tid, gp.PeerID.ID)
+		}
+	}
+	return nil
+}
+
+// dial attempts to open a gRPC connection over the transport to a peer.
+// Connections are pooled within the configured ConnLimits.
+func (s *server) dial(peerID peer.ID) (pb.ServiceClient, error) {
+	conn, err := s.pool.get(peerID)
 	if err != nil {
 		return nil, err
 	}
-	s.conns[peerID] = conn
 	return pb.NewServiceClient(conn), nil
 }
 
-// getLibp2pDialer returns a WithContextDialer option for libp2p dialing.
-func (s *server) getLibp2pDialer() grpc.DialOption {
+// getTransportDialer returns a WithContextDialer option for dialing peers over the transport.
+func (s *server) getTransportDialer() grpc.DialOption {
 	return grpc.WithContextDialer(func(ctx context.Context, peerIDStr string) (nnet.Conn, error) {
 		id, err := peer.Decode(peerIDStr)
 		if err != nil {
 			return nil, fmt.Errorf("grpc tried to dial non peerID: %w", err)
 		}
-
-		conn, err := gostream.Dial(ctx, s.net.host, id, thread.Protocol)
-		if err != nil {
-			return nil, fmt.Errorf("gostream dial failed: %w", err)
-		}
-
-		return conn, nil
+		return s.net.transport.Dial(ctx, id)
 	})
 }
 
@@ -0,0 +1,38 @@
+package net
+
+import (
+	"context"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func (n *net) GetVectorClock(_ context.Context, id thread.ID, opts ...core.ThreadOption) (core.VectorClock, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	return n.vectorClock(id)
+}
+
+// vectorClock returns the clock of the current log heads of a thread.
+func (n *net) vectorClock(id thread.ID) (core.VectorClock, error) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return nil, err
+	}
+	return core.VectorClockFromInfo(info), nil
+}
+
+// setClock sets the thread clock of a record about to be delivered. Heads of other
+// logs are the ones known when the record was added, the head of the record's log
+// is the record itself.
+func setClock(rec core.ThreadRecord, clock core.VectorClock, height int64) {
+	if r, ok := rec.(*Record); ok {
+		r.clock = clock.Copy()
+		r.clock[r.logID] = height
+	}
+}
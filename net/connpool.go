@@ -0,0 +1,242 @@
+package net
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// StreamWaitTimeout is the max time duration a call waits for a free stream when stream limits are hit.
+var StreamWaitTimeout = time.Second * 5
+
+var (
+	// ErrConnLimit indicates that no connection to a peer could be opened without exceeding ConnLimits.MaxConns.
+	ErrConnLimit = errors.New("peer connection limit reached")
+	// ErrStreamLimit indicates that a call to a peer timed out waiting for a free stream.
+	ErrStreamLimit = errors.New("peer stream limit reached")
+)
+
+// ConnLimits bounds the resources used for calls to peers. Each peer has a single
+// connection, i.e. a single transport stream, multiplexing concurrent calls as streams.
+// Zero values mean no limit.
+type ConnLimits struct {
+	// MaxConns is the max number of open peer connections. The least recently used
+	// connections without calls in flight are closed to make room for new ones.
+	MaxConns int
+	// MaxStreamsPerPeer is the max number of concurrent calls to a single peer.
+	MaxStreamsPerPeer int
+	// MaxStreams is the max number of concurrent calls to all peers.
+	MaxStreams int
+}
+
+// Validate returns an error if the limits are invalid.
+func (l ConnLimits) Validate() error {
+	if l.MaxConns < 0 || l.MaxStreamsPerPeer < 0 || l.MaxStreams < 0 {
+		return errors.New("connection limits must not be negative")
+	}
+	return nil
+}
+
+type streamPriorityKey struct{}
+
+// withStreamPriority exempts calls with the context from the pool-wide stream limit,
+// so they don't wait behind bulk traffic. The per-peer limit still applies.
+func withStreamPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamPriorityKey{}, true)
+}
+
+// connPool keeps a connection per peer within limits. Calls over pooled
+// connections wait up to StreamWaitTimeout for a free stream, so that bursts
+// to many peers degrade to queueing instead of exhausting streams.
+type connPool struct {
+	limits ConnLimits
+	opts   []grpc.DialOption
+
+	lock  sync.Mutex
+	conns map[peer.ID]*pooledConn
+	// lru orders peers by last use, most recent first.
+	lru     *list.List
+	streams chan struct{}
+}
+
+type pooledConn struct {
+	pid     peer.ID
+	conn    *grpc.ClientConn
+	elem    *list.Element
+	streams chan struct{}
+	active  int
+}
+
+func newConnPool(limits ConnLimits, opts []grpc.DialOption) *connPool {
+	p := &connPool{
+		limits: limits,
+		opts:   opts,
+		conns:  make(map[peer.ID]*pooledConn),
+		lru:    list.New(),
+	}
+	if limits.MaxStreams > 0 {
+		p.streams = make(chan struct{}, limits.MaxStreams)
+	}
+	return p
+}
+
+// get returns the connection to a peer, dialing it if needed.
+func (p *connPool) get(pid peer.ID) (*grpc.ClientConn, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if pc, ok := p.conns[pid]; ok {
+		if pc.conn.GetState() != connectivity.Shutdown {
+			p.lru.MoveToFront(pc.elem)
+			return pc.conn, nil
+		}
+		p.remove(pc)
+	}
+	if p.limits.MaxConns > 0 && len(p.conns) >= p.limits.MaxConns && !p.evict() {
+		return nil, fmt.Errorf("dialing %s: %w", pid, ErrConnLimit)
+	}
+
+	pc := &pooledConn{pid: pid}
+	if p.limits.MaxStreamsPerPeer > 0 {
+		pc.streams = make(chan struct{}, p.limits.MaxStreamsPerPeer)
+	}
+	opts := append(p.opts[:len(p.opts):len(p.opts)], grpc.WithChainUnaryInterceptor(p.interceptor(pc)))
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, pid.Pretty(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	pc.conn = conn
+	pc.elem = p.lru.PushFront(pid)
+	p.conns[pid] = pc
+	return conn, nil
+}
+
+// lookup returns the open connection to a peer, without dialing it or marking it as used.
+func (p *connPool) lookup(pid peer.ID) (*grpc.ClientConn, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	pc, ok := p.conns[pid]
+	if !ok || pc.conn.GetState() == connectivity.Shutdown {
+		return nil, false
+	}
+	return pc.conn, true
+}
+
+// drop closes the connection to a peer, e.g. once it's found dead, so the next call redials it.
+func (p *connPool) drop(pid peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if pc, ok := p.conns[pid]; ok {
+		p.remove(pc)
+	}
+}
+
+// evict closes the least recently used connection without calls in flight.
+// It returns false if all connections are busy.
+func (p *connPool) evict() bool {
+	for e := p.lru.Back(); e != nil; e = e.Prev() {
+		pc := p.conns[e.Value.(peer.ID)]
+		if pc.active == 0 {
+			p.remove(pc)
+			return true
+		}
+	}
+	return false
+}
+
+func (p *connPool) remove(pc *pooledConn) {
+	if err := pc.conn.Close(); err != nil {
+		log.Debugf("closing connection to %s: %v", pc.pid, err)
+	}
+	p.lru.Remove(pc.elem)
+	delete(p.conns, pc.pid)
+}
+
+// interceptor bounds the concurrent calls over a pooled connection.
+func (p *connPool) interceptor(pc *pooledConn) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		release, err := p.acquire(ctx, pc)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// acquire takes a stream of a connection and of the pool, waiting for up to StreamWaitTimeout.
+// Prioritized calls only take a stream of the connection.
+func (p *connPool) acquire(ctx context.Context, pc *pooledConn) (func(), error) {
+	timer := time.NewTimer(StreamWaitTimeout)
+	defer timer.Stop()
+	take := func(sem chan struct{}) error {
+		if sem == nil {
+			return nil
+		}
+		select {
+		case sem <- struct{}{}:
+			return nil
+		case <-timer.C:
+			return fmt.Errorf("calling %s: %w", pc.pid, ErrStreamLimit)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	give := func(sem chan struct{}) {
+		if sem != nil {
+			<-sem
+		}
+	}
+
+	if err := take(pc.streams); err != nil {
+		return nil, err
+	}
+	shared := p.streams
+	if prioritized, _ := ctx.Value(streamPriorityKey{}).(bool); prioritized {
+		shared = nil
+	}
+	if err := take(shared); err != nil {
+		give(pc.streams)
+		return nil, err
+	}
+	p.lock.Lock()
+	pc.active++
+	p.lock.Unlock()
+	return func() {
+		p.lock.Lock()
+		pc.active--
+		p.lock.Unlock()
+		give(shared)
+		give(pc.streams)
+	}, nil
+}
+
+// Close closes all connections.
+func (p *connPool) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var err error
+	for _, pc := range p.conns {
+		if e := pc.conn.Close(); e != nil {
+			err = e
+		}
+	}
+	p.conns = make(map[peer.ID]*pooledConn)
+	p.lru.Init()
+	return err
+}
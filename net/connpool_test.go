@@ -0,0 +1,91 @@
+package net
+
+import (
+	"context"
+	"errors"
+	gonet "net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestConnPool(t *testing.T) {
+	timeout := StreamWaitTimeout
+	StreamWaitTimeout = time.Millisecond * 100
+	defer func() { StreamWaitTimeout = timeout }()
+	pool := newConnPool(ConnLimits{
+		MaxConns:          2,
+		MaxStreamsPerPeer: 1,
+		MaxStreams:        2,
+	}, []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (gonet.Conn, error) {
+			return nil, errors.New("offline")
+		}),
+	})
+	defer pool.Close()
+	p1, p2, p3 := makePeerID(t), makePeerID(t), makePeerID(t)
+
+	c1, err := pool.get(p1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.get(p2); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := pool.get(p1); c != c1 {
+		t.Fatal("expected pooled connection to be reused")
+	}
+
+	// the least recently used connection makes room for new ones
+	if _, err := pool.get(p3); err != nil {
+		t.Fatal(err)
+	}
+	if c1.GetState() == connectivity.Shutdown {
+		t.Fatal("expected recently used connection to be kept")
+	}
+	if _, ok := pool.conns[p2]; ok {
+		t.Fatal("expected least recently used connection to be evicted")
+	}
+
+	// calls wait for free streams of the peer
+	ctx := context.Background()
+	release1, err := pool.acquire(ctx, pool.conns[p1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.acquire(ctx, pool.conns[p1]); !errors.Is(err, ErrStreamLimit) {
+		t.Fatalf("expected stream limit error, got %v", err)
+	}
+	release3, err := pool.acquire(ctx, pool.conns[p3])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// busy connections aren't evicted
+	if _, err := pool.get(p2); !errors.Is(err, ErrConnLimit) {
+		t.Fatalf("expected connection limit error, got %v", err)
+	}
+	release3()
+	if _, err := pool.get(p2); err != nil {
+		t.Fatal(err)
+	}
+
+	// calls wait for free streams of the pool
+	release2, err := pool.acquire(ctx, pool.conns[p2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(time.Millisecond * 20)
+		release1()
+	}()
+	release1, err = pool.acquire(ctx, pool.conns[p1])
+	if err != nil {
+		t.Fatalf("expected call to get a released stream, got %v", err)
+	}
+	release1()
+	release2()
+}
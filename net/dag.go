@@ -0,0 +1,305 @@
+package net
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrUnknownDAGFormat indicates an unsupported record DAG export format.
+var ErrUnknownDAGFormat = errors.New("unknown DAG format")
+
+// DAGFormat is an encoding of a thread record DAG.
+type DAGFormat string
+
+const (
+	// DAGFormatDOT is the Graphviz DOT language, logs are drawn as clusters.
+	DAGFormatDOT DAGFormat = "dot"
+	// DAGFormatGraphML is the XML based GraphML format.
+	DAGFormatGraphML DAGFormat = "graphml"
+)
+
+// DAGRange selects the records of a record DAG export.
+type DAGRange struct {
+	// Logs restrict the export to some logs of the thread, all logs are exported if empty.
+	Logs []peer.ID
+	// MinHeight and MaxHeight bound the heights of exported records in their logs,
+	// counting from 1. They're unbounded if zero.
+	MinHeight int64
+	MaxHeight int64
+}
+
+// DAGExporter exports the record DAG of a thread, it's implemented by the threads network.
+type DAGExporter interface {
+	ExportDAG(ctx context.Context, id thread.ID, w io.Writer, format DAGFormat, r DAGRange) error
+}
+
+var _ DAGExporter = (*net)(nil)
+
+// dagNode is an exported record, linked to the previous record of its log.
+type dagNode struct {
+	ID     cid.Cid
+	Log    peer.ID
+	Height int64
+	// Author is the identity which created the record, empty if none.
+	Author string
+	// Size of the record blocks stored locally, in bytes.
+	Size int
+	Prev cid.Cid
+}
+
+// ExportDAG writes the record DAG of a thread, walking back the logs from their heads.
+// Records are labeled with their log, height, author and size, and point to the previous
+// record of their log if it's exported too. Logs end at records pruned locally.
+func (n *net) ExportDAG(ctx context.Context, id thread.ID, w io.Writer, format DAGFormat, r DAGRange) error {
+	var write func(io.Writer, thread.ID, []dagNode) error
+	switch format {
+	case DAGFormatDOT:
+		write = writeDOT
+	case DAGFormatGraphML:
+		write = writeGraphML
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownDAGFormat, format)
+	}
+	if r.MinHeight < 0 || r.MaxHeight < 0 || (r.MaxHeight != 0 && r.MaxHeight < r.MinHeight) {
+		return fmt.Errorf("invalid height range [%d, %d]", r.MinHeight, r.MaxHeight)
+	}
+	nodes, err := n.recordDAG(ctx, id, r)
+	if err != nil {
+		return err
+	}
+	return write(w, id, nodes)
+}
+
+// recordDAG returns the records of a thread in range, by log and from the highest.
+func (n *net) recordDAG(ctx context.Context, id thread.ID, r DAGRange) ([]dagNode, error) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[peer.ID]struct{}, len(r.Logs))
+	for _, lid := range r.Logs {
+		selected[lid] = struct{}{}
+	}
+	sort.Slice(info.Logs, func(i, j int) bool {
+		return info.Logs[i].ID < info.Logs[j].ID
+	})
+
+	var nodes []dagNode
+	for _, lg := range info.Logs {
+		if _, ok := selected[lg.ID]; len(selected) > 0 && !ok {
+			continue
+		}
+		var recs []core.Record
+		for cursor := lg.Head.ID; cursor.Defined(); {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if known, err := n.isKnown(cursor); err != nil {
+				return nil, err
+			} else if !known {
+				break
+			}
+			rec, err := n.getRecord(ctx, id, cursor)
+			if err != nil {
+				return nil, err
+			}
+			recs = append(recs, rec)
+			// heights are only known up front for heads with a counter
+			if lg.Head.Counter != thread.CounterUndef && lg.Head.Counter-int64(len(recs)) < r.MinHeight {
+				break
+			}
+			cursor = rec.PrevID()
+		}
+
+		top := lg.Head.Counter
+		if top == thread.CounterUndef {
+			top = int64(len(recs))
+		}
+		for i, rec := range recs {
+			height := top - int64(i)
+			if height < r.MinHeight || (r.MaxHeight != 0 && height > r.MaxHeight) {
+				continue
+			}
+			node, err := n.dagNode(ctx, lg.ID, height, rec)
+			if err != nil {
+				return nil, fmt.Errorf("reading record %s: %w", rec.Cid(), err)
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// dagNode describes a local record without decrypting it. The size covers the record
+// envelope, event and header, along with the body if it's stored.
+func (n *net) dagNode(ctx context.Context, lid peer.ID, height int64, rec core.Record) (dagNode, error) {
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		return dagNode{}, err
+	}
+	node := dagNode{
+		ID:     rec.Cid(),
+		Log:    lid,
+		Height: height,
+		Size:   len(rec.RawData()) + len(event.RawData()),
+		Prev:   rec.PrevID(),
+	}
+	for _, bid := range []cid.Cid{event.HeaderID(), event.BodyID()} {
+		if stored, err := n.isKnown(bid); err != nil {
+			return dagNode{}, err
+		} else if !stored {
+			continue
+		}
+		block, err := n.Get(ctx, bid)
+		if err != nil {
+			return dagNode{}, err
+		}
+		node.Size += len(block.RawData())
+	}
+	if len(rec.PubKey()) != 0 {
+		author := &thread.Libp2pPubKey{}
+		if err := author.UnmarshalBinary(rec.PubKey()); err != nil {
+			return dagNode{}, err
+		}
+		node.Author = author.String()
+	}
+	return node, nil
+}
+
+// exportedLinks returns the records whose previous record is exported too.
+func exportedLinks(nodes []dagNode) []dagNode {
+	ids := make(map[cid.Cid]struct{}, len(nodes))
+	for _, nd := range nodes {
+		ids[nd.ID] = struct{}{}
+	}
+	var linked []dagNode
+	for _, nd := range nodes {
+		if _, ok := ids[nd.Prev]; ok && nd.Prev.Defined() {
+			linked = append(linked, nd)
+		}
+	}
+	return linked
+}
+
+func writeDOT(w io.Writer, id thread.ID, nodes []dagNode) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %s {\n\trankdir=RL;\n\tnode [shape=box];\n", dotQuote("thread "+id.String()))
+	for i := 0; i < len(nodes); {
+		lid := nodes[i].Log
+		fmt.Fprintf(bw, "\tsubgraph %s {\n\t\tlabel=%s;\n", dotQuote("cluster_"+lid.String()), dotQuote(lid.String()))
+		for ; i < len(nodes) && nodes[i].Log == lid; i++ {
+			nd := nodes[i]
+			label := fmt.Sprintf("%s #%d\\n%d B", shortID(lid.String()), nd.Height, nd.Size)
+			if nd.Author != "" {
+				label += "\\nby " + shortID(nd.Author)
+			}
+			fmt.Fprintf(bw, "\t\t%s [label=%s, log=%s, height=%d, author=%s, size=%d];\n",
+				dotQuote(nd.ID.String()), dotQuote(label), dotQuote(lid.String()), nd.Height,
+				dotQuote(nd.Author), nd.Size)
+		}
+		fmt.Fprint(bw, "\t}\n")
+	}
+	for _, nd := range exportedLinks(nodes) {
+		fmt.Fprintf(bw, "\t%s -> %s;\n", dotQuote(nd.ID.String()), dotQuote(nd.Prev.String()))
+	}
+	fmt.Fprint(bw, "}\n")
+	return bw.Flush()
+}
+
+// dotQuote quotes a DOT identifier, escape sequences like \n in labels are kept.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// shortID returns the tail of a long identifier, enough to tell logs apart in labels.
+func shortID(s string) string {
+	if len(s) <= 8 {
+		return s
+	}
+	return "..." + s[len(s)-8:]
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+func writeGraphML(w io.Writer, id thread.ID, nodes []dagNode) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "log", For: "node", Name: "log", Type: "string"},
+			{ID: "height", For: "node", Name: "height", Type: "long"},
+			{ID: "author", For: "node", Name: "author", Type: "string"},
+			{ID: "size", For: "node", Name: "size", Type: "int"},
+		},
+		Graph: graphMLGraph{ID: id.String(), EdgeDefault: "directed"},
+	}
+	for _, nd := range nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: nd.ID.String(),
+			Data: []graphMLData{
+				{Key: "log", Value: nd.Log.String()},
+				{Key: "height", Value: fmt.Sprint(nd.Height)},
+				{Key: "author", Value: nd.Author},
+				{Key: "size", Value: fmt.Sprint(nd.Size)},
+			},
+		})
+	}
+	for _, nd := range exportedLinks(nodes) {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: nd.ID.String(), Target: nd.Prev.String()})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,85 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_ExportDAG(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+
+	t.Run("dot", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := n.ExportDAG(ctx, info.ID, &buf, DAGFormatDOT, DAGRange{}); err != nil {
+			t.Fatal(err)
+		}
+		dot := buf.String()
+		if !strings.HasPrefix(dot, "digraph") || strings.Count(dot, " -> ") != 2 {
+			t.Fatalf("expected records linked to their previous record, got\n%s", dot)
+		}
+		for i, r := range recs {
+			if !strings.Contains(dot, `"`+r.Value().Cid().String()+`" [label=`) {
+				t.Fatalf("expected record %d to be exported, got\n%s", i, dot)
+			}
+		}
+		if !strings.Contains(dot, "height=3") || !strings.Contains(dot, `log="`+recs[0].LogID().String()+`"`) {
+			t.Fatalf("expected records to be labeled with their log and height, got\n%s", dot)
+		}
+	})
+
+	t.Run("graphml range", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := DAGRange{Logs: []peer.ID{recs[0].LogID()}, MinHeight: 2, MaxHeight: 2}
+		if err := n.ExportDAG(ctx, info.ID, &buf, DAGFormatGraphML, r); err != nil {
+			t.Fatal(err)
+		}
+		var doc graphML
+		if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Graph.Nodes) != 1 || doc.Graph.Nodes[0].ID != recs[1].Value().Cid().String() {
+			t.Fatalf("expected only the record at height 2, got %+v", doc.Graph.Nodes)
+		}
+		if len(doc.Graph.Edges) != 0 {
+			t.Fatalf("expected links to records out of range to be dropped, got %+v", doc.Graph.Edges)
+		}
+		for _, d := range doc.Graph.Nodes[0].Data {
+			if d.Key == "size" && d.Value == "0" {
+				t.Fatal("expected record size to be set")
+			}
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := n.ExportDAG(ctx, info.ID, &bytes.Buffer{}, "svg", DAGRange{})
+		if !errors.Is(err, ErrUnknownDAGFormat) {
+			t.Fatalf("expected unknown format to be rejected, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,329 @@
+package net
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+var (
+	// AntiEntropyMinRecords is the minimum length of logs which are reconciled with range
+	// digests before pulling records from a peer. Shorter logs are pulled by offset only.
+	AntiEntropyMinRecords int64 = 10000
+
+	// antiEntropyDepth is the number of tree levels compared per round trip.
+	antiEntropyDepth = 4
+
+	// digestCacheLevel is the lowest tree level with cached digests, lower
+	// levels are hashed on demand.
+	digestCacheLevel = 4
+
+	// maxDigestLevel bounds the levels of requested ranges.
+	maxDigestLevel int32 = 62
+
+	// maxDigestRanges is the max number of ranges of a digests request.
+	maxDigestRanges = 256
+)
+
+var errLogIncomplete = errors.New("log records are incomplete")
+
+// digestNode is a node of the hash tree over log records, covering the
+// 2^level records starting at position index*2^level.
+type digestNode struct {
+	level int
+	index int64
+}
+
+// logIndex maps the positions of log records to their ids, which are the leaves of
+// a binary hash tree. Comparing the digests of tree nodes with a peer finds the first
+// diverging record in a logarithmic number of round trips.
+type logIndex struct {
+	sync.Mutex
+	head  cid.Cid
+	ids   []cid.Cid
+	cache map[digestNode][]byte
+}
+
+// digestIndex keeps the record indexes of logs, which are built on demand
+// and extended as log heads move.
+type digestIndex struct {
+	sync.Mutex
+	logs map[logKey]*logIndex
+}
+
+func newDigestIndex() *digestIndex {
+	return &digestIndex{logs: make(map[logKey]*logIndex)}
+}
+
+func (d *digestIndex) get(tid thread.ID, lid peer.ID) *logIndex {
+	d.Lock()
+	defer d.Unlock()
+	k := logKey{tid: tid, lid: lid}
+	idx, ok := d.logs[k]
+	if !ok {
+		idx = &logIndex{cache: make(map[digestNode][]byte)}
+		d.logs[k] = idx
+	}
+	return idx
+}
+
+// forget drops the log indexes of a thread.
+func (d *digestIndex) forget(tid thread.ID) {
+	d.Lock()
+	defer d.Unlock()
+	for k := range d.logs {
+		if k.tid == tid {
+			delete(d.logs, k)
+		}
+	}
+}
+
+// withLogIndex calls f with the index of a log, updated to the current log head.
+func (n *net) withLogIndex(ctx context.Context, tid thread.ID, lid peer.ID, f func(idx *logIndex) error) error {
+	lg, err := n.store.GetLog(tid, lid)
+	if err != nil {
+		return err
+	}
+	sk, err := n.store.ServiceKey(tid)
+	if err != nil {
+		return err
+	} else if sk == nil {
+		return fmt.Errorf("a service-key is required to index records")
+	}
+	idx := n.digests.get(tid, lid)
+	idx.Lock()
+	defer idx.Unlock()
+	if err := n.updateLogIndex(ctx, idx, lg.Head, sk); err != nil {
+		return err
+	}
+	return f(idx)
+}
+
+// updateLogIndex walks the log back from head until it meets indexed records.
+// Positions are derived from head counters, so logs with records missing
+// locally, e.g. pruned ones, can't be indexed.
+func (n *net) updateLogIndex(ctx context.Context, idx *logIndex, head thread.Head, sk *sym.Key) error {
+	if idx.head.Equals(head.ID) {
+		return nil
+	}
+	var (
+		added   []cid.Cid
+		cursor  = head.ID
+		counter = head.Counter
+	)
+	for cursor.Defined() {
+		if counter <= 0 {
+			return errLogIncomplete
+		}
+		if counter <= int64(len(idx.ids)) && idx.ids[counter-1].Equals(cursor) {
+			break
+		}
+		if known, err := n.isKnown(cursor); err != nil {
+			return err
+		} else if !known {
+			return errLogIncomplete
+		}
+		rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			return err
+		}
+		added = append(added, cursor)
+		cursor = rec.PrevID()
+		counter--
+	}
+	if !cursor.Defined() && counter != 0 {
+		return errLogIncomplete
+	}
+
+	// drop the digests of nodes covering replaced or appended records
+	idx.ids = idx.ids[:counter]
+	for node := range idx.cache {
+		if (node.index+1)<<node.level > counter {
+			delete(idx.cache, node)
+		}
+	}
+	for i := len(added) - 1; i >= 0; i-- {
+		idx.ids = append(idx.ids, added[i])
+	}
+	idx.head = head.ID
+	return nil
+}
+
+// position returns the position of a record in the log, zero if it's not indexed.
+func (idx *logIndex) position(rid cid.Cid) int64 {
+	for i := len(idx.ids) - 1; i >= 0; i-- {
+		if idx.ids[i].Equals(rid) {
+			return int64(i + 1)
+		}
+	}
+	return 0
+}
+
+// digest returns the digest of a tree node over the first length records.
+// Nodes past the length are empty and have nil digests.
+func (idx *logIndex) digest(node digestNode, length int64) []byte {
+	start := node.index << node.level
+	if start >= length {
+		return nil
+	}
+	cacheable := start+1<<node.level <= length && node.level >= digestCacheLevel
+	if cacheable {
+		if d, ok := idx.cache[node]; ok {
+			return d
+		}
+	}
+	var d []byte
+	if node.level == 0 {
+		h := sha256.Sum256(idx.ids[start].Bytes())
+		d = h[:]
+	} else {
+		h := sha256.New()
+		h.Write(idx.digest(digestNode{level: node.level - 1, index: node.index * 2}, length))
+		h.Write(idx.digest(digestNode{level: node.level - 1, index: node.index*2 + 1}, length))
+		d = h.Sum(nil)
+	}
+	if cacheable {
+		idx.cache[node] = d
+	}
+	return d
+}
+
+// treeLevel returns the level of the root of a tree over length records.
+func treeLevel(length int64) int {
+	if length <= 1 {
+		return 0
+	}
+	return bits.Len64(uint64(length - 1))
+}
+
+// localDigests returns the digests of tree nodes over the first length records of a local log.
+func (n *net) localDigests(ctx context.Context, tid thread.ID, lid peer.ID, length int64, nodes []digestNode) ([][]byte, error) {
+	digests := make([][]byte, len(nodes))
+	err := n.withLogIndex(ctx, tid, lid, func(idx *logIndex) error {
+		if length > int64(len(idx.ids)) {
+			return errLogIncomplete
+		}
+		for i, nd := range nodes {
+			digests[i] = idx.digest(nd, length)
+		}
+		return nil
+	})
+	return digests, err
+}
+
+// commonPrefix returns the number of records a log shares with the same log on a peer,
+// counted from the start of the log. Tree nodes are compared top-down, antiEntropyDepth
+// levels per round trip, descending into the first diverging node. The local index
+// isn't locked during calls, so that peers reconciling with each other don't block.
+func (n *net) commonPrefix(ctx context.Context, pid peer.ID, tid thread.ID, lid peer.ID) (int64, error) {
+	var length int64
+	err := n.withLogIndex(ctx, tid, lid, func(idx *logIndex) error {
+		length = int64(len(idx.ids))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	root := digestNode{level: treeLevel(length)}
+	counter, digests, err := n.server.getLogDigests(ctx, pid, tid, lid, length, []digestNode{root})
+	if err != nil {
+		return 0, err
+	}
+	if len(digests) == 0 {
+		// the peer has less records, compare up to its head
+		if length = counter; length <= 0 {
+			return 0, nil
+		}
+		root = digestNode{level: treeLevel(length)}
+		if _, digests, err = n.server.getLogDigests(ctx, pid, tid, lid, length, []digestNode{root}); err != nil {
+			return 0, err
+		} else if len(digests) == 0 {
+			return 0, fmt.Errorf("peer %s has less than %d records", pid, length)
+		}
+	}
+	local, err := n.localDigests(ctx, tid, lid, length, []digestNode{root})
+	if err != nil {
+		return 0, err
+	}
+	if string(digests[0]) == string(local[0]) {
+		return length, nil
+	}
+
+	// descend into the first diverging node until reaching a record
+	node := root
+	for node.level > 0 {
+		depth := antiEntropyDepth
+		if depth > node.level {
+			depth = node.level
+		}
+		var (
+			level = node.level - depth
+			first = node.index << depth
+			nodes = make([]digestNode, 0, 1<<depth)
+		)
+		for i := int64(0); i < 1<<depth && (first+i)<<level < length; i++ {
+			nodes = append(nodes, digestNode{level: level, index: first + i})
+		}
+		_, digests, err := n.server.getLogDigests(ctx, pid, tid, lid, length, nodes)
+		if err != nil {
+			return 0, err
+		} else if len(digests) != len(nodes) {
+			return 0, fmt.Errorf("peer %s returned %d digests, %d expected", pid, len(digests), len(nodes))
+		}
+		local, err := n.localDigests(ctx, tid, lid, length, nodes)
+		if err != nil {
+			return 0, err
+		}
+		diverged := false
+		for i, nd := range nodes {
+			if string(digests[i]) != string(local[i]) {
+				node, diverged = nd, true
+				break
+			}
+		}
+		if !diverged {
+			return 0, fmt.Errorf("peer %s digests of log %s are inconsistent", pid, lid)
+		}
+	}
+	return node.index, nil
+}
+
+// reconcileOffsets moves the offsets of long logs diverging from the peer back to the
+// last record shared with it, so the peer returns the records after the divergence
+// instead of records which don't follow the local head.
+func (n *net) reconcileOffsets(ctx context.Context, pid peer.ID, tid thread.ID, offsets map[peer.ID]thread.Head) {
+	for lid, head := range offsets {
+		if head.Counter < AntiEntropyMinRecords {
+			continue
+		}
+		prefix, err := n.commonPrefix(ctx, pid, tid, lid)
+		if err != nil {
+			log.Debugf("reconciling log %s (thread %s) with %s failed: %v", lid, tid, pid, err)
+			continue
+		}
+		if prefix >= head.Counter {
+			continue
+		}
+		var offset thread.Head
+		if prefix > 0 {
+			err = n.withLogIndex(ctx, tid, lid, func(idx *logIndex) error {
+				offset = thread.Head{ID: idx.ids[prefix-1], Counter: prefix}
+				return nil
+			})
+			if err != nil {
+				continue
+			}
+		}
+		log.Debugf("log %s (thread %s) diverges from %s after %d records", lid, tid, pid, prefix)
+		offsets[lid] = offset
+	}
+}
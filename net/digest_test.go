@@ -0,0 +1,136 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestLogIndexDigest(t *testing.T) {
+	t.Parallel()
+	makeIndex := func(ids []cid.Cid) *logIndex {
+		return &logIndex{ids: ids, cache: make(map[digestNode][]byte)}
+	}
+	var ids, forked []cid.Cid
+	for i := 0; i < 37; i++ {
+		node, err := cbornode.WrapObject(map[string]interface{}{"i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, node.Cid())
+		if i == 20 {
+			node, _ = cbornode.WrapObject(map[string]interface{}{"fork": i}, mh.SHA2_256, -1)
+		}
+		forked = append(forked, node.Cid())
+	}
+	a, b := makeIndex(ids), makeIndex(forked)
+
+	root := digestNode{level: treeLevel(37)}
+	if root.level != 6 {
+		t.Fatalf("expected root level 6, got %d", root.level)
+	}
+	if string(a.digest(root, 37)) == string(b.digest(root, 37)) {
+		t.Fatal("expected diverging logs to have different roots")
+	}
+	// digests over the shared prefix match
+	for _, length := range []int64{1, 16, 20} {
+		r := digestNode{level: treeLevel(length)}
+		if string(a.digest(r, length)) != string(b.digest(r, length)) {
+			t.Fatalf("expected equal roots over %d records", length)
+		}
+	}
+	if string(a.digest(digestNode{level: 4, index: 0}, 37)) != string(b.digest(digestNode{level: 4, index: 0}, 37)) {
+		t.Fatal("expected equal digests of records before the fork")
+	}
+	if string(a.digest(digestNode{level: 4, index: 1}, 37)) == string(b.digest(digestNode{level: 4, index: 1}, 37)) {
+		t.Fatal("expected different digests of records after the fork")
+	}
+	if a.digest(digestNode{level: 4, index: 3}, 37) != nil {
+		t.Fatal("expected nil digest of an empty node")
+	}
+
+	// only full nodes are cached
+	if _, ok := a.cache[digestNode{level: 4, index: 1}]; !ok {
+		t.Fatal("expected full node to be cached")
+	}
+	if _, ok := a.cache[digestNode{level: 5, index: 1}]; ok {
+		t.Fatal("expected partial node not to be cached")
+	}
+}
+
+// TestNet_LogDigests isn't parallel as it lowers AntiEntropyMinRecords.
+func TestNet_LogDigests(t *testing.T) {
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createRecords := func(count int) {
+		for i := 0; i < count; i++ {
+			if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	createRecords(37)
+
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	ti, err := n1.store.GetThread(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid := ti.GetFirstPrivKeyLog().ID
+	prefix, err := n2.commonPrefix(ctx, n1.Host().ID(), info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefix != 37 {
+		t.Fatalf("expected common prefix of 37 records, got %d", prefix)
+	}
+
+	createRecords(5)
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	prefix, err = n2.commonPrefix(ctx, n1.Host().ID(), info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefix != 42 {
+		t.Fatalf("expected common prefix of 42 records, got %d", prefix)
+	}
+
+	// reconciling offsets of logs shared with the peer keeps them
+	offsets, _, err := n2.threadOffsets(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := offsets[lid]
+	old := AntiEntropyMinRecords
+	defer func() { AntiEntropyMinRecords = old }()
+	AntiEntropyMinRecords = 1
+	n2.reconcileOffsets(ctx, n1.Host().ID(), info.ID, offsets)
+	if !offsets[lid].ID.Equals(head.ID) || offsets[lid].Counter != 42 {
+		t.Fatalf("expected offset to be kept, got %v", offsets[lid])
+	}
+}
@@ -0,0 +1,164 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// DispatchEvent is a thread record routed by a Dispatcher, along with its decoded event.
+type DispatchEvent struct {
+	Record core.ThreadRecord
+	// ContentType is the content type of the event body, see core.EventHeader.Type.
+	ContentType string
+	Header      core.EventHeader
+	// Body is the decrypted event body.
+	Body format.Node
+	// Value is the body decoded by a handler registered with HandleCBOR, nil otherwise.
+	Value interface{}
+}
+
+// DispatchHandler handles the events routed to it by a Dispatcher.
+type DispatchHandler func(ctx context.Context, e DispatchEvent) error
+
+// Dispatcher routes thread records to handlers by the content type of their events,
+// so that consumers don't have to decrypt events and sniff their bodies.
+type Dispatcher struct {
+	net core.Net
+
+	lock     sync.RWMutex
+	routes   map[string]dispatchRoute
+	fallback DispatchHandler
+}
+
+type dispatchRoute struct {
+	handler DispatchHandler
+	// typ is the type bodies are decoded into, nil for raw bodies
+	typ reflect.Type
+}
+
+// NewDispatcher returns a dispatcher of the records of n.
+func NewDispatcher(n core.Net) *Dispatcher {
+	return &Dispatcher{
+		net:    n,
+		routes: make(map[string]dispatchRoute),
+	}
+}
+
+// Handle registers a handler for records of a content type, replacing the current one.
+func (d *Dispatcher) Handle(contentType string, h DispatchHandler) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.routes[contentType] = dispatchRoute{handler: h}
+}
+
+// HandleCBOR registers a handler for records of a content type, replacing the current one.
+// Bodies are decoded into new values of the type of v, e.g. a handler registered with
+// &Note{} receives *Note values. The type must be registered with cbornode.RegisterCborType.
+func (d *Dispatcher) HandleCBOR(contentType string, v interface{}, h DispatchHandler) {
+	typ := reflect.TypeOf(v)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.routes[contentType] = dispatchRoute{handler: h, typ: typ}
+}
+
+// HandleDefault registers a handler for records of content types without a handler,
+// replacing the current one. Without a default handler, such records are skipped.
+func (d *Dispatcher) HandleDefault(h DispatchHandler) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.fallback = h
+}
+
+// Run subscribes to records with opts and dispatches them in order until ctx is done.
+// Records which can't be decoded, e.g. of threads without a read key, and handler
+// errors are logged, they don't stop the dispatching.
+func (d *Dispatcher) Run(ctx context.Context, opts ...core.SubOption) error {
+	args := &core.SubOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	records, err := d.net.Subscribe(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	keys := make(map[thread.ID]*sym.Key)
+	for rec := range records {
+		if err := d.dispatch(ctx, rec, keys, args.Token); err != nil {
+			log.Errorf("dispatching record %s (thread %s) failed: %v", rec.Value().Cid(), rec.ThreadID(), err)
+		}
+	}
+	return ctx.Err()
+}
+
+func (d *Dispatcher) dispatch(
+	ctx context.Context,
+	rec core.ThreadRecord,
+	keys map[thread.ID]*sym.Key,
+	token thread.Token,
+) error {
+	rk, ok := keys[rec.ThreadID()]
+	if !ok {
+		info, err := d.net.GetThread(ctx, rec.ThreadID(), core.WithThreadToken(token))
+		if err != nil {
+			return err
+		}
+		if !info.Key.CanRead() {
+			return fmt.Errorf("read key not found")
+		}
+		rk = info.Key.Read()
+		keys[rec.ThreadID()] = rk
+	}
+	event, err := cbor.EventFromRecord(ctx, d.net, rec.Value())
+	if err != nil {
+		return err
+	}
+	header, err := event.GetHeader(ctx, d.net, rk)
+	if err != nil {
+		return err
+	}
+	contentType, err := header.Type()
+	if err != nil {
+		return err
+	}
+
+	d.lock.RLock()
+	route, ok := d.routes[contentType]
+	if !ok {
+		route = dispatchRoute{handler: d.fallback}
+	}
+	d.lock.RUnlock()
+	if route.handler == nil {
+		return nil
+	}
+
+	body, err := event.GetBody(ctx, d.net, rk)
+	if err != nil {
+		return err
+	}
+	e := DispatchEvent{
+		Record:      rec,
+		ContentType: contentType,
+		Header:      header,
+		Body:        body,
+	}
+	if route.typ != nil {
+		v := reflect.New(route.typ).Interface()
+		if err := cbornode.DecodeInto(body.RawData(), v); err != nil {
+			return fmt.Errorf("decoding %s body: %v", contentType, err)
+		}
+		e.Value = v
+	}
+	return route.handler(ctx, e)
+}
@@ -0,0 +1,81 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+const testNoteType = "application/vnd.threads.test-note+cbor"
+
+type testNote struct {
+	Text string
+}
+
+func init() {
+	cbornode.RegisterCborType(testNote{})
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n)
+
+	notes := make(chan *testNote, 1)
+	others := make(chan string, 1)
+	d := NewDispatcher(n)
+	d.HandleCBOR(testNoteType, testNote{}, func(_ context.Context, e DispatchEvent) error {
+		notes <- e.Value.(*testNote)
+		return nil
+	})
+	d.HandleDefault(func(_ context.Context, e DispatchEvent) error {
+		others <- e.ContentType
+		return nil
+	})
+	done := make(chan error)
+	go func() {
+		done <- d.Run(ctx, core.WithSubFilter(info.ID))
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	create := func(v interface{}, typ string) {
+		body, err := cbornode.WrapObject(v, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n.CreateRecord(ctx, info.ID, body, core.WithRecordType(typ)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	create(testNote{Text: "hello"}, testNoteType)
+	select {
+	case note := <-notes:
+		if note.Text != "hello" {
+			t.Fatalf("expected decoded note, got %+v", note)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("note wasn't dispatched")
+	}
+	create(map[string]interface{}{"foo": "bar"}, "application/vnd.threads.test-other+cbor")
+	select {
+	case typ := <-others:
+		if typ != "application/vnd.threads.test-other+cbor" {
+			t.Fatalf("unexpected content type %s", typ)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("record wasn't dispatched to the default handler")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected dispatching to stop with context, got %v", err)
+	}
+}
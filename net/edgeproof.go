@@ -0,0 +1,116 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/textileio/go-threads/cbor"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"github.com/textileio/go-threads/util"
+)
+
+var errNoHeadsProof = errors.New("heads edge isn't proven")
+
+// headProofs returns the header-only head records of thread logs, which prove the heads edge
+// to the exchange edges requester, or nil if they would exceed MaxHeadProofsSize.
+func (s *server) headProofs(ctx context.Context, tid thread.ID) ([]*pb.GetRecordsByCIDReply_LogEntry, error) {
+	info, err := s.net.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		proofs []*pb.GetRecordsByCIDReply_LogEntry
+		size   int
+	)
+	for _, lg := range info.Logs {
+		heads, err := s.net.store.Heads(tid, lg.ID)
+		if err != nil {
+			return nil, err
+		}
+		entry := &pb.GetRecordsByCIDReply_LogEntry{LogID: &pb.ProtoPeerID{ID: lg.ID}}
+		for _, h := range heads {
+			if !h.ID.Defined() {
+				continue
+			}
+			node, err := s.net.Get(ctx, h.ID)
+			if err != nil {
+				return nil, fmt.Errorf("getting head %s: %w", h.ID, err)
+			}
+			entry.Records = append(entry.Records, &pb.Log_Record{RecordNode: node.RawData()})
+		}
+		if len(entry.Records) == 0 {
+			continue
+		}
+		if size += entry.Size(); size > MaxHeadProofsSize {
+			return nil, nil
+		}
+		proofs = append(proofs, entry)
+	}
+	return proofs, nil
+}
+
+// verifyHeadProofs checks the head records received with the exchange edges reply against
+// the log keys and the claimed heads edge. It returns true if any of the proven heads is
+// unknown locally, i.e. pulling records from the peer is worthwhile.
+// Missing proofs and proofs of logs unknown locally are accepted unless the host requires them.
+func (s *server) verifyHeadProofs(tid thread.ID, edge uint64, proofs []*pb.GetRecordsByCIDReply_LogEntry) (bool, error) {
+	if len(proofs) == 0 {
+		if s.net.requireProofs {
+			return false, errNoHeadsProof
+		}
+		return true, nil
+	}
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return false, err
+	} else if sk == nil {
+		return false, errors.New("a service-key is required to verify heads")
+	}
+
+	var (
+		heads   []util.LogHead
+		unknown bool
+	)
+	for _, entry := range proofs {
+		if entry.LogID == nil {
+			return false, errors.New("missing log ID")
+		}
+		lid := entry.LogID.ID
+		pk, err := s.net.store.PubKey(tid, lid)
+		if err != nil {
+			return false, err
+		} else if pk == nil {
+			// keys of logs unknown locally can't be trusted, the heads are left unproven
+			if s.net.requireProofs {
+				return false, errNoHeadsProof
+			}
+			return true, nil
+		}
+		for _, r := range entry.Records {
+			if r == nil {
+				return false, errors.New("missing head record")
+			}
+			rec, err := cbor.RecordFromProto(&pb.Log_Record{RecordNode: r.RecordNode}, sk)
+			if err != nil {
+				return false, fmt.Errorf("decoding head of log %s: %w", lid, err)
+			}
+			if err = cbor.VerifyHeader(rec, pk); err != nil {
+				return false, fmt.Errorf("head %s of log %s: %w", rec.Cid(), lid, err)
+			}
+			heads = append(heads, util.LogHead{Head: thread.Head{ID: rec.Cid()}, LogID: lid})
+			if !unknown {
+				known, err := s.net.isKnown(rec.Cid())
+				if err != nil {
+					return false, err
+				}
+				unknown = !known
+			}
+		}
+	}
+	if util.ComputeHeadsEdge(heads) != edge {
+		return false, errNoHeadsProof
+	}
+	return unknown, nil
+}
@@ -0,0 +1,91 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_RecordExtensions(t *testing.T) {
+	t.Parallel()
+	const ns = "io.textile.net-test"
+	if err := core.RegisterExtensionNamespace(ns); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.RegisterExtensionNamespace(ns); !errors.Is(err, core.ErrExtensionNamespaceTaken) {
+		t.Fatalf("expected namespace to be taken, got %v", err)
+	}
+	if err := core.RegisterExtensionNamespace(core.ExtensionNamespaceThreads); !errors.Is(err, core.ErrExtensionNamespaceTaken) {
+		t.Fatalf("expected reserved namespace to be taken, got %v", err)
+	}
+	if err := core.RegisterExtensionNamespace("Bad/Namespace"); !errors.Is(err, core.ErrInvalidExtensionKey) {
+		t.Fatalf("expected invalid namespace to be rejected, got %v", err)
+	}
+
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, exts := range []core.RecordExtensions{
+		{"unregistered/route": []byte("a")},
+		{"no-name": []byte("a")},
+		{core.ExtensionKey(ns, "big"): make([]byte, core.MaxRecordExtensionsSize)},
+	} {
+		if _, err = n1.CreateRecord(ctx, info.ID, body, core.WithRecordExtensions(exts)); err == nil {
+			t.Fatalf("expected extensions %v to be rejected", exts.Keys())
+		}
+	}
+
+	exts := core.RecordExtensions{
+		core.ExtensionKey(ns, "route"):       []byte("eu-west"),
+		core.ExtensionKey(ns, "contentType"): []byte("image/png"),
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body, core.WithRecordExtensions(exts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plain.Value().Extensions()) != 0 {
+		t.Fatal("expected record without extensions")
+	}
+
+	// extensions are signed with the record, so the peer verifies them on pull
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := n2.GetRecord(ctx, info.ID, r.Value().Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route, ok := rec.Extensions().Get(ns, "route"); !ok || !bytes.Equal(route, []byte("eu-west")) {
+		t.Fatalf("unexpected route extension %q", route)
+	}
+	if typ, ok := rec.Extensions().Get(ns, "contentType"); !ok || !bytes.Equal(typ, []byte("image/png")) {
+		t.Fatalf("unexpected content type extension %q", typ)
+	}
+}
@@ -0,0 +1,39 @@
+// Package faults provides failure injection points for chaos testing of
+// deployed networks. Injection is only built in with the chaos build tag,
+// otherwise the hooks are no-ops and injected failures can't be configured.
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDisabled indicates the binary is built without failure injection.
+var ErrDisabled = errors.New("failure injection is not built in, rebuild with the chaos tag")
+
+// Config of injected failures. Zero config injects nothing.
+type Config struct {
+	// DropPushRecord is the percentage of received record pushes dropped
+	// without processing, while acknowledged to the sender.
+	DropPushRecord uint32
+	// DelayExchangeEdges delays handling of received edge exchanges.
+	DelayExchangeEdges time.Duration
+	// CorruptRecordBody is the percentage of received record pushes with
+	// a byte of the event body corrupted before verification.
+	CorruptRecordBody uint32
+}
+
+// Validate the config.
+func (c Config) Validate() error {
+	if c.DropPushRecord > 100 {
+		return fmt.Errorf("push record drop percentage is out of range: %d", c.DropPushRecord)
+	}
+	if c.DelayExchangeEdges < 0 {
+		return fmt.Errorf("exchange edges delay is negative: %s", c.DelayExchangeEdges)
+	}
+	if c.CorruptRecordBody > 100 {
+		return fmt.Errorf("record body corruption percentage is out of range: %d", c.CorruptRecordBody)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+//go:build chaos
+// +build chaos
+
+package faults
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/logger"
+)
+
+// Enabled reports whether failure injection is built in.
+const Enabled = true
+
+var (
+	log = logger.Logger("netfaults")
+
+	config Config
+	rnd    = rand.New(rand.NewSource(time.Now().UnixNano()))
+	mx     sync.Mutex
+)
+
+// Set replaces the config of injected failures.
+func Set(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	mx.Lock()
+	defer mx.Unlock()
+	config = c
+	log.Warnf("injecting failures: %+v", c)
+	return nil
+}
+
+// Get returns the config of injected failures.
+func Get() Config {
+	mx.Lock()
+	defer mx.Unlock()
+	return config
+}
+
+// DropPushRecord reports whether a received record push should be dropped.
+func DropPushRecord() bool {
+	mx.Lock()
+	defer mx.Unlock()
+	return hit(config.DropPushRecord)
+}
+
+// DelayExchangeEdges blocks for the configured delay or until the context is done.
+func DelayExchangeEdges(ctx context.Context) {
+	mx.Lock()
+	delay := config.DelayExchangeEdges
+	mx.Unlock()
+	if delay <= 0 {
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
+
+// CorruptRecordBody flips a random byte of the received event body in place.
+func CorruptRecordBody(body []byte) {
+	mx.Lock()
+	defer mx.Unlock()
+	if len(body) == 0 || !hit(config.CorruptRecordBody) {
+		return
+	}
+	body[rnd.Intn(len(body))] ^= 0xff
+}
+
+func hit(percent uint32) bool {
+	return percent > 0 && uint32(rnd.Intn(100)) < percent
+}
@@ -0,0 +1,63 @@
+//go:build chaos
+// +build chaos
+
+package faults
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInject(t *testing.T) {
+	if err := Set(Config{CorruptRecordBody: 101}); err == nil {
+		t.Fatal("expected bad config to be rejected")
+	}
+	defer func() {
+		_ = Set(Config{})
+	}()
+
+	if DropPushRecord() {
+		t.Fatal("expected pushes not to be dropped by default")
+	}
+	body := []byte("body")
+	CorruptRecordBody(body)
+	if !bytes.Equal(body, []byte("body")) {
+		t.Fatal("expected body not to be corrupted by default")
+	}
+
+	c := Config{DropPushRecord: 100, DelayExchangeEdges: 100 * time.Millisecond, CorruptRecordBody: 100}
+	if err := Set(c); err != nil {
+		t.Fatal(err)
+	}
+	if Get() != c {
+		t.Fatalf("expected config %+v, got %+v", c, Get())
+	}
+	if !DropPushRecord() {
+		t.Fatal("expected push to be dropped")
+	}
+	CorruptRecordBody(body)
+	var changed int
+	for i := range body {
+		if body[i] != "body"[i] {
+			changed++
+		}
+	}
+	if changed != 1 {
+		t.Fatalf("expected a single byte to be corrupted, got %d", changed)
+	}
+
+	start := time.Now()
+	DelayExchangeEdges(context.Background())
+	if time.Since(start) < c.DelayExchangeEdges {
+		t.Fatal("expected exchange edges to be delayed")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	DelayExchangeEdges(ctx)
+	if time.Since(start) >= c.DelayExchangeEdges {
+		t.Fatal("expected delay to end with the context")
+	}
+}
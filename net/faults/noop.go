@@ -0,0 +1,30 @@
+//go:build !chaos
+// +build !chaos
+
+package faults
+
+import "context"
+
+// Enabled reports whether failure injection is built in.
+const Enabled = false
+
+// Set always fails with ErrDisabled.
+func Set(Config) error {
+	return ErrDisabled
+}
+
+// Get returns zero config.
+func Get() Config {
+	return Config{}
+}
+
+// DropPushRecord never drops pushes.
+func DropPushRecord() bool {
+	return false
+}
+
+// DelayExchangeEdges returns immediately.
+func DelayExchangeEdges(context.Context) {}
+
+// CorruptRecordBody leaves the body intact.
+func CorruptRecordBody([]byte) {}
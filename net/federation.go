@@ -0,0 +1,305 @@
+package net
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	// FederationHeartbeatInterval is the interval of heartbeats sent to other federation members.
+	FederationHeartbeatInterval = time.Second * 10
+
+	// FederationFailureThreshold is the number of consecutive missed heartbeats after which
+	// a member is considered gone and its threads are reassigned.
+	FederationFailureThreshold = 3
+
+	// FederationVirtualNodes is the number of points each member takes on the hash ring.
+	// More points spread threads more evenly across members.
+	FederationVirtualNodes = 64
+)
+
+type ringPoint struct {
+	hash   uint64
+	member peer.ID
+}
+
+// hashRing assigns threads to federation members with consistent hashing, so only
+// the threads of a member which leaves or joins the ring move to other members.
+type hashRing []ringPoint
+
+func newHashRing(members []peer.ID) hashRing {
+	ring := make(hashRing, 0, len(members)*FederationVirtualNodes)
+	for _, m := range members {
+		for i := 0; i < FederationVirtualNodes; i++ {
+			ring = append(ring, ringPoint{
+				hash:   ringHash([]byte(m.String() + "#" + strconv.Itoa(i))),
+				member: m,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	return ring
+}
+
+// lookup returns the member responsible for a thread, or empty ID if the ring is empty.
+func (r hashRing) lookup(tid thread.ID) peer.ID {
+	if len(r) == 0 {
+		return ""
+	}
+	h := ringHash(tid.Bytes())
+	i := sort.Search(len(r), func(i int) bool {
+		return r[i].hash >= h
+	})
+	if i == len(r) {
+		i = 0
+	}
+	return r[i].member
+}
+
+func ringHash(data []byte) uint64 {
+	sum := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// federation tracks the liveness of always-on nodes sharing responsibility for threads.
+// Each thread is assigned to one of the live members, which pulls the thread and accepts
+// pushes forwarded by other members.
+type federation struct {
+	sync.RWMutex
+	self    peer.ID
+	members map[peer.ID]struct{}
+	missed  map[peer.ID]int
+	ring    hashRing
+}
+
+func newFederation(self peer.ID, members []peer.AddrInfo) *federation {
+	f := &federation{
+		self:    self,
+		members: map[peer.ID]struct{}{self: {}},
+		missed:  make(map[peer.ID]int),
+	}
+	for _, m := range members {
+		f.members[m.ID] = struct{}{}
+	}
+	// members are assumed live until they miss heartbeats
+	f.ring = newHashRing(f.liveMembers())
+	return f
+}
+
+// isMember returns whether a peer belongs to the federation.
+func (f *federation) isMember(pid peer.ID) bool {
+	f.RLock()
+	defer f.RUnlock()
+	_, ok := f.members[pid]
+	return ok
+}
+
+// responsible returns the live member a thread is assigned to.
+func (f *federation) responsible(tid thread.ID) peer.ID {
+	f.RLock()
+	defer f.RUnlock()
+	return f.ring.lookup(tid)
+}
+
+// peers returns the members other than the host.
+func (f *federation) peers() []peer.ID {
+	f.RLock()
+	defer f.RUnlock()
+	peers := make([]peer.ID, 0, len(f.members)-1)
+	for m := range f.members {
+		if m != f.self {
+			peers = append(peers, m)
+		}
+	}
+	return peers
+}
+
+// update records heartbeat results and rebuilds the ring if any member left or rejoined.
+// The previous ring is returned along with the change flag.
+func (f *federation) update(alive map[peer.ID]bool) (hashRing, bool) {
+	f.Lock()
+	defer f.Unlock()
+	var changed bool
+	for pid, ok := range alive {
+		wasLive := f.missed[pid] < FederationFailureThreshold
+		if ok {
+			f.missed[pid] = 0
+		} else {
+			f.missed[pid]++
+		}
+		if isLive := f.missed[pid] < FederationFailureThreshold; isLive != wasLive {
+			if isLive {
+				log.Infof("federation member %s is back", pid)
+			} else {
+				log.Warnf("federation member %s is gone, reassigning its threads", pid)
+			}
+			changed = true
+		}
+	}
+	prev := f.ring
+	if changed {
+		f.ring = newHashRing(f.liveMembers())
+	}
+	return prev, changed
+}
+
+func (f *federation) liveMembers() []peer.ID {
+	live := make([]peer.ID, 0, len(f.members))
+	for m := range f.members {
+		if f.missed[m] < FederationFailureThreshold {
+			live = append(live, m)
+		}
+	}
+	return live
+}
+
+// isResponsible returns whether the host should pull a thread,
+// which is always the case outside of a federation.
+func (n *net) isResponsible(tid thread.ID) bool {
+	return n.federation == nil || n.federation.responsible(tid) == n.host.ID()
+}
+
+// forwardTo returns the federation member a push of a thread from a peer should be
+// forwarded to. Pushes from other members are never forwarded, which prevents loops
+// while members disagree about assignments.
+func (n *net) forwardTo(pid peer.ID, tid thread.ID) (peer.ID, bool) {
+	if n.federation == nil || n.federation.isMember(pid) {
+		return "", false
+	}
+	resp := n.federation.responsible(tid)
+	return resp, resp != n.host.ID()
+}
+
+// startFederation sends heartbeats to the other members and takes over threads
+// of the members which are gone.
+func (n *net) startFederation(members []peer.AddrInfo) {
+	for _, m := range members {
+		if m.ID != n.host.ID() {
+			n.host.Peerstore().AddAddrs(m.ID, m.Addrs, pstore.PermanentAddrTTL)
+		}
+	}
+	tick := n.clock.NewTicker(FederationHeartbeatInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-tick.C:
+			n.federationHeartbeat()
+		}
+	}
+}
+
+func (n *net) federationHeartbeat() {
+	var (
+		peers = n.federation.peers()
+		alive = make(map[peer.ID]bool, len(peers))
+		lock  sync.Mutex
+		wg    sync.WaitGroup
+	)
+	for _, pid := range peers {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			err := n.server.heartbeat(n.ctx, pid)
+			if err != nil {
+				log.Debugf("heartbeat to federation member %s failed: %v", pid, err)
+			}
+			lock.Lock()
+			alive[pid] = err == nil
+			lock.Unlock()
+		}(pid)
+	}
+	wg.Wait()
+
+	prev, changed := n.federation.update(alive)
+	if !changed {
+		return
+	}
+	ts, err := n.store.Threads()
+	if err != nil {
+		log.Errorf("error listing threads: %v", err)
+		return
+	}
+	for _, tid := range ts {
+		if prev.lookup(tid) != n.host.ID() && n.isResponsible(tid) {
+			go func(tid thread.ID) {
+				log.Infof("taking over thread %s", tid)
+				if err := n.pullThread(n.ctx, tid); err != nil {
+					log.Errorf("pulling taken over thread %s failed: %v", tid, err)
+				}
+			}(tid)
+		}
+	}
+}
+
+// heartbeat checks a federation member is reachable with an edge exchange of no threads.
+func (s *server) heartbeat(ctx context.Context, pid peer.ID) error {
+	client, err := s.dial(pid)
+	if err != nil {
+		return err
+	}
+	cctx, cancel := context.WithTimeout(ctx, DialTimeout)
+	defer cancel()
+	reply, err := client.ExchangeEdges(cctx, &pb.ExchangeEdgesRequest{
+		Body: &pb.ExchangeEdgesRequest_Body{MaxRecordSize: int64(s.net.maxRecordSize)},
+	})
+	if status.Code(err) == codes.Unimplemented {
+		// the member is up, but runs a version without edge exchange
+		return nil
+	} else if err != nil {
+		return err
+	}
+	s.net.setPeerMaxRecordSize(pid, reply.MaxRecordSize)
+	return nil
+}
+
+// forwardPushRecord passes a pushed record to the federation member responsible for its thread.
+func (s *server) forwardPushRecord(ctx context.Context, req *pb.PushRecordRequest, pid peer.ID) (*pb.PushRecordReply, error) {
+	client, err := s.dial(pid)
+	if err != nil {
+		return nil, err
+	}
+	fctx, cancel := context.WithTimeout(ctx, PushTimeout)
+	defer cancel()
+	return client.PushRecord(fctx, req)
+}
+
+// forwardPushLog passes a pushed log to the federation member responsible for its thread,
+// adding the service key which pushers leave out for peers expected to have the thread.
+func (s *server) forwardPushLog(req *pb.PushLogRequest, pid peer.ID) error {
+	freq, body := *req, *req.Body
+	if body.ServiceKey == nil || body.ServiceKey.Key == nil {
+		sk, err := s.net.store.ServiceKey(body.ThreadID.ID)
+		if err != nil {
+			return err
+		}
+		if sk != nil {
+			body.ServiceKey = &pb.ProtoKey{Key: sk}
+		}
+	}
+	freq.Body = &body
+
+	client, err := s.dial(pid)
+	if err != nil {
+		return err
+	}
+	fctx, cancel := context.WithTimeout(s.net.ctx, PushTimeout)
+	defer cancel()
+	_, err = client.PushLog(fctx, &freq)
+	return err
+}
@@ -0,0 +1,162 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_FederationFailover(t *testing.T) {
+	t.Parallel()
+	self, a, b := makePeerID(t), makePeerID(t), makePeerID(t)
+	f := newFederation(self, []peer.AddrInfo{{ID: a}, {ID: b}})
+
+	assigned := make(map[thread.ID]peer.ID)
+	counts := make(map[peer.ID]int)
+	for i := 0; i < 300; i++ {
+		tid := thread.NewIDV1(thread.Raw, 32)
+		assigned[tid] = f.responsible(tid)
+		counts[assigned[tid]]++
+	}
+	for _, m := range []peer.ID{self, a, b} {
+		if counts[m] == 0 {
+			t.Fatalf("expected threads assigned to %s", m)
+		}
+	}
+
+	// a member is gone after missing enough heartbeats
+	for i := 0; i < FederationFailureThreshold; i++ {
+		prev, changed := f.update(map[peer.ID]bool{a: false, b: true})
+		if changed != (i == FederationFailureThreshold-1) {
+			t.Fatalf("unexpected ring change after %d missed heartbeats", i+1)
+		}
+		if changed {
+			for tid, m := range assigned {
+				if prev.lookup(tid) != m {
+					t.Fatal("expected previous ring to keep assignments")
+				}
+			}
+		}
+	}
+	for tid, m := range assigned {
+		resp := f.responsible(tid)
+		if m == a && resp == a {
+			t.Fatalf("expected thread %s to be reassigned", tid)
+		}
+		if m != a && resp != m {
+			t.Fatalf("expected thread %s of a live member to stay with %s, got %s", tid, m, resp)
+		}
+	}
+
+	// assignments return once the member is back
+	if _, changed := f.update(map[peer.ID]bool{a: true, b: true}); !changed {
+		t.Fatal("expected ring change")
+	}
+	for tid, m := range assigned {
+		if f.responsible(tid) != m {
+			t.Fatalf("expected thread %s to return to %s", tid, m)
+		}
+	}
+}
+
+func TestNet_FederationForwarding(t *testing.T) {
+	t.Parallel()
+	sk1, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk2, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id1, err := peer.IDFromPrivateKey(sk1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, err := peer.IDFromPrivateKey(sk2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n1 := makeNetworkWithKey(t, sk1, Config{Debug: true, Federation: []peer.AddrInfo{{ID: id2}}}).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithKey(t, sk2, Config{Debug: true, Federation: []peer.AddrInfo{{ID: id1}}}).(*net)
+	defer n2.Close()
+	n3 := makeNetwork(t).(*net)
+	defer n3.Close()
+	n1.Host().Peerstore().AddAddrs(id2, n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(id1, n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n3.Host().Peerstore().AddAddrs(id1, n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n1.Host().Peerstore().AddAddrs(n3.Host().ID(), n3.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	// the external peer replicates a thread assigned to n2 through n1
+	var tid thread.ID
+	for {
+		tid = thread.NewIDV1(thread.Raw, 32)
+		if n1.federation.responsible(tid) == id2 {
+			break
+		}
+	}
+	ctx := context.Background()
+	info, err := n3.CreateThread(ctx, tid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid := info.Logs[0].ID
+	if _, err = n3.AddReplicator(ctx, tid, ma.StringCast("/p2p/"+id1.String())); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		lg, err := n2.store.GetLog(tid, lid)
+		return err == nil && lg.ID == lid
+	})
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n3.CreateRecord(ctx, tid, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		h, err := n2.store.Heads(tid, lid)
+		return err == nil && len(h) == 1 && h[0].ID.Equals(r.Value().Cid())
+	})
+	if h, err := n1.store.Heads(tid, lid); err != nil {
+		t.Fatal(err)
+	} else if len(h) != 0 && h[0].ID.Defined() {
+		t.Fatal("expected the forwarding member not to accept the record")
+	}
+}
+
+func makePeerID(t *testing.T) peer.ID {
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(time.Second * 10)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+}
@@ -0,0 +1,210 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// LogLeaseTTL is the duration a process keeps the write lease of an own log after
+// its last write. Leases of closed networks are released, the ones left by crashed
+// processes block other writers until they expire.
+var LogLeaseTTL = time.Minute
+
+// metaLogLeasePrefix prefixes the thread metadata key of an own log write lease:
+// lease:<log ID>
+const metaLogLeasePrefix = "lease:"
+
+// logKey identifies a log of a thread.
+type logKey struct {
+	tid thread.ID
+	lid peer.ID
+}
+
+// logLease is the write lease of an own log, shared with other processes through the
+// logstore. Every process writing to the log takes over the lease with the next epoch.
+type logLease struct {
+	holder  string
+	epoch   uint64
+	expires time.Time
+}
+
+// logFence is the state of a lease held by the host.
+type logFence struct {
+	epoch uint64
+	// head is the last record written with the lease
+	head cid.Cid
+}
+
+// newLeaseHolder returns a random holder identifying the process, since processes
+// sharing a datastore usually share the host identity as well.
+func newLeaseHolder() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (n *net) getLogLease(tid thread.ID, lid peer.ID) (*logLease, *string, error) {
+	v, err := n.store.GetString(tid, metaLogLeasePrefix+lid.String())
+	if err != nil || v == nil {
+		return nil, nil, err
+	}
+	var (
+		l       logLease
+		expires int64
+	)
+	if _, err := fmt.Sscan(*v, &l.holder, &l.epoch, &expires); err != nil {
+		return nil, nil, fmt.Errorf("decoding lease of log %s: %w", lid, err)
+	}
+	l.expires = time.Unix(0, expires)
+	return &l, v, nil
+}
+
+// swapLogLease stores a lease if the stored one is still the one read as old, so concurrent
+// writers can't both take the lease over. It returns whether the lease was stored.
+func (n *net) swapLogLease(tid thread.ID, lid peer.ID, old *string, l logLease) (bool, error) {
+	v := fmt.Sprintf("%s %d %d", l.holder, l.epoch, l.expires.UnixNano())
+	return n.store.CompareAndSwapString(tid, metaLogLeasePrefix+lid.String(), old, v)
+}
+
+// fenceLog ensures the host holds the write lease of an own log, and returns the
+// lease epoch recorded in the headers of new records. Writes are refused with
+// core.ErrLogFenced if the log is leased by another process, or if the log head moved
+// past the last record written by the host, i.e. another process appended to the log.
+// It must be called while holding the log write semaphore.
+func (n *net) fenceLog(ctx context.Context, tid thread.ID, lg thread.LogInfo) (uint64, error) {
+	lease, stored, err := n.getLogLease(tid, lg.ID)
+	if err != nil {
+		return 0, err
+	}
+	now := n.clock.Now()
+
+	n.fenceLock.Lock()
+	fence, held := n.fences[logKey{tid, lg.ID}]
+	n.fenceLock.Unlock()
+
+	if held && lease != nil {
+		if lease.holder != n.leaseHolder || lease.epoch != fence.epoch {
+			n.dropFence(tid, lg.ID)
+			return 0, fmt.Errorf("%w: lease of log %s was taken over with epoch %d", core.ErrLogFenced, lg.ID, lease.epoch)
+		}
+		if !lg.Head.ID.Equals(fence.head) {
+			n.dropFence(tid, lg.ID)
+			return 0, fmt.Errorf("%w: log %s was appended by another writer", core.ErrLogFenced, lg.ID)
+		}
+		if lease.expires.Sub(now) > LogLeaseTTL/2 {
+			return fence.epoch, nil
+		}
+	} else {
+		if lease != nil && lease.holder != n.leaseHolder && lease.expires.After(now) {
+			return 0, fmt.Errorf("%w: log %s is leased until %s", core.ErrLogFenced, lg.ID, lease.expires.Format(time.RFC3339))
+		}
+		var epoch uint64
+		if lease != nil {
+			epoch = lease.epoch
+		}
+		// the lease could be lost along with thread metadata, the head carries the last epoch as well
+		if headEpoch, err := n.recordEpoch(ctx, tid, lg.Head.ID); err != nil {
+			log.Warnf("getting epoch of log %s head failed: %v", lg.ID, err)
+		} else if headEpoch > epoch {
+			epoch = headEpoch
+		}
+		fence = logFence{epoch: epoch + 1, head: lg.Head.ID}
+	}
+
+	// a concurrent writer may have taken the lease since it was read
+	if swapped, err := n.swapLogLease(tid, lg.ID, stored, logLease{
+		holder:  n.leaseHolder,
+		epoch:   fence.epoch,
+		expires: now.Add(LogLeaseTTL),
+	}); err != nil {
+		return 0, err
+	} else if !swapped {
+		n.dropFence(tid, lg.ID)
+		return 0, fmt.Errorf("%w: lease of log %s was taken concurrently", core.ErrLogFenced, lg.ID)
+	}
+	if !held {
+		log.Debugf("acquired lease of log %s (thread=%s, epoch=%d)", lg.ID, tid, fence.epoch)
+	}
+
+	n.fenceLock.Lock()
+	n.fences[logKey{tid, lg.ID}] = fence
+	n.fenceLock.Unlock()
+	return fence.epoch, nil
+}
+
+// setFenceHead records a new head written by the host to a fenced log.
+func (n *net) setFenceHead(tid thread.ID, lid peer.ID, head cid.Cid) {
+	n.fenceLock.Lock()
+	defer n.fenceLock.Unlock()
+	k := logKey{tid, lid}
+	if f, ok := n.fences[k]; ok {
+		f.head = head
+		n.fences[k] = f
+	}
+}
+
+func (n *net) dropFence(tid thread.ID, lid peer.ID) {
+	n.fenceLock.Lock()
+	defer n.fenceLock.Unlock()
+	delete(n.fences, logKey{tid, lid})
+}
+
+// releaseLogLeases expires the leases held by the host, so other processes may write
+// to the logs right away.
+func (n *net) releaseLogLeases() {
+	n.fenceLock.Lock()
+	defer n.fenceLock.Unlock()
+	for k, f := range n.fences {
+		lease, stored, err := n.getLogLease(k.tid, k.lid)
+		if err != nil || lease == nil || lease.holder != n.leaseHolder || lease.epoch != f.epoch {
+			continue
+		}
+		lease.expires = time.Unix(0, 0)
+		if _, err := n.swapLogLease(k.tid, k.lid, stored, *lease); err != nil {
+			log.Errorf("releasing lease of log %s failed: %v", k.lid, err)
+		}
+	}
+	n.fences = make(map[logKey]logFence)
+}
+
+// recordEpoch returns the write epoch in the header of a local record.
+func (n *net) recordEpoch(ctx context.Context, tid thread.ID, rid cid.Cid) (uint64, error) {
+	if !rid.Defined() {
+		return 0, nil
+	}
+	sk, err := n.store.ServiceKey(tid)
+	if err != nil {
+		return 0, err
+	}
+	key, err := n.headerKey(tid)
+	if err != nil {
+		return 0, err
+	}
+	if sk == nil || key == nil {
+		return 0, fmt.Errorf("thread keys are required to read record headers")
+	}
+	rec, err := cbor.GetRecord(ctx, n, rid, sk)
+	if err != nil {
+		return 0, err
+	}
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		return 0, err
+	}
+	header, err := event.GetHeader(ctx, n, key)
+	if err != nil {
+		return 0, err
+	}
+	return header.Epoch()
+}
@@ -0,0 +1,285 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+var (
+	// MaxFollows is the max number of follows kept per thread, including withdrawn ones.
+	// The oldest withdrawn follows are dropped first.
+	MaxFollows = 256
+
+	// MaxNewFollows is the max number of follows of peers unknown to the host accepted
+	// from a single exchange, so a peer can't replace the stored follows at once.
+	MaxNewFollows = 32
+
+	// MaxFollowClockSkew is how far ahead of the local clock the timestamp of a received follow
+	// can be. Follows further ahead are rejected, so they can't evict the follows of other peers.
+	MaxFollowClockSkew = time.Minute
+)
+
+// metaFollows is the metadata key of the marshaled follows of a thread.
+const metaFollows = "follows"
+
+func (n *net) Follow(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	return n.follow(ctx, id, true, opts...)
+}
+
+func (n *net) Unfollow(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	return n.follow(ctx, id, false, opts...)
+}
+
+func (n *net) Followers(ctx context.Context, id thread.ID, opts ...core.ThreadOption) ([]core.Follower, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	follows, err := n.threadFollows(id)
+	if err != nil {
+		return nil, err
+	}
+	var followers []core.Follower
+	for _, f := range follows {
+		if !f.Body.Active {
+			continue
+		}
+		fr := core.Follower{
+			Peer:  f.Body.PeerID.ID,
+			Since: time.Unix(0, f.Body.Timestamp),
+		}
+		for _, a := range f.Body.Addrs {
+			fr.Addrs = append(fr.Addrs, a.Multiaddr)
+		}
+		followers = append(followers, fr)
+	}
+	sort.Slice(followers, func(i, j int) bool {
+		return followers[i].Since.Before(followers[j].Since)
+	})
+	return followers, nil
+}
+
+// follow signs a follow of the host and exchanges it with the thread peers.
+func (n *net) follow(ctx context.Context, id thread.ID, active bool, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	f, err := n.followToProto(id, active)
+	if err != nil {
+		return err
+	}
+	if _, err = n.putFollows(id, []*pb.Follow{f}); err != nil {
+		return err
+	}
+	return n.exchangeLogs(id)
+}
+
+// exchangeLogs gets logs from the thread peers in the background, which exchanges
+// the local follows and freeze state with them.
+func (n *net) exchangeLogs(id thread.ID) error {
+	peers, err := n.server.pushPeers(id)
+	if err != nil {
+		return err
+	}
+	for _, pid := range peers {
+		go func(pid peer.ID) {
+			if err := n.updateLogsFromPeer(n.ctx, pid, id); err != nil {
+				log.Debugf("exchanging logs of thread %s with %s failed: %v", id, pid, err)
+			}
+		}(pid)
+	}
+	return nil
+}
+
+// followToProto returns a follow of the host signed with the host key.
+func (n *net) followToProto(id thread.ID, active bool) (*pb.Follow, error) {
+	body := &pb.Follow_Body{
+		ThreadID:  &pb.ProtoThreadID{ID: id},
+		PeerID:    &pb.ProtoPeerID{ID: n.host.ID()},
+		Active:    active,
+		Timestamp: n.clock.Now().UnixNano(),
+	}
+	if active {
+		for _, a := range n.host.Addrs() {
+			body.Addrs = append(body.Addrs, pb.ProtoAddr{Multiaddr: a})
+		}
+	}
+	msg, release, err := pb.MarshalPooled(body)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	sig, err := n.getPrivKey().Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Follow{Body: body, Sig: sig}, nil
+}
+
+// verifyFollow checks that the follow of a thread is signed by the following peer.
+func verifyFollow(id thread.ID, f *pb.Follow) error {
+	if f == nil || f.Body == nil || f.Body.ThreadID == nil || f.Body.PeerID == nil {
+		return errors.New("incomplete follow message")
+	}
+	if !f.Body.ThreadID.ID.Equals(id) {
+		return fmt.Errorf("follow of another thread %s", f.Body.ThreadID.ID)
+	}
+	pk, err := f.Body.PeerID.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+	msg, release, err := pb.MarshalPooled(f.Body)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if ok, err := pk.Verify(msg, f.Sig); !ok || err != nil {
+		return errors.New("bad follow signature")
+	}
+	return nil
+}
+
+// threadFollows returns the stored follows of a thread, including withdrawn ones.
+func (n *net) threadFollows(id thread.ID) ([]*pb.Follow, error) {
+	return storedFollows(n.store, id)
+}
+
+func storedFollows(ls lstore.Logstore, id thread.ID) ([]*pb.Follow, error) {
+	val, err := ls.GetBytes(id, metaFollows)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	var raw [][]byte
+	if err = json.Unmarshal(*val, &raw); err != nil {
+		return nil, fmt.Errorf("decoding follows: %w", err)
+	}
+	follows := make([]*pb.Follow, 0, len(raw))
+	for _, r := range raw {
+		f := &pb.Follow{}
+		if err = f.Unmarshal(r); err != nil {
+			return nil, fmt.Errorf("decoding follow: %w", err)
+		}
+		follows = append(follows, f)
+	}
+	return follows, nil
+}
+
+// putFollows merges verified follows into the stored ones, the latest follow of each peer wins.
+// Invalid follows, follows timestamped beyond MaxFollowClockSkew ahead of the local clock and
+// new follows beyond MaxNewFollows are skipped. It returns whether any follow changed.
+func (n *net) putFollows(id thread.ID, follows []*pb.Follow) (bool, error) {
+	if len(follows) == 0 {
+		return false, nil
+	}
+	n.followLock.Lock()
+	defer n.followLock.Unlock()
+	current, err := n.threadFollows(id)
+	if err != nil {
+		return false, err
+	}
+	byPeer := make(map[peer.ID]*pb.Follow, len(current))
+	for _, f := range current {
+		byPeer[f.Body.PeerID.ID] = f
+	}
+	var (
+		changed bool
+		added   int
+		limit   = n.clock.Now().Add(MaxFollowClockSkew).UnixNano()
+	)
+	for _, f := range follows {
+		if err := verifyFollow(id, f); err != nil {
+			log.Debugf("skipping follow of thread %s: %v", id, err)
+			continue
+		}
+		pid := f.Body.PeerID.ID
+		if f.Body.Timestamp > limit {
+			log.Debugf("skipping follow of %s ahead of the local clock (thread %s)", pid, id)
+			continue
+		}
+		if known, ok := byPeer[pid]; ok && known.Body.Timestamp >= f.Body.Timestamp {
+			continue
+		} else if !ok {
+			if added >= MaxNewFollows {
+				log.Debugf("skipping follow of %s, too many new follows (thread %s)", pid, id)
+				continue
+			}
+			added++
+		}
+		byPeer[pid] = f
+		changed = true
+		if f.Body.Active && pid != n.host.ID() {
+			// followers may be unknown to the host otherwise
+			addrs := make([]ma.Multiaddr, len(f.Body.Addrs))
+			for i, a := range f.Body.Addrs {
+				addrs[i] = a.Multiaddr
+			}
+			n.host.Peerstore().AddAddrs(pid, addrs, pstore.AddressTTL)
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	merged := make([]*pb.Follow, 0, len(byPeer))
+	for _, f := range byPeer {
+		merged = append(merged, f)
+	}
+	// withdrawn follows first, oldest first
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Body.Active != merged[j].Body.Active {
+			return !merged[i].Body.Active
+		}
+		return merged[i].Body.Timestamp < merged[j].Body.Timestamp
+	})
+	if len(merged) > MaxFollows {
+		merged = merged[len(merged)-MaxFollows:]
+	}
+	raw := make([][]byte, len(merged))
+	for i, f := range merged {
+		if raw[i], err = f.Marshal(); err != nil {
+			return false, err
+		}
+	}
+	val, err := json.Marshal(raw)
+	if err != nil {
+		return false, err
+	}
+	return true, n.store.PutBytes(id, metaFollows, val)
+}
+
+// followerPeers returns the active followers of a thread other than the host.
+func (n *net) followerPeers(id thread.ID) ([]peer.ID, error) {
+	follows, err := n.threadFollows(id)
+	if err != nil {
+		return nil, err
+	}
+	var peers []peer.ID
+	for _, f := range follows {
+		if f.Body.Active && f.Body.PeerID.ID != n.host.ID() {
+			peers = append(peers, f.Body.PeerID.ID)
+		}
+	}
+	return peers, nil
+}
@@ -0,0 +1,193 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+func TestNet_Follow(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n3 := makeNetwork(t).(*net)
+	defer n3.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n3.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addThread := func(n *net, from *net) {
+		addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", from.Host().ID(), info.ID))
+		if _, err := n.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+			t.Fatal(err)
+		}
+		if err := n.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addThread(n2, n1)
+	// n3 learns about the thread from n2 only
+	addThread(n3, n2)
+
+	isFollower := func(n *net) bool {
+		followers, err := n.Followers(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(followers) == 1 && followers[0].Peer == n3.Host().ID() && len(followers[0].Addrs) > 0
+	}
+	if err := n3.Follow(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !isFollower(n3) {
+		t.Fatal("expected host to follow the thread")
+	}
+	waitFor(t, func() bool { return isFollower(n2) })
+
+	// follows are replicated with logs
+	if err := n1.updateLogsFromPeer(ctx, n2.Host().ID(), info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !isFollower(n1) {
+		t.Fatal("expected follow to be replicated")
+	}
+
+	// followers receive new records from any replica
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		_, err := n3.GetRecord(ctx, info.ID, r.Value().Cid())
+		return err == nil
+	})
+
+	if err := n3.Unfollow(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		followers, err := n2.Followers(ctx, info.ID)
+		return err == nil && len(followers) == 0
+	})
+}
+
+func TestNet_PutFollows(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	f, err := n.followToProto(info.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := *f.Body
+	forged.Timestamp++
+	other, err := n.followToProto(thread.NewIDV1(thread.Raw, 32), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := n.putFollows(info.ID, []*pb.Follow{{Body: &forged, Sig: f.Sig}, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("expected forged follows to be skipped")
+	}
+
+	if changed, err = n.putFollows(info.ID, []*pb.Follow{f}); err != nil || !changed {
+		t.Fatalf("expected follow to be stored, got %v", err)
+	}
+	// the latest follow of a peer wins
+	unfollow, err := n.followToProto(info.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.putFollows(info.ID, []*pb.Follow{unfollow}); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err = n.putFollows(info.ID, []*pb.Follow{f}); err != nil || changed {
+		t.Fatal("expected older follow to be ignored")
+	}
+	followers, err := n.Followers(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(followers) != 0 {
+		t.Fatalf("expected no followers, got %v", followers)
+	}
+}
+
+func TestNet_PutFollowsBounds(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	mint := func(ts time.Time) *pb.Follow {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pid, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &pb.Follow_Body{
+			ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+			PeerID:    &pb.ProtoPeerID{ID: pid},
+			Active:    true,
+			Timestamp: ts.UnixNano(),
+		}
+		msg, err := b.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := sk.Sign(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pb.Follow{Body: b, Sig: sig}
+	}
+
+	now := n.clock.Now()
+	if changed, err := n.putFollows(info.ID, []*pb.Follow{mint(now.Add(2 * MaxFollowClockSkew))}); err != nil || changed {
+		t.Fatalf("expected a follow ahead of the local clock to be rejected, got %v (%v)", changed, err)
+	}
+	follows := make([]*pb.Follow, MaxNewFollows+1)
+	for i := range follows {
+		follows[i] = mint(now)
+	}
+	if _, err := n.putFollows(info.ID, follows); err != nil {
+		t.Fatal(err)
+	}
+	followers, err := n.Followers(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(followers) != MaxNewFollows {
+		t.Fatalf("expected %d new follows to be accepted, got %d", MaxNewFollows, len(followers))
+	}
+}
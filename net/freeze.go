@@ -0,0 +1,270 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// metaFreeze is the metadata key of the marshaled freeze state of a thread.
+const metaFreeze = "freeze"
+
+// MaxFreezeClockSkew is how far ahead of the local clock the timestamp of a received freeze
+// state can be. States further ahead are rejected, so they can't outlast later changes.
+var MaxFreezeClockSkew = time.Minute
+
+func (n *net) FreezeThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	return n.setFreeze(id, true, opts...)
+}
+
+func (n *net) UnfreezeThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	return n.setFreeze(id, false, opts...)
+}
+
+func (n *net) GetFreezeState(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.FreezeState, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.FreezeState{}, err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return core.FreezeState{}, err
+	}
+	f, err := n.threadFreeze(id)
+	if err != nil || f == nil {
+		return core.FreezeState{}, err
+	}
+	state := core.FreezeState{
+		Frozen: f.Body.Frozen,
+		Admin:  f.Body.PeerID.ID,
+		Time:   time.Unix(0, f.Body.Timestamp),
+	}
+	if f.Body.Frozen {
+		state.Heads = make(core.VectorClock, len(f.Body.Heads))
+		for _, h := range f.Body.Heads {
+			state.Heads[h.LogID.ID] = h.Counter
+		}
+	}
+	return state, nil
+}
+
+// setFreeze signs the freeze state of the host and exchanges it with the thread peers.
+func (n *net) setFreeze(id thread.ID, frozen bool, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	identity, err := n.Validate(id, args.Token, false)
+	if err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if identity == nil {
+		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	}
+	f, err := n.freezeToProto(id, identity, frozen)
+	if err != nil {
+		return err
+	}
+	if _, err = n.putFreeze(id, f); err != nil {
+		return err
+	}
+	return n.exchangeLogs(id)
+}
+
+// freezeToProto returns the freeze state of a thread at its current heads signed with the key
+// of the freezing identity, see freezeKey.
+func (n *net) freezeToProto(id thread.ID, identity thread.PubKey, frozen bool) (*pb.Freeze, error) {
+	sk, err := n.freezeKey(id, identity)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	body := &pb.Freeze_Body{
+		ThreadID:  &pb.ProtoThreadID{ID: id},
+		PeerID:    &pb.ProtoPeerID{ID: pid},
+		Frozen:    frozen,
+		Timestamp: n.clock.Now().UnixNano(),
+	}
+	if frozen {
+		clock, err := n.vectorClock(id)
+		if err != nil {
+			return nil, err
+		}
+		for lid, counter := range clock {
+			body.Heads = append(body.Heads, &pb.Freeze_Head{LogID: &pb.ProtoPeerID{ID: lid}, Counter: counter})
+		}
+	}
+	msg, release, err := pb.MarshalPooled(body)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	sig, err := sk.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Freeze{Body: body, Sig: sig}, nil
+}
+
+// freezeKey returns the key freeze states of an identity are signed with, which is the host
+// key if the host is the thread owner, and the key of the identity log otherwise.
+func (n *net) freezeKey(id thread.ID, identity thread.PubKey) (crypto.PrivKey, error) {
+	owner, err := n.logApprovalOwner(id)
+	if err != nil {
+		return nil, err
+	}
+	host, err := thread.NewLibp2pPubKey(n.getPrivKey().GetPublic()).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if owner != nil && bytes.Equal(owner, host) {
+		return n.getPrivKey(), nil
+	}
+	lidb, err := n.store.GetBytes(id, identity.String())
+	if err != nil {
+		return nil, err
+	} else if lidb == nil {
+		return nil, core.ErrFreezeDenied
+	}
+	lid, err := peer.IDFromBytes(*lidb)
+	if err != nil {
+		return nil, err
+	}
+	lg, err := n.store.GetLog(id, lid)
+	if err != nil {
+		return nil, err
+	} else if lg.PrivKey == nil {
+		return nil, core.ErrFreezeDenied
+	}
+	return lg.PrivKey, nil
+}
+
+// verifyFreeze checks that the freeze state of a thread is signed by the changing peer, which
+// has to be the thread owner or a log of the thread.
+func (n *net) verifyFreeze(id thread.ID, f *pb.Freeze) error {
+	if f.Body == nil || f.Body.ThreadID == nil || f.Body.PeerID == nil {
+		return errors.New("incomplete freeze message")
+	}
+	if !f.Body.ThreadID.ID.Equals(id) {
+		return fmt.Errorf("freeze of another thread %s", f.Body.ThreadID.ID)
+	}
+	for _, h := range f.Body.Heads {
+		if h == nil || h.LogID == nil {
+			return errors.New("incomplete freeze head")
+		}
+	}
+	pk, err := f.Body.PeerID.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+	msg, release, err := pb.MarshalPooled(f.Body)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if ok, err := pk.Verify(msg, f.Sig); !ok || err != nil {
+		return errors.New("bad freeze signature")
+	}
+	if _, err = n.store.GetLog(id, f.Body.PeerID.ID); err == nil {
+		return nil
+	} else if !errors.Is(err, lstore.ErrLogNotFound) {
+		return err
+	}
+	owner, err := n.logApprovalOwner(id)
+	if err != nil {
+		return err
+	}
+	signer, err := thread.NewLibp2pPubKey(pk).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if owner == nil || !bytes.Equal(owner, signer) {
+		return core.ErrFreezeDenied
+	}
+	return nil
+}
+
+// threadFreeze returns the stored freeze state of a thread, nil if it was never frozen.
+func (n *net) threadFreeze(id thread.ID) (*pb.Freeze, error) {
+	val, err := n.store.GetBytes(id, metaFreeze)
+	if err != nil || val == nil {
+		return nil, err
+	}
+	f := &pb.Freeze{}
+	if err = f.Unmarshal(*val); err != nil {
+		return nil, fmt.Errorf("decoding freeze: %w", err)
+	}
+	return f, nil
+}
+
+// putFreeze replaces the stored freeze state of a thread with a newer verified one.
+// A frozen thread is changed by the peer which froze it only. States timestamped
+// beyond MaxFreezeClockSkew ahead of the local clock are rejected. It returns whether
+// the state changed.
+func (n *net) putFreeze(id thread.ID, f *pb.Freeze) (bool, error) {
+	if err := n.verifyFreeze(id, f); err != nil {
+		return false, err
+	}
+	if f.Body.Timestamp > n.clock.Now().Add(MaxFreezeClockSkew).UnixNano() {
+		return false, fmt.Errorf("freeze of %s is ahead of the local clock", f.Body.PeerID.ID)
+	}
+	n.freezeLock.Lock()
+	defer n.freezeLock.Unlock()
+	current, err := n.threadFreeze(id)
+	if err != nil {
+		return false, err
+	}
+	if current != nil {
+		if current.Body.Timestamp >= f.Body.Timestamp {
+			return false, nil
+		}
+		if current.Body.Frozen && current.Body.PeerID.ID != f.Body.PeerID.ID {
+			return false, core.ErrNotFreezeAdmin
+		}
+	}
+	val, err := f.Marshal()
+	if err != nil {
+		return false, err
+	}
+	if err = n.store.PutBytes(id, metaFreeze, val); err != nil {
+		return false, err
+	}
+	if f.Body.Frozen {
+		log.Infof("thread %s was frozen by %s", id, f.Body.PeerID.ID)
+	} else {
+		log.Infof("thread %s was unfrozen by %s", id, f.Body.PeerID.ID)
+	}
+	return true, nil
+}
+
+// frozenHeight returns the counter of the head a log was frozen at, and whether
+// the thread is frozen. Logs unknown to the freeze were frozen empty.
+func (n *net) frozenHeight(tid thread.ID, lid peer.ID) (int64, bool, error) {
+	f, err := n.threadFreeze(tid)
+	if err != nil || f == nil || !f.Body.Frozen {
+		return 0, false, err
+	}
+	for _, h := range f.Body.Heads {
+		if h.LogID.ID == lid {
+			return h.Counter, true, nil
+		}
+	}
+	return 0, true, nil
+}
@@ -0,0 +1,203 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+func TestNet_FreezeThread(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	// the replica doesn't write, it's reachable as a follower
+	if err := n2.Follow(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		followers, err := n1.Followers(ctx, info.ID)
+		return err == nil && len(followers) == 1
+	})
+
+	if err := n1.FreezeThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	state, err := n1.GetFreezeState(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Frozen || state.Admin != r1.LogID() || state.Heads[r1.LogID()] != 1 {
+		t.Fatalf("unexpected freeze state %v", state)
+	}
+	if _, err = n1.CreateRecord(ctx, info.ID, body); !errors.Is(err, core.ErrThreadFrozen) {
+		t.Fatalf("expected record to be rejected, got %v", err)
+	}
+
+	// the freeze is replicated, only the admin unfreezes
+	waitFor(t, func() bool {
+		state, err := n2.GetFreezeState(ctx, info.ID)
+		return err == nil && state.Frozen
+	})
+	if _, err = n2.CreateRecord(ctx, info.ID, body); !errors.Is(err, core.ErrThreadFrozen) {
+		t.Fatalf("expected record to be rejected by replica, got %v", err)
+	}
+	if err = n2.UnfreezeThread(ctx, info.ID); !errors.Is(err, core.ErrNotFreezeAdmin) {
+		t.Fatalf("expected unfreeze to be rejected, got %v", err)
+	}
+	// states signed by peers without a log of the thread are rejected
+	unfreeze := signedFreeze(t, n2, info.ID, false, n2.clock.Now())
+	if _, err = n1.putFreeze(info.ID, unfreeze); !errors.Is(err, core.ErrFreezeDenied) {
+		t.Fatalf("expected unfreeze of a non-member to be rejected, got %v", err)
+	}
+	// history is still served
+	if _, err = n2.GetRecord(ctx, info.ID, r1.Value().Cid()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = n1.UnfreezeThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		state, err := n2.GetFreezeState(ctx, info.ID)
+		return err == nil && !state.Frozen
+	})
+	r2, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		_, err := n2.GetRecord(ctx, info.ID, r2.Value().Cid())
+		return err == nil
+	})
+}
+
+func TestNet_FrozenRecords(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// freeze the replica only, before the writer learns about it
+	f, err := n1.freezeToProto(info.ID, thread.NewLibp2pPubKey(n1.getPrivKey().GetPublic()), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.putFreeze(info.ID, f); err != nil {
+		t.Fatal(err)
+	}
+	r2, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	lg, err := n2.store.GetLog(info.ID, r1.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lg.Head.ID.Equals(r1.Value().Cid()) || lg.Head.Counter != 1 {
+		t.Fatalf("expected log to stay at the frozen head, got %v", lg.Head)
+	}
+	if _, err = n2.GetRecord(ctx, info.ID, r2.Value().Cid()); err == nil {
+		t.Fatal("expected record beyond the frozen head to be rejected")
+	}
+}
+
+func TestNet_FreezeTimestamp(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithLogApproval(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// states far ahead of the local clock would outlast later changes
+	future := signedFreeze(t, n, info.ID, true, n.clock.Now().Add(MaxFreezeClockSkew*2))
+	if _, err = n.putFreeze(info.ID, future); err == nil {
+		t.Fatal("expected freeze ahead of the local clock to be rejected")
+	}
+	// the owner freezes without a log of the thread
+	if err = n.FreezeThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = n.UnfreezeThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := n.GetFreezeState(ctx, info.ID); err != nil || state.Frozen || state.Admin != n.Host().ID() {
+		t.Fatalf("unexpected freeze state %v (%v)", state, err)
+	}
+}
+
+// signedFreeze returns a freeze state of a thread signed with the host key of a network.
+func signedFreeze(t *testing.T, n *net, id thread.ID, frozen bool, at time.Time) *pb.Freeze {
+	body := &pb.Freeze_Body{
+		ThreadID:  &pb.ProtoThreadID{ID: id},
+		PeerID:    &pb.ProtoPeerID{ID: n.host.ID()},
+		Frozen:    frozen,
+		Timestamp: at.UnixNano(),
+	}
+	msg, err := body.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := n.getPrivKey().Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.Freeze{Body: body, Sig: sig}
+}
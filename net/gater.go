@@ -0,0 +1,159 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/clock"
+	lstore "github.com/textileio/go-threads/core/logstore"
+)
+
+// MembershipRefreshInterval is the minimum interval between rebuilds of the thread members
+// known to a membership gater. Members are rebuilt when an unknown peer connects, so peers
+// added to threads are admitted at most this late.
+var MembershipRefreshInterval = time.Second * 10
+
+// MembershipGater is a libp2p connection gater which only accepts inbound connections of
+// peers sharing a thread with the host, i.e. peers in the addresses of thread logs or following
+// a thread, and of allowed peers. Outbound connections aren't gated. Peers joining a thread
+// with an invite connect before the host knows them, so they have to be allowed until their
+// log is added, e.g. with AllowPeers.
+type MembershipGater struct {
+	store lstore.Logstore
+	clock clock.Clock
+
+	lock      sync.Mutex
+	allowed   map[peer.ID]struct{}
+	members   map[peer.ID]struct{}
+	refreshed time.Time
+}
+
+var _ connmgr.ConnectionGater = (*MembershipGater)(nil)
+
+// NewMembershipGater returns a gater admitting the members of the threads in a logstore
+// and the allowed peers. Members are refreshed on the given clock, clock.Real is used if nil.
+func NewMembershipGater(ls lstore.Logstore, clk clock.Clock, allow ...peer.ID) *MembershipGater {
+	if clk == nil {
+		clk = clock.Real
+	}
+	g := &MembershipGater{
+		store:   ls,
+		clock:   clk,
+		allowed: make(map[peer.ID]struct{}, len(allow)),
+		members: make(map[peer.ID]struct{}),
+	}
+	g.AllowPeers(allow...)
+	return g
+}
+
+// AllowPeers admits peers regardless of their thread membership.
+func (g *MembershipGater) AllowPeers(pids ...peer.ID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, pid := range pids {
+		g.allowed[pid] = struct{}{}
+	}
+}
+
+// DisallowPeers withdraws the admission of allowed peers, which are still admitted while
+// they share a thread with the host. Open connections are kept.
+func (g *MembershipGater) DisallowPeers(pids ...peer.ID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, pid := range pids {
+		delete(g.allowed, pid)
+	}
+}
+
+// Admits returns whether an inbound connection of a peer is accepted.
+func (g *MembershipGater) Admits(pid peer.ID) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if _, ok := g.allowed[pid]; ok {
+		return true
+	}
+	if _, ok := g.members[pid]; ok {
+		return true
+	}
+	if g.clock.Since(g.refreshed) < MembershipRefreshInterval {
+		return false
+	}
+	members, err := threadMembers(g.store)
+	if err != nil {
+		log.Errorf("listing thread members failed: %v", err)
+		return false
+	}
+	g.members, g.refreshed = members, g.clock.Now()
+	_, ok := members[pid]
+	return ok
+}
+
+func (g *MembershipGater) InterceptPeerDial(peer.ID) bool {
+	return true
+}
+
+func (g *MembershipGater) InterceptAddrDial(peer.ID, ma.Multiaddr) bool {
+	return true
+}
+
+func (g *MembershipGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	// the peer is known once the connection is secured
+	return true
+}
+
+func (g *MembershipGater) InterceptSecured(dir network.Direction, pid peer.ID, _ network.ConnMultiaddrs) bool {
+	if dir != network.DirInbound {
+		return true
+	}
+	if !g.Admits(pid) {
+		log.Debugf("rejecting connection of peer %s sharing no thread", pid)
+		return false
+	}
+	return true
+}
+
+func (g *MembershipGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// threadMembers returns the peers in the addresses of thread logs and the active followers
+// of threads in a logstore.
+func threadMembers(ls lstore.Logstore) (map[peer.ID]struct{}, error) {
+	tids, err := ls.Threads()
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[peer.ID]struct{})
+	for _, tid := range tids {
+		info, err := ls.GetThread(tid)
+		if err != nil {
+			return nil, err
+		}
+		for _, lg := range info.Logs {
+			for _, addr := range lg.Addrs {
+				p, err := addr.ValueForProtocol(ma.P_P2P)
+				if err != nil {
+					continue
+				}
+				if pid, err := peer.Decode(p); err == nil {
+					members[pid] = struct{}{}
+				}
+			}
+		}
+		follows, err := storedFollows(ls, tid)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range follows {
+			if f.Body.Active {
+				members[f.Body.PeerID.ID] = struct{}{}
+			}
+		}
+	}
+	return members, nil
+}
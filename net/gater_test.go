@@ -0,0 +1,74 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/clock"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestMembershipGater(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	newHost := func(opts ...libp2p.Option) host.Host {
+		h, err := libp2p.New(ctx, append(opts, libp2p.ListenAddrs(util.MustParseAddr("/ip4/127.0.0.1/tcp/0")))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = h.Close() })
+		return h
+	}
+	allowed, member := newHost(), newHost()
+	clk := clock.NewMock(time.Now())
+	g := NewMembershipGater(n.store, clk, allowed.ID())
+	gated := newHost(libp2p.ConnectionGater(g))
+	connect := func(h host.Host) error {
+		cctx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+		// skip the dial backoff of rejected connections
+		cctx = network.WithForceDirectDial(cctx, "test")
+		return h.Connect(cctx, peer.AddrInfo{ID: gated.ID(), Addrs: gated.Addrs()})
+	}
+
+	if err := connect(allowed); err != nil {
+		t.Fatalf("expected allowed peer to be admitted, got %v", err)
+	}
+	if err := connect(member); err == nil {
+		t.Fatal("expected peer sharing no thread to be rejected")
+	}
+	if !g.InterceptSecured(network.DirOutbound, member.ID(), nil) {
+		t.Fatal("expected outbound connections not to be gated")
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + member.ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.store.AddAddr(info.ID, info.Logs[0].ID, addr, peerstore.PermanentAddrTTL); err != nil {
+		t.Fatal(err)
+	}
+	if g.Admits(member.ID()) {
+		t.Fatal("expected members to be rebuilt at most once per refresh interval")
+	}
+	clk.Add(MembershipRefreshInterval)
+	if err := connect(member); err != nil {
+		t.Fatalf("expected thread member to be admitted, got %v", err)
+	}
+
+	g.DisallowPeers(allowed.ID())
+	if g.Admits(allowed.ID()) {
+		t.Fatal("expected disallowed peer to be rejected")
+	}
+}
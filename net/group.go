@@ -0,0 +1,267 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+const (
+	// metadata keys of the group a thread is a member of and the group storage quota,
+	// which is kept along with each member
+	metaGroup      = "group:name"
+	metaGroupQuota = "group:quota"
+)
+
+func (n *net) AddToGroup(ctx context.Context, group string, id thread.ID, opts ...core.ThreadOption) error {
+	if group == "" {
+		return core.ErrInvalidGroup
+	}
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	_, quota, err := n.groupUsage(group)
+	if err != nil {
+		return err
+	}
+
+	n.quotaLock.Lock()
+	defer n.quotaLock.Unlock()
+	if err := n.store.PutString(id, metaGroup, group); err != nil {
+		return err
+	}
+	return n.store.PutInt64(id, metaGroupQuota, quota)
+}
+
+func (n *net) RemoveFromGroup(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+
+	n.quotaLock.Lock()
+	defer n.quotaLock.Unlock()
+	if err := n.store.PutString(id, metaGroup, ""); err != nil {
+		return err
+	}
+	return n.store.PutInt64(id, metaGroupQuota, 0)
+}
+
+func (n *net) GetGroup(ctx context.Context, group string, opts ...core.ThreadOption) (core.GroupSummary, error) {
+	members, err := n.validateGroup(group, true, opts...)
+	if err != nil {
+		return core.GroupSummary{}, err
+	}
+	summary := core.GroupSummary{Name: group, Threads: members, Health: core.SyncHealthSynced, Paused: true}
+	for _, tid := range members {
+		ts, err := n.threadSummary(tid)
+		if err != nil {
+			return summary, err
+		}
+		// health values are ordered from the best to the worst
+		if ts.Health > summary.Health {
+			summary.Health = ts.Health
+		}
+		summary.Paused = summary.Paused && n.syncs.isPaused(tid)
+	}
+	if summary.Usage, summary.Quota, err = n.groupUsage(group); err != nil {
+		return summary, err
+	}
+	summary.OverQuota = summary.Quota > 0 && summary.Usage > summary.Quota
+	return summary, nil
+}
+
+func (n *net) PauseGroup(ctx context.Context, group string, opts ...core.ThreadOption) error {
+	members, err := n.validateGroup(group, false, opts...)
+	if err != nil {
+		return err
+	}
+	for _, tid := range members {
+		if err := n.setSyncPaused(tid, true, opts...); err != nil {
+			return fmt.Errorf("pausing thread %s: %w", tid, err)
+		}
+	}
+	for _, tid := range members {
+		select {
+		case <-n.syncs.drained(tid):
+		case <-ctx.Done():
+			// the group stays paused, calls in flight finish on their own
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (n *net) ResumeGroup(ctx context.Context, group string, opts ...core.ThreadOption) error {
+	members, err := n.validateGroup(group, false, opts...)
+	if err != nil {
+		return err
+	}
+	for _, tid := range members {
+		if err := n.setSyncPaused(tid, false, opts...); err != nil {
+			return fmt.Errorf("resuming thread %s: %w", tid, err)
+		}
+	}
+	return nil
+}
+
+func (n *net) GetGroupProgress(ctx context.Context, group string, opts ...core.ThreadOption) (core.GroupSyncProgress, error) {
+	members, err := n.validateGroup(group, true, opts...)
+	if err != nil {
+		return core.GroupSyncProgress{}, err
+	}
+	p := core.GroupSyncProgress{Name: group}
+	for _, tid := range members {
+		tp, err := n.syncProgress(tid)
+		if err != nil {
+			return p, err
+		}
+		p.Records += tp.Records
+		p.Expected += tp.Expected
+		p.Threads = append(p.Threads, tp)
+	}
+	return p, nil
+}
+
+// SetGroupQuota limits the size of records stored locally for all members of a group, in bytes.
+// Members are also limited by their own quota. Zero removes the limit.
+func (n *net) SetGroupQuota(group string, quota int64) error {
+	if quota < 0 {
+		return fmt.Errorf("invalid quota of %d bytes", quota)
+	}
+	members, err := n.groupMembers(group)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return core.ErrGroupNotFound
+	}
+
+	n.quotaLock.Lock()
+	defer n.quotaLock.Unlock()
+	for _, tid := range members {
+		if err := n.store.PutInt64(tid, metaGroupQuota, quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateGroup returns the members of a group, checking the token against each of them.
+func (n *net) validateGroup(group string, readOnly bool, opts ...core.ThreadOption) ([]thread.ID, error) {
+	if group == "" {
+		return nil, core.ErrInvalidGroup
+	}
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	members, err := n.groupMembers(group)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, core.ErrGroupNotFound
+	}
+	for _, tid := range members {
+		if _, err := n.Validate(tid, args.Token, readOnly); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// threadGroup returns the group a thread is a member of, empty if none.
+func (n *net) threadGroup(tid thread.ID) (string, error) {
+	group, err := n.store.GetString(tid, metaGroup)
+	if err != nil || group == nil {
+		return "", err
+	}
+	return *group, nil
+}
+
+// groupMembers returns the threads of a group, ordered by id.
+func (n *net) groupMembers(group string) ([]thread.ID, error) {
+	tids, err := n.store.Threads()
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(tids)
+	var members []thread.ID
+	for _, tid := range tids {
+		g, err := n.threadGroup(tid)
+		if err != nil {
+			return nil, err
+		}
+		if g == group {
+			members = append(members, tid)
+		}
+	}
+	return members, nil
+}
+
+// groupUsage returns the stored size of the group members and the group quota,
+// the quota is zero if unlimited.
+func (n *net) groupUsage(group string) (usage, quota int64, err error) {
+	members, err := n.groupMembers(group)
+	if err != nil {
+		return
+	}
+	for _, tid := range members {
+		u, _, err := n.threadUsage(tid)
+		if err != nil {
+			return 0, 0, err
+		}
+		usage += u
+		q, err := n.store.GetInt64(tid, metaGroupQuota)
+		if err != nil {
+			return 0, 0, err
+		} else if q != nil {
+			quota = *q
+		}
+	}
+	return usage, quota, nil
+}
+
+// pullUnits splits threads into the units scheduled for pulls at once. Members of a group
+// form a single unit, so they're packed into the same edge exchanges with their peers,
+// while other threads are scheduled on their own. Units keep the order of their first thread.
+func (n *net) pullUnits(ts []thread.ID) ([][]thread.ID, error) {
+	var (
+		units  [][]thread.ID
+		groups = make(map[string]int)
+	)
+	for _, tid := range ts {
+		group, err := n.threadGroup(tid)
+		if err != nil {
+			return nil, err
+		}
+		if group == "" {
+			units = append(units, []thread.ID{tid})
+			continue
+		}
+		if i, ok := groups[group]; ok {
+			units[i] = append(units[i], tid)
+			continue
+		}
+		groups[group] = len(units)
+		units = append(units, []thread.ID{tid})
+	}
+	return units, nil
+}
@@ -0,0 +1,125 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_ThreadGroups(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info1 := createThread(t, ctx, n)
+	info2 := createThread(t, ctx, n)
+	info3 := createThread(t, ctx, n)
+	addRecord := func(id thread.ID) error {
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = n.CreateRecord(ctx, id, body)
+		return err
+	}
+
+	if _, err := n.GetGroup(ctx, "space"); !errors.Is(err, core.ErrGroupNotFound) {
+		t.Fatalf("expected group to be missing, got %v", err)
+	}
+	if err := n.AddToGroup(ctx, "", info1.ID); !errors.Is(err, core.ErrInvalidGroup) {
+		t.Fatalf("expected empty group name to be rejected, got %v", err)
+	}
+	for _, id := range []thread.ID{info1.ID, info2.ID} {
+		if err := n.AddToGroup(ctx, "space", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	summary, err := n.GetGroup(ctx, "space")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Threads) != 2 || summary.Health != core.SyncHealthUnsynced || summary.Paused {
+		t.Fatalf("unexpected group summary %+v", summary)
+	}
+
+	t.Run("exchange batching", func(t *testing.T) {
+		units, err := n.pullUnits([]thread.ID{info1.ID, info3.ID, info2.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(units) != 2 || len(units[0]) != 2 || units[0][1] != info2.ID || units[1][0] != info3.ID {
+			t.Fatalf("expected group members to be pulled together, got %v", units)
+		}
+	})
+
+	t.Run("quota", func(t *testing.T) {
+		if err := addRecord(info1.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := n.SetGroupQuota("space", 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := addRecord(info2.ID); !errors.Is(err, core.ErrQuotaExceeded) {
+			t.Fatalf("expected group quota to be exceeded, got %v", err)
+		}
+		if err := addRecord(info3.ID); err != nil {
+			t.Fatalf("expected thread outside the group to be unlimited, got %v", err)
+		}
+		if summary, err = n.GetGroup(ctx, "space"); err != nil {
+			t.Fatal(err)
+		}
+		if summary.Quota != 1 || !summary.OverQuota {
+			t.Fatalf("expected group to be over quota, got %+v", summary)
+		}
+		if err := n.SetGroupQuota("space", 0); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		if err := n.PauseGroup(ctx, "space"); err != nil {
+			t.Fatal(err)
+		}
+		if !n.syncs.isPaused(info1.ID) || !n.syncs.isPaused(info2.ID) || n.syncs.isPaused(info3.ID) {
+			t.Fatal("expected only group members to be paused")
+		}
+		if summary, err = n.GetGroup(ctx, "space"); err != nil {
+			t.Fatal(err)
+		}
+		if !summary.Paused {
+			t.Fatal("expected group to be paused")
+		}
+		if err := n.ResumeGroup(ctx, "space"); err != nil {
+			t.Fatal(err)
+		}
+		if n.syncs.isPaused(info1.ID) || n.syncs.isPaused(info2.ID) {
+			t.Fatal("expected group members to be resumed")
+		}
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		p, err := n.GetGroupProgress(ctx, "space")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Threads) != 2 || p.Records != 1 || !p.Done() {
+			t.Fatalf("unexpected group progress %+v", p)
+		}
+	})
+
+	if err := n.RemoveFromGroup(ctx, info1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if summary, err = n.GetGroup(ctx, "space"); err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Threads) != 1 || summary.Threads[0] != info2.ID {
+		t.Fatalf("expected thread to leave the group, got %v", summary.Threads)
+	}
+}
@@ -0,0 +1,148 @@
+package net
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/broadcast"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/lifecycle"
+)
+
+const (
+	// headsBusCapacity is the buffer size of heads listeners.
+	headsBusCapacity = 32
+
+	// headsNotifyTimeout is the duration to wait for a slow heads listener.
+	headsNotifyTimeout = time.Millisecond * 100
+)
+
+func (n *net) SubscribeHeads(ctx context.Context, opts ...core.SubOption) (<-chan core.HeadsUpdate, error) {
+	args := &core.SubOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	filter, err := n.newSubFilter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := make(chan core.HeadsUpdate)
+	listener := n.heads.listen()
+	if err := n.life.Go(lifecycle.StageApp, "heads subscription", func(context.Context) {
+		defer close(channel)
+		defer n.heads.discard(listener)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case i, ok := <-listener.Channel():
+				if !ok {
+					return
+				}
+				u, ok := i.(core.HeadsUpdate)
+				if !ok {
+					log.Warn("listener received a non-heads value")
+					continue
+				}
+				if !filter.Match(u.ThreadID) {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case channel <- u:
+				}
+			}
+		}
+	}); err != nil {
+		n.heads.discard(listener)
+		return nil, err
+	}
+	return channel, nil
+}
+
+// notifyHeads sends the current thread heads to subscribers if the heads edge changed.
+func (n *net) notifyHeads(tid thread.ID) {
+	if !n.heads.active() {
+		return
+	}
+	edge, err := n.store.HeadsEdge(tid)
+	if err != nil {
+		log.Errorf("getting heads edge of thread %s failed: %v", tid, err)
+		return
+	}
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		log.Errorf("getting thread %s failed: %v", tid, err)
+		return
+	}
+	heads := make(map[peer.ID]thread.Head, len(info.Logs))
+	for _, lg := range info.Logs {
+		heads[lg.ID] = lg.Head
+	}
+	n.heads.update(core.HeadsUpdate{
+		ThreadID: tid,
+		Edge:     edge,
+		Heads:    heads,
+	})
+}
+
+// headsTracker notifies subscribers of changed thread heads edges.
+type headsTracker struct {
+	sync.Mutex
+	edges     map[thread.ID]uint64
+	bus       *broadcast.Broadcaster
+	listeners int32
+}
+
+func newHeadsTracker() *headsTracker {
+	return &headsTracker{
+		edges: make(map[thread.ID]uint64),
+		bus:   broadcast.NewBroadcaster(headsBusCapacity),
+	}
+}
+
+func (t *headsTracker) listen() *broadcast.Listener {
+	atomic.AddInt32(&t.listeners, 1)
+	return t.bus.Listen()
+}
+
+func (t *headsTracker) discard(l *broadcast.Listener) {
+	l.Discard()
+	atomic.AddInt32(&t.listeners, -1)
+}
+
+// active returns whether there are any subscribers, so heads aren't collected in vain.
+func (t *headsTracker) active() bool {
+	return atomic.LoadInt32(&t.listeners) > 0
+}
+
+// update notifies subscribers unless the edge was already sent, updates are dropped for slow receivers.
+func (t *headsTracker) update(u core.HeadsUpdate) {
+	t.Lock()
+	defer t.Unlock()
+	if last, ok := t.edges[u.ThreadID]; ok && last == u.Edge {
+		return
+	}
+	t.edges[u.ThreadID] = u.Edge
+	if err := t.bus.SendWithTimeout(u, headsNotifyTimeout); err != nil {
+		log.Debugf("heads notification dropped: %v", err)
+	}
+}
+
+// forget drops the last known edge of a deleted thread.
+func (t *headsTracker) forget(id thread.ID) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.edges, id)
+}
+
+func (t *headsTracker) close() {
+	t.bus.Discard()
+}
@@ -0,0 +1,84 @@
+package net
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// metaHeadHints is the metadata key of the marshaled head hints of a thread.
+const metaHeadHints = "headhints"
+
+// headHints returns the stored head hints of a thread.
+func (n *net) headHints(id thread.ID) (core.HeadHints, error) {
+	val, err := n.store.GetBytes(id, metaHeadHints)
+	if err != nil || val == nil {
+		return core.HeadHints{}, err
+	}
+	var raw map[string]thread.Head
+	if err = json.Unmarshal(*val, &raw); err != nil {
+		return nil, fmt.Errorf("decoding head hints: %w", err)
+	}
+	hints := make(core.HeadHints, len(raw))
+	for k, h := range raw {
+		lid, err := peer.Decode(k)
+		if err != nil {
+			return nil, fmt.Errorf("decoding head hint log: %w", err)
+		}
+		hints[lid] = h
+	}
+	return hints, nil
+}
+
+// putHeadHints merges hints into the stored head hints of a thread, the highest head of each log wins.
+func (n *net) putHeadHints(id thread.ID, hints core.HeadHints) error {
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	current, err := n.headHints(id)
+	if err != nil {
+		return err
+	}
+	raw := make(map[string]thread.Head, len(current)+len(hints))
+	for lid, h := range current {
+		raw[lid.String()] = h
+	}
+	for lid, h := range hints {
+		if h.Counter <= 0 || !h.ID.Defined() {
+			continue
+		}
+		if known, ok := current[lid]; !ok || h.Counter > known.Counter {
+			raw[lid.String()] = h
+		}
+	}
+	val, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(id, metaHeadHints, val)
+}
+
+// pullHinted pulls records from the peer which shared a thread until the hinted heads are reached,
+// since the peer is known to have them. Pulls stop early once a page doesn't advance the sync,
+// the regular pulls from all thread peers fetch the rest.
+func (n *net) pullHinted(id thread.ID, pid peer.ID) {
+	for last := int64(-1); ; {
+		p, err := n.syncProgress(id)
+		if err != nil {
+			log.Debugf("getting sync progress of thread %s failed: %v", id, err)
+			return
+		}
+		if p.Done() || p.Records == last {
+			return
+		}
+		last = p.Records
+		if err = n.updateRecordsFromPeer(n.ctx, pid, id); err != nil {
+			log.Debugf("pulling hinted records of thread %s from %s failed: %v", id, pid, err)
+			return
+		}
+	}
+}
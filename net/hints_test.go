@@ -0,0 +1,87 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_HeadHints(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	for i := 0; i < 5; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := n1.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hints := core.HeadHintsFromInfo(info)
+	if len(hints) != 1 || hints[info.Logs[0].ID].Counter != 5 {
+		t.Fatalf("unexpected head hints %v", hints)
+	}
+
+	// the history is pulled from the inviting peer without waiting for a regular pull
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key), core.WithHeadHints(hints)); err != nil {
+		t.Fatal(err)
+	}
+	progress, err := n2.GetSyncProgress(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.Expected != 5 {
+		t.Fatalf("expected 5 records, got %v", progress)
+	}
+	waitFor(t, func() bool {
+		p, err := n2.GetSyncProgress(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p.Done()
+	})
+	if progress, err = n2.GetSyncProgress(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if progress.Records != 5 || progress.Expected != 5 {
+		t.Fatalf("expected all 5 records, got %v", progress)
+	}
+
+	// stale hints don't lower the expected records, unknown logs raise them
+	lid := info.Logs[0].ID
+	other := createThread(t, ctx, n1)
+	stale := core.HeadHints{
+		lid:              thread.Head{ID: info.Logs[0].Head.ID, Counter: 2},
+		other.Logs[0].ID: thread.Head{ID: info.Logs[0].Head.ID, Counter: 3},
+	}
+	if err = n2.putHeadHints(info.ID, stale); err != nil {
+		t.Fatal(err)
+	}
+	if progress, err = n2.GetSyncProgress(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if progress.Records != 5 || progress.Expected != 8 || progress.Done() {
+		t.Fatalf("expected 5 of 8 records, got %v", progress)
+	}
+}
@@ -0,0 +1,75 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// IdempotencyKeyTTL is the duration record creations are remembered by their idempotency key.
+var IdempotencyKeyTTL = time.Minute * 10
+
+// idempotencyKey identifies a record creation of an identity in a thread.
+type idempotencyKey struct {
+	tid      thread.ID
+	identity string
+	key      string
+}
+
+// createdRecord is a record created with an idempotency key.
+type createdRecord struct {
+	lid     peer.ID
+	rid     cid.Cid
+	expires time.Time
+}
+
+// idempotencyCache remembers recently created records by their idempotency keys.
+type idempotencyCache struct {
+	records   map[idempotencyKey]createdRecord
+	lastSweep time.Time
+	clock     clock.Clock
+	lk        sync.Mutex
+}
+
+func newIdempotencyCache(clk clock.Clock) *idempotencyCache {
+	return &idempotencyCache{
+		records:   make(map[idempotencyKey]createdRecord),
+		lastSweep: clk.Now(),
+		clock:     clk,
+	}
+}
+
+// Get returns the record created with the key, if it's not expired yet.
+func (c *idempotencyCache) Get(k idempotencyKey) (createdRecord, bool) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	r, ok := c.records[k]
+	if !ok || c.clock.Now().After(r.expires) {
+		return createdRecord{}, false
+	}
+	return r, true
+}
+
+// Put remembers the record created with the key, expired keys are dropped along the way.
+func (c *idempotencyCache) Put(k idempotencyKey, lid peer.ID, rid cid.Cid) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	now := c.clock.Now()
+	if now.Sub(c.lastSweep) > IdempotencyKeyTTL {
+		for k, r := range c.records {
+			if now.After(r.expires) {
+				delete(c.records, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.records[k] = createdRecord{
+		lid:     lid,
+		rid:     rid,
+		expires: now.Add(IdempotencyKeyTTL),
+	}
+}
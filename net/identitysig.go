@@ -0,0 +1,91 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// identitySigKey is the extension key of identity signatures, see core.ExtensionIdentitySig.
+var identitySigKey = core.ExtensionKey(core.ExtensionNamespaceThreads, core.ExtensionIdentitySig)
+
+// identitySigPayload returns the payload signed by the identity of a record. It binds the
+// thread, the log and the event of the record, so the signature can't be replayed in other
+// records, along with the other extensions holding what the record authorizes.
+func identitySigPayload(tid thread.ID, lid peer.ID, block cid.Cid, exts core.RecordExtensions) []byte {
+	var buf bytes.Buffer
+	write := func(b []byte) {
+		var l [binary.MaxVarintLen64]byte
+		buf.Write(l[:binary.PutUvarint(l[:], uint64(len(b)))])
+		buf.Write(b)
+	}
+	write([]byte(identitySigKey))
+	write(tid.Bytes())
+	write([]byte(lid))
+	write(block.Bytes())
+	for _, k := range exts.Keys() {
+		if k == identitySigKey {
+			continue
+		}
+		write([]byte(k))
+		write(exts[k])
+	}
+	return buf.Bytes()
+}
+
+// signIdentity returns the extensions of a record along with the signature of its identity.
+func signIdentity(
+	ctx context.Context,
+	signer thread.Identity,
+	tid thread.ID,
+	lid peer.ID,
+	block cid.Cid,
+	exts core.RecordExtensions,
+) (core.RecordExtensions, error) {
+	sig, err := signer.Sign(ctx, identitySigPayload(tid, lid, block, exts))
+	if err != nil {
+		return nil, fmt.Errorf("signing record identity: %w", err)
+	}
+	signed := make(core.RecordExtensions, len(exts)+1)
+	for k, v := range exts {
+		signed[k] = v
+	}
+	signed[identitySigKey] = sig
+	return signed, nil
+}
+
+// verifiedIdentity returns the identity of a record of a log if the record is signed by it.
+// Records without an identity signature only claim their identity, see core.ExtensionIdentitySig.
+func verifiedIdentity(tid thread.ID, lid peer.ID, rec core.Record) (thread.PubKey, bool) {
+	sig, ok := rec.Extensions().Get(core.ExtensionNamespaceThreads, core.ExtensionIdentitySig)
+	if !ok || len(rec.PubKey()) == 0 {
+		return nil, false
+	}
+	identity := &thread.Libp2pPubKey{}
+	if err := identity.UnmarshalBinary(rec.PubKey()); err != nil {
+		return nil, false
+	}
+	valid, err := identity.Verify(identitySigPayload(tid, lid, rec.BlockID(), rec.Extensions()), sig)
+	if err != nil || !valid {
+		return nil, false
+	}
+	return identity, true
+}
+
+// identitySigner returns the signer of records of an identity, which is the given signer or
+// the host identity.
+func (n *net) identitySigner(identity thread.PubKey, signer thread.Identity) (thread.Identity, error) {
+	if signer == nil {
+		signer = thread.NewLibp2pIdentity(n.getPrivKey())
+	}
+	if !signer.GetPublic().Equals(identity) {
+		return nil, core.ErrSignerRequired
+	}
+	return signer, nil
+}
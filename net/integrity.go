@@ -0,0 +1,217 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/lifecycle"
+)
+
+const (
+	// integrityBusCapacity is the buffer size of integrity listeners.
+	integrityBusCapacity = 32
+
+	// integrityNotifyTimeout is the duration to wait for a slow integrity listener.
+	integrityNotifyTimeout = time.Millisecond * 100
+)
+
+// errNotRepairable indicates a corrupted entry which can't be recovered from peers.
+var errNotRepairable = errors.New("entry can't be recovered from peers")
+
+func (n *net) SubscribeIntegrity(ctx context.Context) (<-chan lstore.IntegrityEvent, error) {
+	channel := make(chan lstore.IntegrityEvent)
+	listener := n.integrity.Listen()
+	if err := n.life.Go(lifecycle.StageApp, "integrity subscription", func(context.Context) {
+		defer close(channel)
+		defer listener.Discard()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case i, ok := <-listener.Channel():
+				if !ok {
+					return
+				}
+				ev, ok := i.(lstore.IntegrityEvent)
+				if !ok {
+					log.Warn("listener received a non-integrity value")
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case channel <- ev:
+				}
+			}
+		}
+	}); err != nil {
+		listener.Discard()
+		return nil, err
+	}
+	return channel, nil
+}
+
+// watchIntegrity handles the corrupted entries found by the logstore, if it checks them.
+// Entries found corrupted before, e.g. during a previous run, are handled right away.
+func (n *net) watchIntegrity() {
+	ic, ok := n.store.(lstore.IntegrityChecker)
+	if !ok {
+		return
+	}
+	ic.NotifyIntegrity(n.handleIntegrityEvent)
+	corrupted, err := ic.Corrupted()
+	if err != nil {
+		log.Errorf("getting corrupted logstore entries failed: %v", err)
+		return
+	}
+	for _, ev := range corrupted {
+		n.handleIntegrityEvent(ev)
+	}
+}
+
+// handleIntegrityEvent notifies subscribers of a corrupted entry, which is recovered
+// from thread peers unless the logstore repaired it already.
+func (n *net) handleIntegrityEvent(ev lstore.IntegrityEvent) {
+	// the logstore may be in the middle of an operation
+	go func() {
+		n.notifyIntegrity(ev)
+		if ev.Repaired {
+			return
+		}
+		if err := n.repairEntry(n.ctx, ev); err != nil {
+			log.Errorf("repairing %s of thread %s (log %s) failed: %v", ev.Entry, ev.ThreadID, ev.LogID, err)
+			return
+		}
+		ev.Repaired = true
+		n.notifyIntegrity(ev)
+	}()
+}
+
+func (n *net) notifyIntegrity(ev lstore.IntegrityEvent) {
+	if err := n.integrity.SendWithTimeout(ev, integrityNotifyTimeout); err != nil {
+		log.Debugf("integrity notification dropped: %v", err)
+	}
+}
+
+// repairEntry recovers a corrupted logstore entry. Public keys are restored from log IDs,
+// heads and addresses are recovered from thread peers. Other keys can't be recovered.
+func (n *net) repairEntry(ctx context.Context, ev lstore.IntegrityEvent) error {
+	switch ev.Entry {
+	case lstore.EntryPubKey:
+		pk, err := ev.LogID.ExtractPublicKey()
+		if err != nil {
+			return fmt.Errorf("%w: %v", errNotRepairable, err)
+		}
+		return n.store.AddPubKey(ev.ThreadID, ev.LogID, pk)
+	case lstore.EntryHeads:
+		return n.repairHeads(ctx, ev.ThreadID, ev.LogID)
+	case lstore.EntryAddrs:
+		return n.repairAddrs(ctx, ev.ThreadID, ev.LogID)
+	default:
+		return errNotRepairable
+	}
+}
+
+// repairHeads restores the head of a log to the newest record in the log tail held by peers,
+// which was already processed locally. Newer records are pulled as usual afterwards.
+func (n *net) repairHeads(ctx context.Context, tid thread.ID, lid peer.ID) error {
+	_, peers, err := n.threadOffsets(tid)
+	if err != nil {
+		return err
+	}
+	offsets := map[peer.ID]thread.Head{lid: thread.HeadUndef}
+	res, err := n.pullBudget.Reserve(ctx, len(offsets), MaxPullLimit)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+	req, sk, err := n.server.buildGetRecordsRequest(tid, offsets, res.Limit())
+	if err != nil {
+		return err
+	}
+	for _, pid := range peers {
+		recs, err := n.server.getRecordsFromPeer(ctx, tid, pid, req, sk)
+		if err != nil {
+			log.Debugf("getting records of log %s from %s failed: %v", lid, pid, err)
+			continue
+		}
+		rs, ok := recs[lid]
+		if !ok {
+			continue
+		}
+		for i := len(rs.records) - 1; i >= 0; i-- {
+			rid := rs.records[i].Cid()
+			if known, err := n.isKnown(rid); err != nil {
+				return err
+			} else if !known {
+				continue
+			}
+			counter := thread.CounterUndef
+			if rs.counter != thread.CounterUndef {
+				counter = rs.counter - int64(len(rs.records)-1-i)
+			}
+			if err := n.setRepairedHead(tid, lid, thread.Head{ID: rid, Counter: counter}); err != nil {
+				return err
+			}
+			n.queueGetRecords.Schedule(pid, tid, callPriorityHigh, n.updateRecordsFromPeer)
+			return nil
+		}
+	}
+	return fmt.Errorf("no processed record of log %s found with peers", lid)
+}
+
+func (n *net) setRepairedHead(tid thread.ID, lid peer.ID, head thread.Head) error {
+	ts := n.semaphores.Get(semaThreadUpdate(tid))
+	ts.Acquire()
+	defer ts.Release()
+	if err := n.store.SetHead(tid, lid, head); err != nil {
+		return err
+	}
+	n.notifyHeads(tid)
+	return nil
+}
+
+// repairAddrs replaces the addresses of a log with the ones known to peers.
+// Addresses of logs managed by the host are restored right away.
+func (n *net) repairAddrs(ctx context.Context, tid thread.ID, lid peer.ID) error {
+	_, peers, err := n.threadOffsets(tid)
+	if err != nil {
+		return err
+	}
+	// clearing doesn't read the corrupted entry
+	if err := n.store.ClearAddrs(tid, lid); err != nil {
+		return err
+	}
+
+	var repaired bool
+	if sk, err := n.store.PrivKey(tid, lid); err != nil {
+		return err
+	} else if sk != nil {
+		addr, err := ma.NewMultiaddr("/" + ma.ProtocolWithCode(ma.P_P2P).Name + "/" + n.host.ID().String())
+		if err != nil {
+			return err
+		}
+		if err := n.store.AddAddr(tid, lid, addr, pstore.PermanentAddrTTL); err != nil {
+			return err
+		}
+		repaired = true
+	}
+	for _, pid := range peers {
+		if err := n.updateLogsFromPeer(ctx, pid, tid); err != nil {
+			log.Debugf("getting logs of thread %s from %s failed: %v", tid, pid, err)
+			continue
+		}
+		repaired = true
+	}
+	if !repaired {
+		return fmt.Errorf("no peer of thread %s responded", tid)
+	}
+	return nil
+}
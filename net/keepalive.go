@@ -0,0 +1,257 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/lifecycle"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// KeepAliveCheckInterval is the interval at which peers are checked for due keepalive pings.
+	KeepAliveCheckInterval = time.Second
+
+	// MinKeepAliveInterval is the shortest interval between keepalive pings to a peer.
+	MinKeepAliveInterval = time.Second
+
+	// DefaultKeepAliveTimeout is the time to wait for a ping reply if KeepAlive.Timeout is zero.
+	DefaultKeepAliveTimeout = time.Second * 5
+
+	// DefaultKeepAliveIdle is how long peers are pinged after the last exchange of a thread
+	// if KeepAlive.Idle is zero.
+	DefaultKeepAliveIdle = time.Minute * 5
+)
+
+// KeepAlive configures pings to the peers the host syncs threads with, which keep NAT
+// mappings of their connections open, and detect dead connections between exchanges.
+// A peer is pinged over its open connection while a thread was exchanged with it within
+// Idle, pings are skipped while exchanges keep the connection active.
+type KeepAlive struct {
+	// Interval is the time between pings to a peer. Pings are disabled if zero.
+	Interval time.Duration
+	// Timeout is the time to wait for a ping reply, after which the connection to the peer
+	// is closed so the next call redials it. DefaultKeepAliveTimeout is used if zero.
+	Timeout time.Duration
+	// Idle is how long a peer is pinged after the last exchange of a thread with it.
+	// DefaultKeepAliveIdle is used if zero.
+	Idle time.Duration
+	// Peers overrides the interval of specific peers, e.g. shorter ones for peers behind
+	// aggressive NATs. A negative interval disables pings to the peer.
+	Peers map[peer.ID]time.Duration
+}
+
+// Validate returns an error if the keepalive config is invalid.
+func (k KeepAlive) Validate() error {
+	if k.Interval < 0 || k.Timeout < 0 || k.Idle < 0 {
+		return errors.New("keepalive durations must not be negative")
+	}
+	if k.Interval > 0 && k.Interval < MinKeepAliveInterval {
+		return errors.New("keepalive interval is too short")
+	}
+	for _, d := range k.Peers {
+		if d > 0 && d < MinKeepAliveInterval {
+			return errors.New("keepalive interval is too short")
+		}
+	}
+	return nil
+}
+
+// interval returns the time between pings to a peer, which is zero if pings are disabled.
+func (k KeepAlive) interval(pid peer.ID) time.Duration {
+	if d, ok := k.Peers[pid]; ok {
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+	return k.Interval
+}
+
+func (k KeepAlive) timeout() time.Duration {
+	if k.Timeout == 0 {
+		return DefaultKeepAliveTimeout
+	}
+	return k.Timeout
+}
+
+func (k KeepAlive) idle() time.Duration {
+	if k.Idle == 0 {
+		return DefaultKeepAliveIdle
+	}
+	return k.Idle
+}
+
+// KeepAliveController manages the keepalive pings at runtime, it's implemented by the threads network.
+type KeepAliveController interface {
+	// KeepAlive returns the current keepalive config.
+	KeepAlive() KeepAlive
+	// SetKeepAlive replaces the keepalive config, which applies from the next check of each peer.
+	SetKeepAlive(k KeepAlive) error
+}
+
+var _ KeepAliveController = (*net)(nil)
+
+func (n *net) KeepAlive() KeepAlive {
+	return n.keepalive.get()
+}
+
+func (n *net) SetKeepAlive(k KeepAlive) error {
+	if err := k.Validate(); err != nil {
+		return err
+	}
+	n.keepalive.set(k)
+	return nil
+}
+
+// keepAlivePeer is the keepalive state of a peer the host syncs threads with.
+type keepAlivePeer struct {
+	// synced is the last exchange of a thread with the peer
+	synced time.Time
+	// active is the last successful call to the peer, either an exchange or a ping
+	active   time.Time
+	inflight bool
+}
+
+// keepAliveTracker tracks the peers the host syncs threads with, and schedules their pings.
+type keepAliveTracker struct {
+	lock  sync.Mutex
+	conf  KeepAlive
+	peers map[peer.ID]*keepAlivePeer
+}
+
+func newKeepAliveTracker(conf KeepAlive) *keepAliveTracker {
+	return &keepAliveTracker{conf: conf, peers: make(map[peer.ID]*keepAlivePeer)}
+}
+
+func (t *keepAliveTracker) get() KeepAlive {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.conf
+}
+
+func (t *keepAliveTracker) set(conf KeepAlive) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.conf = conf
+}
+
+// observe an exchange of a thread with a peer. Failed exchanges keep the interest in
+// the peer, but don't count as activity of its connection.
+func (t *keepAliveTracker) observe(pid peer.ID, now time.Time, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	p, ok := t.peers[pid]
+	if !ok {
+		p = &keepAlivePeer{active: now}
+		t.peers[pid] = p
+	}
+	p.synced = now
+	if err == nil {
+		p.active = now
+	}
+}
+
+// due returns the peers to ping, and marks them in flight. Peers without exchanges
+// within the idle duration are forgotten.
+func (t *keepAliveTracker) due(now time.Time) []peer.ID {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var due []peer.ID
+	for pid, p := range t.peers {
+		if now.Sub(p.synced) > t.conf.idle() {
+			delete(t.peers, pid)
+			continue
+		}
+		interval := t.conf.interval(pid)
+		if interval == 0 || p.inflight || now.Sub(p.active) < interval {
+			continue
+		}
+		p.inflight = true
+		due = append(due, pid)
+	}
+	return due
+}
+
+// done records the outcome of a ping.
+func (t *keepAliveTracker) done(pid peer.ID, now time.Time, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	p, ok := t.peers[pid]
+	if !ok {
+		return
+	}
+	p.inflight = false
+	if err == nil {
+		p.active = now
+	} else {
+		// the connection is redialed by the next call, which is the next activity
+		delete(t.peers, pid)
+	}
+}
+
+// startKeepAlive pings the peers the host syncs threads with, see KeepAlive.
+func (n *net) startKeepAlive() {
+	tick := n.clock.NewTicker(KeepAliveCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-tick.C:
+			for _, pid := range n.keepalive.due(n.clock.Now()) {
+				pid := pid
+				if err := n.life.Go(lifecycle.StageSync, "keepalive ping", func(ctx context.Context) {
+					n.pingPeer(ctx, pid)
+				}); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// pingPeer pings a peer, and closes its connection if the ping fails.
+func (n *net) pingPeer(ctx context.Context, pid peer.ID) {
+	err := n.server.ping(ctx, pid, n.keepalive.get().timeout())
+	n.keepalive.done(pid, n.clock.Now(), err)
+	if err == nil || ctx.Err() != nil || errors.Is(err, errNoConn) {
+		return
+	}
+	log.Infof("keepalive ping to %s failed, closing its connection: %v", pid, err)
+	n.server.pool.drop(pid)
+	if _, ok := n.transport.(*libp2pTransport); ok {
+		// the stream of the call is dead along with the underlying connection
+		if err := n.host.Network().ClosePeer(pid); err != nil {
+			log.Debugf("closing connections to %s failed: %v", pid, err)
+		}
+	}
+}
+
+// errNoConn indicates a peer has no open connection to ping.
+var errNoConn = errors.New("no open connection to peer")
+
+// ping checks the open connection to a peer with an edge exchange of no threads, like heartbeat.
+// Peers without an open connection aren't dialed. Pings aren't subject to the pool-wide stream
+// limit, so they don't time out behind bulk traffic.
+func (s *server) ping(ctx context.Context, pid peer.ID, timeout time.Duration) error {
+	conn, ok := s.pool.lookup(pid)
+	if !ok {
+		return errNoConn
+	}
+	cctx, cancel := context.WithTimeout(withStreamPriority(ctx), timeout)
+	defer cancel()
+	_, err := pb.NewServiceClient(conn).ExchangeEdges(cctx, &pb.ExchangeEdgesRequest{
+		Body: &pb.ExchangeEdgesRequest_Body{MaxRecordSize: int64(s.net.maxRecordSize)},
+	})
+	if status.Code(err) == codes.Unimplemented {
+		// the peer is up, but runs a version without edge exchange
+		return nil
+	}
+	return err
+}
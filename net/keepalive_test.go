@@ -0,0 +1,92 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestKeepAliveTracker(t *testing.T) {
+	t.Parallel()
+	p1, p2, p3 := peer.ID("p1"), peer.ID("p2"), peer.ID("p3")
+	tr := newKeepAliveTracker(KeepAlive{
+		Interval: time.Second * 10,
+		Idle:     time.Minute,
+		Peers:    map[peer.ID]time.Duration{p2: time.Second * 2, p3: -1},
+	})
+	now := time.Now()
+	for _, pid := range []peer.ID{p1, p2, p3} {
+		tr.observe(pid, now, nil)
+	}
+
+	if due := tr.due(now.Add(time.Second * 3)); len(due) != 1 || due[0] != p2 {
+		t.Fatalf("expected p2 to be due, got %v", due)
+	}
+	if due := tr.due(now.Add(time.Second * 6)); len(due) != 0 {
+		t.Fatalf("expected pings in flight not to be due again, got %v", due)
+	}
+	tr.done(p2, now.Add(time.Second*6), nil)
+	// exchanges count as activity of the connection
+	tr.observe(p1, now.Add(time.Second*5), nil)
+	if due := tr.due(now.Add(time.Second * 11)); len(due) != 1 || due[0] != p2 {
+		t.Fatalf("expected p2 to be due, got %v", due)
+	}
+	if due := tr.due(now.Add(time.Second * 15)); len(due) != 1 || due[0] != p1 {
+		t.Fatalf("expected p1 to be due, got %v", due)
+	}
+
+	// failed pings and idle peers are forgotten
+	tr.done(p2, now.Add(time.Second*15), fmt.Errorf("timeout"))
+	tr.done(p1, now.Add(time.Second*15), nil)
+	if due := tr.due(now.Add(time.Minute * 2)); len(due) != 0 || len(tr.peers) != 0 {
+		t.Fatalf("expected all peers to be forgotten, got %v", tr.peers)
+	}
+}
+
+func TestNet_KeepAlive(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{
+		PubSub:    true,
+		KeepAlive: KeepAlive{Interval: time.Second, Timeout: time.Second},
+	}).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := n2.server.pool.lookup(n1.Host().ID()); !ok {
+		t.Fatal("expected a connection to the thread peer")
+	}
+
+	// the connection is kept while the peer replies
+	time.Sleep(time.Second * 3)
+	if _, ok := n2.server.pool.lookup(n1.Host().ID()); !ok {
+		t.Fatal("expected the connection to the thread peer to be kept")
+	}
+	if err := n2.SetKeepAlive(KeepAlive{Interval: time.Millisecond}); err == nil {
+		t.Fatal("expected too short intervals to be rejected")
+	}
+
+	// dead connections are closed once a ping fails
+	n1.rpc.Stop()
+	for i := 0; i < 50; i++ {
+		if _, ok := n2.server.pool.lookup(n1.Host().ID()); !ok {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatal("expected the dead connection to be closed")
+}
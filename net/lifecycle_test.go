@@ -0,0 +1,57 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/lifecycle"
+)
+
+func TestNet_CloseLeaks(t *testing.T) {
+	since := lifecycle.Goroutines()
+	n1 := makeNetwork(t).(*net)
+	n2 := makeNetwork(t).(*net)
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	// subscriptions left open by clients are stopped on close
+	if _, err := n1.Subscribe(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.SubscribeHeads(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.SubscribeSyncProgress(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := n1.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if leaks := append(n1.life.Leaks(), n2.life.Leaks()...); len(leaks) != 0 {
+		t.Fatalf("expected all goroutines to stop, got %v", leaks)
+	}
+	lifecycle.CheckLeaks(t, since)
+}
@@ -0,0 +1,110 @@
+package net
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// getRecordEvent returns the record with its event loaded. Light clients sync only record
+// headers, so events missing locally are fetched from peers of the record log along with
+// their headers and bodies, and stored for later access.
+func (n *net) getRecordEvent(ctx context.Context, tid thread.ID, lid peer.ID, rec core.Record) (core.Record, error) {
+	if known, err := n.isKnown(rec.BlockID()); err != nil {
+		return nil, err
+	} else if known {
+		if _, err = rec.GetBlock(ctx, n); err != nil {
+			return nil, err
+		}
+		return rec, nil
+	}
+
+	peers, err := n.recordPeers(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+	for _, pid := range peers {
+		recs, err := n.server.getRecordsByCID(ctx, tid, pid, lid, []cid.Cid{rec.Cid()})
+		if err != nil {
+			log.Debugf("getting event of record %s from %s failed: %v", rec.Cid(), pid, err)
+			continue
+		} else if len(recs) == 0 {
+			continue
+		}
+		full := recs[0]
+		event, err := cbor.EventFromRecord(ctx, n, full)
+		if err != nil {
+			return nil, err
+		}
+		header, err := event.GetHeader(ctx, n, nil)
+		if err != nil {
+			return nil, err
+		}
+		blocks := []format.Node{event, header}
+		// redacted records are received without their bodies
+		if redacted, err := n.isRedacted(tid, rec.Cid()); err != nil {
+			return nil, err
+		} else if !redacted {
+			body, err := event.GetBody(ctx, n, nil)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, body)
+		}
+		if err = n.AddMany(ctx, blocks); err != nil {
+			return nil, err
+		}
+		n.indexRecordBlocks(ctx, tid, full)
+		return full, nil
+	}
+	return nil, fmt.Errorf("event of record %s not found on %d peers", rec.Cid(), len(peers))
+}
+
+// getRecordEventInThread returns the record with its event loaded, like getRecordEvent.
+// The record log is the one whose key signed the record.
+func (n *net) getRecordEventInThread(ctx context.Context, tid thread.ID, rec core.Record) (core.Record, error) {
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	for _, lg := range info.Logs {
+		if lg.PubKey != nil && cbor.VerifyHeader(rec, lg.PubKey) == nil {
+			return n.getRecordEvent(ctx, tid, lg.ID, rec)
+		}
+	}
+	return nil, fmt.Errorf("log of record %s not found", rec.Cid())
+}
+
+// recordPeers returns the peers to fetch thread records from, starting with
+// the peer the context is received from, if any.
+func (n *net) recordPeers(ctx context.Context, tid thread.ID) ([]peer.ID, error) {
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []ma.Multiaddr
+	for _, lg := range info.Logs {
+		addrs = append(addrs, lg.Addrs...)
+	}
+	peers, err := n.uniquePeers(addrs)
+	if err != nil {
+		return nil, err
+	}
+	if pid, err := peerIDFromContext(ctx); err == nil {
+		ordered := []peer.ID{pid}
+		for _, p := range peers {
+			if p != pid {
+				ordered = append(ordered, p)
+			}
+		}
+		return ordered, nil
+	}
+	return peers, nil
+}
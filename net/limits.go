@@ -0,0 +1,35 @@
+package net
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// peerMaxRecordSizeKey is the peerstore metadata key of a peer's advertised maximum record size.
+const peerMaxRecordSizeKey = "threads/maxRecordSize"
+
+// recordSize returns the total size of a record's nodes.
+func recordSize(r *pb.Log_Record) int {
+	return len(r.RecordNode) + len(r.EventNode) + len(r.HeaderNode) + len(r.BodyNode)
+}
+
+// setPeerMaxRecordSize remembers the maximum record size advertised by a peer.
+// Peers which don't advertise a limit are ignored.
+func (n *net) setPeerMaxRecordSize(pid peer.ID, size int64) {
+	if size <= 0 {
+		return
+	}
+	if err := n.host.Peerstore().Put(pid, peerMaxRecordSizeKey, size); err != nil {
+		log.Errorf("storing max record size of %s failed: %v", pid, err)
+	}
+}
+
+// peerAcceptsRecord returns false if the peer advertised a maximum record size below size.
+func (n *net) peerAcceptsRecord(pid peer.ID, size int) bool {
+	v, err := n.host.Peerstore().Get(pid, peerMaxRecordSizeKey)
+	if err != nil {
+		return true
+	}
+	max, ok := v.(int64)
+	return !ok || int64(size) <= max
+}
@@ -0,0 +1,288 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+const (
+	// metadata keys of the thread listing attributes
+	metaThreadTags   = "listing:tags"
+	metaLastActivity = "listing:lastActivity"
+	metaLastSync     = "listing:lastSync"
+
+	tagSeparator = ","
+)
+
+func (n *net) ListThreads(_ context.Context, opts ...core.ListOption) (page core.ThreadsPage, err error) {
+	args := &core.ListOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err = args.Token.Validate(n.getPrivKey()); err != nil {
+		return
+	}
+	var after thread.ID
+	if len(args.PageToken) != 0 {
+		if after, err = thread.Decode(args.PageToken); err != nil {
+			return page, fmt.Errorf("invalid page token: %w", err)
+		}
+	}
+
+	tids, err := n.store.Threads()
+	if err != nil {
+		return
+	}
+	sort.Sort(tids)
+
+	limit := pageLimit(args.Limit)
+	for _, tid := range tids {
+		if after.Defined() && tid <= after {
+			continue
+		}
+		summary, err := n.threadSummary(tid)
+		if err != nil {
+			return page, err
+		}
+		if !matchSummary(summary, args) {
+			continue
+		}
+		if len(page.Threads) == limit {
+			// a matching thread remains, so the page is not the last one
+			page.NextPageToken = page.Threads[len(page.Threads)-1].ID.String()
+			break
+		}
+		page.Threads = append(page.Threads, summary)
+	}
+	return page, nil
+}
+
+func (n *net) GetThreadLogs(_ context.Context, id thread.ID, opts ...core.ListOption) (page core.LogsPage, err error) {
+	args := &core.ListOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err = n.Validate(id, args.Token, true); err != nil {
+		return
+	}
+	var after peer.ID
+	if len(args.PageToken) != 0 {
+		if after, err = peer.Decode(args.PageToken); err != nil {
+			return page, fmt.Errorf("invalid page token: %w", err)
+		}
+	}
+
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return
+	}
+	sort.Slice(info.Logs, func(i, j int) bool {
+		return info.Logs[i].ID < info.Logs[j].ID
+	})
+
+	limit := pageLimit(args.Limit)
+	for _, lg := range info.Logs {
+		if len(after) != 0 && lg.ID <= after {
+			continue
+		}
+		if len(page.Logs) == limit {
+			page.NextPageToken = page.Logs[len(page.Logs)-1].ID.String()
+			break
+		}
+		page.Logs = append(page.Logs, lg)
+	}
+	return page, nil
+}
+
+// threadSummary collects listing attributes of the thread.
+func (n *net) threadSummary(tid thread.ID) (core.ThreadSummary, error) {
+	summary := core.ThreadSummary{ID: tid, Health: core.SyncHealthUnsynced}
+	tags, err := n.threadTags(tid)
+	if err != nil {
+		return summary, err
+	}
+	summary.Tags = tags
+	activity, err := n.store.GetInt64(tid, metaLastActivity)
+	if err != nil {
+		return summary, err
+	} else if activity != nil {
+		summary.LastActivity = time.Unix(0, *activity)
+	}
+	synced, err := n.store.GetInt64(tid, metaLastSync)
+	if err != nil {
+		return summary, err
+	} else if synced != nil {
+		summary.LastSync = time.Unix(0, *synced)
+		policy, err := n.SyncPolicy(tid)
+		if err != nil {
+			return summary, err
+		}
+		if n.clock.Since(summary.LastSync) <= staleAfter(policy) {
+			summary.Health = core.SyncHealthSynced
+		} else {
+			summary.Health = core.SyncHealthStale
+		}
+	}
+	if summary.Usage, summary.Quota, err = n.threadUsage(tid); err != nil {
+		return summary, err
+	}
+	summary.OverQuota = summary.Quota > 0 && summary.Usage > summary.Quota
+	blocks, _, err := n.blocks.usage(tid)
+	if err != nil {
+		return summary, err
+	}
+	summary.StoredBlocks, summary.StoredBytes = blocks.Blocks, blocks.Bytes
+	return summary, nil
+}
+
+// threadTags returns the tags the thread is labeled with.
+func (n *net) threadTags(tid thread.ID) ([]string, error) {
+	tags, err := n.store.GetString(tid, metaThreadTags)
+	if err != nil || tags == nil || len(*tags) == 0 {
+		return nil, err
+	}
+	return strings.Split(*tags, tagSeparator), nil
+}
+
+// setThreadTags stores thread tags, merging them with the existing ones.
+func (n *net) setThreadTags(tid thread.ID, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	current, err := n.store.GetString(tid, metaThreadTags)
+	if err != nil {
+		return err
+	}
+	var merged []string
+	if current != nil && len(*current) != 0 {
+		merged = strings.Split(*current, tagSeparator)
+	}
+	for _, tag := range tags {
+		if len(tag) == 0 || strings.Contains(tag, tagSeparator) {
+			return fmt.Errorf("invalid thread tag %q", tag)
+		}
+		if !containsTag(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	sort.Strings(merged)
+	return n.store.PutString(tid, metaThreadTags, strings.Join(merged, tagSeparator))
+}
+
+// markActivity records the time of the last local or remote change of the thread.
+func (n *net) markActivity(tid thread.ID) {
+	if err := n.store.PutInt64(tid, metaLastActivity, n.clock.Now().UnixNano()); err != nil {
+		log.Errorf("marking activity of thread %s failed: %v", tid, err)
+	}
+}
+
+// markSynced records the time the thread was last known to be in sync with a remote peer.
+func (n *net) markSynced(tid thread.ID) {
+	if err := n.store.PutInt64(tid, metaLastSync, n.clock.Now().UnixNano()); err != nil {
+		log.Errorf("marking sync of thread %s failed: %v", tid, err)
+	}
+}
+
+func matchSummary(s core.ThreadSummary, args *core.ListOptions) bool {
+	if len(args.Tag) != 0 && !containsTag(s.Tags, args.Tag) {
+		return false
+	}
+	if !args.ActiveSince.IsZero() && s.LastActivity.Before(args.ActiveSince) {
+		return false
+	}
+	if !args.ActiveBefore.IsZero() && !s.LastActivity.Before(args.ActiveBefore) {
+		return false
+	}
+	if args.Health != core.SyncHealthAny && s.Health != args.Health {
+		return false
+	}
+	return true
+}
+
+// subFilter matches the threads of a subscription by ID or tag. Tags are looked up as
+// updates arrive, so threads added with a tag after subscribing are matched as well.
+type subFilter struct {
+	ids    map[thread.ID]struct{}
+	tags   []string
+	tagsOf func(thread.ID) ([]string, error)
+}
+
+// newSubFilter validates subscription filters and the token authorizing them.
+func (n *net) newSubFilter(args *core.SubOptions) (*subFilter, error) {
+	f := &subFilter{
+		ids:    make(map[thread.ID]struct{}),
+		tagsOf: n.threadTags,
+	}
+	for _, id := range args.ThreadIDs {
+		if err := id.Validate(); err != nil {
+			return nil, err
+		}
+		if id.Defined() {
+			if _, err := n.Validate(id, args.Token, true); err != nil {
+				return nil, err
+			}
+			f.ids[id] = struct{}{}
+		}
+	}
+	for _, tag := range args.Tags {
+		if len(tag) == 0 || strings.Contains(tag, tagSeparator) {
+			return nil, fmt.Errorf("invalid thread tag %q", tag)
+		}
+		f.tags = append(f.tags, tag)
+	}
+	if len(f.tags) > 0 {
+		if _, err := args.Token.Validate(n.getPrivKey()); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Match returns true if the subscription covers the thread.
+func (f *subFilter) Match(tid thread.ID) bool {
+	if len(f.ids) == 0 && len(f.tags) == 0 {
+		return true
+	}
+	if _, ok := f.ids[tid]; ok {
+		return true
+	}
+	if len(f.tags) == 0 {
+		return false
+	}
+	tags, err := f.tagsOf(tid)
+	if err != nil {
+		log.Errorf("getting tags of thread %s failed: %v", tid, err)
+		return false
+	}
+	for _, tag := range f.tags {
+		if containsTag(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageLimit
+	} else if limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return limit
+}
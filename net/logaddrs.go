@@ -0,0 +1,160 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// metaLogAddrs prefixes the metadata keys of the timestamps of the applied signed log addresses.
+const metaLogAddrs = "logaddrs/"
+
+// errInvalidLogAddrs indicates log addresses which aren't signed by the log key.
+var errInvalidLogAddrs = errors.New("invalid log addresses")
+
+func (n *net) UpdateLogAddrs(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	addrs []ma.Multiaddr,
+	opts ...core.ThreadOption,
+) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	lg, err := n.store.GetLog(id, lid)
+	if err != nil {
+		return err
+	}
+	if lg.PrivKey == nil {
+		return fmt.Errorf("log %s isn't managed by the host", lid)
+	}
+	// peers only reachable at the previous addresses learn about the change too
+	previous, err := n.server.pushPeers(id)
+	if err != nil {
+		return err
+	}
+	if err = n.replaceLogAddrs(id, lid, addrs); err != nil {
+		return err
+	}
+	peers, err := n.server.pushPeers(id)
+	if err != nil {
+		return err
+	}
+	for _, pid := range previous {
+		var known bool
+		for _, p := range peers {
+			if p == pid {
+				known = true
+				break
+			}
+		}
+		if !known {
+			peers = append(peers, pid)
+		}
+	}
+
+	lg.Addrs = addrs
+	var wg sync.WaitGroup
+	for _, pid := range peers {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			if err := n.server.pushLog(ctx, id, lg, pid, nil, nil); err != nil {
+				log.Errorf("error pushing addresses of log %s to %s: %v", lid, pid, err)
+			}
+		}(pid)
+	}
+	wg.Wait()
+	return nil
+}
+
+// logAddrsToProto returns the addresses of a managed log signed with the log key.
+func logAddrsToProto(id thread.ID, lg thread.LogInfo) (*pb.LogAddrs, error) {
+	body := &pb.LogAddrs_Body{
+		ThreadID:  &pb.ProtoThreadID{ID: id},
+		LogID:     &pb.ProtoPeerID{ID: lg.ID},
+		Addrs:     addrsToProto(lg.Addrs),
+		Timestamp: time.Now().UnixNano(),
+	}
+	msg, release, err := pb.MarshalPooled(body)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	sig, err := lg.PrivKey.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.LogAddrs{Body: body, Sig: sig}, nil
+}
+
+// verifyLogAddrs checks that the addresses of a thread log are signed by the log key.
+func verifyLogAddrs(id thread.ID, lid peer.ID, pk crypto.PubKey, la *pb.LogAddrs) error {
+	if la.Body == nil || la.Body.ThreadID == nil || la.Body.LogID == nil {
+		return fmt.Errorf("%w: incomplete message", errInvalidLogAddrs)
+	}
+	if !la.Body.ThreadID.ID.Equals(id) || la.Body.LogID.ID != lid {
+		return fmt.Errorf("%w: addresses of another log %s (thread %s)", errInvalidLogAddrs, la.Body.LogID.ID, la.Body.ThreadID.ID)
+	}
+	msg, release, err := pb.MarshalPooled(la.Body)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if ok, err := pk.Verify(msg, la.Sig); !ok || err != nil {
+		return fmt.Errorf("%w: bad signature", errInvalidLogAddrs)
+	}
+	return nil
+}
+
+// putLogAddrs replaces the addresses of a known log with signed ones, unless newer
+// addresses were applied already. It returns whether the addresses were replaced.
+func (n *net) putLogAddrs(id thread.ID, lid peer.ID, la *pb.LogAddrs) (bool, error) {
+	pk, err := n.store.PubKey(id, lid)
+	if err != nil {
+		return false, err
+	} else if pk == nil {
+		return false, fmt.Errorf("cannot verify addresses of unknown log %s", lid)
+	}
+	if err = verifyLogAddrs(id, lid, pk, la); err != nil {
+		return false, err
+	}
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	key := metaLogAddrs + lid.String()
+	applied, err := n.store.GetInt64(id, key)
+	if err != nil {
+		return false, err
+	}
+	if applied != nil && *applied >= la.Body.Timestamp {
+		return false, nil
+	}
+	if err = n.replaceLogAddrs(id, lid, addrsFromProto(la.Body.Addrs)); err != nil {
+		return false, err
+	}
+	return true, n.store.PutInt64(id, key, la.Body.Timestamp)
+}
+
+func (n *net) replaceLogAddrs(id thread.ID, lid peer.ID, addrs []ma.Multiaddr) error {
+	if err := n.store.ClearAddrs(id, lid); err != nil {
+		return err
+	}
+	return n.store.AddAddrs(id, lid, addrs, pstore.PermanentAddrTTL)
+}
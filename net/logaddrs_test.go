@@ -0,0 +1,103 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+func TestNet_UpdateLogAddrs(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	info2, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var own = info2.Logs[0]
+	for _, lg := range info2.Logs {
+		if lg.PrivKey != nil {
+			own = lg
+		}
+	}
+	if err = n2.server.pushLog(ctx, info.ID, own, n1.Host().ID(), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the host moved networks
+	moved := []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/4006/p2p/" + n2.Host().ID().String())}
+	if err = n2.UpdateLogAddrs(ctx, info.ID, own.ID, moved); err != nil {
+		t.Fatal(err)
+	}
+	lg, err := n1.store.GetLog(info.ID, own.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lg.Addrs) != 1 || !lg.Addrs[0].Equal(moved[0]) {
+		t.Fatalf("expected addresses to be replaced, got %v", lg.Addrs)
+	}
+
+	// unsigned addresses of a known log are ignored
+	unsigned := own
+	unsigned.PrivKey = nil
+	unsigned.Addrs = []ma.Multiaddr{ma.StringCast("/ip4/5.6.7.8/tcp/4006/p2p/" + n2.Host().ID().String())}
+	if err = n2.server.pushLog(ctx, info.ID, unsigned, n1.Host().ID(), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if lg, err = n1.store.GetLog(info.ID, own.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(lg.Addrs) != 1 || !lg.Addrs[0].Equal(moved[0]) {
+		t.Fatalf("expected unsigned addresses to be ignored, got %v", lg.Addrs)
+	}
+
+	// stale and forged addresses aren't applied
+	signed := func(sk crypto.PrivKey, timestamp time.Time) *pb.LogAddrs {
+		body := &pb.LogAddrs_Body{
+			ThreadID:  &pb.ProtoThreadID{ID: info.ID},
+			LogID:     &pb.ProtoPeerID{ID: own.ID},
+			Addrs:     addrsToProto(unsigned.Addrs),
+			Timestamp: timestamp.UnixNano(),
+		}
+		msg, err := body.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := sk.Sign(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pb.LogAddrs{Body: body, Sig: sig}
+	}
+	stale := signed(own.PrivKey, time.Now().Add(-time.Hour))
+	if applied, err := n1.putLogAddrs(info.ID, own.ID, stale); err != nil || applied {
+		t.Fatalf("expected stale addresses to be skipped, got %v, %v", applied, err)
+	}
+	sk, _, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := signed(sk, time.Now())
+	if _, err = n1.putLogAddrs(info.ID, own.ID, forged); !errors.Is(err, errInvalidLogAddrs) {
+		t.Fatalf("expected forged addresses to be rejected, got %v", err)
+	}
+	if applied, err := n1.putLogAddrs(info.ID, own.ID, signed(own.PrivKey, time.Now())); err != nil || !applied {
+		t.Fatalf("expected newer signed addresses to be applied, got %v, %v", applied, err)
+	}
+}
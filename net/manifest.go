@@ -0,0 +1,178 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// Merkle tree node prefixes, which keep leaves from being passed off as inner nodes.
+const (
+	manifestLeaf byte = 0
+	manifestNode byte = 1
+)
+
+func (n *net) IssueManifest(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.Manifest, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.Manifest{}, err
+	}
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return core.Manifest{}, err
+	}
+	sk, err := n.manifestServiceKey(id)
+	if err != nil {
+		return core.Manifest{}, err
+	}
+	sort.Slice(info.Logs, func(i, j int) bool {
+		return info.Logs[i].ID < info.Logs[j].ID
+	})
+
+	var leaves []cid.Cid
+	m := core.Manifest{ThreadID: id, Issuer: n.host.ID(), Time: n.clock.Now()}
+	for _, lg := range info.Logs {
+		if !lg.Head.ID.Defined() {
+			continue
+		}
+		rids, err := n.logRecordIDs(ctx, lg.Head.ID, sk)
+		if err != nil {
+			return core.Manifest{}, fmt.Errorf("reading log %s: %w", lg.ID, err)
+		}
+		m.Heads = append(m.Heads, core.ManifestHead{LogID: lg.ID, Head: lg.Head})
+		leaves = append(leaves, rids...)
+	}
+	m.Records = int64(len(leaves))
+	m.Root = merkleRoot(leaves)
+	if m.Sig, err = n.getPrivKey().Sign(m.Payload()); err != nil {
+		return core.Manifest{}, err
+	}
+	return m, nil
+}
+
+func (n *net) VerifyManifest(ctx context.Context, id thread.ID, m core.Manifest, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+	if err := m.VerifySignature(); err != nil {
+		return err
+	}
+	if !m.ThreadID.Equals(id) {
+		return fmt.Errorf("%w: manifest of thread %s", core.ErrManifestMismatch, m.ThreadID)
+	}
+	sk, err := n.manifestServiceKey(id)
+	if err != nil {
+		return err
+	}
+
+	var leaves []cid.Cid
+	for _, h := range m.Heads {
+		lg, err := n.store.GetLog(id, h.LogID)
+		if errors.Is(err, lstore.ErrLogNotFound) {
+			return fmt.Errorf("%w: log %s not found", core.ErrManifestMismatch, h.LogID)
+		} else if err != nil {
+			return err
+		}
+		// the manifest head has to be in the log, which may have grown since
+		for cursor := lg.Head.ID; !cursor.Equals(h.Head.ID); {
+			if !cursor.Defined() {
+				return fmt.Errorf("%w: head %s not found in log %s", core.ErrManifestMismatch, h.Head.ID, h.LogID)
+			}
+			rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+			if err != nil {
+				return fmt.Errorf("reading log %s: %w", h.LogID, err)
+			}
+			cursor = rec.PrevID()
+		}
+		rids, err := n.logRecordIDs(ctx, h.Head.ID, sk)
+		if err != nil {
+			return fmt.Errorf("reading log %s: %w", h.LogID, err)
+		}
+		leaves = append(leaves, rids...)
+	}
+	if int64(len(leaves)) != m.Records {
+		return fmt.Errorf("%w: %d records attested, %d found", core.ErrManifestMismatch, m.Records, len(leaves))
+	}
+	if !bytes.Equal(merkleRoot(leaves), m.Root) {
+		return fmt.Errorf("%w: record root differs", core.ErrManifestMismatch)
+	}
+	return nil
+}
+
+func (n *net) manifestServiceKey(id thread.ID) (*sym.Key, error) {
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if sk == nil {
+		return nil, fmt.Errorf("a service-key is required to read records")
+	}
+	return sk, nil
+}
+
+// logRecordIDs returns the cids of the log records up to head, oldest first.
+func (n *net) logRecordIDs(ctx context.Context, head cid.Cid, sk *sym.Key) ([]cid.Cid, error) {
+	var rids []cid.Cid
+	for cursor := head; cursor.Defined(); {
+		rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			return nil, err
+		}
+		rids = append(rids, cursor)
+		cursor = rec.PrevID()
+	}
+	for i, j := 0, len(rids)-1; i < j; i, j = i+1, j-1 {
+		rids[i], rids[j] = rids[j], rids[i]
+	}
+	return rids, nil
+}
+
+// merkleRoot returns the root of a binary Merkle tree over the cids, an odd node
+// is promoted to the next level as is. The root of no cids is nil.
+func merkleRoot(leaves []cid.Cid) []byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+	level := make([][]byte, len(leaves))
+	for i, c := range leaves {
+		level[i] = merkleHash(manifestLeaf, c.Bytes())
+	}
+	for len(level) > 1 {
+		next := level[:0]
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+			} else {
+				next = append(next, merkleHash(manifestNode, level[i], level[i+1]))
+			}
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func merkleHash(prefix byte, parts ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{prefix})
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
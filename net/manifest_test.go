@@ -0,0 +1,114 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_Manifest(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	for i := 0; i < 5; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := n.IssueManifest(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Records != 5 || len(m.Heads) != 1 || m.Heads[0].Head.Counter != 5 {
+		t.Fatalf("unexpected manifest %v", m)
+	}
+	if err = m.VerifySignature(); err != nil {
+		t.Fatal(err)
+	}
+	if err = n.VerifyManifest(ctx, info.ID, m); err != nil {
+		t.Fatal(err)
+	}
+
+	// records added since the manifest don't affect it
+	body, err := cbornode.WrapObject(map[string]interface{}{"i": 5}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	if err = n.VerifyManifest(ctx, info.ID, m); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := n.IssueManifest(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Records != 6 || string(latest.Root) == string(m.Root) {
+		t.Fatal("expected the root to change with new records")
+	}
+
+	// tampered manifests are rejected
+	forged := m
+	forged.Records++
+	if err = n.VerifyManifest(ctx, info.ID, forged); err == nil {
+		t.Fatal("expected forged manifest to be rejected")
+	}
+	other := createThread(t, ctx, n)
+	if err = n.VerifyManifest(ctx, other.ID, m); !errors.Is(err, core.ErrManifestMismatch) {
+		t.Fatalf("expected manifest of another thread to mismatch, got %v", err)
+	}
+	// a manifest signed over records the thread doesn't have
+	forged = m
+	forged.Root = latest.Root
+	if forged.Sig, err = n.getPrivKey().Sign(forged.Payload()); err != nil {
+		t.Fatal(err)
+	}
+	if err = n.VerifyManifest(ctx, info.ID, forged); !errors.Is(err, core.ErrManifestMismatch) {
+		t.Fatalf("expected mismatching root, got %v", err)
+	}
+}
+
+func TestMerkleRoot(t *testing.T) {
+	t.Parallel()
+	rids := make([]cid.Cid, 3)
+	for i := range rids {
+		rids[i] = cid.NewCidV1(cid.Raw, mustMultihash(t, []byte{byte(i)}))
+	}
+	if merkleRoot(nil) != nil {
+		t.Fatal("expected no root without records")
+	}
+	leaf := merkleHash(manifestLeaf, rids[0].Bytes())
+	if string(merkleRoot(rids[:1])) != string(leaf) {
+		t.Fatal("expected the root of a single record to be its leaf")
+	}
+	pair := merkleHash(manifestNode, leaf, merkleHash(manifestLeaf, rids[1].Bytes()))
+	want := merkleHash(manifestNode, pair, merkleHash(manifestLeaf, rids[2].Bytes()))
+	if string(merkleRoot(rids)) != string(want) {
+		t.Fatal("unexpected root")
+	}
+	// order matters
+	if string(merkleRoot([]cid.Cid{rids[1], rids[0], rids[2]})) == string(want) {
+		t.Fatal("expected reordered records to change the root")
+	}
+}
+
+func mustMultihash(t *testing.T, data []byte) mh.Multihash {
+	h, err := mh.Sum(data, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
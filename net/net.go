@@ -7,36 +7,42 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ipfs/go-cid"
+	datastore "github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
 	bs "github.com/ipfs/go-ipfs-blockstore"
 	format "github.com/ipfs/go-ipld-format"
-	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pstore "github.com/libp2p/go-libp2p-core/peerstore"
-	gostream "github.com/libp2p/go-libp2p-gostream"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/textileio/go-threads/audit"
 	"github.com/textileio/go-threads/broadcast"
 	"github.com/textileio/go-threads/cbor"
+	"github.com/textileio/go-threads/clock"
 	"github.com/textileio/go-threads/core/app"
 	lstore "github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crash"
 	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/lifecycle"
+	"github.com/textileio/go-threads/logger"
 	pb "github.com/textileio/go-threads/net/pb"
 	"github.com/textileio/go-threads/net/queue"
 	"github.com/textileio/go-threads/net/util"
-	tu "github.com/textileio/go-threads/util"
+	"github.com/textileio/go-threads/synctrace"
 	"google.golang.org/grpc"
 )
 
 var (
-	log = logging.Logger("net")
+	log = logger.Logger("net")
 
 	// MaxPullLimit is the maximum page size for pulling records.
 	MaxPullLimit = 10000
@@ -56,9 +62,45 @@ var (
 	// ExchangeCompressionTimeout is the maximum duration of collecting threads for the exchange edges request.
 	ExchangeCompressionTimeout = PullTimeout / 2
 
+	// MaxInlinedLogsSize is the maximum size of log information inlined into the exchange edges reply.
+	// Threads having larger address books are synced with a separate GetLogs call.
+	MaxInlinedLogsSize = 1 << 12
+
+	// MaxHeadProofsSize is the maximum size of head records proving the heads edge of the exchange edges reply.
+	// Requesters fall back to pulling without a proof from peers sending larger heads, unless proofs are required.
+	MaxHeadProofsSize = 1 << 14
+
+	// GossipPeers is the maximum number of other thread peers piggybacked on the exchange edges reply,
+	// so newly joining peers discover the replica set of a thread without a central bootstrap.
+	// Zero disables gossip.
+	GossipPeers = 8
+
+	// SyncStaleAfter is the duration after the last sync with a remote peer a thread is considered stale.
+	SyncStaleAfter = PullInterval * 6
+
+	// DefaultPageLimit is the default page size of thread and log listings.
+	DefaultPageLimit = 100
+
+	// MaxPageLimit is the maximum page size of thread and log listings.
+	MaxPageLimit = 1000
+
 	// QueuePollInterval is the polling interval for the call queue.
 	QueuePollInterval = time.Millisecond * 500
 
+	// PresenceTTL is the duration after the last heartbeat a thread identity's presence expires.
+	PresenceTTL = time.Second * 30
+
+	// PresenceCheckInterval is the interval between checks for expired presence.
+	PresenceCheckInterval = time.Second
+
+	// DefaultMaxRecordSize is the default maximum size of records created or accepted by a host.
+	// It matches the default maximum message size of libp2p pubsub.
+	DefaultMaxRecordSize = 1 << 20
+
+	// MaxRecordsReplySize is the maximum total size of records returned by a recent records,
+	// records by CID or query records reply. It's kept below the default maximum gRPC message size.
+	MaxRecordsReplySize = 3 << 20
+
 	// EventBusCapacity is the buffer size of local event bus listeners.
 	EventBusCapacity = 1
 
@@ -79,6 +121,7 @@ const (
 
 var (
 	_ util.SemaphoreKey = (*semaThreadUpdate)(nil)
+	_ util.SemaphoreKey = (*semaLogWrite)(nil)
 )
 
 // semaphore protecting thread info updates
@@ -88,6 +131,13 @@ func (t semaThreadUpdate) Key() string {
 	return "tu:" + string(t)
 }
 
+// semaphore serializing records creation in an own log
+type semaLogWrite logKey
+
+func (l semaLogWrite) Key() string {
+	return "lw:" + string(l.tid) + "/" + string(l.lid)
+}
+
 // net is an implementation of app.Net.
 type net struct {
 	format.DAGService
@@ -96,28 +146,197 @@ type net struct {
 
 	store lstore.Logstore
 
-	rpc    *grpc.Server
-	server *server
-	bus    *broadcast.Broadcaster
+	transport Transport
+
+	rpc       *grpc.Server
+	server    *server
+	clock     clock.Clock
+	bus       *broadcast.Broadcaster
+	integrity *broadcast.Broadcaster
+	presence  *presenceTracker
+	heads     *headsTracker
+	pending   *pendingRecords
+	meter     *writeMeter
+	held      *quarantine
+	activity  *activityIndex
+	syncLag   *queue.LagTracker
+	journal   *syncJournal
+	repairs   *recordRepairs
+	backfills *backfillSet
+	// acks are the logs to acknowledge to their authors, nil if record acks are disabled
+	acks   *pendingAcks
+	trace  *synctrace.Recorder
+	audit  *audit.Log
+	events *logger.EventExporter
+
+	annotations datastore.Datastore
+	blocks      *blockIndex
+	bootstrap   *bootstrapBook
+	digests     *digestIndex
+	verifier    *verifyPool
+	progress    *syncProgressTracker
+	power       *powerState
+	keepalive   *keepAliveTracker
+	federation  *federation
+
+	maxRecordSize int
+	lightClient   bool
+	requireProofs bool
+	burstSync     bool
+	indexHeaders  bool
 
 	connectors map[thread.ID]*app.Connector
 	connLock   sync.RWMutex
+	// appResolver connects apps on demand, guarded by connLock
+	appResolver app.AppResolver
 
 	semaphores      *util.SemaphorePool
+	leaseHolder     string
+	fences          map[logKey]logFence
+	idempotency     *idempotencyCache
+	fenceLock       sync.Mutex
+	followLock      sync.Mutex
+	freezeLock      sync.Mutex
+	ackLock         sync.Mutex
+	quotaLock       sync.Mutex
+	redactLock      sync.Mutex
+	approvalLock    sync.Mutex
 	queueGetLogs    queue.CallQueue
 	queueGetRecords queue.CallQueue
-
-	ctx    context.Context
-	cancel context.CancelFunc
+	retries         *retryBudgets
+	syncs           *syncGate
+	pullBudget      *queue.Budget
+	access          *accessControl
+
+	// life shuts the network down in order, background work runs in the sync stage context
+	life *lifecycle.Manager
+	ctx  context.Context
 }
 
 // Config is used to specify thread instance options.
 type Config struct {
-	Debug  bool
+	// Debug enables debug logging of the net and logstore.
+	Debug bool
+	// PubSub enables exchanging records and presence over pubsub, in addition to direct pushes.
 	PubSub bool
+	// PubSubCacheSize is the number of records recently multicast over each thread topic kept
+	// for peers joining late. Recent records are also fetched from peers after joining a topic.
+	// Caching is disabled if zero.
+	PubSubCacheSize int
+	// BlockFetcher is used to resolve event and body blocks missing from the local DAG,
+	// e.g. a bitswap session. If not set, missing blocks are fetched with the DAG only.
+	// Networks built with common.DefaultNetwork fetch them with the ipfs-lite peer.
+	BlockFetcher format.NodeGetter
+	// MaxRecordSize is the maximum size of records created or accepted by the host.
+	// DefaultMaxRecordSize is used if zero. The limit is advertised to peers during edge exchange.
+	MaxRecordSize int
+	// PullMemoryBudget is the maximum total size in bytes of records being pulled at once.
+	// Pulls wait while the budget is exhausted, and their record limits are derived from the
+	// size of records pulled before. Pulls aren't limited if zero.
+	PullMemoryBudget int64
+	// AnnotationStore is the sidecar datastore of local record annotations.
+	// Annotations are kept in memory if not set.
+	AnnotationStore datastore.Datastore
+	// BootstrapStore is the sidecar datastore of known-good peers, which are reconnected to
+	// after long offline periods. Peers are kept in memory if not set.
+	BootstrapStore datastore.Datastore
+	// BlockIndexStore is the sidecar datastore of the per-thread namespaces of the blockstore,
+	// which track the blocks stored for each thread. It's kept in memory if not set, it must
+	// be persisted along with the blockstore for threads to be deleted without walking their logs.
+	BlockIndexStore datastore.Datastore
+	// AuditLog records pushes accepted from remote peers, if set.
+	// The log is owned by the caller and isn't closed along with the network.
+	AuditLog *audit.Log
+	// Transport carries the service between peers, libp2p streams over the host are used if not set.
+	// The host still provides the peer identity when another transport is used.
+	Transport Transport
+	// Federation lists always-on nodes sharing responsibility for threads with the host.
+	// Threads are assigned to live members with consistent hashing, each member pulls only
+	// threads assigned to it, and pushes from other peers are forwarded to the responsible member.
+	// Threads of a member which stops responding to heartbeats are taken over by the others.
+	Federation []peer.AddrInfo
+	// LightClient makes the host sync only record headers, suitable for constrained devices.
+	// Pulled records are checked against the log key and prev links without their events,
+	// which are fetched from log peers on demand, e.g. with GetRecord or for connected apps.
+	LightClient bool
+	// RequireEdgeProofs makes the host pull records after an edge exchange only if the peer proved
+	// its heads edge with signed head records. Peers not sending proofs, e.g. older versions, are
+	// otherwise trusted, while invalid proofs are always rejected.
+	RequireEdgeProofs bool
+	// SyncTrace records sync protocol messages of threads, if set, to reproduce head divergence offline.
+	// The recorder is owned by the caller, which exports the traces.
+	SyncTrace *synctrace.Recorder
+	// SyncEvents exports the exchanges with thread peers as JSON lines, if set, e.g. for ingestion
+	// into ELK. The exporter is owned by the caller.
+	SyncEvents *logger.EventExporter
+	// ConnLimits bounds the connections and concurrent calls to peers, which aren't limited if zero.
+	ConnLimits ConnLimits
+	// RecordAcks makes the host acknowledge the records it receives and marks as seen to their log
+	// authors, and accept acks of the logs it authors, which are aggregated into delivery reports.
+	RecordAcks bool
+	// VerifyWorkers is the number of record signature checks running at once across all threads.
+	// The number of CPUs is used if zero.
+	VerifyWorkers int
+	// BurstSync coalesces periodic pulls into bursts exchanging all threads at once every
+	// BurstPullInterval, instead of spreading them over PullInterval. It suits hosts on battery.
+	BurstSync bool
+	// Access restricts the peers allowed to connect to the network service. It can be replaced
+	// at runtime with SetAccessList, changes aren't persisted.
+	Access AccessList
+	// IndexableHeaders encrypts the event headers of records created by the host with the index
+	// key of their thread instead of the read key, so index key holders, e.g. hosted search services,
+	// can traverse records and index their headers without reading bodies. Peers running versions
+	// without index keys can't read such headers.
+	IndexableHeaders bool
+	// KeepAlive pings the peers the host syncs threads with, so NAT mappings of their connections
+	// stay open and dead connections are closed within seconds. It can be replaced at runtime
+	// with SetKeepAlive, changes aren't persisted.
+	KeepAlive KeepAlive
+	// Clock drives the sync loops, queue scheduling, backoffs and cache expiry of the network,
+	// which follow the wall clock if not set. Tests and simulations pass a virtual clock.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config is invalid.
+func (c Config) Validate() error {
+	if c.MaxRecordSize < 0 {
+		return errors.New("max record size must not be negative")
+	}
+	if c.MaxRecordSize != 0 && c.MaxRecordSize <= core.RecordOverhead {
+		return fmt.Errorf("max record size must be larger than %d bytes", core.RecordOverhead)
+	}
+	if c.PullMemoryBudget < 0 {
+		return errors.New("pull memory budget must not be negative")
+	}
+	if c.VerifyWorkers < 0 {
+		return errors.New("verify workers must not be negative")
+	}
+	if c.PubSubCacheSize < 0 {
+		return errors.New("pubsub cache size must not be negative")
+	}
+	if c.PubSubCacheSize > 0 && !c.PubSub {
+		return errors.New("pubsub cache requires pubsub")
+	}
+	if c.PubSub && c.Transport != nil {
+		return errors.New("pubsub requires the libp2p transport")
+	}
+	if c.LightClient && len(c.Federation) != 0 {
+		return errors.New("light clients can't be federation members")
+	}
+	if err := c.ConnLimits.Validate(); err != nil {
+		return err
+	}
+	if err := c.Access.Validate(); err != nil {
+		return err
+	}
+	if err := c.KeepAlive.Validate(); err != nil {
+		return err
+	}
+	return nil
 }
 
 // NewNetwork creates an instance of net from the given host and thread store.
+// The config is validated before any resources are allocated.
 func NewNetwork(
 	ctx context.Context,
 	h host.Host,
@@ -128,57 +347,202 @@ func NewNetwork(
 	serverOptions []grpc.ServerOption,
 	dialOptions []grpc.DialOption,
 ) (app.Net, error) {
+	if h == nil {
+		return nil, errors.New("network requires a host")
+	}
+	if bstore == nil || ds == nil || ls == nil {
+		return nil, errors.New("network requires a blockstore, DAG service, and logstore")
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid network config: %w", err)
+	}
+
 	var err error
 	if conf.Debug {
-		if err = tu.SetLogLevels(map[string]logging.LogLevel{
-			"net":      logging.LevelDebug,
-			"logstore": logging.LevelDebug,
+		if err = logger.SetLevels(map[string]logger.Level{
+			"net":      logger.LevelDebug,
+			"logstore": logger.LevelDebug,
 		}); err != nil {
 			return nil, err
 		}
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	t := &net{
-		DAGService:      ds,
-		host:            h,
-		bstore:          bstore,
-		store:           ls,
-		rpc:             grpc.NewServer(serverOptions...),
-		bus:             broadcast.NewBroadcaster(EventBusCapacity),
-		connectors:      make(map[thread.ID]*app.Connector),
-		ctx:             ctx,
-		cancel:          cancel,
-		semaphores:      util.NewSemaphorePool(1),
-		queueGetLogs:    queue.NewFFQueue(ctx, QueuePollInterval, PullInterval),
-		queueGetRecords: queue.NewFFQueue(ctx, QueuePollInterval, PullInterval),
+	if conf.BlockFetcher != nil {
+		ds = cbor.NewFallbackDAG(ds, conf.BlockFetcher)
+	}
+	if conf.MaxRecordSize == 0 {
+		conf.MaxRecordSize = DefaultMaxRecordSize
+	}
+	if conf.Transport == nil {
+		conf.Transport = &libp2pTransport{h: h}
+	}
+	if conf.VerifyWorkers == 0 {
+		conf.VerifyWorkers = runtime.NumCPU()
 	}
+	if conf.Clock == nil {
+		conf.Clock = clock.Real
+	}
+	if conf.AnnotationStore == nil {
+		conf.AnnotationStore = syncds.MutexWrap(datastore.NewMapDatastore())
+	}
+	if conf.BootstrapStore == nil {
+		conf.BootstrapStore = syncds.MutexWrap(datastore.NewMapDatastore())
+	}
+	if conf.BlockIndexStore == nil {
+		conf.BlockIndexStore = syncds.MutexWrap(datastore.NewMapDatastore())
+	}
+	bootstrap, err := newBootstrapBook(conf.BootstrapStore, conf.Clock)
+	if err != nil {
+		return nil, fmt.Errorf("loading bootstrap peers: %w", err)
+	}
+
+	// handler panics fail the call from a peer instead of stopping the node
+	serverOptions = append(serverOptions[:len(serverOptions):len(serverOptions)],
+		grpc.ChainUnaryInterceptor(crash.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(crash.StreamServerInterceptor()))
+
+	life := lifecycle.New(ctx)
+	ctx = life.Context(lifecycle.StageSync)
+	t := &net{
+		DAGService:    ds,
+		host:          h,
+		transport:     conf.Transport,
+		bstore:        bstore,
+		store:         ls,
+		rpc:           grpc.NewServer(serverOptions...),
+		clock:         conf.Clock,
+		bus:           broadcast.NewBroadcaster(EventBusCapacity),
+		integrity:     broadcast.NewBroadcaster(integrityBusCapacity),
+		presence:      newPresenceTracker(),
+		heads:         newHeadsTracker(),
+		pending:       newPendingRecords(conf.Clock),
+		meter:         newWriteMeter(conf.Clock),
+		held:          newQuarantine(conf.Clock),
+		activity:      newActivityIndex(),
+		syncLag:       queue.NewLagTracker(),
+		journal:       newSyncJournal(),
+		repairs:       newRecordRepairs(),
+		backfills:     newBackfillSet(),
+		trace:         conf.SyncTrace,
+		audit:         conf.AuditLog,
+		events:        conf.SyncEvents,
+		annotations:   conf.AnnotationStore,
+		blocks:        newBlockIndex(conf.BlockIndexStore),
+		bootstrap:     bootstrap,
+		digests:       newDigestIndex(),
+		verifier:      newVerifyPool(conf.VerifyWorkers),
+		progress:      newSyncProgressTracker(conf.Clock),
+		power:         newPowerState(),
+		keepalive:     newKeepAliveTracker(conf.KeepAlive),
+		maxRecordSize: conf.MaxRecordSize,
+		lightClient:   conf.LightClient,
+		requireProofs: conf.RequireEdgeProofs,
+		burstSync:     conf.BurstSync,
+		indexHeaders:  conf.IndexableHeaders,
+		connectors:    make(map[thread.ID]*app.Connector),
+		life:          life,
+		ctx:           ctx,
+		semaphores:    util.NewSemaphorePool(1),
+		leaseHolder:   newLeaseHolder(),
+		fences:        make(map[logKey]logFence),
+		idempotency:   newIdempotencyCache(conf.Clock),
+		retries:       newRetryBudgets(),
+		pullBudget:    queue.NewBudget(conf.Clock, conf.PullMemoryBudget),
+	}
+	t.queueGetLogs = newPolicyQueue(t, queue.NewFFQueue(ctx, conf.Clock, QueuePollInterval, PullInterval))
+	t.queueGetRecords = newPolicyQueue(t, queue.NewFFQueue(ctx, conf.Clock, QueuePollInterval, PullInterval))
+	if len(conf.Federation) != 0 {
+		t.federation = newFederation(h.ID(), conf.Federation)
+	}
+	if conf.RecordAcks {
+		t.acks = newPendingAcks()
+	}
+	t.access = newAccessControl(conf.Access, t.observedIPs)
 
 	err = t.migrateHeadsIfNeeded(ctx, ls)
 	if err != nil {
 		return nil, err
 	}
+	if err = t.replayWAL(ls); err != nil {
+		return nil, fmt.Errorf("replaying head updates: %w", err)
+	}
+	if t.syncs, err = newSyncGate(ls); err != nil {
+		return nil, fmt.Errorf("loading paused threads: %w", err)
+	}
 
-	t.server, err = newServer(t, conf.PubSub, dialOptions...)
+	t.server, err = newServer(t, conf, dialOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	listener, err := gostream.Listen(h, thread.Protocol)
+	listener, err := t.transport.Listen()
 	if err != nil {
 		return nil, err
 	}
-	go func() {
+	listener = t.access.listen(listener)
+	t.trackShutdown()
+	t.watchIntegrity()
+	if err = t.life.Go(lifecycle.StageAPI, "grpc serve", func(context.Context) {
 		pb.RegisterServiceServer(t.rpc, t.server)
 		if err := t.rpc.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			log.Fatalf("serve error: %v", err)
 		}
-	}()
-
-	go t.startPulling()
+	}); err != nil {
+		return nil, err
+	}
+	loops := map[string]func(){"pulling": t.startPulling, "bootstrap": t.startBootstrap, "keepalive": t.startKeepAlive}
+	if conf.PubSub {
+		loops["presence expiration"] = t.startPresenceExpiration
+	}
+	if t.federation != nil {
+		loops["federation"] = func() { t.startFederation(conf.Federation) }
+	}
+	if t.acks != nil {
+		loops["acking"] = t.startAcking
+	}
+	for name, loop := range loops {
+		loop := loop
+		if err = t.life.Go(lifecycle.StageSync, name, func(context.Context) { loop() }); err != nil {
+			return nil, err
+		}
+	}
 	return t, nil
 }
 
+// trackShutdown registers the shutdown order of the network, see Close.
+func (n *net) trackShutdown() {
+	n.life.TrackFunc(lifecycle.StageAPI, "grpc server", func() error {
+		n.rpc.GracefulStop()
+		return nil
+	})
+	n.life.TrackFunc(lifecycle.StageApp, "subscriptions", func() error {
+		n.bus.Discard()
+		n.integrity.Discard()
+		n.presence.close()
+		n.heads.close()
+		return nil
+	})
+	n.life.TrackFunc(lifecycle.StageSync, "thread updates", func() error {
+		// wait for all thread pulls to finish
+		n.semaphores.Stop()
+		n.releaseLogLeases()
+		if err := n.bootstrap.flush(); err != nil {
+			log.Errorf("persisting bootstrap peers failed: %v", err)
+		}
+		return nil
+	})
+	weakTrack := func(s lifecycle.Stage, name string, c interface{}) {
+		if cl, ok := c.(io.Closer); ok {
+			n.life.Track(s, name, cl)
+		}
+	}
+	// resources of a stage are closed in reverse order
+	weakTrack(lifecycle.StageTransport, "host", n.host)
+	n.life.Track(lifecycle.StageTransport, "peer connections", n.server.pool)
+	weakTrack(lifecycle.StageStorage, "threadstore", n.store)
+	weakTrack(lifecycle.StageStorage, "DAGService", n.DAGService)
+}
+
 func (n *net) countRecords(ctx context.Context, tid thread.ID, rid cid.Cid) (int64, error) {
 	var (
 		cursor        = rid
@@ -253,40 +617,20 @@ func (n *net) migrateHeadsIfNeeded(ctx context.Context, ls lstore.Logstore) (err
 	return nil
 }
 
-func (n *net) Close() (err error) {
-	// Wait for all thread pulls to finish
-	n.semaphores.Stop()
-
-	// Close peer connections and shutdown the server
-	n.server.Lock()
-	defer n.server.Unlock()
-	for _, c := range n.server.conns {
-		if err = c.Close(); err != nil {
-			log.Errorf("error closing connection: %v", err)
-		}
+// Close shuts the network down in the lifecycle order: the network service stops taking calls,
+// subscriptions end, thread pulls finish and background sync stops, then peer connections and
+// the host are closed, and the stores last.
+func (n *net) Close() error {
+	if err := n.life.Close(); err != nil {
+		return fmt.Errorf("failed while closing net: %w", err)
 	}
-	n.rpc.GracefulStop()
-
-	var errs []error
-	weakClose := func(name string, c interface{}) {
-		if cl, ok := c.(io.Closer); ok {
-			if err = cl.Close(); err != nil {
-				errs = append(errs, fmt.Errorf("%s error: %v", name, err))
-			}
-		}
-	}
-	weakClose("DAGService", n.DAGService)
-	weakClose("host", n.host)
-	weakClose("threadstore", n.store)
-	if len(errs) > 0 {
-		return fmt.Errorf("failed while closing net; err(s): %q", errs)
-	}
-
-	n.bus.Discard()
-	n.cancel()
 	return nil
 }
 
+func (n *net) MaxRecordSize() int {
+	return n.maxRecordSize
+}
+
 func (n *net) Host() host.Host {
 	return n.host
 }
@@ -348,12 +692,40 @@ func (n *net) CreateThread(
 	if !info.Key.Defined() {
 		info.Key = thread.NewRandomKey()
 	}
-	if err = n.store.AddThread(info); err != nil {
+	if err = n.initBlocks(id, func() error { return n.store.AddThread(info) }); err != nil {
 		return
 	}
 	if _, err = n.createLog(id, args.LogKey, identity); err != nil {
 		return
 	}
+	if err = n.setThreadTags(id, args.Tags); err != nil {
+		return
+	}
+	if args.Quota != 0 {
+		if err = n.SetThreadQuota(id, args.Quota); err != nil {
+			return
+		}
+	}
+	if !args.SyncPolicy.IsZero() {
+		if err = n.SetSyncPolicy(id, args.SyncPolicy); err != nil {
+			return
+		}
+	}
+	if args.LogApproval {
+		owner := args.Owner
+		if owner == nil {
+			owner = identity
+		}
+		if err = n.requireLogApproval(id, owner); err != nil {
+			return
+		}
+	}
+	if args.SkipLinks {
+		if err = n.store.PutBool(id, metaSkipLinks, true); err != nil {
+			return
+		}
+	}
+	n.markActivity(id)
 	if n.server.ps != nil {
 		if err = n.server.ps.Add(id); err != nil {
 			return
@@ -411,9 +783,11 @@ func (n *net) AddThread(
 	}
 
 	// Even if we already have the thread locally, we might still need to add a new log
-	if err = n.store.AddThread(thread.Info{
-		ID:  id,
-		Key: args.ThreadKey,
+	if err = n.initBlocks(id, func() error {
+		return n.store.AddThread(thread.Info{
+			ID:  id,
+			Key: args.ThreadKey,
+		})
 	}); err != nil {
 		return
 	}
@@ -422,6 +796,43 @@ func (n *net) AddThread(
 			return
 		}
 	}
+	if err = n.setThreadTags(id, args.Tags); err != nil {
+		return
+	}
+	if args.Quota != 0 {
+		if err = n.SetThreadQuota(id, args.Quota); err != nil {
+			return
+		}
+	}
+	if !args.SyncPolicy.IsZero() {
+		if err = n.SetSyncPolicy(id, args.SyncPolicy); err != nil {
+			return
+		}
+	}
+	if len(args.HeadHints) != 0 {
+		if err = n.putHeadHints(id, args.HeadHints); err != nil {
+			return
+		}
+	}
+	if args.Progressive {
+		if err = n.store.PutBool(id, metaProgressive, true); err != nil {
+			return
+		}
+	}
+	if args.LogApproval {
+		owner := args.Owner
+		if owner == nil {
+			owner = identity
+		}
+		if err = n.requireLogApproval(id, owner); err != nil {
+			return
+		}
+	}
+	if args.SkipLinks {
+		if err = n.store.PutBool(id, metaSkipLinks, true); err != nil {
+			return
+		}
+	}
 
 	// Skip if trying to dial ourselves (already have the logs)
 	if !addFromSelf {
@@ -430,7 +841,8 @@ func (n *net) AddThread(
 		}
 
 		if err = n.queueGetLogs.Call(addri.ID, id, func(ctx context.Context, p peer.ID, t thread.ID) error {
-			if err := n.updateLogsFromPeer(ctx, p, t); err != nil {
+			// logs of the peer sharing the thread are trusted
+			if err := n.addLogsFromPeer(ctx, p, t, true); err != nil {
 				return err
 			}
 			if n.server.ps != nil {
@@ -440,6 +852,9 @@ func (n *net) AddThread(
 		}); err != nil {
 			return
 		}
+		if len(args.HeadHints) != 0 {
+			go n.pullHinted(id, addri.ID)
+		}
 	}
 	return n.getThreadWithAddrs(id)
 }
@@ -471,7 +886,7 @@ func (n *net) getThreadWithAddrs(id thread.ID) (info thread.Info, err error) {
 	if err != nil {
 		return
 	}
-	addrs := n.host.Addrs()
+	addrs := n.transport.Addrs()
 	res := make([]ma.Multiaddr, len(addrs))
 	for i := range addrs {
 		res[i] = addrs[i].Encapsulate(peerID).Encapsulate(threadID)
@@ -493,26 +908,129 @@ func (n *net) PullThread(ctx context.Context, id thread.ID, opts ...core.ThreadO
 
 // pullThread for the new records. This method is thread-safe.
 func (n *net) pullThread(ctx context.Context, tid thread.ID) error {
+	if !n.syncs.enter(tid) {
+		return core.ErrSyncPaused
+	}
+	defer n.syncs.leave(tid)
 	offsets, peers, err := n.threadOffsets(tid)
 	if err != nil {
 		return err
 	}
 
 	// Pull from peers
-	recs, err := n.server.getRecords(peers, tid, offsets, MaxPullLimit)
+	res, err := n.pullBudget.Reserve(ctx, len(offsets)*len(peers), MaxPullLimit)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+	recs, err := n.server.getRecords(peers, tid, offsets, res.Limit())
 	if err != nil {
 		return err
 	}
 
 	for lid, rs := range recs {
-		if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter); err != nil {
+		if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter, rs.base); errors.Is(err, core.ErrThreadFrozen) {
+			log.Debugf("skipping records of log %s beyond the frozen heads of thread %s", lid, tid)
+		} else if err != nil {
 			return err
 		}
 	}
+	if n.deferLinkage(tid) {
+		n.scheduleBackfill(tid)
+	}
 
 	return nil
 }
 
+func (n *net) PullThreadFrom(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+
+	addri, err := peer.AddrInfoFromP2pAddr(paddr)
+	if err != nil {
+		return err
+	}
+	if addri.ID == n.host.ID() {
+		return fmt.Errorf("cannot pull thread from self")
+	}
+	// The address is only known to the host's peerstore for the duration of the connection,
+	// it's not added to the thread's address book. Other transports dial known peers only.
+	if _, ok := n.transport.(*libp2pTransport); ok {
+		if err = n.host.Connect(ctx, *addri); err != nil {
+			return err
+		}
+	}
+	return n.pullThreadFrom(ctx, addri.ID, id)
+}
+
+// pullThreadFrom syncs the logs and records of a thread with a single peer.
+// Records are pulled until local heads stop advancing, or the maximum pull
+// duration of the thread is reached. Records which are already known locally
+// are skipped.
+func (n *net) pullThreadFrom(ctx context.Context, pid peer.ID, tid thread.ID) error {
+	if !n.syncs.enter(tid) {
+		return core.ErrSyncPaused
+	}
+	defer n.syncs.leave(tid)
+	policy, err := n.SyncPolicy(tid)
+	if err != nil {
+		return err
+	}
+	if policy.MaxPullDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.MaxPullDuration)
+		defer cancel()
+	}
+	if err := n.updateLogsFromPeer(ctx, pid, tid); err != nil {
+		return fmt.Errorf("getting logs for thread %s from %s failed: %w", tid, pid, err)
+	}
+	offsets, _, err := n.threadOffsets(tid)
+	if err != nil {
+		return fmt.Errorf("getting offsets for thread %s failed: %w", tid, err)
+	}
+	n.reconcileOffsets(ctx, pid, tid, offsets)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := n.pullRecordsFromPeer(ctx, pid, tid, offsets); err != nil {
+			return err
+		}
+
+		next, _, err := n.threadOffsets(tid)
+		if err != nil {
+			return fmt.Errorf("getting offsets for thread %s failed: %w", tid, err)
+		}
+		if !offsetsAdvanced(offsets, next) {
+			break
+		}
+		offsets = next
+	}
+	n.markSynced(tid)
+	return nil
+}
+
+// offsetsAdvanced returns whether or not any log head changed, or a log was added.
+func offsetsAdvanced(prev, next map[peer.ID]thread.Head) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+	for lid, h := range next {
+		if p, ok := prev[lid]; !ok || !p.ID.Equals(h.ID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *net) DeleteThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -553,16 +1071,25 @@ func (n *net) deleteThread(ctx context.Context, id thread.ID) error {
 	if err != nil {
 		return err
 	}
-	for _, lg := range info.Logs { // Walk logs, removing record and event nodes
-		head := lg.Head.ID
-		for head.Defined() {
-			head, err = n.deleteRecord(ctx, head, info.Key.Service())
-			if err != nil {
-				return err
-			}
-		}
+	if err = n.deleteThreadBlocks(ctx, info); err != nil {
+		return err
 	}
 
+	n.heads.forget(id)
+	n.pending.forget(id)
+	n.meter.forget(id)
+	n.held.forget(id)
+	n.activity.forget(id)
+	n.syncLag.Forget(id)
+	n.journal.forget(id)
+	n.trace.Forget(id)
+	n.bootstrap.forgetThread(id)
+	n.digests.forget(id)
+	n.progress.forget(id)
+	n.syncs.resume(id)
+	if err := n.deleteAnnotations(id); err != nil {
+		return err
+	}
 	return n.store.DeleteThread(id) // Delete logstore keys, addresses, heads, and metadata
 }
 
@@ -643,9 +1170,18 @@ func (n *net) AddReplicator(
 	}
 
 	// Send the updated log(s) to peers
-	var addrs []ma.Multiaddr
+	var (
+		addrs   []ma.Multiaddr
+		updated []thread.LogInfo
+	)
 	for _, l := range info.Logs {
 		addrs = append(addrs, l.Addrs...)
+		for _, lg := range managedLogs {
+			if lg.ID == l.ID {
+				updated = append(updated, l)
+				break
+			}
+		}
 	}
 	peers, err := n.uniquePeers(addrs)
 	if err != nil {
@@ -657,7 +1193,7 @@ func (n *net) AddReplicator(
 		wg.Add(1)
 		go func(pid peer.ID) {
 			defer wg.Done()
-			for _, lg := range managedLogs {
+			for _, lg := range updated {
 				if err = n.server.pushLog(ctx, info.ID, lg, pid, nil, nil); err != nil {
 					log.Errorf("error pushing log %s to %s: %v", lg.ID, pid, err)
 				}
@@ -729,6 +1265,20 @@ func (n *net) CreateRecord(
 	if identity == nil {
 		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
 	}
+	if err = args.Extensions.Validate(); err != nil {
+		return
+	}
+	if size := len(body.RawData()) + args.Extensions.Size() + core.RecordOverhead; size > n.maxRecordSize {
+		return nil, &core.RecordTooLargeError{Size: size, MaxSize: n.maxRecordSize}
+	}
+	if _, frozen, err := n.frozenHeight(id, ""); err != nil {
+		return nil, err
+	} else if frozen {
+		return nil, core.ErrThreadFrozen
+	}
+	if err = n.checkQuota(id, int64(len(body.RawData())+core.RecordOverhead)); err != nil {
+		return
+	}
 	con, ok := n.getConnectorProtected(id, args.APIToken)
 	if !ok {
 		return nil, fmt.Errorf("cannot create record: %w", app.ErrThreadInUse)
@@ -738,30 +1288,113 @@ func (n *net) CreateRecord(
 		}
 	}
 
+	var signer thread.Identity
+	if args.Signer != nil {
+		if signer, err = n.identitySigner(identity, args.Signer); err != nil {
+			return
+		}
+	}
+	tr, head, created, err := n.appendRecord(ctx, id, body, identity, args.IdempotencyKey, args.RecordType, args.Extensions, signer)
+	if err != nil {
+		return
+	} else if !created {
+		log.Debugf("record %s was created before with idempotency key %s", tr.Value().Cid(), args.IdempotencyKey)
+		return tr, nil
+	}
+	log.Debugf("created record %s (thread=%s, log=%s)", tr.Value().Cid(), id, tr.LogID())
+	if err = n.publishRecord(ctx, id, tr, head, args.Priority); err != nil {
+		return
+	}
+	return tr, nil
+}
+
+// publishRecord notifies listeners of a record created by the host and pushes it to the thread peers.
+func (n *net) publishRecord(
+	ctx context.Context,
+	id thread.ID,
+	tr core.ThreadRecord,
+	head thread.Head,
+	priority core.RecordPriority,
+) error {
+	n.traceRecords(ctx, synctrace.KindCreate, id, tr.LogID(), []core.Record{tr.Value()}, head.Counter, cid.Undef, nil)
+	n.markActivity(id)
+	n.indexActivity(ctx, id, tr.LogID(), tr.Value())
+	n.addUsage(ctx, id, tr.Value())
+	n.notifyHeads(id)
+	clock, err := n.vectorClock(id)
+	if err != nil {
+		return err
+	}
+	setClock(tr, clock, head.Counter)
+	if err = n.bus.SendWithTimeout(tr, notifyTimeout); err != nil {
+		return err
+	}
+	return n.server.pushRecord(ctx, id, tr.LogID(), tr.Value(), head.Counter, priority)
+}
+
+// appendRecord creates a record with the given body in the identity's own log and moves
+// the log head to it. Records creation is serialized per log and fenced against other
+// processes writing to the same log. If the idempotency key is set and was used before,
+// the record created first is returned instead, with created set to false. Record type
+// is an optional hint of the body type recorded in the event header, extensions are
+// attached to the record itself.
+func (n *net) appendRecord(
+	ctx context.Context,
+	id thread.ID,
+	body format.Node,
+	identity thread.PubKey,
+	ikey string,
+	typ string,
+	exts core.RecordExtensions,
+	signer thread.Identity,
+) (tr core.ThreadRecord, head thread.Head, created bool, err error) {
 	lg, err := n.getOrCreateLog(id, identity)
 	if err != nil {
 		return
 	}
-	r, err := n.newRecord(ctx, id, lg, body, identity)
+	ls := n.semaphores.Get(semaLogWrite{tid: id, lid: lg.ID})
+	ls.Acquire()
+	defer ls.Release()
+
+	ik := idempotencyKey{tid: id, identity: identity.String(), key: ikey}
+	if len(ikey) != 0 {
+		if cr, ok := n.idempotency.Get(ik); ok {
+			r, err := n.getRecord(ctx, id, cr.rid)
+			if err != nil {
+				return nil, head, false, err
+			}
+			return NewRecord(r, id, cr.lid), head, false, nil
+		}
+	}
+
+	// the head may have moved while waiting for other writes
+	if lg, err = n.store.GetLog(id, lg.ID); err != nil {
+		return
+	}
+	epoch, err := n.fenceLog(ctx, id, lg)
+	if err != nil {
+		return
+	}
+	r, err := n.newRecord(ctx, id, lg, body, identity, cbor.EventHeaderConfig{Epoch: epoch, Type: typ}, exts, signer)
 	if err != nil {
 		return
 	}
-	tr = NewRecord(r, id, lg.ID)
-	head := thread.Head{
-		ID:      tr.Value().Cid(),
+	head = thread.Head{
+		ID:      r.Cid(),
 		Counter: lg.Head.Counter + 1,
 	}
-	if err = n.store.SetHead(id, lg.ID, head); err != nil {
+	if err = n.beginWAL(id, lg.ID, lg.Head, []thread.Head{head}); err != nil {
 		return
 	}
-	log.Debugf("created record %s (thread=%s, log=%s)", tr.Value().Cid(), id, lg.ID)
-	if err = n.bus.SendWithTimeout(tr, notifyTimeout); err != nil {
+	if err = n.store.SetHead(id, lg.ID, head); err != nil {
 		return
 	}
-	if err = n.server.pushRecord(ctx, id, lg.ID, tr.Value(), lg.Head.Counter+1); err != nil {
-		return
+	n.commitWAL(id, lg.ID)
+	n.setFenceHead(id, lg.ID, head.ID)
+	if len(ikey) != 0 {
+		n.idempotency.Put(ik, lg.ID, head.ID)
 	}
-	return tr, nil
+	return NewRecord(r, id, lg.ID), head, true, nil
 }
 
 func (n *net) AddRecord(
@@ -796,10 +1429,17 @@ func (n *net) AddRecord(
 	if err = rec.Verify(logpk); err != nil {
 		return err
 	}
-	if err = n.putRecords(ctx, id, lid, []core.Record{rec}, thread.CounterUndef); err != nil {
+	pbrec, err := cbor.RecordToProto(ctx, n, rec)
+	if err != nil {
 		return err
 	}
-	return n.server.pushRecord(ctx, id, lid, rec, thread.CounterUndef)
+	if size := recordSize(pbrec); size > n.maxRecordSize {
+		return &core.RecordTooLargeError{Size: size, MaxSize: n.maxRecordSize}
+	}
+	if err = n.putRecords(ctx, id, lid, []core.Record{rec}, thread.CounterUndef, cid.Undef); err != nil {
+		return err
+	}
+	return n.server.pushRecord(ctx, id, lid, rec, thread.CounterUndef, core.PriorityNormal)
 }
 
 func (n *net) GetRecord(
@@ -815,7 +1455,11 @@ func (n *net) GetRecord(
 	if _, err := n.Validate(id, args.Token, true); err != nil {
 		return nil, err
 	}
-	return n.getRecord(ctx, id, rid)
+	rec, err := n.getRecord(ctx, id, rid)
+	if err != nil || !n.lightClient {
+		return rec, err
+	}
+	return n.getRecordEventInThread(ctx, id, rec)
 }
 
 func (n *net) getRecord(ctx context.Context, id thread.ID, rid cid.Cid) (core.Record, error) {
@@ -829,11 +1473,30 @@ func (n *net) getRecord(ctx context.Context, id thread.ID, rid cid.Cid) (core.Re
 	return cbor.GetRecord(ctx, n, rid, sk)
 }
 
+// getMissingRecord requests a record which couldn't be resolved locally from the peer
+// which pushed its successor. The original error is returned if the context doesn't
+// carry a remote peer or the peer doesn't have the record.
+func (n *net) getMissingRecord(ctx context.Context, id thread.ID, lid peer.ID, rid cid.Cid, cause error) (core.Record, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, cause
+	}
+	recs, err := n.server.getRecordsByCID(ctx, id, pid, lid, []cid.Cid{rid})
+	if err != nil {
+		log.Debugf("getting record %s from %s failed: %v", rid, pid, err)
+		return nil, cause
+	} else if len(recs) == 0 {
+		return nil, cause
+	}
+	return recs[0], nil
+}
+
 // Record implements core.Record. The most basic component of a Log.
 type Record struct {
 	core.Record
 	threadID thread.ID
 	logID    peer.ID
+	clock    core.VectorClock
 }
 
 // NewRecord returns a record with the given values.
@@ -841,6 +1504,11 @@ func NewRecord(r core.Record, id thread.ID, lid peer.ID) core.ThreadRecord {
 	return &Record{Record: r, threadID: id, logID: lid}
 }
 
+// NewRecordWithClock returns a record with the given values and thread clock.
+func NewRecordWithClock(r core.Record, id thread.ID, lid peer.ID, clock core.VectorClock) core.ThreadRecord {
+	return &Record{Record: r, threadID: id, logID: lid, clock: clock}
+}
+
 func (r *Record) Value() core.Record {
 	return r
 }
@@ -853,55 +1521,58 @@ func (r *Record) LogID() peer.ID {
 	return r.logID
 }
 
+func (r *Record) Clock() core.VectorClock {
+	return r.clock
+}
+
 func (n *net) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan core.ThreadRecord, error) {
 	args := &core.SubOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
 
-	filter := make(map[thread.ID]struct{})
-	for _, id := range args.ThreadIDs {
-		if err := id.Validate(); err != nil {
-			return nil, err
-		}
-		if id.Defined() {
-			if _, err := n.Validate(id, args.Token, true); err != nil {
-				return nil, err
-			}
-			filter[id] = struct{}{}
-		}
+	filter, err := n.newSubFilter(args)
+	if err != nil {
+		return nil, err
 	}
 	return n.subscribe(ctx, filter)
 }
 
-func (n *net) subscribe(ctx context.Context, filter map[thread.ID]struct{}) (<-chan core.ThreadRecord, error) {
+func (n *net) subscribe(ctx context.Context, filter *subFilter) (<-chan core.ThreadRecord, error) {
 	channel := make(chan core.ThreadRecord)
-	go func() {
+	listener := n.bus.Listen()
+	if err := n.life.Go(lifecycle.StageApp, "record subscription", func(lctx context.Context) {
 		defer close(channel)
-		listener := n.bus.Listen()
 		defer listener.Discard()
 		for {
 			select {
 			case <-ctx.Done():
 				return
+			case <-lctx.Done():
+				return
 			case i, ok := <-listener.Channel():
 				if !ok {
 					return
 				}
 				if rec, ok := i.(*Record); ok {
-					if len(filter) > 0 {
-						if _, ok := filter[rec.threadID]; ok {
-							channel <- rec
+					if filter.Match(rec.threadID) {
+						select {
+						case <-ctx.Done():
+							return
+						case <-lctx.Done():
+							return
+						case channel <- rec:
 						}
-					} else {
-						channel <- rec
 					}
 				} else {
 					log.Warn("listener received a non-record value")
 				}
 			}
 		}
-	}()
+	}); err != nil {
+		listener.Discard()
+		return nil, err
+	}
 	return channel, nil
 }
 
@@ -935,6 +1606,36 @@ func (n *net) addConnector(id thread.ID, conn *app.Connector) {
 	n.connLock.Unlock()
 }
 
+// SetAppResolver sets the resolver of apps for threads without a connected app.
+func (n *net) SetAppResolver(r app.AppResolver) {
+	n.connLock.Lock()
+	n.appResolver = r
+	n.connLock.Unlock()
+}
+
+// resolveConnector returns the connector tied to the thread, connecting an app
+// with the app resolver if there's none.
+func (n *net) resolveConnector(id thread.ID) (*app.Connector, bool) {
+	if conn, exist := n.getConnector(id); exist {
+		return conn, true
+	}
+	n.connLock.RLock()
+	resolve := n.appResolver
+	n.connLock.RUnlock()
+	if resolve == nil {
+		return nil, false
+	}
+	tags, err := n.threadTags(id)
+	if err != nil {
+		log.Errorf("getting tags of thread %s failed: %v", id, err)
+		return nil, false
+	}
+	if !resolve(id, tags) {
+		return nil, false
+	}
+	return n.getConnector(id)
+}
+
 func (n *net) getConnector(id thread.ID) (*app.Connector, bool) {
 	n.connLock.RLock()
 	defer n.connLock.RUnlock()
@@ -961,21 +1662,29 @@ func (n *net) PutRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core
 	if err := id.Validate(); err != nil {
 		return err
 	}
-	return n.putRecords(ctx, id, lid, []core.Record{rec}, counter)
+	return n.putRecords(ctx, id, lid, []core.Record{rec}, counter, cid.Undef)
 }
 
-// putRecords adds existing records. This method is thread-safe.
-func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs []core.Record, counter int64) error {
-	chain, head, err := n.loadRecords(ctx, tid, lid, recs, counter)
+// putRecords adds existing records. If base is defined, the log was pruned by the
+// peer the records come from and the first record is a checkpoint preceded by base,
+// which the log may continue from if base can't be reached otherwise.
+// This method is thread-safe.
+func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs []core.Record, counter int64, base cid.Cid) (err error) {
+	chain, head, err := n.loadRecords(ctx, tid, lid, recs, counter, base)
 	if err != nil {
-		return fmt.Errorf("loading records failed: %w", err)
+		err = fmt.Errorf("loading records failed: %w", err)
+		n.traceRecords(ctx, synctrace.KindRecords, tid, lid, recs, counter, base, err)
+		return err
 	} else if len(chain) == 0 {
+		n.traceRecords(ctx, synctrace.KindRecords, tid, lid, recs, counter, base, nil)
 		return nil
 	}
 
 	ts := n.semaphores.Get(semaThreadUpdate(tid))
 	ts.Acquire()
 	defer ts.Release()
+	// traced under the semaphore to keep the order of head updates
+	defer func() { n.traceRecords(ctx, synctrace.KindRecords, tid, lid, recs, counter, base, err) }()
 
 	// check the head again, as some other process could change the log concurrently
 	if current, err := n.currentHead(tid, lid); err != nil {
@@ -991,7 +1700,7 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 				break
 			}
 		}
-		if !headReached {
+		if !headReached || len(chain) == 0 {
 			// entire chain already processed
 			return nil
 		}
@@ -999,7 +1708,56 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 
 	// setting new counters for heads
 	updatedCounter := head.Counter
+	if !chain[0].Value().PrevID().Equals(head.ID) {
+		// the log continues from a checkpoint
+		updatedCounter = counter - int64(len(chain))
+		// or from the newest records of a progressively bootstrapped log, the gap is back-filled later
+		if !head.ID.Defined() && !chain[0].Value().PrevID().Equals(base) && n.deferLinkage(tid) {
+			floor := thread.Head{ID: chain[0].Value().Cid(), Counter: updatedCounter + 1}
+			if err := n.setLogFloor(tid, lid, floor); err != nil {
+				return fmt.Errorf("setting log floor failed: %w", err)
+			}
+		}
+	}
+	// frozen threads accept records up to the heads they were frozen at only
+	var rejected bool
+	if limit, frozen, err := n.frozenHeight(tid, lid); err != nil {
+		return fmt.Errorf("getting freeze state failed: %w", err)
+	} else if frozen && updatedCounter+int64(len(chain)) > limit {
+		if updatedCounter >= limit {
+			return core.ErrThreadFrozen
+		}
+		chain = chain[:limit-updatedCounter]
+		rejected = true
+	}
+	// records of identities exceeding their write limits are quarantined with the rest of the chain
+	if admitted, err := n.throttleRecords(ctx, tid, lid, chain, updatedCounter); err != nil {
+		return fmt.Errorf("throttling records failed: %w", err)
+	} else if admitted == 0 {
+		if rejected {
+			return core.ErrThreadFrozen
+		}
+		return nil
+	} else {
+		chain = chain[:admitted]
+	}
 	connector, appConnected := n.getConnector(tid)
+	clock, err := n.vectorClock(tid)
+	if err != nil {
+		return fmt.Errorf("getting thread clock failed: %w", err)
+	}
+	redactions, err := n.redactions(tid)
+	if err != nil {
+		return fmt.Errorf("getting redactions failed: %w", err)
+	}
+	intent := make([]thread.Head, len(chain))
+	for i, record := range chain {
+		intent[i] = thread.Head{ID: record.Value().Cid(), Counter: updatedCounter + int64(i) + 1}
+	}
+	if err := n.beginWAL(tid, lid, head, intent); err != nil {
+		return err
+	}
+	defer n.commitWAL(tid, lid)
 	for _, record := range chain {
 		updatedCounter++
 		if err := n.store.SetHead(
@@ -1011,8 +1769,11 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 			}); err != nil {
 			return fmt.Errorf("setting log head failed: %w", err)
 		}
+		setClock(record, clock, updatedCounter)
 
-		if appConnected {
+		// apps don't handle redactions nor the redacted records
+		_, redaction := redactionTarget(record.Value())
+		if appConnected && !redaction && !bodyRedacted(tid, lid, record.Value(), redactions, recs) {
 			if err := connector.HandleNetRecord(ctx, record); err != nil {
 				// Future improvement notes.
 				// If record handling fails there are two options available:
@@ -1029,6 +1790,28 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 		if err := n.Add(ctx, record.Value()); err != nil {
 			return fmt.Errorf("adding record to the blockstore failed: %w", err)
 		}
+		if _, pending := redactions[record.Value().Cid().String()]; redaction || pending {
+			if err := n.applyRedactions(ctx, tid, lid, record.Value()); err != nil {
+				return fmt.Errorf("applying redactions failed: %w", err)
+			}
+		}
+		if err := n.observeWriteLimits(tid, lid, record.Value()); err != nil {
+			return fmt.Errorf("applying write limits failed: %w", err)
+		}
+		if entry, ok, err := n.observeLogApproval(tid, lid, record.Value()); err != nil {
+			return fmt.Errorf("applying log approval failed: %w", err)
+		} else if ok {
+			// the log is added once the thread semaphore is released
+			if err := n.life.Go(lifecycle.StageSync, "log activation", func(context.Context) {
+				if err := n.activateLog(tid, entry); err != nil {
+					log.Errorf("activating approved log (thread %s) failed: %v", tid, err)
+				}
+			}); err != nil {
+				log.Debugf("skipping activation of approved log (thread %s): %v", tid, err)
+			}
+		}
+		n.indexActivity(ctx, tid, lid, record.Value())
+		n.addUsage(ctx, tid, record.Value())
 
 		// Generally broadcasting should not block for too long, i.e. we have to run it
 		// under the semaphore to ensure consistent order seen by the listeners. Record
@@ -1038,6 +1821,14 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 		}
 	}
 
+	n.held.trim(tid, lid, chain)
+	n.observeSyncLag(ctx, tid, chain[len(chain)-1].Value())
+	n.markActivity(tid)
+	n.notifyHeads(tid)
+	n.scheduleAck(tid, lid)
+	if rejected {
+		return core.ErrThreadFrozen
+	}
 	return nil
 }
 
@@ -1048,6 +1839,7 @@ func (n *net) loadRecords(
 	lid peer.ID,
 	recs []core.Record,
 	counter int64,
+	base cid.Cid,
 ) ([]core.ThreadRecord, thread.Head, error) {
 	if len(recs) == 0 {
 		return nil, thread.HeadUndef, errors.New("cannot load empty record chain")
@@ -1067,7 +1859,11 @@ func (n *net) loadRecords(
 			return nil, thread.HeadUndef, nil
 		}
 	} else if counter <= head.Counter {
-		return nil, head, nil
+		// counters of logs continued from a checkpoint are claimed by peers, so records
+		// behind the head counter are skipped unless they follow the head
+		if !followsHead(head, recs) {
+			return nil, head, nil
+		}
 	}
 
 	var (
@@ -1087,14 +1883,33 @@ func (n *net) loadRecords(
 	if !complete {
 		// bridge the gap between the last provided record and current head
 		var c = chain[len(chain)-1].PrevID()
+		if !head.ID.Defined() && c.Defined() && n.deferLinkage(tid) {
+			// progressively bootstrapped logs start from the newest records
+			if err := checkCheckpoint(head, chain, counter); err != nil {
+				return nil, head, err
+			}
+			c = cid.Undef
+		}
 		for c.Defined() {
 			if c.Equals(head.ID) {
 				break
 			}
+			if c.Equals(base) {
+				// records preceding the checkpoint were pruned by the peer
+				if err := checkCheckpoint(head, chain, counter); err != nil {
+					return nil, head, err
+				}
+				log.Warnf("log %s (thread %s) continues from checkpoint %s, pruned records preceding it are skipped",
+					lid, tid, chain[len(chain)-1].Cid())
+				break
+			}
 
 			r, err := n.getRecord(ctx, tid, c)
 			if err != nil {
-				return nil, head, err
+				// ask the pushing peer for the missing record directly
+				if r, err = n.getMissingRecord(ctx, tid, lid, c, err); err != nil {
+					return nil, head, err
+				}
 			}
 
 			chain = append(chain, r)
@@ -1107,8 +1922,12 @@ func (n *net) loadRecords(
 		return nil, head, nil
 	}
 
+	redactions, err := n.redactions(tid)
+	if err != nil {
+		return nil, head, err
+	}
 	var (
-		connector, appConnected = n.getConnector(tid)
+		connector, appConnected = n.resolveConnector(tid)
 		identity                = &thread.Libp2pPubKey{}
 		tRecords                = make([]core.ThreadRecord, 0, len(chain))
 		readKey                 *sym.Key
@@ -1126,6 +1945,17 @@ func (n *net) loadRecords(
 
 	for i := len(chain) - 1; i >= 0; i-- {
 		var r = chain[i]
+		if n.lightClient && cbor.IsHeader(r) {
+			if !appConnected {
+				// events are fetched on demand
+				tRecords = append(tRecords, NewRecord(r, tid, lid))
+				continue
+			}
+			var err error
+			if r, err = n.getRecordEvent(ctx, tid, lid, r); err != nil {
+				return nil, head, err
+			}
+		}
 		block, err := r.GetBlock(ctx, n)
 		if err != nil {
 			return nil, head, err
@@ -1143,13 +1973,23 @@ func (n *net) loadRecords(
 		if err != nil {
 			return nil, head, err
 		}
+		blocks := []format.Node{event, header}
 
+		// redacted records are kept without their bodies
+		if bodyRedacted(tid, lid, r, redactions, chain) {
+			cbor.RedactRecord(r)
+			if err = n.AddMany(ctx, blocks); err != nil {
+				return nil, head, err
+			}
+			tRecords = append(tRecords, NewRecord(r, tid, lid))
+			continue
+		}
 		body, err := event.GetBody(ctx, n, nil)
 		if err != nil {
 			return nil, head, err
 		}
 
-		if validate {
+		if _, redaction := redactionTarget(r); validate && !redaction {
 			dbody, err := event.GetBody(ctx, n, readKey)
 			if err != nil {
 				return nil, head, err
@@ -1165,7 +2005,7 @@ func (n *net) loadRecords(
 		}
 
 		// store internal blocks locally, record envelope will be added by the caller after successful processing
-		if err = n.AddMany(ctx, []format.Node{event, header, body}); err != nil {
+		if err = n.AddMany(ctx, append(blocks, body)); err != nil {
 			return nil, head, err
 		}
 
@@ -1175,6 +2015,41 @@ func (n *net) loadRecords(
 	return tRecords, head, nil
 }
 
+// followsHead returns whether a record chain continues from the head, i.e. the head is
+// one of the records or precedes the first one.
+func followsHead(head thread.Head, recs []core.Record) bool {
+	if !head.ID.Defined() {
+		return false
+	}
+	if recs[0].PrevID().Equals(head.ID) {
+		return true
+	}
+	for _, r := range recs[:len(recs)-1] {
+		if r.Cid().Equals(head.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCheckpoint ensures a log could safely continue from the checkpoint, i.e. the last
+// record of the chain in reverse order, skipping records between the head and the checkpoint.
+func checkCheckpoint(head thread.Head, chain []core.Record, counter int64) error {
+	if counter == thread.CounterUndef {
+		return fmt.Errorf("checkpoint %s has no counter", chain[len(chain)-1].Cid())
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		if !chain[i].PrevID().Equals(chain[i+1].Cid()) {
+			return fmt.Errorf("records are not linked to checkpoint %s", chain[len(chain)-1].Cid())
+		}
+	}
+	if cc := counter - int64(len(chain)) + 1; cc <= head.Counter {
+		return fmt.Errorf("checkpoint %s with counter %d is not ahead of head %s with counter %d",
+			chain[len(chain)-1].Cid(), cc, head.ID, head.Counter)
+	}
+	return nil
+}
+
 func (n *net) isKnown(rec cid.Cid) (bool, error) {
 	return n.bstore.Has(rec)
 }
@@ -1202,6 +2077,9 @@ func (n *net) newRecord(
 	lg thread.LogInfo,
 	body format.Node,
 	pk thread.PubKey,
+	header cbor.EventHeaderConfig,
+	exts core.RecordExtensions,
+	signer thread.Identity,
 ) (core.Record, error) {
 	if lg.PrivKey == nil {
 		return nil, fmt.Errorf("a private-key is required to create records")
@@ -1220,19 +2098,43 @@ func (n *net) newRecord(
 	if rk == nil {
 		return nil, fmt.Errorf("a read-key is required to create records")
 	}
-	event, err := cbor.CreateEvent(ctx, n, body, rk)
+	if n.indexHeaders && header.IndexKey == nil {
+		header.IndexKey = thread.DeriveIndexKey(rk)
+	}
+	event, err := cbor.CreateEventWithHeader(ctx, n, body, rk, header)
+	if err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		if exts, err = signIdentity(ctx, signer, id, lg.ID, event.Cid(), exts); err != nil {
+			return nil, err
+		}
+	}
+	skips, err := n.skipLinks(ctx, id, lg.Head, sk)
 	if err != nil {
 		return nil, err
 	}
 	return cbor.CreateRecord(ctx, n, cbor.CreateRecordConfig{
 		Block:      event,
 		Prev:       lg.Head.ID,
+		Skips:      skips,
 		Key:        lg.PrivKey,
 		PubKey:     pk,
 		ServiceKey: sk,
+		Extensions: exts,
 	})
 }
 
+// headerKey returns the key reading the event headers of a thread, which is the read key,
+// or the index key if the host holds only the latter. It's nil if neither is available.
+func (n *net) headerKey(id thread.ID) (*sym.Key, error) {
+	rk, err := n.store.ReadKey(id)
+	if err != nil || rk != nil {
+		return rk, err
+	}
+	return n.store.IndexKey(id)
+}
+
 // getPrivKey returns the host's private key.
 func (n *net) getPrivKey() crypto.PrivKey {
 	return n.host.Peerstore().PrivKey(n.host.ID())
@@ -1242,6 +2144,9 @@ func (n *net) getPrivKey() crypto.PrivKey {
 // offset but not farther than limit.
 // It is possible to reach limit before offset, meaning that the caller
 // will be responsible for the remaining traversal.
+// If the log was pruned before reaching the offset, the pruned predecessor of the
+// first returned record is returned as the log base, the first record being a
+// checkpoint the caller has to continue the log from.
 func (n *net) getLocalRecords(
 	ctx context.Context,
 	id thread.ID,
@@ -1249,31 +2154,39 @@ func (n *net) getLocalRecords(
 	offset cid.Cid,
 	limit int,
 	counter int64,
-) ([]core.Record, error) {
+) ([]core.Record, cid.Cid, error) {
 	lg, err := n.store.GetLog(id, lid)
 	if err != nil {
-		return nil, err
+		return nil, cid.Undef, err
 	}
 	// reverting to old logic if the new one is not supported
 	if counter == thread.CounterUndef && offset != cid.Undef {
 		if offset.Defined() {
 			// ensure that we know about requested offset
 			if knownRecord, err := n.isKnown(offset); err != nil {
-				return nil, err
+				return nil, cid.Undef, err
 			} else if !knownRecord {
-				return nil, nil
+				return nil, cid.Undef, nil
 			}
 		}
 		// if we have less or equal records
 	} else if lg.Head.Counter <= counter {
-		return []core.Record{}, nil
+		// counters are claimed by peers, so records after a known offset are served anyway
+		if !offset.Defined() || offset.Equals(lg.Head.ID) {
+			return []core.Record{}, cid.Undef, nil
+		}
+		if knownRecord, err := n.isKnown(offset); err != nil {
+			return nil, cid.Undef, err
+		} else if !knownRecord {
+			return []core.Record{}, cid.Undef, nil
+		}
 	}
 	sk, err := n.store.ServiceKey(id)
 	if err != nil {
-		return nil, err
+		return nil, cid.Undef, err
 	}
 	if sk == nil {
-		return nil, fmt.Errorf("a service-key is required to get records")
+		return nil, cid.Undef, fmt.Errorf("a service-key is required to get records")
 	}
 
 	var (
@@ -1281,20 +2194,33 @@ func (n *net) getLocalRecords(
 		recs   []core.Record
 	)
 
-	for len(recs) < limit {
+	for {
 		if !cursor.Defined() || cursor.String() == offset.String() {
 			break
 		}
-		r, err := cbor.GetRecord(ctx, n, cursor, sk) // Important invariant: heads are always in blockstore
+		if len(recs) > 0 {
+			// Important invariant: heads are always in blockstore, so are processed records
+			// unless the log was compacted or restored from a partial backup.
+			if known, err := n.isKnown(cursor); err != nil {
+				return recs, cid.Undef, err
+			} else if !known {
+				log.Debugf("log %s (thread %s) is pruned before record %s", lid, id, recs[0].Cid())
+				return recs, cursor, nil
+			}
+		}
+		if len(recs) >= limit {
+			break
+		}
+		r, err := cbor.GetRecord(ctx, n, cursor, sk)
 		if err != nil {
 			// return records fetched so far
-			return recs, err
+			return recs, cid.Undef, err
 		}
 		recs = append([]core.Record{r}, recs...)
 		cursor = r.PrevID()
 	}
 
-	return recs, nil
+	return recs, cid.Undef, nil
 }
 
 // deleteRecord remove a record from the dag service.
@@ -1319,7 +2245,7 @@ func (n *net) deleteRecord(ctx context.Context, rid cid.Cid, sk *sym.Key) (prev
 // startPulling periodically pulls on all threads.
 func (n *net) startPulling() {
 	select {
-	case <-time.After(PullStartAfter):
+	case <-n.clock.After(PullStartAfter):
 	case <-n.ctx.Done():
 		return
 	}
@@ -1329,21 +2255,50 @@ func (n *net) startPulling() {
 	var interval = InitialPullInterval
 
 	// group threads by peers and exchange edges efficiently
-	var compressor = queue.NewThreadPacker(n.ctx, MaxThreadsExchanged, ExchangeCompressionTimeout)
-	go n.startExchange(compressor)
+	var compressor = queue.NewThreadPacker(n.ctx, n.clock, MaxThreadsExchanged, ExchangeCompressionTimeout)
+	if err := n.life.Go(lifecycle.StageSync, "exchange", func(context.Context) { n.startExchange(compressor) }); err != nil {
+		return
+	}
 
 PullCycle:
 	for {
+		interrupted, err := n.power.wait(n.ctx)
+		if err != nil {
+			return
+		}
+
 		ts, err := n.store.Threads()
 		if err != nil {
 			log.Errorf("error listing threads: %s", err)
 			return
 		}
 
+		if interrupted || n.burstSync {
+			// threads are pulled right away, those active recently first after a suspension
+			if interrupted {
+				ts = n.catchUpPlan(ts)
+			}
+			if err = n.pullBurst(compressor, ts); err != nil {
+				log.Errorf("error scheduling pull burst: %s", err)
+				return
+			}
+			if n.burstSync {
+				interval = BurstPullInterval
+			} else {
+				interval = PullInterval
+			}
+			select {
+			case <-n.clock.After(interval):
+				continue PullCycle
+			case <-n.ctx.Done():
+				return
+			}
+		}
+
 		if len(ts) == 0 {
 			// if there are no threads served, just wait and retry
 			select {
-			case <-time.After(interval):
+			case <-n.clock.After(interval):
 				interval = PullInterval
 				continue PullCycle
 			case <-n.ctx.Done():
@@ -1351,27 +2306,34 @@ PullCycle:
 			}
 		}
 
+		units, err := n.pullUnits(ts)
+		if err != nil {
+			log.Errorf("error grouping threads: %s", err)
+			return
+		}
+
 		var (
-			period = interval / time.Duration(len(ts))
-			ticker = time.NewTicker(period)
+			period = interval / time.Duration(len(units))
+			ticker = n.clock.NewTicker(period)
 			idx    = 0
 		)
 
 		for {
 			select {
 			case <-ticker.C:
-				var tid = ts[idx]
-				if _, peers, err := n.threadOffsets(tid); err != nil {
-					log.Errorf("error getting thread info %s: %s", tid, err)
-					return
-				} else {
-					for _, pid := range peers {
-						compressor.Add(pid, tid)
+				if n.power.suspended() {
+					ticker.Stop()
+					continue PullCycle
+				}
+				for _, tid := range units[idx] {
+					if err = n.schedulePull(compressor, tid); err != nil {
+						log.Errorf("error getting thread info %s: %s", tid, err)
+						return
 					}
 				}
 
 				idx++
-				if idx >= len(ts) {
+				if idx >= len(units) {
 					ticker.Stop()
 					interval = PullInterval
 					continue PullCycle
@@ -1385,6 +2347,30 @@ PullCycle:
 	}
 }
 
+// schedulePull adds a thread to the next edge exchanges with its peers.
+func (n *net) schedulePull(compressor queue.ThreadPacker, tid thread.ID) error {
+	if !n.isResponsible(tid) {
+		// pulled by another federation member
+		return nil
+	}
+	if n.syncs.isPaused(tid) {
+		return nil
+	}
+	if n.tolerateStaleness(tid) {
+		log.Debugf("skip pulling thread %s: synced within its staleness tolerance", tid)
+		return nil
+	}
+	n.retries.reset(tid)
+	_, peers, err := n.threadOffsets(tid)
+	if err != nil {
+		return err
+	}
+	for _, pid := range peers {
+		compressor.Add(pid, tid)
+	}
+	return nil
+}
+
 func (n *net) startExchange(compressor queue.ThreadPacker) {
 	for pack := range compressor.Run() {
 		go func(p queue.ThreadPack) {
@@ -1424,6 +2410,7 @@ func (n *net) createLog(id thread.ID, key crypto.Key, identity thread.PubKey) (i
 	if err = n.store.AddLog(id, info); err != nil {
 		return info, err
 	}
+	n.traceLog(id, info)
 	lidb, err := info.ID.MarshalBinary()
 	if err != nil {
 		return info, err
@@ -1465,7 +2452,8 @@ func (n *net) getOrCreateLog(id thread.ID, identity thread.PubKey) (info thread.
 }
 
 // createExternalLogsIfNotExist creates an external logs if doesn't exists. The created
-// logs will have cid.Undef as the current head. Is thread-safe.
+// logs will have cid.Undef as the current head, records which arrived before a created
+// log are applied afterwards. Is thread-safe.
 func (n *net) createExternalLogsIfNotExist(
 	tid thread.ID,
 	lis []thread.LogInfo,
@@ -1482,6 +2470,11 @@ func (n *net) createExternalLogsIfNotExist(
 			if err = n.Store().AddLog(tid, li); err != nil {
 				return err
 			}
+			n.traceLog(tid, li)
+			if n.pending.has(tid, li.ID) {
+				// records are applied once the thread semaphore is released
+				go n.applyPendingRecords(tid, li.ID)
+			}
 		} else {
 			// update log addresses
 			if err = n.Store().AddAddrs(tid, li.ID, li.Addrs, pstore.PermanentAddrTTL); err != nil {
@@ -1553,7 +2546,23 @@ func (n *net) updateRecordsFromPeer(ctx context.Context, pid peer.ID, tid thread
 	if err != nil {
 		return fmt.Errorf("getting offsets for thread %s failed: %w", tid, err)
 	}
-	req, sk, err := n.server.buildGetRecordsRequest(tid, offsets, MaxPullLimit)
+	n.reconcileOffsets(ctx, pid, tid, offsets)
+	if err := n.pullRecordsFromPeer(ctx, pid, tid, offsets); err != nil {
+		return err
+	}
+	n.markSynced(tid)
+	return nil
+}
+
+// pullRecordsFromPeer fetches a page of records following the offsets from the peer and adds
+// them in the local peer store. Page size is limited by the pull memory budget.
+func (n *net) pullRecordsFromPeer(ctx context.Context, pid peer.ID, tid thread.ID, offsets map[peer.ID]thread.Head) error {
+	res, err := n.pullBudget.Reserve(ctx, len(offsets), MaxPullLimit)
+	if err != nil {
+		return err
+	}
+	defer res.Release()
+	req, sk, err := n.server.buildGetRecordsRequest(tid, offsets, res.Limit())
 	if err != nil {
 		return fmt.Errorf("building GetRecords request for thread %s failed: %w", tid, err)
 	}
@@ -1562,7 +2571,9 @@ func (n *net) updateRecordsFromPeer(ctx context.Context, pid peer.ID, tid thread
 		return fmt.Errorf("getting records for thread %s from %s failed: %w", tid, pid, err)
 	}
 	for lid, rs := range recs {
-		if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter); err != nil {
+		if err = n.putRecords(ctx, tid, lid, rs.records, rs.counter, rs.base); errors.Is(err, core.ErrThreadFrozen) {
+			log.Debugf("skipping records of log %s beyond the frozen heads of thread %s", lid, tid)
+		} else if err != nil {
 			return fmt.Errorf("putting records from log %s (thread %s) failed: %w", lid, tid, err)
 		}
 	}
@@ -1571,11 +2582,29 @@ func (n *net) updateRecordsFromPeer(ctx context.Context, pid peer.ID, tid thread
 
 // updateLogsFromPeer gets new logs information from the peer and adds it in the local peer store.
 func (n *net) updateLogsFromPeer(ctx context.Context, pid peer.ID, tid thread.ID) error {
+	return n.addLogsFromPeer(ctx, pid, tid, false)
+}
+
+// addLogsFromPeer adds the logs of a thread known to a peer. Unless they're trusted, logs
+// which weren't approved yet are held if the thread requires log approval.
+func (n *net) addLogsFromPeer(ctx context.Context, pid peer.ID, tid thread.ID, trusted bool) error {
 	lgs, err := n.server.getLogs(ctx, tid, pid)
 	if err != nil {
 		return err
 	}
-	return n.createExternalLogsIfNotExist(tid, lgs)
+	active := lgs[:0]
+	for _, lg := range lgs {
+		if !trusted {
+			if pending, err := n.holdLog(tid, pid, lg); err != nil {
+				return err
+			} else if pending {
+				continue
+			}
+		}
+		n.observeHeight(tid, lg.ID, lg.Head.Counter)
+		active = append(active, lg)
+	}
+	return n.createExternalLogsIfNotExist(tid, active)
 }
 
 // returns offsets and involved peers for all known thread's logs.
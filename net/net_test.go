@@ -1,20 +1,30 @@
 package net
 
 import (
+	"bytes"
 	"context"
 	rand "crypto/rand"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gogo/status"
 	bserv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	syncds "github.com/ipfs/go-datastore/sync"
 	bstore "github.com/ipfs/go-ipfs-blockstore"
 	offline "github.com/ipfs/go-ipfs-exchange-offline"
 	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
 	dag "github.com/ipfs/go-merkledag"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
 	ma "github.com/multiformats/go-multiaddr"
 	mh "github.com/multiformats/go-multihash"
@@ -23,7 +33,11 @@ import (
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	tstore "github.com/textileio/go-threads/logstore/lstoremem"
+	pb "github.com/textileio/go-threads/net/pb"
+	"github.com/textileio/go-threads/synctrace"
 	"github.com/textileio/go-threads/util"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
 )
 
 func TestNet_GetToken(t *testing.T) {
@@ -109,6 +123,46 @@ func TestNet_CreateRecord(t *testing.T) {
 	})
 }
 
+func TestNet_CreateRecordIdempotency(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r1, err := n.CreateRecord(ctx, info.ID, body, core.WithIdempotencyKey("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := n.CreateRecord(ctx, info.ID, body, core.WithIdempotencyKey("k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r2.Value().Cid().Equals(r1.Value().Cid()) || r2.LogID() != r1.LogID() {
+		t.Fatal("expected retry to return the original record")
+	}
+	if _, err := n.CreateRecord(ctx, info.ID, body, core.WithIdempotencyKey("k2")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	lg, err := n.store.GetLog(info.ID, r1.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lg.Head.Counter != 3 {
+		t.Fatalf("expected 3 records in the log, got %d", lg.Head.Counter)
+	}
+}
+
 func TestNet_AddThread(t *testing.T) {
 	t.Parallel()
 	n1 := makeNetwork(t)
@@ -409,55 +463,2392 @@ func TestNet_DeleteThread(t *testing.T) {
 	}
 }
 
-func TestClose(t *testing.T) {
+func TestNet_ExchangeEdgesInlinedLogs(t *testing.T) {
 	t.Parallel()
-	n := makeNetwork(t)
-	defer n.Close()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
 
-	t.Run("test close", func(t *testing.T) {
-		if err := n.Close(); err != nil {
-			t.Fatal(err)
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := n1.server.inlineLogs(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) == 0 {
+		t.Fatal("expected logs to be inlined")
+	}
+	if _, err := n2.server.applyInlinedLogs(info.ID, n1.Host().ID(), data); err != nil {
+		t.Fatal(err)
+	}
+	// the address edge of the memory store is updated asynchronously, so logs are compared
+	expected, err := n1.store.GetThread(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := n2.store.GetThread(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logAddrs := func(info thread.Info) map[peer.ID]string {
+		addrs := make(map[peer.ID]string, len(info.Logs))
+		for _, lg := range info.Logs {
+			strs := make([]string, len(lg.Addrs))
+			for i, a := range lg.Addrs {
+				strs[i] = a.String()
+			}
+			sort.Strings(strs)
+			addrs[lg.ID] = strings.Join(strs, ",")
 		}
-	})
+		return addrs
+	}
+	if !reflect.DeepEqual(logAddrs(applied), logAddrs(expected)) {
+		t.Fatalf("logs do not match after applying inlined logs")
+	}
+
+	// inlined logs must not be readable without the service key
+	other := thread.Info{ID: thread.NewIDV1(thread.Raw, 32), Key: thread.NewRandomKey()}
+	if err := n2.store.AddThread(other); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.server.applyInlinedLogs(other.ID, n1.Host().ID(), data); err == nil {
+		t.Fatal("expected decryption with a wrong service key to fail")
+	}
 }
 
-func makeNetwork(t *testing.T) core.Net {
-	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+func TestNet_ExchangeEdgesHeadProofs(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{RequireEdgeProofs: true}).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"msg": "hi"}, mh.SHA2_256, -1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	addr := util.MustParseAddr("/ip4/127.0.0.1/tcp/0")
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
 
-	host, err := libp2p.New(
-		context.Background(),
-		libp2p.ListenAddrs(addr),
-		libp2p.Identity(sk),
-	)
+	edge, err := n1.store.HeadsEdge(info.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
-	bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
-	bsrv := bserv.New(bs, offline.Exchange(bs))
-	n, err := NewNetwork(
-		context.Background(),
-		host,
-		bsrv.Blockstore(),
-		dag.NewDAGService(bsrv),
-		tstore.NewLogstore(),
-		Config{
-			Debug:  true,
-			PubSub: true,
-		}, nil, nil)
+	proofs, err := n1.server.headProofs(ctx, info.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
-	return n
+	if len(proofs) != 1 {
+		t.Fatalf("expected heads of 1 log, got %d", len(proofs))
+	}
+
+	// the log is unknown to n2, its heads can't be proven
+	if _, err := n2.server.verifyHeadProofs(info.ID, edge, proofs); !errors.Is(err, errNoHeadsProof) {
+		t.Fatalf("expected heads of an unknown log to be rejected, got %v", err)
+	}
+
+	lg := info.Logs[0]
+	if err := n2.store.AddLog(info.ID, thread.LogInfo{ID: lg.ID, PubKey: lg.PubKey}); err != nil {
+		t.Fatal(err)
+	}
+	pull, err := n2.server.verifyHeadProofs(info.ID, edge, proofs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pull {
+		t.Fatal("expected unknown heads to be pulled")
+	}
+
+	if _, err := n2.server.verifyHeadProofs(info.ID, edge+1, proofs); !errors.Is(err, errNoHeadsProof) {
+		t.Fatalf("expected a spoofed edge to be rejected, got %v", err)
+	}
+	if _, err := n2.server.verifyHeadProofs(info.ID, edge, nil); !errors.Is(err, errNoHeadsProof) {
+		t.Fatalf("expected a missing proof to be rejected, got %v", err)
+	}
+
+	// heads signed by another key must not be accepted
+	other, err := n2.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithThreadKey(info.Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.CreateRecord(ctx, other.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	forged, err := n2.server.headProofs(ctx, other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged[0].LogID = proofs[0].LogID
+	if _, err := n2.server.verifyHeadProofs(info.ID, edge, forged); err == nil {
+		t.Fatal("expected heads signed by another key to be rejected")
+	}
 }
 
-func createThread(t *testing.T, ctx context.Context, api core.API) thread.Info {
-	info, err := api.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+func TestNet_GetLogsIncremental(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+
+	lgs, err := n2.server.getLogs(ctx, info.ID, n1.Host().ID())
 	if err != nil {
 		t.Fatal(err)
 	}
-	return info
+	if len(lgs) != 1 {
+		t.Fatalf("expected 1 new log got %d", len(lgs))
+	}
+	if err := n2.createExternalLogsIfNotExist(info.ID, lgs); err != nil {
+		t.Fatal(err)
+	}
+
+	lgs, err = n2.server.getLogs(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lgs) != 0 {
+		t.Fatalf("expected no changed logs got %d", len(lgs))
+	}
+
+	addr, err := ma.NewMultiaddr("/ip4/10.0.0.1/tcp/4006/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n1.store.AddAddr(info.ID, info.Logs[0].ID, addr, peerstore.PermanentAddrTTL); err != nil {
+		t.Fatal(err)
+	}
+	lgs, err = n2.server.getLogs(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lgs) != 1 {
+		t.Fatalf("expected 1 changed log got %d", len(lgs))
+	}
+	if len(lgs[0].Addrs) != len(info.Logs[0].Addrs)+1 {
+		t.Fatalf("expected changed log to include the new address")
+	}
+}
+
+func TestNet_PendingRecords(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{}).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{}).(*net)
+	defer n2.Close()
+
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbrec, err := cbor.RecordToProto(ctx, n1, r.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &pb.PushRecordRequest{
+		Body: &pb.PushRecordRequest_Body{
+			ThreadID: &pb.ProtoThreadID{ID: info.ID},
+			LogID:    &pb.ProtoPeerID{ID: r.LogID()},
+			Record:   pbrec,
+		},
+		Counter: 1,
+	}
+
+	// the record beats the log
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n1.Host().ID()}})
+	n2.server.pubsubHandler(pctx, req)
+	if !n2.pending.has(info.ID, r.LogID()) {
+		t.Fatal("expected record to be held until the log arrives")
+	}
+
+	lgs, err := n2.server.getLogs(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.createExternalLogsIfNotExist(info.ID, lgs); err != nil {
+		t.Fatal(err)
+	}
+	timeout := time.After(time.Second * 5)
+	for {
+		if _, err := n2.GetRecord(ctx, info.ID, r.Value().Cid()); err == nil {
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatal("held record wasn't applied")
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+	if n2.pending.has(info.ID, r.LogID()) {
+		t.Fatal("expected applied record to be released")
+	}
+}
+
+func TestNet_GetRecordsByCID(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{}).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{}).(*net)
+	defer n2.Close()
+
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var (
+		recs   []core.ThreadRecord
+		bodies []cid.Cid
+	)
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+		bodies = append(bodies, body.Cid())
+	}
+	lid := recs[0].LogID()
+
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	lgs, err := n2.server.getLogs(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.createExternalLogsIfNotExist(info.ID, lgs); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test get records by cid", func(t *testing.T) {
+		got, err := n2.server.getRecordsByCID(ctx, info.ID, n1.Host().ID(), lid, []cid.Cid{
+			recs[1].Value().Cid(),
+			bodies[1], // not a record
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || !got[0].Cid().Equals(recs[1].Value().Cid()) {
+			t.Fatalf("expected only the requested record, got %d records", len(got))
+		}
+	})
+
+	t.Run("test records off the log chain are omitted", func(t *testing.T) {
+		lg, err := n1.store.GetLog(info.ID, lid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a record signed by the log key forking the log at its first record
+		lg.Head = thread.Head{ID: recs[0].Value().Cid(), Counter: 1}
+		body, err := cbornode.WrapObject(map[string]interface{}{"msg": "fork"}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identity := thread.NewLibp2pPubKey(n1.getPrivKey().GetPublic())
+		fork, err := n1.newRecord(ctx, info.ID, lg, body, identity, cbor.EventHeaderConfig{}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := n1.Add(ctx, fork); err != nil {
+			t.Fatal(err)
+		}
+		got, err := n2.server.getRecordsByCID(ctx, info.ID, n1.Host().ID(), lid, []cid.Cid{fork.Cid(), recs[2].Value().Cid()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || !got[0].Cid().Equals(recs[2].Value().Cid()) {
+			t.Fatalf("expected only the record on the log chain, got %d records", len(got))
+		}
+	})
+
+	t.Run("test bridge gap with missing records", func(t *testing.T) {
+		pbrec, err := cbor.RecordToProto(ctx, n1, recs[2].Value())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n1.Host().ID()}})
+		if _, err = n2.server.PushRecord(pctx, &pb.PushRecordRequest{
+			Body: &pb.PushRecordRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: info.ID},
+				LogID:    &pb.ProtoPeerID{ID: lid},
+				Record:   pbrec,
+			},
+			Counter: 3,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range recs {
+			if _, err := n2.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+				t.Fatalf("expected record %s to be fetched: %v", r.Value().Cid(), err)
+			}
+		}
+	})
+}
+
+func TestNet_PullThreadFrom(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var last cid.Cid
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last = r.Value().Cid()
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 1 {
+		t.Fatalf("expected 1 log got %d", len(info2.Logs))
+	}
+	if !info2.Logs[0].Head.ID.Equals(last) || info2.Logs[0].Head.Counter != 3 {
+		t.Fatalf("expected head to be the last record")
+	}
+
+	// pulling again is a no-op
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, n2.Host().Addrs()[0].Encapsulate(
+		ma.StringCast("/p2p/"+n2.Host().ID().String()))); err == nil {
+		t.Fatal("expected pulling from self to fail")
+	}
+}
+
+func TestNet_SyncStats(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"msg": "hi"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+
+	if lag := n2.ThreadSyncLag(info.ID); lag.Count != 1 || lag.Max <= 0 {
+		t.Fatalf("expected sync lag of the pulled head, got %d observations", lag.Count)
+	}
+	if stats := n2.SyncStats(); stats.Lag.Count != 1 {
+		t.Fatalf("expected total sync lag of 1 head, got %d", stats.Lag.Count)
+	}
+}
+
+func TestNet_ThreadQuota(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	quota := int64(4 * core.RecordOverhead)
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadQuota(quota))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var created int
+	for ; created < 10; created++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"msg": created}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n1.CreateRecord(ctx, info.ID, body); errors.Is(err, core.ErrQuotaExceeded) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if created == 0 || created == 10 {
+		t.Fatalf("expected quota to be exceeded after some records, created %d", created)
+	}
+	summary, err := n1.(*net).threadSummary(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Quota != quota || summary.Usage == 0 || summary.Usage > quota || summary.OverQuota {
+		t.Fatalf("unexpected usage of %d bytes for quota of %d bytes", summary.Usage, summary.Quota)
+	}
+
+	// removed bodies no longer count towards the quota
+	if err := n1.(*net).SetThreadQuota(info.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"msg": make([]byte, 4096)}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, _, err := n1.(*net).threadUsage(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n1.RedactRecord(ctx, info.ID, tr.Value().Cid()); err != nil {
+		t.Fatal(err)
+	}
+	after, _, err := n1.(*net).threadUsage(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after >= before {
+		t.Fatalf("expected usage to decrease after redaction, got %d bytes from %d bytes", after, before)
+	}
+
+	// remote records are accepted beyond the quota
+	if err := n2.initBlocks(info.ID, func() error {
+		return n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.SetThreadQuota(info.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+	summary, err = n2.threadSummary(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !summary.OverQuota || summary.Usage <= 1 {
+		t.Fatalf("expected pulled thread to be over quota, got usage of %d bytes", summary.Usage)
+	}
+}
+
+func TestNet_BlockFetcher(t *testing.T) {
+	t.Parallel()
+	rbs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
+	remote := dag.NewDAGService(bserv.New(rbs, offline.Exchange(rbs)))
+	n := makeNetworkWithConfig(t, Config{BlockFetcher: remote}).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	node, err := cbornode.WrapObject(map[string]interface{}{"msg": "remote"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = remote.Add(ctx, node); err != nil {
+		t.Fatal(err)
+	}
+	if known, err := n.isKnown(node.Cid()); err != nil || known {
+		t.Fatalf("expected block to be missing locally, got %v (%v)", known, err)
+	}
+	got, err := n.Get(ctx, node.Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Cid().Equals(node.Cid()) {
+		t.Fatalf("expected block %s, got %s", node.Cid(), got.Cid())
+	}
+	if known, err := n.isKnown(node.Cid()); err != nil || !known {
+		t.Fatalf("expected fetched block to be stored locally, got %v (%v)", known, err)
+	}
+
+	missing, err := cbornode.WrapObject(map[string]interface{}{"msg": "missing"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.Get(ctx, missing.Cid()); !errors.Is(err, format.ErrNotFound) {
+		t.Fatalf("expected block missing everywhere not to be found, got %v", err)
+	}
+}
+
+// uncachedRecord hides a cbor record, so its proto is built on every call as before it was cached.
+type uncachedRecord struct {
+	core.Record
+}
+
+func BenchmarkNet_RecordToProto(b *testing.B) {
+	n := makeNetworkWithConfig(b, Config{}).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(b, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{"data": make([]byte, 1<<10)}, mh.SHA2_256, -1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	tr, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pbrec, err := cbor.RecordToProto(ctx, n, tr.Value())
+	if err != nil {
+		b.Fatal(err)
+	}
+	// records relayed to peers are received from other peers
+	rec, err := cbor.RecordFromProto(pbrec, info.Key.Service())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("loaded", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r, err := cbor.GetRecord(ctx, n, rec.Cid(), info.Key.Service())
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := cbor.RecordToProto(ctx, n, r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cbor.RecordToProto(ctx, n, uncachedRecord{rec}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cbor.RecordToProto(ctx, n, rec); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkNet_GetRecords(b *testing.B) {
+	n := makeNetworkWithConfig(b, Config{}).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(b, ctx, n)
+	var lid peer.ID
+	for i := 0; i < 100; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"data": make([]byte, 1<<10), "i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tr, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			b.Fatal(err)
+		}
+		lid = tr.LogID()
+	}
+	req, _, err := n.server.buildGetRecordsRequest(info.ID, map[peer.ID]thread.Head{lid: thread.HeadUndef}, 100)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{Addr: &addr{id: n.Host().ID()}})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reply, err := n.server.GetRecords(pctx, req)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(reply.Logs) != 1 || len(reply.Logs[0].Records) != 100 {
+			b.Fatalf("expected 100 records, got %+v", reply.Logs)
+		}
+	}
+}
+
+func TestNet_SyncTrace(t *testing.T) {
+	t.Parallel()
+	tr1, tr2 := synctrace.NewRecorder(0), synctrace.NewRecorder(0)
+	n1 := makeNetworkWithConfig(t, Config{SyncTrace: tr1})
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{SyncTrace: tr2}).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"msg": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := tr2.Export(&buf, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	events2, err := synctrace.Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer := synctrace.NewReplayer(synctrace.Merge(tr1.Events(info.ID), events2))
+	steps, err := replayer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) < 5 {
+		t.Fatalf("expected log and record events of both hosts, got %d", len(steps))
+	}
+	for i, s := range steps {
+		if s.Mismatch {
+			t.Fatalf("replayed head %v doesn't match recorded %v at step %d", s.Head, s.Event.Head, i)
+		}
+	}
+
+	lid := info.Logs[0].ID
+	expected, err := n2.store.Heads(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, host := range []peer.ID{n1.Host().ID(), n2.Host().ID()} {
+		heads, err := replayer.Store(host.String()).Heads(info.ID, lid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(heads) != 1 || heads[0] != expected[0] {
+			t.Fatalf("replayed heads of %s don't match the synced heads", host)
+		}
+	}
+}
+
+func TestNet_LightClient(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{LightClient: true}).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 1 || !info2.Logs[0].Head.ID.Equals(recs[2].Value().Cid()) || info2.Logs[0].Head.Counter != 3 {
+		t.Fatalf("expected head to be the last record")
+	}
+
+	// only headers are synced
+	for _, r := range recs {
+		if known, err := n2.isKnown(r.Value().BlockID()); err != nil {
+			t.Fatal(err)
+		} else if known {
+			t.Fatalf("expected event of record %s to be missing", r.Value().Cid())
+		}
+	}
+
+	// events are fetched on demand
+	rec, err := n2.GetRecord(ctx, info.ID, recs[1].Value().Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := cbor.EventFromRecord(ctx, n2, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := event.GetBody(ctx, n2, info.Key.Read())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := make(map[string]interface{})
+	if err = cbornode.DecodeInto(node.RawData(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(body["msg"]) != "1" {
+		t.Fatalf("expected body of record 2, got %v", body)
+	}
+	if known, err := n2.isKnown(recs[1].Value().BlockID()); err != nil {
+		t.Fatal(err)
+	} else if !known {
+		t.Fatal("expected fetched event to be stored")
+	}
+}
+
+func TestNet_PullMemoryBudget(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	// a budget share fits a few records only
+	n2 := makeNetworkWithConfig(t, Config{PullMemoryBudget: 16 << 10}).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var last cid.Cid
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last = r.Value().Cid()
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 1 {
+		t.Fatalf("expected 1 log got %d", len(info2.Logs))
+	}
+	if !info2.Logs[0].Head.ID.Equals(last) || info2.Logs[0].Head.Counter != 3 {
+		t.Fatalf("expected head to be the last record")
+	}
+	if reserved := n2.pullBudget.Reserved(); reserved != 0 {
+		t.Fatalf("expected pull budget to be released, got %d reserved bytes", reserved)
+	}
+}
+
+func TestNet_PullPrunedLog(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var rids []cid.Cid
+	for i := 0; i < 5; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rids = append(rids, r.Value().Cid())
+	}
+
+	// prune the first two records, as compaction would
+	sk, err := n1.store.ServiceKey(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rid := range rids[:2] {
+		if _, err := n1.deleteRecord(ctx, rid, sk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr(n1.Host().Addrs()[0].String() + "/p2p/" + n1.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadFrom(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 1 {
+		t.Fatalf("expected 1 log got %d", len(info2.Logs))
+	}
+	if !info2.Logs[0].Head.ID.Equals(rids[4]) || info2.Logs[0].Head.Counter != 5 {
+		t.Fatalf("expected head to be the last record with counter 5, got %s with counter %d",
+			info2.Logs[0].Head.ID, info2.Logs[0].Head.Counter)
+	}
+	for i, rid := range rids {
+		if known, err := n2.isKnown(rid); err != nil {
+			t.Fatal(err)
+		} else if known != (i >= 2) {
+			t.Fatalf("record %d expected to be known: %v", i, i >= 2)
+		}
+	}
+}
+
+func TestNet_CheckpointCounter(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"msg": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	lid := recs[0].LogID()
+	lg, err := n1.store.GetLog(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.createExternalLogsIfNotExist(info.ID, []thread.LogInfo{{ID: lid, PubKey: lg.PubKey}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a peer claims an inflated counter for a checkpoint
+	const inflated = 1000
+	if err := n2.putRecords(ctx, info.ID, lid, []core.Record{recs[1].Value()}, inflated, recs[0].Value().Cid()); err != nil {
+		t.Fatal(err)
+	}
+	head, err := n2.currentHead(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !head.ID.Equals(recs[1].Value().Cid()) || head.Counter != inflated {
+		t.Fatalf("expected the checkpoint to be the head, got %s with counter %d", head.ID, head.Counter)
+	}
+
+	// records following the head are applied with their actual counters
+	if err := n2.putRecords(ctx, info.ID, lid, []core.Record{recs[2].Value()}, 3, cid.Undef); err != nil {
+		t.Fatal(err)
+	}
+	if head, err = n2.currentHead(info.ID, lid); err != nil {
+		t.Fatal(err)
+	}
+	if !head.ID.Equals(recs[2].Value().Cid()) {
+		t.Fatalf("expected the following record to be applied, got head %s", head.ID)
+	}
+
+	// and served to peers claiming inflated counters
+	served, _, err := n1.getLocalRecords(ctx, info.ID, lid, recs[1].Value().Cid(), 10, inflated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(served) != 1 || !served[0].Cid().Equals(recs[2].Value().Cid()) {
+		t.Fatalf("expected the record following the offset to be served, got %d records", len(served))
+	}
+}
+
+func TestNet_LogFencing(t *testing.T) {
+	t.Parallel()
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n1 := makeNetworkWithKey(t, sk, Config{}).(*net)
+	defer n1.Close()
+
+	// a second process with the same identity, sharing the stores of the first one
+	host, err := libp2p.New(
+		context.Background(),
+		libp2p.ListenAddrs(util.MustParseAddr("/ip4/127.0.0.1/tcp/0")),
+		libp2p.Identity(sk),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n2i, err := NewNetwork(context.Background(), host, n1.bstore, n1.DAGService, n1.store, Config{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n2 := n2i.(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEpoch := func(r core.ThreadRecord, expected uint64) {
+		epoch, err := n1.recordEpoch(ctx, info.ID, r.Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if epoch != expected {
+			t.Fatalf("expected record epoch %d, got %d", expected, epoch)
+		}
+	}
+
+	r1, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEpoch(r1, 1)
+	if _, err := n2.CreateRecord(ctx, info.ID, body); !errors.Is(err, core.ErrLogFenced) {
+		t.Fatalf("expected write to a leased log to be fenced, got %v", err)
+	}
+	r2, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEpoch(r2, 1)
+
+	// released leases are taken over with the next epoch
+	n1.releaseLogLeases()
+	r3, err := n2.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEpoch(r3, 2)
+	if r3.LogID() != r1.LogID() {
+		t.Fatal("expected records in the same log")
+	}
+	if _, err := n1.CreateRecord(ctx, info.ID, body); !errors.Is(err, core.ErrLogFenced) {
+		t.Fatalf("expected write to a taken over log to be fenced, got %v", err)
+	}
+	lg, err := n1.store.GetLog(info.ID, r1.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lg.Head.ID.Equals(r3.Value().Cid()) || lg.Head.Counter != 3 {
+		t.Fatalf("expected the log not to be forked")
+	}
+}
+
+func TestNet_LogFencingConcurrent(t *testing.T) {
+	t.Parallel()
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n1 := makeNetworkWithKey(t, sk, Config{}).(*net)
+	defer n1.Close()
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	lg, err := n1.getOrCreateLog(info.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// processes sharing the stores race for the lease of the log
+	const processes = 8
+	nets := make([]*net, processes)
+	for i := range nets {
+		host, err := libp2p.New(
+			context.Background(),
+			libp2p.ListenAddrs(util.MustParseAddr("/ip4/127.0.0.1/tcp/0")),
+			libp2p.Identity(sk),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := NewNetwork(context.Background(), host, n1.bstore, n1.DAGService, n1.store, Config{}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nets[i] = n.(*net)
+		defer n.Close()
+	}
+	start := make(chan struct{})
+	results := make(chan error, processes)
+	for _, n := range nets {
+		go func(n *net) {
+			<-start
+			_, err := n.fenceLog(ctx, info.ID, lg)
+			results <- err
+		}(n)
+	}
+	close(start)
+	var acquired int
+	for range nets {
+		if err := <-results; err == nil {
+			acquired++
+		} else if !errors.Is(err, core.ErrLogFenced) {
+			t.Fatal(err)
+		}
+	}
+	if acquired != 1 {
+		t.Fatalf("expected the lease to be acquired once, got %d", acquired)
+	}
+}
+
+func TestNet_MaxRecordSize(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{MaxRecordSize: 4 << 10}).(*net)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.store.AddLog(info.ID, thread.LogInfo{
+		ID:     info.Logs[0].ID,
+		PubKey: info.Logs[0].PubKey,
+		Head:   thread.HeadUndef,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"data": make([]byte, 8<<10),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test create", func(t *testing.T) {
+		_, err := n2.CreateRecord(ctx, info.ID, body)
+		var tooLarge *core.RecordTooLargeError
+		if !errors.As(err, &tooLarge) || !errors.Is(err, core.ErrRecordTooLarge) {
+			t.Fatalf("expected record too large error, got %v", err)
+		}
+		if tooLarge.MaxSize != 4<<10 {
+			t.Fatalf("expected max size of 4KiB, got %d", tooLarge.MaxSize)
+		}
+	})
+
+	t.Run("test push", func(t *testing.T) {
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pbrec, err := cbor.RecordToProto(ctx, n1, r.Value())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pctx := grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+			Addr: &addr{id: n1.Host().ID()},
+		})
+		_, err = n2.server.PushRecord(pctx, &pb.PushRecordRequest{
+			Body: &pb.PushRecordRequest_Body{
+				ThreadID: &pb.ProtoThreadID{ID: info.ID},
+				LogID:    &pb.ProtoPeerID{ID: r.LogID()},
+				Record:   pbrec,
+			},
+		})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected resource exhausted error, got %v", err)
+		}
+	})
+
+	t.Run("test pull", func(t *testing.T) {
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+		req, sk, err := n2.server.buildGetRecordsRequest(info.ID, map[peer.ID]thread.Head{
+			r.LogID(): thread.HeadUndef,
+		}, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs, err := n2.server.getRecordsFromPeer(ctx, info.ID, n1.Host().ID(), req, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recs[r.LogID()].records) != 0 {
+			t.Fatalf("expected oversized records not to be pulled, got %d", len(recs[r.LogID()].records))
+		}
+		got, err := n2.server.getRecordsByCID(ctx, info.ID, n1.Host().ID(), r.LogID(), []cid.Cid{r.Value().Cid()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("expected oversized record not to be fetched, got %d", len(got))
+		}
+	})
+
+	t.Run("test advertise", func(t *testing.T) {
+		if !n1.peerAcceptsRecord(n2.Host().ID(), 8<<10) {
+			t.Fatal("expected unknown limit to accept records")
+		}
+		if err := n1.server.exchangeEdges(ctx, n2.Host().ID(), []thread.ID{info.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if n1.peerAcceptsRecord(n2.Host().ID(), 8<<10) {
+			t.Fatal("expected advertised limit to reject larger records")
+		}
+		if !n1.peerAcceptsRecord(n2.Host().ID(), 1<<10) {
+			t.Fatal("expected advertised limit to accept smaller records")
+		}
+	})
+}
+
+func TestNet_GossipPeers(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n3 := makeNetwork(t).(*net)
+	defer n3.Close()
+	for _, n := range []*net{n2, n3} {
+		n1.Host().Peerstore().AddAddrs(n.Host().ID(), n.Host().Addrs(), peerstore.PermanentAddrTTL)
+		n.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	}
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	n1.observePeer(info.ID, n2.Host().ID(), true)
+
+	// requesters aren't gossiped to themselves
+	if peers, err := n1.server.gossipPeers(info.ID, n2.Host().ID(), GossipPeers); err != nil || peers != nil {
+		t.Fatalf("expected no peers for the only replica, got %v", err)
+	}
+
+	if _, err = n3.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if len(n3.Host().Peerstore().Addrs(n2.Host().ID())) != 0 {
+		t.Fatal("expected replica to be unknown before the exchange")
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	if err = n3.server.exchangeEdges(ctx, n1.Host().ID(), []thread.ID{info.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if len(n3.Host().Peerstore().Addrs(n2.Host().ID())) == 0 {
+		t.Fatal("expected replica addresses to be gossiped")
+	}
+}
+
+func TestNet_SubscribeHeads(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n)
+	other := createThread(t, ctx, n)
+	sub, err := n.SubscribeHeads(ctx, core.WithSubFilter(info.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var edges []uint64
+	for i := 1; i <= 2; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n.CreateRecord(ctx, other.ID, body); err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case u := <-sub:
+			if !u.ThreadID.Equals(info.ID) {
+				t.Fatalf("expected update for thread %s, got %s", info.ID, u.ThreadID)
+			}
+			h, ok := u.Heads[r.LogID()]
+			if !ok || !h.ID.Equals(r.Value().Cid()) || h.Counter != int64(i) {
+				t.Fatalf("expected head to be the new record")
+			}
+			edge, err := n.store.HeadsEdge(info.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Edge != edge {
+				t.Fatalf("expected edge %d, got %d", edge, u.Edge)
+			}
+			edges = append(edges, u.Edge)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for heads update")
+		}
+	}
+	if edges[0] == edges[1] {
+		t.Fatal("expected heads edge to change")
+	}
+}
+
+func TestNet_SubscribeTags(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := n.Subscribe(ctx, core.WithSubTag("a,b")); err == nil {
+		t.Fatal("expected invalid tag to be refused")
+	}
+	sub, err := n.Subscribe(ctx, core.WithSubTag("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	heads, err := n.SubscribeHeads(ctx, core.WithSubTag("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// threads added after subscribing are included
+	tagged, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadTags("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadTags("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.CreateRecord(ctx, other.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	r, err := n.CreateRecord(ctx, tagged.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case u := <-heads:
+		if !u.ThreadID.Equals(tagged.ID) {
+			t.Fatalf("expected heads update of the tagged thread, got one of %s", u.ThreadID)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for heads update")
+	}
+	select {
+	case rec := <-sub:
+		if !rec.ThreadID().Equals(tagged.ID) || !rec.Value().Cid().Equals(r.Value().Cid()) {
+			t.Fatalf("expected record of the tagged thread, got one of %s", rec.ThreadID())
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for record")
+	}
+}
+
+func TestNet_ActivityFeed(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := n.GetToken(ctx, thread.NewLibp2pIdentity(sk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(typ string, opts ...core.ThreadOption) core.ThreadRecord {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"type": typ,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body, append(opts, core.WithRecordType(typ))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	checkFeed := func(feed []core.RecordSummary, expected ...core.ThreadRecord) {
+		if len(feed) != len(expected) {
+			t.Fatalf("expected %d records in feed, got %d", len(expected), len(feed))
+		}
+		for i, r := range expected {
+			if !feed[i].ID.Equals(r.Value().Cid()) || feed[i].LogID != r.LogID() {
+				t.Fatalf("expected record %s of log %s at %d, got %s of log %s",
+					r.Value().Cid(), r.LogID(), i, feed[i].ID, feed[i].LogID)
+			}
+		}
+	}
+
+	// records of two logs indexed on the first request
+	r1 := create("note")
+	r2 := create("comment", core.WithThreadToken(tok))
+	if r1.LogID() == r2.LogID() {
+		t.Fatal("expected records in different logs")
+	}
+	feed, err := n.ActivityFeed(ctx, info.ID, time.Time{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFeed(feed, r2, r1)
+	if feed[0].Type != "comment" || feed[1].Type != "note" {
+		t.Fatalf("unexpected record types: %s, %s", feed[0].Type, feed[1].Type)
+	}
+	if feed[0].Author == nil || !feed[0].Author.Equals(thread.NewLibp2pPubKey(sk.GetPublic())) {
+		t.Fatalf("expected the token identity to author the record, got %v", feed[0].Author)
+	}
+	if feed[1].Time.IsZero() || feed[1].Size == 0 {
+		t.Fatalf("expected record time and size, got %+v", feed[1])
+	}
+
+	// new records are indexed incrementally
+	r3 := create("note")
+	if feed, err = n.ActivityFeed(ctx, info.ID, time.Time{}, 0); err != nil {
+		t.Fatal(err)
+	}
+	checkFeed(feed, r3, r2, r1)
+	if feed, err = n.ActivityFeed(ctx, info.ID, feed[2].Time, 0); err != nil {
+		t.Fatal(err)
+	}
+	checkFeed(feed, r3, r2)
+	if feed, err = n.ActivityFeed(ctx, info.ID, time.Time{}, 1); err != nil {
+		t.Fatal(err)
+	}
+	checkFeed(feed, r3)
+}
+
+func TestNet_IndexKey(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{IndexableHeaders: true}).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "secret",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := n1.CreateRecord(ctx, info.ID, body, core.WithRecordType("note"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the author reads the body with the read key
+	event, err := cbor.EventFromRecord(ctx, n1, tr.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := event.GetBody(ctx, n1, info.Key.Read())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Cid().Equals(body.Cid()) {
+		t.Fatal("expected the body to be decrypted with the read key")
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexKey := thread.NewIndexKey(info.Key.Service(), info.Key.Index())
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(indexKey)); err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info2.Key.CanRead() || !info2.Key.CanIndex() {
+		t.Fatal("expected only the index key to be held")
+	}
+
+	// the index key holder reads headers, but not bodies
+	feed, err := n2.ActivityFeed(ctx, info.ID, time.Time{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(feed) != 1 || !feed[0].ID.Equals(tr.Value().Cid()) || feed[0].Type != "note" {
+		t.Fatalf("unexpected feed %+v", feed)
+	}
+	rec, err := n2.getRecord(ctx, info.ID, tr.Value().Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err = cbor.EventFromRecord(ctx, n2, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = event.GetBody(ctx, n2, info2.Key.Index()); !errors.Is(err, cbor.ErrBodyKeySealed) {
+		t.Fatalf("expected body key to be sealed, got %v", err)
+	}
+}
+
+func TestNet_RecordAnnotations(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	var rids []cid.Cid
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rids = append(rids, r.Value().Cid())
+	}
+	checkQuery := func(list []core.RecordAnnotation, expected ...cid.Cid) {
+		if len(list) != len(expected) {
+			t.Fatalf("expected %d annotations, got %d", len(expected), len(list))
+		}
+		for i, rid := range expected {
+			if !list[i].RecordID.Equals(rid) {
+				t.Fatalf("expected annotation of record %s at %d, got %s", rid, i, list[i].RecordID)
+			}
+		}
+	}
+
+	if err := n.AnnotateRecord(ctx, info.ID, rids[0], core.Annotation{Read: true, Labels: []string{"todo"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.AnnotateRecord(ctx, info.ID, rids[1], core.Annotation{Starred: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.AnnotateRecord(ctx, info.ID, rids[2], core.Annotation{Starred: true, Labels: []string{"todo"}}); err != nil {
+		t.Fatal(err)
+	}
+	a, err := n.GetAnnotation(ctx, info.ID, rids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Read || a.Starred || len(a.Labels) != 1 || a.Labels[0] != "todo" || a.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected annotation: %+v", a)
+	}
+
+	// most recently updated first
+	list, err := n.QueryAnnotations(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQuery(list, rids[2], rids[1], rids[0])
+	if list, err = n.QueryAnnotations(ctx, info.ID, core.WithUnreadFilter()); err != nil {
+		t.Fatal(err)
+	}
+	checkQuery(list, rids[2], rids[1])
+	if list, err = n.QueryAnnotations(ctx, info.ID, core.WithStarredFilter(), core.WithLabelFilter("todo")); err != nil {
+		t.Fatal(err)
+	}
+	checkQuery(list, rids[2])
+
+	// zero annotation removes it
+	if err := n.AnnotateRecord(ctx, info.ID, rids[1], core.Annotation{}); err != nil {
+		t.Fatal(err)
+	}
+	if list, err = n.QueryAnnotations(ctx, info.ID, core.WithStarredFilter()); err != nil {
+		t.Fatal(err)
+	}
+	checkQuery(list, rids[2])
+
+	// records must be available locally
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.AnnotateRecord(ctx, info.ID, body.Cid(), core.Annotation{Read: true})
+	if !errors.Is(err, core.ErrRecordNotFound) {
+		t.Fatalf("expected record not found error, got %v", err)
+	}
+
+	// annotations of pruned records are removed
+	sk, err := n.store.ServiceKey(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.deleteRecord(ctx, rids[0], sk); err != nil {
+		t.Fatal(err)
+	}
+	if list, err = n.QueryAnnotations(ctx, info.ID, core.WithLabelFilter("todo")); err != nil {
+		t.Fatal(err)
+	}
+	checkQuery(list, rids[2])
+	if _, err := n.annotations.Get(annotationKey(info.ID, rids[0])); !errors.Is(err, ds.ErrNotFound) {
+		t.Fatalf("expected pruned annotation to be removed, got %v", err)
+	}
+}
+
+func TestNet_AncestryProof(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithSkipLinks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []core.ThreadRecord
+	for i := 0; i < 40; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"n": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	if skips := recs[31].Value().SkipIDs(); len(skips) != 4 || !skips[3].Equals(recs[15].Value().Cid()) {
+		t.Fatalf("expected 4 skip links from record 32 down to record 16, got %v", skips)
+	}
+	if skips := recs[30].Value().SkipIDs(); len(skips) != 0 {
+		t.Fatalf("expected no skip links in record 31, got %d", len(skips))
+	}
+
+	lid := recs[0].LogID()
+	info, err = n.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg := info.GetFirstPrivKeyLog()
+	for _, i := range []int{0, 7, 16, 38, 39} {
+		rid := recs[i].Value().Cid()
+		proof, err := n.GetAncestryProof(ctx, info.ID, lid, rid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proof.Position != int64(i+1) {
+			t.Fatalf("expected position %d, got %d", i+1, proof.Position)
+		}
+		if len(proof.Records) > 12 {
+			t.Fatalf("expected a short proof for record %d, got %d records", i+1, len(proof.Records))
+		}
+		if err = cbor.VerifyAncestryProof(proof, rid, lg.PubKey); err != nil {
+			t.Fatalf("verifying proof of record %d: %v", i+1, err)
+		}
+		if err = cbor.VerifyAncestryProof(proof, recs[(i+1)%len(recs)].Value().Cid(), lg.PubKey); err == nil {
+			t.Fatalf("expected proof of record %d to fail for another record", i+1)
+		}
+	}
+
+	other := createThread(t, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{"n": 0}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n.CreateRecord(ctx, other.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.GetAncestryProof(ctx, info.ID, lid, r.Value().Cid()); err == nil {
+		t.Fatal("expected error for a record of another log")
+	}
+}
+
+// legacyRecord is the node structure of records before skip links.
+type legacyRecord struct {
+	Block  cid.Cid
+	Sig    []byte
+	PubKey []byte
+	Prev   cid.Cid `refmt:",omitempty"`
+}
+
+func init() {
+	cbornode.RegisterCborType(legacyRecord{})
+}
+
+func TestNet_SkipLinksCompat(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	var last core.ThreadRecord
+	for i := 0; i < 4; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"n": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last, err = n.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// threads keep the original record format unless skip links are enabled
+	if skips := last.Value().SkipIDs(); len(skips) != 0 {
+		t.Fatalf("expected no skip links without the thread option, got %v", skips)
+	}
+
+	// records of the original format still verify
+	lg, err := n.store.GetLog(info.ID, last.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"n": 4}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := cbor.CreateEvent(ctx, n, body, info.Key.Read())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := thread.NewLibp2pPubKey(n.getPrivKey().GetPublic()).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := lg.PrivKey.Sign(append(event.Cid().Bytes(), lg.Head.ID.Bytes()...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := cbornode.WrapObject(&legacyRecord{
+		Block:  event.Cid(),
+		Sig:    sig,
+		PubKey: pk,
+		Prev:   lg.Head.ID,
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coded, err := cbor.EncodeBlock(node, info.Key.Service())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := cbor.RecordFromNode(coded, info.Key.Service())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cbor.VerifyHeader(rec, lg.PubKey); err != nil {
+		t.Fatalf("expected record of the original format to verify: %v", err)
+	}
+	if !rec.PrevID().Equals(lg.Head.ID) || len(rec.SkipIDs()) != 0 {
+		t.Fatalf("unexpected links of record of the original format")
+	}
+}
+
+func TestNet_ListThreads(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	start := time.Now()
+	tagged := make(map[thread.ID]struct{})
+	for i := 0; i < 5; i++ {
+		var opts []core.NewThreadOption
+		if i%2 == 0 {
+			opts = append(opts, core.WithNewThreadTags("even"))
+		}
+		info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i%2 == 0 {
+			tagged[info.ID] = struct{}{}
+		}
+	}
+
+	var (
+		listed []thread.ID
+		token  string
+	)
+	for {
+		page, err := n.ListThreads(ctx, core.WithPageLimit(2), core.WithPageToken(token))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Threads) > 2 {
+			t.Fatalf("expected at most 2 threads per page, got %d", len(page.Threads))
+		}
+		for _, ts := range page.Threads {
+			listed = append(listed, ts.ID)
+			if ts.Health != core.SyncHealthUnsynced {
+				t.Fatalf("expected thread %s to be unsynced, got %s", ts.ID, ts.Health)
+			}
+			if ts.LastActivity.Before(start) {
+				t.Fatalf("expected thread %s to have last activity", ts.ID)
+			}
+		}
+		if token = page.NextPageToken; len(token) == 0 {
+			break
+		}
+	}
+	if len(listed) != 5 {
+		t.Fatalf("expected 5 listed threads, got %d", len(listed))
+	}
+	for i := 1; i < len(listed); i++ {
+		if listed[i-1] >= listed[i] {
+			t.Fatalf("threads are not listed in order")
+		}
+	}
+
+	page, err := n.ListThreads(ctx, core.WithTagFilter("even"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Threads) != len(tagged) || len(page.NextPageToken) != 0 {
+		t.Fatalf("expected %d tagged threads in a single page, got %d", len(tagged), len(page.Threads))
+	}
+	for _, ts := range page.Threads {
+		if _, ok := tagged[ts.ID]; !ok {
+			t.Fatalf("thread %s is not tagged", ts.ID)
+		}
+	}
+
+	page, err = n.ListThreads(ctx, core.WithActivityWindow(time.Time{}, start))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Threads) != 0 {
+		t.Fatalf("expected no threads active before the start, got %d", len(page.Threads))
+	}
+	page, err = n.ListThreads(ctx, core.WithSyncHealthFilter(core.SyncHealthSynced))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Threads) != 0 {
+		t.Fatalf("expected no synced threads, got %d", len(page.Threads))
+	}
+}
+
+func TestNet_GetThreadLogs(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	for i := 0; i < 2; i++ {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n.CreateThread(ctx, info.ID, core.WithLogKey(sk), core.WithThreadKey(info.Key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := n.GetThreadLogs(ctx, info.ID, core.WithPageLimit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Logs) != 2 || len(page.NextPageToken) == 0 {
+		t.Fatalf("expected a full first page, got %d logs", len(page.Logs))
+	}
+	next, err := n.GetThreadLogs(ctx, info.ID, core.WithPageLimit(2), core.WithPageToken(page.NextPageToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.Logs) != 1 || len(next.NextPageToken) != 0 {
+		t.Fatalf("expected a single log on the last page, got %d", len(next.Logs))
+	}
+	if page.Logs[1].ID >= next.Logs[0].ID {
+		t.Fatalf("logs are not listed in order")
+	}
+}
+
+func TestNet_Presence(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n1)
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := thread.NewLibp2pIdentity(sk)
+	tok, err := n1.GetToken(ctx, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := n2.SubscribePresence(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// retry until the pubsub mesh is formed
+	var p core.Presence
+	tick := time.NewTicker(time.Millisecond * 500)
+	defer tick.Stop()
+	timeout := time.After(time.Second * 20)
+loop:
+	for {
+		if err := n1.PublishPresence(
+			ctx,
+			info.ID,
+			core.PresenceOnline,
+			[]byte("cursor"),
+			core.WithThreadToken(tok),
+			core.WithSigner(identity),
+		); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case p = <-sub:
+			break loop
+		case <-tick.C:
+		case <-timeout:
+			t.Fatal("presence wasn't received")
+		}
+	}
+	if p.Peer != n1.Host().ID() || p.Status != core.PresenceOnline || string(p.Payload) != "cursor" {
+		t.Fatalf("unexpected presence: %v", p)
+	}
+	if p.Identity == nil || !p.Identity.Equals(identity.GetPublic()) {
+		t.Fatalf("unexpected presence identity: %v", p.Identity)
+	}
+
+	// the identity must sign its presence
+	if err := n1.PublishPresence(ctx, info.ID, core.PresenceOnline, nil, core.WithThreadToken(tok)); !errors.Is(err, core.ErrSignerRequired) {
+		t.Fatalf("expected signer required error, got %v", err)
+	}
+	serviceKey, err := n1.(*net).store.ServiceKey(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := core.Presence{
+		ThreadID: info.ID,
+		Peer:     n1.Host().ID(),
+		Identity: identity.GetPublic(),
+		Status:   core.PresenceOnline,
+		Payload:  []byte("cursor"),
+		Time:     time.Now(),
+	}
+	forgedSig, err := n1.(*net).getPrivKey().Sign(core.PresenceIdentityPayload(forged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := n1.(*net).presenceToProto(forged, forgedSig, serviceKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(pp.Body.Payload, []byte("cursor")) {
+		t.Fatal("presence payload isn't encrypted")
+	}
+	if _, err := presenceFromProto(pp, serviceKey); err == nil {
+		t.Fatal("expected presence with a forged identity to be rejected")
+	}
+
+	// presence is sent to new subscribers right away
+	sub2, err := n2.SubscribePresence(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case p = <-sub2:
+		if p.Status != core.PresenceOnline {
+			t.Fatalf("expected online presence, got %s", p.Status)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("current presence wasn't received")
+	}
+
+	n2.(*net).presence.expire(time.Now().Add(time.Minute))
+	for p.Status != core.PresenceOffline {
+		select {
+		case p = <-sub2:
+		case <-time.After(time.Second * 5):
+			t.Fatal("presence expiration wasn't received")
+		}
+	}
+}
+
+func TestNet_PubSubCache(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{PubSub: true, PubSubCacheSize: 2})
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{PubSub: true, PubSubCacheSize: 2})
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+
+	// records are cached even if nobody else joined the topic yet
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs, err := n2.(*net).server.getRecentRecords(ctx, n1.Host().ID(), info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 recent records, got %d", len(reqs))
+	}
+	for i, req := range reqs {
+		rec, err := cbor.RecordFromProto(req.Body.Record, info.Key.Service())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !rec.Cid().Equals(recs[i+1].Value().Cid()) {
+			t.Fatalf("expected the newest records oldest first, got %s at %d", rec.Cid(), i)
+		}
+	}
+}
+
+func TestNet_IntegrityRepair(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetworkWithConfig(t, Config{}).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithConfig(t, Config{}).(*net)
+	defer n2.Close()
+
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n1)
+	var last core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lid := last.LogID()
+
+	if err := n2.store.AddThread(thread.Info{ID: info.ID, Key: info.Key}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.updateLogsFromPeer(ctx, n1.Host().ID(), info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.updateRecordsFromPeer(ctx, n1.Host().ID(), info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := n2.SubscribeIntegrity(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// heads dropped along with a corrupted entry are recovered from peers
+	if err := n2.store.ClearHeads(info.ID, lid); err != nil {
+		t.Fatal(err)
+	}
+	n2.handleIntegrityEvent(logstore.IntegrityEvent{ThreadID: info.ID, LogID: lid, Entry: logstore.EntryHeads})
+
+	var repaired bool
+	for !repaired {
+		select {
+		case ev := <-events:
+			if ev.ThreadID != info.ID || ev.LogID != lid || ev.Entry != logstore.EntryHeads {
+				t.Fatalf("unexpected integrity event: %+v", ev)
+			}
+			repaired = ev.Repaired
+		case <-time.After(time.Second * 5):
+			t.Fatal("entry wasn't repaired")
+		}
+	}
+	head, err := n2.currentHead(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !head.ID.Equals(last.Value().Cid()) || head.Counter != 3 {
+		t.Fatalf("expected head %s (counter 3), got %s (counter %d)", last.Value().Cid(), head.ID, head.Counter)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+	if err := (Config{}).Validate(); err != nil {
+		t.Fatalf("expected empty config to be valid, got %v", err)
+	}
+	if err := (Config{MaxRecordSize: -1}).Validate(); err == nil {
+		t.Fatal("expected negative max record size to be invalid")
+	}
+	if err := (Config{MaxRecordSize: core.RecordOverhead}).Validate(); err == nil {
+		t.Fatal("expected max record size within the record overhead to be invalid")
+	}
+	if err := (Config{PullMemoryBudget: -1}).Validate(); err == nil {
+		t.Fatal("expected negative pull memory budget to be invalid")
+	}
+	if err := (Config{VerifyWorkers: -1}).Validate(); err == nil {
+		t.Fatal("expected negative verify workers to be invalid")
+	}
+	if err := (Config{PubSubCacheSize: 8}).Validate(); err == nil {
+		t.Fatal("expected pubsub cache without pubsub to be invalid")
+	}
+	if _, err := NewNetwork(context.Background(), nil, nil, nil, nil, Config{PubSub: true}, nil, nil); err == nil {
+		t.Fatal("expected network without a host to be invalid")
+	}
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	t.Run("test close", func(t *testing.T) {
+		if err := n.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func makeNetwork(t testing.TB) core.Net {
+	return makeNetworkWithConfig(t, Config{
+		Debug:  true,
+		PubSub: true,
+	})
+}
+
+func makeNetworkWithConfig(t testing.TB, conf Config) core.Net {
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return makeNetworkWithKey(t, sk, conf)
+}
+
+func makeNetworkWithKey(t testing.TB, sk crypto.PrivKey, conf Config) core.Net {
+	addr := util.MustParseAddr("/ip4/127.0.0.1/tcp/0")
+
+	host, err := libp2p.New(
+		context.Background(),
+		libp2p.ListenAddrs(addr),
+		libp2p.Identity(sk),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
+	bsrv := bserv.New(bs, offline.Exchange(bs))
+	n, err := NewNetwork(
+		context.Background(),
+		host,
+		bsrv.Blockstore(),
+		dag.NewDAGService(bsrv),
+		tstore.NewLogstore(),
+		conf, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func createThread(t testing.TB, ctx context.Context, api core.API) thread.Info {
+	info, err := api.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func TestNet_VectorClock(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n)
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := n.GetToken(ctx, thread.NewLibp2pIdentity(sk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := n.Subscribe(ctx, core.WithSubFilter(info.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// records are delivered while created, the subscription isn't buffered
+	seen := make(core.VectorClock)
+	createAndReceive := func(opts ...core.ThreadOption) core.ThreadRecord {
+		var r core.ThreadRecord
+		var err error
+		done := make(chan struct{})
+		go func() {
+			r, err = n.CreateRecord(ctx, info.ID, body, opts...)
+			close(done)
+		}()
+		select {
+		case rec := <-sub:
+			seen.Observe(rec.LogID(), rec.Clock()[rec.LogID()])
+		case <-time.After(time.Second * 5):
+			t.Fatal("record wasn't delivered")
+		}
+		<-done
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	r1 := createAndReceive()
+	r2 := createAndReceive()
+	r3 := createAndReceive(core.WithThreadToken(tok))
+	if h := r2.Clock()[r2.LogID()]; h != 2 {
+		t.Fatalf("expected second record at height 2, got %d", h)
+	}
+	if r3.Clock()[r1.LogID()] != 2 || r3.Clock()[r3.LogID()] != 1 {
+		t.Fatalf("unexpected clock of the last record %v", r3.Clock())
+	}
+
+	clock, err := n.GetVectorClock(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o := clock.Compare(r3.Clock()); o != core.ClockEqual {
+		t.Fatalf("expected thread clock equal to the last record clock, got %v", o)
+	}
+	if !seen.Covers(clock) {
+		t.Fatalf("expected subscriber to have seen everything up to %v, seen %v", clock, seen)
+	}
+	if o := r1.Clock().Compare(clock); o != core.ClockBefore {
+		t.Fatalf("expected first record clock before thread clock, got %v", o)
+	}
+	other := core.VectorClock{r1.LogID(): 3}
+	if o := other.Compare(clock); o != core.ClockConcurrent {
+		t.Fatalf("expected concurrent clocks, got %v", o)
+	}
+}
+
+func TestNet_RecordPriority(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr := ma.StringCast("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	synced := func(r core.ThreadRecord) func() bool {
+		return func() bool {
+			lg, err := n2.Store().GetLog(info.ID, r.LogID())
+			return err == nil && lg.Head.ID.Equals(r.Value().Cid())
+		}
+	}
+
+	// low priority records are queued for the next batch
+	r1, err := n1.CreateRecord(ctx, info.ID, body, core.WithRecordPriority(core.PriorityLow))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n1.server.batcher.lock.Lock()
+	queued := len(n1.server.batcher.pending[info.ID])
+	n1.server.batcher.lock.Unlock()
+	if queued != 1 {
+		t.Fatalf("expected low priority record to be queued, got %d queued records", queued)
+	}
+	waitFor(t, synced(r1))
+
+	r2, err := n1.CreateRecord(ctx, info.ID, body, core.WithRecordPriority(core.PriorityHigh))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, synced(r2))
 }
@@ -0,0 +1,153 @@
+package net
+
+import (
+	"context"
+	"sync"
+
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// metaSyncPaused is the metadata key of the sync pause flag of a thread.
+const metaSyncPaused = "sync:paused"
+
+func (n *net) PauseSync(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	if err := n.setSyncPaused(id, true, opts...); err != nil {
+		return err
+	}
+	select {
+	case <-n.syncs.drained(id):
+		return nil
+	case <-ctx.Done():
+		// the thread stays paused, calls in flight finish on their own
+		return ctx.Err()
+	}
+}
+
+func (n *net) ResumeSync(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	return n.setSyncPaused(id, false, opts...)
+}
+
+// setSyncPaused persists the pause flag of a thread and joins or leaves its topic.
+func (n *net) setSyncPaused(id thread.ID, paused bool, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if err := n.store.PutBool(id, metaSyncPaused, paused); err != nil {
+		return err
+	}
+	if paused {
+		n.syncs.pause(id)
+	} else {
+		n.syncs.resume(id)
+	}
+	if n.server.ps == nil {
+		return nil
+	}
+	if paused {
+		return n.server.ps.Remove(id)
+	}
+	return n.server.ps.Add(id)
+}
+
+// syncGate tracks the threads with paused sync and the sync calls in flight of each thread,
+// so pausing a thread can wait until its calls are drained.
+type syncGate struct {
+	lock     sync.Mutex
+	paused   map[thread.ID]struct{}
+	inflight map[thread.ID]int
+	// idle are closed once the calls in flight of paused threads are drained
+	idle map[thread.ID]chan struct{}
+}
+
+// newSyncGate loads the threads with paused sync of a logstore.
+func newSyncGate(ls lstore.Logstore) (*syncGate, error) {
+	g := &syncGate{
+		paused:   make(map[thread.ID]struct{}),
+		inflight: make(map[thread.ID]int),
+		idle:     make(map[thread.ID]chan struct{}),
+	}
+	tids, err := ls.Threads()
+	if err != nil {
+		return nil, err
+	}
+	for _, tid := range tids {
+		paused, err := ls.GetBool(tid, metaSyncPaused)
+		if err != nil {
+			return nil, err
+		} else if paused != nil && *paused {
+			g.paused[tid] = struct{}{}
+		}
+	}
+	return g, nil
+}
+
+// enter starts a sync call of a thread. It returns false if the thread sync is paused,
+// otherwise the call must be finished with leave.
+func (g *syncGate) enter(tid thread.ID) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if _, ok := g.paused[tid]; ok {
+		return false
+	}
+	g.inflight[tid]++
+	return true
+}
+
+func (g *syncGate) leave(tid thread.ID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.inflight[tid]--; g.inflight[tid] > 0 {
+		return
+	}
+	delete(g.inflight, tid)
+	if idle, ok := g.idle[tid]; ok {
+		close(idle)
+		delete(g.idle, tid)
+	}
+}
+
+// isPaused returns whether the thread sync is paused.
+func (g *syncGate) isPaused(tid thread.ID) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	_, ok := g.paused[tid]
+	return ok
+}
+
+func (g *syncGate) pause(tid thread.ID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.paused[tid] = struct{}{}
+}
+
+func (g *syncGate) resume(tid thread.ID) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.paused, tid)
+}
+
+// drained returns a channel closed once the thread has no sync calls in flight.
+func (g *syncGate) drained(tid thread.ID) <-chan struct{} {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.inflight[tid] == 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	idle, ok := g.idle[tid]
+	if !ok {
+		idle = make(chan struct{})
+		g.idle[tid] = idle
+	}
+	return idle
+}
@@ -0,0 +1,91 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_PauseSync(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// calls in flight are drained
+	if !n2.syncs.enter(info.ID) {
+		t.Fatal("expected sync call to be allowed")
+	}
+	tctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cancel()
+	if err = n2.PauseSync(tctx, info.ID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected pause to wait for the call in flight, got %v", err)
+	}
+	n2.syncs.leave(info.ID)
+	if err = n2.PauseSync(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if n2.syncs.enter(info.ID) {
+		t.Fatal("expected sync call to be rejected")
+	}
+
+	r1, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); !errors.Is(err, core.ErrSyncPaused) {
+		t.Fatalf("expected paused thread not to be pulled, got %v", err)
+	}
+	// paused threads stay writable
+	r2, err := n2.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 200)
+	if pushed, err := n1.isKnown(r2.Value().Cid()); err != nil || pushed {
+		t.Fatalf("expected record not to be pushed, got %v", err)
+	}
+
+	// the pause persists across restarts
+	gate, err := newSyncGate(n2.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gate.isPaused(info.ID) {
+		t.Fatal("expected thread sync to be paused after a restart")
+	}
+
+	if err = n2.ResumeSync(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if pulled, err := n2.isKnown(r1.Value().Cid()); err != nil || !pulled {
+		t.Fatalf("expected record to be pulled after resuming, got %v", err)
+	}
+}
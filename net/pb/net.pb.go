@@ -27,6 +27,59 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type GetRecordsRequest_Direction int32
+
+const (
+	GetRecordsRequest_FORWARD  GetRecordsRequest_Direction = 0
+	GetRecordsRequest_BACKWARD GetRecordsRequest_Direction = 1
+)
+
+var GetRecordsRequest_Direction_name = map[int32]string{
+	0: "FORWARD",
+	1: "BACKWARD",
+}
+
+var GetRecordsRequest_Direction_value = map[string]int32{
+	"FORWARD":  0,
+	"BACKWARD": 1,
+}
+
+func (x GetRecordsRequest_Direction) String() string {
+	return proto.EnumName(GetRecordsRequest_Direction_name, int32(x))
+}
+
+func (GetRecordsRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{5, 0}
+}
+
+type Presence_Status int32
+
+const (
+	Presence_OFFLINE Presence_Status = 0
+	Presence_ONLINE  Presence_Status = 1
+	Presence_AWAY    Presence_Status = 2
+)
+
+var Presence_Status_name = map[int32]string{
+	0: "OFFLINE",
+	1: "ONLINE",
+	2: "AWAY",
+}
+
+var Presence_Status_value = map[string]int32{
+	"OFFLINE": 0,
+	"ONLINE":  1,
+	"AWAY":    2,
+}
+
+func (x Presence_Status) String() string {
+	return proto.EnumName(Presence_Status_name, int32(x))
+}
+
+func (Presence_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{15, 0}
+}
+
 // Log represents a thread log.
 type Log struct {
 	// ID of the log.
@@ -201,10 +254,11 @@ func (m *GetLogsRequest) GetBody() *GetLogsRequest_Body {
 }
 
 type GetLogsRequest_Body struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// serviceKey for the thread.
-	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	ThreadID   *ProtoThreadID                  `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	ServiceKey *ProtoKey                       `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	Logs       []*GetLogsRequest_Body_LogEntry `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+	Follows    []*Follow                       `protobuf:"bytes,4,rep,name=follows,proto3" json:"follows,omitempty"`
+	Freeze     *Freeze                         `protobuf:"bytes,5,opt,name=freeze,proto3" json:"freeze,omitempty"`
 }
 
 func (m *GetLogsRequest_Body) Reset()         { *m = GetLogsRequest_Body{} }
@@ -240,10 +294,79 @@ func (m *GetLogsRequest_Body) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetLogsRequest_Body proto.InternalMessageInfo
 
+func (m *GetLogsRequest_Body) GetLogs() []*GetLogsRequest_Body_LogEntry {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *GetLogsRequest_Body) GetFollows() []*Follow {
+	if m != nil {
+		return m.Follows
+	}
+	return nil
+}
+
+func (m *GetLogsRequest_Body) GetFreeze() *Freeze {
+	if m != nil {
+		return m.Freeze
+	}
+	return nil
+}
+
+type GetLogsRequest_Body_LogEntry struct {
+	// logID is the log's ID.
+	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// addressEdge is the hash of the log's addresses stored on the requester.
+	AddressEdge uint64 `protobuf:"varint,2,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
+}
+
+func (m *GetLogsRequest_Body_LogEntry) Reset()         { *m = GetLogsRequest_Body_LogEntry{} }
+func (m *GetLogsRequest_Body_LogEntry) String() string { return proto.CompactTextString(m) }
+func (*GetLogsRequest_Body_LogEntry) ProtoMessage()    {}
+func (*GetLogsRequest_Body_LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{1, 0, 0}
+}
+func (m *GetLogsRequest_Body_LogEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLogsRequest_Body_LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLogsRequest_Body_LogEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetLogsRequest_Body_LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsRequest_Body_LogEntry.Merge(m, src)
+}
+func (m *GetLogsRequest_Body_LogEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLogsRequest_Body_LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsRequest_Body_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsRequest_Body_LogEntry proto.InternalMessageInfo
+
+func (m *GetLogsRequest_Body_LogEntry) GetAddressEdge() uint64 {
+	if m != nil {
+		return m.AddressEdge
+	}
+	return 0
+}
+
 // GetLogsReply is the response from a GetLogsRequest.
 type GetLogsReply struct {
-	// logs are the result of the request.
-	Logs []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Logs    []*Log    `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Follows []*Follow `protobuf:"bytes,2,rep,name=follows,proto3" json:"follows,omitempty"`
+	Freeze  *Freeze   `protobuf:"bytes,3,opt,name=freeze,proto3" json:"freeze,omitempty"`
 }
 
 func (m *GetLogsReply) Reset()         { *m = GetLogsReply{} }
@@ -286,7 +409,20 @@ func (m *GetLogsReply) GetLogs() []*Log {
 	return nil
 }
 
-// PushLogRequest is used to push a thread log to a peer.
+func (m *GetLogsReply) GetFollows() []*Follow {
+	if m != nil {
+		return m.Follows
+	}
+	return nil
+}
+
+func (m *GetLogsReply) GetFreeze() *Freeze {
+	if m != nil {
+		return m.Freeze
+	}
+	return nil
+}
+
 type PushLogRequest struct {
 	// body is the message body.
 	Body *PushLogRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
@@ -333,14 +469,11 @@ func (m *PushLogRequest) GetBody() *PushLogRequest_Body {
 }
 
 type PushLogRequest_Body struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// serviceKey for the thread.
-	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
-	// readKey for the thread.
-	ReadKey *ProtoKey `protobuf:"bytes,3,opt,name=readKey,proto3,customtype=ProtoKey" json:"readKey,omitempty"`
-	// log is the actual log payload.
-	Log *Log `protobuf:"bytes,4,opt,name=log,proto3" json:"log,omitempty"`
+	ThreadID   *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	ServiceKey *ProtoKey      `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	ReadKey    *ProtoKey      `protobuf:"bytes,3,opt,name=readKey,proto3,customtype=ProtoKey" json:"readKey,omitempty"`
+	Log        *Log           `protobuf:"bytes,4,opt,name=log,proto3" json:"log,omitempty"`
+	Addrs      *LogAddrs      `protobuf:"bytes,5,opt,name=addrs,proto3" json:"addrs,omitempty"`
 }
 
 func (m *PushLogRequest_Body) Reset()         { *m = PushLogRequest_Body{} }
@@ -383,7 +516,13 @@ func (m *PushLogRequest_Body) GetLog() *Log {
 	return nil
 }
 
-// PushLogReply is the response from a PushLogRequest.
+func (m *PushLogRequest_Body) GetAddrs() *LogAddrs {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
 type PushLogReply struct {
 }
 
@@ -473,6 +612,8 @@ type GetRecordsRequest_Body struct {
 	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
 	// List of requested logs.
 	Logs []*GetRecordsRequest_Body_LogEntry `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+	// headersOnly requests records without their events, headers and bodies.
+	HeadersOnly bool `protobuf:"varint,4,opt,name=headersOnly,proto3" json:"headersOnly,omitempty"`
 }
 
 func (m *GetRecordsRequest_Body) Reset()         { *m = GetRecordsRequest_Body{} }
@@ -515,16 +656,21 @@ func (m *GetRecordsRequest_Body) GetLogs() []*GetRecordsRequest_Body_LogEntry {
 	return nil
 }
 
+func (m *GetRecordsRequest_Body) GetHeadersOnly() bool {
+	if m != nil {
+		return m.HeadersOnly
+	}
+	return false
+}
+
 // LogEntry represents a single log.
 type GetRecordsRequest_Body_LogEntry struct {
-	// logID of this entry.
-	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
-	// offset tells the recipient at which point to consider records new for the reply.
-	Offset *ProtoCid `protobuf:"bytes,2,opt,name=offset,proto3,customtype=ProtoCid" json:"offset,omitempty"`
-	// limit indicates the max number of records to return.
-	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	// counter indicates the position of record
-	Counter int64 `protobuf:"varint,4,opt,name=counter,proto3" json:"counter,omitempty"`
+	LogID     *ProtoPeerID                `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	Offset    *ProtoCid                   `protobuf:"bytes,2,opt,name=offset,proto3,customtype=ProtoCid" json:"offset,omitempty"`
+	Limit     int32                       `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Counter   int64                       `protobuf:"varint,4,opt,name=counter,proto3" json:"counter,omitempty"`
+	Direction GetRecordsRequest_Direction `protobuf:"varint,5,opt,name=direction,proto3,enum=net.pb.GetRecordsRequest_Direction" json:"direction,omitempty"`
+	Anchor    *ProtoCid                   `protobuf:"bytes,6,opt,name=anchor,proto3,customtype=ProtoCid" json:"anchor,omitempty"`
 }
 
 func (m *GetRecordsRequest_Body_LogEntry) Reset()         { *m = GetRecordsRequest_Body_LogEntry{} }
@@ -574,7 +720,13 @@ func (m *GetRecordsRequest_Body_LogEntry) GetCounter() int64 {
 	return 0
 }
 
-// GetRecordsReply contains records requested with a GetRecordsRequest.
+func (m *GetRecordsRequest_Body_LogEntry) GetDirection() GetRecordsRequest_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return GetRecordsRequest_FORWARD
+}
+
 type GetRecordsReply struct {
 	// records are the result of the request.
 	Logs []*GetRecordsReply_LogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
@@ -628,6 +780,10 @@ type GetRecordsReply_LogEntry struct {
 	Records []*Log_Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
 	// log contains new log info that was missing from the request.
 	Log *Log `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	// base is set if the log was pruned past the requested offset, e.g. by compaction.
+	// It's the pruned predecessor of the first record, which is a checkpoint the log
+	// is continued from.
+	Base *ProtoCid `protobuf:"bytes,4,opt,name=base,proto3,customtype=ProtoCid" json:"base,omitempty"`
 }
 
 func (m *GetRecordsReply_LogEntry) Reset()         { *m = GetRecordsReply_LogEntry{} }
@@ -677,26 +833,24 @@ func (m *GetRecordsReply_LogEntry) GetLog() *Log {
 	return nil
 }
 
-// PushRecordRequest is used to push a log record to a peer.
-type PushRecordRequest struct {
+// GetRecordsByCIDRequest is used to request specific records of thread logs.
+type GetRecordsByCIDRequest struct {
 	// body is the message body.
-	Body *PushRecordRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	// position of the record
-	Counter int64 `protobuf:"varint,3,opt,name=counter,proto3" json:"counter,omitempty"`
+	Body *GetRecordsByCIDRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 }
 
-func (m *PushRecordRequest) Reset()         { *m = PushRecordRequest{} }
-func (m *PushRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*PushRecordRequest) ProtoMessage()    {}
-func (*PushRecordRequest) Descriptor() ([]byte, []int) {
+func (m *GetRecordsByCIDRequest) Reset()         { *m = GetRecordsByCIDRequest{} }
+func (m *GetRecordsByCIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRecordsByCIDRequest) ProtoMessage()    {}
+func (*GetRecordsByCIDRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a5b10ce944527a32, []int{7}
 }
-func (m *PushRecordRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetRecordsByCIDRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PushRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetRecordsByCIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PushRecordRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetRecordsByCIDRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -706,53 +860,46 @@ func (m *PushRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *PushRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PushRecordRequest.Merge(m, src)
+func (m *GetRecordsByCIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordsByCIDRequest.Merge(m, src)
 }
-func (m *PushRecordRequest) XXX_Size() int {
+func (m *GetRecordsByCIDRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PushRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PushRecordRequest.DiscardUnknown(m)
+func (m *GetRecordsByCIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordsByCIDRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PushRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetRecordsByCIDRequest proto.InternalMessageInfo
 
-func (m *PushRecordRequest) GetBody() *PushRecordRequest_Body {
+func (m *GetRecordsByCIDRequest) GetBody() *GetRecordsByCIDRequest_Body {
 	if m != nil {
 		return m.Body
 	}
 	return nil
 }
 
-func (m *PushRecordRequest) GetCounter() int64 {
-	if m != nil {
-		return m.Counter
-	}
-	return 0
-}
-
-type PushRecordRequest_Body struct {
+type GetRecordsByCIDRequest_Body struct {
 	// threadID is the target thread's ID.
 	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// logID is the target log's ID.
-	LogID *ProtoPeerID `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
-	// record is the actual record payload.
-	Record *Log_Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	// serviceKey for the thread.
+	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	// List of requested logs.
+	Logs []*GetRecordsByCIDRequest_Body_LogEntry `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
 }
 
-func (m *PushRecordRequest_Body) Reset()         { *m = PushRecordRequest_Body{} }
-func (m *PushRecordRequest_Body) String() string { return proto.CompactTextString(m) }
-func (*PushRecordRequest_Body) ProtoMessage()    {}
-func (*PushRecordRequest_Body) Descriptor() ([]byte, []int) {
+func (m *GetRecordsByCIDRequest_Body) Reset()         { *m = GetRecordsByCIDRequest_Body{} }
+func (m *GetRecordsByCIDRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*GetRecordsByCIDRequest_Body) ProtoMessage()    {}
+func (*GetRecordsByCIDRequest_Body) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a5b10ce944527a32, []int{7, 0}
 }
-func (m *PushRecordRequest_Body) XXX_Unmarshal(b []byte) error {
+func (m *GetRecordsByCIDRequest_Body) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PushRecordRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetRecordsByCIDRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PushRecordRequest_Body.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetRecordsByCIDRequest_Body.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -762,41 +909,45 @@ func (m *PushRecordRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *PushRecordRequest_Body) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PushRecordRequest_Body.Merge(m, src)
+func (m *GetRecordsByCIDRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordsByCIDRequest_Body.Merge(m, src)
 }
-func (m *PushRecordRequest_Body) XXX_Size() int {
+func (m *GetRecordsByCIDRequest_Body) XXX_Size() int {
 	return m.Size()
 }
-func (m *PushRecordRequest_Body) XXX_DiscardUnknown() {
-	xxx_messageInfo_PushRecordRequest_Body.DiscardUnknown(m)
+func (m *GetRecordsByCIDRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordsByCIDRequest_Body.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PushRecordRequest_Body proto.InternalMessageInfo
+var xxx_messageInfo_GetRecordsByCIDRequest_Body proto.InternalMessageInfo
 
-func (m *PushRecordRequest_Body) GetRecord() *Log_Record {
+func (m *GetRecordsByCIDRequest_Body) GetLogs() []*GetRecordsByCIDRequest_Body_LogEntry {
 	if m != nil {
-		return m.Record
+		return m.Logs
 	}
 	return nil
 }
 
-// PushRecordReply is the response from a PushRecordRequest.
-type PushRecordReply struct {
+// LogEntry represents the requested records of a single log.
+type GetRecordsByCIDRequest_Body_LogEntry struct {
+	// logID of this entry.
+	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// recordIDs are the CIDs of the requested records.
+	RecordIDs []ProtoCid `protobuf:"bytes,2,rep,name=recordIDs,proto3,customtype=ProtoCid" json:"recordIDs,omitempty"`
 }
 
-func (m *PushRecordReply) Reset()         { *m = PushRecordReply{} }
-func (m *PushRecordReply) String() string { return proto.CompactTextString(m) }
-func (*PushRecordReply) ProtoMessage()    {}
-func (*PushRecordReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a5b10ce944527a32, []int{8}
+func (m *GetRecordsByCIDRequest_Body_LogEntry) Reset()         { *m = GetRecordsByCIDRequest_Body_LogEntry{} }
+func (m *GetRecordsByCIDRequest_Body_LogEntry) String() string { return proto.CompactTextString(m) }
+func (*GetRecordsByCIDRequest_Body_LogEntry) ProtoMessage()    {}
+func (*GetRecordsByCIDRequest_Body_LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{7, 0, 0}
 }
-func (m *PushRecordReply) XXX_Unmarshal(b []byte) error {
+func (m *GetRecordsByCIDRequest_Body_LogEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PushRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetRecordsByCIDRequest_Body_LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PushRecordReply.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetRecordsByCIDRequest_Body_LogEntry.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -806,36 +957,37 @@ func (m *PushRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *PushRecordReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PushRecordReply.Merge(m, src)
+func (m *GetRecordsByCIDRequest_Body_LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordsByCIDRequest_Body_LogEntry.Merge(m, src)
 }
-func (m *PushRecordReply) XXX_Size() int {
+func (m *GetRecordsByCIDRequest_Body_LogEntry) XXX_Size() int {
 	return m.Size()
 }
-func (m *PushRecordReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_PushRecordReply.DiscardUnknown(m)
+func (m *GetRecordsByCIDRequest_Body_LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordsByCIDRequest_Body_LogEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PushRecordReply proto.InternalMessageInfo
+var xxx_messageInfo_GetRecordsByCIDRequest_Body_LogEntry proto.InternalMessageInfo
 
-// ExchangeEdgesRequest is used to exchange address/heads edges with a peer.
-type ExchangeEdgesRequest struct {
-	// body is the message body.
-	Body *ExchangeEdgesRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+// GetRecordsByCIDReply contains records requested with a GetRecordsByCIDRequest.
+// Records unknown to the respondent are omitted.
+type GetRecordsByCIDReply struct {
+	// records are the result of the request.
+	Logs []*GetRecordsByCIDReply_LogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
 }
 
-func (m *ExchangeEdgesRequest) Reset()         { *m = ExchangeEdgesRequest{} }
-func (m *ExchangeEdgesRequest) String() string { return proto.CompactTextString(m) }
-func (*ExchangeEdgesRequest) ProtoMessage()    {}
-func (*ExchangeEdgesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a5b10ce944527a32, []int{9}
+func (m *GetRecordsByCIDReply) Reset()         { *m = GetRecordsByCIDReply{} }
+func (m *GetRecordsByCIDReply) String() string { return proto.CompactTextString(m) }
+func (*GetRecordsByCIDReply) ProtoMessage()    {}
+func (*GetRecordsByCIDReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{8}
 }
-func (m *ExchangeEdgesRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetRecordsByCIDReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExchangeEdgesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetRecordsByCIDReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExchangeEdgesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetRecordsByCIDReply.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -845,42 +997,47 @@ func (m *ExchangeEdgesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *ExchangeEdgesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExchangeEdgesRequest.Merge(m, src)
+func (m *GetRecordsByCIDReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordsByCIDReply.Merge(m, src)
 }
-func (m *ExchangeEdgesRequest) XXX_Size() int {
+func (m *GetRecordsByCIDReply) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExchangeEdgesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExchangeEdgesRequest.DiscardUnknown(m)
+func (m *GetRecordsByCIDReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordsByCIDReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExchangeEdgesRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetRecordsByCIDReply proto.InternalMessageInfo
 
-func (m *ExchangeEdgesRequest) GetBody() *ExchangeEdgesRequest_Body {
+func (m *GetRecordsByCIDReply) GetLogs() []*GetRecordsByCIDReply_LogEntry {
 	if m != nil {
-		return m.Body
+		return m.Logs
 	}
 	return nil
 }
 
-type ExchangeEdgesRequest_Body struct {
-	// threads is a list of requested thread IDs with its local edges.
-	Threads []*ExchangeEdgesRequest_Body_ThreadEntry `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+// LogEntry represents a single log.
+type GetRecordsByCIDReply_LogEntry struct {
+	// logID of this entry.
+	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// records returned for this entry, each signed by the log key.
+	Records []*Log_Record `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	// proofs link the records to the log head, in the order of records.
+	Proofs []*QueryRecordsReply_Proof `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty"`
 }
 
-func (m *ExchangeEdgesRequest_Body) Reset()         { *m = ExchangeEdgesRequest_Body{} }
-func (m *ExchangeEdgesRequest_Body) String() string { return proto.CompactTextString(m) }
-func (*ExchangeEdgesRequest_Body) ProtoMessage()    {}
-func (*ExchangeEdgesRequest_Body) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a5b10ce944527a32, []int{9, 0}
+func (m *GetRecordsByCIDReply_LogEntry) Reset()         { *m = GetRecordsByCIDReply_LogEntry{} }
+func (m *GetRecordsByCIDReply_LogEntry) String() string { return proto.CompactTextString(m) }
+func (*GetRecordsByCIDReply_LogEntry) ProtoMessage()    {}
+func (*GetRecordsByCIDReply_LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{8, 0}
 }
-func (m *ExchangeEdgesRequest_Body) XXX_Unmarshal(b []byte) error {
+func (m *GetRecordsByCIDReply_LogEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExchangeEdgesRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetRecordsByCIDReply_LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExchangeEdgesRequest_Body.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetRecordsByCIDReply_LogEntry.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -890,46 +1047,52 @@ func (m *ExchangeEdgesRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([
 		return b[:n], nil
 	}
 }
-func (m *ExchangeEdgesRequest_Body) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExchangeEdgesRequest_Body.Merge(m, src)
+func (m *GetRecordsByCIDReply_LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecordsByCIDReply_LogEntry.Merge(m, src)
 }
-func (m *ExchangeEdgesRequest_Body) XXX_Size() int {
+func (m *GetRecordsByCIDReply_LogEntry) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExchangeEdgesRequest_Body) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExchangeEdgesRequest_Body.DiscardUnknown(m)
+func (m *GetRecordsByCIDReply_LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecordsByCIDReply_LogEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExchangeEdgesRequest_Body proto.InternalMessageInfo
+var xxx_messageInfo_GetRecordsByCIDReply_LogEntry proto.InternalMessageInfo
 
-func (m *ExchangeEdgesRequest_Body) GetThreads() []*ExchangeEdgesRequest_Body_ThreadEntry {
+func (m *GetRecordsByCIDReply_LogEntry) GetRecords() []*Log_Record {
 	if m != nil {
-		return m.Threads
+		return m.Records
 	}
 	return nil
 }
 
-type ExchangeEdgesRequest_Body_ThreadEntry struct {
-	// threadID is the target thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// addressEdge is the current hash of peers addresses stored on a requester.
-	AddressEdge uint64 `protobuf:"varint,2,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
-	// headsEdge is the current hash of the log's heads stored on a requester.
-	HeadsEdge uint64 `protobuf:"varint,3,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
+func (m *GetRecordsByCIDReply_LogEntry) GetProofs() []*QueryRecordsReply_Proof {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
 }
 
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) Reset()         { *m = ExchangeEdgesRequest_Body_ThreadEntry{} }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) String() string { return proto.CompactTextString(m) }
-func (*ExchangeEdgesRequest_Body_ThreadEntry) ProtoMessage()    {}
-func (*ExchangeEdgesRequest_Body_ThreadEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a5b10ce944527a32, []int{9, 0, 0}
+// PushRecordRequest is used to push a log record to a peer.
+type PushRecordRequest struct {
+	// body is the message body.
+	Body *PushRecordRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// position of the record
+	Counter int64 `protobuf:"varint,3,opt,name=counter,proto3" json:"counter,omitempty"`
 }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Unmarshal(b []byte) error {
+
+func (m *PushRecordRequest) Reset()         { *m = PushRecordRequest{} }
+func (m *PushRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*PushRecordRequest) ProtoMessage()    {}
+func (*PushRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{9}
+}
+func (m *PushRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PushRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PushRecordRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -939,14 +1102,262 @@ func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Marshal(b []byte, determinis
 		return b[:n], nil
 	}
 }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry.Merge(m, src)
+func (m *PushRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushRecordRequest.Merge(m, src)
 }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Size() int {
+func (m *PushRecordRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry.DiscardUnknown(m)
+func (m *PushRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushRecordRequest proto.InternalMessageInfo
+
+func (m *PushRecordRequest) GetBody() *PushRecordRequest_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *PushRecordRequest) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+type PushRecordRequest_Body struct {
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// logID is the target log's ID.
+	LogID *ProtoPeerID `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// record is the actual record payload.
+	Record *Log_Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (m *PushRecordRequest_Body) Reset()         { *m = PushRecordRequest_Body{} }
+func (m *PushRecordRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*PushRecordRequest_Body) ProtoMessage()    {}
+func (*PushRecordRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{9, 0}
+}
+func (m *PushRecordRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushRecordRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushRecordRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PushRecordRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushRecordRequest_Body.Merge(m, src)
+}
+func (m *PushRecordRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *PushRecordRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushRecordRequest_Body.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushRecordRequest_Body proto.InternalMessageInfo
+
+func (m *PushRecordRequest_Body) GetRecord() *Log_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+// PushRecordReply is the response from a PushRecordRequest.
+type PushRecordReply struct {
+}
+
+func (m *PushRecordReply) Reset()         { *m = PushRecordReply{} }
+func (m *PushRecordReply) String() string { return proto.CompactTextString(m) }
+func (*PushRecordReply) ProtoMessage()    {}
+func (*PushRecordReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{10}
+}
+func (m *PushRecordReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushRecordReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushRecordReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PushRecordReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushRecordReply.Merge(m, src)
+}
+func (m *PushRecordReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *PushRecordReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushRecordReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushRecordReply proto.InternalMessageInfo
+
+// ExchangeEdgesRequest is used to exchange address/heads edges with a peer.
+type ExchangeEdgesRequest struct {
+	// body is the message body.
+	Body *ExchangeEdgesRequest_Body `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (m *ExchangeEdgesRequest) Reset()         { *m = ExchangeEdgesRequest{} }
+func (m *ExchangeEdgesRequest) String() string { return proto.CompactTextString(m) }
+func (*ExchangeEdgesRequest) ProtoMessage()    {}
+func (*ExchangeEdgesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{11}
+}
+func (m *ExchangeEdgesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExchangeEdgesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExchangeEdgesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExchangeEdgesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeEdgesRequest.Merge(m, src)
+}
+func (m *ExchangeEdgesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExchangeEdgesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeEdgesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeEdgesRequest proto.InternalMessageInfo
+
+func (m *ExchangeEdgesRequest) GetBody() *ExchangeEdgesRequest_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+type ExchangeEdgesRequest_Body struct {
+	Threads       []*ExchangeEdgesRequest_Body_ThreadEntry `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+	MaxRecordSize int64                                    `protobuf:"varint,2,opt,name=maxRecordSize,proto3" json:"maxRecordSize,omitempty"`
+	GossipPeers   int32                                    `protobuf:"varint,3,opt,name=gossipPeers,proto3" json:"gossipPeers,omitempty"`
+}
+
+func (m *ExchangeEdgesRequest_Body) Reset()         { *m = ExchangeEdgesRequest_Body{} }
+func (m *ExchangeEdgesRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*ExchangeEdgesRequest_Body) ProtoMessage()    {}
+func (*ExchangeEdgesRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{11, 0}
+}
+func (m *ExchangeEdgesRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExchangeEdgesRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExchangeEdgesRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExchangeEdgesRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeEdgesRequest_Body.Merge(m, src)
+}
+func (m *ExchangeEdgesRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExchangeEdgesRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeEdgesRequest_Body.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeEdgesRequest_Body proto.InternalMessageInfo
+
+func (m *ExchangeEdgesRequest_Body) GetThreads() []*ExchangeEdgesRequest_Body_ThreadEntry {
+	if m != nil {
+		return m.Threads
+	}
+	return nil
+}
+
+func (m *ExchangeEdgesRequest_Body) GetMaxRecordSize() int64 {
+	if m != nil {
+		return m.MaxRecordSize
+	}
+	return 0
+}
+
+func (m *ExchangeEdgesRequest_Body) GetGossipPeers() int32 {
+	if m != nil {
+		return m.GossipPeers
+	}
+	return 0
+}
+
+type ExchangeEdgesRequest_Body_ThreadEntry struct {
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// addressEdge is the current hash of peers addresses stored on a requester.
+	AddressEdge uint64 `protobuf:"varint,2,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
+	// headsEdge is the current hash of the log's heads stored on a requester.
+	HeadsEdge uint64 `protobuf:"varint,3,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
+}
+
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) Reset()         { *m = ExchangeEdgesRequest_Body_ThreadEntry{} }
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) String() string { return proto.CompactTextString(m) }
+func (*ExchangeEdgesRequest_Body_ThreadEntry) ProtoMessage()    {}
+func (*ExchangeEdgesRequest_Body_ThreadEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{11, 0, 0}
+}
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry.Merge(m, src)
+}
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry.DiscardUnknown(m)
 }
 
 var xxx_messageInfo_ExchangeEdgesRequest_Body_ThreadEntry proto.InternalMessageInfo
@@ -969,13 +1380,15 @@ func (m *ExchangeEdgesRequest_Body_ThreadEntry) GetHeadsEdge() uint64 {
 type ExchangeEdgesReply struct {
 	// edges contains edge information about requested threads.
 	Edges []*ExchangeEdgesReply_ThreadEdges `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// maxRecordSize is the maximum size of records accepted by the respondent.
+	MaxRecordSize int64 `protobuf:"varint,2,opt,name=maxRecordSize,proto3" json:"maxRecordSize,omitempty"`
 }
 
 func (m *ExchangeEdgesReply) Reset()         { *m = ExchangeEdgesReply{} }
 func (m *ExchangeEdgesReply) String() string { return proto.CompactTextString(m) }
 func (*ExchangeEdgesReply) ProtoMessage()    {}
 func (*ExchangeEdgesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a5b10ce944527a32, []int{10}
+	return fileDescriptor_a5b10ce944527a32, []int{12}
 }
 func (m *ExchangeEdgesReply) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1011,22 +1424,28 @@ func (m *ExchangeEdgesReply) GetEdges() []*ExchangeEdgesReply_ThreadEdges {
 	return nil
 }
 
+func (m *ExchangeEdgesReply) GetMaxRecordSize() int64 {
+	if m != nil {
+		return m.MaxRecordSize
+	}
+	return 0
+}
+
 type ExchangeEdgesReply_ThreadEdges struct {
-	// threadID is the requested thread's ID.
-	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
-	// deprecated, use default values for addressEdge and headsEdge
-	Exists bool `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
-	// addressEdge is the current hash of peers addresses stored on a respondent.
-	AddressEdge uint64 `protobuf:"varint,3,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
-	// headsEdge is the current hash of the log's heads stored on a respondent.
-	HeadsEdge uint64 `protobuf:"varint,4,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
+	ThreadID    *ProtoThreadID                   `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	Exists      bool                             `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	AddressEdge uint64                           `protobuf:"varint,3,opt,name=addressEdge,proto3" json:"addressEdge,omitempty"`
+	HeadsEdge   uint64                           `protobuf:"varint,4,opt,name=headsEdge,proto3" json:"headsEdge,omitempty"`
+	Logs        []byte                           `protobuf:"bytes,5,opt,name=logs,proto3" json:"logs,omitempty"`
+	Heads       []*GetRecordsByCIDReply_LogEntry `protobuf:"bytes,6,rep,name=heads,proto3" json:"heads,omitempty"`
+	Peers       []byte                           `protobuf:"bytes,7,opt,name=peers,proto3" json:"peers,omitempty"`
 }
 
 func (m *ExchangeEdgesReply_ThreadEdges) Reset()         { *m = ExchangeEdgesReply_ThreadEdges{} }
 func (m *ExchangeEdgesReply_ThreadEdges) String() string { return proto.CompactTextString(m) }
 func (*ExchangeEdgesReply_ThreadEdges) ProtoMessage()    {}
 func (*ExchangeEdgesReply_ThreadEdges) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a5b10ce944527a32, []int{10, 0}
+	return fileDescriptor_a5b10ce944527a32, []int{12, 0}
 }
 func (m *ExchangeEdgesReply_ThreadEdges) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1076,2027 +1495,13229 @@ func (m *ExchangeEdgesReply_ThreadEdges) GetHeadsEdge() uint64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*Log)(nil), "net.pb.Log")
-	proto.RegisterType((*Log_Record)(nil), "net.pb.Log.Record")
-	proto.RegisterType((*GetLogsRequest)(nil), "net.pb.GetLogsRequest")
-	proto.RegisterType((*GetLogsRequest_Body)(nil), "net.pb.GetLogsRequest.Body")
-	proto.RegisterType((*GetLogsReply)(nil), "net.pb.GetLogsReply")
-	proto.RegisterType((*PushLogRequest)(nil), "net.pb.PushLogRequest")
-	proto.RegisterType((*PushLogRequest_Body)(nil), "net.pb.PushLogRequest.Body")
-	proto.RegisterType((*PushLogReply)(nil), "net.pb.PushLogReply")
-	proto.RegisterType((*GetRecordsRequest)(nil), "net.pb.GetRecordsRequest")
-	proto.RegisterType((*GetRecordsRequest_Body)(nil), "net.pb.GetRecordsRequest.Body")
-	proto.RegisterType((*GetRecordsRequest_Body_LogEntry)(nil), "net.pb.GetRecordsRequest.Body.LogEntry")
-	proto.RegisterType((*GetRecordsReply)(nil), "net.pb.GetRecordsReply")
-	proto.RegisterType((*GetRecordsReply_LogEntry)(nil), "net.pb.GetRecordsReply.LogEntry")
-	proto.RegisterType((*PushRecordRequest)(nil), "net.pb.PushRecordRequest")
-	proto.RegisterType((*PushRecordRequest_Body)(nil), "net.pb.PushRecordRequest.Body")
-	proto.RegisterType((*PushRecordReply)(nil), "net.pb.PushRecordReply")
-	proto.RegisterType((*ExchangeEdgesRequest)(nil), "net.pb.ExchangeEdgesRequest")
-	proto.RegisterType((*ExchangeEdgesRequest_Body)(nil), "net.pb.ExchangeEdgesRequest.Body")
-	proto.RegisterType((*ExchangeEdgesRequest_Body_ThreadEntry)(nil), "net.pb.ExchangeEdgesRequest.Body.ThreadEntry")
-	proto.RegisterType((*ExchangeEdgesReply)(nil), "net.pb.ExchangeEdgesReply")
-	proto.RegisterType((*ExchangeEdgesReply_ThreadEdges)(nil), "net.pb.ExchangeEdgesReply.ThreadEdges")
+func (m *ExchangeEdgesReply_ThreadEdges) GetLogs() []byte {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("net.proto", fileDescriptor_a5b10ce944527a32) }
-
-var fileDescriptor_a5b10ce944527a32 = []byte{
-	// 912 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x3b, 0x8f, 0x23, 0x45,
-	0x10, 0x76, 0xcf, 0x8c, 0x1f, 0x5b, 0xf6, 0xee, 0xe2, 0xd6, 0xea, 0x18, 0x86, 0x63, 0x3c, 0x18,
-	0xb8, 0xb3, 0xd0, 0xad, 0x2d, 0x19, 0x08, 0x10, 0x24, 0x98, 0x5d, 0xad, 0x96, 0x5b, 0xa1, 0x55,
-	0xc3, 0x1f, 0xb0, 0x3d, 0xbd, 0x63, 0x4b, 0x3e, 0xb7, 0x99, 0x19, 0x9f, 0xce, 0x12, 0x22, 0x20,
-	0x81, 0x90, 0x80, 0x7f, 0x40, 0x86, 0x48, 0xc9, 0xc9, 0x20, 0x41, 0xba, 0x80, 0x00, 0x39, 0xb0,
-	0xc0, 0x1b, 0x91, 0x22, 0x02, 0x44, 0x84, 0xfa, 0x31, 0x2f, 0xbf, 0x4e, 0x7b, 0xc1, 0x66, 0xae,
-	0xfa, 0xaa, 0x7a, 0xea, 0xab, 0xfa, 0xaa, 0xdb, 0xb0, 0x37, 0xa6, 0x61, 0x73, 0xe2, 0xb3, 0x90,
-	0xe1, 0x82, 0xf8, 0xd9, 0xb3, 0x8e, 0xbd, 0x61, 0x38, 0x98, 0xf6, 0x9a, 0x7d, 0xf6, 0xa8, 0xe5,
-	0x31, 0x8f, 0xb5, 0x04, 0xdc, 0x9b, 0x5e, 0x09, 0x4b, 0x18, 0xe2, 0x97, 0x4c, 0xab, 0xff, 0xa8,
-	0x81, 0x7e, 0xc1, 0x3c, 0x5c, 0x03, 0xed, 0xfc, 0xc4, 0x44, 0x0e, 0x6a, 0x54, 0x3a, 0x87, 0xf3,
-	0x45, 0xad, 0x7c, 0xc9, 0xe1, 0x4b, 0x4a, 0xfd, 0xf3, 0x13, 0xa2, 0x9d, 0x9f, 0xe0, 0xfb, 0x50,
-	0x98, 0x4c, 0x7b, 0x0f, 0xe9, 0xcc, 0xd4, 0x56, 0x83, 0x84, 0x9b, 0x28, 0x18, 0xbf, 0x06, 0xf9,
-	0xae, 0xeb, 0xfa, 0x81, 0xa9, 0x3b, 0x7a, 0xa3, 0xd2, 0xd9, 0x9f, 0x2f, 0x6a, 0x7b, 0x22, 0xee,
-	0x03, 0xd7, 0xf5, 0x89, 0xc4, 0xb0, 0x03, 0xc6, 0x80, 0x76, 0x5d, 0xd3, 0x10, 0x67, 0x55, 0xe6,
-	0x8b, 0x5a, 0x49, 0xc4, 0x7c, 0x38, 0x74, 0x89, 0x40, 0xb0, 0x09, 0xc5, 0x3e, 0x9b, 0x8e, 0x43,
-	0xea, 0x9b, 0x79, 0x07, 0x35, 0x74, 0x12, 0x99, 0xd6, 0x97, 0x08, 0x0a, 0x84, 0xf6, 0x99, 0xef,
-	0x62, 0x1b, 0xc0, 0x17, 0xbf, 0x3e, 0x66, 0x2e, 0x95, 0xd5, 0x93, 0x94, 0x07, 0xdf, 0x85, 0x3d,
-	0xfa, 0x98, 0x8e, 0x43, 0x01, 0x8b, 0xba, 0x49, 0xe2, 0xe0, 0xd9, 0xfc, 0x53, 0xd4, 0x17, 0xb0,
-	0x2e, 0xb3, 0x13, 0x0f, 0xb6, 0xa0, 0xd4, 0x63, 0xee, 0x4c, 0xa0, 0xa2, 0x50, 0x12, 0xdb, 0xf5,
-	0x1f, 0x10, 0x1c, 0x9c, 0xd1, 0xf0, 0x82, 0x79, 0x01, 0xa1, 0x9f, 0x4d, 0x69, 0x10, 0xe2, 0x16,
-	0x18, 0x1c, 0x16, 0xdf, 0x29, 0xb7, 0x5f, 0x6e, 0xca, 0x81, 0x34, 0xb3, 0x51, 0xcd, 0x0e, 0x73,
-	0x67, 0x44, 0x04, 0x5a, 0x7d, 0x30, 0xb8, 0x85, 0x8f, 0xa1, 0x14, 0x0e, 0x7c, 0xda, 0x75, 0xe3,
-	0x09, 0x54, 0xe7, 0x8b, 0xda, 0xbe, 0x68, 0xc8, 0xa7, 0x0a, 0x20, 0x71, 0x08, 0x7e, 0x00, 0x10,
-	0x50, 0xff, 0xf1, 0xb0, 0x4f, 0x93, 0x69, 0x24, 0x1d, 0xe4, 0xa3, 0x48, 0xe1, 0x1f, 0x19, 0x25,
-	0xf4, 0x82, 0x56, 0x6f, 0x41, 0x25, 0xae, 0x63, 0x32, 0x9a, 0xe1, 0x1a, 0x18, 0x23, 0xe6, 0x05,
-	0x26, 0x72, 0xf4, 0x46, 0xb9, 0x5d, 0x8e, 0x6a, 0xbd, 0x60, 0x1e, 0x11, 0x40, 0xfd, 0x1f, 0x04,
-	0x07, 0x97, 0xd3, 0x60, 0xc0, 0x3d, 0xbb, 0xf9, 0x65, 0xa3, 0xd2, 0xfc, 0xbe, 0x47, 0xb7, 0x40,
-	0x10, 0xdf, 0x83, 0x22, 0xcf, 0xe3, 0xa1, 0xfa, 0x86, 0xd0, 0x08, 0xc4, 0xaf, 0x80, 0x3e, 0x62,
-	0x9e, 0x18, 0xe4, 0x0a, 0x63, 0xee, 0x57, 0x7d, 0x3a, 0x80, 0x4a, 0xcc, 0x67, 0x32, 0x9a, 0xd5,
-	0xff, 0xd3, 0xa0, 0x7a, 0x46, 0x43, 0x29, 0xb7, 0x78, 0xd2, 0xed, 0x4c, 0x27, 0xec, 0xd4, 0xa4,
-	0xb3, 0x81, 0x99, 0x66, 0x68, 0xb7, 0xd1, 0x8c, 0xf7, 0xd4, 0x5c, 0x75, 0x31, 0xd7, 0xfb, 0xbb,
-	0x2b, 0xe3, 0xe4, 0x4f, 0xc7, 0xa1, 0x3f, 0x93, 0x33, 0xb7, 0xbe, 0x42, 0x50, 0x8a, 0x5c, 0xf8,
-	0x0d, 0xc8, 0x8f, 0x98, 0xb7, 0xfd, 0x4e, 0x90, 0x28, 0x7e, 0x1d, 0x0a, 0xec, 0xea, 0x2a, 0xa0,
-	0xe1, 0x5a, 0x69, 0x7c, 0x95, 0x15, 0x86, 0x8f, 0x20, 0x3f, 0x1a, 0x3e, 0x1a, 0x86, 0x62, 0x42,
-	0x79, 0x22, 0x8d, 0xf4, 0x8a, 0x1b, 0x99, 0x15, 0x57, 0xc3, 0xf8, 0x19, 0xc1, 0x61, 0xba, 0x72,
-	0x2e, 0xdc, 0xb7, 0x33, 0xc2, 0x75, 0x36, 0x11, 0x9c, 0x8c, 0xd6, 0x98, 0x7d, 0x71, 0x73, 0x62,
-	0x0f, 0xb8, 0xac, 0xc4, 0x89, 0xa6, 0x26, 0xbe, 0x85, 0x53, 0x92, 0x69, 0xca, 0x8f, 0x91, 0x28,
-	0x24, 0x12, 0x97, 0xbe, 0x59, 0x5c, 0xf5, 0xbf, 0x11, 0x54, 0xb9, 0xae, 0x54, 0xda, 0x6e, 0x19,
-	0xad, 0x05, 0xa6, 0x64, 0x94, 0xee, 0x99, 0x9e, 0xbd, 0x16, 0xbf, 0x7e, 0xce, 0x6d, 0x8b, 0xfb,
-	0xa1, 0xed, 0xec, 0xc7, 0x9b, 0x50, 0x90, 0x64, 0x15, 0xc9, 0x4d, 0xed, 0x50, 0x11, 0x6a, 0x7c,
-	0x55, 0x38, 0x4c, 0x53, 0xe1, 0xeb, 0xf4, 0x9d, 0x06, 0x47, 0xa7, 0x4f, 0xfa, 0x83, 0xee, 0xd8,
-	0xa3, 0xa7, 0xae, 0x47, 0xe3, 0x8d, 0x7a, 0x27, 0xd3, 0x8a, 0x57, 0xa3, 0xb3, 0x37, 0xc5, 0xa6,
-	0x97, 0xea, 0xd7, 0x88, 0xf3, 0x19, 0x14, 0x25, 0xa1, 0x48, 0x19, 0xc7, 0xcf, 0x3c, 0xa2, 0x29,
-	0x7b, 0x21, 0x65, 0x12, 0x65, 0x5b, 0x9f, 0x43, 0x39, 0xe5, 0xbf, 0x69, 0x2f, 0x1d, 0x28, 0xf3,
-	0xf7, 0x8d, 0x06, 0x01, 0xff, 0x9c, 0x60, 0x63, 0x90, 0xb4, 0x8b, 0xbf, 0x48, 0xfc, 0x85, 0x91,
-	0xb8, 0x2e, 0xf0, 0xc4, 0xa1, 0x1a, 0xf7, 0x17, 0x02, 0xbc, 0x52, 0x36, 0x97, 0xfe, 0xfb, 0x90,
-	0xa7, 0xdc, 0x52, 0x0c, 0xef, 0x6d, 0x61, 0xc8, 0xe5, 0xaf, 0x28, 0x08, 0x87, 0x4c, 0xb2, 0xbe,
-	0x45, 0x31, 0x33, 0x6e, 0xdf, 0x94, 0xd9, 0x1d, 0x28, 0xd0, 0x27, 0xc3, 0x20, 0x0c, 0x04, 0xa9,
-	0x12, 0x51, 0xd6, 0x2a, 0x63, 0xfd, 0x19, 0x8c, 0x8d, 0x15, 0xc6, 0xed, 0xdf, 0x34, 0x28, 0x7e,
-	0x22, 0xef, 0x2f, 0xfc, 0x2e, 0x14, 0xd5, 0x23, 0x85, 0xef, 0x6c, 0x7e, 0x3d, 0xad, 0xa3, 0x35,
-	0x3f, 0x97, 0x55, 0x8e, 0xa7, 0xaa, 0x7b, 0x3b, 0x49, 0xcd, 0x3e, 0x4c, 0x49, 0x6a, 0xe6, 0x82,
-	0xcf, 0xe1, 0x0e, 0x40, 0x72, 0x7b, 0xe0, 0x97, 0xb6, 0x5e, 0x99, 0xd6, 0x8b, 0x5b, 0x2e, 0x1b,
-	0x79, 0x46, 0x22, 0xf5, 0xe4, 0x8c, 0xb5, 0x4d, 0x4e, 0xce, 0x58, 0xdd, 0x8c, 0x1c, 0x7e, 0x08,
-	0xfb, 0x99, 0x49, 0xe2, 0xbb, 0xbb, 0x24, 0x6c, 0x59, 0xdb, 0xc7, 0x5f, 0xcf, 0x75, 0x9c, 0x7f,
-	0xff, 0xb4, 0xd1, 0x4f, 0x4b, 0x1b, 0xfd, 0xb2, 0xb4, 0xd1, 0xd3, 0xa5, 0x8d, 0xfe, 0x58, 0xda,
-	0xe8, 0x9b, 0x6b, 0x3b, 0xf7, 0xf4, 0xda, 0xce, 0xfd, 0x7e, 0x6d, 0xe7, 0x7a, 0x05, 0xf1, 0xff,
-	0xef, 0xad, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x8d, 0xfc, 0x77, 0x2b, 0x43, 0x0a, 0x00, 0x00,
+func (m *ExchangeEdgesReply_ThreadEdges) GetHeads() []*GetRecordsByCIDReply_LogEntry {
+	if m != nil {
+		return m.Heads
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ExchangeEdgesReply_ThreadEdges) GetPeers() []byte {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type GetRecentRecordsRequest struct {
+	// body is the message body.
+	Body *GetRecentRecordsRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+}
 
-// ServiceClient is the client API for Service service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ServiceClient interface {
-	// GetLogs from a peer.
-	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsReply, error)
-	// PushLog to a peer.
-	PushLog(ctx context.Context, in *PushLogRequest, opts ...grpc.CallOption) (*PushLogReply, error)
-	// GetRecords from a peer.
-	GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsReply, error)
-	// PushRecord to a peer.
-	PushRecord(ctx context.Context, in *PushRecordRequest, opts ...grpc.CallOption) (*PushRecordReply, error)
-	// ExchangeEdges with a peer.
-	ExchangeEdges(ctx context.Context, in *ExchangeEdgesRequest, opts ...grpc.CallOption) (*ExchangeEdgesReply, error)
+func (m *GetRecentRecordsRequest) Reset()         { *m = GetRecentRecordsRequest{} }
+func (m *GetRecentRecordsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRecentRecordsRequest) ProtoMessage()    {}
+func (*GetRecentRecordsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{13}
+}
+func (m *GetRecentRecordsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRecentRecordsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRecentRecordsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetRecentRecordsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecentRecordsRequest.Merge(m, src)
+}
+func (m *GetRecentRecordsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRecentRecordsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecentRecordsRequest.DiscardUnknown(m)
 }
 
-type serviceClient struct {
-	cc *grpc.ClientConn
+var xxx_messageInfo_GetRecentRecordsRequest proto.InternalMessageInfo
+
+func (m *GetRecentRecordsRequest) GetBody() *GetRecentRecordsRequest_Body {
+	if m != nil {
+		return m.Body
+	}
+	return nil
 }
 
-func NewServiceClient(cc *grpc.ClientConn) ServiceClient {
-	return &serviceClient{cc}
+type GetRecentRecordsRequest_Body struct {
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// serviceKey for the thread.
+	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	// maxRecordSize is the maximum size of records accepted by the requester.
+	MaxRecordSize int64 `protobuf:"varint,3,opt,name=maxRecordSize,proto3" json:"maxRecordSize,omitempty"`
 }
 
-func (c *serviceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsReply, error) {
-	out := new(GetLogsReply)
-	err := c.cc.Invoke(ctx, "/net.pb.Service/GetLogs", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetRecentRecordsRequest_Body) Reset()         { *m = GetRecentRecordsRequest_Body{} }
+func (m *GetRecentRecordsRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*GetRecentRecordsRequest_Body) ProtoMessage()    {}
+func (*GetRecentRecordsRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{13, 0}
+}
+func (m *GetRecentRecordsRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRecentRecordsRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRecentRecordsRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *GetRecentRecordsRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecentRecordsRequest_Body.Merge(m, src)
+}
+func (m *GetRecentRecordsRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRecentRecordsRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecentRecordsRequest_Body.DiscardUnknown(m)
 }
 
-func (c *serviceClient) PushLog(ctx context.Context, in *PushLogRequest, opts ...grpc.CallOption) (*PushLogReply, error) {
-	out := new(PushLogReply)
-	err := c.cc.Invoke(ctx, "/net.pb.Service/PushLog", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetRecentRecordsRequest_Body proto.InternalMessageInfo
+
+func (m *GetRecentRecordsRequest_Body) GetMaxRecordSize() int64 {
+	if m != nil {
+		return m.MaxRecordSize
 	}
-	return out, nil
+	return 0
 }
 
-func (c *serviceClient) GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsReply, error) {
-	out := new(GetRecordsReply)
-	err := c.cc.Invoke(ctx, "/net.pb.Service/GetRecords", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// GetRecentRecordsReply contains records requested with a GetRecentRecordsRequest.
+type GetRecentRecordsReply struct {
+	// records are the recently multicast push requests, oldest first.
+	Records []*PushRecordRequest `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
 }
 
-func (c *serviceClient) PushRecord(ctx context.Context, in *PushRecordRequest, opts ...grpc.CallOption) (*PushRecordReply, error) {
-	out := new(PushRecordReply)
-	err := c.cc.Invoke(ctx, "/net.pb.Service/PushRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetRecentRecordsReply) Reset()         { *m = GetRecentRecordsReply{} }
+func (m *GetRecentRecordsReply) String() string { return proto.CompactTextString(m) }
+func (*GetRecentRecordsReply) ProtoMessage()    {}
+func (*GetRecentRecordsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{14}
+}
+func (m *GetRecentRecordsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRecentRecordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRecentRecordsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *GetRecentRecordsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecentRecordsReply.Merge(m, src)
+}
+func (m *GetRecentRecordsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRecentRecordsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecentRecordsReply.DiscardUnknown(m)
 }
 
-func (c *serviceClient) ExchangeEdges(ctx context.Context, in *ExchangeEdgesRequest, opts ...grpc.CallOption) (*ExchangeEdgesReply, error) {
-	out := new(ExchangeEdgesReply)
-	err := c.cc.Invoke(ctx, "/net.pb.Service/ExchangeEdges", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetRecentRecordsReply proto.InternalMessageInfo
+
+func (m *GetRecentRecordsReply) GetRecords() []*PushRecordRequest {
+	if m != nil {
+		return m.Records
 	}
-	return out, nil
+	return nil
 }
 
-// ServiceServer is the server API for Service service.
-type ServiceServer interface {
-	// GetLogs from a peer.
-	GetLogs(context.Context, *GetLogsRequest) (*GetLogsReply, error)
-	// PushLog to a peer.
-	PushLog(context.Context, *PushLogRequest) (*PushLogReply, error)
-	// GetRecords from a peer.
-	GetRecords(context.Context, *GetRecordsRequest) (*GetRecordsReply, error)
-	// PushRecord to a peer.
-	PushRecord(context.Context, *PushRecordRequest) (*PushRecordReply, error)
-	// ExchangeEdges with a peer.
-	ExchangeEdges(context.Context, *ExchangeEdgesRequest) (*ExchangeEdgesReply, error)
+// Presence is an ephemeral presence heartbeat published over a thread's presence topic.
+// It's never written to a log.
+type Presence struct {
+	// body is the message body.
+	Body *Presence_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// sig is the body signature from the publishing peer's host key.
+	Sig []byte `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+	// identitySig is the signature of the present identity over the presence, if any.
+	IdentitySig []byte `protobuf:"bytes,3,opt,name=identitySig,proto3" json:"identitySig,omitempty"`
 }
 
-// UnimplementedServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedServiceServer struct {
+func (m *Presence) Reset()         { *m = Presence{} }
+func (m *Presence) String() string { return proto.CompactTextString(m) }
+func (*Presence) ProtoMessage()    {}
+func (*Presence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{15}
 }
-
-func (*UnimplementedServiceServer) GetLogs(ctx context.Context, req *GetLogsRequest) (*GetLogsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+func (m *Presence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedServiceServer) PushLog(ctx context.Context, req *PushLogRequest) (*PushLogReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PushLog not implemented")
+func (m *Presence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Presence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedServiceServer) GetRecords(ctx context.Context, req *GetRecordsRequest) (*GetRecordsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRecords not implemented")
+func (m *Presence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Presence.Merge(m, src)
 }
-func (*UnimplementedServiceServer) PushRecord(ctx context.Context, req *PushRecordRequest) (*PushRecordReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PushRecord not implemented")
+func (m *Presence) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedServiceServer) ExchangeEdges(ctx context.Context, req *ExchangeEdgesRequest) (*ExchangeEdgesReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExchangeEdges not implemented")
+func (m *Presence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Presence.DiscardUnknown(m)
 }
 
-func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
-	s.RegisterService(&_Service_serviceDesc, srv)
-}
+var xxx_messageInfo_Presence proto.InternalMessageInfo
 
-func _Service_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetLogsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Presence) GetBody() *Presence_Body {
+	if m != nil {
+		return m.Body
 	}
-	if interceptor == nil {
-		return srv.(ServiceServer).GetLogs(ctx, in)
+	return nil
+}
+
+func (m *Presence) GetSig() []byte {
+	if m != nil {
+		return m.Sig
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/net.pb.Service/GetLogs",
+	return nil
+}
+
+func (m *Presence) GetIdentitySig() []byte {
+	if m != nil {
+		return m.IdentitySig
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).GetLogs(ctx, req.(*GetLogsRequest))
+	return nil
+}
+
+type Presence_Body struct {
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// peerID is the publishing peer's ID.
+	PeerID *ProtoPeerID `protobuf:"bytes,2,opt,name=peerID,proto3,customtype=ProtoPeerID" json:"peerID,omitempty"`
+	// identity is the public key of the present identity, if any.
+	Identity []byte `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	// status of the identity.
+	Status Presence_Status `protobuf:"varint,4,opt,name=status,proto3,enum=net.pb.Presence_Status" json:"status,omitempty"`
+	// payload is an app-defined payload, e.g., a cursor position, encrypted with the thread service key.
+	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// timestamp is the publishing time in unix nanoseconds.
+	Timestamp int64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *Presence_Body) Reset()         { *m = Presence_Body{} }
+func (m *Presence_Body) String() string { return proto.CompactTextString(m) }
+func (*Presence_Body) ProtoMessage()    {}
+func (*Presence_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{15, 0}
+}
+func (m *Presence_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Presence_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Presence_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *Presence_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Presence_Body.Merge(m, src)
+}
+func (m *Presence_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *Presence_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_Presence_Body.DiscardUnknown(m)
 }
 
-func _Service_PushLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PushLogRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+var xxx_messageInfo_Presence_Body proto.InternalMessageInfo
+
+func (m *Presence_Body) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
 	}
-	if interceptor == nil {
-		return srv.(ServiceServer).PushLog(ctx, in)
+	return nil
+}
+
+func (m *Presence_Body) GetStatus() Presence_Status {
+	if m != nil {
+		return m.Status
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/net.pb.Service/PushLog",
+	return Presence_OFFLINE
+}
+
+func (m *Presence_Body) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).PushLog(ctx, req.(*PushLogRequest))
+	return nil
+}
+
+func (m *Presence_Body) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Service_GetRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRecordsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ServiceServer).GetRecords(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/net.pb.Service/GetRecords",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).GetRecords(ctx, req.(*GetRecordsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+// GetLogDigestsRequest is used to request digests of record ranges of a log.
+// Ranges are nodes of a binary hash tree over the records of the log.
+type GetLogDigestsRequest struct {
+	// body is the message body.
+	Body *GetLogDigestsRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 }
 
-func _Service_PushRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PushRecordRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ServiceServer).PushRecord(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/net.pb.Service/PushRecord",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).PushRecord(ctx, req.(*PushRecordRequest))
+func (m *GetLogDigestsRequest) Reset()         { *m = GetLogDigestsRequest{} }
+func (m *GetLogDigestsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogDigestsRequest) ProtoMessage()    {}
+func (*GetLogDigestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{16}
+}
+func (m *GetLogDigestsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLogDigestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLogDigestsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *GetLogDigestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogDigestsRequest.Merge(m, src)
+}
+func (m *GetLogDigestsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLogDigestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogDigestsRequest.DiscardUnknown(m)
 }
 
-func _Service_ExchangeEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExchangeEdgesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ServiceServer).ExchangeEdges(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/net.pb.Service/ExchangeEdges",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).ExchangeEdges(ctx, req.(*ExchangeEdgesRequest))
+var xxx_messageInfo_GetLogDigestsRequest proto.InternalMessageInfo
+
+func (m *GetLogDigestsRequest) GetBody() *GetLogDigestsRequest_Body {
+	if m != nil {
+		return m.Body
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _Service_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "net.pb.Service",
-	HandlerType: (*ServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetLogs",
-			Handler:    _Service_GetLogs_Handler,
-		},
-		{
-			MethodName: "PushLog",
-			Handler:    _Service_PushLog_Handler,
-		},
-		{
-			MethodName: "GetRecords",
-			Handler:    _Service_GetRecords_Handler,
-		},
-		{
-			MethodName: "PushRecord",
-			Handler:    _Service_PushRecord_Handler,
-		},
-		{
-			MethodName: "ExchangeEdges",
-			Handler:    _Service_ExchangeEdges_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "net.proto",
+type GetLogDigestsRequest_Body struct {
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// serviceKey for the thread.
+	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	// logID is the target log's ID.
+	LogID *ProtoPeerID `protobuf:"bytes,3,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// length is the number of records from the start of the log the tree is built over.
+	Length int64 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	// ranges are the requested tree nodes.
+	Ranges []*GetLogDigestsRequest_Range `protobuf:"bytes,5,rep,name=ranges,proto3" json:"ranges,omitempty"`
 }
 
-func (m *Log) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GetLogDigestsRequest_Body) Reset()         { *m = GetLogDigestsRequest_Body{} }
+func (m *GetLogDigestsRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*GetLogDigestsRequest_Body) ProtoMessage()    {}
+func (*GetLogDigestsRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{16, 0}
+}
+func (m *GetLogDigestsRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLogDigestsRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLogDigestsRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *GetLogDigestsRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogDigestsRequest_Body.Merge(m, src)
+}
+func (m *GetLogDigestsRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLogDigestsRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogDigestsRequest_Body.DiscardUnknown(m)
 }
 
-func (m *Log) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_GetLogDigestsRequest_Body proto.InternalMessageInfo
+
+func (m *GetLogDigestsRequest_Body) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
 }
 
-func (m *Log) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Counter != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
-		i--
-		dAtA[i] = 0x28
+func (m *GetLogDigestsRequest_Body) GetRanges() []*GetLogDigestsRequest_Range {
+	if m != nil {
+		return m.Ranges
 	}
-	if m.Head != nil {
-		{
-			size := m.Head.Size()
-			i -= size
-			if _, err := m.Head.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+	return nil
+}
+
+// Range covers the 2^level records starting at position index*2^level.
+type GetLogDigestsRequest_Range struct {
+	// level of the tree node, leaves are at level zero.
+	Level int32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	// index of the tree node at its level.
+	Index int64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *GetLogDigestsRequest_Range) Reset()         { *m = GetLogDigestsRequest_Range{} }
+func (m *GetLogDigestsRequest_Range) String() string { return proto.CompactTextString(m) }
+func (*GetLogDigestsRequest_Range) ProtoMessage()    {}
+func (*GetLogDigestsRequest_Range) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{16, 1}
+}
+func (m *GetLogDigestsRequest_Range) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLogDigestsRequest_Range) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLogDigestsRequest_Range.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x22
+		return b[:n], nil
 	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size := m.Addrs[iNdEx].Size()
-				i -= size
-				if _, err := m.Addrs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
-					return 0, err
-				}
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+}
+func (m *GetLogDigestsRequest_Range) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogDigestsRequest_Range.Merge(m, src)
+}
+func (m *GetLogDigestsRequest_Range) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLogDigestsRequest_Range) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogDigestsRequest_Range.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogDigestsRequest_Range proto.InternalMessageInfo
+
+func (m *GetLogDigestsRequest_Range) GetLevel() int32 {
+	if m != nil {
+		return m.Level
 	}
-	if m.PubKey != nil {
-		{
-			size := m.PubKey.Size()
-			i -= size
-			if _, err := m.PubKey.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return 0
+}
+
+func (m *GetLogDigestsRequest_Range) GetIndex() int64 {
+	if m != nil {
+		return m.Index
 	}
-	if m.ID != nil {
-		{
-			size := m.ID.Size()
-			i -= size
-			if _, err := m.ID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+	return 0
+}
+
+// GetLogDigestsReply contains digests requested with a GetLogDigestsRequest.
+type GetLogDigestsReply struct {
+	// counter is the position of the log head.
+	Counter int64 `protobuf:"varint,1,opt,name=counter,proto3" json:"counter,omitempty"`
+	// digests of the requested ranges in request order.
+	// It's empty if the log has less records than the requested length.
+	Digests [][]byte `protobuf:"bytes,2,rep,name=digests,proto3" json:"digests,omitempty"`
+}
+
+func (m *GetLogDigestsReply) Reset()         { *m = GetLogDigestsReply{} }
+func (m *GetLogDigestsReply) String() string { return proto.CompactTextString(m) }
+func (*GetLogDigestsReply) ProtoMessage()    {}
+func (*GetLogDigestsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{17}
+}
+func (m *GetLogDigestsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLogDigestsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLogDigestsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GetLogDigestsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogDigestsReply.Merge(m, src)
+}
+func (m *GetLogDigestsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLogDigestsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogDigestsReply.DiscardUnknown(m)
 }
 
-func (m *Log_Record) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetLogDigestsReply proto.InternalMessageInfo
+
+func (m *GetLogDigestsReply) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Log_Record) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GetLogDigestsReply) GetDigests() [][]byte {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
 }
 
-func (m *Log_Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.BodyNode) > 0 {
-		i -= len(m.BodyNode)
-		copy(dAtA[i:], m.BodyNode)
-		i = encodeVarintNet(dAtA, i, uint64(len(m.BodyNode)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.HeaderNode) > 0 {
-		i -= len(m.HeaderNode)
-		copy(dAtA[i:], m.HeaderNode)
-		i = encodeVarintNet(dAtA, i, uint64(len(m.HeaderNode)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.EventNode) > 0 {
-		i -= len(m.EventNode)
-		copy(dAtA[i:], m.EventNode)
-		i = encodeVarintNet(dAtA, i, uint64(len(m.EventNode)))
-		i--
-		dAtA[i] = 0x12
+// Follow is a record of a peer following a thread, which is replicated to thread peers
+// with logs. Peers push new records to the thread followers.
+type Follow struct {
+	// body is the message body.
+	Body *Follow_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// sig is the body signature from the following peer's host key.
+	Sig []byte `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+}
+
+func (m *Follow) Reset()         { *m = Follow{} }
+func (m *Follow) String() string { return proto.CompactTextString(m) }
+func (*Follow) ProtoMessage()    {}
+func (*Follow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{18}
+}
+func (m *Follow) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Follow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Follow.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.RecordNode) > 0 {
-		i -= len(m.RecordNode)
-		copy(dAtA[i:], m.RecordNode)
-		i = encodeVarintNet(dAtA, i, uint64(len(m.RecordNode)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *Follow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Follow.Merge(m, src)
+}
+func (m *Follow) XXX_Size() int {
+	return m.Size()
+}
+func (m *Follow) XXX_DiscardUnknown() {
+	xxx_messageInfo_Follow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Follow proto.InternalMessageInfo
+
+func (m *Follow) GetBody() *Follow_Body {
+	if m != nil {
+		return m.Body
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetLogsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Follow) GetSig() []byte {
+	if m != nil {
+		return m.Sig
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetLogsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Follow_Body struct {
+	// threadID is the followed thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// peerID is the following peer's ID.
+	PeerID *ProtoPeerID `protobuf:"bytes,2,opt,name=peerID,proto3,customtype=ProtoPeerID" json:"peerID,omitempty"`
+	// addrs the following peer is reachable at.
+	Addrs []ProtoAddr `protobuf:"bytes,3,rep,name=addrs,proto3,customtype=ProtoAddr" json:"addrs,omitempty"`
+	// active is false if the peer stopped following the thread.
+	Active bool `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	// timestamp is the signing time in unix nanoseconds, the latest follow of a peer wins.
+	Timestamp int64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (m *GetLogsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Body != nil {
-		{
-			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *Follow_Body) Reset()         { *m = Follow_Body{} }
+func (m *Follow_Body) String() string { return proto.CompactTextString(m) }
+func (*Follow_Body) ProtoMessage()    {}
+func (*Follow_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{18, 0}
+}
+func (m *Follow_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Follow_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Follow_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Follow_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Follow_Body.Merge(m, src)
+}
+func (m *Follow_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *Follow_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_Follow_Body.DiscardUnknown(m)
 }
 
-func (m *GetLogsRequest_Body) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Follow_Body proto.InternalMessageInfo
+
+func (m *Follow_Body) GetActive() bool {
+	if m != nil {
+		return m.Active
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *GetLogsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Follow_Body) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func (m *GetLogsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ServiceKey != nil {
-		{
-			size := m.ServiceKey.Size()
-			i -= size
-			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+// Freeze is the freeze state of a thread, which is replicated to thread peers with logs.
+// Peers reject records beyond the heads of a frozen thread.
+type Freeze struct {
+	// body is the message body.
+	Body *Freeze_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// sig is the body signature from the freezing peer's host key.
+	Sig []byte `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+}
+
+func (m *Freeze) Reset()         { *m = Freeze{} }
+func (m *Freeze) String() string { return proto.CompactTextString(m) }
+func (*Freeze) ProtoMessage()    {}
+func (*Freeze) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{19}
+}
+func (m *Freeze) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Freeze) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Freeze.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if m.ThreadID != nil {
-		{
-			size := m.ThreadID.Size()
-			i -= size
-			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+}
+func (m *Freeze) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Freeze.Merge(m, src)
+}
+func (m *Freeze) XXX_Size() int {
+	return m.Size()
+}
+func (m *Freeze) XXX_DiscardUnknown() {
+	xxx_messageInfo_Freeze.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Freeze proto.InternalMessageInfo
+
+func (m *Freeze) GetBody() *Freeze_Body {
+	if m != nil {
+		return m.Body
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetLogsReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Freeze) GetSig() []byte {
+	if m != nil {
+		return m.Sig
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetLogsReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Freeze_Body struct {
+	// threadID is the frozen thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// peerID is the ID of the peer which changed the state, the only peer allowed to unfreeze.
+	PeerID *ProtoPeerID `protobuf:"bytes,2,opt,name=peerID,proto3,customtype=ProtoPeerID" json:"peerID,omitempty"`
+	// frozen is false if the thread was unfrozen.
+	Frozen bool `protobuf:"varint,3,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	// heads are the log heads the thread was frozen at.
+	Heads []*Freeze_Head `protobuf:"bytes,4,rep,name=heads,proto3" json:"heads,omitempty"`
+	// timestamp is the signing time in unix nanoseconds, the latest state wins.
+	Timestamp int64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (m *GetLogsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Logs) > 0 {
-		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *Freeze_Body) Reset()         { *m = Freeze_Body{} }
+func (m *Freeze_Body) String() string { return proto.CompactTextString(m) }
+func (*Freeze_Body) ProtoMessage()    {}
+func (*Freeze_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{19, 0}
+}
+func (m *Freeze_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Freeze_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Freeze_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *PushLogRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *Freeze_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Freeze_Body.Merge(m, src)
+}
+func (m *Freeze_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *Freeze_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_Freeze_Body.DiscardUnknown(m)
 }
 
-func (m *PushLogRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_Freeze_Body proto.InternalMessageInfo
+
+func (m *Freeze_Body) GetFrozen() bool {
+	if m != nil {
+		return m.Frozen
+	}
+	return false
 }
 
-func (m *PushLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Body != nil {
-		{
-			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (m *Freeze_Body) GetHeads() []*Freeze_Head {
+	if m != nil {
+		return m.Heads
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *PushLogRequest_Body) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Freeze_Body) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *PushLogRequest_Body) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Head is the position of a log head.
+type Freeze_Head struct {
+	// logID is the log's ID.
+	LogID *ProtoPeerID `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// counter is the position of the log head.
+	Counter int64 `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`
 }
 
-func (m *PushLogRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Log != nil {
-		{
-			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *Freeze_Head) Reset()         { *m = Freeze_Head{} }
+func (m *Freeze_Head) String() string { return proto.CompactTextString(m) }
+func (*Freeze_Head) ProtoMessage()    {}
+func (*Freeze_Head) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{19, 1}
+}
+func (m *Freeze_Head) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Freeze_Head) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Freeze_Head.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x22
+		return b[:n], nil
 	}
-	if m.ReadKey != nil {
-		{
-			size := m.ReadKey.Size()
-			i -= size
-			if _, err := m.ReadKey.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+}
+func (m *Freeze_Head) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Freeze_Head.Merge(m, src)
+}
+func (m *Freeze_Head) XXX_Size() int {
+	return m.Size()
+}
+func (m *Freeze_Head) XXX_DiscardUnknown() {
+	xxx_messageInfo_Freeze_Head.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Freeze_Head proto.InternalMessageInfo
+
+func (m *Freeze_Head) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
 	}
-	if m.ServiceKey != nil {
-		{
-			size := m.ServiceKey.Size()
-			i -= size
-			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+	return 0
+}
+
+// LogAddrs are the addresses of a log signed by the log key, which replace the known ones.
+type LogAddrs struct {
+	// body is the message body.
+	Body *LogAddrs_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// sig is the body signature from the log key.
+	Sig []byte `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+}
+
+func (m *LogAddrs) Reset()         { *m = LogAddrs{} }
+func (m *LogAddrs) String() string { return proto.CompactTextString(m) }
+func (*LogAddrs) ProtoMessage()    {}
+func (*LogAddrs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{20}
+}
+func (m *LogAddrs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogAddrs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogAddrs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if m.ThreadID != nil {
-		{
-			size := m.ThreadID.Size()
-			i -= size
-			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+}
+func (m *LogAddrs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogAddrs.Merge(m, src)
+}
+func (m *LogAddrs) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogAddrs) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogAddrs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogAddrs proto.InternalMessageInfo
+
+func (m *LogAddrs) GetBody() *LogAddrs_Body {
+	if m != nil {
+		return m.Body
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *PushLogReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LogAddrs) GetSig() []byte {
+	if m != nil {
+		return m.Sig
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PushLogReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogAddrs_Body struct {
+	// threadID is the log's thread ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// logID is the log's ID.
+	LogID *ProtoPeerID `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// addrs of the log.
+	Addrs []ProtoAddr `protobuf:"bytes,3,rep,name=addrs,proto3,customtype=ProtoAddr" json:"addrs,omitempty"`
+	// timestamp is the signing time in unix nanoseconds, only newer addresses are applied.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (m *PushLogReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *LogAddrs_Body) Reset()         { *m = LogAddrs_Body{} }
+func (m *LogAddrs_Body) String() string { return proto.CompactTextString(m) }
+func (*LogAddrs_Body) ProtoMessage()    {}
+func (*LogAddrs_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{20, 0}
+}
+func (m *LogAddrs_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogAddrs_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogAddrs_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LogAddrs_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogAddrs_Body.Merge(m, src)
+}
+func (m *LogAddrs_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogAddrs_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogAddrs_Body.DiscardUnknown(m)
 }
 
-func (m *GetRecordsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LogAddrs_Body proto.InternalMessageInfo
+
+func (m *LogAddrs_Body) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetRecordsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// TopicMessage is a message published to a thread topic.
+type TopicMessage struct {
+	// data is the published message, e.g. a record push or a presence.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// proof of the thread service key, bound to the topic, the publisher and the data.
+	// Subscribers drop messages without a valid proof.
+	Proof []byte `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
 }
 
-func (m *GetRecordsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Body != nil {
-		{
-			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *TopicMessage) Reset()         { *m = TopicMessage{} }
+func (m *TopicMessage) String() string { return proto.CompactTextString(m) }
+func (*TopicMessage) ProtoMessage()    {}
+func (*TopicMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{21}
+}
+func (m *TopicMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopicMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopicMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *TopicMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicMessage.Merge(m, src)
+}
+func (m *TopicMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopicMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicMessage.DiscardUnknown(m)
 }
 
-func (m *GetRecordsRequest_Body) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TopicMessage proto.InternalMessageInfo
+
+func (m *TopicMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetRecordsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *TopicMessage) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
 }
 
-func (m *GetRecordsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Logs) > 0 {
-		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.ServiceKey != nil {
-		{
-			size := m.ServiceKey.Size()
-			i -= size
-			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+// RecordAck acknowledges the records of a log received and seen by a peer.
+// Acks are sent to the log author, which reports the delivery of its records.
+type RecordAck struct {
+	// body is the message body.
+	Body *RecordAck_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	// sig is the body signature from the acknowledging peer's host key.
+	Sig []byte `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+}
+
+func (m *RecordAck) Reset()         { *m = RecordAck{} }
+func (m *RecordAck) String() string { return proto.CompactTextString(m) }
+func (*RecordAck) ProtoMessage()    {}
+func (*RecordAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{22}
+}
+func (m *RecordAck) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordAck.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if m.ThreadID != nil {
-		{
-			size := m.ThreadID.Size()
-			i -= size
-			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+}
+func (m *RecordAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordAck.Merge(m, src)
+}
+func (m *RecordAck) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordAck proto.InternalMessageInfo
+
+func (m *RecordAck) GetBody() *RecordAck_Body {
+	if m != nil {
+		return m.Body
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetRecordsRequest_Body_LogEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RecordAck) GetSig() []byte {
+	if m != nil {
+		return m.Sig
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetRecordsRequest_Body_LogEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RecordAck_Body struct {
+	// threadID is the thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// logID is the acknowledged log's ID.
+	LogID *ProtoPeerID `protobuf:"bytes,2,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	// peerID is the acknowledging peer's ID.
+	PeerID *ProtoPeerID `protobuf:"bytes,3,opt,name=peerID,proto3,customtype=ProtoPeerID" json:"peerID,omitempty"`
+	// delivered is the range of log records stored by the peer.
+	Delivered *RecordAck_Range `protobuf:"bytes,4,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	// seen is the range of log records marked as seen on the peer, if any.
+	Seen *RecordAck_Range `protobuf:"bytes,5,opt,name=seen,proto3" json:"seen,omitempty"`
+	// timestamp is the signing time in unix nanoseconds, the latest ack of a peer wins.
+	Timestamp int64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (m *GetRecordsRequest_Body_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Counter != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Limit != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.Limit))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Offset != nil {
-		{
-			size := m.Offset.Size()
-			i -= size
-			if _, err := m.Offset.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *RecordAck_Body) Reset()         { *m = RecordAck_Body{} }
+func (m *RecordAck_Body) String() string { return proto.CompactTextString(m) }
+func (*RecordAck_Body) ProtoMessage()    {}
+func (*RecordAck_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{22, 0}
+}
+func (m *RecordAck_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordAck_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordAck_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if m.LogID != nil {
-		{
-			size := m.LogID.Size()
-			i -= size
-			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+}
+func (m *RecordAck_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordAck_Body.Merge(m, src)
+}
+func (m *RecordAck_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordAck_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordAck_Body.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordAck_Body proto.InternalMessageInfo
+
+func (m *RecordAck_Body) GetDelivered() *RecordAck_Range {
+	if m != nil {
+		return m.Delivered
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *GetRecordsReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RecordAck_Body) GetSeen() *RecordAck_Range {
+	if m != nil {
+		return m.Seen
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetRecordsReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RecordAck_Body) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func (m *GetRecordsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Logs) > 0 {
-		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+// Range covers the log records from the start of the log up to the head record.
+type RecordAck_Range struct {
+	// head is the last record in the range.
+	Head *ProtoCid `protobuf:"bytes,1,opt,name=head,proto3,customtype=ProtoCid" json:"head,omitempty"`
+	// counter is the position of the head in the log.
+	Counter int64 `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`
+}
+
+func (m *RecordAck_Range) Reset()         { *m = RecordAck_Range{} }
+func (m *RecordAck_Range) String() string { return proto.CompactTextString(m) }
+func (*RecordAck_Range) ProtoMessage()    {}
+func (*RecordAck_Range) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{22, 1}
+}
+func (m *RecordAck_Range) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordAck_Range) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordAck_Range.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RecordAck_Range) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordAck_Range.Merge(m, src)
+}
+func (m *RecordAck_Range) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordAck_Range) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordAck_Range.DiscardUnknown(m)
 }
 
-func (m *GetRecordsReply_LogEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RecordAck_Range proto.InternalMessageInfo
+
+func (m *RecordAck_Range) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetRecordsReply_LogEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// PushAcksRequest is used to push record acks to a log author.
+type PushAcksRequest struct {
+	// body is the message body.
+	Body *PushAcksRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 }
 
-func (m *GetRecordsReply_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Log != nil {
-		{
-			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Records) > 0 {
-		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.LogID != nil {
-		{
-			size := m.LogID.Size()
-			i -= size
-			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *PushAcksRequest) Reset()         { *m = PushAcksRequest{} }
+func (m *PushAcksRequest) String() string { return proto.CompactTextString(m) }
+func (*PushAcksRequest) ProtoMessage()    {}
+func (*PushAcksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{23}
+}
+func (m *PushAcksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushAcksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushAcksRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PushAcksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushAcksRequest.Merge(m, src)
+}
+func (m *PushAcksRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PushAcksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushAcksRequest.DiscardUnknown(m)
 }
 
-func (m *PushRecordRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PushAcksRequest proto.InternalMessageInfo
+
+func (m *PushAcksRequest) GetBody() *PushAcksRequest_Body {
+	if m != nil {
+		return m.Body
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PushRecordRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PushAcksRequest_Body struct {
+	// threadID is the target thread's ID.
+	ThreadID *ProtoThreadID `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	// serviceKey for the thread.
+	ServiceKey *ProtoKey `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	// acks of the thread logs.
+	Acks []*RecordAck `protobuf:"bytes,3,rep,name=acks,proto3" json:"acks,omitempty"`
 }
 
-func (m *PushRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Counter != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Body != nil {
-		{
-			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *PushAcksRequest_Body) Reset()         { *m = PushAcksRequest_Body{} }
+func (m *PushAcksRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*PushAcksRequest_Body) ProtoMessage()    {}
+func (*PushAcksRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{23, 0}
+}
+func (m *PushAcksRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushAcksRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushAcksRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PushAcksRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushAcksRequest_Body.Merge(m, src)
+}
+func (m *PushAcksRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *PushAcksRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushAcksRequest_Body.DiscardUnknown(m)
 }
 
-func (m *PushRecordRequest_Body) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PushAcksRequest_Body proto.InternalMessageInfo
+
+func (m *PushAcksRequest_Body) GetAcks() []*RecordAck {
+	if m != nil {
+		return m.Acks
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PushRecordRequest_Body) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// PushAcksReply is a response to PushAcksRequest.
+type PushAcksReply struct {
 }
 
-func (m *PushRecordRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Record != nil {
-		{
-			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.LogID != nil {
-		{
-			size := m.LogID.Size()
-			i -= size
-			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.ThreadID != nil {
-		{
-			size := m.ThreadID.Size()
-			i -= size
-			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *PushAcksReply) Reset()         { *m = PushAcksReply{} }
+func (m *PushAcksReply) String() string { return proto.CompactTextString(m) }
+func (*PushAcksReply) ProtoMessage()    {}
+func (*PushAcksReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{24}
+}
+func (m *PushAcksReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PushAcksReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PushAcksReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *PushRecordReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *PushAcksReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushAcksReply.Merge(m, src)
 }
-
-func (m *PushRecordReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PushAcksReply) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *PushRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *PushAcksReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushAcksReply.DiscardUnknown(m)
 }
 
-func (m *ExchangeEdgesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
+var xxx_messageInfo_PushAcksReply proto.InternalMessageInfo
 
-func (m *ExchangeEdgesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// HandshakeRequest advertises the protocol version and capabilities of the caller.
+type HandshakeRequest struct {
+	// body is the message body.
+	Body *HandshakeRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 }
 
-func (m *ExchangeEdgesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Body != nil {
-		{
-			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintNet(dAtA, i, uint64(size))
+func (m *HandshakeRequest) Reset()         { *m = HandshakeRequest{} }
+func (m *HandshakeRequest) String() string { return proto.CompactTextString(m) }
+func (*HandshakeRequest) ProtoMessage()    {}
+func (*HandshakeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{25}
+}
+func (m *HandshakeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HandshakeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HandshakeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *HandshakeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandshakeRequest.Merge(m, src)
+}
+func (m *HandshakeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *HandshakeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandshakeRequest.DiscardUnknown(m)
 }
 
-func (m *ExchangeEdgesRequest_Body) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_HandshakeRequest proto.InternalMessageInfo
+
+func (m *HandshakeRequest) GetBody() *HandshakeRequest_Body {
+	if m != nil {
+		return m.Body
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ExchangeEdgesRequest_Body) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type HandshakeRequest_Body struct {
+	// version is the protocol version of the caller.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// capabilities are the optional protocol features supported by the caller.
+	Capabilities []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 }
 
-func (m *ExchangeEdgesRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Threads) > 0 {
-		for iNdEx := len(m.Threads) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Threads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *HandshakeRequest_Body) Reset()         { *m = HandshakeRequest_Body{} }
+func (m *HandshakeRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*HandshakeRequest_Body) ProtoMessage()    {}
+func (*HandshakeRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{25, 0}
+}
+func (m *HandshakeRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HandshakeRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HandshakeRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *HandshakeRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandshakeRequest_Body.Merge(m, src)
 }
-
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *HandshakeRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *HandshakeRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandshakeRequest_Body.DiscardUnknown(m)
 }
 
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.HeadsEdge != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.HeadsEdge))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.AddressEdge != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.AddressEdge))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.ThreadID != nil {
-		{
-			size := m.ThreadID.Size()
-			i -= size
-			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_HandshakeRequest_Body proto.InternalMessageInfo
+
+func (m *HandshakeRequest_Body) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ExchangeEdgesReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *HandshakeRequest_Body) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ExchangeEdgesReply) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// HandshakeReply advertises the protocol version and capabilities of the callee.
+type HandshakeReply struct {
+	// version is the protocol version of the callee.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// capabilities are the optional protocol features supported by the callee.
+	Capabilities []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 }
 
-func (m *ExchangeEdgesReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Edges) > 0 {
-		for iNdEx := len(m.Edges) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Edges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintNet(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *HandshakeReply) Reset()         { *m = HandshakeReply{} }
+func (m *HandshakeReply) String() string { return proto.CompactTextString(m) }
+func (*HandshakeReply) ProtoMessage()    {}
+func (*HandshakeReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{26}
+}
+func (m *HandshakeReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HandshakeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HandshakeReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *HandshakeReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandshakeReply.Merge(m, src)
+}
+func (m *HandshakeReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *HandshakeReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandshakeReply.DiscardUnknown(m)
 }
 
-func (m *ExchangeEdgesReply_ThreadEdges) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_HandshakeReply proto.InternalMessageInfo
+
+func (m *HandshakeReply) GetVersion() string {
+	if m != nil {
+		return m.Version
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ExchangeEdgesReply_ThreadEdges) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *HandshakeReply) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
 }
 
-func (m *ExchangeEdgesReply_ThreadEdges) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.HeadsEdge != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.HeadsEdge))
-		i--
-		dAtA[i] = 0x20
+// GossipPeers contains peers replicating a thread, piggybacked on the exchange edges reply.
+type GossipPeers struct {
+	// peers are the replicas along with their addresses.
+	Peers []*GossipPeers_Peer `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (m *GossipPeers) Reset()         { *m = GossipPeers{} }
+func (m *GossipPeers) String() string { return proto.CompactTextString(m) }
+func (*GossipPeers) ProtoMessage()    {}
+func (*GossipPeers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{27}
+}
+func (m *GossipPeers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GossipPeers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GossipPeers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.AddressEdge != 0 {
-		i = encodeVarintNet(dAtA, i, uint64(m.AddressEdge))
-		i--
-		dAtA[i] = 0x18
+}
+func (m *GossipPeers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipPeers.Merge(m, src)
+}
+func (m *GossipPeers) XXX_Size() int {
+	return m.Size()
+}
+func (m *GossipPeers) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipPeers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipPeers proto.InternalMessageInfo
+
+func (m *GossipPeers) GetPeers() []*GossipPeers_Peer {
+	if m != nil {
+		return m.Peers
 	}
-	if m.Exists {
-		i--
-		if m.Exists {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	return nil
+}
+
+type GossipPeers_Peer struct {
+	// peerID is the replica's peer ID.
+	PeerID *ProtoPeerID `protobuf:"bytes,1,opt,name=peerID,proto3,customtype=ProtoPeerID" json:"peerID,omitempty"`
+	// addrs are the replica's known addresses.
+	Addrs []ProtoAddr `protobuf:"bytes,2,rep,name=addrs,proto3,customtype=ProtoAddr" json:"addrs,omitempty"`
+}
+
+func (m *GossipPeers_Peer) Reset()         { *m = GossipPeers_Peer{} }
+func (m *GossipPeers_Peer) String() string { return proto.CompactTextString(m) }
+func (*GossipPeers_Peer) ProtoMessage()    {}
+func (*GossipPeers_Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{27, 0}
+}
+func (m *GossipPeers_Peer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GossipPeers_Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GossipPeers_Peer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x10
+		return b[:n], nil
 	}
-	if m.ThreadID != nil {
-		{
-			size := m.ThreadID.Size()
-			i -= size
-			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-			i = encodeVarintNet(dAtA, i, uint64(size))
+}
+func (m *GossipPeers_Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipPeers_Peer.Merge(m, src)
+}
+func (m *GossipPeers_Peer) XXX_Size() int {
+	return m.Size()
+}
+func (m *GossipPeers_Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipPeers_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipPeers_Peer proto.InternalMessageInfo
+
+// QueryRecordsRequest is used to request the records matching a selector from a peer.
+type QueryRecordsRequest struct {
+	// body is the message body.
+	Body *QueryRecordsRequest_Body `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (m *QueryRecordsRequest) Reset()         { *m = QueryRecordsRequest{} }
+func (m *QueryRecordsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRecordsRequest) ProtoMessage()    {}
+func (*QueryRecordsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{28}
+}
+func (m *QueryRecordsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRecordsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRecordsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *QueryRecordsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRecordsRequest.Merge(m, src)
+}
+func (m *QueryRecordsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRecordsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRecordsRequest.DiscardUnknown(m)
 }
 
-func encodeVarintNet(dAtA []byte, offset int, v uint64) int {
-	offset -= sovNet(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_QueryRecordsRequest proto.InternalMessageInfo
+
+func (m *QueryRecordsRequest) GetBody() *QueryRecordsRequest_Body {
+	if m != nil {
+		return m.Body
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return nil
 }
-func NewPopulatedLog(r randyNet, easy bool) *Log {
-	this := &Log{}
-	this.ID = NewPopulatedProtoPeerID(r)
-	this.PubKey = NewPopulatedProtoPubKey(r)
-	v1 := r.Intn(10)
-	this.Addrs = make([]ProtoAddr, v1)
-	for i := 0; i < v1; i++ {
-		v2 := NewPopulatedProtoAddr(r)
-		this.Addrs[i] = *v2
-	}
-	this.Head = NewPopulatedProtoCid(r)
-	this.Counter = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Counter *= -1
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+
+type QueryRecordsRequest_Body struct {
+	ThreadID   *ProtoThreadID                `protobuf:"bytes,1,opt,name=threadID,proto3,customtype=ProtoThreadID" json:"threadID,omitempty"`
+	ServiceKey *ProtoKey                     `protobuf:"bytes,2,opt,name=serviceKey,proto3,customtype=ProtoKey" json:"serviceKey,omitempty"`
+	Selector   *QueryRecordsRequest_Selector `protobuf:"bytes,3,opt,name=selector,proto3" json:"selector,omitempty"`
 }
 
-func NewPopulatedLog_Record(r randyNet, easy bool) *Log_Record {
-	this := &Log_Record{}
-	v3 := r.Intn(100)
-	this.RecordNode = make([]byte, v3)
-	for i := 0; i < v3; i++ {
-		this.RecordNode[i] = byte(r.Intn(256))
-	}
-	v4 := r.Intn(100)
-	this.EventNode = make([]byte, v4)
-	for i := 0; i < v4; i++ {
-		this.EventNode[i] = byte(r.Intn(256))
-	}
-	v5 := r.Intn(100)
-	this.HeaderNode = make([]byte, v5)
-	for i := 0; i < v5; i++ {
-		this.HeaderNode[i] = byte(r.Intn(256))
-	}
-	v6 := r.Intn(100)
-	this.BodyNode = make([]byte, v6)
-	for i := 0; i < v6; i++ {
-		this.BodyNode[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
+func (m *QueryRecordsRequest_Body) Reset()         { *m = QueryRecordsRequest_Body{} }
+func (m *QueryRecordsRequest_Body) String() string { return proto.CompactTextString(m) }
+func (*QueryRecordsRequest_Body) ProtoMessage()    {}
+func (*QueryRecordsRequest_Body) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{28, 0}
+}
+func (m *QueryRecordsRequest_Body) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRecordsRequest_Body) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRecordsRequest_Body.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return this
+}
+func (m *QueryRecordsRequest_Body) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRecordsRequest_Body.Merge(m, src)
+}
+func (m *QueryRecordsRequest_Body) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRecordsRequest_Body) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRecordsRequest_Body.DiscardUnknown(m)
 }
 
-func NewPopulatedGetLogsRequest(r randyNet, easy bool) *GetLogsRequest {
-	this := &GetLogsRequest{}
-	if r.Intn(5) != 0 {
-		this.Body = NewPopulatedGetLogsRequest_Body(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
+var xxx_messageInfo_QueryRecordsRequest_Body proto.InternalMessageInfo
+
+func (m *QueryRecordsRequest_Body) GetSelector() *QueryRecordsRequest_Selector {
+	if m != nil {
+		return m.Selector
 	}
-	return this
+	return nil
 }
 
-func NewPopulatedGetLogsRequest_Body(r randyNet, easy bool) *GetLogsRequest_Body {
-	this := &GetLogsRequest_Body{}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.ServiceKey = NewPopulatedProtoKey(r)
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+// Selector matches records whose IPLD node field equals a value.
+type QueryRecordsRequest_Selector struct {
+	Target int32  `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
+	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Value  []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func NewPopulatedGetLogsReply(r randyNet, easy bool) *GetLogsReply {
-	this := &GetLogsReply{}
-	if r.Intn(5) != 0 {
-		v7 := r.Intn(5)
-		this.Logs = make([]*Log, v7)
-		for i := 0; i < v7; i++ {
-			this.Logs[i] = NewPopulatedLog(r, easy)
+func (m *QueryRecordsRequest_Selector) Reset()         { *m = QueryRecordsRequest_Selector{} }
+func (m *QueryRecordsRequest_Selector) String() string { return proto.CompactTextString(m) }
+func (*QueryRecordsRequest_Selector) ProtoMessage()    {}
+func (*QueryRecordsRequest_Selector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{28, 1}
+}
+func (m *QueryRecordsRequest_Selector) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRecordsRequest_Selector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRecordsRequest_Selector.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+}
+func (m *QueryRecordsRequest_Selector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRecordsRequest_Selector.Merge(m, src)
+}
+func (m *QueryRecordsRequest_Selector) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRecordsRequest_Selector) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRecordsRequest_Selector.DiscardUnknown(m)
 }
 
-func NewPopulatedPushLogRequest(r randyNet, easy bool) *PushLogRequest {
-	this := &PushLogRequest{}
-	if r.Intn(5) != 0 {
-		this.Body = NewPopulatedPushLogRequest_Body(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
+var xxx_messageInfo_QueryRecordsRequest_Selector proto.InternalMessageInfo
+
+func (m *QueryRecordsRequest_Selector) GetTarget() int32 {
+	if m != nil {
+		return m.Target
 	}
-	return this
+	return 0
 }
 
-func NewPopulatedPushLogRequest_Body(r randyNet, easy bool) *PushLogRequest_Body {
-	this := &PushLogRequest_Body{}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.ServiceKey = NewPopulatedProtoKey(r)
-	this.ReadKey = NewPopulatedProtoKey(r)
-	if r.Intn(5) != 0 {
-		this.Log = NewPopulatedLog(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
+func (m *QueryRecordsRequest_Selector) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return this
+	return ""
 }
 
-func NewPopulatedPushLogReply(r randyNet, easy bool) *PushLogReply {
-	this := &PushLogReply{}
-	if !easy && r.Intn(10) != 0 {
+func (m *QueryRecordsRequest_Selector) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return this
+	return nil
 }
 
-func NewPopulatedGetRecordsRequest(r randyNet, easy bool) *GetRecordsRequest {
-	this := &GetRecordsRequest{}
-	if r.Intn(5) != 0 {
-		this.Body = NewPopulatedGetRecordsRequest_Body(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+// QueryRecordsReply contains the records matching a selector.
+type QueryRecordsReply struct {
+	Matches []*QueryRecordsReply_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
 }
 
-func NewPopulatedGetRecordsRequest_Body(r randyNet, easy bool) *GetRecordsRequest_Body {
-	this := &GetRecordsRequest_Body{}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.ServiceKey = NewPopulatedProtoKey(r)
-	if r.Intn(5) != 0 {
-		v8 := r.Intn(5)
-		this.Logs = make([]*GetRecordsRequest_Body_LogEntry, v8)
-		for i := 0; i < v8; i++ {
-			this.Logs[i] = NewPopulatedGetRecordsRequest_Body_LogEntry(r, easy)
+func (m *QueryRecordsReply) Reset()         { *m = QueryRecordsReply{} }
+func (m *QueryRecordsReply) String() string { return proto.CompactTextString(m) }
+func (*QueryRecordsReply) ProtoMessage()    {}
+func (*QueryRecordsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{29}
+}
+func (m *QueryRecordsReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRecordsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRecordsReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+}
+func (m *QueryRecordsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRecordsReply.Merge(m, src)
+}
+func (m *QueryRecordsReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRecordsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRecordsReply.DiscardUnknown(m)
 }
 
-func NewPopulatedGetRecordsRequest_Body_LogEntry(r randyNet, easy bool) *GetRecordsRequest_Body_LogEntry {
-	this := &GetRecordsRequest_Body_LogEntry{}
-	this.LogID = NewPopulatedProtoPeerID(r)
-	this.Offset = NewPopulatedProtoCid(r)
-	this.Limit = int32(r.Int31())
-	if r.Intn(2) == 0 {
-		this.Limit *= -1
-	}
-	this.Counter = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Counter *= -1
-	}
-	if !easy && r.Intn(10) != 0 {
+var xxx_messageInfo_QueryRecordsReply proto.InternalMessageInfo
+
+func (m *QueryRecordsReply) GetMatches() []*QueryRecordsReply_Match {
+	if m != nil {
+		return m.Matches
 	}
-	return this
+	return nil
 }
 
-func NewPopulatedGetRecordsReply(r randyNet, easy bool) *GetRecordsReply {
-	this := &GetRecordsReply{}
-	if r.Intn(5) != 0 {
-		v9 := r.Intn(5)
-		this.Logs = make([]*GetRecordsReply_LogEntry, v9)
-		for i := 0; i < v9; i++ {
-			this.Logs[i] = NewPopulatedGetRecordsReply_LogEntry(r, easy)
-		}
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type QueryRecordsReply_Match struct {
+	LogID  *ProtoPeerID             `protobuf:"bytes,1,opt,name=logID,proto3,customtype=ProtoPeerID" json:"logID,omitempty"`
+	Record *Log_Record              `protobuf:"bytes,2,opt,name=record,proto3" json:"record,omitempty"`
+	Proof  *QueryRecordsReply_Proof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
 }
 
-func NewPopulatedGetRecordsReply_LogEntry(r randyNet, easy bool) *GetRecordsReply_LogEntry {
-	this := &GetRecordsReply_LogEntry{}
-	this.LogID = NewPopulatedProtoPeerID(r)
-	if r.Intn(5) != 0 {
-		v10 := r.Intn(5)
-		this.Records = make([]*Log_Record, v10)
-		for i := 0; i < v10; i++ {
-			this.Records[i] = NewPopulatedLog_Record(r, easy)
+func (m *QueryRecordsReply_Match) Reset()         { *m = QueryRecordsReply_Match{} }
+func (m *QueryRecordsReply_Match) String() string { return proto.CompactTextString(m) }
+func (*QueryRecordsReply_Match) ProtoMessage()    {}
+func (*QueryRecordsReply_Match) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{29, 0}
+}
+func (m *QueryRecordsReply_Match) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRecordsReply_Match) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRecordsReply_Match.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if r.Intn(5) != 0 {
-		this.Log = NewPopulatedLog(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
 }
-
-func NewPopulatedPushRecordRequest(r randyNet, easy bool) *PushRecordRequest {
-	this := &PushRecordRequest{}
-	if r.Intn(5) != 0 {
-		this.Body = NewPopulatedPushRecordRequest_Body(r, easy)
-	}
-	this.Counter = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Counter *= -1
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func (m *QueryRecordsReply_Match) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRecordsReply_Match.Merge(m, src)
+}
+func (m *QueryRecordsReply_Match) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRecordsReply_Match) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRecordsReply_Match.DiscardUnknown(m)
 }
 
-func NewPopulatedPushRecordRequest_Body(r randyNet, easy bool) *PushRecordRequest_Body {
-	this := &PushRecordRequest_Body{}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.LogID = NewPopulatedProtoPeerID(r)
-	if r.Intn(5) != 0 {
-		this.Record = NewPopulatedLog_Record(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
+var xxx_messageInfo_QueryRecordsReply_Match proto.InternalMessageInfo
+
+func (m *QueryRecordsReply_Match) GetRecord() *Log_Record {
+	if m != nil {
+		return m.Record
 	}
-	return this
+	return nil
 }
 
-func NewPopulatedPushRecordReply(r randyNet, easy bool) *PushRecordReply {
-	this := &PushRecordReply{}
-	if !easy && r.Intn(10) != 0 {
+func (m *QueryRecordsReply_Match) GetProof() *QueryRecordsReply_Proof {
+	if m != nil {
+		return m.Proof
 	}
-	return this
+	return nil
 }
 
-func NewPopulatedExchangeEdgesRequest(r randyNet, easy bool) *ExchangeEdgesRequest {
-	this := &ExchangeEdgesRequest{}
-	if r.Intn(5) != 0 {
-		this.Body = NewPopulatedExchangeEdgesRequest_Body(r, easy)
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+// Proof is an ancestry proof of a record, see core/net.AncestryProof.
+type QueryRecordsReply_Proof struct {
+	Head     *ProtoCid `protobuf:"bytes,1,opt,name=head,proto3,customtype=ProtoCid" json:"head,omitempty"`
+	Counter  int64     `protobuf:"varint,2,opt,name=counter,proto3" json:"counter,omitempty"`
+	Position int64     `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	Records  [][]byte  `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
 }
 
-func NewPopulatedExchangeEdgesRequest_Body(r randyNet, easy bool) *ExchangeEdgesRequest_Body {
-	this := &ExchangeEdgesRequest_Body{}
-	if r.Intn(5) != 0 {
-		v11 := r.Intn(5)
-		this.Threads = make([]*ExchangeEdgesRequest_Body_ThreadEntry, v11)
-		for i := 0; i < v11; i++ {
-			this.Threads[i] = NewPopulatedExchangeEdgesRequest_Body_ThreadEntry(r, easy)
+func (m *QueryRecordsReply_Proof) Reset()         { *m = QueryRecordsReply_Proof{} }
+func (m *QueryRecordsReply_Proof) String() string { return proto.CompactTextString(m) }
+func (*QueryRecordsReply_Proof) ProtoMessage()    {}
+func (*QueryRecordsReply_Proof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a5b10ce944527a32, []int{29, 1}
+}
+func (m *QueryRecordsReply_Proof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRecordsReply_Proof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRecordsReply_Proof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+}
+func (m *QueryRecordsReply_Proof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRecordsReply_Proof.Merge(m, src)
+}
+func (m *QueryRecordsReply_Proof) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRecordsReply_Proof) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRecordsReply_Proof.DiscardUnknown(m)
 }
 
-func NewPopulatedExchangeEdgesRequest_Body_ThreadEntry(r randyNet, easy bool) *ExchangeEdgesRequest_Body_ThreadEntry {
-	this := &ExchangeEdgesRequest_Body_ThreadEntry{}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.AddressEdge = uint64(uint64(r.Uint32()))
-	this.HeadsEdge = uint64(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
+var xxx_messageInfo_QueryRecordsReply_Proof proto.InternalMessageInfo
+
+func (m *QueryRecordsReply_Proof) GetCounter() int64 {
+	if m != nil {
+		return m.Counter
 	}
-	return this
+	return 0
 }
 
-func NewPopulatedExchangeEdgesReply(r randyNet, easy bool) *ExchangeEdgesReply {
-	this := &ExchangeEdgesReply{}
-	if r.Intn(5) != 0 {
-		v12 := r.Intn(5)
-		this.Edges = make([]*ExchangeEdgesReply_ThreadEdges, v12)
-		for i := 0; i < v12; i++ {
-			this.Edges[i] = NewPopulatedExchangeEdgesReply_ThreadEdges(r, easy)
-		}
-	}
-	if !easy && r.Intn(10) != 0 {
+func (m *QueryRecordsReply_Proof) GetPosition() int64 {
+	if m != nil {
+		return m.Position
 	}
-	return this
+	return 0
 }
 
-func NewPopulatedExchangeEdgesReply_ThreadEdges(r randyNet, easy bool) *ExchangeEdgesReply_ThreadEdges {
-	this := &ExchangeEdgesReply_ThreadEdges{}
-	this.ThreadID = NewPopulatedProtoThreadID(r)
-	this.Exists = bool(bool(r.Intn(2) == 0))
-	this.AddressEdge = uint64(uint64(r.Uint32()))
-	this.HeadsEdge = uint64(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
+func (m *QueryRecordsReply_Proof) GetRecords() [][]byte {
+	if m != nil {
+		return m.Records
 	}
-	return this
+	return nil
 }
 
-type randyNet interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
+func init() {
+	proto.RegisterEnum("net.pb.GetRecordsRequest_Direction", GetRecordsRequest_Direction_name, GetRecordsRequest_Direction_value)
+	proto.RegisterEnum("net.pb.Presence_Status", Presence_Status_name, Presence_Status_value)
+	proto.RegisterType((*Log)(nil), "net.pb.Log")
+	proto.RegisterType((*Log_Record)(nil), "net.pb.Log.Record")
+	proto.RegisterType((*GetLogsRequest)(nil), "net.pb.GetLogsRequest")
+	proto.RegisterType((*GetLogsRequest_Body)(nil), "net.pb.GetLogsRequest.Body")
+	proto.RegisterType((*GetLogsRequest_Body_LogEntry)(nil), "net.pb.GetLogsRequest.Body.LogEntry")
+	proto.RegisterType((*GetLogsReply)(nil), "net.pb.GetLogsReply")
+	proto.RegisterType((*PushLogRequest)(nil), "net.pb.PushLogRequest")
+	proto.RegisterType((*PushLogRequest_Body)(nil), "net.pb.PushLogRequest.Body")
+	proto.RegisterType((*PushLogReply)(nil), "net.pb.PushLogReply")
+	proto.RegisterType((*GetRecordsRequest)(nil), "net.pb.GetRecordsRequest")
+	proto.RegisterType((*GetRecordsRequest_Body)(nil), "net.pb.GetRecordsRequest.Body")
+	proto.RegisterType((*GetRecordsRequest_Body_LogEntry)(nil), "net.pb.GetRecordsRequest.Body.LogEntry")
+	proto.RegisterType((*GetRecordsReply)(nil), "net.pb.GetRecordsReply")
+	proto.RegisterType((*GetRecordsReply_LogEntry)(nil), "net.pb.GetRecordsReply.LogEntry")
+	proto.RegisterType((*GetRecordsByCIDRequest)(nil), "net.pb.GetRecordsByCIDRequest")
+	proto.RegisterType((*GetRecordsByCIDRequest_Body)(nil), "net.pb.GetRecordsByCIDRequest.Body")
+	proto.RegisterType((*GetRecordsByCIDRequest_Body_LogEntry)(nil), "net.pb.GetRecordsByCIDRequest.Body.LogEntry")
+	proto.RegisterType((*GetRecordsByCIDReply)(nil), "net.pb.GetRecordsByCIDReply")
+	proto.RegisterType((*GetRecordsByCIDReply_LogEntry)(nil), "net.pb.GetRecordsByCIDReply.LogEntry")
+	proto.RegisterType((*PushRecordRequest)(nil), "net.pb.PushRecordRequest")
+	proto.RegisterType((*PushRecordRequest_Body)(nil), "net.pb.PushRecordRequest.Body")
+	proto.RegisterType((*PushRecordReply)(nil), "net.pb.PushRecordReply")
+	proto.RegisterType((*ExchangeEdgesRequest)(nil), "net.pb.ExchangeEdgesRequest")
+	proto.RegisterType((*ExchangeEdgesRequest_Body)(nil), "net.pb.ExchangeEdgesRequest.Body")
+	proto.RegisterType((*ExchangeEdgesRequest_Body_ThreadEntry)(nil), "net.pb.ExchangeEdgesRequest.Body.ThreadEntry")
+	proto.RegisterType((*ExchangeEdgesReply)(nil), "net.pb.ExchangeEdgesReply")
+	proto.RegisterType((*ExchangeEdgesReply_ThreadEdges)(nil), "net.pb.ExchangeEdgesReply.ThreadEdges")
+	proto.RegisterType((*GetRecentRecordsRequest)(nil), "net.pb.GetRecentRecordsRequest")
+	proto.RegisterType((*GetRecentRecordsRequest_Body)(nil), "net.pb.GetRecentRecordsRequest.Body")
+	proto.RegisterType((*GetRecentRecordsReply)(nil), "net.pb.GetRecentRecordsReply")
+	proto.RegisterType((*Presence)(nil), "net.pb.Presence")
+	proto.RegisterType((*Presence_Body)(nil), "net.pb.Presence.Body")
+	proto.RegisterType((*GetLogDigestsRequest)(nil), "net.pb.GetLogDigestsRequest")
+	proto.RegisterType((*GetLogDigestsRequest_Body)(nil), "net.pb.GetLogDigestsRequest.Body")
+	proto.RegisterType((*GetLogDigestsRequest_Range)(nil), "net.pb.GetLogDigestsRequest.Range")
+	proto.RegisterType((*GetLogDigestsReply)(nil), "net.pb.GetLogDigestsReply")
+	proto.RegisterType((*Follow)(nil), "net.pb.Follow")
+	proto.RegisterType((*Follow_Body)(nil), "net.pb.Follow.Body")
+	proto.RegisterType((*Freeze)(nil), "net.pb.Freeze")
+	proto.RegisterType((*Freeze_Body)(nil), "net.pb.Freeze.Body")
+	proto.RegisterType((*Freeze_Head)(nil), "net.pb.Freeze.Head")
+	proto.RegisterType((*LogAddrs)(nil), "net.pb.LogAddrs")
+	proto.RegisterType((*LogAddrs_Body)(nil), "net.pb.LogAddrs.Body")
+	proto.RegisterType((*TopicMessage)(nil), "net.pb.TopicMessage")
+	proto.RegisterType((*RecordAck)(nil), "net.pb.RecordAck")
+	proto.RegisterType((*RecordAck_Body)(nil), "net.pb.RecordAck.Body")
+	proto.RegisterType((*RecordAck_Range)(nil), "net.pb.RecordAck.Range")
+	proto.RegisterType((*PushAcksRequest)(nil), "net.pb.PushAcksRequest")
+	proto.RegisterType((*PushAcksRequest_Body)(nil), "net.pb.PushAcksRequest.Body")
+	proto.RegisterType((*PushAcksReply)(nil), "net.pb.PushAcksReply")
+	proto.RegisterType((*HandshakeRequest)(nil), "net.pb.HandshakeRequest")
+	proto.RegisterType((*HandshakeRequest_Body)(nil), "net.pb.HandshakeRequest.Body")
+	proto.RegisterType((*HandshakeReply)(nil), "net.pb.HandshakeReply")
+	proto.RegisterType((*GossipPeers)(nil), "net.pb.GossipPeers")
+	proto.RegisterType((*GossipPeers_Peer)(nil), "net.pb.GossipPeers.Peer")
+	proto.RegisterType((*QueryRecordsRequest)(nil), "net.pb.QueryRecordsRequest")
+	proto.RegisterType((*QueryRecordsRequest_Body)(nil), "net.pb.QueryRecordsRequest.Body")
+	proto.RegisterType((*QueryRecordsRequest_Selector)(nil), "net.pb.QueryRecordsRequest.Selector")
+	proto.RegisterType((*QueryRecordsReply)(nil), "net.pb.QueryRecordsReply")
+	proto.RegisterType((*QueryRecordsReply_Match)(nil), "net.pb.QueryRecordsReply.Match")
+	proto.RegisterType((*QueryRecordsReply_Proof)(nil), "net.pb.QueryRecordsReply.Proof")
 }
 
-func randUTF8RuneNet(r randyNet) rune {
-	ru := r.Intn(62)
-	if ru < 10 {
-		return rune(ru + 48)
-	} else if ru < 36 {
-		return rune(ru + 55)
+func init() { proto.RegisterFile("net.proto", fileDescriptor_a5b10ce944527a32) }
+
+var fileDescriptor_a5b10ce944527a32 = []byte{
+	// 2216 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x39, 0x4b, 0x6c, 0x1c, 0x69,
+	0xd1, 0xee, 0xc7, 0xf4, 0xcc, 0x94, 0xdf, 0xdf, 0x3a, 0xf6, 0xa4, 0xd7, 0x19, 0xcf, 0xdf, 0x9b,
+	0x75, 0xb2, 0xbb, 0xc9, 0xe4, 0xc7, 0xd9, 0x68, 0xb3, 0xec, 0x4a, 0x60, 0xc7, 0x89, 0x63, 0xe2,
+	0x4d, 0xbc, 0x9f, 0x23, 0xad, 0x38, 0x70, 0x68, 0x4f, 0x7f, 0xee, 0x69, 0x65, 0x3c, 0x3d, 0x74,
+	0xb7, 0x4d, 0x26, 0xe2, 0x02, 0x42, 0xe2, 0x25, 0x21, 0xd0, 0x0a, 0x69, 0x0f, 0x48, 0x70, 0xe1,
+	0xc8, 0x71, 0x0f, 0x9c, 0x00, 0x89, 0x03, 0x27, 0x14, 0x71, 0x5a, 0x45, 0xc2, 0x40, 0x7c, 0xe2,
+	0x71, 0x40, 0x1c, 0x10, 0x12, 0x48, 0xa0, 0xef, 0xd5, 0xd3, 0x3d, 0x33, 0xdd, 0xb6, 0xb3, 0xb2,
+	0x2f, 0xa3, 0xae, 0xaf, 0xaa, 0xbe, 0x47, 0xbd, 0xab, 0x06, 0xca, 0x6d, 0x12, 0xd5, 0x3b, 0x81,
+	0x1f, 0xf9, 0xc8, 0x60, 0x9f, 0xdb, 0xe6, 0x55, 0xd7, 0x8b, 0x9a, 0x7b, 0xdb, 0xf5, 0x86, 0xbf,
+	0x7b, 0xcd, 0xf5, 0x5d, 0xff, 0x1a, 0x43, 0x6f, 0xef, 0xed, 0x30, 0x88, 0x01, 0xec, 0x8b, 0xb3,
+	0x59, 0x1f, 0xab, 0xa0, 0x6d, 0xf8, 0x2e, 0x5a, 0x00, 0x75, 0x7d, 0xb5, 0xa2, 0xd4, 0x94, 0xcb,
+	0x63, 0x2b, 0x93, 0xcf, 0x0e, 0x16, 0x46, 0x37, 0x29, 0x7a, 0x93, 0x90, 0x60, 0x7d, 0x15, 0xab,
+	0xeb, 0xab, 0xe8, 0x12, 0x18, 0x9d, 0xbd, 0xed, 0x7b, 0xa4, 0x5b, 0x51, 0xfb, 0x89, 0xd8, 0x32,
+	0x16, 0x68, 0xf4, 0x0a, 0x14, 0x6c, 0xc7, 0x09, 0xc2, 0x8a, 0x56, 0xd3, 0x2e, 0x8f, 0xad, 0x8c,
+	0x3f, 0x3b, 0x58, 0x28, 0x33, 0xba, 0x65, 0xc7, 0x09, 0x30, 0xc7, 0xa1, 0x1a, 0xe8, 0x4d, 0x62,
+	0x3b, 0x15, 0x9d, 0xed, 0x35, 0xf6, 0xec, 0x60, 0xa1, 0xc4, 0x68, 0x6e, 0x79, 0x0e, 0x66, 0x18,
+	0x54, 0x81, 0x62, 0xc3, 0xdf, 0x6b, 0x47, 0x24, 0xa8, 0x14, 0x6a, 0xca, 0x65, 0x0d, 0x4b, 0xd0,
+	0xfc, 0xba, 0x02, 0x06, 0x26, 0x0d, 0x3f, 0x70, 0x50, 0x15, 0x20, 0x60, 0x5f, 0xf7, 0x7d, 0x87,
+	0xf0, 0xdb, 0xe3, 0xc4, 0x0a, 0x9a, 0x87, 0x32, 0xd9, 0x27, 0xed, 0x88, 0xa1, 0xd9, 0xbd, 0x71,
+	0x6f, 0x81, 0x72, 0xd3, 0xa3, 0x48, 0xc0, 0xd0, 0x1a, 0xe7, 0xee, 0xad, 0x20, 0x13, 0x4a, 0xdb,
+	0xbe, 0xd3, 0x65, 0x58, 0x76, 0x51, 0x1c, 0xc3, 0xd6, 0xf7, 0x34, 0x98, 0x58, 0x23, 0xd1, 0x86,
+	0xef, 0x86, 0x98, 0x7c, 0x79, 0x8f, 0x84, 0x11, 0xba, 0x06, 0x3a, 0x45, 0xb3, 0x73, 0x46, 0x97,
+	0x5e, 0xae, 0x73, 0x85, 0xd4, 0xd3, 0x54, 0xf5, 0x15, 0xdf, 0xe9, 0x62, 0x46, 0x68, 0xfe, 0x5a,
+	0x05, 0x9d, 0x82, 0xe8, 0x2a, 0x94, 0xa2, 0x66, 0x40, 0x6c, 0x27, 0x56, 0xc1, 0xf4, 0xb3, 0x83,
+	0x85, 0x71, 0x26, 0x91, 0x87, 0x02, 0x81, 0x63, 0x12, 0x74, 0x05, 0x20, 0x24, 0xc1, 0xbe, 0xd7,
+	0x20, 0x3d, 0x75, 0xf4, 0x44, 0x48, 0x75, 0x91, 0xc0, 0xa3, 0x9b, 0xa0, 0xb7, 0x7c, 0x97, 0xab,
+	0x63, 0x74, 0xe9, 0x62, 0xce, 0xb5, 0xea, 0x1b, 0xbe, 0x7b, 0xbb, 0x1d, 0x05, 0x5d, 0xcc, 0x38,
+	0xd0, 0x65, 0x28, 0xee, 0xf8, 0xad, 0x96, 0xff, 0x95, 0xb0, 0xa2, 0x33, 0xe6, 0x09, 0xc9, 0x7c,
+	0x87, 0x2d, 0x63, 0x89, 0x46, 0x8b, 0x60, 0xec, 0x04, 0x84, 0x3c, 0x21, 0x4c, 0x57, 0x49, 0x42,
+	0xb6, 0x8a, 0x05, 0xd6, 0xdc, 0x82, 0x92, 0x3c, 0x03, 0xbd, 0x0a, 0x85, 0x96, 0xef, 0x66, 0x1b,
+	0x1d, 0xc7, 0xa2, 0x1a, 0x8c, 0x52, 0x93, 0x21, 0x61, 0x78, 0xdb, 0x71, 0xb9, 0x12, 0x75, 0x9c,
+	0x5c, 0xfa, 0x82, 0x5e, 0x52, 0xa6, 0x54, 0xeb, 0x6b, 0x0a, 0x8c, 0xc5, 0x6f, 0xea, 0xb4, 0xba,
+	0x68, 0x41, 0xbc, 0x5b, 0x61, 0x57, 0x1f, 0x95, 0x37, 0xda, 0xf0, 0xdd, 0xc1, 0xe7, 0xa9, 0xc7,
+	0x7d, 0x9e, 0x96, 0xf7, 0x3c, 0xeb, 0x47, 0x2a, 0x4c, 0x6c, 0xee, 0x85, 0x4d, 0x7a, 0x46, 0xbe,
+	0x51, 0xa4, 0xa9, 0x92, 0x46, 0xf1, 0x3b, 0xe5, 0x2c, 0x8c, 0x62, 0x11, 0x8a, 0x94, 0x8f, 0x92,
+	0x6a, 0x43, 0x48, 0x25, 0x12, 0x5d, 0x00, 0xad, 0xe5, 0xbb, 0xcc, 0xfa, 0xfb, 0x64, 0x48, 0xd7,
+	0xd1, 0xa2, 0xf4, 0x75, 0xae, 0xf6, 0xa9, 0x04, 0x01, 0xf5, 0xf6, 0x50, 0xb8, 0xbb, 0x50, 0xd1,
+	0x04, 0x8c, 0xc5, 0xef, 0xee, 0xb4, 0xba, 0xd6, 0xcf, 0x74, 0x98, 0x5e, 0x23, 0x11, 0xf7, 0xe5,
+	0xd8, 0x8d, 0x96, 0x52, 0x12, 0xab, 0x26, 0xec, 0x35, 0x4d, 0x98, 0x14, 0xda, 0xc7, 0xda, 0x59,
+	0x08, 0xed, 0x9d, 0x94, 0x27, 0x5d, 0xca, 0xbf, 0x59, 0xbf, 0x33, 0xd5, 0x60, 0x94, 0x87, 0x96,
+	0xf0, 0x41, 0xbb, 0xd5, 0x65, 0x12, 0x2d, 0xe1, 0xe4, 0x92, 0xf9, 0x77, 0xe5, 0xe4, 0xde, 0x71,
+	0x11, 0x0c, 0x7f, 0x67, 0x27, 0x24, 0x51, 0x45, 0x1d, 0x12, 0x49, 0x05, 0x0e, 0xcd, 0x40, 0xa1,
+	0xe5, 0xed, 0x7a, 0x11, 0xd3, 0x75, 0x01, 0x73, 0x20, 0x19, 0x61, 0xf5, 0x54, 0x84, 0x45, 0xcb,
+	0x50, 0x76, 0xbc, 0x80, 0x34, 0x22, 0xcf, 0x6f, 0x33, 0xd5, 0x4e, 0x2c, 0xbd, 0x92, 0xfd, 0xda,
+	0x55, 0x49, 0x8a, 0x7b, 0x5c, 0xf4, 0x62, 0x76, 0xbb, 0xd1, 0xf4, 0x83, 0x8a, 0x31, 0xec, 0x62,
+	0x1c, 0x67, 0x2d, 0x42, 0x39, 0xe6, 0x46, 0xa3, 0x50, 0xbc, 0xf3, 0x00, 0x7f, 0xb0, 0x8c, 0x57,
+	0xa7, 0x46, 0xd0, 0x18, 0x94, 0x56, 0x96, 0x6f, 0xdd, 0x63, 0x90, 0x22, 0xec, 0xe7, 0x2f, 0x0a,
+	0x4c, 0x26, 0x8f, 0xa7, 0x5e, 0xfe, 0x66, 0xca, 0xcb, 0x6b, 0xc3, 0x6e, 0xd9, 0x69, 0xf5, 0x2b,
+	0xc3, 0xfc, 0xc9, 0x0b, 0x88, 0xfa, 0x0a, 0x75, 0x19, 0xb6, 0xa5, 0x08, 0x17, 0x28, 0x61, 0xed,
+	0x75, 0x7e, 0x1a, 0x96, 0x24, 0xd2, 0x71, 0xb4, 0x0c, 0xc7, 0xa9, 0x81, 0xbe, 0x6d, 0x87, 0x64,
+	0x78, 0xfe, 0xa3, 0x18, 0xeb, 0x13, 0x15, 0x66, 0x7b, 0xaf, 0x58, 0xe9, 0xde, 0x5a, 0x5f, 0x95,
+	0x1e, 0xf2, 0x96, 0xf0, 0x10, 0x85, 0x6d, 0x3e, 0x44, 0x33, 0x49, 0xea, 0xa4, 0x9b, 0x7c, 0xe3,
+	0x4c, 0x12, 0xce, 0xe7, 0x53, 0x6e, 0x72, 0xe5, 0x18, 0xd7, 0xeb, 0x57, 0xcf, 0x97, 0x4e, 0xae,
+	0x9d, 0xd7, 0xa1, 0xcc, 0x45, 0xbf, 0xbe, 0xca, 0xf5, 0xd3, 0x2f, 0xd5, 0x1e, 0xda, 0xfa, 0x9b,
+	0x02, 0x33, 0x03, 0xb7, 0xa1, 0xc6, 0xf4, 0x76, 0xca, 0x98, 0x5e, 0xcd, 0xbc, 0xf9, 0x10, 0x8b,
+	0xfa, 0xe8, 0xd4, 0x2d, 0xea, 0x2d, 0x30, 0x3a, 0x81, 0xef, 0xef, 0x48, 0xc1, 0x2e, 0x48, 0xe2,
+	0xf7, 0xf7, 0x48, 0xd0, 0x4d, 0x59, 0xfb, 0x26, 0xa5, 0xc3, 0x82, 0xdc, 0xfa, 0x87, 0x02, 0xd3,
+	0x34, 0xee, 0x8a, 0x0d, 0xf3, 0xc3, 0xec, 0x00, 0x61, 0xc2, 0x7e, 0x92, 0x11, 0x43, 0x4b, 0xd7,
+	0x64, 0xdf, 0x7a, 0xc1, 0xac, 0x15, 0x4b, 0x4a, 0x3d, 0x42, 0xbb, 0x06, 0x17, 0x83, 0x70, 0xa8,
+	0x61, 0x82, 0x12, 0x14, 0x22, 0x56, 0x4c, 0xc3, 0x64, 0xf2, 0x29, 0x34, 0xdd, 0xfc, 0x55, 0x85,
+	0x99, 0xdb, 0x8f, 0x1b, 0x4d, 0xbb, 0xed, 0x12, 0x5a, 0x38, 0xc4, 0x19, 0xe7, 0x46, 0x4a, 0x14,
+	0xff, 0x27, 0xf7, 0x1e, 0x46, 0x9b, 0xf4, 0xa6, 0x1f, 0x4a, 0x6f, 0x5a, 0x83, 0x22, 0x7f, 0x90,
+	0xb4, 0x9c, 0xab, 0x47, 0x6e, 0x51, 0xe7, 0xb2, 0xe0, 0x16, 0x24, 0xb9, 0xd1, 0x45, 0x18, 0xdf,
+	0xb5, 0x1f, 0xf3, 0x3b, 0x6f, 0x79, 0x4f, 0x78, 0xb5, 0xa3, 0xe1, 0xf4, 0x22, 0xcd, 0x24, 0xae,
+	0x1f, 0x86, 0x5e, 0x87, 0xca, 0x28, 0x14, 0x31, 0x3d, 0xb9, 0x64, 0x7e, 0x15, 0x46, 0x13, 0xfb,
+	0x9f, 0x54, 0x27, 0x47, 0x56, 0x5c, 0xb4, 0xac, 0xa6, 0x89, 0x8b, 0xe3, 0x35, 0x86, 0xef, 0x2d,
+	0x08, 0x05, 0xfc, 0x53, 0x05, 0xd4, 0xf7, 0x7c, 0xea, 0x62, 0xef, 0x42, 0x81, 0x50, 0x48, 0x48,
+	0x6a, 0x31, 0x43, 0x52, 0xd4, 0x8a, 0xc5, 0x13, 0xd8, 0x02, 0x67, 0x3a, 0x9e, 0x80, 0xcc, 0x7f,
+	0x2b, 0xf1, 0xfb, 0x19, 0xd7, 0x09, 0xdf, 0x3f, 0x0b, 0x06, 0x79, 0xec, 0x85, 0x51, 0xc8, 0x76,
+	0x2f, 0x61, 0x01, 0xf5, 0xcb, 0x45, 0x3b, 0x42, 0x2e, 0x7a, 0x9f, 0x5c, 0x10, 0x12, 0xd1, 0xa5,
+	0xc0, 0x5a, 0x09, 0xf6, 0x8d, 0xde, 0x81, 0x02, 0x23, 0xa8, 0x18, 0x27, 0x09, 0x39, 0x9c, 0x87,
+	0xa6, 0xf5, 0x0e, 0x33, 0x81, 0x22, 0xdb, 0x91, 0x03, 0xd6, 0x1f, 0x14, 0x98, 0xe3, 0xec, 0xa4,
+	0xdd, 0x5f, 0x5b, 0xdd, 0x4c, 0x65, 0x8e, 0x8b, 0xe9, 0xd3, 0x06, 0xc8, 0x93, 0xc6, 0xfe, 0xed,
+	0x33, 0x29, 0x4b, 0x07, 0xf4, 0xab, 0x0d, 0xd1, 0xaf, 0xb5, 0x01, 0xe7, 0x06, 0x6f, 0x4c, 0x8d,
+	0xeb, 0x7a, 0x2f, 0xa0, 0x72, 0xf3, 0x3a, 0x9f, 0x19, 0xd6, 0xe2, 0xb8, 0x6a, 0xfd, 0x47, 0x85,
+	0xd2, 0x66, 0x40, 0x42, 0xd2, 0x6e, 0x10, 0xf4, 0x5a, 0x4a, 0x40, 0xe7, 0x62, 0x76, 0x81, 0x4f,
+	0x06, 0xc3, 0x29, 0xd0, 0x42, 0xcf, 0x15, 0x5d, 0x25, 0xfd, 0xa4, 0x06, 0xe2, 0x39, 0xa4, 0x1d,
+	0x79, 0x51, 0x77, 0xcb, 0x73, 0x45, 0x43, 0x99, 0x5c, 0x32, 0x0f, 0x5f, 0x50, 0x8a, 0xb4, 0xf9,
+	0x66, 0x01, 0x31, 0x2b, 0x4e, 0x0a, 0x34, 0x6d, 0x59, 0xe5, 0x79, 0xe2, 0xfc, 0x18, 0x46, 0xd7,
+	0xc0, 0x08, 0x23, 0x3b, 0xda, 0x0b, 0x99, 0x69, 0x4e, 0x2c, 0xcd, 0x0d, 0xbc, 0x6e, 0x8b, 0xa1,
+	0xb1, 0x20, 0xa3, 0xe1, 0xbe, 0x63, 0x77, 0x5b, 0xbe, 0xed, 0x08, 0x9b, 0x95, 0x20, 0x35, 0xf4,
+	0xc8, 0xdb, 0x25, 0x61, 0x64, 0xef, 0x76, 0x58, 0x81, 0xa7, 0xe1, 0xde, 0x82, 0xf5, 0x06, 0x18,
+	0x7c, 0x27, 0x5a, 0xd2, 0x3d, 0xb8, 0x73, 0x67, 0x63, 0xfd, 0xfe, 0xed, 0xa9, 0x11, 0x04, 0x60,
+	0x3c, 0xb8, 0xcf, 0xbe, 0x15, 0x54, 0x02, 0x7d, 0xf9, 0x83, 0xe5, 0x2f, 0x4e, 0xa9, 0xd6, 0xa1,
+	0xca, 0x92, 0xf1, 0x86, 0xef, 0xae, 0x7a, 0x2e, 0x09, 0xa3, 0x81, 0xa8, 0xac, 0xa4, 0xa3, 0xf2,
+	0x30, 0xda, 0xa4, 0xa1, 0x1e, 0x9c, 0x89, 0xa1, 0xc6, 0x79, 0x4b, 0xcb, 0xcd, 0x5b, 0xb3, 0x60,
+	0xb4, 0x48, 0xdb, 0x8d, 0x9a, 0xa2, 0xc2, 0x16, 0x10, 0xfa, 0x2c, 0x18, 0x01, 0x8d, 0x76, 0x34,
+	0x18, 0x50, 0x3b, 0xb5, 0x72, 0x5f, 0x87, 0x29, 0x29, 0x16, 0x1c, 0xe6, 0x75, 0x28, 0xb0, 0x05,
+	0x56, 0xd5, 0x93, 0x7d, 0xd2, 0xaa, 0x28, 0xa2, 0xaa, 0xa7, 0x00, 0x5d, 0xf5, 0xda, 0x0e, 0x79,
+	0x2c, 0x42, 0x23, 0x07, 0xac, 0xbb, 0x80, 0xfa, 0xb6, 0xee, 0xb4, 0x52, 0xf9, 0x5c, 0x49, 0x77,
+	0x00, 0x15, 0x28, 0x3a, 0x9c, 0x92, 0x17, 0x53, 0x58, 0x82, 0xd6, 0x7f, 0x15, 0x30, 0x78, 0x7f,
+	0x8c, 0x2e, 0xa5, 0x34, 0xf4, 0x52, 0xba, 0x7b, 0xce, 0x75, 0x15, 0xf3, 0xe7, 0xa7, 0xed, 0x08,
+	0xc7, 0x9a, 0x42, 0xcd, 0x82, 0x61, 0x37, 0x22, 0x6f, 0x9f, 0x88, 0x76, 0x4c, 0x40, 0x69, 0xf3,
+	0x2e, 0xf4, 0x9b, 0xf7, 0x6f, 0x55, 0x30, 0x78, 0xe3, 0x9f, 0x29, 0x01, 0x86, 0xcd, 0x97, 0xc0,
+	0x2f, 0x4e, 0x5b, 0x02, 0xb3, 0x74, 0x68, 0xe1, 0x3f, 0x21, 0x6d, 0x66, 0xa3, 0x25, 0x2c, 0x20,
+	0xf4, 0x9a, 0x4c, 0x39, 0x7c, 0xa6, 0xd3, 0x7f, 0xe9, 0xbb, 0xc4, 0x76, 0x64, 0x82, 0xc9, 0x95,
+	0x83, 0xb9, 0x06, 0x3a, 0x25, 0x3e, 0x6e, 0xb5, 0x9b, 0x30, 0x36, 0x35, 0x65, 0x6c, 0xd6, 0x9f,
+	0x79, 0xed, 0xcc, 0x26, 0x06, 0x59, 0x11, 0x58, 0xe2, 0xf3, 0x85, 0xfa, 0xe3, 0xd3, 0x2d, 0x43,
+	0x8f, 0x65, 0x54, 0x29, 0xa1, 0xe9, 0xfd, 0xc6, 0x73, 0x13, 0xc6, 0x1e, 0xfa, 0x1d, 0xaf, 0xf1,
+	0x1e, 0x09, 0x43, 0x9b, 0x17, 0x05, 0x8e, 0x1d, 0xd9, 0xfc, 0x92, 0x98, 0x7d, 0xb3, 0xbc, 0x4e,
+	0x4b, 0x77, 0xf1, 0x32, 0x0e, 0x58, 0x1f, 0x69, 0x50, 0xe6, 0x29, 0x6c, 0xb9, 0xf1, 0x08, 0xbd,
+	0x9e, 0x12, 0xd3, 0xac, 0x14, 0x53, 0x4c, 0x90, 0x2f, 0xa7, 0x6f, 0xaa, 0xa7, 0x2a, 0xa7, 0x9e,
+	0x8d, 0x6a, 0xf9, 0x36, 0x7a, 0x03, 0xca, 0x0e, 0x69, 0x79, 0xfb, 0x24, 0x20, 0x8e, 0x18, 0x32,
+	0xcd, 0x0d, 0x3e, 0x85, 0xc7, 0xbf, 0x1e, 0x25, 0x7a, 0x03, 0xf4, 0x90, 0x90, 0x76, 0xa5, 0x90,
+	0xcf, 0xc1, 0x88, 0xf2, 0x73, 0x95, 0x79, 0x4b, 0x46, 0x53, 0x39, 0x91, 0x56, 0x8e, 0x33, 0x91,
+	0xee, 0x33, 0xe0, 0xa7, 0x0a, 0xef, 0x36, 0x96, 0x1b, 0x8f, 0xe2, 0xf4, 0xf5, 0xff, 0x29, 0x05,
+	0xcd, 0x27, 0x0b, 0x91, 0x04, 0x59, 0x32, 0x73, 0x7d, 0xe7, 0x8c, 0x32, 0x97, 0x6e, 0x37, 0x1e,
+	0xc9, 0x26, 0x72, 0x7a, 0x40, 0x76, 0x98, 0xa1, 0xad, 0x49, 0x18, 0xef, 0x5d, 0x95, 0x76, 0x4f,
+	0xdf, 0x55, 0x60, 0xea, 0xae, 0xdd, 0x76, 0xc2, 0xa6, 0xfd, 0x88, 0xc8, 0x47, 0x7e, 0x26, 0xf5,
+	0xc8, 0x0b, 0x72, 0xb3, 0x7e, 0xba, 0xe4, 0x2b, 0x57, 0xc5, 0x23, 0x2b, 0x50, 0xdc, 0x27, 0x41,
+	0x48, 0x27, 0x4c, 0x94, 0xbb, 0x8c, 0x25, 0x88, 0x2c, 0x18, 0x6b, 0xd8, 0x1d, 0x7b, 0xdb, 0x6b,
+	0x79, 0x91, 0x47, 0x78, 0x02, 0x2a, 0xe3, 0xd4, 0x9a, 0x75, 0x1f, 0x26, 0x12, 0x87, 0x88, 0x5c,
+	0xf6, 0x29, 0xf6, 0xfb, 0x50, 0x81, 0xd1, 0xb5, 0x5e, 0x07, 0x85, 0xea, 0xb2, 0xb4, 0xe6, 0x75,
+	0x64, 0x25, 0xce, 0xcf, 0x3d, 0x9a, 0x3a, 0xfd, 0x15, 0x45, 0xb7, 0xf9, 0x10, 0x74, 0x0a, 0x26,
+	0x2c, 0x5f, 0x39, 0x66, 0x7e, 0x52, 0xb3, 0x43, 0x89, 0xf5, 0x2b, 0x15, 0x5e, 0x4a, 0x77, 0xf7,
+	0x5c, 0xec, 0x6f, 0xa6, 0xc4, 0x5e, 0x1b, 0x3e, 0x08, 0x18, 0x90, 0xfc, 0x4f, 0x95, 0xb3, 0x99,
+	0xfe, 0x94, 0x42, 0xd2, 0x22, 0x8d, 0xc8, 0x0f, 0x2a, 0x5a, 0xba, 0xcd, 0x18, 0x76, 0xbf, 0x2d,
+	0x41, 0x8b, 0x63, 0x2e, 0x73, 0x03, 0x4a, 0x72, 0x95, 0x26, 0xb1, 0xc8, 0x0e, 0x5c, 0x12, 0x89,
+	0x22, 0x47, 0x40, 0x34, 0x6c, 0x76, 0xec, 0xa8, 0xc9, 0x6e, 0x53, 0xc6, 0xec, 0x9b, 0x86, 0xcd,
+	0x7d, 0xbb, 0xb5, 0x27, 0xff, 0xc9, 0xe1, 0x80, 0xf5, 0x7b, 0x15, 0xa6, 0x07, 0x26, 0x24, 0xe8,
+	0x6d, 0x28, 0xee, 0xda, 0x51, 0xa3, 0x19, 0x37, 0xa2, 0x39, 0xd3, 0x94, 0xf7, 0x28, 0x21, 0x96,
+	0xf4, 0xe6, 0x0f, 0x14, 0x28, 0xb0, 0xa5, 0xe3, 0x8f, 0xa6, 0xe4, 0xf0, 0x42, 0x3d, 0x6a, 0x78,
+	0x81, 0x6e, 0xc8, 0xd0, 0xcf, 0x45, 0x77, 0xe4, 0x8c, 0x87, 0x53, 0x9b, 0x5d, 0x28, 0x30, 0xf8,
+	0xd3, 0x44, 0x31, 0xda, 0x3b, 0x74, 0xfc, 0xd0, 0x63, 0x43, 0x5f, 0xde, 0x77, 0xc5, 0x30, 0xe5,
+	0x92, 0x9d, 0x95, 0xce, 0xeb, 0x41, 0x01, 0x2e, 0x7d, 0x68, 0x40, 0x71, 0x8b, 0xab, 0x9f, 0x4a,
+	0x55, 0xfc, 0x05, 0x83, 0x66, 0x87, 0xff, 0xcf, 0x64, 0xce, 0x0c, 0xac, 0xd3, 0xe0, 0x32, 0x42,
+	0x59, 0xc5, 0x7f, 0x03, 0x3d, 0xd6, 0xf4, 0x9f, 0x24, 0xe6, 0xcc, 0xc0, 0x3a, 0x67, 0x5d, 0x01,
+	0xe8, 0xb5, 0xcb, 0xe8, 0x7c, 0xe6, 0xa0, 0xda, 0x9c, 0xcb, 0x98, 0x0e, 0x5b, 0x23, 0xe8, 0x7d,
+	0x98, 0xec, 0x6b, 0xb9, 0x51, 0x35, 0x7f, 0x70, 0x69, 0xce, 0xe7, 0xf5, 0xea, 0xfc, 0x5a, 0xbd,
+	0xae, 0x13, 0x65, 0x77, 0xa2, 0xe6, 0xdc, 0x30, 0x14, 0xdf, 0xe3, 0x1e, 0x8c, 0xa7, 0x06, 0x23,
+	0x68, 0x3e, 0x6f, 0xb2, 0x64, 0x9a, 0xd9, 0xd3, 0x14, 0x6b, 0x04, 0x3d, 0x84, 0xa9, 0xfe, 0xb6,
+	0x19, 0x2d, 0x1c, 0x31, 0x02, 0x30, 0x2f, 0x64, 0x13, 0xc4, 0x57, 0x4c, 0x75, 0x16, 0x68, 0x3e,
+	0xaf, 0x97, 0x31, 0xcd, 0x0c, 0x2c, 0xdf, 0xec, 0x5d, 0x28, 0xc9, 0xac, 0x83, 0xe6, 0x32, 0x52,
+	0xa6, 0x79, 0x6e, 0x10, 0xc1, 0xb9, 0x3f, 0x07, 0xe5, 0x38, 0x29, 0xa0, 0x4a, 0x56, 0x32, 0x32,
+	0x67, 0x87, 0x60, 0xf8, 0x06, 0x77, 0x61, 0x2c, 0xe9, 0x68, 0xe8, 0xe5, 0x9c, 0xc8, 0x65, 0x9e,
+	0xcf, 0xf4, 0x4d, 0x6b, 0x64, 0xa5, 0xf6, 0xaf, 0x3f, 0x55, 0x95, 0x5f, 0x3e, 0xaf, 0x2a, 0xbf,
+	0x79, 0x5e, 0x55, 0x9e, 0x3e, 0xaf, 0x2a, 0x7f, 0x7c, 0x5e, 0x55, 0xbe, 0x7f, 0x58, 0x1d, 0x79,
+	0x7a, 0x58, 0x1d, 0xf9, 0xe4, 0xb0, 0x3a, 0xb2, 0x6d, 0xb0, 0xff, 0xdf, 0xaf, 0xff, 0x6f, 0x00,
+	0xdc, 0x0b, 0xc2, 0x95, 0xc3, 0x1f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ServiceClient is the client API for Service service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ServiceClient interface {
+	// GetLogs from a peer.
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsReply, error)
+	// PushLog to a peer.
+	PushLog(ctx context.Context, in *PushLogRequest, opts ...grpc.CallOption) (*PushLogReply, error)
+	// GetRecords from a peer.
+	GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsReply, error)
+	// GetRecordsByCID from a peer.
+	GetRecordsByCID(ctx context.Context, in *GetRecordsByCIDRequest, opts ...grpc.CallOption) (*GetRecordsByCIDReply, error)
+	// PushRecord to a peer.
+	PushRecord(ctx context.Context, in *PushRecordRequest, opts ...grpc.CallOption) (*PushRecordReply, error)
+	// ExchangeEdges with a peer.
+	ExchangeEdges(ctx context.Context, in *ExchangeEdgesRequest, opts ...grpc.CallOption) (*ExchangeEdgesReply, error)
+	// GetRecentRecords multicast over a thread's topic from a peer.
+	GetRecentRecords(ctx context.Context, in *GetRecentRecordsRequest, opts ...grpc.CallOption) (*GetRecentRecordsReply, error)
+	// GetLogDigests from a peer.
+	GetLogDigests(ctx context.Context, in *GetLogDigestsRequest, opts ...grpc.CallOption) (*GetLogDigestsReply, error)
+	// PushAcks of records to a log author.
+	PushAcks(ctx context.Context, in *PushAcksRequest, opts ...grpc.CallOption) (*PushAcksReply, error)
+	// Handshake exchanges protocol versions and capabilities with a peer.
+	Handshake(ctx context.Context, in *HandshakeRequest, opts ...grpc.CallOption) (*HandshakeReply, error)
+	// QueryRecords matching a selector from a peer.
+	QueryRecords(ctx context.Context, in *QueryRecordsRequest, opts ...grpc.CallOption) (*QueryRecordsReply, error)
+}
+
+type serviceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewServiceClient(cc *grpc.ClientConn) ServiceClient {
+	return &serviceClient{cc}
+}
+
+func (c *serviceClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsReply, error) {
+	out := new(GetLogsReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/GetLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return rune(ru + 61)
+	return out, nil
 }
-func randStringNet(r randyNet) string {
-	v13 := r.Intn(100)
-	tmps := make([]rune, v13)
-	for i := 0; i < v13; i++ {
-		tmps[i] = randUTF8RuneNet(r)
+
+func (c *serviceClient) PushLog(ctx context.Context, in *PushLogRequest, opts ...grpc.CallOption) (*PushLogReply, error) {
+	out := new(PushLogReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/PushLog", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return string(tmps)
+	return out, nil
 }
-func randUnrecognizedNet(r randyNet, maxFieldNumber int) (dAtA []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
-		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		dAtA = randFieldNet(dAtA, r, fieldNumber, wire)
+
+func (c *serviceClient) GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsReply, error) {
+	out := new(GetRecordsReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/GetRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return dAtA
+	return out, nil
 }
-func randFieldNet(dAtA []byte, r randyNet, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
-		v14 := r.Int63()
-		if r.Intn(2) == 0 {
-			v14 *= -1
-		}
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(v14))
-	case 1:
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
-		ll := r.Intn(100)
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(ll))
-		for j := 0; j < ll; j++ {
-			dAtA = append(dAtA, byte(r.Intn(256)))
-		}
-	default:
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+
+func (c *serviceClient) GetRecordsByCID(ctx context.Context, in *GetRecordsByCIDRequest, opts ...grpc.CallOption) (*GetRecordsByCIDReply, error) {
+	out := new(GetRecordsByCIDReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/GetRecordsByCID", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return dAtA
+	return out, nil
 }
-func encodeVarintPopulateNet(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
+
+func (c *serviceClient) PushRecord(ctx context.Context, in *PushRecordRequest, opts ...grpc.CallOption) (*PushRecordReply, error) {
+	out := new(PushRecordReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/PushRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
+	return out, nil
 }
-func (m *Log) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ID != nil {
-		l = m.ID.Size()
-		n += 1 + l + sovNet(uint64(l))
+
+func (c *serviceClient) ExchangeEdges(ctx context.Context, in *ExchangeEdgesRequest, opts ...grpc.CallOption) (*ExchangeEdgesReply, error) {
+	out := new(ExchangeEdgesReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/ExchangeEdges", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.PubKey != nil {
-		l = m.PubKey.Size()
-		n += 1 + l + sovNet(uint64(l))
+	return out, nil
+}
+
+func (c *serviceClient) GetRecentRecords(ctx context.Context, in *GetRecentRecordsRequest, opts ...grpc.CallOption) (*GetRecentRecordsReply, error) {
+	out := new(GetRecentRecordsReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/GetRecentRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Addrs) > 0 {
-		for _, e := range m.Addrs {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
-		}
+	return out, nil
+}
+
+func (c *serviceClient) GetLogDigests(ctx context.Context, in *GetLogDigestsRequest, opts ...grpc.CallOption) (*GetLogDigestsReply, error) {
+	out := new(GetLogDigestsReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/GetLogDigests", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Head != nil {
-		l = m.Head.Size()
-		n += 1 + l + sovNet(uint64(l))
+	return out, nil
+}
+
+func (c *serviceClient) PushAcks(ctx context.Context, in *PushAcksRequest, opts ...grpc.CallOption) (*PushAcksReply, error) {
+	out := new(PushAcksReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/PushAcks", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Counter != 0 {
-		n += 1 + sovNet(uint64(m.Counter))
+	return out, nil
+}
+
+func (c *serviceClient) Handshake(ctx context.Context, in *HandshakeRequest, opts ...grpc.CallOption) (*HandshakeReply, error) {
+	out := new(HandshakeReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/Handshake", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *Log_Record) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *serviceClient) QueryRecords(ctx context.Context, in *QueryRecordsRequest, opts ...grpc.CallOption) (*QueryRecordsReply, error) {
+	out := new(QueryRecordsReply)
+	err := c.cc.Invoke(ctx, "/net.pb.Service/QueryRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.RecordNode)
-	if l > 0 {
-		n += 1 + l + sovNet(uint64(l))
+	return out, nil
+}
+
+// ServiceServer is the server API for Service service.
+type ServiceServer interface {
+	// GetLogs from a peer.
+	GetLogs(context.Context, *GetLogsRequest) (*GetLogsReply, error)
+	// PushLog to a peer.
+	PushLog(context.Context, *PushLogRequest) (*PushLogReply, error)
+	// GetRecords from a peer.
+	GetRecords(context.Context, *GetRecordsRequest) (*GetRecordsReply, error)
+	// GetRecordsByCID from a peer.
+	GetRecordsByCID(context.Context, *GetRecordsByCIDRequest) (*GetRecordsByCIDReply, error)
+	// PushRecord to a peer.
+	PushRecord(context.Context, *PushRecordRequest) (*PushRecordReply, error)
+	// ExchangeEdges with a peer.
+	ExchangeEdges(context.Context, *ExchangeEdgesRequest) (*ExchangeEdgesReply, error)
+	// GetRecentRecords multicast over a thread's topic from a peer.
+	GetRecentRecords(context.Context, *GetRecentRecordsRequest) (*GetRecentRecordsReply, error)
+	// GetLogDigests from a peer.
+	GetLogDigests(context.Context, *GetLogDigestsRequest) (*GetLogDigestsReply, error)
+	// PushAcks of records to a log author.
+	PushAcks(context.Context, *PushAcksRequest) (*PushAcksReply, error)
+	// Handshake exchanges protocol versions and capabilities with a peer.
+	Handshake(context.Context, *HandshakeRequest) (*HandshakeReply, error)
+	// QueryRecords matching a selector from a peer.
+	QueryRecords(context.Context, *QueryRecordsRequest) (*QueryRecordsReply, error)
+}
+
+// UnimplementedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (*UnimplementedServiceServer) GetLogs(ctx context.Context, req *GetLogsRequest) (*GetLogsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
+func (*UnimplementedServiceServer) PushLog(ctx context.Context, req *PushLogRequest) (*PushLogReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushLog not implemented")
+}
+func (*UnimplementedServiceServer) GetRecords(ctx context.Context, req *GetRecordsRequest) (*GetRecordsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecords not implemented")
+}
+func (*UnimplementedServiceServer) GetRecordsByCID(ctx context.Context, req *GetRecordsByCIDRequest) (*GetRecordsByCIDReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecordsByCID not implemented")
+}
+func (*UnimplementedServiceServer) PushRecord(ctx context.Context, req *PushRecordRequest) (*PushRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushRecord not implemented")
+}
+func (*UnimplementedServiceServer) ExchangeEdges(ctx context.Context, req *ExchangeEdgesRequest) (*ExchangeEdgesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeEdges not implemented")
+}
+func (*UnimplementedServiceServer) GetRecentRecords(ctx context.Context, req *GetRecentRecordsRequest) (*GetRecentRecordsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentRecords not implemented")
+}
+func (*UnimplementedServiceServer) GetLogDigests(ctx context.Context, req *GetLogDigestsRequest) (*GetLogDigestsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogDigests not implemented")
+}
+func (*UnimplementedServiceServer) PushAcks(ctx context.Context, req *PushAcksRequest) (*PushAcksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushAcks not implemented")
+}
+func (*UnimplementedServiceServer) Handshake(ctx context.Context, req *HandshakeRequest) (*HandshakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handshake not implemented")
+}
+func (*UnimplementedServiceServer) QueryRecords(ctx context.Context, req *QueryRecordsRequest) (*QueryRecordsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryRecords not implemented")
+}
+
+func RegisterServiceServer(s *grpc.Server, srv ServiceServer) {
+	s.RegisterService(&_Service_serviceDesc, srv)
+}
+
+func _Service_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.EventNode)
-	if l > 0 {
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).GetLogs(ctx, in)
 	}
-	l = len(m.HeaderNode)
-	if l > 0 {
-		n += 1 + l + sovNet(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/GetLogs",
 	}
-	l = len(m.BodyNode)
-	if l > 0 {
-		n += 1 + l + sovNet(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetLogs(ctx, req.(*GetLogsRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetLogsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_PushLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).PushLog(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/PushLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PushLog(ctx, req.(*PushLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetLogsRequest_Body) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_GetRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.ThreadID != nil {
-		l = m.ThreadID.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).GetRecords(ctx, in)
 	}
-	if m.ServiceKey != nil {
-		l = m.ServiceKey.Size()
-		n += 1 + l + sovNet(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/GetRecords",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetRecords(ctx, req.(*GetRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetLogsReply) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_GetRecordsByCID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordsByCIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Logs) > 0 {
-		for _, e := range m.Logs {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetRecordsByCID(ctx, in)
 	}
-	return n
-}
-
-func (m *PushLogRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/GetRecordsByCID",
 	}
-	var l int
-	_ = l
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovNet(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetRecordsByCID(ctx, req.(*GetRecordsByCIDRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PushLogRequest_Body) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_PushRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.ThreadID != nil {
-		l = m.ThreadID.Size()
-		n += 1 + l + sovNet(uint64(l))
-	}
-	if m.ServiceKey != nil {
-		l = m.ServiceKey.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).PushRecord(ctx, in)
 	}
-	if m.ReadKey != nil {
-		l = m.ReadKey.Size()
-		n += 1 + l + sovNet(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/PushRecord",
 	}
-	if m.Log != nil {
-		l = m.Log.Size()
-		n += 1 + l + sovNet(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PushRecord(ctx, req.(*PushRecordRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PushLogReply) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_ExchangeEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeEdgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
-}
-
-func (m *GetRecordsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if interceptor == nil {
+		return srv.(ServiceServer).ExchangeEdges(ctx, in)
 	}
-	var l int
-	_ = l
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovNet(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/ExchangeEdges",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ExchangeEdges(ctx, req.(*ExchangeEdgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRecordsRequest_Body) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_GetRecentRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.ThreadID != nil {
-		l = m.ThreadID.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).GetRecentRecords(ctx, in)
 	}
-	if m.ServiceKey != nil {
-		l = m.ServiceKey.Size()
-		n += 1 + l + sovNet(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/GetRecentRecords",
 	}
-	if len(m.Logs) > 0 {
-		for _, e := range m.Logs {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetRecentRecords(ctx, req.(*GetRecentRecordsRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRecordsRequest_Body_LogEntry) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.LogID != nil {
-		l = m.LogID.Size()
-		n += 1 + l + sovNet(uint64(l))
-	}
-	if m.Offset != nil {
-		l = m.Offset.Size()
-		n += 1 + l + sovNet(uint64(l))
-	}
-	if m.Limit != 0 {
-		n += 1 + sovNet(uint64(m.Limit))
+func _Service_GetLogDigests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogDigestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Counter != 0 {
-		n += 1 + sovNet(uint64(m.Counter))
+	if interceptor == nil {
+		return srv.(ServiceServer).GetLogDigests(ctx, in)
 	}
-	return n
-}
-
-func (m *GetRecordsReply) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/GetLogDigests",
 	}
-	var l int
-	_ = l
-	if len(m.Logs) > 0 {
-		for _, e := range m.Logs {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetLogDigests(ctx, req.(*GetLogDigestsRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRecordsReply_LogEntry) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_PushAcks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushAcksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.LogID != nil {
-		l = m.LogID.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).PushAcks(ctx, in)
 	}
-	if len(m.Records) > 0 {
-		for _, e := range m.Records {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/PushAcks",
 	}
-	if m.Log != nil {
-		l = m.Log.Size()
-		n += 1 + l + sovNet(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).PushAcks(ctx, req.(*PushAcksRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PushRecordRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_Handshake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandshakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).Handshake(ctx, in)
 	}
-	if m.Counter != 0 {
-		n += 1 + sovNet(uint64(m.Counter))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/Handshake",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Handshake(ctx, req.(*HandshakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PushRecordRequest_Body) Size() (n int) {
-	if m == nil {
-		return 0
+func _Service_QueryRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.ThreadID != nil {
-		l = m.ThreadID.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if interceptor == nil {
+		return srv.(ServiceServer).QueryRecords(ctx, in)
 	}
-	if m.LogID != nil {
-		l = m.LogID.Size()
-		n += 1 + l + sovNet(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/net.pb.Service/QueryRecords",
 	}
-	if m.Record != nil {
-		l = m.Record.Size()
-		n += 1 + l + sovNet(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).QueryRecords(ctx, req.(*QueryRecordsRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *PushRecordReply) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
+var _Service_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "net.pb.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogs",
+			Handler:    _Service_GetLogs_Handler,
+		},
+		{
+			MethodName: "PushLog",
+			Handler:    _Service_PushLog_Handler,
+		},
+		{
+			MethodName: "GetRecords",
+			Handler:    _Service_GetRecords_Handler,
+		},
+		{
+			MethodName: "GetRecordsByCID",
+			Handler:    _Service_GetRecordsByCID_Handler,
+		},
+		{
+			MethodName: "PushRecord",
+			Handler:    _Service_PushRecord_Handler,
+		},
+		{
+			MethodName: "ExchangeEdges",
+			Handler:    _Service_ExchangeEdges_Handler,
+		},
+		{
+			MethodName: "GetRecentRecords",
+			Handler:    _Service_GetRecentRecords_Handler,
+		},
+		{
+			MethodName: "GetLogDigests",
+			Handler:    _Service_GetLogDigests_Handler,
+		},
+		{
+			MethodName: "PushAcks",
+			Handler:    _Service_PushAcks_Handler,
+		},
+		{
+			MethodName: "Handshake",
+			Handler:    _Service_Handshake_Handler,
+		},
+		{
+			MethodName: "QueryRecords",
+			Handler:    _Service_QueryRecords_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "net.proto",
 }
 
-func (m *ExchangeEdgesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovNet(uint64(l))
+func (m *Log) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ExchangeEdgesRequest_Body) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Threads) > 0 {
-		for _, e := range m.Threads {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
-		}
-	}
-	return n
+func (m *Log) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Log) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Head != nil {
+		{
+			size := m.Head.Size()
+			i -= size
+			if _, err := m.Head.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Addrs[iNdEx].Size()
+				i -= size
+				if _, err := m.Addrs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.PubKey != nil {
+		{
+			size := m.PubKey.Size()
+			i -= size
+			if _, err := m.PubKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != nil {
+		{
+			size := m.ID.Size()
+			i -= size
+			if _, err := m.ID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Log_Record) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Log_Record) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Log_Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ThreadID != nil {
-		l = m.ThreadID.Size()
-		n += 1 + l + sovNet(uint64(l))
+	if len(m.BodyNode) > 0 {
+		i -= len(m.BodyNode)
+		copy(dAtA[i:], m.BodyNode)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.BodyNode)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.AddressEdge != 0 {
-		n += 1 + sovNet(uint64(m.AddressEdge))
+	if len(m.HeaderNode) > 0 {
+		i -= len(m.HeaderNode)
+		copy(dAtA[i:], m.HeaderNode)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.HeaderNode)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.HeadsEdge != 0 {
-		n += 1 + sovNet(uint64(m.HeadsEdge))
+	if len(m.EventNode) > 0 {
+		i -= len(m.EventNode)
+		copy(dAtA[i:], m.EventNode)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.EventNode)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.RecordNode) > 0 {
+		i -= len(m.RecordNode)
+		copy(dAtA[i:], m.RecordNode)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.RecordNode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ExchangeEdgesReply) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetLogsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogsRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Edges) > 0 {
-		for _, e := range m.Edges {
-			l = e.Size()
-			n += 1 + l + sovNet(uint64(l))
+	if m.Freeze != nil {
+		{
+			size, err := m.Freeze.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Follows) > 0 {
+		for iNdEx := len(m.Follows) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Follows[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogsRequest_Body_LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogsRequest_Body_LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsRequest_Body_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AddressEdge != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.AddressEdge))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Freeze != nil {
+		{
+			size, err := m.Freeze.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Follows) > 0 {
+		for iNdEx := len(m.Follows) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Follows[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushLogRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushLogRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushLogRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushLogRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushLogRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Addrs != nil {
+		{
+			size, err := m.Addrs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Log != nil {
+		{
+			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ReadKey != nil {
+		{
+			size := m.ReadKey.Size()
+			i -= size
+			if _, err := m.ReadKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushLogReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushLogReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushLogReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HeadersOnly {
+		i--
+		if m.HeadersOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsRequest_Body_LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsRequest_Body_LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsRequest_Body_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Anchor != nil {
+		{
+			size := m.Anchor.Size()
+			i -= size
+			if _, err := m.Anchor.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Direction != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Direction))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Limit != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Offset != nil {
+		{
+			size := m.Offset.Size()
+			i -= size
+			if _, err := m.Offset.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsReply_LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsReply_LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsReply_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Base != nil {
+		{
+			size := m.Base.Size()
+			i -= size
+			if _, err := m.Base.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Log != nil {
+		{
+			size, err := m.Log.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsByCIDRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsByCIDRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsByCIDRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsByCIDRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsByCIDRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsByCIDRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsByCIDRequest_Body_LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsByCIDRequest_Body_LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsByCIDRequest_Body_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RecordIDs) > 0 {
+		for iNdEx := len(m.RecordIDs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.RecordIDs[iNdEx].Size()
+				i -= size
+				if _, err := m.RecordIDs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsByCIDReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsByCIDReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsByCIDReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordsByCIDReply_LogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordsByCIDReply_LogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordsByCIDReply_LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Proofs) > 0 {
+		for iNdEx := len(m.Proofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Proofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushRecordRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushRecordRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushRecordRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushRecordReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushRecordReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushRecordReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ExchangeEdgesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExchangeEdgesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExchangeEdgesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExchangeEdgesRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExchangeEdgesRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExchangeEdgesRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GossipPeers != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.GossipPeers))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxRecordSize != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.MaxRecordSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Threads) > 0 {
+		for iNdEx := len(m.Threads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Threads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HeadsEdge != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.HeadsEdge))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AddressEdge != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.AddressEdge))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExchangeEdgesReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExchangeEdgesReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExchangeEdgesReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxRecordSize != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.MaxRecordSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Edges) > 0 {
+		for iNdEx := len(m.Edges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Edges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExchangeEdgesReply_ThreadEdges) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExchangeEdgesReply_ThreadEdges) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExchangeEdgesReply_ThreadEdges) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		i -= len(m.Peers)
+		copy(dAtA[i:], m.Peers)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Peers)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Heads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Logs) > 0 {
+		i -= len(m.Logs)
+		copy(dAtA[i:], m.Logs)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Logs)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.HeadsEdge != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.HeadsEdge))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.AddressEdge != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.AddressEdge))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Exists {
+		i--
+		if m.Exists {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecentRecordsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecentRecordsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecentRecordsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecentRecordsRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecentRecordsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecentRecordsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxRecordSize != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.MaxRecordSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecentRecordsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecentRecordsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecentRecordsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Presence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Presence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Presence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IdentitySig) > 0 {
+		i -= len(m.IdentitySig)
+		copy(dAtA[i:], m.IdentitySig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.IdentitySig)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Sig) > 0 {
+		i -= len(m.Sig)
+		copy(dAtA[i:], m.Sig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Sig)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Presence_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Presence_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Presence_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Status != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PeerID != nil {
+		{
+			size := m.PeerID.Size()
+			i -= size
+			if _, err := m.PeerID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogDigestsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogDigestsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogDigestsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogDigestsRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogDigestsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogDigestsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ranges) > 0 {
+		for iNdEx := len(m.Ranges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ranges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Length != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Length))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogDigestsRequest_Range) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogDigestsRequest_Range) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogDigestsRequest_Range) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Level != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Level))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLogDigestsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLogDigestsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLogDigestsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Digests) > 0 {
+		for iNdEx := len(m.Digests) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Digests[iNdEx])
+			copy(dAtA[i:], m.Digests[iNdEx])
+			i = encodeVarintNet(dAtA, i, uint64(len(m.Digests[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Follow) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Follow) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Follow) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sig) > 0 {
+		i -= len(m.Sig)
+		copy(dAtA[i:], m.Sig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Sig)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Follow_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Follow_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Follow_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Active {
+		i--
+		if m.Active {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Addrs[iNdEx].Size()
+				i -= size
+				if _, err := m.Addrs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.PeerID != nil {
+		{
+			size := m.PeerID.Size()
+			i -= size
+			if _, err := m.PeerID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Freeze) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Freeze) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Freeze) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sig) > 0 {
+		i -= len(m.Sig)
+		copy(dAtA[i:], m.Sig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Sig)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Freeze_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Freeze_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Freeze_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Heads[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Frozen {
+		i--
+		if m.Frozen {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.PeerID != nil {
+		{
+			size := m.PeerID.Size()
+			i -= size
+			if _, err := m.PeerID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Freeze_Head) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Freeze_Head) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Freeze_Head) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogAddrs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogAddrs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogAddrs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sig) > 0 {
+		i -= len(m.Sig)
+		copy(dAtA[i:], m.Sig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Sig)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogAddrs_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogAddrs_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogAddrs_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Addrs[iNdEx].Size()
+				i -= size
+				if _, err := m.Addrs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TopicMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TopicMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Proof) > 0 {
+		i -= len(m.Proof)
+		copy(dAtA[i:], m.Proof)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Proof)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordAck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordAck) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordAck) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sig) > 0 {
+		i -= len(m.Sig)
+		copy(dAtA[i:], m.Sig)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Sig)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordAck_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordAck_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordAck_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Seen != nil {
+		{
+			size, err := m.Seen.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Delivered != nil {
+		{
+			size, err := m.Delivered.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.PeerID != nil {
+		{
+			size := m.PeerID.Size()
+			i -= size
+			if _, err := m.PeerID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordAck_Range) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordAck_Range) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordAck_Range) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Head != nil {
+		{
+			size := m.Head.Size()
+			i -= size
+			if _, err := m.Head.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushAcksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushAcksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushAcksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushAcksRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushAcksRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushAcksRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Acks) > 0 {
+		for iNdEx := len(m.Acks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Acks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PushAcksReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PushAcksReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PushAcksReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *HandshakeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HandshakeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HandshakeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HandshakeRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HandshakeRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HandshakeRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintNet(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HandshakeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HandshakeReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HandshakeReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintNet(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GossipPeers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GossipPeers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GossipPeers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GossipPeers_Peer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GossipPeers_Peer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GossipPeers_Peer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Addrs[iNdEx].Size()
+				i -= size
+				if _, err := m.Addrs[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.PeerID != nil {
+		{
+			size := m.PeerID.Size()
+			i -= size
+			if _, err := m.PeerID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRecordsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRecordsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRecordsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRecordsRequest_Body) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRecordsRequest_Body) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRecordsRequest_Body) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Selector != nil {
+		{
+			size, err := m.Selector.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ServiceKey != nil {
+		{
+			size := m.ServiceKey.Size()
+			i -= size
+			if _, err := m.ServiceKey.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ThreadID != nil {
+		{
+			size := m.ThreadID.Size()
+			i -= size
+			if _, err := m.ThreadID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRecordsRequest_Selector) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRecordsRequest_Selector) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRecordsRequest_Selector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintNet(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Target != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Target))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRecordsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRecordsReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRecordsReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matches) > 0 {
+		for iNdEx := len(m.Matches) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matches[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNet(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRecordsReply_Match) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRecordsReply_Match) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRecordsReply_Match) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.LogID != nil {
+		{
+			size := m.LogID.Size()
+			i -= size
+			if _, err := m.LogID.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRecordsReply_Proof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRecordsReply_Proof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRecordsReply_Proof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Records[iNdEx])
+			copy(dAtA[i:], m.Records[iNdEx])
+			i = encodeVarintNet(dAtA, i, uint64(len(m.Records[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Position != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Position))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Counter != 0 {
+		i = encodeVarintNet(dAtA, i, uint64(m.Counter))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Head != nil {
+		{
+			size := m.Head.Size()
+			i -= size
+			if _, err := m.Head.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+			i = encodeVarintNet(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintNet(dAtA []byte, offset int, v uint64) int {
+	offset -= sovNet(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedLog(r randyNet, easy bool) *Log {
+	this := &Log{}
+	this.ID = NewPopulatedProtoPeerID(r)
+	this.PubKey = NewPopulatedProtoPubKey(r)
+	v1 := r.Intn(10)
+	this.Addrs = make([]ProtoAddr, v1)
+	for i := 0; i < v1; i++ {
+		v2 := NewPopulatedProtoAddr(r)
+		this.Addrs[i] = *v2
+	}
+	this.Head = NewPopulatedProtoCid(r)
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedLog_Record(r randyNet, easy bool) *Log_Record {
+	this := &Log_Record{}
+	v3 := r.Intn(100)
+	this.RecordNode = make([]byte, v3)
+	for i := 0; i < v3; i++ {
+		this.RecordNode[i] = byte(r.Intn(256))
+	}
+	v4 := r.Intn(100)
+	this.EventNode = make([]byte, v4)
+	for i := 0; i < v4; i++ {
+		this.EventNode[i] = byte(r.Intn(256))
+	}
+	v5 := r.Intn(100)
+	this.HeaderNode = make([]byte, v5)
+	for i := 0; i < v5; i++ {
+		this.HeaderNode[i] = byte(r.Intn(256))
+	}
+	v6 := r.Intn(100)
+	this.BodyNode = make([]byte, v6)
+	for i := 0; i < v6; i++ {
+		this.BodyNode[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsRequest(r randyNet, easy bool) *GetLogsRequest {
+	this := &GetLogsRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedGetLogsRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsRequest_Body(r randyNet, easy bool) *GetLogsRequest_Body {
+	this := &GetLogsRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		v7 := r.Intn(5)
+		this.Logs = make([]*GetLogsRequest_Body_LogEntry, v7)
+		for i := 0; i < v7; i++ {
+			this.Logs[i] = NewPopulatedGetLogsRequest_Body_LogEntry(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		v8 := r.Intn(5)
+		this.Follows = make([]*Follow, v8)
+		for i := 0; i < v8; i++ {
+			this.Follows[i] = NewPopulatedFollow(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.Freeze = NewPopulatedFreeze(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsRequest_Body_LogEntry(r randyNet, easy bool) *GetLogsRequest_Body_LogEntry {
+	this := &GetLogsRequest_Body_LogEntry{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	this.AddressEdge = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogsReply(r randyNet, easy bool) *GetLogsReply {
+	this := &GetLogsReply{}
+	if r.Intn(5) != 0 {
+		v9 := r.Intn(5)
+		this.Logs = make([]*Log, v9)
+		for i := 0; i < v9; i++ {
+			this.Logs[i] = NewPopulatedLog(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		v10 := r.Intn(5)
+		this.Follows = make([]*Follow, v10)
+		for i := 0; i < v10; i++ {
+			this.Follows[i] = NewPopulatedFollow(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.Freeze = NewPopulatedFreeze(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushLogRequest(r randyNet, easy bool) *PushLogRequest {
+	this := &PushLogRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedPushLogRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushLogRequest_Body(r randyNet, easy bool) *PushLogRequest_Body {
+	this := &PushLogRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	this.ReadKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		this.Log = NewPopulatedLog(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Addrs = NewPopulatedLogAddrs(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushLogReply(r randyNet, easy bool) *PushLogReply {
+	this := &PushLogReply{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsRequest(r randyNet, easy bool) *GetRecordsRequest {
+	this := &GetRecordsRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedGetRecordsRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsRequest_Body(r randyNet, easy bool) *GetRecordsRequest_Body {
+	this := &GetRecordsRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		v11 := r.Intn(5)
+		this.Logs = make([]*GetRecordsRequest_Body_LogEntry, v11)
+		for i := 0; i < v11; i++ {
+			this.Logs[i] = NewPopulatedGetRecordsRequest_Body_LogEntry(r, easy)
+		}
+	}
+	this.HeadersOnly = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsRequest_Body_LogEntry(r randyNet, easy bool) *GetRecordsRequest_Body_LogEntry {
+	this := &GetRecordsRequest_Body_LogEntry{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	this.Offset = NewPopulatedProtoCid(r)
+	this.Limit = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Limit *= -1
+	}
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	this.Direction = GetRecordsRequest_Direction([]int32{0, 1}[r.Intn(2)])
+	this.Anchor = NewPopulatedProtoCid(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsReply(r randyNet, easy bool) *GetRecordsReply {
+	this := &GetRecordsReply{}
+	if r.Intn(5) != 0 {
+		v12 := r.Intn(5)
+		this.Logs = make([]*GetRecordsReply_LogEntry, v12)
+		for i := 0; i < v12; i++ {
+			this.Logs[i] = NewPopulatedGetRecordsReply_LogEntry(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsReply_LogEntry(r randyNet, easy bool) *GetRecordsReply_LogEntry {
+	this := &GetRecordsReply_LogEntry{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	if r.Intn(5) != 0 {
+		v13 := r.Intn(5)
+		this.Records = make([]*Log_Record, v13)
+		for i := 0; i < v13; i++ {
+			this.Records[i] = NewPopulatedLog_Record(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.Log = NewPopulatedLog(r, easy)
+	}
+	this.Base = NewPopulatedProtoCid(r)
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsByCIDRequest(r randyNet, easy bool) *GetRecordsByCIDRequest {
+	this := &GetRecordsByCIDRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedGetRecordsByCIDRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsByCIDRequest_Body(r randyNet, easy bool) *GetRecordsByCIDRequest_Body {
+	this := &GetRecordsByCIDRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		v14 := r.Intn(5)
+		this.Logs = make([]*GetRecordsByCIDRequest_Body_LogEntry, v14)
+		for i := 0; i < v14; i++ {
+			this.Logs[i] = NewPopulatedGetRecordsByCIDRequest_Body_LogEntry(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsByCIDRequest_Body_LogEntry(r randyNet, easy bool) *GetRecordsByCIDRequest_Body_LogEntry {
+	this := &GetRecordsByCIDRequest_Body_LogEntry{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	v15 := r.Intn(10)
+	this.RecordIDs = make([]ProtoCid, v15)
+	for i := 0; i < v15; i++ {
+		v16 := NewPopulatedProtoCid(r)
+		this.RecordIDs[i] = *v16
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsByCIDReply(r randyNet, easy bool) *GetRecordsByCIDReply {
+	this := &GetRecordsByCIDReply{}
+	if r.Intn(5) != 0 {
+		v17 := r.Intn(5)
+		this.Logs = make([]*GetRecordsByCIDReply_LogEntry, v17)
+		for i := 0; i < v17; i++ {
+			this.Logs[i] = NewPopulatedGetRecordsByCIDReply_LogEntry(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecordsByCIDReply_LogEntry(r randyNet, easy bool) *GetRecordsByCIDReply_LogEntry {
+	this := &GetRecordsByCIDReply_LogEntry{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	if r.Intn(5) != 0 {
+		v18 := r.Intn(5)
+		this.Records = make([]*Log_Record, v18)
+		for i := 0; i < v18; i++ {
+			this.Records[i] = NewPopulatedLog_Record(r, easy)
+		}
+	}
+	if r.Intn(5) != 0 {
+		v54 := r.Intn(5)
+		this.Proofs = make([]*QueryRecordsReply_Proof, v54)
+		for i := 0; i < v54; i++ {
+			this.Proofs[i] = NewPopulatedQueryRecordsReply_Proof(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushRecordRequest(r randyNet, easy bool) *PushRecordRequest {
+	this := &PushRecordRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedPushRecordRequest_Body(r, easy)
+	}
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushRecordRequest_Body(r randyNet, easy bool) *PushRecordRequest_Body {
+	this := &PushRecordRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.LogID = NewPopulatedProtoPeerID(r)
+	if r.Intn(5) != 0 {
+		this.Record = NewPopulatedLog_Record(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushRecordReply(r randyNet, easy bool) *PushRecordReply {
+	this := &PushRecordReply{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedExchangeEdgesRequest(r randyNet, easy bool) *ExchangeEdgesRequest {
+	this := &ExchangeEdgesRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedExchangeEdgesRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedExchangeEdgesRequest_Body(r randyNet, easy bool) *ExchangeEdgesRequest_Body {
+	this := &ExchangeEdgesRequest_Body{}
+	if r.Intn(5) != 0 {
+		v19 := r.Intn(5)
+		this.Threads = make([]*ExchangeEdgesRequest_Body_ThreadEntry, v19)
+		for i := 0; i < v19; i++ {
+			this.Threads[i] = NewPopulatedExchangeEdgesRequest_Body_ThreadEntry(r, easy)
+		}
+	}
+	this.MaxRecordSize = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxRecordSize *= -1
+	}
+	this.GossipPeers = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.GossipPeers *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedExchangeEdgesRequest_Body_ThreadEntry(r randyNet, easy bool) *ExchangeEdgesRequest_Body_ThreadEntry {
+	this := &ExchangeEdgesRequest_Body_ThreadEntry{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.AddressEdge = uint64(uint64(r.Uint32()))
+	this.HeadsEdge = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedExchangeEdgesReply(r randyNet, easy bool) *ExchangeEdgesReply {
+	this := &ExchangeEdgesReply{}
+	if r.Intn(5) != 0 {
+		v20 := r.Intn(5)
+		this.Edges = make([]*ExchangeEdgesReply_ThreadEdges, v20)
+		for i := 0; i < v20; i++ {
+			this.Edges[i] = NewPopulatedExchangeEdgesReply_ThreadEdges(r, easy)
+		}
+	}
+	this.MaxRecordSize = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxRecordSize *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedExchangeEdgesReply_ThreadEdges(r randyNet, easy bool) *ExchangeEdgesReply_ThreadEdges {
+	this := &ExchangeEdgesReply_ThreadEdges{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.Exists = bool(bool(r.Intn(2) == 0))
+	this.AddressEdge = uint64(uint64(r.Uint32()))
+	this.HeadsEdge = uint64(uint64(r.Uint32()))
+	v21 := r.Intn(100)
+	this.Logs = make([]byte, v21)
+	for i := 0; i < v21; i++ {
+		this.Logs[i] = byte(r.Intn(256))
+	}
+	if r.Intn(5) != 0 {
+		v22 := r.Intn(5)
+		this.Heads = make([]*GetRecordsByCIDReply_LogEntry, v22)
+		for i := 0; i < v22; i++ {
+			this.Heads[i] = NewPopulatedGetRecordsByCIDReply_LogEntry(r, easy)
+		}
+	}
+	v23 := r.Intn(100)
+	this.Peers = make([]byte, v23)
+	for i := 0; i < v23; i++ {
+		this.Peers[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecentRecordsRequest(r randyNet, easy bool) *GetRecentRecordsRequest {
+	this := &GetRecentRecordsRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedGetRecentRecordsRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecentRecordsRequest_Body(r randyNet, easy bool) *GetRecentRecordsRequest_Body {
+	this := &GetRecentRecordsRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	this.MaxRecordSize = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxRecordSize *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetRecentRecordsReply(r randyNet, easy bool) *GetRecentRecordsReply {
+	this := &GetRecentRecordsReply{}
+	if r.Intn(5) != 0 {
+		v24 := r.Intn(5)
+		this.Records = make([]*PushRecordRequest, v24)
+		for i := 0; i < v24; i++ {
+			this.Records[i] = NewPopulatedPushRecordRequest(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPresence(r randyNet, easy bool) *Presence {
+	this := &Presence{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedPresence_Body(r, easy)
+	}
+	v25 := r.Intn(100)
+	this.Sig = make([]byte, v25)
+	for i := 0; i < v25; i++ {
+		this.Sig[i] = byte(r.Intn(256))
+	}
+	v26 := r.Intn(100)
+	this.IdentitySig = make([]byte, v26)
+	for i := 0; i < v26; i++ {
+		this.IdentitySig[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPresence_Body(r randyNet, easy bool) *Presence_Body {
+	this := &Presence_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.PeerID = NewPopulatedProtoPeerID(r)
+	v27 := r.Intn(100)
+	this.Identity = make([]byte, v27)
+	for i := 0; i < v27; i++ {
+		this.Identity[i] = byte(r.Intn(256))
+	}
+	this.Status = Presence_Status([]int32{0, 1, 2}[r.Intn(3)])
+	v28 := r.Intn(100)
+	this.Payload = make([]byte, v28)
+	for i := 0; i < v28; i++ {
+		this.Payload[i] = byte(r.Intn(256))
+	}
+	this.Timestamp = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Timestamp *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogDigestsRequest(r randyNet, easy bool) *GetLogDigestsRequest {
+	this := &GetLogDigestsRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedGetLogDigestsRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogDigestsRequest_Body(r randyNet, easy bool) *GetLogDigestsRequest_Body {
+	this := &GetLogDigestsRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	this.LogID = NewPopulatedProtoPeerID(r)
+	this.Length = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Length *= -1
+	}
+	if r.Intn(5) != 0 {
+		v29 := r.Intn(5)
+		this.Ranges = make([]*GetLogDigestsRequest_Range, v29)
+		for i := 0; i < v29; i++ {
+			this.Ranges[i] = NewPopulatedGetLogDigestsRequest_Range(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogDigestsRequest_Range(r randyNet, easy bool) *GetLogDigestsRequest_Range {
+	this := &GetLogDigestsRequest_Range{}
+	this.Level = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Level *= -1
+	}
+	this.Index = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Index *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGetLogDigestsReply(r randyNet, easy bool) *GetLogDigestsReply {
+	this := &GetLogDigestsReply{}
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	v30 := r.Intn(10)
+	this.Digests = make([][]byte, v30)
+	for i := 0; i < v30; i++ {
+		v31 := r.Intn(100)
+		this.Digests[i] = make([]byte, v31)
+		for j := 0; j < v31; j++ {
+			this.Digests[i][j] = byte(r.Intn(256))
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFollow(r randyNet, easy bool) *Follow {
+	this := &Follow{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedFollow_Body(r, easy)
+	}
+	v32 := r.Intn(100)
+	this.Sig = make([]byte, v32)
+	for i := 0; i < v32; i++ {
+		this.Sig[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFollow_Body(r randyNet, easy bool) *Follow_Body {
+	this := &Follow_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.PeerID = NewPopulatedProtoPeerID(r)
+	v33 := r.Intn(10)
+	this.Addrs = make([]ProtoAddr, v33)
+	for i := 0; i < v33; i++ {
+		v34 := NewPopulatedProtoAddr(r)
+		this.Addrs[i] = *v34
+	}
+	this.Active = bool(bool(r.Intn(2) == 0))
+	this.Timestamp = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Timestamp *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFreeze(r randyNet, easy bool) *Freeze {
+	this := &Freeze{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedFreeze_Body(r, easy)
+	}
+	v35 := r.Intn(100)
+	this.Sig = make([]byte, v35)
+	for i := 0; i < v35; i++ {
+		this.Sig[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFreeze_Body(r randyNet, easy bool) *Freeze_Body {
+	this := &Freeze_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.PeerID = NewPopulatedProtoPeerID(r)
+	this.Frozen = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		v36 := r.Intn(5)
+		this.Heads = make([]*Freeze_Head, v36)
+		for i := 0; i < v36; i++ {
+			this.Heads[i] = NewPopulatedFreeze_Head(r, easy)
+		}
+	}
+	this.Timestamp = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Timestamp *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFreeze_Head(r randyNet, easy bool) *Freeze_Head {
+	this := &Freeze_Head{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedLogAddrs(r randyNet, easy bool) *LogAddrs {
+	this := &LogAddrs{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedLogAddrs_Body(r, easy)
+	}
+	v37 := r.Intn(100)
+	this.Sig = make([]byte, v37)
+	for i := 0; i < v37; i++ {
+		this.Sig[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedLogAddrs_Body(r randyNet, easy bool) *LogAddrs_Body {
+	this := &LogAddrs_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.LogID = NewPopulatedProtoPeerID(r)
+	v38 := r.Intn(10)
+	this.Addrs = make([]ProtoAddr, v38)
+	for i := 0; i < v38; i++ {
+		v39 := NewPopulatedProtoAddr(r)
+		this.Addrs[i] = *v39
+	}
+	this.Timestamp = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Timestamp *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedTopicMessage(r randyNet, easy bool) *TopicMessage {
+	this := &TopicMessage{}
+	v40 := r.Intn(100)
+	this.Data = make([]byte, v40)
+	for i := 0; i < v40; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	v41 := r.Intn(100)
+	this.Proof = make([]byte, v41)
+	for i := 0; i < v41; i++ {
+		this.Proof[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRecordAck(r randyNet, easy bool) *RecordAck {
+	this := &RecordAck{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedRecordAck_Body(r, easy)
+	}
+	v42 := r.Intn(100)
+	this.Sig = make([]byte, v42)
+	for i := 0; i < v42; i++ {
+		this.Sig[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRecordAck_Body(r randyNet, easy bool) *RecordAck_Body {
+	this := &RecordAck_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.LogID = NewPopulatedProtoPeerID(r)
+	this.PeerID = NewPopulatedProtoPeerID(r)
+	if r.Intn(5) != 0 {
+		this.Delivered = NewPopulatedRecordAck_Range(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Seen = NewPopulatedRecordAck_Range(r, easy)
+	}
+	this.Timestamp = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Timestamp *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRecordAck_Range(r randyNet, easy bool) *RecordAck_Range {
+	this := &RecordAck_Range{}
+	this.Head = NewPopulatedProtoCid(r)
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushAcksRequest(r randyNet, easy bool) *PushAcksRequest {
+	this := &PushAcksRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedPushAcksRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushAcksRequest_Body(r randyNet, easy bool) *PushAcksRequest_Body {
+	this := &PushAcksRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		v43 := r.Intn(5)
+		this.Acks = make([]*RecordAck, v43)
+		for i := 0; i < v43; i++ {
+			this.Acks[i] = NewPopulatedRecordAck(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPushAcksReply(r randyNet, easy bool) *PushAcksReply {
+	this := &PushAcksReply{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHandshakeRequest(r randyNet, easy bool) *HandshakeRequest {
+	this := &HandshakeRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedHandshakeRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHandshakeRequest_Body(r randyNet, easy bool) *HandshakeRequest_Body {
+	this := &HandshakeRequest_Body{}
+	this.Version = string(randStringNet(r))
+	v44 := r.Intn(10)
+	this.Capabilities = make([]string, v44)
+	for i := 0; i < v44; i++ {
+		this.Capabilities[i] = string(randStringNet(r))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHandshakeReply(r randyNet, easy bool) *HandshakeReply {
+	this := &HandshakeReply{}
+	this.Version = string(randStringNet(r))
+	v45 := r.Intn(10)
+	this.Capabilities = make([]string, v45)
+	for i := 0; i < v45; i++ {
+		this.Capabilities[i] = string(randStringNet(r))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGossipPeers(r randyNet, easy bool) *GossipPeers {
+	this := &GossipPeers{}
+	if r.Intn(5) != 0 {
+		v46 := r.Intn(5)
+		this.Peers = make([]*GossipPeers_Peer, v46)
+		for i := 0; i < v46; i++ {
+			this.Peers[i] = NewPopulatedGossipPeers_Peer(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedGossipPeers_Peer(r randyNet, easy bool) *GossipPeers_Peer {
+	this := &GossipPeers_Peer{}
+	this.PeerID = NewPopulatedProtoPeerID(r)
+	v47 := r.Intn(10)
+	this.Addrs = make([]ProtoAddr, v47)
+	for i := 0; i < v47; i++ {
+		v48 := NewPopulatedProtoAddr(r)
+		this.Addrs[i] = *v48
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRecordsRequest(r randyNet, easy bool) *QueryRecordsRequest {
+	this := &QueryRecordsRequest{}
+	if r.Intn(5) != 0 {
+		this.Body = NewPopulatedQueryRecordsRequest_Body(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRecordsRequest_Body(r randyNet, easy bool) *QueryRecordsRequest_Body {
+	this := &QueryRecordsRequest_Body{}
+	this.ThreadID = NewPopulatedProtoThreadID(r)
+	this.ServiceKey = NewPopulatedProtoKey(r)
+	if r.Intn(5) != 0 {
+		this.Selector = NewPopulatedQueryRecordsRequest_Selector(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRecordsRequest_Selector(r randyNet, easy bool) *QueryRecordsRequest_Selector {
+	this := &QueryRecordsRequest_Selector{}
+	this.Target = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Target *= -1
+	}
+	this.Path = string(randStringNet(r))
+	v49 := r.Intn(100)
+	this.Value = make([]byte, v49)
+	for i := 0; i < v49; i++ {
+		this.Value[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRecordsReply(r randyNet, easy bool) *QueryRecordsReply {
+	this := &QueryRecordsReply{}
+	if r.Intn(5) != 0 {
+		v50 := r.Intn(5)
+		this.Matches = make([]*QueryRecordsReply_Match, v50)
+		for i := 0; i < v50; i++ {
+			this.Matches[i] = NewPopulatedQueryRecordsReply_Match(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRecordsReply_Match(r randyNet, easy bool) *QueryRecordsReply_Match {
+	this := &QueryRecordsReply_Match{}
+	this.LogID = NewPopulatedProtoPeerID(r)
+	if r.Intn(5) != 0 {
+		this.Record = NewPopulatedLog_Record(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Proof = NewPopulatedQueryRecordsReply_Proof(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRecordsReply_Proof(r randyNet, easy bool) *QueryRecordsReply_Proof {
+	this := &QueryRecordsReply_Proof{}
+	this.Head = NewPopulatedProtoCid(r)
+	this.Counter = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Counter *= -1
+	}
+	this.Position = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Position *= -1
+	}
+	v51 := r.Intn(10)
+	this.Records = make([][]byte, v51)
+	for i := 0; i < v51; i++ {
+		v52 := r.Intn(100)
+		this.Records[i] = make([]byte, v52)
+		for j := 0; j < v52; j++ {
+			this.Records[i][j] = byte(r.Intn(256))
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+type randyNet interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneNet(r randyNet) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringNet(r randyNet) string {
+	v52 := r.Intn(100)
+	tmps := make([]rune, v52)
+	for i := 0; i < v52; i++ {
+		tmps[i] = randUTF8RuneNet(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedNet(r randyNet, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldNet(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldNet(dAtA []byte, r randyNet, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
+		v53 := r.Int63()
+		if r.Intn(2) == 0 {
+			v53 *= -1
+		}
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(v53))
+	case 1:
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateNet(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *Log) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != nil {
+		l = m.ID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PubKey != nil {
+		l = m.PubKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, e := range m.Addrs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	return n
+}
+
+func (m *Log_Record) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordNode)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.EventNode)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.HeaderNode)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.BodyNode)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetLogsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetLogsRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if len(m.Follows) > 0 {
+		for _, e := range m.Follows {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Freeze != nil {
+		l = m.Freeze.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetLogsRequest_Body_LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.AddressEdge != 0 {
+		n += 1 + sovNet(uint64(m.AddressEdge))
+	}
+	return n
+}
+
+func (m *GetLogsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if len(m.Follows) > 0 {
+		for _, e := range m.Follows {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Freeze != nil {
+		l = m.Freeze.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *PushLogRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *PushLogRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ReadKey != nil {
+		l = m.ReadKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Log != nil {
+		l = m.Log.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Addrs != nil {
+		l = m.Addrs.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *PushLogReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetRecordsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordsRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.HeadersOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetRecordsRequest_Body_LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Offset != nil {
+		l = m.Offset.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovNet(uint64(m.Limit))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	if m.Direction != 0 {
+		n += 1 + sovNet(uint64(m.Direction))
+	}
+	if m.Anchor != nil {
+		l = m.Anchor.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetRecordsReply_LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Log != nil {
+		l = m.Log.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Base != nil {
+		l = m.Base.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordsByCIDRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordsByCIDRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetRecordsByCIDRequest_Body_LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.RecordIDs) > 0 {
+		for _, e := range m.RecordIDs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetRecordsByCIDReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetRecordsByCIDReply_LogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if len(m.Proofs) > 0 {
+		for _, e := range m.Proofs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PushRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	return n
+}
+
+func (m *PushRecordRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *PushRecordReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ExchangeEdgesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *ExchangeEdgesRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Threads) > 0 {
+		for _, e := range m.Threads {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.MaxRecordSize != 0 {
+		n += 1 + sovNet(uint64(m.MaxRecordSize))
+	}
+	if m.GossipPeers != 0 {
+		n += 1 + sovNet(uint64(m.GossipPeers))
+	}
+	return n
+}
+
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.AddressEdge != 0 {
+		n += 1 + sovNet(uint64(m.AddressEdge))
+	}
+	if m.HeadsEdge != 0 {
+		n += 1 + sovNet(uint64(m.HeadsEdge))
+	}
+	return n
+}
+
+func (m *ExchangeEdgesReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Edges) > 0 {
+		for _, e := range m.Edges {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.MaxRecordSize != 0 {
+		n += 1 + sovNet(uint64(m.MaxRecordSize))
+	}
+	return n
+}
+
+func (m *ExchangeEdgesReply_ThreadEdges) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Exists {
+		n += 2
+	}
+	if m.AddressEdge != 0 {
+		n += 1 + sovNet(uint64(m.AddressEdge))
+	}
+	if m.HeadsEdge != 0 {
+		n += 1 + sovNet(uint64(m.HeadsEdge))
+	}
+	l = len(m.Logs)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Heads) > 0 {
+		for _, e := range m.Heads {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	l = len(m.Peers)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecentRecordsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecentRecordsRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.MaxRecordSize != 0 {
+		n += 1 + sovNet(uint64(m.MaxRecordSize))
+	}
+	return n
+}
+
+func (m *GetRecentRecordsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Presence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.IdentitySig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *Presence_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PeerID != nil {
+		l = m.PeerID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovNet(uint64(m.Status))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovNet(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *GetLogDigestsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *GetLogDigestsRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Length != 0 {
+		n += 1 + sovNet(uint64(m.Length))
+	}
+	if len(m.Ranges) > 0 {
+		for _, e := range m.Ranges {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetLogDigestsRequest_Range) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Level != 0 {
+		n += 1 + sovNet(uint64(m.Level))
+	}
+	if m.Index != 0 {
+		n += 1 + sovNet(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *GetLogDigestsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	if len(m.Digests) > 0 {
+		for _, b := range m.Digests {
+			l = len(b)
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Follow) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *Follow_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PeerID != nil {
+		l = m.PeerID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, e := range m.Addrs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Active {
+		n += 2
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovNet(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *Freeze) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *Freeze_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PeerID != nil {
+		l = m.PeerID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Frozen {
+		n += 2
+	}
+	if len(m.Heads) > 0 {
+		for _, e := range m.Heads {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovNet(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *Freeze_Head) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	return n
+}
+
+func (m *LogAddrs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *LogAddrs_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, e := range m.Addrs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovNet(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *TopicMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Proof)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *RecordAck) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *RecordAck_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.PeerID != nil {
+		l = m.PeerID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Delivered != nil {
+		l = m.Delivered.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Seen != nil {
+		l = m.Seen.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovNet(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *RecordAck_Range) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	return n
+}
+
+func (m *PushAcksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *PushAcksRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Acks) > 0 {
+		for _, e := range m.Acks {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PushAcksReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *HandshakeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *HandshakeRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HandshakeReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GossipPeers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GossipPeers_Peer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PeerID != nil {
+		l = m.PeerID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, e := range m.Addrs {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryRecordsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRecordsRequest_Body) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ThreadID != nil {
+		l = m.ThreadID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.ServiceKey != nil {
+		l = m.ServiceKey.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Selector != nil {
+		l = m.Selector.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRecordsRequest_Selector) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Target != 0 {
+		n += 1 + sovNet(uint64(m.Target))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRecordsReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matches) > 0 {
+		for _, e := range m.Matches {
+			l = e.Size()
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryRecordsReply_Match) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != nil {
+		l = m.LogID.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRecordsReply_Proof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Head != nil {
+		l = m.Head.Size()
+		n += 1 + l + sovNet(uint64(l))
+	}
+	if m.Counter != 0 {
+		n += 1 + sovNet(uint64(m.Counter))
+	}
+	if m.Position != 0 {
+		n += 1 + sovNet(uint64(m.Position))
+	}
+	if len(m.Records) > 0 {
+		for _, b := range m.Records {
+			l = len(b)
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovNet(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozNet(x uint64) (n int) {
+	return sovNet(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Log) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Log: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Log: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.ID = &v
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPubKey
+			m.PubKey = &v
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoAddr
+			m.Addrs = append(m.Addrs, v)
+			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Head = &v
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Log_Record) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordNode = append(m.RecordNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.RecordNode == nil {
+				m.RecordNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventNode = append(m.EventNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.EventNode == nil {
+				m.EventNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderNode = append(m.HeaderNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.HeaderNode == nil {
+				m.HeaderNode = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BodyNode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BodyNode = append(m.BodyNode[:0], dAtA[iNdEx:postIndex]...)
+			if m.BodyNode == nil {
+				m.BodyNode = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLogsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &GetLogsRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &GetLogsRequest_Body_LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Follows", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Follows = append(m.Follows, &Follow{})
+			if err := m.Follows[len(m.Follows)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Freeze", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Freeze == nil {
+				m.Freeze = &Freeze{}
+			}
+			if err := m.Freeze.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddressEdge", wireType)
+			}
+			m.AddressEdge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AddressEdge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLogsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLogsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &Log{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Follows", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Follows = append(m.Follows, &Follow{})
+			if err := m.Follows[len(m.Follows)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Freeze", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Freeze == nil {
+				m.Freeze = &Freeze{}
+			}
+			if err := m.Freeze.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushLogRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &PushLogRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ReadKey = &v
+			if err := m.ReadKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Log == nil {
+				m.Log = &Log{}
+			}
+			if err := m.Log.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Addrs == nil {
+				m.Addrs = &LogAddrs{}
+			}
+			if err := m.Addrs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushLogReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushLogReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushLogReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &GetRecordsRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &GetRecordsRequest_Body_LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadersOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HeadersOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Offset = &v
+			if err := m.Offset.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
+			}
+			m.Direction = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Direction |= GetRecordsRequest_Direction(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Anchor", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Anchor = &v
+			if err := m.Anchor.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &GetRecordsReply_LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, &Log_Record{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Log == nil {
+				m.Log = &Log{}
+			}
+			if err := m.Log.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.Base = &v
+			if err := m.Base.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsByCIDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordsByCIDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordsByCIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &GetRecordsByCIDRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsByCIDRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &GetRecordsByCIDRequest_Body_LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsByCIDRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoCid
+			m.RecordIDs = append(m.RecordIDs, v)
+			if err := m.RecordIDs[len(m.RecordIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsByCIDReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordsByCIDReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordsByCIDReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &GetRecordsByCIDReply_LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordsByCIDReply_LogEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, &Log_Record{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proofs = append(m.Proofs, &QueryRecordsReply_Proof{})
+			if err := m.Proofs[len(m.Proofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushRecordRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushRecordRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &PushRecordRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushRecordRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Log_Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PushRecordReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PushRecordReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PushRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExchangeEdgesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExchangeEdgesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExchangeEdgesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &ExchangeEdgesRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExchangeEdgesRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Threads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Threads = append(m.Threads, &ExchangeEdgesRequest_Body_ThreadEntry{})
+			if err := m.Threads[len(m.Threads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecordSize", wireType)
+			}
+			m.MaxRecordSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRecordSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GossipPeers", wireType)
+			}
+			m.GossipPeers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GossipPeers |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExchangeEdgesRequest_Body_ThreadEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThreadEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThreadEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddressEdge", wireType)
+			}
+			m.AddressEdge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AddressEdge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadsEdge", wireType)
+			}
+			m.HeadsEdge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HeadsEdge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExchangeEdgesReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExchangeEdgesReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExchangeEdgesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Edges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Edges = append(m.Edges, &ExchangeEdgesReply_ThreadEdges{})
+			if err := m.Edges[len(m.Edges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecordSize", wireType)
+			}
+			m.MaxRecordSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRecordSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThreadEdges: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThreadEdges: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exists", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Exists = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddressEdge", wireType)
+			}
+			m.AddressEdge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AddressEdge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadsEdge", wireType)
+			}
+			m.HeadsEdge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HeadsEdge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs[:0], dAtA[iNdEx:postIndex]...)
+			if m.Logs == nil {
+				m.Logs = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Heads = append(m.Heads, &GetRecordsByCIDReply_LogEntry{})
+			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peers == nil {
+				m.Peers = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecentRecordsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecentRecordsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecentRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &GetRecentRecordsRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecentRecordsRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRecordSize", wireType)
+			}
+			m.MaxRecordSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRecordSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecentRecordsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecentRecordsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecentRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, &PushRecordRequest{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Presence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Presence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Presence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &Presence_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sig = append(m.Sig[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sig == nil {
+				m.Sig = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdentitySig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdentitySig = append(m.IdentitySig[:0], dAtA[iNdEx:postIndex]...)
+			if m.IdentitySig == nil {
+				m.IdentitySig = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Presence_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.PeerID = &v
+			if err := m.PeerID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= Presence_Status(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogDigestsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLogDigestsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLogDigestsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &GetLogDigestsRequest_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogDigestsRequest_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ranges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ranges = append(m.Ranges, &GetLogDigestsRequest_Range{})
+			if err := m.Ranges[len(m.Ranges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogDigestsRequest_Range) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Range: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Range: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			m.Level = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Level |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLogDigestsReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLogDigestsReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLogDigestsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digests", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Digests = append(m.Digests, make([]byte, postIndex-iNdEx))
+			copy(m.Digests[len(m.Digests)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Follow) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Follow: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Follow: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &Follow_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sig = append(m.Sig[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sig == nil {
+				m.Sig = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Follow_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.PeerID = &v
+			if err := m.PeerID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoAddr
+			m.Addrs = append(m.Addrs, v)
+			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Active", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Active = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Freeze) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Freeze: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Freeze: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &Freeze_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sig = append(m.Sig[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sig == nil {
+				m.Sig = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Freeze_Body) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.PeerID = &v
+			if err := m.PeerID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Frozen", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Frozen = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Heads = append(m.Heads, &Freeze_Head{})
+			if err := m.Heads[len(m.Heads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNet
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func (m *ExchangeEdgesReply_ThreadEdges) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ThreadID != nil {
-		l = m.ThreadID.Size()
-		n += 1 + l + sovNet(uint64(l))
-	}
-	if m.Exists {
-		n += 2
-	}
-	if m.AddressEdge != 0 {
-		n += 1 + sovNet(uint64(m.AddressEdge))
-	}
-	if m.HeadsEdge != 0 {
-		n += 1 + sovNet(uint64(m.HeadsEdge))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovNet(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozNet(x uint64) (n int) {
-	return sovNet(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Log) Unmarshal(dAtA []byte) error {
+func (m *Freeze_Head) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3119,15 +14740,15 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Log: wiretype end group for non-group")
+			return fmt.Errorf("proto: Head: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Log: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Head: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3155,16 +14776,16 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			var v ProtoPeerID
-			m.ID = &v
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
 			}
-			var byteLen int
+			m.Counter = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -3174,32 +14795,66 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Counter |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthNet
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthNet
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoPubKey
-			m.PubKey = &v
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogAddrs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
 			}
-			iNdEx = postIndex
-		case 3:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogAddrs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogAddrs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -3209,30 +14864,31 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoAddr
-			m.Addrs = append(m.Addrs, v)
-			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Body == nil {
+				m.Body = &LogAddrs_Body{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sig", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3259,31 +14915,11 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoCid
-			m.Head = &v
-			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Sig = append(m.Sig[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sig == nil {
+				m.Sig = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
-			}
-			m.Counter = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Counter |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -3305,7 +14941,7 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Log_Record) Unmarshal(dAtA []byte) error {
+func (m *LogAddrs_Body) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3328,15 +14964,15 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+			return fmt.Errorf("proto: Body: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RecordNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3363,14 +14999,50 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RecordNode = append(m.RecordNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.RecordNode == nil {
-				m.RecordNode = []byte{}
+			var v ProtoThreadID
+			m.ThreadID = &v
+			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3387,24 +15059,94 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoAddr
+			m.Addrs = append(m.Addrs, v)
+			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNet(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthNet
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNet
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EventNode = append(m.EventNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.EventNode == nil {
-				m.EventNode = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 3:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeaderNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3431,14 +15173,14 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.HeaderNode = append(m.HeaderNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.HeaderNode == nil {
-				m.HeaderNode = []byte{}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BodyNode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3465,9 +15207,9 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BodyNode = append(m.BodyNode[:0], dAtA[iNdEx:postIndex]...)
-			if m.BodyNode == nil {
-				m.BodyNode = []byte{}
+			m.Proof = append(m.Proof[:0], dAtA[iNdEx:postIndex]...)
+			if m.Proof == nil {
+				m.Proof = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -3491,7 +15233,7 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
+func (m *RecordAck) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3514,13 +15256,13 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetLogsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RecordAck: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RecordAck: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
@@ -3550,12 +15292,46 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Body == nil {
-				m.Body = &GetLogsRequest_Body{}
+				m.Body = &RecordAck_Body{}
 			}
 			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sig", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sig = append(m.Sig[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sig == nil {
+				m.Sig = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -3577,7 +15353,7 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLogsRequest_Body) Unmarshal(dAtA []byte) error {
+func (m *RecordAck_Body) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3643,7 +15419,7 @@ func (m *GetLogsRequest_Body) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3670,12 +15446,138 @@ func (m *GetLogsRequest_Body) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoKey
-			m.ServiceKey = &v
-			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v ProtoPeerID
+			m.PeerID = &v
+			if err := m.PeerID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delivered", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Delivered == nil {
+				m.Delivered = &RecordAck_Range{}
+			}
+			if err := m.Delivered.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seen", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Seen == nil {
+				m.Seen = &RecordAck_Range{}
+			}
+			if err := m.Seen.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -3697,7 +15599,7 @@ func (m *GetLogsRequest_Body) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
+func (m *RecordAck_Range) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3720,17 +15622,17 @@ func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetLogsReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: Range: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLogsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Range: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -3740,26 +15642,46 @@ func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &Log{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoCid
+			m.Head = &v
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
+			}
+			m.Counter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Counter |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -3781,7 +15703,7 @@ func (m *GetLogsReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
+func (m *PushAcksRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3804,13 +15726,13 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushLogRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PushAcksRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PushAcksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
@@ -3840,7 +15762,7 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Body == nil {
-				m.Body = &PushLogRequest_Body{}
+				m.Body = &PushAcksRequest_Body{}
 			}
 			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -3867,7 +15789,7 @@ func (m *PushLogRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
+func (m *PushAcksRequest_Body) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3968,42 +15890,7 @@ func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthNet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthNet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v ProtoKey
-			m.ReadKey = &v
-			if err := m.ReadKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Acks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4030,10 +15917,8 @@ func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Log == nil {
-				m.Log = &Log{}
-			}
-			if err := m.Log.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Acks = append(m.Acks, &RecordAck{})
+			if err := m.Acks[len(m.Acks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4058,7 +15943,7 @@ func (m *PushLogRequest_Body) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushLogReply) Unmarshal(dAtA []byte) error {
+func (m *PushAcksReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4081,10 +15966,10 @@ func (m *PushLogReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushLogReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: PushAcksReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushLogReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PushAcksReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -4108,7 +15993,7 @@ func (m *PushLogReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
+func (m *HandshakeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4131,13 +16016,13 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HandshakeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HandshakeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
@@ -4167,7 +16052,7 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Body == nil {
-				m.Body = &GetRecordsRequest_Body{}
+				m.Body = &HandshakeRequest_Body{}
 			}
 			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -4194,7 +16079,7 @@ func (m *GetRecordsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
+func (m *HandshakeRequest_Body) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4225,9 +16110,9 @@ func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -4237,67 +16122,29 @@ func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoThreadID
-			m.ThreadID = &v
-			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthNet
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthNet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v ProtoKey
-			m.ServiceKey = &v
-			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -4307,25 +16154,23 @@ func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &GetRecordsRequest_Body_LogEntry{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4348,7 +16193,7 @@ func (m *GetRecordsRequest_Body) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
+func (m *HandshakeReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4371,17 +16216,17 @@ func (m *GetRecordsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: HandshakeReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HandshakeReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -4391,32 +16236,29 @@ func (m *GetRecordsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoPeerID
-			m.LogID = &v
-			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -4426,65 +16268,24 @@ func (m *GetRecordsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoCid
-			m.Offset = &v
-			if err := m.Offset.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
-			}
-			m.Limit = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Limit |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
-			}
-			m.Counter = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Counter |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -4506,7 +16307,7 @@ func (m *GetRecordsRequest_Body_LogEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
+func (m *GossipPeers) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4529,15 +16330,15 @@ func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordsReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GossipPeers: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GossipPeers: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4564,8 +16365,8 @@ func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &GetRecordsReply_LogEntry{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Peers = append(m.Peers, &GossipPeers_Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4590,7 +16391,7 @@ func (m *GetRecordsReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
+func (m *GossipPeers_Peer) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4613,15 +16414,15 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: Peer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Peer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4649,50 +16450,16 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			var v ProtoPeerID
-			m.LogID = &v
-			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.PeerID = &v
+			if err := m.PeerID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthNet
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthNet
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Records = append(m.Records, &Log_Record{})
-			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -4702,25 +16469,24 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Log == nil {
-				m.Log = &Log{}
-			}
-			if err := m.Log.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoAddr
+			m.Addrs = append(m.Addrs, v)
+			if err := m.Addrs[len(m.Addrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4745,7 +16511,7 @@ func (m *GetRecordsReply_LogEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushRecordRequest) Unmarshal(dAtA []byte) error {
+func (m *QueryRecordsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4768,13 +16534,13 @@ func (m *PushRecordRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushRecordRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryRecordsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryRecordsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
 			}
@@ -4804,31 +16570,12 @@ func (m *PushRecordRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Body == nil {
-				m.Body = &PushRecordRequest_Body{}
+				m.Body = &QueryRecordsRequest_Body{}
 			}
 			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
-			}
-			m.Counter = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Counter |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
@@ -4850,7 +16597,7 @@ func (m *PushRecordRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushRecordRequest_Body) Unmarshal(dAtA []byte) error {
+func (m *QueryRecordsRequest_Body) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4916,7 +16663,7 @@ func (m *PushRecordRequest_Body) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4943,15 +16690,15 @@ func (m *PushRecordRequest_Body) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoPeerID
-			m.LogID = &v
-			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoKey
+			m.ServiceKey = &v
+			if err := m.ServiceKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Selector", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4978,10 +16725,10 @@ func (m *PushRecordRequest_Body) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Record == nil {
-				m.Record = &Log_Record{}
+			if m.Selector == nil {
+				m.Selector = &QueryRecordsRequest_Selector{}
 			}
-			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Selector.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5006,7 +16753,7 @@ func (m *PushRecordRequest_Body) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PushRecordReply) Unmarshal(dAtA []byte) error {
+func (m *QueryRecordsRequest_Selector) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5029,67 +16776,68 @@ func (m *PushRecordReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PushRecordReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: Selector: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PushRecordReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Selector: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNet(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthNet
+			m.Target = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Target |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ExchangeEdgesRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowNet
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNet
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ExchangeEdgesRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExchangeEdgesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 2:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -5099,26 +16847,24 @@ func (m *ExchangeEdgesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthNet
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Body == nil {
-				m.Body = &ExchangeEdgesRequest_Body{}
-			}
-			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -5142,7 +16888,7 @@ func (m *ExchangeEdgesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExchangeEdgesRequest_Body) Unmarshal(dAtA []byte) error {
+func (m *QueryRecordsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5165,15 +16911,15 @@ func (m *ExchangeEdgesRequest_Body) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Body: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryRecordsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Body: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryRecordsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Threads", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Matches", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5200,8 +16946,8 @@ func (m *ExchangeEdgesRequest_Body) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Threads = append(m.Threads, &ExchangeEdgesRequest_Body_ThreadEntry{})
-			if err := m.Threads[len(m.Threads)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Matches = append(m.Matches, &QueryRecordsReply_Match{})
+			if err := m.Matches[len(m.Matches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5226,7 +16972,7 @@ func (m *ExchangeEdgesRequest_Body) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExchangeEdgesRequest_Body_ThreadEntry) Unmarshal(dAtA []byte) error {
+func (m *QueryRecordsReply_Match) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5249,15 +16995,15 @@ func (m *ExchangeEdgesRequest_Body_ThreadEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ThreadEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: Match: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ThreadEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Match: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5284,36 +17030,17 @@ func (m *ExchangeEdgesRequest_Body_ThreadEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoThreadID
-			m.ThreadID = &v
-			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoPeerID
+			m.LogID = &v
+			if err := m.LogID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddressEdge", wireType)
-			}
-			m.AddressEdge = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNet
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.AddressEdge |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeadsEdge", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
 			}
-			m.HeadsEdge = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -5323,64 +17050,31 @@ func (m *ExchangeEdgesRequest_Body_ThreadEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.HeadsEdge |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipNet(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			if msglen < 0 {
+				return ErrInvalidLengthNet
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthNet
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ExchangeEdgesReply) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowNet
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Record == nil {
+				m.Record = &Log_Record{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ExchangeEdgesReply: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExchangeEdgesReply: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Edges", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5407,8 +17101,10 @@ func (m *ExchangeEdgesReply) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Edges = append(m.Edges, &ExchangeEdgesReply_ThreadEdges{})
-			if err := m.Edges[len(m.Edges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Proof == nil {
+				m.Proof = &QueryRecordsReply_Proof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5433,7 +17129,7 @@ func (m *ExchangeEdgesReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
+func (m *QueryRecordsReply_Proof) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5456,15 +17152,15 @@ func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ThreadEdges: wiretype end group for non-group")
+			return fmt.Errorf("proto: Proof: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ThreadEdges: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Proof: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThreadID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5491,17 +17187,17 @@ func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v ProtoThreadID
-			m.ThreadID = &v
-			if err := m.ThreadID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v ProtoCid
+			m.Head = &v
+			if err := m.Head.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exists", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Counter", wireType)
 			}
-			var v int
+			m.Counter = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -5511,17 +17207,16 @@ func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Counter |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Exists = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddressEdge", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Position", wireType)
 			}
-			m.AddressEdge = 0
+			m.Position = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -5531,16 +17226,16 @@ func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AddressEdge |= uint64(b&0x7F) << shift
+				m.Position |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeadsEdge", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
 			}
-			m.HeadsEdge = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNet
@@ -5550,11 +17245,24 @@ func (m *ExchangeEdgesReply_ThreadEdges) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.HeadsEdge |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, make([]byte, postIndex-iNdEx))
+			copy(m.Records[len(m.Records)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
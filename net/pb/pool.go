@@ -0,0 +1,45 @@
+package net_pb
+
+import (
+	"sync"
+)
+
+// maxPooledBufferSize bounds the capacity of buffers returned to the pool,
+// so occasional large messages don't pin memory.
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 1<<10)
+		return &b
+	},
+}
+
+// sizedMarshaler is implemented by generated messages.
+type sizedMarshaler interface {
+	Size() int
+	MarshalToSizedBuffer(data []byte) (int, error)
+}
+
+// MarshalPooled marshals m into a pooled buffer. The bytes are only valid until
+// release is called, so it must only be used for transient encodings, e.g.,
+// payloads which are signed, verified or encrypted right away.
+func MarshalPooled(m sizedMarshaler) (data []byte, release func(), err error) {
+	bp := bufferPool.Get().(*[]byte)
+	release = func() {
+		if cap(*bp) <= maxPooledBufferSize {
+			bufferPool.Put(bp)
+		}
+	}
+	size := m.Size()
+	if cap(*bp) < size {
+		*bp = make([]byte, size)
+	}
+	buf := (*bp)[:size]
+	n, err := m.MarshalToSizedBuffer(buf)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return buf[size-n:], release, nil
+}
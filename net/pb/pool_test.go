@@ -0,0 +1,38 @@
+package net_pb
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestMarshalPooled(t *testing.T) {
+	popr := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		p := NewPopulatedLog_Record(popr, false)
+		want, err := p.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, release, err := MarshalPooled(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("pooled encoding differs from Marshal")
+		}
+		release()
+	}
+}
+
+func BenchmarkMarshalPooled(b *testing.B) {
+	p := NewPopulatedLog_Record(rand.New(rand.NewSource(1)), false)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, release, err := MarshalPooled(p)
+		if err != nil {
+			b.Fatal(err)
+		}
+		release()
+	}
+}
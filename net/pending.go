@@ -0,0 +1,152 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+var (
+	// PendingRecordTTL is the duration a record multicast before its log is held for the log to arrive.
+	PendingRecordTTL = time.Second * 30
+
+	// MaxPendingRecords is the maximum number of records held for logs which haven't arrived yet.
+	MaxPendingRecords = 256
+)
+
+// pendingRecord is a pushed record waiting for its log.
+type pendingRecord struct {
+	req   *pb.PushRecordRequest
+	from  peer.ID
+	added time.Time
+}
+
+// pendingRecords is a reorder buffer of records which beat their log announcement.
+// Records are applied once the log is delivered by PushLog or GetLogs.
+type pendingRecords struct {
+	sync.Mutex
+	recs  map[thread.ID]map[peer.ID][]pendingRecord
+	count int
+	clock clock.Clock
+}
+
+func newPendingRecords(clk clock.Clock) *pendingRecords {
+	return &pendingRecords{recs: make(map[thread.ID]map[peer.ID][]pendingRecord), clock: clk}
+}
+
+// add holds a record until its log arrives. Expired records are pruned if the buffer is full,
+// the record is dropped if that doesn't make room.
+func (p *pendingRecords) add(from peer.ID, req *pb.PushRecordRequest) bool {
+	p.Lock()
+	defer p.Unlock()
+	if p.count >= MaxPendingRecords {
+		p.prune(p.clock.Now())
+		if p.count >= MaxPendingRecords {
+			return false
+		}
+	}
+	tid, lid := req.Body.ThreadID.ID, req.Body.LogID.ID
+	lgs, ok := p.recs[tid]
+	if !ok {
+		lgs = make(map[peer.ID][]pendingRecord)
+		p.recs[tid] = lgs
+	}
+	lgs[lid] = append(lgs[lid], pendingRecord{req: req, from: from, added: p.clock.Now()})
+	p.count++
+	return true
+}
+
+// has returns whether records are held for a log.
+func (p *pendingRecords) has(tid thread.ID, lid peer.ID) bool {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.recs[tid][lid]) > 0
+}
+
+// counts returns the number of records held for each log of a thread.
+func (p *pendingRecords) counts(tid thread.ID) map[peer.ID]int {
+	p.Lock()
+	defer p.Unlock()
+	counts := make(map[peer.ID]int, len(p.recs[tid]))
+	for lid, recs := range p.recs[tid] {
+		counts[lid] = len(recs)
+	}
+	return counts
+}
+
+// take removes the unexpired records held for a log, in arrival order.
+func (p *pendingRecords) take(tid thread.ID, lid peer.ID) []pendingRecord {
+	p.Lock()
+	defer p.Unlock()
+	lgs, ok := p.recs[tid]
+	if !ok {
+		return nil
+	}
+	held := lgs[lid]
+	delete(lgs, lid)
+	if len(lgs) == 0 {
+		delete(p.recs, tid)
+	}
+	p.count -= len(held)
+
+	var (
+		now  = p.clock.Now()
+		recs = held[:0]
+	)
+	for _, r := range held {
+		if now.Sub(r.added) < PendingRecordTTL {
+			recs = append(recs, r)
+		}
+	}
+	return recs
+}
+
+// forget drops the records held for a deleted thread.
+func (p *pendingRecords) forget(tid thread.ID) {
+	p.Lock()
+	defer p.Unlock()
+	for _, held := range p.recs[tid] {
+		p.count -= len(held)
+	}
+	delete(p.recs, tid)
+}
+
+// prune drops expired records, the caller must hold the lock.
+func (p *pendingRecords) prune(now time.Time) {
+	for tid, lgs := range p.recs {
+		for lid, held := range lgs {
+			recs := held[:0]
+			for _, r := range held {
+				if now.Sub(r.added) < PendingRecordTTL {
+					recs = append(recs, r)
+				}
+			}
+			p.count -= len(held) - len(recs)
+			if len(recs) == 0 {
+				delete(lgs, lid)
+			} else {
+				lgs[lid] = recs
+			}
+		}
+		if len(lgs) == 0 {
+			delete(p.recs, tid)
+		}
+	}
+}
+
+// applyPendingRecords pushes the records held for a newly added log as if they just arrived.
+func (n *net) applyPendingRecords(tid thread.ID, lid peer.ID) {
+	for _, r := range n.pending.take(tid, lid) {
+		ctx := grpcpeer.NewContext(n.ctx, &grpcpeer.Peer{
+			Addr: &addr{id: r.from},
+		})
+		if _, err := n.server.acceptRecord(ctx, r.req, false); err != nil {
+			log.Debugf("applying pending record (thread: %s, log: %s) failed: %v", tid, lid, err)
+		}
+	}
+}
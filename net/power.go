@@ -0,0 +1,115 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/queue"
+)
+
+// BurstPullInterval is the interval between pull bursts of hosts with burst sync enabled.
+var BurstPullInterval = time.Minute
+
+func (n *net) SetPowerState(state core.PowerState) {
+	if prev := n.power.set(state); prev != state {
+		log.Debugf("power state changed from %s to %s", prev, state)
+	}
+}
+
+// powerState tracks the platform state reported by the embedder.
+type powerState struct {
+	lock  sync.Mutex
+	state core.PowerState
+	// resumed is closed while periodic pulls aren't suspended
+	resumed chan struct{}
+	// interrupted is set once pulls are suspended, until the puller catches up
+	interrupted bool
+}
+
+func newPowerState() *powerState {
+	resumed := make(chan struct{})
+	close(resumed)
+	return &powerState{state: core.PowerForeground, resumed: resumed}
+}
+
+// set changes the state and returns the previous one.
+func (p *powerState) set(state core.PowerState) core.PowerState {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	prev := p.state
+	p.state = state
+	switch suspended := state != core.PowerForeground; {
+	case suspended && prev == core.PowerForeground:
+		p.resumed = make(chan struct{})
+		p.interrupted = true
+	case !suspended && prev != core.PowerForeground:
+		close(p.resumed)
+	}
+	return prev
+}
+
+// suspended returns whether periodic pulls are suspended.
+func (p *powerState) suspended() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.state != core.PowerForeground
+}
+
+// wait blocks while periodic pulls are suspended. It returns whether pulls were suspended
+// since the last wait, and false along with ctx's error if ctx is done first.
+func (p *powerState) wait(ctx context.Context) (bool, error) {
+	for {
+		p.lock.Lock()
+		resumed := p.resumed
+		p.lock.Unlock()
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		p.lock.Lock()
+		if p.state == core.PowerForeground {
+			interrupted := p.interrupted
+			p.interrupted = false
+			p.lock.Unlock()
+			return interrupted, nil
+		}
+		// suspended again in the meantime
+		p.lock.Unlock()
+	}
+}
+
+// catchUpPlan orders threads for pulling after a suspension, those with the most recent
+// local activity first. Threads without activity keep their order at the end.
+func (n *net) catchUpPlan(ts []thread.ID) []thread.ID {
+	activity := make(map[thread.ID]int64, len(ts))
+	for _, tid := range ts {
+		last, err := n.store.GetInt64(tid, metaLastActivity)
+		if err != nil {
+			log.Errorf("error getting last activity of thread %s: %v", tid, err)
+		} else if last != nil {
+			activity[tid] = *last
+		}
+	}
+	plan := make([]thread.ID, len(ts))
+	copy(plan, ts)
+	sort.SliceStable(plan, func(i, j int) bool {
+		return activity[plan[i]] > activity[plan[j]]
+	})
+	return plan
+}
+
+// pullBurst schedules edge exchanges of all threads at once, so they're packed into as
+// few requests as possible and the host's radio wakes up rarely.
+func (n *net) pullBurst(compressor queue.ThreadPacker, ts []thread.ID) error {
+	for _, tid := range ts {
+		if err := n.schedulePull(compressor, tid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package net
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestPowerState(t *testing.T) {
+	t.Parallel()
+	p := newPowerState()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	if suspended, err := p.wait(ctx); err != nil || suspended {
+		t.Fatalf("expected pulls not to be suspended in the foreground, got %v, %v", suspended, err)
+	}
+
+	p.set(core.PowerBackground)
+	if prev := p.set(core.PowerLowBattery); prev != core.PowerBackground {
+		t.Fatalf("expected previous state to be returned, got %s", prev)
+	}
+	if !p.suspended() {
+		t.Fatal("expected pulls to be suspended on low battery")
+	}
+	if _, err := p.wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected wait to block while suspended, got %v", err)
+	}
+
+	done := make(chan bool)
+	go func() {
+		suspended, _ := p.wait(context.Background())
+		done <- suspended
+	}()
+	p.set(core.PowerForeground)
+	select {
+	case suspended := <-done:
+		if !suspended {
+			t.Fatal("expected wait to report the suspension")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wait didn't return once foregrounded")
+	}
+}
+
+func TestNet_CatchUpPlan(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	var ts []thread.ID
+	for _, last := range []int64{1, 3, 2} {
+		info := createThread(t, ctx, n)
+		if err := n.store.PutInt64(info.ID, metaLastActivity, last); err != nil {
+			t.Fatal(err)
+		}
+		ts = append(ts, info.ID)
+	}
+	plan := n.catchUpPlan(ts)
+	if len(plan) != 3 || plan[0] != ts[1] || plan[1] != ts[2] || plan[2] != ts[0] {
+		t.Fatalf("expected the most active threads first, got %v", plan)
+	}
+
+	// setting the power state is safe while the host pulls
+	n.SetPowerState(core.PowerBackground)
+	n.SetPowerState(core.PowerForeground)
+}
@@ -0,0 +1,354 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/broadcast"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	"github.com/textileio/go-threads/lifecycle"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+const (
+	// presenceBusCapacity is the buffer size of presence listeners.
+	presenceBusCapacity = 32
+
+	// presenceNotifyTimeout is the duration to wait for a slow presence listener.
+	presenceNotifyTimeout = time.Millisecond * 100
+)
+
+func (n *net) PublishPresence(
+	ctx context.Context,
+	id thread.ID,
+	status core.PresenceStatus,
+	payload []byte,
+	opts ...core.ThreadOption,
+) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	identity, err := n.Validate(id, args.Token, true)
+	if err != nil {
+		return err
+	}
+	if n.server.ps == nil {
+		return core.ErrPresenceUnavailable
+	}
+	if _, err = n.store.GetThread(id); err != nil {
+		return err
+	}
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return err
+	}
+	if sk == nil {
+		return fmt.Errorf("a service-key is required to publish presence")
+	}
+
+	p := core.Presence{
+		ThreadID: id,
+		Peer:     n.host.ID(),
+		Identity: identity,
+		Status:   status,
+		Payload:  payload,
+		Time:     n.clock.Now(),
+	}
+	var identitySig []byte
+	if identity != nil {
+		if args.PresenceSig != nil {
+			if d := p.Time.Sub(args.PresenceTime); d > PresenceTTL || d < -PresenceTTL {
+				return fmt.Errorf("presence time is out of bounds")
+			}
+			p.Time = args.PresenceTime
+			identitySig = args.PresenceSig
+			if ok, err := identity.Verify(core.PresenceIdentityPayload(p), identitySig); !ok || err != nil {
+				return fmt.Errorf("bad presence identity signature")
+			}
+		} else {
+			signer, err := n.identitySigner(identity, args.Signer)
+			if err != nil {
+				return err
+			}
+			if identitySig, err = signer.Sign(ctx, core.PresenceIdentityPayload(p)); err != nil {
+				return fmt.Errorf("signing presence identity: %w", err)
+			}
+		}
+	}
+	pp, err := n.presenceToProto(p, identitySig, sk)
+	if err != nil {
+		return err
+	}
+	if err = n.server.ps.PublishPresence(ctx, id, pp); err != nil {
+		return err
+	}
+	// pubsub doesn't deliver our own messages back to the tracker
+	n.presence.update(p)
+	return nil
+}
+
+func (n *net) SubscribePresence(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (<-chan core.Presence, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	if n.server.ps == nil {
+		return nil, core.ErrPresenceUnavailable
+	}
+
+	channel := make(chan core.Presence)
+	listener := n.presence.bus.Listen()
+	current := n.presence.list(id)
+	if err := n.life.Go(lifecycle.StageApp, "presence subscription", func(context.Context) {
+		defer close(channel)
+		defer listener.Discard()
+		for _, p := range current {
+			select {
+			case <-ctx.Done():
+				return
+			case channel <- p:
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case i, ok := <-listener.Channel():
+				if !ok {
+					return
+				}
+				p, ok := i.(core.Presence)
+				if !ok {
+					log.Warn("listener received a non-presence value")
+					continue
+				}
+				if !p.ThreadID.Equals(id) {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case channel <- p:
+				}
+			}
+		}
+	}); err != nil {
+		listener.Discard()
+		return nil, err
+	}
+	return channel, nil
+}
+
+// presenceHandler receives presence messages over pubsub.
+func (n *net) presenceHandler(pp *pb.Presence) {
+	if pp.Body == nil || pp.Body.ThreadID == nil {
+		log.Debugf("error handling presence: incomplete presence message")
+		return
+	}
+	sk, err := n.store.ServiceKey(pp.Body.ThreadID.ID)
+	if err != nil || sk == nil {
+		log.Debugf("error handling presence: service-key not found")
+		return
+	}
+	p, err := presenceFromProto(pp, sk)
+	if err != nil {
+		log.Debugf("error handling presence: %v", err)
+		return
+	}
+	n.presence.update(p)
+}
+
+// startPresenceExpiration periodically expires presence that wasn't refreshed in time.
+func (n *net) startPresenceExpiration() {
+	tick := n.clock.NewTicker(PresenceCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-tick.C:
+			n.presence.expire(n.clock.Now().Add(-PresenceTTL))
+		}
+	}
+}
+
+// presenceToProto returns a presence message signed with the host key, along with the
+// identity signature of the presence, if any. The payload is encrypted with the service key.
+func (n *net) presenceToProto(p core.Presence, identitySig []byte, sk *sym.Key) (*pb.Presence, error) {
+	body := &pb.Presence_Body{
+		ThreadID:  &pb.ProtoThreadID{ID: p.ThreadID},
+		PeerID:    &pb.ProtoPeerID{ID: p.Peer},
+		Status:    pb.Presence_Status(p.Status),
+		Timestamp: p.Time.UnixNano(),
+	}
+	var err error
+	if len(p.Payload) > 0 {
+		if body.Payload, err = sk.Encrypt(p.Payload); err != nil {
+			return nil, err
+		}
+	}
+	if p.Identity != nil {
+		if body.Identity, err = p.Identity.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	msg, release, err := pb.MarshalPooled(body)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	sig, err := n.getPrivKey().Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Presence{Body: body, Sig: sig, IdentitySig: identitySig}, nil
+}
+
+// presenceFromProto returns presence from a verified presence message. The payload is
+// decrypted with the service key and the identity must have signed the presence.
+func presenceFromProto(pp *pb.Presence, sk *sym.Key) (p core.Presence, err error) {
+	if err = verifyPresence(pp); err != nil {
+		return
+	}
+	p = core.Presence{
+		ThreadID: pp.Body.ThreadID.ID,
+		Peer:     pp.Body.PeerID.ID,
+		Status:   core.PresenceStatus(pp.Body.Status),
+		Time:     time.Unix(0, pp.Body.Timestamp),
+	}
+	if len(pp.Body.Payload) > 0 {
+		if p.Payload, err = sk.Decrypt(pp.Body.Payload); err != nil {
+			return p, fmt.Errorf("decrypting payload: %w", err)
+		}
+	}
+	if len(pp.Body.Identity) > 0 {
+		identity := &thread.Libp2pPubKey{}
+		if err = identity.UnmarshalBinary(pp.Body.Identity); err != nil {
+			return p, fmt.Errorf("invalid identity: %w", err)
+		}
+		p.Identity = identity
+		if ok, err := identity.Verify(core.PresenceIdentityPayload(p), pp.IdentitySig); !ok || err != nil {
+			return p, errors.New("bad presence identity signature")
+		}
+	}
+	return p, nil
+}
+
+// verifyPresence checks that the presence message is signed by the publishing peer.
+func verifyPresence(pp *pb.Presence) error {
+	if pp.Body == nil || pp.Body.ThreadID == nil || pp.Body.PeerID == nil {
+		return errors.New("incomplete presence message")
+	}
+	pk, err := pp.Body.PeerID.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+	msg, release, err := pb.MarshalPooled(pp.Body)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if ok, err := pk.Verify(msg, pp.Sig); !ok || err != nil {
+		return errors.New("bad presence signature")
+	}
+	return nil
+}
+
+// presenceTracker aggregates the latest presence of thread identities.
+type presenceTracker struct {
+	sync.Mutex
+	m   map[thread.ID]map[string]core.Presence
+	bus *broadcast.Broadcaster
+}
+
+func newPresenceTracker() *presenceTracker {
+	return &presenceTracker{
+		m:   make(map[thread.ID]map[string]core.Presence),
+		bus: broadcast.NewBroadcaster(presenceBusCapacity),
+	}
+}
+
+// update stores the presence and notifies subscribers, presence older than the known one is ignored.
+func (t *presenceTracker) update(p core.Presence) {
+	t.Lock()
+	key := presenceKey(p)
+	tm, ok := t.m[p.ThreadID]
+	if !ok {
+		tm = make(map[string]core.Presence)
+		t.m[p.ThreadID] = tm
+	}
+	if last, ok := tm[key]; ok && last.Time.After(p.Time) {
+		t.Unlock()
+		return
+	}
+	if p.Status == core.PresenceOffline {
+		delete(tm, key)
+	} else {
+		tm[key] = p
+	}
+	t.Unlock()
+	t.notify(p)
+}
+
+// expire drops presence last published before deadline and notifies subscribers it's offline.
+func (t *presenceTracker) expire(deadline time.Time) {
+	var expired []core.Presence
+	t.Lock()
+	for id, tm := range t.m {
+		for key, p := range tm {
+			if p.Time.Before(deadline) {
+				delete(tm, key)
+				p.Status = core.PresenceOffline
+				p.Payload = nil
+				expired = append(expired, p)
+			}
+		}
+		if len(tm) == 0 {
+			delete(t.m, id)
+		}
+	}
+	t.Unlock()
+	for _, p := range expired {
+		t.notify(p)
+	}
+}
+
+// list returns the current presence of thread identities.
+func (t *presenceTracker) list(id thread.ID) []core.Presence {
+	t.Lock()
+	defer t.Unlock()
+	list := make([]core.Presence, 0, len(t.m[id]))
+	for _, p := range t.m[id] {
+		list = append(list, p)
+	}
+	return list
+}
+
+// notify sends presence to subscribers, presence is dropped for slow receivers.
+// It must be called without holding the tracker lock.
+func (t *presenceTracker) notify(p core.Presence) {
+	if err := t.bus.SendWithTimeout(p, presenceNotifyTimeout); err != nil {
+		log.Debugf("presence notification dropped: %v", err)
+	}
+}
+
+func (t *presenceTracker) close() {
+	t.bus.Discard()
+}
+
+// presenceKey identifies an identity's presence published from a peer.
+func presenceKey(p core.Presence) string {
+	if p.Identity == nil {
+		return p.Peer.String()
+	}
+	return p.Peer.String() + "/" + p.Identity.String()
+}
@@ -0,0 +1,146 @@
+package net
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+var (
+	// LowPriorityBatchInterval is the max duration low priority records wait before they're pushed.
+	LowPriorityBatchInterval = time.Second
+
+	// LowPriorityBatchSize is the number of queued low priority records of a thread which triggers a push.
+	LowPriorityBatchSize = 100
+)
+
+type queuedPush struct {
+	lid     peer.ID
+	rec     core.Record
+	counter int64
+}
+
+// pushBatcher queues low priority records and pushes them per thread in batches. Each peer
+// receives the records of a batch one after another over a single call at a time, so bulk
+// writes don't compete with other pushes for streams.
+type pushBatcher struct {
+	s *server
+
+	lock    sync.Mutex
+	pending map[thread.ID][]queuedPush
+	full    chan thread.ID
+}
+
+func newPushBatcher(s *server) *pushBatcher {
+	return &pushBatcher{
+		s:       s,
+		pending: make(map[thread.ID][]queuedPush),
+		full:    make(chan thread.ID, 1),
+	}
+}
+
+// add queues a record, triggering a push of the thread's batch once it's full.
+func (b *pushBatcher) add(tid thread.ID, lid peer.ID, rec core.Record, counter int64) {
+	b.lock.Lock()
+	b.pending[tid] = append(b.pending[tid], queuedPush{lid: lid, rec: rec, counter: counter})
+	full := len(b.pending[tid]) >= LowPriorityBatchSize
+	b.lock.Unlock()
+	if full {
+		select {
+		case b.full <- tid:
+		default:
+			// a push is triggered already, the batch is taken by the next tick otherwise
+		}
+	}
+}
+
+// run pushes batches until ctx is done. Queued records left are pulled by peers instead.
+func (b *pushBatcher) run(ctx context.Context) {
+	tick := b.s.net.clock.NewTicker(LowPriorityBatchInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			b.lock.Lock()
+			tids := make([]thread.ID, 0, len(b.pending))
+			for tid := range b.pending {
+				tids = append(tids, tid)
+			}
+			b.lock.Unlock()
+			for _, tid := range tids {
+				b.flush(ctx, tid)
+			}
+		case tid := <-b.full:
+			b.flush(ctx, tid)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flush pushes the queued records of a thread.
+func (b *pushBatcher) flush(ctx context.Context, tid thread.ID) {
+	b.lock.Lock()
+	batch := b.pending[tid]
+	delete(b.pending, tid)
+	b.lock.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	// records of threads with paused sync are pulled by peers once it's resumed
+	if !b.s.net.syncs.enter(tid) {
+		return
+	}
+	var wg sync.WaitGroup
+	defer func() {
+		go func() {
+			wg.Wait()
+			b.s.net.syncs.leave(tid)
+		}()
+	}()
+
+	peers, err := b.s.pushPeers(tid)
+	if err != nil {
+		log.Errorf("getting peers of thread %s failed: %v", tid, err)
+		return
+	}
+	reqs := make([]*pb.PushRecordRequest, 0, len(batch))
+	for _, p := range batch {
+		req, err := b.s.pushRecordRequest(ctx, tid, p.lid, p.rec, p.counter)
+		if err != nil {
+			log.Errorf("building push of record %s (thread: %s) failed: %v", p.rec.Cid(), tid, err)
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+	log.Debugf("pushing %d low priority records of thread %s to %d peers", len(reqs), tid, len(peers))
+
+	for _, p := range peers {
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			for _, req := range reqs {
+				lid := req.Body.LogID.ID
+				if !b.s.net.peerAcceptsRecord(pid, recordSize(req.Body.Record)) {
+					log.Warnf("record exceeds the max record size of %s, skip pushing (thread: %s, log: %s)", pid, tid, lid)
+					continue
+				}
+				if err := b.s.pushRecordToPeer(req, pid, tid, lid, core.PriorityLow); err != nil {
+					log.Errorf("pushing record to %s (thread: %s, log: %s) failed: %v", pid, tid, lid, err)
+				}
+			}
+		}(p)
+	}
+	if b.s.ps != nil {
+		for _, req := range reqs {
+			if err := b.s.ps.Publish(ctx, tid, req); err != nil {
+				log.Errorf("error publishing record: %s", err)
+			}
+		}
+	}
+}
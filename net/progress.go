@@ -0,0 +1,248 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/lifecycle"
+)
+
+// SyncProgressInterval is the interval at which the sync progress of subscribed threads is checked.
+var SyncProgressInterval = time.Second
+
+func (n *net) GetSyncProgress(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.SyncProgress, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.SyncProgress{}, err
+	}
+	return n.syncProgress(id)
+}
+
+func (n *net) SubscribeSyncProgress(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (<-chan core.SyncProgress, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	current, err := n.syncProgress(id)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := make(chan core.SyncProgress)
+	if err := n.life.Go(lifecycle.StageApp, "sync progress subscription", func(lctx context.Context) {
+		defer close(channel)
+		tick := n.clock.NewTicker(SyncProgressInterval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-lctx.Done():
+				return
+			case <-n.ctx.Done():
+				return
+			case channel <- current:
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-n.ctx.Done():
+					return
+				case <-tick.C:
+				}
+				next, err := n.syncProgress(id)
+				if err != nil {
+					log.Debugf("getting sync progress of thread %s failed: %v", id, err)
+					return
+				}
+				if !progressEqual(next, current) {
+					current = next
+					break
+				}
+			}
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// progressEqual returns whether two progress values of a thread report the same sync state.
+// Estimates which change on every check, i.e. the ETA, are ignored.
+func progressEqual(a, b core.SyncProgress) bool {
+	if a.Records != b.Records || a.Expected != b.Expected || a.Bytes != b.Bytes || len(a.Logs) != len(b.Logs) {
+		return false
+	}
+	for i := range a.Logs {
+		if a.Logs[i] != b.Logs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// syncProgress returns the progress of a thread, the expected height of each log is the
+// highest of its local height, the hinted height and the height learned from peers.
+func (n *net) syncProgress(id thread.ID) (core.SyncProgress, error) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return core.SyncProgress{}, err
+	}
+	hints, err := n.headHints(id)
+	if err != nil {
+		return core.SyncProgress{}, err
+	}
+	heights, bytes := n.progress.get(id)
+
+	logs := make(map[peer.ID]*core.LogSyncProgress, len(info.Logs))
+	height := func(lid peer.ID) *core.LogSyncProgress {
+		lp, ok := logs[lid]
+		if !ok {
+			lp = &core.LogSyncProgress{LogID: lid}
+			logs[lid] = lp
+		}
+		return lp
+	}
+	for _, lg := range info.Logs {
+		lp := height(lg.ID)
+		lp.Applied = lg.Head.Counter
+		lp.Height = lg.Head.Counter
+		floor, err := n.logFloor(id, lg.ID)
+		if err != nil {
+			return core.SyncProgress{}, err
+		}
+		if floor.ID.Defined() {
+			lp.Floor = floor.Counter
+		}
+	}
+	// hinted logs and logs learned from peers may not be fetched yet
+	for lid, h := range hints {
+		if lp := height(lid); h.Counter > lp.Height {
+			lp.Height = h.Counter
+		}
+	}
+	for lid, h := range heights {
+		if lp := height(lid); h > lp.Height {
+			lp.Height = h
+		}
+	}
+
+	p := core.SyncProgress{ThreadID: id, Bytes: bytes}
+	for _, lp := range logs {
+		p.Records += lp.Applied
+		p.Expected += lp.Height
+		p.Logs = append(p.Logs, *lp)
+	}
+	sort.Slice(p.Logs, func(i, j int) bool {
+		return p.Logs[i].LogID < p.Logs[j].LogID
+	})
+	p.ETA = n.progress.estimate(id, p.Records, p.Expected)
+	return p, nil
+}
+
+// observeHeight accounts the height of a thread log learned from a peer.
+func (n *net) observeHeight(id thread.ID, lid peer.ID, height int64) {
+	if height > 0 {
+		n.progress.observeHeight(id, lid, height)
+	}
+}
+
+// syncProgressTracker keeps the log heights learned from peers and the size of the records
+// received, along with the start of the current sync of each thread to estimate its rate.
+type syncProgressTracker struct {
+	sync.Mutex
+	threads map[thread.ID]*threadProgress
+	clock   clock.Clock
+}
+
+type threadProgress struct {
+	heights map[peer.ID]int64
+	bytes   int64
+	// started is the time the progress was first requested during the current sync,
+	// when startRecords were applied. It's reset once the thread is synced.
+	started      time.Time
+	startRecords int64
+}
+
+func newSyncProgressTracker(clk clock.Clock) *syncProgressTracker {
+	return &syncProgressTracker{threads: make(map[thread.ID]*threadProgress), clock: clk}
+}
+
+func (t *syncProgressTracker) thread(id thread.ID) *threadProgress {
+	tp, ok := t.threads[id]
+	if !ok {
+		tp = &threadProgress{heights: make(map[peer.ID]int64)}
+		t.threads[id] = tp
+	}
+	return tp
+}
+
+func (t *syncProgressTracker) observeHeight(id thread.ID, lid peer.ID, height int64) {
+	t.Lock()
+	defer t.Unlock()
+	if tp := t.thread(id); height > tp.heights[lid] {
+		tp.heights[lid] = height
+	}
+}
+
+func (t *syncProgressTracker) observeBytes(id thread.ID, size int64) {
+	t.Lock()
+	defer t.Unlock()
+	t.thread(id).bytes += size
+}
+
+// get returns a copy of the log heights learned from peers and the size of the received records.
+func (t *syncProgressTracker) get(id thread.ID) (map[peer.ID]int64, int64) {
+	t.Lock()
+	defer t.Unlock()
+	tp, ok := t.threads[id]
+	if !ok {
+		return nil, 0
+	}
+	heights := make(map[peer.ID]int64, len(tp.heights))
+	for lid, h := range tp.heights {
+		heights[lid] = h
+	}
+	return heights, tp.bytes
+}
+
+// estimate returns the time until the expected records are applied at the rate
+// records were applied at since the current sync started.
+func (t *syncProgressTracker) estimate(id thread.ID, records, expected int64) time.Duration {
+	t.Lock()
+	defer t.Unlock()
+	tp := t.thread(id)
+	if records >= expected {
+		tp.started = time.Time{}
+		return 0
+	}
+	if tp.started.IsZero() || records < tp.startRecords {
+		tp.started, tp.startRecords = t.clock.Now(), records
+		return 0
+	}
+	applied, elapsed := records-tp.startRecords, t.clock.Since(tp.started)
+	if applied <= 0 {
+		return 0
+	}
+	return time.Duration(float64(elapsed) / float64(applied) * float64(expected-records))
+}
+
+// forget drops the progress of a thread.
+func (t *syncProgressTracker) forget(id thread.ID) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.threads, id)
+}
@@ -0,0 +1,119 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/clock"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_SubscribeSyncProgress(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n1)
+	for i := 0; i < 5; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lid := info.Logs[0].ID
+
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	sub, err := n2.SubscribeSyncProgress(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var last core.SyncProgress
+	timeout := time.After(10 * time.Second)
+	for !last.Done() || last.Records == 0 {
+		select {
+		case p, ok := <-sub:
+			if !ok {
+				t.Fatal("sync progress subscription closed")
+			}
+			if p.ThreadID != info.ID {
+				t.Fatalf("unexpected thread %s", p.ThreadID)
+			}
+			last = p
+		case <-timeout:
+			t.Fatalf("thread not synced, last progress %v", last)
+		}
+	}
+	if last.Records != 5 || last.Expected != 5 || last.ETA != 0 {
+		t.Fatalf("expected all 5 records, got %v", last)
+	}
+	var found bool
+	for _, l := range last.Logs {
+		if l.LogID == lid {
+			found = l == core.LogSyncProgress{LogID: lid, Applied: 5, Height: 5}
+		}
+	}
+	if !found {
+		t.Fatalf("unexpected log progress %v", last.Logs)
+	}
+	if last.Bytes == 0 {
+		t.Fatal("expected received records to be accounted")
+	}
+
+	// heights learned from peers raise the expected records
+	n2.observeHeight(info.ID, lid, 8)
+	select {
+	case p := <-sub:
+		if p.Records != 5 || p.Expected != 8 || p.Done() {
+			t.Fatalf("expected 8 records, got %v", p)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("progress change not sent")
+	}
+
+	cancel()
+	for range sub {
+	}
+}
+
+func TestSyncProgressTracker_Estimate(t *testing.T) {
+	t.Parallel()
+	clk := clock.NewMock(time.Unix(0, 0))
+	tr := newSyncProgressTracker(clk)
+	id := thread.NewIDV1(thread.Raw, 32)
+	if eta := tr.estimate(id, 0, 10); eta != 0 {
+		t.Fatalf("expected unknown eta before any records are applied, got %v", eta)
+	}
+	clk.Add(time.Second)
+	if eta := tr.estimate(id, 0, 10); eta != 0 {
+		t.Fatalf("expected unknown eta without progress, got %v", eta)
+	}
+	if eta := tr.estimate(id, 5, 10); eta != time.Second {
+		t.Fatalf("expected eta of a second, got %v", eta)
+	}
+	if eta := tr.estimate(id, 10, 10); eta != 0 || !tr.threads[id].started.IsZero() {
+		t.Fatalf("expected the estimate to be reset once synced, got %v", eta)
+	}
+}
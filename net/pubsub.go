@@ -2,30 +2,60 @@ package net
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
 	pb "github.com/textileio/go-threads/net/pb"
 	grpcpeer "google.golang.org/grpc/peer"
 )
 
+// presenceTopicSuffix is appended to the thread ID to build the thread presence topic name.
+const presenceTopicSuffix = "/presence"
+
+// topicCapabilityLabel separates the topic capability from other keys derived from the service key.
+const topicCapabilityLabel = "threads/topic-capability"
+
+// RecentFetchTimeout is the duration to wait for a peer to return the recent records of a topic.
+var RecentFetchTimeout = time.Second * 10
+
 // Handler receives all pushed thread records.
 type Handler func(context.Context, *pb.PushRecordRequest)
 
+// PresenceHandler receives all valid presence messages published by other peers.
+type PresenceHandler func(*pb.Presence)
+
+// ServiceKeyResolver returns the service key of a thread, which is required to publish to its topics.
+type ServiceKeyResolver func(id thread.ID) (*sym.Key, error)
+
+// RecentFetcher requests the records recently multicast over a thread topic from a peer.
+type RecentFetcher func(ctx context.Context, pid peer.ID, id thread.ID) ([]*pb.PushRecordRequest, error)
+
 // PubSub manages thread pubsub topics.
 type PubSub struct {
 	sync.RWMutex
 
-	ctx     context.Context
-	host    peer.ID
-	ps      *pubsub.PubSub
-	handler Handler
-	m       map[thread.ID]*topic
+	ctx      context.Context
+	host     peer.ID
+	ps       *pubsub.PubSub
+	handler  Handler
+	presence PresenceHandler
+	keys     ServiceKeyResolver
+	m        map[thread.ID]*topic
+
+	// recent records cache, disabled if cacheSize is zero
+	cacheSize int
+	fetch     RecentFetcher
 }
 
 type topic struct {
@@ -33,17 +63,48 @@ type topic struct {
 	h *pubsub.TopicEventHandler
 	s *pubsub.Subscription
 
+	// presence topic and subscription
+	pt *pubsub.Topic
+	ps *pubsub.Subscription
+
+	// capability derived from the thread service key, which proves messages are
+	// published by a thread peer without revealing the service key
+	capability []byte
+
+	// recent records multicast over the topic, nil if caching is disabled
+	recent *recentRecords
+	// caughtUp is set once recent records were fetched from a peer
+	caughtUp int32
+
 	cancel context.CancelFunc
 }
 
 // NewPubSub returns a new thread topic manager.
-func NewPubSub(ctx context.Context, host peer.ID, ps *pubsub.PubSub, handler Handler) *PubSub {
+// Messages published to a thread topic carry a proof of the thread service key resolved
+// with keys, subscribers drop messages without a valid proof.
+// If cacheSize is positive, up to cacheSize records multicast over each topic are kept
+// for peers joining late, and recent records are fetched with fetch from the first peer
+// seen after joining a topic.
+func NewPubSub(
+	ctx context.Context,
+	host peer.ID,
+	ps *pubsub.PubSub,
+	handler Handler,
+	presence PresenceHandler,
+	keys ServiceKeyResolver,
+	cacheSize int,
+	fetch RecentFetcher,
+) *PubSub {
 	return &PubSub{
-		ctx:     ctx,
-		host:    host,
-		ps:      ps,
-		handler: handler,
-		m:       make(map[thread.ID]*topic),
+		ctx:       ctx,
+		host:      host,
+		ps:        ps,
+		handler:   handler,
+		presence:  presence,
+		keys:      keys,
+		m:         make(map[thread.ID]*topic),
+		cacheSize: cacheSize,
+		fetch:     fetch,
 	}
 }
 
@@ -58,6 +119,13 @@ func (s *PubSub) Add(id thread.ID) error {
 	if err := id.Validate(); err != nil {
 		return err
 	}
+	sk, err := s.keys(id)
+	if err != nil {
+		return err
+	} else if sk == nil {
+		return fmt.Errorf("cannot join topic of thread %s without service key", id)
+	}
+	capability := topicCapability(sk)
 	pt, err := s.ps.Join(id.String())
 	if err != nil {
 		return err
@@ -66,19 +134,37 @@ func (s *PubSub) Add(id thread.ID) error {
 	if err != nil {
 		return err
 	}
-	if err = s.ps.RegisterTopicValidator(id.String(), s.topicValidator); err != nil {
+	if err = s.ps.RegisterTopicValidator(id.String(), s.topicValidator(capability, nil)); err != nil {
+		return err
+	}
+	ppt, err := s.ps.Join(id.String() + presenceTopicSuffix)
+	if err != nil {
+		return err
+	}
+	if err = s.ps.RegisterTopicValidator(id.String()+presenceTopicSuffix, s.topicValidator(capability, s.presenceValidator)); err != nil {
+		return err
+	}
+	pps, err := ppt.Subscribe()
+	if err != nil {
 		return err
 	}
 
 	ctx, cancel := context.WithCancel(s.ctx)
 	topic := &topic{
-		t:      pt,
-		h:      h,
-		cancel: cancel,
+		t:          pt,
+		h:          h,
+		pt:         ppt,
+		ps:         pps,
+		capability: capability,
+		cancel:     cancel,
+	}
+	if s.cacheSize > 0 {
+		topic.recent = newRecentRecords(s.cacheSize)
 	}
 	s.m[id] = topic
 	go s.watch(ctx, id, topic)
 	go s.subscribe(ctx, id, topic)
+	go s.subscribePresence(ctx, id, topic)
 	return nil
 }
 
@@ -90,8 +176,12 @@ func (s *PubSub) Remove(id thread.ID) error {
 	if !ok {
 		return nil
 	}
-	topic.s.Cancel()
+	// the subscription is set by the subscribe routine, which may not have run yet
+	if topic.s != nil {
+		topic.s.Cancel()
+	}
 	topic.h.Cancel()
+	topic.ps.Cancel()
 	if err := id.Validate(); err != nil {
 		return err
 	}
@@ -101,14 +191,75 @@ func (s *PubSub) Remove(id thread.ID) error {
 	if err := topic.t.Close(); err != nil {
 		return err
 	}
+	if err := s.ps.UnregisterTopicValidator(id.String() + presenceTopicSuffix); err != nil {
+		return err
+	}
+	if err := topic.pt.Close(); err != nil {
+		return err
+	}
 	topic.cancel()
 	delete(s.m, id)
 	return nil
 }
 
-func (s *PubSub) topicValidator(context.Context, peer.ID, *pubsub.Message) bool {
-	// @todo: determine if this is needed (related to host signatures)
-	return true
+// topicValidator drops messages without a valid proof of the thread service key.
+// The data of valid messages is passed on to the subscription as the message ValidatorData,
+// after it's checked with validate, if any.
+func (s *PubSub) topicValidator(capability []byte, validate func([]byte) bool) pubsub.Validator {
+	return func(_ context.Context, _ peer.ID, m *pubsub.Message) bool {
+		tm := new(pb.TopicMessage)
+		if err := proto.Unmarshal(m.Data, tm); err != nil {
+			return false
+		}
+		from, err := peer.IDFromBytes(m.From)
+		if err != nil {
+			return false
+		}
+		if !hmac.Equal(tm.Proof, topicProof(capability, m.GetTopic(), from, tm.Data)) {
+			log.Debugf("dropping message from %s to %s without valid proof", from, m.GetTopic())
+			return false
+		}
+		if validate != nil && !validate(tm.Data) {
+			return false
+		}
+		m.ValidatorData = tm.Data
+		return true
+	}
+}
+
+// publish data to a topic along with the proof of the thread service key.
+func (s *PubSub) publish(ctx context.Context, t *pubsub.Topic, capability, data []byte) error {
+	tm := &pb.TopicMessage{
+		Data:  data,
+		Proof: topicProof(capability, t.String(), s.host, data),
+	}
+	msg, err := tm.Marshal()
+	if err != nil {
+		return err
+	}
+	return t.Publish(ctx, msg)
+}
+
+// topicCapability derives the key proving knowledge of the thread service key on its topics.
+func topicCapability(sk *sym.Key) []byte {
+	mac := hmac.New(sha256.New, sk.Bytes())
+	mac.Write([]byte(topicCapabilityLabel))
+	return mac.Sum(nil)
+}
+
+// topicProof binds the capability to a message, so it can't be replayed on another topic or by another peer.
+func topicProof(capability []byte, topic string, from peer.ID, data []byte) []byte {
+	mac := hmac.New(sha256.New, capability)
+	mac.Write([]byte(topic))
+	mac.Write([]byte(from))
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// messageData returns the data of a message accepted by topicValidator.
+func messageData(m *pubsub.Message) []byte {
+	data, _ := m.ValidatorData.([]byte)
+	return data
 }
 
 // Publish a record request to a thread.
@@ -124,7 +275,54 @@ func (s *PubSub) Publish(ctx context.Context, id thread.ID, req *pb.PushRecordRe
 	if err != nil {
 		return err
 	}
-	return topic.t.Publish(ctx, data)
+	if err = s.publish(ctx, topic.t, topic.capability, data); err != nil {
+		return err
+	}
+	if topic.recent != nil {
+		topic.recent.add(req)
+	}
+	return nil
+}
+
+// Recent returns the records recently multicast over a thread topic, oldest first.
+// Nothing is returned if caching is disabled or the topic is unknown.
+func (s *PubSub) Recent(id thread.ID) []*pb.PushRecordRequest {
+	s.RLock()
+	defer s.RUnlock()
+	topic, ok := s.m[id]
+	if !ok || topic.recent == nil {
+		return nil
+	}
+	return topic.recent.list()
+}
+
+// PublishPresence publishes a presence message to a thread's presence topic.
+func (s *PubSub) PublishPresence(ctx context.Context, id thread.ID, p *pb.Presence) error {
+	s.RLock()
+	defer s.RUnlock()
+	topic, ok := s.m[id]
+	if !ok {
+		return errors.New("thread topic not found")
+	}
+
+	data, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+	return s.publish(ctx, topic.pt, topic.capability, data)
+}
+
+// presenceValidator drops presence messages which aren't signed by the publishing peer.
+func (s *PubSub) presenceValidator(data []byte) bool {
+	p := new(pb.Presence)
+	if err := proto.Unmarshal(data, p); err != nil {
+		return false
+	}
+	if err := verifyPresence(p); err != nil {
+		log.Debugf("invalid presence message: %v", err)
+		return false
+	}
+	return true
 }
 
 // watch peer events from a pubsub topic.
@@ -138,6 +336,9 @@ func (s *PubSub) watch(ctx context.Context, id thread.ID, topic *topic) {
 		switch pe.Type {
 		case pubsub.PeerJoin:
 			msg = "JOINED"
+			if topic.recent != nil && s.fetch != nil && atomic.LoadInt32(&topic.caughtUp) == 0 {
+				go s.catchUp(ctx, id, topic, pe.Peer)
+			}
 		case pubsub.PeerLeave:
 			msg = "LEFT"
 		}
@@ -145,6 +346,30 @@ func (s *PubSub) watch(ctx context.Context, id thread.ID, topic *topic) {
 	}
 }
 
+// catchUp feeds the records recently multicast over a topic before we joined it to the handler.
+// Records are fetched from a single peer, the next joined peer is tried if the fetch fails.
+func (s *PubSub) catchUp(ctx context.Context, id thread.ID, topic *topic, pid peer.ID) {
+	if !atomic.CompareAndSwapInt32(&topic.caughtUp, 0, 1) {
+		return
+	}
+	fctx, cancel := context.WithTimeout(ctx, RecentFetchTimeout)
+	defer cancel()
+	reqs, err := s.fetch(fctx, pid, id)
+	if err != nil {
+		log.Debugf("fetching recent records of %s from %s failed: %v", id, pid, err)
+		atomic.StoreInt32(&topic.caughtUp, 0)
+		return
+	}
+	log.Debugf("received %d recent records of %s from %s", len(reqs), id, pid)
+
+	ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
+		Addr: &addr{id: pid},
+	})
+	for _, req := range reqs {
+		s.handler(ctx, req)
+	}
+}
+
 // subscribe to a topic for thread updates.
 func (s *PubSub) subscribe(ctx context.Context, id thread.ID, topic *topic) {
 	var err error
@@ -169,6 +394,10 @@ func (s *PubSub) subscribe(ctx context.Context, id thread.ID, topic *topic) {
 			continue
 		}
 		log.Debugf("received multicast record from %s", from)
+		if topic.recent != nil && req.Body != nil && req.Body.Record != nil &&
+			req.Body.ThreadID != nil && req.Body.ThreadID.ID == id {
+			topic.recent.add(req)
+		}
 
 		ctx = grpcpeer.NewContext(ctx, &grpcpeer.Peer{
 			Addr: &addr{id: from},
@@ -177,6 +406,25 @@ func (s *PubSub) subscribe(ctx context.Context, id thread.ID, topic *topic) {
 	}
 }
 
+// subscribePresence handles presence messages published to a thread's presence topic.
+func (s *PubSub) subscribePresence(ctx context.Context, id thread.ID, topic *topic) {
+	for {
+		msg, err := topic.ps.Next(ctx)
+		if err != nil {
+			break
+		}
+		if msg.ReceivedFrom == s.host {
+			continue
+		}
+		p := new(pb.Presence)
+		if err := proto.Unmarshal(messageData(msg), p); err != nil {
+			log.Errorf("error handling presence message in %s: %s", id, err)
+			continue
+		}
+		s.presence(p)
+	}
+}
+
 func (s *PubSub) handleMsg(m *pubsub.Message) (from peer.ID, rec *pb.PushRecordRequest, err error) {
 	from, err = peer.IDFromBytes(m.From)
 	if err != nil {
@@ -187,7 +435,7 @@ func (s *PubSub) handleMsg(m *pubsub.Message) (from peer.ID, rec *pb.PushRecordR
 	}
 
 	req := new(pb.PushRecordRequest)
-	if err = proto.Unmarshal(m.Data, req); err != nil {
+	if err = proto.Unmarshal(messageData(m), req); err != nil {
 		return "", nil, err
 	}
 	return from, req, nil
@@ -202,3 +450,38 @@ func (a *addr) Network() string { return gostream.Network }
 // String returns the peer ID of this address in string form
 // (B58-encoded).
 func (a *addr) String() string { return a.id.Pretty() }
+
+// recentRecords is a bounded buffer of the last records multicast over a topic.
+type recentRecords struct {
+	sync.Mutex
+	reqs []*pb.PushRecordRequest
+	next int
+	full bool
+}
+
+func newRecentRecords(size int) *recentRecords {
+	return &recentRecords{reqs: make([]*pb.PushRecordRequest, size)}
+}
+
+// add a record, replacing the oldest one if the buffer is full.
+func (r *recentRecords) add(req *pb.PushRecordRequest) {
+	r.Lock()
+	defer r.Unlock()
+	r.reqs[r.next] = req
+	r.next = (r.next + 1) % len(r.reqs)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// list returns the buffered records, oldest first.
+func (r *recentRecords) list() []*pb.PushRecordRequest {
+	r.Lock()
+	defer r.Unlock()
+	if !r.full {
+		return append([]*pb.PushRecordRequest(nil), r.reqs[:r.next]...)
+	}
+	reqs := make([]*pb.PushRecordRequest, 0, len(r.reqs))
+	reqs = append(reqs, r.reqs[r.next:]...)
+	return append(reqs, r.reqs[:r.next]...)
+}
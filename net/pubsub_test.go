@@ -0,0 +1,70 @@
+package net
+
+import (
+	"context"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+func TestPubSub_TopicProof(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	n.server.ps.RLock()
+	capability := n.server.ps.m[info.ID].capability
+	n.server.ps.RUnlock()
+	validate := n.server.ps.topicValidator(capability, nil)
+
+	name := info.ID.String()
+	from := n.Host().ID()
+	message := func(tm *pb.TopicMessage) *pubsub.Message {
+		data, err := tm.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pubsub.Message{Message: &pubsubpb.Message{
+			From:  []byte(from),
+			Data:  data,
+			Topic: &name,
+		}}
+	}
+	data := []byte("record")
+
+	valid := message(&pb.TopicMessage{Data: data, Proof: topicProof(capability, name, from, data)})
+	if !validate(ctx, from, valid) {
+		t.Fatal("expected message with valid proof to be accepted")
+	}
+	if string(messageData(valid)) != string(data) {
+		t.Fatalf("expected message data to be passed on, got %s", messageData(valid))
+	}
+
+	if validate(ctx, from, message(&pb.TopicMessage{Data: data})) {
+		t.Fatal("expected message without proof to be dropped")
+	}
+	other, err := sym.NewRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := topicProof(topicCapability(other), name, from, data)
+	if validate(ctx, from, message(&pb.TopicMessage{Data: data, Proof: forged})) {
+		t.Fatal("expected message proved with another key to be dropped")
+	}
+	replayed := topicProof(capability, name+presenceTopicSuffix, from, data)
+	if validate(ctx, from, message(&pb.TopicMessage{Data: data, Proof: replayed})) {
+		t.Fatal("expected message proved for another topic to be dropped")
+	}
+	tampered := message(&pb.TopicMessage{Data: []byte("tampered"), Proof: topicProof(capability, name, from, data)})
+	if validate(ctx, from, tampered) {
+		t.Fatal("expected tampered message to be dropped")
+	}
+	if validate(ctx, from, &pubsub.Message{Message: &pubsubpb.Message{From: []byte(from), Data: data, Topic: &name}}) {
+		t.Fatal("expected plain message to be dropped")
+	}
+}
@@ -0,0 +1,296 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crypto"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	pb "github.com/textileio/go-threads/net/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (n *net) QueryRecords(
+	ctx context.Context,
+	id thread.ID,
+	pid peer.ID,
+	sel core.Selector,
+	opts ...core.ThreadOption,
+) ([]core.QueryResult, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return nil, err
+	} else if sk == nil {
+		return nil, errors.New("a service-key is required to query records")
+	}
+	psel, err := selectorToProto(sel)
+	if err != nil {
+		return nil, err
+	}
+	if err = n.server.requireCapability(ctx, pid, CapRecordQueries); err != nil {
+		return nil, err
+	}
+
+	client, err := n.server.dial(pid)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s failed: %w", pid, err)
+	}
+	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+	defer cancel()
+	reply, err := client.QueryRecords(cctx, &pb.QueryRecordsRequest{
+		Body: &pb.QueryRecordsRequest_Body{
+			ThreadID:   &pb.ProtoThreadID{ID: id},
+			ServiceKey: &pb.ProtoKey{Key: sk},
+			Selector:   psel,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// matches are checked if the host can decrypt the selector target
+	key, err := n.selectorKey(id, sel.Target)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]core.QueryResult, 0, len(reply.Matches))
+	for _, m := range reply.Matches {
+		res, err := n.queryResultFromProto(ctx, id, m, sk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match from %s: %w", pid, err)
+		}
+		if key != nil {
+			if ok, err := cbor.MatchSelector(ctx, n, res.Record, sel, key); err != nil {
+				return nil, fmt.Errorf("checking match %s from %s: %w", res.Record.Cid(), pid, err)
+			} else if !ok {
+				return nil, fmt.Errorf("record %s from %s doesn't match the selector", res.Record.Cid(), pid)
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// QueryRecords receives a query records request. Logs are walked back from their heads,
+// at most MaxPullLimit matching records are returned.
+func (s *server) QueryRecords(ctx context.Context, req *pb.QueryRecordsRequest) (*pb.QueryRecordsReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received query records request from %s", pid)
+
+	if req.Body == nil || req.Body.ThreadID == nil || req.Body.Selector == nil {
+		return nil, status.Error(codes.InvalidArgument, "a thread and a selector are required")
+	}
+	tid := req.Body.ThreadID.ID
+	if err := s.checkServiceKey(tid, req.Body.ServiceKey); err != nil {
+		return nil, err
+	}
+	sel, err := selectorFromProto(req.Body.Selector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	key, err := s.net.selectorKey(tid, sel.Target)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if key == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the selector target can't be decrypted by the host")
+	}
+	matches, err := s.net.queryRecords(ctx, tid, sel, key)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.QueryRecordsReply{Matches: matches}, nil
+}
+
+// queryRecords returns the local records of a thread matching the selector along with their
+// ancestry proofs. Logs are walked back from their heads until a record is missing locally.
+func (n *net) queryRecords(
+	ctx context.Context,
+	tid thread.ID,
+	sel core.Selector,
+	key crypto.DecryptionKey,
+) ([]*pb.QueryRecordsReply_Match, error) {
+	info, err := n.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	sk := info.Key.Service()
+	var (
+		matches []*pb.QueryRecordsReply_Match
+		total   int
+	)
+	for _, lg := range info.Logs {
+		// proofs require record positions
+		if lg.Head.Counter == thread.CounterUndef {
+			continue
+		}
+		pos := lg.Head.Counter
+		for cursor := lg.Head.ID; cursor.Defined() && pos > 0; pos-- {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if known, err := n.isKnown(cursor); err != nil {
+				return nil, err
+			} else if !known {
+				break
+			}
+			rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+			if err != nil {
+				return nil, err
+			}
+			cursor = rec.PrevID()
+			if ok, err := cbor.MatchSelector(ctx, n, rec, sel, key); err != nil {
+				// e.g. a redacted body
+				log.Debugf("evaluating selector over record %s failed: %v", rec.Cid(), err)
+				continue
+			} else if !ok {
+				continue
+			}
+			proof, err := n.ancestryProof(ctx, lg.Head, pos, sk)
+			if err != nil {
+				return nil, err
+			}
+			pbrec, err := n.recordToProto(ctx, tid, rec)
+			if err != nil {
+				return nil, err
+			}
+			m := &pb.QueryRecordsReply_Match{
+				LogID:  &pb.ProtoPeerID{ID: lg.ID},
+				Record: pbrec,
+				Proof:  proofToProto(proof),
+			}
+			if total += m.Size(); total > MaxRecordsReplySize {
+				return matches, nil
+			}
+			if matches = append(matches, m); len(matches) >= MaxPullLimit {
+				return matches, nil
+			}
+		}
+	}
+	return matches, nil
+}
+
+// selectorKey returns the key decrypting the selector target of thread records,
+// or nil if the host doesn't hold it.
+func (n *net) selectorKey(tid thread.ID, target core.SelectorTarget) (crypto.DecryptionKey, error) {
+	var (
+		key *sym.Key
+		err error
+	)
+	if target == core.SelectHeader {
+		key, err = n.headerKey(tid)
+	} else {
+		key, err = n.store.ReadKey(tid)
+	}
+	if err != nil || key == nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// queryResultFromProto decodes a match and verifies the record and its proof against the log key.
+func (n *net) queryResultFromProto(
+	ctx context.Context,
+	tid thread.ID,
+	m *pb.QueryRecordsReply_Match,
+	sk *sym.Key,
+) (core.QueryResult, error) {
+	if m.LogID == nil || m.Record == nil || m.Proof == nil || m.Proof.Head == nil {
+		return core.QueryResult{}, errors.New("incomplete match")
+	}
+	pk, err := n.store.PubKey(tid, m.LogID.ID)
+	if err != nil {
+		return core.QueryResult{}, err
+	} else if pk == nil {
+		return core.QueryResult{}, fmt.Errorf("log %s is unknown", m.LogID.ID)
+	}
+	rec, err := cbor.RecordFromProto(m.Record, sk)
+	if err != nil {
+		return core.QueryResult{}, err
+	}
+	if _, err = rec.GetBlock(ctx, n); err != nil {
+		return core.QueryResult{}, err
+	}
+	if err = rec.Verify(pk); err != nil {
+		return core.QueryResult{}, err
+	}
+	proof, err := proofFromProto(m.Proof, sk)
+	if err != nil {
+		return core.QueryResult{}, err
+	}
+	if err = cbor.VerifyAncestryProof(proof, rec.Cid(), pk); err != nil {
+		return core.QueryResult{}, err
+	}
+	return core.QueryResult{LogID: m.LogID.ID, Record: rec, Proof: proof}, nil
+}
+
+func proofFromProto(pp *pb.QueryRecordsReply_Proof, sk *sym.Key) (core.AncestryProof, error) {
+	if pp == nil || pp.Head == nil {
+		return core.AncestryProof{}, errors.New("incomplete proof")
+	}
+	proof := core.AncestryProof{
+		Head:     thread.Head{ID: pp.Head.Cid, Counter: pp.Counter},
+		Position: pp.Position,
+		Records:  make([]core.Record, len(pp.Records)),
+	}
+	for i, r := range pp.Records {
+		var err error
+		if proof.Records[i], err = cbor.RecordFromProto(&pb.Log_Record{RecordNode: r}, sk); err != nil {
+			return core.AncestryProof{}, err
+		}
+	}
+	return proof, nil
+}
+
+func proofToProto(proof core.AncestryProof) *pb.QueryRecordsReply_Proof {
+	pp := &pb.QueryRecordsReply_Proof{
+		Head:     &pb.ProtoCid{Cid: proof.Head.ID},
+		Counter:  proof.Head.Counter,
+		Position: proof.Position,
+		Records:  make([][]byte, len(proof.Records)),
+	}
+	for i, r := range proof.Records {
+		pp.Records[i] = r.RawData()
+	}
+	return pp
+}
+
+func selectorToProto(sel core.Selector) (*pb.QueryRecordsRequest_Selector, error) {
+	value, err := cbornode.DumpObject(sel.Value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding selector value: %w", err)
+	}
+	return &pb.QueryRecordsRequest_Selector{
+		Target: int32(sel.Target),
+		Path:   sel.Path,
+		Value:  value,
+	}, nil
+}
+
+func selectorFromProto(psel *pb.QueryRecordsRequest_Selector) (core.Selector, error) {
+	target := core.SelectorTarget(psel.Target)
+	if target != core.SelectHeader && target != core.SelectBody {
+		return core.Selector{}, fmt.Errorf("unknown selector target %d", psel.Target)
+	}
+	var value interface{}
+	if err := cbornode.DecodeInto(psel.Value, &value); err != nil {
+		return core.Selector{}, fmt.Errorf("decoding selector value: %w", err)
+	}
+	return core.Selector{Target: target, Path: psel.Path, Value: value}, nil
+}
@@ -0,0 +1,75 @@
+package net
+
+import (
+	"context"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_QueryRecords(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i, kind := range []string{"note", "task", "note"} {
+		body, err := cbornode.WrapObject(map[string]interface{}{"kind": kind, "n": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr, err := n1.CreateRecord(ctx, info.ID, body, core.WithRecordType("app/"+kind))
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, tr)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	query := func(sel core.Selector, expected ...core.ThreadRecord) {
+		t.Helper()
+		res, err := n2.QueryRecords(ctx, info.ID, n1.Host().ID(), sel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != len(expected) {
+			t.Fatalf("expected %d matches, got %d", len(expected), len(res))
+		}
+		// logs are walked back from their heads
+		for i, r := range res {
+			tr := expected[len(expected)-1-i]
+			if !r.Record.Cid().Equals(tr.Value().Cid()) || r.LogID != tr.LogID() {
+				t.Fatalf("unexpected match %s", r.Record.Cid())
+			}
+			pk, err := n2.store.PubKey(info.ID, r.LogID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = cbor.VerifyAncestryProof(r.Proof, r.Record.Cid(), pk); err != nil {
+				t.Fatalf("expected valid proof: %v", err)
+			}
+		}
+	}
+	query(core.Selector{Target: core.SelectBody, Path: "kind", Value: "note"}, recs[0], recs[2])
+	query(core.Selector{Target: core.SelectBody, Path: "n", Value: 1}, recs[1])
+	query(core.Selector{Target: core.SelectBody, Path: "missing", Value: "note"})
+	query(core.Selector{Target: core.SelectHeader, Path: "type", Value: "app/task"}, recs[1])
+}
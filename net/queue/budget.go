@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/clock"
+)
+
+const (
+	// Record size assumed until records are observed.
+	defaultRecordSize = 1 << 10
+
+	// A single reservation takes at most a share of the budget,
+	// so a few pulls could proceed concurrently.
+	budgetShares = 4
+
+	// Weight of the latest observation in the average record size.
+	recordSizeWeight = 0.2
+
+	budgetBackoffMin = 50 * time.Millisecond
+	budgetBackoffMax = 2 * time.Second
+)
+
+// Budget bounds the total memory taken by records being pulled at once.
+// Pulls reserve memory for the records they request before sending requests,
+// with the record limits derived from the average size of records observed
+// in earlier pulls.
+type Budget struct {
+	total    int64
+	reserved int64
+	avgSize  float64
+	clock    clock.Clock
+	mx       sync.Mutex
+}
+
+// NewBudget creates a pull memory budget of total bytes. Budget is
+// disabled if total isn't positive: reservations never wait then.
+// Reservations back off in time of the given clock.
+func NewBudget(clk clock.Clock, total int64) *Budget {
+	return &Budget{total: total, avgSize: defaultRecordSize, clock: clk}
+}
+
+// Reserve memory for pulling records of the given number of logs. While
+// the budget is exhausted, reservation is retried with exponential backoff
+// until the context is done. Returned reservation holds the per-log record
+// limit of the pull, which is never larger than maxLimit, and must be
+// released once pulled records are processed.
+func (b *Budget) Reserve(ctx context.Context, logs, maxLimit int) (*Reservation, error) {
+	if logs < 1 {
+		logs = 1
+	}
+	backoff := budgetBackoffMin
+	for {
+		if r := b.tryReserve(logs, maxLimit); r != nil {
+			return r, nil
+		}
+		log.Debugf("pull budget exhausted, retrying in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-b.clock.After(backoff):
+		}
+		if backoff *= 2; backoff > budgetBackoffMax {
+			backoff = budgetBackoffMax
+		}
+	}
+}
+
+func (b *Budget) tryReserve(logs, maxLimit int) *Reservation {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	if b.total <= 0 {
+		return &Reservation{budget: b, limit: maxLimit}
+	}
+
+	var (
+		perRecord = b.avgSize * float64(logs)
+		available = b.total - b.reserved
+	)
+	if share := b.total / budgetShares; available > share {
+		available = share
+	}
+	limit := int(float64(available) / perRecord)
+	if limit < 1 {
+		if b.reserved > 0 {
+			return nil
+		}
+		// nothing else is being pulled, so allow a record per log
+		// even if it doesn't fit into the budget
+		limit = 1
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	size := int64(float64(limit) * perRecord)
+	b.reserved += size
+	return &Reservation{budget: b, limit: limit, size: size}
+}
+
+// Observe updates the average record size with records received by a pull.
+func (b *Budget) Observe(records int, bytes int64) {
+	if records <= 0 {
+		return
+	}
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	b.avgSize += recordSizeWeight * (float64(bytes)/float64(records) - b.avgSize)
+}
+
+// Reserved returns the number of bytes currently reserved by pulls.
+func (b *Budget) Reserved() int64 {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return b.reserved
+}
+
+// Reservation of the pull memory budget.
+type Reservation struct {
+	budget *Budget
+	limit  int
+	size   int64
+	once   sync.Once
+}
+
+// Limit returns the maximum number of records to be pulled from each log.
+func (r *Reservation) Limit() int {
+	return r.limit
+}
+
+// Release reserved memory back to the budget. It's safe to call more than once.
+func (r *Reservation) Release() {
+	r.once.Do(func() {
+		r.budget.mx.Lock()
+		r.budget.reserved -= r.size
+		r.budget.mx.Unlock()
+	})
+}
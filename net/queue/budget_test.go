@@ -0,0 +1,104 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/textileio/go-threads/clock"
+)
+
+func TestBudget(t *testing.T) {
+	var (
+		// fits four pulls of 100 default-sized records per log
+		b   = NewBudget(clock.Real, 4*100*defaultRecordSize)
+		ctx = context.Background()
+	)
+
+	r1, err := b.Reserve(ctx, 1, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r1.Limit() != 100 {
+		t.Errorf("expected limit of a budget share, got: %d", r1.Limit())
+	}
+
+	// record limit is split among logs
+	r2, err := b.Reserve(ctx, 2, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r2.Limit() != 50 {
+		t.Errorf("expected limit split among logs, got: %d", r2.Limit())
+	}
+
+	// limit is capped
+	r3, err := b.Reserve(ctx, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r3.Limit() != 10 {
+		t.Errorf("expected capped limit, got: %d", r3.Limit())
+	}
+	r3.Release()
+	r3.Release()
+	if reserved := b.Reserved(); reserved != 2*100*defaultRecordSize {
+		t.Errorf("bad reserved size after release: %d", reserved)
+	}
+
+	// larger records observed, so less of them fit
+	b.Observe(10, 10*10*defaultRecordSize)
+	r4, err := b.Reserve(ctx, 1, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r4.Limit() >= 100 {
+		t.Errorf("expected limit to shrink with larger records, got: %d", r4.Limit())
+	}
+	r4.Release()
+	r2.Release()
+
+	// exhausted budget delays reservations
+	b.Observe(1, 1000*100*defaultRecordSize)
+	rctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := b.Reserve(rctx, 1, 1000); err != context.DeadlineExceeded {
+		t.Errorf("expected reservation to wait for the budget, got: %v", err)
+	}
+
+	// a record per log is allowed once nothing else is pulled
+	done := make(chan *Reservation)
+	go func() {
+		r, err := b.Reserve(ctx, 1, 1000)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- r
+	}()
+	r1.Release()
+	select {
+	case r := <-done:
+		if r.Limit() != 1 {
+			t.Errorf("expected a single record limit, got: %d", r.Limit())
+		}
+		r.Release()
+	case <-time.After(5 * time.Second):
+		t.Fatal("reservation wasn't granted after the budget was released")
+	}
+	if reserved := b.Reserved(); reserved != 0 {
+		t.Errorf("expected empty budget, got: %d", reserved)
+	}
+}
+
+func TestBudget_Disabled(t *testing.T) {
+	b := NewBudget(clock.Real, 0)
+	b.Observe(1, 1<<30)
+	r, err := b.Reserve(context.Background(), 100, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Limit() != 1000 {
+		t.Errorf("expected max limit with disabled budget, got: %d", r.Limit())
+	}
+	r.Release()
+}
@@ -2,13 +2,14 @@ package queue
 
 import (
 	"context"
+	"time"
 
-	logging "github.com/ipfs/go-log"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/logger"
 )
 
-var log = logging.Logger("netqueue")
+var log = logger.Logger("netqueue")
 
 type (
 	PeerCall func(context.Context, peer.ID, thread.ID) error
@@ -19,6 +20,21 @@ type (
 
 		// Schedule call to be invoked later.
 		Schedule(p peer.ID, t thread.ID, priority int, c PeerCall) bool
+
+		// Stats returns current queue metrics.
+		Stats() Stats
+
+		// ThreadCalls returns the calls of a thread which are waiting or in-flight.
+		ThreadCalls(t thread.ID) []ThreadCall
+	}
+
+	// ThreadCall is a call of a thread to a peer, either waiting in the queue or in-flight.
+	ThreadCall struct {
+		Peer     peer.ID
+		Priority int
+		// Scheduled is the time a waiting call was added to the queue at, zero for in-flight calls.
+		Scheduled time.Time
+		InFlight  bool
 	}
 )
 
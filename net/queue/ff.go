@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crash"
 )
 
 type linkedOperation struct {
@@ -23,12 +25,13 @@ type linkedOperation struct {
 type peerQueue struct {
 	index       map[thread.ID]*linkedOperation
 	first, last *linkedOperation
+	clock       clock.Clock
 	sync.Mutex
 }
 
 // Simple FIFO-queue with O(1)-operations.
-func newPeerQueue() *peerQueue {
-	return &peerQueue{index: make(map[thread.ID]*linkedOperation)}
+func newPeerQueue(clk clock.Clock) *peerQueue {
+	return &peerQueue{index: make(map[thread.ID]*linkedOperation), clock: clk}
 }
 
 // Add new call to the queue or replace existing one with lower priority.
@@ -40,7 +43,7 @@ func (q *peerQueue) Add(tid thread.ID, call PeerCall, priority int) bool {
 			tid:      tid,
 			call:     call,
 			priority: priority,
-			created:  time.Now().Unix(),
+			created:  q.clock.Now().UnixNano(),
 		}
 		if q.last == nil {
 			// empty queue
@@ -119,11 +122,18 @@ func (q *peerQueue) Size() int {
 
 var _ CallQueue = (*ffQueue)(nil)
 
+type inflightCall struct {
+	pid peer.ID
+	tid thread.ID
+}
+
 type ffQueue struct {
 	peers    map[peer.ID]*peerQueue
-	inflight map[uint64]struct{}
+	inflight map[uint64]inflightCall
 	poll     time.Duration
 	deadline time.Duration
+	latency  *Histogram
+	clock    clock.Clock
 	ctx      context.Context
 	mx       sync.Mutex
 }
@@ -133,8 +143,10 @@ type ffQueue struct {
 // spawned until its deadline. At every moment only one call for the peer/thread
 // pair exists in the queue. Scheduled operations could be replaced with a new ones
 // based on the priority value (new higher-priority call replaces waiting one).
+// Polling and deadlines follow the given clock.
 func NewFFQueue(
 	ctx context.Context,
+	clk clock.Clock,
 	pollInterval time.Duration,
 	spawnDeadline time.Duration,
 ) *ffQueue {
@@ -142,7 +154,9 @@ func NewFFQueue(
 		ctx:      ctx,
 		poll:     pollInterval,
 		deadline: spawnDeadline,
-		inflight: make(map[uint64]struct{}),
+		latency:  NewHistogram(),
+		clock:    clk,
+		inflight: make(map[uint64]inflightCall),
 		peers:    make(map[peer.ID]*peerQueue),
 	}
 }
@@ -162,7 +176,7 @@ func (q *ffQueue) Schedule(
 	}
 	pq, exist := q.peers[pid]
 	if !exist {
-		pq = newPeerQueue()
+		pq = newPeerQueue(q.clock)
 		q.peers[pid] = pq
 		go q.pollQueue(pid, pq)
 	}
@@ -181,7 +195,7 @@ func (q *ffQueue) Call(
 	h := hash(pid, tid)
 	q.mx.Lock()
 	pq, exist := q.peers[pid]
-	q.inflight[h] = struct{}{}
+	q.inflight[h] = inflightCall{pid: pid, tid: tid}
 	q.mx.Unlock()
 
 	if exist {
@@ -193,7 +207,7 @@ func (q *ffQueue) Call(
 		}
 	}
 
-	err := call(q.ctx, pid, tid)
+	err := crash.Call("queue call", func() error { return call(q.ctx, pid, tid) })
 	q.mx.Lock()
 	delete(q.inflight, h)
 	q.mx.Unlock()
@@ -201,7 +215,7 @@ func (q *ffQueue) Call(
 }
 
 func (q *ffQueue) pollQueue(pid peer.ID, pq *peerQueue) {
-	var tick = time.NewTicker(q.poll)
+	var tick = q.clock.NewTicker(q.poll)
 
 	for {
 		select {
@@ -212,23 +226,24 @@ func (q *ffQueue) pollQueue(pid peer.ID, pq *peerQueue) {
 		case <-tick.C:
 			pq.Lock()
 			// every call scheduled before this moment is overdue now and should be spawned immediately
-			var deadlineBound = time.Now().Add(-q.deadline).Unix()
+			var deadlineBound = q.clock.Now().Add(-q.deadline).UnixNano()
 			for waiting := pq.Size(); waiting > 0; waiting-- {
 				call, tid, created, ok := pq.Pop()
 				if !ok {
 					break
 				}
 
+				q.latency.Observe(time.Duration(q.clock.Now().UnixNano() - created))
 				go func() {
 					var h = hash(pid, tid)
 
 					// set in-flight status
 					q.mx.Lock()
-					q.inflight[h] = struct{}{}
+					q.inflight[h] = inflightCall{pid: pid, tid: tid}
 					q.mx.Unlock()
 
-					// make a call
-					if err := call(q.ctx, pid, tid); err != nil {
+					// make a call, a panic fails it without stopping the node
+					if err := crash.Call("queue call", func() error { return call(q.ctx, pid, tid) }); err != nil {
 						log.Errorf("call to [%s/%s] failed: %v", pid, tid, err)
 					}
 
@@ -260,7 +275,7 @@ func (q *ffQueue) pollQueue(pid peer.ID, pq *peerQueue) {
 				// meeting deadlines in general, nevertheless it's far from perfect. So if you are
 				// aware of any better approach - please, contribute it!
 
-				if remainIters := int(float64(created-deadlineBound) / q.poll.Seconds()); remainIters > 0 &&
+				if remainIters := int((created - deadlineBound) / int64(q.poll)); remainIters > 0 &&
 					rand.Float64() > math.Sqrt(3*float64(waiting))/float64(remainIters) {
 					break
 				}
@@ -270,6 +285,55 @@ func (q *ffQueue) pollQueue(pid peer.ID, pq *peerQueue) {
 	}
 }
 
+// Stats returns the number of waiting calls and their enqueue-to-execution latency.
+func (q *ffQueue) Stats() Stats {
+	q.mx.Lock()
+	var pqs = make([]*peerQueue, 0, len(q.peers))
+	for _, pq := range q.peers {
+		pqs = append(pqs, pq)
+	}
+	q.mx.Unlock()
+
+	var waiting int
+	for _, pq := range pqs {
+		pq.Lock()
+		waiting += pq.Size()
+		pq.Unlock()
+	}
+	return Stats{Waiting: waiting, Latency: q.latency.Snapshot()}
+}
+
+// ThreadCalls returns the waiting and in-flight calls of a thread.
+func (q *ffQueue) ThreadCalls(tid thread.ID) []ThreadCall {
+	q.mx.Lock()
+	var (
+		calls []ThreadCall
+		pqs   = make(map[peer.ID]*peerQueue, len(q.peers))
+	)
+	for _, c := range q.inflight {
+		if c.tid == tid {
+			calls = append(calls, ThreadCall{Peer: c.pid, InFlight: true})
+		}
+	}
+	for pid, pq := range q.peers {
+		pqs[pid] = pq
+	}
+	q.mx.Unlock()
+
+	for pid, pq := range pqs {
+		pq.Lock()
+		if op, ok := pq.index[tid]; ok {
+			calls = append(calls, ThreadCall{
+				Peer:      pid,
+				Priority:  op.priority,
+				Scheduled: time.Unix(0, op.created),
+			})
+		}
+		pq.Unlock()
+	}
+	return calls
+}
+
 func hash(pid peer.ID, tid thread.ID) uint64 {
 	var hasher = fnv.New64a()
 	_, _ = hasher.Write([]byte(pid))
@@ -2,15 +2,19 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crash"
 )
 
 func TestOperationQueue(t *testing.T) {
 	var (
-		q  = newPeerQueue()
+		q  = newPeerQueue(clock.Real)
 		t1 = thread.NewIDV1(thread.Raw, 32)
 		t2 = thread.NewIDV1(thread.Raw, 32)
 		t3 = thread.NewIDV1(thread.Raw, 32)
@@ -129,7 +133,7 @@ func TestOperationQueue(t *testing.T) {
 
 func TestOperationQueue_Pop(t *testing.T) {
 	var (
-		q  = newPeerQueue()
+		q  = newPeerQueue(clock.Real)
 		t1 = thread.NewIDV1(thread.Raw, 32)
 		t2 = thread.NewIDV1(thread.Raw, 32)
 
@@ -167,3 +171,100 @@ func TestOperationQueue_Pop(t *testing.T) {
 	checkedPop(false, thread.Undef)
 	checkedPop(false, thread.Undef)
 }
+
+func TestFFQueue_ThreadCalls(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		q           = NewFFQueue(ctx, clock.Real, time.Hour, time.Hour)
+		p1, p2      = peer.ID("p1"), peer.ID("p2")
+		t1, t2      = thread.NewIDV1(thread.Raw, 32), thread.NewIDV1(thread.Raw, 32)
+		release     = make(chan struct{})
+		started     = make(chan struct{})
+		noop        = func(context.Context, peer.ID, thread.ID) error { return nil }
+	)
+	defer cancel()
+
+	q.Schedule(p1, t1, 1, noop)
+	q.Schedule(p1, t2, 1, noop)
+	go func() {
+		_ = q.Call(p2, t1, func(context.Context, peer.ID, thread.ID) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	calls := q.ThreadCalls(t1)
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %v", calls)
+	}
+	for _, c := range calls {
+		switch c.Peer {
+		case p1:
+			if c.InFlight || c.Priority != 1 || c.Scheduled.IsZero() {
+				t.Errorf("unexpected waiting call %v", c)
+			}
+		case p2:
+			if !c.InFlight {
+				t.Errorf("expected call to be in-flight %v", c)
+			}
+		default:
+			t.Errorf("unexpected call %v", c)
+		}
+	}
+	close(release)
+}
+
+func TestFFQueue_CallPanic(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		q           = NewFFQueue(ctx, clock.Real, time.Hour, time.Hour)
+		pid         = peer.ID("p1")
+		tid         = thread.NewIDV1(thread.Raw, 32)
+	)
+	defer cancel()
+
+	err := q.Call(pid, tid, func(context.Context, peer.ID, thread.ID) error {
+		panic("boom")
+	})
+	var perr *crash.PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected panic to be converted into an error, got %v", err)
+	}
+	if calls := q.ThreadCalls(tid); len(calls) != 0 {
+		t.Fatalf("expected panicked call to be cleared, got %v", calls)
+	}
+}
+
+func TestFFQueue_VirtualTime(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		clk         = clock.NewMock(time.Unix(0, 0))
+		q           = NewFFQueue(ctx, clk, time.Minute, time.Minute)
+		called      = make(chan struct{})
+	)
+	defer cancel()
+
+	q.Schedule(peer.ID("p1"), thread.NewIDV1(thread.Raw, 32), 0, func(context.Context, peer.ID, thread.ID) error {
+		close(called)
+		return nil
+	})
+	// wait for the peer queue to start polling
+	clk.BlockUntil(1)
+	select {
+	case <-called:
+		t.Fatal("expected call to wait for the poll")
+	default:
+	}
+
+	clk.Add(time.Minute)
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("expected call to be spawned once the queue is polled")
+	}
+	if l := q.Stats().Latency; l.Count != 1 || l.Max != time.Minute {
+		t.Fatalf("expected latency in virtual time, got %+v", l)
+	}
+}
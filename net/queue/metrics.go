@@ -0,0 +1,181 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// LatencyBuckets are the upper bounds of histogram buckets, observations
+// exceeding the last bound are counted in an overflow bucket.
+var LatencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+}
+
+// Histogram accumulates durations into LatencyBuckets. It's safe for concurrent use.
+type Histogram struct {
+	counts []uint64
+	sum    int64
+	max    int64
+}
+
+func NewHistogram() *Histogram {
+	return &Histogram{counts: make([]uint64, len(LatencyBuckets)+1)}
+}
+
+// Observe adds a duration to the histogram, negative durations are counted as zero.
+func (h *Histogram) Observe(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	i := 0
+	for i < len(LatencyBuckets) && d > LatencyBuckets[i] {
+		i++
+	}
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddInt64(&h.sum, int64(d))
+	for {
+		max := atomic.LoadInt64(&h.max)
+		if int64(d) <= max || atomic.CompareAndSwapInt64(&h.max, max, int64(d)) {
+			return
+		}
+	}
+}
+
+// Snapshot returns the current state of the histogram.
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	s := HistogramSnapshot{
+		Buckets: make([]Bucket, len(h.counts)),
+		Sum:     time.Duration(atomic.LoadInt64(&h.sum)),
+		Max:     time.Duration(atomic.LoadInt64(&h.max)),
+	}
+	for i := range h.counts {
+		s.Buckets[i].Count = atomic.LoadUint64(&h.counts[i])
+		if i < len(LatencyBuckets) {
+			s.Buckets[i].UpperBound = LatencyBuckets[i]
+		}
+		s.Count += s.Buckets[i].Count
+	}
+	return s
+}
+
+// Bucket counts observations up to its upper bound and above the bound of the previous
+// bucket. Upper bound of the last bucket is zero, it counts the overflowing observations.
+type Bucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// HistogramSnapshot is a point-in-time copy of a histogram.
+type HistogramSnapshot struct {
+	Buckets []Bucket
+	Count   uint64
+	Sum     time.Duration
+	Max     time.Duration
+}
+
+// Mean returns the average observed duration.
+func (s HistogramSnapshot) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / time.Duration(s.Count)
+}
+
+// Quantile returns the upper bound of the bucket containing the q-quantile, e.g. 0.99.
+// Max is returned for quantiles falling into the overflow bucket.
+func (s HistogramSnapshot) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(s.Count))
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for _, b := range s.Buckets {
+		if seen += b.Count; seen >= rank {
+			if b.UpperBound == 0 || b.UpperBound > s.Max {
+				return s.Max
+			}
+			return b.UpperBound
+		}
+	}
+	return s.Max
+}
+
+// Stats is a snapshot of call queue metrics, latency is accumulated since the queue was created.
+type Stats struct {
+	// Waiting is the number of scheduled calls not spawned yet.
+	Waiting int
+	// Latency of scheduled calls from enqueueing to execution.
+	Latency HistogramSnapshot
+}
+
+// LagTracker accumulates the sync lag of threads, i.e. the time between creation
+// of remote records and their local application, overall and per thread.
+type LagTracker struct {
+	total   *Histogram
+	threads map[thread.ID]*Histogram
+	mx      sync.RWMutex
+}
+
+func NewLagTracker() *LagTracker {
+	return &LagTracker{
+		total:   NewHistogram(),
+		threads: make(map[thread.ID]*Histogram),
+	}
+}
+
+// Observe adds a sync lag of the thread.
+func (t *LagTracker) Observe(tid thread.ID, lag time.Duration) {
+	t.mx.RLock()
+	h, ok := t.threads[tid]
+	t.mx.RUnlock()
+	if !ok {
+		t.mx.Lock()
+		if h, ok = t.threads[tid]; !ok {
+			h = NewHistogram()
+			t.threads[tid] = h
+		}
+		t.mx.Unlock()
+	}
+	h.Observe(lag)
+	t.total.Observe(lag)
+}
+
+// Total returns the sync lag of all threads.
+func (t *LagTracker) Total() HistogramSnapshot {
+	return t.total.Snapshot()
+}
+
+// Thread returns the sync lag of the thread, it's empty if nothing was observed.
+func (t *LagTracker) Thread(tid thread.ID) HistogramSnapshot {
+	t.mx.RLock()
+	h, ok := t.threads[tid]
+	t.mx.RUnlock()
+	if !ok {
+		return NewHistogram().Snapshot()
+	}
+	return h.Snapshot()
+}
+
+// Forget drops the sync lag of the thread, it's still accounted in the total.
+func (t *LagTracker) Forget(tid thread.ID) {
+	t.mx.Lock()
+	delete(t.threads, tid)
+	t.mx.Unlock()
+}
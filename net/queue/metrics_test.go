@@ -0,0 +1,97 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram()
+	for i := 0; i < 98; i++ {
+		h.Observe(5 * time.Millisecond)
+	}
+	h.Observe(2 * time.Second)
+	h.Observe(2 * time.Hour)
+
+	s := h.Snapshot()
+	if s.Count != 100 {
+		t.Fatalf("expected 100 observations, got %d", s.Count)
+	}
+	if s.Max != 2*time.Hour {
+		t.Fatalf("expected max of 2h, got %v", s.Max)
+	}
+	if last := s.Buckets[len(s.Buckets)-1]; last.UpperBound != 0 || last.Count != 1 {
+		t.Fatalf("expected 1 overflowing observation, got %+v", last)
+	}
+	if q := s.Quantile(0.5); q != 10*time.Millisecond {
+		t.Fatalf("expected median bound of 10ms, got %v", q)
+	}
+	if q := s.Quantile(0.99); q != 5*time.Second {
+		t.Fatalf("expected p99 bound of 5s, got %v", q)
+	}
+	if q := s.Quantile(1); q != 2*time.Hour {
+		t.Fatalf("expected p100 of max, got %v", q)
+	}
+}
+
+func TestLagTracker(t *testing.T) {
+	var (
+		lt = NewLagTracker()
+		t1 = thread.NewIDV1(thread.Raw, 32)
+		t2 = thread.NewIDV1(thread.Raw, 32)
+	)
+	lt.Observe(t1, time.Second)
+	lt.Observe(t1, 3*time.Second)
+	lt.Observe(t2, time.Minute)
+
+	if s := lt.Thread(t1); s.Count != 2 || s.Mean() != 2*time.Second {
+		t.Fatalf("unexpected lag of thread 1: %d observations, mean %v", s.Count, s.Mean())
+	}
+	if s := lt.Total(); s.Count != 3 || s.Max != time.Minute {
+		t.Fatalf("unexpected total lag: %d observations, max %v", s.Count, s.Max)
+	}
+	lt.Forget(t2)
+	if s := lt.Thread(t2); s.Count != 0 {
+		t.Fatal("expected lag of forgotten thread to be dropped")
+	}
+	if s := lt.Total(); s.Count != 3 {
+		t.Fatal("expected total lag to keep forgotten thread")
+	}
+}
+
+func TestFFQueue_Stats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		q   = NewFFQueue(ctx, clock.Real, 10*time.Millisecond, 50*time.Millisecond)
+		pid = peer.ID("peer")
+		wg  sync.WaitGroup
+	)
+	const calls = 5
+	wg.Add(calls)
+	for i := 0; i < calls; i++ {
+		q.Schedule(pid, thread.NewIDV1(thread.Raw, 32), 0, func(context.Context, peer.ID, thread.ID) error {
+			wg.Done()
+			return nil
+		})
+	}
+	if s := q.Stats(); s.Waiting != calls {
+		t.Fatalf("expected %d waiting calls, got %d", calls, s.Waiting)
+	}
+	wg.Wait()
+
+	s := q.Stats()
+	if s.Waiting != 0 {
+		t.Fatalf("expected no waiting calls, got %d", s.Waiting)
+	}
+	if s.Latency.Count != calls || s.Latency.Max == 0 {
+		t.Fatalf("expected latency of %d calls, got %d", calls, s.Latency.Count)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/clock"
 	"github.com/textileio/go-threads/core/thread"
 )
 
@@ -34,17 +35,20 @@ type (
 		ctx         context.Context
 		peers       map[peer.ID][]tEntry
 		input       chan request
+		clock       clock.Clock
 		timeout     time.Duration
 		maxPackSize int
 	}
 )
 
 // Packer accumulates peer-related thread requests and packs it into the
-// limited-size containers during time-window constrained by provided timeout.
-func NewThreadPacker(ctx context.Context, maxPackSize int, timeout time.Duration) *threadPacker {
+// limited-size containers during time-window constrained by provided timeout,
+// which follows the given clock.
+func NewThreadPacker(ctx context.Context, clk clock.Clock, maxPackSize int, timeout time.Duration) *threadPacker {
 	return &threadPacker{
 		peers:       make(map[peer.ID][]tEntry),
 		input:       make(chan request, InBufSize),
+		clock:       clk,
 		timeout:     timeout,
 		maxPackSize: maxPackSize,
 		ctx:         ctx,
@@ -55,7 +59,7 @@ func (q *threadPacker) Add(pid peer.ID, tid thread.ID) {
 	q.input <- request{
 		pid:   pid,
 		tid:   tid,
-		added: time.Now().Unix(),
+		added: q.clock.Now().Unix(),
 	}
 }
 
@@ -63,7 +67,7 @@ func (q *threadPacker) Run() <-chan ThreadPack {
 	var sink = make(chan ThreadPack, OutBufSize)
 
 	go func() {
-		tm := time.NewTicker(q.timeout)
+		tm := q.clock.NewTicker(q.timeout)
 		defer tm.Stop()
 
 		for {
@@ -77,7 +81,7 @@ func (q *threadPacker) Run() <-chan ThreadPack {
 
 			case <-tm.C:
 				// periodic check for inactive peer queues with overdue entries
-				var now = time.Now().Unix()
+				var now = q.clock.Now().Unix()
 				for pid, pq := range q.peers {
 					if len(pq) > 0 && now-pq[0].added >= int64(q.timeout/time.Second) {
 						q.drainPeerQueue(pid, sink)
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/textileio/go-threads/clock"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/test"
 )
@@ -14,7 +15,7 @@ func TestThreadPacker(t *testing.T) {
 		maxPack     = 3
 		timeout     = 1 * time.Second
 		ctx, cancel = context.WithCancel(context.Background())
-		tp          = NewThreadPacker(ctx, maxPack, timeout)
+		tp          = NewThreadPacker(ctx, clock.Real, maxPack, timeout)
 
 		pid  = test.GeneratePeerIDs(1)[0]
 		tids = make([]thread.ID, 2*maxPack+1)
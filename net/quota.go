@@ -0,0 +1,87 @@
+package net
+
+import (
+	"context"
+	"fmt"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// metaStorageQuota is the metadata key of the thread storage quota.
+const metaStorageQuota = "quota:max"
+
+// SetThreadQuota limits the size of records stored locally for the thread, in bytes.
+// Creating records beyond the quota fails with core.ErrQuotaExceeded, while records of
+// other peers are accepted and flag the thread as over quota. Zero removes the limit.
+// Threads added before the block index have no known usage, so they're never over quota.
+func (n *net) SetThreadQuota(id thread.ID, quota int64) error {
+	if quota < 0 {
+		return fmt.Errorf("invalid quota of %d bytes", quota)
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	return n.store.PutInt64(id, metaStorageQuota, quota)
+}
+
+// threadUsage returns the stored size and the quota of the thread, the quota is zero if unlimited.
+// The stored size is read from the block index, it's zero for threads added before the index.
+func (n *net) threadUsage(tid thread.ID) (usage, quota int64, err error) {
+	blocks, _, err := n.blocks.usage(tid)
+	if err != nil {
+		return
+	}
+	q, err := n.store.GetInt64(tid, metaStorageQuota)
+	if err != nil {
+		return
+	} else if q != nil {
+		quota = *q
+	}
+	return blocks.Bytes, quota, nil
+}
+
+// checkQuota returns an error if storing size more bytes would exceed the quota of the thread
+// or of its group.
+func (n *net) checkQuota(tid thread.ID, size int64) error {
+	usage, quota, err := n.threadUsage(tid)
+	if err != nil {
+		return err
+	}
+	if quota > 0 && usage+size > quota {
+		return &core.QuotaExceededError{Usage: usage, Size: size, Quota: quota}
+	}
+	group, err := n.threadGroup(tid)
+	if err != nil || group == "" {
+		return err
+	}
+	if usage, quota, err = n.groupUsage(group); err != nil {
+		return err
+	}
+	if quota > 0 && usage+size > quota {
+		return &core.QuotaExceededError{Usage: usage, Size: size, Quota: quota}
+	}
+	return nil
+}
+
+// addUsage files the blocks of a record stored locally under its thread, which accounts them
+// towards the thread usage. Records of other peers are never rejected, but a warning is
+// logged once they exceed the quota.
+func (n *net) addUsage(ctx context.Context, tid thread.ID, rec core.Record) {
+	n.quotaLock.Lock()
+	defer n.quotaLock.Unlock()
+	usage, quota, err := n.threadUsage(tid)
+	if err != nil {
+		log.Errorf("getting usage of thread %s failed: %v", tid, err)
+		return
+	}
+	n.indexRecordBlocks(ctx, tid, rec)
+	if quota == 0 || usage > quota {
+		return
+	}
+	if usage, _, err = n.threadUsage(tid); err != nil {
+		log.Errorf("getting usage of thread %s failed: %v", tid, err)
+	} else if usage > quota {
+		log.Warnf("thread %s exceeded its storage quota of %d bytes", tid, quota)
+	}
+}
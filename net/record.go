@@ -19,6 +19,7 @@ type linkedRecord interface {
 type recordCollector struct {
 	rs       map[peer.ID]*recordSequence
 	counters map[peer.ID]int64
+	bases    map[peer.ID]map[cid.Cid]struct{}
 	lock     sync.Mutex
 }
 
@@ -26,6 +27,7 @@ func newRecordCollector() *recordCollector {
 	return &recordCollector{
 		rs:       make(map[peer.ID]*recordSequence),
 		counters: make(map[peer.ID]int64),
+		bases:    make(map[peer.ID]map[cid.Cid]struct{}),
 	}
 }
 
@@ -60,6 +62,23 @@ func (r *recordCollector) UpdateHeadCounter(lid peer.ID, counter int64) {
 	}
 }
 
+// UpdateBase records the base of a log pruned by some peer. Base is kept only if
+// no other peer provided records preceding it.
+func (r *recordCollector) UpdateBase(lid peer.ID, base cid.Cid) {
+	if !base.Defined() {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bs, found := r.bases[lid]
+	if !found {
+		bs = make(map[cid.Cid]struct{})
+		r.bases[lid] = bs
+	}
+	bs[base] = struct{}{}
+}
+
 // List all previously stored records in a proper order if the latter exists.
 func (r *recordCollector) List() (map[peer.ID]peerRecords, error) {
 	r.lock.Lock()
@@ -78,6 +97,11 @@ func (r *recordCollector) List() (map[peer.ID]peerRecords, error) {
 			return nil, fmt.Errorf("did not find log counter in log %s", id)
 		}
 
+		var base cid.Cid
+		if _, found := r.bases[id][ordered[0].PrevID()]; found {
+			base = ordered[0].PrevID()
+		}
+
 		casted := make([]core.Record, len(ordered))
 		for i := 0; i < len(ordered); i++ {
 			casted[i] = ordered[i].(core.Record)
@@ -85,6 +109,7 @@ func (r *recordCollector) List() (map[peer.ID]peerRecords, error) {
 		logSeqs[id] = peerRecords{
 			records: casted,
 			counter: counter,
+			base:    base,
 		}
 	}
 
@@ -0,0 +1,301 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	bs "github.com/ipfs/go-ipfs-blockstore"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// metaRedactions is the metadata key of the marshaled redactions of a thread, by record ID.
+const metaRedactions = "redactions"
+
+// redactionEntry is a redaction of a record. Identity is the verified one of the redaction
+// record, the redaction is applied only if it's the identity of the redacted record too.
+type redactionEntry struct {
+	By       cid.Cid `json:"by"`
+	Log      peer.ID `json:"log"`
+	Identity []byte  `json:"identity"`
+	Applied  bool    `json:"applied"`
+}
+
+func (n *net) RedactRecord(
+	ctx context.Context,
+	id thread.ID,
+	rid cid.Cid,
+	opts ...core.ThreadOption,
+) (core.ThreadRecord, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	identity, err := n.Validate(id, args.Token, false)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	}
+	if _, frozen, err := n.frozenHeight(id, ""); err != nil {
+		return nil, err
+	} else if frozen {
+		return nil, core.ErrThreadFrozen
+	}
+	target, err := n.getRecord(ctx, id, rid)
+	if err != nil {
+		return nil, err
+	}
+	author, err := identity.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(target.PubKey(), author) {
+		return nil, core.ErrRedactionDenied
+	}
+	signer, err := n.identitySigner(identity, args.Signer)
+	if err != nil {
+		return nil, err
+	}
+	if entries, err := n.redactions(id); err != nil {
+		return nil, err
+	} else if _, ok := entries[rid.String()]; ok {
+		return nil, fmt.Errorf("%w: %s", core.ErrRecordRedacted, rid)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"record": rid.String(),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		return nil, err
+	}
+	exts := core.RecordExtensions{
+		core.ExtensionKey(core.ExtensionNamespaceThreads, core.ExtensionRedact): rid.Bytes(),
+	}
+	tr, head, _, err := n.appendRecord(ctx, id, body, identity, "", core.RedactionRecordType, exts, signer)
+	if err != nil {
+		return nil, err
+	}
+	if err = n.applyRedactions(ctx, id, tr.LogID(), tr.Value()); err != nil {
+		return nil, err
+	}
+	log.Debugf("redacted record %s with %s (thread=%s, log=%s)", rid, tr.Value().Cid(), id, tr.LogID())
+	if err = n.publishRecord(ctx, id, tr, head, args.Priority); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+func (n *net) ListRedactions(ctx context.Context, id thread.ID, opts ...core.ThreadOption) ([]core.Redaction, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return nil, err
+	}
+	entries, err := n.redactions(id)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]core.Redaction, 0, len(entries))
+	for key, entry := range entries {
+		rid, err := cid.Decode(key)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, core.Redaction{
+			RecordID:    rid,
+			RedactionID: entry.By,
+			LogID:       entry.Log,
+			Applied:     entry.Applied,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].RecordID.String() < list[j].RecordID.String()
+	})
+	return list, nil
+}
+
+// redactionTarget returns the record redacted by a redaction record.
+func redactionTarget(rec core.Record) (cid.Cid, bool) {
+	v, ok := rec.Extensions().Get(core.ExtensionNamespaceThreads, core.ExtensionRedact)
+	if !ok {
+		return cid.Undef, false
+	}
+	rid, err := cid.Cast(v)
+	if err != nil {
+		return cid.Undef, false
+	}
+	return rid, true
+}
+
+// redactions returns the redactions of a thread by record ID.
+func (n *net) redactions(tid thread.ID) (map[string]redactionEntry, error) {
+	entries := make(map[string]redactionEntry)
+	val, err := n.store.GetBytes(tid, metaRedactions)
+	if err != nil {
+		return nil, err
+	} else if val == nil || len(*val) == 0 {
+		return entries, nil
+	}
+	if err = json.Unmarshal(*val, &entries); err != nil {
+		return nil, fmt.Errorf("decoding redactions of thread %s: %w", tid, err)
+	}
+	return entries, nil
+}
+
+func (n *net) putRedactions(tid thread.ID, entries map[string]redactionEntry) error {
+	val, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(tid, metaRedactions, val)
+}
+
+// isRedacted returns whether the body of a local record was removed by a redaction.
+func (n *net) isRedacted(tid thread.ID, rid cid.Cid) (bool, error) {
+	entries, err := n.redactions(tid)
+	if err != nil {
+		return false, err
+	}
+	return entries[rid.String()].Applied, nil
+}
+
+// bodyRedacted returns whether the body of a record being loaded was redacted by its author,
+// with a known redaction or a redaction record of the log loaded along with it. Redacted records
+// are sent without their bodies, which aren't stored either if they are.
+func bodyRedacted(
+	tid thread.ID,
+	lid peer.ID,
+	rec core.Record,
+	entries map[string]redactionEntry,
+	loading []core.Record,
+) bool {
+	if entry, ok := entries[rec.Cid().String()]; ok && bytes.Equal(entry.Identity, rec.PubKey()) {
+		return true
+	}
+	for _, r := range loading {
+		if rid, ok := redactionTarget(r); ok && rid.Equals(rec.Cid()) {
+			if identity, ok := redactionIdentity(tid, lid, r); ok && bytes.Equal(identity, rec.PubKey()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactionIdentity returns the marshaled identity of a redaction record of a log if the record
+// is signed by it. The identities unsigned redactions claim can't authorize them.
+func redactionIdentity(tid thread.ID, lid peer.ID, rec core.Record) ([]byte, bool) {
+	identity, ok := verifiedIdentity(tid, lid, rec)
+	if !ok {
+		return nil, false
+	}
+	b, err := identity.MarshalBinary()
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// applyRedactions updates the redactions of a thread with a record stored locally. The body
+// of the record is removed if it was redacted before it was received, and the body of the
+// record redacted by a redaction record is removed if it's already stored.
+func (n *net) applyRedactions(ctx context.Context, tid thread.ID, lid peer.ID, rec core.Record) error {
+	n.redactLock.Lock()
+	defer n.redactLock.Unlock()
+	entries, err := n.redactions(tid)
+	if err != nil {
+		return err
+	}
+	var changed bool
+	if entry, ok := entries[rec.Cid().String()]; ok && !entry.Applied {
+		n.applyRedaction(ctx, tid, rec.Cid(), entry, entries)
+		changed = true
+	}
+	if rid, ok := redactionTarget(rec); ok {
+		identity, signed := redactionIdentity(tid, lid, rec)
+		if !signed {
+			log.Warnf("ignoring redaction %s of record %s without a valid identity signature (thread %s)", rec.Cid(), rid, tid)
+		} else if _, ok := entries[rid.String()]; !ok {
+			// the first redaction of a record is kept
+			entry := redactionEntry{By: rec.Cid(), Log: lid, Identity: identity}
+			entries[rid.String()] = entry
+			if known, err := n.isKnown(rid); err != nil {
+				return err
+			} else if known {
+				n.applyRedaction(ctx, tid, rid, entry, entries)
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return n.putRedactions(tid, entries)
+}
+
+// applyRedaction removes the body of a stored record if the redaction is authorized, and drops
+// the redaction otherwise. Redactions failing for other reasons are left unapplied.
+func (n *net) applyRedaction(ctx context.Context, tid thread.ID, rid cid.Cid, entry redactionEntry, entries map[string]redactionEntry) {
+	if err := n.removeBody(ctx, tid, rid, entry.Identity); errors.Is(err, core.ErrRedactionDenied) {
+		log.Warnf("rejected redaction %s of record %s (thread %s): %v", entry.By, rid, tid, err)
+		delete(entries, rid.String())
+		return
+	} else if err != nil {
+		log.Errorf("redacting record %s (thread %s) with %s failed: %v", rid, tid, entry.By, err)
+		return
+	}
+	entry.Applied = true
+	entries[rid.String()] = entry
+}
+
+// removeBody removes the event body of a record authored by identity from the blockstore.
+func (n *net) removeBody(ctx context.Context, tid thread.ID, rid cid.Cid, identity []byte) error {
+	rec, err := n.getRecord(ctx, tid, rid)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rec.PubKey(), identity) {
+		return core.ErrRedactionDenied
+	}
+	// light clients may not have loaded the event
+	if known, err := n.isKnown(rec.BlockID()); err != nil || !known {
+		return err
+	}
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		return err
+	}
+	if err = n.bstore.DeleteBlock(event.BodyID()); err != nil && !errors.Is(err, bs.ErrNotFound) {
+		return err
+	}
+	return n.blocks.remove(tid, event.BodyID())
+}
+
+// recordToProto returns a proto version of a thread record for transport, redacted records
+// are sent without their bodies.
+func (n *net) recordToProto(ctx context.Context, tid thread.ID, rec core.Record) (*pb.Log_Record, error) {
+	redacted, err := n.isRedacted(tid, rec.Cid())
+	if err != nil {
+		return nil, err
+	} else if redacted {
+		return cbor.RedactedRecordToProto(ctx, n, rec)
+	}
+	return cbor.RecordToProto(ctx, n, rec)
+}
@@ -0,0 +1,193 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_RedactRecord(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n3 := makeNetwork(t).(*net)
+	defer n3.Close()
+	for _, n := range []*net{n2, n3} {
+		n1.Host().Peerstore().AddAddrs(n.Host().ID(), n.Host().Addrs(), peerstore.PermanentAddrTTL)
+		n.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+	}
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"ssn": "078-05-1120"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rid := tr.Value().Cid()
+	event, err := cbor.EventFromRecord(ctx, n1, tr.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBody := func(n *net, expected bool) {
+		t.Helper()
+		if stored, err := n.isKnown(event.BodyID()); err != nil {
+			t.Fatal(err)
+		} else if stored != expected {
+			t.Fatalf("expected body to be stored: %v, got %v", expected, stored)
+		}
+		if header, err := n.isKnown(event.HeaderID()); err != nil || !header {
+			t.Fatalf("expected header to be kept, got %v", err)
+		}
+		if _, err := n.GetRecord(ctx, info.ID, rid); err != nil {
+			t.Fatalf("expected record to be kept: %v", err)
+		}
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	checkBody(n2, true)
+
+	// only the author redacts records
+	if _, err = n2.RedactRecord(ctx, info.ID, rid); !errors.Is(err, core.ErrRedactionDenied) {
+		t.Fatalf("expected redaction by another identity to be denied, got %v", err)
+	}
+	// nor do identities claiming to be the author
+	own, err := n2.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg, err := n2.store.GetLog(info.ID, own.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := thread.NewLibp2pPubKey(n1.getPrivKey().GetPublic())
+	tombstone, err := cbornode.WrapObject(map[string]interface{}{"record": rid.String()}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exts := core.RecordExtensions{core.ExtensionKey(core.ExtensionNamespaceThreads, core.ExtensionRedact): rid.Bytes()}
+	header := cbor.EventHeaderConfig{Type: core.RedactionRecordType}
+	for _, signer := range []thread.Identity{nil, thread.NewLibp2pIdentity(n2.getPrivKey())} {
+		forged, err := n2.newRecord(ctx, info.ID, lg, tombstone, author, header, exts, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bodyRedacted(info.ID, lg.ID, tr.Value(), nil, []core.Record{forged}) {
+			t.Fatal("expected forged redaction not to redact the body being loaded")
+		}
+		if err = n1.applyRedactions(ctx, info.ID, lg.ID, forged); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if list, err := n1.ListRedactions(ctx, info.ID); err != nil || len(list) != 0 {
+		t.Fatalf("expected forged redactions to be ignored, got %+v (%v)", list, err)
+	}
+	checkBody(n1, true)
+
+	redaction, err := n1.RedactRecord(ctx, info.ID, rid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBody(n1, false)
+	if _, err = n1.RedactRecord(ctx, info.ID, rid); !errors.Is(err, core.ErrRecordRedacted) {
+		t.Fatalf("expected record to be redacted once, got %v", err)
+	}
+	list, err := n1.ListRedactions(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || !list[0].RecordID.Equals(rid) || !list[0].RedactionID.Equals(redaction.Value().Cid()) ||
+		list[0].LogID != redaction.LogID() || !list[0].Applied {
+		t.Fatalf("unexpected redactions %+v", list)
+	}
+
+	// replicas holding the body remove it
+	if err = n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	checkBody(n2, false)
+
+	// new replicas receive the record without its body
+	if _, err = n3.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err = n3.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	checkBody(n3, false)
+	if list, err = n3.ListRedactions(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 || !list[0].Applied {
+		t.Fatalf("unexpected redactions %+v", list)
+	}
+	res, err := n3.VerifyThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Damaged) != 0 {
+		t.Fatalf("expected redacted record not to be damaged, got %+v", res.Damaged)
+	}
+}
+
+func TestNet_RedactRecordProto(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{"ssn": "078-05-1120"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbrec, err := cbor.RecordToProto(ctx, n, tr.Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// records received from peers keep their proto
+	rec, err := cbor.RecordFromProto(pbrec, info.Key.Service())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := cbor.RecordToProto(ctx, n, rec); err != nil || len(cached.BodyNode) == 0 {
+		t.Fatalf("expected cached proto with the body, got %v", err)
+	}
+
+	cbor.RedactRecord(rec)
+	redacted, err := cbor.RecordToProto(ctx, n, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(redacted.BodyNode) != 0 || len(redacted.HeaderNode) == 0 {
+		t.Fatal("expected redacted record to be sent without its body")
+	}
+	if len(pbrec.BodyNode) == 0 {
+		t.Fatal("expected shared proto not to be modified")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/gogo/status"
 	"github.com/ipfs/go-cid"
@@ -14,8 +15,12 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/textileio/go-threads/cbor"
 	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/crash"
+	"github.com/textileio/go-threads/lifecycle"
 	"github.com/textileio/go-threads/logstore/lstoreds"
+	"github.com/textileio/go-threads/net/faults"
 	pb "github.com/textileio/go-threads/net/pb"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
@@ -31,29 +36,32 @@ var (
 // server implements the net gRPC server.
 type server struct {
 	sync.Mutex
-	net   *net
-	ps    *PubSub
-	opts  []grpc.DialOption
-	conns map[peer.ID]*grpc.ClientConn
+	net     *net
+	ps      *PubSub
+	pool    *connPool
+	batcher *pushBatcher
 }
 
 // newServer creates a new network server.
-func newServer(n *net, enablePubSub bool, opts ...grpc.DialOption) (*server, error) {
+func newServer(n *net, conf Config, opts ...grpc.DialOption) (*server, error) {
 	var (
 		s = &server{
-			net:   n,
-			conns: make(map[peer.ID]*grpc.ClientConn),
+			net: n,
 		}
 
 		defaultOpts = []grpc.DialOption{
-			s.getLibp2pDialer(),
+			s.getTransportDialer(),
 			grpc.WithInsecure(),
 		}
 	)
 
-	s.opts = append(defaultOpts, opts...)
+	s.pool = newConnPool(conf.ConnLimits, append(defaultOpts, opts...))
+	s.batcher = newPushBatcher(s)
+	if err := n.life.Go(lifecycle.StageSync, "push batcher", s.batcher.run); err != nil {
+		return nil, err
+	}
 
-	if enablePubSub {
+	if conf.PubSub {
 		ps, err := pubsub.NewGossipSub(
 			n.ctx,
 			n.host,
@@ -62,13 +70,16 @@ func newServer(n *net, enablePubSub bool, opts ...grpc.DialOption) (*server, err
 		if err != nil {
 			return nil, err
 		}
-		s.ps = NewPubSub(n.ctx, n.host.ID(), ps, s.pubsubHandler)
+		s.ps = NewPubSub(n.ctx, n.host.ID(), ps, s.pubsubHandler, n.presenceHandler, n.store.ServiceKey, conf.PubSubCacheSize, s.getRecentRecords)
 
 		ts, err := n.store.Threads()
 		if err != nil {
 			return nil, err
 		}
 		for _, id := range ts {
+			if n.syncs.isPaused(id) {
+				continue
+			}
 			if err := s.ps.Add(id); err != nil {
 				return nil, err
 			}
@@ -80,15 +91,74 @@ func newServer(n *net, enablePubSub bool, opts ...grpc.DialOption) (*server, err
 
 // pubsubHandler receives records over pubsub.
 func (s *server) pubsubHandler(ctx context.Context, req *pb.PushRecordRequest) {
-	if _, err := s.PushRecord(ctx, req); err != nil {
+	// members receive thread topics themselves, so records aren't forwarded
+	if _, err := s.acceptRecord(ctx, req, false); err != nil {
 		// This error will be "log not found" if the record sent over pubsub
 		// beat the log, which has to be sent directly via the normal API.
-		// In this case, the record will arrive directly after the log via
-		// the normal API.
+		// In this case, the record is held until the log arrives.
+		if status.Code(err) == codes.NotFound && s.holdRecord(ctx, req) {
+			return
+		}
 		log.Debugf("error handling pubsub record: %s", err)
 	}
 }
 
+// holdRecord buffers a record of a known thread until its log arrives.
+func (s *server) holdRecord(ctx context.Context, req *pb.PushRecordRequest) bool {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	if sk, err := s.net.store.ServiceKey(req.Body.ThreadID.ID); err != nil || sk == nil {
+		return false
+	}
+	if !s.net.pending.add(pid, req) {
+		log.Debugf("pending records buffer is full, dropping record (thread: %s, log: %s)",
+			req.Body.ThreadID.ID, req.Body.LogID.ID)
+		return false
+	}
+	log.Debugf("holding record until log arrives (thread: %s, log: %s)", req.Body.ThreadID.ID, req.Body.LogID.ID)
+	return true
+}
+
+// GetRecentRecords receives a get recent records request.
+func (s *server) GetRecentRecords(ctx context.Context, req *pb.GetRecentRecordsRequest) (*pb.GetRecentRecordsReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received get recent records request from %s", pid)
+
+	reply := &pb.GetRecentRecordsReply{}
+	if err := s.checkServiceKey(req.Body.ThreadID.ID, req.Body.ServiceKey); err != nil {
+		return reply, err
+	}
+	if s.ps == nil {
+		return reply, nil
+	}
+
+	// Keep the newest records which the requester accepts and which fit into the reply
+	var (
+		recs  = s.ps.Recent(req.Body.ThreadID.ID)
+		total int
+	)
+	for i := len(recs) - 1; i >= 0; i-- {
+		size := recordSize(recs[i].Body.Record)
+		if req.Body.MaxRecordSize > 0 && int64(size) > req.Body.MaxRecordSize {
+			continue
+		}
+		if total+size > MaxRecordsReplySize {
+			break
+		}
+		total += size
+		reply.Records = append(reply.Records, recs[i])
+	}
+	for i, j := 0, len(reply.Records)-1; i < j; i, j = i+1, j-1 {
+		reply.Records[i], reply.Records[j] = reply.Records[j], reply.Records[i]
+	}
+	return reply, nil
+}
+
 // GetLogs receives a get logs request.
 func (s *server) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLogsReply, error) {
 	pid, err := peerIDFromContext(ctx)
@@ -107,24 +177,53 @@ func (s *server) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLo
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	pblgs.Logs = make([]*pb.Log, len(info.Logs))
-	for i, l := range info.Logs {
-		pblgs.Logs[i] = logToProto(l)
+	// skip logs whose addresses the requester is up to date with
+	known := make(map[peer.ID]uint64, len(req.Body.Logs))
+	for _, l := range req.Body.Logs {
+		if l.LogID != nil {
+			known[l.LogID.ID] = l.AddressEdge
+		}
+	}
+	pblgs.Logs = make([]*pb.Log, 0, len(info.Logs))
+	for _, l := range info.Logs {
+		if edge, ok := known[l.ID]; ok && edge == logAddrsEdge(l) {
+			continue
+		}
+		pblgs.Logs = append(pblgs.Logs, logToProto(l))
+	}
+
+	// follows and freezes are merged both ways, so they spread to all replicas
+	if _, err = s.net.putFollows(info.ID, req.Body.Follows); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if pblgs.Follows, err = s.net.threadFollows(info.ID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if req.Body.Freeze != nil {
+		if _, err = s.net.putFreeze(info.ID, req.Body.Freeze); err != nil {
+			log.Debugf("skipping freeze of thread %s from %s: %v", info.ID, pid, err)
+		}
+	}
+	if pblgs.Freeze, err = s.net.threadFreeze(info.ID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	log.Debugf("sending %d logs to %s", len(info.Logs), pid)
+	log.Debugf("sending %d of %d logs to %s", len(pblgs.Logs), len(info.Logs), pid)
 
 	return pblgs, nil
 }
 
 // PushLog receives a push log request.
 // @todo: Don't overwrite info from non-owners
-func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushLogReply, error) {
+func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (_ *pb.PushLogReply, err error) {
 	pid, err := peerIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	log.Debugf("received push log request from %s", pid)
+	defer func() {
+		s.net.auditPush("PushLog", pid, req.Body.ThreadID.ID, req.Body.Log.ID.ID, cid.Undef, err)
+	}()
 
 	// Pick up missing keys
 	info, err := s.net.store.GetThread(req.Body.ThreadID.ID)
@@ -147,11 +246,43 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 		}
 	}
 
+	// Logs are kept by all federation members to take over the thread if needed
+	if fid, ok := s.net.forwardTo(pid, req.Body.ThreadID.ID); ok {
+		crash.Go("forwarding log", func() {
+			if err := s.forwardPushLog(req, fid); err != nil {
+				log.Errorf("forwarding log to federation member %s failed: %v", fid, err)
+			}
+		})
+	}
+
 	lg := logFromProto(req.Body.Log)
-	if err = s.net.createExternalLogsIfNotExist(req.Body.ThreadID.ID, []thread.LogInfo{lg}); err != nil {
+	// Logs of new writers may have to be approved by the thread owner first
+	if pending, err := s.net.holdLog(req.Body.ThreadID.ID, pid, lg); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if pending {
+		return &pb.PushLogReply{}, nil
+	}
+	held := s.net.pending.has(req.Body.ThreadID.ID, lg.ID)
+	if _, err = s.net.store.GetLog(req.Body.ThreadID.ID, lg.ID); errors.Is(err, lstore.ErrLogNotFound) {
+		if err = s.net.createExternalLogsIfNotExist(req.Body.ThreadID.ID, []thread.LogInfo{lg}); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	} else if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	// Addresses of known logs are only replaced by the log owner
+	if req.Body.Addrs != nil {
+		if _, err = s.net.putLogAddrs(req.Body.ThreadID.ID, lg.ID, req.Body.Addrs); errors.Is(err, errInvalidLogAddrs) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		} else if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
 
+	// Records which arrived before the log are applied directly, missing ones are picked up by the next pull
+	if held || !s.net.isResponsible(req.Body.ThreadID.ID) {
+		return &pb.PushLogReply{}, nil
+	}
 	if s.net.queueGetRecords.Schedule(pid, req.Body.ThreadID.ID, callPriorityLow, s.net.updateRecordsFromPeer) {
 		log.Debugf("record update for thread %s from %s scheduled", req.Body.ThreadID.ID, pid)
 	}
@@ -171,10 +302,11 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 		return pbrecs, err
 	}
 
-	// fast check if requested offsets are equal with thread heads
+	// fast check if requested offsets are equal with thread heads,
+	// records preceding anchors are requested regardless of the heads
 	if changed, err := s.headsChanged(req); err != nil {
 		return nil, err
-	} else if !changed {
+	} else if !changed && !requestsBackward(req) {
 		return pbrecs, nil
 	}
 
@@ -194,11 +326,13 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 		logRecordLimit = MaxPullLimit / len(info.Logs)
 		mx             sync.Mutex
 		wg             sync.WaitGroup
+		panicked       error
 	)
 
 	for _, lg := range info.Logs {
 		var (
 			offset  cid.Cid
+			anchor  cid.Cid
 			limit   int
 			counter int64
 			pblg    = logToProto(lg)
@@ -207,6 +341,9 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 			offset = opts.Offset.Cid
 			counter = opts.Counter
 			limit = minInt(int(opts.Limit), logRecordLimit)
+			if opts.Direction == pb.GetRecordsRequest_BACKWARD && opts.Anchor != nil {
+				anchor = opts.Anchor.Cid
+			}
 		} else {
 			offset = cid.Undef
 			limit = logRecordLimit
@@ -216,19 +353,42 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 		wg.Add(1)
 		go func(tid thread.ID, lid peer.ID, off cid.Cid, lim int) {
 			defer wg.Done()
+			// a panic fails the request instead of stopping the node
+			var perr error
+			defer func() {
+				if perr != nil {
+					mx.Lock()
+					panicked = perr
+					mx.Unlock()
+				}
+			}()
+			defer crash.Recover("getting records", &perr)
 			// if we don't have records in the log then skipping it
 			if pblg.Counter == thread.CounterUndef {
 				return
 			}
 
-			recs, err := s.net.getLocalRecords(ctx, tid, lid, off, lim, counter)
+			var (
+				recs []core.Record
+				base cid.Cid
+				err  error
+			)
+			if anchor.Defined() {
+				recs, err = s.net.getLocalRecordsBefore(ctx, tid, anchor, lim)
+			} else {
+				recs, base, err = s.net.getLocalRecords(ctx, tid, lid, off, lim, counter)
+			}
 			if err != nil {
 				log.Errorf("getting local records (thread %s, log %s): %v", tid, lid, err)
 			}
 
 			var prs = make([]*pb.Log_Record, 0, len(recs))
 			for _, r := range recs {
-				pr, err := cbor.RecordToProto(ctx, s.net, r)
+				if req.Body.HeadersOnly {
+					prs = append(prs, &pb.Log_Record{RecordNode: r.RawData()})
+					continue
+				}
+				pr, err := s.net.recordToProto(ctx, tid, r)
 				if err != nil {
 					log.Errorf("constructing proto-record %s (thread %s, log %s): %v", r.Cid(), tid, lid, err)
 					break
@@ -241,12 +401,18 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 				return
 			}
 
-			mx.Lock()
-			pbrecs.Logs = append(pbrecs.Logs, &pb.GetRecordsReply_LogEntry{
+			entry := &pb.GetRecordsReply_LogEntry{
 				LogID:   &pb.ProtoPeerID{ID: lid},
 				Records: prs,
 				Log:     pblg,
-			})
+			}
+			if base.Defined() && len(prs) == len(recs) {
+				// requested offset was pruned, so the log is served from the checkpoint
+				entry.Base = &pb.ProtoCid{Cid: base}
+			}
+
+			mx.Lock()
+			pbrecs.Logs = append(pbrecs.Logs, entry)
 			mx.Unlock()
 
 			log.Debugf("sending %d records in log %s to %s", len(recs), lid, pid)
@@ -254,16 +420,214 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 	}
 
 	wg.Wait()
+	if panicked != nil {
+		return nil, status.Error(codes.Internal, "internal error getting records")
+	}
 	return pbrecs, nil
 }
 
+// GetRecordsByCID receives a get records by CID request.
+// Only processed records which are signed by the requested log are returned, along with
+// their ancestry proofs from the log head. Records of logs which can't be indexed, e.g.
+// pruned ones, can't be proven and are omitted.
+func (s *server) GetRecordsByCID(ctx context.Context, req *pb.GetRecordsByCIDRequest) (*pb.GetRecordsByCIDReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received get records by cid request from %s", pid)
+
+	reply := &pb.GetRecordsByCIDReply{}
+	if err := s.checkServiceKey(req.Body.ThreadID.ID, req.Body.ServiceKey); err != nil {
+		return reply, err
+	}
+	var requested int
+	for _, l := range req.Body.Logs {
+		requested += len(l.RecordIDs)
+	}
+	if requested > MaxPullLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d records may be requested", MaxPullLimit)
+	}
+
+	var (
+		tid   = req.Body.ThreadID.ID
+		total int
+	)
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, l := range req.Body.Logs {
+		logpk, err := s.net.store.PubKey(tid, l.LogID.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		} else if logpk == nil {
+			continue
+		}
+		lg, err := s.net.store.GetLog(tid, l.LogID.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		positions, err := s.net.recordPositions(ctx, tid, lg.ID, l.RecordIDs)
+		if errors.Is(err, errLogIncomplete) {
+			continue
+		} else if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		entry := &pb.GetRecordsByCIDReply_LogEntry{LogID: l.LogID}
+		for _, rid := range l.RecordIDs {
+			// records are added to the blockstore once processed
+			if known, err := s.net.isKnown(rid.Cid); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			} else if !known {
+				continue
+			}
+			pos, ok := positions[rid.Cid]
+			if !ok || pos > lg.Head.Counter {
+				// the record isn't on the log chain up to the head
+				continue
+			}
+			rec, err := s.net.getRecord(ctx, tid, rid.Cid)
+			if err != nil {
+				// the block isn't a record of the thread
+				log.Debugf("getting record %s failed: %v", rid.Cid, err)
+				continue
+			}
+			if _, err = rec.GetBlock(ctx, s.net); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			if err = rec.Verify(logpk); err != nil {
+				// the record belongs to another log
+				continue
+			}
+			pbrec, err := s.net.recordToProto(ctx, tid, rec)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			proof, err := s.net.ancestryProof(ctx, lg.Head, pos, sk)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			pbproof := proofToProto(proof)
+			if total += recordSize(pbrec) + pbproof.Size(); total > MaxRecordsReplySize {
+				break
+			}
+			entry.Records = append(entry.Records, pbrec)
+			entry.Proofs = append(entry.Proofs, pbproof)
+		}
+		if len(entry.Records) > 0 {
+			reply.Logs = append(reply.Logs, entry)
+		}
+		if total > MaxRecordsReplySize {
+			break
+		}
+	}
+	return reply, nil
+}
+
+// GetLogDigests receives a get log digests request.
+// Digests are computed over the first requested number of records of the log,
+// none are returned if the log has fewer records.
+func (s *server) GetLogDigests(ctx context.Context, req *pb.GetLogDigestsRequest) (*pb.GetLogDigestsReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received get log digests request from %s", pid)
+
+	reply := &pb.GetLogDigestsReply{}
+	if err := s.checkServiceKey(req.Body.ThreadID.ID, req.Body.ServiceKey); err != nil {
+		return reply, err
+	}
+	if len(req.Body.Ranges) > maxDigestRanges {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ranges may be requested", maxDigestRanges)
+	}
+	nodes := make([]digestNode, len(req.Body.Ranges))
+	for i, r := range req.Body.Ranges {
+		if r.Level < 0 || r.Level > maxDigestLevel || r.Index < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid range %d/%d", r.Level, r.Index)
+		}
+		nodes[i] = digestNode{level: int(r.Level), index: r.Index}
+	}
+
+	var (
+		tid = req.Body.ThreadID.ID
+		lid = req.Body.LogID.ID
+	)
+	err = s.net.withLogIndex(ctx, tid, lid, func(idx *logIndex) error {
+		reply.Counter = int64(len(idx.ids))
+		if req.Body.Length > reply.Counter {
+			return nil
+		}
+		for _, nd := range nodes {
+			reply.Digests = append(reply.Digests, idx.digest(nd, req.Body.Length))
+		}
+		return nil
+	})
+	if errors.Is(err, errLogIncomplete) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return reply, nil
+}
+
+// PushAcks receives acks of records of logs authored by the host.
+func (s *server) PushAcks(ctx context.Context, req *pb.PushAcksRequest) (*pb.PushAcksReply, error) {
+	pid, err := peerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("received push acks request from %s", pid)
+
+	if s.net.acks == nil {
+		return nil, status.Error(codes.Unimplemented, "record acks are disabled")
+	}
+	reply := &pb.PushAcksReply{}
+	if err := s.checkServiceKey(req.Body.ThreadID.ID, req.Body.ServiceKey); err != nil {
+		return reply, err
+	}
+	if err := s.net.putAcks(req.Body.ThreadID.ID, pid, req.Body.Acks); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return reply, nil
+}
+
 // PushRecord receives a push record request.
 func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb.PushRecordReply, error) {
+	return s.acceptRecord(ctx, req, true)
+}
+
+// acceptRecord accepts a pushed record, or forwards it to the federation member responsible
+// for the thread if forwarding is allowed.
+func (s *server) acceptRecord(ctx context.Context, req *pb.PushRecordRequest, forward bool) (_ *pb.PushRecordReply, err error) {
 	pid, err := peerIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	log.Debugf("received push record request from %s", pid)
+	var rid cid.Cid
+	start := time.Now()
+	defer func() {
+		s.net.auditPush("PushRecord", pid, req.Body.ThreadID.ID, req.Body.LogID.ID, rid, err)
+		// pushes of unknown threads would grow the journal
+		if sk, _ := s.net.store.ServiceKey(req.Body.ThreadID.ID); sk != nil {
+			s.net.observeExchange(req.Body.ThreadID.ID, pid, opAcceptRecord, start, err)
+		}
+	}()
+
+	if size := recordSize(req.Body.Record); size > s.net.maxRecordSize {
+		tooLarge := &core.RecordTooLargeError{Size: size, MaxSize: s.net.maxRecordSize}
+		return nil, status.Error(codes.ResourceExhausted, tooLarge.Error())
+	}
+
+	if fid, ok := s.net.forwardTo(pid, req.Body.ThreadID.ID); ok && forward {
+		reply, err := s.forwardPushRecord(ctx, req, fid)
+		if err == nil || status.Convert(err).Code() != codes.Unavailable {
+			return reply, err
+		}
+		log.Warnf("forwarding record to federation member %s failed, accepting it: %v", fid, err)
+	}
 
 	// A log is required to accept new records
 	logpk, err := s.net.store.PubKey(req.Body.ThreadID.ID, req.Body.LogID.ID)
@@ -274,19 +638,34 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 		return nil, status.Error(codes.NotFound, "log not found")
 	}
 
+	if faults.DropPushRecord() {
+		log.Warnf("dropping record pushed by %s (injected failure)", pid)
+		return &pb.PushRecordReply{}, nil
+	}
+
 	key, err := s.net.store.ServiceKey(req.Body.ThreadID.ID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	faults.CorruptRecordBody(req.Body.Record.BodyNode)
 	rec, err := cbor.RecordFromProto(req.Body.Record, key)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	if err = rec.Verify(logpk); err != nil {
+	rid = rec.Cid()
+	if err = s.net.verifier.verify(ctx, 1, func(int) error {
+		return rec.Verify(logpk)
+	}); err != nil {
+		if ctx.Err() != nil {
+			return nil, status.Error(codes.Canceled, err.Error())
+		}
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
-	if err = s.net.PutRecord(ctx, req.Body.ThreadID.ID, req.Body.LogID.ID, rec, req.Counter); err != nil {
+	s.net.observeHeight(req.Body.ThreadID.ID, req.Body.LogID.ID, req.Counter)
+	if err = s.net.PutRecord(ctx, req.Body.ThreadID.ID, req.Body.LogID.ID, rec, req.Counter); errors.Is(err, core.ErrThreadFrozen) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.PushRecordReply{}, nil
@@ -299,8 +678,10 @@ func (s *server) ExchangeEdges(ctx context.Context, req *pb.ExchangeEdgesRequest
 		return nil, err
 	}
 	log.Debugf("received exchange edges request from %s", pid)
+	faults.DelayExchangeEdges(ctx)
 
-	var reply pb.ExchangeEdgesReply
+	s.net.setPeerMaxRecordSize(pid, req.Body.MaxRecordSize)
+	reply := pb.ExchangeEdgesReply{MaxRecordSize: int64(s.net.maxRecordSize)}
 	for _, entry := range req.Body.Threads {
 		var tid = entry.ThreadID.ID
 		switch addrsEdgeLocal, headsEdgeLocal, err := s.localEdges(tid); err {
@@ -346,12 +727,42 @@ func (s *server) ExchangeEdges(ctx context.Context, req *pb.ExchangeEdgesRequest
 				exists = false
 			}
 
-			reply.Edges = append(reply.Edges, &pb.ExchangeEdgesReply_ThreadEdges{
+			edges := &pb.ExchangeEdgesReply_ThreadEdges{
 				ThreadID:    &pb.ProtoThreadID{ID: tid},
 				Exists:      exists,
 				AddressEdge: addrsEdgeLocal,
 				HeadsEdge:   headsEdgeLocal,
-			})
+			}
+
+			// inline our logs to save the requester a GetLogs round trip
+			if addrsEdgeLocal != lstoreds.EmptyEdgeValue && addrsEdgeLocal != addrsEdgeRemote {
+				if logs, err := s.inlineLogs(tid); err != nil {
+					log.Debugf("inlining logs of thread %s failed: %v", tid, err)
+				} else {
+					edges.Logs = logs
+				}
+			}
+
+			// gossip other replicas to requesters lagging behind, which are likely joining
+			if limit := req.Body.GossipPeers; limit > 0 &&
+				(addrsEdgeLocal != addrsEdgeRemote || headsEdgeLocal != headsEdgeRemote) {
+				if peers, err := s.gossipPeers(tid, pid, int(limit)); err != nil {
+					log.Debugf("gossiping peers of thread %s failed: %v", tid, err)
+				} else {
+					edges.Peers = peers
+				}
+			}
+
+			// prove our heads, so the requester doesn't pull on a spoofed edge
+			if headsEdgeLocal != lstoreds.EmptyEdgeValue && headsEdgeLocal != headsEdgeRemote {
+				if proofs, err := s.headProofs(ctx, tid); err != nil {
+					log.Debugf("proving heads of thread %s failed: %v", tid, err)
+				} else {
+					edges.Heads = proofs
+				}
+			}
+
+			reply.Edges = append(reply.Edges, edges)
 
 		default:
 			return nil, fmt.Errorf("getting edges for %s: %w", tid, err)
@@ -361,6 +772,74 @@ func (s *server) ExchangeEdges(ctx context.Context, req *pb.ExchangeEdgesRequest
 	return &reply, nil
 }
 
+// inlineLogs returns thread logs encrypted with the service key, or nil if
+// the resulting payload would exceed MaxInlinedLogsSize.
+func (s *server) inlineLogs(tid thread.ID) ([]byte, error) {
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return nil, err
+	} else if sk == nil {
+		return nil, nil
+	}
+	info, err := s.net.store.GetThread(tid)
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.GetLogsReply{Logs: make([]*pb.Log, len(info.Logs))}
+	for i, l := range info.Logs {
+		reply.Logs[i] = logToProto(l)
+	}
+	if reply.Size() > MaxInlinedLogsSize {
+		return nil, nil
+	}
+	data, release, err := pb.MarshalPooled(reply)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return sk.Encrypt(data)
+}
+
+// gossipPeers returns the healthiest peers known to replicate the thread along with their
+// addresses, encrypted with the service key. The requester and the host are left out.
+// At most GossipPeers peers are returned, nil if none is known.
+func (s *server) gossipPeers(tid thread.ID, requester peer.ID, limit int) ([]byte, error) {
+	if limit > GossipPeers {
+		limit = GossipPeers
+	}
+	sk, err := s.net.store.ServiceKey(tid)
+	if err != nil {
+		return nil, err
+	} else if sk == nil {
+		return nil, nil
+	}
+	var gossip pb.GossipPeers
+	for _, p := range s.net.bootstrap.list(tid) {
+		if len(gossip.Peers) >= limit {
+			break
+		}
+		if p.ID == requester || p.ID == s.net.host.ID() || len(p.Addrs) == 0 {
+			continue
+		}
+		gp := &pb.GossipPeers_Peer{
+			PeerID: &pb.ProtoPeerID{ID: p.ID},
+			Addrs:  make([]pb.ProtoAddr, len(p.Addrs)),
+		}
+		for i, a := range p.Addrs {
+			gp.Addrs[i] = pb.ProtoAddr{Multiaddr: a}
+		}
+		gossip.Peers = append(gossip.Peers, gp)
+	}
+	if len(gossip.Peers) == 0 {
+		return nil, nil
+	}
+	data, err := gossip.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return sk.Encrypt(data)
+}
+
 // checkServiceKey compares a key with the one stored under thread.
 func (s *server) checkServiceKey(id thread.ID, k *pb.ProtoKey) error {
 	if k == nil || k.Key == nil {
@@ -450,6 +929,15 @@ func logToProto(l thread.LogInfo) *pb.Log {
 	}
 }
 
+// logAddrsEdge returns a deterministic hash of the log's addresses.
+func logAddrsEdge(l thread.LogInfo) uint64 {
+	as := make([]util.PeerAddr, len(l.Addrs))
+	for i, a := range l.Addrs {
+		as[i] = util.PeerAddr{PeerID: l.ID, Addr: a}
+	}
+	return util.ComputeAddrsEdge(as)
+}
+
 // logFromProto returns a thread log from a proto log.
 func logFromProto(l *pb.Log) thread.LogInfo {
 	return thread.LogInfo{
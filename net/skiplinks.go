@@ -0,0 +1,127 @@
+package net
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+// metaSkipLinks is the metadata key of threads the host adds skip links to, see core.WithSkipLinks.
+const metaSkipLinks = "records:skips"
+
+// skipLinks returns the skip links of a record appended to a log of a thread with the given head.
+// Link k of the new record at position n is link k-1 of the record at n-2^(k-1),
+// so links are resolved with a record load each. Links are left out once a record
+// on the way is missing locally or was created without skip links, and in threads
+// without skip links enabled, whose peers may not verify them.
+func (n *net) skipLinks(ctx context.Context, id thread.ID, head thread.Head, sk *sym.Key) ([]cid.Cid, error) {
+	if !head.ID.Defined() || head.Counter == thread.CounterUndef {
+		return nil, nil
+	}
+	if enabled, err := n.store.GetBool(id, metaSkipLinks); err != nil {
+		return nil, err
+	} else if enabled == nil || !*enabled {
+		return nil, nil
+	}
+	count := cbor.SkipLinkCount(head.Counter + 1)
+	if count == 0 {
+		return nil, nil
+	}
+	var (
+		skips = make([]cid.Cid, 0, count)
+		link  = head.ID
+	)
+	for k := 1; k <= count; k++ {
+		if known, err := n.isKnown(link); err != nil {
+			return nil, err
+		} else if !known {
+			break
+		}
+		rec, err := cbor.GetRecord(ctx, n, link, sk)
+		if err != nil {
+			return nil, err
+		}
+		if k == 1 {
+			link = rec.PrevID()
+		} else if prev := rec.SkipIDs(); len(prev) >= k-1 {
+			link = prev[k-2]
+		} else {
+			break
+		}
+		skips = append(skips, link)
+	}
+	return skips, nil
+}
+
+func (n *net) GetAncestryProof(ctx context.Context, id thread.ID, lid peer.ID, rid cid.Cid) (core.AncestryProof, error) {
+	lg, err := n.store.GetLog(id, lid)
+	if err != nil {
+		return core.AncestryProof{}, err
+	}
+	if lg.Head.Counter == thread.CounterUndef {
+		return core.AncestryProof{}, fmt.Errorf("log %s has no record counter", lid)
+	}
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return core.AncestryProof{}, err
+	}
+	if sk == nil {
+		return core.AncestryProof{}, fmt.Errorf("a service-key is required to get records")
+	}
+
+	// find the record position walking back from the head
+	pos := lg.Head.Counter
+	for cursor := lg.Head.ID; !cursor.Equals(rid); pos-- {
+		if !cursor.Defined() || pos <= 1 {
+			return core.AncestryProof{}, fmt.Errorf("record %s not found in log %s", rid, lid)
+		}
+		rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			return core.AncestryProof{}, err
+		}
+		cursor = rec.PrevID()
+	}
+	return n.ancestryProof(ctx, lg.Head, pos, sk)
+}
+
+// recordPositions returns the positions of the given records in a log, records which
+// aren't on the log chain are left out.
+func (n *net) recordPositions(ctx context.Context, id thread.ID, lid peer.ID, rids []pb.ProtoCid) (map[cid.Cid]int64, error) {
+	positions := make(map[cid.Cid]int64, len(rids))
+	err := n.withLogIndex(ctx, id, lid, func(idx *logIndex) error {
+		for _, rid := range rids {
+			if pos := idx.position(rid.Cid); pos > 0 {
+				positions[rid.Cid] = pos
+			}
+		}
+		return nil
+	})
+	return positions, err
+}
+
+// ancestryProof returns the proof of the record at position pos of the log with the given head.
+func (n *net) ancestryProof(ctx context.Context, head thread.Head, pos int64, sk *sym.Key) (core.AncestryProof, error) {
+	proof := core.AncestryProof{Head: head, Position: pos}
+	var (
+		cursor = head.ID
+		at     = head.Counter
+	)
+	for {
+		rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			return core.AncestryProof{}, err
+		}
+		proof.Records = append(proof.Records, rec)
+		if at == pos {
+			return proof, nil
+		}
+		cursor, at = cbor.NextAncestor(rec, at, pos)
+	}
+}
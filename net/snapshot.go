@@ -0,0 +1,280 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/queue"
+	"github.com/textileio/go-threads/synctrace"
+)
+
+var (
+	// SnapshotMaxErrors is the number of recent failed exchanges kept per thread for snapshots.
+	SnapshotMaxErrors = 32
+
+	// SnapshotMaxTraceEvents is the number of the most recent sync trace events in a snapshot.
+	SnapshotMaxTraceEvents = 256
+)
+
+// Exchange operations with thread peers.
+const (
+	opGetLogs       = "getLogs"
+	opGetRecords    = "getRecords"
+	opPushLog       = "pushLog"
+	opPushRecord    = "pushRecord"
+	opAcceptRecord  = "acceptRecord"
+	opExchangeEdges = "exchangeEdges"
+)
+
+// ThreadSnapshotter dumps the sync state of a thread, it's implemented by the threads network.
+type ThreadSnapshotter interface {
+	ThreadSnapshot(ctx context.Context, id thread.ID) (ThreadSnapshot, error)
+}
+
+var _ ThreadSnapshotter = (*net)(nil)
+
+// ThreadSnapshot is everything relevant to the sync state of a thread, meant to be
+// encoded as JSON and attached to bug reports.
+type ThreadSnapshot struct {
+	Thread       string        `json:"thread"`
+	Host         string        `json:"host"`
+	Time         time.Time     `json:"time"`
+	Tags         []string      `json:"tags,omitempty"`
+	LastActivity time.Time     `json:"lastActivity"`
+	LastSync     time.Time     `json:"lastSync"`
+	Health       string        `json:"health"`
+	Usage        int64         `json:"usage"`
+	Quota        int64         `json:"quota,omitempty"`
+	Frozen       bool          `json:"frozen,omitempty"`
+	Followers    []string      `json:"followers,omitempty"`
+	Logs         []LogSnapshot `json:"logs"`
+	// Pending are records held until their logs arrive, by log.
+	Pending map[string]int `json:"pending,omitempty"`
+	// Repairs is the number of damaged records waiting to be re-fetched from peers.
+	Repairs int           `json:"repairs,omitempty"`
+	Queues  QueueSnapshot `json:"queues"`
+	// Exchanges are the latest exchanges with each thread peer, by operation.
+	Exchanges []PeerExchange `json:"exchanges,omitempty"`
+	// Errors are the most recent failed exchanges, oldest first.
+	Errors []PeerExchange          `json:"errors,omitempty"`
+	Lag    queue.HistogramSnapshot `json:"lag"`
+	Trace  []synctrace.Event       `json:"trace,omitempty"`
+}
+
+// LogSnapshot is the logstore entry of a thread log.
+type LogSnapshot struct {
+	ID      string   `json:"id"`
+	Head    string   `json:"head,omitempty"`
+	Counter int64    `json:"counter"`
+	Addrs   []string `json:"addrs,omitempty"`
+	Managed bool     `json:"managed,omitempty"`
+	Own     bool     `json:"own,omitempty"`
+}
+
+// QueueSnapshot are the calls of a thread waiting in the sync queues or in-flight.
+type QueueSnapshot struct {
+	GetLogs    []QueuedCall `json:"getLogs,omitempty"`
+	GetRecords []QueuedCall `json:"getRecords,omitempty"`
+}
+
+// QueuedCall is a call to a thread peer, see queue.ThreadCall.
+type QueuedCall struct {
+	Peer      string    `json:"peer"`
+	Priority  int       `json:"priority,omitempty"`
+	Scheduled time.Time `json:"scheduled"`
+	InFlight  bool      `json:"inFlight,omitempty"`
+}
+
+// PeerExchange is the outcome of an exchange with a thread peer.
+type PeerExchange struct {
+	Peer     string        `json:"peer"`
+	Op       string        `json:"op"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ThreadSnapshot dumps the sync state of a thread.
+func (n *net) ThreadSnapshot(ctx context.Context, id thread.ID) (ThreadSnapshot, error) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return ThreadSnapshot{}, err
+	}
+	summary, err := n.threadSummary(id)
+	if err != nil {
+		return ThreadSnapshot{}, err
+	}
+	s := ThreadSnapshot{
+		Thread:       id.String(),
+		Host:         n.host.ID().String(),
+		Time:         n.clock.Now(),
+		Tags:         summary.Tags,
+		LastActivity: summary.LastActivity,
+		LastSync:     summary.LastSync,
+		Health:       summary.Health.String(),
+		Usage:        summary.Usage,
+		Quota:        summary.Quota,
+		Lag:          n.syncLag.Thread(id),
+	}
+	if _, s.Frozen, err = n.frozenHeight(id, ""); err != nil {
+		return ThreadSnapshot{}, err
+	}
+	followers, err := n.followerPeers(id)
+	if err != nil {
+		return ThreadSnapshot{}, err
+	}
+	for _, pid := range followers {
+		s.Followers = append(s.Followers, pid.String())
+	}
+
+	sort.Slice(info.Logs, func(i, j int) bool {
+		return info.Logs[i].ID < info.Logs[j].ID
+	})
+	for _, lg := range info.Logs {
+		ls := LogSnapshot{
+			ID:      lg.ID.String(),
+			Counter: lg.Head.Counter,
+			Managed: lg.Managed,
+			Own:     lg.PrivKey != nil,
+		}
+		if lg.Head.ID.Defined() {
+			ls.Head = lg.Head.ID.String()
+		}
+		for _, a := range lg.Addrs {
+			ls.Addrs = append(ls.Addrs, a.String())
+		}
+		s.Logs = append(s.Logs, ls)
+	}
+	for lid, count := range n.pending.counts(id) {
+		if s.Pending == nil {
+			s.Pending = make(map[string]int)
+		}
+		s.Pending[lid.String()] = count
+	}
+
+	s.Repairs = n.repairs.pending(id)
+	s.Queues.GetLogs = queuedCalls(n.queueGetLogs.ThreadCalls(id))
+	s.Queues.GetRecords = queuedCalls(n.queueGetRecords.ThreadCalls(id))
+	s.Exchanges, s.Errors = n.journal.get(id)
+	if trace := n.trace.Events(id); len(trace) > SnapshotMaxTraceEvents {
+		s.Trace = trace[len(trace)-SnapshotMaxTraceEvents:]
+	} else {
+		s.Trace = trace
+	}
+	return s, nil
+}
+
+func queuedCalls(calls []queue.ThreadCall) []QueuedCall {
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Peer < calls[j].Peer
+	})
+	res := make([]QueuedCall, len(calls))
+	for i, c := range calls {
+		res[i] = QueuedCall{
+			Peer:      c.Peer.String(),
+			Priority:  c.Priority,
+			Scheduled: c.Scheduled,
+			InFlight:  c.InFlight,
+		}
+	}
+	return res
+}
+
+// observeExchange records the outcome of an exchange with a thread peer, which started at start.
+func (n *net) observeExchange(tid thread.ID, pid peer.ID, op string, start time.Time, err error) {
+	e := PeerExchange{
+		Peer:     pid.String(),
+		Op:       op,
+		Time:     start,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	n.journal.observe(tid, e)
+	n.exportExchange(tid, e)
+	n.keepalive.observe(pid, n.clock.Now(), err)
+}
+
+// exportExchange writes an exchange with a thread peer to the sync event exporter, if any.
+func (n *net) exportExchange(tid thread.ID, e PeerExchange) {
+	if n.events == nil {
+		return
+	}
+	fields := map[string]interface{}{
+		"thread":     tid.String(),
+		"peer":       e.Peer,
+		"op":         e.Op,
+		"durationMs": e.Duration.Milliseconds(),
+	}
+	if len(e.Error) != 0 {
+		fields["error"] = e.Error
+	}
+	if err := n.events.Export("net", "exchange", fields); err != nil {
+		log.Debugf("exporting exchange with %s (thread %s) failed: %v", e.Peer, tid, err)
+	}
+}
+
+// syncJournal keeps the latest exchange of each operation with each thread peer,
+// and the most recent failed ones, for thread snapshots.
+type syncJournal struct {
+	lk      sync.Mutex
+	threads map[thread.ID]*threadJournal
+}
+
+type threadJournal struct {
+	latest map[string]PeerExchange
+	errors []PeerExchange
+}
+
+func newSyncJournal() *syncJournal {
+	return &syncJournal{threads: make(map[thread.ID]*threadJournal)}
+}
+
+func (j *syncJournal) observe(tid thread.ID, e PeerExchange) {
+	j.lk.Lock()
+	defer j.lk.Unlock()
+	tj, ok := j.threads[tid]
+	if !ok {
+		tj = &threadJournal{latest: make(map[string]PeerExchange)}
+		j.threads[tid] = tj
+	}
+	tj.latest[e.Peer+"/"+e.Op] = e
+	if len(e.Error) != 0 {
+		if len(tj.errors) >= SnapshotMaxErrors {
+			tj.errors = append(tj.errors[:0], tj.errors[len(tj.errors)-SnapshotMaxErrors+1:]...)
+		}
+		tj.errors = append(tj.errors, e)
+	}
+}
+
+// get returns the latest exchanges ordered by peer and operation, and the recent errors.
+func (j *syncJournal) get(tid thread.ID) ([]PeerExchange, []PeerExchange) {
+	j.lk.Lock()
+	defer j.lk.Unlock()
+	tj, ok := j.threads[tid]
+	if !ok {
+		return nil, nil
+	}
+	latest := make([]PeerExchange, 0, len(tj.latest))
+	for _, e := range tj.latest {
+		latest = append(latest, e)
+	}
+	sort.Slice(latest, func(i, j int) bool {
+		if latest[i].Peer != latest[j].Peer {
+			return latest[i].Peer < latest[j].Peer
+		}
+		return latest[i].Op < latest[j].Op
+	})
+	return latest, append([]PeerExchange(nil), tj.errors...)
+}
+
+func (j *syncJournal) forget(tid thread.ID) {
+	j.lk.Lock()
+	defer j.lk.Unlock()
+	delete(j.threads, tid)
+}
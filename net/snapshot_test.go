@@ -0,0 +1,123 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/logger"
+)
+
+func TestNet_ThreadSnapshot(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := n2.ThreadSnapshot(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Thread != info.ID.String() || s.Host != n2.Host().ID().String() {
+		t.Fatalf("unexpected snapshot %v", s)
+	}
+	var pulledLog *LogSnapshot
+	for i, lg := range s.Logs {
+		if lg.ID == r.LogID().String() {
+			pulledLog = &s.Logs[i]
+		}
+	}
+	if len(s.Logs) != 2 || pulledLog == nil || pulledLog.Head != r.Value().Cid().String() || pulledLog.Counter != 1 || pulledLog.Own {
+		t.Fatalf("unexpected logs %v", s.Logs)
+	}
+	var pulled bool
+	for _, e := range s.Exchanges {
+		if e.Peer == n1.Host().ID().String() && e.Op == opGetRecords && e.Error == "" {
+			pulled = true
+		}
+	}
+	if !pulled {
+		t.Fatalf("expected records exchange with %s, got %v", n1.Host().ID(), s.Exchanges)
+	}
+	if _, err := json.Marshal(s); err != nil {
+		t.Fatal(err)
+	}
+
+	// failed exchanges are kept
+	n2.observeExchange(info.ID, n1.Host().ID(), opPushLog, s.Time, fmt.Errorf("boom"))
+	if s, err = n2.ThreadSnapshot(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Errors) != 1 || s.Errors[0].Op != opPushLog || s.Errors[0].Error != "boom" {
+		t.Fatalf("unexpected errors %v", s.Errors)
+	}
+}
+
+func TestSyncJournal(t *testing.T) {
+	t.Parallel()
+	tid := thread.NewIDV1(thread.Raw, 32)
+	j := newSyncJournal()
+	for i := 0; i < SnapshotMaxErrors+5; i++ {
+		j.observe(tid, PeerExchange{Peer: "a", Op: opGetLogs, Error: fmt.Sprint(i)})
+	}
+	j.observe(tid, PeerExchange{Peer: "a", Op: opGetRecords})
+	latest, errs := j.get(tid)
+	if len(latest) != 2 || latest[0].Op != opGetLogs || latest[1].Op != opGetRecords {
+		t.Fatalf("unexpected latest exchanges %v", latest)
+	}
+	if len(errs) != SnapshotMaxErrors || errs[0].Error != "5" || errs[len(errs)-1].Error != fmt.Sprint(SnapshotMaxErrors+4) {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	j.forget(tid)
+	if latest, errs = j.get(tid); latest != nil || errs != nil {
+		t.Fatal("expected forgotten thread to have no exchanges")
+	}
+}
+
+func TestNet_ExportExchange(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+	var buf bytes.Buffer
+	n.events = logger.NewEventExporter(&buf)
+
+	tid := thread.NewIDV1(thread.Raw, 32)
+	n.observeExchange(tid, n.Host().ID(), opPushRecord, time.Now(), fmt.Errorf("boom"))
+	var e map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e["event"] != "exchange" || e["thread"] != tid.String() || e["op"] != opPushRecord || e["error"] != "boom" {
+		t.Fatalf("unexpected exported exchange %v", e)
+	}
+}
@@ -0,0 +1,166 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/queue"
+)
+
+const (
+	// metadata keys of the thread sync policy
+	metaSyncMaxPull     = "sync:maxPull"
+	metaSyncRetryBudget = "sync:retryBudget"
+	metaSyncStaleness   = "sync:staleness"
+)
+
+// SetSyncPolicy bounds the sync of the thread, so a large or misbehaving thread doesn't
+// hold up the sync of other threads. The zero policy removes the bounds.
+func (n *net) SetSyncPolicy(id thread.ID, p core.SyncPolicy) error {
+	if p.MaxPullDuration < 0 || p.RetryBudget < 0 || p.StalenessTolerance < 0 {
+		return fmt.Errorf("invalid sync policy %+v", p)
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if err := n.store.PutInt64(id, metaSyncMaxPull, int64(p.MaxPullDuration)); err != nil {
+		return err
+	}
+	if err := n.store.PutInt64(id, metaSyncRetryBudget, int64(p.RetryBudget)); err != nil {
+		return err
+	}
+	return n.store.PutInt64(id, metaSyncStaleness, int64(p.StalenessTolerance))
+}
+
+// SyncPolicy returns the sync policy of the thread.
+func (n *net) SyncPolicy(id thread.ID) (p core.SyncPolicy, err error) {
+	v, err := n.store.GetInt64(id, metaSyncMaxPull)
+	if err != nil {
+		return
+	} else if v != nil {
+		p.MaxPullDuration = time.Duration(*v)
+	}
+	if v, err = n.store.GetInt64(id, metaSyncRetryBudget); err != nil {
+		return
+	} else if v != nil {
+		p.RetryBudget = int(*v)
+	}
+	if v, err = n.store.GetInt64(id, metaSyncStaleness); err != nil {
+		return
+	} else if v != nil {
+		p.StalenessTolerance = time.Duration(*v)
+	}
+	return p, nil
+}
+
+// staleAfter returns the duration after the last sync the thread is reported as stale.
+func staleAfter(p core.SyncPolicy) time.Duration {
+	if p.StalenessTolerance > SyncStaleAfter {
+		return p.StalenessTolerance
+	}
+	return SyncStaleAfter
+}
+
+// tolerateStaleness returns whether the thread was synced within its staleness tolerance,
+// so it can be left out of periodic exchanges.
+func (n *net) tolerateStaleness(tid thread.ID) bool {
+	p, err := n.SyncPolicy(tid)
+	if err != nil || p.StalenessTolerance == 0 {
+		return false
+	}
+	synced, err := n.store.GetInt64(tid, metaLastSync)
+	if err != nil || synced == nil {
+		return false
+	}
+	return n.clock.Since(time.Unix(0, *synced)) < p.StalenessTolerance
+}
+
+// retryBudgets counts the failed pulls of threads in the current exchange round.
+type retryBudgets struct {
+	lock     sync.Mutex
+	failures map[thread.ID]int
+}
+
+func newRetryBudgets() *retryBudgets {
+	return &retryBudgets{failures: make(map[thread.ID]int)}
+}
+
+// reset starts a new exchange round of the thread.
+func (b *retryBudgets) reset(tid thread.ID) {
+	b.lock.Lock()
+	delete(b.failures, tid)
+	b.lock.Unlock()
+}
+
+func (b *retryBudgets) fail(tid thread.ID) {
+	b.lock.Lock()
+	b.failures[tid]++
+	b.lock.Unlock()
+}
+
+// allow returns whether the thread has failed fewer pulls than its budget in the current round.
+func (b *retryBudgets) allow(tid thread.ID, budget int) bool {
+	if budget == 0 {
+		return true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.failures[tid] < budget
+}
+
+// policyQueue applies the sync policies of threads to the calls of a queue. Scheduled calls
+// of threads which exhausted their retry budget are skipped, while direct calls are always
+// made, but count towards the budget.
+type policyQueue struct {
+	queue.CallQueue
+	n *net
+}
+
+func newPolicyQueue(n *net, q queue.CallQueue) *policyQueue {
+	return &policyQueue{CallQueue: q, n: n}
+}
+
+func (q *policyQueue) Call(pid peer.ID, tid thread.ID, call queue.PeerCall) error {
+	return q.CallQueue.Call(pid, tid, q.bound(call, false))
+}
+
+func (q *policyQueue) Schedule(pid peer.ID, tid thread.ID, priority int, call queue.PeerCall) bool {
+	return q.CallQueue.Schedule(pid, tid, priority, q.bound(call, true))
+}
+
+// bound wraps a call with the pull deadline and retry budget of its thread.
+// Calls of threads with paused sync are skipped.
+func (q *policyQueue) bound(call queue.PeerCall, skippable bool) queue.PeerCall {
+	return func(ctx context.Context, pid peer.ID, tid thread.ID) error {
+		if !q.n.syncs.enter(tid) {
+			if skippable {
+				log.Debugf("skip call to [%s/%s]: sync paused", pid, tid)
+				return nil
+			}
+			return core.ErrSyncPaused
+		}
+		defer q.n.syncs.leave(tid)
+		p, err := q.n.SyncPolicy(tid)
+		if err != nil {
+			return err
+		}
+		if skippable && !q.n.retries.allow(tid, p.RetryBudget) {
+			log.Debugf("skip call to [%s/%s]: retry budget of %d exhausted", pid, tid, p.RetryBudget)
+			return nil
+		}
+		if p.MaxPullDuration > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.MaxPullDuration)
+			defer cancel()
+		}
+		if err = call(ctx, pid, tid); err != nil {
+			q.n.retries.fail(tid)
+		}
+		return err
+	}
+}
@@ -0,0 +1,101 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_SyncPolicy(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	policy := core.SyncPolicy{
+		MaxPullDuration:    time.Millisecond * 50,
+		RetryBudget:        1,
+		StalenessTolerance: SyncStaleAfter * 2,
+	}
+	info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadSyncPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := n.SyncPolicy(info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != policy {
+		t.Fatalf("expected policy %+v, got %+v", policy, got)
+	}
+	if err := n.SetSyncPolicy(info.ID, core.SyncPolicy{RetryBudget: -1}); err == nil {
+		t.Fatal("expected negative retry budget to be rejected")
+	}
+
+	t.Run("pull deadline and retry budget", func(t *testing.T) {
+		var calls int
+		failing := func(ctx context.Context, _ peer.ID, _ thread.ID) error {
+			calls++
+			if _, ok := ctx.Deadline(); !ok {
+				t.Fatal("expected call to be bounded by the max pull duration")
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		q := newPolicyQueue(n, nil)
+		pid := n.Host().ID()
+		if err := q.bound(failing, true)(ctx, pid, info.ID); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline to be exceeded, got %v", err)
+		}
+		if err := q.bound(failing, true)(ctx, pid, info.ID); err != nil || calls != 1 {
+			t.Fatalf("expected scheduled call beyond the retry budget to be skipped, got %v", err)
+		}
+		_ = q.bound(failing, false)(ctx, pid, info.ID)
+		if calls != 2 {
+			t.Fatal("expected direct call to be made regardless of the retry budget")
+		}
+		n.retries.reset(info.ID)
+		_ = q.bound(failing, true)(ctx, pid, info.ID)
+		if calls != 3 {
+			t.Fatal("expected retry budget to be restored in the next round")
+		}
+	})
+
+	t.Run("staleness tolerance", func(t *testing.T) {
+		if n.tolerateStaleness(info.ID) {
+			t.Fatal("expected unsynced thread to be pulled")
+		}
+		synced := time.Now().Add(-SyncStaleAfter - time.Second)
+		if err := n.store.PutInt64(info.ID, metaLastSync, synced.UnixNano()); err != nil {
+			t.Fatal(err)
+		}
+		if !n.tolerateStaleness(info.ID) {
+			t.Fatal("expected thread synced within its tolerance to be skipped")
+		}
+		summary, err := n.threadSummary(info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if summary.Health != core.SyncHealthSynced {
+			t.Fatalf("expected thread to be synced within its tolerance, got %s", summary.Health)
+		}
+
+		if err := n.SetSyncPolicy(info.ID, core.SyncPolicy{}); err != nil {
+			t.Fatal(err)
+		}
+		if n.tolerateStaleness(info.ID) {
+			t.Fatal("expected thread without tolerance to be pulled")
+		}
+		if summary, err = n.threadSummary(info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if summary.Health != core.SyncHealthStale {
+			t.Fatalf("expected thread to be stale, got %s", summary.Health)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package net
+
+import (
+	"context"
+
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/queue"
+)
+
+// SyncStats is a snapshot of sync metrics, accumulated since the network was created.
+type SyncStats struct {
+	// GetLogs is the queue of log updates scheduled with peers.
+	GetLogs queue.Stats
+	// GetRecords is the queue of record pulls scheduled with peers.
+	GetRecords queue.Stats
+	// Lag is the time between creation of remote heads and their local application, across threads.
+	Lag queue.HistogramSnapshot
+}
+
+// SyncStats returns the current sync metrics, e.g. for alerting on degraded sync.
+func (n *net) SyncStats() SyncStats {
+	return SyncStats{
+		GetLogs:    n.queueGetLogs.Stats(),
+		GetRecords: n.queueGetRecords.Stats(),
+		Lag:        n.syncLag.Total(),
+	}
+}
+
+// ThreadSyncLag returns the sync lag of a thread, which is empty until remote records are applied.
+func (n *net) ThreadSyncLag(id thread.ID) queue.HistogramSnapshot {
+	return n.syncLag.Thread(id)
+}
+
+// observeSyncLag accounts the sync lag of a remote record applied locally, based on the
+// creation time of its event. Records of older hosts and records without local events,
+// e.g. on light clients, are skipped.
+func (n *net) observeSyncLag(ctx context.Context, tid thread.ID, rec core.Record) {
+	if known, err := n.isKnown(rec.BlockID()); err != nil || !known {
+		return
+	}
+	key, err := n.headerKey(tid)
+	if err != nil || key == nil {
+		return
+	}
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		log.Debugf("getting event of record %s failed: %v", rec.Cid(), err)
+		return
+	}
+	header, err := event.GetHeader(ctx, n, key)
+	if err != nil {
+		log.Debugf("getting header of record %s failed: %v", rec.Cid(), err)
+		return
+	}
+	created, err := header.Time()
+	if err != nil || created.IsZero() {
+		return
+	}
+	n.syncLag.Observe(tid, n.clock.Since(created))
+}
@@ -0,0 +1,73 @@
+package net
+
+import (
+	"context"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/synctrace"
+)
+
+// traceLog records a log added to a thread, if sync tracing is enabled.
+func (n *net) traceLog(tid thread.ID, lg thread.LogInfo) {
+	if n.trace == nil {
+		return
+	}
+	e := synctrace.Event{
+		Host: n.host.ID().String(),
+		Kind: synctrace.KindLog,
+		Log:  lg.ID.String(),
+	}
+	if lg.PubKey != nil {
+		if pk, err := crypto.MarshalPublicKey(lg.PubKey); err == nil {
+			e.PubKey = pk
+		}
+	}
+	n.trace.Record(tid, e)
+}
+
+// traceRecords records records of a log created or received from a peer, along
+// with the resulting log head, if sync tracing is enabled.
+func (n *net) traceRecords(
+	ctx context.Context,
+	kind synctrace.Kind,
+	tid thread.ID,
+	lid peer.ID,
+	recs []core.Record,
+	counter int64,
+	base cid.Cid,
+	err error,
+) {
+	if n.trace == nil {
+		return
+	}
+	e := synctrace.Event{
+		Host:    n.host.ID().String(),
+		Kind:    kind,
+		Log:     lid.String(),
+		Records: make([]synctrace.Record, len(recs)),
+		Counter: counter,
+	}
+	if pid, err := peerIDFromContext(ctx); err == nil {
+		e.Peer = pid.String()
+	}
+	for i, r := range recs {
+		e.Records[i].ID = r.Cid().String()
+		if r.PrevID().Defined() {
+			e.Records[i].Prev = r.PrevID().String()
+		}
+	}
+	if base.Defined() {
+		e.Base = base.String()
+	}
+	if head, herr := n.currentHead(tid, lid); herr == nil && head.ID.Defined() {
+		e.Head = synctrace.Head{ID: head.ID.String(), Counter: head.Counter}
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	n.trace.Record(tid, e)
+}
@@ -0,0 +1,151 @@
+package net
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/phayes/freeport"
+)
+
+func TestNet_TLSTransport(t *testing.T) {
+	t.Parallel()
+	p1, p2 := makeTLSPeer(t), makeTLSPeer(t)
+	n1 := makeNetworkWithKey(t, p1.sk, Config{
+		Debug:     true,
+		Transport: p1.transport(t, p2),
+	}).(*net)
+	defer n1.Close()
+	n2 := makeNetworkWithKey(t, p2.sk, Config{
+		Debug:     true,
+		Transport: p2.transport(t, p1),
+	}).(*net)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	info1, err := n1.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info1.Addrs) != 1 || !info1.Addrs[0].Decapsulate(ma.StringCast("/p2p/"+p1.id.String())).Equal(p1.maddr) {
+		t.Fatalf("expected thread address over the tls listener, got %v", info1.Addrs)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n1.AddReplicator(ctx, info.ID, p2.maddr.Encapsulate(ma.StringCast("/p2p/"+p2.id.String()))); err != nil {
+		t.Fatal(err)
+	}
+	var synced bool
+	for i := 0; i < 20 && !synced; i++ {
+		time.Sleep(time.Millisecond * 500)
+		info2, err := n2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		synced = len(info2.Logs) == 1 && info2.Logs[0].Head.ID.Equals(r.Value().Cid())
+	}
+	if !synced {
+		t.Fatal("expected record to be replicated over tls")
+	}
+
+	// peers presenting unknown certificates are rejected
+	p3 := makeTLSPeer(t)
+	conn, err := p3.transport(t, p2).Dial(ctx, p2.id)
+	if err == nil {
+		defer conn.Close()
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		if _, err = conn.Read(make([]byte, 1)); err == nil {
+			t.Fatal("expected connection with an unknown certificate to be rejected")
+		}
+	}
+}
+
+type tlsTestPeer struct {
+	sk    crypto.PrivKey
+	id    peer.ID
+	addr  string
+	maddr ma.Multiaddr
+	cert  tls.Certificate
+	x509  *x509.Certificate
+}
+
+func makeTLSPeer(t *testing.T) tlsTestPeer {
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: id.String()},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tlsTestPeer{
+		sk:    sk,
+		id:    id,
+		addr:  fmt.Sprintf("127.0.0.1:%d", port),
+		maddr: ma.StringCast(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		cert:  tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		x509:  cert,
+	}
+}
+
+func (p tlsTestPeer) transport(t *testing.T, peers ...tlsTestPeer) Transport {
+	conf := TLSConfig{
+		ListenAddr: p.addr,
+		Cert:       p.cert,
+		Peers:      make(map[peer.ID]TLSPeer),
+	}
+	for _, pp := range peers {
+		conf.Peers[pp.id] = TLSPeer{Addr: pp.addr, Cert: pp.x509}
+	}
+	tr, err := NewTLSTransport(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
@@ -0,0 +1,349 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	bs "github.com/ipfs/go-ipfs-blockstore"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	sym "github.com/textileio/go-threads/crypto/symmetric"
+)
+
+// errDamagedBlock indicates a block missing in the blockstore or not matching its cid.
+var errDamagedBlock = errors.New("block is missing or corrupt")
+
+func (n *net) VerifyThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.ThreadVerification, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.ThreadVerification{}, err
+	}
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return core.ThreadVerification{}, err
+	}
+	sk, err := n.manifestServiceKey(id)
+	if err != nil {
+		return core.ThreadVerification{}, err
+	}
+
+	redactions, err := n.redactions(id)
+	if err != nil {
+		return core.ThreadVerification{}, err
+	}
+
+	var res core.ThreadVerification
+	for _, lg := range info.Logs {
+		checked, damaged, err := n.verifyLog(ctx, lg.ID, lg.Head.ID, sk, redactions)
+		if err != nil {
+			return core.ThreadVerification{}, fmt.Errorf("verifying log %s: %w", lg.ID, err)
+		}
+		res.Records += checked
+		res.Damaged = append(res.Damaged, damaged...)
+	}
+	if len(res.Damaged) > 0 {
+		log.Warnf("found %d damaged records in thread %s, re-fetching them from peers", len(res.Damaged), id)
+		if n.repairs.add(id, res.Damaged) {
+			go n.repairRecords(id)
+		}
+	}
+	return res, nil
+}
+
+// verifyLog checks the blocks of the log records, walking back from head. A missing record
+// envelope ends the log unless it's the head, as processed records precede it otherwise,
+// e.g. the log was pruned. Records preceding a damaged envelope are checked once it's repaired.
+// Bodies of redacted records aren't checked.
+func (n *net) verifyLog(
+	ctx context.Context,
+	lid peer.ID,
+	head cid.Cid,
+	sk *sym.Key,
+	redactions map[string]redactionEntry,
+) (int64, []core.DamagedRecord, error) {
+	var (
+		checked int64
+		damaged []core.DamagedRecord
+	)
+	for cursor := head; cursor.Defined(); {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		if !cursor.Equals(head) {
+			if known, err := n.isKnown(cursor); err != nil {
+				return 0, nil, err
+			} else if !known {
+				break
+			}
+		}
+		checked++
+		dr := core.DamagedRecord{LogID: lid, RecordID: cursor}
+		node, err := n.localBlock(cursor)
+		if errors.Is(err, errDamagedBlock) {
+			dr.Blocks = []cid.Cid{cursor}
+			damaged = append(damaged, dr)
+			break
+		} else if err != nil {
+			return 0, nil, err
+		}
+		rec, err := cbor.RecordFromNode(node, sk)
+		if err != nil {
+			return 0, nil, err
+		}
+		redacted := redactions[cursor.String()].Applied
+		if dr.Blocks, err = n.verifyEvent(rec.BlockID(), redacted); err != nil {
+			return 0, nil, err
+		} else if len(dr.Blocks) > 0 {
+			damaged = append(damaged, dr)
+		}
+		cursor = rec.PrevID()
+	}
+	return checked, damaged, nil
+}
+
+// verifyEvent returns the damaged blocks of a record event. Light clients load events on
+// demand, so a missing event isn't damaged, unlike missing header and body of a stored event.
+// The body isn't checked if it was redacted.
+func (n *net) verifyEvent(id cid.Cid, redacted bool) ([]cid.Cid, error) {
+	if n.lightClient {
+		if known, err := n.isKnown(id); err != nil || !known {
+			return nil, err
+		}
+	}
+	node, err := n.localBlock(id)
+	if errors.Is(err, errDamagedBlock) {
+		return []cid.Cid{id}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	event, err := cbor.EventFromNode(node)
+	if err != nil {
+		return nil, err
+	}
+	blocks := []cid.Cid{event.HeaderID()}
+	if !redacted {
+		blocks = append(blocks, event.BodyID())
+	}
+	var damaged []cid.Cid
+	for _, c := range blocks {
+		if _, err := n.localBlock(c); errors.Is(err, errDamagedBlock) {
+			damaged = append(damaged, c)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return damaged, nil
+}
+
+// localBlock decodes a block from the blockstore, checking it matches its cid.
+func (n *net) localBlock(c cid.Cid) (format.Node, error) {
+	blk, err := n.bstore.Get(c)
+	if errors.Is(err, bs.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s not found", errDamagedBlock, c)
+	} else if err != nil {
+		return nil, err
+	}
+	if sum, err := c.Prefix().Sum(blk.RawData()); err != nil {
+		return nil, err
+	} else if !sum.Equals(c) {
+		return nil, fmt.Errorf("%w: %s doesn't match its data", errDamagedBlock, c)
+	}
+	return cbornode.DecodeBlock(blk)
+}
+
+// repairRecords re-fetches the damaged records of a thread from peers, until none is left.
+// Records peers don't have are dropped, they're reported again by the next verification.
+func (n *net) repairRecords(tid thread.ID) {
+	for {
+		damaged := n.repairs.next(tid)
+		if len(damaged) == 0 {
+			return
+		}
+		repaired, err := n.repairLogRecords(n.ctx, tid, damaged)
+		if err != nil {
+			log.Errorf("repairing %d records of log %s (thread %s) failed: %v", len(damaged), damaged[0].LogID, tid, err)
+		} else if repaired < len(damaged) {
+			log.Errorf("%d damaged records of log %s (thread %s) not found with peers",
+				len(damaged)-repaired, damaged[0].LogID, tid)
+		} else {
+			log.Infof("repaired %d records of log %s (thread %s)", repaired, damaged[0].LogID, tid)
+		}
+		n.repairs.done(tid, damaged)
+		if n.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// repairLogRecords requests damaged records of a single log from peers holding the log first,
+// then from the other thread peers, and replaces the damaged blocks with the received ones.
+func (n *net) repairLogRecords(ctx context.Context, tid thread.ID, damaged []core.DamagedRecord) (int, error) {
+	lid := damaged[0].LogID
+	lg, err := n.store.GetLog(tid, lid)
+	if err != nil {
+		return 0, err
+	}
+	peers, err := n.uniquePeers(lg.Addrs)
+	if err != nil {
+		return 0, err
+	}
+	others, err := n.recordPeers(ctx, tid)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[peer.ID]struct{}, len(peers))
+	for _, pid := range peers {
+		seen[pid] = struct{}{}
+	}
+	for _, pid := range others {
+		if _, ok := seen[pid]; !ok {
+			peers = append(peers, pid)
+		}
+	}
+
+	pending := make(map[cid.Cid]core.DamagedRecord, len(damaged))
+	for _, dr := range damaged {
+		pending[dr.RecordID] = dr
+	}
+	for _, pid := range peers {
+		if len(pending) == 0 {
+			break
+		}
+		rids := make([]cid.Cid, 0, len(pending))
+		for rid := range pending {
+			rids = append(rids, rid)
+		}
+		recs, err := n.server.getRecordsByCID(ctx, tid, pid, lid, rids)
+		if err != nil {
+			log.Debugf("getting damaged records of log %s from %s failed: %v", lid, pid, err)
+			continue
+		}
+		for _, rec := range recs {
+			if err := n.replaceRecordBlocks(ctx, tid, rec, pending[rec.Cid()].Blocks); err != nil {
+				return len(damaged) - len(pending), err
+			}
+			delete(pending, rec.Cid())
+		}
+	}
+	return len(damaged) - len(pending), nil
+}
+
+// replaceRecordBlocks stores the blocks of a received record, the damaged ones are removed
+// first as the blockstore doesn't overwrite existing blocks. Redacted records are received
+// without their bodies.
+func (n *net) replaceRecordBlocks(ctx context.Context, tid thread.ID, rec core.Record, damaged []cid.Cid) error {
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		return err
+	}
+	header, err := event.GetHeader(ctx, n, nil)
+	if err != nil {
+		return err
+	}
+	blocks := []format.Node{rec, event, header}
+	if redacted, err := n.isRedacted(tid, rec.Cid()); err != nil {
+		return err
+	} else if !redacted {
+		body, err := event.GetBody(ctx, n, nil)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, body)
+	}
+	for _, c := range damaged {
+		if err := n.bstore.DeleteBlock(c); err != nil && !errors.Is(err, bs.ErrNotFound) {
+			return err
+		}
+	}
+	return n.AddMany(ctx, blocks)
+}
+
+// recordRepairs are the damaged records waiting to be re-fetched from peers, by thread.
+type recordRepairs struct {
+	lk      sync.Mutex
+	threads map[thread.ID]map[cid.Cid]core.DamagedRecord
+}
+
+func newRecordRepairs() *recordRepairs {
+	return &recordRepairs{threads: make(map[thread.ID]map[cid.Cid]core.DamagedRecord)}
+}
+
+// add queues damaged records for repair. It returns true if the thread had none queued,
+// i.e. the caller has to start repairing it.
+func (r *recordRepairs) add(tid thread.ID, damaged []core.DamagedRecord) bool {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	pending, ok := r.threads[tid]
+	if !ok {
+		pending = make(map[cid.Cid]core.DamagedRecord, len(damaged))
+		r.threads[tid] = pending
+	}
+	for _, dr := range damaged {
+		if prev, ok := pending[dr.RecordID]; ok {
+			dr.Blocks = mergeCids(prev.Blocks, dr.Blocks)
+		}
+		pending[dr.RecordID] = dr
+	}
+	return !ok
+}
+
+// next returns the queued records of a single thread log. Once no record is left,
+// the thread is removed, so records added later start a new repair.
+func (r *recordRepairs) next(tid thread.ID) []core.DamagedRecord {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	pending := r.threads[tid]
+	var damaged []core.DamagedRecord
+	for _, dr := range pending {
+		if len(damaged) == 0 || dr.LogID == damaged[0].LogID {
+			damaged = append(damaged, dr)
+		}
+	}
+	if len(damaged) == 0 {
+		delete(r.threads, tid)
+	}
+	return damaged
+}
+
+// done removes the records handled by a repair attempt.
+func (r *recordRepairs) done(tid thread.ID, damaged []core.DamagedRecord) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	for _, dr := range damaged {
+		delete(r.threads[tid], dr.RecordID)
+	}
+}
+
+// pending returns the number of records of a thread waiting for repair.
+func (r *recordRepairs) pending(tid thread.ID) int {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	return len(r.threads[tid])
+}
+
+func mergeCids(a, b []cid.Cid) []cid.Cid {
+	res := append([]cid.Cid(nil), a...)
+	for _, c := range b {
+		var found bool
+		for _, e := range a {
+			if e.Equals(c) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, c)
+		}
+	}
+	return res
+}
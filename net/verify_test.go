@@ -0,0 +1,106 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+func TestNet_VerifyThread(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"i": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	res, err := n2.VerifyThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Records != 3 || len(res.Damaged) != 0 {
+		t.Fatalf("unexpected verification of an intact thread %v", res)
+	}
+
+	// lose the body of the first record and corrupt the event of the second one
+	event, err := cbor.EventFromRecord(ctx, n2, recs[0].Value())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.bstore.DeleteBlock(event.BodyID()); err != nil {
+		t.Fatal(err)
+	}
+	eid := recs[1].Value().BlockID()
+	if err = n2.bstore.DeleteBlock(eid); err != nil {
+		t.Fatal(err)
+	}
+	corrupt, err := blocks.NewBlockWithCid([]byte("corrupt"), eid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = n2.bstore.Put(corrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err = n2.VerifyThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if res.Records != 3 || len(res.Damaged) != 2 {
+		t.Fatalf("expected 2 damaged records, got %v", res)
+	}
+	damaged := make(map[string]string)
+	for _, dr := range res.Damaged {
+		if dr.LogID != recs[0].LogID() || len(dr.Blocks) != 1 {
+			t.Fatalf("unexpected damaged record %v", dr)
+		}
+		damaged[dr.RecordID.String()] = dr.Blocks[0].String()
+	}
+	if damaged[recs[0].Value().Cid().String()] != event.BodyID().String() ||
+		damaged[recs[1].Value().Cid().String()] != eid.String() {
+		t.Fatalf("unexpected damaged blocks %v", damaged)
+	}
+
+	// damaged records are re-fetched from the log peer
+	waitFor(t, func() bool {
+		return n2.repairs.pending(info.ID) == 0
+	})
+	if res, err = n2.VerifyThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if res.Records != 3 || len(res.Damaged) != 0 {
+		t.Fatalf("expected the thread to be repaired, got %v", res)
+	}
+	if _, err = n2.GetRecord(ctx, info.ID, recs[1].Value().Cid()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,77 @@
+package net
+
+import (
+	"context"
+	"sync"
+)
+
+// verifyChunkSize is the number of records a worker verifies at once, which amortizes
+// scheduling over cheap signature checks. libp2p keys don't support batch verification,
+// so records of a chunk are still verified one by one.
+const verifyChunkSize = 16
+
+// verifyPool bounds the record signature checks running at once across all threads,
+// while spreading the checks of large batches, e.g. during initial sync, over all workers.
+type verifyPool struct {
+	tokens chan struct{}
+}
+
+func newVerifyPool(workers int) *verifyPool {
+	return &verifyPool{tokens: make(chan struct{}, workers)}
+}
+
+// verify calls check for each of n records in chunks on the pool workers and waits for them.
+// The error of the first failing record in order is returned.
+func (p *verifyPool) verify(ctx context.Context, n int, check func(i int) error) error {
+	if n <= verifyChunkSize {
+		// a single chunk is verified by the caller, which still takes a worker
+		select {
+		case p.tokens <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		defer func() { <-p.tokens }()
+		for i := 0; i < n; i++ {
+			if err := check(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		errs = make([]error, n)
+		wg   sync.WaitGroup
+	)
+	for start := 0; start < n; start += verifyChunkSize {
+		select {
+		case p.tokens <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		end := start + verifyChunkSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-p.tokens
+				wg.Done()
+			}()
+			for i := start; i < end; i++ {
+				if errs[i] = check(i); errs[i] != nil {
+					return
+				}
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
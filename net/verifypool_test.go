@@ -0,0 +1,59 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifyPool(t *testing.T) {
+	t.Parallel()
+	p := newVerifyPool(2)
+	ctx := context.Background()
+
+	var running, peak, checked int32
+	check := func(int) error {
+		if r := atomic.AddInt32(&running, 1); r > atomic.LoadInt32(&peak) {
+			atomic.StoreInt32(&peak, r)
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&checked, 1)
+		return nil
+	}
+	if err := p.verify(ctx, 100, check); err != nil {
+		t.Fatal(err)
+	}
+	if checked != 100 {
+		t.Fatalf("expected 100 checked records, got %d", checked)
+	}
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent checks, got %d", peak)
+	}
+
+	// the first failing record in order is reported
+	err := p.verify(ctx, 100, func(i int) error {
+		if i == 70 || i == 30 {
+			return fmt.Errorf("bad record %d", i)
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "bad record 30" {
+		t.Fatalf("expected the first bad record to be reported, got %v", err)
+	}
+
+	// callers give up waiting for busy workers once cancelled
+	p.tokens <- struct{}{}
+	p.tokens <- struct{}{}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err = p.verify(cctx, 1, check); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected verification to be cancelled, got %v", err)
+	}
+	if err = p.verify(cctx, 100, check); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected verification to be cancelled, got %v", err)
+	}
+}
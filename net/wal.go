@@ -0,0 +1,105 @@
+package net
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// metaWAL prefixes the metadata keys of the pending head updates of logs. The logstore
+// and the blockstore are separate datastores, so a crash while records are put may leave
+// a head pointing at a record missing from the blockstore, or a stored record the head
+// never moved to. Head updates are recorded before they're applied and reconciled with
+// the blockstore at startup.
+const metaWAL = "wal/"
+
+// walEntry is the intent to move a log head from a head along a chain of records.
+type walEntry struct {
+	From thread.Head   `json:"from"`
+	To   []thread.Head `json:"to"`
+}
+
+// beginWAL records the intent to move a log head along the chain of records.
+// It must be committed with commitWAL once the records are put.
+func (n *net) beginWAL(tid thread.ID, lid peer.ID, from thread.Head, to []thread.Head) error {
+	val, err := json.Marshal(walEntry{From: from, To: to})
+	if err != nil {
+		return err
+	}
+	if err = n.store.PutBytes(tid, metaWAL+lid.String(), val); err != nil {
+		return fmt.Errorf("writing head update intent: %w", err)
+	}
+	return nil
+}
+
+// commitWAL clears the pending head update of a log.
+func (n *net) commitWAL(tid thread.ID, lid peer.ID) {
+	if err := n.store.PutBytes(tid, metaWAL+lid.String(), nil); err != nil {
+		log.Errorf("committing head update of log %s (thread %s) failed: %v", lid, tid, err)
+	}
+}
+
+// replayWAL reconciles the heads of logs having pending updates with the blockstore.
+// Records are added to the blockstore once they're processed, so each head is moved
+// to the last record of its update which was stored, or back to where it was before.
+func (n *net) replayWAL(ls lstore.Logstore) error {
+	tids, err := ls.Threads()
+	if err != nil {
+		return err
+	}
+	for _, tid := range tids {
+		info, err := ls.GetThread(tid)
+		if err != nil {
+			return err
+		}
+		for _, lg := range info.Logs {
+			val, err := ls.GetBytes(tid, metaWAL+lg.ID.String())
+			if err != nil {
+				return err
+			} else if val == nil || len(*val) == 0 {
+				continue
+			}
+			var entry walEntry
+			if err = json.Unmarshal(*val, &entry); err != nil {
+				return fmt.Errorf("decoding head update of log %s (thread %s): %w", lg.ID, tid, err)
+			}
+			if err = n.replayWALEntry(ls, tid, lg.ID, entry); err != nil {
+				return err
+			}
+			n.commitWAL(tid, lg.ID)
+		}
+	}
+	return nil
+}
+
+func (n *net) replayWALEntry(ls lstore.Logstore, tid thread.ID, lid peer.ID, entry walEntry) error {
+	target := entry.From
+	for _, h := range entry.To {
+		stored, err := n.isKnown(h.ID)
+		if err != nil {
+			return err
+		} else if !stored {
+			break
+		}
+		target = h
+	}
+	heads, err := ls.Heads(tid, lid)
+	if err != nil {
+		return err
+	}
+	current := thread.HeadUndef
+	if len(heads) != 0 {
+		current = heads[0]
+	}
+	if current.ID.Equals(target.ID) && current.Counter == target.Counter {
+		return nil
+	}
+	log.Warnf("recovering interrupted head update of log %s (thread %s) at %s", lid, tid, target.ID)
+	if !target.ID.Defined() {
+		return ls.SetHeads(tid, lid, nil)
+	}
+	return ls.SetHead(tid, lid, target)
+}
@@ -0,0 +1,96 @@
+package net
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_ReplayWAL(t *testing.T) {
+	t.Parallel()
+	n := makeNetwork(t).(*net)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid := tr.LogID()
+	committed := thread.Head{ID: tr.Value().Cid(), Counter: 1}
+	checkHead := func(expected thread.Head) {
+		t.Helper()
+		head, err := n.currentHead(info.ID, lid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !head.ID.Equals(expected.ID) || head.Counter != expected.Counter {
+			t.Fatalf("expected head %s/%d, got %s/%d", expected.ID, expected.Counter, head.ID, head.Counter)
+		}
+	}
+
+	// committed updates are left as they are
+	if err := n.replayWAL(n.store); err != nil {
+		t.Fatal(err)
+	}
+	checkHead(committed)
+
+	// the head was moved to a record which didn't make it to the blockstore
+	missing := thread.Head{ID: cid.NewCidV1(cid.DagCBOR, mustHash(t, "missing")), Counter: 2}
+	if err := n.beginWAL(info.ID, lid, committed, []thread.Head{missing}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.store.SetHead(info.ID, lid, missing); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.replayWAL(n.store); err != nil {
+		t.Fatal(err)
+	}
+	checkHead(committed)
+
+	// the record was stored, but the head wasn't moved to it
+	lg, err := n.store.GetLog(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	rec, err := n.newRecord(ctx, info.ID, lg, body, pk, cbor.EventHeaderConfig{}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := thread.Head{ID: rec.Cid(), Counter: 2}
+	if err := n.beginWAL(info.ID, lid, committed, []thread.Head{stored, missing}); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.replayWAL(n.store); err != nil {
+		t.Fatal(err)
+	}
+	checkHead(stored)
+
+	// replayed updates are cleared
+	if err := n.store.SetHead(info.ID, lid, committed); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.replayWAL(n.store); err != nil {
+		t.Fatal(err)
+	}
+	checkHead(committed)
+}
+
+func mustHash(t *testing.T, s string) mh.Multihash {
+	h, err := mh.Sum([]byte(s), mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
@@ -0,0 +1,528 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	"github.com/textileio/go-threads/clock"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// MaxQuarantinedRecords is the max number of records quarantined across threads. Records over
+// the limit aren't quarantined, they're pulled again later.
+var MaxQuarantinedRecords = 1024
+
+const (
+	// metaWriteLimits is the metadata key of the marshaled write limits of a thread.
+	metaWriteLimits = "writelimits"
+
+	// metaDiscardedRecords is the metadata key of the marshaled first discarded record by
+	// log ID. Logs aren't accepted past their discarded records.
+	metaDiscardedRecords = "writelimits:discarded"
+)
+
+func (n *net) SetWriteLimits(
+	ctx context.Context,
+	id thread.ID,
+	limits core.WriteLimits,
+	opts ...core.ThreadOption,
+) (core.ThreadRecord, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	identity, err := n.Validate(id, args.Token, false)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	}
+	if _, frozen, err := n.frozenHeight(id, ""); err != nil {
+		return nil, err
+	} else if frozen {
+		return nil, core.ErrThreadFrozen
+	}
+	owner, err := n.logApprovalOwner(id)
+	if err != nil {
+		return nil, err
+	} else if owner == nil {
+		return nil, core.ErrNoThreadOwner
+	}
+	author, err := identity.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(owner, author) {
+		return nil, core.ErrNotThreadOwner
+	}
+	signer, err := n.identitySigner(identity, args.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := json.Marshal(limits)
+	if err != nil {
+		return nil, err
+	}
+	exts := core.RecordExtensions{
+		core.ExtensionKey(core.ExtensionNamespaceThreads, core.ExtensionWriteLimits): val,
+	}
+	if err = exts.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"limits": string(val),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		return nil, err
+	}
+	tr, head, _, err := n.appendRecord(ctx, id, body, identity, "", core.WriteLimitsRecordType, exts, signer)
+	if err != nil {
+		return nil, err
+	}
+	if err = n.observeWriteLimits(id, tr.LogID(), tr.Value()); err != nil {
+		return nil, err
+	}
+	log.Debugf("declared write limits with %s (thread=%s)", tr.Value().Cid(), id)
+	if err = n.publishRecord(ctx, id, tr, head, args.Priority); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+func (n *net) GetWriteLimits(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.WriteLimits, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return core.WriteLimits{}, err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return core.WriteLimits{}, err
+	}
+	limits, err := n.writeLimits(id)
+	if err != nil || limits == nil {
+		return core.WriteLimits{}, err
+	}
+	return *limits, nil
+}
+
+func (n *net) QuarantinedRecords(ctx context.Context, id thread.ID, opts ...core.ThreadOption) ([]core.QuarantinedRecord, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return nil, err
+	}
+	return n.held.list(id), nil
+}
+
+func (n *net) ReleaseQuarantined(ctx context.Context, id thread.ID, lid peer.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	entries := n.held.take(id, lid)
+	if len(entries) == 0 {
+		return core.ErrLogNotQuarantined
+	}
+	recs := make([]core.Record, len(entries))
+	for i, e := range entries {
+		recs[i] = e.rec
+	}
+	n.held.exempt(recs, true)
+	defer n.held.exempt(recs, false)
+	log.Debugf("releasing %d quarantined records of log %s (thread %s)", len(recs), lid, id)
+	return n.putRecords(ctx, id, lid, recs, entries[len(entries)-1].counter, cid.Undef)
+}
+
+func (n *net) DiscardQuarantined(ctx context.Context, id thread.ID, lid peer.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	entries := n.held.take(id, lid)
+	if len(entries) == 0 {
+		return core.ErrLogNotQuarantined
+	}
+	discarded, err := n.discardedRecords(id)
+	if err != nil {
+		return err
+	}
+	discarded[lid.String()] = entries[0].rec.Cid()
+	val, err := json.Marshal(discarded)
+	if err != nil {
+		return err
+	}
+	log.Debugf("discarded %d quarantined records of log %s (thread %s)", len(entries), lid, id)
+	return n.store.PutBytes(id, metaDiscardedRecords, val)
+}
+
+// writeLimits returns the write limits of a thread, which are nil if they weren't declared.
+func (n *net) writeLimits(tid thread.ID) (*core.WriteLimits, error) {
+	val, err := n.store.GetBytes(tid, metaWriteLimits)
+	if err != nil || val == nil || len(*val) == 0 {
+		return nil, err
+	}
+	limits := &core.WriteLimits{}
+	if err = json.Unmarshal(*val, limits); err != nil {
+		return nil, fmt.Errorf("decoding write limits of thread %s: %w", tid, err)
+	}
+	return limits, nil
+}
+
+// discardedRecords returns the first discarded record of the logs of a thread, by log ID.
+func (n *net) discardedRecords(tid thread.ID) (map[string]cid.Cid, error) {
+	discarded := make(map[string]cid.Cid)
+	val, err := n.store.GetBytes(tid, metaDiscardedRecords)
+	if err != nil {
+		return nil, err
+	} else if val == nil || len(*val) == 0 {
+		return discarded, nil
+	}
+	if err = json.Unmarshal(*val, &discarded); err != nil {
+		return nil, fmt.Errorf("decoding discarded records of thread %s: %w", tid, err)
+	}
+	return discarded, nil
+}
+
+// observeWriteLimits stores the write limits declared by a write limits record of a thread log.
+// Limits which aren't signed by the thread owner are ignored, see core.ExtensionIdentitySig.
+func (n *net) observeWriteLimits(tid thread.ID, lid peer.ID, rec core.Record) error {
+	val, ok := rec.Extensions().Get(core.ExtensionNamespaceThreads, core.ExtensionWriteLimits)
+	if !ok {
+		return nil
+	}
+	owner, err := n.logApprovalOwner(tid)
+	if err != nil || owner == nil {
+		return err
+	}
+	if !n.signedByOwner(tid, lid, rec, owner) {
+		log.Warnf("ignoring write limits %s without a valid owner signature (thread %s)", rec.Cid(), tid)
+		return nil
+	}
+	var limits core.WriteLimits
+	if err = json.Unmarshal(val, &limits); err != nil {
+		log.Warnf("ignoring invalid write limits %s (thread %s): %v", rec.Cid(), tid, err)
+		return nil
+	}
+	return n.store.PutBytes(tid, metaWriteLimits, val)
+}
+
+// throttleRecords returns the number of records of a chain to be applied. The first record of
+// an identity exceeding its write limit is quarantined along with the rest of the chain, which
+// can't be linked without it. Records are metered by identity only if they're signed by it,
+// the identities they claim otherwise are ignored and they're metered by log under the default
+// limit. Chains are cut at discarded records too.
+func (n *net) throttleRecords(ctx context.Context, tid thread.ID, lid peer.ID, chain []core.ThreadRecord, counter int64) (int, error) {
+	limits, err := n.writeLimits(tid)
+	if err != nil {
+		return 0, err
+	}
+	discarded, err := n.discardedRecords(tid)
+	if err != nil {
+		return 0, err
+	}
+	stop, hasStop := discarded[lid.String()]
+	if limits == nil && !hasStop {
+		return len(chain), nil
+	}
+	owner, err := n.logApprovalOwner(tid)
+	if err != nil {
+		return 0, err
+	}
+	for i, r := range chain {
+		rec := r.Value()
+		if hasStop && rec.Cid().Equals(stop) {
+			return i, nil
+		}
+		if limits == nil || n.held.isExempt(rec.Cid()) {
+			continue
+		}
+		// unsigned records share the default limit of their log
+		limit, metered := limits.Default, "log:"+lid.String()
+		author, verified := verifiedIdentity(tid, lid, rec)
+		if verified {
+			if n.signedByOwner(tid, lid, rec, owner) {
+				continue
+			}
+			limit, metered = limits.Limit(author), author.String()
+		}
+		if limit.IsZero() || n.meter.admit(tid, metered, n.storedSize(ctx, rec), limit) {
+			continue
+		}
+		log.Debugf("%s exceeded its write limit, quarantining %d records of log %s (thread %s)",
+			metered, len(chain)-i, lid, tid)
+		n.held.hold(tid, lid, n.quarantinedEntries(ctx, tid, lid, chain[i:], counter+int64(i)))
+		return i, nil
+	}
+	return len(chain), nil
+}
+
+func (n *net) quarantinedEntries(
+	ctx context.Context,
+	tid thread.ID,
+	lid peer.ID,
+	chain []core.ThreadRecord,
+	counter int64,
+) []quarantinedEntry {
+	entries := make([]quarantinedEntry, len(chain))
+	for i, r := range chain {
+		rec := r.Value()
+		entries[i] = quarantinedEntry{rec: rec, counter: counter + int64(i) + 1, size: n.storedSize(ctx, rec)}
+		if author, ok := verifiedIdentity(tid, lid, rec); ok {
+			entries[i].author = author
+		}
+	}
+	return entries
+}
+
+// signedByOwner returns whether a record of a thread log is signed by the marshaled owner identity.
+func (n *net) signedByOwner(tid thread.ID, lid peer.ID, rec core.Record, owner []byte) bool {
+	if owner == nil {
+		return false
+	}
+	identity, ok := verifiedIdentity(tid, lid, rec)
+	if !ok {
+		return false
+	}
+	signer, err := identity.MarshalBinary()
+	return err == nil && bytes.Equal(owner, signer)
+}
+
+// storedSize returns the size of a record along with its event, like it's accounted in quotas.
+func (n *net) storedSize(ctx context.Context, rec core.Record) int64 {
+	if pbrec, err := cbor.RecordToProto(ctx, n, rec); err == nil {
+		return int64(recordSize(pbrec))
+	}
+	return int64(len(rec.RawData()))
+}
+
+// meteredWrite is a record written by an identity.
+type meteredWrite struct {
+	at   time.Time
+	size int64
+}
+
+// writeMeter tracks the writes of the identities limited in threads over the last hour.
+type writeMeter struct {
+	sync.Mutex
+	writes map[thread.ID]map[string][]meteredWrite
+	clock  clock.Clock
+}
+
+func newWriteMeter(clk clock.Clock) *writeMeter {
+	return &writeMeter{writes: make(map[thread.ID]map[string][]meteredWrite), clock: clk}
+}
+
+// admit accounts a write of an identity if it's within the limit, and returns whether it is.
+func (m *writeMeter) admit(tid thread.ID, identity string, size int64, limit core.WriteLimit) bool {
+	m.Lock()
+	defer m.Unlock()
+	ids, ok := m.writes[tid]
+	if !ok {
+		ids = make(map[string][]meteredWrite)
+		m.writes[tid] = ids
+	}
+	var (
+		now     = m.clock.Now()
+		recent  = ids[identity][:0]
+		records int
+		written int64
+	)
+	for _, w := range ids[identity] {
+		if now.Sub(w.at) >= time.Hour {
+			continue
+		}
+		recent = append(recent, w)
+		written += w.size
+		if now.Sub(w.at) < time.Minute {
+			records++
+		}
+	}
+	admitted := (limit.RecordsPerMinute == 0 || records < limit.RecordsPerMinute) &&
+		(limit.BytesPerHour == 0 || written+size <= limit.BytesPerHour)
+	if admitted {
+		recent = append(recent, meteredWrite{at: now, size: size})
+	}
+	ids[identity] = recent
+	return admitted
+}
+
+func (m *writeMeter) forget(tid thread.ID) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.writes, tid)
+}
+
+// quarantinedEntry is a record held back by write limits.
+type quarantinedEntry struct {
+	rec     core.Record
+	counter int64
+	author  thread.PubKey
+	size    int64
+	since   time.Time
+}
+
+// quarantine holds the records cut from log chains by write limits until they're reviewed.
+// Quarantined records aren't persisted, the log heads stay behind them so they're pulled again.
+type quarantine struct {
+	sync.Mutex
+	recs  map[thread.ID]map[peer.ID][]quarantinedEntry
+	count int
+	// exempted are released records, which are applied regardless of the write limits
+	exempted map[cid.Cid]struct{}
+	clock    clock.Clock
+}
+
+func newQuarantine(clk clock.Clock) *quarantine {
+	return &quarantine{
+		recs:     make(map[thread.ID]map[peer.ID][]quarantinedEntry),
+		exempted: make(map[cid.Cid]struct{}),
+		clock:    clk,
+	}
+}
+
+// hold quarantines the records cut from a log chain, replacing the ones quarantined before.
+// Records quarantined again keep their quarantine time.
+func (q *quarantine) hold(tid thread.ID, lid peer.ID, entries []quarantinedEntry) {
+	q.Lock()
+	defer q.Unlock()
+	lgs, ok := q.recs[tid]
+	if !ok {
+		lgs = make(map[peer.ID][]quarantinedEntry)
+		q.recs[tid] = lgs
+	}
+	since := make(map[cid.Cid]time.Time, len(lgs[lid]))
+	for _, e := range lgs[lid] {
+		since[e.rec.Cid()] = e.since
+	}
+	q.count -= len(lgs[lid])
+	if room := MaxQuarantinedRecords - q.count; len(entries) > room {
+		entries = entries[:room]
+	}
+	now := q.clock.Now()
+	for i := range entries {
+		if at, ok := since[entries[i].rec.Cid()]; ok {
+			entries[i].since = at
+		} else {
+			entries[i].since = now
+		}
+	}
+	if len(entries) == 0 {
+		delete(lgs, lid)
+	} else {
+		lgs[lid] = entries
+	}
+	q.count += len(entries)
+}
+
+// take removes the quarantined records of a log.
+func (q *quarantine) take(tid thread.ID, lid peer.ID) []quarantinedEntry {
+	q.Lock()
+	defer q.Unlock()
+	entries := q.recs[tid][lid]
+	q.count -= len(entries)
+	delete(q.recs[tid], lid)
+	return entries
+}
+
+// trim removes the quarantined records of a log which were applied.
+func (q *quarantine) trim(tid thread.ID, lid peer.ID, chain []core.ThreadRecord) {
+	q.Lock()
+	defer q.Unlock()
+	held := q.recs[tid][lid]
+	if len(held) == 0 {
+		return
+	}
+	applied := make(map[cid.Cid]struct{}, len(chain))
+	for _, r := range chain {
+		applied[r.Value().Cid()] = struct{}{}
+	}
+	entries := held[:0]
+	for _, e := range held {
+		if _, ok := applied[e.rec.Cid()]; !ok {
+			entries = append(entries, e)
+		}
+	}
+	q.count -= len(held) - len(entries)
+	if len(entries) == 0 {
+		delete(q.recs[tid], lid)
+	} else {
+		q.recs[tid][lid] = entries
+	}
+}
+
+// list returns the quarantined records of a thread by log, oldest first.
+func (q *quarantine) list(tid thread.ID) []core.QuarantinedRecord {
+	q.Lock()
+	defer q.Unlock()
+	var list []core.QuarantinedRecord
+	for lid, entries := range q.recs[tid] {
+		for _, e := range entries {
+			list = append(list, core.QuarantinedRecord{
+				RecordID: e.rec.Cid(),
+				LogID:    lid,
+				Author:   e.author,
+				Size:     e.size,
+				Since:    e.since,
+			})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].LogID < list[j].LogID
+	})
+	return list
+}
+
+// exempt marks released records to be applied regardless of the write limits, or unmarks them.
+func (q *quarantine) exempt(recs []core.Record, exempt bool) {
+	q.Lock()
+	defer q.Unlock()
+	for _, r := range recs {
+		if exempt {
+			q.exempted[r.Cid()] = struct{}{}
+		} else {
+			delete(q.exempted, r.Cid())
+		}
+	}
+}
+
+func (q *quarantine) isExempt(c cid.Cid) bool {
+	q.Lock()
+	defer q.Unlock()
+	_, ok := q.exempted[c]
+	return ok
+}
+
+func (q *quarantine) forget(tid thread.ID) {
+	q.Lock()
+	defer q.Unlock()
+	for _, entries := range q.recs[tid] {
+		q.count -= len(entries)
+	}
+	delete(q.recs, tid)
+}
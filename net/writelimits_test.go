@@ -0,0 +1,147 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNet_WriteLimits(t *testing.T) {
+	t.Parallel()
+	n1 := makeNetwork(t).(*net)
+	defer n1.Close()
+	n2 := makeNetwork(t).(*net)
+	defer n2.Close()
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithLogApproval(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	limits := core.WriteLimits{Default: core.WriteLimit{RecordsPerMinute: 2}}
+	if _, err := n1.SetWriteLimits(ctx, info.ID, limits); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := n1.GetWriteLimits(ctx, info.ID); err != nil || got.Default != limits.Default {
+		t.Fatalf("expected declared write limits, got %v (%v)", got, err)
+	}
+	owner := thread.NewLibp2pPubKey(n1.getPrivKey().GetPublic())
+	addr := ma.StringCast(fmt.Sprintf("/p2p/%s/thread/%s", n1.Host().ID(), info.ID))
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key), core.WithLogApproval(owner)); err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(i int) core.ThreadRecord {
+		body, err := cbornode.WrapObject(map[string]interface{}{"foo": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n2.CreateRecord(ctx, info.ID, body, core.WithSigner(thread.NewLibp2pIdentity(n2.getPrivKey())))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	waitHead := func(lid peer.ID, expected core.ThreadRecord) {
+		var head thread.Head
+		for i := 0; i < 50 && head.ID != expected.Value().Cid(); i++ {
+			time.Sleep(100 * time.Millisecond)
+			if lg, err := n1.store.GetLog(info.ID, lid); err == nil {
+				head = lg.Head
+			}
+		}
+		if head.ID != expected.Value().Cid() {
+			t.Fatalf("expected head %s, got %s", expected.Value().Cid(), head.ID)
+		}
+	}
+	waitQuarantined := func(count int) []core.QuarantinedRecord {
+		var held []core.QuarantinedRecord
+		for i := 0; i < 50 && len(held) != count; i++ {
+			time.Sleep(100 * time.Millisecond)
+			if held, err = n1.QuarantinedRecords(ctx, info.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(held) != count {
+			t.Fatalf("expected %d quarantined records, got %v", count, held)
+		}
+		return held
+	}
+
+	r1 := create(1)
+	lid := r1.LogID()
+	for i := 0; i < 50; i++ {
+		if pending, err := n1.PendingLogs(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		} else if len(pending) == 1 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if _, err := n1.ApproveLog(ctx, info.ID, lid); err != nil {
+		t.Fatal(err)
+	}
+	waitHead(lid, r1)
+
+	// records over the limit are quarantined with the rest of their chain
+	r2, r3, r4 := create(2), create(3), create(4)
+	held := waitQuarantined(2)
+	if held[0].RecordID != r3.Value().Cid() || held[1].RecordID != r4.Value().Cid() ||
+		held[0].LogID != lid || !held[0].Author.Equals(thread.NewLibp2pPubKey(n2.getPrivKey().GetPublic())) {
+		t.Fatalf("expected records %s and %s to be quarantined, got %v", r3.Value().Cid(), r4.Value().Cid(), held)
+	}
+	waitHead(lid, r2)
+
+	if err := n1.ReleaseQuarantined(ctx, info.ID, lid); err != nil {
+		t.Fatal(err)
+	}
+	waitHead(lid, r4)
+	waitQuarantined(0)
+
+	// logs aren't accepted past discarded records
+	create(5)
+	waitQuarantined(1)
+	if err := n1.DiscardQuarantined(ctx, info.ID, lid); err != nil {
+		t.Fatal(err)
+	}
+	create(6)
+	time.Sleep(500 * time.Millisecond)
+	waitQuarantined(0)
+	waitHead(lid, r4)
+
+	// records claiming the owner identity without its signature are metered by log
+	lg, err := n2.store.GetLog(info.ID, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chain []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{"bar": i}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		forged, err := n2.newRecord(ctx, info.ID, lg, body, owner, cbor.EventHeaderConfig{}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, NewRecord(forged, info.ID, lid))
+	}
+	if applied, err := n1.throttleRecords(ctx, info.ID, lid, chain, 0); err != nil || applied == len(chain) {
+		t.Fatalf("expected records claiming the owner identity to be limited, got %d (%v)", applied, err)
+	}
+	if held := n1.held.list(info.ID); len(held) != 1 || held[0].Author != nil {
+		t.Fatalf("expected unsigned records to be metered by log, got %v", held)
+	}
+}
@@ -0,0 +1,218 @@
+package synctrace
+
+import (
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/logstore/lstoremem"
+)
+
+// Step is the outcome of replaying a single event.
+type Step struct {
+	Event Event
+	// Head is the replayed log head after the event.
+	Head Head
+	// Mismatch is set if the replayed head differs from the recorded one,
+	// which points at the event diverging from the expected sync behavior.
+	Mismatch bool
+	// Note describes why the head didn't advance, if it didn't.
+	Note string
+}
+
+// Replayer reconstructs the logstore state of each traced host by replaying events in order.
+// Records are applied like the network does, a chain of records advances the log head only
+// if it descends from the current head, or from the base if the peer's log was compacted.
+type Replayer struct {
+	events []Event
+	next   int
+	hosts  map[string]*hostState
+}
+
+type hostState struct {
+	store lstore.Logstore
+	// prev links of records known to the host
+	known map[cid.Cid]cid.Cid
+}
+
+// NewReplayer returns a replayer of events, e.g. merged traces of several peers.
+func NewReplayer(events []Event) *Replayer {
+	return &Replayer{
+		events: events,
+		hosts:  make(map[string]*hostState),
+	}
+}
+
+// Next replays the next event. It returns false once all events are replayed.
+// Errors indicate malformed events, replay may continue with the next one.
+func (r *Replayer) Next() (Step, bool, error) {
+	if r.next >= len(r.events) {
+		return Step{}, false, nil
+	}
+	e := r.events[r.next]
+	r.next++
+	step, err := r.apply(e)
+	if err != nil {
+		return step, true, fmt.Errorf("replaying event %d: %w", r.next-1, err)
+	}
+	return step, true, nil
+}
+
+// Run replays all remaining events.
+func (r *Replayer) Run() ([]Step, error) {
+	var steps []Step
+	for {
+		step, ok, err := r.Next()
+		if err != nil {
+			return steps, err
+		} else if !ok {
+			return steps, nil
+		}
+		steps = append(steps, step)
+	}
+}
+
+// Store returns the replayed logstore of a host, or nil if the host isn't traced.
+func (r *Replayer) Store(host string) lstore.Logstore {
+	if h, ok := r.hosts[host]; ok {
+		return h.store
+	}
+	return nil
+}
+
+// Hosts returns the hosts seen so far.
+func (r *Replayer) Hosts() []string {
+	hosts := make([]string, 0, len(r.hosts))
+	for h := range r.hosts {
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+func (r *Replayer) host(name string) *hostState {
+	h, ok := r.hosts[name]
+	if !ok {
+		h = &hostState{
+			store: lstoremem.NewLogstore(),
+			known: make(map[cid.Cid]cid.Cid),
+		}
+		r.hosts[name] = h
+	}
+	return h
+}
+
+func (r *Replayer) apply(e Event) (Step, error) {
+	step := Step{Event: e}
+	tid, err := thread.Decode(e.Thread)
+	if err != nil {
+		return step, fmt.Errorf("decoding thread: %w", err)
+	}
+	lid, err := peer.Decode(e.Log)
+	if err != nil {
+		return step, fmt.Errorf("decoding log: %w", err)
+	}
+	h := r.host(e.Host)
+
+	switch e.Kind {
+	case KindLog:
+		err = h.addLog(tid, lid, e.PubKey)
+	case KindCreate, KindRecords:
+		step.Note, err = h.putRecords(tid, lid, e)
+	default:
+		err = fmt.Errorf("unknown event kind %q", e.Kind)
+	}
+	if err != nil {
+		return step, err
+	}
+
+	head, err := h.head(tid, lid)
+	if err != nil {
+		return step, err
+	}
+	step.Head = toHead(head)
+	step.Mismatch = e.Error == "" && step.Head != e.Head
+	return step, nil
+}
+
+func (h *hostState) addLog(tid thread.ID, lid peer.ID, pubKey []byte) error {
+	if heads, err := h.store.Heads(tid, lid); err != nil {
+		return err
+	} else if len(heads) != 0 {
+		return nil
+	}
+	pk, err := crypto.UnmarshalPublicKey(pubKey)
+	if err != nil {
+		if pk, err = lid.ExtractPublicKey(); err != nil {
+			return fmt.Errorf("getting key of log %s: %w", lid, err)
+		}
+	}
+	return h.store.AddLog(tid, thread.LogInfo{ID: lid, PubKey: pk})
+}
+
+// putRecords advances the log head with the records of the event. It returns
+// a note if the head didn't advance.
+func (h *hostState) putRecords(tid thread.ID, lid peer.ID, e Event) (string, error) {
+	if len(e.Records) == 0 {
+		return "no records", nil
+	}
+	recs := make([]cid.Cid, len(e.Records))
+	for i, rec := range e.Records {
+		id, err := cid.Decode(rec.ID)
+		if err != nil {
+			return "", fmt.Errorf("decoding record: %w", err)
+		}
+		prev := cid.Undef
+		if rec.Prev != "" {
+			if prev, err = cid.Decode(rec.Prev); err != nil {
+				return "", fmt.Errorf("decoding record prev: %w", err)
+			}
+		}
+		recs[i] = id
+		if _, ok := h.known[id]; ok && i == len(e.Records)-1 {
+			return "records are known", nil
+		}
+		h.known[id] = prev
+	}
+	head, err := h.head(tid, lid)
+	if err != nil {
+		return "", err
+	}
+	last := recs[len(recs)-1]
+	var length int64
+	for cursor := last; ; length++ {
+		// logs without records have an undefined head, which the first record descends from
+		if cursor.Equals(head.ID) {
+			return "", h.store.SetHead(tid, lid, thread.Head{ID: last, Counter: head.Counter + length})
+		}
+		if !cursor.Defined() {
+			return fmt.Sprintf("records fork from head %s", head.ID), nil
+		}
+		prev, ok := h.known[cursor]
+		if !ok {
+			if base, err := cid.Decode(e.Base); err == nil && base.Equals(cursor) && e.Counter != 0 {
+				// the log continues from a checkpoint
+				return "", h.store.SetHead(tid, lid, thread.Head{ID: last, Counter: e.Counter})
+			}
+			return fmt.Sprintf("missing record %s", cursor), nil
+		}
+		cursor = prev
+	}
+}
+
+func (h *hostState) head(tid thread.ID, lid peer.ID) (thread.Head, error) {
+	heads, err := h.store.Heads(tid, lid)
+	if err != nil || len(heads) == 0 {
+		return thread.HeadUndef, err
+	}
+	return heads[0], nil
+}
+
+func toHead(h thread.Head) Head {
+	if !h.ID.Defined() {
+		return Head{Counter: h.Counter}
+	}
+	return Head{ID: h.ID.String(), Counter: h.Counter}
+}
@@ -0,0 +1,175 @@
+// Package synctrace records the sync protocol messages of threads, so head divergence
+// reported by users can be reproduced offline. Traces of several peers are merged and
+// replayed step by step against fresh logstores, see Replayer.
+//
+// Traces contain IDs and links of records only, neither keys nor payloads, so they
+// can be shared without giving access to thread contents.
+package synctrace
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// DefaultMaxEvents is the default number of the most recent events kept for each thread.
+var DefaultMaxEvents = 10000
+
+// Kind is the kind of a traced event.
+type Kind string
+
+const (
+	// KindLog is a log added to the thread, either created locally or received from a peer.
+	KindLog Kind = "log"
+	// KindCreate is a record created locally.
+	KindCreate Kind = "create"
+	// KindRecords are records of a log received from a peer, pushed or pulled.
+	KindRecords Kind = "records"
+)
+
+// Head is a log head, the ID is empty for logs without records.
+type Head struct {
+	ID      string `json:"id,omitempty"`
+	Counter int64  `json:"counter"`
+}
+
+// Record references a record by its ID and the ID of its predecessor.
+type Record struct {
+	ID   string `json:"id"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// Event is a single traced protocol message along with the resulting log head.
+type Event struct {
+	Time time.Time `json:"time"`
+	// Host is the peer which recorded the event.
+	Host string `json:"host"`
+	// Peer is the remote peer the message came from, if known.
+	Peer   string `json:"peer,omitempty"`
+	Kind   Kind   `json:"kind"`
+	Thread string `json:"thread"`
+	Log    string `json:"log"`
+	// PubKey is the marshaled public key of a log added with a KindLog event.
+	PubKey []byte `json:"pubKey,omitempty"`
+	// Records are received or created records, oldest first.
+	Records []Record `json:"records,omitempty"`
+	// Counter of the last record as claimed by the peer, if known.
+	Counter int64 `json:"counter,omitempty"`
+	// Base is the pruned predecessor of the first record, if the peer's log was compacted.
+	Base string `json:"base,omitempty"`
+	// Head is the log head after the message was handled.
+	Head Head `json:"head"`
+	// Error is set if handling the message failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Recorder keeps the most recent events of each thread in memory.
+// A nil recorder discards events, so callers don't need to check if tracing is enabled.
+type Recorder struct {
+	lock      sync.Mutex
+	maxEvents int
+	threads   map[thread.ID][]Event
+}
+
+// NewRecorder returns a recorder keeping up to maxEvents events of each thread.
+// DefaultMaxEvents is used if maxEvents isn't positive.
+func NewRecorder(maxEvents int) *Recorder {
+	if maxEvents <= 0 {
+		maxEvents = DefaultMaxEvents
+	}
+	return &Recorder{
+		maxEvents: maxEvents,
+		threads:   make(map[thread.ID][]Event),
+	}
+}
+
+// Record appends an event of the thread. The event time is set if missing.
+func (r *Recorder) Record(tid thread.ID, e Event) {
+	if r == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Thread = tid.String()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	events := append(r.threads[tid], e)
+	if len(events) > r.maxEvents {
+		events = append(events[:0:0], events[len(events)-r.maxEvents:]...)
+	}
+	r.threads[tid] = events
+}
+
+// Events returns the recorded events of the thread, oldest first.
+func (r *Recorder) Events(tid thread.ID) []Event {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Event(nil), r.threads[tid]...)
+}
+
+// Forget drops the events of the thread.
+func (r *Recorder) Forget(tid thread.ID) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.threads, tid)
+}
+
+// Export writes the recorded events of the thread to w as JSON, one event per line.
+func (r *Recorder) Export(w io.Writer, tid thread.ID) error {
+	return Write(w, r.Events(tid))
+}
+
+// Write writes events to w as JSON, one event per line.
+func Write(w io.Writer, events []Event) error {
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read reads exported events from r.
+func Read(r io.Reader) ([]Event, error) {
+	var events []Event
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, s.Err()
+}
+
+// Merge combines traces of several peers ordered by time. Order of events
+// with equal times, e.g. within a trace, is preserved.
+func Merge(traces ...[]Event) []Event {
+	var events []Event
+	for _, t := range traces {
+		events = append(events, t...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
@@ -0,0 +1,143 @@
+package synctrace
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestRecorder_Export(t *testing.T) {
+	t.Parallel()
+	r := NewRecorder(2)
+	tid := thread.NewIDV1(thread.Raw, 32)
+	for i := 0; i < 3; i++ {
+		r.Record(tid, Event{Host: "h", Kind: KindRecords, Log: fmt.Sprint(i)})
+	}
+
+	var buf bytes.Buffer
+	if err := r.Export(&buf, tid); err != nil {
+		t.Fatal(err)
+	}
+	events, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Log != "1" || events[1].Log != "2" {
+		t.Fatalf("expected the 2 most recent events, got %v", events)
+	}
+	if events[0].Thread != tid.String() || events[0].Time.IsZero() {
+		t.Fatal("expected thread and time to be set")
+	}
+
+	var nilRecorder *Recorder
+	nilRecorder.Record(tid, Event{})
+	if len(nilRecorder.Events(tid)) != 0 {
+		t.Fatal("expected nil recorder to discard events")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	a := []Event{{Host: "a", Time: start}, {Host: "a", Time: start.Add(2 * time.Second)}}
+	b := []Event{{Host: "b", Time: start.Add(time.Second)}}
+	var hosts []string
+	for _, e := range Merge(a, b) {
+		hosts = append(hosts, e.Host)
+	}
+	if strings.Join(hosts, "") != "aba" {
+		t.Fatalf("expected events ordered by time, got %v", hosts)
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	t.Parallel()
+	tid := thread.NewIDV1(thread.Raw, 32)
+	lid, pk := makeLog(t)
+	r1, r2, r3, fork := makeCid(t, "1"), makeCid(t, "2"), makeCid(t, "3"), makeCid(t, "fork")
+
+	event := func(host string, kind Kind, head Head, recs ...Record) Event {
+		return Event{Host: host, Kind: kind, Thread: tid.String(), Log: lid.String(), Records: recs, Head: head}
+	}
+	events := []Event{
+		{Host: "a", Kind: KindLog, Thread: tid.String(), Log: lid.String(), PubKey: pk},
+		event("a", KindCreate, Head{ID: r1.String(), Counter: 1}, Record{ID: r1.String()}),
+		event("a", KindCreate, Head{ID: r2.String(), Counter: 2}, Record{ID: r2.String(), Prev: r1.String()}),
+		{Host: "b", Kind: KindLog, Thread: tid.String(), Log: lid.String(), PubKey: pk},
+		// record 3 arrives before its predecessor
+		event("b", KindRecords, Head{}, Record{ID: r3.String(), Prev: r2.String()}),
+		event("b", KindRecords, Head{ID: r2.String(), Counter: 2}, Record{ID: r1.String()}, Record{ID: r2.String(), Prev: r1.String()}),
+		// a record not descending from the head is rejected
+		event("b", KindRecords, Head{ID: r2.String(), Counter: 2}, Record{ID: fork.String()}),
+	}
+
+	rp := NewReplayer(events)
+	steps, err := rp.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != len(events) {
+		t.Fatalf("expected %d steps, got %d", len(events), len(steps))
+	}
+	for i, s := range steps[:6] {
+		if s.Mismatch {
+			t.Fatalf("unexpected mismatch at step %d: replayed %v, recorded %v", i, s.Head, s.Event.Head)
+		}
+	}
+	if !strings.HasPrefix(steps[4].Note, "missing record") {
+		t.Fatalf("expected a missing record note, got %q", steps[4].Note)
+	}
+	if s := steps[6]; s.Mismatch || !strings.HasPrefix(s.Note, "records fork") {
+		t.Fatalf("expected a fork note, got %q", s.Note)
+	}
+
+	heads, err := rp.Store("b").Heads(tid, lid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heads) != 1 || !heads[0].ID.Equals(r2) || heads[0].Counter != 2 {
+		t.Fatalf("unexpected replayed heads of host b: %v", heads)
+	}
+
+	// recorded heads not reproduced by the replay are flagged
+	events[2].Head = Head{ID: r1.String(), Counter: 1}
+	steps, err = NewReplayer(events).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !steps[2].Mismatch {
+		t.Fatal("expected a head mismatch")
+	}
+}
+
+func makeLog(t *testing.T) (peer.ID, []byte) {
+	_, pk, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := crypto.MarshalPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lid, data
+}
+
+func makeCid(t *testing.T, data string) cid.Cid {
+	h, err := mh.Sum([]byte(data), mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cid.NewCidV1(cid.DagCBOR, h)
+}
@@ -20,6 +20,7 @@ var keyBookSuite = map[string]func(kb core.KeyBook) func(*testing.T){
 	"AddGetPubKey":            testKeyBookPubKey,
 	"AddGetReadKey":           testKeyBookReadKey,
 	"AddGetServiceKey":        testKeyBookServiceKey,
+	"AddGetIndexKey":          testKeyBookIndexKey,
 	"LogsWithKeys":            testKeyBookLogs,
 	"testKeyBookClearKeys":    testKeyBookClearKeys,
 	"testKeyBookClearLogKeys": testKeyBookClearLogKeys,
@@ -167,6 +168,30 @@ func testKeyBookServiceKey(kb core.KeyBook) func(t *testing.T) {
 	}
 }
 
+func testKeyBookIndexKey(kb core.KeyBook) func(t *testing.T) {
+	return func(t *testing.T) {
+		tid := thread.NewIDV1(thread.Raw, 24)
+
+		if res, err := kb.IndexKey(tid); err != nil || res != nil {
+			t.Error("expected index key to be empty on init without errors")
+		}
+
+		key, err := sym.NewRandom()
+		if err != nil {
+			t.Error(err)
+		}
+
+		err = kb.AddIndexKey(tid, key)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if res, err := kb.IndexKey(tid); err != nil || !bytes.Equal(res.Bytes(), key.Bytes()) {
+			t.Error("retrieved index key did not match stored index key without errors")
+		}
+	}
+}
+
 func testKeyBookClearKeys(kb core.KeyBook) func(t *testing.T) {
 	return func(t *testing.T) {
 		tid := thread.NewIDV1(thread.Raw, 24)
@@ -431,6 +456,7 @@ func testKeyBookExport(kb core.KeyBook) func(t *testing.T) {
 			private = make(map[thread.ID]map[peer.ID]crypto.PrivKey)
 			read    = make(map[thread.ID][]byte)
 			service = make(map[thread.ID][]byte)
+			index   = make(map[thread.ID][]byte)
 		)
 
 		// generate key set
@@ -455,6 +481,12 @@ func testKeyBookExport(kb core.KeyBook) func(t *testing.T) {
 				t.Fatal(err)
 			}
 
+			indexKey := thread.DeriveIndexKey(readKey)
+			index[tid] = indexKey.Bytes()
+			if err := kb.AddIndexKey(tid, indexKey); err != nil {
+				t.Fatal(err)
+			}
+
 			public[tid] = make(map[peer.ID]crypto.PubKey)
 			private[tid] = make(map[peer.ID]crypto.PrivKey)
 
@@ -540,6 +572,17 @@ func testKeyBookExport(kb core.KeyBook) func(t *testing.T) {
 				t.Error("restored thread-service key is different from the original one")
 			}
 		}
+
+		// compare index keys
+		for tid, key := range index {
+			ik, err := kb.IndexKey(tid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ik.Bytes(), key) {
+				t.Error("restored thread-index key is different from the original one")
+			}
+		}
 	}
 }
 
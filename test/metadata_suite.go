@@ -20,6 +20,7 @@ const (
 var metadataBookSuite = map[string]func(mb core.ThreadMetadata) func(*testing.T){
 	"Int64":          testMetadataBookInt64,
 	"String":         testMetadataBookString,
+	"SwapString":     testMetadataBookSwapString,
 	"Byte":           testMetadataBookBytes,
 	"NotFound":       testMetadataBookNotFound,
 	"ClearMetadata":  testClearMetadata,
@@ -88,6 +89,36 @@ func testMetadataBookString(mb core.ThreadMetadata) func(*testing.T) {
 	}
 }
 
+func testMetadataBookSwapString(mb core.ThreadMetadata) func(*testing.T) {
+	return func(t *testing.T) {
+		t.Run("CompareAndSwap", func(t *testing.T) {
+			t.Parallel()
+			tid := thread.NewIDV1(thread.Raw, 24)
+
+			key, old := "key1", "textile"
+			if swapped, err := mb.CompareAndSwapString(tid, key, &old, "threads"); err != nil || swapped {
+				t.Fatalf("expected missing value not to be swapped, got %v (%v)", swapped, err)
+			}
+			if swapped, err := mb.CompareAndSwapString(tid, key, nil, old); err != nil || !swapped {
+				t.Fatalf("expected missing value to be swapped, got %v (%v)", swapped, err)
+			}
+			if swapped, err := mb.CompareAndSwapString(tid, key, nil, "threads"); err != nil || swapped {
+				t.Fatalf("expected existing value not to be swapped, got %v (%v)", swapped, err)
+			}
+			if swapped, err := mb.CompareAndSwapString(tid, key, &old, "threads"); err != nil || !swapped {
+				t.Fatalf("expected matching value to be swapped, got %v (%v)", swapped, err)
+			}
+			v, err := mb.GetString(tid, key)
+			if err != nil {
+				t.Fatalf(errStrGet, key, err)
+			}
+			if v == nil || *v != "threads" {
+				t.Fatalf(errStrValueMatch, "threads", v)
+			}
+		})
+	}
+}
+
 func testMetadataBookBytes(mb core.ThreadMetadata) func(*testing.T) {
 	return func(t *testing.T) {
 		t.Run("Put&Get", func(t *testing.T) {
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	dbclient "github.com/textileio/go-threads/api/client"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage dbs and query their collections",
+}
+
+var (
+	dbName  string
+	dbQuery string
+	dbFile  string
+)
+
+func init() {
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new db",
+		Args:  cobra.NoArgs,
+		RunE:  createDB,
+	}
+	createCmd.Flags().StringVar(&dbName, "name", "", "Name of the db")
+
+	queryCmd := &cobra.Command{
+		Use:   "query <db> <collection>",
+		Short: "Find the instances of a collection matching a query",
+		Long:  "Find the instances of a collection matching a query, given as a JSON encoded db.Query. All instances are returned without a query.",
+		Args:  cobra.ExactArgs(2),
+		RunE:  queryDB,
+	}
+	queryCmd.Flags().StringVar(&dbQuery, "query", "", "JSON encoded query")
+
+	exportCmd := &cobra.Command{
+		Use:   "export <db>",
+		Short: "Export the collections and instances of a db as JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE:  exportDB,
+	}
+	exportCmd.Flags().StringVarP(&dbFile, "out", "o", "", "Output file, stdout if empty")
+
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import an exported db into a new db",
+		Args:  cobra.ExactArgs(1),
+		RunE:  importDB,
+	}
+	importCmd.Flags().StringVar(&dbName, "name", "", "Name of the new db")
+
+	dbCmd.AddCommand(
+		createCmd,
+		&cobra.Command{
+			Use:   "list",
+			Short: "List dbs",
+			Args:  cobra.NoArgs,
+			RunE:  listDBs,
+		},
+		&cobra.Command{
+			Use:   "info <db>",
+			Short: "Show the info required to join a db",
+			Args:  cobra.ExactArgs(1),
+			RunE:  dbInfo,
+		},
+		&cobra.Command{
+			Use:   "delete <db>",
+			Short: "Delete a db",
+			Args:  cobra.ExactArgs(1),
+			RunE:  deleteDB,
+		},
+		&cobra.Command{
+			Use:   "collections <db>",
+			Short: "List the collections of a db",
+			Args:  cobra.ExactArgs(1),
+			RunE:  listCollections,
+		},
+		queryCmd,
+		exportCmd,
+		importCmd,
+	)
+}
+
+// dbInfoView is the printed form of db info.
+type dbInfoView struct {
+	ID    string           `json:"id"`
+	Name  string           `json:"name,omitempty"`
+	Addrs []string         `json:"addrs"`
+	Key   string           `json:"key,omitempty"`
+	Heads map[string]int64 `json:"heads,omitempty"`
+}
+
+func newDBInfoView(id thread.ID, info db.Info) dbInfoView {
+	v := dbInfoView{
+		ID:    id.String(),
+		Name:  info.Name,
+		Addrs: addrStrings(info.Addrs),
+		Heads: make(map[string]int64, len(info.Heads)),
+	}
+	if info.Key.Defined() {
+		v.Key = info.Key.String()
+	}
+	for lid, h := range info.Heads {
+		v.Heads[lid.String()] = h.Counter
+	}
+	return v
+}
+
+// dbExport is the exported form of a db.
+type dbExport struct {
+	Name        string             `json:"name,omitempty"`
+	Collections []collectionExport `json:"collections"`
+}
+
+type collectionExport struct {
+	Config    db.CollectionConfig `json:"config"`
+	Instances []json.RawMessage   `json:"instances"`
+}
+
+func createDB(cmd *cobra.Command, _ []string) error {
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	id := thread.NewIDV1(thread.Raw, 32)
+	if err = client.NewDB(
+		ctx,
+		id,
+		db.WithNewManagedName(dbName),
+		db.WithNewManagedToken(threadToken()),
+	); err != nil {
+		return err
+	}
+	info, err := client.GetDBInfo(ctx, id, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	return printJSON(newDBInfoView(id, info))
+}
+
+func listDBs(cmd *cobra.Command, _ []string) error {
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	dbs, err := client.ListDBs(ctx, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	list := make([]dbInfoView, 0, len(dbs))
+	for id, info := range dbs {
+		list = append(list, newDBInfoView(id, info))
+	}
+	return printJSON(list)
+}
+
+func dbInfo(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	info, err := client.GetDBInfo(ctx, id, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	return printJSON(newDBInfoView(id, info))
+}
+
+func deleteDB(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	return client.DeleteDB(ctx, id, db.WithManagedToken(threadToken()))
+}
+
+func listCollections(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	list, err := client.ListCollections(ctx, id, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	return printJSON(list)
+}
+
+func queryDB(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	query := &db.Query{}
+	if dbQuery != "" {
+		if err = json.Unmarshal([]byte(dbQuery), query); err != nil {
+			return fmt.Errorf("parsing query: %w", err)
+		}
+	}
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	instances, err := findAll(ctx, client, id, args[1], query)
+	if err != nil {
+		return err
+	}
+	return printJSON(instances)
+}
+
+func exportDB(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	info, err := client.GetDBInfo(ctx, id, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	configs, err := client.ListCollections(ctx, id, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	export := dbExport{Name: info.Name, Collections: make([]collectionExport, len(configs))}
+	for i, c := range configs {
+		instances, err := findAll(ctx, client, id, c.Name, &db.Query{})
+		if err != nil {
+			return fmt.Errorf("exporting collection %s: %w", c.Name, err)
+		}
+		export.Collections[i] = collectionExport{Config: c, Instances: instances}
+	}
+
+	out := os.Stdout
+	if dbFile != "" {
+		if out, err = os.Create(dbFile); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+func importDB(cmd *cobra.Command, args []string) error {
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	var export dbExport
+	if err = json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("parsing export: %w", err)
+	}
+	name := dbName
+	if name == "" {
+		name = export.Name
+	}
+	configs := make([]db.CollectionConfig, len(export.Collections))
+	for i, c := range export.Collections {
+		configs[i] = c.Config
+	}
+
+	client, err := newDBClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	id := thread.NewIDV1(thread.Raw, 32)
+	if err = client.NewDB(
+		ctx,
+		id,
+		db.WithNewManagedName(name),
+		db.WithNewManagedToken(threadToken()),
+		db.WithNewManagedCollections(configs...),
+	); err != nil {
+		return err
+	}
+	for _, c := range export.Collections {
+		if len(c.Instances) == 0 {
+			continue
+		}
+		instances := make(dbclient.Instances, len(c.Instances))
+		for i, inst := range c.Instances {
+			instances[i] = inst
+		}
+		if _, err = client.Create(ctx, id, c.Config.Name, instances, db.WithTxnToken(threadToken())); err != nil {
+			return fmt.Errorf("importing collection %s: %w", c.Config.Name, err)
+		}
+	}
+	info, err := client.GetDBInfo(ctx, id, db.WithManagedToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	return printJSON(newDBInfoView(id, info))
+}
+
+// findAll returns the raw instances of a collection matching a query.
+func findAll(ctx context.Context, client *dbclient.Client, id thread.ID, collection string, query *db.Query) ([]json.RawMessage, error) {
+	res, err := client.Find(ctx, id, collection, query, &json.RawMessage{}, db.WithTxnToken(threadToken()))
+	if err != nil {
+		return nil, err
+	}
+	found, ok := res.([]*json.RawMessage)
+	if !ok {
+		return nil, errors.New("unexpected find result")
+	}
+	instances := make([]json.RawMessage, len(found))
+	for i, inst := range found {
+		instances[i] = *inst
+	}
+	return instances, nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/spf13/cobra"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/net/api"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage API keys and generate thread keys and identities",
+}
+
+var (
+	keyAdmin    bool
+	keyReadOnly bool
+	keyThreads  []string
+	keyService  bool
+)
+
+func init() {
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API key, requires an admin API key",
+		Args:  cobra.NoArgs,
+		RunE:  createAPIKey,
+	}
+	createCmd.Flags().BoolVar(&keyAdmin, "admin", false, "Grant full access, including API key management")
+	createCmd.Flags().BoolVar(&keyReadOnly, "readOnly", false, "Only allow methods which don't modify threads")
+	createCmd.Flags().StringSliceVar(&keyThreads, "threads", nil, "Restrict access to the threads")
+
+	threadKeyCmd := &cobra.Command{
+		Use:   "thread",
+		Short: "Generate a random thread key",
+		Args:  cobra.NoArgs,
+		RunE:  newThreadKey,
+	}
+	threadKeyCmd.Flags().BoolVar(&keyService, "service", false, "Generate a service key only, which can't read records")
+
+	keysCmd.AddCommand(
+		createCmd,
+		&cobra.Command{
+			Use:   "list",
+			Short: "List API keys, requires an admin API key",
+			Args:  cobra.NoArgs,
+			RunE:  listAPIKeys,
+		},
+		&cobra.Command{
+			Use:   "revoke <key>",
+			Short: "Revoke an API key, requires an admin API key",
+			Args:  cobra.ExactArgs(1),
+			RunE:  revokeAPIKey,
+		},
+		threadKeyCmd,
+		&cobra.Command{
+			Use:   "identity",
+			Short: "Generate a random identity, which can be used to get thread tokens",
+			Args:  cobra.NoArgs,
+			RunE:  newIdentity,
+		},
+	)
+}
+
+// apiKeyView is the printed form of an API key.
+type apiKeyView struct {
+	Key       string    `json:"key"`
+	Secret    string    `json:"secret,omitempty"`
+	Admin     bool      `json:"admin"`
+	ReadOnly  bool      `json:"readOnly"`
+	Threads   []string  `json:"threads,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func newAPIKeyView(k api.APIKey, secret string) apiKeyView {
+	v := apiKeyView{
+		Key:       k.Key,
+		Secret:    secret,
+		Admin:     k.Scope.Admin,
+		ReadOnly:  k.Scope.ReadOnly,
+		CreatedAt: k.CreatedAt,
+	}
+	for _, id := range k.Scope.Threads {
+		v.Threads = append(v.Threads, id.String())
+	}
+	return v
+}
+
+func createAPIKey(cmd *cobra.Command, _ []string) error {
+	scope := api.Scope{Admin: keyAdmin, ReadOnly: keyReadOnly}
+	for _, s := range keyThreads {
+		id, err := parseThreadID(s)
+		if err != nil {
+			return err
+		}
+		scope.Threads = append(scope.Threads, id)
+	}
+	client, err := newAdminClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	key, secret, err := client.CreateAPIKey(ctx, scope)
+	if err != nil {
+		return err
+	}
+	return printJSON(newAPIKeyView(key, secret))
+}
+
+func listAPIKeys(cmd *cobra.Command, _ []string) error {
+	client, err := newAdminClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	keys, err := client.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+	list := make([]apiKeyView, len(keys))
+	for i, k := range keys {
+		list[i] = newAPIKeyView(k, "")
+	}
+	return printJSON(list)
+}
+
+func revokeAPIKey(cmd *cobra.Command, args []string) error {
+	client, err := newAdminClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	return client.RevokeAPIKey(ctx, args[0])
+}
+
+func newThreadKey(cmd *cobra.Command, _ []string) error {
+	key := thread.NewRandomKey()
+	if keyService {
+		key = thread.NewRandomServiceKey()
+	}
+	fmt.Println(key.String())
+	return nil
+}
+
+func newIdentity(cmd *cobra.Command, _ []string) error {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return err
+	}
+	identity := thread.NewLibp2pIdentity(sk)
+	data, err := identity.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return printJSON(struct {
+		Identity string `json:"identity"`
+		PubKey   string `json:"pubKey"`
+	}{
+		Identity: crypto.ConfigEncodeKey(data),
+		PubKey:   identity.GetPublic().String(),
+	})
+}
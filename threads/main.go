@@ -0,0 +1,122 @@
+// Command threads talks to a threadsd daemon over its APIs, to inspect and manage
+// threads, dbs and API keys without writing a Go program.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/spf13/cobra"
+	dbclient "github.com/textileio/go-threads/api/client"
+	"github.com/textileio/go-threads/core/thread"
+	netclient "github.com/textileio/go-threads/net/api/client"
+	"github.com/textileio/go-threads/util"
+	"google.golang.org/grpc"
+)
+
+var (
+	apiAddr   string
+	apiKey    string
+	apiSecret string
+	token     string
+	timeout   time.Duration
+)
+
+var rootCmd = &cobra.Command{
+	Use:           "threads",
+	Short:         "Threads client",
+	Long:          "Inspects and manages the threads, dbs and API keys of a threadsd daemon.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&apiAddr, "api", "/ip4/127.0.0.1/tcp/6006", "gRPC API address of the daemon")
+	flags.StringVar(&apiKey, "apiKey", "", "API key, required if the daemon requires API keys")
+	flags.StringVar(&apiSecret, "apiSecret", "", "API key secret")
+	flags.StringVar(&token, "token", "", "Thread token authorizing the calls")
+	flags.DurationVar(&timeout, "timeout", time.Second*30, "Timeout of each command, except for streaming ones")
+
+	rootCmd.AddCommand(threadCmd, recordsCmd, dbCmd, syncCmd, keysCmd)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// dialOptions returns the options of connections to the daemon.
+func dialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if apiKey != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(netclient.APIKeyCredentials{
+			Key:    apiKey,
+			Secret: apiSecret,
+		}))
+	}
+	return opts
+}
+
+// target returns the dial target of the API address.
+func target() (string, error) {
+	addr, err := ma.NewMultiaddr(apiAddr)
+	if err != nil {
+		return "", fmt.Errorf("parsing api address: %w", err)
+	}
+	return util.TCPAddrFromMultiAddr(addr)
+}
+
+func newNetClient() (*netclient.Client, error) {
+	t, err := target()
+	if err != nil {
+		return nil, err
+	}
+	return netclient.NewClient(t, dialOptions()...)
+}
+
+func newAdminClient() (*netclient.AdminClient, error) {
+	t, err := target()
+	if err != nil {
+		return nil, err
+	}
+	return netclient.NewAdminClient(t, dialOptions()...)
+}
+
+func newDBClient() (*dbclient.Client, error) {
+	t, err := target()
+	if err != nil {
+		return nil, err
+	}
+	return dbclient.NewClient(t, dialOptions()...)
+}
+
+// commandContext returns the context of a command, bounded by the timeout flag.
+func commandContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func threadToken() thread.Token {
+	return thread.Token(token)
+}
+
+func parseThreadID(s string) (thread.ID, error) {
+	id, err := thread.Decode(s)
+	if err != nil {
+		return thread.Undef, fmt.Errorf("parsing thread id: %w", err)
+	}
+	return id, nil
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
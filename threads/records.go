@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/spf13/cobra"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var (
+	recordsLog   string
+	recordsLimit int
+)
+
+var recordsCmd = &cobra.Command{
+	Use:   "records",
+	Short: "Inspect thread records",
+}
+
+func init() {
+	dumpCmd := &cobra.Command{
+		Use:   "dump <thread>",
+		Short: "Dump the records of a thread as JSON lines, oldest first in each log",
+		Args:  cobra.ExactArgs(1),
+		RunE:  dumpRecords,
+	}
+	dumpCmd.Flags().StringVar(&recordsLog, "log", "", "Only dump the records of the log")
+	dumpCmd.Flags().IntVar(&recordsLimit, "limit", 0, "Only dump the latest records of each log, all of them if zero")
+	recordsCmd.AddCommand(dumpCmd)
+}
+
+// recordView is the printed form of a record.
+type recordView struct {
+	Log    string `json:"log"`
+	ID     string `json:"id"`
+	Prev   string `json:"prev,omitempty"`
+	Block  string `json:"block"`
+	Author string `json:"author,omitempty"`
+	Size   int    `json:"size"`
+}
+
+func dumpRecords(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	var only peer.ID
+	if recordsLog != "" {
+		if only, err = peer.Decode(recordsLog); err != nil {
+			return err
+		}
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	opt := core.WithThreadToken(threadToken())
+	info, err := client.GetThread(ctx, id, opt)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, lg := range info.Logs {
+		if only != "" && lg.ID != only {
+			continue
+		}
+		// records link to their predecessor, so the log is walked back from its head
+		var recs []recordView
+		for rid := lg.Head.ID; rid.Defined(); {
+			if recordsLimit > 0 && len(recs) == recordsLimit {
+				break
+			}
+			rec, err := client.GetRecord(ctx, id, rid, opt)
+			if err != nil {
+				return err
+			}
+			v := recordView{
+				Log:   lg.ID.String(),
+				ID:    rec.Cid().String(),
+				Block: rec.BlockID().String(),
+				Size:  len(rec.RawData()),
+			}
+			if rec.PrevID().Defined() {
+				v.Prev = rec.PrevID().String()
+			}
+			if len(rec.PubKey()) > 0 {
+				author := &thread.Libp2pPubKey{}
+				if err = author.UnmarshalBinary(rec.PubKey()); err != nil {
+					return err
+				}
+				v.Author = author.String()
+			}
+			recs = append(recs, v)
+			rid = rec.PrevID()
+		}
+		for i := len(recs) - 1; i >= 0; i-- {
+			if err = enc.Encode(recs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	core "github.com/textileio/go-threads/core/net"
+)
+
+var syncFollow bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <thread>",
+	Short: "Show the sync progress of a thread",
+	Args:  cobra.ExactArgs(1),
+	RunE:  syncStatus,
+}
+
+func init() {
+	syncCmd.Flags().BoolVarP(&syncFollow, "follow", "f", false, "Keep printing progress changes until the thread is synced")
+}
+
+func syncStatus(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// progress is streamed, so only the wait for the first update is bounded by the timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	sub, err := client.SubscribeSyncProgress(ctx, id, core.WithThreadToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	first := time.NewTimer(timeout)
+	defer first.Stop()
+	for {
+		select {
+		case p, ok := <-sub:
+			if !ok {
+				return nil
+			}
+			printSyncProgress(p)
+			if !syncFollow || p.Done() {
+				return nil
+			}
+			first.Stop()
+		case <-first.C:
+			return fmt.Errorf("no sync progress received within %s", timeout)
+		}
+	}
+}
+
+func printSyncProgress(p core.SyncProgress) {
+	status := "syncing"
+	if p.Done() {
+		status = "synced"
+	}
+	fmt.Printf("%s: %s, %d/%d records, %d bytes received", p.ThreadID, status, p.Records, p.Expected, p.Bytes)
+	if p.ETA > 0 {
+		fmt.Printf(", eta %s", p.ETA.Round(time.Second))
+	}
+	fmt.Println()
+	for _, l := range p.Logs {
+		fmt.Printf("  %s: %d/%d\n", l.LogID, l.Applied, l.Height)
+	}
+}
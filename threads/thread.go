@@ -0,0 +1,275 @@
+package main
+
+import (
+	"time"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/spf13/cobra"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var threadCmd = &cobra.Command{
+	Use:   "thread",
+	Short: "Manage threads",
+}
+
+var (
+	threadKey  string
+	threadTags []string
+	threadTag  string
+)
+
+func init() {
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new thread",
+		Args:  cobra.NoArgs,
+		RunE:  createThread,
+	}
+	createCmd.Flags().StringVar(&threadKey, "key", "", "Thread key, a random one is used if empty")
+	createCmd.Flags().StringSliceVar(&threadTags, "tags", nil, "Tags of the thread")
+
+	addCmd := &cobra.Command{
+		Use:   "add <addr>",
+		Short: "Add a thread from a peer address",
+		Args:  cobra.ExactArgs(1),
+		RunE:  addThread,
+	}
+	addCmd.Flags().StringVar(&threadKey, "key", "", "Thread key")
+	addCmd.Flags().StringSliceVar(&threadTags, "tags", nil, "Tags of the thread")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List threads",
+		Args:  cobra.NoArgs,
+		RunE:  listThreads,
+	}
+	listCmd.Flags().StringVar(&threadTag, "tag", "", "Only list threads with the tag")
+
+	threadCmd.AddCommand(
+		createCmd,
+		addCmd,
+		listCmd,
+		&cobra.Command{
+			Use:   "get <thread>",
+			Short: "Show a thread and its logs",
+			Args:  cobra.ExactArgs(1),
+			RunE:  getThread,
+		},
+		&cobra.Command{
+			Use:   "pull <thread>",
+			Short: "Pull a thread from its peers",
+			Args:  cobra.ExactArgs(1),
+			RunE:  pullThread,
+		},
+		&cobra.Command{
+			Use:   "delete <thread>",
+			Short: "Delete a thread",
+			Args:  cobra.ExactArgs(1),
+			RunE:  deleteThread,
+		},
+	)
+}
+
+// threadView is the printed form of a thread.
+type threadView struct {
+	ID    string    `json:"id"`
+	Key   string    `json:"key,omitempty"`
+	Addrs []string  `json:"addrs,omitempty"`
+	Logs  []logView `json:"logs"`
+}
+
+type logView struct {
+	ID      string   `json:"id"`
+	Head    string   `json:"head,omitempty"`
+	Counter int64    `json:"counter"`
+	Addrs   []string `json:"addrs,omitempty"`
+	Managed bool     `json:"managed"`
+}
+
+func newThreadView(info thread.Info) threadView {
+	v := threadView{
+		ID:    info.ID.String(),
+		Addrs: addrStrings(info.Addrs),
+		Logs:  make([]logView, len(info.Logs)),
+	}
+	if info.Key.Defined() {
+		v.Key = info.Key.String()
+	}
+	for i, lg := range info.Logs {
+		v.Logs[i] = logView{
+			ID:      lg.ID.String(),
+			Counter: lg.Head.Counter,
+			Addrs:   addrStrings(lg.Addrs),
+			Managed: lg.Managed,
+		}
+		if lg.Head.ID.Defined() {
+			v.Logs[i].Head = lg.Head.ID.String()
+		}
+	}
+	return v
+}
+
+func addrStrings(addrs []ma.Multiaddr) []string {
+	strs := make([]string, len(addrs))
+	for i, a := range addrs {
+		strs[i] = a.String()
+	}
+	return strs
+}
+
+func newThreadOptions() ([]core.NewThreadOption, error) {
+	opts := []core.NewThreadOption{core.WithNewThreadToken(threadToken())}
+	if threadKey != "" {
+		key, err := thread.KeyFromString(threadKey)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, core.WithThreadKey(key))
+	}
+	if len(threadTags) > 0 {
+		opts = append(opts, core.WithNewThreadTags(threadTags...))
+	}
+	return opts, nil
+}
+
+func createThread(cmd *cobra.Command, _ []string) error {
+	opts, err := newThreadOptions()
+	if err != nil {
+		return err
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	info, err := client.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), opts...)
+	if err != nil {
+		return err
+	}
+	return printJSON(newThreadView(info))
+}
+
+func addThread(cmd *cobra.Command, args []string) error {
+	addr, err := ma.NewMultiaddr(args[0])
+	if err != nil {
+		return err
+	}
+	opts, err := newThreadOptions()
+	if err != nil {
+		return err
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	info, err := client.AddThread(ctx, addr, opts...)
+	if err != nil {
+		return err
+	}
+	return printJSON(newThreadView(info))
+}
+
+func getThread(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	info, err := client.GetThread(ctx, id, core.WithThreadToken(threadToken()))
+	if err != nil {
+		return err
+	}
+	return printJSON(newThreadView(info))
+}
+
+func listThreads(cmd *cobra.Command, _ []string) error {
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	type summaryView struct {
+		ID           string    `json:"id"`
+		Tags         []string  `json:"tags,omitempty"`
+		LastActivity time.Time `json:"lastActivity"`
+		LastSync     time.Time `json:"lastSync"`
+		Health       string    `json:"health"`
+		Usage        int64     `json:"usage"`
+		Quota        int64     `json:"quota,omitempty"`
+	}
+	var (
+		list      = []summaryView{}
+		pageToken string
+	)
+	for {
+		opts := []core.ListOption{core.WithListToken(threadToken()), core.WithPageToken(pageToken)}
+		if threadTag != "" {
+			opts = append(opts, core.WithTagFilter(threadTag))
+		}
+		page, err := client.ListThreads(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		for _, t := range page.Threads {
+			list = append(list, summaryView{
+				ID:           t.ID.String(),
+				Tags:         t.Tags,
+				LastActivity: t.LastActivity,
+				LastSync:     t.LastSync,
+				Health:       t.Health.String(),
+				Usage:        t.Usage,
+				Quota:        t.Quota,
+			})
+		}
+		if page.NextPageToken == "" {
+			return printJSON(list)
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func pullThread(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	return client.PullThread(ctx, id, core.WithThreadToken(threadToken()))
+}
+
+func deleteThread(cmd *cobra.Command, args []string) error {
+	id, err := parseThreadID(args[0])
+	if err != nil {
+		return err
+	}
+	client, err := newNetClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, cancel := commandContext()
+	defer cancel()
+	return client.DeleteThread(ctx, id, core.WithThreadToken(threadToken()))
+}
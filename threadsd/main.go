@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -9,22 +10,37 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	logging "github.com/ipfs/go-log"
 	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/namsral/flag"
 	mongods "github.com/textileio/go-ds-mongo"
 	"github.com/textileio/go-threads/api"
+	"github.com/textileio/go-threads/api/graphql"
 	pb "github.com/textileio/go-threads/api/pb"
+	"github.com/textileio/go-threads/audit"
 	"github.com/textileio/go-threads/common"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/crash"
+	"github.com/textileio/go-threads/datastore"
+	"github.com/textileio/go-threads/db"
 	kt "github.com/textileio/go-threads/db/keytransform"
+	"github.com/textileio/go-threads/lifecycle"
+	"github.com/textileio/go-threads/logstore/lstoreds"
+	tnet "github.com/textileio/go-threads/net"
 	netapi "github.com/textileio/go-threads/net/api"
 	netpb "github.com/textileio/go-threads/net/api/pb"
+	"github.com/textileio/go-threads/net/faults"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var log = logging.Logger("threadsd")
@@ -36,14 +52,42 @@ func main() {
 	hostAddrStr := fs.String("hostAddr", "/ip4/0.0.0.0/tcp/4006", "Libp2p host bind address")
 	apiAddrStr := fs.String("apiAddr", "/ip4/127.0.0.1/tcp/6006", "gRPC API bind address")
 	apiProxyAddrStr := fs.String("apiProxyAddr", "/ip4/127.0.0.1/tcp/6007", "gRPC API web proxy bind address")
+	graphqlAddrStr := fs.String("graphqlAddr", "", "GraphQL API bind address, disabled if empty")
+	apiTLSCert := fs.String("apiTLSCert", "", "PEM certificate file serving the APIs over TLS, reloaded when changed")
+	apiTLSKey := fs.String("apiTLSKey", "", "PEM private key file of apiTLSCert")
+	apiTLSClientCA := fs.String("apiTLSClientCA", "", "PEM CA certificates file, requires API clients to present a certificate signed by them (mTLS)")
 	connLowWater := fs.Int("connLowWater", 100, "Low watermark of libp2p connections that'll be maintained")
 	connHighWater := fs.Int("connHighWater", 400, "High watermark of libp2p connections that'll be maintained")
 	connGracePeriod := fs.Duration("connGracePeriod", time.Second*20, "Duration a new opened connection is not subject to pruning")
 	keepAliveInterval := fs.Duration("keepAliveInterval", time.Second*5, "Websocket keepalive interval (must be >= 1s)")
 	enableNetPubsub := fs.Bool("enableNetPubsub", false, "Enables thread networking over libp2p pubsub")
+	maxRecordSize := fs.Int("maxRecordSize", tnet.DefaultMaxRecordSize, "Maximum size in bytes of records created or accepted by the host")
+	pullMemoryBudget := fs.Int64("pullMemoryBudget", 0, "Maximum total size in bytes of records being pulled at once, unlimited if zero")
+	maxPeerConns := fs.Int("maxPeerConns", 0, "Maximum number of open connections to thread peers, unlimited if zero")
+	maxPeerStreams := fs.Int("maxPeerStreams", 0, "Maximum number of concurrent calls to a single thread peer, unlimited if zero")
+	maxStreams := fs.Int("maxStreams", 0, "Maximum number of concurrent calls to all thread peers, unlimited if zero")
+	peerPingInterval := fs.Duration("peerPingInterval", 0, "Interval of keepalive pings to the peers threads are synced with, disabled if zero (must be >= 1s)")
+	peerPingTimeout := fs.Duration("peerPingTimeout", tnet.DefaultKeepAliveTimeout, "Duration to wait for a keepalive ping reply before closing the peer connection")
+	federation := fs.String("federation", "", "Comma-separated p2p addresses of always-on nodes sharing responsibility for threads")
+	allowPeers := fs.String("allowPeers", "", "Comma-separated peer IDs allowed to connect, all peers are allowed if empty along with allowNets")
+	allowNets := fs.String("allowNets", "", "Comma-separated CIDR networks of observed peer addresses allowed to connect")
+	denyPeers := fs.String("denyPeers", "", "Comma-separated peer IDs denied to connect, even if allowed")
+	denyNets := fs.String("denyNets", "", "Comma-separated CIDR networks of observed peer addresses denied to connect, even if allowed")
+	membershipGating := fs.Bool("membershipGating", false, "Only accepts inbound connections of peers sharing a thread with the host, or listed in gatingAllowPeers")
+	gatingAllowPeers := fs.String("gatingAllowPeers", "", "Comma-separated peer IDs accepted by membershipGating regardless of thread membership")
+	swarmKey := fs.String("swarmKey", "", "Private network key file, restricts the host to peers sharing the same key")
+	enableAuditLog := fs.Bool("enableAuditLog", false, "Records mutating API calls and accepted remote pushes in an audit log under the repo")
+	auditMaxSize := fs.Int64("auditMaxSize", audit.DefaultMaxSize, "Size in bytes of the audit log file which triggers rotation")
+	auditMaxFiles := fs.Int("auditMaxFiles", audit.DefaultMaxFiles, "Number of rotated audit log files to keep")
+	eventsSocket := fs.String("eventsSocket", "", "UNIX socket path serving db change events to local processes, disabled if empty")
+	extensionNamespaces := fs.String("extensionNamespaces", "", "Comma-separated record extension namespaces API clients may attach to records")
+	requireAPIKeys := fs.Bool("requireAPIKeys", false, "Requires API keys for the net API, an admin key is printed on first start")
 	mongoUri := fs.String("mongoUri", "", "MongoDB URI (if not provided, an embedded Badger datastore will be used)")
 	mongoDatabase := fs.String("mongoDatabase", "", "MongoDB database name (required with mongoUri")
-	badgerLowMem := fs.Bool("badgerLowMem", false, "Use Badger's low memory settings")
+	badgerLowMem := fs.Bool("badgerLowMem", false, "Use the low memory settings of the datastore backend")
+	datastoreBackend := fs.String("datastoreBackend", string(datastore.BackendBadger), "Embedded datastore backend (badger, badger3, or pebble)")
+	datastoreMigrateFrom := fs.String("datastoreMigrateFrom", "", "Migrates the repo datastores from the given backend to datastoreBackend on start")
+	logstoreMigration := fs.String("logstoreMigration", string(lstoreds.MigrateApply), "How pending logstore schema migrations are handled on start (apply, backup, or dry-run)")
 	debug := fs.Bool("debug", false, "Enables debug logging")
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		log.Fatal(err)
@@ -61,7 +105,30 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	var graphqlAddr ma.Multiaddr
+	if len(*graphqlAddrStr) != 0 {
+		if graphqlAddr, err = ma.NewMultiaddr(*graphqlAddrStr); err != nil {
+			log.Fatal(err)
+		}
+	}
 
+	apiTLS := util.TLSConfig{
+		CertFile:     *apiTLSCert,
+		KeyFile:      *apiTLSKey,
+		ClientCAFile: *apiTLSClientCA,
+	}
+	if !apiTLS.Enabled() && len(apiTLS.ClientCAFile) != 0 {
+		log.Fatal("apiTLSClientCA requires apiTLSCert and apiTLSKey")
+	}
+
+	backend, err := datastore.ParseBackend(*datastoreBackend)
+	if err != nil {
+		log.Fatal(err)
+	}
+	lsMigration, err := lstoreds.ParseMigrationMode(*logstoreMigration)
+	if err != nil {
+		log.Fatal(err)
+	}
 	var parsedMongoUri *url.URL
 	if len(*mongoUri) != 0 {
 		parsedMongoUri, err = url.Parse(*mongoUri)
@@ -71,6 +138,9 @@ func main() {
 		if len(*mongoDatabase) == 0 {
 			log.Fatal("mongoDatabase is required with mongoUri")
 		}
+		if backend != datastore.BackendBadger || len(*datastoreMigrateFrom) != 0 {
+			log.Fatal("datastoreBackend and datastoreMigrateFrom don't apply with mongoUri")
+		}
 	} else {
 		log.Debugf("badgerLowMem: %v", *badgerLowMem)
 	}
@@ -83,34 +153,151 @@ func main() {
 			log.Fatal(err)
 		}
 	}
+	reporter, err := crash.DirReporter(filepath.Join(*repo, "crashes"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	crash.SetReporter(reporter)
 
 	log.Debugf("repo: %v", *repo)
 	log.Debugf("hostAddr: %v", *hostAddrStr)
 	log.Debugf("apiAddr: %v", *apiAddrStr)
 	log.Debugf("apiProxyAddr: %v", *apiProxyAddrStr)
+	log.Debugf("graphqlAddr: %v", *graphqlAddrStr)
+	log.Debugf("apiTLSCert: %v", *apiTLSCert)
+	log.Debugf("apiTLSKey: %v", *apiTLSKey)
+	log.Debugf("apiTLSClientCA: %v", *apiTLSClientCA)
 	log.Debugf("connLowWater: %v", *connLowWater)
 	log.Debugf("connHighWater: %v", *connHighWater)
 	log.Debugf("connGracePeriod: %v", *connGracePeriod)
 	log.Debugf("keepAliveInterval: %v", *keepAliveInterval)
 	log.Debugf("enableNetPubsub: %v", *enableNetPubsub)
+	log.Debugf("maxRecordSize: %v", *maxRecordSize)
+	log.Debugf("pullMemoryBudget: %v", *pullMemoryBudget)
+	log.Debugf("peerPingInterval: %v", *peerPingInterval)
+	log.Debugf("peerPingTimeout: %v", *peerPingTimeout)
+	log.Debugf("allowPeers: %v", *allowPeers)
+	log.Debugf("allowNets: %v", *allowNets)
+	log.Debugf("denyPeers: %v", *denyPeers)
+	log.Debugf("denyNets: %v", *denyNets)
+	log.Debugf("swarmKey: %v", *swarmKey)
+	log.Debugf("enableAuditLog: %v", *enableAuditLog)
+	log.Debugf("auditMaxSize: %v", *auditMaxSize)
+	log.Debugf("auditMaxFiles: %v", *auditMaxFiles)
+	log.Debugf("eventsSocket: %v", *eventsSocket)
+	log.Debugf("extensionNamespaces: %v", *extensionNamespaces)
+	log.Debugf("requireAPIKeys: %v", *requireAPIKeys)
 	if parsedMongoUri != nil {
 		log.Debugf("mongoUri: %v", parsedMongoUri.Redacted())
 		log.Debugf("mongoDatabase: %v", *mongoDatabase)
 	} else {
 		log.Debugf("badgerLowMem: %v", *badgerLowMem)
+		log.Debugf("datastoreBackend: %v", backend)
+		log.Debugf("datastoreMigrateFrom: %v", *datastoreMigrateFrom)
 	}
+	log.Debugf("logstoreMigration: %v", lsMigration)
 	log.Debugf("debug: %v", *debug)
 
+	if len(*datastoreMigrateFrom) != 0 {
+		from, err := datastore.ParseBackend(*datastoreMigrateFrom)
+		if err != nil {
+			log.Fatal(err)
+		}
+		stores := []string{"ipfslite", "logstore", "eventstore", "annotations", "bootstrap", "blockindex"}
+		if err := datastore.MigrateRepo(context.Background(), *repo, from, backend, stores,
+			datastore.WithLowMem(*badgerLowMem)); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(*extensionNamespaces) != 0 {
+		for _, ns := range strings.Split(*extensionNamespaces, ",") {
+			if err := core.RegisterExtensionNamespace(strings.TrimSpace(ns)); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
 	opts := []common.NetOption{
 		common.WithNetHostAddr(hostAddr),
 		common.WithConnectionManager(connmgr.NewConnManager(*connLowWater, *connHighWater, *connGracePeriod)),
 		common.WithNetPubSub(*enableNetPubsub),
+		common.WithNetMaxRecordSize(*maxRecordSize),
+		common.WithNetPullMemoryBudget(*pullMemoryBudget),
+		common.WithNetConnLimits(tnet.ConnLimits{
+			MaxConns:          *maxPeerConns,
+			MaxStreamsPerPeer: *maxPeerStreams,
+			MaxStreams:        *maxStreams,
+		}),
+		common.WithNetKeepAlive(tnet.KeepAlive{
+			Interval: *peerPingInterval,
+			Timeout:  *peerPingTimeout,
+		}),
+		common.WithNetLogstoreMigration(lsMigration),
 		common.WithNetDebug(*debug),
 	}
 	if parsedMongoUri != nil {
 		opts = append(opts, common.WithNetMongoPersistence(*mongoUri, *mongoDatabase))
 	} else {
-		opts = append(opts, common.WithNetBadgerPersistence(*repo))
+		opts = append(opts, common.WithNetBadgerPersistence(*repo), common.WithNetDatastoreBackend(backend))
+	}
+	if len(*swarmKey) != 0 {
+		opts = append(opts, common.WithNetPrivateNetworkFile(*swarmKey))
+	}
+	if len(*federation) != 0 {
+		var addrs []ma.Multiaddr
+		for _, a := range strings.Split(*federation, ",") {
+			addr, err := ma.NewMultiaddr(strings.TrimSpace(a))
+			if err != nil {
+				log.Fatalf("parsing federation address: %v", err)
+			}
+			addrs = append(addrs, addr)
+		}
+		members, err := peer.AddrInfosFromP2pAddrs(addrs...)
+		if err != nil {
+			log.Fatalf("parsing federation address: %v", err)
+		}
+		opts = append(opts, common.WithNetFederation(members))
+	}
+	var access tnet.AccessList
+	if access.AllowPeers, err = parsePeers(*allowPeers); err != nil {
+		log.Fatalf("parsing allowPeers: %v", err)
+	}
+	if access.AllowNets, err = parseNets(*allowNets); err != nil {
+		log.Fatalf("parsing allowNets: %v", err)
+	}
+	if access.DenyPeers, err = parsePeers(*denyPeers); err != nil {
+		log.Fatalf("parsing denyPeers: %v", err)
+	}
+	if access.DenyNets, err = parseNets(*denyNets); err != nil {
+		log.Fatalf("parsing denyNets: %v", err)
+	}
+	if !access.Empty() {
+		opts = append(opts, common.WithNetAccessList(access))
+	}
+	if *membershipGating {
+		allow, err := parsePeers(*gatingAllowPeers)
+		if err != nil {
+			log.Fatalf("parsing gatingAllowPeers: %v", err)
+		}
+		opts = append(opts, common.WithNetMembershipGating(allow...))
+	}
+	// the daemon shuts down in the order of the lifecycle stages, closers of a stage run in reverse
+	life := lifecycle.New(context.Background())
+
+	var auditLog *audit.Log
+	if *enableAuditLog {
+		auditLog, err = audit.New(audit.Config{
+			Dir:      filepath.Join(*repo, "audit"),
+			MaxSize:  *auditMaxSize,
+			MaxFiles: *auditMaxFiles,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		// the network and the API record to the log until they're closed
+		life.Track(lifecycle.StageStorage, "audit log", auditLog)
+		opts = append(opts, common.WithNetAuditLog(auditLog))
 	}
 	n, err := common.DefaultNetwork(opts...)
 	if err != nil {
@@ -125,13 +312,32 @@ func main() {
 	if *mongoUri != "" {
 		store, err = mongods.New(ctx, *mongoUri, *mongoDatabase, mongods.WithCollName("eventstore"))
 	} else {
-		store, err = util.NewBadgerDatastore(*repo, "eventstore", *badgerLowMem)
+		store, err = datastore.New(backend, datastore.Path(*repo, "eventstore", backend), datastore.WithLowMem(*badgerLowMem))
 	}
 	if err != nil {
 		log.Fatal(err)
 	}
+	var events *db.EventStream
+	if len(*eventsSocket) != 0 {
+		// remove the socket left by an unclean shutdown
+		if err := os.Remove(*eventsSocket); err != nil && !os.IsNotExist(err) {
+			log.Fatal(err)
+		}
+		lis, err := net.Listen("unix", *eventsSocket)
+		if err != nil {
+			log.Fatal(err)
+		}
+		events = db.NewEventStream(lis)
+		life.Track(lifecycle.StageAPI, "event stream", events)
+		go func() {
+			if err := events.Serve(); err != nil {
+				log.Errorf("event stream error: %v", err)
+			}
+		}()
+	}
 	service, err := api.NewService(store, n, api.Config{
-		Debug: *debug,
+		Debug:       *debug,
+		EventStream: events,
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -152,7 +358,57 @@ func main() {
 		log.Fatal(err)
 	}
 
-	server := grpc.NewServer()
+	// panics are recovered first so that they fail calls going through any interceptor
+	var (
+		unaryInterceptors  = []grpc.UnaryServerInterceptor{crash.UnaryServerInterceptor()}
+		streamInterceptors = []grpc.StreamServerInterceptor{crash.StreamServerInterceptor()}
+	)
+	if auditLog != nil {
+		// audit first so that unauthorized calls are recorded too
+		audited := func(fullMethod string) bool {
+			return api.IsMutatingMethod(fullMethod) || netapi.IsMutatingMethod(fullMethod)
+		}
+		unaryInterceptors = append(unaryInterceptors, netapi.AuditUnaryServerInterceptor(auditLog, audited))
+		streamInterceptors = append(streamInterceptors, netapi.AuditStreamServerInterceptor(auditLog, audited))
+	}
+	var keys *netapi.KeyStore
+	if *requireAPIKeys {
+		keys = netapi.NewKeyStore(store)
+		hasAdmin, err := keys.HasAdmin()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !hasAdmin {
+			key, secret, err := keys.Create(netapi.Scope{Admin: true})
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println("Created admin API key " + key.Key + " with secret " + secret)
+		}
+		unaryInterceptors = append(unaryInterceptors, keys.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, keys.StreamServerInterceptor())
+	}
+
+	if faults.Enabled {
+		log.Warn("Failure injection is built in, it's configured with the admin API")
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+	}
+	var tlsConf *tls.Config
+	if apiTLS.Enabled() {
+		reloader, err := util.NewTLSReloader(apiTLS)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// tracked before the servers, so it's closed once they're stopped
+		life.Track(lifecycle.StageAPI, "tls reloader", reloader)
+		tlsConf = reloader.Config()
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConf)))
+	}
+	server := grpc.NewServer(serverOpts...)
 	listener, err := net.Listen("tcp", target)
 	if err != nil {
 		log.Fatal(err)
@@ -160,6 +416,9 @@ func main() {
 	go func() {
 		pb.RegisterAPIServer(server, service)
 		netpb.RegisterAPIServer(server, netService)
+		if keys != nil {
+			netpb.RegisterAdminServer(server, netapi.NewAdminService(keys, auditLog, n))
+		}
 		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			log.Fatalf("serve error: %v", err)
 		}
@@ -175,7 +434,8 @@ func main() {
 			return true
 		}))
 	proxy := &http.Server{
-		Addr: ptarget,
+		Addr:      ptarget,
+		TLSConfig: tlsConf,
 	}
 	proxy.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if webrpc.IsGrpcWebRequest(r) ||
@@ -185,27 +445,97 @@ func main() {
 		}
 	})
 	go func() {
-		if err := proxy.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := listenAndServe(proxy); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("proxy error: %v", err)
 		}
 	}()
 
+	var gql *http.Server
+	if graphqlAddr != nil {
+		gtarget, err := util.TCPAddrFromMultiAddr(graphqlAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		gql = &http.Server{
+			Addr:      gtarget,
+			Handler:   graphql.NewHandler(service.Manager()),
+			TLSConfig: tlsConf,
+		}
+		go func() {
+			if err := listenAndServe(gql); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("graphql error: %v", err)
+			}
+		}()
+	}
+
 	fmt.Println("Welcome to Threads!")
 	fmt.Println("Your peer ID is " + n.Host().ID().String())
 
-	handleInterrupt(func() {
+	life.TrackFunc(lifecycle.StageAPI, "grpc server", func() error {
+		server.GracefulStop()
+		return nil
+	})
+	if gql != nil {
+		// subscription streams don't end on their own, so they aren't waited for
+		life.Track(lifecycle.StageAPI, "graphql server", gql)
+	}
+	life.TrackFunc(lifecycle.StageAPI, "proxy", func() error {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		if err := proxy.Shutdown(ctx); err != nil {
-			log.Fatal(err)
-		}
-		server.GracefulStop()
-		if err := n.Close(); err != nil {
+		return proxy.Shutdown(ctx)
+	})
+	life.Track(lifecycle.StageApp, "dbs", service)
+	life.Track(lifecycle.StageTransport, "network", n)
+	life.Track(lifecycle.StageStorage, "eventstore", store)
+
+	handleInterrupt(func() {
+		if err := life.Close(); err != nil {
 			log.Fatal(err)
 		}
 	})
 }
 
+// parsePeers returns the peer IDs of a comma-separated list.
+func parsePeers(list string) ([]peer.ID, error) {
+	var ids []peer.ID
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); len(s) == 0 {
+			continue
+		}
+		id, err := peer.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseNets returns the networks of a comma-separated list of CIDRs.
+func parseNets(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); len(s) == 0 {
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// listenAndServe serves over TLS if the server is configured with it.
+func listenAndServe(s *http.Server) error {
+	if s.TLSConfig != nil {
+		// certificates are provided by the TLS config
+		return s.ListenAndServeTLS("", "")
+	}
+	return s.ListenAndServe()
+}
+
 func handleInterrupt(stop func()) {
 	quit := make(chan os.Signal)
 	signal.Notify(quit, os.Interrupt)
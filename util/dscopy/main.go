@@ -14,8 +14,8 @@ import (
 	"github.com/ipfs/go-datastore/query"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/namsral/flag"
-	badger "github.com/textileio/go-ds-badger"
 	mongods "github.com/textileio/go-ds-mongo"
+	"github.com/textileio/go-threads/datastore"
 )
 
 var log = logging.Logger("dscopy")
@@ -43,6 +43,8 @@ func main() {
 
 	fromBadgerRepos := fs.String("fromBadgerRepos", "", "Source badger repos path")
 	toBadgerRepos := fs.String("toBadgerRepos", "", "Destination badger repos path")
+	fromBackend := fs.String("fromBackend", string(datastore.BackendBadger), "Source repos datastore backend (badger, badger3, or pebble)")
+	toBackend := fs.String("toBackend", string(datastore.BackendBadger), "Destination repos datastore backend (badger, badger3, or pebble)")
 
 	fromMongoUri := fs.String("fromMongoUri", "", "Source MongoDB URI")
 	fromMongoDatabase := fs.String("fromMongoDatabase", "", "Source MongoDB database")
@@ -67,11 +69,22 @@ func main() {
 
 	start := time.Now()
 
+	fromB, err := datastore.ParseBackend(*fromBackend)
+	if err != nil {
+		log.Fatal(err)
+	}
+	toB, err := datastore.ParseBackend(*toBackend)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	for _, s := range stores {
 		if err := copyDatastore(
 			s,
 			*fromBadgerRepos,
 			*toBadgerRepos,
+			fromB,
+			toB,
 			*fromMongoUri,
 			*toMongoUri,
 			*fromMongoDatabase,
@@ -89,6 +102,7 @@ func main() {
 func copyDatastore(
 	s store,
 	fromBadgerRepos, toBadgerRepos string,
+	fromBackend, toBackend datastore.Backend,
 	fromMongoUri, toMongoUri string,
 	fromMongoDatabase, toMongoDatabase string,
 	parallel int,
@@ -110,23 +124,20 @@ func copyDatastore(
 	var from, to ds.Datastore
 	var err error
 	if len(fromBadgerRepos) != 0 {
-		path := filepath.Join(fromBadgerRepos, s.name)
-		from, err = badger.NewDatastore(path, &badger.DefaultOptions)
+		path := datastore.Path(fromBadgerRepos, s.name, fromBackend)
+		from, err = datastore.New(fromBackend, path)
 		if err != nil {
-			return fmt.Errorf("connecting to badger source: %v", err)
+			return fmt.Errorf("connecting to %s source: %v", fromBackend, err)
 		}
-		log.Infof("connected to badger source: %s", path)
+		log.Infof("connected to %s source: %s", fromBackend, path)
 	}
 	if len(toBadgerRepos) != 0 {
-		path := filepath.Join(toBadgerRepos, s.name)
-		if err := os.MkdirAll(path, os.ModePerm); err != nil {
-			return fmt.Errorf("making destination path: %v", err)
-		}
-		to, err = badger.NewDatastore(path, &badger.DefaultOptions)
+		path := datastore.Path(toBadgerRepos, s.name, toBackend)
+		to, err = datastore.New(toBackend, path)
 		if err != nil {
-			return fmt.Errorf("connecting to badger destination: %v", err)
+			return fmt.Errorf("connecting to %s destination: %v", toBackend, err)
 		}
-		log.Infof("connected to badger destination: %s", path)
+		log.Infof("connected to %s destination: %s", toBackend, path)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/pnet"
+)
+
+const (
+	// PSKFileName is the default name of a private network key file inside a repo.
+	PSKFileName = "swarm.key"
+
+	// pskLength is the byte length of a private network key.
+	pskLength = 32
+
+	pskV1Header   = "/key/swarm/psk/1.0.0/"
+	pskBase16Enc  = "/base16/"
+	pskFileMode   = 0400
+	pskRotateTime = "20060102T150405"
+)
+
+// GeneratePSK returns a new random private network key.
+func GeneratePSK() (pnet.PSK, error) {
+	psk := make([]byte, pskLength)
+	if _, err := rand.Read(psk); err != nil {
+		return nil, err
+	}
+	return psk, nil
+}
+
+// EncodePSK encodes the key in the V1 swarm key format, which is understood
+// by all libp2p implementations (including go-ipfs).
+func EncodePSK(psk pnet.PSK) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(pskV1Header + "\n")
+	buf.WriteString(pskBase16Enc + "\n")
+	buf.WriteString(hex.EncodeToString(psk) + "\n")
+	return buf.Bytes()
+}
+
+// LoadPSK reads a V1 swarm key from path.
+func LoadPSK(path string) (pnet.PSK, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	psk, err := pnet.DecodeV1PSK(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding private network key %s: %w", path, err)
+	}
+	if len(psk) != pskLength {
+		return nil, fmt.Errorf("private network key %s must be %d bytes long", path, pskLength)
+	}
+	return psk, nil
+}
+
+// WritePSK writes the key to path in the V1 swarm key format.
+// An existing key is never overwritten, use RotatePSK instead.
+func WritePSK(path string, psk pnet.PSK) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("private network key %s already exists", path)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, EncodePSK(psk), pskFileMode)
+}
+
+// RotatePSK replaces the key stored at path with a newly generated one.
+// The previous key is kept next to the new one with a timestamp suffix,
+// so that it can be restored if the rollout has to be reverted.
+// Note that peers using different keys are not able to connect to each other,
+// i.e. the new key has to be distributed to all the hosts of the network
+// before they are restarted.
+func RotatePSK(path string) (psk pnet.PSK, prev string, err error) {
+	if _, err = LoadPSK(path); err != nil {
+		return
+	}
+	prev = path + "." + time.Now().UTC().Format(pskRotateTime)
+	if err = os.Rename(path, prev); err != nil {
+		return
+	}
+	if psk, err = GeneratePSK(); err != nil {
+		return
+	}
+	if err = WritePSK(path, psk); err != nil {
+		// restore the previous key
+		if rerr := os.Rename(prev, path); rerr != nil {
+			err = fmt.Errorf("%v; restoring previous key failed: %v", err, rerr)
+		}
+		return
+	}
+	return psk, prev, nil
+}
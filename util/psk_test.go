@@ -0,0 +1,64 @@
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/pnet"
+)
+
+func TestPSK(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, PSKFileName)
+
+	psk, err := GeneratePSK()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := pnet.DecodeV1PSK(bytes.NewReader(EncodePSK(psk)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(psk, decoded) {
+		t.Fatal("decoded key does not match the original")
+	}
+
+	if err := WritePSK(path, psk); err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePSK(path, psk); err == nil {
+		t.Fatal("existing key should not be overwritten")
+	}
+	loaded, err := LoadPSK(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(psk, loaded) {
+		t.Fatal("loaded key does not match the written one")
+	}
+
+	rotated, prev, err := RotatePSK(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(psk, rotated) {
+		t.Fatal("rotated key should differ from the previous one")
+	}
+	if loaded, err = LoadPSK(prev); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(psk, loaded) {
+		t.Fatal("previous key was not preserved")
+	}
+	if loaded, err = LoadPSK(path); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(rotated, loaded) {
+		t.Fatal("rotated key was not written")
+	}
+}
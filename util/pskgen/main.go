@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/namsral/flag"
+	"github.com/textileio/go-threads/util"
+)
+
+var log = logging.Logger("pskgen")
+
+func main() {
+	fs := flag.NewFlagSet(os.Args[0], 0)
+
+	repo := fs.String("repo", ".threads", "Repo location, the key is written to <repo>/"+util.PSKFileName)
+	out := fs.String("out", "", "Key file location (overrides repo)")
+	rotate := fs.Bool("rotate", false, "Replace an existing key, keeping the previous one as a backup")
+	stdout := fs.Bool("stdout", false, "Print the generated key instead of writing it to a file")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+
+	logging.SetupLogging(logging.Config{
+		Format: logging.ColorizedOutput,
+		Stderr: true,
+		Level:  logging.LevelError,
+	})
+
+	if *stdout {
+		psk, err := util.GeneratePSK()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(string(util.EncodePSK(psk)))
+		return
+	}
+
+	path := *out
+	if len(path) == 0 {
+		path = filepath.Join(*repo, util.PSKFileName)
+	}
+
+	if *rotate {
+		psk, prev, err := util.RotatePSK(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Rotated private network key %s (fingerprint %s)\n", path, fingerprint(psk))
+		fmt.Printf("Previous key saved to %s\n", prev)
+		fmt.Println("Distribute the new key to all hosts before restarting them.")
+		return
+	}
+
+	psk, err := util.GeneratePSK()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := util.WritePSK(path, psk); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Wrote private network key %s (fingerprint %s)\n", path, fingerprint(psk))
+}
+
+// fingerprint returns a short, non-secret identifier of the key,
+// useful to check that all hosts are configured with the same key.
+func fingerprint(psk []byte) string {
+	sum := sha256.Sum256(psk)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,192 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/textileio/go-threads/logger"
+)
+
+var tlsLog = logger.Logger("tls")
+
+// TLSReloadDelay is the time a change of the TLS files has to settle for
+// before they are reloaded, so that a certificate and its key being replaced
+// one after the other are loaded together.
+var TLSReloadDelay = time.Millisecond * 200
+
+// TLSConfig configures TLS of an API server.
+type TLSConfig struct {
+	// CertFile is the path of the PEM encoded server certificate chain.
+	CertFile string
+	// KeyFile is the path of the PEM encoded server private key.
+	KeyFile string
+	// ClientCAFile is the path of PEM encoded CA certificates. If set, clients
+	// must present a certificate signed by one of them (mutual TLS).
+	ClientCAFile string
+}
+
+// Enabled returns whether TLS is configured.
+func (c TLSConfig) Enabled() bool {
+	return len(c.CertFile) != 0 || len(c.KeyFile) != 0
+}
+
+// TLSReloader serves a TLS configuration loaded from files, which is reloaded
+// when the files change. This allows rotating certificates without a restart.
+type TLSReloader struct {
+	conf    TLSConfig
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+
+	lk      sync.RWMutex
+	current *tls.Config
+}
+
+// NewTLSReloader loads the TLS files and starts watching them for changes.
+func NewTLSReloader(conf TLSConfig) (*TLSReloader, error) {
+	if len(conf.CertFile) == 0 || len(conf.KeyFile) == 0 {
+		return nil, errors.New("both a TLS certificate and key are required")
+	}
+	r := &TLSReloader{conf: conf, done: make(chan struct{})}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// files are often replaced by renames or symlink swaps,
+	// so their directories are watched instead
+	dirs := make(map[string]struct{})
+	for _, f := range []string{conf.CertFile, conf.KeyFile, conf.ClientCAFile} {
+		if len(f) != 0 {
+			dirs[filepath.Dir(f)] = struct{}{}
+		}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+	r.watcher = watcher
+	go r.watch()
+	return r, nil
+}
+
+// Config returns a server TLS configuration which always uses the latest loaded files.
+func (r *TLSReloader) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.get(), nil
+		},
+		// unused as the client config is replaced, but http servers require a certificate
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &r.get().Certificates[0], nil
+		},
+	}
+}
+
+func (r *TLSReloader) get() *tls.Config {
+	r.lk.RLock()
+	defer r.lk.RUnlock()
+	return r.current
+}
+
+// Reload loads the TLS files. The previous configuration stays in use if they're invalid.
+func (r *TLSReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.conf.CertFile, r.conf.KeyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS certificate: %w", err)
+	}
+	conf := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if len(r.conf.ClientCAFile) != 0 {
+		if conf.ClientCAs, err = loadCertPool(r.conf.ClientCAFile); err != nil {
+			return err
+		}
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	r.lk.Lock()
+	r.current = conf
+	r.lk.Unlock()
+	return nil
+}
+
+// Close stops watching the TLS files.
+func (r *TLSReloader) Close() error {
+	close(r.done)
+	return r.watcher.Close()
+}
+
+func (r *TLSReloader) watch() {
+	reload := time.NewTimer(TLSReloadDelay)
+	reload.Stop()
+	defer reload.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case _, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			reload.Reset(TLSReloadDelay)
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			tlsLog.Errorf("watching TLS files: %v", err)
+		case <-reload.C:
+			if err := r.Reload(); err != nil {
+				tlsLog.Errorf("reloading TLS files: %v", err)
+			} else {
+				tlsLog.Infof("reloaded TLS certificate %s", r.conf.CertFile)
+			}
+		}
+	}
+}
+
+// ClientTLSConfig returns a client TLS configuration verifying servers with the
+// CA certificates in caFile, or the system ones if empty. If certFile and keyFile
+// are set, the client presents their certificate to servers requiring mutual TLS.
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caFile) != 0 {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = pool
+	}
+	if len(certFile) != 0 || len(keyFile) != 0 {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no CA certificates found in %s", path)
+	}
+	return pool, nil
+}
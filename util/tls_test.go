@@ -0,0 +1,178 @@
+package util
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTLSReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	ca, caKey := makeTestCert(t, "ca", nil, nil)
+	writeTestCert(t, path("ca.pem"), ca, nil)
+	server, serverKey := makeTestCert(t, "server", ca, caKey)
+	writeTestCert(t, path("server.pem"), server, serverKey)
+	client, clientKey := makeTestCert(t, "client", ca, caKey)
+	writeTestCert(t, path("client.pem"), client, clientKey)
+
+	conf := TLSConfig{
+		CertFile:     path("server.pem"),
+		KeyFile:      path("server.pem"),
+		ClientCAFile: path("ca.pem"),
+	}
+	r, err := NewTLSReloader(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", r.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() {
+		for {
+			c, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = c.(*tls.Conn).Handshake()
+				_ = c.Close()
+			}()
+		}
+	}()
+
+	dial := func(certFile string) (*x509.Certificate, error) {
+		conf, err := ClientTLSConfig(path("ca.pem"), certFile, certFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf.ServerName = "localhost"
+		c, err := tls.Dial("tcp", lis.Addr().String(), conf)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		// client certificates are verified after the client handshake is done
+		if _, err = c.Read(make([]byte, 1)); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return c.ConnectionState().PeerCertificates[0], nil
+	}
+	cert, err := dial(path("client.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cert.Raw, server.Raw) {
+		t.Fatal("unexpected server certificate")
+	}
+	if _, err = dial(""); err == nil {
+		t.Fatal("expected a client without certificate to be rejected")
+	}
+
+	// the rotated certificate is served without restarting
+	rotated, rotatedKey := makeTestCert(t, "server", ca, caKey)
+	writeTestCert(t, path("server.pem.tmp"), rotated, rotatedKey)
+	if err = os.Rename(path("server.pem.tmp"), path("server.pem")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second * 10)
+	for {
+		if cert, err = dial(path("client.pem")); err != nil {
+			t.Fatal(err)
+		} else if bytes.Equal(cert.Raw, rotated.Raw) {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatal("rotated certificate was not reloaded")
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+
+	// invalid files don't replace the loaded ones
+	if err = ioutil.WriteFile(path("server.pem"), []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Reload(); err == nil {
+		t.Fatal("expected reloading invalid files to fail")
+	}
+	if cert, err = dial(path("client.pem")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(cert.Raw, rotated.Raw) {
+		t.Fatal("expected the previous certificate to stay in use")
+	}
+}
+
+func makeTestCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func writeTestCert(t *testing.T, path string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+		t.Fatal(err)
+	}
+	if key != nil {
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = pem.Encode(&buf, &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	badger "github.com/textileio/go-ds-badger"
 	core "github.com/textileio/go-threads/core/db"
 	kt "github.com/textileio/go-threads/db/keytransform"
+	"github.com/textileio/go-threads/logger"
 	"github.com/tidwall/sjson"
 	"go.uber.org/zap/zapcore"
 )
@@ -72,9 +73,12 @@ func SetupDefaultLoggingConfig(repoPath string) error {
 	return nil
 }
 
-// SetLogLevels sets levels for the given systems.
+// SetLogLevels sets levels for the given systems, on both the ipfs log and the thread subsystem loggers.
 func SetLogLevels(systems map[string]logging.LogLevel) error {
 	for sys, level := range systems {
+		if err := logger.SetLevel(sys, logger.Level(level)); err != nil {
+			return err
+		}
 		l := zapcore.Level(level)
 		if sys == "*" {
 			for _, s := range logging.GetSubsystems() {